@@ -2,9 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,17 +14,30 @@ import (
 	"go-recruitment-backend/config"
 	_ "go-recruitment-backend/docs" // Important for Swagger
 	v1 "go-recruitment-backend/internal/delivery/http/v1"
+	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/internal/repository/postgres"
 	"go-recruitment-backend/internal/usecase"
+	"go-recruitment-backend/migrations"
 	"go-recruitment-backend/pkg/auth"
+	"go-recruitment-backend/pkg/buildinfo"
+	"go-recruitment-backend/pkg/cache"
+	"go-recruitment-backend/pkg/chaos"
 	"go-recruitment-backend/pkg/database"
 	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/errreport"
+	"go-recruitment-backend/pkg/jobs"
 	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/metrics"
+	"go-recruitment-backend/pkg/realtime"
 	"go-recruitment-backend/pkg/redis"
 	"go-recruitment-backend/pkg/security"
+	"go-recruitment-backend/pkg/sms"
+	"go-recruitment-backend/pkg/storage"
+	"go-recruitment-backend/pkg/tracing"
 	"go-recruitment-backend/pkg/validation"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
 )
 
 // @title           Recruitment Backend API
@@ -47,21 +61,123 @@ func main() {
 	// 1. Load Config
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		logger.Log.Error("Failed to load config", "error", err)
+		os.Exit(1)
 	}
 
 	// 2. Setup Logger
-	logger.Init()
+	logger.Init(logger.Options{
+		Format:           cfg.LogFormat,
+		Level:            cfg.LogLevel,
+		SampleInitial:    cfg.LogSampleInitial,
+		SampleThereafter: cfg.LogSampleThereafter,
+	})
 	logger.Log.Info("Initializing recruitment backend...")
 
+	// Validasi dasar untuk mencegah panic aneh nanti
+	if cfg.DBUrl == "" {
+		logger.Log.Warn("DATABASE_URL is missing. Application may fail to connect.")
+	}
+	if cfg.UpstashRedisURL == "" {
+		logger.Log.Warn("UPSTASH_REDIS_URL not configured. Rate limiting will use in-memory fallback.")
+	}
+
+	// 2a. Fault injection for resilience testing (never in release mode)
+	supabaseHost := ""
+	if u, err := url.Parse(cfg.SupabaseUrl); err == nil {
+		supabaseHost = u.Host
+	}
+	var chaosInjector *chaos.Injector
+	var dbTracer pgx.QueryTracer
+	if cfg.ChaosEnabled {
+		if os.Getenv("GIN_MODE") == "release" {
+			logger.Log.Error("CHAOS_ENABLED is ignored in release mode")
+		} else {
+			chaosInjector = chaos.New(chaos.Config{
+				LatencyPercent:       cfg.ChaosLatencyPercent,
+				Latency:              time.Duration(cfg.ChaosLatencyMs) * time.Millisecond,
+				SupabaseErrorPercent: cfg.ChaosSupabaseErrorPercent,
+				SupabaseHost:         supabaseHost,
+				DBErrorPercent:       cfg.ChaosDBErrorPercent,
+			})
+			dbTracer = chaosInjector.QueryTracer()
+			// Handlers build their own http.Clients on the default transport
+			http.DefaultTransport = chaosInjector.Transport(http.DefaultTransport)
+			logger.Log.Warn("Fault injection enabled",
+				"latency_percent", cfg.ChaosLatencyPercent, "latency_ms", cfg.ChaosLatencyMs,
+				"supabase_error_percent", cfg.ChaosSupabaseErrorPercent, "db_error_percent", cfg.ChaosDBErrorPercent)
+		}
+	}
+
+	// 2b. Metrics: query timing wraps every repository; Supabase calls are counted
+	// on the default transport (outside fault injection, so injected errors count)
+	if cfg.MetricsEnabled {
+		dbTracer = metrics.QueryTracer(dbTracer)
+		http.DefaultTransport = metrics.SupabaseTransport(http.DefaultTransport, supabaseHost)
+	}
+
+	// 2c. Tracing: spans for requests (middleware), queries and outbound HTTP,
+	// exported over OTLP/HTTP
+	shutdownTracing := func(context.Context) {}
+	if cfg.TracingEndpoint != "" {
+		shutdownTracing = tracing.Init(tracing.Config{
+			Endpoint:      cfg.TracingEndpoint,
+			Headers:       tracing.ParseHeaders(cfg.TracingHeaders),
+			ServiceName:   cfg.TracingServiceName,
+			Version:       buildinfo.Get().Version,
+			SamplePercent: cfg.TracingSamplePercent,
+		})
+		dbTracer = tracing.QueryTracer(dbTracer)
+		http.DefaultTransport = tracing.Transport(http.DefaultTransport)
+		logger.Log.Info("Tracing enabled", "endpoint", cfg.TracingEndpoint, "sample_percent", cfg.TracingSamplePercent)
+	}
+
+	// 2d. Error reporting: panics and 5xx responses (Recovery middleware), tagged
+	// with the release so crashes can be tied to a deploy
+	shutdownErrorReport := func(context.Context) {}
+	if cfg.ErrorReportDSN != "" || cfg.ErrorReportWebhookURL != "" {
+		build := buildinfo.Get()
+		shutdown, err := errreport.Init(errreport.Config{
+			DSN:         cfg.ErrorReportDSN,
+			WebhookURL:  cfg.ErrorReportWebhookURL,
+			Environment: cfg.ErrorReportEnvironment,
+			Release:     build.Version,
+			Commit:      build.Commit,
+		})
+		if err != nil {
+			logger.Log.Error("Error reporting disabled", "error", err)
+		} else {
+			shutdownErrorReport = shutdown
+			logger.Log.Info("Error reporting enabled", "sentry", cfg.ErrorReportDSN != "", "webhook", cfg.ErrorReportWebhookURL != "",
+				"environment", cfg.ErrorReportEnvironment, "release", build.Version)
+		}
+	}
+
 	// 3. Setup Database
-	dbPool, err := database.NewPostgresConnection(cfg.DBUrl)
+	dbPool, err := database.NewPostgresConnection(cfg.DBUrl, dbTracer)
 	if err != nil {
 		logger.Log.Error("Failed to connect to database", "error", err)
 		// Paksa berhenti jika DB mati, karena app tidak berguna tanpa DB
 		os.Exit(1)
 	}
 	defer dbPool.Close()
+	if cfg.MetricsEnabled {
+		metrics.RegisterDBPool(dbPool)
+	}
+
+	// 3a. Apply schema migrations
+	if cfg.DBMigrateOnStartup {
+		applied, err := database.Migrate(context.Background(), dbPool, migrations.FS, cfg.DBMigrationsBase)
+		if errors.Is(err, database.ErrUnversionedDatabase) {
+			// Serving on the current schema beats refusing to start; the baseline is a one-off setup step
+			logger.Log.Error("Migrations skipped", "error", err)
+		} else if err != nil {
+			logger.Log.Error("Failed to apply migrations", "error", err)
+			os.Exit(1)
+		} else {
+			logger.Log.Info("Database schema up to date", "applied", len(applied))
+		}
+	}
 
 	// 2b. Initialize Redis
 	redisCfg := redis.Config{
@@ -69,7 +185,7 @@ func main() {
 		Password: cfg.UpstashRedisPassword,
 	}
 	if err := redis.Initialize(redisCfg); err != nil {
-		logger.Log.Warn("Redis initialization failed - rate limiting will fall back to in-memory", "error", err)
+		logger.Log.Warn("Redis initialization failed - rate limiting and caching will fall back to in-memory", "error", err)
 	} else {
 		logger.Log.Info("Redis initialized successfully")
 		defer redis.Close()
@@ -90,6 +206,30 @@ func main() {
 		logger.Log.Info("Security event database persistence enabled")
 	}
 
+	// 2f. Forward security events to an external SIEM (if enabled)
+	var siemSink *security.SIEMSink
+	if cfg.SIEMSinkEnabled {
+		severities, err := security.ParseSeverities(cfg.SIEMSinkSeverities)
+		if err == nil {
+			siemSink, err = security.NewSIEMSink(security.SIEMSinkConfig{
+				Transport:     cfg.SIEMSinkTransport,
+				Address:       cfg.SIEMSinkAddress,
+				Format:        cfg.SIEMSinkFormat,
+				AuthHeader:    cfg.SIEMSinkAuthHeader,
+				Severities:    severities,
+				BufferSize:    cfg.SIEMSinkBufferSize,
+				MaxRetryDelay: time.Duration(cfg.SIEMSinkMaxRetrySeconds) * time.Second,
+			})
+		}
+		if err != nil {
+			logger.Log.Warn("SIEM forwarding disabled - invalid configuration", "error", err)
+		} else {
+			siemSink.Start()
+			secLogger.SetSIEMSink(siemSink)
+			logger.Log.Info("SIEM forwarding enabled", "transport", cfg.SIEMSinkTransport, "format", cfg.SIEMSinkFormat)
+		}
+	}
+
 	// 2d. Initialize Login Tracker
 	loginTracker := security.NewLoginTracker(security.LoginTrackerConfig{
 		MaxAttempts:   cfg.FailedLoginMaxAttempts,
@@ -108,55 +248,370 @@ func main() {
 	companyProfileRepo := postgres.NewCompanyProfileRepository(dbPool)
 	onboardingRepo := postgres.NewOnboardingRepository(dbPool)
 	atsRepo := postgres.NewATSRepository(dbPool)
+	atsExportAuditRepo := postgres.NewATSExportAuditRepository(dbPool)
+	atsSavedFilterRepo := postgres.NewATSSavedFilterRepository(dbPool)
+	lpkPartnerRepo := postgres.NewLPKPartnerRepository(dbPool)
+	cohortRepo := postgres.NewCohortRepository(dbPool)
+	placementRepo := postgres.NewPlacementRepository(dbPool)
+	uploadedFileRepo := postgres.NewUploadedFileRepository(dbPool)
+	phoneVerificationRepo := postgres.NewPhoneVerificationRepository(dbPool)
+	contactCreditRepo := postgres.NewContactCreditRepository(dbPool)
+	financeReportRepo := postgres.NewFinanceReportRepository(dbPool)
+	holidayRepo := postgres.NewHolidayRepository(dbPool)
+	reengagementRepo := postgres.NewReengagementRepository(dbPool)
+	careerPageRepo := postgres.NewCareerPageRepository(dbPool)
+	screeningQuestionRepo := postgres.NewScreeningQuestionRepository(dbPool)
+	quizRepo := postgres.NewQuizRepository(dbPool)
+	piiAccessLogRepo := postgres.NewPIIAccessLogRepository(dbPool)
+	aggregateRepo := postgres.NewAggregateRepository(dbPool)
+	retentionRepo := postgres.NewRetentionRepository(dbPool)
+	companyMergeRepo := postgres.NewCompanyMergeRepository(dbPool)
+	killSwitchRepo := postgres.NewKillSwitchRepository(dbPool)
+	documentExpiryRepo := postgres.NewDocumentExpiryRepository(dbPool)
+	companyUsageRepo := postgres.NewCompanyUsageRepository(dbPool)
+	notificationRepo := postgres.NewNotificationRepository(dbPool)
+	adminSearchRepo := postgres.NewAdminSearchRepository(dbPool)
+	savedSearchRepo := postgres.NewSavedSearchRepository(dbPool)
+	applicationDraftRepo := postgres.NewApplicationDraftRepository(dbPool)
+	verificationSchemaRepo := postgres.NewVerificationSchemaRepository(dbPool)
+	emailValidationRepo := postgres.NewEmailValidationRepository(dbPool)
+	adminMFARepo := postgres.NewAdminMFARepository(dbPool)
+	interviewFeedbackRepo := postgres.NewInterviewFeedbackRepository(dbPool)
+	employerMessageRepo := postgres.NewEmployerMessageRepository(dbPool)
+	cvParseRepo := postgres.NewCVParseRepository(dbPool)
+	applicationStageRepo := postgres.NewApplicationStageRepository(dbPool)
+	warehouseRepo := postgres.NewWarehouseRepository(dbPool)
+	applicationInsightsRepo := postgres.NewApplicationInsightsRepository(dbPool)
+	webhookRepo := postgres.NewWebhookRepository(dbPool)
+	storageCleanupRepo := postgres.NewStorageCleanupRepository(dbPool)
+	maintenanceRepo := postgres.NewMaintenanceRepository(dbPool)
+	candidateDocumentRepo := postgres.NewCandidateDocumentRepository(dbPool)
+	candidatePortfolioRepo := postgres.NewCandidatePortfolioRepository(dbPool)
+	duplicateDocumentRepo := postgres.NewDuplicateDocumentRepository(dbPool)
+	inviteCodeRepo := postgres.NewInviteCodeRepository(dbPool)
+	screeningCallRepo := postgres.NewScreeningCallRepository(dbPool)
+	candidateActivityRepo := postgres.NewCandidateActivityRepository(dbPool)
+	companyVerificationRepo := postgres.NewCompanyVerificationRepository(dbPool)
+	pipelineSLARepo := postgres.NewPipelineSLARepository(dbPool)
+	lpkIntegrationRepo := postgres.NewLPKIntegrationRepository(dbPool)
+	companyLocationRepo := postgres.NewCompanyLocationRepository(dbPool)
+	companyMemberRepo := postgres.NewCompanyMemberRepository(dbPool)
 
 	// 5. Setup Email Service
 	emailService := email.NewEmailService(cfg)
 	if !emailService.IsConfigured() {
 		logger.Log.Warn("Email service missing configuration - contact/verification features may fail")
 	}
+	// Open/click tracking of emails to users (off while EMAIL_TRACKING_BASE_URL is empty)
+	emailTrackingUC := usecase.NewEmailTrackingUsecase(postgres.NewEmailTrackingRepository(dbPool), cfg)
+	emailService.SetTracker(emailTrackingUC)
+
+	// 5b. Setup SMS Service (phone OTP)
+	smsService := sms.NewSMSService(cfg)
+	if !smsService.IsConfigured() {
+		logger.Log.Warn("SMS service missing configuration - phone OTP will only be logged outside release mode")
+	}
 
 	// 6. Setup UseCases
 	validate := validator.New()
 	validation.RegisterValidators(validate) // Register custom validators
-	authUC := usecase.NewAuthUsecase(userRepo)
-	jobUC := usecase.NewJobUsecase(jobRepo, companyProfileRepo)
-	candidateUC := usecase.NewCandidateUsecase(candidateRepo, verificationRepo, validate)
-	adminUC := usecase.NewAdminUsecase(adminRepo)
-	verificationUC := usecase.NewVerificationUsecase(verificationRepo, userRepo)
-	applicationUC := usecase.NewApplicationUsecase(applicationRepo, jobRepo, verificationRepo)
+	authUC := usecase.NewAuthUsecase(userRepo, emailService)
+	supabaseWebhookUC := usecase.NewSupabaseWebhookUsecase(postgres.NewSupabaseWebhookRepository(dbPool), userRepo, authUC, cfg.SupabaseWebhookSecret)
+	// Public job pages are cached in Redis so every instance sees an invalidation;
+	// without Redis each instance keeps its own short-lived copy
+	var publicJobStore cache.Cache = cache.NewMemory()
+	if redis.IsAvailable() {
+		publicJobStore = cache.NewRedis(redis.Client())
+	}
+	publicJobCache := usecase.NewPublicJobCache(publicJobStore,
+		time.Duration(cfg.PublicJobListCacheTTLSeconds)*time.Second,
+		time.Duration(cfg.PublicJobDetailCacheTTLSeconds)*time.Second)
+	webhookUC := usecase.NewWebhookUsecase(webhookRepo, usecase.WebhookConfig{
+		MaxAttempts:   cfg.WebhookMaxAttempts,
+		BaseBackoff:   time.Duration(cfg.WebhookBackoffSeconds) * time.Second,
+		MaxBackoff:    time.Duration(cfg.WebhookMaxBackoffMinutes) * time.Minute,
+		Timeout:       time.Duration(cfg.WebhookTimeoutSeconds) * time.Second,
+		AllowInsecure: cfg.WebhookAllowInsecure,
+	})
+	jobUC := usecase.NewJobUsecase(jobRepo, companyProfileRepo, publicJobCache, webhookUC, companyVerificationRepo, validate, companyLocationRepo)
+	candidateUC := usecase.NewCandidateUsecase(candidateRepo, verificationRepo, documentExpiryRepo, validate)
+	adminUC := usecase.NewAdminUsecase(adminRepo, publicJobCache)
+	adminSearchUC := usecase.NewAdminSearchUsecase(adminSearchRepo)
+	holidayCalendarUC := usecase.NewHolidayCalendarUsecase(holidayRepo)
+	// Realtime events: the hub holds this instance's WebSocket connections; with Redis,
+	// events fan out so users connected to another instance receive them too
+	realtimeHub := realtime.NewHub()
+	var realtimeBroker realtime.Broker = realtimeHub
+	var realtimeFanout *realtime.RedisBroker
+	if redis.IsAvailable() {
+		realtimeFanout = realtime.NewRedisBroker(redis.Client(), realtimeHub)
+		realtimeBroker = realtimeFanout
+	}
+	realtimeEvents := usecase.NewRealtimePublisher(realtimeBroker)
+	var candidateNotifier domain.CandidateNotifier // nil logs notifications instead of sending them
+	if emailService.IsConfigured() {
+		// Addresses the email validation worker found undeliverable are skipped
+		candidateNotifier = usecase.NewDeliverableCandidateNotifier(usecase.NewEmailCandidateNotifier(emailService), emailValidationRepo)
+	}
+	notificationUC := usecase.NewNotificationUsecase(notificationRepo, candidateNotifier, usecase.NotificationConfig{
+		DigestWindow: time.Duration(cfg.NotificationDigestWindowMinutes) * time.Minute,
+		MaxPerRun:    cfg.NotificationDigestMaxPerRun,
+		FrontendURL:  cfg.FrontendURL,
+	})
+	verificationUC := usecase.NewVerificationUsecase(verificationRepo, userRepo, verificationSchemaRepo, companyProfileRepo, holidayCalendarUC, cfg.VerificationSLABusinessDays, cfg.GuardianConsentUnderAge, realtimeEvents, webhookUC, emailService, companyVerificationRepo, notificationUC)
+	// LPK partner feed: status changes of consenting candidates go to their LPK's
+	// pull API and webhooks
+	lpkIntegrationUC := usecase.NewLPKIntegrationUsecase(lpkIntegrationRepo, lpkPartnerRepo, webhookRepo, cfg.WebhookAllowInsecure)
+	applicationUC := usecase.NewApplicationUsecase(applicationRepo, jobRepo, verificationRepo, screeningQuestionRepo, applicationDraftRepo, companyProfileRepo, piiAccessLogRepo, notificationUC, realtimeEvents, webhookUC, lpkIntegrationUC)
+	interviewFeedbackUC := usecase.NewInterviewFeedbackUsecase(interviewFeedbackRepo, notificationUC, usecase.InterviewFeedbackConfig{
+		RequireReview: cfg.InterviewFeedbackRequireReview,
+	})
+	employerMessageUC := usecase.NewEmployerMessageUsecase(employerMessageRepo, companyProfileRepo, notificationUC, usecase.EmployerMessageConfig{
+		DailyCap:        cfg.EmployerMessageDailyCap,
+		SpamReportLimit: cfg.EmployerMessageSpamReportLimit,
+		SpamWindow:      time.Duration(cfg.EmployerMessageSpamWindowDays) * 24 * time.Hour,
+	})
+	cvParseUC := usecase.NewCVParseUsecase(cvParseRepo)
+	applicationInsightsUC := usecase.NewApplicationInsightsUsecase(applicationInsightsRepo)
+	applicationStageUC := usecase.NewApplicationStageUsecase(applicationStageRepo, jobRepo, companyProfileRepo, notificationUC, realtimeEvents, lpkIntegrationUC)
+	var warehouseStore domain.WarehouseStore
+	if store, err := security.NewS3ExportStoreForBucket(context.Background(), cfg.WarehouseExportBucket); err != nil {
+		logger.Log.Warn("Warehouse export storage unavailable - exports disabled", "error", err)
+	} else if store != nil {
+		warehouseStore = store
+	}
+	warehouseUC := usecase.NewWarehouseUsecase(warehouseRepo, warehouseStore, usecase.WarehouseConfig{
+		Format:       cfg.WarehouseExportFormat,
+		PseudonymKey: cfg.WarehousePseudonymKey,
+	})
 	companyProfileUC := usecase.NewCompanyProfileUsecase(companyProfileRepo, verificationRepo)
-	contactUC := usecase.NewContactUsecase(emailService)
+	// Background job queue: work moved out of request handlers, retried with backoff
+	jobQueue := jobs.NewQueue(dbPool, jobs.Config{
+		Workers:     cfg.JobQueueWorkers,
+		MaxAttempts: cfg.JobQueueMaxAttempts,
+	})
+	jobQueue.Register(domain.JobTypeContactEmail, usecase.NewContactEmailJob(emailService))
+	jobQueueUC := usecase.NewJobQueueUsecase(jobQueue)
+	contactUC := usecase.NewContactUsecase(emailService, jobQueue)
 	onboardingUC := usecase.NewOnboardingUsecase(onboardingRepo, validate)
-	atsUC := usecase.NewATSUsecase(atsRepo)
+	// File storage: Supabase by default, or one S3 bucket with a key prefix per upload bucket
+	var fileStore storage.Store = storage.NewSupabaseStore(cfg.SupabaseUrl, cfg.SupabaseServiceKey)
+	if cfg.StorageProvider == "s3" {
+		if cfg.StorageS3Bucket == "" {
+			logger.Log.Error("STORAGE_S3_BUCKET is required with STORAGE_PROVIDER=s3")
+			os.Exit(1)
+		}
+		s3Cfg := security.NewS3ClientConfigFromEnv()
+		s3Cfg.Bucket = cfg.StorageS3Bucket
+		s3Client, err := security.NewS3Client(context.Background(), s3Cfg)
+		if err != nil {
+			logger.Log.Error("S3 file storage unavailable", "error", err)
+			os.Exit(1)
+		}
+		publicURL := cfg.StorageS3PublicURL
+		if publicURL == "" {
+			publicURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", cfg.StorageS3Bucket, s3Cfg.Region)
+		}
+		fileStore = storage.NewS3Store(s3Client, cfg.StorageS3Bucket, publicURL)
+	}
+	atsUC := usecase.NewATSUsecase(atsRepo, companyProfileRepo, atsExportAuditRepo, usecase.NewPublicStoragePhotoFetcher(fileStore))
+	atsSavedFilterUC := usecase.NewATSSavedFilterUsecase(atsSavedFilterRepo)
+	lpkPartnerUC := usecase.NewLPKPartnerUsecase(lpkPartnerRepo)
+	cohortUC := usecase.NewCohortUsecase(cohortRepo, lpkPartnerRepo)
+	placementUC := usecase.NewPlacementUsecase(placementRepo, applicationRepo)
+	uploadedFileUC := usecase.NewUploadedFileUsecase(uploadedFileRepo, companyProfileRepo, fileStore,
+		time.Duration(cfg.SignedURLTTLMinutes)*time.Minute, usecase.NewFileProcessingNotifier(notificationUC, webhookUC))
+	storageCleanupUC := usecase.NewStorageCleanupUsecase(storageCleanupRepo, fileStore, usecase.StorageCleanupConfig{
+		MaxAttempts: cfg.StorageDeletionMaxAttempts,
+		BaseBackoff: time.Duration(cfg.StorageDeletionBackoffSeconds) * time.Second,
+		OrphanGrace: time.Duration(cfg.StorageOrphanGraceHours) * time.Hour,
+	})
+	candidateDocumentUC := usecase.NewCandidateDocumentUsecase(candidateDocumentRepo, storageCleanupRepo, storageCleanupUC, notificationUC)
+	candidatePortfolioUC := usecase.NewCandidatePortfolioUsecase(candidatePortfolioRepo, storageCleanupRepo, storageCleanupUC,
+		contactCreditRepo, companyProfileRepo, verificationRepo, int64(cfg.CandidatePortfolioQuotaMB)*1024*1024)
+	duplicateDocumentUC := usecase.NewDuplicateDocumentUsecase(duplicateDocumentRepo)
+	inviteCodeUC := usecase.NewInviteCodeUsecase(inviteCodeRepo, cfg.InviteOnlyRoles)
+	emailValidationUC := usecase.NewEmailValidationUsecase(emailValidationRepo, usecase.EmailValidationConfig{
+		RecheckAfter:      time.Duration(cfg.EmailValidationRecheckDays) * 24 * time.Hour,
+		MaxPerRun:         cfg.EmailValidationMaxPerRun,
+		DisposableDomains: cfg.EmailValidationDisposableDomains,
+	})
+	adminMFAUC := usecase.NewAdminMFAUsecase(adminMFARepo, usecase.AdminMFAConfig{
+		Required:   cfg.AdminMFARequired,
+		SessionTTL: time.Duration(cfg.AdminMFASessionHours) * time.Hour,
+	})
+	phoneVerificationUC := usecase.NewPhoneVerificationUsecase(phoneVerificationRepo, smsService)
+	contactCreditUC := usecase.NewContactCreditUsecase(contactCreditRepo, companyProfileRepo)
+	companyUsageUC := usecase.NewCompanyUsageUsecase(companyUsageRepo, contactCreditRepo, companyProfileRepo)
+	candidateResumeUC := usecase.NewCandidateResumeUsecase(verificationRepo, contactCreditRepo, companyProfileRepo, piiAccessLogRepo, candidatePortfolioRepo)
+	financeReportUC := usecase.NewFinanceReportUsecase(financeReportRepo)
+	careerPageUC := usecase.NewCareerPageUsecase(careerPageRepo, companyProfileRepo)
+	companyLocationUC := usecase.NewCompanyLocationUsecase(companyLocationRepo, companyProfileRepo, publicJobCache)
+	companyMemberUC := usecase.NewCompanyMemberUsecase(companyMemberRepo, companyProfileRepo, userRepo, emailService)
+	adminScopeRepo := postgres.NewAdminScopeRepository(dbPool)
+	adminScopeUC := usecase.NewAdminScopeUsecase(adminScopeRepo, userRepo)
+	quizUC := usecase.NewQuizUsecase(quizRepo)
+	aggregateUC := usecase.NewAggregateUsecase(aggregateRepo)
+	retentionUC := usecase.NewRetentionUsecase(retentionRepo, cfg.RetentionBatchSize)
+	maintenanceUC := usecase.NewMaintenanceUsecase(maintenanceRepo, aggregateUC)
+	companyMergeUC := usecase.NewCompanyMergeUsecase(companyMergeRepo)
+	killSwitchUC := usecase.NewKillSwitchUsecase(killSwitchRepo)
+	if err := killSwitchUC.Refresh(context.Background()); err != nil {
+		logger.Log.Warn("Failed to load kill switches - starting with everything enabled", "error", err)
+	}
+	reengagementUC := usecase.NewReengagementUsecase(reengagementRepo, candidateNotifier, usecase.ReengagementConfig{
+		InactiveDays:    cfg.ReengagementInactiveDays,
+		StalledDays:     cfg.ReengagementStalledDays,
+		CooldownDays:    cfg.ReengagementCooldownDays,
+		MaxPerRun:       cfg.ReengagementMaxPerRun,
+		AttributionDays: cfg.ReengagementAttributionDays,
+		FrontendURL:     cfg.FrontendURL,
+	})
+	documentExpiryUC := usecase.NewDocumentExpiryUsecase(documentExpiryRepo, candidateNotifier, usecase.DocumentExpiryConfig{
+		MaxPerRun:   cfg.DocumentExpiryMaxPerRun,
+		FrontendURL: cfg.FrontendURL,
+	})
+	savedSearchUC := usecase.NewSavedSearchUsecase(savedSearchRepo, candidateNotifier, realtimeEvents, usecase.SavedSearchConfig{
+		MaxPerRun:   cfg.SavedSearchAlertMaxPerRun,
+		FrontendURL: cfg.FrontendURL,
+	})
+	applicationDraftUC := usecase.NewApplicationDraftUsecase(applicationDraftRepo, jobRepo, applicationRepo, candidateNotifier, usecase.ApplicationDraftConfig{
+		ReminderAfter: time.Duration(cfg.ApplicationDraftReminderHours) * time.Hour,
+		ExpiryAfter:   time.Duration(cfg.ApplicationDraftExpiryDays) * 24 * time.Hour,
+		MaxPerRun:     cfg.ApplicationDraftMaxPerRun,
+		FrontendURL:   cfg.FrontendURL,
+	})
+	screeningCallUC := usecase.NewScreeningCallUsecase(screeningCallRepo, companyProfileRepo, notificationUC, usecase.ScreeningCallConfig{
+		ReminderLead: time.Duration(cfg.ScreeningCallReminderLeadMinutes) * time.Minute,
+		MaxPerRun:    cfg.ScreeningCallReminderMaxPerRun,
+		FrontendURL:  cfg.FrontendURL,
+	})
+	candidateActivityUC := usecase.NewCandidateActivityUsecase(candidateActivityRepo)
+	companyVerificationUC := usecase.NewCompanyVerificationUsecase(companyVerificationRepo, companyProfileRepo, notificationUC)
+	pipelineSLAUC := usecase.NewPipelineSLAUsecase(pipelineSLARepo, companyProfileRepo, notificationUC, usecase.PipelineSLAConfig{
+		MaxNotificationsPerRun: cfg.PipelineSLAMaxNotificationsPerRun,
+	})
 
 	// 6b. Setup Security Dashboard (isolated authentication)
 	securityDashboardRepo := postgres.NewSecurityDashboardRepository(dbPool)
-	securityAuthService := security.NewSecurityAuthService(dbPool, security.DefaultSecurityAuthConfig())
-	securityDashboardUC := usecase.NewSecurityDashboardUsecase(securityDashboardRepo, securityAuthService, nil)
+	securityAuthConfig := security.DefaultSecurityAuthConfig()
+	securityAuthConfig.BreakGlassDualApproval = cfg.SecurityBreakGlassDualApproval
+	securityAuthService := security.NewSecurityAuthService(dbPool, securityAuthConfig)
+	// Verification only reads the hash chain and anchors, so no S3 client is needed here
+	logIntegrityService := security.NewLogIntegrityService(dbPool, nil, security.LogIntegrityConfig{})
+	securityDashboardUC := usecase.NewSecurityDashboardUsecase(securityDashboardRepo, securityAuthService, logIntegrityService)
+	if emailService.IsConfigured() {
+		securityDashboardUC.SetAlertNotifier(usecase.NewEmailSecurityAlertNotifier(emailService))
+	}
+	if cfg.SecurityAlertWebhookURL != "" {
+		securityDashboardUC.SetAlertWebhook(usecase.NewWebhookSecurityAlertPoster(cfg.SecurityAlertWebhookURL))
+	}
+	if cfg.SecurityAlertSlackWebhookURL != "" {
+		securityDashboardUC.SetAlertWebhook(usecase.NewSlackSecurityAlertPoster(cfg.SecurityAlertSlackWebhookURL))
+	}
+	anomalyConfig := security.AnomalyDetectorConfig{
+		FailedLoginThreshold: cfg.AnomalyFailedLoginThreshold,
+		FailedLoginWindow:    time.Duration(cfg.AnomalyFailedLoginWindowMinutes) * time.Minute,
+		ExportSpikeThreshold: cfg.AnomalyExportSpikeThreshold,
+		ExportSpikeWindow:    time.Duration(cfg.AnomalyExportSpikeWindowMinutes) * time.Minute,
+		BusinessHoursStart:   cfg.AnomalyBusinessHoursStart,
+		BusinessHoursEnd:     cfg.AnomalyBusinessHoursEnd,
+	}
+	if loc, err := time.LoadLocation(cfg.AnomalyBusinessTimezone); err != nil {
+		logger.Log.Warn("Invalid anomaly detection time zone - using Asia/Jakarta", "timezone", cfg.AnomalyBusinessTimezone, "error", err)
+	} else {
+		anomalyConfig.BusinessLocation = loc
+	}
+	securityDashboardUC.SetAnomalyDetector(security.NewAnomalyDetector(anomalyConfig))
+	securityDashboardUC.SetAnchorHeartbeatWindow(time.Duration(cfg.AnchorHeartbeatWindowHours) * time.Hour)
+	if exportStore, err := security.NewS3ExportStoreFromEnv(context.Background()); err != nil {
+		logger.Log.Warn("Security export storage unavailable - large exports disabled", "error", err)
+	} else if exportStore != nil {
+		securityDashboardUC.SetExportStore(exportStore)
+		securityDashboardUC.SetJobQueue(jobQueue)
+	}
+	jobQueue.Register(domain.JobTypeSecurityExportArtifact, securityDashboardUC.RunExportArtifactJob)
 	logger.Log.Info("Security Dashboard initialized")
 
 	// 7. Setup Auth Provider (JWKS)
 	// URL construction is now safer due to config sanitization
 	jwksURL := fmt.Sprintf("%s/auth/v1/.well-known/jwks.json", cfg.SupabaseUrl)
 	jwksProvider := auth.NewProvider(jwksURL)
+	refreshService := auth.NewRefreshService(dbPool, auth.RefreshConfig{
+		SupabaseURL: cfg.SupabaseUrl,
+		SupabaseKey: cfg.SupabaseKey,
+		TTL:         time.Duration(cfg.RefreshTokenTTLDays) * 24 * time.Hour,
+	})
 
 	// 8. Setup Router
 	router := v1.NewRouter(v1.RouterDeps{
-		AuthUC:              authUC,
-		JobUC:               jobUC,
-		CandidateUC:         candidateUC,
-		ApplicationUC:       applicationUC,
-		AdminUC:             adminUC,
-		VerificationUC:      verificationUC,
-		CompanyProfileUC:    companyProfileUC,
-		ContactUC:           contactUC,
-		OnboardingUC:        onboardingUC,
-		ATSUC:               atsUC,
-		LoginTracker:        loginTracker,
-		JWKSProvider:        jwksProvider,
-		Config:              cfg,
-		SecurityDashboardUC: securityDashboardUC,
-		SecurityAuthService: securityAuthService,
+		AuthUC:                authUC,
+		JobUC:                 jobUC,
+		CandidateUC:           candidateUC,
+		ApplicationUC:         applicationUC,
+		ApplicationDraftUC:    applicationDraftUC,
+		AdminUC:               adminUC,
+		VerificationUC:        verificationUC,
+		CompanyProfileUC:      companyProfileUC,
+		ContactUC:             contactUC,
+		OnboardingUC:          onboardingUC,
+		ATSUC:                 atsUC,
+		LPKPartnerUC:          lpkPartnerUC,
+		CohortUC:              cohortUC,
+		UploadedFileUC:        uploadedFileUC,
+		PhoneVerificationUC:   phoneVerificationUC,
+		ContactCreditUC:       contactCreditUC,
+		FinanceReportUC:       financeReportUC,
+		HolidayCalendarUC:     holidayCalendarUC,
+		ReengagementUC:        reengagementUC,
+		CareerPageUC:          careerPageUC,
+		QuizUC:                quizUC,
+		AggregateUC:           aggregateUC,
+		RetentionUC:           retentionUC,
+		CompanyMergeUC:        companyMergeUC,
+		KillSwitchUC:          killSwitchUC,
+		DocumentExpiryUC:      documentExpiryUC,
+		CompanyUsageUC:        companyUsageUC,
+		CandidateResumeUC:     candidateResumeUC,
+		AdminSearchUC:         adminSearchUC,
+		SavedSearchUC:         savedSearchUC,
+		InterviewFeedbackUC:   interviewFeedbackUC,
+		EmployerMessageUC:     employerMessageUC,
+		CVParseUC:             cvParseUC,
+		ApplicationStageUC:    applicationStageUC,
+		WarehouseUC:           warehouseUC,
+		ApplicationInsightsUC: applicationInsightsUC,
+		WebhookUC:             webhookUC,
+		StorageCleanupUC:      storageCleanupUC,
+		MaintenanceUC:         maintenanceUC,
+		CandidateDocumentUC:   candidateDocumentUC,
+		ScreeningCallUC:       screeningCallUC,
+		CandidateActivityUC:   candidateActivityUC,
+		CompanyVerificationUC: companyVerificationUC,
+		PipelineSLAUC:         pipelineSLAUC,
+		DuplicateDocumentUC:   duplicateDocumentUC,
+		InviteCodeUC:          inviteCodeUC,
+		EmailValidationUC:     emailValidationUC,
+		AdminMFAUC:            adminMFAUC,
+		JobQueueUC:            jobQueueUC,
+		CandidatePortfolioUC:  candidatePortfolioUC,
+		LPKIntegrationUC:      lpkIntegrationUC,
+		CompanyLocationUC:     companyLocationUC,
+		CompanyMemberUC:       companyMemberUC,
+		AdminScopeUC:          adminScopeUC,
+		EmailTrackingUC:       emailTrackingUC,
+		SupabaseWebhookUC:     supabaseWebhookUC,
+		PlacementUC:           placementUC,
+		NotificationUC:        notificationUC,
+		ATSSavedFilterUC:      atsSavedFilterUC,
+		RealtimeHub:           realtimeHub,
+		ChaosInjector:         chaosInjector,
+		LoginTracker:          loginTracker,
+		JWKSProvider:          jwksProvider,
+		RefreshService:        refreshService,
+		Config:                cfg,
+		SecurityDashboardUC:   securityDashboardUC,
+		SecurityAuthService:   securityAuthService,
 	})
 
 	// 9. Start Server
@@ -169,6 +624,91 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// 9b. Background workers (stopped on shutdown)
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go jobQueue.Run(workerCtx)
+	logger.Log.Info("Job queue started", "workers", cfg.JobQueueWorkers)
+	if cfg.ReengagementEnabled {
+		go runReengagementWorker(workerCtx, reengagementUC, time.Duration(cfg.ReengagementIntervalHours)*time.Hour)
+		logger.Log.Info("Re-engagement worker started", "interval_hours", cfg.ReengagementIntervalHours)
+	}
+	if cfg.AggregateRecomputeEnabled {
+		go runAggregateRecomputeWorker(workerCtx, aggregateUC, cfg.AggregateRecomputeHourUTC)
+		logger.Log.Info("Aggregate recompute worker started", "hour_utc", cfg.AggregateRecomputeHourUTC)
+	}
+	if cfg.RetentionEnabled {
+		go runRetentionWorker(workerCtx, retentionUC, cfg.RetentionHourUTC, cfg.RetentionEnforceEnabled)
+		logger.Log.Info("Retention worker started", "hour_utc", cfg.RetentionHourUTC, "enforce", cfg.RetentionEnforceEnabled)
+	}
+	if cfg.WarehouseExportEnabled {
+		if warehouseStore == nil || cfg.WarehousePseudonymKey == "" {
+			logger.Log.Warn("Warehouse export worker not started - WAREHOUSE_EXPORT_BUCKET and WAREHOUSE_PSEUDONYM_KEY are required")
+		} else {
+			go runWarehouseExportWorker(workerCtx, warehouseUC, cfg.WarehouseExportHourUTC)
+			logger.Log.Info("Warehouse export worker started", "hour_utc", cfg.WarehouseExportHourUTC, "format", cfg.WarehouseExportFormat)
+		}
+	}
+	if cfg.DocumentExpiryRemindersEnabled {
+		go runDocumentExpiryWorker(workerCtx, documentExpiryUC, time.Duration(cfg.DocumentExpiryIntervalHours)*time.Hour)
+		logger.Log.Info("Document expiry reminder worker started", "interval_hours", cfg.DocumentExpiryIntervalHours)
+	}
+	if cfg.EmailValidationEnabled {
+		go runEmailValidationWorker(workerCtx, emailValidationUC, time.Duration(cfg.EmailValidationIntervalHours)*time.Hour)
+		logger.Log.Info("Email validation worker started", "interval_hours", cfg.EmailValidationIntervalHours)
+	}
+	if cfg.NotificationDigestWindowMinutes > 0 {
+		go runNotificationDigestWorker(workerCtx, notificationUC, time.Duration(cfg.NotificationDigestIntervalMinutes)*time.Minute)
+		logger.Log.Info("Notification digest worker started", "window_minutes", cfg.NotificationDigestWindowMinutes)
+	}
+	if cfg.SavedSearchAlertsEnabled {
+		go runSavedSearchAlertWorker(workerCtx, savedSearchUC, time.Duration(cfg.SavedSearchAlertIntervalMinutes)*time.Minute)
+		logger.Log.Info("Saved search alert worker started", "interval_minutes", cfg.SavedSearchAlertIntervalMinutes)
+	}
+	if cfg.ApplicationDraftRemindersEnabled {
+		go runApplicationDraftWorker(workerCtx, applicationDraftUC, time.Duration(cfg.ApplicationDraftIntervalMinutes)*time.Minute)
+		logger.Log.Info("Application draft reminder worker started", "interval_minutes", cfg.ApplicationDraftIntervalMinutes)
+	}
+	if cfg.ScreeningCallRemindersEnabled {
+		go runScreeningCallReminderWorker(workerCtx, screeningCallUC, time.Duration(cfg.ScreeningCallReminderIntervalMinutes)*time.Minute)
+		logger.Log.Info("Screening call reminder worker started", "interval_minutes", cfg.ScreeningCallReminderIntervalMinutes)
+	}
+	if cfg.PipelineSLAEnabled {
+		go runPipelineSLAWorker(workerCtx, pipelineSLAUC, time.Duration(cfg.PipelineSLAIntervalMinutes)*time.Minute)
+		logger.Log.Info("Pipeline SLA worker started", "interval_minutes", cfg.PipelineSLAIntervalMinutes)
+	}
+	if cfg.WebhookDeliveryEnabled {
+		go runWebhookDeliveryWorker(workerCtx, webhookUC, time.Duration(cfg.WebhookDeliveryIntervalSeconds)*time.Second)
+		logger.Log.Info("Webhook delivery worker started", "interval_seconds", cfg.WebhookDeliveryIntervalSeconds)
+	}
+	if cfg.StorageCleanupEnabled {
+		go runStorageDeletionWorker(workerCtx, storageCleanupUC, time.Duration(cfg.StorageCleanupIntervalSeconds)*time.Second)
+		logger.Log.Info("Storage deletion worker started", "interval_seconds", cfg.StorageCleanupIntervalSeconds)
+	}
+	if cfg.StorageOrphanSweepEnabled {
+		go runStorageOrphanSweepWorker(workerCtx, storageCleanupUC, time.Duration(cfg.StorageOrphanSweepHours)*time.Hour)
+		logger.Log.Info("Storage orphan sweep worker started", "interval_hours", cfg.StorageOrphanSweepHours)
+	}
+	if realtimeFanout != nil {
+		go runRealtimeFanoutWorker(workerCtx, realtimeFanout)
+		logger.Log.Info("Realtime Redis fan-out started")
+	}
+	go runKillSwitchRefreshWorker(workerCtx, killSwitchUC, time.Duration(cfg.KillSwitchRefreshSeconds)*time.Second)
+	go runJobSchedulerWorker(workerCtx, jobUC, time.Duration(cfg.JobSchedulerIntervalSeconds)*time.Second)
+	go runRefreshTokenPurgeWorker(workerCtx, refreshService)
+	if cfg.IntegrityVerifyEnabled {
+		go runIntegrityVerificationWorker(workerCtx, securityDashboardUC, time.Duration(cfg.IntegrityVerifyIntervalHours)*time.Hour, cfg.IntegrityVerifyDays)
+		logger.Log.Info("Integrity verification worker started", "interval_hours", cfg.IntegrityVerifyIntervalHours, "days", cfg.IntegrityVerifyDays)
+	}
+	if cfg.AnchorHeartbeatCheckEnabled {
+		go runAnchorHeartbeatWorker(workerCtx, securityDashboardUC, time.Duration(cfg.AnchorHeartbeatCheckIntervalMinutes)*time.Minute)
+		logger.Log.Info("Anchor heartbeat check started", "interval_minutes", cfg.AnchorHeartbeatCheckIntervalMinutes, "window_hours", cfg.AnchorHeartbeatWindowHours)
+	}
+	if cfg.AnomalyDetectionEnabled {
+		go runAnomalyDetectionWorker(workerCtx, securityDashboardUC, time.Duration(cfg.AnomalyDetectionIntervalSeconds)*time.Second)
+		logger.Log.Info("Anomaly detection worker started", "interval_seconds", cfg.AnomalyDetectionIntervalSeconds)
+	}
+
 	go func() {
 		logger.Log.Info("Server is running", "port", cfg.Port)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -182,6 +722,8 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Log.Info("Shutting down server...")
+	stopWorkers()
+	realtimeHub.Close() // WebSockets are hijacked, so srv.Shutdown does not close them
 
 	// REVISI: Naikkan timeout ke 10-15 detik untuk Cloud Environment
 	// 5 detik seringkali terlalu cepat untuk memutus koneksi DB yang sibuk
@@ -191,6 +733,584 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Log.Error("Server forced to shutdown", "error", err)
 	}
+	if siemSink != nil {
+		siemSink.Close(ctx) // last attempt to forward buffered events
+	}
+	shutdownTracing(ctx)     // flush pending spans
+	shutdownErrorReport(ctx) // send queued error reports
 
 	logger.Log.Info("Server exited properly")
 }
+
+// runReengagementWorker runs the re-engagement campaigns every interval until ctx is cancelled
+func runReengagementWorker(ctx context.Context, reengagementUC domain.ReengagementUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+			result, err := reengagementUC.RunCampaigns(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Re-engagement run failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Re-engagement run finished",
+				"evaluated", result.Evaluated, "sent", result.Sent, "failed", result.Failed, "skipped", result.Skipped)
+		}
+	}
+}
+
+// runAggregateRecomputeWorker recomputes derived candidate aggregates once a day at hourUTC
+func runAggregateRecomputeWorker(ctx context.Context, aggregateUC domain.AggregateUsecase, hourUTC int) {
+	if hourUTC < 0 || hourUTC > 23 {
+		hourUTC = 19
+	}
+
+	for {
+		now := time.Now().UTC()
+		next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			runCtx, cancel := context.WithTimeout(ctx, time.Hour)
+			run, err := aggregateUC.RunRecompute(runCtx, domain.RecomputeTriggerScheduled)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Aggregate recompute failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Aggregate recompute finished",
+				"checked", run.CheckedCount, "drifted", run.DriftCount, "fixed", run.FixedCount)
+		}
+	}
+}
+
+// runRetentionWorker runs a retention dry run once a day at hourUTC and, when
+// enforce is set, enforces the policies right after it
+func runRetentionWorker(ctx context.Context, retentionUC domain.RetentionUsecase, hourUTC int, enforce bool) {
+	if hourUTC < 0 || hourUTC > 23 {
+		hourUTC = 21
+	}
+
+	for {
+		now := time.Now().UTC()
+		next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			runCtx, cancel := context.WithTimeout(ctx, time.Hour)
+			run, err := retentionUC.RunRetention(runCtx, domain.RetentionTriggerScheduled, true, nil)
+			if err == nil && enforce {
+				run, err = retentionUC.RunRetention(runCtx, domain.RetentionTriggerScheduled, false, nil)
+			}
+			cancel()
+			if err != nil {
+				logger.Log.Error("Retention run failed", "error", err)
+				continue
+			}
+			for _, result := range run.Results {
+				logger.Log.Info("Retention run finished", "dry_run", run.DryRun,
+					"entity", result.Entity, "action", result.Action, "eligible", result.Eligible, "processed", result.Processed)
+			}
+		}
+	}
+}
+
+// runWarehouseExportWorker exports warehouse snapshots once a day at hourUTC
+func runWarehouseExportWorker(ctx context.Context, warehouseUC domain.WarehouseUsecase, hourUTC int) {
+	if hourUTC < 0 || hourUTC > 23 {
+		hourUTC = 20
+	}
+
+	for {
+		now := time.Now().UTC()
+		next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			runCtx, cancel := context.WithTimeout(ctx, 3*time.Hour)
+			run, err := warehouseUC.RunExport(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Warehouse export failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Warehouse export finished", "tables", len(run.Tables))
+		}
+	}
+}
+
+// runIntegrityVerificationWorker verifies the last `days` of security logs every interval until ctx is cancelled
+func runIntegrityVerificationWorker(ctx context.Context, securityDashboardUC domain.SecurityDashboardUsecase, interval time.Duration, days int) {
+	if interval <= 0 {
+		interval = 7 * 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+			run, err := securityDashboardUC.RunScheduledVerification(runCtx, days)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Integrity verification failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Integrity verification finished",
+				"status", run.Status, "chain_breaks", run.ChainBreaks, "anchor_mismatches", run.AnchorMismatches,
+				"alerted_admins", run.AlertedAdmins)
+		}
+	}
+}
+
+// runAnchorHeartbeatWorker checks the anchoring heartbeat every interval until ctx is cancelled
+func runAnchorHeartbeatWorker(ctx context.Context, securityDashboardUC domain.SecurityDashboardUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			status, err := securityDashboardUC.CheckAnchorHeartbeat(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Anchor heartbeat check failed", "error", err)
+				continue
+			}
+			if status.Alerted {
+				logger.Log.Warn("Anchor heartbeat overdue, security admins alerted", "status", status.Status, "overdue_minutes", status.OverdueMinutes)
+			}
+		}
+	}
+}
+
+// runAnomalyDetectionWorker evaluates the anomaly detection rules every interval until ctx is cancelled
+func runAnomalyDetectionWorker(ctx context.Context, securityDashboardUC domain.SecurityDashboardUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			run, err := securityDashboardUC.RunAnomalyDetection(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Anomaly detection failed", "error", err)
+				continue
+			}
+			if run.NewAlerts > 0 {
+				logger.Log.Warn("Anomaly detection raised alerts", "new_alerts", run.NewAlerts, "anomalies", run.Anomalies, "events_scanned", run.EventsScanned)
+			}
+		}
+	}
+}
+
+// runKillSwitchRefreshWorker reloads kill switches and ends expired maintenance windows every interval
+func runKillSwitchRefreshWorker(ctx context.Context, killSwitchUC domain.KillSwitchUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, interval)
+			if err := killSwitchUC.Refresh(runCtx); err != nil {
+				logger.Log.Error("Kill switch refresh failed", "error", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// runJobSchedulerWorker publishes and unpublishes scheduled jobs and closes jobs past
+// their application limits every interval until ctx is cancelled
+func runJobSchedulerWorker(ctx context.Context, jobUC domain.JobUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, interval)
+			result, err := jobUC.RunScheduledTransitions(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Job scheduler run failed", "error", err)
+				continue
+			}
+			if result.Published+result.Unpublished+result.Closed > 0 {
+				logger.Log.Info("Job scheduler run finished", "published", result.Published, "unpublished", result.Unpublished, "closed", result.Closed)
+			}
+		}
+	}
+}
+
+// runRefreshTokenPurgeWorker deletes long-expired refresh tokens daily until ctx is cancelled
+func runRefreshTokenPurgeWorker(ctx context.Context, refreshService *auth.RefreshService) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			purged, err := refreshService.PurgeExpired(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Refresh token purge failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Refresh token purge finished", "purged", purged)
+		}
+	}
+}
+
+// runDocumentExpiryWorker sends document expiry reminders every interval until ctx is cancelled
+func runDocumentExpiryWorker(ctx context.Context, documentExpiryUC domain.DocumentExpiryUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+			result, err := documentExpiryUC.RunReminders(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Document expiry reminder run failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Document expiry reminder run finished",
+				"documents", result.Documents, "candidates", result.Candidates, "sent", result.Sent, "failed", result.Failed)
+		}
+	}
+}
+
+// runEmailValidationWorker checks stored email addresses every interval until ctx is cancelled
+func runEmailValidationWorker(ctx context.Context, emailValidationUC domain.EmailValidationUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+			result, err := emailValidationUC.RunValidation(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Email validation run failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Email validation run finished",
+				"checked", result.Checked, "deliverable", result.Deliverable, "undeliverable", result.Undeliverable, "errors", result.Errors)
+		}
+	}
+}
+
+// runNotificationDigestWorker sends due notification digests every interval until ctx is cancelled
+func runNotificationDigestWorker(ctx context.Context, notificationUC domain.NotificationUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+			result, err := notificationUC.RunDigests(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Notification digest run failed", "error", err)
+				continue
+			}
+			if result.Recipients > 0 {
+				logger.Log.Info("Notification digest run finished",
+					"items", result.Items, "recipients", result.Recipients, "sent", result.Sent, "failed", result.Failed)
+			}
+		}
+	}
+}
+
+// runSavedSearchAlertWorker sends saved search job alerts every interval until ctx is cancelled
+func runSavedSearchAlertWorker(ctx context.Context, savedSearchUC domain.SavedSearchUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+			result, err := savedSearchUC.RunAlerts(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Saved search alert run failed", "error", err)
+				continue
+			}
+			if result.Searches > 0 {
+				logger.Log.Info("Saved search alert run finished",
+					"searches", result.Searches, "matched", result.Matched, "candidates", result.Candidates, "sent", result.Sent, "failed", result.Failed)
+			}
+		}
+	}
+}
+
+// runApplicationDraftWorker sends application draft reminders and deletes expired drafts every interval until ctx is cancelled
+func runApplicationDraftWorker(ctx context.Context, applicationDraftUC domain.ApplicationDraftUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 15*time.Minute)
+			result, err := applicationDraftUC.RunReminders(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Application draft reminder run failed", "error", err)
+				continue
+			}
+			if result.Due > 0 || result.Expired > 0 {
+				logger.Log.Info("Application draft reminder run finished",
+					"due", result.Due, "sent", result.Sent, "failed", result.Failed, "expired", result.Expired)
+			}
+		}
+	}
+}
+
+// runScreeningCallReminderWorker reminds candidates of upcoming calls every interval until ctx is cancelled
+func runScreeningCallReminderWorker(ctx context.Context, screeningCallUC domain.ScreeningCallUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			result, err := screeningCallUC.RunReminders(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Screening call reminder run failed", "error", err)
+				continue
+			}
+			if result.Due > 0 {
+				logger.Log.Info("Screening call reminder run finished",
+					"due", result.Due, "sent", result.Sent, "failed", result.Failed)
+			}
+		}
+	}
+}
+
+// runPipelineSLAWorker flags pipeline SLA breaches and notifies employers every interval until ctx is cancelled
+func runPipelineSLAWorker(ctx context.Context, pipelineSLAUC domain.PipelineSLAUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 15*time.Minute)
+			result, err := pipelineSLAUC.RunChecks(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Pipeline SLA run failed", "error", err)
+				continue
+			}
+			if result.Resolved > 0 || result.Breached > 0 || result.Notified > 0 || result.Failed > 0 {
+				logger.Log.Info("Pipeline SLA run finished",
+					"resolved", result.Resolved, "breached", result.Breached, "notified", result.Notified, "failed", result.Failed)
+			}
+		}
+	}
+}
+
+// runWebhookDeliveryWorker sends due webhook deliveries every interval until ctx is cancelled
+func runWebhookDeliveryWorker(ctx context.Context, webhookUC domain.WebhookUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			result, err := webhookUC.RunDeliveries(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Webhook delivery run failed", "error", err)
+				continue
+			}
+			if result.Due > 0 {
+				logger.Log.Info("Webhook delivery run finished",
+					"due", result.Due, "succeeded", result.Succeeded, "retrying", result.Retrying, "failed", result.Failed)
+			}
+		}
+	}
+}
+
+// runStorageDeletionWorker deletes due queued storage objects every interval until ctx is cancelled
+func runStorageDeletionWorker(ctx context.Context, cleanupUC domain.StorageCleanupUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+			result, err := cleanupUC.RunDeletions(runCtx)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Storage deletion run failed", "error", err)
+				continue
+			}
+			if result.Due > 0 {
+				logger.Log.Info("Storage deletion run finished",
+					"due", result.Due, "deleted", result.Deleted, "retrying", result.Retrying, "failed", result.Failed,
+					"reclaimed_bytes", result.ReclaimedBytes)
+			}
+		}
+	}
+}
+
+// runStorageOrphanSweepWorker queues orphaned storage objects for deletion every interval until ctx is cancelled
+func runStorageOrphanSweepWorker(ctx context.Context, cleanupUC domain.StorageCleanupUsecase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+			sweep, err := cleanupUC.RunSweep(runCtx, domain.StorageSweepScheduled)
+			cancel()
+			if err != nil {
+				logger.Log.Error("Storage orphan sweep failed", "error", err)
+				continue
+			}
+			logger.Log.Info("Storage orphan sweep finished",
+				"status", sweep.Status, "objects_scanned", sweep.ObjectsScanned, "orphans_found", sweep.OrphansFound,
+				"orphan_bytes", sweep.OrphanBytes, "bucket_errors", len(sweep.BucketErrors))
+		}
+	}
+}
+
+// runRealtimeFanoutWorker delivers events published on other instances to this
+// instance's WebSockets, resubscribing after Redis errors until ctx is cancelled
+func runRealtimeFanoutWorker(ctx context.Context, broker *realtime.RedisBroker) {
+	for {
+		err := broker.Run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Log.Error("Realtime fan-out subscription failed, retrying", "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
@@ -1,28 +1,35 @@
 package config
 
 import (
-	"log"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Port              string
-	DBUrl             string
-	SupabaseUrl       string
-	SupabaseKey       string
-	SupabaseJWTSecret string
-	FrontendURL       string
+	Port               string
+	DBUrl              string
+	DBMigrateOnStartup bool   // apply the embedded migrations before serving
+	DBMigrationsBase   string // last migration applied by hand on a database without migration history
+	SupabaseUrl        string
+	SupabaseKey        string
+	SupabaseJWTSecret  string
+	SupabaseServiceKey string // storage administration: listing and deleting uploaded files
+	FrontendURL        string
+	// Supabase auth webhooks: signing secret (v1,whsec_...); empty disables POST /v1/webhooks/supabase
+	SupabaseWebhookSecret string
 	// SMTP Configuration (Brevo)
 	SMTPHost       string
 	SMTPPort       string
 	SMTPUsername   string
 	SMTPPassword   string
 	SMTPFromEmail  string // Verified sender email (different from SMTP login)
-	ContactEmailTo string
+	ContactEmailTo string // comma-separated
+	ContactEmailCc string // comma-separated, optional
 	// Redis/Upstash Configuration
 	UpstashRedisURL      string
 	UpstashRedisPassword string
@@ -32,8 +39,191 @@ type Config struct {
 	RateLimitGlobalThreshold int
 	FailedLoginBlockMinutes  int
 	FailedLoginMaxAttempts   int
+	// Per-route token buckets per IP, "<requests>/<second|minute|hour|day>"
+	RateLimitLogin          RateLimitRule
+	RateLimitForgotPassword RateLimitRule
+	RateLimitContact        RateLimitRule
+	RateLimitUpload         RateLimitRule
+	RateLimitPartnerAPI     RateLimitRule // per API key on the LPK partner API
 	// Security Configuration
-	SecurityLogToDB bool // Whether to persist security events to database
+	SecurityLogToDB        bool   // Whether to persist security events to database
+	SecurityCookieSameSite string // SameSite of the security dashboard session cookie: strict, lax or none
+	// Break-glass needs a second SECURITY_ADMIN to approve within 10 minutes
+	SecurityBreakGlassDualApproval bool
+	// SIEM forwarding of security events (syslog or HTTP)
+	SIEMSinkEnabled         bool
+	SIEMSinkTransport       string // udp, tcp or http
+	SIEMSinkAddress         string // host:port for syslog, URL for http (e.g. Splunk HEC raw endpoint)
+	SIEMSinkFormat          string // cef or jsonl
+	SIEMSinkAuthHeader      string // http only, e.g. "Splunk <token>"
+	SIEMSinkSeverities      string // comma-separated; empty forwards all
+	SIEMSinkBufferSize      int
+	SIEMSinkMaxRetrySeconds int
+	// Request Body Limits
+	MaxJSONBodyBytes   int64 // Limit for JSON API request bodies
+	MaxUploadBodyBytes int64 // Limit for multipart upload request bodies
+	// SMS Configuration (Twilio-compatible REST API)
+	SMSAPIURL     string
+	SMSAccountSID string
+	SMSAuthToken  string
+	SMSFromNumber string
+	// Verification review SLA (working days, Indonesian holiday calendar)
+	VerificationSLABusinessDays int
+	// Candidates younger than this must upload guardian consent before submitting their profile
+	GuardianConsentUnderAge int
+	// Re-engagement campaigns (inactive candidate nudges)
+	ReengagementEnabled         bool
+	ReengagementIntervalHours   int
+	ReengagementInactiveDays    int
+	ReengagementStalledDays     int
+	ReengagementCooldownDays    int
+	ReengagementMaxPerRun       int
+	ReengagementAttributionDays int
+	// Derived candidate aggregates (nightly recompute + drift report)
+	AggregateRecomputeEnabled bool
+	AggregateRecomputeHourUTC int
+	// Data retention policies (nightly dry run, then enforcement when enabled)
+	RetentionEnabled        bool
+	RetentionHourUTC        int
+	RetentionEnforceEnabled bool // Without it the nightly run only reports
+	RetentionBatchSize      int
+	// Scheduled security log integrity verification
+	IntegrityVerifyEnabled       bool
+	IntegrityVerifyIntervalHours int
+	IntegrityVerifyDays          int
+	// Anchoring dead-man switch: alert when no anchor run succeeded within the window
+	AnchorHeartbeatCheckEnabled         bool
+	AnchorHeartbeatWindowHours          int
+	AnchorHeartbeatCheckIntervalMinutes int
+	AnchorHeartbeatCheckToken           string // Bearer token for GET /v1/health/anchoring (empty disables it)
+	SecurityAlertWebhookURL             string // Also POST security alerts here (optional)
+	SecurityAlertSlackWebhookURL        string // Also send security alerts to this Slack incoming webhook (optional)
+	// Anomaly detection over security_events (alerts on the security dashboard)
+	AnomalyDetectionEnabled         bool
+	AnomalyDetectionIntervalSeconds int
+	AnomalyFailedLoginThreshold     int // failed logins from one IP ...
+	AnomalyFailedLoginWindowMinutes int // ... within this window
+	AnomalyExportSpikeThreshold     int // exports requested or downloaded by one user ...
+	AnomalyExportSpikeWindowMinutes int // ... within this window
+	AnomalyBusinessHoursStart       int // break-glass outside weekdays start-end (local hours) is alerted
+	AnomalyBusinessHoursEnd         int
+	AnomalyBusinessTimezone         string
+	// Kill switches: how often each instance reloads toggles and expires maintenance windows
+	KillSwitchRefreshSeconds int
+	// Job posting schedules: how often scheduled jobs are published and unpublished
+	JobSchedulerIntervalSeconds int
+	// Public job list/detail caching (Redis, or in-memory without it); 0 disables
+	PublicJobListCacheTTLSeconds   int
+	PublicJobDetailCacheTTLSeconds int
+	// Candidate document expiry reminders (90/30/7 days before expiry)
+	DocumentExpiryRemindersEnabled bool
+	DocumentExpiryIntervalHours    int
+	DocumentExpiryMaxPerRun        int
+	// Notification digests: non-critical events are batched per user over the window
+	NotificationDigestWindowMinutes   int
+	NotificationDigestIntervalMinutes int
+	NotificationDigestMaxPerRun       int
+	// Saved search job alerts (each search is alerted at most once per its DAILY/WEEKLY frequency)
+	SavedSearchAlertsEnabled        bool
+	SavedSearchAlertIntervalMinutes int
+	SavedSearchAlertMaxPerRun       int
+	// Interview feedback: hold all feedback for admin review (custom messages are always reviewed)
+	InterviewFeedbackRequireReview bool
+	// Employer bulk messaging: recipients per company per UTC day; spam reports within the window that suspend sending
+	EmployerMessageDailyCap        int
+	EmployerMessageSpamReportLimit int
+	EmployerMessageSpamWindowDays  int
+	// Webhooks: background delivery of signed events to admin-registered endpoints
+	WebhookDeliveryEnabled         bool
+	WebhookDeliveryIntervalSeconds int
+	WebhookMaxAttempts             int
+	WebhookBackoffSeconds          int // wait after the first failure, doubled after each further one
+	WebhookMaxBackoffMinutes       int
+	WebhookTimeoutSeconds          int
+	WebhookAllowInsecure           bool // allow http:// and private network endpoints (local development only)
+	// File storage: where uploads are kept, and how long signed URLs for private buckets work
+	StorageProvider     string // supabase or s3
+	StorageS3Bucket     string // with s3, the bucket holding every upload bucket as a key prefix
+	StorageS3PublicURL  string // with s3, where StorageS3Bucket is served (CDN or bucket endpoint)
+	SignedURLTTLMinutes int
+	// Storage cleanup: queued deletion of replaced files and a periodic sweep for orphaned objects
+	StorageCleanupEnabled         bool
+	StorageCleanupIntervalSeconds int
+	StorageDeletionMaxAttempts    int
+	StorageDeletionBackoffSeconds int // wait after the first failure, doubled after each further one
+	StorageOrphanSweepEnabled     bool
+	StorageOrphanSweepHours       int
+	StorageOrphanGraceHours       int // objects younger than this are never treated as orphans
+	// Application drafts: reminder after the candidate leaves a draft, deletion after expiry
+	ApplicationDraftRemindersEnabled bool
+	ApplicationDraftReminderHours    int
+	ApplicationDraftExpiryDays       int
+	ApplicationDraftIntervalMinutes  int
+	ApplicationDraftMaxPerRun        int
+	// Screening calls: candidate reminder shortly before a booked call
+	ScreeningCallRemindersEnabled        bool
+	ScreeningCallReminderLeadMinutes     int
+	ScreeningCallReminderIntervalMinutes int
+	ScreeningCallReminderMaxPerRun       int
+	// Pipeline SLA: flag applications that stay in a stage past the company's threshold
+	PipelineSLAEnabled                bool
+	PipelineSLAIntervalMinutes        int
+	PipelineSLAMaxNotificationsPerRun int
+	// Data warehouse export (nightly anonymized snapshots to object storage)
+	WarehouseExportEnabled bool
+	WarehouseExportHourUTC int
+	WarehouseExportBucket  string
+	WarehouseExportFormat  string // parquet or csv
+	WarehousePseudonymKey  string
+	// Refresh tokens: how long a login session can be extended by rotation
+	RefreshTokenTTLDays int
+	// Soft launch: roles (candidate, employer) that need an invite code to register; empty = open
+	InviteOnlyRoles []string
+	// Email validation: syntax, MX and disposable-domain checks of stored addresses
+	EmailValidationEnabled           bool
+	EmailValidationIntervalHours     int
+	EmailValidationRecheckDays       int
+	EmailValidationMaxPerRun         int
+	EmailValidationDisposableDomains []string // added to the built-in disposable list
+	// Admin two-factor authentication: admin routes need a TOTP-verified session
+	AdminMFARequired     bool
+	AdminMFASessionHours int // how long one verification lasts
+	// Background job queue (pkg/jobs): workers per instance and attempts before a job is dead
+	JobQueueWorkers     int
+	JobQueueMaxAttempts int
+	// Candidate portfolio: stored file size allowed per candidate
+	CandidatePortfolioQuotaMB int
+	// Serve the generated OpenAPI document at /v1/openapi.json (also in release mode)
+	OpenAPIEnabled bool
+	// Read-only GraphQL gateway at POST /v1/graphql; queries above the complexity limit are refused
+	GraphQLEnabled       bool
+	GraphQLMaxComplexity int
+	// Prometheus metrics at GET /metrics; with a token, scrapers must send it as a Bearer token
+	MetricsEnabled bool
+	MetricsToken   string
+	// Tracing: OTLP/HTTP export, off while the endpoint is empty (standard OpenTelemetry variable names)
+	TracingEndpoint      string
+	TracingHeaders       string // key=value,key2=value2, e.g. a vendor API key
+	TracingServiceName   string
+	TracingSamplePercent int // share of new traces recorded; traces continued from a sampled caller are always recorded
+	// Error reporting: panics and 5xx responses go to Sentry and/or a JSON webhook; off while both are empty
+	ErrorReportDSN         string
+	ErrorReportWebhookURL  string
+	ErrorReportEnvironment string
+	// Logging: format (json|text), minimum level and sampling of noisy paths
+	LogFormat           string
+	LogLevel            string
+	LogSampleInitial    int // records per second per key and level before sampling starts (0 = off)
+	LogSampleThereafter int // then keep one in N
+	// Fault injection for resilience testing (ignored in release mode); percentages are 0-100
+	ChaosEnabled              bool
+	ChaosLatencyPercent       int
+	ChaosLatencyMs            int
+	ChaosSupabaseErrorPercent int
+	ChaosDBErrorPercent       int
+	// Email tracking: open pixel and click redirects in emails to users; off while the base URL is empty
+	EmailTrackingBaseURL            string   // public URL of this API, e.g. https://api.example.com
+	EmailTrackingDisabledCategories []string // email categories never tracked (security ones never are)
 }
 
 func LoadConfig() (*Config, error) {
@@ -43,11 +233,17 @@ func LoadConfig() (*Config, error) {
 	cfg := &Config{
 		Port:  getEnv("PORT", "8080"),
 		DBUrl: getEnv("DATABASE_URL", ""),
+		// Migrations
+		DBMigrateOnStartup: getEnvBool("DB_MIGRATE_ON_STARTUP", true),
+		DBMigrationsBase:   getEnv("DB_MIGRATIONS_BASELINE", ""),
 		// Sanitasi: Hapus slash di akhir URL untuk mencegah double slash (misal: .co//auth)
-		SupabaseUrl:       strings.TrimRight(getEnv("SUPABASE_URL", ""), "/"),
-		SupabaseKey:       getEnv("SUPABASE_KEY", getEnv("SUPABASE_ANON_KEY", "")),
-		SupabaseJWTSecret: getEnv("SUPABASE_JWT_SECRET", getEnv("SUPABASE_JWT_KEY", "")),
-		FrontendURL:       strings.TrimRight(getEnv("FRONTEND_URL", "http://localhost:3000"), "/"),
+		SupabaseUrl:        strings.TrimRight(getEnv("SUPABASE_URL", ""), "/"),
+		SupabaseKey:        getEnv("SUPABASE_KEY", getEnv("SUPABASE_ANON_KEY", "")),
+		SupabaseJWTSecret:  getEnv("SUPABASE_JWT_SECRET", getEnv("SUPABASE_JWT_KEY", "")),
+		SupabaseServiceKey: getEnv("SUPABASE_SERVICE_KEY", getEnv("SUPABASE_SERVICE_ROLE_KEY", getEnv("SUPABASE_KEY", ""))),
+		FrontendURL:        strings.TrimRight(getEnv("FRONTEND_URL", "http://localhost:3000"), "/"),
+		// Supabase auth webhooks
+		SupabaseWebhookSecret: getEnv("SUPABASE_WEBHOOK_SECRET", ""),
 		// SMTP Configuration
 		SMTPHost:       getEnv("SMTP_HOST", "smtp-relay.brevo.com"),
 		SMTPPort:       getEnv("SMTP_PORT", "587"),
@@ -55,6 +251,7 @@ func LoadConfig() (*Config, error) {
 		SMTPPassword:   getEnv("SMTP_PASSWORD", ""),
 		SMTPFromEmail:  getEnv("SMTP_FROM_EMAIL", "noreply@jexpertrecruitment.com"), // Must be verified in Brevo
 		ContactEmailTo: getEnv("CONTACT_EMAIL_TO", "info@jexpertrecruitment.com"),
+		ContactEmailCc: getEnv("CONTACT_EMAIL_CC", ""),
 		// Redis/Upstash Configuration
 		UpstashRedisURL:      getEnv("UPSTASH_REDIS_URL", ""),
 		UpstashRedisPassword: getEnv("UPSTASH_REDIS_PASSWORD", ""),
@@ -64,21 +261,229 @@ func LoadConfig() (*Config, error) {
 		RateLimitGlobalThreshold: getEnvInt("RATE_LIMIT_GLOBAL_THRESHOLD", 100), // 100 requests per window
 		FailedLoginBlockMinutes:  getEnvInt("FAILED_LOGIN_BLOCK_MINUTES", 15),   // 15 minute block
 		FailedLoginMaxAttempts:   getEnvInt("FAILED_LOGIN_MAX_ATTEMPTS", 5),     // 5 failed attempts before block
+		// Per-route rate limits
+		RateLimitLogin:          getEnvRate("RATE_LIMIT_LOGIN", "5/min"),
+		RateLimitForgotPassword: getEnvRate("RATE_LIMIT_FORGOT_PASSWORD", "3/hour"),
+		RateLimitContact:        getEnvRate("RATE_LIMIT_CONTACT", "3/hour"),
+		RateLimitUpload:         getEnvRate("RATE_LIMIT_UPLOAD", "10/hour"),
+		RateLimitPartnerAPI:     getEnvRate("RATE_LIMIT_PARTNER_API", "60/min"),
 		// Security Configuration
-		SecurityLogToDB: getEnvBool("SECURITY_LOG_TO_DB", true), // Persist security events to DB by default
+		SecurityLogToDB:        getEnvBool("SECURITY_LOG_TO_DB", true), // Persist security events to DB by default
+		SecurityCookieSameSite: getEnv("SECURITY_COOKIE_SAMESITE", "strict"),
+		// Two-person rule for break-glass (off keeps single-admin activation)
+		SecurityBreakGlassDualApproval: getEnvBool("SECURITY_BREAKGLASS_DUAL_APPROVAL", false),
+		// SIEM forwarding (off until an address is configured)
+		SIEMSinkEnabled:         getEnvBool("SIEM_SINK_ENABLED", false),
+		SIEMSinkTransport:       getEnv("SIEM_SINK_TRANSPORT", "udp"),
+		SIEMSinkAddress:         getEnv("SIEM_SINK_ADDRESS", ""),
+		SIEMSinkFormat:          getEnv("SIEM_SINK_FORMAT", "cef"),
+		SIEMSinkAuthHeader:      getEnv("SIEM_SINK_AUTH_HEADER", ""),
+		SIEMSinkSeverities:      getEnv("SIEM_SINK_SEVERITIES", ""),
+		SIEMSinkBufferSize:      getEnvInt("SIEM_SINK_BUFFER_SIZE", 10000),
+		SIEMSinkMaxRetrySeconds: getEnvInt("SIEM_SINK_MAX_RETRY_SECONDS", 60),
+		// Request Body Limits
+		MaxJSONBodyBytes:   int64(getEnvInt("MAX_JSON_BODY_KB", 1024)) * 1024,        // 1MB for JSON APIs
+		MaxUploadBodyBytes: int64(getEnvInt("MAX_UPLOAD_BODY_MB", 11)) * 1024 * 1024, // 10MB file + multipart overhead
+		// SMS Configuration
+		SMSAPIURL:     getEnv("SMS_API_URL", "https://api.twilio.com"),
+		SMSAccountSID: getEnv("SMS_ACCOUNT_SID", ""),
+		SMSAuthToken:  getEnv("SMS_AUTH_TOKEN", ""),
+		SMSFromNumber: getEnv("SMS_FROM_NUMBER", ""),
+		// Verification SLA
+		VerificationSLABusinessDays: getEnvInt("VERIFICATION_SLA_BUSINESS_DAYS", 3),
+		GuardianConsentUnderAge:     getEnvInt("GUARDIAN_CONSENT_UNDER_AGE", 18),
+		// Re-engagement campaigns
+		ReengagementEnabled:         getEnvBool("REENGAGEMENT_ENABLED", false),
+		ReengagementIntervalHours:   getEnvInt("REENGAGEMENT_INTERVAL_HOURS", 24),
+		ReengagementInactiveDays:    getEnvInt("REENGAGEMENT_INACTIVE_DAYS", 14),
+		ReengagementStalledDays:     getEnvInt("REENGAGEMENT_STALLED_DAYS", 21),
+		ReengagementCooldownDays:    getEnvInt("REENGAGEMENT_COOLDOWN_DAYS", 30),
+		ReengagementMaxPerRun:       getEnvInt("REENGAGEMENT_MAX_PER_RUN", 200),
+		ReengagementAttributionDays: getEnvInt("REENGAGEMENT_ATTRIBUTION_DAYS", 7),
+		// Aggregate recompute (19:00 UTC = 02:00 WIB)
+		AggregateRecomputeEnabled: getEnvBool("AGGREGATE_RECOMPUTE_ENABLED", true),
+		AggregateRecomputeHourUTC: getEnvInt("AGGREGATE_RECOMPUTE_HOUR_UTC", 19),
+		// Data retention (21:00 UTC = 04:00 WIB, after the warehouse export)
+		RetentionEnabled:        getEnvBool("RETENTION_ENABLED", true),
+		RetentionHourUTC:        getEnvInt("RETENTION_HOUR_UTC", 21),
+		RetentionEnforceEnabled: getEnvBool("RETENTION_ENFORCE_ENABLED", false),
+		RetentionBatchSize:      getEnvInt("RETENTION_BATCH_SIZE", 500),
+		// Integrity verification (weekly, covering the last 7 days)
+		IntegrityVerifyEnabled:       getEnvBool("INTEGRITY_VERIFY_ENABLED", true),
+		IntegrityVerifyIntervalHours: getEnvInt("INTEGRITY_VERIFY_INTERVAL_HOURS", 168),
+		IntegrityVerifyDays:          getEnvInt("INTEGRITY_VERIFY_DAYS", 7),
+		// Anchoring heartbeat (anchors are daily; 26h allows for a late run)
+		AnchorHeartbeatCheckEnabled:         getEnvBool("ANCHOR_HEARTBEAT_CHECK_ENABLED", true),
+		AnchorHeartbeatWindowHours:          getEnvInt("ANCHOR_HEARTBEAT_WINDOW_HOURS", 26),
+		AnchorHeartbeatCheckIntervalMinutes: getEnvInt("ANCHOR_HEARTBEAT_CHECK_INTERVAL_MINUTES", 15),
+		AnchorHeartbeatCheckToken:           getEnv("ANCHOR_HEARTBEAT_CHECK_TOKEN", ""),
+		SecurityAlertWebhookURL:             getEnv("SECURITY_ALERT_WEBHOOK_URL", ""),
+		SecurityAlertSlackWebhookURL:        getEnv("SECURITY_ALERT_SLACK_WEBHOOK_URL", ""),
+		// Anomaly detection (break-glass is only seen while inside the longest window, so run well within it)
+		AnomalyDetectionEnabled:         getEnvBool("ANOMALY_DETECTION_ENABLED", true),
+		AnomalyDetectionIntervalSeconds: getEnvInt("ANOMALY_DETECTION_INTERVAL_SECONDS", 60),
+		AnomalyFailedLoginThreshold:     getEnvInt("ANOMALY_FAILED_LOGIN_THRESHOLD", 10),
+		AnomalyFailedLoginWindowMinutes: getEnvInt("ANOMALY_FAILED_LOGIN_WINDOW_MINUTES", 10),
+		AnomalyExportSpikeThreshold:     getEnvInt("ANOMALY_EXPORT_SPIKE_THRESHOLD", 5),
+		AnomalyExportSpikeWindowMinutes: getEnvInt("ANOMALY_EXPORT_SPIKE_WINDOW_MINUTES", 60),
+		AnomalyBusinessHoursStart:       getEnvInt("ANOMALY_BUSINESS_HOURS_START", 8),
+		AnomalyBusinessHoursEnd:         getEnvInt("ANOMALY_BUSINESS_HOURS_END", 18),
+		AnomalyBusinessTimezone:         getEnv("ANOMALY_BUSINESS_TIMEZONE", "Asia/Jakarta"),
+		// Kill switches
+		KillSwitchRefreshSeconds: getEnvInt("KILL_SWITCH_REFRESH_SECONDS", 15),
+		// Job posting schedules (public listings follow the schedule between runs)
+		JobSchedulerIntervalSeconds: getEnvInt("JOB_SCHEDULER_INTERVAL_SECONDS", 60),
+		// Public job caching
+		PublicJobListCacheTTLSeconds:   getEnvInt("PUBLIC_JOB_LIST_CACHE_TTL_SECONDS", 30),
+		PublicJobDetailCacheTTLSeconds: getEnvInt("PUBLIC_JOB_DETAIL_CACHE_TTL_SECONDS", 120),
+		// Document expiry reminders
+		DocumentExpiryRemindersEnabled: getEnvBool("DOCUMENT_EXPIRY_REMINDERS_ENABLED", true),
+		DocumentExpiryIntervalHours:    getEnvInt("DOCUMENT_EXPIRY_INTERVAL_HOURS", 24),
+		DocumentExpiryMaxPerRun:        getEnvInt("DOCUMENT_EXPIRY_MAX_PER_RUN", 500),
+		// Notification digests (0 window = send every notification immediately)
+		NotificationDigestWindowMinutes:   getEnvInt("NOTIFICATION_DIGEST_WINDOW_MINUTES", 60),
+		NotificationDigestIntervalMinutes: getEnvInt("NOTIFICATION_DIGEST_INTERVAL_MINUTES", 5),
+		NotificationDigestMaxPerRun:       getEnvInt("NOTIFICATION_DIGEST_MAX_PER_RUN", 1000),
+		// Saved search job alerts
+		SavedSearchAlertsEnabled:        getEnvBool("SAVED_SEARCH_ALERTS_ENABLED", true),
+		SavedSearchAlertIntervalMinutes: getEnvInt("SAVED_SEARCH_ALERT_INTERVAL_MINUTES", 60),
+		SavedSearchAlertMaxPerRun:       getEnvInt("SAVED_SEARCH_ALERT_MAX_PER_RUN", 1000),
+		// Interview feedback
+		InterviewFeedbackRequireReview: getEnvBool("INTERVIEW_FEEDBACK_REQUIRE_REVIEW", false),
+		// Employer bulk messaging (0 spam report limit = never suspend)
+		EmployerMessageDailyCap:        getEnvInt("EMPLOYER_MESSAGE_DAILY_CAP", 200),
+		EmployerMessageSpamReportLimit: getEnvInt("EMPLOYER_MESSAGE_SPAM_REPORT_LIMIT", 5),
+		EmployerMessageSpamWindowDays:  getEnvInt("EMPLOYER_MESSAGE_SPAM_WINDOW_DAYS", 30),
+		// Webhooks (10 attempts with 1 minute base backoff span about 8.5 hours)
+		WebhookDeliveryEnabled:         getEnvBool("WEBHOOK_DELIVERY_ENABLED", true),
+		WebhookDeliveryIntervalSeconds: getEnvInt("WEBHOOK_DELIVERY_INTERVAL_SECONDS", 15),
+		WebhookMaxAttempts:             getEnvInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		WebhookBackoffSeconds:          getEnvInt("WEBHOOK_BACKOFF_SECONDS", 60),
+		WebhookMaxBackoffMinutes:       getEnvInt("WEBHOOK_MAX_BACKOFF_MINUTES", 360),
+		WebhookTimeoutSeconds:          getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+		WebhookAllowInsecure:           getEnvBool("WEBHOOK_ALLOW_INSECURE", false),
+		// File storage (S3 uses the shared S3_* credentials)
+		StorageProvider:     getEnv("STORAGE_PROVIDER", "supabase"),
+		StorageS3Bucket:     getEnv("STORAGE_S3_BUCKET", ""),
+		StorageS3PublicURL:  getEnv("STORAGE_S3_PUBLIC_URL", ""),
+		SignedURLTTLMinutes: getEnvInt("SIGNED_URL_TTL_MINUTES", 15),
+		// Storage cleanup (8 attempts with 1 minute base backoff span about 4 hours)
+		StorageCleanupEnabled:         getEnvBool("STORAGE_CLEANUP_ENABLED", true),
+		StorageCleanupIntervalSeconds: getEnvInt("STORAGE_CLEANUP_INTERVAL_SECONDS", 60),
+		StorageDeletionMaxAttempts:    getEnvInt("STORAGE_DELETION_MAX_ATTEMPTS", 8),
+		StorageDeletionBackoffSeconds: getEnvInt("STORAGE_DELETION_BACKOFF_SECONDS", 60),
+		StorageOrphanSweepEnabled:     getEnvBool("STORAGE_ORPHAN_SWEEP_ENABLED", false),
+		StorageOrphanSweepHours:       getEnvInt("STORAGE_ORPHAN_SWEEP_HOURS", 24),
+		StorageOrphanGraceHours:       getEnvInt("STORAGE_ORPHAN_GRACE_HOURS", 24),
+		// Application drafts (expiry counts from the last save)
+		ApplicationDraftRemindersEnabled: getEnvBool("APPLICATION_DRAFT_REMINDERS_ENABLED", true),
+		ApplicationDraftReminderHours:    getEnvInt("APPLICATION_DRAFT_REMINDER_HOURS", 24),
+		ApplicationDraftExpiryDays:       getEnvInt("APPLICATION_DRAFT_EXPIRY_DAYS", 14),
+		ApplicationDraftIntervalMinutes:  getEnvInt("APPLICATION_DRAFT_INTERVAL_MINUTES", 30),
+		ApplicationDraftMaxPerRun:        getEnvInt("APPLICATION_DRAFT_MAX_PER_RUN", 500),
+		// Screening calls (the interval must be shorter than the lead so no call is missed)
+		ScreeningCallRemindersEnabled:        getEnvBool("SCREENING_CALL_REMINDERS_ENABLED", true),
+		ScreeningCallReminderLeadMinutes:     getEnvInt("SCREENING_CALL_REMINDER_LEAD_MINUTES", 60),
+		ScreeningCallReminderIntervalMinutes: getEnvInt("SCREENING_CALL_REMINDER_INTERVAL_MINUTES", 5),
+		ScreeningCallReminderMaxPerRun:       getEnvInt("SCREENING_CALL_REMINDER_MAX_PER_RUN", 500),
+		// Pipeline SLA
+		PipelineSLAEnabled:                getEnvBool("PIPELINE_SLA_ENABLED", true),
+		PipelineSLAIntervalMinutes:        getEnvInt("PIPELINE_SLA_INTERVAL_MINUTES", 60),
+		PipelineSLAMaxNotificationsPerRun: getEnvInt("PIPELINE_SLA_MAX_NOTIFICATIONS_PER_RUN", 500),
+		// Warehouse export (20:00 UTC = 03:00 WIB, after the aggregate recompute)
+		WarehouseExportEnabled: getEnvBool("WAREHOUSE_EXPORT_ENABLED", false),
+		WarehouseExportHourUTC: getEnvInt("WAREHOUSE_EXPORT_HOUR_UTC", 20),
+		WarehouseExportBucket:  getEnv("WAREHOUSE_EXPORT_BUCKET", ""),
+		WarehouseExportFormat:  getEnv("WAREHOUSE_EXPORT_FORMAT", "parquet"),
+		WarehousePseudonymKey:  getEnv("WAREHOUSE_PSEUDONYM_KEY", ""),
+		// Refresh tokens
+		RefreshTokenTTLDays: getEnvInt("REFRESH_TOKEN_TTL_DAYS", 30),
+		// Invite-only registration
+		InviteOnlyRoles: getEnvList("INVITE_ONLY_ROLES"),
+		// Email validation
+		EmailValidationEnabled:           getEnvBool("EMAIL_VALIDATION_ENABLED", true),
+		EmailValidationIntervalHours:     getEnvInt("EMAIL_VALIDATION_INTERVAL_HOURS", 24),
+		EmailValidationRecheckDays:       getEnvInt("EMAIL_VALIDATION_RECHECK_DAYS", 30),
+		EmailValidationMaxPerRun:         getEnvInt("EMAIL_VALIDATION_MAX_PER_RUN", 1000),
+		EmailValidationDisposableDomains: getEnvList("EMAIL_VALIDATION_DISPOSABLE_DOMAINS"),
+		// Admin two-factor authentication
+		AdminMFARequired:     getEnvBool("ADMIN_MFA_REQUIRED", true),
+		AdminMFASessionHours: getEnvInt("ADMIN_MFA_SESSION_HOURS", 12),
+		// Background job queue
+		JobQueueWorkers:     getEnvInt("JOB_QUEUE_WORKERS", 4),
+		JobQueueMaxAttempts: getEnvInt("JOB_QUEUE_MAX_ATTEMPTS", 5),
+		// Candidate portfolio
+		CandidatePortfolioQuotaMB: getEnvInt("CANDIDATE_PORTFOLIO_QUOTA_MB", 50),
+		// OpenAPI document
+		OpenAPIEnabled: getEnvBool("OPENAPI_ENABLED", true),
+		// GraphQL gateway
+		GraphQLEnabled:       getEnvBool("GRAPHQL_ENABLED", false),
+		GraphQLMaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 200),
+		// Metrics
+		MetricsEnabled: getEnvBool("METRICS_ENABLED", true),
+		MetricsToken:   getEnv("METRICS_TOKEN", ""),
+		// Tracing
+		TracingEndpoint:      getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		TracingHeaders:       getEnv("OTEL_EXPORTER_OTLP_HEADERS", ""),
+		TracingServiceName:   getEnv("OTEL_SERVICE_NAME", "go-recruitment-backend"),
+		TracingSamplePercent: getEnvInt("TRACING_SAMPLE_PERCENT", 10),
+		// Error reporting
+		ErrorReportDSN:         getEnv("SENTRY_DSN", ""),
+		ErrorReportWebhookURL:  getEnv("ERROR_REPORT_WEBHOOK_URL", ""),
+		ErrorReportEnvironment: getEnv("ERROR_REPORT_ENVIRONMENT", "production"),
+		// Logging
+		LogFormat:           getEnv("LOG_FORMAT", "json"),
+		LogLevel:            getEnv("LOG_LEVEL", "info"),
+		LogSampleInitial:    getEnvInt("LOG_SAMPLE_INITIAL", 10),
+		LogSampleThereafter: getEnvInt("LOG_SAMPLE_THEREAFTER", 100),
+		// Fault injection
+		ChaosEnabled:              getEnvBool("CHAOS_ENABLED", false),
+		ChaosLatencyPercent:       getEnvInt("CHAOS_LATENCY_PERCENT", 0),
+		ChaosLatencyMs:            getEnvInt("CHAOS_LATENCY_MS", 2000),
+		ChaosSupabaseErrorPercent: getEnvInt("CHAOS_SUPABASE_ERROR_PERCENT", 0),
+		ChaosDBErrorPercent:       getEnvInt("CHAOS_DB_ERROR_PERCENT", 0),
+		// Email tracking
+		EmailTrackingBaseURL:            strings.TrimRight(getEnv("EMAIL_TRACKING_BASE_URL", ""), "/"),
+		EmailTrackingDisabledCategories: getEnvList("EMAIL_TRACKING_DISABLED_CATEGORIES"),
 	}
 
-	// Validasi dasar untuk mencegah panic aneh nanti
-	if cfg.DBUrl == "" {
-		log.Println("WARNING: DATABASE_URL is missing. Application may fail to connect.")
-	}
+	return cfg, nil
+}
+
+// RateLimitRule allows Limit requests per Per; a zero Limit disables the rule
+type RateLimitRule struct {
+	Limit int
+	Per   time.Duration
+}
 
-	// Log Redis configuration status (helpful for debugging)
-	if cfg.UpstashRedisURL == "" {
-		log.Println("WARNING: UPSTASH_REDIS_URL not configured. Rate limiting will use in-memory fallback.")
+// parseRateLimitRule parses "5/min", "3/hour" or "100/30s" (any Go duration)
+func parseRateLimitRule(value string) (RateLimitRule, error) {
+	countStr, unit, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return RateLimitRule{}, fmt.Errorf("invalid rate %q: want <requests>/<period>", value)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(countStr))
+	if err != nil || limit < 0 {
+		return RateLimitRule{}, fmt.Errorf("invalid rate %q: bad request count", value)
 	}
 
-	return cfg, nil
+	var per time.Duration
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "s", "sec", "second":
+		per = time.Second
+	case "m", "min", "minute":
+		per = time.Minute
+	case "h", "hour":
+		per = time.Hour
+	case "d", "day":
+		per = 24 * time.Hour
+	default:
+		per, err = time.ParseDuration(strings.TrimSpace(unit))
+		if err != nil || per <= 0 {
+			return RateLimitRule{}, fmt.Errorf("invalid rate %q: bad period", value)
+		}
+	}
+	return RateLimitRule{Limit: limit, Per: per}, nil
 }
 
 func getEnv(key, fallback string) string {
@@ -98,6 +503,28 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+// getEnvRate returns a rate limit rule from the environment or fallback if not set/invalid
+func getEnvRate(key, fallback string) RateLimitRule {
+	if value, exists := os.LookupEnv(key); exists {
+		if rule, err := parseRateLimitRule(value); err == nil {
+			return rule
+		}
+	}
+	rule, _ := parseRateLimitRule(fallback)
+	return rule
+}
+
+// getEnvList returns the non-empty, lower-cased entries of a comma-separated environment variable
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 // getEnvBool returns a boolean environment variable or fallback if not set/invalid
 func getEnvBool(key string, fallback bool) bool {
 	if value, exists := os.LookupEnv(key); exists {
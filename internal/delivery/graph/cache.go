@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"go-recruitment-backend/internal/domain"
+)
+
+// requestCache holds the lookups shared by many fields of one operation, so a
+// page of jobs loads the viewer, each company and the candidate's applications
+// once. Sibling fields resolve concurrently.
+type requestCache struct {
+	viewerOnce sync.Once
+	viewer     *domain.ViewerInfo
+
+	applicationsOnce sync.Once
+	applications     map[int64]*domain.Application // by job ID
+	applicationsErr  error
+
+	mu        sync.Mutex
+	companies map[int64]*companyEntry
+}
+
+type companyEntry struct {
+	once    sync.Once
+	profile *domain.PublicCompanyProfile
+	err     error
+}
+
+type requestCacheKey struct{}
+
+func withRequestCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestCacheKey{}, &requestCache{companies: map[int64]*companyEntry{}})
+}
+
+// cacheFrom returns the operation's cache; a fresh one outside an operation
+// only happens in tests that call resolvers directly
+func cacheFrom(ctx context.Context) *requestCache {
+	if c, ok := ctx.Value(requestCacheKey{}).(*requestCache); ok {
+		return c
+	}
+	return &requestCache{companies: map[int64]*companyEntry{}}
+}
+
+func (c *requestCache) company(id int64) *companyEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.companies[id]
+	if !ok {
+		entry = &companyEntry{}
+		c.companies[id] = entry
+	}
+	return entry
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+)
+
+// maxPageSize caps jobs(pageSize:) like the paginated REST lists
+const maxPageSize = 100
+
+// Resolver serves the schema through the REST API's usecases
+type Resolver struct {
+	JobUC            domain.JobUsecase
+	CompanyProfileUC domain.CompanyProfileUsecase
+	CandidateUC      domain.CandidateUsecase
+	ApplicationUC    domain.ApplicationUsecase
+	VerificationUC   domain.VerificationUsecase
+}
+
+// viewer describes the caller for company visibility rules, as the REST
+// company profile handler does
+func (r *Resolver) viewer(ctx context.Context) *domain.ViewerInfo {
+	cache := cacheFrom(ctx)
+	cache.viewerOnce.Do(func() {
+		cache.viewer = &domain.ViewerInfo{}
+		userID, _ := ctx.Value(domain.KeyUserID).(string)
+		if userID == "" {
+			return
+		}
+		cache.viewer.IsAuthenticated = true
+		cache.viewer.Role, _ = ctx.Value(domain.KeyUserRole).(string)
+		if cache.viewer.Role == domain.RoleCandidate {
+			verification, err := r.VerificationUC.GetVerificationStatus(ctx, userID)
+			if err == nil && verification != nil && verification.Verification != nil {
+				cache.viewer.VerificationStatus = verification.Verification.Status
+			}
+		}
+	})
+	return cache.viewer
+}
+
+// company loads a public company profile once per operation; a missing
+// company is null rather than an error
+func (r *Resolver) company(ctx context.Context, id int64) (*domain.PublicCompanyProfile, error) {
+	entry := cacheFrom(ctx).company(id)
+	entry.once.Do(func() {
+		entry.profile, entry.err = r.CompanyProfileUC.GetPublicProfile(ctx, id, r.viewer(ctx))
+		if isNotFound(entry.err) {
+			entry.profile, entry.err = nil, nil
+		}
+	})
+	return entry.profile, entry.err
+}
+
+// applicationsByJob loads the signed-in candidate's applications once per
+// operation, keyed by job ID
+func (r *Resolver) applicationsByJob(ctx context.Context, userID string) (map[int64]*domain.Application, error) {
+	cache := cacheFrom(ctx)
+	cache.applicationsOnce.Do(func() {
+		applications, err := r.ApplicationUC.GetMyApplications(ctx, userID)
+		if err != nil {
+			cache.applicationsErr = err
+			return
+		}
+		cache.applications = make(map[int64]*domain.Application, len(applications))
+		for i := range applications {
+			cache.applications[applications[i].JobID] = &applications[i]
+		}
+	})
+	return cache.applications, cache.applicationsErr
+}
+
+// candidateID returns the caller's user ID when the caller is a candidate
+func candidateID(ctx context.Context) (string, bool) {
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	role, _ := ctx.Value(domain.KeyUserRole).(string)
+	return userID, userID != "" && role == domain.RoleCandidate
+}
+
+func isNotFound(err error) bool {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Status == http.StatusNotFound
+	}
+	return errors.Is(err, domain.ErrNotFound)
+}
@@ -0,0 +1,133 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+)
+
+// Job is the resolver for the job field.
+func (r *applicationResolver) Job(ctx context.Context, obj *domain.Application) (*domain.JobWithCompany, error) {
+	job, err := r.JobUC.GetPublicJob(ctx, obj.JobID)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	return job, err
+}
+
+// Completeness is the resolver for the completeness field.
+func (r *candidateProfileResolver) Completeness(ctx context.Context, obj *domain.CandidateProfile) (*domain.ProfileCompleteness, error) {
+	return r.VerificationUC.GetProfileCompleteness(ctx, obj.UserID)
+}
+
+// Company is the resolver for the company field.
+func (r *jobResolver) Company(ctx context.Context, obj *domain.JobWithCompany) (*domain.PublicCompanyProfile, error) {
+	// Confidential jobs come without their company
+	if obj.CompanyID == 0 {
+		return nil, nil
+	}
+	return r.company(ctx, obj.CompanyID)
+}
+
+// MyApplication is the resolver for the myApplication field.
+func (r *jobResolver) MyApplication(ctx context.Context, obj *domain.JobWithCompany) (*domain.Application, error) {
+	userID, ok := candidateID(ctx)
+	if !ok {
+		return nil, nil
+	}
+	applications, err := r.applicationsByJob(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return applications[obj.ID], nil
+}
+
+// Jobs is the resolver for the jobs field.
+func (r *queryResolver) Jobs(ctx context.Context, sort *string, page *int, pageSize *int) (*JobPage, error) {
+	p, size := 1, 20
+	if page != nil && *page > 0 {
+		p = *page
+	}
+	if pageSize != nil && *pageSize > 0 {
+		size = min(*pageSize, maxPageSize)
+	}
+	var sortBy string
+	if sort != nil {
+		sortBy = *sort
+	}
+
+	jobs, total, err := r.JobUC.ListPublicActiveJobs(ctx, sortBy, p, size)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*domain.JobWithCompany, len(jobs))
+	for i := range jobs {
+		items[i] = &jobs[i]
+	}
+	return &JobPage{Items: items, Total: int(total), Page: p, PageSize: size}, nil
+}
+
+// Job is the resolver for the job field.
+func (r *queryResolver) Job(ctx context.Context, id int64) (*domain.JobWithCompany, error) {
+	job, err := r.JobUC.GetPublicJob(ctx, id)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	return job, err
+}
+
+// Company is the resolver for the company field.
+func (r *queryResolver) Company(ctx context.Context, id int64) (*domain.PublicCompanyProfile, error) {
+	return r.company(ctx, id)
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*domain.CandidateProfile, error) {
+	userID, ok := candidateID(ctx)
+	if !ok {
+		return nil, apperror.Forbidden("Only candidates have a profile")
+	}
+	profile, err := r.CandidateUC.GetProfile(ctx, userID)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	return profile, err
+}
+
+// MyApplications is the resolver for the myApplications field.
+func (r *queryResolver) MyApplications(ctx context.Context) ([]*domain.Application, error) {
+	userID, ok := candidateID(ctx)
+	if !ok {
+		return nil, apperror.Forbidden("Only candidates have applications")
+	}
+	applications, err := r.ApplicationUC.GetMyApplications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Application, len(applications))
+	for i := range applications {
+		result[i] = &applications[i]
+	}
+	return result, nil
+}
+
+// Application returns ApplicationResolver implementation.
+func (r *Resolver) Application() ApplicationResolver { return &applicationResolver{r} }
+
+// CandidateProfile returns CandidateProfileResolver implementation.
+func (r *Resolver) CandidateProfile() CandidateProfileResolver { return &candidateProfileResolver{r} }
+
+// Job returns JobResolver implementation.
+func (r *Resolver) Job() JobResolver { return &jobResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type applicationResolver struct{ *Resolver }
+type candidateProfileResolver struct{ *Resolver }
+type jobResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
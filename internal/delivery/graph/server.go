@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// unexpectedError answers errors that are not an AppError, as the REST error
+// middleware does
+const unexpectedError = "An unexpected error occurred. Please try again later."
+
+type localeKey struct{}
+
+// WithLocale sets the locale error messages are translated to
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// NewServer serves queries as JSON POSTs. Errors carry the REST API's
+// message, code and status in their extensions; introspection is for
+// development builds only.
+func NewServer(resolver *Resolver, introspection bool, maxComplexity int) *handler.Server {
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.FixedComplexityLimit(maxComplexity))
+	if introspection {
+		srv.Use(extension.Introspection{})
+	}
+
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(withRequestCache(ctx))
+	})
+	srv.SetErrorPresenter(presentError)
+	srv.SetRecoverFunc(func(ctx context.Context, p any) error {
+		logger.FromContext(ctx).Error("Panic recovered", "panic", p, "route", "/v1/graphql", "stack", string(debug.Stack()))
+		return apperror.Internal(fmt.Errorf("panic: %v", p))
+	})
+	return srv
+}
+
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) {
+		// Parse and validation errors are the client's; anything else a
+		// resolver returned is ours and stays in the log
+		var parseErr *gqlerror.Error
+		if errors.As(err, &parseErr) && parseErr.Err == nil {
+			return gqlErr
+		}
+		appErr = apperror.New(http.StatusInternalServerError, unexpectedError, err)
+	}
+	if appErr.Status >= http.StatusInternalServerError {
+		logger.FromContext(ctx).Error(appErr.Message, "status", appErr.Status, "code", appErr.Code, "error", appErr.Err)
+	}
+
+	locale, _ := ctx.Value(localeKey{}).(string)
+	gqlErr.Message = i18n.T(locale, appErr.Message)
+	gqlErr.Extensions = map[string]any{"code": appErr.Code, "status": appErr.Status}
+	if appErr.Details != nil {
+		gqlErr.Extensions["details"] = appErr.Details
+	}
+	return gqlErr
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityTracker records candidate activity for re-engagement campaigns.
+// Must run after AuthMiddleware; the usecase throttles the actual writes.
+func ActivityTracker(reengagementUC domain.ReengagementUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(string(domain.KeyUserRole)) == domain.RoleCandidate {
+			reengagementUC.RecordActivity(c.Request.Context(), c.GetString(string(domain.KeyUserID)))
+		}
+		c.Next()
+	}
+}
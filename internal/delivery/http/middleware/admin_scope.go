@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/logger"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// regionalAdminReadPrefixes are the admin areas regional admins can open; each
+// filters its results by province
+var regionalAdminReadPrefixes = []string{
+	"/v1/admin/users",
+	"/v1/admin/ats/",
+	"/v1/admin/company-verification",
+	"/v1/admin/search",
+	"/v1/admin/me/scope",
+}
+
+// regionalAdminWritePrefixes stay writable for regional admins: sessions,
+// verification decisions and company levels
+var regionalAdminWritePrefixes = []string{
+	"/v1/auth/",
+	"/v1/verifications/",
+	"/v1/admin/company-verification/",
+}
+
+// AdminScope loads a regional admin's provinces into the gin and request
+// contexts (domain.KeyAdminProvinces) for the usecases and repositories to
+// filter by, and keeps regional admins to the admin areas that do. Unrestricted
+// admins and other roles pass through untouched. Must run after AuthMiddleware.
+func AdminScope(scopeUC domain.AdminScopeUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(string(domain.KeyUserRole)) != domain.RoleAdmin {
+			c.Next()
+			return
+		}
+
+		provinces, err := scopeUC.Provinces(c.Request.Context(), c.GetString(string(domain.KeyUserID)))
+		if err != nil {
+			// Fail closed: an unknown scope could be a regional admin's
+			logger.FromContext(c.Request.Context()).Warn("Admin scope check failed", "error", err)
+			response.Error(c, http.StatusServiceUnavailable, "Please try again shortly", nil)
+			c.Abort()
+			return
+		}
+		if provinces == nil {
+			c.Next()
+			return
+		}
+
+		if !regionalAdminAllowed(c.Request.Method, c.Request.URL.Path) {
+			response.ErrorWithCode(c, http.StatusForbidden, domain.ErrCodeAdminScopeForbidden, "Not available to regional admins", gin.H{"admin_provinces": provinces})
+			c.Abort()
+			return
+		}
+		c.Set(string(domain.KeyAdminProvinces), provinces)
+		c.Request = c.Request.WithContext(domain.WithAdminProvinces(c.Request.Context(), provinces))
+		c.Next()
+	}
+}
+
+func regionalAdminAllowed(method, path string) bool {
+	if !isSafeMethod(method) {
+		return hasAnyPrefix(path, regionalAdminWritePrefixes) && !strings.HasPrefix(path, "/v1/verifications/schema")
+	}
+	return !strings.HasPrefix(path, "/v1/admin/") || hasAnyPrefix(path, regionalAdminReadPrefixes)
+}
@@ -2,31 +2,122 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-recruitment-backend/config"
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/auth"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/security"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware(jwksProvider *auth.Provider, cfg *config.Config, authUC domain.AuthUsecase) gin.HandlerFunc {
+const (
+	// AuthCookieName holds the access token for browser sessions
+	AuthCookieName = "auth_token"
+	// RefreshCookieName holds the refresh token for browser sessions
+	RefreshCookieName = "refresh_token"
+)
+
+// authCookieMaxAge matches the default refresh token TTL; the server-side
+// expiry is what actually bounds the session
+const authCookieMaxAge = 30 * 24 * time.Hour
+
+// SetAuthCookies stores a token pair in HttpOnly cookies. The access token cookie
+// outlives its JWT on purpose: the expired token must still reach AuthMiddleware
+// for the session to be refreshed.
+func SetAuthCookies(c *gin.Context, pair *auth.TokenPair) {
+	setAuthCookie(c, AuthCookieName, pair.AccessToken, authCookieMaxAge)
+	setAuthCookie(c, RefreshCookieName, pair.RefreshToken, authCookieMaxAge)
+}
+
+// ClearAuthCookies removes both session cookies
+func ClearAuthCookies(c *gin.Context) {
+	setAuthCookie(c, AuthCookieName, "", -time.Second)
+	setAuthCookie(c, RefreshCookieName, "", -time.Second)
+}
+
+func setAuthCookie(c *gin.Context, name, value string, maxAge time.Duration) {
+	// SameSite=None for cross-origin frontends, like the security session cookie
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	})
+}
+
+// LogRefreshTokenReuse records a replayed refresh token; the family it belonged
+// to has already been revoked
+func LogRefreshTokenReuse(c *gin.Context) {
+	security.DefaultLogger().Log(c.Request.Context(), security.SecurityEvent{
+		Event:        security.EventRefreshTokenReuse,
+		SubjectType:  "ip",
+		SubjectValue: c.ClientIP(),
+		IP:           c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+		Details: map[string]interface{}{
+			"endpoint": c.Request.URL.Path,
+		},
+	})
+}
+
+// mfaExemptRoutes are reachable by admins before their session passed TOTP
+var mfaExemptRoutes = []string{
+	"/v1/auth/me",
+	"/v1/auth/mfa/status",
+	"/v1/auth/mfa/enroll",
+	"/v1/auth/mfa/enroll/confirm",
+	"/v1/auth/mfa/verify",
+}
+
+// AuthMiddleware validates the access token. An expired cookie session with a
+// refresh_token cookie is refreshed transparently; header clients get a 401
+// "Token expired" and call POST /auth/refresh themselves. With mfaUC set, admin
+// sessions must have passed TOTP (POST /auth/mfa/verify) before other routes.
+func AuthMiddleware(jwksProvider *auth.Provider, cfg *config.Config, authUC domain.AuthUsecase, mfaUC domain.AdminMFAUsecase, refresher *auth.RefreshService) gin.HandlerFunc {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		// Check signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			// HS256 - Use Secret
+			if cfg.SupabaseJWTSecret == "" {
+				return nil, fmt.Errorf("HS256 token received but SUPABASE_JWT_KEY is not configured")
+			}
+			return []byte(cfg.SupabaseJWTSecret), nil
+		}
+
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+			// RS256 - Use JWKS
+			return jwksProvider.KeyFunc(token)
+		}
+
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		var tokenString string
+		fromCookie := false
 
 		// 1. Try to get token from Header
 		if authHeader != "" {
 			tokenString = strings.TrimPrefix(authHeader, "Bearer ")
 		} else {
 			// 2. Try to get token from Cookie
-			cookie, err := c.Cookie("auth_token")
+			cookie, err := c.Cookie(AuthCookieName)
 			if err == nil && cookie != "" {
 				tokenString = cookie
+				fromCookie = true
 			}
 		}
 
@@ -35,26 +126,37 @@ func AuthMiddleware(jwksProvider *auth.Provider, cfg *config.Config, authUC doma
 			c.Abort()
 			return
 		}
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Check signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
-				// HS256 - Use Secret
-				if cfg.SupabaseJWTSecret == "" {
-					return nil, fmt.Errorf("HS256 token received but SUPABASE_JWT_KEY is not configured")
+		token, err := jwt.Parse(tokenString, keyFunc)
+
+		// 3. Refresh an expired cookie session in place
+		if errors.Is(err, jwt.ErrTokenExpired) && fromCookie && refresher != nil {
+			if refreshToken, cerr := c.Cookie(RefreshCookieName); cerr == nil && refreshToken != "" {
+				pair, rerr := refresher.Rotate(c.Request.Context(), refreshToken, c.ClientIP(), c.Request.UserAgent())
+				switch {
+				case rerr == nil:
+					SetAuthCookies(c, pair)
+					token, err = jwt.Parse(pair.AccessToken, keyFunc)
+				case errors.Is(rerr, auth.ErrInvalidRefreshToken), errors.Is(rerr, auth.ErrRefreshTokenReused):
+					// The session cannot be refreshed any more; make the browser log in again
+					if errors.Is(rerr, auth.ErrRefreshTokenReused) {
+						LogRefreshTokenReuse(c)
+					}
+					ClearAuthCookies(c)
+				default:
+					logger.FromContext(c.Request.Context()).Warn("Transparent token refresh failed", "error", rerr)
 				}
-				return []byte(cfg.SupabaseJWTSecret), nil
-			}
-
-			if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
-				// RS256 - Use JWKS
-				return jwksProvider.KeyFunc(token)
 			}
+		}
 
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		})
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token", error_description="The access token expired"`)
+			response.Error(c, http.StatusUnauthorized, "Token expired", nil)
+			c.Abort()
+			return
+		}
 
 		if err != nil || !token.Valid {
-			fmt.Printf("Token validation failed: %v\n", err)
+			logger.Sampled(c.Request.Context(), "auth.invalid_token").Warn("Token validation failed", "error", err)
 			response.Error(c, http.StatusUnauthorized, "Invalid token", err.Error())
 			c.Abort()
 			return
@@ -70,6 +172,7 @@ func AuthMiddleware(jwksProvider *auth.Provider, cfg *config.Config, authUC doma
 		// Extract Supabase standard claims
 		sub, _ := claims["sub"].(string)
 		email, _ := claims["email"].(string)
+		sessionID, _ := claims["session_id"].(string)
 
 		// Fetch fresh user data from DB to get the correct Role
 		// We do NOT rely on the JWT role claim as it might be 'authenticated' or stale
@@ -82,21 +185,50 @@ func AuthMiddleware(jwksProvider *auth.Provider, cfg *config.Config, authUC doma
 			return
 		}
 
-		role := user.Role
-		if role == "" {
-			role = "candidate" // Fallback
+		// Only canonical roles reach the context, so handlers and usecases
+		// can compare against the domain.Role* constants directly
+		role := domain.RoleCandidate // Fallback
+		if user.Role != "" {
+			role, err = domain.ParseRole(user.Role)
+			if err != nil {
+				response.Error(c, http.StatusForbidden, "Unknown user role", nil)
+				c.Abort()
+				return
+			}
 		}
 
 		c.Set(string(domain.KeyUserID), sub)
 		c.Set(string(domain.KeyUserEmail), email)
 		c.Set(string(domain.KeyUserRole), role)
-
-		// Also set with typed keys for usecase context compatibility
+		c.Set(string(domain.KeySessionID), sessionID)
+		applyUserLocale(c, user.PreferredLocale)
 
 		// Also set with typed keys for usecase context compatibility
 		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), domain.KeyUserID, sub))
 		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), domain.KeyUserEmail, email))
 		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), domain.KeyUserRole, role))
+		c.Request = c.Request.WithContext(logger.With(c.Request.Context(), "user_id", sub))
+
+		// Admins: two-factor authentication for this session
+		if role == domain.RoleAdmin && mfaUC != nil && !slices.Contains(mfaExemptRoutes, c.FullPath()) {
+			status, err := mfaUC.Status(c.Request.Context(), sub, sessionID)
+			if err != nil {
+				logger.FromContext(c.Request.Context()).Error("Failed to check two-factor status", "error", err)
+				response.Error(c, http.StatusInternalServerError, "Failed to check two-factor status", nil)
+				c.Abort()
+				return
+			}
+			if !status.Enrolled {
+				response.Error(c, http.StatusForbidden, "Two-factor authentication must be enabled for admin accounts", domain.MFAErrorEnrollmentRequired)
+				c.Abort()
+				return
+			}
+			if !status.Verified {
+				response.Error(c, http.StatusForbidden, "Two-factor verification required", domain.MFAErrorVerificationRequired)
+				c.Abort()
+				return
+			}
+		}
 
 		c.Next()
 	}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"go-recruitment-backend/internal/delivery/http/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimitConfig holds per-route-group request body limits and accepted content types
+type BodyLimitConfig struct {
+	// Default limit for JSON APIs
+	DefaultMaxBytes int64
+	// Path prefix → limit overrides (e.g. "/v1/upload" → 10MB)
+	PathMaxBytes map[string]int64
+	// Path prefix → accepted media types for requests with a body.
+	// Paths not listed here accept DefaultContentTypes.
+	PathContentTypes map[string][]string
+	// Accepted media types when no override matches
+	DefaultContentTypes []string
+}
+
+// DefaultBodyLimitConfig returns limits for the public API:
+// small JSON bodies everywhere, larger multipart bodies only on the file upload routes
+func DefaultBodyLimitConfig(jsonMaxBytes, uploadMaxBytes int64) BodyLimitConfig {
+	return BodyLimitConfig{
+		DefaultMaxBytes: jsonMaxBytes,
+		PathMaxBytes: map[string]int64{
+			"/v1/upload":                 uploadMaxBytes,
+			"/v1/candidates/me/cv/parse": uploadMaxBytes,
+			"/v1/employers/jobs/import":  uploadMaxBytes,
+		},
+		PathContentTypes: map[string][]string{
+			"/v1/upload":                 {"multipart/form-data"},
+			"/v1/candidates/me/cv/parse": {"multipart/form-data"},
+			"/v1/employers/jobs/import":  {"multipart/form-data"},
+		},
+		DefaultContentTypes: []string{"application/json"},
+	}
+}
+
+// BodyLimitMiddleware caps request body size and rejects unexpected content types
+// before any handler reads the body.
+//
+// - Content-Length above the limit is rejected immediately with 413
+// - Bodies without Content-Length (chunked) are wrapped in http.MaxBytesReader
+// - POST/PUT/PATCH with a body must use an accepted media type, otherwise 415
+func BodyLimitMiddleware(cfg BodyLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		maxBytes := cfg.limitFor(path)
+
+		if c.Request.ContentLength > maxBytes {
+			response.Error(c, http.StatusRequestEntityTooLarge, "Request body too large", nil)
+			c.Abort()
+			return
+		}
+
+		if hasBody(c.Request) {
+			if !cfg.contentTypeAllowed(path, c.GetHeader("Content-Type")) {
+				response.Error(c, http.StatusUnsupportedMediaType, "Unsupported content type", nil)
+				c.Abort()
+				return
+			}
+		}
+
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		}
+
+		c.Next()
+	}
+}
+
+// limitFor returns the limit of the longest matching path prefix
+func (cfg BodyLimitConfig) limitFor(path string) int64 {
+	limit := cfg.DefaultMaxBytes
+	matched := 0
+	for prefix, max := range cfg.PathMaxBytes {
+		if strings.HasPrefix(path, prefix) && len(prefix) > matched {
+			limit = max
+			matched = len(prefix)
+		}
+	}
+	return limit
+}
+
+func (cfg BodyLimitConfig) contentTypeAllowed(path, contentType string) bool {
+	allowed := cfg.DefaultContentTypes
+	matched := 0
+	for prefix, types := range cfg.PathContentTypes {
+		if strings.HasPrefix(path, prefix) && len(prefix) > matched {
+			allowed = types
+			matched = len(prefix)
+		}
+	}
+	if len(allowed) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range allowed {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// hasBody reports whether a state-changing request actually carries a body
+func hasBody(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return r.ContentLength > 0 || (r.ContentLength == -1 && r.Body != nil && r.Body != http.NoBody)
+	}
+	return false
+}
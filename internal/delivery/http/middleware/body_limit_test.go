@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testJSONMaxBytes   = 1 << 10
+	testUploadMaxBytes = 8 << 10
+)
+
+// bodyLimitRouter answers 200 on any path once the middleware lets a request
+// through; the handler reads the whole body so MaxBytesReader applies
+func bodyLimitRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(BodyLimitMiddleware(DefaultBodyLimitConfig(testJSONMaxBytes, testUploadMaxBytes)))
+	r.NoRoute(func(c *gin.Context) {
+		if _, err := io.ReadAll(c.Request.Body); err != nil {
+			c.Status(http.StatusRequestEntityTooLarge)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func multipartBody(t *testing.T, size int) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", "file.pdf")
+	require.NoError(t, err)
+	_, err = part.Write(bytes.Repeat([]byte("a"), size))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return &buf, w.FormDataContentType()
+}
+
+func serve(r *gin.Engine, req *http.Request) int {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestDefaultBodyLimitConfig(t *testing.T) {
+	cfg := DefaultBodyLimitConfig(testJSONMaxBytes, testUploadMaxBytes)
+
+	assert.Equal(t, int64(testJSONMaxBytes), cfg.limitFor("/v1/jobs"))
+	assert.True(t, cfg.contentTypeAllowed("/v1/jobs", "application/json; charset=utf-8"))
+	assert.NotEmpty(t, cfg.PathMaxBytes)
+
+	// Every path with a larger limit is a multipart upload, and the other way round
+	for path, limit := range cfg.PathMaxBytes {
+		assert.Equal(t, int64(testUploadMaxBytes), limit, path)
+		assert.Equal(t, []string{"multipart/form-data"}, cfg.PathContentTypes[path], path)
+	}
+	for path := range cfg.PathContentTypes {
+		assert.Contains(t, cfg.PathMaxBytes, path)
+	}
+}
+
+func TestBodyLimitMiddlewareJSON(t *testing.T) {
+	r := bodyLimitRouter()
+
+	cases := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"small JSON", http.MethodPost, "application/json", `{"title":"Welder"}`, http.StatusOK},
+		{"JSON with charset", http.MethodPut, "application/json; charset=utf-8", `{}`, http.StatusOK},
+		{"oversized JSON", http.MethodPost, "application/json", `"` + strings.Repeat("a", testJSONMaxBytes) + `"`, http.StatusRequestEntityTooLarge},
+		{"form body", http.MethodPost, "application/x-www-form-urlencoded", "a=b", http.StatusUnsupportedMediaType},
+		{"text body", http.MethodPatch, "text/plain", "hello", http.StatusUnsupportedMediaType},
+		{"missing content type", http.MethodPost, "", `{}`, http.StatusUnsupportedMediaType},
+		{"empty POST", http.MethodPost, "", "", http.StatusOK},
+		{"GET", http.MethodGet, "", "", http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/v1/jobs", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			assert.Equal(t, tc.want, serve(r, req))
+		})
+	}
+
+	t.Run("multipart outside upload paths", func(t *testing.T) {
+		body, contentType := multipartBody(t, 10)
+		req := httptest.NewRequest(http.MethodPost, "/v1/jobs", body)
+		req.Header.Set("Content-Type", contentType)
+		assert.Equal(t, http.StatusUnsupportedMediaType, serve(r, req))
+	})
+
+	t.Run("chunked body over the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/jobs", strings.NewReader(strings.Repeat("a", testJSONMaxBytes+1)))
+		req.Header.Set("Content-Type", "application/json")
+		req.ContentLength = -1
+		assert.Equal(t, http.StatusRequestEntityTooLarge, serve(r, req))
+	})
+}
+
+func TestBodyLimitMiddlewareUploadPaths(t *testing.T) {
+	r := bodyLimitRouter()
+
+	for path := range DefaultBodyLimitConfig(testJSONMaxBytes, testUploadMaxBytes).PathMaxBytes {
+		t.Run(path, func(t *testing.T) {
+			body, contentType := multipartBody(t, testJSONMaxBytes*2)
+			req := httptest.NewRequest(http.MethodPost, path, body)
+			req.Header.Set("Content-Type", contentType)
+			assert.Equal(t, http.StatusOK, serve(r, req), "multipart above the JSON limit")
+
+			body, contentType = multipartBody(t, testUploadMaxBytes)
+			req = httptest.NewRequest(http.MethodPost, path, body)
+			req.Header.Set("Content-Type", contentType)
+			assert.Equal(t, http.StatusRequestEntityTooLarge, serve(r, req), "multipart above the upload limit")
+
+			req = httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			assert.Equal(t, http.StatusUnsupportedMediaType, serve(r, req), "JSON on an upload path")
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"go-recruitment-backend/pkg/chaos"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChaosMiddleware delays a configured share of requests before they are handled.
+// Only registered when fault injection is enabled outside release mode.
+func ChaosMiddleware(injector *chaos.Injector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if injector.Delay(c.Request.Context()) {
+			c.Header("X-Chaos-Injected", "latency")
+		}
+		c.Next()
+	}
+}
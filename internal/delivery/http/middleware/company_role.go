@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/logger"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// companyOwnerPrefixes are company-wide settings only the owner may change
+var companyOwnerPrefixes = []string{
+	"/v1/employers/company-profile",
+	"/v1/employers/career-page",
+	"/v1/employers/members",
+	"/v1/employers/me/pipeline-sla",
+}
+
+// companyViewerAllowedPrefixes stay writable for viewers: sessions only
+var companyViewerAllowedPrefixes = []string{
+	"/v1/auth/",
+}
+
+// CompanyRoleGate applies company roles to employer requests: viewers are
+// read-only and recruiters cannot change the company profile, offices, career
+// page, team or pipeline SLAs. Which company a request touches is resolved by
+// the usecases (CompanyProfileRepository.GetByUserID); this only filters by
+// method and path. Leaving a team (DELETE /v1/employers/members/{own id}) stays
+// open to members. Must run after AuthMiddleware.
+func CompanyRoleGate(memberUC domain.CompanyMemberUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(string(domain.KeyUserRole)) != domain.RoleEmployer || isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		userID := c.GetString(string(domain.KeyUserID))
+		role, err := memberUC.MemberRole(c.Request.Context(), userID)
+		if err != nil {
+			// Fail closed: a write with an unknown role could be a viewer's
+			logger.FromContext(c.Request.Context()).Warn("Company role check failed", "error", err)
+			response.Error(c, http.StatusServiceUnavailable, "Please try again shortly", nil)
+			c.Abort()
+			return
+		}
+
+		path := c.Request.URL.Path
+		if role != "" && role != domain.CompanyRoleOwner && path == "/v1/employers/members/"+userID && c.Request.Method == http.MethodDelete {
+			c.Next()
+			return
+		}
+		switch role {
+		case domain.CompanyRoleViewer:
+			if !hasAnyPrefix(path, companyViewerAllowedPrefixes) {
+				response.ErrorWithCode(c, http.StatusForbidden, domain.ErrCodeCompanyRoleForbidden, "Your company role is read-only", gin.H{"company_role": role})
+				c.Abort()
+				return
+			}
+		case domain.CompanyRoleRecruiter:
+			if hasAnyPrefix(path, companyOwnerPrefixes) {
+				response.ErrorWithCode(c, http.StatusForbidden, domain.ErrCodeCompanyRoleForbidden, "Only the company owner can change this", gin.H{"company_role": role})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
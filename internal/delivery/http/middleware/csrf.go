@@ -8,13 +8,15 @@ import (
 	"time"
 
 	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
 
 // isSecurityDashboardRoute checks if the path is a security dashboard route
 // Security dashboard uses hidden path prefix that starts with /v1/sec-ops-
-// These routes are protected by IP allowlist + MFA, not CSRF
+// These routes are protected by IP allowlist + MFA and their own session-bound
+// CSRF check (SecurityCSRFMiddleware), not the double-submit cookie
 func isSecurityDashboardRoute(path string) bool {
 	// Match pattern: /v1/sec-ops-* (hidden security dashboard path)
 	return strings.HasPrefix(path, "/v1/sec-ops-") ||
@@ -70,8 +72,10 @@ func CSRFMiddleware() gin.HandlerFunc {
 		"/v1/auth/register":        true,
 		"/v1/auth/forgot-password": true,
 		"/v1/auth/reset-password":  true,
+		"/v1/auth/refresh":         true, // The refresh token is the credential; a forged call only rotates it
 		"/v1/contact":              true, // Public contact form
 		"/v1/health":               true, // Health check
+		"/v1/webhooks/supabase":    true, // Server-to-server, signed by Supabase
 	}
 
 	return func(c *gin.Context) {
@@ -130,9 +134,9 @@ func CSRFMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Security Dashboard routes are exempt from CSRF
-		// They have their own layered protection: IP Allowlist → TOTP MFA → Session Auth
-		// CSRF is designed for browser-based attacks; security dashboard uses API clients
+		// Security Dashboard routes are exempt from the double-submit check
+		// They have their own layered protection: IP Allowlist → TOTP MFA → Session Auth,
+		// with a CSRF token bound to the session (SecurityCSRFMiddleware)
 		if isSecurityDashboardRoute(path) {
 			c.Next()
 			return
@@ -177,13 +181,13 @@ func CSRFMiddleware() gin.HandlerFunc {
 		headerToken := c.GetHeader(CSRFTokenHeaderName)
 
 		if headerToken == "" {
-			response.Error(c, http.StatusForbidden, "Missing CSRF token", nil)
+			response.ErrorWithCode(c, http.StatusForbidden, domain.ErrCodeCSRFTokenInvalid, "Missing CSRF token", nil)
 			c.Abort()
 			return
 		}
 
 		if headerToken != csrfCookie {
-			response.Error(c, http.StatusForbidden, "Invalid CSRF token", nil)
+			response.ErrorWithCode(c, http.StatusForbidden, domain.ErrCodeCSRFTokenInvalid, "Invalid CSRF token", nil)
 			c.Abort()
 			return
 		}
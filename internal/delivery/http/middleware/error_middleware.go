@@ -2,9 +2,9 @@ package middleware
 
 import (
 	"errors"
-	"fmt"
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -19,12 +19,15 @@ func ErrorHandler() gin.HandlerFunc {
 			err := c.Errors.Last().Err
 			var appErr *apperror.AppError
 			if errors.As(err, &appErr) {
-				response.Error(c, appErr.Code, appErr.Message, nil)
+				if appErr.Status >= http.StatusInternalServerError {
+					logger.FromContext(c.Request.Context()).Error(appErr.Message, "status", appErr.Status, "code", appErr.Code, "error", appErr.Err)
+				}
+				response.ErrorWithCode(c, appErr.Status, appErr.Code, appErr.Message, appErr.Details)
 			} else {
 				// SECURITY: Never expose internal error details to clients.
 				// Log the actual error server-side for debugging, but send a
 				// generic message to the user to prevent information disclosure.
-				fmt.Printf("[ERROR] Internal Server Error: %v\n", err)
+				logger.FromContext(c.Request.Context()).Error("Internal Server Error", "error", err)
 				response.Error(c, http.StatusInternalServerError, "An unexpected error occurred. Please try again later.", nil)
 			}
 		}
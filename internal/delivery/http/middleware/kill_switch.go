@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// killSwitchSubsystemRoutes maps each subsystem switch to the routes it takes down
+var killSwitchSubsystemRoutes = map[string][]string{
+	domain.KillSwitchUploads: {
+		"POST /v1/upload",
+	},
+	domain.KillSwitchExports: {
+		"GET /v1/admin/ats/export",
+		"GET /v1/employers/jobs/:jobId/applications/export",
+	},
+	domain.KillSwitchRegistration: {
+		"POST /v1/auth/register",
+	},
+}
+
+// KillSwitchMiddleware answers 503 for endpoints an admin has switched off, either
+// directly ("METHOD /v1/route") or through their subsystem. Lookups hit the
+// usecase's in-memory snapshot only.
+func KillSwitchMiddleware(killSwitchUC domain.KillSwitchUsecase) gin.HandlerFunc {
+	subsystemsByRoute := map[string][]string{}
+	for subsystem, routes := range killSwitchSubsystemRoutes {
+		for _, route := range routes {
+			subsystemsByRoute[route] = append(subsystemsByRoute[route], subsystem)
+		}
+	}
+
+	return func(c *gin.Context) {
+		// Unmatched routes 404 as usual
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+
+		route := c.Request.Method + " " + c.FullPath()
+		for _, key := range append([]string{route}, subsystemsByRoute[route]...) {
+			if s, ok := killSwitchUC.ActiveSwitch(key); ok {
+				abortForMaintenance(c, s)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+func abortForMaintenance(c *gin.Context, s *domain.KillSwitch) {
+	message := "This feature is temporarily unavailable for maintenance"
+	if s.Message != nil {
+		message = *s.Message
+	}
+	if s.ReenableAt != nil {
+		retryAfter := int(math.Ceil(time.Until(*s.ReenableAt).Seconds()))
+		c.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+	}
+
+	response.ErrorWithCode(c, http.StatusServiceUnavailable, domain.ErrCodeFeatureDisabled, message, gin.H{
+		"kill_switch": s.Key,
+		"reenable_at": s.ReenableAt,
+	})
+	c.Abort()
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"go-recruitment-backend/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware negotiates the response language.
+//
+// Precedence: ?lang= query → user preference (applied later by AuthMiddleware)
+// → Accept-Language. When nothing matches, messages are returned as written.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := ""
+		if lang := c.Query("lang"); i18n.IsSupported(lang) {
+			locale = lang
+		} else {
+			locale = i18n.Negotiate(c.GetHeader("Accept-Language"))
+		}
+
+		if locale != "" {
+			c.Set(i18n.ContextKey, locale)
+			c.Header("Content-Language", locale)
+		}
+		c.Next()
+	}
+}
+
+// applyUserLocale overrides the negotiated locale with the user's saved preference
+// unless the request explicitly asked for a language via ?lang=
+func applyUserLocale(c *gin.Context, preferred *string) {
+	if preferred == nil || !i18n.IsSupported(*preferred) {
+		return
+	}
+	if i18n.IsSupported(c.Query("lang")) {
+		return
+	}
+	c.Set(i18n.ContextKey, *preferred)
+	c.Header("Content-Language", *preferred)
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"go-recruitment-backend/pkg/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics records request count and latency per route template, so /jobs/1 and
+// /jobs/2 share one series; requests matching no route are labelled "unmatched".
+// It must run before Recovery so recovered panics are counted as 500s.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request.Method
+		metrics.HTTPRequests.Inc(method, route, strconv.Itoa(c.Writer.Status()))
+		metrics.HTTPRequestDuration.Observe(time.Since(start).Seconds(), method, route)
+	}
+}
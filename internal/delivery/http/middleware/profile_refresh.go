@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/logger"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// profileRefreshAllowedPrefixes stay open to candidates who must refresh their
+// profile: the wizard itself, sessions, and uploading replacement documents
+var profileRefreshAllowedPrefixes = []string{
+	"/v1/candidates/me/profile-refresh",
+	"/v1/candidates/me/documents",
+	"/v1/auth/",
+	"/v1/upload",
+}
+
+// ProfileRefreshGate holds candidates returning after a long absence at the
+// profile refresh wizard: until they confirm availability, salary and documents,
+// every write outside the wizard is answered with 428. Reads stay open so the
+// app can show the profile. Must run after AuthMiddleware and before
+// ActivityTracker, which overwrites the last activity the check relies on.
+func ProfileRefreshGate(candidateUC domain.CandidateUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(string(domain.KeyUserRole)) != domain.RoleCandidate {
+			c.Next()
+			return
+		}
+
+		required, err := candidateUC.ProfileRefreshRequired(c.Request.Context(), c.GetString(string(domain.KeyUserID)))
+		if err != nil {
+			// Fail open: a database hiccup must not lock candidates out
+			logger.FromContext(c.Request.Context()).Warn("Profile refresh check failed", "error", err)
+			c.Next()
+			return
+		}
+		if required {
+			c.Header("X-Profile-Refresh-Required", "true")
+			if !profileRefreshAllowed(c.Request.Method, c.Request.URL.Path) {
+				response.ErrorWithCode(c, http.StatusPreconditionRequired, domain.ErrCodeProfileRefreshRequired, "Please review your profile before continuing", gin.H{
+					"profile_refresh_required": true,
+				})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+func profileRefreshAllowed(method, path string) bool {
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+		return true
+	}
+	for _, prefix := range profileRefreshAllowedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
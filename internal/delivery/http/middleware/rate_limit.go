@@ -72,6 +72,15 @@ func startCleanup() {
 				entry.mu.Unlock()
 				return true
 			})
+			tokenBuckets.Range(func(key, value interface{}) bool {
+				bucket := value.(*tokenBucket)
+				bucket.mu.Lock()
+				if now.After(bucket.fullAt) {
+					tokenBuckets.Delete(key)
+				}
+				bucket.mu.Unlock()
+				return true
+			})
 		}
 	}()
 }
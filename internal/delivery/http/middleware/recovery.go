@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/errreport"
+	"go-recruitment-backend/pkg/logger"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery turns a panic into the standard 500 response and reports it with its
+// stack; 5xx responses are reported too. It replaces gin.Recovery, in the same
+// place: after Metrics, so panics are counted as 500s, and before RequestID and
+// auth, whose request and user IDs it reads once the handler has run.
+//
+// 503s are only reported when a handler attached an error: without one they are
+// deliberate load shedding (kill switch, upload slots, fail-closed rate limits).
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if clientGone(p) {
+				// Nothing to fix and nobody to answer
+				c.Abort()
+				return
+			}
+
+			logger.FromContext(c.Request.Context()).Error("Panic recovered",
+				"panic", p, "route", c.FullPath(), "stack", string(debug.Stack()))
+			if errreport.Enabled() {
+				errreport.Capture(c.Request.Context(), errreport.Event{
+					Level:     errreport.LevelFatal,
+					Message:   fmt.Sprint(p),
+					ErrorType: fmt.Sprintf("%T", p),
+					Stack:     errreport.Stack(1),
+					Request:   reportedRequest(c, http.StatusInternalServerError),
+				})
+			}
+
+			if !c.Writer.Written() {
+				response.Error(c, http.StatusInternalServerError, "An unexpected error occurred. Please try again later.", nil)
+			}
+			c.Abort()
+		}()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError || !errreport.Enabled() ||
+			(status == http.StatusServiceUnavailable && len(c.Errors) == 0) {
+			return
+		}
+		event := errreport.Event{Message: http.StatusText(status), Request: reportedRequest(c, status)}
+		if len(c.Errors) > 0 {
+			// The wrapped cause rather than the generic message sent to the client
+			err := c.Errors.Last().Err
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) && appErr.Err != nil {
+				err = appErr.Err
+			}
+			event.Message, event.ErrorType = err.Error(), fmt.Sprintf("%T", err)
+		}
+		errreport.Capture(c.Request.Context(), event)
+	}
+}
+
+func reportedRequest(c *gin.Context, status int) *errreport.Request {
+	return &errreport.Request{
+		Method:    c.Request.Method,
+		Path:      c.Request.URL.Path,
+		Route:     c.FullPath(),
+		Status:    status,
+		RequestID: c.GetString("RequestID"),
+		TraceID:   c.Writer.Header().Get("X-Trace-ID"),
+		UserID:    c.GetString(string(domain.KeyUserID)),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// clientGone reports a panic caused by the client going away: the abort
+// sentinel of net/http, or a write to a closed connection
+func clientGone(p interface{}) bool {
+	err, ok := p.(error)
+	if !ok {
+		return false
+	}
+	return errors.Is(err, http.ErrAbortHandler) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"go-recruitment-backend/pkg/logger"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -10,6 +12,8 @@ func RequestID() gin.HandlerFunc {
 		id := uuid.New().String()
 		c.Set("RequestID", id)
 		c.Writer.Header().Set("X-Request-ID", id)
+		// Request-scoped logger: everything logged via logger.FromContext carries the ID
+		c.Request = c.Request.WithContext(logger.With(c.Request.Context(), "request_id", id))
 		c.Next()
 	}
 }
@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-recruitment-backend/config"
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/redis"
+
+	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RateLimitPolicy is a token bucket holding up to Limit requests, refilled
+// evenly over Per (5/min allows a burst of 5, then one request every 12s).
+// Each client IP gets its own bucket unless KeyFunc says otherwise.
+type RateLimitPolicy struct {
+	// Name namespaces the buckets, e.g. "login"
+	Name  string
+	Limit int
+	Per   time.Duration
+	// Custom key extractor (default: client IP)
+	KeyFunc func(*gin.Context) string
+	// Whether to reject when Redis errors instead of using the in-memory bucket
+	FailClosed bool
+}
+
+// RouteRateLimits maps gin routes ("POST /v1/auth/login") to their policy
+type RouteRateLimits map[string]RateLimitPolicy
+
+// SensitiveRouteRateLimits returns the per-IP policies for abuse-prone public endpoints
+func SensitiveRouteRateLimits(cfg *config.Config) RouteRateLimits {
+	return RouteRateLimits{
+		"POST /v1/auth/login":           {Name: "login", Limit: cfg.RateLimitLogin.Limit, Per: cfg.RateLimitLogin.Per, FailClosed: true},
+		"POST /v1/auth/forgot-password": {Name: "forgot", Limit: cfg.RateLimitForgotPassword.Limit, Per: cfg.RateLimitForgotPassword.Per, FailClosed: true},
+		"POST /v1/contact":              {Name: "contact", Limit: cfg.RateLimitContact.Limit, Per: cfg.RateLimitContact.Per},
+		"POST /v1/upload":               {Name: "upload", Limit: cfg.RateLimitUpload.Limit, Per: cfg.RateLimitUpload.Per},
+		// Partner systems share an IP across keys, so their bucket follows the API key
+		"GET /v1/partners/lpk/placements": {Name: "lpk-placements", Limit: cfg.RateLimitPartnerAPI.Limit, Per: cfg.RateLimitPartnerAPI.Per, KeyFunc: partnerAPIKey},
+	}
+}
+
+// partnerAPIKey keys a bucket by a hash of the request's API key (never the key
+// itself, which would end up in Redis), or by client IP without one
+func partnerAPIKey(c *gin.Context) string {
+	key := c.GetHeader(domain.LPKAPIKeyHeader)
+	if key == "" {
+		return c.ClientIP()
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "key:" + hex.EncodeToString(sum[:16])
+}
+
+// tokenBucket is the in-memory bucket state (used when Redis is unavailable)
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	fullAt  time.Time // when the bucket has refilled and can be dropped
+	mu      sync.Mutex
+}
+
+var tokenBuckets = sync.Map{}
+
+// Lua script for an atomic token bucket
+// KEYS[1] = bucket key
+// ARGV[1] = capacity
+// ARGV[2] = refill rate in tokens per millisecond
+// ARGV[3] = now in unix milliseconds
+// Returns: [allowed (0/1), tokens_left as string]
+const tokenBucketLuaScript = `
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+    tokens = tokens - 1
+    allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`
+
+// RateLimit applies the policy of the matched route; other routes pass through.
+// Register it globally: the route pattern is already known when it runs.
+// Uses Redis when available so limits hold across instances, in-memory otherwise.
+func RateLimit(routes RouteRateLimits) gin.HandlerFunc {
+	// Start cleanup goroutine once (for fallback)
+	cleanupOnce.Do(startCleanup)
+
+	return func(c *gin.Context) {
+		policy, ok := routes[c.Request.Method+" "+c.FullPath()]
+		if !ok || policy.Limit <= 0 || policy.Per <= 0 {
+			c.Next()
+			return
+		}
+
+		key := c.ClientIP()
+		if policy.KeyFunc != nil {
+			key = policy.KeyFunc(c)
+		}
+		fullKey := "rl:tb:" + policy.Name + ":" + key
+		now := time.Now()
+
+		var allowed bool
+		var tokens float64
+		var err error
+
+		// Try Redis first
+		redisClient := redis.Client()
+		if redisClient != nil {
+			allowed, tokens, err = takeTokenRedis(c.Request.Context(), redisClient, fullKey, policy, now)
+			if err != nil {
+				// Redis error - use fallback or fail based on policy
+				if policy.FailClosed {
+					logRateLimitError(c, "redis_error", err)
+					response.Error(c, http.StatusServiceUnavailable, "Service temporarily unavailable. Please try again.", nil)
+					c.Abort()
+					return
+				}
+				allowed, tokens = takeTokenInMemory(fullKey, policy, now)
+			}
+		} else {
+			allowed, tokens = takeTokenInMemory(fullKey, policy, now)
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(policy.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(int(tokens)))
+
+		if !allowed {
+			// Time until one whole token has refilled
+			retryAfter := int(math.Ceil((1 - tokens) * policy.Per.Seconds() / float64(policy.Limit)))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+			logRateLimitTriggered(c)
+
+			response.Error(c, http.StatusTooManyRequests, "Rate limit exceeded. Please try again later.", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// takeTokenRedis takes one token from the Redis bucket
+func takeTokenRedis(ctx context.Context, client *goredis.Client, key string, policy RateLimitPolicy, now time.Time) (bool, float64, error) {
+	ratePerMs := float64(policy.Limit) / float64(policy.Per.Milliseconds())
+
+	result, err := client.Eval(ctx, tokenBucketLuaScript, []string{key}, policy.Limit, ratePerMs, now.UnixMilli()).Result()
+	if err != nil {
+		return false, 0, fmt.Errorf("redis token bucket eval failed: %w", err)
+	}
+
+	// Parse result [allowed, tokens]
+	arr, ok := result.([]interface{})
+	if !ok || len(arr) < 2 {
+		return false, 0, fmt.Errorf("unexpected redis result format")
+	}
+
+	allowed, _ := arr[0].(int64)
+	tokensStr, _ := arr[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return false, 0, fmt.Errorf("unexpected redis token count %q", tokensStr)
+	}
+
+	return allowed == 1, tokens, nil
+}
+
+// takeTokenInMemory takes one token from the in-memory bucket (fallback)
+func takeTokenInMemory(key string, policy RateLimitPolicy, now time.Time) (bool, float64) {
+	entryI, _ := tokenBuckets.LoadOrStore(key, &tokenBucket{
+		tokens:  float64(policy.Limit),
+		updated: now,
+	})
+	bucket := entryI.(*tokenBucket)
+
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+
+	// Refill for the time elapsed since the last request
+	ratePerSec := float64(policy.Limit) / policy.Per.Seconds()
+	if elapsed := now.Sub(bucket.updated).Seconds(); elapsed > 0 {
+		bucket.tokens = math.Min(float64(policy.Limit), bucket.tokens+elapsed*ratePerSec)
+	}
+	bucket.updated = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+	bucket.fullAt = now.Add(time.Duration((float64(policy.Limit) - bucket.tokens) / ratePerSec * float64(time.Second)))
+
+	return allowed, bucket.tokens
+}
@@ -2,20 +2,39 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 
 	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/pkg/i18n"
 	"go-recruitment-backend/pkg/security"
 
 	"github.com/gin-gonic/gin"
 )
 
+// SecuritySessionCookieName is the HttpOnly cookie holding the security dashboard session token
+const SecuritySessionCookieName = "security_session"
+
 // SecurityMiddlewareConfig holds configuration for security middleware
 type SecurityMiddlewareConfig struct {
 	AuthService *security.SecurityAuthService
 	Logger      *security.SecurityLogger
 }
 
+// SecurityLocaleMiddleware limits the negotiated locale to the dashboard's
+// languages (security.DashboardLocales); anything else falls back to English,
+// the language its messages are written in. SecurityAuthMiddleware later
+// applies the operator's saved preference.
+func SecurityLocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !slices.Contains(security.DashboardLocales, c.GetString(i18n.ContextKey)) {
+			c.Set(i18n.ContextKey, i18n.LocaleEN)
+			c.Header("Content-Language", i18n.LocaleEN)
+		}
+		c.Next()
+	}
+}
+
 // SecurityIPAllowlistMiddleware validates that the request IP is in the allowed list
 // This is the PRIMARY security control for the security dashboard
 // The hidden route provides ZERO security guarantees - this is the real gate
@@ -70,11 +89,66 @@ func SecurityAuthMiddleware(authService *security.SecurityAuthService) gin.Handl
 		c.Set("security_user", user)
 		c.Set("security_session", session)
 		c.Set("security_role", user.Role)
+		c.Set("security_csrf_token", security.SessionCSRFToken(token))
+		applyUserLocale(c, user.PreferredLocale)
 
 		c.Next()
 	}
 }
 
+// SecurityCSRFMiddleware rejects state-changing requests whose X-CSRF-Token was
+// not issued for the session (synchronizer token, see security.SessionCSRFToken).
+// Only cookie sessions are checked: a browser attaches the cookie to cross-site
+// requests on its own, but a session sent in a header had to be set by the client.
+// Rejections are logged as csrf_violation security events.
+func SecurityCSRFMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		method := c.Request.Method
+		if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		sessionToken, err := c.Cookie(SecuritySessionCookieName)
+		if err != nil || sessionToken == "" {
+			c.Next()
+			return
+		}
+
+		headerToken := c.GetHeader(CSRFTokenHeaderName)
+		if security.ValidateCSRFToken(sessionToken, headerToken) {
+			c.Next()
+			return
+		}
+
+		reason := "invalid_token"
+		if headerToken == "" {
+			reason = "missing_token"
+		}
+		sessionID := ""
+		if s, ok := c.Get("security_session"); ok {
+			if session, ok := s.(*security.SecuritySession); ok {
+				sessionID = session.ID
+			}
+		}
+		security.DefaultLogger().Log(c.Request.Context(), security.SecurityEvent{
+			Event:        security.EventCSRFViolation,
+			SubjectType:  "session",
+			SubjectValue: sessionID,
+			IP:           c.GetString("client_ip"),
+			UserAgent:    c.GetHeader("User-Agent"),
+			Details: map[string]interface{}{
+				"reason":   reason,
+				"method":   method,
+				"endpoint": c.Request.URL.Path,
+				"origin":   c.GetHeader("Origin"),
+			},
+		})
+		response.Error(c, http.StatusForbidden, "Invalid CSRF token", nil)
+		c.Abort()
+	}
+}
+
 // SecurityRoleMiddleware enforces role-based access control
 // roles parameter lists minimum required roles (any match allows access)
 func SecurityRoleMiddleware(minRoles ...security.SecurityRole) gin.HandlerFunc {
@@ -226,7 +300,7 @@ func SecurityAuditMiddleware() gin.HandlerFunc {
 
 func extractSecurityToken(c *gin.Context) string {
 	// Try cookie first
-	if token, err := c.Cookie("security_session"); err == nil && token != "" {
+	if token, err := c.Cookie(SecuritySessionCookieName); err == nil && token != "" {
 		return token
 	}
 
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"fmt"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/tracing"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tracing starts a server span per request, continuing the caller's trace when
+// it sends a traceparent header. It must run after RequestID: the request ID is
+// attached to every span of the request and the trace ID to its log records.
+// The trace ID is returned in X-Trace-ID for support requests.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx := tracing.Extract(c.Request.Context(), c.Request.Header)
+		ctx = tracing.WithRequestID(ctx, c.GetString("RequestID"))
+		ctx, span := tracing.StartKind(ctx, c.Request.Method+" "+route, tracing.KindServer)
+		if span == nil {
+			c.Next()
+			return
+		}
+		defer span.End()
+
+		span.Set("http.request.method", c.Request.Method)
+		span.Set("http.route", route)
+		c.Writer.Header().Set("X-Trace-ID", span.TraceID())
+		c.Request = c.Request.WithContext(logger.With(ctx, "trace_id", span.TraceID()))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.Set("http.response.status_code", status)
+		if status >= http.StatusInternalServerError {
+			span.SetError(fmt.Errorf("%d %s", status, http.StatusText(status)))
+		}
+		if len(c.Errors) > 0 {
+			span.Set("error.message", c.Errors.Last().Error())
+		}
+	}
+}
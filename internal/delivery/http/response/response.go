@@ -3,17 +3,23 @@ package response
 import (
 	"strings"
 
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
 	"go-recruitment-backend/pkg/validation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
-// Response standardizes the API JSON response
+// Response standardizes the API JSON response. Failures carry a stable
+// machine-readable code (apperror.Code*, domain.ErrCode*) and optional details;
+// error repeats the details for older clients.
 type Response struct {
 	Success   bool        `json:"success"`
+	Code      string      `json:"code,omitempty"`
 	Message   string      `json:"message"`
 	Data      interface{} `json:"data,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
 	Error     interface{} `json:"error,omitempty"`
 	RequestID string      `json:"request_id,omitempty"`
 }
@@ -25,21 +31,28 @@ func Success(c *gin.Context, code int, message string, data interface{}) {
 
 	c.JSON(code, Response{
 		Success:   true,
-		Message:   message,
+		Message:   translate(c, message),
 		Data:      data,
 		RequestID: idStr,
 	})
 }
 
-// Error sends an error response
+// Error sends an error response with the generic code of the status
 func Error(c *gin.Context, code int, message string, err interface{}) {
+	ErrorWithCode(c, code, apperror.CodeForStatus(code), message, err)
+}
+
+// ErrorWithCode sends an error response with a specific machine-readable code
+func ErrorWithCode(c *gin.Context, status int, code, message string, details interface{}) {
 	reqID, _ := c.Get("RequestID")
 	idStr, _ := reqID.(string)
 
-	c.JSON(code, Response{
+	c.JSON(status, Response{
 		Success:   false,
-		Message:   message,
-		Error:     err,
+		Code:      code,
+		Message:   translate(c, message),
+		Details:   details,
+		Error:     details,
 		RequestID: idStr,
 	})
 }
@@ -55,7 +68,9 @@ func ValidationError(c *gin.Context, err error) {
 		messages := validation.FormatValidationErrors(validationErrs)
 		c.JSON(400, Response{
 			Success:   false,
-			Message:   "Validasi gagal: " + strings.Join(messages, "; "),
+			Code:      apperror.CodeValidationFailed,
+			Message:   translate(c, "Validasi gagal: ") + strings.Join(messages, "; "),
+			Details:   messages,
 			Error:     messages,
 			RequestID: idStr,
 		})
@@ -65,8 +80,15 @@ func ValidationError(c *gin.Context, err error) {
 	// Fallback for non-validation errors (e.g., JSON parse errors)
 	c.JSON(400, Response{
 		Success:   false,
-		Message:   "Data tidak valid: " + err.Error(),
+		Code:      apperror.CodeBadRequest,
+		Message:   translate(c, "Data tidak valid: ") + err.Error(),
+		Details:   err.Error(),
 		Error:     err.Error(),
 		RequestID: idStr,
 	})
 }
+
+// translate localizes message using the locale negotiated by LocaleMiddleware
+func translate(c *gin.Context, message string) string {
+	return i18n.T(c.GetString(i18n.ContextKey), message)
+}
@@ -1,13 +1,19 @@
 package security
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"go-recruitment-backend/internal/delivery/http/middleware"
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
 	"go-recruitment-backend/pkg/security"
 
 	"github.com/gin-gonic/gin"
@@ -15,15 +21,31 @@ import (
 
 // SecurityDashboardHandler handles HTTP requests for the security dashboard
 type SecurityDashboardHandler struct {
-	usecase     domain.SecurityDashboardUsecase
-	authService *security.SecurityAuthService
+	usecase        domain.SecurityDashboardUsecase
+	authService    *security.SecurityAuthService
+	cookieSameSite http.SameSite
 }
 
-// NewSecurityDashboardHandler creates a new security dashboard handler
-func NewSecurityDashboardHandler(usecase domain.SecurityDashboardUsecase, authService *security.SecurityAuthService) *SecurityDashboardHandler {
+// NewSecurityDashboardHandler creates a new security dashboard handler. sameSite
+// is the SameSite mode of the session cookie: strict, lax or none (only for a
+// dashboard served from another site).
+func NewSecurityDashboardHandler(usecase domain.SecurityDashboardUsecase, authService *security.SecurityAuthService, sameSite string) *SecurityDashboardHandler {
 	return &SecurityDashboardHandler{
-		usecase:     usecase,
-		authService: authService,
+		usecase:        usecase,
+		authService:    authService,
+		cookieSameSite: parseSameSite(sameSite),
+	}
+}
+
+// parseSameSite maps the configured SameSite mode, defaulting to Strict
+func parseSameSite(mode string) http.SameSite {
+	switch strings.ToLower(mode) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
 	}
 }
 
@@ -33,6 +55,7 @@ func NewSecurityDashboardHandler(usecase domain.SecurityDashboardUsecase, authSe
 // Real security: IP Allowlist → MFA Auth → RBAC → Audit Log
 func (h *SecurityDashboardHandler) RegisterRoutes(router *gin.RouterGroup) {
 	// All routes require IP allowlist + auth
+	router.Use(middleware.SecurityLocaleMiddleware()) // en/id only; the operator's preference applies once authenticated
 	router.Use(middleware.SecurityIPAllowlistMiddleware(h.authService))
 	router.Use(middleware.SecurityAuditMiddleware())
 
@@ -45,10 +68,19 @@ func (h *SecurityDashboardHandler) RegisterRoutes(router *gin.RouterGroup) {
 		auth.POST("/confirm-totp", h.ConfirmTOTPSetup) // Verify and enable TOTP
 	}
 
+	// Account settings (session required; observers may change their own language)
+	account := router.Group("/auth/me")
+	account.Use(middleware.SecurityAuthMiddleware(h.authService))
+	account.Use(middleware.SecurityCSRFMiddleware())
+	{
+		account.PUT("/locale", h.UpdateLocale)
+	}
+
 	// Protected routes (session required)
 	protected := router.Group("")
 	protected.Use(middleware.SecurityAuthMiddleware(h.authService))
 	protected.Use(middleware.ReadOnlyModeMiddleware())
+	protected.Use(middleware.SecurityCSRFMiddleware()) // state-changing requests need the session's X-CSRF-Token
 	{
 		// Read-only routes (OBSERVER+)
 		protected.GET("/auth/me", h.GetCurrentUser) // Get current authenticated user
@@ -57,6 +89,8 @@ func (h *SecurityDashboardHandler) RegisterRoutes(router *gin.RouterGroup) {
 		protected.GET("/heatmap", h.GetHeatmap)
 		protected.GET("/timeline", h.GetTimeline)
 		protected.GET("/integrity/status", h.GetIntegrityStatus)
+		protected.GET("/integrity/history", h.GetIntegrityHistory)
+		protected.GET("/alerts", h.ListAlerts) // Anomaly detection alerts
 		protected.POST("/logout", h.Logout)
 
 		// Analyst routes (ANALYST+)
@@ -66,6 +100,10 @@ func (h *SecurityDashboardHandler) RegisterRoutes(router *gin.RouterGroup) {
 			analyst.POST("/export/request", h.RequestExport)
 			analyst.GET("/export/:id", h.GetExportRequest)
 			analyst.GET("/export/:id/download", h.DownloadExport)
+			analyst.POST("/export/:id/artifact", h.RequestExportArtifact) // Queue worker-generated file (large ranges)
+			analyst.GET("/export/:id/artifact", h.GetExportArtifact)      // Poll status / get signed URL
+			analyst.POST("/alerts/:id/acknowledge", h.AcknowledgeAlert)
+			analyst.POST("/alerts/:id/resolve", h.ResolveAlert)
 		}
 
 		// Admin routes (ADMIN only)
@@ -78,6 +116,9 @@ func (h *SecurityDashboardHandler) RegisterRoutes(router *gin.RouterGroup) {
 			admin.POST("/break-glass/activate", h.ActivateBreakGlass)
 			admin.GET("/break-glass/status", h.GetBreakGlassStatus)
 			admin.POST("/break-glass/revoke", h.RevokeBreakGlass)
+			admin.GET("/break-glass/pending", h.ListPendingBreakGlass)  // Dual approval: requests from other admins
+			admin.POST("/break-glass/:id/approve", h.ApproveBreakGlass) // Second admin activates the session
+			admin.POST("/break-glass/:id/deny", h.DenyBreakGlass)
 			admin.POST("/integrity/verify", h.VerifyIntegrity)
 		}
 	}
@@ -114,16 +155,46 @@ func (h *SecurityDashboardHandler) GetCurrentUser(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "User retrieved", gin.H{
 		"user": gin.H{
-			"id":       u.ID,
-			"username": u.Username,
-			"email":    u.Email,
-			"role":     u.Role,
+			"id":              u.ID,
+			"username":        u.Username,
+			"email":           u.Email,
+			"role":            u.Role,
+			"preferredLocale": u.PreferredLocale,
 		},
 		"sessionId": s.ID,
 		"expiresAt": s.ExpiresAt,
+		"csrfToken": c.GetString("security_csrf_token"), // lets a reloaded page recover its token
 	})
 }
 
+// UpdateLocale sets the operator's dashboard language (en or id); an empty
+// locale clears it so Accept-Language applies again
+func (h *SecurityDashboardHandler) UpdateLocale(c *gin.Context) {
+	var req struct {
+		Locale string `json:"locale" binding:"omitempty,oneof=en id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	user := c.MustGet("security_user").(*security.SecurityUser)
+
+	if err := h.authService.SetPreferredLocale(c.Request.Context(), user.ID, req.Locale); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to update language", nil)
+		return
+	}
+
+	// Respond in the newly chosen language
+	locale := req.Locale
+	if locale == "" {
+		locale = i18n.LocaleEN
+	}
+	c.Set(i18n.ContextKey, locale)
+	c.Header("Content-Language", locale)
+	response.Success(c, http.StatusOK, "Preferred language updated", gin.H{"locale": req.Locale})
+}
+
 // Login handles initial username/password authentication
 func (h *SecurityDashboardHandler) Login(c *gin.Context) {
 	var req struct {
@@ -193,23 +264,25 @@ func (h *SecurityDashboardHandler) VerifyTOTP(c *gin.Context) {
 		return
 	}
 
-	// Set session cookie
-	// Set session cookie with SameSite=None for Cross-Origin support
+	// Set session cookie (SameSite from SECURITY_COOKIE_SAMESITE; None only for a cross-site dashboard)
 	http.SetCookie(c.Writer, &http.Cookie{
-		Name:     "security_session",
+		Name:     middleware.SecuritySessionCookieName,
 		Value:    token,
 		Path:     "/",
 		Domain:   "",
 		Expires:  session.ExpiresAt,
 		Secure:   true,
 		HttpOnly: true,
-		SameSite: http.SameSiteNoneMode,
+		SameSite: h.cookieSameSite,
 	})
 
+	// The CSRF token goes in the body, not a cookie, so a dashboard on another
+	// origin can read it; it must be sent as X-CSRF-Token on state-changing requests
 	response.Success(c, http.StatusOK, "Authentication successful", gin.H{
 		"sessionId": session.ID,
 		"expiresAt": session.ExpiresAt,
 		"role":      user.Role,
+		"csrfToken": security.SessionCSRFToken(token),
 	})
 }
 
@@ -232,16 +305,15 @@ func (h *SecurityDashboardHandler) Logout(c *gin.Context) {
 		return
 	}
 
-	// Clear cookie
 	// Clear cookie
 	http.SetCookie(c.Writer, &http.Cookie{
-		Name:     "security_session",
+		Name:     middleware.SecuritySessionCookieName,
 		Value:    "",
 		MaxAge:   -1,
 		Path:     "/",
 		Secure:   true,
 		HttpOnly: true,
-		SameSite: http.SameSiteNoneMode,
+		SameSite: h.cookieSameSite,
 	})
 
 	response.Success(c, http.StatusOK, "Logged out successfully", nil)
@@ -294,7 +366,7 @@ func (h *SecurityDashboardHandler) SetupTOTP(c *gin.Context) {
 		"userId":     user.ID,
 		"username":   user.Username,
 		"issuer":     "J-Expert Security",
-		"setupGuide": "Scan QR code with Google Authenticator, Authy, or 1Password. Then confirm with a code.",
+		"setupGuide": i18n.T(c.GetString(i18n.ContextKey), "Scan QR code with Google Authenticator, Authy, or 1Password. Then confirm with a code."),
 	})
 }
 
@@ -333,7 +405,7 @@ func (h *SecurityDashboardHandler) ConfirmTOTPSetup(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "TOTP berhasil diaktifkan! Silakan login kembali.", gin.H{
+	response.Success(c, http.StatusOK, "TOTP enabled. Please log in again.", gin.H{
 		"enabled":  true,
 		"username": user.Username,
 	})
@@ -348,6 +420,7 @@ func (h *SecurityDashboardHandler) GetStats(c *gin.Context) {
 		response.Error(c, http.StatusInternalServerError, "Failed to get stats", nil)
 		return
 	}
+	localizeStats(c, stats)
 	response.Success(c, http.StatusOK, "Stats retrieved", stats)
 }
 
@@ -384,12 +457,22 @@ func (h *SecurityDashboardHandler) ListEvents(c *gin.Context) {
 	if user := c.Query("user"); user != "" {
 		filter.SearchUser = user
 	}
+	if classes := c.Query("deviceClass"); classes != "" {
+		for _, class := range strings.Split(classes, ",") {
+			if !slices.Contains(security.DeviceClasses, class) {
+				response.Error(c, http.StatusBadRequest, "Invalid device class", nil)
+				return
+			}
+			filter.DeviceClasses = append(filter.DeviceClasses, class)
+		}
+	}
 
 	events, total, err := h.usecase.ListEvents(c.Request.Context(), filter)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "Failed to list events", nil)
 		return
 	}
+	localizeEvents(c, events)
 
 	response.Success(c, http.StatusOK, "Events retrieved", gin.H{
 		"events": events,
@@ -471,6 +554,7 @@ func (h *SecurityDashboardHandler) RequestExport(c *gin.Context) {
 		response.Error(c, http.StatusInternalServerError, "Failed to create export request", nil)
 		return
 	}
+	export.StatusLabel = label(c, export.Status)
 
 	response.Success(c, http.StatusCreated, "Export request created", export)
 }
@@ -489,12 +573,23 @@ func (h *SecurityDashboardHandler) ListPendingExports(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Pending exports listed", gin.H{"exports": []interface{}{}})
 }
 
-// ApproveExport approves an export request (admin only)
+// ApproveExport approves an export request (admin only). An optional body narrows
+// the approved scope: a shorter date range, a subset of event types, redacted IPs.
 func (h *SecurityDashboardHandler) ApproveExport(c *gin.Context) {
 	exportID := c.Param("id")
 	user := c.MustGet("security_user").(*security.SecurityUser)
 
-	if err := h.usecase.ApproveExport(c.Request.Context(), exportID, user.ID); err != nil {
+	var scope domain.ApproveExportScope
+	if err := c.ShouldBindJSON(&scope); err != nil && !errors.Is(err, io.EOF) {
+		response.ValidationError(c, err)
+		return
+	}
+
+	if err := h.usecase.ApproveExport(c.Request.Context(), exportID, user.ID, scope); err != nil {
+		if errors.Is(err, domain.ErrInvalidExportScope) {
+			response.Error(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
 		response.Error(c, http.StatusInternalServerError, "Failed to approve export", nil)
 		return
 	}
@@ -524,17 +619,53 @@ func (h *SecurityDashboardHandler) RejectExport(c *gin.Context) {
 }
 
 // DownloadExport streams the approved export data
+// ?format=json (default), csv, xlsx, jsonl or parquet. csv, xlsx and jsonl are
+// read and written in chunks and end with a watermark naming the export and the
+// downloader. Ranges too large for direct download return 413 and must use the
+// artifact endpoints instead.
 func (h *SecurityDashboardHandler) DownloadExport(c *gin.Context) {
 	exportID := c.Param("id")
 	user := c.MustGet("security_user").(*security.SecurityUser)
+	format := c.DefaultQuery("format", domain.ExportFormatJSON)
+	if !isExportFormat(format) && !slices.Contains(domain.StreamExportFormats, format) {
+		response.Error(c, http.StatusBadRequest, "Invalid export format", nil)
+		return
+	}
+
+	if slices.Contains(domain.StreamExportFormats, format) {
+		stream, err := h.usecase.StreamExport(c.Request.Context(), exportID, user.ID, format)
+		if err != nil {
+			respondExportError(c, err, "Failed to get export data")
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename="+stream.Filename)
+		c.Header("Content-Type", stream.ContentType)
+		c.Header("Cache-Control", "no-store")
+		c.Status(http.StatusOK)
+		// Headers are sent once writing starts; a failure past that point can only be logged
+		if err := stream.Write(c.Writer); err != nil {
+			logger.FromContext(c.Request.Context()).Error("Security export download aborted", "export_id", exportID, "format", format, "error", err)
+		}
+		return
+	}
+
+	if format != domain.ExportFormatJSON {
+		file, err := h.usecase.GetExportFile(c.Request.Context(), exportID, user.ID, format)
+		if err != nil {
+			respondExportError(c, err, "Failed to get export data")
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename="+file.Filename)
+		c.Data(http.StatusOK, file.ContentType, file.Data)
+		return
+	}
 
 	events, err := h.usecase.GetExportData(c.Request.Context(), exportID, user.ID)
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to get export data", nil)
+		respondExportError(c, err, "Failed to get export data")
 		return
 	}
 
-	// Return as JSON for now - could be CSV/Excel
 	c.JSON(http.StatusOK, gin.H{
 		"exportId": exportID,
 		"events":   events,
@@ -542,6 +673,71 @@ func (h *SecurityDashboardHandler) DownloadExport(c *gin.Context) {
 	})
 }
 
+// RequestExportArtifact queues generation of an export file (?format=, default parquet)
+func (h *SecurityDashboardHandler) RequestExportArtifact(c *gin.Context) {
+	exportID := c.Param("id")
+	user := c.MustGet("security_user").(*security.SecurityUser)
+	format := c.DefaultQuery("format", domain.ExportFormatParquet)
+	if !isExportFormat(format) {
+		response.Error(c, http.StatusBadRequest, "Invalid export format", nil)
+		return
+	}
+
+	artifact, err := h.usecase.RequestExportArtifact(c.Request.Context(), exportID, user.ID, format)
+	if err != nil {
+		respondExportError(c, err, "Failed to queue export")
+		return
+	}
+	artifact.StatusLabel = label(c, artifact.Status)
+
+	response.Success(c, http.StatusAccepted, "Export queued", artifact)
+}
+
+// GetExportArtifact returns artifact status and, once ready, a short-lived signed URL
+func (h *SecurityDashboardHandler) GetExportArtifact(c *gin.Context) {
+	exportID := c.Param("id")
+	user := c.MustGet("security_user").(*security.SecurityUser)
+	format := c.DefaultQuery("format", domain.ExportFormatParquet)
+	if !isExportFormat(format) {
+		response.Error(c, http.StatusBadRequest, "Invalid export format", nil)
+		return
+	}
+
+	artifact, err := h.usecase.GetExportArtifact(c.Request.Context(), exportID, user.ID, format)
+	if err != nil {
+		respondExportError(c, err, "Failed to get export status")
+		return
+	}
+	artifact.StatusLabel = label(c, artifact.Status)
+
+	response.Success(c, http.StatusOK, "Export status retrieved", artifact)
+}
+
+// isExportFormat reports whether format can be rendered as a whole file, which
+// artifacts require; csv, xlsx and jsonl downloads stream instead
+func isExportFormat(format string) bool {
+	switch format {
+	case domain.ExportFormatJSON, domain.ExportFormatCSV, domain.ExportFormatParquet:
+		return true
+	}
+	return false
+}
+
+// respondExportError maps the export errors callers can act on; anything else
+// is reported with the generic message so export details don't leak
+func respondExportError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrExportTooLarge):
+		response.Error(c, http.StatusRequestEntityTooLarge, "Export too large for direct download, use the artifact endpoint", nil)
+	case errors.Is(err, domain.ErrExportStorageDisabled):
+		response.Error(c, http.StatusServiceUnavailable, "Export storage is not configured", nil)
+	case errors.Is(err, domain.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "Export not generated yet", nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback, nil)
+	}
+}
+
 // === Break-Glass Handlers ===
 
 // ActivateBreakGlass activates a time-limited DEVELOPER_ROOT session
@@ -560,6 +756,10 @@ func (h *SecurityDashboardHandler) ActivateBreakGlass(c *gin.Context) {
 		return
 	}
 
+	if result.Status == security.BreakGlassPending {
+		response.Success(c, http.StatusAccepted, "Break-glass requested, awaiting a second security admin", result)
+		return
+	}
 	response.Success(c, http.StatusOK, "Break-glass activated", result)
 }
 
@@ -574,6 +774,18 @@ func (h *SecurityDashboardHandler) GetBreakGlassStatus(c *gin.Context) {
 	}
 
 	if result == nil {
+		pending, err := h.usecase.GetPendingBreakGlass(c.Request.Context(), user.ID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to get status", nil)
+			return
+		}
+		if pending != nil {
+			response.Success(c, http.StatusOK, "Break-glass awaiting approval", gin.H{
+				"active":  false,
+				"pending": pending,
+			})
+			return
+		}
 		response.Success(c, http.StatusOK, "No active break-glass session", gin.H{"active": false})
 		return
 	}
@@ -603,6 +815,64 @@ func (h *SecurityDashboardHandler) RevokeBreakGlass(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Break-glass revoked", nil)
 }
 
+// ListPendingBreakGlass lists other admins' break-glass requests awaiting approval
+func (h *SecurityDashboardHandler) ListPendingBreakGlass(c *gin.Context) {
+	user := c.MustGet("security_user").(*security.SecurityUser)
+
+	requests, err := h.usecase.ListPendingBreakGlass(c.Request.Context(), user.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to list break-glass requests", nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Pending break-glass requests", requests)
+}
+
+// ApproveBreakGlass activates another admin's break-glass request (two-person rule)
+func (h *SecurityDashboardHandler) ApproveBreakGlass(c *gin.Context) {
+	user := c.MustGet("security_user").(*security.SecurityUser)
+
+	result, err := h.usecase.ApproveBreakGlass(c.Request.Context(), c.Param("id"), user.ID)
+	if err != nil {
+		respondBreakGlassDecisionError(c, err, "Failed to approve break-glass")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Break-glass approved", result)
+}
+
+// DenyBreakGlass closes another admin's break-glass request
+func (h *SecurityDashboardHandler) DenyBreakGlass(c *gin.Context) {
+	var req domain.DenyBreakGlassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	user := c.MustGet("security_user").(*security.SecurityUser)
+
+	if err := h.usecase.DenyBreakGlass(c.Request.Context(), c.Param("id"), user.ID, req.Reason); err != nil {
+		respondBreakGlassDecisionError(c, err, "Failed to deny break-glass")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Break-glass denied", gin.H{"id": c.Param("id")})
+}
+
+// respondBreakGlassDecisionError maps the approval errors a co-approver can act on
+func respondBreakGlassDecisionError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, security.ErrSecurityRecordNotFound):
+		response.Error(c, http.StatusNotFound, "Break-glass request not found", nil)
+	case errors.Is(err, security.ErrBreakGlassSelfApproval):
+		response.Error(c, http.StatusForbidden, err.Error(), nil)
+	case errors.Is(err, security.ErrBreakGlassNotPending), errors.Is(err, security.ErrBreakGlassApprovalExpired):
+		response.Error(c, http.StatusConflict, err.Error(), nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback, nil)
+	}
+}
+
 // === Integrity Handlers ===
 
 // GetIntegrityStatus returns current log integrity status
@@ -614,8 +884,9 @@ func (h *SecurityDashboardHandler) GetIntegrityStatus(c *gin.Context) {
 	}
 
 	response.Success(c, http.StatusOK, "Integrity status", gin.H{
-		"status":     status,
-		"lastAnchor": lastAnchor,
+		"status":      status,
+		"statusLabel": label(c, status),
+		"lastAnchor":  lastAnchor,
 	})
 }
 
@@ -638,11 +909,156 @@ func (h *SecurityDashboardHandler) VerifyIntegrity(c *gin.Context) {
 		return
 	}
 
-	report, err := h.usecase.VerifyIntegrity(c.Request.Context(), startDate, endDate)
+	user := c.MustGet("security_user").(*security.SecurityUser)
+
+	report, err := h.usecase.VerifyIntegrity(c.Request.Context(), user.ID, startDate, endDate)
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "Verification failed", nil)
 		return
 	}
+	report.StatusLabel = label(c, report.Status)
 
 	response.Success(c, http.StatusOK, "Integrity verification complete", report)
 }
+
+// GetIntegrityHistory returns past scheduled and manual verification runs
+func (h *SecurityDashboardHandler) GetIntegrityHistory(c *gin.Context) {
+	page := 1
+	pageSize := 20
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if sizeStr := c.Query("pageSize"); sizeStr != "" {
+		if s, err := strconv.Atoi(sizeStr); err == nil && s > 0 && s <= 100 {
+			pageSize = s
+		}
+	}
+
+	runs, total, err := h.usecase.GetIntegrityHistory(c.Request.Context(), page, pageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to get integrity history", nil)
+		return
+	}
+	localizeRuns(c, runs)
+
+	response.Success(c, http.StatusOK, "Integrity history retrieved", gin.H{
+		"runs":     runs,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// === Alert Handlers ===
+
+// ListAlerts returns anomaly detection alerts, newest first
+func (h *SecurityDashboardHandler) ListAlerts(c *gin.Context) {
+	filter := domain.SecurityAlertFilter{
+		Limit:  50,
+		Offset: 0,
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 200 {
+			filter.Limit = l
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			filter.Offset = o
+		}
+	}
+	if statuses := c.Query("status"); statuses != "" {
+		for _, status := range strings.Split(strings.ToUpper(statuses), ",") {
+			if status != domain.SecurityAlertOpen && status != domain.SecurityAlertAcknowledged && status != domain.SecurityAlertResolved {
+				response.Error(c, http.StatusBadRequest, "Invalid alert status", nil)
+				return
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	if rule := c.Query("rule"); rule != "" {
+		if !slices.Contains(security.AnomalyRules, rule) {
+			response.Error(c, http.StatusBadRequest, "Invalid alert rule", nil)
+			return
+		}
+		filter.Rule = rule
+	}
+
+	alerts, total, err := h.usecase.ListAlerts(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to list alerts", nil)
+		return
+	}
+	for i := range alerts {
+		localizeAlert(c, &alerts[i])
+	}
+
+	response.Success(c, http.StatusOK, "Alerts retrieved", gin.H{
+		"alerts": alerts,
+		"total":  total,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+}
+
+// AcknowledgeAlert marks an open alert as being investigated
+func (h *SecurityDashboardHandler) AcknowledgeAlert(c *gin.Context) {
+	alertID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid alert ID", nil)
+		return
+	}
+
+	user := c.MustGet("security_user").(*security.SecurityUser)
+
+	alert, err := h.usecase.AcknowledgeAlert(c.Request.Context(), alertID, user.ID)
+	if err != nil {
+		respondAlertError(c, err, "Alert is already acknowledged or resolved", "Failed to acknowledge alert")
+		return
+	}
+	localizeAlert(c, alert)
+
+	response.Success(c, http.StatusOK, "Alert acknowledged", alert)
+}
+
+// ResolveAlert closes an open or acknowledged alert with a resolution note
+func (h *SecurityDashboardHandler) ResolveAlert(c *gin.Context) {
+	alertID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid alert ID", nil)
+		return
+	}
+
+	var req domain.ResolveSecurityAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	user := c.MustGet("security_user").(*security.SecurityUser)
+
+	alert, err := h.usecase.ResolveAlert(c.Request.Context(), alertID, user.ID, req.Resolution)
+	if err != nil {
+		respondAlertError(c, err, "Alert is already resolved", "Failed to resolve alert")
+		return
+	}
+	localizeAlert(c, alert)
+
+	response.Success(c, http.StatusOK, "Alert resolved", alert)
+}
+
+// respondAlertError maps alert workflow errors to HTTP responses
+func respondAlertError(c *gin.Context, err error, conflict, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		response.Error(c, http.StatusNotFound, "Alert not found", nil)
+	case errors.Is(err, domain.ErrSecurityAlertState):
+		response.Error(c, http.StatusConflict, conflict, nil)
+	default:
+		response.Error(c, http.StatusInternalServerError, fallback, nil)
+	}
+}
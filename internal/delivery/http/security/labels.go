@@ -0,0 +1,82 @@
+package security
+
+import (
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// statusLabels are the English display labels of the severity and status codes
+// the dashboard returns; codes stay in the response for filtering and logic,
+// labels are translated into the operator's language
+var statusLabels = map[string]string{
+	// Event and alert severities
+	string(security.SeverityINFO):     "Info",
+	string(security.SeverityMEDIUM):   "Medium",
+	string(security.SeverityWARN):     "Warning",
+	string(security.SeverityHIGH):     "High",
+	string(security.SeverityCRITICAL): "Critical",
+
+	// Alert workflow
+	domain.SecurityAlertOpen:         "Open",
+	domain.SecurityAlertAcknowledged: "Acknowledged",
+	domain.SecurityAlertResolved:     "Resolved",
+
+	// Export requests and artifacts
+	"pending":    "Pending",
+	"approved":   "Approved",
+	"rejected":   "Rejected",
+	"expired":    "Expired",
+	"processing": "Processing",
+	"ready":      "Ready",
+	"failed":     "Failed",
+
+	// Log integrity
+	"intact":      "Intact",
+	"degraded":    "Degraded",
+	"compromised": "Compromised",
+	"error":       "Error",
+
+	// Anchoring heartbeat
+	domain.AnchorHeartbeatOK:      "OK",
+	domain.AnchorHeartbeatStale:   "Stale",
+	domain.AnchorHeartbeatMissing: "Missing",
+}
+
+// label translates a severity or status code; unknown codes are returned as is
+func label(c *gin.Context, code string) string {
+	text, ok := statusLabels[code]
+	if !ok {
+		return code
+	}
+	return i18n.T(c.GetString(i18n.ContextKey), text)
+}
+
+func localizeStats(c *gin.Context, stats *domain.SecurityDashboardStats) {
+	stats.IntegrityStatusLabel = label(c, stats.IntegrityStatus)
+	for i := range stats.TopIPs {
+		stats.TopIPs[i].HighestSeverityLabel = label(c, stats.TopIPs[i].HighestSeverity)
+	}
+	if stats.AnchorHeartbeat != nil {
+		stats.AnchorHeartbeat.StatusLabel = label(c, stats.AnchorHeartbeat.Status)
+	}
+}
+
+func localizeEvents(c *gin.Context, events []domain.SecurityEventView) {
+	for i := range events {
+		events[i].SeverityLabel = label(c, events[i].Severity)
+	}
+}
+
+func localizeAlert(c *gin.Context, alert *domain.SecurityAlert) {
+	alert.SeverityLabel = label(c, alert.Severity)
+	alert.StatusLabel = label(c, alert.Status)
+}
+
+func localizeRuns(c *gin.Context, runs []domain.IntegrityVerificationRun) {
+	for i := range runs {
+		runs[i].StatusLabel = label(c, runs[i].Status)
+	}
+}
@@ -40,6 +40,25 @@ func NewAdminHandler(protected *gin.RouterGroup, adminUC domain.AdminUsecase) {
 	}
 }
 
+type DisableUserRequest struct {
+	Disable bool `json:"disable"`
+}
+
+type VerifyCompanyRequest struct {
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+type HideJobRequest struct {
+	Hide   bool   `json:"hide"`
+	Reason string `json:"reason"`
+}
+
+type FlagJobRequest struct {
+	Flag   bool   `json:"flag"`
+	Reason string `json:"reason"`
+}
+
 // GetStats godoc
 // @Summary      Get admin dashboard statistics
 // @Description  Returns counts for users, companies, jobs, and applications
@@ -102,9 +121,7 @@ func (h *AdminHandler) DisableUser(c *gin.Context) {
 		return
 	}
 
-	var body struct {
-		Disable bool `json:"disable"`
-	}
+	var body DisableUserRequest
 	if err := c.ShouldBindJSON(&body); err != nil {
 		response.ValidationError(c, err)
 		return
@@ -246,10 +263,7 @@ func (h *AdminHandler) VerifyCompany(c *gin.Context) {
 		return
 	}
 
-	var body struct {
-		Action string `json:"action"`
-		Reason string `json:"reason"`
-	}
+	var body VerifyCompanyRequest
 	if err := c.ShouldBindJSON(&body); err != nil {
 		response.ValidationError(c, err)
 		return
@@ -308,10 +322,7 @@ func (h *AdminHandler) HideJob(c *gin.Context) {
 		return
 	}
 
-	var body struct {
-		Hide   bool   `json:"hide"`
-		Reason string `json:"reason"`
-	}
+	var body HideJobRequest
 	if err := c.ShouldBindJSON(&body); err != nil {
 		response.ValidationError(c, err)
 		return
@@ -345,10 +356,7 @@ func (h *AdminHandler) FlagJob(c *gin.Context) {
 		return
 	}
 
-	var body struct {
-		Flag   bool   `json:"flag"`
-		Reason string `json:"reason"`
-	}
+	var body FlagJobRequest
 	if err := c.ShouldBindJSON(&body); err != nil {
 		response.ValidationError(c, err)
 		return
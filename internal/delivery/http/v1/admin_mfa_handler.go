@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminMFAHandler struct {
+	mfaUC domain.AdminMFAUsecase
+}
+
+// NewAdminMFAHandler registers admin two-factor enrollment, verification and reset routes
+func NewAdminMFAHandler(protected *gin.RouterGroup, mfaUC domain.AdminMFAUsecase) {
+	handler := &AdminMFAHandler{mfaUC: mfaUC}
+
+	// Reachable before the session passed TOTP (see middleware.AuthMiddleware)
+	mfa := protected.Group("/auth/mfa")
+	{
+		mfa.GET("/status", handler.Status)
+		mfa.POST("/enroll", handler.Enroll)
+		mfa.POST("/enroll/confirm", handler.ConfirmEnrollment)
+		mfa.POST("/verify", handler.Verify)
+	}
+
+	// Admin: reset another admin's enrollment after a lost device
+	protected.POST("/admin/users/:id/mfa/reset", handler.Reset)
+}
+
+// Status godoc
+// @Summary      Two-factor status
+// @Description  Whether the admin has enrolled TOTP and whether the current session is verified
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.MFAStatus}
+// @Failure      403  {object}  response.Response
+// @Router       /auth/mfa/status [get]
+func (h *AdminMFAHandler) Status(c *gin.Context) {
+	status, err := h.mfaUC.Status(c.Request.Context(), c.GetString(string(domain.KeyUserID)), c.GetString(string(domain.KeySessionID)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Two-factor status retrieved", status)
+}
+
+// Enroll godoc
+// @Summary      Start two-factor enrollment
+// @Description  Generates a TOTP secret for an authenticator app. Calling it again before confirming replaces the secret.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.MFAEnrollment}
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /auth/mfa/enroll [post]
+func (h *AdminMFAHandler) Enroll(c *gin.Context) {
+	enrollment, err := h.mfaUC.Enroll(c.Request.Context(), c.GetString(string(domain.KeyUserID)), c.GetString(string(domain.KeyUserEmail)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Two-factor enrollment started", enrollment)
+}
+
+// ConfirmEnrollment godoc
+// @Summary      Confirm two-factor enrollment
+// @Description  Enables TOTP with a code from the authenticator app; the current session counts as verified
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.MFACodeRequest  true  "TOTP code"
+// @Success      200      {object}  response.Response{data=domain.MFAStatus}
+// @Failure      400      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Failure      429      {object}  response.Response
+// @Router       /auth/mfa/enroll/confirm [post]
+func (h *AdminMFAHandler) ConfirmEnrollment(c *gin.Context) {
+	var req domain.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	status, err := h.mfaUC.ConfirmEnrollment(c.Request.Context(), c.GetString(string(domain.KeyUserID)), c.GetString(string(domain.KeySessionID)), req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Two-factor authentication enabled", status)
+}
+
+// Verify godoc
+// @Summary      Verify two-factor code
+// @Description  Verifies the current session with a TOTP code; admin routes are refused until then
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.MFACodeRequest  true  "TOTP code"
+// @Success      200      {object}  response.Response{data=domain.MFAStatus}
+// @Failure      400      {object}  response.Response
+// @Failure      429      {object}  response.Response
+// @Router       /auth/mfa/verify [post]
+func (h *AdminMFAHandler) Verify(c *gin.Context) {
+	var req domain.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	status, err := h.mfaUC.Verify(c.Request.Context(), c.GetString(string(domain.KeyUserID)), c.GetString(string(domain.KeySessionID)), req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Two-factor verification successful", status)
+}
+
+// Reset godoc
+// @Summary      Reset an admin's two-factor authentication
+// @Description  Removes the enrollment and verified sessions so the admin enrolls again on next login
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/users/{id}/mfa/reset [post]
+func (h *AdminMFAHandler) Reset(c *gin.Context) {
+	if err := h.mfaUC.Reset(c.Request.Context(), c.GetString(string(domain.KeyUserID)), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Two-factor authentication reset", nil)
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminScopeHandler struct {
+	scopeUC domain.AdminScopeUsecase
+}
+
+// NewAdminScopeHandler registers regional admin scopes: the caller's own scope
+// and, for unrestricted admins, managing the scopes of the others
+func NewAdminScopeHandler(protected *gin.RouterGroup, scopeUC domain.AdminScopeUsecase) {
+	handler := &AdminScopeHandler{scopeUC: scopeUC}
+
+	protected.GET("/admin/me/scope", handler.GetMyScope)
+
+	scopes := protected.Group("/admin/scopes")
+	{
+		scopes.GET("", handler.ListScopes)
+		scopes.PUT("/:userId", handler.SetScope)
+		scopes.DELETE("/:userId", handler.ClearScope)
+	}
+}
+
+// GetMyScope godoc
+// @Summary      Get my admin scope
+// @Description  Regional admins see the provinces they are limited to; unrestricted admins see unrestricted=true
+// @Tags         Admin Scopes
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.MyAdminScope}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/me/scope [get]
+func (h *AdminScopeHandler) GetMyScope(c *gin.Context) {
+	scope, err := h.scopeUC.GetMyScope(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Admin scope", scope)
+}
+
+// ListScopes godoc
+// @Summary      List regional admins
+// @Description  Unrestricted admins only. Admins without a scope are not listed.
+// @Tags         Admin Scopes
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.AdminScope}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/scopes [get]
+func (h *AdminScopeHandler) ListScopes(c *gin.Context) {
+	scopes, err := h.scopeUC.ListScopes(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Admin scopes", scopes)
+}
+
+// SetScope godoc
+// @Summary      Limit an admin to provinces
+// @Description  Unrestricted admins only. Replaces the admin's provinces; they then only see candidates domiciled and companies located in them. Provinces match case-insensitively.
+// @Tags         Admin Scopes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId   path      string                       true  "Admin user ID"
+// @Param        request  body      domain.SetAdminScopeRequest  true  "Provinces"
+// @Success      200      {object}  response.Response{data=domain.AdminScope}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /admin/scopes/{userId} [put]
+func (h *AdminScopeHandler) SetScope(c *gin.Context) {
+	var req domain.SetAdminScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	scope, err := h.scopeUC.SetScope(c.Request.Context(), c.Param("userId"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Admin scope updated", scope)
+}
+
+// ClearScope godoc
+// @Summary      Make an admin unrestricted
+// @Description  Unrestricted admins only
+// @Tags         Admin Scopes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId  path      string  true  "Admin user ID"
+// @Success      200     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Router       /admin/scopes/{userId} [delete]
+func (h *AdminScopeHandler) ClearScope(c *gin.Context) {
+	if err := h.scopeUC.ClearScope(c.Request.Context(), c.Param("userId")); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Admin scope removed", nil)
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminSearchHandler struct {
+	searchUC domain.AdminSearchUsecase
+}
+
+// NewAdminSearchHandler registers the admin global search route
+func NewAdminSearchHandler(protected *gin.RouterGroup, searchUC domain.AdminSearchUsecase) {
+	handler := &AdminSearchHandler{searchUC: searchUC}
+
+	protected.GET("/admin/search", handler.Search)
+}
+
+// Search godoc
+// @Summary      Search users, candidates, companies and jobs
+// @Description  Substring search across entity types in parallel. Users match by email, candidates by name or phone, companies by name, jobs by title. Results are grouped by type with links to admin pages.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q      query     string  true   "Search text (3-100 characters)"
+// @Param        types  query     string  false  "Comma-separated types: user,candidate,company,job (default all)"
+// @Param        limit  query     int     false  "Results per type (1-20, default 5)"
+// @Success      200    {object}  response.Response{data=domain.AdminSearchResponse}
+// @Failure      400    {object}  response.Response
+// @Failure      403    {object}  response.Response
+// @Router       /admin/search [get]
+func (h *AdminSearchHandler) Search(c *gin.Context) {
+	var types []string
+	if t := c.Query("types"); t != "" {
+		types = strings.Split(t, ",")
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+
+	result, err := h.searchUC.Search(c.Request.Context(), c.Query("q"), types, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Search results", result)
+}
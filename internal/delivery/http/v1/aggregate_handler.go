@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AggregateHandler struct {
+	aggregateUC domain.AggregateUsecase
+}
+
+// NewAggregateHandler registers admin routes for derived aggregate recomputes and drift reports
+func NewAggregateHandler(protected *gin.RouterGroup, aggregateUC domain.AggregateUsecase) {
+	handler := &AggregateHandler{aggregateUC: aggregateUC}
+
+	admin := protected.Group("/admin/aggregates")
+	{
+		admin.POST("/recompute", handler.TriggerRecompute)
+		admin.GET("/runs", handler.ListRuns)
+		admin.GET("/runs/:id", handler.GetDriftReport)
+	}
+}
+
+// TriggerRecompute godoc
+// @Summary      Recompute derived candidate aggregates now
+// @Description  Runs the nightly recompute immediately and returns the run with its drift
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.AggregateRecomputeRun}
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/aggregates/recompute [post]
+func (h *AggregateHandler) TriggerRecompute(c *gin.Context) {
+	run, err := h.aggregateUC.TriggerRecompute(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Aggregate recompute finished", run)
+}
+
+// ListRuns godoc
+// @Summary      List aggregate recompute runs
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.AggregateRecomputeRun}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/aggregates/runs [get]
+func (h *AggregateHandler) ListRuns(c *gin.Context) {
+	runs, err := h.aggregateUC.ListRuns(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Recompute runs retrieved", runs)
+}
+
+// GetDriftReport godoc
+// @Summary      Get a recompute run's drift report
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Run ID"
+// @Success      200  {object}  response.Response{data=domain.AggregateRecomputeRun}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/aggregates/runs/{id} [get]
+func (h *AggregateHandler) GetDriftReport(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid run ID"))
+		return
+	}
+
+	run, err := h.aggregateUC.GetDriftReport(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Drift report retrieved", run)
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnchorHeartbeatHandler lets an external monitor poll the anchoring dead-man
+// switch. It authenticates with a shared token, not a user session, since the
+// monitor cannot pass the security dashboard's IP allowlist and MFA.
+type AnchorHeartbeatHandler struct {
+	securityUC domain.SecurityDashboardUsecase
+	token      string
+}
+
+func NewAnchorHeartbeatHandler(public *gin.RouterGroup, securityUC domain.SecurityDashboardUsecase, token string) {
+	handler := &AnchorHeartbeatHandler{securityUC: securityUC, token: token}
+
+	public.GET("/health/anchoring", handler.Check)
+}
+
+// Check godoc
+// @Summary      Anchoring heartbeat check
+// @Description  For external uptime monitors: 200 while an anchor run succeeded within the heartbeat window, 503 when it is overdue or never ran. Requires `Authorization: Bearer <ANCHOR_HEARTBEAT_CHECK_TOKEN>`; 404 when no token is configured.
+// @Tags         system
+// @Produce      json
+// @Param        Authorization  header  string  true  "Bearer token"
+// @Success      200  {object}  response.Response{data=domain.AnchorHeartbeatStatus}
+// @Failure      401  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      503  {object}  response.Response{error=domain.AnchorHeartbeatStatus}
+// @Router       /health/anchoring [get]
+func (h *AnchorHeartbeatHandler) Check(c *gin.Context) {
+	if h.token == "" || h.securityUC == nil {
+		c.Error(apperror.NotFound("Anchor heartbeat check is not enabled"))
+		return
+	}
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		c.Error(apperror.Unauthorized("Invalid heartbeat check token"))
+		return
+	}
+
+	status, err := h.securityUC.GetAnchorHeartbeat(c.Request.Context())
+	if err != nil {
+		c.Error(apperror.Internal(err))
+		return
+	}
+	if status.Status != domain.AnchorHeartbeatOK {
+		response.Error(c, http.StatusServiceUnavailable, "Anchoring heartbeat overdue", status)
+		return
+	}
+	response.Success(c, http.StatusOK, "Anchoring heartbeat ok", status)
+}
@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApplicationDraftHandler struct {
+	draftUC domain.ApplicationDraftUsecase
+}
+
+// NewApplicationDraftHandler registers candidate application draft routes
+func NewApplicationDraftHandler(protected *gin.RouterGroup, draftUC domain.ApplicationDraftUsecase) {
+	handler := &ApplicationDraftHandler{draftUC: draftUC}
+
+	// Candidate: unsubmitted application for a job (deleted on submit)
+	protected.GET("/jobs/:id/application-draft", handler.GetDraft)
+	protected.PUT("/jobs/:id/application-draft", handler.SaveDraft)
+}
+
+// GetDraft godoc
+// @Summary      Get my application draft
+// @Description  The unsubmitted application for this job, to resume where the candidate left off
+// @Tags         applications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  response.Response{data=domain.ApplicationDraft}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /jobs/{id}/application-draft [get]
+func (h *ApplicationDraftHandler) GetDraft(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	draft, err := h.draftUC.GetDraft(c.Request.Context(), c.GetString(string(domain.KeyUserID)), jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Application draft retrieved", draft)
+}
+
+// SaveDraft godoc
+// @Summary      Save my application draft
+// @Description  Replaces the draft; answers are checked against the screening questions only on submit. A reminder is sent if the draft is left unsubmitted, and it expires after a period without saves.
+// @Tags         applications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                                 true  "Job ID"
+// @Param        body  body      domain.SaveApplicationDraftRequest  true  "Draft"
+// @Success      200   {object}  response.Response{data=domain.ApplicationDraft}
+// @Failure      400   {object}  response.Response
+// @Failure      403   {object}  response.Response
+// @Failure      404   {object}  response.Response
+// @Router       /jobs/{id}/application-draft [put]
+func (h *ApplicationDraftHandler) SaveDraft(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	var req domain.SaveApplicationDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	draft, err := h.draftUC.SaveDraft(c.Request.Context(), c.GetString(string(domain.KeyUserID)), jobID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Application draft saved", draft)
+}
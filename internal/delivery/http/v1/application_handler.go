@@ -4,8 +4,10 @@ import (
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,13 +24,18 @@ func NewApplicationHandler(r *gin.RouterGroup, applicationUC domain.ApplicationU
 	candidates := r.Group("/candidates")
 	{
 		candidates.POST("/jobs/:jobId/apply", handler.ApplyToJob)
+		candidates.GET("/jobs/:jobId/screening-questions", handler.GetScreeningQuestions)
 		candidates.GET("/applications", handler.GetMyApplications)
+		candidates.GET("/me/applications", handler.GetMyApplicationHistory)
 	}
 
 	// Employer routes
 	employers := r.Group("/employers")
 	{
 		employers.GET("/jobs/:jobId/applications", handler.ListJobApplications)
+		employers.GET("/jobs/:jobId/applications/export", handler.ExportJobApplications)
+		employers.GET("/jobs/:jobId/screening-questions", handler.GetJobScreeningQuestions)
+		employers.PUT("/jobs/:jobId/screening-questions", handler.ReplaceScreeningQuestions)
 		employers.GET("/applications/:id", handler.GetApplicationDetail)
 		employers.PATCH("/applications/:id", handler.UpdateApplicationStatus)
 	}
@@ -36,13 +43,14 @@ func NewApplicationHandler(r *gin.RouterGroup, applicationUC domain.ApplicationU
 
 // ApplyToJobRequest is the request payload for applying to a job
 type ApplyToJobRequest struct {
-	CvURL       string `json:"cv_url" binding:"required"`
-	CoverLetter string `json:"cover_letter"`
+	CvURL       string                        `json:"cv_url" binding:"required"`
+	CoverLetter string                        `json:"cover_letter"`
+	Answers     []domain.ScreeningAnswerInput `json:"answers" binding:"omitempty,dive"` // Screening question answers
 }
 
 // ApplyToJob godoc
 // @Summary      Apply to a job
-// @Description  Submit an application for a job (Candidate only, must be verified)
+// @Description  Submit an application for a job (Candidate only, must be verified). Required screening questions must be answered; a disqualifying answer rejects the application automatically.
 // @Tags         applications
 // @Accept       json
 // @Produce      json
@@ -59,7 +67,7 @@ func (h *ApplicationHandler) ApplyToJob(c *gin.Context) {
 	role := c.GetString(string(domain.KeyUserRole))
 
 	// Only candidates can apply
-	if role != "candidate" {
+	if role != domain.RoleCandidate {
 		c.Error(apperror.Forbidden("Only candidates can apply to jobs"))
 		return
 	}
@@ -80,7 +88,7 @@ func (h *ApplicationHandler) ApplyToJob(c *gin.Context) {
 	}
 
 	// 4. Apply
-	app, err := h.applicationUC.ApplyToJob(c, userID, jobID, req.CvURL, req.CoverLetter)
+	app, err := h.applicationUC.ApplyToJob(c, userID, jobID, req.CvURL, req.CoverLetter, req.Answers)
 	if err != nil {
 		c.Error(err)
 		return
@@ -89,6 +97,32 @@ func (h *ApplicationHandler) ApplyToJob(c *gin.Context) {
 	response.Success(c, http.StatusCreated, "Application submitted successfully", app)
 }
 
+// GetScreeningQuestions godoc
+// @Summary      Get screening questions for a job
+// @Description  Questions to answer when applying (knock-out rules are not included)
+// @Tags         applications
+// @Produce      json
+// @Param        jobId  path      int  true  "Job ID"
+// @Success      200    {object}  response.Response{data=[]domain.ScreeningQuestion}
+// @Failure      404    {object}  response.Response
+// @Router       /candidates/jobs/{jobId}/screening-questions [get]
+// @Security     BearerAuth
+func (h *ApplicationHandler) GetScreeningQuestions(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	questions, err := h.applicationUC.GetScreeningQuestions(c, jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Screening questions retrieved", questions)
+}
+
 // GetMyApplications godoc
 // @Summary      Get my applications
 // @Description  Get all applications submitted by the current candidate
@@ -110,6 +144,39 @@ func (h *ApplicationHandler) GetMyApplications(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Applications retrieved", applications)
 }
 
+// GetMyApplicationHistory godoc
+// @Summary      My application history
+// @Description  Applications with job details, current status, stage history, interview dates and delivered employer feedback. Feedback wording is in the request's language.
+// @Tags         applications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status      query     string  false  "applied, reviewed, accepted or rejected"
+// @Param        sort_by     query     string  false  "applied_at or updated_at (default)"
+// @Param        sort_order  query     string  false  "asc or desc (default)"
+// @Param        page        query     int     false  "Page (default 1)"
+// @Param        page_size   query     int     false  "Applications per page (default 20, max 100)"
+// @Success      200  {object}  response.Response{data=domain.PaginatedResult[domain.CandidateApplicationView]}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /candidates/me/applications [get]
+func (h *ApplicationHandler) GetMyApplicationHistory(c *gin.Context) {
+	filter := domain.ApplicationHistoryFilter{
+		Status:    c.Query("status"),
+		SortBy:    c.Query("sort_by"),
+		SortOrder: c.Query("sort_order"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	userID := c.GetString(string(domain.KeyUserID))
+	history, err := h.applicationUC.GetMyApplicationHistory(c.Request.Context(), userID, filter, c.GetString(i18n.ContextKey))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Application history retrieved", history)
+}
+
 // ListJobApplications godoc
 // @Summary      List applications for a job
 // @Description  Get all applications for a specific job (Employer only)
@@ -126,7 +193,7 @@ func (h *ApplicationHandler) ListJobApplications(c *gin.Context) {
 	role := c.GetString(string(domain.KeyUserRole))
 
 	// Only employers can view applications
-	if role != "employer" && role != "admin" {
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
 		c.Error(apperror.Forbidden("Only employers can view job applications"))
 		return
 	}
@@ -163,7 +230,7 @@ func (h *ApplicationHandler) GetApplicationDetail(c *gin.Context) {
 	userID := c.GetString(string(domain.KeyUserID))
 	role := c.GetString(string(domain.KeyUserRole))
 
-	if role != "employer" && role != "admin" {
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
 		c.Error(apperror.Forbidden("Only employers can view application details"))
 		return
 	}
@@ -207,7 +274,7 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 	userID := c.GetString(string(domain.KeyUserID))
 	role := c.GetString(string(domain.KeyUserRole))
 
-	if role != "employer" && role != "admin" {
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
 		c.Error(apperror.Forbidden("Only employers can update application status"))
 		return
 	}
@@ -232,3 +299,122 @@ func (h *ApplicationHandler) UpdateApplicationStatus(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Application status updated", nil)
 }
+
+// GetJobScreeningQuestions godoc
+// @Summary      Get a job's screening questions
+// @Description  Questions including knock-out answers (Employer only)
+// @Tags         applications
+// @Produce      json
+// @Param        jobId  path      int  true  "Job ID"
+// @Success      200    {object}  response.Response{data=[]domain.ScreeningQuestion}
+// @Failure      403    {object}  response.Response
+// @Failure      404    {object}  response.Response
+// @Router       /employers/jobs/{jobId}/screening-questions [get]
+// @Security     BearerAuth
+func (h *ApplicationHandler) GetJobScreeningQuestions(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	role := c.GetString(string(domain.KeyUserRole))
+
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
+		c.Error(apperror.Forbidden("Only employers can manage screening questions"))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	questions, err := h.applicationUC.GetJobScreeningQuestions(c, userID, jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Screening questions retrieved", questions)
+}
+
+// ReplaceScreeningQuestions godoc
+// @Summary      Replace a job's screening questions
+// @Description  Replaces the whole question set in list order (Employer only). Knock-out answers auto-reject applicants who choose them.
+// @Tags         applications
+// @Accept       json
+// @Produce      json
+// @Param        jobId  path      int                                      true  "Job ID"
+// @Param        body   body      domain.ReplaceScreeningQuestionsRequest  true  "Questions"
+// @Success      200    {object}  response.Response{data=[]domain.ScreeningQuestion}
+// @Failure      400    {object}  response.Response
+// @Failure      403    {object}  response.Response
+// @Failure      404    {object}  response.Response
+// @Router       /employers/jobs/{jobId}/screening-questions [put]
+// @Security     BearerAuth
+func (h *ApplicationHandler) ReplaceScreeningQuestions(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	role := c.GetString(string(domain.KeyUserRole))
+
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
+		c.Error(apperror.Forbidden("Only employers can manage screening questions"))
+		return
+	}
+
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	var req domain.ReplaceScreeningQuestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	questions, err := h.applicationUC.ReplaceScreeningQuestions(c, userID, jobID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Screening questions updated", questions)
+}
+
+// ExportJobApplications godoc
+// @Summary      Download a job's applications as XLSX
+// @Description  Applicants with the selected columns and current stage. Contact details are only filled for unlocked candidates; every export is recorded in the PII access log (Employer only)
+// @Tags         applications
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        jobId    path      int     true   "Job ID"
+// @Param        columns  query     string  false  "Comma-separated columns (default: all)"
+// @Success      200      {file}    binary
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /employers/jobs/{jobId}/applications/export [get]
+// @Security     BearerAuth
+func (h *ApplicationHandler) ExportJobApplications(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	req := domain.ApplicationExportRequest{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	if cols := c.Query("columns"); cols != "" {
+		req.Columns = strings.Split(cols, ",")
+	}
+
+	data, filename, err := h.applicationUC.ExportJobApplications(c.Request.Context(), userID, jobID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", data)
+}
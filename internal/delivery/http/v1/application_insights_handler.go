@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApplicationInsightsHandler struct {
+	insightsUC domain.ApplicationInsightsUsecase
+}
+
+// NewApplicationInsightsHandler registers the candidate application insights route
+func NewApplicationInsightsHandler(protected *gin.RouterGroup, insightsUC domain.ApplicationInsightsUsecase) {
+	handler := &ApplicationInsightsHandler{insightsUC: insightsUC}
+
+	protected.GET("/candidates/me/application-insights", handler.GetMyInsights)
+}
+
+// GetMyInsights godoc
+// @Summary      Get my application insights
+// @Description  Interview rate, average employer response time and, once at least 10 candidates were hired in one of my target job fields, how my Japanese level, Japan experience and skills compare with theirs. Benchmarks are aggregates only.
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.ApplicationInsights}
+// @Failure      403  {object}  response.Response
+// @Router       /candidates/me/application-insights [get]
+func (h *ApplicationInsightsHandler) GetMyInsights(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	insights, err := h.insightsUC.GetMyInsights(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Application insights retrieved", insights)
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApplicationStageHandler struct {
+	stageUC domain.ApplicationStageUsecase
+}
+
+// NewApplicationStageHandler registers the employer pipeline (Kanban) routes
+func NewApplicationStageHandler(protected *gin.RouterGroup, stageUC domain.ApplicationStageUsecase) {
+	handler := &ApplicationStageHandler{stageUC: stageUC}
+
+	employers := protected.Group("/employers")
+	{
+		employers.GET("/applications/:id/stage", handler.GetStage)
+		employers.PUT("/applications/:id/stage", handler.MoveStage)
+		employers.GET("/jobs/:jobId/pipeline", handler.GetPipeline)
+		employers.PUT("/jobs/:jobId/pipeline", handler.UpdatePipeline)
+		employers.GET("/jobs/:jobId/funnel", handler.GetJobFunnel)
+		employers.GET("/funnel", handler.ListFunnels)
+	}
+}
+
+// GetStage godoc
+// @Summary      Get an application's pipeline stage
+// @Description  Current stage, the stages the job uses and the full stage history (oldest first)
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Application ID"
+// @Success      200  {object}  response.Response{data=domain.ApplicationStageView}
+// @Failure      404  {object}  response.Response
+// @Router       /employers/applications/{id}/stage [get]
+func (h *ApplicationStageHandler) GetStage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid application ID"))
+		return
+	}
+
+	view, err := h.stageUC.GetStage(c.Request.Context(), c.GetString(string(domain.KeyUserID)), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Application stage retrieved", view)
+}
+
+// MoveStage godoc
+// @Summary      Move an application to another stage
+// @Description  Records the move with an optional note. The candidate is notified only when their visible status changes (screening, interview and offer all show as reviewed). Returns 409 if the application was moved by someone else in the meantime.
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                 true  "Application ID"
+// @Param        request  body      domain.MoveApplicationStageRequest  true  "Target stage and note"
+// @Success      200      {object}  response.Response{data=domain.ApplicationStageView}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /employers/applications/{id}/stage [put]
+func (h *ApplicationStageHandler) MoveStage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid application ID"))
+		return
+	}
+
+	var req domain.MoveApplicationStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	view, err := h.stageUC.MoveStage(c.Request.Context(), c.GetString(string(domain.KeyUserID)), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Application stage updated", view)
+}
+
+// GetPipeline godoc
+// @Summary      Get a job's pipeline stages
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        jobId  path      int  true  "Job ID"
+// @Success      200    {object}  response.Response{data=domain.JobPipeline}
+// @Failure      404    {object}  response.Response
+// @Router       /employers/jobs/{jobId}/pipeline [get]
+func (h *ApplicationStageHandler) GetPipeline(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	pipeline, err := h.stageUC.GetPipeline(c.Request.Context(), c.GetString(string(domain.KeyUserID)), jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Pipeline retrieved", pipeline)
+}
+
+// UpdatePipeline godoc
+// @Summary      Choose a job's optional pipeline stages
+// @Description  applied, hired and rejected are always part of the pipeline; screening, interview and offer can be switched off. Applications already in a removed stage stay there until moved.
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        jobId    path      int                              true  "Job ID"
+// @Param        request  body      domain.UpdateJobPipelineRequest  true  "Optional stages to use"
+// @Success      200      {object}  response.Response{data=domain.JobPipeline}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /employers/jobs/{jobId}/pipeline [put]
+func (h *ApplicationStageHandler) UpdatePipeline(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	var req domain.UpdateJobPipelineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	pipeline, err := h.stageUC.UpdatePipeline(c.Request.Context(), c.GetString(string(domain.KeyUserID)), jobID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Pipeline updated", pipeline)
+}
+
+// GetJobFunnel godoc
+// @Summary      Count a job's applications per stage
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        jobId  path      int  true  "Job ID"
+// @Success      200    {object}  response.Response{data=domain.JobFunnel}
+// @Failure      404    {object}  response.Response
+// @Router       /employers/jobs/{jobId}/funnel [get]
+func (h *ApplicationStageHandler) GetJobFunnel(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	funnel, err := h.stageUC.GetJobFunnel(c.Request.Context(), c.GetString(string(domain.KeyUserID)), jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Funnel retrieved", funnel)
+}
+
+// ListFunnels godoc
+// @Summary      Count applications per stage for all of the employer's jobs
+// @Description  One funnel per job for the employer dashboard, newest job first
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.JobFunnel}
+// @Router       /employers/funnel [get]
+func (h *ApplicationStageHandler) ListFunnels(c *gin.Context) {
+	funnels, err := h.stageUC.ListFunnels(c.Request.Context(), c.GetString(string(domain.KeyUserID)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Funnels retrieved", funnels)
+}
@@ -1,9 +1,12 @@
 package v1
 
 import (
+	"cmp"
+	"errors"
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,19 +16,39 @@ import (
 )
 
 type ATSHandler struct {
-	atsUC domain.ATSUsecase
+	atsUC         domain.ATSUsecase
+	savedFilterUC domain.ATSSavedFilterUsecase
 }
 
 // NewATSHandler registers ATS routes
-func NewATSHandler(protected *gin.RouterGroup, atsUC domain.ATSUsecase) {
-	handler := &ATSHandler{atsUC: atsUC}
+func NewATSHandler(protected *gin.RouterGroup, atsUC domain.ATSUsecase, savedFilterUC domain.ATSSavedFilterUsecase) {
+	handler := &ATSHandler{atsUC: atsUC, savedFilterUC: savedFilterUC}
 
 	ats := protected.Group("/admin/ats")
 	{
 		ats.GET("/candidates", handler.SearchCandidates)
 		ats.GET("/export", handler.ExportCandidates)
+		ats.GET("/export-audits", handler.ListExportAudits)
+		ats.GET("/export-audits/:id", handler.GetExportAudit)
 		ats.GET("/filter-options", handler.GetFilterOptions)
 	}
+
+	// Employer-facing search, scoped by each candidate's profile visibility
+	protected.GET("/employers/ats/candidates", handler.SearchEmployerCandidates)
+
+	privacy := protected.Group("/candidates/me/privacy")
+	{
+		privacy.GET("", handler.GetPrivacySettings)
+		privacy.PUT("", handler.UpdatePrivacySettings)
+	}
+
+	talentPool := protected.Group("/candidates/me/talent-pool")
+	{
+		talentPool.GET("", handler.GetTalentPoolSettings)
+		talentPool.PUT("", handler.UpdateTalentPoolSettings)
+		talentPool.POST("/grants", handler.GrantPIIAccess)
+		talentPool.DELETE("/grants/:companyId", handler.RevokePIIAccess)
+	}
 }
 
 // SearchCandidates godoc
@@ -38,6 +61,7 @@ func NewATSHandler(protected *gin.RouterGroup, atsUC domain.ATSUsecase) {
 // @Param        japan_experience_min  query     int      false  "Minimum Japan experience in months"
 // @Param        japan_experience_max  query     int      false  "Maximum Japan experience in months"
 // @Param        has_lpk_training      query     bool     false  "Filter by LPK training status"
+// @Param        cohort_ids            query     string   false  "Comma-separated training cohort IDs"
 // @Param        english_cert_types    query     string   false  "Comma-separated cert types (TOEFL,IELTS,TOEIC)"
 // @Param        english_min_score     query     number   false  "Minimum English score"
 // @Param        technical_skill_ids   query     string   false  "Comma-separated skill IDs"
@@ -53,136 +77,373 @@ func NewATSHandler(protected *gin.RouterGroup, atsUC domain.ATSUsecase) {
 // @Param        major_fields          query     string   false  "Comma-separated major fields"
 // @Param        total_experience_min  query     int      false  "Minimum total experience in months"
 // @Param        total_experience_max  query     int      false  "Maximum total experience in months"
+// @Param        phone_verified_only   query     bool     false  "Only candidates with OTP-verified phone"
+// @Param        coe_statuses          query     string   false  "Comma-separated CoE statuses (NONE,IN_PROCESS,ISSUED,EXPIRED)"
+// @Param        visa_ready_only       query     bool     false  "Only candidates holding an unexpired Certificate of Eligibility"
+// @Param        ssw_sectors           query     string   false  "Comma-separated SSW sectors with a passed exam (e.g. NURSING_CARE,FOOD_SERVICE)"
+// @Param        ssw_level             query     int      false  "Minimum SSW level (1 or 2)"
+// @Param        expiring_documents_only query   bool     false  "Only candidates whose passport, JLPT, medical check or CoE expired or expires within 30 days"
+// @Param        quiz_id               query     int      false  "Skill quiz whose best score is shown and filtered/sorted on"
+// @Param        quiz_min_score        query     int      false  "Minimum best score (percent) on quiz_id"
 // @Param        page                  query     int      false  "Page number (default: 1)"
 // @Param        page_size             query     int      false  "Items per page (default: 20, max: 100)"
-// @Param        sort_by               query     string   false  "Sort column (verified_at,japanese_level,age,expected_salary)"
+// @Param        sort_by               query     string   false  "Sort column (verified_at,japanese_level,age,expected_salary,quiz_score,profile_completeness)"
 // @Param        sort_order            query     string   false  "Sort order (asc,desc)"
+// @Param        filter_id             query     int      false  "Saved filter to run instead of the filter parameters; page, page_size and sort still apply"
 // @Success      200  {object}  response.Response
 // @Failure      400  {object}  response.Response
 // @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
 // @Router       /admin/ats/candidates [get]
 func (h *ATSHandler) SearchCandidates(c *gin.Context) {
-	filter := domain.ATSFilter{}
+	filter, ok := h.adminATSFilter(c)
+	if !ok {
+		return
+	}
+	parseATSPagination(c, &filter)
 
-	// Parse Japanese Proficiency Group
-	if levels := c.Query("japanese_levels"); levels != "" {
-		filter.JapaneseLevels = strings.Split(levels, ",")
+	result, err := h.atsUC.SearchCandidates(c, filter)
+	if err != nil {
+		c.Error(atsError(err))
+		return
 	}
-	if min := c.Query("japan_experience_min"); min != "" {
-		if v, err := strconv.Atoi(min); err == nil {
-			filter.JapanExperienceMin = &v
-		}
+
+	response.Success(c, http.StatusOK, "Candidates retrieved", result)
+}
+
+// ExportCandidates godoc
+// @Summary      Export candidates to Excel/CSV or PDF profiles
+// @Description  Downloads candidates matching the filter criteria as Excel or CSV file, or as a ZIP of one-page PDF profiles (format=pdf, at most 500 candidates) streamed as it is rendered. Every export is recorded in the export audit with a fingerprint of each candidate's row.
+// @Tags         admin-ats
+// @Produce      application/octet-stream
+// @Security     BearerAuth
+// @Param        format               query     string   false  "Export format (xlsx, csv, pdf). Default: xlsx"
+// @Param        columns              query     string   false  "Comma-separated column names to include (xlsx, csv)"
+// @Param        japanese_levels      query     string   false  "Comma-separated JLPT levels"
+// @Param        ... (same filters as SearchCandidates)
+// @Param        filter_id            query     int      false  "Saved filter to export instead of the filter parameters"
+// @Success      200  {file}    binary
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/ats/export [get]
+func (h *ATSHandler) ExportCandidates(c *gin.Context) {
+	// Parse the same filters as SearchCandidates
+	filter, ok := h.adminATSFilter(c)
+	if !ok {
+		return
 	}
-	if max := c.Query("japan_experience_max"); max != "" {
-		if v, err := strconv.Atoi(max); err == nil {
-			filter.JapanExperienceMax = &v
-		}
+
+	// Parse export-specific params
+	format := c.DefaultQuery("format", "xlsx")
+	var columns []string
+	if cols := c.Query("columns"); cols != "" {
+		columns = strings.Split(cols, ",")
 	}
-	if lpk := c.Query("has_lpk_training"); lpk != "" {
-		v := lpk == "true"
-		filter.HasLPKTraining = &v
+
+	req := domain.ATSExportRequest{
+		Filter:    filter,
+		Columns:   columns,
+		Format:    format,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
 	}
 
-	// Parse Competency & Language Group
-	if certs := c.Query("english_cert_types"); certs != "" {
-		filter.EnglishCertTypes = strings.Split(certs, ",")
+	file, err := h.atsUC.ExportCandidates(c, req)
+	if err != nil {
+		c.Error(atsError(err))
+		return
 	}
-	if score := c.Query("english_min_score"); score != "" {
-		if v, err := strconv.ParseFloat(score, 64); err == nil {
-			filter.EnglishMinScore = &v
-		}
+
+	c.Header("Content-Disposition", "attachment; filename="+file.Filename)
+	c.Header("Content-Type", file.ContentType)
+	c.Status(http.StatusOK)
+	// Headers are sent once writing starts; a failure past that point can only be logged
+	if err := file.Write(c.Writer); err != nil {
+		logger.FromContext(c.Request.Context()).Error("Candidate export aborted", "format", format, "error", err)
 	}
-	if skills := c.Query("technical_skill_ids"); skills != "" {
-		filter.TechnicalSkillIDs = parseIntArray(skills)
+}
+
+// ListExportAudits godoc
+// @Summary      List ATS export audits
+// @Description  Lists recorded candidate exports, newest first. Filtering by candidate_user_id answers data-protection requests ("who exported my data") and returns that candidate's row fingerprint on each export.
+// @Tags         admin-ats
+// @Produce      json
+// @Security     BearerAuth
+// @Param        candidate_user_id  query     string  false  "Only exports that included this candidate"
+// @Param        actor_user_id      query     string  false  "Only exports run by this admin"
+// @Param        page               query     int     false  "Page number"
+// @Param        pageSize           query     int     false  "Items per page (max 100)"
+// @Success      200  {object}  response.Response{data=domain.PaginatedResult[domain.ATSExportAudit]}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/ats/export-audits [get]
+func (h *ATSHandler) ListExportAudits(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	filter := domain.ATSExportAuditFilter{
+		CandidateUserID: c.Query("candidate_user_id"),
+		ActorUserID:     c.Query("actor_user_id"),
+		Page:            page,
+		PageSize:        pageSize,
 	}
-	if skills := c.Query("computer_skill_ids"); skills != "" {
-		filter.ComputerSkillIDs = parseIntArray(skills)
+
+	result, err := h.atsUC.ListExportAudits(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
 	}
+	response.Success(c, http.StatusOK, "Export audits retrieved", result)
+}
 
-	// Parse Logistics & Availability Group
-	if min := c.Query("age_min"); min != "" {
-		if v, err := strconv.Atoi(min); err == nil {
-			filter.AgeMin = &v
-		}
+// GetExportAudit godoc
+// @Summary      Get an ATS export audit
+// @Description  Returns one recorded export with the fingerprint of every candidate it included
+// @Tags         admin-ats
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Export audit ID"
+// @Success      200  {object}  response.Response{data=domain.ATSExportAudit}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/ats/export-audits/{id} [get]
+func (h *ATSHandler) GetExportAudit(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid export audit ID"))
+		return
 	}
-	if max := c.Query("age_max"); max != "" {
-		if v, err := strconv.Atoi(max); err == nil {
-			filter.AgeMax = &v
+
+	audit, err := h.atsUC.GetExportAudit(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Export audit retrieved", audit)
+}
+
+// GetFilterOptions godoc
+// @Summary      Get available filter options
+// @Description  Returns all available filter options for the ATS UI
+// @Tags         admin-ats
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /admin/ats/filter-options [get]
+func (h *ATSHandler) GetFilterOptions(c *gin.Context) {
+	options, err := h.atsUC.GetFilterOptions(c)
+	if err != nil {
+		var appErr *apperror.AppError
+		if !errors.As(err, &appErr) {
+			appErr = apperror.Internal(err)
 		}
+		c.Error(appErr)
+		return
 	}
-	if genders := c.Query("genders"); genders != "" {
-		filter.Genders = strings.Split(genders, ",")
+
+	response.Success(c, http.StatusOK, "Filter options retrieved", options)
+}
+
+// SearchEmployerCandidates godoc
+// @Summary      Search candidates visible to the employer
+// @Description  Same filters as the admin ATS search, limited to candidates who applied to the company's jobs or opted in to the talent pool. Name (initials only) and photo are masked unless the candidate granted the company access or the company unlocked their contact.
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        japanese_levels      query     string   false  "Comma-separated JLPT levels"
+// @Param        ... (same filters, pagination and sorting as /admin/ats/candidates)
+// @Success      200  {object}  response.Response{data=domain.PaginatedResult[domain.ATSCandidate]}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/ats/candidates [get]
+func (h *ATSHandler) SearchEmployerCandidates(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	filter := parseATSFilter(c)
+	parseATSPagination(c, &filter)
+
+	result, err := h.atsUC.SearchEmployerCandidates(c.Request.Context(), userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	if cities := c.Query("domicile_cities"); cities != "" {
-		filter.DomicileCities = strings.Split(cities, ",")
+
+	response.Success(c, http.StatusOK, "Candidates retrieved", result)
+}
+
+// GetTalentPoolSettings godoc
+// @Summary      Get my talent pool settings
+// @Description  Whether employers can find the candidate without an application, and which companies may see their name and photo
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.TalentPoolSettings}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/talent-pool [get]
+func (h *ATSHandler) GetTalentPoolSettings(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	settings, err := h.atsUC.GetTalentPoolSettings(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	if min := c.Query("expected_salary_min"); min != "" {
-		if v, err := strconv.ParseInt(min, 10, 64); err == nil {
-			filter.ExpectedSalaryMin = &v
-		}
+
+	response.Success(c, http.StatusOK, "Talent pool settings retrieved", settings)
+}
+
+// GetPrivacySettings godoc
+// @Summary      Get my privacy settings
+// @Description  Which employers can find the candidate in ATS search and unlock their contact (visibility), and which companies may see their name and photo
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.TalentPoolSettings}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/privacy [get]
+func (h *ATSHandler) GetPrivacySettings(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	settings, err := h.atsUC.GetTalentPoolSettings(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	if max := c.Query("expected_salary_max"); max != "" {
-		if v, err := strconv.ParseInt(max, 10, 64); err == nil {
-			filter.ExpectedSalaryMax = &v
-		}
+
+	response.Success(c, http.StatusOK, "Privacy settings retrieved", settings)
+}
+
+// UpdatePrivacySettings godoc
+// @Summary      Choose which employers can find me
+// @Description  ALL_VERIFIED_EMPLOYERS: every STANDARD or PREMIUM company and the companies applied to; APPLIED_COMPANIES: only companies applied to; HIDDEN: no employer search or contact unlock (applications still reach the company)
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.UpdatePrivacyRequest  true  "Visibility"
+// @Success      200      {object}  response.Response{data=domain.TalentPoolSettings}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /candidates/me/privacy [put]
+func (h *ATSHandler) UpdatePrivacySettings(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	var req domain.UpdatePrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
 	}
-	if dateStr := c.Query("available_start_before"); dateStr != "" {
-		if t, err := time.Parse("2006-01-02", dateStr); err == nil {
-			filter.AvailableStartBefore = &t
-		}
+
+	settings, err := h.atsUC.UpdatePrivacySettings(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	// Parse Education & Experience Group
-	if levels := c.Query("education_levels"); levels != "" {
-		filter.EducationLevels = strings.Split(levels, ",")
+	response.Success(c, http.StatusOK, "Privacy settings updated", settings)
+}
+
+// UpdateTalentPoolSettings godoc
+// @Summary      Join or leave the talent pool
+// @Description  Talent pool members (visibility ALL_VERIFIED_EMPLOYERS) appear in every verified employer's ATS search, with name and photo masked. Leaving falls back to APPLIED_COMPANIES; a HIDDEN profile stays hidden.
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.UpdateTalentPoolRequest  true  "Visibility"
+// @Success      200      {object}  response.Response{data=domain.TalentPoolSettings}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /candidates/me/talent-pool [put]
+func (h *ATSHandler) UpdateTalentPoolSettings(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	var req domain.UpdateTalentPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
 	}
-	if majors := c.Query("major_fields"); majors != "" {
-		filter.MajorFields = strings.Split(majors, ",")
+
+	settings, err := h.atsUC.UpdateTalentPoolSettings(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	if min := c.Query("total_experience_min"); min != "" {
-		if v, err := strconv.Atoi(min); err == nil {
-			filter.TotalExperienceMin = &v
-		}
+
+	response.Success(c, http.StatusOK, "Talent pool settings updated", settings)
+}
+
+// GrantPIIAccess godoc
+// @Summary      Let a company see my name and photo
+// @Description  Unmasks the candidate in the company's ATS search
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.GrantPIIAccessRequest  true  "Company"
+// @Success      200      {object}  response.Response{data=domain.TalentPoolSettings}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /candidates/me/talent-pool/grants [post]
+func (h *ATSHandler) GrantPIIAccess(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	var req domain.GrantPIIAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
 	}
-	if max := c.Query("total_experience_max"); max != "" {
-		if v, err := strconv.Atoi(max); err == nil {
-			filter.TotalExperienceMax = &v
-		}
+
+	settings, err := h.atsUC.GrantPIIAccess(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	// Parse Pagination & Sorting
-	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
-	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "20"))
-	filter.SortBy = c.DefaultQuery("sort_by", "verified_at")
-	filter.SortOrder = c.DefaultQuery("sort_order", "desc")
+	response.Success(c, http.StatusOK, "Access granted", settings)
+}
 
-	result, err := h.atsUC.SearchCandidates(c, filter)
+// RevokePIIAccess godoc
+// @Summary      Revoke a company's access to my name and photo
+// @Description  Masks the candidate in the company's ATS search again. A contact the company already unlocked stays unlocked.
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        companyId  path      int  true  "Company ID"
+// @Success      200        {object}  response.Response{data=domain.TalentPoolSettings}
+// @Failure      400        {object}  response.Response
+// @Failure      403        {object}  response.Response
+// @Failure      404        {object}  response.Response
+// @Router       /candidates/me/talent-pool/grants/{companyId} [delete]
+func (h *ATSHandler) RevokePIIAccess(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	companyID, err := strconv.ParseInt(c.Param("companyId"), 10, 64)
 	if err != nil {
-		c.Error(apperror.BadRequest(err.Error()))
+		c.Error(apperror.BadRequest("Invalid company ID"))
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Candidates retrieved", result)
+	settings, err := h.atsUC.RevokePIIAccess(c.Request.Context(), userID, companyID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Access revoked", settings)
 }
 
-// ExportCandidates godoc
-// @Summary      Export candidates to Excel/CSV
-// @Description  Downloads candidates matching the filter criteria as Excel or CSV file
-// @Tags         admin-ats
-// @Produce      application/octet-stream
-// @Security     BearerAuth
-// @Param        format               query     string   false  "Export format (xlsx, csv). Default: xlsx"
-// @Param        columns              query     string   false  "Comma-separated column names to include"
-// @Param        japanese_levels      query     string   false  "Comma-separated JLPT levels"
-// @Param        ... (same filters as SearchCandidates)
-// @Success      200  {file}    binary
-// @Failure      400  {object}  response.Response
-// @Failure      403  {object}  response.Response
-// @Router       /admin/ats/export [get]
-func (h *ATSHandler) ExportCandidates(c *gin.Context) {
-	// Parse the same filters as SearchCandidates
+// parseATSFilter reads the candidate filters shared by search and export (not pagination)
+func parseATSFilter(c *gin.Context) domain.ATSFilter {
 	filter := domain.ATSFilter{}
 
+	// Parse Japanese Proficiency Group
 	if levels := c.Query("japanese_levels"); levels != "" {
 		filter.JapaneseLevels = strings.Split(levels, ",")
 	}
@@ -200,6 +461,13 @@ func (h *ATSHandler) ExportCandidates(c *gin.Context) {
 		v := lpk == "true"
 		filter.HasLPKTraining = &v
 	}
+	if cohorts := c.Query("cohort_ids"); cohorts != "" {
+		for _, id := range parseIntArray(cohorts) {
+			filter.CohortIDs = append(filter.CohortIDs, int64(id))
+		}
+	}
+
+	// Parse Competency & Language Group
 	if certs := c.Query("english_cert_types"); certs != "" {
 		filter.EnglishCertTypes = strings.Split(certs, ",")
 	}
@@ -214,6 +482,8 @@ func (h *ATSHandler) ExportCandidates(c *gin.Context) {
 	if skills := c.Query("computer_skill_ids"); skills != "" {
 		filter.ComputerSkillIDs = parseIntArray(skills)
 	}
+
+	// Parse Logistics & Availability Group
 	if min := c.Query("age_min"); min != "" {
 		if v, err := strconv.Atoi(min); err == nil {
 			filter.AgeMin = &v
@@ -245,6 +515,8 @@ func (h *ATSHandler) ExportCandidates(c *gin.Context) {
 			filter.AvailableStartBefore = &t
 		}
 	}
+
+	// Parse Education & Experience Group
 	if levels := c.Query("education_levels"); levels != "" {
 		filter.EducationLevels = strings.Split(levels, ",")
 	}
@@ -262,52 +534,85 @@ func (h *ATSHandler) ExportCandidates(c *gin.Context) {
 		}
 	}
 
-	// Parse export-specific params
-	format := c.DefaultQuery("format", "xlsx")
-	var columns []string
-	if cols := c.Query("columns"); cols != "" {
-		columns = strings.Split(cols, ",")
-	}
+	// Parse Trust Signals Group
+	filter.PhoneVerifiedOnly = c.Query("phone_verified_only") == "true"
 
-	req := domain.ATSExportRequest{
-		Filter:  filter,
-		Columns: columns,
-		Format:  format,
-	}
+	// Parse Visa Readiness Group
+	parseVisaReadinessFilter(c, &filter)
+
+	// Parse Skill Quiz Group
+	parseQuizScoreFilter(c, &filter)
+
+	return filter
+}
+
+// parseATSPagination reads pagination and sorting
+func parseATSPagination(c *gin.Context, filter *domain.ATSFilter) {
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	// A saved filter's sort is the default, query parameters still override it
+	filter.SortBy = c.DefaultQuery("sort_by", cmp.Or(filter.SortBy, "verified_at"))
+	filter.SortOrder = c.DefaultQuery("sort_order", cmp.Or(filter.SortOrder, "desc"))
+}
 
-	data, filename, err := h.atsUC.ExportCandidates(c, req)
+// adminATSFilter runs the saved filter named by filter_id, or parses the filter
+// parameters when there is none
+func (h *ATSHandler) adminATSFilter(c *gin.Context) (domain.ATSFilter, bool) {
+	raw := c.Query("filter_id")
+	if raw == "" {
+		return parseATSFilter(c), true
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
 	if err != nil {
-		c.Error(apperror.BadRequest(err.Error()))
-		return
+		c.Error(apperror.BadRequest("Invalid filter ID"))
+		return domain.ATSFilter{}, false
 	}
-
-	// Set content type based on format
-	contentType := "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-	if format == "csv" {
-		contentType = "text/csv"
+	filter, err := h.savedFilterUC.UseFilter(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return domain.ATSFilter{}, false
 	}
+	return *filter, true
+}
 
-	c.Header("Content-Disposition", "attachment; filename="+filename)
-	c.Data(http.StatusOK, contentType, data)
+// atsError keeps authorization errors; anything else is a filter validation error
+func atsError(err error) *apperror.AppError {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperror.BadRequest(err.Error())
 }
 
-// GetFilterOptions godoc
-// @Summary      Get available filter options
-// @Description  Returns all available filter options for the ATS UI
-// @Tags         admin-ats
-// @Produce      json
-// @Security     BearerAuth
-// @Success      200  {object}  response.Response
-// @Failure      403  {object}  response.Response
-// @Router       /admin/ats/filter-options [get]
-func (h *ATSHandler) GetFilterOptions(c *gin.Context) {
-	options, err := h.atsUC.GetFilterOptions(c)
-	if err != nil {
-		c.Error(apperror.Internal(err))
-		return
+// parseVisaReadinessFilter reads the CoE, SSW exam and document expiry filters
+func parseVisaReadinessFilter(c *gin.Context, filter *domain.ATSFilter) {
+	filter.ExpiringDocumentsOnly = c.Query("expiring_documents_only") == "true"
+	if statuses := c.Query("coe_statuses"); statuses != "" {
+		filter.CoEStatuses = strings.Split(statuses, ",")
+	}
+	filter.VisaReadyOnly = c.Query("visa_ready_only") == "true"
+	if sectors := c.Query("ssw_sectors"); sectors != "" {
+		filter.SSWSectors = strings.Split(sectors, ",")
+	}
+	if level := c.Query("ssw_level"); level != "" {
+		if v, err := strconv.Atoi(level); err == nil {
+			filter.SSWLevel = &v
+		}
 	}
+}
 
-	response.Success(c, http.StatusOK, "Filter options retrieved", options)
+// parseQuizScoreFilter reads the skill quiz score filter
+func parseQuizScoreFilter(c *gin.Context, filter *domain.ATSFilter) {
+	if quizID := c.Query("quiz_id"); quizID != "" {
+		if v, err := strconv.ParseInt(quizID, 10, 64); err == nil {
+			filter.QuizID = &v
+		}
+	}
+	if score := c.Query("quiz_min_score"); score != "" {
+		if v, err := strconv.Atoi(score); err == nil {
+			filter.QuizMinScore = &v
+		}
+	}
 }
 
 // parseIntArray parses a comma-separated string into an int array
@@ -0,0 +1,165 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ATSSavedFilterHandler struct {
+	savedFilterUC domain.ATSSavedFilterUsecase
+}
+
+// NewATSSavedFilterHandler registers admins' saved ATS filters; the ATS search
+// and export run them through filter_id
+func NewATSSavedFilterHandler(protected *gin.RouterGroup, savedFilterUC domain.ATSSavedFilterUsecase) {
+	handler := &ATSSavedFilterHandler{savedFilterUC: savedFilterUC}
+
+	filters := protected.Group("/admin/ats/filters")
+	{
+		filters.GET("", handler.ListFilters)
+		filters.POST("", handler.CreateFilter)
+		filters.GET("/:id", handler.GetFilter)
+		filters.PUT("/:id", handler.UpdateFilter)
+		filters.DELETE("/:id", handler.DeleteFilter)
+	}
+}
+
+// ListFilters godoc
+// @Summary      List saved ATS filters
+// @Description  The caller's own filters first, then those other admins shared
+// @Tags         admin-ats
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.ATSSavedFilter}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/ats/filters [get]
+func (h *ATSSavedFilterHandler) ListFilters(c *gin.Context) {
+	filters, err := h.savedFilterUC.ListFilters(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved filters retrieved", filters)
+}
+
+// CreateFilter godoc
+// @Summary      Save an ATS filter
+// @Description  The filter is validated like a search and stored without pagination. shared=true shows it to every admin.
+// @Tags         admin-ats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.ATSSavedFilterRequest  true  "Saved filter"
+// @Success      201      {object}  response.Response{data=domain.ATSSavedFilter}
+// @Failure      400      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /admin/ats/filters [post]
+func (h *ATSSavedFilterHandler) CreateFilter(c *gin.Context) {
+	var req domain.ATSSavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	filter, err := h.savedFilterUC.CreateFilter(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Filter saved", filter)
+}
+
+// GetFilter godoc
+// @Summary      Get a saved ATS filter
+// @Tags         admin-ats
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Saved filter ID"
+// @Success      200  {object}  response.Response{data=domain.ATSSavedFilter}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/ats/filters/{id} [get]
+func (h *ATSSavedFilterHandler) GetFilter(c *gin.Context) {
+	id, ok := parseSavedFilterID(c)
+	if !ok {
+		return
+	}
+
+	filter, err := h.savedFilterUC.GetFilter(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved filter retrieved", filter)
+}
+
+// UpdateFilter godoc
+// @Summary      Replace a saved ATS filter
+// @Description  Owner only
+// @Tags         admin-ats
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                           true  "Saved filter ID"
+// @Param        request  body      domain.ATSSavedFilterRequest  true  "Saved filter"
+// @Success      200      {object}  response.Response{data=domain.ATSSavedFilter}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /admin/ats/filters/{id} [put]
+func (h *ATSSavedFilterHandler) UpdateFilter(c *gin.Context) {
+	id, ok := parseSavedFilterID(c)
+	if !ok {
+		return
+	}
+	var req domain.ATSSavedFilterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	filter, err := h.savedFilterUC.UpdateFilter(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved filter updated", filter)
+}
+
+// DeleteFilter godoc
+// @Summary      Delete a saved ATS filter
+// @Description  Owner only
+// @Tags         admin-ats
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Saved filter ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/ats/filters/{id} [delete]
+func (h *ATSSavedFilterHandler) DeleteFilter(c *gin.Context) {
+	id, ok := parseSavedFilterID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.savedFilterUC.DeleteFilter(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved filter deleted", nil)
+}
+
+func parseSavedFilterID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid filter ID"))
+		return 0, false
+	}
+	return id, true
+}
@@ -3,14 +3,20 @@ package v1
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-recruitment-backend/config"
+	"go-recruitment-backend/internal/delivery/http/middleware"
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/auth"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
 	"go-recruitment-backend/pkg/security"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -19,16 +25,20 @@ import (
 type AuthHandler struct {
 	authUC       domain.AuthUsecase
 	onboardingUC domain.OnboardingUsecase
+	inviteUC     domain.InviteCodeUsecase
 	config       *config.Config
 	loginTracker *security.LoginTracker
+	refresher    *auth.RefreshService
 }
 
-func NewAuthHandler(public *gin.RouterGroup, protected *gin.RouterGroup, authUC domain.AuthUsecase, onboardingUC domain.OnboardingUsecase, paramsConfig *config.Config, loginTracker *security.LoginTracker) {
+func NewAuthHandler(public *gin.RouterGroup, protected *gin.RouterGroup, authUC domain.AuthUsecase, onboardingUC domain.OnboardingUsecase, inviteUC domain.InviteCodeUsecase, paramsConfig *config.Config, loginTracker *security.LoginTracker, refresher *auth.RefreshService) {
 	handler := &AuthHandler{
 		authUC:       authUC,
 		onboardingUC: onboardingUC,
+		inviteUC:     inviteUC,
 		config:       paramsConfig,
 		loginTracker: loginTracker,
+		refresher:    refresher,
 	}
 
 	// Public Routes
@@ -36,8 +46,12 @@ func NewAuthHandler(public *gin.RouterGroup, protected *gin.RouterGroup, authUC
 	{
 		publicAuth.POST("/login", handler.Login)
 		publicAuth.POST("/register", handler.Register)
+		publicAuth.GET("/registration-settings", handler.RegistrationSettings) // Roles that need an invite code
 		publicAuth.POST("/forgot-password", handler.ForgotPassword)
 		publicAuth.POST("/reset-password", handler.ResetPassword)
+		// Public so clients holding only an expired access token can still use them
+		publicAuth.POST("/refresh", handler.Refresh)
+		publicAuth.POST("/logout", handler.Logout)
 		// Note: Email verification is handled directly by Supabase via email link
 	}
 
@@ -46,6 +60,9 @@ func NewAuthHandler(public *gin.RouterGroup, protected *gin.RouterGroup, authUC
 	{
 		protectedAuth.POST("/sync", handler.SyncProfile)
 		protectedAuth.GET("/me", handler.Me)
+		protectedAuth.POST("/me/pause", handler.PauseProfile)
+		protectedAuth.DELETE("/me/pause", handler.ResumeProfile)
+		protectedAuth.PUT("/me/locale", handler.UpdateLocale)
 	}
 }
 
@@ -57,16 +74,36 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshRequest struct {
+	// Optional for browsers, which send the refresh_token cookie instead
+	RefreshToken string `json:"refresh_token"`
+}
+
 type RegisterRequest struct {
 	Email        string `json:"email" binding:"required,email"`
 	Password     string `json:"password" binding:"required,min=6"`
 	Role         string `json:"role" binding:"required,oneof=candidate employer"`
-	CaptchaToken string `json:"captchaToken"` // Cloudflare Turnstile Token
+	CaptchaToken string `json:"captchaToken"`                 // Cloudflare Turnstile Token
+	InviteCode   string `json:"invite_code" binding:"max=64"` // Required while registration is invite-only for the role
+}
+
+// LoginResponse carries the Supabase session and the local user
+type LoginResponse struct {
+	Token        string       `json:"token"`
+	RefreshToken string       `json:"refresh_token"`
+	ExpiresIn    int          `json:"expires_in"`
+	User         *domain.User `json:"user"`
+}
+
+// RegisterResponse is only returned when Supabase confirms the email immediately
+type RegisterResponse struct {
+	Token string       `json:"token"`
+	User  *domain.User `json:"user"`
 }
 
 // Register godoc
 // @Summary      User Registration
-// @Description  Register a new user with email, password, and role. Supports Turnstile Captcha.
+// @Description  Register a new user with email, password, and role. Supports Turnstile Captcha. While registration is invite-only for the role (see GET /auth/registration-settings), invite_code must be a usable code; a signup that fails gives the use back.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -82,6 +119,20 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	// Invite gate: take one use of the code now so concurrent signups cannot
+	// overshoot it, and give it back unless Supabase accepts the signup
+	redemptionID, err := h.inviteUC.Reserve(c.Request.Context(), req.InviteCode, req.Role, req.Email, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	signedUp := false
+	defer func() {
+		if !signedUp {
+			h.inviteUC.Release(c.Request.Context(), redemptionID)
+		}
+	}()
+
 	// 1. Prepare Request to Supabase Auth API
 	// We use direct HTTP client to pass custom Captcha headers, which gotrue-go might not support directly per-request.
 	supabaseURL := h.config.SupabaseUrl
@@ -112,7 +163,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 	jsonBody, _ := json.Marshal(reqBody)
 
-	httpReq, err := http.NewRequest("POST", signupURL, bytes.NewBuffer(jsonBody))
+	httpReq, err := http.NewRequestWithContext(c.Request.Context(), "POST", signupURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		c.Error(apperror.Internal(err))
 		return
@@ -140,7 +191,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(httpReq)
 	if err != nil {
-		fmt.Printf("Supabase Request Error: %v\n", err)
+		logger.FromContext(c.Request.Context()).Error("Supabase signup request failed", "error", err)
 		c.Error(apperror.New(http.StatusInternalServerError, "Registration service unavailable", err))
 		return
 	}
@@ -150,7 +201,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	if resp.StatusCode >= 400 {
 		var errResp map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&errResp)
-		fmt.Printf("Supabase Error Response: %v\n", errResp)
+		logger.FromContext(c.Request.Context()).Warn("Supabase rejected signup", "status", resp.StatusCode, "response", errResp)
 
 		msg := "Registration failed"
 		if m, ok := errResp["msg"].(string); ok {
@@ -172,6 +223,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		c.Error(apperror.New(http.StatusInternalServerError, "Failed to parse response", err))
 		return
 	}
+	signedUp = true
+	h.inviteUC.Complete(c.Request.Context(), redemptionID, supabaseUser.ID)
 
 	// 5. Response - User will be synced to local DB on first login (after email verification)
 	// This ensures email must be verified before the user exists in our database
@@ -191,9 +244,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			return
 		}
 		msg = "Registration successful"
-		data = gin.H{
-			"token": supabaseUser.AccessToken,
-			"user":  user,
+		data = &RegisterResponse{
+			Token: supabaseUser.AccessToken,
+			User:  user,
 		}
 	}
 
@@ -201,6 +254,17 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 }
 
+// RegistrationSettings godoc
+// @Summary      Get registration settings
+// @Description  Roles whose registration needs an invite code during a soft launch; an empty list means registration is open
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  response.Response{data=domain.RegistrationSettings}
+// @Router       /auth/registration-settings [get]
+func (h *AuthHandler) RegistrationSettings(c *gin.Context) {
+	response.Success(c, http.StatusOK, "Registration settings", h.inviteUC.Settings())
+}
+
 // Login godoc
 // @Summary      User Login
 // @Description  Login with email and password via Supabase
@@ -237,7 +301,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		isBlocked, err := h.loginTracker.IsBlocked(c.Request.Context(), req.Email, c.ClientIP())
 		if err != nil {
 			// Log error but proceed (fail open) - Redis might be unavailable
-			fmt.Printf("Error checking block status: %v\n", err)
+			logger.FromContext(c.Request.Context()).Warn("Failed to check login block status", "error", err)
 		}
 		if isBlocked {
 			// Return 429 Too Many Requests
@@ -265,7 +329,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 	jsonBody, _ := json.Marshal(reqBody)
 
-	httpReq, err := http.NewRequest("POST", loginURL, bytes.NewBuffer(jsonBody))
+	httpReq, err := http.NewRequestWithContext(c.Request.Context(), "POST", loginURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		c.Error(apperror.Internal(err))
 		return
@@ -281,7 +345,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(httpReq)
 	if err != nil {
-		fmt.Printf("Supabase Login Error: %v\n", err)
+		logger.FromContext(c.Request.Context()).Error("Supabase login request failed", "error", err)
 		c.Error(apperror.New(http.StatusInternalServerError, "Login service unavailable", err))
 		return
 	}
@@ -290,7 +354,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	if resp.StatusCode >= 400 {
 		var errResp map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&errResp)
-		fmt.Printf("Supabase Login Error: response status code %d: %s\n", resp.StatusCode, debugJSON(errResp)) // Helper or just stringify
+		// Sampled: credential stuffing can produce thousands of these per second
+		logger.Sampled(c.Request.Context(), "auth.login_failed").Warn("Login failed", "status", resp.StatusCode, "response", errResp)
 
 		msg := "Wrong Password Or Account Not Found!"
 		// If captcha failed, be specific if possible, though usually it's just 400
@@ -319,7 +384,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			}
 			_, _, err := h.loginTracker.RecordFailedAttempt(c.Request.Context(), req.Email, c.ClientIP(), c.Request.UserAgent(), reqID)
 			if err != nil {
-				fmt.Printf("Failed to record login attempt: %v\n", err)
+				logger.FromContext(c.Request.Context()).Error("Failed to record login attempt", "error", err)
 			}
 		}
 
@@ -327,8 +392,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	var supabaseUser struct {
-		User        domain.User `json:"user"`
-		AccessToken string      `json:"access_token"`
+		User         domain.User `json:"user"`
+		AccessToken  string      `json:"access_token"`
+		RefreshToken string      `json:"refresh_token"`
+		ExpiresIn    int         `json:"expires_in"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&supabaseUser); err != nil {
 		c.Error(apperror.New(http.StatusInternalServerError, "Failed to parse login response", err))
@@ -338,7 +405,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// SECURITY: Clear failed attempts on successful login
 	if h.loginTracker != nil {
 		if err := h.loginTracker.ClearAttempts(c.Request.Context(), req.Email, c.ClientIP()); err != nil {
-			fmt.Printf("Failed to clear attempts: %v\n", err)
+			logger.FromContext(c.Request.Context()).Warn("Failed to clear login attempts", "error", err)
 		}
 	}
 
@@ -363,16 +430,135 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Login successful", gin.H{
-		"token": supabaseUser.AccessToken,
-		"user":  actualUser,
+	// Track the refresh token so it can be rotated and revoked. Login still
+	// succeeds without it; the client just has to log in again on expiry.
+	if h.refresher != nil && supabaseUser.RefreshToken != "" {
+		if err := h.refresher.Track(c.Request.Context(), user.ID, supabaseUser.RefreshToken, c.ClientIP(), c.Request.UserAgent()); err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Failed to store refresh token", "error", err)
+		} else {
+			middleware.SetAuthCookies(c, &auth.TokenPair{
+				AccessToken:  supabaseUser.AccessToken,
+				RefreshToken: supabaseUser.RefreshToken,
+				ExpiresIn:    supabaseUser.ExpiresIn,
+			})
+		}
+	}
+
+	response.Success(c, http.StatusOK, "Login successful", LoginResponse{
+		Token:        supabaseUser.AccessToken,
+		RefreshToken: supabaseUser.RefreshToken,
+		ExpiresIn:    supabaseUser.ExpiresIn,
+		User:         actualUser,
 	})
 }
 
-// debugJSON Helper
-func debugJSON(v interface{}) string {
-	b, _ := json.Marshal(v)
-	return string(b)
+// Refresh godoc
+// @Summary      Refresh the access token
+// @Description  Exchanges a refresh token (body or refresh_token cookie) for a new access and refresh token. Each refresh token works once; replaying a used one revokes the whole session.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        refresh  body      RefreshRequest  false  "Refresh token (omit to use the cookie)"
+// @Success      200  {object}  response.Response{data=auth.TokenPair}
+// @Failure      400  {object}  response.Response
+// @Failure      401  {object}  response.Response
+// @Failure      503  {object}  response.Response
+// @Router       /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+	fromCookie := false
+	if req.RefreshToken == "" {
+		req.RefreshToken, _ = c.Cookie(middleware.RefreshCookieName)
+		fromCookie = req.RefreshToken != ""
+	}
+	if req.RefreshToken == "" {
+		c.Error(apperror.BadRequest("Refresh token required"))
+		return
+	}
+	if h.refresher == nil {
+		c.Error(apperror.New(http.StatusServiceUnavailable, "Token refresh is not available", nil))
+		return
+	}
+
+	pair, err := h.refresher.Rotate(c.Request.Context(), req.RefreshToken, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrRefreshTokenReused):
+			middleware.LogRefreshTokenReuse(c)
+			middleware.ClearAuthCookies(c)
+			c.Error(apperror.Unauthorized("Invalid refresh token"))
+		case errors.Is(err, auth.ErrInvalidRefreshToken):
+			if fromCookie {
+				middleware.ClearAuthCookies(c)
+			}
+			c.Error(apperror.Unauthorized("Invalid refresh token"))
+		case errors.Is(err, auth.ErrRefreshTokenRotated):
+			c.Error(apperror.Conflict("Token was already refreshed, retry with the latest refresh token"))
+		case errors.Is(err, auth.ErrRefreshUnavailable):
+			logger.FromContext(c.Request.Context()).Error("Supabase refresh request failed", "error", err)
+			c.Error(apperror.New(http.StatusServiceUnavailable, "Login service unavailable", err))
+		default:
+			c.Error(apperror.Internal(errors.New("Failed to refresh token: " + err.Error())))
+		}
+		return
+	}
+
+	if fromCookie {
+		middleware.SetAuthCookies(c, pair)
+	}
+	response.Success(c, http.StatusOK, "Token refreshed", pair)
+}
+
+// Logout godoc
+// @Summary      Log out
+// @Description  Revokes the refresh token (body or cookie) with every token rotated from it, ends the Supabase session when an access token is sent, and clears the auth cookies
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        refresh  body      RefreshRequest  false  "Refresh token (omit to use the cookie)"
+// @Success      200  {object}  response.Response
+// @Failure      400  {object}  response.Response
+// @Router       /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req RefreshRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+	if req.RefreshToken == "" {
+		req.RefreshToken, _ = c.Cookie(middleware.RefreshCookieName)
+	}
+
+	if h.refresher != nil {
+		ctx := c.Request.Context()
+		if err := h.refresher.Revoke(ctx, req.RefreshToken, auth.RevokeReasonLogout); err != nil {
+			c.Error(apperror.Internal(errors.New("Failed to revoke refresh token: " + err.Error())))
+			return
+		}
+
+		accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if accessToken == "" {
+			accessToken, _ = c.Cookie(middleware.AuthCookieName)
+		}
+		if accessToken != "" {
+			// Best effort: an expired access token cannot end the Supabase session,
+			// but the revoked refresh token is refused here either way
+			if err := h.refresher.SignOut(ctx, accessToken); err != nil {
+				logger.FromContext(ctx).Warn("Supabase logout failed", "error", err)
+			}
+		}
+	}
+
+	middleware.ClearAuthCookies(c)
+	response.Success(c, http.StatusOK, "Logged out", nil)
 }
 
 func (h *AuthHandler) SyncProfile(c *gin.Context) {
@@ -403,7 +589,7 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	}
 
 	// For candidates, check onboarding status
-	if user.Role == "candidate" { // Todo: Use domain constant if available
+	if user.Role == domain.RoleCandidate {
 		status, err := h.onboardingUC.GetOnboardingStatus(c, userID)
 		if err == nil && status != nil {
 			user.OnboardingCompleted = &status.Completed
@@ -417,6 +603,89 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	response.Success(c, http.StatusOK, "User details", user)
 }
 
+// PauseProfile godoc
+// @Summary      Pause candidate profile
+// @Description  Temporarily hide the candidate from employer/admin search and job alerts. Reactivates automatically at the end date.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      domain.PauseProfileRequest  true  "Pause end date and optional reason"
+// @Success      200      {object}  response.Response{data=domain.User}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /auth/me/pause [post]
+// @Security     BearerAuth
+func (h *AuthHandler) PauseProfile(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	var req domain.PauseProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	user, err := h.authUC.PauseProfile(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Profile paused", user)
+}
+
+// ResumeProfile godoc
+// @Summary      Resume candidate profile
+// @Description  End an active profile pause early
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  response.Response{data=domain.User}
+// @Router       /auth/me/pause [delete]
+// @Security     BearerAuth
+func (h *AuthHandler) ResumeProfile(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	user, err := h.authUC.ResumeProfile(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Profile resumed", user)
+}
+
+// UpdateLocale godoc
+// @Summary      Set preferred language
+// @Description  Language for API messages (id, en, ja). Overrides Accept-Language; an empty locale clears the preference.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request  body      domain.UpdateLocaleRequest  true  "Locale"
+// @Success      200      {object}  response.Response{data=domain.User}
+// @Failure      400      {object}  response.Response
+// @Router       /auth/me/locale [put]
+// @Security     BearerAuth
+func (h *AuthHandler) UpdateLocale(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	var req domain.UpdateLocaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	user, err := h.authUC.UpdatePreferredLocale(c.Request.Context(), userID, req.Locale)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Respond in the newly chosen language
+	if user.PreferredLocale != nil {
+		c.Set(i18n.ContextKey, *user.PreferredLocale)
+	}
+	response.Success(c, http.StatusOK, "Preferred language updated", user)
+}
+
 // ForgotPasswordRequest for requesting password reset email
 type ForgotPasswordRequest struct {
 	Email        string `json:"email" binding:"required,email"`
@@ -457,7 +726,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	exists, err := h.authUC.CheckEmailExists(c.Request.Context(), req.Email)
 	if err != nil {
 		// Log internally but don't expose to user
-		fmt.Printf("ForgotPassword check error (non-fatal): %v\n", err)
+		logger.FromContext(c.Request.Context()).Warn("ForgotPassword email check failed (non-fatal)", "error", err)
 		// Apply artificial delay to maintain constant response time
 		h.simulateDelay(start, targetDuration)
 		response.Success(c, http.StatusOK, successMessage, nil)
@@ -502,10 +771,10 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	}
 	jsonBody, _ := json.Marshal(reqBody)
 
-	httpReq, err := http.NewRequest("POST", recoveryURL, bytes.NewBuffer(jsonBody))
+	httpReq, err := http.NewRequestWithContext(c.Request.Context(), "POST", recoveryURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		// Log internally but return same success message
-		fmt.Printf("ForgotPassword request creation error: %v\n", err)
+		logger.FromContext(c.Request.Context()).Error("ForgotPassword request creation failed", "error", err)
 		h.simulateDelay(start, targetDuration) // Ensure constant timing
 		response.Success(c, http.StatusOK, successMessage, nil)
 		return
@@ -522,7 +791,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 	resp, err := client.Do(httpReq)
 	if err != nil {
 		// Log internally but don't reveal failure to user
-		fmt.Printf("Supabase Recovery Error: %v\n", err)
+		logger.FromContext(c.Request.Context()).Error("Supabase recovery request failed", "error", err)
 		h.simulateDelay(start, targetDuration) // Ensure constant timing
 		response.Success(c, http.StatusOK, successMessage, nil)
 		return
@@ -533,7 +802,7 @@ func (h *AuthHandler) ForgotPassword(c *gin.Context) {
 		var errResp map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&errResp)
 		// Log the actual error internally
-		fmt.Printf("Supabase Recovery Error Response (non-fatal): %v\n", errResp)
+		logger.FromContext(c.Request.Context()).Warn("Supabase rejected recovery request (non-fatal)", "status", resp.StatusCode, "response", errResp)
 		// Still return success to user - don't reveal if email exists or if there's a backend issue
 	}
 
@@ -589,7 +858,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	}
 	jsonBody, _ := json.Marshal(reqBody)
 
-	httpReq, err := http.NewRequest("PUT", updateURL, bytes.NewBuffer(jsonBody))
+	httpReq, err := http.NewRequestWithContext(c.Request.Context(), "PUT", updateURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		c.Error(apperror.Internal(err))
 		return
@@ -603,7 +872,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(httpReq)
 	if err != nil {
-		fmt.Printf("Supabase Password Update Error: %v\n", err)
+		logger.FromContext(c.Request.Context()).Error("Supabase password update request failed", "error", err)
 		c.Error(apperror.New(http.StatusInternalServerError, "Password update service unavailable", err))
 		return
 	}
@@ -612,7 +881,7 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	if resp.StatusCode >= 400 {
 		var errResp map[string]interface{}
 		json.NewDecoder(resp.Body).Decode(&errResp)
-		fmt.Printf("Supabase Password Update Error Response: %v\n", errResp)
+		logger.FromContext(c.Request.Context()).Warn("Supabase rejected password update", "status", resp.StatusCode, "response", errResp)
 
 		msg := "Password reset failed"
 		if m, ok := errResp["msg"].(string); ok {
@@ -624,5 +893,13 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 		return
 	}
 
+	// Supabase answers with the updated user; let them know their password changed
+	var updated struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&updated); err == nil && updated.ID != "" {
+		h.authUC.NotifyPasswordChanged(c.Request.Context(), updated.ID)
+	}
+
 	response.Success(c, http.StatusOK, "Password has been reset successfully. You can now login with your new password.", nil)
 }
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CandidateActivityHandler struct {
+	activityUC domain.CandidateActivityUsecase
+}
+
+// NewCandidateActivityHandler registers the candidate activity feed route
+func NewCandidateActivityHandler(protected *gin.RouterGroup, activityUC domain.CandidateActivityUsecase) {
+	handler := &CandidateActivityHandler{activityUC: activityUC}
+
+	protected.GET("/candidates/me/activity", handler.ListMyActivity)
+}
+
+// ListMyActivity godoc
+// @Summary      My activity feed
+// @Description  Newest first. Pass the last id seen as before to fetch older entries.
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        before  query     int  false  "Return entries older than this id"
+// @Param        limit   query     int  false  "Entries to return (default 20, max 100)"
+// @Success      200     {object}  response.Response{data=[]domain.CandidateActivity}
+// @Failure      403     {object}  response.Response
+// @Router       /candidates/me/activity [get]
+func (h *CandidateActivityHandler) ListMyActivity(c *gin.Context) {
+	before, _ := strconv.ParseInt(c.Query("before"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	userID := c.GetString(string(domain.KeyUserID))
+	activities, err := h.activityUC.ListMyActivity(c.Request.Context(), userID, before, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Activity retrieved", activities)
+}
@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CandidateDocumentHandler struct {
+	documentUC domain.CandidateDocumentUsecase
+}
+
+// NewCandidateDocumentHandler registers candidate document vault and admin review routes
+func NewCandidateDocumentHandler(protected *gin.RouterGroup, documentUC domain.CandidateDocumentUsecase) {
+	handler := &CandidateDocumentHandler{documentUC: documentUC}
+
+	// Candidate: own document vault
+	documents := protected.Group("/candidates/me/documents")
+	{
+		documents.GET("", handler.ListMyDocuments)
+		documents.POST("", handler.CreateDocument)
+		documents.DELETE("/:id", handler.DeleteDocument)
+	}
+
+	// Admin: review queue
+	admin := protected.Group("/admin/candidate-documents")
+	{
+		admin.GET("", handler.ListDocuments)
+		admin.POST("/:id/review", handler.ReviewDocument)
+	}
+}
+
+// ListMyDocuments godoc
+// @Summary      List my vault documents
+// @Description  Documents by type, newest first, with review status and an expiry flag
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.CandidateDocument}
+// @Failure      403  {object}  response.Response
+// @Router       /candidates/me/documents [get]
+func (h *CandidateDocumentHandler) ListMyDocuments(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	docs, err := h.documentUC.ListMyDocuments(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Documents retrieved", docs)
+}
+
+// CreateDocument godoc
+// @Summary      Add a document to my vault
+// @Description  Upload the file first with POST /upload?bucket=Candidate_Documents. Passports and visas need an expiry date; expiry reminders are sent at 90, 30 and 7 days.
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.CreateCandidateDocumentRequest  true  "Document type, file and dates"
+// @Success      201      {object}  response.Response{data=domain.CandidateDocument}
+// @Failure      400      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /candidates/me/documents [post]
+func (h *CandidateDocumentHandler) CreateDocument(c *gin.Context) {
+	var req domain.CreateCandidateDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	doc, err := h.documentUC.CreateDocument(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Document added", doc)
+}
+
+// DeleteDocument godoc
+// @Summary      Delete a vault document
+// @Description  The stored file is queued for deletion
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Document ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/documents/{id} [delete]
+func (h *CandidateDocumentHandler) DeleteDocument(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid document ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.documentUC.DeleteDocument(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Document deleted", nil)
+}
+
+// ListDocuments godoc
+// @Summary      List candidate documents for review
+// @Description  Oldest first. status defaults to PENDING; ALL lists every status.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status        query     string  false  "PENDING, APPROVED, REJECTED or ALL"
+// @Param        documentType  query     string  false  "PASSPORT, JLPT, DIPLOMA or VISA"
+// @Param        page          query     int     false  "Page number"
+// @Param        pageSize      query     int     false  "Items per page"
+// @Success      200           {object}  response.Response{data=domain.PaginatedResult[domain.AdminCandidateDocument]}
+// @Failure      400           {object}  response.Response
+// @Failure      403           {object}  response.Response
+// @Router       /admin/candidate-documents [get]
+func (h *CandidateDocumentHandler) ListDocuments(c *gin.Context) {
+	filter := domain.CandidateDocumentFilter{
+		Status:       c.Query("status"),
+		DocumentType: c.Query("documentType"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.documentUC.ListDocuments(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Documents retrieved", result)
+}
+
+// ReviewDocument godoc
+// @Summary      Approve or reject a candidate document
+// @Description  The candidate is notified; notes are required to reject. Rejected documents get no expiry reminders.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                    true  "Document ID"
+// @Param        request  body      domain.ReviewCandidateDocumentRequest  true  "Decision and notes"
+// @Success      200      {object}  response.Response{data=domain.CandidateDocument}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /admin/candidate-documents/{id}/review [post]
+func (h *CandidateDocumentHandler) ReviewDocument(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid document ID"))
+		return
+	}
+
+	var req domain.ReviewCandidateDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	adminID := c.GetString(string(domain.KeyUserID))
+	doc, err := h.documentUC.ReviewDocument(c.Request.Context(), adminID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Document reviewed", doc)
+}
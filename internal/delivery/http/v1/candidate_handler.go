@@ -23,6 +23,8 @@ func NewCandidateHandler(r *gin.RouterGroup, candidateUC domain.CandidateUsecase
 		candidates.GET("/me/full", handler.GetFullProfile)    // New Endpoint
 		candidates.PUT("/me/full", handler.UpdateFullProfile) // New Endpoint
 		candidates.GET("/skills", handler.GetMasterSkills)    // Helper Endpoint
+		candidates.GET("/me/profile-refresh", handler.GetProfileRefresh)
+		candidates.PUT("/me/profile-refresh", handler.RefreshProfile)
 	}
 }
 
@@ -151,3 +153,51 @@ func (h *CandidateHandler) GetMasterSkills(c *gin.Context) {
 	}
 	response.Success(c, http.StatusOK, "Master skills", skills)
 }
+
+// GetProfileRefresh godoc
+// @Summary      Profile refresh status
+// @Description  Whether the candidate must refresh their profile after a long absence, with the availability, salary and documents to confirm
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CandidateProfileRefresh}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/profile-refresh [get]
+func (h *CandidateHandler) GetProfileRefresh(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	refresh, err := h.candidateUC.GetProfileRefresh(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Profile refresh status retrieved", refresh)
+}
+
+// RefreshProfile godoc
+// @Summary      Refresh my profile
+// @Description  Confirms or updates availability and expected salary and confirms documents are current. Puts a returning candidate back into employer search.
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.RefreshCandidateProfileRequest  true  "Refreshed profile"
+// @Success      200      {object}  response.Response{data=domain.CandidateProfileRefresh}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /candidates/me/profile-refresh [put]
+func (h *CandidateHandler) RefreshProfile(c *gin.Context) {
+	var req domain.RefreshCandidateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	refresh, err := h.candidateUC.RefreshProfile(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Profile refreshed", refresh)
+}
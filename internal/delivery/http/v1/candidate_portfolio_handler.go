@@ -0,0 +1,191 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CandidatePortfolioHandler struct {
+	portfolioUC domain.CandidatePortfolioUsecase
+}
+
+// NewCandidatePortfolioHandler registers candidate portfolio and employer portfolio view routes
+func NewCandidatePortfolioHandler(protected *gin.RouterGroup, portfolioUC domain.CandidatePortfolioUsecase) {
+	handler := &CandidatePortfolioHandler{portfolioUC: portfolioUC}
+
+	// Candidate: own work samples
+	portfolio := protected.Group("/candidates/me/portfolio")
+	{
+		portfolio.GET("", handler.GetMyPortfolio)
+		portfolio.POST("", handler.CreateItem)
+		portfolio.PUT("/order", handler.ReorderItems)
+		portfolio.PUT("/:id", handler.UpdateItem)
+		portfolio.DELETE("/:id", handler.DeleteItem)
+	}
+
+	// Employer: a visible candidate's portfolio
+	protected.GET("/employers/candidates/:userId/portfolio", handler.GetCandidatePortfolio)
+}
+
+// GetMyPortfolio godoc
+// @Summary      Get my portfolio
+// @Description  Work samples in display order, with the item count and storage used against the quota
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CandidatePortfolio}
+// @Failure      403  {object}  response.Response
+// @Router       /candidates/me/portfolio [get]
+func (h *CandidatePortfolioHandler) GetMyPortfolio(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	portfolio, err := h.portfolioUC.GetMyPortfolio(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Portfolio retrieved", portfolio)
+}
+
+// CreateItem godoc
+// @Summary      Add a work sample to my portfolio
+// @Description  Either a file (image or PDF) uploaded first with POST /upload?bucket=Candidate_Portfolio, or a link. Files count against the storage quota. New items go last.
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.CreatePortfolioItemRequest  true  "Title, description and a file or link"
+// @Success      201      {object}  response.Response{data=domain.CandidatePortfolioItem}
+// @Failure      400      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /candidates/me/portfolio [post]
+func (h *CandidatePortfolioHandler) CreateItem(c *gin.Context) {
+	var req domain.CreatePortfolioItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	item, err := h.portfolioUC.CreateItem(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Portfolio item added", item)
+}
+
+// UpdateItem godoc
+// @Summary      Edit a portfolio item
+// @Description  Title, description, resume inclusion and, for links, the URL. Replace a file by adding a new item.
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                true  "Portfolio item ID"
+// @Param        request  body      domain.UpdatePortfolioItemRequest  true  "Item fields"
+// @Success      200      {object}  response.Response{data=domain.CandidatePortfolioItem}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /candidates/me/portfolio/{id} [put]
+func (h *CandidatePortfolioHandler) UpdateItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid portfolio item ID"))
+		return
+	}
+	var req domain.UpdatePortfolioItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	item, err := h.portfolioUC.UpdateItem(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Portfolio item updated", item)
+}
+
+// DeleteItem godoc
+// @Summary      Delete a portfolio item
+// @Description  The stored file is queued for deletion
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Portfolio item ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/portfolio/{id} [delete]
+func (h *CandidatePortfolioHandler) DeleteItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid portfolio item ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.portfolioUC.DeleteItem(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Portfolio item deleted", nil)
+}
+
+// ReorderItems godoc
+// @Summary      Reorder my portfolio
+// @Description  item_ids lists every item of the portfolio in the new display order
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.ReorderPortfolioRequest  true  "Item IDs in display order"
+// @Success      200      {object}  response.Response{data=domain.CandidatePortfolio}
+// @Failure      400      {object}  response.Response
+// @Router       /candidates/me/portfolio/order [put]
+func (h *CandidatePortfolioHandler) ReorderItems(c *gin.Context) {
+	var req domain.ReorderPortfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	portfolio, err := h.portfolioUC.ReorderItems(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Portfolio reordered", portfolio)
+}
+
+// GetCandidatePortfolio godoc
+// @Summary      Get a candidate's portfolio
+// @Description  Only for candidates whose privacy settings make them visible to the company. Until the company unlocks the candidate's contact, links are left out and contact details in descriptions are redacted.
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId  path      string  true  "Candidate user ID"
+// @Success      200     {object}  response.Response{data=domain.EmployerCandidatePortfolio}
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Router       /employers/candidates/{userId}/portfolio [get]
+func (h *CandidatePortfolioHandler) GetCandidatePortfolio(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	portfolio, err := h.portfolioUC.GetCandidatePortfolio(c.Request.Context(), userID, c.Param("userId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	// The same URL returns different content after an unlock
+	c.Header("Cache-Control", "no-store")
+	response.Success(c, http.StatusOK, "Portfolio retrieved", portfolio)
+}
@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CandidateResumeHandler struct {
+	resumeUC domain.CandidateResumeUsecase
+}
+
+// NewCandidateResumeHandler registers the employer resume PDF route
+func NewCandidateResumeHandler(protected *gin.RouterGroup, resumeUC domain.CandidateResumeUsecase) {
+	handler := &CandidateResumeHandler{resumeUC: resumeUC}
+
+	protected.GET("/employers/candidates/:userId/resume", handler.GetResumePDF)
+}
+
+// GetResumePDF godoc
+// @Summary      Download a candidate resume as PDF
+// @Description  Redacted (initials, no contact details) until the company unlocks the candidate's contact, full afterwards. The mode is returned in X-Resume-Mode.
+// @Tags         employers
+// @Produce      application/pdf
+// @Security     BearerAuth
+// @Param        userId  path      string  true  "Candidate user ID"
+// @Success      200     {file}    binary
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Router       /employers/candidates/{userId}/resume [get]
+func (h *CandidateResumeHandler) GetResumePDF(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	resume, err := h.resumeUC.GetResumePDF(c.Request.Context(), userID, c.Param("userId"), domain.CandidateResumeRequest{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// The same URL returns different content after an unlock
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Resume-Mode", resume.Mode)
+	c.Header("Content-Disposition", "attachment; filename="+resume.Filename)
+	c.Data(http.StatusOK, "application/pdf", resume.Data)
+}
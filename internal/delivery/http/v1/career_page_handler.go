@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CareerPageHandler struct {
+	careerPageUC domain.CareerPageUsecase
+}
+
+// NewCareerPageHandler registers the public career page and employer career page management
+func NewCareerPageHandler(public, protected *gin.RouterGroup, careerPageUC domain.CareerPageUsecase) {
+	handler := &CareerPageHandler{careerPageUC: careerPageUC}
+
+	// Public: branded career page by slug. The wildcard is named :id because it
+	// shares the path segment with GET /companies/public/:id (gin requires one name).
+	public.GET("/companies/public/:id/career-page", handler.GetPublicCareerPage)
+
+	// Employer: own career page content and URL
+	employers := protected.Group("/employers/career-page")
+	{
+		employers.GET("", handler.GetOwnCareerPage)
+		employers.PUT("", handler.UpdateOwnCareerPage)
+		employers.GET("/slug-availability", handler.CheckSlug)
+		employers.PUT("/slug", handler.UpdateSlug)
+	}
+}
+
+// GetPublicCareerPage godoc
+// @Summary      Get a company's career page
+// @Description  Branding, culture content and active jobs for a published career page
+// @Tags         Company Profile
+// @Produce      json
+// @Param        slug  path      string  true  "Company slug"
+// @Success      200   {object}  response.Response{data=domain.PublicCareerPage}
+// @Failure      404   {object}  response.Response
+// @Router       /companies/public/{slug}/career-page [get]
+func (h *CareerPageHandler) GetPublicCareerPage(c *gin.Context) {
+	page, err := h.careerPageUC.GetPublicCareerPage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Career page", page)
+}
+
+// GetOwnCareerPage godoc
+// @Summary      Get own career page
+// @Tags         Company Profile
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CareerPage}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/career-page [get]
+func (h *CareerPageHandler) GetOwnCareerPage(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	page, err := h.careerPageUC.GetOwnCareerPage(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Career page retrieved", page)
+}
+
+// UpdateOwnCareerPage godoc
+// @Summary      Update own career page
+// @Description  Replaces branding and culture content; publishing requires a slug
+// @Tags         Company Profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.CareerPageRequest  true  "Career page content"
+// @Success      200      {object}  response.Response{data=domain.CareerPage}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /employers/career-page [put]
+func (h *CareerPageHandler) UpdateOwnCareerPage(c *gin.Context) {
+	var req domain.CareerPageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	page, err := h.careerPageUC.UpdateOwnCareerPage(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Career page updated", page)
+}
+
+// CheckSlug godoc
+// @Summary      Check career page URL availability
+// @Tags         Company Profile
+// @Produce      json
+// @Security     BearerAuth
+// @Param        slug  query     string  true  "Desired slug"
+// @Success      200   {object}  response.Response{data=domain.SlugAvailability}
+// @Router       /employers/career-page/slug-availability [get]
+func (h *CareerPageHandler) CheckSlug(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	result, err := h.careerPageUC.CheckSlug(c.Request.Context(), userID, c.Query("slug"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Slug availability", result)
+}
+
+// UpdateSlug godoc
+// @Summary      Set career page URL
+// @Tags         Company Profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.UpdateSlugRequest  true  "Slug"
+// @Success      200      {object}  response.Response{data=domain.CareerPage}
+// @Failure      400      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /employers/career-page/slug [put]
+func (h *CareerPageHandler) UpdateSlug(c *gin.Context) {
+	var req domain.UpdateSlugRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	page, err := h.careerPageUC.UpdateSlug(c.Request.Context(), userID, req.Slug)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Career page URL updated", page)
+}
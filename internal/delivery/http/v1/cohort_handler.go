@@ -0,0 +1,270 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CohortHandler struct {
+	cohortUC domain.CohortUsecase
+}
+
+// NewCohortHandler registers training cohort routes for admins (every LPK) and
+// the LPK portal (the partner's own LPK); both share the same handlers
+func NewCohortHandler(protected *gin.RouterGroup, cohortUC domain.CohortUsecase) {
+	handler := &CohortHandler{cohortUC: cohortUC}
+
+	for _, group := range []*gin.RouterGroup{protected.Group("/admin/cohorts"), protected.Group("/lpk/cohorts")} {
+		group.GET("", handler.ListCohorts)
+		group.POST("", handler.CreateCohort)
+		group.GET("/:id", handler.GetCohort)
+		group.PUT("/:id", handler.UpdateCohort)
+		group.DELETE("/:id", handler.DeleteCohort)
+		group.GET("/:id/members", handler.ListMembers)
+		group.POST("/:id/members", handler.AssignMembers)
+		group.DELETE("/:id/members/:ref", handler.RemoveMember)
+	}
+}
+
+// ListCohorts godoc
+// @Summary      List training cohorts with their progress
+// @Description  Admins see every LPK's cohorts (optionally one LPK's); LPK partners see their own
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        lpk_id  query     int  false  "Filter by LPK ID (admin only)"
+// @Success      200     {object}  response.Response{data=[]domain.Cohort}
+// @Failure      403     {object}  response.Response
+// @Router       /admin/cohorts [get]
+// @Router       /lpk/cohorts [get]
+func (h *CohortHandler) ListCohorts(c *gin.Context) {
+	var lpkID *int64
+	if raw := c.Query("lpk_id"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid LPK ID"))
+			return
+		}
+		lpkID = &v
+	}
+
+	cohorts, err := h.cohortUC.ListCohorts(c.Request.Context(), lpkID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Cohorts retrieved", cohorts)
+}
+
+// CreateCohort godoc
+// @Summary      Create a training cohort
+// @Description  Admins must set lpk_id; LPK partners always create cohorts for their own LPK
+// @Tags         lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      domain.CohortRequest  true  "Cohort"
+// @Success      201   {object}  response.Response{data=domain.Cohort}
+// @Failure      400   {object}  response.Response
+// @Failure      409   {object}  response.Response
+// @Router       /admin/cohorts [post]
+// @Router       /lpk/cohorts [post]
+func (h *CohortHandler) CreateCohort(c *gin.Context) {
+	var req domain.CohortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	cohort, err := h.cohortUC.CreateCohort(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Cohort created", cohort)
+}
+
+// GetCohort godoc
+// @Summary      Get a training cohort with its progress
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Cohort ID"
+// @Success      200  {object}  response.Response{data=domain.Cohort}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/cohorts/{id} [get]
+// @Router       /lpk/cohorts/{id} [get]
+func (h *CohortHandler) GetCohort(c *gin.Context) {
+	id, ok := parseCohortID(c)
+	if !ok {
+		return
+	}
+
+	cohort, err := h.cohortUC.GetCohort(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Cohort retrieved", cohort)
+}
+
+// UpdateCohort godoc
+// @Summary      Update a training cohort
+// @Tags         lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                   true  "Cohort ID"
+// @Param        body  body      domain.CohortRequest  true  "Cohort"
+// @Success      200   {object}  response.Response{data=domain.Cohort}
+// @Failure      400   {object}  response.Response
+// @Failure      404   {object}  response.Response
+// @Router       /admin/cohorts/{id} [put]
+// @Router       /lpk/cohorts/{id} [put]
+func (h *CohortHandler) UpdateCohort(c *gin.Context) {
+	id, ok := parseCohortID(c)
+	if !ok {
+		return
+	}
+	var req domain.CohortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	cohort, err := h.cohortUC.UpdateCohort(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Cohort updated", cohort)
+}
+
+// DeleteCohort godoc
+// @Summary      Delete a training cohort
+// @Description  Removes the cohort and its member assignments; the candidates are kept
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Cohort ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/cohorts/{id} [delete]
+// @Router       /lpk/cohorts/{id} [delete]
+func (h *CohortHandler) DeleteCohort(c *gin.Context) {
+	id, ok := parseCohortID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.cohortUC.DeleteCohort(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Cohort deleted", nil)
+}
+
+// ListMembers godoc
+// @Summary      List a cohort's candidates
+// @Description  Returns the anonymized progress of each member, as in the LPK candidate list
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      int  true   "Cohort ID"
+// @Param        page      query     int  false  "Page number"
+// @Param        pageSize  query     int  false  "Items per page"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.LPKCandidateProgress]}
+// @Failure      404       {object}  response.Response
+// @Router       /admin/cohorts/{id}/members [get]
+// @Router       /lpk/cohorts/{id}/members [get]
+func (h *CohortHandler) ListMembers(c *gin.Context) {
+	id, ok := parseCohortID(c)
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.cohortUC.ListMembers(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Cohort members retrieved", result)
+}
+
+// AssignMembers godoc
+// @Summary      Bulk-assign candidates to a cohort
+// @Description  Candidates already in another cohort are moved. References that are not candidates of the cohort's LPK are skipped and returned.
+// @Tags         lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                                true  "Cohort ID"
+// @Param        body  body      domain.AssignCohortMembersRequest  true  "Candidate references"
+// @Success      200   {object}  response.Response{data=domain.CohortAssignResult}
+// @Failure      400   {object}  response.Response
+// @Failure      404   {object}  response.Response
+// @Router       /admin/cohorts/{id}/members [post]
+// @Router       /lpk/cohorts/{id}/members [post]
+func (h *CohortHandler) AssignMembers(c *gin.Context) {
+	id, ok := parseCohortID(c)
+	if !ok {
+		return
+	}
+	var req domain.AssignCohortMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	result, err := h.cohortUC.AssignMembers(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Candidates assigned", result)
+}
+
+// RemoveMember godoc
+// @Summary      Remove a candidate from a cohort
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Cohort ID"
+// @Param        ref  path      int  true  "Candidate reference"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/cohorts/{id}/members/{ref} [delete]
+// @Router       /lpk/cohorts/{id}/members/{ref} [delete]
+func (h *CohortHandler) RemoveMember(c *gin.Context) {
+	id, ok := parseCohortID(c)
+	if !ok {
+		return
+	}
+	ref, err := strconv.ParseInt(c.Param("ref"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid candidate reference"))
+		return
+	}
+
+	if err := h.cohortUC.RemoveMember(c.Request.Context(), id, ref); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Candidate removed from cohort", nil)
+}
+
+func parseCohortID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid cohort ID"))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompanyLocationHandler struct {
+	locationUC domain.CompanyLocationUsecase
+}
+
+// NewCompanyLocationHandler registers company office locations: the public list of
+// a verified company's offices and the employer's own management
+func NewCompanyLocationHandler(public, protected *gin.RouterGroup, locationUC domain.CompanyLocationUsecase) {
+	handler := &CompanyLocationHandler{locationUC: locationUC}
+
+	public.GET("/companies/public/:id/locations", handler.ListPublicLocations)
+
+	employers := protected.Group("/employers/company-profile/locations")
+	{
+		employers.GET("", handler.ListOwnLocations)
+		employers.POST("", handler.CreateLocation)
+		employers.PUT("/:id", handler.UpdateLocation)
+		employers.DELETE("/:id", handler.DeleteLocation)
+	}
+}
+
+// ListPublicLocations godoc
+// @Summary      List a company's offices
+// @Description  Offices of a verified company with address and coordinates. Site contacts are left out when the company hides its details.
+// @Tags         Company Profile
+// @Produce      json
+// @Param        id   path      int  true  "Company ID"
+// @Success      200  {object}  response.Response{data=[]domain.CompanyLocation}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /companies/public/{id}/locations [get]
+func (h *CompanyLocationHandler) ListPublicLocations(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid company ID"))
+		return
+	}
+
+	locations, err := h.locationUC.ListPublicLocations(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company locations", locations)
+}
+
+// ListOwnLocations godoc
+// @Summary      List my company's offices
+// @Tags         Company Profile
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.CompanyLocation}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/company-profile/locations [get]
+func (h *CompanyLocationHandler) ListOwnLocations(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	locations, err := h.locationUC.ListOwnLocations(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company locations", locations)
+}
+
+// CreateLocation godoc
+// @Summary      Add an office
+// @Description  Adds an office jobs can be based at (location_id on POST /jobs). Latitude and longitude are optional but go together; the contact phone is normalized to E.164.
+// @Tags         Company Profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.CompanyLocationRequest  true  "Office"
+// @Success      201      {object}  response.Response{data=domain.CompanyLocation}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /employers/company-profile/locations [post]
+func (h *CompanyLocationHandler) CreateLocation(c *gin.Context) {
+	var req domain.CompanyLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	location, err := h.locationUC.CreateLocation(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Location added", location)
+}
+
+// UpdateLocation godoc
+// @Summary      Update an office
+// @Description  Replaces the office; jobs based at it show the new address
+// @Tags         Company Profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                            true  "Location ID"
+// @Param        request  body      domain.CompanyLocationRequest  true  "Office"
+// @Success      200      {object}  response.Response{data=domain.CompanyLocation}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /employers/company-profile/locations/{id} [put]
+func (h *CompanyLocationHandler) UpdateLocation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid location ID"))
+		return
+	}
+	var req domain.CompanyLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	location, err := h.locationUC.UpdateLocation(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Location updated", location)
+}
+
+// DeleteLocation godoc
+// @Summary      Delete an office
+// @Description  Jobs based at the office keep their free-text location
+// @Tags         Company Profile
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Location ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/company-profile/locations/{id} [delete]
+func (h *CompanyLocationHandler) DeleteLocation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid location ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.locationUC.DeleteLocation(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Location deleted", nil)
+}
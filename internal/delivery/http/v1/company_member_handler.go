@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompanyMemberHandler struct {
+	memberUC domain.CompanyMemberUsecase
+}
+
+// NewCompanyMemberHandler registers company team management: members and
+// invitations for the owner, the caller's membership, and accepting an invitation
+func NewCompanyMemberHandler(protected *gin.RouterGroup, memberUC domain.CompanyMemberUsecase) {
+	handler := &CompanyMemberHandler{memberUC: memberUC}
+
+	protected.GET("/employers/me/membership", handler.GetMyMembership)
+	protected.POST("/company-invitations/accept", handler.AcceptInvitation)
+
+	members := protected.Group("/employers/members")
+	{
+		members.GET("", handler.GetTeam)
+		members.POST("/invitations", handler.InviteMember)
+		members.DELETE("/invitations/:id", handler.RevokeInvitation)
+		members.PUT("/:userId", handler.UpdateMemberRole)
+		members.DELETE("/:userId", handler.RemoveMember)
+	}
+}
+
+// GetMyMembership godoc
+// @Summary      Get my company and role
+// @Description  The company the employer works for and their role in it: OWNER, RECRUITER or VIEWER
+// @Tags         Company Team
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CompanyMembership}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/me/membership [get]
+func (h *CompanyMemberHandler) GetMyMembership(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	membership, err := h.memberUC.GetMyMembership(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company membership", membership)
+}
+
+// GetTeam godoc
+// @Summary      List my company's team
+// @Description  The owner and the members; open invitations are only listed for the owner
+// @Tags         Company Team
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CompanyTeam}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/members [get]
+func (h *CompanyMemberHandler) GetTeam(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	team, err := h.memberUC.GetTeam(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company team", team)
+}
+
+// InviteMember godoc
+// @Summary      Invite a team member
+// @Description  Owner only. Emails a link valid for 7 days; the response carries the token so the link can also be shared directly. Inviting the same address again replaces the open invitation.
+// @Tags         Company Team
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.InviteCompanyMemberRequest  true  "Invitation"
+// @Success      201      {object}  response.Response{data=domain.CompanyInvitation}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /employers/members/invitations [post]
+func (h *CompanyMemberHandler) InviteMember(c *gin.Context) {
+	var req domain.InviteCompanyMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	inv, err := h.memberUC.InviteMember(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Invitation sent", inv)
+}
+
+// RevokeInvitation godoc
+// @Summary      Revoke an invitation
+// @Tags         Company Team
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Invitation ID"
+// @Success      200  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/members/invitations/{id} [delete]
+func (h *CompanyMemberHandler) RevokeInvitation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid invitation ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.memberUC.RevokeInvitation(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Invitation revoked", nil)
+}
+
+// UpdateMemberRole godoc
+// @Summary      Change a member's role
+// @Description  Owner only
+// @Tags         Company Team
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId   path      string                             true  "Member user ID"
+// @Param        request  body      domain.UpdateCompanyMemberRequest  true  "Role"
+// @Success      200      {object}  response.Response{data=domain.CompanyMember}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /employers/members/{userId} [put]
+func (h *CompanyMemberHandler) UpdateMemberRole(c *gin.Context) {
+	var req domain.UpdateCompanyMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	member, err := h.memberUC.UpdateMemberRole(c.Request.Context(), userID, c.Param("userId"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Member updated", member)
+}
+
+// RemoveMember godoc
+// @Summary      Remove a member or leave the team
+// @Description  The owner removes members; a member passing their own user ID leaves the company
+// @Tags         Company Team
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId  path      string  true  "Member user ID"
+// @Success      200     {object}  response.Response
+// @Failure      400     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Router       /employers/members/{userId} [delete]
+func (h *CompanyMemberHandler) RemoveMember(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.memberUC.RemoveMember(c.Request.Context(), userID, c.Param("userId")); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Member removed", nil)
+}
+
+// AcceptInvitation godoc
+// @Summary      Accept a company invitation
+// @Description  Joins the caller's employer account to the inviting company. The account email must match the invited address, and the caller must not own or belong to a company.
+// @Tags         Company Team
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.AcceptCompanyInvitationRequest  true  "Token from the invitation link"
+// @Success      200      {object}  response.Response{data=domain.CompanyMembership}
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /company-invitations/accept [post]
+func (h *CompanyMemberHandler) AcceptInvitation(c *gin.Context) {
+	var req domain.AcceptCompanyInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	membership, err := h.memberUC.AcceptInvitation(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Invitation accepted", membership)
+}
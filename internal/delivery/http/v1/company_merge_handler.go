@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompanyMergeHandler struct {
+	mergeUC domain.CompanyMergeUsecase
+}
+
+// NewCompanyMergeHandler registers admin routes for merging duplicate company profiles
+func NewCompanyMergeHandler(protected *gin.RouterGroup, mergeUC domain.CompanyMergeUsecase) {
+	handler := &CompanyMergeHandler{mergeUC: mergeUC}
+
+	admin := protected.Group("/admin/company-merges")
+	{
+		admin.POST("", handler.MergeCompanies)
+		admin.GET("", handler.ListMerges)
+		admin.GET("/:id", handler.GetMerge)
+	}
+}
+
+// MergeCompanies godoc
+// @Summary      Merge a duplicate company into a surviving company
+// @Description  Moves jobs (and their applications), team members and gallery media to the surviving company, archives the duplicate and records audit entries
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.CompanyMergeRequest  true  "Companies to merge"
+// @Success      201      {object}  response.Response{data=domain.CompanyMerge}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /admin/company-merges [post]
+func (h *CompanyMergeHandler) MergeCompanies(c *gin.Context) {
+	var req domain.CompanyMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	merge, err := h.mergeUC.MergeCompanies(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Companies merged", merge)
+}
+
+// ListMerges godoc
+// @Summary      List company merges
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.CompanyMerge}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/company-merges [get]
+func (h *CompanyMergeHandler) ListMerges(c *gin.Context) {
+	merges, err := h.mergeUC.ListMerges(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company merges retrieved", merges)
+}
+
+// GetMerge godoc
+// @Summary      Get a company merge with its audit entries
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Merge ID"
+// @Success      200  {object}  response.Response{data=domain.CompanyMerge}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/company-merges/{id} [get]
+func (h *CompanyMergeHandler) GetMerge(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid merge ID"))
+		return
+	}
+
+	merge, err := h.mergeUC.GetMerge(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company merge retrieved", merge)
+}
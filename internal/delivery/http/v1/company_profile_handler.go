@@ -29,6 +29,8 @@ func NewCompanyProfileHandler(
 
 	// Public routes
 	public.GET("/companies/:id", handler.GetPublicProfile)
+	public.GET("/companies/public", handler.ListPublicCompanies)
+	public.GET("/companies/public/:id", handler.GetPublicCompanyPage)
 
 	// Protected employer routes
 	employers := protected.Group("/employers")
@@ -43,6 +45,7 @@ type CompanyProfileRequest struct {
 	CompanyName        string  `json:"company_name" binding:"required"`
 	LogoURL            *string `json:"logo_url"`
 	Location           *string `json:"location"`
+	Province           *string `json:"province"`
 	CompanyStory       *string `json:"company_story"`
 	Founded            *string `json:"founded"`
 	Founder            *string `json:"founder"`
@@ -70,7 +73,7 @@ type CompanyProfileRequest struct {
 func (h *CompanyProfileHandler) GetOwnProfile(c *gin.Context) {
 	// Check role
 	role := c.GetString(string(domain.KeyUserRole))
-	if role != "employer" && role != "admin" {
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
 		c.Error(apperror.Forbidden("Only employers can access company profiles"))
 		return
 	}
@@ -106,7 +109,7 @@ func (h *CompanyProfileHandler) GetOwnProfile(c *gin.Context) {
 func (h *CompanyProfileHandler) UpdateProfile(c *gin.Context) {
 	// Check role
 	role := c.GetString(string(domain.KeyUserRole))
-	if role != "employer" && role != "admin" {
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
 		c.Error(apperror.Forbidden("Only employers can update company profiles"))
 		return
 	}
@@ -127,6 +130,7 @@ func (h *CompanyProfileHandler) UpdateProfile(c *gin.Context) {
 		CompanyName:        req.CompanyName,
 		LogoURL:            req.LogoURL,
 		Location:           req.Location,
+		Province:           req.Province,
 		CompanyStory:       req.CompanyStory,
 		Founded:            req.Founded,
 		Founder:            req.Founder,
@@ -166,7 +170,71 @@ func (h *CompanyProfileHandler) GetPublicProfile(c *gin.Context) {
 		return
 	}
 
-	// Build viewer info from context (may be nil for unauthenticated)
+	profile, err := h.profileUC.GetPublicProfile(c, id, h.viewerInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Company profile", profile)
+}
+
+// ListPublicCompanies godoc
+// @Summary List verified companies
+// @Description Public company directory: verified employers only, sorted by name, with their active job count
+// @Tags Company Profile
+// @Produce json
+// @Param q query string false "Company name contains"
+// @Param industry query string false "Industry (exact, case-insensitive)"
+// @Param location query string false "Location contains"
+// @Param page query int false "Page number"
+// @Param page_size query int false "Items per page (max 100)"
+// @Success 200 {object} response.Response{data=domain.PaginatedResult[domain.PublicCompanySummary]}
+// @Router /companies/public [get]
+func (h *CompanyProfileHandler) ListPublicCompanies(c *gin.Context) {
+	filter := domain.PublicCompanyFilter{
+		Query:    c.Query("q"),
+		Industry: c.Query("industry"),
+		Location: c.Query("location"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	result, err := h.profileUC.ListPublicCompanies(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Companies retrieved", result)
+}
+
+// GetPublicCompanyPage godoc
+// @Summary Get a company's public page
+// @Description Branding (logo, industry, gallery, about) and active jobs of a verified company. Founder, size and website follow the same visibility rules as GET /companies/{id}.
+// @Tags Company Profile
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {object} response.Response{data=domain.PublicCompanyPage}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /companies/public/{id} [get]
+func (h *CompanyProfileHandler) GetPublicCompanyPage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid company ID"))
+		return
+	}
+
+	page, err := h.profileUC.GetPublicCompanyPage(c.Request.Context(), id, h.viewerInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company page", page)
+}
+
+// viewerInfo describes the (optionally authenticated) viewer for the visibility rules
+func (h *CompanyProfileHandler) viewerInfo(c *gin.Context) *domain.ViewerInfo {
 	viewer := &domain.ViewerInfo{
 		IsAuthenticated: false,
 	}
@@ -177,19 +245,12 @@ func (h *CompanyProfileHandler) GetPublicProfile(c *gin.Context) {
 		viewer.Role = c.GetString(string(domain.KeyUserRole))
 
 		// Get verification status for candidates
-		if viewer.Role == "candidate" {
+		if viewer.Role == domain.RoleCandidate {
 			verification, err := h.verificationUC.GetVerificationStatus(c, userID)
 			if err == nil && verification != nil && verification.Verification != nil {
 				viewer.VerificationStatus = verification.Verification.Status
 			}
 		}
 	}
-
-	profile, err := h.profileUC.GetPublicProfile(c, id, viewer)
-	if err != nil {
-		c.Error(err)
-		return
-	}
-
-	response.Success(c, http.StatusOK, "Company profile", profile)
+	return viewer
 }
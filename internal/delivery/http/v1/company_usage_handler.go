@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompanyUsageHandler struct {
+	companyUsageUC domain.CompanyUsageUsecase
+}
+
+// NewCompanyUsageHandler registers the employer usage dashboard route
+func NewCompanyUsageHandler(protected *gin.RouterGroup, companyUsageUC domain.CompanyUsageUsecase) {
+	handler := &CompanyUsageHandler{companyUsageUC: companyUsageUC}
+
+	protected.GET("/employers/me/usage", handler.GetMyUsage)
+}
+
+// GetMyUsage godoc
+// @Summary      Get my company's usage against quotas
+// @Description  Job slots, contact unlocks, exports and API-key calls against the plan's limits, with monthly history
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        months  query     int  false  "Months of history including this one (1-24, default 6)"
+// @Success      200     {object}  response.Response{data=domain.CompanyUsage}
+// @Failure      400     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Router       /employers/me/usage [get]
+func (h *CompanyUsageHandler) GetMyUsage(c *gin.Context) {
+	months, _ := strconv.Atoi(c.DefaultQuery("months", "0"))
+
+	userID := c.GetString(string(domain.KeyUserID))
+	usage, err := h.companyUsageUC.GetMyUsage(c.Request.Context(), userID, months)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Company usage retrieved", usage)
+}
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompanyVerificationHandler struct {
+	verificationUC domain.CompanyVerificationUsecase
+}
+
+// NewCompanyVerificationHandler registers employer and admin company verification level routes
+func NewCompanyVerificationHandler(protected *gin.RouterGroup, verificationUC domain.CompanyVerificationUsecase) {
+	handler := &CompanyVerificationHandler{verificationUC: verificationUC}
+
+	// Employer: own level and what it unlocks
+	protected.GET("/employers/me/verification-level", handler.GetMyVerification)
+
+	// Admin: grant levels
+	admin := protected.Group("/admin/company-verification")
+	{
+		admin.GET("", handler.ListCompanies)
+		admin.GET("/:companyId", handler.GetCompanyVerification)
+		admin.PUT("/:companyId", handler.SetLevel)
+	}
+}
+
+// GetMyVerification godoc
+// @Summary      Get my company's verification level
+// @Description  The level, what it unlocks (open job limit, ATS access), open jobs and the next level
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CompanyVerification}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/me/verification-level [get]
+func (h *CompanyVerificationHandler) GetMyVerification(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	verification, err := h.verificationUC.GetMyVerification(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Verification level retrieved", verification)
+}
+
+// ListCompanies godoc
+// @Summary      List companies by verification level
+// @Description  Sorted by company name; merged duplicates are left out
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        level     query     string  false  "BASIC, STANDARD or PREMIUM"
+// @Param        q         query     string  false  "Company name contains"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.CompanyVerification]}
+// @Failure      400       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /admin/company-verification [get]
+func (h *CompanyVerificationHandler) ListCompanies(c *gin.Context) {
+	filter := domain.CompanyVerificationFilter{
+		Level: c.Query("level"),
+		Query: c.Query("q"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.verificationUC.ListCompanies(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Companies retrieved", result)
+}
+
+// GetCompanyVerification godoc
+// @Summary      Get a company's verification level
+// @Description  Includes the history of level changes, newest first
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        companyId  path      int  true  "Company ID"
+// @Success      200        {object}  response.Response{data=domain.CompanyVerification}
+// @Failure      403        {object}  response.Response
+// @Failure      404        {object}  response.Response
+// @Router       /admin/company-verification/{companyId} [get]
+func (h *CompanyVerificationHandler) GetCompanyVerification(c *gin.Context) {
+	companyID, err := strconv.ParseInt(c.Param("companyId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid company ID"))
+		return
+	}
+
+	verification, err := h.verificationUC.GetCompanyVerification(c.Request.Context(), companyID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Verification level retrieved", verification)
+}
+
+// SetLevel godoc
+// @Summary      Set a company's verification level
+// @Description  Grants (or withdraws) a level, e.g. PREMIUM after a site visit or signed contract. The change is recorded with the reason and the employer is notified. Lowering a level does not close open jobs above the new limit.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        companyId  path      int                            true  "Company ID"
+// @Param        request    body      domain.SetCompanyLevelRequest  true  "Level and reason"
+// @Success      200        {object}  response.Response{data=domain.CompanyVerification}
+// @Failure      400        {object}  response.Response
+// @Failure      404        {object}  response.Response
+// @Failure      409        {object}  response.Response
+// @Router       /admin/company-verification/{companyId} [put]
+func (h *CompanyVerificationHandler) SetLevel(c *gin.Context) {
+	companyID, err := strconv.ParseInt(c.Param("companyId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid company ID"))
+		return
+	}
+
+	var req domain.SetCompanyLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	adminID := c.GetString(string(domain.KeyUserID))
+	verification, err := h.verificationUC.SetLevel(c.Request.Context(), adminID, companyID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Verification level updated", verification)
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContactCreditHandler struct {
+	creditUC domain.ContactCreditUsecase
+}
+
+// NewContactCreditHandler registers employer credit/reveal routes and admin credit management
+func NewContactCreditHandler(protected *gin.RouterGroup, creditUC domain.ContactCreditUsecase) {
+	handler := &ContactCreditHandler{creditUC: creditUC}
+
+	// Employer: balance, ledger, and contact reveals
+	employer := protected.Group("/employers")
+	{
+		employer.GET("/credits", handler.GetMyBalance)
+		employer.GET("/credits/ledger", handler.ListMyLedger)
+		employer.POST("/candidates/:userId/reveal", handler.RevealContact)
+	}
+
+	// Admin: per-company balance, ledger, and grants
+	admin := protected.Group("/admin/companies/:id/credits")
+	{
+		admin.GET("", handler.GetCompanyBalance)
+		admin.GET("/ledger", handler.ListCompanyLedger)
+		admin.POST("", handler.GrantCredits)
+	}
+}
+
+// GetMyBalance godoc
+// @Summary      Get company credit balance
+// @Tags         employer-credits
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CompanyCreditBalance}
+// @Failure      403  {object}  response.Response
+// @Router       /employers/credits [get]
+func (h *ContactCreditHandler) GetMyBalance(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	balance, err := h.creditUC.GetMyBalance(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Credit balance", balance)
+}
+
+// ListMyLedger godoc
+// @Summary      List company credit ledger
+// @Tags         employer-credits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query     int  false  "Page number"
+// @Param        pageSize  query     int  false  "Items per page"
+// @Success      200       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /employers/credits/ledger [get]
+func (h *ContactCreditHandler) ListMyLedger(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+
+	result, err := h.creditUC.ListMyLedger(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Credit ledger", result)
+}
+
+// RevealContact godoc
+// @Summary      Reveal candidate contact details
+// @Description  Consumes one company credit. Revealing the same candidate again is free; retries with the same Idempotency-Key never charge twice.
+// @Tags         employer-credits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId           path      string  true   "Candidate user ID"
+// @Param        Idempotency-Key  header    string  false  "Client-generated key for safe retries"
+// @Success      200              {object}  response.Response{data=domain.ContactRevealResult}
+// @Failure      402              {object}  response.Response
+// @Failure      404              {object}  response.Response
+// @Failure      409              {object}  response.Response
+// @Router       /employers/candidates/{userId}/reveal [post]
+func (h *ContactCreditHandler) RevealContact(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	result, err := h.creditUC.RevealContact(c.Request.Context(), userID, c.Param("userId"), c.GetHeader("Idempotency-Key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Candidate contact revealed", result)
+}
+
+// GetCompanyBalance godoc
+// @Summary      Get a company's credit balance
+// @Tags         admin-credits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Company ID"
+// @Success      200  {object}  response.Response{data=domain.CompanyCreditBalance}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/companies/{id}/credits [get]
+func (h *ContactCreditHandler) GetCompanyBalance(c *gin.Context) {
+	companyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid company ID"))
+		return
+	}
+
+	balance, err := h.creditUC.GetCompanyBalance(c.Request.Context(), companyID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Credit balance", balance)
+}
+
+// ListCompanyLedger godoc
+// @Summary      List a company's credit ledger
+// @Tags         admin-credits
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      int  true   "Company ID"
+// @Param        page      query     int  false  "Page number"
+// @Param        pageSize  query     int  false  "Items per page"
+// @Success      200       {object}  response.Response
+// @Failure      404       {object}  response.Response
+// @Router       /admin/companies/{id}/credits/ledger [get]
+func (h *ContactCreditHandler) ListCompanyLedger(c *gin.Context) {
+	companyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid company ID"))
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+
+	result, err := h.creditUC.ListCompanyLedger(c.Request.Context(), companyID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Credit ledger", result)
+}
+
+// GrantCredits godoc
+// @Summary      Grant credits to a company
+// @Description  Adds plan, purchased, or adjustment credits. Retries with the same Idempotency-Key are applied once.
+// @Tags         admin-credits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id               path      int                         true   "Company ID"
+// @Param        Idempotency-Key  header    string                      false  "Client-generated key for safe retries"
+// @Param        body             body      domain.GrantCreditsRequest  true   "Grant details"
+// @Success      201              {object}  response.Response{data=domain.CreditLedgerEntry}
+// @Failure      404              {object}  response.Response
+// @Failure      409              {object}  response.Response
+// @Router       /admin/companies/{id}/credits [post]
+func (h *ContactCreditHandler) GrantCredits(c *gin.Context) {
+	companyID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid company ID"))
+		return
+	}
+
+	var req domain.GrantCreditsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	entry, err := h.creditUC.GrantCredits(c.Request.Context(), companyID, c.GetHeader("Idempotency-Key"), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Credits granted", entry)
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"fmt"
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/security"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CVParseHandler struct {
+	cvParseUC domain.CVParseUsecase
+}
+
+// NewCVParseHandler registers the candidate CV parse route
+func NewCVParseHandler(protected *gin.RouterGroup, cvParseUC domain.CVParseUsecase) {
+	handler := &CVParseHandler{cvParseUC: cvParseUC}
+
+	protected.POST("/candidates/me/cv/parse", handler.ParseCV)
+}
+
+// ParseCV godoc
+// @Summary      Parse a CV into a profile draft
+// @Description  Extracts name, contact details, education, work experience and skills from a PDF or DOCX CV. Nothing is saved; confirm the draft through PUT /candidates/me/verification and the candidate profile endpoints.
+// @Tags         candidates
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file  formData  file  true  "CV (PDF or DOCX)"
+// @Success      200   {object}  response.Response{data=domain.CVDraft}
+// @Failure      400   {object}  response.Response
+// @Failure      403   {object}  response.Response
+// @Failure      413   {object}  response.Response
+// @Failure      429   {object}  response.Response
+// @Router       /candidates/me/cv/parse [post]
+func (h *CVParseHandler) ParseCV(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	// Same limits as /upload: the file is held in memory while parsing
+	allowed, retryAfter, err := uploadLimiter.AllowUpload(c.Request.Context(), c.ClientIP(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Upload rate limiter unavailable", "error", err)
+	}
+	if !allowed {
+		c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+		response.Error(c, http.StatusTooManyRequests, "Upload rate limit exceeded. Please try again later.", nil)
+		return
+	}
+	select {
+	case uploadSlots <- struct{}{}:
+		defer func() { <-uploadSlots }()
+	default:
+		c.Header("Retry-After", "5")
+		response.Error(c, http.StatusServiceUnavailable, "Too many uploads in progress. Please try again shortly.", nil)
+		return
+	}
+
+	filename, fileBytes, err := readMultipartFile(c.Request, "file", maxUploadSize)
+	if err != nil {
+		respondUploadReadError(c, err)
+		return
+	}
+	if result := security.ValidateFile(filename, fileBytes, http.DetectContentType(fileBytes)); !result.Valid {
+		response.Error(c, http.StatusBadRequest, fmt.Sprintf("File rejected: %s", result.Error), nil)
+		return
+	}
+
+	draft, err := h.cvParseUC.ParseCV(c.Request.Context(), userID, filename, fileBytes)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "CV parsed", draft)
+}
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubCVParseUC struct {
+	domain.CVParseUsecase
+	filename string
+	data     []byte
+}
+
+func (s *stubCVParseUC) ParseCV(ctx context.Context, userID, filename string, data []byte) (*domain.CVDraft, error) {
+	s.filename, s.data = filename, data
+	return &domain.CVDraft{}, nil
+}
+
+func TestParseCVThroughRouter(t *testing.T) {
+	cvParseUC := &stubCVParseUC{}
+	r := newTestRouter(RouterDeps{CVParseUC: cvParseUC})
+
+	// Larger than the JSON body limit, well within the upload limit
+	cv := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("% resume\n"), 150_000)...)
+	cv = append(cv, []byte("%%EOF\n")...)
+
+	body, contentType := multipartFile(t, "resume.pdf", cv)
+	rec := serveRouter(r, authorizedRequest(t, http.MethodPost, "/v1/candidates/me/cv/parse", domain.RoleCandidate, body, contentType))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "resume.pdf", cvParseUC.filename)
+	assert.Equal(t, cv, cvParseUC.data)
+
+	body, contentType = multipartFile(t, "resume.pdf", bytes.Repeat([]byte("a"), 12<<20))
+	rec = serveRouter(r, authorizedRequest(t, http.MethodPost, "/v1/candidates/me/cv/parse", domain.RoleCandidate, body, contentType))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec = serveRouter(r, authorizedRequest(t, http.MethodPost, "/v1/candidates/me/cv/parse", domain.RoleCandidate, strings.NewReader(`{}`), "application/json"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
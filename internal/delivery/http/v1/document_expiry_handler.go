@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentExpiryHandler struct {
+	documentExpiryUC domain.DocumentExpiryUsecase
+}
+
+// NewDocumentExpiryHandler registers candidate document expiry and admin reporting routes
+func NewDocumentExpiryHandler(protected *gin.RouterGroup, documentExpiryUC domain.DocumentExpiryUsecase) {
+	handler := &DocumentExpiryHandler{documentExpiryUC: documentExpiryUC}
+
+	// Candidate: own expiring documents
+	protected.GET("/candidates/me/document-expiries", handler.ListMyDocuments)
+
+	// Admin: expirations by month and manual reminder runs
+	admin := protected.Group("/admin/document-expiries")
+	{
+		admin.GET("/report", handler.GetReport)
+		admin.POST("/run", handler.TriggerReminders)
+	}
+}
+
+// ListMyDocuments godoc
+// @Summary      List my expiring documents
+// @Description  Passport, JLPT, medical check, CoE and certificates with an expiry date, soonest first
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.CandidateDocumentExpiry}
+// @Router       /candidates/me/document-expiries [get]
+func (h *DocumentExpiryHandler) ListMyDocuments(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	documents, err := h.documentExpiryUC.ListMyDocuments(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Document expiries retrieved", documents)
+}
+
+// GetReport godoc
+// @Summary      Document expirations by month
+// @Description  Expiring candidate documents per month and document type
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from  query     string  false  "First month (YYYY-MM), defaults to this month"
+// @Param        to    query     string  false  "Last month (YYYY-MM), defaults to 11 months after 'from'"
+// @Success      200   {object}  response.Response{data=domain.DocumentExpiryReport}
+// @Failure      400   {object}  response.Response
+// @Failure      403   {object}  response.Response
+// @Router       /admin/document-expiries/report [get]
+func (h *DocumentExpiryHandler) GetReport(c *gin.Context) {
+	report, err := h.documentExpiryUC.GetReport(c.Request.Context(), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Document expiry report generated", report)
+}
+
+// TriggerReminders godoc
+// @Summary      Send document expiry reminders now
+// @Description  Runs the same pass as the background worker; each 90/30/7-day reminder is still sent once
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.DocumentExpiryRunResult}
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/document-expiries/run [post]
+func (h *DocumentExpiryHandler) TriggerReminders(c *gin.Context) {
+	result, err := h.documentExpiryUC.TriggerReminders(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Document expiry reminders sent", result)
+}
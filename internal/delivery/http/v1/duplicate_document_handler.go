@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateDocumentHandler struct {
+	duplicateUC domain.DuplicateDocumentUsecase
+}
+
+// NewDuplicateDocumentHandler registers the admin duplicate document review routes
+func NewDuplicateDocumentHandler(protected *gin.RouterGroup, duplicateUC domain.DuplicateDocumentUsecase) {
+	handler := &DuplicateDocumentHandler{duplicateUC: duplicateUC}
+
+	admin := protected.Group("/admin/duplicate-documents")
+	{
+		admin.GET("", handler.List)
+		admin.GET("/:id", handler.Get)
+		admin.POST("/:id/review", handler.Review)
+	}
+}
+
+// List godoc
+// @Summary      List duplicate document reviews
+// @Description  Certificate and vault images that look like documents of other accounts, oldest first. status defaults to PENDING; ALL lists every status. userId lists the tasks an account uploaded or was matched in.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false  "PENDING, CONFIRMED, DISMISSED or ALL"
+// @Param        userId    query     string  false  "Account involved"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.DuplicateDocumentReview]}
+// @Failure      400       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /admin/duplicate-documents [get]
+func (h *DuplicateDocumentHandler) List(c *gin.Context) {
+	filter := domain.DuplicateDocumentFilter{
+		Status: c.Query("status"),
+		UserID: c.Query("userId"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.duplicateUC.ListReviews(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Duplicate documents retrieved", result)
+}
+
+// Get godoc
+// @Summary      Get a duplicate document review
+// @Description  The flagged upload with links to the accounts whose documents it matches, closest first
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Review ID"
+// @Success      200  {object}  response.Response{data=domain.DuplicateDocumentReview}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/duplicate-documents/{id} [get]
+func (h *DuplicateDocumentHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid review ID"))
+		return
+	}
+
+	review, err := h.duplicateUC.GetReview(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Duplicate document retrieved", review)
+}
+
+// Review godoc
+// @Summary      Resolve a duplicate document review
+// @Description  CONFIRM records that the document was reused; DISMISS marks a false positive
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                    true  "Review ID"
+// @Param        request  body      domain.ReviewDuplicateDocumentRequest  true  "Decision and notes"
+// @Success      200      {object}  response.Response{data=domain.DuplicateDocumentReview}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /admin/duplicate-documents/{id}/review [post]
+func (h *DuplicateDocumentHandler) Review(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid review ID"))
+		return
+	}
+
+	var req domain.ReviewDuplicateDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	adminID := c.GetString(string(domain.KeyUserID))
+	review, err := h.duplicateUC.ReviewDuplicate(c.Request.Context(), adminID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Duplicate document reviewed", review)
+}
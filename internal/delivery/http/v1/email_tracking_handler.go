@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// trackingPixel is a transparent 1x1 GIF
+var trackingPixel = []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00\x00\x00\x00\x00\x00\x00!\xf9\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;")
+
+type EmailTrackingHandler struct {
+	trackingUC domain.EmailTrackingUsecase
+}
+
+// NewEmailTrackingHandler registers the tracking links of emails (outside /v1,
+// where emails point), users' tracking preferences and admin engagement stats
+func NewEmailTrackingHandler(engine *gin.Engine, protected *gin.RouterGroup, trackingUC domain.EmailTrackingUsecase) {
+	handler := &EmailTrackingHandler{trackingUC: trackingUC}
+
+	engine.GET("/t/:token", handler.Hit)
+
+	protected.GET("/auth/me/email-tracking", handler.GetPreference)
+	protected.PUT("/auth/me/email-tracking", handler.UpdatePreference)
+
+	protected.GET("/admin/email-engagement", handler.GetReport)
+}
+
+// Hit godoc
+// @Summary      Email open or click
+// @Description  Open pixels answer with a 1x1 GIF, links redirect to their original URL. Not cached, so every open counts.
+// @Tags         system
+// @Produce      image/gif
+// @Param        token  path  string  true  "Tracking token from the email"
+// @Success      200  {string}  string  "GIF pixel"
+// @Success      302  {string}  string  "Redirect to the link"
+// @Failure      404  {object}  response.Response
+// @Router       /t/{token} [get]
+func (h *EmailTrackingHandler) Hit(c *gin.Context) {
+	hit, err := h.trackingUC.Hit(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	if hit.URL != nil {
+		c.Redirect(http.StatusFound, *hit.URL)
+		return
+	}
+	c.Data(http.StatusOK, "image/gif", trackingPixel)
+}
+
+// GetPreference godoc
+// @Summary      Get my email tracking preference
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.EmailTrackingPreference}
+// @Router       /auth/me/email-tracking [get]
+func (h *EmailTrackingHandler) GetPreference(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	pref, err := h.trackingUC.GetPreference(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Email tracking preference retrieved", pref)
+}
+
+// UpdatePreference godoc
+// @Summary      Switch email tracking off or on
+// @Description  enabled=false stops open and click tracking of all the caller's emails; disabled_categories stops it for those categories only
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.UpdateEmailTrackingPreferenceRequest  true  "Preference"
+// @Success      200      {object}  response.Response{data=domain.EmailTrackingPreference}
+// @Failure      400      {object}  response.Response
+// @Router       /auth/me/email-tracking [put]
+func (h *EmailTrackingHandler) UpdatePreference(c *gin.Context) {
+	var req domain.UpdateEmailTrackingPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	pref, err := h.trackingUC.UpdatePreference(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Email tracking preference updated", pref)
+}
+
+// GetReport godoc
+// @Summary      Email engagement per template
+// @Description  Unrestricted admins only. Sent, opened and clicked tracked emails per template, for emails sent in the date range. Untracked emails (opted out, disabled categories) are not counted.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from  query     string  false  "First day (YYYY-MM-DD), defaults to 29 days before 'to'"
+// @Param        to    query     string  false  "Last day (YYYY-MM-DD), defaults to today"
+// @Success      200   {object}  response.Response{data=domain.EmailEngagementReport}
+// @Failure      400   {object}  response.Response
+// @Failure      403   {object}  response.Response
+// @Router       /admin/email-engagement [get]
+func (h *EmailTrackingHandler) GetReport(c *gin.Context) {
+	report, err := h.trackingUC.GetReport(c.Request.Context(), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Email engagement report generated", report)
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmailValidationHandler struct {
+	emailValidationUC domain.EmailValidationUsecase
+}
+
+// NewEmailValidationHandler registers the admin email deliverability routes
+func NewEmailValidationHandler(protected *gin.RouterGroup, emailValidationUC domain.EmailValidationUsecase) {
+	handler := &EmailValidationHandler{emailValidationUC: emailValidationUC}
+
+	admin := protected.Group("/admin/email-validations")
+	{
+		admin.GET("", handler.ListUndeliverable)
+		admin.GET("/report", handler.GetReport)
+		admin.POST("/run", handler.TriggerValidation)
+	}
+}
+
+// ListUndeliverable godoc
+// @Summary      List accounts with undeliverable emails
+// @Description  Accounts whose email failed validation, least recently active first, to re-engage or prune
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        reason    query     string  false  "syntax, no_mx or disposable"
+// @Param        role      query     string  false  "candidate, employer or admin"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.UndeliverableEmail]}
+// @Failure      400       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /admin/email-validations [get]
+func (h *EmailValidationHandler) ListUndeliverable(c *gin.Context) {
+	filter := domain.UndeliverableEmailFilter{
+		Reason: c.Query("reason"),
+		Role:   c.Query("role"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.emailValidationUC.ListUndeliverable(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Undeliverable emails retrieved", result)
+}
+
+// GetReport godoc
+// @Summary      Email deliverability report
+// @Description  Users by email state (unchecked, deliverable, undeliverable) and undeliverable ones by reason and role
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.EmailValidationReport}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/email-validations/report [get]
+func (h *EmailValidationHandler) GetReport(c *gin.Context) {
+	report, err := h.emailValidationUC.GetReport(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Email validation report generated", report)
+}
+
+// TriggerValidation godoc
+// @Summary      Validate emails now
+// @Description  Runs the same pass as the background worker over addresses that are unchecked or due for a recheck
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.EmailValidationRunResult}
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/email-validations/run [post]
+func (h *EmailValidationHandler) TriggerValidation(c *gin.Context) {
+	result, err := h.emailValidationUC.TriggerValidation(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Email validation finished", result)
+}
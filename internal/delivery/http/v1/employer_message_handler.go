@@ -0,0 +1,284 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EmployerMessageHandler struct {
+	messageUC domain.EmployerMessageUsecase
+}
+
+// NewEmployerMessageHandler registers employer bulk messaging and candidate inbox routes
+func NewEmployerMessageHandler(protected *gin.RouterGroup, messageUC domain.EmployerMessageUsecase) {
+	handler := &EmployerMessageHandler{messageUC: messageUC}
+
+	// Employer: templates, bulk sends and the daily allowance
+	employers := protected.Group("/employers")
+	{
+		employers.GET("/message-templates", handler.ListTemplates)
+		employers.POST("/message-templates", handler.CreateTemplate)
+		employers.PUT("/message-templates/:id", handler.UpdateTemplate)
+		employers.DELETE("/message-templates/:id", handler.DeleteTemplate)
+		employers.POST("/messages", handler.SendBulk)
+		employers.GET("/messages", handler.ListMessages)
+		employers.GET("/messages/:id", handler.GetMessage)
+		employers.GET("/message-quota", handler.GetQuota)
+	}
+
+	// Candidate: received messages and spam reports
+	candidate := protected.Group("/candidates/me/messages")
+	{
+		candidate.GET("", handler.ListMyMessages)
+		candidate.POST("/:id/report-spam", handler.ReportSpam)
+	}
+}
+
+// ListTemplates godoc
+// @Summary      List message templates
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.MessageTemplate}
+// @Router       /employers/message-templates [get]
+func (h *EmployerMessageHandler) ListTemplates(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	templates, err := h.messageUC.ListTemplates(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Message templates retrieved", templates)
+}
+
+// CreateTemplate godoc
+// @Summary      Create a message template
+// @Description  Subject and body may use {{candidate_name}}, {{job_title}} and {{company_name}}, filled in per recipient
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.MessageTemplateRequest  true  "Template"
+// @Success      201      {object}  response.Response{data=domain.MessageTemplate}
+// @Failure      400      {object}  response.Response
+// @Router       /employers/message-templates [post]
+func (h *EmployerMessageHandler) CreateTemplate(c *gin.Context) {
+	var req domain.MessageTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	template, err := h.messageUC.CreateTemplate(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Message template created", template)
+}
+
+// UpdateTemplate godoc
+// @Summary      Replace a message template
+// @Description  Messages already sent keep the wording they were sent with
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                            true  "Template ID"
+// @Param        request  body      domain.MessageTemplateRequest  true  "Template"
+// @Success      200      {object}  response.Response{data=domain.MessageTemplate}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /employers/message-templates/{id} [put]
+func (h *EmployerMessageHandler) UpdateTemplate(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid template ID"))
+		return
+	}
+
+	var req domain.MessageTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	template, err := h.messageUC.UpdateTemplate(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Message template updated", template)
+}
+
+// DeleteTemplate godoc
+// @Summary      Delete a message template
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Template ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/message-templates/{id} [delete]
+func (h *EmployerMessageHandler) DeleteTemplate(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid template ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.messageUC.DeleteTemplate(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Message template deleted", nil)
+}
+
+// SendBulk godoc
+// @Summary      Message shortlisted candidates
+// @Description  Sends a template to the candidates of up to 100 applications in the screening, interview or offer stage, one message per candidate. Candidates who reported the company's messages as spam are skipped (BLOCKED). Returns 429 when the daily limit would be exceeded and 403 while messaging is suspended for spam reports.
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.SendBulkMessageRequest  true  "Template and applications"
+// @Success      201      {object}  response.Response{data=domain.BulkMessage}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      429      {object}  response.Response
+// @Router       /employers/messages [post]
+func (h *EmployerMessageHandler) SendBulk(c *gin.Context) {
+	var req domain.SendBulkMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	message, err := h.messageUC.SendBulk(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Message sent", message)
+}
+
+// ListMessages godoc
+// @Summary      List sent bulk messages
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query     int  false  "Page number"
+// @Param        pageSize  query     int  false  "Items per page (max 100)"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.BulkMessage]}
+// @Router       /employers/messages [get]
+func (h *EmployerMessageHandler) ListMessages(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	userID := c.GetString(string(domain.KeyUserID))
+	result, err := h.messageUC.ListMessages(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Messages retrieved", result)
+}
+
+// GetMessage godoc
+// @Summary      Get a bulk message with per-recipient delivery status
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Message ID"
+// @Success      200  {object}  response.Response{data=domain.BulkMessage}
+// @Failure      404  {object}  response.Response
+// @Router       /employers/messages/{id} [get]
+func (h *EmployerMessageHandler) GetMessage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid message ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	message, err := h.messageUC.GetMessage(c.Request.Context(), userID, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Message retrieved", message)
+}
+
+// GetQuota godoc
+// @Summary      Get my company's bulk messaging allowance
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.BulkMessageQuota}
+// @Router       /employers/message-quota [get]
+func (h *EmployerMessageHandler) GetQuota(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	quota, err := h.messageUC.GetQuota(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Message quota retrieved", quota)
+}
+
+// ListMyMessages godoc
+// @Summary      List messages employers sent me
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query     int  false  "Page number"
+// @Param        pageSize  query     int  false  "Items per page (max 100)"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.CandidateMessage]}
+// @Router       /candidates/me/messages [get]
+func (h *EmployerMessageHandler) ListMyMessages(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	userID := c.GetString(string(domain.KeyUserID))
+	result, err := h.messageUC.ListMyMessages(c.Request.Context(), userID, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Messages retrieved", result)
+}
+
+// ReportSpam godoc
+// @Summary      Report a message as spam
+// @Description  The company cannot message me again, and companies with many reports lose bulk messaging
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Message ID (from my messages)"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/messages/{id}/report-spam [post]
+func (h *EmployerMessageHandler) ReportSpam(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid message ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.messageUC.ReportSpam(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Message reported as spam", nil)
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FileHandler struct {
+	fileUC domain.UploadedFileUsecase
+}
+
+// NewFileHandler registers file processing status and signed URL routes
+func NewFileHandler(protected *gin.RouterGroup, fileUC domain.UploadedFileUsecase) {
+	handler := &FileHandler{fileUC: fileUC}
+
+	files := protected.Group("/files")
+	{
+		files.GET("/signed-url", handler.GetSignedURL)
+		files.GET("/:id/status", handler.GetStatus)
+	}
+}
+
+// GetStatus godoc
+// @Summary      Get file processing status
+// @Description  Returns the processing state of an upload (uploaded, processing, ready, failed). The URL is present once ready.
+// @Tags         Upload
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "File ID returned by POST /upload"
+// @Success      200  {object}  response.Response{data=domain.UploadedFile}
+// @Failure      404  {object}  response.Response
+// @Router       /files/{id}/status [get]
+func (h *FileHandler) GetStatus(c *gin.Context) {
+	file, err := h.fileUC.GetStatus(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "File status", file)
+}
+
+// GetSignedURL godoc
+// @Summary      Get a download URL for a stored file
+// @Description  CV, JLPT, vault document and guardian consent files are in private buckets: their stored URLs only identify them, and this returns a signed URL that expires. Available to the owner, admins, and employers whose company may see the candidate (applied to it, visible to it, or contact unlocked). Files in public buckets are returned as stored, without an expiry.
+// @Tags         Upload
+// @Produce      json
+// @Security     BearerAuth
+// @Param        url  query     string  true  "Stored file URL (as saved on the profile or application)"
+// @Success      200  {object}  response.Response{data=domain.SignedFileURL}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /files/signed-url [get]
+func (h *FileHandler) GetSignedURL(c *gin.Context) {
+	signed, err := h.fileUC.SignURL(c.Request.Context(), c.Query("url"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Signed URLs are short-lived and per-caller
+	c.Header("Cache-Control", "no-store")
+	response.Success(c, http.StatusOK, "Signed URL created", signed)
+}
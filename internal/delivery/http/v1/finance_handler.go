@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FinanceHandler struct {
+	financeUC domain.FinanceReportUsecase
+}
+
+// NewFinanceHandler registers admin financial reporting routes
+func NewFinanceHandler(protected *gin.RouterGroup, financeUC domain.FinanceReportUsecase) {
+	handler := &FinanceHandler{financeUC: financeUC}
+
+	finance := protected.Group("/admin/finance")
+	{
+		finance.GET("/summary", handler.GetSummary)
+		finance.GET("/months/:month", handler.GetMonthDetail)
+	}
+}
+
+// GetSummary godoc
+// @Summary      Get financial summary
+// @Description  Monthly revenue, credit consumption, and churn plus current outstanding invoices. Requires finance access.
+// @Tags         admin-finance
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        from    query     string  false  "First month (YYYY-MM), defaults to 11 months before 'to'"
+// @Param        to      query     string  false  "Last month (YYYY-MM), defaults to the current month"
+// @Param        format  query     string  false  "Set to 'csv' to download"
+// @Success      200     {object}  response.Response{data=domain.FinanceSummary}
+// @Failure      400     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Router       /admin/finance/summary [get]
+func (h *FinanceHandler) GetSummary(c *gin.Context) {
+	ctx := c.Request.Context()
+	from, to := c.Query("from"), c.Query("to")
+
+	if c.Query("format") == "csv" {
+		data, filename, err := h.financeUC.ExportSummaryCSV(ctx, from, to)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename="+filename)
+		c.Data(http.StatusOK, "text/csv", data)
+		return
+	}
+
+	summary, err := h.financeUC.GetSummary(ctx, from, to)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Finance summary", summary)
+}
+
+// GetMonthDetail godoc
+// @Summary      Get financial detail for one month
+// @Description  Revenue by plan, per-company credit usage, churned companies, and unpaid invoices issued in the month. Requires finance access.
+// @Tags         admin-finance
+// @Produce      json
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        month   path      string  true   "Month (YYYY-MM)"
+// @Param        format  query     string  false  "Set to 'csv' to download"
+// @Success      200     {object}  response.Response{data=domain.FinanceMonthDetail}
+// @Failure      400     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Router       /admin/finance/months/{month} [get]
+func (h *FinanceHandler) GetMonthDetail(c *gin.Context) {
+	ctx := c.Request.Context()
+	month := c.Param("month")
+
+	if c.Query("format") == "csv" {
+		data, filename, err := h.financeUC.ExportMonthCSV(ctx, month)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename="+filename)
+		c.Data(http.StatusOK, "text/csv", data)
+		return
+	}
+
+	detail, err := h.financeUC.GetMonthDetail(ctx, month)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Finance month detail", detail)
+}
@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/graph"
+	"go-recruitment-backend/pkg/i18n"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler serves the read-only GraphQL gateway (internal/delivery/graph)
+// for clients that want a job, its company and their application in one
+// request. It sits behind the same session auth as the REST routes.
+type GraphQLHandler struct {
+	server http.Handler
+}
+
+func NewGraphQLHandler(protected *gin.RouterGroup, resolver *graph.Resolver, introspection bool, maxComplexity int) {
+	handler := &GraphQLHandler{server: graph.NewServer(resolver, introspection, maxComplexity)}
+
+	protected.POST("/graphql", handler.Query)
+}
+
+// Query godoc
+// @Summary      GraphQL query
+// @Description  Runs a GraphQL query against the schema in internal/delivery/graph/schema.graphqls: published jobs with their company and the candidate's application, public company profiles, and the signed-in candidate's profile and applications. Errors carry the REST error code and status in `extensions`. Only enabled with GRAPHQL_ENABLED.
+// @Tags         GraphQL
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  response.Response
+// @Router       /graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	c.Request = c.Request.WithContext(graph.WithLocale(c.Request.Context(), c.GetString(i18n.ContextKey)))
+	h.server.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"go-recruitment-backend/config"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubGraphJobUC struct {
+	domain.JobUsecase
+	jobs map[int64]domain.JobWithCompany
+}
+
+func (s stubGraphJobUC) ListPublicActiveJobs(ctx context.Context, sort string, page, pageSize int) ([]domain.JobWithCompany, int64, error) {
+	if sort != "" && sort != "newest" {
+		return nil, 0, apperror.BadRequest("Invalid sort")
+	}
+	return []domain.JobWithCompany{s.jobs[1], s.jobs[2], s.jobs[3]}, 3, nil
+}
+
+func (s stubGraphJobUC) GetPublicJob(ctx context.Context, id int64) (*domain.JobWithCompany, error) {
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+	return &job, nil
+}
+
+type stubGraphCompanyProfileUC struct {
+	domain.CompanyProfileUsecase
+	calls atomic.Int32
+}
+
+func (s *stubGraphCompanyProfileUC) GetPublicProfile(ctx context.Context, id int64, viewer *domain.ViewerInfo) (*domain.PublicCompanyProfile, error) {
+	s.calls.Add(1)
+	return &domain.PublicCompanyProfile{ID: id, CompanyName: "Nippon Weld", VerificationLevel: "STANDARD", DetailsHidden: viewer.VerificationStatus != "VERIFIED"}, nil
+}
+
+type stubGraphApplicationUC struct {
+	domain.ApplicationUsecase
+	calls atomic.Int32
+}
+
+func (s *stubGraphApplicationUC) GetMyApplications(ctx context.Context, userID string) ([]domain.Application, error) {
+	s.calls.Add(1)
+	return []domain.Application{{ID: 7, JobID: 2, CandidateUserID: userID, Status: "reviewed"}}, nil
+}
+
+type stubGraphVerificationUC struct{ domain.VerificationUsecase }
+
+func (stubGraphVerificationUC) GetVerificationStatus(ctx context.Context, userID string) (*domain.VerificationResponse, error) {
+	return &domain.VerificationResponse{Verification: &domain.AccountVerification{Status: "VERIFIED"}}, nil
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func postGraphQL(t *testing.T, deps RouterDeps, role, query string) (*httptest.ResponseRecorder, graphQLResponse) {
+	t.Helper()
+	deps.Config = &config.Config{GraphQLEnabled: true, GraphQLMaxComplexity: 200}
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+
+	rec := serveRouter(newTestRouter(deps), authorizedRequest(t, http.MethodPost, "/v1/graphql", role, strings.NewReader(string(body)), "application/json"))
+	var resp graphQLResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+	}
+	return rec, resp
+}
+
+func graphTestDeps() (RouterDeps, *stubGraphCompanyProfileUC, *stubGraphApplicationUC) {
+	companies := &stubGraphCompanyProfileUC{}
+	applications := &stubGraphApplicationUC{}
+	confidential := domain.JobWithCompany{Job: domain.Job{ID: 3, Title: "Caregiver", IsConfidential: true}, CompanyName: domain.ConfidentialCompanyName}
+	return RouterDeps{
+		JobUC: stubGraphJobUC{jobs: map[int64]domain.JobWithCompany{
+			1: {Job: domain.Job{ID: 1, CompanyID: 10, Title: "Welder"}, CompanyName: "Nippon Weld"},
+			2: {Job: domain.Job{ID: 2, CompanyID: 10, Title: "Fitter"}, CompanyName: "Nippon Weld"},
+			3: confidential,
+		}},
+		CompanyProfileUC: companies,
+		ApplicationUC:    applications,
+		VerificationUC:   stubGraphVerificationUC{},
+	}, companies, applications
+}
+
+func TestGraphQLJobsWithCompanyAndApplication(t *testing.T) {
+	deps, companies, applications := graphTestDeps()
+
+	rec, resp := postGraphQL(t, deps, domain.RoleCandidate, `{
+		jobs { total items { id title companyName company { id name detailsHidden } myApplication { id status } } }
+	}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"jobs": {"total": 3, "items": [
+		{"id": "1", "title": "Welder", "companyName": "Nippon Weld", "company": {"id": "10", "name": "Nippon Weld", "detailsHidden": false}, "myApplication": null},
+		{"id": "2", "title": "Fitter", "companyName": "Nippon Weld", "company": {"id": "10", "name": "Nippon Weld", "detailsHidden": false}, "myApplication": {"id": "7", "status": "reviewed"}},
+		{"id": "3", "title": "Caregiver", "companyName": "Confidential Company", "company": null, "myApplication": null}
+	]}}`, string(resp.Data))
+
+	// One lookup per company and one for the applications, however many jobs
+	assert.Equal(t, int32(1), companies.calls.Load())
+	assert.Equal(t, int32(1), applications.calls.Load())
+}
+
+func TestGraphQLCandidateOnlyFields(t *testing.T) {
+	deps, _, applications := graphTestDeps()
+
+	rec, resp := postGraphQL(t, deps, domain.RoleEmployer, `{ job(id: 2) { id myApplication { id } } myApplications { id } }`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "FORBIDDEN", resp.Errors[0].Extensions["code"])
+	assert.EqualValues(t, http.StatusForbidden, resp.Errors[0].Extensions["status"])
+	assert.JSONEq(t, `null`, string(resp.Data), "myApplications is non-null, so its error nulls the whole result")
+	assert.Zero(t, applications.calls.Load())
+
+	rec, resp = postGraphQL(t, deps, domain.RoleEmployer, `{ job(id: 2) { id myApplication { id } } }`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"job": {"id": "2", "myApplication": null}}`, string(resp.Data))
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	deps, _, _ := graphTestDeps()
+
+	t.Run("missing job is null", func(t *testing.T) {
+		rec, resp := postGraphQL(t, deps, domain.RoleCandidate, `{ job(id: 99) { id } }`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Empty(t, resp.Errors)
+		assert.JSONEq(t, `{"job": null}`, string(resp.Data))
+	})
+
+	t.Run("usecase error keeps its code", func(t *testing.T) {
+		rec, resp := postGraphQL(t, deps, domain.RoleCandidate, `{ jobs(sort: "cheapest") { total } }`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "Invalid sort", resp.Errors[0].Message)
+		assert.Equal(t, "BAD_REQUEST", resp.Errors[0].Extensions["code"])
+	})
+
+	t.Run("query over the complexity limit", func(t *testing.T) {
+		query := "{" + strings.Repeat(" jobs { items { company { name } } }", 70) + " }"
+		rec, resp := postGraphQL(t, deps, domain.RoleCandidate, query)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NotEmpty(t, resp.Errors)
+		assert.Contains(t, resp.Errors[0].Message, "complexity")
+	})
+
+	t.Run("invalid session", func(t *testing.T) {
+		deps.Config = &config.Config{GraphQLEnabled: true, GraphQLMaxComplexity: 200}
+		req := httptest.NewRequest(http.MethodPost, "/v1/graphql", strings.NewReader(`{"query":"{ jobs { total } }"}`))
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		req.Header.Set("Content-Type", "application/json")
+		rec := serveRouter(newTestRouter(deps), req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestGraphQLDisabledByDefault(t *testing.T) {
+	deps, _, _ := graphTestDeps()
+	rec := serveRouter(newTestRouter(deps), authorizedRequest(t, http.MethodPost, "/v1/graphql", domain.RoleCandidate, strings.NewReader(`{"query":"{ jobs { total } }"}`), "application/json"))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
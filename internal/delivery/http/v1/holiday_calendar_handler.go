@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HolidayCalendarHandler struct {
+	calendarUC domain.HolidayCalendarUsecase
+}
+
+// NewHolidayCalendarHandler registers holiday calendar lookups and admin calendar management
+func NewHolidayCalendarHandler(protected *gin.RouterGroup, calendarUC domain.HolidayCalendarUsecase) {
+	handler := &HolidayCalendarHandler{calendarUC: calendarUC}
+
+	// Any authenticated user: date pickers and interview scheduling
+	calendar := protected.Group("/calendar")
+	{
+		calendar.GET("/holidays", handler.ListHolidays)
+		calendar.GET("/interview-days", handler.SuggestInterviewDays)
+	}
+
+	// Admin: manage calendar entries
+	admin := protected.Group("/admin/holidays")
+	{
+		admin.GET("", handler.ListHolidays)
+		admin.POST("", handler.CreateHoliday)
+		admin.PUT("/:id", handler.UpdateHoliday)
+		admin.DELETE("/:id", handler.DeleteHoliday)
+	}
+}
+
+// ListHolidays godoc
+// @Summary      List public holidays
+// @Description  Indonesian (ID) and Japanese (JP) public holidays, including inactive entries
+// @Tags         calendar
+// @Produce      json
+// @Security     BearerAuth
+// @Param        country  query     string  false  "ID or JP"
+// @Param        year     query     int     false  "Calendar year"
+// @Success      200      {object}  response.Response{data=[]domain.PublicHoliday}
+// @Failure      400      {object}  response.Response
+// @Router       /calendar/holidays [get]
+func (h *HolidayCalendarHandler) ListHolidays(c *gin.Context) {
+	year, _ := strconv.Atoi(c.Query("year"))
+	filter := domain.HolidayFilter{CountryCode: c.Query("country"), Year: year}
+
+	holidays, err := h.calendarUC.ListHolidays(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Holidays retrieved", holidays)
+}
+
+// SuggestInterviewDays godoc
+// @Summary      Suggest interview days
+// @Description  Next working days that are not weekends or public holidays in any of the given countries
+// @Tags         calendar
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from       query     string  false  "First candidate day (YYYY-MM-DD), defaults to tomorrow"
+// @Param        count      query     int     false  "Number of days (default 5, max 30)"
+// @Param        countries  query     string  false  "Comma-separated calendars, default ID,JP"
+// @Success      200        {object}  response.Response{data=[]string}
+// @Failure      400        {object}  response.Response
+// @Router       /calendar/interview-days [get]
+func (h *HolidayCalendarHandler) SuggestInterviewDays(c *gin.Context) {
+	count, _ := strconv.Atoi(c.DefaultQuery("count", "5"))
+	var countries []string
+	if raw := c.Query("countries"); raw != "" {
+		countries = strings.Split(raw, ",")
+	}
+
+	days, err := h.calendarUC.SuggestInterviewDays(c.Request.Context(), c.Query("from"), count, countries)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Interview days suggested", days)
+}
+
+// CreateHoliday godoc
+// @Summary      Add a public holiday
+// @Tags         admin-calendar
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.HolidayRequest  true  "Holiday"
+// @Success      201      {object}  response.Response{data=domain.PublicHoliday}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /admin/holidays [post]
+func (h *HolidayCalendarHandler) CreateHoliday(c *gin.Context) {
+	var req domain.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	holiday, err := h.calendarUC.CreateHoliday(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Holiday created", holiday)
+}
+
+// UpdateHoliday godoc
+// @Summary      Update a public holiday
+// @Description  Set is_active=false to keep the entry but treat the day as a working day
+// @Tags         admin-calendar
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                    true  "Holiday ID"
+// @Param        request  body      domain.HolidayRequest  true  "Holiday"
+// @Success      200      {object}  response.Response{data=domain.PublicHoliday}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /admin/holidays/{id} [put]
+func (h *HolidayCalendarHandler) UpdateHoliday(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid holiday ID"))
+		return
+	}
+
+	var req domain.HolidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	holiday, err := h.calendarUC.UpdateHoliday(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Holiday updated", holiday)
+}
+
+// DeleteHoliday godoc
+// @Summary      Delete a public holiday
+// @Tags         admin-calendar
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Holiday ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/holidays/{id} [delete]
+func (h *HolidayCalendarHandler) DeleteHoliday(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid holiday ID"))
+		return
+	}
+
+	if err := h.calendarUC.DeleteHoliday(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Holiday deleted", nil)
+}
@@ -0,0 +1,236 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InterviewFeedbackHandler struct {
+	feedbackUC domain.InterviewFeedbackUsecase
+}
+
+// NewInterviewFeedbackHandler registers employer, candidate and admin interview feedback routes
+func NewInterviewFeedbackHandler(protected *gin.RouterGroup, feedbackUC domain.InterviewFeedbackUsecase) {
+	handler := &InterviewFeedbackHandler{feedbackUC: feedbackUC}
+
+	// Employer: approved wordings and feedback on rejected applications
+	employers := protected.Group("/employers")
+	{
+		employers.GET("/interview-feedback/templates", handler.ListTemplates)
+		employers.GET("/applications/:id/feedback", handler.GetFeedback)
+		employers.POST("/applications/:id/feedback", handler.SubmitFeedback)
+	}
+
+	// Candidate: received feedback and opt-out
+	candidate := protected.Group("/candidates/me/interview-feedback")
+	{
+		candidate.GET("", handler.ListMyFeedback)
+		candidate.GET("/preference", handler.GetPreference)
+		candidate.PUT("/preference", handler.UpdatePreference)
+	}
+
+	// Admin: review queue for custom messages
+	admin := protected.Group("/admin/interview-feedback")
+	{
+		admin.GET("", handler.ListForReview)
+		admin.POST("/:id/review", handler.ReviewFeedback)
+	}
+}
+
+// ListTemplates godoc
+// @Summary      List approved feedback wordings
+// @Description  Employers build feedback from these; wording is in the request's language
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.InterviewFeedbackTemplate}
+// @Router       /employers/interview-feedback/templates [get]
+func (h *InterviewFeedbackHandler) ListTemplates(c *gin.Context) {
+	templates := h.feedbackUC.ListTemplates(c.Request.Context(), c.GetString(i18n.ContextKey))
+	response.Success(c, http.StatusOK, "Feedback templates retrieved", templates)
+}
+
+// SubmitFeedback godoc
+// @Summary      Share feedback with a rejected candidate
+// @Description  Feedback with only approved wordings is sent right away. A custom message is held for admin review first. Candidates who opted out do not receive it.
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                    true  "Application ID"
+// @Param        request  body      domain.SubmitInterviewFeedbackRequest  true  "Template keys and optional message"
+// @Success      201      {object}  response.Response{data=domain.InterviewFeedback}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /employers/applications/{id}/feedback [post]
+func (h *InterviewFeedbackHandler) SubmitFeedback(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid application ID"))
+		return
+	}
+
+	var req domain.SubmitInterviewFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	feedback, err := h.feedbackUC.SubmitFeedback(c.Request.Context(), userID, id, req, c.GetString(i18n.ContextKey))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Interview feedback saved", feedback)
+}
+
+// GetFeedback godoc
+// @Summary      Get the feedback shared on an application
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Application ID"
+// @Success      200  {object}  response.Response{data=domain.InterviewFeedback}
+// @Failure      404  {object}  response.Response
+// @Router       /employers/applications/{id}/feedback [get]
+func (h *InterviewFeedbackHandler) GetFeedback(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid application ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	feedback, err := h.feedbackUC.GetFeedback(c.Request.Context(), userID, id, c.GetString(i18n.ContextKey))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Interview feedback retrieved", feedback)
+}
+
+// ListMyFeedback godoc
+// @Summary      List feedback I received
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.InterviewFeedback}
+// @Router       /candidates/me/interview-feedback [get]
+func (h *InterviewFeedbackHandler) ListMyFeedback(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	feedback, err := h.feedbackUC.ListMyFeedback(c.Request.Context(), userID, c.GetString(i18n.ContextKey))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Interview feedback retrieved", feedback)
+}
+
+// GetPreference godoc
+// @Summary      Get my interview feedback preference
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.InterviewFeedbackPreference}
+// @Router       /candidates/me/interview-feedback/preference [get]
+func (h *InterviewFeedbackHandler) GetPreference(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	pref, err := h.feedbackUC.GetPreference(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Preference retrieved", pref)
+}
+
+// UpdatePreference godoc
+// @Summary      Opt out of (or back into) interview feedback
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.UpdateInterviewFeedbackPreferenceRequest  true  "Opt-out flag"
+// @Success      200      {object}  response.Response{data=domain.InterviewFeedbackPreference}
+// @Failure      400      {object}  response.Response
+// @Router       /candidates/me/interview-feedback/preference [put]
+func (h *InterviewFeedbackHandler) UpdatePreference(c *gin.Context) {
+	var req domain.UpdateInterviewFeedbackPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	pref, err := h.feedbackUC.UpdatePreference(c.Request.Context(), userID, *req.OptOut)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Preference updated", pref)
+}
+
+// ListForReview godoc
+// @Summary      List interview feedback by status
+// @Description  Defaults to feedback waiting for review, oldest first
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status  query     string  false  "PENDING_REVIEW (default), APPROVED, SENT, SUPPRESSED or REJECTED"
+// @Success      200     {object}  response.Response{data=[]domain.InterviewFeedback}
+// @Failure      400     {object}  response.Response
+// @Failure      403     {object}  response.Response
+// @Router       /admin/interview-feedback [get]
+func (h *InterviewFeedbackHandler) ListForReview(c *gin.Context) {
+	feedback, err := h.feedbackUC.ListForReview(c.Request.Context(), c.Query("status"), c.GetString(i18n.ContextKey))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Interview feedback retrieved", feedback)
+}
+
+// ReviewFeedback godoc
+// @Summary      Approve or reject interview feedback
+// @Description  Approved feedback is sent to the candidate immediately; approving feedback whose delivery failed retries it
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                    true  "Feedback ID"
+// @Param        request  body      domain.ReviewInterviewFeedbackRequest  true  "Decision"
+// @Success      200      {object}  response.Response{data=domain.InterviewFeedback}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /admin/interview-feedback/{id}/review [post]
+func (h *InterviewFeedbackHandler) ReviewFeedback(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid feedback ID"))
+		return
+	}
+
+	var req domain.ReviewInterviewFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	adminID := c.GetString(string(domain.KeyUserID))
+	feedback, err := h.feedbackUC.ReviewFeedback(c.Request.Context(), adminID, id, req, c.GetString(i18n.ContextKey))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Interview feedback reviewed", feedback)
+}
@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InviteCodeHandler struct {
+	inviteUC domain.InviteCodeUsecase
+}
+
+// NewInviteCodeHandler registers the admin invite code routes
+func NewInviteCodeHandler(protected *gin.RouterGroup, inviteUC domain.InviteCodeUsecase) {
+	handler := &InviteCodeHandler{inviteUC: inviteUC}
+
+	admin := protected.Group("/admin/invite-codes")
+	{
+		admin.POST("", handler.Generate)
+		admin.GET("", handler.List)
+		admin.GET("/:id", handler.Get)
+		admin.POST("/:id/revoke", handler.Revoke)
+	}
+}
+
+// Generate godoc
+// @Summary      Generate invite codes
+// @Description  Creates count codes with the same limits. max_uses defaults to 1 (single use); role limits a code to candidate or employer registrations.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.GenerateInviteCodesRequest  true  "Batch size and limits"
+// @Success      201      {object}  response.Response{data=[]domain.InviteCode}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /admin/invite-codes [post]
+func (h *InviteCodeHandler) Generate(c *gin.Context) {
+	var req domain.GenerateInviteCodesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	adminID := c.GetString(string(domain.KeyUserID))
+	codes, err := h.inviteUC.Generate(c.Request.Context(), adminID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Invite codes generated", codes)
+}
+
+// List godoc
+// @Summary      List invite codes
+// @Description  Newest first. status defaults to ACTIVE; ALL lists every status.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false  "ACTIVE, USED_UP, EXPIRED, REVOKED or ALL"
+// @Param        role      query     string  false  "candidate or employer"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.InviteCode]}
+// @Failure      400       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /admin/invite-codes [get]
+func (h *InviteCodeHandler) List(c *gin.Context) {
+	filter := domain.InviteCodeFilter{
+		Status: c.Query("status"),
+		Role:   c.Query("role"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.inviteUC.List(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Invite codes retrieved", result)
+}
+
+// Get godoc
+// @Summary      Get an invite code
+// @Description  The code with every registration made with it, newest first
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Invite code ID"
+// @Success      200  {object}  response.Response{data=domain.InviteCode}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/invite-codes/{id} [get]
+func (h *InviteCodeHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid invite code ID"))
+		return
+	}
+
+	code, err := h.inviteUC.Get(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Invite code retrieved", code)
+}
+
+// Revoke godoc
+// @Summary      Revoke an invite code
+// @Description  The code stops working; registrations already made with it are kept
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Invite code ID"
+// @Success      200  {object}  response.Response{data=domain.InviteCode}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/invite-codes/{id}/revoke [post]
+func (h *InviteCodeHandler) Revoke(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid invite code ID"))
+		return
+	}
+
+	code, err := h.inviteUC.Revoke(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Invite code revoked", code)
+}
@@ -1,11 +1,16 @@
 package v1
 
 import (
+	"errors"
+	"fmt"
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,8 +26,10 @@ func NewJobHandler(public *gin.RouterGroup, protected *gin.RouterGroup, jobUC do
 	// These endpoints only return active jobs (server-side enforced)
 	publicJobs := public.Group("/jobs")
 	{
-		publicJobs.GET("/public", handler.PublicList)           // List active jobs only
-		publicJobs.GET("/public/:id", handler.PublicGetDetails) // Get active job details
+		publicJobs.GET("/public", handler.PublicList)                   // List active jobs only
+		publicJobs.GET("/search", handler.Search)                       // Full-text search over active jobs
+		publicJobs.GET("/public/:id", handler.PublicGetDetails)         // Get active job details
+		publicJobs.GET("/public/:id/page", handler.PublicGetDetailPage) // Job detail page read model
 	}
 
 	// PROTECTED routes - authentication required
@@ -33,12 +40,16 @@ func NewJobHandler(public *gin.RouterGroup, protected *gin.RouterGroup, jobUC do
 		protectedJobs.POST("", handler.Create)
 		protectedJobs.PUT("/:id", handler.Update)
 		protectedJobs.DELETE("/:id", handler.Delete)
+		protectedJobs.POST("/:id/reopen", handler.Reopen) // Reopen a job closed by its deadline or applicant cap
 	}
 
 	// Employer-specific job routes (only shows employer's own jobs)
 	employers := protected.Group("/employers")
 	{
 		employers.GET("/jobs", handler.ListByEmployer)
+		employers.POST("/jobs/import", handler.Import)                    // Create jobs from a CSV or XLSX file
+		employers.PUT("/jobs/:jobId/schedule", handler.Schedule)          // Schedule or reschedule publishing
+		employers.DELETE("/jobs/:jobId/schedule", handler.CancelSchedule) // Cancel a pending publish or auto-unpublish
 	}
 }
 
@@ -47,11 +58,24 @@ type CreateJobRequest struct {
 	Description     string  `json:"description" binding:"required"`
 	SalaryMin       float64 `json:"salary_min" binding:"required,gt=0"`
 	SalaryMax       float64 `json:"salary_max" binding:"required,gt=0,gtefield=SalaryMin"`
-	Location        string  `json:"location" binding:"required"`
+	Location        string  `json:"location" binding:"required_without=LocationID"`
 	EmploymentType  string  `json:"employment_type"`
 	JobType         string  `json:"job_type"`
 	ExperienceLevel string  `json:"experience_level"`
 	Qualifications  string  `json:"qualifications"`
+	// Office of the company the job is based at; location defaults to its city
+	LocationID *int64 `json:"location_id" binding:"omitempty,gt=0"`
+	// Minimum JLPT level; empty means no Japanese requirement
+	JapaneseLevelRequired string `json:"japanese_level_required" binding:"omitempty,oneof=N1 N2 N3 N4 N5"`
+	// Hide the company on public pages; needs STANDARD verification or higher
+	IsConfidential bool `json:"is_confidential"`
+	// Optional schedule: publish later and/or unpublish automatically
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	// Optional application limits; auto_close (default true) closes the job when one is reached
+	ApplyDeadline *time.Time `json:"apply_deadline"`
+	MaxApplicants *int       `json:"max_applicants" binding:"omitempty,min=1"`
+	AutoClose     *bool      `json:"auto_close"`
 }
 
 type UpdateJobRequest struct {
@@ -59,16 +83,47 @@ type UpdateJobRequest struct {
 	Description     string  `json:"description" binding:"required"`
 	SalaryMin       float64 `json:"salary_min" binding:"required,gt=0"`
 	SalaryMax       float64 `json:"salary_max" binding:"required,gt=0,gtefield=SalaryMin"`
-	Location        string  `json:"location" binding:"required"`
+	Location        string  `json:"location" binding:"required_without=LocationID"`
 	EmploymentType  string  `json:"employment_type"`
 	JobType         string  `json:"job_type"`
 	ExperienceLevel string  `json:"experience_level"`
 	Qualifications  string  `json:"qualifications"`
+	// Office of the company the job is based at; location defaults to its city
+	LocationID *int64 `json:"location_id" binding:"omitempty,gt=0"`
+	// Minimum JLPT level; empty means no Japanese requirement
+	JapaneseLevelRequired string `json:"japanese_level_required" binding:"omitempty,oneof=N1 N2 N3 N4 N5"`
+	// Hide the company on public pages; needs STANDARD verification or higher
+	IsConfidential bool `json:"is_confidential"`
+	// Application limits; auto_close (default true) closes the job when one is reached
+	ApplyDeadline *time.Time `json:"apply_deadline"`
+	MaxApplicants *int       `json:"max_applicants" binding:"omitempty,min=1"`
+	AutoClose     *bool      `json:"auto_close"`
+}
+
+// autoCloseOrDefault closes jobs automatically unless auto_close is false
+func autoCloseOrDefault(autoClose *bool) bool {
+	return autoClose == nil || *autoClose
+}
+
+// JobListResponse is the paginated job list returned to candidates and the public
+type JobListResponse struct {
+	Jobs     []domain.JobWithCompany `json:"jobs"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+// EmployerJobListResponse is the paginated list of an employer's own jobs
+type EmployerJobListResponse struct {
+	Jobs     []domain.Job `json:"jobs"`
+	Total    int64        `json:"total"`
+	Page     int          `json:"page"`
+	PageSize int          `json:"page_size"`
 }
 
 // CreateJob godoc
 // @Summary      Create a new job
-// @Description  Create a new job posting (Employer only)
+// @Description  Create a new job posting (Employer only). location_id bases the job at one of the company's offices (GET /employers/company-profile/locations); location then defaults to the office's city. is_confidential hides the company on public pages and needs STANDARD verification or higher. Applications stop at apply_deadline or after max_applicants; with auto_close (default true) the job is then closed and leaves public listings.
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
@@ -82,7 +137,7 @@ type UpdateJobRequest struct {
 func (h *JobHandler) Create(c *gin.Context) {
 	// 1. Role Check
 	role := c.GetString(string(domain.KeyUserRole))
-	if role != "employer" && role != "admin" {
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
 		c.Error(apperror.Forbidden("Only employers or admins can create jobs"))
 		return
 	}
@@ -106,16 +161,24 @@ func (h *JobHandler) Create(c *gin.Context) {
 	}
 
 	job := &domain.Job{
-		Title:           req.Title,
-		Description:     req.Description,
-		SalaryMin:       req.SalaryMin,
-		SalaryMax:       req.SalaryMax,
-		Location:        req.Location,
-		EmploymentType:  toPtr(req.EmploymentType),
-		JobType:         toPtr(req.JobType),
-		ExperienceLevel: toPtr(req.ExperienceLevel),
-		Qualifications:  toPtr(req.Qualifications),
-		CompanyStatus:   "active",
+		Title:                 req.Title,
+		Description:           req.Description,
+		SalaryMin:             req.SalaryMin,
+		SalaryMax:             req.SalaryMax,
+		Location:              req.Location,
+		LocationID:            req.LocationID,
+		EmploymentType:        toPtr(req.EmploymentType),
+		JobType:               toPtr(req.JobType),
+		ExperienceLevel:       toPtr(req.ExperienceLevel),
+		Qualifications:        toPtr(req.Qualifications),
+		JapaneseLevelRequired: toPtr(req.JapaneseLevelRequired),
+		IsConfidential:        req.IsConfidential,
+		CompanyStatus:         domain.JobStatusActive,
+		PublishAt:             req.PublishAt,
+		UnpublishAt:           req.UnpublishAt,
+		ApplyDeadline:         req.ApplyDeadline,
+		MaxApplicants:         req.MaxApplicants,
+		AutoClose:             autoCloseOrDefault(req.AutoClose),
 	}
 
 	if err := h.jobUC.CreateJob(c, userID, job); err != nil {
@@ -128,32 +191,137 @@ func (h *JobHandler) Create(c *gin.Context) {
 
 // PublicListJobs godoc
 // @Summary      List active jobs (public)
-// @Description  Get a list of active jobs for public access (no auth required)
+// @Description  Get a list of active jobs for public access (no auth required), with popularity signals (application count band, views in the last 7 days, NEW / CLOSING_SOON badges)
 // @Tags         jobs
 // @Produce      json
-// @Param        page       query     int  false  "Page number"
-// @Param        page_size  query     int  false  "Page size"
+// @Param        sort       query     string  false  "newest (default) or popular (most views and applications in the last 7 days)"
+// @Param        page       query     int     false  "Page number"
+// @Param        page_size  query     int     false  "Page size"
 // @Success      200        {object}  response.Response
+// @Failure      400        {object}  response.Response
 // @Router       /jobs/public [get]
 func (h *JobHandler) PublicList(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 
 	// SECURITY: Always return only active jobs - no client-side bypass possible
-	jobs, total, err := h.jobUC.ListPublicActiveJobs(c, page, pageSize)
+	jobs, total, err := h.jobUC.ListPublicActiveJobs(c, c.Query("sort"), page, pageSize)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Public job list", gin.H{
-		"jobs":      jobs,
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
+	response.Success(c, http.StatusOK, "Public job list", JobListResponse{
+		Jobs:     jobs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
 	})
 }
 
+// SearchJobs godoc
+// @Summary      Search active jobs (public)
+// @Description  Full-text search over job title, description and qualifications, combined with filters. Keyword results are ranked by relevance (title matches first).
+// @Tags         jobs
+// @Produce      json
+// @Param        q                query     string  false  "Keywords; supports \"exact phrases\", OR and -excluded words"
+// @Param        location         query     string  false  "Comma-separated locations (substring match)"
+// @Param        city             query     string  false  "Comma-separated cities of the company office the job is based at"
+// @Param        near             query     string  false  "latitude,longitude: jobs whose office is within radius_km (confidential jobs excluded)"
+// @Param        radius_km        query     number  false  "Radius for near (default: 25, max: 200)"
+// @Param        salary_min       query     number  false  "Jobs whose maximum salary reaches this"
+// @Param        salary_max       query     number  false  "Jobs whose minimum salary does not exceed this"
+// @Param        employment_type  query     string  false  "Comma-separated employment types"
+// @Param        japanese_level   query     string  false  "Candidate's JLPT level (N1-N5): jobs requiring it, an easier level or none"
+// @Param        sort             query     string  false  "relevance (default with q), newest (default without q), salary, popular"
+// @Param        page             query     int     false  "Page number (default: 1)"
+// @Param        page_size        query     int     false  "Items per page (default: 10, max: 50)"
+// @Success      200  {object}  response.Response{data=domain.PaginatedResult[domain.JobSearchResult]}
+// @Failure      400  {object}  response.Response
+// @Router       /jobs/search [get]
+func (h *JobHandler) Search(c *gin.Context) {
+	params := domain.JobSearchParams{
+		Query:         c.Query("q"),
+		JapaneseLevel: strings.ToUpper(c.Query("japanese_level")),
+		Sort:          c.Query("sort"),
+	}
+	if locations := c.Query("location"); locations != "" {
+		params.Locations = splitQueryList(locations)
+	}
+	if cities := c.Query("city"); cities != "" {
+		params.Cities = splitQueryList(cities)
+	}
+	if near := c.Query("near"); near != "" {
+		point, ok := parseGeoPoint(near)
+		if !ok {
+			c.Error(apperror.BadRequest("Invalid near, expected latitude,longitude"))
+			return
+		}
+		params.Near = &point
+		if radius := c.Query("radius_km"); radius != "" {
+			v, err := strconv.ParseFloat(radius, 64)
+			if err != nil {
+				c.Error(apperror.BadRequest("Invalid radius_km"))
+				return
+			}
+			params.RadiusKm = v
+		}
+	}
+	if types := c.Query("employment_type"); types != "" {
+		params.EmploymentTypes = splitQueryList(types)
+	}
+	if min := c.Query("salary_min"); min != "" {
+		v, err := strconv.ParseFloat(min, 64)
+		if err != nil || v < 0 {
+			c.Error(apperror.BadRequest("Invalid salary_min"))
+			return
+		}
+		params.SalaryMin = &v
+	}
+	if max := c.Query("salary_max"); max != "" {
+		v, err := strconv.ParseFloat(max, 64)
+		if err != nil || v < 0 {
+			c.Error(apperror.BadRequest("Invalid salary_max"))
+			return
+		}
+		params.SalaryMax = &v
+	}
+	params.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	params.PageSize, _ = strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	result, err := h.jobUC.SearchJobs(c.Request.Context(), params)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Jobs found", result)
+}
+
+// splitQueryList splits a comma-separated query value, dropping blanks
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseGeoPoint parses "latitude,longitude"
+func parseGeoPoint(value string) (domain.GeoPoint, bool) {
+	lat, lng, found := strings.Cut(value, ",")
+	if !found {
+		return domain.GeoPoint{}, false
+	}
+	latitude, err1 := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	longitude, err2 := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err1 != nil || err2 != nil {
+		return domain.GeoPoint{}, false
+	}
+	return domain.GeoPoint{Latitude: latitude, Longitude: longitude}, true
+}
+
 // PublicGetDetails godoc
 // @Summary      Get active job details (public)
 // @Description  Get detailed info of an active job (no auth required)
@@ -172,20 +340,39 @@ func (h *JobHandler) PublicGetDetails(c *gin.Context) {
 		return
 	}
 
-	// Return job with company profile data
-	job, err := h.jobUC.GetJobDetailsWithCompany(c, id)
+	// Return job with company profile data; only published jobs are returned
+	job, err := h.jobUC.GetPublicJob(c, id)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	// SECURITY: Only return active jobs via public endpoint
-	if job.CompanyStatus != "active" {
-		c.Error(apperror.NotFound("Job not found"))
+	response.Success(c, http.StatusOK, "Job details", job)
+}
+
+// PublicGetDetailPage godoc
+// @Summary      Get the public job detail page (public)
+// @Description  Active job with company info, similar jobs, the company's other openings and an application count band, in one call. Jobs based at a company office also get a location_map (address, coordinates and a map link); confidential jobs only show the city.
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  response.Response{data=domain.PublicJobDetail}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /jobs/public/{id}/page [get]
+func (h *JobHandler) PublicGetDetailPage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid ID format"))
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Job details", job)
+	detail, err := h.jobUC.GetPublicJobDetail(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Job details", detail)
 }
 
 // ListJobs godoc
@@ -209,11 +396,11 @@ func (h *JobHandler) List(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Job list", gin.H{
-		"jobs":      jobs,
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
+	response.Success(c, http.StatusOK, "Job list", JobListResponse{
+		Jobs:     jobs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
 	})
 }
 
@@ -232,7 +419,7 @@ func (h *JobHandler) List(c *gin.Context) {
 func (h *JobHandler) ListByEmployer(c *gin.Context) {
 	// Check role - only employers can access
 	role := c.GetString(string(domain.KeyUserRole))
-	if role != "employer" && role != "admin" {
+	if role != domain.RoleEmployer && role != domain.RoleAdmin {
 		c.Error(apperror.Forbidden("Only employers can access their job list"))
 		return
 	}
@@ -252,17 +439,81 @@ func (h *JobHandler) ListByEmployer(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, "Employer job list", gin.H{
-		"jobs":      jobs,
-		"total":     total,
-		"page":      page,
-		"page_size": pageSize,
+	response.Success(c, http.StatusOK, "Employer job list", EmployerJobListResponse{
+		Jobs:     jobs,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
 	})
 }
 
+// ScheduleJob godoc
+// @Summary      Schedule a job's publishing
+// @Description  Sets when one of the employer's jobs publishes and unpublishes. A future publish_at hides the job until then (status "scheduled"); a missing or past publish_at publishes now. A missing unpublish_at keeps the job up. Also reschedules scheduled, draft and expired jobs.
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Param        jobId    path      int                        true  "Job ID"
+// @Param        request  body      domain.JobScheduleRequest  true  "Schedule"
+// @Success      200      {object}  response.Response{data=domain.Job}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /employers/jobs/{jobId}/schedule [put]
+// @Security     BearerAuth
+func (h *JobHandler) Schedule(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	var req domain.JobScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	job, err := h.jobUC.ScheduleJob(c.Request.Context(), c.GetString(string(domain.KeyUserID)), jobID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job schedule updated", job)
+}
+
+// CancelJobSchedule godoc
+// @Summary      Cancel a job's schedule
+// @Description  Cancels a pending publication (the job becomes a "draft") or, for a published job, its automatic unpublishing
+// @Tags         employers
+// @Produce      json
+// @Param        jobId  path      int  true  "Job ID"
+// @Success      200    {object}  response.Response{data=domain.Job}
+// @Failure      403    {object}  response.Response
+// @Failure      404    {object}  response.Response
+// @Failure      409    {object}  response.Response
+// @Router       /employers/jobs/{jobId}/schedule [delete]
+// @Security     BearerAuth
+func (h *JobHandler) CancelSchedule(c *gin.Context) {
+	jobID, err := strconv.ParseInt(c.Param("jobId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	job, err := h.jobUC.CancelJobSchedule(c.Request.Context(), c.GetString(string(domain.KeyUserID)), jobID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Job schedule cancelled", job)
+}
+
 // GetJobDetails godoc
 // @Summary      Get job details
-// @Description  Get detailed info of a job with company profile
+// @Description  Get detailed info of a job with company profile. The company of a confidential job is shown only to admins, the posting employer and candidates whose application was advanced.
 // @Tags         jobs
 // @Produce      json
 // @Param        id   path      int  true  "Job ID"
@@ -317,9 +568,47 @@ func (h *JobHandler) Delete(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Job deleted successfully", nil)
 }
 
+// ReopenJob godoc
+// @Summary      Reopen a closed job
+// @Description  Reopens one of the employer's jobs closed by its apply deadline or applicant cap. The optional body sets a later apply_deadline or a higher max_applicants; missing fields keep the current limits, and the job must not be due to close again.
+// @Tags         jobs
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                      true   "Job ID"
+// @Param        request  body      domain.ReopenJobRequest  false  "New application limits"
+// @Success      200      {object}  response.Response{data=domain.Job}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /jobs/{id}/reopen [post]
+// @Security     BearerAuth
+func (h *JobHandler) Reopen(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid job ID"))
+		return
+	}
+
+	var req domain.ReopenJobRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+
+	job, err := h.jobUC.ReopenJob(c.Request.Context(), c.GetString(string(domain.KeyUserID)), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Job reopened", job)
+}
+
 // UpdateJob godoc
 // @Summary      Update a job
-// @Description  Update an existing job posting
+// @Description  Update an existing job posting. location_id must name one of the job company's offices. Changing apply_deadline or max_applicants does not reopen a closed job; use POST /jobs/{id}/reopen.
 // @Tags         jobs
 // @Accept       json
 // @Produce      json
@@ -345,12 +634,17 @@ func (h *JobHandler) Update(c *gin.Context) {
 	}
 
 	job := &domain.Job{
-		ID:          id,
-		Title:       req.Title,
-		Description: req.Description,
-		SalaryMin:   req.SalaryMin,
-		SalaryMax:   req.SalaryMax,
-		Location:    req.Location,
+		ID:             id,
+		Title:          req.Title,
+		Description:    req.Description,
+		SalaryMin:      req.SalaryMin,
+		SalaryMax:      req.SalaryMax,
+		Location:       req.Location,
+		LocationID:     req.LocationID,
+		IsConfidential: req.IsConfidential,
+		ApplyDeadline:  req.ApplyDeadline,
+		MaxApplicants:  req.MaxApplicants,
+		AutoClose:      autoCloseOrDefault(req.AutoClose),
 	}
 
 	// Set optional fields (convert empty to nil)
@@ -366,6 +660,9 @@ func (h *JobHandler) Update(c *gin.Context) {
 	if req.Qualifications != "" {
 		job.Qualifications = &req.Qualifications
 	}
+	if req.JapaneseLevelRequired != "" {
+		job.JapaneseLevelRequired = &req.JapaneseLevelRequired
+	}
 
 	err = h.jobUC.UpdateJob(c, job)
 	if err != nil {
@@ -375,3 +672,62 @@ func (h *JobHandler) Update(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Job updated successfully", job)
 }
+
+// Import godoc
+// @Summary      Import jobs from a CSV or XLSX file
+// @Description  Creates the employer's jobs from a CSV file or the first sheet of an XLSX file. The header row names the columns: title, description, salary_min, salary_max and location are required; employment_type, job_type, experience_level, qualifications, japanese_level_required, apply_deadline, max_applicants and auto_close are optional. Each row is validated like POST /jobs; the valid rows are created in one transaction and the report lists the new job or the errors of every row.
+// @Tags         employers
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "Job postings (.csv or .xlsx)"
+// @Success      200   {object}  response.Response{data=domain.JobImportResult}
+// @Failure      400   {object}  response.Response
+// @Failure      403   {object}  response.Response
+// @Failure      413   {object}  response.Response
+// @Failure      429   {object}  response.Response
+// @Router       /employers/jobs/import [post]
+// @Security     BearerAuth
+func (h *JobHandler) Import(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	// Same limits as /upload: the file is held in memory while parsing
+	allowed, retryAfter, err := uploadLimiter.AllowUpload(c.Request.Context(), c.ClientIP(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("Upload rate limiter unavailable", "error", err)
+	}
+	if !allowed {
+		c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
+		response.Error(c, http.StatusTooManyRequests, "Upload rate limit exceeded. Please try again later.", nil)
+		return
+	}
+	select {
+	case uploadSlots <- struct{}{}:
+		defer func() { <-uploadSlots }()
+	default:
+		c.Header("Retry-After", "5")
+		response.Error(c, http.StatusServiceUnavailable, "Too many uploads in progress. Please try again shortly.", nil)
+		return
+	}
+
+	filename, data, err := readMultipartFileWith(c.Request, "file", maxUploadSize, checkJobImportName)
+	if err != nil {
+		respondUploadReadError(c, err)
+		return
+	}
+
+	result, err := h.jobUC.ImportJobs(c.Request.Context(), userID, filename, data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Jobs imported", result)
+}
+
+// checkJobImportName accepts the spreadsheet formats of a job import
+func checkJobImportName(filename string) error {
+	switch strings.ToLower(getExtension(filename)) {
+	case "csv", "xlsx":
+		return nil
+	}
+	return errors.New("Upload a .csv or .xlsx file")
+}
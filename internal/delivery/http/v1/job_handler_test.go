@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubJobUC struct {
+	domain.JobUsecase
+	userID   string
+	filename string
+	data     []byte
+}
+
+func (s *stubJobUC) ImportJobs(ctx context.Context, userID, filename string, data []byte) (*domain.JobImportResult, error) {
+	s.userID, s.filename, s.data = userID, filename, data
+	return &domain.JobImportResult{}, nil
+}
+
+func TestImportJobsThroughRouter(t *testing.T) {
+	jobUC := &stubJobUC{}
+	r := newTestRouter(RouterDeps{JobUC: jobUC})
+
+	csv := "title,description,salary_min,salary_max,location\n" +
+		"Welder,Shipyard welding in Nagasaki,220000,260000,Nagasaki\n" +
+		"Caregiver,Elderly care facility night shifts,200000,240000,Osaka\n"
+
+	body, contentType := multipartFile(t, "jobs.csv", []byte(csv))
+	rec := serveRouter(r, authorizedRequest(t, http.MethodPost, "/v1/employers/jobs/import", domain.RoleEmployer, body, contentType))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, domain.RoleEmployer, jobUC.userID)
+	assert.Equal(t, "jobs.csv", jobUC.filename)
+	assert.Equal(t, csv, string(jobUC.data))
+
+	body, contentType = multipartFile(t, "jobs.txt", []byte(csv))
+	rec = serveRouter(r, authorizedRequest(t, http.MethodPost, "/v1/employers/jobs/import", domain.RoleEmployer, body, contentType))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveRouter(r, authorizedRequest(t, http.MethodPost, "/v1/employers/jobs/import", domain.RoleEmployer, strings.NewReader(csv), "text/csv"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+}
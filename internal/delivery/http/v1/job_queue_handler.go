@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/jobs"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobQueueHandler struct {
+	jobQueueUC domain.JobQueueUsecase
+}
+
+// NewJobQueueHandler registers the admin background job queue routes
+func NewJobQueueHandler(protected *gin.RouterGroup, jobQueueUC domain.JobQueueUsecase) {
+	handler := &JobQueueHandler{jobQueueUC: jobQueueUC}
+
+	admin := protected.Group("/admin/jobs-queue")
+	{
+		admin.GET("", handler.List)
+		admin.GET("/counts", handler.Counts)
+		admin.POST("/:id/retry", handler.Retry)
+	}
+}
+
+// List godoc
+// @Summary      List background jobs
+// @Description  Most recently updated first. status defaults to dead (the dead-letter list); ALL lists every status.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false  "queued, running, done, dead or ALL"
+// @Param        type      query     string  false  "Job type, e.g. contact_email"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[jobs.Job]}
+// @Failure      400       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /admin/jobs-queue [get]
+func (h *JobQueueHandler) List(c *gin.Context) {
+	filter := jobs.ListFilter{
+		Status: c.Query("status"),
+		Type:   c.Query("type"),
+	}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.jobQueueUC.List(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Background jobs retrieved", result)
+}
+
+// Counts godoc
+// @Summary      Count background jobs
+// @Description  Number of jobs per type and status
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]jobs.TypeCount}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/jobs-queue/counts [get]
+func (h *JobQueueHandler) Counts(c *gin.Context) {
+	counts, err := h.jobQueueUC.Counts(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Background job counts retrieved", counts)
+}
+
+// Retry godoc
+// @Summary      Retry a dead job
+// @Description  Queues the job again with a fresh set of attempts
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  response.Response{data=jobs.Job}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/jobs-queue/{id}/retry [post]
+func (h *JobQueueHandler) Retry(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid background job ID"))
+		return
+	}
+
+	job, err := h.jobQueueUC.Retry(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Background job queued for retry", job)
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KillSwitchHandler struct {
+	killSwitchUC domain.KillSwitchUsecase
+}
+
+// NewKillSwitchHandler registers admin routes for runtime kill switches / maintenance windows
+func NewKillSwitchHandler(protected *gin.RouterGroup, killSwitchUC domain.KillSwitchUsecase) {
+	handler := &KillSwitchHandler{killSwitchUC: killSwitchUC}
+
+	admin := protected.Group("/admin/kill-switches")
+	{
+		admin.GET("", handler.ListSwitches)
+		admin.POST("/disable", handler.DisableSwitch)
+		admin.POST("/enable", handler.EnableSwitch)
+		admin.GET("/audit", handler.ListAudit)
+	}
+}
+
+// ListSwitches godoc
+// @Summary      List kill switches
+// @Description  Subsystem switches are always listed; endpoint switches once they have been toggled
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.KillSwitch}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/kill-switches [get]
+func (h *KillSwitchHandler) ListSwitches(c *gin.Context) {
+	switches, err := h.killSwitchUC.ListSwitches(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Kill switches retrieved", switches)
+}
+
+// DisableSwitch godoc
+// @Summary      Disable an endpoint or subsystem
+// @Description  Requests to it get 503 with the message until re-enabled, or until duration_minutes passes
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.DisableKillSwitchRequest  true  "Switch key, message, reason and optional window"
+// @Success      200      {object}  response.Response{data=domain.KillSwitch}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /admin/kill-switches/disable [post]
+func (h *KillSwitchHandler) DisableSwitch(c *gin.Context) {
+	var req domain.DisableKillSwitchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	s, err := h.killSwitchUC.DisableSwitch(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Kill switch disabled", s)
+}
+
+// EnableSwitch godoc
+// @Summary      Re-enable an endpoint or subsystem
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.EnableKillSwitchRequest  true  "Switch key and reason"
+// @Success      200      {object}  response.Response{data=domain.KillSwitch}
+// @Failure      404      {object}  response.Response
+// @Router       /admin/kill-switches/enable [post]
+func (h *KillSwitchHandler) EnableSwitch(c *gin.Context) {
+	var req domain.EnableKillSwitchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	s, err := h.killSwitchUC.EnableSwitch(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Kill switch enabled", s)
+}
+
+// ListAudit godoc
+// @Summary      List kill switch toggles
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key  query     string  false  "Only this switch"
+// @Success      200  {object}  response.Response{data=[]domain.KillSwitchAuditEntry}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/kill-switches/audit [get]
+func (h *KillSwitchHandler) ListAudit(c *gin.Context) {
+	entries, err := h.killSwitchUC.ListAudit(c.Request.Context(), c.Query("key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Kill switch audit retrieved", entries)
+}
@@ -0,0 +1,346 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LPKIntegrationHandler struct {
+	integrationUC domain.LPKIntegrationUsecase
+}
+
+// NewLPKIntegrationHandler registers the partner placement API (API key auth),
+// candidate consent and LPK portal routes for API keys and webhooks
+func NewLPKIntegrationHandler(v1 *gin.RouterGroup, protected *gin.RouterGroup, integrationUC domain.LPKIntegrationUsecase) {
+	handler := &LPKIntegrationHandler{integrationUC: integrationUC}
+
+	// Partner systems: authenticated by X-API-Key, not a session
+	v1.GET("/partners/lpk/placements", handler.ListPlacements)
+
+	// Candidate: consent to share application status with their LPK
+	protected.GET("/candidates/me/lpk-sharing", handler.GetPlacementSharing)
+	protected.PUT("/candidates/me/lpk-sharing", handler.UpdatePlacementSharing)
+
+	// LPK portal: scoped to the caller's LPK
+	portal := protected.Group("/lpk")
+	{
+		portal.GET("/api-keys", handler.ListAPIKeys)
+		portal.POST("/api-keys", handler.CreateAPIKey)
+		portal.DELETE("/api-keys/:id", handler.RevokeAPIKey)
+		portal.GET("/webhooks", handler.ListWebhooks)
+		portal.POST("/webhooks", handler.CreateWebhook)
+		portal.GET("/webhooks/deliveries", handler.ListWebhookDeliveries)
+		portal.PUT("/webhooks/:id", handler.UpdateWebhook)
+		portal.DELETE("/webhooks/:id", handler.DeleteWebhook)
+		portal.POST("/webhooks/:id/rotate-secret", handler.RotateWebhookSecret)
+	}
+}
+
+// ListPlacements godoc
+// @Summary      Pull placement status changes
+// @Description  Application status changes of candidates who share them with the key's LPK, oldest first. Pass next_cursor as since to continue; an empty page means the partner is up to date.
+// @Tags         lpk-partner-api
+// @Produce      json
+// @Param        X-API-Key  header    string  true   "LPK API key"
+// @Param        since      query     int     false  "Return events after this cursor (default 0)"
+// @Param        limit      query     int     false  "Events per page (default 100, max 500)"
+// @Success      200        {object}  response.Response{data=domain.LPKPlacementFeed}
+// @Failure      401        {object}  response.Response
+// @Failure      429        {object}  response.Response
+// @Router       /partners/lpk/placements [get]
+func (h *LPKIntegrationHandler) ListPlacements(c *gin.Context) {
+	var since int64
+	if raw := c.Query("since"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v < 0 {
+			c.Error(apperror.BadRequest("Invalid since cursor"))
+			return
+		}
+		since = v
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	feed, err := h.integrationUC.ListPlacements(c.Request.Context(), c.GetHeader(domain.LPKAPIKeyHeader), since, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	response.Success(c, http.StatusOK, "Placement events retrieved", feed)
+}
+
+// GetPlacementSharing godoc
+// @Summary      Get LPK placement sharing
+// @Description  Whether application status changes are shared with the candidate's LPK
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.LPKPlacementSharing}
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/lpk-sharing [get]
+func (h *LPKIntegrationHandler) GetPlacementSharing(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	sharing, err := h.integrationUC.GetPlacementSharing(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Placement sharing retrieved", sharing)
+}
+
+// UpdatePlacementSharing godoc
+// @Summary      Give or withdraw LPK placement sharing
+// @Description  Consent covers the LPK chosen now; choosing another LPK ends it. Withdrawing hides earlier events from the partner API.
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.UpdateLPKPlacementSharingRequest  true  "Consent"
+// @Success      200      {object}  response.Response{data=domain.LPKPlacementSharing}
+// @Failure      400      {object}  response.Response
+// @Router       /candidates/me/lpk-sharing [put]
+func (h *LPKIntegrationHandler) UpdatePlacementSharing(c *gin.Context) {
+	var req domain.UpdateLPKPlacementSharingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	sharing, err := h.integrationUC.UpdatePlacementSharing(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Placement sharing updated", sharing)
+}
+
+// ListAPIKeys godoc
+// @Summary      List partner API keys
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.LPKAPIKey}
+// @Failure      403  {object}  response.Response
+// @Router       /lpk/api-keys [get]
+func (h *LPKIntegrationHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.integrationUC.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "API keys retrieved", keys)
+}
+
+// CreateAPIKey godoc
+// @Summary      Create a partner API key
+// @Description  The response contains the key, which is not shown again. At most 5 keys can be active.
+// @Tags         lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.CreateLPKAPIKeyRequest  true  "Key name"
+// @Success      201      {object}  response.Response{data=domain.LPKAPIKey}
+// @Failure      403      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /lpk/api-keys [post]
+func (h *LPKIntegrationHandler) CreateAPIKey(c *gin.Context) {
+	var req domain.CreateLPKAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	key, err := h.integrationUC.CreateAPIKey(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "API key created", key)
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke a partner API key
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "API key ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /lpk/api-keys/{id} [delete]
+func (h *LPKIntegrationHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid API key ID"))
+		return
+	}
+	if err := h.integrationUC.RevokeAPIKey(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "API key revoked", nil)
+}
+
+// ListWebhooks godoc
+// @Summary      List partner webhook endpoints
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.WebhookEndpoint}
+// @Failure      403  {object}  response.Response
+// @Router       /lpk/webhooks [get]
+func (h *LPKIntegrationHandler) ListWebhooks(c *gin.Context) {
+	endpoints, err := h.integrationUC.ListWebhooks(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook endpoints retrieved", endpoints)
+}
+
+// CreateWebhook godoc
+// @Summary      Register a partner webhook endpoint
+// @Description  Subscribes an https URL to placement.status_changed. The response contains the signing secret, which is not shown again; deliveries are signed like platform webhooks.
+// @Tags         lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.WebhookEndpointRequest  true  "Endpoint"
+// @Success      201      {object}  response.Response{data=domain.WebhookEndpoint}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /lpk/webhooks [post]
+func (h *LPKIntegrationHandler) CreateWebhook(c *gin.Context) {
+	var req domain.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	endpoint, err := h.integrationUC.CreateWebhook(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Webhook endpoint created", endpoint)
+}
+
+// UpdateWebhook godoc
+// @Summary      Update a partner webhook endpoint
+// @Tags         lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                            true  "Endpoint ID"
+// @Param        request  body      domain.WebhookEndpointRequest  true  "Endpoint"
+// @Success      200      {object}  response.Response{data=domain.WebhookEndpoint}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /lpk/webhooks/{id} [put]
+func (h *LPKIntegrationHandler) UpdateWebhook(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	var req domain.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	endpoint, err := h.integrationUC.UpdateWebhook(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook endpoint updated", endpoint)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a partner webhook endpoint
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Endpoint ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /lpk/webhooks/{id} [delete]
+func (h *LPKIntegrationHandler) DeleteWebhook(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	if err := h.integrationUC.DeleteWebhook(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook endpoint deleted", nil)
+}
+
+// RotateWebhookSecret godoc
+// @Summary      Rotate a partner webhook signing secret
+// @Description  Returns the new secret, which is not shown again
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Endpoint ID"
+// @Success      200  {object}  response.Response{data=domain.WebhookEndpoint}
+// @Failure      404  {object}  response.Response
+// @Router       /lpk/webhooks/{id}/rotate-secret [post]
+func (h *LPKIntegrationHandler) RotateWebhookSecret(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	endpoint, err := h.integrationUC.RotateWebhookSecret(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook secret rotated", endpoint)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List partner webhook deliveries
+// @Description  Newest first, with attempts, last response and next retry
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        endpointId  query     int     false  "Endpoint ID"
+// @Param        eventType   query     string  false  "placement.status_changed"
+// @Param        status      query     string  false  "PENDING, SUCCEEDED or FAILED"
+// @Param        page        query     int     false  "Page number"
+// @Param        pageSize    query     int     false  "Items per page (max 100)"
+// @Success      200         {object}  response.Response{data=domain.PaginatedResult[domain.WebhookDelivery]}
+// @Failure      400         {object}  response.Response
+// @Router       /lpk/webhooks/deliveries [get]
+func (h *LPKIntegrationHandler) ListWebhookDeliveries(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	filter := domain.WebhookDeliveryFilter{
+		EventType: c.Query("eventType"),
+		Status:    c.Query("status"),
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	if raw := c.Query("endpointId"); raw != "" {
+		endpointID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid webhook endpoint ID"))
+			return
+		}
+		filter.EndpointID = &endpointID
+	}
+
+	result, err := h.integrationUC.ListWebhookDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook deliveries retrieved", result)
+}
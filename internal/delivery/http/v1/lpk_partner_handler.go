@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LPKPartnerHandler struct {
+	lpkUC domain.LPKPartnerUsecase
+}
+
+// NewLPKPartnerHandler registers admin partner management and LPK portal routes
+func NewLPKPartnerHandler(protected *gin.RouterGroup, lpkUC domain.LPKPartnerUsecase) {
+	handler := &LPKPartnerHandler{lpkUC: lpkUC}
+
+	// Admin: manage partner accounts
+	admin := protected.Group("/admin/lpk-partners")
+	{
+		admin.GET("", handler.ListPartners)
+		admin.POST("", handler.AssignPartner)
+		admin.DELETE("/:userId", handler.RevokePartner)
+	}
+
+	// LPK portal: scoped to the caller's LPK
+	portal := protected.Group("/lpk")
+	{
+		portal.GET("/me", handler.GetMyPartnership)
+		portal.GET("/candidates", handler.ListCandidates)
+		portal.GET("/candidates/:ref/endorsements", handler.ListEndorsements)
+		portal.POST("/candidates/:ref/endorsements", handler.CreateEndorsement)
+		portal.GET("/stats", handler.GetPlacementStats)
+	}
+}
+
+// ListPartners godoc
+// @Summary      List LPK partner accounts
+// @Description  Returns all users mapped to an LPK, optionally filtered by LPK
+// @Tags         admin-lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        lpk_id  query     int  false  "Filter by LPK ID"
+// @Success      200     {object}  response.Response{data=[]domain.LPKPartner}
+// @Failure      403     {object}  response.Response
+// @Router       /admin/lpk-partners [get]
+func (h *LPKPartnerHandler) ListPartners(c *gin.Context) {
+	var lpkID *int64
+	if raw := c.Query("lpk_id"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid LPK ID"))
+			return
+		}
+		lpkID = &v
+	}
+
+	partners, err := h.lpkUC.ListPartners(c.Request.Context(), lpkID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "LPK partners", partners)
+}
+
+// AssignPartner godoc
+// @Summary      Assign an LPK partner account
+// @Description  Grants a user the LPK role scoped to a single LPK. Admin and employer accounts are refused.
+// @Tags         admin-lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      domain.AssignLPKPartnerRequest  true  "User and LPK"
+// @Success      200   {object}  response.Response{data=domain.LPKPartner}
+// @Failure      403   {object}  response.Response
+// @Failure      404   {object}  response.Response
+// @Failure      409   {object}  response.Response
+// @Router       /admin/lpk-partners [post]
+func (h *LPKPartnerHandler) AssignPartner(c *gin.Context) {
+	var req domain.AssignLPKPartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	partner, err := h.lpkUC.AssignPartner(c.Request.Context(), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "LPK partner assigned", partner)
+}
+
+// RevokePartner godoc
+// @Summary      Revoke an LPK partner account
+// @Description  Removes the user's LPK mapping and returns the account to the candidate role; it loses all portal access
+// @Tags         admin-lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        userId  path      string  true  "User ID"
+// @Success      200     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Router       /admin/lpk-partners/{userId} [delete]
+func (h *LPKPartnerHandler) RevokePartner(c *gin.Context) {
+	if err := h.lpkUC.RevokePartner(c.Request.Context(), c.Param("userId")); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "LPK partner revoked", nil)
+}
+
+// GetMyPartnership godoc
+// @Summary      Get current LPK partnership
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.LPKPartner}
+// @Failure      403  {object}  response.Response
+// @Router       /lpk/me [get]
+func (h *LPKPartnerHandler) GetMyPartnership(c *gin.Context) {
+	partner, err := h.lpkUC.GetMyPartnership(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "LPK partnership", partner)
+}
+
+// ListCandidates godoc
+// @Summary      List candidates who selected this LPK
+// @Description  Returns anonymized verification progress (initials only, no contact details)
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false  "Verification status (PENDING, SUBMITTED, VERIFIED, REJECTED)"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page"
+// @Success      200       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /lpk/candidates [get]
+func (h *LPKPartnerHandler) ListCandidates(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+
+	result, err := h.lpkUC.ListMyCandidates(c.Request.Context(), c.Query("status"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "LPK candidates", result)
+}
+
+// ListEndorsements godoc
+// @Summary      List endorsement notes for a candidate
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Param        ref  path      int  true  "Candidate reference"
+// @Success      200  {object}  response.Response{data=[]domain.LPKEndorsement}
+// @Failure      404  {object}  response.Response
+// @Router       /lpk/candidates/{ref}/endorsements [get]
+func (h *LPKPartnerHandler) ListEndorsements(c *gin.Context) {
+	ref, err := strconv.ParseInt(c.Param("ref"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid candidate reference"))
+		return
+	}
+
+	endorsements, err := h.lpkUC.ListEndorsements(c.Request.Context(), ref)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Endorsements", endorsements)
+}
+
+// CreateEndorsement godoc
+// @Summary      Submit an endorsement note for a candidate
+// @Tags         lpk
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        ref   path      int                                 true  "Candidate reference"
+// @Param        body  body      domain.CreateLPKEndorsementRequest  true  "Endorsement note"
+// @Success      201   {object}  response.Response{data=domain.LPKEndorsement}
+// @Failure      404   {object}  response.Response
+// @Router       /lpk/candidates/{ref}/endorsements [post]
+func (h *LPKPartnerHandler) CreateEndorsement(c *gin.Context) {
+	ref, err := strconv.ParseInt(c.Param("ref"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid candidate reference"))
+		return
+	}
+
+	var req domain.CreateLPKEndorsementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	endorsement, err := h.lpkUC.CreateEndorsement(c.Request.Context(), ref, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Endorsement submitted", endorsement)
+}
+
+// GetPlacementStats godoc
+// @Summary      Get placement statistics for this LPK
+// @Tags         lpk
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.LPKPlacementStats}
+// @Failure      403  {object}  response.Response
+// @Router       /lpk/stats [get]
+func (h *LPKPartnerHandler) GetPlacementStats(c *gin.Context) {
+	stats, err := h.lpkUC.GetPlacementStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "LPK placement statistics", stats)
+}
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceHandler struct {
+	maintenanceUC domain.MaintenanceUsecase
+}
+
+// NewMaintenanceHandler registers admin routes for operational maintenance tasks
+func NewMaintenanceHandler(protected *gin.RouterGroup, maintenanceUC domain.MaintenanceUsecase) {
+	handler := &MaintenanceHandler{maintenanceUC: maintenanceUC}
+
+	admin := protected.Group("/admin/maintenance")
+	{
+		admin.GET("/tasks", handler.ListTasks)
+		admin.GET("/runs", handler.ListRuns)
+		admin.GET("/runs/:id", handler.GetRun)
+		admin.POST("/:task", handler.StartTask)
+	}
+}
+
+// StartTask godoc
+// @Summary      Start a maintenance task
+// @Description  Starts refresh-stats, rebuild-search-indexes or recompute-aggregates in the background and returns the run; poll GET /admin/maintenance/runs/{id} for progress. A task runs at most once at a time.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        task  path      string  true  "refresh-stats, rebuild-search-indexes or recompute-aggregates"
+// @Success      202   {object}  response.Response{data=domain.MaintenanceRun}
+// @Failure      404   {object}  response.Response
+// @Failure      409   {object}  response.Response
+// @Router       /admin/maintenance/{task} [post]
+func (h *MaintenanceHandler) StartTask(c *gin.Context) {
+	adminID := c.GetString(string(domain.KeyUserID))
+	run, err := h.maintenanceUC.StartTask(c.Request.Context(), adminID, c.Param("task"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusAccepted, "Maintenance task started", run)
+}
+
+// ListTasks godoc
+// @Summary      List maintenance tasks
+// @Description  Every task with its description and latest run
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.MaintenanceTaskInfo}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/maintenance/tasks [get]
+func (h *MaintenanceHandler) ListTasks(c *gin.Context) {
+	tasks, err := h.maintenanceUC.ListTasks(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Maintenance tasks retrieved", tasks)
+}
+
+// ListRuns godoc
+// @Summary      List maintenance runs
+// @Description  The 50 most recent runs, optionally of one task
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        task  query     string  false  "Task name"
+// @Success      200   {object}  response.Response{data=[]domain.MaintenanceRun}
+// @Failure      400   {object}  response.Response
+// @Router       /admin/maintenance/runs [get]
+func (h *MaintenanceHandler) ListRuns(c *gin.Context) {
+	runs, err := h.maintenanceUC.ListRuns(c.Request.Context(), c.Query("task"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Maintenance runs retrieved", runs)
+}
+
+// GetRun godoc
+// @Summary      Get a maintenance run
+// @Description  Progress (steps done of total, current step) while running; per-step results once finished
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Run ID"
+// @Success      200  {object}  response.Response{data=domain.MaintenanceRun}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/maintenance/runs/{id} [get]
+func (h *MaintenanceHandler) GetRun(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid run ID"))
+		return
+	}
+	run, err := h.maintenanceUC.GetRun(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Maintenance run retrieved", run)
+}
@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/metrics"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsHandler serves Prometheus metrics outside /v1, where scrapers expect
+// them. With a token configured, scrapers authenticate with it instead of a
+// user session.
+type MetricsHandler struct {
+	token string
+}
+
+func NewMetricsHandler(engine *gin.Engine, token string) {
+	handler := &MetricsHandler{token: token}
+
+	engine.GET("/metrics", handler.Scrape)
+}
+
+// Scrape godoc
+// @Summary      Prometheus metrics
+// @Description  Request counts and latency per route, database pool and query stats, email failures and Supabase errors in the Prometheus text format. Requires `Authorization: Bearer <METRICS_TOKEN>` when a token is configured.
+// @Tags         system
+// @Produce      plain
+// @Param        Authorization  header  string  false  "Bearer token"
+// @Success      200  {string}  string
+// @Failure      401  {object}  response.Response
+// @Router       /metrics [get]
+func (h *MetricsHandler) Scrape(c *gin.Context) {
+	if h.token != "" {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			c.Error(apperror.Unauthorized("Invalid metrics token"))
+			return
+		}
+	}
+	metrics.Default.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationUC domain.NotificationUsecase
+}
+
+// NewNotificationHandler registers the caller's notification center and admin
+// announcements
+func NewNotificationHandler(protected *gin.RouterGroup, notificationUC domain.NotificationUsecase) {
+	handler := &NotificationHandler{notificationUC: notificationUC}
+
+	protected.GET("/notifications", handler.ListNotifications)
+	protected.GET("/notifications/unread-count", handler.UnreadCount)
+	protected.PATCH("/notifications/read-all", handler.MarkAllRead)
+	protected.PATCH("/notifications/:id/read", handler.MarkRead)
+
+	protected.POST("/admin/announcements", handler.Announce)
+}
+
+// ListNotifications godoc
+// @Summary      List my notifications
+// @Description  Newest first, with the unread count for the header badge
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unread_only  query     bool  false  "Only unread notifications"
+// @Param        page         query     int   false  "Page number"  default(1)
+// @Param        page_size    query     int   false  "Page size"    default(20)
+// @Success      200          {object}  response.Response{data=domain.NotificationList}
+// @Router       /notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	unreadOnly, _ := strconv.ParseBool(c.Query("unread_only"))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	list, err := h.notificationUC.ListNotifications(c.Request.Context(), userID, unreadOnly, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Notifications retrieved", list)
+}
+
+// UnreadCount godoc
+// @Summary      Count my unread notifications
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.UnreadNotificationCount}
+// @Router       /notifications/unread-count [get]
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	count, err := h.notificationUC.UnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Unread notifications counted", count)
+}
+
+// MarkRead godoc
+// @Summary      Mark a notification read
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Notification ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /notifications/{id}/read [patch]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid notification ID"))
+		return
+	}
+	userID := c.GetString(string(domain.KeyUserID))
+
+	if err := h.notificationUC.MarkRead(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Notification marked read", nil)
+}
+
+// MarkAllRead godoc
+// @Summary      Mark all my notifications read
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.NotificationsReadResult}
+// @Router       /notifications/read-all [patch]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	result, err := h.notificationUC.MarkAllRead(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Notifications marked read", result)
+}
+
+// Announce godoc
+// @Summary      Send an announcement
+// @Description  Unrestricted admins only. Adds the announcement to the notification center of every enabled user, or of one role's users. It is shown as written, not translated, and not emailed.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.AnnouncementRequest  true  "Announcement"
+// @Success      201      {object}  response.Response{data=domain.AnnouncementResult}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /admin/announcements [post]
+func (h *NotificationHandler) Announce(c *gin.Context) {
+	var req domain.AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	result, err := h.notificationUC.Announce(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Announcement sent", result)
+}
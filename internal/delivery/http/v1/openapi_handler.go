@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/buildinfo"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/openapi"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIHandler serves the OpenAPI 3 document of this binary. The document is
+// generated from the engine's routes and the DTOs in apiOperations on first
+// request, when every route is registered, and cached for the process lifetime.
+type OpenAPIHandler struct {
+	engine *gin.Engine
+	hidden []string // path prefixes left out of the document
+
+	once       sync.Once
+	spec       []byte
+	hash       string
+	operations int
+	err        error
+}
+
+func NewOpenAPIHandler(engine *gin.Engine, public *gin.RouterGroup, hidden ...string) {
+	handler := &OpenAPIHandler{engine: engine, hidden: hidden}
+
+	public.GET("/openapi.json", handler.GetSpec)
+	public.GET("/openapi/version", handler.GetVersion)
+	public.GET("/error-codes", handler.ListErrorCodes)
+}
+
+// GetSpec godoc
+// @Summary      OpenAPI document for this binary
+// @Description  OpenAPI 3 document generated from the typed request/response DTOs, for client SDK generation
+// @Tags         system
+// @Produce      json
+// @Success      200  {object}  object
+// @Failure      500  {object}  response.Response
+// @Router       /openapi.json [get]
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	if err := h.build(); err != nil {
+		c.Error(apperror.New(http.StatusInternalServerError, "API specification unavailable", err))
+		return
+	}
+
+	etag := `"` + h.hash + `"`
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", h.spec)
+}
+
+// GetVersion godoc
+// @Summary      Build and spec version of this binary
+// @Description  Lets SDK builds check that their spec matches the deployed binary
+// @Tags         system
+// @Produce      json
+// @Success      200  {object}  response.Response{data=SpecVersionResponse}
+// @Failure      500  {object}  response.Response
+// @Router       /openapi/version [get]
+func (h *OpenAPIHandler) GetVersion(c *gin.Context) {
+	if err := h.build(); err != nil {
+		c.Error(apperror.New(http.StatusInternalServerError, "API specification unavailable", err))
+		return
+	}
+
+	response.Success(c, http.StatusOK, "API version", SpecVersionResponse{
+		Info:       buildinfo.Get(),
+		OpenAPI:    openapi.Version,
+		SpecSHA256: h.hash,
+		Operations: h.operations,
+	})
+}
+
+// ListErrorCodes godoc
+// @Summary      Error codes returned by the API
+// @Description  Every machine-readable "code" an error response can carry, with its usual status
+// @Tags         system
+// @Produce      json
+// @Success      200  {object}  response.Response{data=[]domain.ErrorCodeInfo}
+// @Router       /error-codes [get]
+func (h *OpenAPIHandler) ListErrorCodes(c *gin.Context) {
+	response.Success(c, http.StatusOK, "Error codes", domain.ErrorCodes)
+}
+
+func (h *OpenAPIHandler) build() error {
+	h.once.Do(func() {
+		var routes []openapi.Route
+		for _, r := range h.engine.Routes() {
+			routes = append(routes, openapi.Route{Method: r.Method, Path: r.Path, Handler: r.Handler})
+		}
+
+		doc, err := openapi.Build(openapi.Config{
+			Title:       "J-Expert Recruitment API",
+			Description: "Backend for the J-Expert recruitment platform. Responses are wrapped in the standard envelope; payloads are in `data`, and failures carry a machine-readable `code` (listed by `/error-codes`).",
+			Version:     buildinfo.Get().Version,
+			BasePath:    "/v1",
+			Envelope:    response.Response{},
+			DataField:   "data",
+			ErrorCodes:  []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusInternalServerError},
+			Skip:        h.skip,
+		}, routes, apiOperations)
+		if err != nil {
+			h.err = err
+			logger.Log.Error("Failed to build OpenAPI document", "error", err)
+			return
+		}
+
+		h.spec, h.err = json.Marshal(doc)
+		sum := sha256.Sum256(h.spec)
+		h.hash = hex.EncodeToString(sum[:])
+		for _, item := range doc.Paths {
+			h.operations += len(item)
+		}
+	})
+	return h.err
+}
+
+// skip leaves out the Swagger UI, the WebSocket upgrade, the GraphQL gateway
+// (described by its schema), the Prometheus endpoint, email tracking links and
+// hidden surfaces
+func (h *OpenAPIHandler) skip(r openapi.Route) bool {
+	if strings.HasPrefix(r.Path, "/v1/swagger/") || r.Path == "/v1/ws" || r.Path == "/v1/graphql" || r.Path == "/metrics" || r.Path == "/t/:token" {
+		return true
+	}
+	for _, prefix := range h.hidden {
+		if strings.HasPrefix(r.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
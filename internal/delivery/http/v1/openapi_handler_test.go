@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"encoding/json"
+	"go-recruitment-backend/config"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// TestOpenAPIDocumentCoversRouter serves /openapi.json from the full router and
+// checks that every public route is in it under its own operationId
+func TestOpenAPIDocumentCoversRouter(t *testing.T) {
+	r := newTestRouter(RouterDeps{Config: &config.Config{OpenAPIEnabled: true, MetricsEnabled: true, GraphQLEnabled: true}})
+
+	rec := serveRouter(r, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+
+	hidden := "/v1/" + generateSecurityDashboardPath()
+	routes := map[string]string{} // operationId -> route
+	for _, route := range r.Routes() {
+		if route.Method == http.MethodHead || strings.HasPrefix(route.Path, "/v1/swagger/") || strings.HasPrefix(route.Path, hidden) ||
+			route.Path == "/v1/ws" || route.Path == "/v1/graphql" || route.Path == "/metrics" || route.Path == "/t/:token" {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		path := pathParam.ReplaceAllString(strings.TrimPrefix(route.Path, "/v1"), "{$1}")
+
+		op, ok := doc.Paths[path][strings.ToLower(route.Method)]
+		if !assert.True(t, ok, "%s is missing from the document", key) {
+			continue
+		}
+		if !assert.NotEmpty(t, op.OperationID, key) {
+			continue
+		}
+		if other, dup := routes[op.OperationID]; dup {
+			t.Errorf("operationId %q is used by %s and %s", op.OperationID, other, key)
+		}
+		routes[op.OperationID] = key
+	}
+
+	operations := 0
+	for _, item := range doc.Paths {
+		operations += len(item)
+	}
+	assert.Equal(t, len(routes), operations, "the document lists operations that are not routed")
+}
@@ -0,0 +1,589 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/auth"
+	"go-recruitment-backend/pkg/buildinfo"
+	"go-recruitment-backend/pkg/jobs"
+	"go-recruitment-backend/pkg/openapi"
+	"net/http"
+)
+
+// Query parameter DTOs for the OpenAPI document; only their `form` tags are read.
+// Handlers parse these parameters themselves, so keep the names in sync.
+type pageQuery struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
+
+// adminPageQuery is the camelCase paging used by the admin, credit and LPK lists
+type adminPageQuery struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
+
+type adminUserQuery struct {
+	Role     string `form:"role"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type adminCompanyQuery struct {
+	VerificationStatus string `form:"verificationStatus"`
+	Page               int    `form:"page"`
+	PageSize           int    `form:"pageSize"`
+}
+
+type webhookDeliveryQuery struct {
+	EndpointID int64  `form:"endpointId"`
+	EventType  string `form:"eventType"`
+	Status     string `form:"status"`
+	Page       int    `form:"page"`
+	PageSize   int    `form:"pageSize"`
+}
+
+type applicationHistoryQuery struct {
+	Status    string `form:"status"`
+	SortBy    string `form:"sort_by"`
+	SortOrder string `form:"sort_order"`
+	Page      int    `form:"page"`
+	PageSize  int    `form:"page_size"`
+}
+
+type lpkPlacementQuery struct {
+	Since int64 `form:"since"`
+	Limit int   `form:"limit"`
+}
+
+type atsExportAuditQuery struct {
+	CandidateUserID string `form:"candidate_user_id"`
+	ActorUserID     string `form:"actor_user_id"`
+	Page            int    `form:"page"`
+	PageSize        int    `form:"pageSize"`
+}
+
+type cohortListQuery struct {
+	LPKID int64 `form:"lpk_id"`
+}
+
+type notificationListQuery struct {
+	UnreadOnly bool `form:"unread_only"`
+	Page       int  `form:"page"`
+	PageSize   int  `form:"page_size"`
+}
+
+type placementListQuery struct {
+	Status    string `form:"status"`
+	CompanyID int64  `form:"company_id"`
+	Page      int    `form:"page"`
+	PageSize  int    `form:"pageSize"`
+}
+
+type maintenanceRunQuery struct {
+	Task string `form:"task"`
+}
+
+type statusPageQuery struct {
+	Status   string `form:"status"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type candidateDocumentQuery struct {
+	Status       string `form:"status"`
+	DocumentType string `form:"documentType"`
+	Page         int    `form:"page"`
+	PageSize     int    `form:"pageSize"`
+}
+
+type inviteCodeQuery struct {
+	Status   string `form:"status"`
+	Role     string `form:"role"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type undeliverableEmailQuery struct {
+	Reason   string `form:"reason"`
+	Role     string `form:"role"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type jobQueueQuery struct {
+	Status   string `form:"status"`
+	Type     string `form:"type"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type duplicateDocumentQuery struct {
+	Status   string `form:"status"`
+	UserID   string `form:"userId"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type activityQuery struct {
+	Before int64 `form:"before"`
+	Limit  int   `form:"limit"`
+}
+
+type screeningCallSlotQuery struct {
+	ApplicationID   int64  `form:"application_id"`
+	CandidateUserID string `form:"candidate_user_id"`
+}
+
+type screeningCallListQuery struct {
+	CandidateUserID string `form:"candidate_user_id"`
+	Status          string `form:"status"`
+	Upcoming        bool   `form:"upcoming"`
+	Limit           int    `form:"limit"`
+}
+
+type companyVerificationQuery struct {
+	Level    string `form:"level"`
+	Q        string `form:"q"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type pipelineSLABreachQuery struct {
+	Status   string `form:"status"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+type publicCompanyQuery struct {
+	Q        string `form:"q"`
+	Industry string `form:"industry"`
+	Location string `form:"location"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+type publicJobListQuery struct {
+	Sort     string `form:"sort"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+type jobSearchQuery struct {
+	Q              string  `form:"q"`
+	Location       string  `form:"location"`        // comma-separated
+	City           string  `form:"city"`            // comma-separated
+	Near           string  `form:"near"`            // latitude,longitude
+	EmploymentType string  `form:"employment_type"` // comma-separated
+	JapaneseLevel  string  `form:"japanese_level" binding:"omitempty,oneof=N1 N2 N3 N4 N5"`
+	SalaryMin      float64 `form:"salary_min" binding:"omitempty,gte=0"`
+	SalaryMax      float64 `form:"salary_max" binding:"omitempty,gte=0"`
+	RadiusKm       float64 `form:"radius_km" binding:"omitempty,gte=1,lte=200"`
+	Sort           string  `form:"sort"`
+	Page           int     `form:"page"`
+	PageSize       int     `form:"page_size"`
+}
+
+type adminSearchQuery struct {
+	Q     string `form:"q" binding:"required"`
+	Types string `form:"types"` // comma-separated
+	Limit int    `form:"limit"`
+}
+
+type verificationListQuery struct {
+	Role   string `form:"role"`
+	Status string `form:"status"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+type verificationSchemaQuery struct {
+	Role string `form:"role"`
+}
+
+type uploadQuery struct {
+	Bucket string `form:"bucket"`
+	OldURL string `form:"old_url"`
+	Async  bool   `form:"async"`
+}
+
+type signedURLQuery struct {
+	URL string `form:"url"`
+}
+
+type searchTermQuery struct {
+	Q string `form:"q"`
+}
+
+// uploadForm is the multipart body of file uploads
+type uploadForm struct {
+	File openapi.File `json:"file" binding:"required"`
+}
+
+// SpecVersionResponse identifies the binary that serves the OpenAPI document
+type SpecVersionResponse struct {
+	buildinfo.Info
+	OpenAPI    string `json:"openapi"`
+	SpecSHA256 string `json:"spec_sha256"`
+	Operations int    `json:"operations"`
+}
+
+// apiOperations documents routes by "METHOD /v1/path". Routes missing here are still
+// in the spec with a handler-derived operationId and an untyped data payload;
+// entries for routes that no longer exist fail the build of the document.
+var apiOperations = map[string]openapi.Op{
+	// System
+	"GET /v1/health":                  {ID: "getHealth", Summary: "Health check", Public: true},
+	"GET /v1/health/anchoring":        {ID: "getAnchoringHeartbeat", Summary: "Anchoring heartbeat check (shared monitor token)", Public: true, Data: domain.AnchorHeartbeatStatus{}},
+	"GET /v1/openapi.json":            {ID: "getOpenAPISpec", Summary: "OpenAPI document for this binary", Public: true, Content: "application/json"},
+	"GET /v1/openapi/version":         {ID: "getOpenAPIVersion", Summary: "Build and spec version of this binary", Public: true, Data: SpecVersionResponse{}},
+	"GET /v1/error-codes":             {ID: "listErrorCodes", Summary: "Error codes returned by the API", Public: true, Data: []domain.ErrorCodeInfo{}},
+	"POST /v1/contact":                {Summary: "Submit Contact Form", Public: true, Body: domain.ContactRequest{}},
+	"POST /v1/webhooks/supabase":      {ID: "handleSupabaseAuthEvent", Summary: "Supabase auth webhook (signed by Supabase)", Public: true, Body: domain.SupabaseAuthEvent{}, Data: domain.SupabaseWebhookResult{}},
+	"POST /v1/upload":                 {Summary: "Upload a file", Body: uploadForm{}, Form: true, Query: uploadQuery{}, Data: UploadFileResponse{}},
+	"GET /v1/files/:id/status":        {Summary: "Get file processing status", Data: domain.UploadedFile{}},
+	"GET /v1/files/signed-url":        {Summary: "Get a download URL for a stored file", Query: signedURLQuery{}, Data: domain.SignedFileURL{}},
+	"GET /v1/calendar/holidays":       {Summary: "List public holidays", Data: []domain.PublicHoliday{}},
+	"GET /v1/calendar/interview-days": {Summary: "Suggest interview days", Data: []string{}},
+
+	// Auth
+	"POST /v1/auth/register":             {Summary: "User Registration", Public: true, Body: RegisterRequest{}, Data: RegisterResponse{}, Status: http.StatusCreated},
+	"GET /v1/auth/registration-settings": {Summary: "Get registration settings", Public: true, Data: domain.RegistrationSettings{}},
+	"POST /v1/auth/login":                {Summary: "User Login", Public: true, Body: LoginRequest{}, Data: LoginResponse{}},
+	"POST /v1/auth/refresh":              {Summary: "Refresh the access token", Public: true, Body: RefreshRequest{}, OptionalBody: true, Data: auth.TokenPair{}},
+	"POST /v1/auth/logout":               {Summary: "Log out", Public: true, Body: RefreshRequest{}, OptionalBody: true},
+	"POST /v1/auth/forgot-password":      {Summary: "Request Password Reset", Public: true, Body: ForgotPasswordRequest{}},
+	"POST /v1/auth/reset-password":       {Summary: "Reset Password", Public: true, Body: ResetPasswordRequest{}},
+	"POST /v1/auth/sync":                 {Summary: "Sync the authenticated user", Data: domain.User{}},
+	"GET /v1/auth/me":                    {Summary: "Get the authenticated user", Data: domain.User{}},
+	"POST /v1/auth/me/pause":             {Summary: "Pause candidate profile", Body: domain.PauseProfileRequest{}, Data: domain.User{}},
+	"DELETE /v1/auth/me/pause":           {Summary: "Resume candidate profile", Data: domain.User{}},
+	"PUT /v1/auth/me/locale":             {Summary: "Set preferred language", Body: domain.UpdateLocaleRequest{}, Data: domain.User{}},
+	"GET /v1/auth/mfa/status":            {Summary: "Two-factor status", Data: domain.MFAStatus{}},
+	"POST /v1/auth/mfa/enroll":           {Summary: "Start two-factor enrollment", Data: domain.MFAEnrollment{}},
+	"POST /v1/auth/mfa/enroll/confirm":   {Summary: "Confirm two-factor enrollment", Body: domain.MFACodeRequest{}, Data: domain.MFAStatus{}},
+	"POST /v1/auth/mfa/verify":           {Summary: "Verify two-factor code", Body: domain.MFACodeRequest{}, Data: domain.MFAStatus{}},
+
+	// Jobs
+	"GET /v1/jobs/public":          {Summary: "List active jobs (public)", Public: true, Query: publicJobListQuery{}, Data: JobListResponse{}},
+	"GET /v1/jobs/search":          {Summary: "Search active jobs (public)", Public: true, Query: jobSearchQuery{}, Data: domain.PaginatedResult[domain.JobSearchResult]{}},
+	"GET /v1/jobs/public/:id":      {Summary: "Get active job details (public)", Public: true, Data: domain.JobWithCompany{}},
+	"GET /v1/jobs/public/:id/page": {Summary: "Get the public job detail page (public)", Public: true, Data: domain.PublicJobDetail{}},
+	"GET /v1/jobs":                 {Summary: "List jobs", Query: pageQuery{}, Data: JobListResponse{}},
+	"GET /v1/jobs/:id":             {Summary: "Get job details", Data: domain.JobWithCompany{}},
+	"POST /v1/jobs":                {Summary: "Create a new job", Body: CreateJobRequest{}, Data: domain.Job{}, Status: http.StatusCreated},
+	"PUT /v1/jobs/:id":             {Summary: "Update a job", Body: UpdateJobRequest{}, Data: domain.Job{}},
+	"DELETE /v1/jobs/:id":          {Summary: "Delete a job"},
+	"POST /v1/jobs/:id/reopen":     {Summary: "Reopen a closed job", Body: domain.ReopenJobRequest{}, Data: domain.Job{}},
+
+	// Application drafts
+	"GET /v1/jobs/:id/application-draft": {Summary: "Get my application draft", Data: domain.ApplicationDraft{}},
+	"PUT /v1/jobs/:id/application-draft": {Summary: "Save my application draft", Body: domain.SaveApplicationDraftRequest{}, Data: domain.ApplicationDraft{}},
+
+	// Companies and career pages
+	"GET /v1/companies/:id":                    {Summary: "Get public company profile", Public: true, Data: domain.PublicCompanyProfile{}},
+	"GET /v1/companies/public":                 {Summary: "List verified companies", Public: true, Query: publicCompanyQuery{}, Data: domain.PaginatedResult[domain.PublicCompanySummary]{}},
+	"GET /v1/companies/public/:id":             {Summary: "Get a company's public page", Public: true, Data: domain.PublicCompanyPage{}},
+	"GET /v1/companies/public/:id/career-page": {Summary: "Get a company's career page", Public: true, Data: domain.PublicCareerPage{}},
+	"GET /v1/companies/public/:id/locations":   {Summary: "List a company's offices", Public: true, Data: []domain.CompanyLocation{}},
+
+	// Candidates
+	"GET /v1/candidates/me":                                  {Summary: "Get candidate profile (Simple)", Data: domain.CandidateProfile{}},
+	"GET /v1/candidates/me/full":                             {Summary: "Get full candidate profile", Data: domain.CandidateWithFullDetails{}},
+	"PUT /v1/candidates/me/full":                             {Summary: "Update full candidate profile", Body: domain.CandidateWithFullDetails{}},
+	"GET /v1/candidates/skills":                              {Summary: "Get master skills list", Data: []domain.Skill{}},
+	"GET /v1/candidates/me/profile-refresh":                  {Summary: "Profile refresh status", Data: domain.CandidateProfileRefresh{}},
+	"PUT /v1/candidates/me/profile-refresh":                  {Summary: "Refresh my profile", Body: domain.RefreshCandidateProfileRequest{}, Data: domain.CandidateProfileRefresh{}},
+	"GET /v1/candidates/applications":                        {Summary: "Get my applications", Data: []domain.Application{}},
+	"GET /v1/candidates/me/applications":                     {Summary: "My application history", Query: applicationHistoryQuery{}, Data: domain.PaginatedResult[domain.CandidateApplicationView]{}},
+	"POST /v1/candidates/jobs/:jobId/apply":                  {Summary: "Apply to a job", Body: ApplyToJobRequest{}, Data: domain.Application{}, Status: http.StatusCreated},
+	"GET /v1/candidates/jobs/:jobId/screening-questions":     {Summary: "Get screening questions for a job", Data: []domain.ScreeningQuestion{}},
+	"POST /v1/candidates/me/cv/parse":                        {Summary: "Parse a CV into a profile draft", Body: uploadForm{}, Form: true, Data: domain.CVDraft{}},
+	"GET /v1/candidates/me/document-expiries":                {Summary: "List my expiring documents", Data: []domain.CandidateDocumentExpiry{}},
+	"GET /v1/candidates/me/documents":                        {Summary: "List my vault documents", Data: []domain.CandidateDocument{}},
+	"POST /v1/candidates/me/documents":                       {Summary: "Add a document to my vault", Body: domain.CreateCandidateDocumentRequest{}, Data: domain.CandidateDocument{}, Status: http.StatusCreated},
+	"DELETE /v1/candidates/me/documents/:id":                 {Summary: "Delete a vault document"},
+	"GET /v1/candidates/me/portfolio":                        {Summary: "Get my portfolio", Data: domain.CandidatePortfolio{}},
+	"GET /v1/candidates/me/lpk-sharing":                      {Summary: "Get whether my application status is shared with my LPK", Data: domain.LPKPlacementSharing{}},
+	"PUT /v1/candidates/me/lpk-sharing":                      {Summary: "Give or withdraw sharing my application status with my LPK", Body: domain.UpdateLPKPlacementSharingRequest{}, Data: domain.LPKPlacementSharing{}},
+	"POST /v1/candidates/me/portfolio":                       {Summary: "Add a work sample to my portfolio", Body: domain.CreatePortfolioItemRequest{}, Data: domain.CandidatePortfolioItem{}, Status: http.StatusCreated},
+	"PUT /v1/candidates/me/portfolio/order":                  {Summary: "Reorder my portfolio", Body: domain.ReorderPortfolioRequest{}, Data: domain.CandidatePortfolio{}},
+	"PUT /v1/candidates/me/portfolio/:id":                    {Summary: "Edit a portfolio item", Body: domain.UpdatePortfolioItemRequest{}, Data: domain.CandidatePortfolioItem{}},
+	"DELETE /v1/candidates/me/portfolio/:id":                 {Summary: "Delete a portfolio item"},
+	"GET /v1/candidates/me/contact-hours":                    {Summary: "Get my preferred contact hours", Data: domain.CandidateContactHours{}},
+	"PUT /v1/candidates/me/contact-hours":                    {Summary: "Set my preferred contact hours", Body: domain.ContactHoursRequest{}, Data: domain.CandidateContactHours{}},
+	"GET /v1/candidates/me/screening-calls":                  {Summary: "List my screening calls", Data: []domain.ScreeningCall{}},
+	"POST /v1/candidates/me/screening-calls/:id/cancel":      {Summary: "Cancel one of my screening calls", Body: domain.CancelScreeningCallRequest{}, Data: domain.ScreeningCall{}},
+	"GET /v1/candidates/me/activity":                         {Summary: "My activity feed", Query: activityQuery{}, Data: []domain.CandidateActivity{}},
+	"GET /v1/candidates/me/interview-feedback":               {Summary: "List feedback I received", Data: []domain.InterviewFeedback{}},
+	"GET /v1/candidates/me/interview-feedback/preference":    {Summary: "Get my interview feedback preference", Data: domain.InterviewFeedbackPreference{}},
+	"GET /v1/candidates/me/application-insights":             {Summary: "Get my application insights", Data: domain.ApplicationInsights{}},
+	"GET /v1/candidates/me/messages":                         {Summary: "List messages employers sent me", Query: adminPageQuery{}, Data: domain.PaginatedResult[domain.CandidateMessage]{}},
+	"POST /v1/candidates/me/messages/:id/report-spam":        {Summary: "Report a message as spam"},
+	"PUT /v1/candidates/me/interview-feedback/preference":    {Summary: "Opt out of (or back into) interview feedback", Body: domain.UpdateInterviewFeedbackPreferenceRequest{}, Data: domain.InterviewFeedbackPreference{}},
+	"GET /v1/candidates/me/phone":                            {Summary: "Get phone verification status", Data: domain.PhoneVerificationStatus{}},
+	"POST /v1/candidates/me/phone/otp":                       {Summary: "Send phone verification code", Data: domain.PhoneVerificationStatus{}},
+	"POST /v1/candidates/me/phone/verify":                    {Summary: "Verify phone with OTP", Body: domain.VerifyPhoneRequest{}, Data: domain.PhoneVerificationStatus{}},
+	"GET /v1/candidates/me/privacy":                          {Summary: "Get my privacy settings", Data: domain.TalentPoolSettings{}},
+	"PUT /v1/candidates/me/privacy":                          {Summary: "Choose which employers can find me", Body: domain.UpdatePrivacyRequest{}, Data: domain.TalentPoolSettings{}},
+	"GET /v1/candidates/me/quizzes":                          {Summary: "List available skill quizzes", Data: []domain.CandidateQuizOverview{}},
+	"POST /v1/candidates/me/quizzes/:id/attempts":            {Summary: "Start or resume a quiz attempt", Data: domain.QuizAttemptSession{}},
+	"POST /v1/candidates/me/quiz-attempts/:attemptId/submit": {Summary: "Submit a quiz attempt", Body: domain.SubmitQuizRequest{}, Data: domain.QuizAttempt{}},
+	"GET /v1/candidates/me/quiz-scores":                      {Summary: "Get own quiz scores", Data: []domain.CandidateQuizScore{}},
+	"GET /v1/candidates/me/reengagement":                     {Summary: "Get re-engagement reminder preference", Data: domain.ReengagementPreference{}},
+	"PUT /v1/candidates/me/reengagement":                     {Summary: "Opt out of (or back into) re-engagement reminders", Body: domain.UpdateReengagementPreferenceRequest{}, Data: domain.ReengagementPreference{}},
+	"GET /v1/candidates/me/saved-searches":                   {Summary: "List my saved searches", Data: []domain.SavedSearch{}},
+	"POST /v1/candidates/me/saved-searches":                  {Summary: "Save a job search", Body: domain.SavedSearchRequest{}, Data: domain.SavedSearch{}, Status: http.StatusCreated},
+	"PUT /v1/candidates/me/saved-searches/:id":               {Summary: "Replace a saved search", Body: domain.SavedSearchRequest{}, Data: domain.SavedSearch{}},
+	"DELETE /v1/candidates/me/saved-searches/:id":            {Summary: "Delete a saved search"},
+	"GET /v1/candidates/me/talent-pool":                      {Summary: "Get my talent pool settings", Data: domain.TalentPoolSettings{}},
+	"PUT /v1/candidates/me/talent-pool":                      {Summary: "Join or leave the talent pool", Body: domain.UpdateTalentPoolRequest{}, Data: domain.TalentPoolSettings{}},
+	"POST /v1/candidates/me/talent-pool/grants":              {Summary: "Let a company see my name and photo", Body: domain.GrantPIIAccessRequest{}, Data: domain.TalentPoolSettings{}},
+	"DELETE /v1/candidates/me/talent-pool/grants/:companyId": {Summary: "Revoke a company's access to my name and photo", Data: domain.TalentPoolSettings{}},
+	"GET /v1/candidates/me/verification":                     {ID: "getMyCandidateVerification", Summary: "Get my verification status", Data: domain.VerificationResponse{}},
+	"PUT /v1/candidates/me/verification":                     {Summary: "Update candidate verification profile", Body: UpdateProfileRequest{}},
+	"GET /v1/candidates/me/profile-completeness":             {Summary: "Get my profile completeness", Data: domain.ProfileCompleteness{}},
+
+	// Onboarding
+	"GET /v1/onboarding/status":     {Summary: "Get onboarding status", Data: domain.OnboardingStatus{}},
+	"GET /v1/onboarding/data":       {Summary: "Get onboarding data", Data: domain.OnboardingData{}},
+	"GET /v1/onboarding/lpk/search": {Summary: "Search LPK training centers", Query: searchTermQuery{}, Data: []domain.LPK{}},
+	"POST /v1/onboarding/complete":  {Summary: "Complete onboarding wizard", Body: domain.OnboardingSubmitRequest{}},
+
+	// Employers
+	"GET /v1/employers/ats/candidates":                  {Summary: "Search candidates visible to the employer", Data: domain.PaginatedResult[domain.ATSCandidate]{}},
+	"GET /v1/employers/jobs":                            {Summary: "List employer's own jobs", Query: pageQuery{}, Data: EmployerJobListResponse{}},
+	"POST /v1/employers/jobs/import":                    {Summary: "Import jobs from a CSV or XLSX file", Body: uploadForm{}, Form: true, Data: domain.JobImportResult{}},
+	"PUT /v1/employers/jobs/:jobId/schedule":            {Summary: "Schedule a job's publishing", Body: domain.JobScheduleRequest{}, Data: domain.Job{}},
+	"DELETE /v1/employers/jobs/:jobId/schedule":         {Summary: "Cancel a job's schedule", Data: domain.Job{}},
+	"GET /v1/employers/company-profile":                 {Summary: "Get employer's own company profile", Data: domain.CompanyProfile{}},
+	"PUT /v1/employers/company-profile":                 {Summary: "Create or update company profile", Body: CompanyProfileRequest{}, Data: domain.CompanyProfile{}},
+	"GET /v1/employers/jobs/:jobId/applications":        {Summary: "List applications for a job", Data: []domain.Application{}},
+	"GET /v1/employers/jobs/:jobId/applications/export": {Summary: "Download a job's applications as XLSX", Content: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+	"GET /v1/employers/jobs/:jobId/screening-questions": {Summary: "Get a job's screening questions", Data: []domain.ScreeningQuestion{}},
+	"PUT /v1/employers/jobs/:jobId/screening-questions": {Summary: "Replace a job's screening questions", Body: domain.ReplaceScreeningQuestionsRequest{}, Data: []domain.ScreeningQuestion{}},
+	"GET /v1/employers/jobs/:jobId/pipeline":            {Summary: "Get a job's pipeline stages", Data: domain.JobPipeline{}},
+	"PUT /v1/employers/jobs/:jobId/pipeline":            {Summary: "Choose a job's optional pipeline stages", Body: domain.UpdateJobPipelineRequest{}, Data: domain.JobPipeline{}},
+	"GET /v1/employers/jobs/:jobId/funnel":              {Summary: "Count a job's applications per stage", Data: domain.JobFunnel{}},
+	"GET /v1/employers/funnel":                          {Summary: "Count applications per stage for all of the employer's jobs", Data: []domain.JobFunnel{}},
+	"GET /v1/employers/applications/:id":                {Summary: "Get application detail", Data: domain.ApplicationDetailResponse{}},
+	"PATCH /v1/employers/applications/:id":              {Summary: "Update application status", Body: UpdateStatusRequest{}},
+	"GET /v1/employers/applications/:id/stage":          {Summary: "Get an application's pipeline stage", Data: domain.ApplicationStageView{}},
+	"PUT /v1/employers/applications/:id/stage":          {Summary: "Move an application to another stage", Body: domain.MoveApplicationStageRequest{}, Data: domain.ApplicationStageView{}},
+	"GET /v1/employers/applications/:id/feedback":       {Summary: "Get the feedback shared on an application", Data: domain.InterviewFeedback{}},
+	"POST /v1/employers/applications/:id/feedback":      {Summary: "Share feedback with a rejected candidate", Body: domain.SubmitInterviewFeedbackRequest{}, Data: domain.InterviewFeedback{}, Status: http.StatusCreated},
+	"GET /v1/employers/interview-feedback/templates":    {Summary: "List approved feedback wordings", Data: []domain.InterviewFeedbackTemplate{}},
+	"GET /v1/employers/message-templates":               {Summary: "List message templates", Data: []domain.MessageTemplate{}},
+	"POST /v1/employers/message-templates":              {Summary: "Create a message template", Body: domain.MessageTemplateRequest{}, Data: domain.MessageTemplate{}, Status: http.StatusCreated},
+	"PUT /v1/employers/message-templates/:id":           {Summary: "Replace a message template", Body: domain.MessageTemplateRequest{}, Data: domain.MessageTemplate{}},
+	"DELETE /v1/employers/message-templates/:id":        {Summary: "Delete a message template"},
+	"POST /v1/employers/messages":                       {Summary: "Message shortlisted candidates", Body: domain.SendBulkMessageRequest{}, Data: domain.BulkMessage{}, Status: http.StatusCreated},
+	"GET /v1/employers/messages":                        {Summary: "List sent bulk messages", Query: adminPageQuery{}, Data: domain.PaginatedResult[domain.BulkMessage]{}},
+	"GET /v1/employers/messages/:id":                    {Summary: "Get a bulk message with per-recipient delivery status", Data: domain.BulkMessage{}},
+	"GET /v1/employers/message-quota":                   {Summary: "Get my company's bulk messaging allowance", Data: domain.BulkMessageQuota{}},
+	"GET /v1/employers/candidates/:userId/resume":       {Summary: "Download a candidate resume as PDF", Content: "application/pdf"},
+	"GET /v1/employers/candidates/:userId/portfolio":    {Summary: "Get a candidate's portfolio", Data: domain.EmployerCandidatePortfolio{}},
+	"POST /v1/employers/candidates/:userId/reveal":      {Summary: "Reveal candidate contact details", Data: domain.ContactRevealResult{}},
+	"GET /v1/employers/credits":                         {Summary: "Get company credit balance", Data: domain.CompanyCreditBalance{}},
+	"GET /v1/employers/credits/ledger":                  {Summary: "List company credit ledger", Query: adminPageQuery{}, Data: domain.PaginatedResult[domain.CreditLedgerEntry]{}},
+	"GET /v1/employers/career-page":                     {Summary: "Get own career page", Data: domain.CareerPage{}},
+	"PUT /v1/employers/career-page":                     {Summary: "Update own career page", Body: domain.CareerPageRequest{}, Data: domain.CareerPage{}},
+	"PUT /v1/employers/career-page/slug":                {Summary: "Set career page URL", Body: domain.UpdateSlugRequest{}, Data: domain.CareerPage{}},
+	"GET /v1/employers/career-page/slug-availability":   {Summary: "Check career page URL availability", Data: domain.SlugAvailability{}},
+	"GET /v1/employers/me/usage":                        {Summary: "Get my company's usage against quotas", Data: domain.CompanyUsage{}},
+	"GET /v1/employers/me/verification-level":           {Summary: "Get my company's verification level", Data: domain.CompanyVerification{}},
+	"GET /v1/employers/me/pipeline-sla":                 {Summary: "Get my company's pipeline SLA thresholds", Data: domain.PipelineSLASettings{}},
+	"PUT /v1/employers/me/pipeline-sla":                 {Summary: "Set my company's pipeline SLA thresholds", Body: domain.UpdatePipelineSLARequest{}, Data: domain.PipelineSLASettings{}},
+	"GET /v1/employers/me/pipeline-sla/breaches":        {Summary: "List my company's pipeline SLA breaches", Query: pipelineSLABreachQuery{}, Data: domain.PaginatedResult[domain.PipelineSLABreach]{}},
+
+	// Company offices
+	"GET /v1/employers/company-profile/locations":        {Summary: "List my company's offices", Data: []domain.CompanyLocation{}},
+	"POST /v1/employers/company-profile/locations":       {Summary: "Add an office", Body: domain.CompanyLocationRequest{}, Data: domain.CompanyLocation{}, Status: http.StatusCreated},
+	"PUT /v1/employers/company-profile/locations/:id":    {Summary: "Update an office", Body: domain.CompanyLocationRequest{}, Data: domain.CompanyLocation{}},
+	"DELETE /v1/employers/company-profile/locations/:id": {Summary: "Delete an office"},
+
+	// Company team
+	"GET /v1/employers/me/membership":              {Summary: "Get my company and role", Data: domain.CompanyMembership{}},
+	"GET /v1/employers/members":                    {Summary: "List my company's team", Data: domain.CompanyTeam{}},
+	"POST /v1/employers/members/invitations":       {Summary: "Invite a team member", Body: domain.InviteCompanyMemberRequest{}, Data: domain.CompanyInvitation{}, Status: http.StatusCreated},
+	"DELETE /v1/employers/members/invitations/:id": {Summary: "Revoke an invitation"},
+	"PUT /v1/employers/members/:userId":            {Summary: "Change a member's role", Body: domain.UpdateCompanyMemberRequest{}, Data: domain.CompanyMember{}},
+	"DELETE /v1/employers/members/:userId":         {Summary: "Remove a member or leave the team"},
+	"POST /v1/company-invitations/accept":          {Summary: "Accept a company invitation", Body: domain.AcceptCompanyInvitationRequest{}, Data: domain.CompanyMembership{}},
+
+	// Regional admin scopes
+	"GET /v1/admin/me/scope":          {Summary: "Get my admin scope", Data: domain.MyAdminScope{}},
+	"GET /v1/admin/scopes":            {Summary: "List regional admins", Data: []domain.AdminScope{}},
+	"PUT /v1/admin/scopes/:userId":    {Summary: "Limit an admin to provinces", Body: domain.SetAdminScopeRequest{}, Data: domain.AdminScope{}},
+	"DELETE /v1/admin/scopes/:userId": {Summary: "Make an admin unrestricted"},
+
+	// Email tracking
+	"GET /v1/auth/me/email-tracking": {Summary: "Get my email tracking preference", Data: domain.EmailTrackingPreference{}},
+	"PUT /v1/auth/me/email-tracking": {Summary: "Switch email tracking off or on", Body: domain.UpdateEmailTrackingPreferenceRequest{}, Data: domain.EmailTrackingPreference{}},
+	"GET /v1/admin/email-engagement": {Summary: "Email engagement per template", Data: domain.EmailEngagementReport{}},
+
+	// Notification center
+	"GET /v1/notifications":              {Summary: "List my notifications", Query: notificationListQuery{}, Data: domain.NotificationList{}},
+	"GET /v1/notifications/unread-count": {Summary: "Count my unread notifications", Data: domain.UnreadNotificationCount{}},
+	"PATCH /v1/notifications/:id/read":   {Summary: "Mark a notification read"},
+	"PATCH /v1/notifications/read-all":   {Summary: "Mark all my notifications read", Data: domain.NotificationsReadResult{}},
+	"POST /v1/admin/announcements":       {Summary: "Send an announcement to the notification center", Body: domain.AnnouncementRequest{}, Data: domain.AnnouncementResult{}, Status: http.StatusCreated},
+
+	// Screening calls (admins and employers)
+	"GET /v1/screening-calls/slots":        {Summary: "Open call slots of a candidate", Query: screeningCallSlotQuery{}, Data: domain.ScreeningCallAvailability{}},
+	"POST /v1/screening-calls":             {Summary: "Book a screening call", Body: domain.BookScreeningCallRequest{}, Data: domain.ScreeningCall{}, Status: http.StatusCreated},
+	"GET /v1/screening-calls":              {Summary: "List screening calls", Query: screeningCallListQuery{}, Data: []domain.ScreeningCall{}},
+	"POST /v1/screening-calls/:id/cancel":  {Summary: "Cancel a screening call", Body: domain.CancelScreeningCallRequest{}, Data: domain.ScreeningCall{}},
+	"POST /v1/screening-calls/:id/outcome": {Summary: "Record a screening call outcome", Body: domain.ScreeningCallOutcomeRequest{}, Data: domain.ScreeningCall{}},
+
+	// LPK partners
+	"GET /v1/lpk/me":                            {Summary: "Get current LPK partnership", Data: domain.LPKPartner{}},
+	"GET /v1/lpk/candidates":                    {Summary: "List candidates who selected this LPK", Query: statusPageQuery{}, Data: domain.PaginatedResult[domain.LPKCandidateProgress]{}},
+	"GET /v1/lpk/candidates/:ref/endorsements":  {Summary: "List endorsement notes for a candidate", Data: []domain.LPKEndorsement{}},
+	"POST /v1/lpk/candidates/:ref/endorsements": {Summary: "Submit an endorsement note for a candidate", Body: domain.CreateLPKEndorsementRequest{}, Data: domain.LPKEndorsement{}, Status: http.StatusCreated},
+	"GET /v1/lpk/stats":                         {Summary: "Get placement statistics for this LPK", Data: domain.LPKPlacementStats{}},
+	"GET /v1/lpk/cohorts":                       {ID: "lpkListCohorts", Summary: "List this LPK's training cohorts with their progress", Data: []domain.Cohort{}},
+	"POST /v1/lpk/cohorts":                      {ID: "lpkCreateCohort", Summary: "Create a training cohort", Body: domain.CohortRequest{}, Data: domain.Cohort{}, Status: http.StatusCreated},
+	"GET /v1/lpk/cohorts/:id":                   {ID: "lpkGetCohort", Summary: "Get a training cohort with its progress", Data: domain.Cohort{}},
+	"PUT /v1/lpk/cohorts/:id":                   {ID: "lpkUpdateCohort", Summary: "Update a training cohort", Body: domain.CohortRequest{}, Data: domain.Cohort{}},
+	"DELETE /v1/lpk/cohorts/:id":                {ID: "lpkDeleteCohort", Summary: "Delete a training cohort"},
+	"GET /v1/lpk/cohorts/:id/members":           {ID: "lpkListCohortMembers", Summary: "List a cohort's candidates", Query: adminPageQuery{}, Data: domain.PaginatedResult[domain.LPKCandidateProgress]{}},
+	"POST /v1/lpk/cohorts/:id/members":          {ID: "lpkAssignCohortMembers", Summary: "Bulk-assign candidates to a cohort", Body: domain.AssignCohortMembersRequest{}, Data: domain.CohortAssignResult{}},
+	"DELETE /v1/lpk/cohorts/:id/members/:ref":   {ID: "lpkRemoveCohortMember", Summary: "Remove a candidate from a cohort"},
+	"GET /v1/lpk/api-keys":                      {Summary: "List partner API keys", Data: []domain.LPKAPIKey{}},
+	"POST /v1/lpk/api-keys":                     {Summary: "Create a partner API key", Body: domain.CreateLPKAPIKeyRequest{}, Data: domain.LPKAPIKey{}, Status: http.StatusCreated},
+	"DELETE /v1/lpk/api-keys/:id":               {Summary: "Revoke a partner API key"},
+	"GET /v1/lpk/webhooks":                      {Summary: "List partner webhook endpoints", Data: []domain.WebhookEndpoint{}},
+	"POST /v1/lpk/webhooks":                     {Summary: "Register a partner webhook endpoint", Body: domain.WebhookEndpointRequest{}, Data: domain.WebhookEndpoint{}, Status: http.StatusCreated},
+	"PUT /v1/lpk/webhooks/:id":                  {Summary: "Replace a partner webhook endpoint", Body: domain.WebhookEndpointRequest{}, Data: domain.WebhookEndpoint{}},
+	"DELETE /v1/lpk/webhooks/:id":               {Summary: "Delete a partner webhook endpoint"},
+	"POST /v1/lpk/webhooks/:id/rotate-secret":   {Summary: "Rotate a partner webhook signing secret", Data: domain.WebhookEndpoint{}},
+	"GET /v1/lpk/webhooks/deliveries":           {Summary: "List partner webhook deliveries", Query: webhookDeliveryQuery{}, Data: domain.PaginatedResult[domain.WebhookDelivery]{}},
+	// Partner API: X-API-Key header instead of a bearer token
+	"GET /v1/partners/lpk/placements": {Summary: "Pull placement status changes (X-API-Key)", Public: true, Query: lpkPlacementQuery{}, Data: domain.LPKPlacementFeed{}},
+
+	// Verifications
+	"GET /v1/verifications":              {Summary: "List account verifications", Query: verificationListQuery{}, Data: domain.PaginatedResult[domain.AccountVerification]{}},
+	"GET /v1/verifications/me":           {Summary: "Get my verification status", Data: domain.VerificationResponse{}},
+	"GET /v1/verifications/:id":          {Summary: "Get verification detail", Data: domain.ComprehensiveVerificationResponse{}},
+	"POST /v1/verifications/:id/verify":  {Summary: "Verify an account", Body: VerifyRequest{}},
+	"GET /v1/verifications/schema":       {Summary: "Get the verification form schema", Query: verificationSchemaQuery{}, Data: domain.VerificationSchema{}},
+	"PUT /v1/verifications/schema/:role": {Summary: "Update the verification form schema", Body: domain.UpdateVerificationSchemaRequest{}, Data: domain.VerificationSchema{}},
+
+	// Admin
+	"GET /v1/admin/stats":                                      {Summary: "Get admin dashboard statistics", Data: domain.AdminStats{}},
+	"GET /v1/admin/search":                                     {Summary: "Search users, candidates, companies and jobs", Query: adminSearchQuery{}, Data: domain.AdminSearchResponse{}},
+	"GET /v1/admin/users":                                      {Summary: "List all users", Query: adminUserQuery{}, Data: domain.PaginatedResult[domain.AdminUser]{}},
+	"POST /v1/admin/users":                                     {Summary: "Create a new user", Body: domain.CreateUserRequest{}, Data: domain.AdminUser{}, Status: http.StatusCreated},
+	"PUT /v1/admin/users/:id":                                  {Summary: "Update a user", Body: domain.UpdateUserRequest{}, Data: domain.AdminUser{}},
+	"DELETE /v1/admin/users/:id":                               {Summary: "Delete a user"},
+	"PATCH /v1/admin/users/:id/disable":                        {Summary: "Disable or enable a user", Body: DisableUserRequest{}, Data: domain.AdminUser{}},
+	"GET /v1/admin/companies":                                  {Summary: "List all companies", Query: adminCompanyQuery{}, Data: domain.PaginatedResult[domain.AdminCompany]{}},
+	"PATCH /v1/admin/companies/:id/verify":                     {Summary: "Verify a company", Body: VerifyCompanyRequest{}, Data: domain.AdminCompany{}},
+	"GET /v1/admin/companies/:id/credits":                      {Summary: "Get a company's credit balance", Data: domain.CompanyCreditBalance{}},
+	"POST /v1/admin/companies/:id/credits":                     {Summary: "Grant credits to a company", Body: domain.GrantCreditsRequest{}, Data: domain.CreditLedgerEntry{}, Status: http.StatusCreated},
+	"GET /v1/admin/companies/:id/credits/ledger":               {Summary: "List a company's credit ledger", Query: adminPageQuery{}, Data: domain.PaginatedResult[domain.CreditLedgerEntry]{}},
+	"GET /v1/admin/jobs":                                       {Summary: "List all jobs for moderation", Query: statusPageQuery{}, Data: domain.PaginatedResult[domain.AdminJob]{}},
+	"PATCH /v1/admin/jobs/:id/hide":                            {Summary: "Hide or unhide a job", Body: HideJobRequest{}, Data: domain.AdminJob{}},
+	"PATCH /v1/admin/jobs/:id/flag":                            {Summary: "Flag or unflag a job", Body: FlagJobRequest{}, Data: domain.AdminJob{}},
+	"GET /v1/admin/ats/candidates":                             {Summary: "Search candidates with filters", Data: domain.PaginatedResult[domain.ATSCandidate]{}},
+	"GET /v1/admin/ats/export":                                 {Summary: "Export candidates to Excel/CSV or a ZIP of PDF profiles", Content: "application/octet-stream"},
+	"GET /v1/admin/ats/export-audits":                          {Summary: "List ATS export audits", Query: atsExportAuditQuery{}, Data: domain.PaginatedResult[domain.ATSExportAudit]{}},
+	"GET /v1/admin/ats/export-audits/:id":                      {Summary: "Get an ATS export audit", Data: domain.ATSExportAudit{}},
+	"GET /v1/admin/ats/filter-options":                         {Summary: "Get available filter options", Data: domain.ATSFilterOptions{}},
+	"GET /v1/admin/ats/filters":                                {Summary: "List my saved ATS filters and those shared with me", Data: []domain.ATSSavedFilter{}},
+	"POST /v1/admin/ats/filters":                               {Summary: "Save an ATS filter", Body: domain.ATSSavedFilterRequest{}, Data: domain.ATSSavedFilter{}, Status: http.StatusCreated},
+	"GET /v1/admin/ats/filters/:id":                            {Summary: "Get a saved ATS filter", Data: domain.ATSSavedFilter{}},
+	"PUT /v1/admin/ats/filters/:id":                            {Summary: "Replace a saved ATS filter", Body: domain.ATSSavedFilterRequest{}, Data: domain.ATSSavedFilter{}},
+	"DELETE /v1/admin/ats/filters/:id":                         {Summary: "Delete a saved ATS filter"},
+	"GET /v1/admin/lpk-partners":                               {Summary: "List LPK partner accounts", Data: []domain.LPKPartner{}},
+	"POST /v1/admin/lpk-partners":                              {Summary: "Assign an LPK partner account", Body: domain.AssignLPKPartnerRequest{}, Data: domain.LPKPartner{}},
+	"DELETE /v1/admin/lpk-partners/:userId":                    {Summary: "Revoke an LPK partner account"},
+	"GET /v1/admin/cohorts":                                    {Summary: "List training cohorts with their progress", Query: cohortListQuery{}, Data: []domain.Cohort{}},
+	"POST /v1/admin/cohorts":                                   {Summary: "Create a training cohort for an LPK", Body: domain.CohortRequest{}, Data: domain.Cohort{}, Status: http.StatusCreated},
+	"GET /v1/admin/cohorts/:id":                                {Summary: "Get a training cohort with its progress", Data: domain.Cohort{}},
+	"PUT /v1/admin/cohorts/:id":                                {Summary: "Update a training cohort", Body: domain.CohortRequest{}, Data: domain.Cohort{}},
+	"DELETE /v1/admin/cohorts/:id":                             {Summary: "Delete a training cohort"},
+	"GET /v1/admin/cohorts/:id/members":                        {Summary: "List a cohort's candidates", Query: adminPageQuery{}, Data: domain.PaginatedResult[domain.LPKCandidateProgress]{}},
+	"POST /v1/admin/cohorts/:id/members":                       {Summary: "Bulk-assign candidates to a cohort", Body: domain.AssignCohortMembersRequest{}, Data: domain.CohortAssignResult{}},
+	"DELETE /v1/admin/cohorts/:id/members/:ref":                {Summary: "Remove a candidate from a cohort"},
+	"GET /v1/admin/placements":                                 {Summary: "List placements", Query: placementListQuery{}, Data: domain.PaginatedResult[domain.Placement]{}},
+	"POST /v1/admin/placements":                                {Summary: "Record a placement for a hired candidate", Body: domain.CreatePlacementRequest{}, Data: domain.Placement{}, Status: http.StatusCreated},
+	"GET /v1/admin/placements/check-ins":                       {Summary: "List post-placement check-ins due or overdue", Data: []domain.PlacementCheckInTask{}},
+	"GET /v1/admin/placements/stats":                           {Summary: "Placement statistics", Data: domain.PlacementStats{}},
+	"GET /v1/admin/placements/:id":                             {Summary: "Get a placement with its check-ins", Data: domain.Placement{}},
+	"PUT /v1/admin/placements/:id/end":                         {Summary: "End a placement", Body: domain.EndPlacementRequest{}, Data: domain.Placement{}},
+	"PUT /v1/admin/placements/:id/check-ins/:months":           {Summary: "Record a post-placement check-in", Body: domain.CompleteCheckInRequest{}, Data: domain.Placement{}},
+	"GET /v1/admin/finance/summary":                            {Summary: "Get financial summary", Data: domain.FinanceSummary{}},
+	"GET /v1/admin/finance/months/:month":                      {Summary: "Get financial detail for one month", Data: domain.FinanceMonthDetail{}},
+	"GET /v1/admin/holidays":                                   {ID: "adminListHolidays", Summary: "List public holidays", Data: []domain.PublicHoliday{}},
+	"POST /v1/admin/holidays":                                  {Summary: "Add a public holiday", Body: domain.HolidayRequest{}, Data: domain.PublicHoliday{}, Status: http.StatusCreated},
+	"PUT /v1/admin/holidays/:id":                               {Summary: "Update a public holiday", Body: domain.HolidayRequest{}, Data: domain.PublicHoliday{}},
+	"DELETE /v1/admin/holidays/:id":                            {Summary: "Delete a public holiday"},
+	"GET /v1/admin/reengagement/report":                        {Summary: "Re-engagement campaign report", Data: domain.ReengagementReport{}},
+	"POST /v1/admin/reengagement/run":                          {Summary: "Run re-engagement campaigns now", Data: domain.ReengagementRunResult{}},
+	"GET /v1/admin/quizzes":                                    {Summary: "List skill quizzes", Data: []domain.SkillQuiz{}},
+	"POST /v1/admin/quizzes":                                   {Summary: "Create a skill quiz", Body: domain.QuizRequest{}, Data: domain.SkillQuiz{}, Status: http.StatusCreated},
+	"GET /v1/admin/quizzes/:id":                                {Summary: "Get a skill quiz with its answer key", Data: domain.SkillQuiz{}},
+	"PUT /v1/admin/quizzes/:id":                                {Summary: "Replace a skill quiz", Body: domain.QuizRequest{}, Data: domain.SkillQuiz{}},
+	"POST /v1/admin/aggregates/recompute":                      {Summary: "Recompute derived candidate aggregates now", Data: domain.AggregateRecomputeRun{}},
+	"GET /v1/admin/aggregates/runs":                            {Summary: "List aggregate recompute runs", Data: []domain.AggregateRecomputeRun{}},
+	"GET /v1/admin/aggregates/runs/:id":                        {Summary: "Get a recompute run's drift report", Data: domain.AggregateRecomputeRun{}},
+	"GET /v1/admin/retention/policies":                         {Summary: "List data retention policies", Data: []domain.RetentionPolicy{}},
+	"PUT /v1/admin/retention/policies/:entity":                 {Summary: "Update a data retention policy", Body: domain.UpdateRetentionPolicyRequest{}, Data: domain.RetentionPolicy{}},
+	"POST /v1/admin/retention/dry-run":                         {Summary: "Report what retention enforcement would change", Data: domain.RetentionRun{}},
+	"POST /v1/admin/retention/enforce":                         {Summary: "Enforce the data retention policies now", Data: domain.RetentionRun{}},
+	"GET /v1/admin/retention/runs":                             {Summary: "List retention runs", Data: []domain.RetentionRun{}},
+	"GET /v1/admin/retention/runs/:id":                         {Summary: "Get a retention run's report", Data: domain.RetentionRun{}},
+	"GET /v1/admin/company-merges":                             {Summary: "List company merges", Data: []domain.CompanyMerge{}},
+	"POST /v1/admin/company-merges":                            {Summary: "Merge a duplicate company into a surviving company", Body: domain.CompanyMergeRequest{}, Data: domain.CompanyMerge{}, Status: http.StatusCreated},
+	"GET /v1/admin/company-merges/:id":                         {Summary: "Get a company merge with its audit entries", Data: domain.CompanyMerge{}},
+	"GET /v1/admin/kill-switches":                              {Summary: "List kill switches", Data: []domain.KillSwitch{}},
+	"GET /v1/admin/kill-switches/audit":                        {Summary: "List kill switch toggles", Data: []domain.KillSwitchAuditEntry{}},
+	"POST /v1/admin/kill-switches/disable":                     {Summary: "Disable an endpoint or subsystem", Body: domain.DisableKillSwitchRequest{}, Data: domain.KillSwitch{}},
+	"POST /v1/admin/kill-switches/enable":                      {Summary: "Re-enable an endpoint or subsystem", Body: domain.EnableKillSwitchRequest{}, Data: domain.KillSwitch{}},
+	"GET /v1/admin/document-expiries/report":                   {Summary: "Document expirations by month", Data: domain.DocumentExpiryReport{}},
+	"POST /v1/admin/document-expiries/run":                     {Summary: "Send document expiry reminders now", Data: domain.DocumentExpiryRunResult{}},
+	"GET /v1/admin/email-validations":                          {Summary: "List accounts with undeliverable emails", Query: undeliverableEmailQuery{}, Data: domain.PaginatedResult[domain.UndeliverableEmail]{}},
+	"GET /v1/admin/email-validations/report":                   {Summary: "Email deliverability report", Data: domain.EmailValidationReport{}},
+	"POST /v1/admin/email-validations/run":                     {Summary: "Validate emails now", Data: domain.EmailValidationRunResult{}},
+	"POST /v1/admin/users/:id/mfa/reset":                       {Summary: "Reset an admin's two-factor authentication"},
+	"GET /v1/admin/jobs-queue":                                 {Summary: "List background jobs", Query: jobQueueQuery{}, Data: domain.PaginatedResult[jobs.Job]{}},
+	"GET /v1/admin/jobs-queue/counts":                          {Summary: "Count background jobs", Data: []jobs.TypeCount{}},
+	"POST /v1/admin/jobs-queue/:id/retry":                      {Summary: "Retry a dead job", Data: jobs.Job{}},
+	"GET /v1/admin/candidate-documents":                        {Summary: "List candidate documents for review", Query: candidateDocumentQuery{}, Data: domain.PaginatedResult[domain.AdminCandidateDocument]{}},
+	"POST /v1/admin/candidate-documents/:id/review":            {Summary: "Approve or reject a candidate document", Body: domain.ReviewCandidateDocumentRequest{}, Data: domain.CandidateDocument{}},
+	"GET /v1/admin/duplicate-documents":                        {Summary: "List duplicate document reviews", Query: duplicateDocumentQuery{}, Data: domain.PaginatedResult[domain.DuplicateDocumentReview]{}},
+	"GET /v1/admin/duplicate-documents/:id":                    {Summary: "Get a duplicate document review", Data: domain.DuplicateDocumentReview{}},
+	"POST /v1/admin/invite-codes":                              {Summary: "Generate invite codes", Body: domain.GenerateInviteCodesRequest{}, Data: []domain.InviteCode{}, Status: http.StatusCreated},
+	"GET /v1/admin/invite-codes":                               {Summary: "List invite codes", Query: inviteCodeQuery{}, Data: domain.PaginatedResult[domain.InviteCode]{}},
+	"GET /v1/admin/invite-codes/:id":                           {Summary: "Get an invite code", Data: domain.InviteCode{}},
+	"POST /v1/admin/invite-codes/:id/revoke":                   {Summary: "Revoke an invite code", Data: domain.InviteCode{}},
+	"POST /v1/admin/duplicate-documents/:id/review":            {Summary: "Resolve a duplicate document review", Body: domain.ReviewDuplicateDocumentRequest{}, Data: domain.DuplicateDocumentReview{}},
+	"GET /v1/admin/company-verification":                       {Summary: "List companies by verification level", Query: companyVerificationQuery{}, Data: domain.PaginatedResult[domain.CompanyVerification]{}},
+	"GET /v1/admin/company-verification/:companyId":            {Summary: "Get a company's verification level", Data: domain.CompanyVerification{}},
+	"PUT /v1/admin/company-verification/:companyId":            {Summary: "Set a company's verification level", Body: domain.SetCompanyLevelRequest{}, Data: domain.CompanyVerification{}},
+	"GET /v1/admin/pipeline-sla/report":                        {Summary: "Companies with chronic pipeline SLA breaches", Query: domain.PipelineSLAReportFilter{}, Data: []domain.PipelineSLACompanyReport{}},
+	"POST /v1/admin/saved-searches/run":                        {Summary: "Send saved search job alerts now", Data: domain.SavedSearchRunResult{}},
+	"GET /v1/admin/interview-feedback":                         {Summary: "List interview feedback by status", Data: []domain.InterviewFeedback{}},
+	"POST /v1/admin/interview-feedback/:id/review":             {Summary: "Approve or reject interview feedback", Body: domain.ReviewInterviewFeedbackRequest{}, Data: domain.InterviewFeedback{}},
+	"GET /v1/admin/webhooks":                                   {Summary: "List webhook endpoints", Data: []domain.WebhookEndpoint{}},
+	"POST /v1/admin/webhooks":                                  {Summary: "Register a webhook endpoint", Body: domain.WebhookEndpointRequest{}, Data: domain.WebhookEndpoint{}, Status: http.StatusCreated},
+	"GET /v1/admin/webhooks/:id":                               {Summary: "Get a webhook endpoint", Data: domain.WebhookEndpoint{}},
+	"PUT /v1/admin/webhooks/:id":                               {Summary: "Replace a webhook endpoint", Body: domain.WebhookEndpointRequest{}, Data: domain.WebhookEndpoint{}},
+	"DELETE /v1/admin/webhooks/:id":                            {Summary: "Delete a webhook endpoint"},
+	"POST /v1/admin/webhooks/:id/rotate-secret":                {Summary: "Rotate a webhook signing secret", Data: domain.WebhookEndpoint{}},
+	"GET /v1/admin/webhooks/deliveries":                        {Summary: "List webhook deliveries", Query: webhookDeliveryQuery{}, Data: domain.PaginatedResult[domain.WebhookDelivery]{}},
+	"GET /v1/admin/webhooks/deliveries/:deliveryId":            {Summary: "Get a webhook delivery", Data: domain.WebhookDelivery{}},
+	"POST /v1/admin/webhooks/deliveries/:deliveryId/redeliver": {Summary: "Redeliver a webhook", Data: domain.WebhookDelivery{}},
+	"GET /v1/admin/storage/cleanup":                            {Summary: "Storage cleanup summary", Data: domain.StorageCleanupSummary{}},
+	"GET /v1/admin/storage/deletions":                          {Summary: "List storage deletions", Query: statusPageQuery{}, Data: domain.PaginatedResult[domain.StorageDeletion]{}},
+	"POST /v1/admin/storage/deletions/:id/retry":               {Summary: "Retry a failed storage deletion", Data: domain.StorageDeletion{}},
+	"GET /v1/admin/storage/sweeps":                             {Summary: "List orphan sweeps", Data: []domain.StorageOrphanSweep{}},
+	"POST /v1/admin/storage/sweeps/run":                        {Summary: "Run an orphan sweep now", Data: domain.StorageOrphanSweep{}},
+	"POST /v1/admin/maintenance/:task":                         {Summary: "Start a maintenance task", Data: domain.MaintenanceRun{}, Status: http.StatusAccepted},
+	"GET /v1/admin/maintenance/tasks":                          {Summary: "List maintenance tasks", Data: []domain.MaintenanceTaskInfo{}},
+	"GET /v1/admin/maintenance/runs":                           {Summary: "List maintenance runs", Query: maintenanceRunQuery{}, Data: []domain.MaintenanceRun{}},
+	"GET /v1/admin/maintenance/runs/:id":                       {Summary: "Get a maintenance run", Data: domain.MaintenanceRun{}},
+	"GET /v1/admin/warehouse/exports":                          {Summary: "List warehouse export status per table", Data: []domain.WarehouseExportState{}},
+	"POST /v1/admin/warehouse/exports/run":                     {Summary: "Run the warehouse export now", Data: domain.WarehouseExportRun{}},
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PhoneVerificationHandler struct {
+	phoneUC domain.PhoneVerificationUsecase
+}
+
+// NewPhoneVerificationHandler registers candidate phone OTP routes
+func NewPhoneVerificationHandler(protected *gin.RouterGroup, phoneUC domain.PhoneVerificationUsecase) {
+	handler := &PhoneVerificationHandler{phoneUC: phoneUC}
+
+	phone := protected.Group("/candidates/me/phone")
+	{
+		phone.GET("", handler.GetStatus)
+		phone.POST("/otp", handler.SendOTP)
+		phone.POST("/verify", handler.Verify)
+	}
+}
+
+// GetStatus godoc
+// @Summary      Get phone verification status
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.PhoneVerificationStatus}
+// @Router       /candidates/me/phone [get]
+func (h *PhoneVerificationHandler) GetStatus(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	status, err := h.phoneUC.GetStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Phone verification status", status)
+}
+
+// SendOTP godoc
+// @Summary      Send phone verification code
+// @Description  Sends a 6-digit OTP via SMS to the phone number on the candidate profile
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.PhoneVerificationStatus}
+// @Failure      400  {object}  response.Response
+// @Failure      429  {object}  response.Response
+// @Router       /candidates/me/phone/otp [post]
+func (h *PhoneVerificationHandler) SendOTP(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	status, err := h.phoneUC.SendOTP(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Kode verifikasi telah dikirim", status)
+}
+
+// Verify godoc
+// @Summary      Verify phone with OTP
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      domain.VerifyPhoneRequest  true  "6-digit code"
+// @Success      200   {object}  response.Response{data=domain.PhoneVerificationStatus}
+// @Failure      400   {object}  response.Response
+// @Failure      429   {object}  response.Response
+// @Router       /candidates/me/phone/verify [post]
+func (h *PhoneVerificationHandler) Verify(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	var req domain.VerifyPhoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	status, err := h.phoneUC.VerifyOTP(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Nomor telepon berhasil diverifikasi", status)
+}
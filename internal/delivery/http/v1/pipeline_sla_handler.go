@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PipelineSLAHandler struct {
+	slaUC domain.PipelineSLAUsecase
+}
+
+// NewPipelineSLAHandler registers employer pipeline SLA routes and the admin
+// chronic breach report
+func NewPipelineSLAHandler(protected *gin.RouterGroup, slaUC domain.PipelineSLAUsecase) {
+	handler := &PipelineSLAHandler{slaUC: slaUC}
+
+	// Employer: per-stage thresholds and breaches
+	employer := protected.Group("/employers/me/pipeline-sla")
+	{
+		employer.GET("", handler.GetSettings)
+		employer.PUT("", handler.UpdateSettings)
+		employer.GET("/breaches", handler.ListBreaches)
+	}
+
+	// Admin: companies with chronic breaches
+	protected.GET("/admin/pipeline-sla/report", handler.GetChronicBreachReport)
+}
+
+// GetSettings godoc
+// @Summary      Get my company's pipeline SLA thresholds
+// @Description  Maximum days an application may stay in each stage; stages not configured use the platform default
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.PipelineSLASettings}
+// @Failure      403  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /employers/me/pipeline-sla [get]
+func (h *PipelineSLAHandler) GetSettings(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	settings, err := h.slaUC.GetSettings(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Pipeline SLA retrieved", settings)
+}
+
+// UpdateSettings godoc
+// @Summary      Set my company's pipeline SLA thresholds
+// @Description  Stages left out keep their current threshold; max_days 0 turns the SLA off for a stage
+// @Tags         employers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      domain.UpdatePipelineSLARequest  true  "Thresholds"
+// @Success      200   {object}  response.Response{data=domain.PipelineSLASettings}
+// @Failure      400   {object}  response.Response
+// @Failure      403   {object}  response.Response
+// @Router       /employers/me/pipeline-sla [put]
+func (h *PipelineSLAHandler) UpdateSettings(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	var req domain.UpdatePipelineSLARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	settings, err := h.slaUC.UpdateSettings(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Pipeline SLA updated", settings)
+}
+
+// ListBreaches godoc
+// @Summary      List my company's pipeline SLA breaches
+// @Description  Applications that stayed in a stage past the threshold, newest first. A breach is resolved once the application moves on.
+// @Tags         employers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false  "OPEN or RESOLVED"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.PipelineSLABreach]}
+// @Failure      400       {object}  response.Response
+// @Failure      403       {object}  response.Response
+// @Router       /employers/me/pipeline-sla/breaches [get]
+func (h *PipelineSLAHandler) ListBreaches(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+	filter := domain.PipelineSLABreachFilter{Status: c.Query("status")}
+	filter.Page, _ = strconv.Atoi(c.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.slaUC.ListBreaches(c.Request.Context(), userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Pipeline SLA breaches retrieved", result)
+}
+
+// GetChronicBreachReport godoc
+// @Summary      Companies with chronic pipeline SLA breaches
+// @Description  Companies with at least min_breaches breaches in the period, lowest candidate experience score first. The score is the percentage of the company's applications that never waited past an SLA.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        days          query     int  false  "Report period in days (default 90, max 365)"
+// @Param        min_breaches  query     int  false  "Minimum breaches in the period (default 5)"
+// @Success      200           {object}  response.Response{data=[]domain.PipelineSLACompanyReport}
+// @Failure      400           {object}  response.Response
+// @Failure      403           {object}  response.Response
+// @Router       /admin/pipeline-sla/report [get]
+func (h *PipelineSLAHandler) GetChronicBreachReport(c *gin.Context) {
+	var filter domain.PipelineSLAReportFilter
+	if raw := c.Query("days"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid days"))
+			return
+		}
+		filter.Days = days
+	}
+	if raw := c.Query("min_breaches"); raw != "" {
+		minBreaches, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid min_breaches"))
+			return
+		}
+		filter.MinBreaches = minBreaches
+	}
+
+	report, err := h.slaUC.GetChronicBreachReport(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Pipeline SLA report generated", report)
+}
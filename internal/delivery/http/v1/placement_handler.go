@@ -0,0 +1,228 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlacementHandler struct {
+	placementUC domain.PlacementUsecase
+}
+
+// NewPlacementHandler registers the admin placement records, their check-in
+// task list and placement statistics
+func NewPlacementHandler(protected *gin.RouterGroup, placementUC domain.PlacementUsecase) {
+	handler := &PlacementHandler{placementUC: placementUC}
+
+	group := protected.Group("/admin/placements")
+	group.GET("", handler.ListPlacements)
+	group.POST("", handler.CreatePlacement)
+	group.GET("/check-ins", handler.ListCheckInTasks)
+	group.GET("/stats", handler.GetStats)
+	group.GET("/:id", handler.GetPlacement)
+	group.PUT("/:id/end", handler.EndPlacement)
+	group.PUT("/:id/check-ins/:months", handler.CompleteCheckIn)
+}
+
+// ListPlacements godoc
+// @Summary      List placements
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status      query     string  false  "ACTIVE, COMPLETED or ENDED_EARLY"
+// @Param        company_id  query     int     false  "Filter by company"
+// @Param        page        query     int     false  "Page number"  default(1)
+// @Param        pageSize    query     int     false  "Page size"    default(20)
+// @Success      200         {object}  response.Response{data=domain.PaginatedResult[domain.Placement]}
+// @Failure      400         {object}  response.Response
+// @Router       /admin/placements [get]
+func (h *PlacementHandler) ListPlacements(c *gin.Context) {
+	filter := domain.PlacementFilter{Status: c.Query("status")}
+	if raw := c.Query("company_id"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid company ID"))
+			return
+		}
+		filter.CompanyID = &v
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.placementUC.ListPlacements(c.Request.Context(), filter, page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Placements retrieved", result)
+}
+
+// CreatePlacement godoc
+// @Summary      Record a placement
+// @Description  Records where a hired candidate (accepted application) starts working and schedules the 3, 6 and 12-month check-ins
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body      domain.CreatePlacementRequest  true  "Placement"
+// @Success      201   {object}  response.Response{data=domain.Placement}
+// @Failure      400   {object}  response.Response
+// @Failure      404   {object}  response.Response
+// @Failure      409   {object}  response.Response
+// @Router       /admin/placements [post]
+func (h *PlacementHandler) CreatePlacement(c *gin.Context) {
+	var req domain.CreatePlacementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	placement, err := h.placementUC.CreatePlacement(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Placement created", placement)
+}
+
+// GetPlacement godoc
+// @Summary      Get a placement with its check-ins
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Placement ID"
+// @Success      200  {object}  response.Response{data=domain.Placement}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/placements/{id} [get]
+func (h *PlacementHandler) GetPlacement(c *gin.Context) {
+	id, ok := parsePlacementID(c)
+	if !ok {
+		return
+	}
+
+	placement, err := h.placementUC.GetPlacement(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Placement retrieved", placement)
+}
+
+// EndPlacement godoc
+// @Summary      End a placement
+// @Description  COMPLETED when the contract was served, ENDED_EARLY otherwise. Check-ins due after ended_on are skipped; earlier ones stay on the task list.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                         true  "Placement ID"
+// @Param        body  body      domain.EndPlacementRequest  true  "End"
+// @Success      200   {object}  response.Response{data=domain.Placement}
+// @Failure      400   {object}  response.Response
+// @Failure      404   {object}  response.Response
+// @Failure      409   {object}  response.Response
+// @Router       /admin/placements/{id}/end [put]
+func (h *PlacementHandler) EndPlacement(c *gin.Context) {
+	id, ok := parsePlacementID(c)
+	if !ok {
+		return
+	}
+	var req domain.EndPlacementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	placement, err := h.placementUC.EndPlacement(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Placement ended", placement)
+}
+
+// CompleteCheckIn godoc
+// @Summary      Record a post-placement check-in
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      int                            true  "Placement ID"
+// @Param        months  path      int                            true  "Milestone: 3, 6 or 12"
+// @Param        body    body      domain.CompleteCheckInRequest  true  "Outcome"
+// @Success      200     {object}  response.Response{data=domain.Placement}
+// @Failure      400     {object}  response.Response
+// @Failure      404     {object}  response.Response
+// @Failure      409     {object}  response.Response
+// @Router       /admin/placements/{id}/check-ins/{months} [put]
+func (h *PlacementHandler) CompleteCheckIn(c *gin.Context) {
+	id, ok := parsePlacementID(c)
+	if !ok {
+		return
+	}
+	months, err := strconv.Atoi(c.Param("months"))
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid milestone"))
+		return
+	}
+	var req domain.CompleteCheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	placement, err := h.placementUC.CompleteCheckIn(c.Request.Context(), id, months, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Check-in recorded", placement)
+}
+
+// ListCheckInTasks godoc
+// @Summary      Post-placement check-ins to do
+// @Description  Pending check-ins due within 14 days, and overdue ones, oldest first
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.PlacementCheckInTask}
+// @Router       /admin/placements/check-ins [get]
+func (h *PlacementHandler) ListCheckInTasks(c *gin.Context) {
+	tasks, err := h.placementUC.ListCheckInTasks(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Check-in tasks retrieved", tasks)
+}
+
+// GetStats godoc
+// @Summary      Placement statistics
+// @Description  Placements by status, check-ins due and overdue, and retention at each milestone
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.PlacementStats}
+// @Router       /admin/placements/stats [get]
+func (h *PlacementHandler) GetStats(c *gin.Context) {
+	stats, err := h.placementUC.GetStats(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Placement statistics retrieved", stats)
+}
+
+func parsePlacementID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid placement ID"))
+		return 0, false
+	}
+	return id, true
+}
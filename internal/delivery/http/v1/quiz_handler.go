@@ -0,0 +1,238 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuizHandler struct {
+	quizUC domain.QuizUsecase
+}
+
+// NewQuizHandler registers admin quiz authoring and candidate quiz taking
+func NewQuizHandler(protected *gin.RouterGroup, quizUC domain.QuizUsecase) {
+	handler := &QuizHandler{quizUC: quizUC}
+
+	// Admin: author quizzes
+	admin := protected.Group("/admin/quizzes")
+	{
+		admin.GET("", handler.ListQuizzes)
+		admin.POST("", handler.CreateQuiz)
+		admin.GET("/:id", handler.GetQuiz)
+		admin.PUT("/:id", handler.UpdateQuiz)
+	}
+
+	// Candidate: take quizzes and view own scores
+	candidate := protected.Group("/candidates/me")
+	{
+		candidate.GET("/quizzes", handler.ListAvailableQuizzes)
+		candidate.POST("/quizzes/:id/attempts", handler.StartAttempt)
+		candidate.POST("/quiz-attempts/:attemptId/submit", handler.SubmitAttempt)
+		candidate.GET("/quiz-scores", handler.GetMyScores)
+	}
+}
+
+// ListQuizzes godoc
+// @Summary      List skill quizzes
+// @Tags         admin-quizzes
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.SkillQuiz}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/quizzes [get]
+func (h *QuizHandler) ListQuizzes(c *gin.Context) {
+	quizzes, err := h.quizUC.ListQuizzes(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Quizzes retrieved", quizzes)
+}
+
+// CreateQuiz godoc
+// @Summary      Create a skill quiz
+// @Tags         admin-quizzes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.QuizRequest  true  "Quiz with questions"
+// @Success      201      {object}  response.Response{data=domain.SkillQuiz}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /admin/quizzes [post]
+func (h *QuizHandler) CreateQuiz(c *gin.Context) {
+	var req domain.QuizRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	quiz, err := h.quizUC.CreateQuiz(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Quiz created", quiz)
+}
+
+// GetQuiz godoc
+// @Summary      Get a skill quiz with its answer key
+// @Tags         admin-quizzes
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Quiz ID"
+// @Success      200  {object}  response.Response{data=domain.SkillQuiz}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/quizzes/{id} [get]
+func (h *QuizHandler) GetQuiz(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid quiz ID"))
+		return
+	}
+
+	quiz, err := h.quizUC.GetQuiz(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Quiz retrieved", quiz)
+}
+
+// UpdateQuiz godoc
+// @Summary      Replace a skill quiz
+// @Description  Replaces settings and questions; earned best scores are kept
+// @Tags         admin-quizzes
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                 true  "Quiz ID"
+// @Param        request  body      domain.QuizRequest  true  "Quiz with questions"
+// @Success      200      {object}  response.Response{data=domain.SkillQuiz}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /admin/quizzes/{id} [put]
+func (h *QuizHandler) UpdateQuiz(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid quiz ID"))
+		return
+	}
+
+	var req domain.QuizRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	quiz, err := h.quizUC.UpdateQuiz(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Quiz updated", quiz)
+}
+
+// ListAvailableQuizzes godoc
+// @Summary      List available skill quizzes
+// @Description  Published quizzes with the candidate's best score and retake availability
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.CandidateQuizOverview}
+// @Router       /candidates/me/quizzes [get]
+func (h *QuizHandler) ListAvailableQuizzes(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	quizzes, err := h.quizUC.ListAvailableQuizzes(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Quizzes retrieved", quizzes)
+}
+
+// StartAttempt godoc
+// @Summary      Start or resume a quiz attempt
+// @Description  Starts the timer; an unexpired open attempt is resumed instead
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Quiz ID"
+// @Success      200  {object}  response.Response{data=domain.QuizAttemptSession}
+// @Failure      404  {object}  response.Response
+// @Failure      429  {object}  response.Response
+// @Router       /candidates/me/quizzes/{id}/attempts [post]
+func (h *QuizHandler) StartAttempt(c *gin.Context) {
+	quizID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid quiz ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	session, err := h.quizUC.StartAttempt(c.Request.Context(), userID, quizID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Quiz attempt started", session)
+}
+
+// SubmitAttempt godoc
+// @Summary      Submit a quiz attempt
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        attemptId  path      int                       true  "Attempt ID"
+// @Param        request    body      domain.SubmitQuizRequest  true  "Answers"
+// @Success      200        {object}  response.Response{data=domain.QuizAttempt}
+// @Failure      400        {object}  response.Response
+// @Failure      409        {object}  response.Response
+// @Router       /candidates/me/quiz-attempts/{attemptId}/submit [post]
+func (h *QuizHandler) SubmitAttempt(c *gin.Context) {
+	attemptID, err := strconv.ParseInt(c.Param("attemptId"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid attempt ID"))
+		return
+	}
+
+	var req domain.SubmitQuizRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	attempt, err := h.quizUC.SubmitAttempt(c.Request.Context(), userID, attemptID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Quiz submitted", attempt)
+}
+
+// GetMyScores godoc
+// @Summary      Get own quiz scores
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.CandidateQuizScore}
+// @Router       /candidates/me/quiz-scores [get]
+func (h *QuizHandler) GetMyScores(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	scores, err := h.quizUC.GetMyScores(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Quiz scores retrieved", scores)
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/realtime"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RealtimeHandler struct {
+	hub *realtime.Hub
+}
+
+// NewRealtimeHandler registers the WebSocket event stream route
+func NewRealtimeHandler(protected *gin.RouterGroup, hub *realtime.Hub) {
+	handler := &RealtimeHandler{hub: hub}
+
+	protected.GET("/ws", handler.Connect)
+}
+
+// Connect godoc
+// @Summary      Open the realtime event stream
+// @Description  Upgrades to a WebSocket that pushes JSON events ({type, data, occurred_at}) for the current user: application.status_changed, application.received, verification.decided and job.match. Browsers authenticate with the auth_token cookie. Events are not replayed; refetch state after reconnecting.
+// @Tags         realtime
+// @Security     BearerAuth
+// @Success      101
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Router       /ws [get]
+func (h *RealtimeHandler) Connect(c *gin.Context) {
+	// Browsers send cookies on cross-site WebSocket handshakes and CORS does not
+	// apply to them, so only accept origins the CORS middleware allowed
+	if origin := c.GetHeader("Origin"); origin != "" && c.Writer.Header().Get("Access-Control-Allow-Origin") != origin {
+		c.Error(apperror.Forbidden("Origin not allowed"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	err := h.hub.Serve(c.Writer, c.Request, userID)
+	if errors.Is(err, realtime.ErrBadHandshake) {
+		c.Error(apperror.BadRequest("WebSocket upgrade required"))
+		return
+	}
+	if err != nil {
+		// The connection is hijacked by now; there is no response to write
+		logger.FromContext(c.Request.Context()).Warn("WebSocket connection closed with error", "error", err)
+	}
+}
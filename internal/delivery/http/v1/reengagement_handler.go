@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReengagementHandler struct {
+	reengagementUC domain.ReengagementUsecase
+}
+
+// NewReengagementHandler registers candidate nudge preferences and admin campaign reporting
+func NewReengagementHandler(protected *gin.RouterGroup, reengagementUC domain.ReengagementUsecase) {
+	handler := &ReengagementHandler{reengagementUC: reengagementUC}
+
+	// Candidate: opt out of / back into re-engagement reminders
+	candidate := protected.Group("/candidates/me/reengagement")
+	{
+		candidate.GET("", handler.GetPreference)
+		candidate.PUT("", handler.UpdatePreference)
+	}
+
+	// Admin: campaign effectiveness and manual runs
+	admin := protected.Group("/admin/reengagement")
+	{
+		admin.GET("/report", handler.GetReport)
+		admin.POST("/run", handler.TriggerRun)
+	}
+}
+
+// GetPreference godoc
+// @Summary      Get re-engagement reminder preference
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.ReengagementPreference}
+// @Router       /candidates/me/reengagement [get]
+func (h *ReengagementHandler) GetPreference(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	pref, err := h.reengagementUC.GetPreference(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Re-engagement preference retrieved", pref)
+}
+
+// UpdatePreference godoc
+// @Summary      Opt out of (or back into) re-engagement reminders
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.UpdateReengagementPreferenceRequest  true  "Preference"
+// @Success      200      {object}  response.Response{data=domain.ReengagementPreference}
+// @Failure      400      {object}  response.Response
+// @Router       /candidates/me/reengagement [put]
+func (h *ReengagementHandler) UpdatePreference(c *gin.Context) {
+	var req domain.UpdateReengagementPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	pref, err := h.reengagementUC.UpdatePreference(c.Request.Context(), userID, *req.OptOut)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Re-engagement preference updated", pref)
+}
+
+// GetReport godoc
+// @Summary      Re-engagement campaign report
+// @Description  Sent, failed, returned and converted nudges per campaign for nudges sent in the date range
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from  query     string  false  "First day (YYYY-MM-DD), defaults to 29 days before 'to'"
+// @Param        to    query     string  false  "Last day (YYYY-MM-DD), defaults to today"
+// @Success      200   {object}  response.Response{data=domain.ReengagementReport}
+// @Failure      400   {object}  response.Response
+// @Failure      403   {object}  response.Response
+// @Router       /admin/reengagement/report [get]
+func (h *ReengagementHandler) GetReport(c *gin.Context) {
+	report, err := h.reengagementUC.GetReport(c.Request.Context(), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Re-engagement report generated", report)
+}
+
+// TriggerRun godoc
+// @Summary      Run re-engagement campaigns now
+// @Description  Runs the same pass as the background worker; frequency caps and opt-outs still apply
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.ReengagementRunResult}
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/reengagement/run [post]
+func (h *ReengagementHandler) TriggerRun(c *gin.Context) {
+	result, err := h.reengagementUC.TriggerRun(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Re-engagement run completed", result)
+}
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RetentionHandler struct {
+	retentionUC domain.RetentionUsecase
+}
+
+// NewRetentionHandler registers admin routes for data retention policies, dry runs and enforcement
+func NewRetentionHandler(protected *gin.RouterGroup, retentionUC domain.RetentionUsecase) {
+	handler := &RetentionHandler{retentionUC: retentionUC}
+
+	admin := protected.Group("/admin/retention")
+	{
+		admin.GET("/policies", handler.ListPolicies)
+		admin.PUT("/policies/:entity", handler.UpdatePolicy)
+		admin.POST("/dry-run", handler.DryRun)
+		admin.POST("/enforce", handler.Enforce)
+		admin.GET("/runs", handler.ListRuns)
+		admin.GET("/runs/:id", handler.GetRun)
+	}
+}
+
+// ListPolicies godoc
+// @Summary      List data retention policies
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.RetentionPolicy}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/retention/policies [get]
+func (h *RetentionHandler) ListPolicies(c *gin.Context) {
+	policies, err := h.retentionUC.ListPolicies(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Retention policies retrieved", policies)
+}
+
+// UpdatePolicy godoc
+// @Summary      Update a data retention policy
+// @Description  Changes how long a data class is kept and what enforcement does with expired records. Enforcement needs a new dry run afterwards.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        entity   path      string                               true  "Data class (applications, rejected_verifications)"
+// @Param        request  body      domain.UpdateRetentionPolicyRequest  true  "Retention rule"
+// @Success      200  {object}  response.Response{data=domain.RetentionPolicy}
+// @Failure      400  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/retention/policies/{entity} [put]
+func (h *RetentionHandler) UpdatePolicy(c *gin.Context) {
+	var req domain.UpdateRetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	adminID := c.GetString(string(domain.KeyUserID))
+	policy, err := h.retentionUC.UpdatePolicy(c.Request.Context(), adminID, c.Param("entity"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Retention policy updated", policy)
+}
+
+// DryRun godoc
+// @Summary      Report what retention enforcement would change
+// @Description  Counts the expired records of every policy without changing them
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.RetentionRun}
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/retention/dry-run [post]
+func (h *RetentionHandler) DryRun(c *gin.Context) {
+	adminID := c.GetString(string(domain.KeyUserID))
+	run, err := h.retentionUC.DryRun(c.Request.Context(), adminID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Retention dry run finished", run)
+}
+
+// Enforce godoc
+// @Summary      Enforce the data retention policies now
+// @Description  Anonymizes or deletes expired records of the enabled policies. Requires a dry run of the current policies from the last 24 hours.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.RetentionRun}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/retention/enforce [post]
+func (h *RetentionHandler) Enforce(c *gin.Context) {
+	adminID := c.GetString(string(domain.KeyUserID))
+	run, err := h.retentionUC.Enforce(c.Request.Context(), adminID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Retention enforcement finished", run)
+}
+
+// ListRuns godoc
+// @Summary      List retention runs
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.RetentionRun}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/retention/runs [get]
+func (h *RetentionHandler) ListRuns(c *gin.Context) {
+	runs, err := h.retentionUC.ListRuns(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Retention runs retrieved", runs)
+}
+
+// GetRun godoc
+// @Summary      Get a retention run's report
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Run ID"
+// @Success      200  {object}  response.Response{data=domain.RetentionRun}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/retention/runs/{id} [get]
+func (h *RetentionHandler) GetRun(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid run ID"))
+		return
+	}
+
+	run, err := h.retentionUC.GetRun(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Retention run retrieved", run)
+}
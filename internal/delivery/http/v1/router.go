@@ -4,11 +4,14 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"go-recruitment-backend/config"
+	"go-recruitment-backend/internal/delivery/graph"
 	"go-recruitment-backend/internal/delivery/http/middleware"
 	"go-recruitment-backend/internal/delivery/http/response"
 	securityHandler "go-recruitment-backend/internal/delivery/http/security"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/auth"
+	"go-recruitment-backend/pkg/chaos"
+	"go-recruitment-backend/pkg/realtime"
 	"go-recruitment-backend/pkg/security"
 	"net/http"
 	"os"
@@ -19,19 +22,71 @@ import (
 )
 
 type RouterDeps struct {
-	AuthUC           domain.AuthUsecase
-	JobUC            domain.JobUsecase
-	CandidateUC      domain.CandidateUsecase
-	ApplicationUC    domain.ApplicationUsecase    // Added for application endpoints
-	AdminUC          domain.AdminUsecase          // Added for admin endpoints
-	VerificationUC   domain.VerificationUsecase   // Added for verification endpoints
-	CompanyProfileUC domain.CompanyProfileUsecase // Added for company profile endpoints
-	ContactUC        domain.ContactUsecase        // Added for contact form
-	OnboardingUC     domain.OnboardingUsecase     // Added for onboarding wizard
-	ATSUC            domain.ATSUsecase            // Added for ATS (Applicant Tracking System)
-	LoginTracker     *security.LoginTracker       // Security: Login blocking
-	JWKSProvider     *auth.Provider
-	Config           *config.Config
+	AuthUC                domain.AuthUsecase
+	JobUC                 domain.JobUsecase
+	CandidateUC           domain.CandidateUsecase
+	ApplicationUC         domain.ApplicationUsecase         // Added for application endpoints
+	ApplicationDraftUC    domain.ApplicationDraftUsecase    // Added for unsubmitted application drafts
+	AdminUC               domain.AdminUsecase               // Added for admin endpoints
+	VerificationUC        domain.VerificationUsecase        // Added for verification endpoints
+	CompanyProfileUC      domain.CompanyProfileUsecase      // Added for company profile endpoints
+	ContactUC             domain.ContactUsecase             // Added for contact form
+	OnboardingUC          domain.OnboardingUsecase          // Added for onboarding wizard
+	ATSUC                 domain.ATSUsecase                 // Added for ATS (Applicant Tracking System)
+	LPKPartnerUC          domain.LPKPartnerUsecase          // Added for LPK partner portal
+	CohortUC              domain.CohortUsecase              // Added for LPK training cohorts
+	UploadedFileUC        domain.UploadedFileUsecase        // Added for upload processing status
+	PhoneVerificationUC   domain.PhoneVerificationUsecase   // Added for candidate phone OTP
+	ContactCreditUC       domain.ContactCreditUsecase       // Added for contact reveal credits
+	FinanceReportUC       domain.FinanceReportUsecase       // Added for admin financial reporting
+	HolidayCalendarUC     domain.HolidayCalendarUsecase     // Added for holiday calendar / business days
+	ReengagementUC        domain.ReengagementUsecase        // Added for re-engagement campaigns
+	CareerPageUC          domain.CareerPageUsecase          // Added for branded company career pages
+	QuizUC                domain.QuizUsecase                // Added for skill quizzes
+	AggregateUC           domain.AggregateUsecase           // Added for derived aggregate recompute
+	RetentionUC           domain.RetentionUsecase           // Added for data retention policies
+	CompanyMergeUC        domain.CompanyMergeUsecase        // Added for admin company merges
+	KillSwitchUC          domain.KillSwitchUsecase          // Added for maintenance windows / kill switches
+	DocumentExpiryUC      domain.DocumentExpiryUsecase      // Added for document expiry reminders
+	CompanyUsageUC        domain.CompanyUsageUsecase        // Added for the employer usage dashboard
+	CandidateResumeUC     domain.CandidateResumeUsecase     // Added for redacted/full resume PDFs
+	AdminSearchUC         domain.AdminSearchUsecase         // Added for admin global search
+	SavedSearchUC         domain.SavedSearchUsecase         // Added for candidate saved searches + job alerts
+	InterviewFeedbackUC   domain.InterviewFeedbackUsecase   // Added for interview feedback to rejected candidates
+	EmployerMessageUC     domain.EmployerMessageUsecase     // Added for employer bulk messaging to shortlisted candidates
+	CVParseUC             domain.CVParseUsecase             // Added for CV parsing + profile prefill
+	ApplicationStageUC    domain.ApplicationStageUsecase    // Added for the application stage pipeline
+	WarehouseUC           domain.WarehouseUsecase           // Added for the data warehouse export
+	ApplicationInsightsUC domain.ApplicationInsightsUsecase // Added for candidate application insights
+	WebhookUC             domain.WebhookUsecase             // Added for admin webhook endpoints + delivery history
+	StorageCleanupUC      domain.StorageCleanupUsecase      // Added for storage deletion queue + orphan sweep
+	MaintenanceUC         domain.MaintenanceUsecase         // Added for admin-triggered maintenance tasks
+	CandidateDocumentUC   domain.CandidateDocumentUsecase   // Added for the candidate document vault
+	ScreeningCallUC       domain.ScreeningCallUsecase       // Added for screening call booking
+	CandidateActivityUC   domain.CandidateActivityUsecase   // Added for the candidate activity feed
+	CompanyVerificationUC domain.CompanyVerificationUsecase // Added for company verification levels
+	PipelineSLAUC         domain.PipelineSLAUsecase         // Added for hiring pipeline SLA alerts
+	DuplicateDocumentUC   domain.DuplicateDocumentUsecase   // Added for duplicate document detection
+	InviteCodeUC          domain.InviteCodeUsecase          // Added for invite-only registration
+	EmailValidationUC     domain.EmailValidationUsecase     // Added for email deliverability checks
+	AdminMFAUC            domain.AdminMFAUsecase            // Added for admin two-factor authentication
+	JobQueueUC            domain.JobQueueUsecase            // Added for the background job queue
+	CandidatePortfolioUC  domain.CandidatePortfolioUsecase  // Added for candidate work-sample portfolios
+	LPKIntegrationUC      domain.LPKIntegrationUsecase      // Added for LPK partner placement feed
+	CompanyLocationUC     domain.CompanyLocationUsecase     // Added for company office locations
+	CompanyMemberUC       domain.CompanyMemberUsecase       // Added for company teams (invited recruiters + viewers)
+	AdminScopeUC          domain.AdminScopeUsecase          // Added for regional admin scopes
+	EmailTrackingUC       domain.EmailTrackingUsecase       // Added for email open/click tracking
+	SupabaseWebhookUC     domain.SupabaseWebhookUsecase     // Added for Supabase auth webhooks
+	PlacementUC           domain.PlacementUsecase           // Added for placement records + alumni tracking
+	NotificationUC        domain.NotificationUsecase        // Added for the in-app notification center
+	ATSSavedFilterUC      domain.ATSSavedFilterUsecase      // Added for saved + shared ATS filters
+	RealtimeHub           *realtime.Hub                     // Added for WebSocket event push
+	ChaosInjector         *chaos.Injector                   // Added for fault injection (nil unless enabled)
+	LoginTracker          *security.LoginTracker            // Security: Login blocking
+	JWKSProvider          *auth.Provider
+	RefreshService        *auth.RefreshService // Added for refresh token rotation
+	Config                *config.Config
 	// Security Dashboard dependencies
 	SecurityDashboardUC domain.SecurityDashboardUsecase
 	SecurityAuthService *security.SecurityAuthService
@@ -43,12 +98,30 @@ func NewRouter(deps RouterDeps) *gin.Engine {
 	// Global Middlewares
 	r.Use(middleware.CORSMiddleware())            // CORS must be first!
 	r.Use(middleware.SecurityHeadersMiddleware()) // Security headers (HSTS, XSS, etc.)
+	if deps.Config.MetricsEnabled {
+		r.Use(middleware.Metrics()) // Request count + latency per route (before Recovery so panics count as 500)
+	}
+	r.Use(middleware.LocaleMiddleware())          // Response language (?lang= / Accept-Language)
 	r.Use(middleware.GlobalRateLimitMiddleware()) // Global rate limit: 100 req/min per IP
 	r.Use(middleware.CSRFMiddleware())            // CSRF protection (Double-Submit Cookie)
-	r.Use(gin.Recovery())
-	r.Use(gin.Logger()) // Use standard Gin logger
+	r.Use(middleware.BodyLimitMiddleware(middleware.DefaultBodyLimitConfig(
+		deps.Config.MaxJSONBodyBytes, deps.Config.MaxUploadBodyBytes,
+	))) // Body size + content type limits per route group
+	r.Use(middleware.Recovery()) // 500 for panics; panics and 5xx go to the error tracker when configured
 	r.Use(middleware.RequestID())
+	r.Use(middleware.Tracing())       // Server span per request when OTLP export is configured (after RequestID)
+	r.Use(middleware.RequestLogger()) // Structured access log (after RequestID)
 	r.Use(middleware.ErrorHandler())
+	r.Use(middleware.RateLimit(middleware.SensitiveRouteRateLimits(deps.Config))) // Per-IP token buckets: login, forgot password, contact, upload
+	r.Use(middleware.KillSwitchMiddleware(deps.KillSwitchUC))                     // 503 for endpoints/subsystems switched off by admins
+	if deps.ChaosInjector != nil {
+		r.Use(middleware.ChaosMiddleware(deps.ChaosInjector)) // Injected latency for resilience testing
+	}
+
+	// Prometheus scrape endpoint (outside /v1)
+	if deps.Config.MetricsEnabled {
+		NewMetricsHandler(r, deps.Config.MetricsToken)
+	}
 
 	v1 := r.Group("/v1")
 
@@ -59,6 +132,10 @@ func NewRouter(deps RouterDeps) *gin.Engine {
 
 	// Public routes
 	NewContactHandler(v1, deps.ContactUC) // Contact form (no auth required)
+	// Anchoring dead-man switch for external monitors (shared token, not a session)
+	NewAnchorHeartbeatHandler(v1, deps.SecurityDashboardUC, deps.Config.AnchorHeartbeatCheckToken)
+	// Supabase Auth account events (signed webhook, not a session)
+	NewSupabaseWebhookHandler(v1, deps.SupabaseWebhookUC)
 
 	// Swagger - ONLY available in development mode
 	// In production, this is disabled to prevent API enumeration
@@ -66,28 +143,102 @@ func NewRouter(deps RouterDeps) *gin.Engine {
 		v1.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
+	// OpenAPI 3 document for SDK generation (security dashboard routes are never listed)
+	secDashboardPath := generateSecurityDashboardPath()
+	if deps.Config.OpenAPIEnabled {
+		NewOpenAPIHandler(r, v1, "/v1/"+secDashboardPath)
+	}
+
 	// Protected routes
 	protected := v1.Group("")
-	protected.Use(middleware.AuthMiddleware(deps.JWKSProvider, deps.Config, deps.AuthUC))
+	var adminMFA domain.AdminMFAUsecase // nil leaves admin routes without the TOTP requirement
+	if deps.Config.AdminMFARequired {
+		adminMFA = deps.AdminMFAUC
+	}
+	protected.Use(middleware.AuthMiddleware(deps.JWKSProvider, deps.Config, deps.AuthUC, adminMFA, deps.RefreshService))
+	protected.Use(middleware.ProfileRefreshGate(deps.CandidateUC)) // 428 for candidates back after a long absence (before ActivityTracker)
+	protected.Use(middleware.ActivityTracker(deps.ReengagementUC)) // last_active_at for re-engagement
+	// Company roles: viewers are read-only, company settings are owner-only
+	protected.Use(middleware.CompanyRoleGate(deps.CompanyMemberUC))
+	// Regional admins: province scope for admin lists, other admin areas closed
+	protected.Use(middleware.AdminScope(deps.AdminScopeUC))
 	{
-		NewAuthHandler(v1, protected, deps.AuthUC, deps.OnboardingUC, deps.Config, deps.LoginTracker)
+		NewAuthHandler(v1, protected, deps.AuthUC, deps.OnboardingUC, deps.InviteCodeUC, deps.Config, deps.LoginTracker, deps.RefreshService)
 		NewJobHandler(v1, protected, deps.JobUC)
 		NewCandidateHandler(protected, deps.CandidateUC)
-		NewApplicationHandler(protected, deps.ApplicationUC)                                // Application routes
-		NewAdminHandler(protected, deps.AdminUC)                                            // Admin routes
-		NewVerificationHandler(protected, deps.VerificationUC)                              // Verification routes
-		NewCompanyProfileHandler(v1, protected, deps.CompanyProfileUC, deps.VerificationUC) // Company profile routes
-		NewOnboardingHandler(protected, deps.OnboardingUC)                                  // Onboarding wizard routes
-		NewATSHandler(protected, deps.ATSUC)                                                // ATS (Applicant Tracking System) routes
+		NewApplicationHandler(protected, deps.ApplicationUC)                                                                                         // Application routes
+		NewAdminHandler(protected, deps.AdminUC)                                                                                                     // Admin routes
+		NewVerificationHandler(protected, deps.VerificationUC, deps.UploadedFileUC, deps.CVParseUC, deps.StorageCleanupUC, deps.DuplicateDocumentUC) // Verification routes
+		NewFileHandler(protected, deps.UploadedFileUC)                                                                                               // File processing status routes
+		NewPhoneVerificationHandler(protected, deps.PhoneVerificationUC)                                                                             // Candidate phone OTP routes
+		NewCompanyProfileHandler(v1, protected, deps.CompanyProfileUC, deps.VerificationUC)                                                          // Company profile routes
+		NewOnboardingHandler(protected, deps.OnboardingUC)                                                                                           // Onboarding wizard routes
+		NewATSHandler(protected, deps.ATSUC, deps.ATSSavedFilterUC)                                                                                  // ATS (Applicant Tracking System) routes
+		NewLPKPartnerHandler(protected, deps.LPKPartnerUC)                                                                                           // LPK partner portal routes
+		NewCohortHandler(protected, deps.CohortUC)                                                                                                   // Admin + LPK portal training cohort routes
+		NewContactCreditHandler(protected, deps.ContactCreditUC)                                                                                     // Contact reveal credit routes
+		NewFinanceHandler(protected, deps.FinanceReportUC)                                                                                           // Admin financial reporting routes
+		NewHolidayCalendarHandler(protected, deps.HolidayCalendarUC)                                                                                 // Holiday calendar + scheduling routes
+		NewReengagementHandler(protected, deps.ReengagementUC)                                                                                       // Re-engagement opt-out + admin reporting routes
+		NewCareerPageHandler(v1, protected, deps.CareerPageUC)                                                                                       // Public career page + employer career page routes
+		NewQuizHandler(protected, deps.QuizUC)                                                                                                       // Admin quiz authoring + candidate quiz routes
+		NewAggregateHandler(protected, deps.AggregateUC)                                                                                             // Admin aggregate recompute + drift report routes
+		NewRetentionHandler(protected, deps.RetentionUC)                                                                                             // Admin data retention policy, dry run + enforcement routes
+		NewCompanyMergeHandler(protected, deps.CompanyMergeUC)                                                                                       // Admin duplicate company merge routes
+		NewKillSwitchHandler(protected, deps.KillSwitchUC)                                                                                           // Admin kill switch / maintenance window routes
+		NewDocumentExpiryHandler(protected, deps.DocumentExpiryUC)                                                                                   // Candidate document expiries + admin expiry report routes
+		NewCompanyUsageHandler(protected, deps.CompanyUsageUC)                                                                                       // Employer usage against plan quotas
+		NewCandidateResumeHandler(protected, deps.CandidateResumeUC)                                                                                 // Employer resume PDF (redacted until unlock)
+		NewAdminSearchHandler(protected, deps.AdminSearchUC)                                                                                         // Admin global search across entities
+		NewRealtimeHandler(protected, deps.RealtimeHub)                                                                                              // WebSocket event stream (GET /ws)
+		NewSavedSearchHandler(protected, deps.SavedSearchUC)                                                                                         // Candidate saved searches + admin job alert runs
+		NewInterviewFeedbackHandler(protected, deps.InterviewFeedbackUC)                                                                             // Employer feedback, candidate opt-out + admin review routes
+		NewEmployerMessageHandler(protected, deps.EmployerMessageUC)                                                                                 // Employer templates + bulk messages, candidate inbox + spam reports
+		NewCVParseHandler(protected, deps.CVParseUC)                                                                                                 // Candidate CV parse into a profile draft
+		NewApplicationStageHandler(protected, deps.ApplicationStageUC)                                                                               // Employer stage pipeline, history + funnels
+		NewWarehouseHandler(protected, deps.WarehouseUC)                                                                                             // Admin warehouse export status + manual run
+		NewApplicationInsightsHandler(protected, deps.ApplicationInsightsUC)                                                                         // Candidate application stats + hired-candidate benchmarks
+		NewWebhookHandler(protected, deps.WebhookUC)                                                                                                 // Admin webhook endpoints, delivery history + redelivery
+		NewStorageCleanupHandler(protected, deps.StorageCleanupUC)                                                                                   // Admin storage deletion queue, orphan sweeps + reclaimed space
+		NewMaintenanceHandler(protected, deps.MaintenanceUC)                                                                                         // Admin maintenance tasks (stats refresh, search reindex, aggregate recompute) + progress
+		NewApplicationDraftHandler(protected, deps.ApplicationDraftUC)                                                                               // Candidate application drafts (resume later)
+		NewCandidateDocumentHandler(protected, deps.CandidateDocumentUC)                                                                             // Candidate document vault + admin document review
+		NewScreeningCallHandler(protected, deps.ScreeningCallUC)                                                                                     // Candidate contact hours + screening call booking
+		NewCandidateActivityHandler(protected, deps.CandidateActivityUC)                                                                             // Candidate activity feed
+		NewCompanyVerificationHandler(protected, deps.CompanyVerificationUC)                                                                         // Employer verification level + admin level grants
+		NewPipelineSLAHandler(protected, deps.PipelineSLAUC)                                                                                         // Employer pipeline SLA thresholds + breaches, admin chronic breach report
+		NewDuplicateDocumentHandler(protected, deps.DuplicateDocumentUC)                                                                             // Admin review of document images reused across accounts
+		NewInviteCodeHandler(protected, deps.InviteCodeUC)                                                                                           // Admin invite codes for invite-only registration
+		NewEmailValidationHandler(protected, deps.EmailValidationUC)                                                                                 // Admin email deliverability report
+		NewAdminMFAHandler(protected, deps.AdminMFAUC)                                                                                               // Admin TOTP enrollment, verification and reset
+		NewJobQueueHandler(protected, deps.JobQueueUC)                                                                                               // Admin background job queue + dead letters
+		NewCandidatePortfolioHandler(protected, deps.CandidatePortfolioUC)                                                                           // Candidate work-sample portfolio + employer portfolio view
+		NewLPKIntegrationHandler(v1, protected, deps.LPKIntegrationUC)                                                                               // Partner placement API, candidate LPK sharing, LPK API keys + webhooks
+		NewCompanyLocationHandler(v1, protected, deps.CompanyLocationUC)                                                                             // Public company offices + employer office management
+		NewCompanyMemberHandler(protected, deps.CompanyMemberUC)                                                                                     // Company team, invitations + invitation acceptance
+		NewAdminScopeHandler(protected, deps.AdminScopeUC)                                                                                           // Regional admin scopes by province
+		NewEmailTrackingHandler(r, protected, deps.EmailTrackingUC)                                                                                  // Email open/click links (/t/:token), tracking preferences + admin engagement
+		NewPlacementHandler(protected, deps.PlacementUC)                                                                                             // Admin placement records, check-in tasks + placement stats
+		NewNotificationHandler(protected, deps.NotificationUC)                                                                                       // Notification center + admin announcements
+		NewATSSavedFilterHandler(protected, deps.ATSSavedFilterUC)                                                                                   // Admin saved + shared ATS filters
+		if deps.Config.GraphQLEnabled {
+			// Read-only GraphQL gateway over the job, company, candidate and application usecases
+			NewGraphQLHandler(protected, &graph.Resolver{
+				JobUC:            deps.JobUC,
+				CompanyProfileUC: deps.CompanyProfileUC,
+				CandidateUC:      deps.CandidateUC,
+				ApplicationUC:    deps.ApplicationUC,
+				VerificationUC:   deps.VerificationUC,
+			}, os.Getenv("GIN_MODE") != "release", deps.Config.GraphQLMaxComplexity)
+		}
 	}
 
 	// Security Dashboard - COMPLETELY ISOLATED authentication surface
 	// Uses non-discoverable path as NOISE LAYER (not security control)
 	// Real security: IP Allowlist → MFA → RBAC → Audit
 	if deps.SecurityDashboardUC != nil && deps.SecurityAuthService != nil {
-		secDashboardPath := generateSecurityDashboardPath()
 		secDashboard := v1.Group("/" + secDashboardPath)
-		handler := securityHandler.NewSecurityDashboardHandler(deps.SecurityDashboardUC, deps.SecurityAuthService)
+		handler := securityHandler.NewSecurityDashboardHandler(deps.SecurityDashboardUC, deps.SecurityAuthService, deps.Config.SecurityCookieSameSite)
 		handler.RegisterRoutes(secDashboard)
 	}
 
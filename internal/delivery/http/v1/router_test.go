@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"go-recruitment-backend/config"
+	"go-recruitment-backend/internal/domain"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const testJWTSecret = "router-test-secret"
+
+// Router tests run requests through NewRouter with the production middleware
+// chain. Usecases the middleware calls on every request are stubbed below; a
+// test sets the usecase its route needs on the RouterDeps it passes in.
+
+type stubAuthUC struct {
+	domain.AuthUsecase
+	roles map[string]string // user ID -> role
+}
+
+func (s stubAuthUC) GetCurrentUser(ctx context.Context, id string) (*domain.User, error) {
+	role, ok := s.roles[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.User{ID: id, Role: role}, nil
+}
+
+type stubKillSwitchUC struct{ domain.KillSwitchUsecase }
+
+func (stubKillSwitchUC) ActiveSwitch(key string) (*domain.KillSwitch, bool) { return nil, false }
+
+type stubCandidateUC struct{ domain.CandidateUsecase }
+
+func (stubCandidateUC) ProfileRefreshRequired(ctx context.Context, userID string) (bool, error) {
+	return false, nil
+}
+
+type stubReengagementUC struct{ domain.ReengagementUsecase }
+
+func (stubReengagementUC) RecordActivity(ctx context.Context, userID string) {}
+
+type stubCompanyMemberUC struct{ domain.CompanyMemberUsecase }
+
+func (stubCompanyMemberUC) MemberRole(ctx context.Context, userID string) (string, error) {
+	return domain.CompanyRoleOwner, nil
+}
+
+// newTestRouter builds the full router for users whose ID is their role. The
+// body limits and the token secret are set on the given config, if any.
+func newTestRouter(deps RouterDeps) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	if deps.Config == nil {
+		deps.Config = &config.Config{}
+	}
+	deps.Config.SupabaseJWTSecret = testJWTSecret
+	deps.Config.MaxJSONBodyBytes = 1 << 20
+	deps.Config.MaxUploadBodyBytes = 11 << 20
+	deps.AuthUC = stubAuthUC{roles: map[string]string{
+		domain.RoleCandidate: domain.RoleCandidate,
+		domain.RoleEmployer:  domain.RoleEmployer,
+	}}
+	deps.KillSwitchUC = stubKillSwitchUC{}
+	deps.CandidateUC = stubCandidateUC{}
+	deps.ReengagementUC = stubReengagementUC{}
+	deps.CompanyMemberUC = stubCompanyMemberUC{}
+	return NewRouter(deps)
+}
+
+// authorizedRequest signs a request as the test user with the given role
+func authorizedRequest(t *testing.T, method, path, role string, body io.Reader, contentType string) *http.Request {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": role,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
+
+// multipartFile builds a multipart body with one file part named "file"
+func multipartFile(t *testing.T, filename string, data []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return &buf, w.FormDataContentType()
+}
+
+func serveRouter(r *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
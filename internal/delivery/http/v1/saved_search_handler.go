@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedSearchHandler struct {
+	savedSearchUC domain.SavedSearchUsecase
+}
+
+// NewSavedSearchHandler registers candidate saved search and admin alert routes
+func NewSavedSearchHandler(protected *gin.RouterGroup, savedSearchUC domain.SavedSearchUsecase) {
+	handler := &SavedSearchHandler{savedSearchUC: savedSearchUC}
+
+	// Candidate: saved job searches with email alerts
+	searches := protected.Group("/candidates/me/saved-searches")
+	{
+		searches.GET("", handler.ListMySavedSearches)
+		searches.POST("", handler.CreateSavedSearch)
+		searches.PUT("/:id", handler.UpdateSavedSearch)
+		searches.DELETE("/:id", handler.DeleteSavedSearch)
+	}
+
+	// Admin: manual alert runs
+	protected.POST("/admin/saved-searches/run", handler.TriggerAlerts)
+}
+
+// ListMySavedSearches godoc
+// @Summary      List my saved searches
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.SavedSearch}
+// @Router       /candidates/me/saved-searches [get]
+func (h *SavedSearchHandler) ListMySavedSearches(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	searches, err := h.savedSearchUC.ListMySavedSearches(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved searches retrieved", searches)
+}
+
+// CreateSavedSearch godoc
+// @Summary      Save a job search
+// @Description  Alerts are on by default and only cover jobs posted after the search is saved
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.SavedSearchRequest  true  "Name, filter and alert settings"
+// @Success      201      {object}  response.Response{data=domain.SavedSearch}
+// @Failure      400      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /candidates/me/saved-searches [post]
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	var req domain.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	search, err := h.savedSearchUC.CreateSavedSearch(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Saved search created", search)
+}
+
+// UpdateSavedSearch godoc
+// @Summary      Replace a saved search
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                        true  "Saved search ID"
+// @Param        request  body      domain.SavedSearchRequest  true  "Name, filter and alert settings"
+// @Success      200      {object}  response.Response{data=domain.SavedSearch}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /candidates/me/saved-searches/{id} [put]
+func (h *SavedSearchHandler) UpdateSavedSearch(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid saved search ID"))
+		return
+	}
+
+	var req domain.SavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	search, err := h.savedSearchUC.UpdateSavedSearch(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved search updated", search)
+}
+
+// DeleteSavedSearch godoc
+// @Summary      Delete a saved search
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Saved search ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /candidates/me/saved-searches/{id} [delete]
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid saved search ID"))
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	if err := h.savedSearchUC.DeleteSavedSearch(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved search deleted", nil)
+}
+
+// TriggerAlerts godoc
+// @Summary      Send saved search job alerts now
+// @Description  Runs the same pass as the background worker; searches are still alerted at most once per frequency interval
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.SavedSearchRunResult}
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/saved-searches/run [post]
+func (h *SavedSearchHandler) TriggerAlerts(c *gin.Context) {
+	result, err := h.savedSearchUC.TriggerAlerts(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Saved search alerts sent", result)
+}
@@ -0,0 +1,307 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScreeningCallHandler struct {
+	callUC domain.ScreeningCallUsecase
+}
+
+// NewScreeningCallHandler registers candidate contact hours and screening call booking routes
+func NewScreeningCallHandler(protected *gin.RouterGroup, callUC domain.ScreeningCallUsecase) {
+	handler := &ScreeningCallHandler{callUC: callUC}
+
+	// Candidate: contact hours and own calls
+	me := protected.Group("/candidates/me")
+	{
+		me.GET("/contact-hours", handler.GetMyContactHours)
+		me.PUT("/contact-hours", handler.UpdateMyContactHours)
+		me.GET("/screening-calls", handler.ListMyCalls)
+		me.POST("/screening-calls/:id/cancel", handler.CancelMyCall)
+	}
+
+	// Admins and employers: booking
+	calls := protected.Group("/screening-calls")
+	{
+		calls.GET("/slots", handler.GetAvailability)
+		calls.POST("", handler.BookCall)
+		calls.GET("", handler.ListCalls)
+		calls.POST("/:id/cancel", handler.CancelCall)
+		calls.POST("/:id/outcome", handler.RecordOutcome)
+	}
+}
+
+// GetMyContactHours godoc
+// @Summary      Get my preferred contact hours
+// @Description  is_default is true while weekdays 09:00-17:00 Asia/Jakarta apply
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.CandidateContactHours}
+// @Failure      403  {object}  response.Response
+// @Router       /candidates/me/contact-hours [get]
+func (h *ScreeningCallHandler) GetMyContactHours(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	hours, err := h.callUC.GetMyContactHours(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Contact hours retrieved", hours)
+}
+
+// UpdateMyContactHours godoc
+// @Summary      Set my preferred contact hours
+// @Description  Weekly windows (ISO weekday, 1 = Monday) in an IANA time zone. Recruiters can only book calls within them.
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.ContactHoursRequest  true  "Time zone and windows"
+// @Success      200      {object}  response.Response{data=domain.CandidateContactHours}
+// @Failure      400      {object}  response.Response
+// @Router       /candidates/me/contact-hours [put]
+func (h *ScreeningCallHandler) UpdateMyContactHours(c *gin.Context) {
+	var req domain.ContactHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	hours, err := h.callUC.UpdateMyContactHours(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Contact hours updated", hours)
+}
+
+// ListMyCalls godoc
+// @Summary      List my screening calls
+// @Description  Newest first, all statuses
+// @Tags         candidates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.ScreeningCall}
+// @Failure      403  {object}  response.Response
+// @Router       /candidates/me/screening-calls [get]
+func (h *ScreeningCallHandler) ListMyCalls(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	calls, err := h.callUC.ListMyCalls(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Screening calls retrieved", calls)
+}
+
+// CancelMyCall godoc
+// @Summary      Cancel one of my screening calls
+// @Description  Whoever booked the call is notified
+// @Tags         candidates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                true   "Call ID"
+// @Param        request  body      domain.CancelScreeningCallRequest  false  "Optional reason"
+// @Success      200      {object}  response.Response{data=domain.ScreeningCall}
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /candidates/me/screening-calls/{id}/cancel [post]
+func (h *ScreeningCallHandler) CancelMyCall(c *gin.Context) {
+	id, req, ok := bindCancelScreeningCall(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	call, err := h.callUC.CancelMyCall(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Screening call cancelled", call)
+}
+
+// GetAvailability godoc
+// @Summary      Open call slots of a candidate
+// @Description  The candidate's contact hours over the next 14 days minus calls already booked. Employers pass application_id; admins may pass candidate_user_id instead.
+// @Tags         screening-calls
+// @Produce      json
+// @Security     BearerAuth
+// @Param        application_id     query     int     false  "Application ID"
+// @Param        candidate_user_id  query     string  false  "Candidate user ID (admins)"
+// @Success      200                {object}  response.Response{data=domain.ScreeningCallAvailability}
+// @Failure      400                {object}  response.Response
+// @Failure      404                {object}  response.Response
+// @Router       /screening-calls/slots [get]
+func (h *ScreeningCallHandler) GetAvailability(c *gin.Context) {
+	var applicationID int64
+	if raw := c.Query("application_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid application ID"))
+			return
+		}
+		applicationID = id
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	availability, err := h.callUC.GetAvailability(c.Request.Context(), userID, c.Query("candidate_user_id"), applicationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Availability retrieved", availability)
+}
+
+// BookCall godoc
+// @Summary      Book a screening call
+// @Description  The call must fit in one of the candidate's contact windows, start at least 15 minutes from now and not overlap another call. The candidate is notified and reminded before the call.
+// @Tags         screening-calls
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.BookScreeningCallRequest  true  "Candidate or application, time and duration"
+// @Success      201      {object}  response.Response{data=domain.ScreeningCall}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /screening-calls [post]
+func (h *ScreeningCallHandler) BookCall(c *gin.Context) {
+	var req domain.BookScreeningCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	call, err := h.callUC.BookCall(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Screening call booked", call)
+}
+
+// ListCalls godoc
+// @Summary      List screening calls
+// @Description  Employers see their company's calls; admins see all. upcoming=true lists confirmed calls from now on, soonest first.
+// @Tags         screening-calls
+// @Produce      json
+// @Security     BearerAuth
+// @Param        candidate_user_id  query     string  false  "Candidate user ID"
+// @Param        status             query     string  false  "CONFIRMED, CANCELLED, COMPLETED or NO_ANSWER"
+// @Param        upcoming           query     bool    false  "Only calls from now on"
+// @Param        limit              query     int     false  "Calls to return (default 50, max 100)"
+// @Success      200                {object}  response.Response{data=[]domain.ScreeningCall}
+// @Failure      400                {object}  response.Response
+// @Failure      403                {object}  response.Response
+// @Router       /screening-calls [get]
+func (h *ScreeningCallHandler) ListCalls(c *gin.Context) {
+	filter := domain.ScreeningCallFilter{
+		CandidateUserID: c.Query("candidate_user_id"),
+		Status:          c.Query("status"),
+		Upcoming:        c.Query("upcoming") == "true",
+	}
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	userID := c.GetString(string(domain.KeyUserID))
+	calls, err := h.callUC.ListCalls(c.Request.Context(), userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Screening calls retrieved", calls)
+}
+
+// CancelCall godoc
+// @Summary      Cancel a screening call
+// @Description  The candidate is notified
+// @Tags         screening-calls
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                true   "Call ID"
+// @Param        request  body      domain.CancelScreeningCallRequest  false  "Optional reason, shown to the candidate"
+// @Success      200      {object}  response.Response{data=domain.ScreeningCall}
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /screening-calls/{id}/cancel [post]
+func (h *ScreeningCallHandler) CancelCall(c *gin.Context) {
+	id, req, ok := bindCancelScreeningCall(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	call, err := h.callUC.CancelCall(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Screening call cancelled", call)
+}
+
+// RecordOutcome godoc
+// @Summary      Record a screening call outcome
+// @Description  Marks a confirmed call COMPLETED or NO_ANSWER once it has started
+// @Tags         screening-calls
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                                 true  "Call ID"
+// @Param        request  body      domain.ScreeningCallOutcomeRequest  true  "Outcome"
+// @Success      200      {object}  response.Response{data=domain.ScreeningCall}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Failure      409      {object}  response.Response
+// @Router       /screening-calls/{id}/outcome [post]
+func (h *ScreeningCallHandler) RecordOutcome(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid call ID"))
+		return
+	}
+
+	var req domain.ScreeningCallOutcomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	userID := c.GetString(string(domain.KeyUserID))
+	call, err := h.callUC.RecordOutcome(c.Request.Context(), userID, id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Screening call updated", call)
+}
+
+// bindCancelScreeningCall reads the call ID and the optional body
+func bindCancelScreeningCall(c *gin.Context) (int64, domain.CancelScreeningCallRequest, bool) {
+	var req domain.CancelScreeningCallRequest
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid call ID"))
+		return 0, req, false
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return 0, req, false
+		}
+	}
+	return id, req, true
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StorageCleanupHandler struct {
+	cleanupUC domain.StorageCleanupUsecase
+}
+
+// NewStorageCleanupHandler registers admin routes for the storage deletion queue and orphan sweeps
+func NewStorageCleanupHandler(protected *gin.RouterGroup, cleanupUC domain.StorageCleanupUsecase) {
+	handler := &StorageCleanupHandler{cleanupUC: cleanupUC}
+
+	admin := protected.Group("/admin/storage")
+	{
+		admin.GET("/cleanup", handler.GetSummary)
+		admin.GET("/deletions", handler.ListDeletions)
+		admin.POST("/deletions/:id/retry", handler.RetryDeletion)
+		admin.GET("/sweeps", handler.ListSweeps)
+		admin.POST("/sweeps/run", handler.RunSweep)
+	}
+}
+
+// GetSummary godoc
+// @Summary      Storage cleanup summary
+// @Description  Space reclaimed by deleting replaced and orphaned files (all time and last 30 days), open deletions and the last orphan sweep
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.StorageCleanupSummary}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/storage/cleanup [get]
+func (h *StorageCleanupHandler) GetSummary(c *gin.Context) {
+	summary, err := h.cleanupUC.GetSummary(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Storage cleanup summary retrieved", summary)
+}
+
+// ListDeletions godoc
+// @Summary      List storage deletions
+// @Description  Newest first, with attempts, last error and reclaimed bytes
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status    query     string  false  "PENDING, DELETED or FAILED"
+// @Param        page      query     int     false  "Page number"
+// @Param        pageSize  query     int     false  "Items per page (max 100)"
+// @Success      200       {object}  response.Response{data=domain.PaginatedResult[domain.StorageDeletion]}
+// @Failure      400       {object}  response.Response
+// @Router       /admin/storage/deletions [get]
+func (h *StorageCleanupHandler) ListDeletions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+
+	result, err := h.cleanupUC.ListDeletions(c.Request.Context(), c.Query("status"), page, pageSize)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Storage deletions retrieved", result)
+}
+
+// RetryDeletion godoc
+// @Summary      Retry a failed storage deletion
+// @Description  Queues a deletion that was given up for a new round of attempts
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Deletion ID"
+// @Success      200  {object}  response.Response{data=domain.StorageDeletion}
+// @Failure      404  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/storage/deletions/{id}/retry [post]
+func (h *StorageCleanupHandler) RetryDeletion(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid storage deletion ID"))
+		return
+	}
+	deletion, err := h.cleanupUC.RetryDeletion(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Storage deletion queued", deletion)
+}
+
+// ListSweeps godoc
+// @Summary      List orphan sweeps
+// @Description  The 50 most recent sweeps with objects scanned, orphans found and their size
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.StorageOrphanSweep}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/storage/sweeps [get]
+func (h *StorageCleanupHandler) ListSweeps(c *gin.Context) {
+	sweeps, err := h.cleanupUC.ListSweeps(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Orphan sweeps retrieved", sweeps)
+}
+
+// RunSweep godoc
+// @Summary      Run an orphan sweep now
+// @Description  Compares the upload buckets against the file records and queues orphaned objects for deletion
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.StorageOrphanSweep}
+// @Failure      409  {object}  response.Response
+// @Router       /admin/storage/sweeps/run [post]
+func (h *StorageCleanupHandler) RunSweep(c *gin.Context) {
+	sweep, err := h.cleanupUC.TriggerSweep(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Orphan sweep finished", sweep)
+}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SupabaseWebhookHandler struct {
+	webhookUC domain.SupabaseWebhookUsecase
+}
+
+// NewSupabaseWebhookHandler registers the endpoint Supabase Auth posts account
+// events to (signature auth, not a session)
+func NewSupabaseWebhookHandler(public *gin.RouterGroup, webhookUC domain.SupabaseWebhookUsecase) {
+	handler := &SupabaseWebhookHandler{webhookUC: webhookUC}
+
+	public.POST("/webhooks/supabase", handler.HandleAuthEvent)
+}
+
+// HandleAuthEvent godoc
+// @Summary      Supabase auth webhook
+// @Description  Consumes user.confirmed (creates or links the user and records the email confirmation), user.deleted (removes the user) and password.recovery (security event). Signed with the Standard Webhooks headers; deliveries older than 5 minutes or already processed are not applied again. 404 while SUPABASE_WEBHOOK_SECRET is not set.
+// @Tags         system
+// @Accept       json
+// @Produce      json
+// @Param        webhook-id         header    string                    true  "Delivery ID"
+// @Param        webhook-timestamp  header    string                    true  "Unix seconds"
+// @Param        webhook-signature  header    string                    true  "v1,<base64 HMAC-SHA256>"
+// @Param        request            body      domain.SupabaseAuthEvent  true  "Auth event"
+// @Success      200                {object}  response.Response{data=domain.SupabaseWebhookResult}
+// @Failure      400                {object}  response.Response
+// @Failure      401                {object}  response.Response
+// @Failure      404                {object}  response.Response
+// @Router       /webhooks/supabase [post]
+func (h *SupabaseWebhookHandler) HandleAuthEvent(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.Error(apperror.BadRequest("Failed to read request body"))
+		return
+	}
+
+	result, err := h.webhookUC.HandleAuthEvent(c.Request.Context(), domain.SupabaseWebhookSignature{
+		ID:        c.GetHeader("webhook-id"),
+		Timestamp: c.GetHeader("webhook-timestamp"),
+		Signature: c.GetHeader("webhook-signature"),
+	}, body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook processed", result)
+}
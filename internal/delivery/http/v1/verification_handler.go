@@ -2,17 +2,21 @@ package v1
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"go-recruitment-backend/internal/delivery/http/response"
 	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
 	"go-recruitment-backend/pkg/security"
+	"go-recruitment-backend/pkg/storage"
 	"image"
 	"image/jpeg"
 	_ "image/png" // Register PNG decoder
 	"io"
-	"log"
 	"net/http"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -24,13 +28,41 @@ import (
 // Package-level rate limiter (initialized once)
 var uploadLimiter = security.NewUploadLimiter(10, 50) // 10/min per IP, 50/day per user
 
+// maxUploadSize is the hard cap for a single uploaded file
+const maxUploadSize = 10 * 1024 * 1024 // 10MB
+
+// maxConcurrentUploads bounds memory used by in-flight uploads
+const maxConcurrentUploads = 8
+
+var uploadSlots = make(chan struct{}, maxConcurrentUploads)
+
+var (
+	errUploadMissing  = errors.New("no file part in request")
+	errUploadTooLarge = errors.New("file exceeds upload limit")
+)
+
+// uploadRejectedError carries a client-safe reason for rejecting a file part
+type uploadRejectedError struct {
+	reason string
+}
+
+func (e *uploadRejectedError) Error() string { return e.reason }
+
 type VerificationHandler struct {
 	verificationUC domain.VerificationUsecase
+	fileUC         domain.UploadedFileUsecase
+	cvParseUC      domain.CVParseUsecase
+	cleanupUC      domain.StorageCleanupUsecase
+	duplicateUC    domain.DuplicateDocumentUsecase
 }
 
-func NewVerificationHandler(r *gin.RouterGroup, uc domain.VerificationUsecase) {
+func NewVerificationHandler(r *gin.RouterGroup, uc domain.VerificationUsecase, fileUC domain.UploadedFileUsecase, cvParseUC domain.CVParseUsecase, cleanupUC domain.StorageCleanupUsecase, duplicateUC domain.DuplicateDocumentUsecase) {
 	handler := &VerificationHandler{
 		verificationUC: uc,
+		fileUC:         fileUC,
+		cvParseUC:      cvParseUC,
+		cleanupUC:      cleanupUC,
+		duplicateUC:    duplicateUC,
 	}
 
 	// Admin routes
@@ -43,12 +75,15 @@ func NewVerificationHandler(r *gin.RouterGroup, uc domain.VerificationUsecase) {
 
 	// User routes
 	r.GET("/verifications/me", handler.MyStatus)
+	r.GET("/verifications/schema", handler.GetSchema)          // Required/optional/hidden fields for the verification form
+	r.PUT("/verifications/schema/:role", handler.UpdateSchema) // Admin: change field requirements
 
 	// Candidate Verification Routes
 	candidates := r.Group("/candidates")
 	{
 		candidates.GET("/me/verification", handler.MyStatus)
 		candidates.PUT("/me/verification", handler.UpdateProfile)
+		candidates.GET("/me/profile-completeness", handler.ProfileCompleteness)
 	}
 
 	r.POST("/upload", handler.UploadFile)
@@ -67,6 +102,7 @@ type UpdateProfileRequest struct {
 // @Accept json
 // @Produce json
 // @Success 200 {object} domain.AccountVerification
+// @Failure 400 {object} response.Response "error.code: EMERGENCY_CONTACT_* or GUARDIAN_CONSENT_REQUIRED"
 // @Router /candidates/me/verification [put]
 func (h *VerificationHandler) UpdateProfile(c *gin.Context) {
 	userID := c.GetString(string(domain.KeyUserID))
@@ -83,8 +119,11 @@ func (h *VerificationHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	err := h.verificationUC.UpdateCandidateProfile(c.Request.Context(), userID, req.Verification, req.Experiences)
+	if respondProfileRuleError(c, err) {
+		return
+	}
 	if err != nil {
-		log.Printf("ERROR UpdateProfile: userID=%s, error=%v", userID, err)
+		logger.FromContext(c.Request.Context()).Error("Failed to update profile", "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to update profile", err.Error())
 		return
 	}
@@ -92,16 +131,32 @@ func (h *VerificationHandler) UpdateProfile(c *gin.Context) {
 	response.Success(c, http.StatusOK, "Profile updated successfully", nil)
 }
 
+// respondProfileRuleError answers a profile rule violation with 400 and its code
+func respondProfileRuleError(c *gin.Context, err error) bool {
+	var ruleErr *domain.ProfileRuleError
+	if !errors.As(err, &ruleErr) {
+		return false
+	}
+	details := gin.H{"code": ruleErr.Code}
+	if len(ruleErr.Fields) > 0 {
+		details["fields"] = ruleErr.Fields
+	}
+	response.ErrorWithCode(c, http.StatusBadRequest, ruleErr.Code, ruleErr.Message, details)
+	return true
+}
+
 // UploadFile godoc
 // @Summary Upload a file
-// @Description Upload a file (image/pdf) and get a URL. Images are compressed automatically.
+// @Description Upload a file (image/pdf) and get a URL. Images are compressed automatically. A candidate's PDF/DOCX CV in the CV bucket also fills their empty profile fields.
 // @Tags Upload
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "File to upload"
 // @Param bucket query string false "Target bucket"
 // @Param old_url query string false "Previous file URL to delete"
+// @Param async query bool false "Return 202 immediately and process in background; poll GET /files/{id}/status"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} map[string]string
 // @Router /upload [post]
 func (h *VerificationHandler) UploadFile(c *gin.Context) {
 	// === SECURITY: Rate Limiting ===
@@ -112,7 +167,7 @@ func (h *VerificationHandler) UploadFile(c *gin.Context) {
 	allowed, retryAfter, err := uploadLimiter.AllowUpload(c.Request.Context(), ip, userID)
 	if err != nil {
 		// Log system warning but DO NOT trigger security event for infrastructure failures (Fail Open)
-		log.Printf("WARNING: Rate limiter unavailable: %v", err)
+		logger.FromContext(c.Request.Context()).Warn("Upload rate limiter unavailable", "error", err)
 	}
 	if !allowed {
 		c.Header("Retry-After", fmt.Sprintf("%d", retryAfter))
@@ -121,31 +176,28 @@ func (h *VerificationHandler) UploadFile(c *gin.Context) {
 		return
 	}
 
-	// === SECURITY: File Size Limit ===
-	// Limit request body to 10MB to prevent resource exhaustion
-	const maxUploadSize = 10 * 1024 * 1024 // 10MB
-	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize)
-
-	file, err := c.FormFile("file")
-	if err != nil {
-		if err.Error() == "http: request body too large" {
-			response.Error(c, http.StatusRequestEntityTooLarge, "File too large. Maximum size is 10MB.", nil)
-			return
-		}
-		response.Error(c, http.StatusBadRequest, "No file uploaded", err.Error())
-		return
-	}
-
-	// === SECURITY: File Size Double-Check ===
-	if file.Size > maxUploadSize {
-		response.Error(c, http.StatusRequestEntityTooLarge, "File too large. Maximum size is 10MB.", nil)
+	// === SECURITY: Concurrency Cap ===
+	// Each in-flight upload holds up to maxUploadSize in memory; bound the total
+	slotHandedOff := false
+	select {
+	case uploadSlots <- struct{}{}:
+		defer func() {
+			if !slotHandedOff {
+				<-uploadSlots
+			}
+		}()
+	default:
+		c.Header("Retry-After", "5")
+		response.Error(c, http.StatusServiceUnavailable, "Too many uploads in progress. Please try again shortly.", nil)
 		return
 	}
 
-	// === SECURITY: Extension Pre-Validation ===
-	// Quick check before reading file content
-	if err := security.ValidateFileExtension(file.Filename); err != nil {
-		response.Error(c, http.StatusBadRequest, err.Error(), nil)
+	// === SECURITY: Streaming Multipart Parse ===
+	// Body size is already capped by BodyLimitMiddleware; the file part itself is
+	// read through a hard limit so we never buffer more than maxUploadSize.
+	filename, fileBytes, err := readMultipartFile(c.Request, "file", maxUploadSize)
+	if err != nil {
+		respondUploadReadError(c, err)
 		return
 	}
 
@@ -153,162 +205,298 @@ func (h *VerificationHandler) UploadFile(c *gin.Context) {
 	bucket := c.DefaultQuery("bucket", "CV") // Default to CV bucket
 
 	// Validate bucket name - include all supported buckets
-	validBuckets := map[string]bool{
-		"Profile_Picture": true,
-		"JLPT":            true,
-		"CV":              true,
-		"Company_Logo":    true,
-		"Company_Gallery": true,
-		"company_gallery": true, // Supabase bucket names as shown
-		"COMPANY_GALLERY": true, // Uppercase version
-		"profile_company": true, // Supabase bucket names as shown
-	}
-	if !validBuckets[bucket] {
-		log.Printf("Invalid bucket requested: %s, falling back to CV", bucket)
+	if !slices.Contains(domain.UploadBuckets, bucket) {
+		logger.FromContext(c.Request.Context()).Warn("Invalid bucket requested, falling back to CV", "bucket", bucket)
 		bucket = "CV" // Fallback to CV
 	}
 
-	// Get Supabase config
-	supabaseURL := os.Getenv("SUPABASE_URL")
-	supabaseKey := os.Getenv("SUPABASE_SERVICE_KEY")
-	if supabaseKey == "" {
-		supabaseKey = os.Getenv("SUPABASE_SERVICE_ROLE_KEY")
-		if supabaseKey == "" {
-			supabaseKey = os.Getenv("SUPABASE_KEY")
-		}
-	}
-
-	// Open file
-	src, err := file.Open()
-	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to open file", err.Error())
-		return
-	}
-	defer src.Close()
-
-	// Read file content
-	fileBytes, err := io.ReadAll(src)
-	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to read file", err.Error())
-		return
-	}
-
 	// Detect content type from file bytes (more reliable than header - uses magic bytes)
 	contentType := http.DetectContentType(fileBytes)
-	log.Printf("Detected content type: %s (original filename: %s)", contentType, file.Filename)
+	logger.FromContext(c.Request.Context()).Debug("Detected upload content type", "content_type", contentType, "filename", filename)
 
 	// === SECURITY: 3-Layer File Validation ===
 	// Uses security.ValidateFile for extension + magic bytes + MIME type validation
 	// CRITICAL: application/octet-stream is rejected (prevents arbitrary binary uploads)
-	validationResult := security.ValidateFile(file.Filename, fileBytes, contentType)
+	validationResult := security.ValidateFile(filename, fileBytes, contentType)
 	if !validationResult.Valid {
-		log.Printf("SECURITY: File validation failed for %s: %s", file.Filename, validationResult.Error)
+		logger.FromContext(c.Request.Context()).Warn("Upload rejected by file validation", "filename", filename, "reason", validationResult.Error)
 		response.Error(c, http.StatusBadRequest,
 			fmt.Sprintf("File rejected: %s. Allowed types: JPG, PNG, GIF, WebP, PDF, DOC, DOCX, TXT", validationResult.Error), nil)
 		return
 	}
 
+	// Register the file so the client can poll its processing status
+	record := &domain.UploadedFile{
+		UserID:           userID,
+		Bucket:           bucket,
+		OriginalFilename: filename,
+		ContentType:      contentType,
+		SizeBytes:        int64(len(fileBytes)),
+	}
+	if err := h.fileUC.Register(c.Request.Context(), record); err != nil {
+		c.Error(err)
+		return
+	}
+
+	job := uploadJob{
+		fileID:      record.ID,
+		userID:      userID,
+		filename:    filename,
+		data:        fileBytes,
+		contentType: contentType,
+		bucket:      bucket,
+		oldURL:      c.Query("old_url"),
+	}
+	if bucket == "CV" && c.GetString(string(domain.KeyUserRole)) == domain.RoleCandidate && isParsableCV(filename) {
+		job.prefillUserID = userID
+	}
+	if slices.Contains(domain.DuplicateDocumentBuckets, bucket) && c.GetString(string(domain.KeyUserRole)) == domain.RoleCandidate {
+		job.duplicateCheck = true
+	}
+
+	// Async mode: respond immediately, client polls GET /files/:id/status.
+	// The concurrency slot is handed to the goroutine since it still holds the bytes.
+	if c.Query("async") == "true" {
+		slotHandedOff = true
+		go func() {
+			defer func() { <-uploadSlots }()
+			// Detached from the request, but keeps its logger (request and user IDs)
+			ctx, cancel := context.WithTimeout(logger.WithContext(context.Background(), logger.FromContext(c.Request.Context())), 2*time.Minute)
+			defer cancel()
+			h.processUpload(ctx, job)
+		}()
+
+		response.Success(c, http.StatusAccepted, "File accepted for processing", UploadFileResponse{
+			FileID: record.ID,
+			Status: domain.FileStatusProcessing,
+		})
+		return
+	}
+
+	publicURL, err := h.processUpload(c.Request.Context(), job)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Upload failed", gin.H{"file_id": record.ID})
+		return
+	}
+
+	resp := UploadFileResponse{
+		URL:    publicURL,
+		FileID: record.ID,
+		Status: domain.FileStatusReady,
+	}
+	// Files in private buckets are read through a signed URL; the stored URL only identifies them
+	if slices.Contains(domain.PrivateUploadBuckets, bucket) {
+		signed, err := h.fileUC.SignURL(c.Request.Context(), publicURL)
+		if err != nil {
+			logger.FromContext(c.Request.Context()).Warn("Failed to sign uploaded file URL", "file_id", record.ID, "error", err)
+		} else {
+			resp.SignedURL = signed.URL
+		}
+	}
+	response.Success(c, http.StatusOK, "File uploaded", resp)
+}
+
+// UploadFileResponse reports the stored file; URL is empty until processing is done (async uploads).
+// URL is what profiles and applications store. For private buckets (CV, JLPT, vault documents, guardian consent) it does not serve
+// the file; SignedURL does until it expires, and GET /files/signed-url issues new ones.
+type UploadFileResponse struct {
+	URL       string `json:"url,omitempty"`
+	SignedURL string `json:"signed_url,omitempty"`
+	FileID    string `json:"file_id"`
+	Status    string `json:"status"`
+}
+
+// uploadJob carries everything needed to finish an upload outside the request
+type uploadJob struct {
+	fileID      string
+	userID      string
+	filename    string
+	data        []byte
+	contentType string
+	bucket      string
+	oldURL      string
+
+	// prefillUserID is set for a candidate's CV, whose parsed content fills
+	// their empty profile data once stored
+	prefillUserID string
+
+	// duplicateCheck is set for a candidate's certificate or vault document,
+	// whose image is compared with the documents of other accounts
+	duplicateCheck bool
+}
+
+// processUpload compresses images, stores the file and records the
+// outcome on the file status resource. It is safe to run in a goroutine.
+func (h *VerificationHandler) processUpload(ctx context.Context, job uploadJob) (string, error) {
+	l := logger.FromContext(ctx).With("file_id", job.fileID)
+	if err := h.fileUC.MarkProcessing(ctx, job.fileID); err != nil {
+		l.Warn("Failed to mark file processing", "error", err)
+	}
+
+	fail := func(reason string, err error) (string, error) {
+		l.Error("Upload failed", "reason", reason, "error", err)
+		if markErr := h.fileUC.MarkFailed(ctx, job.fileID, reason); markErr != nil {
+			l.Warn("Failed to mark file failed", "error", markErr)
+		}
+		return "", err
+	}
+
 	// Determine if it's an image for compression
-	isImage := strings.HasPrefix(contentType, "image/")
+	isImage := strings.HasPrefix(job.contentType, "image/")
 	var finalBytes []byte
 	var finalFilename string
 
 	if isImage {
 		// Compress image
-		compressedBytes, compressErr := compressImage(fileBytes, contentType, 1200, 80)
+		compressedBytes, compressErr := compressImage(job.data, job.contentType, 1200, 80)
 		if compressErr != nil {
-			log.Printf("Image compression failed, using original: %v", compressErr)
-			finalBytes = fileBytes
+			l.Warn("Image compression failed, using original", "error", compressErr)
+			finalBytes = job.data
 		} else {
 			finalBytes = compressedBytes
-			log.Printf("Image compressed: %d bytes -> %d bytes", len(fileBytes), len(compressedBytes))
+			l.Debug("Image compressed", "original_bytes", len(job.data), "compressed_bytes", len(compressedBytes))
 		}
 
 		// Generate filename with proper extension (ASCII only for Supabase)
-		finalFilename = fmt.Sprintf("%d_%s.jpg", time.Now().UnixNano(), sanitizeFilename(file.Filename))
+		finalFilename = fmt.Sprintf("%d_%s.jpg", time.Now().UnixNano(), sanitizeFilename(job.filename))
 	} else {
 		// Non-image file (PDF, etc) - use as-is
-		finalBytes = fileBytes
-		finalFilename = fmt.Sprintf("%d_%s", time.Now().UnixNano(), sanitizeFilename(file.Filename))
+		finalBytes = job.data
+		finalFilename = fmt.Sprintf("%d_%s", time.Now().UnixNano(), sanitizeFilename(job.filename))
 	}
 
-	// Credentials validation (no logging of sensitive values)
+	// Storage requires the correct MIME type
+	storedContentType := job.contentType // Use detected content type for non-images
+	if isImage {
+		storedContentType = "image/jpeg" // Compressed images are always JPEG
+	}
+	publicURL, err := h.fileUC.StoreObject(ctx, job.bucket, finalFilename, storedContentType, finalBytes)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotConfigured) {
+			return fail("storage not configured", err)
+		}
+		return fail("storage upload failed", err)
+	}
 
-	if supabaseURL == "" || supabaseKey == "" {
-		response.Error(c, http.StatusInternalServerError, "Storage not configured", "Missing Supabase credentials")
-		return
+	// ATOMICITY: Only delete old file AFTER new file is successfully uploaded.
+	// The deletion is queued and retried in the background; the orphan sweep
+	// catches anything that could not be queued.
+	if job.oldURL != "" && h.cleanupUC != nil {
+		if err := h.cleanupUC.QueueReplacedFile(ctx, job.userID, job.oldURL); err != nil {
+			l.Warn("Failed to queue old file deletion", "error", err)
+		}
 	}
 
-	// Upload to Supabase Storage
-	uploadURL := fmt.Sprintf("%s/storage/v1/object/%s/%s", supabaseURL, bucket, finalFilename)
+	if err := h.fileUC.MarkReady(ctx, job.fileID, publicURL); err != nil {
+		l.Warn("Failed to mark file ready", "error", err)
+	}
 
-	req, err := http.NewRequest("POST", uploadURL, bytes.NewReader(finalBytes))
-	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to create request", err.Error())
-		return
+	// Best-effort: the upload already succeeded, so a CV that cannot be parsed is only logged
+	if job.prefillUserID != "" && h.cvParseUC != nil {
+		result, err := h.cvParseUC.PrefillFromCV(ctx, job.prefillUserID, job.filename, job.data, publicURL)
+		if err != nil {
+			l.Info("CV prefill skipped", "error", err)
+		} else {
+			l.Info("CV prefill done",
+				"verification_fields", result.VerificationFields,
+				"education_fields", result.EducationFields,
+				"work_experiences", result.WorkExperiences,
+				"skills", result.Skills,
+			)
+		}
 	}
 
-	// Set headers with correct content type
-	// Supabase requires the correct MIME type for uploads
-	req.Header.Set("Authorization", "Bearer "+supabaseKey)
-	if isImage {
-		req.Header.Set("Content-Type", "image/jpeg") // Compressed images are always JPEG
-	} else {
-		req.Header.Set("Content-Type", contentType) // Use detected content type for non-images
+	// Best-effort as well: a failed check only misses a review task
+	if job.duplicateCheck && h.duplicateUC != nil {
+		if err := h.duplicateUC.CheckUpload(ctx, domain.DocumentImageUpload{
+			UserID:  job.userID,
+			FileID:  job.fileID,
+			Bucket:  job.bucket,
+			FileURL: publicURL,
+			Data:    job.data,
+		}); err != nil {
+			l.Warn("Duplicate document check failed", "error", err)
+		}
 	}
-	req.Header.Set("x-upsert", "true") // Overwrite if exists
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
+	return publicURL, nil
+}
+
+// isParsableCV reports whether the resume parser reads this file type
+func isParsableCV(filename string) bool {
+	ext := strings.ToLower(getExtension(filename))
+	return ext == "pdf" || ext == "docx"
+}
+
+// readMultipartFile streams the multipart body and returns the named file part.
+// The extension is checked as soon as the part header is seen, before any content
+// is read, and the content is read through a limit of maxBytes+1 so oversized
+// files fail without being buffered in full. Other parts are skipped.
+func readMultipartFile(r *http.Request, field string, maxBytes int64) (string, []byte, error) {
+	return readMultipartFileWith(r, field, maxBytes, security.ValidateFileExtension)
+}
+
+// readMultipartFileWith is readMultipartFile with its own extension check, for
+// uploads that are not documents or images
+func readMultipartFileWith(r *http.Request, field string, maxBytes int64, checkName func(string) error) (string, []byte, error) {
+	reader, err := r.MultipartReader()
 	if err != nil {
-		response.Error(c, http.StatusInternalServerError, "Failed to upload file", err.Error())
-		return
+		return "", nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		respBody, _ := io.ReadAll(resp.Body)
-		log.Printf("Upload failed: status=%d, body=%s", resp.StatusCode, string(respBody))
-		response.Error(c, http.StatusInternalServerError, "Upload failed", string(respBody))
-		return
-	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return "", nil, errUploadMissing
+		}
+		if err != nil {
+			return "", nil, err
+		}
 
-	// ATOMICITY: Only delete old file AFTER new file is successfully uploaded
-	oldURL := c.Query("old_url")
-	if oldURL != "" && supabaseURL != "" && supabaseKey != "" {
-		go func(urlToDelete, sbURL, sbKey string) {
-			// Extract bucket and filename from the old URL
-			// URL format: https://xxx.supabase.co/storage/v1/object/public/BUCKET/FILENAME
-			if strings.Contains(urlToDelete, "/storage/v1/object/public/") {
-				parts := strings.Split(urlToDelete, "/storage/v1/object/public/")
-				if len(parts) == 2 {
-					pathParts := strings.SplitN(parts[1], "/", 2)
-					if len(pathParts) == 2 {
-						oldBucket := pathParts[0]
-						oldFilename := pathParts[1]
-						deleteURL := fmt.Sprintf("%s/storage/v1/object/%s/%s", sbURL, oldBucket, oldFilename)
-
-						deleteReq, _ := http.NewRequest("DELETE", deleteURL, nil)
-						deleteReq.Header.Set("Authorization", "Bearer "+sbKey)
-
-						delClient := &http.Client{Timeout: 10 * time.Second}
-						deleteResp, deleteErr := delClient.Do(deleteReq)
-						if deleteErr == nil {
-							deleteResp.Body.Close()
-							log.Printf("Deleted old file: %s", oldFilename)
-						} else {
-							log.Printf("Failed to delete old file (cleanup): %v", deleteErr)
-						}
-					}
-				}
-			}
-		}(oldURL, supabaseURL, supabaseKey)
-	}
+		if part.FormName() != field || part.FileName() == "" {
+			part.Close()
+			continue
+		}
 
-	// Construct public URL
-	publicURL := fmt.Sprintf("%s/storage/v1/object/public/%s/%s", supabaseURL, bucket, finalFilename)
+		filename := part.FileName()
+		if err := checkName(filename); err != nil {
+			part.Close()
+			return "", nil, &uploadRejectedError{reason: err.Error()}
+		}
 
-	response.Success(c, http.StatusOK, "File uploaded", gin.H{"url": publicURL})
+		var buf bytes.Buffer
+		n, err := io.Copy(&buf, io.LimitReader(part, maxBytes+1))
+		part.Close()
+		if err != nil {
+			return "", nil, err
+		}
+		if n > maxBytes {
+			return "", nil, errUploadTooLarge
+		}
+		return filename, buf.Bytes(), nil
+	}
+}
+
+// respondUploadReadError writes the client response for a readMultipartFile error
+func respondUploadReadError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errUploadTooLarge):
+		response.Error(c, http.StatusRequestEntityTooLarge, "File too large. Maximum size is 10MB.", nil)
+	case errors.Is(err, errUploadMissing):
+		response.Error(c, http.StatusBadRequest, "No file uploaded", nil)
+	default:
+		var rejected *uploadRejectedError
+		if errors.As(err, &rejected) {
+			response.Error(c, http.StatusBadRequest, rejected.Error(), nil)
+			return
+		}
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "File too large. Maximum size is 10MB.", nil)
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "Invalid multipart request", nil)
+	}
 }
 
 // compressImage compresses an image to the specified max dimension and quality
@@ -320,8 +508,6 @@ func compressImage(data []byte, contentType string, maxDimension int, quality in
 		return nil, fmt.Errorf("failed to decode image (format: %s): %w", format, err)
 	}
 
-	log.Printf("Decoding image format: %s", format)
-
 	// Get original dimensions
 	bounds := img.Bounds()
 	width := bounds.Dx()
@@ -412,7 +598,7 @@ func (h *VerificationHandler) List(c *gin.Context) {
 	// TODO: Check if user is ADMIN (Middleware should handle this, or check here)
 	// Assuming RBAC middleware handles general auth, but we might want specific role check here if not global
 	role, exists := c.Get(string(domain.KeyUserRole))
-	if !exists || role != "admin" {
+	if !exists || role != domain.RoleAdmin {
 		response.Error(c, http.StatusForbidden, "Access denied: Admins only", nil)
 		return
 	}
@@ -458,7 +644,7 @@ func (h *VerificationHandler) List(c *gin.Context) {
 func (h *VerificationHandler) GetDetail(c *gin.Context) {
 	// Check Admin
 	role, exists := c.Get(string(domain.KeyUserRole))
-	if !exists || role != "admin" {
+	if !exists || role != domain.RoleAdmin {
 		response.Error(c, http.StatusForbidden, "Access denied: Admins only", nil)
 		return
 	}
@@ -477,7 +663,7 @@ func (h *VerificationHandler) GetDetail(c *gin.Context) {
 	}
 
 	if detail == nil {
-		response.Error(c, http.StatusNotFound, "Verification not found", nil)
+		response.ErrorWithCode(c, http.StatusNotFound, domain.ErrCodeVerificationNotFound, "Verification not found", nil)
 		return
 	}
 
@@ -502,7 +688,7 @@ type VerifyRequest struct {
 func (h *VerificationHandler) Verify(c *gin.Context) {
 	// Check Admin
 	role, exists := c.Get(string(domain.KeyUserRole))
-	if !exists || role != "admin" {
+	if !exists || role != domain.RoleAdmin {
 		response.Error(c, http.StatusForbidden, "Access denied: Admins only", nil)
 		return
 	}
@@ -522,6 +708,14 @@ func (h *VerificationHandler) Verify(c *gin.Context) {
 	}
 
 	err = h.verificationUC.VerifyUser(c.Request.Context(), adminID.(string), id, req.Action, req.Notes)
+	if respondProfileRuleError(c, err) {
+		return
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		c.Error(err)
+		return
+	}
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "Failed to verify user", err.Error())
 		return
@@ -554,3 +748,73 @@ func (h *VerificationHandler) MyStatus(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Status fetched", status)
 }
+
+// ProfileCompleteness godoc
+// @Summary Get my profile completeness
+// @Description 0-100 score with a checklist of photo, cv, jlpt_certificate, work_experience and preferences; missing lists the sections still to fill in
+// @Tags Verification
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=domain.ProfileCompleteness}
+// @Failure 403 {object} response.Response
+// @Router /candidates/me/profile-completeness [get]
+func (h *VerificationHandler) ProfileCompleteness(c *gin.Context) {
+	userID := c.GetString(string(domain.KeyUserID))
+
+	completeness, err := h.verificationUC.GetProfileCompleteness(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Profile completeness retrieved", completeness)
+}
+
+// GetSchema godoc
+// @Summary Get the verification form schema
+// @Description Which verification fields are required, optional or hidden. Defaults to the caller's role; admins pass role.
+// @Tags Verification
+// @Produce json
+// @Param role query string false "CANDIDATE or EMPLOYER"
+// @Success 200 {object} response.Response{data=domain.VerificationSchema}
+// @Failure 400 {object} response.Response
+// @Router /verifications/schema [get]
+func (h *VerificationHandler) GetSchema(c *gin.Context) {
+	role := c.Query("role")
+	if role == "" {
+		role = c.GetString(string(domain.KeyUserRole))
+	}
+
+	schema, err := h.verificationUC.GetVerificationSchema(c.Request.Context(), role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Verification schema retrieved", schema)
+}
+
+// UpdateSchema godoc
+// @Summary Update the verification form schema
+// @Description Sets the listed fields to REQUIRED, OPTIONAL or HIDDEN (Admin only). Required fields block candidate submission and approval; hidden candidate fields are dropped on the next profile update.
+// @Tags Verification
+// @Accept json
+// @Produce json
+// @Param role path string true "CANDIDATE or EMPLOYER"
+// @Param request body domain.UpdateVerificationSchemaRequest true "Field requirements"
+// @Success 200 {object} response.Response{data=domain.VerificationSchema}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /verifications/schema/{role} [put]
+func (h *VerificationHandler) UpdateSchema(c *gin.Context) {
+	var req domain.UpdateVerificationSchemaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	schema, err := h.verificationUC.UpdateVerificationSchema(c.Request.Context(), c.GetString(string(domain.KeyUserID)), c.Param("role"), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Verification schema updated", schema)
+}
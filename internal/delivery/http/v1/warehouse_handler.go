@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WarehouseHandler struct {
+	warehouseUC domain.WarehouseUsecase
+}
+
+// NewWarehouseHandler registers admin routes for the data warehouse export
+func NewWarehouseHandler(protected *gin.RouterGroup, warehouseUC domain.WarehouseUsecase) {
+	handler := &WarehouseHandler{warehouseUC: warehouseUC}
+
+	admin := protected.Group("/admin/warehouse")
+	{
+		admin.GET("/exports", handler.ListExports)
+		admin.POST("/exports/run", handler.TriggerExport)
+	}
+}
+
+// ListExports godoc
+// @Summary      List warehouse export status per table
+// @Description  Schema version, watermark, last successful export and last attempt of every exported table
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.WarehouseExportState}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/warehouse/exports [get]
+func (h *WarehouseHandler) ListExports(c *gin.Context) {
+	states, err := h.warehouseUC.ListExports(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Warehouse exports retrieved", states)
+}
+
+// TriggerExport godoc
+// @Summary      Run the warehouse export now
+// @Description  Runs the nightly incremental export immediately and returns each table's outcome
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=domain.WarehouseExportRun}
+// @Failure      400  {object}  response.Response
+// @Failure      403  {object}  response.Response
+// @Failure      409  {object}  response.Response
+// @Router       /admin/warehouse/exports/run [post]
+func (h *WarehouseHandler) TriggerExport(c *gin.Context) {
+	run, err := h.warehouseUC.TriggerExport(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Warehouse export finished", run)
+}
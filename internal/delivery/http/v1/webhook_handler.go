@@ -0,0 +1,282 @@
+package v1
+
+import (
+	"go-recruitment-backend/internal/delivery/http/response"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WebhookHandler struct {
+	webhookUC domain.WebhookUsecase
+}
+
+// NewWebhookHandler registers admin routes for webhook endpoints and delivery history
+func NewWebhookHandler(protected *gin.RouterGroup, webhookUC domain.WebhookUsecase) {
+	handler := &WebhookHandler{webhookUC: webhookUC}
+
+	admin := protected.Group("/admin/webhooks")
+	{
+		admin.GET("", handler.ListEndpoints)
+		admin.POST("", handler.CreateEndpoint)
+		admin.GET("/deliveries", handler.ListDeliveries)
+		admin.GET("/deliveries/:deliveryId", handler.GetDelivery)
+		admin.POST("/deliveries/:deliveryId/redeliver", handler.Redeliver)
+		admin.GET("/:id", handler.GetEndpoint)
+		admin.PUT("/:id", handler.UpdateEndpoint)
+		admin.DELETE("/:id", handler.DeleteEndpoint)
+		admin.POST("/:id/rotate-secret", handler.RotateSecret)
+	}
+}
+
+// ListEndpoints godoc
+// @Summary      List webhook endpoints
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  response.Response{data=[]domain.WebhookEndpoint}
+// @Failure      403  {object}  response.Response
+// @Router       /admin/webhooks [get]
+func (h *WebhookHandler) ListEndpoints(c *gin.Context) {
+	endpoints, err := h.webhookUC.ListEndpoints(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook endpoints retrieved", endpoints)
+}
+
+// CreateEndpoint godoc
+// @Summary      Register a webhook endpoint
+// @Description  Subscribes an https URL to application.created, job.published, verification.approved and/or file.processed. The response contains the signing secret, which is not shown again; every delivery carries X-Webhook-Signature "t=<unix>,v1=<hex HMAC-SHA256 of "<t>.<body>">".
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      domain.WebhookEndpointRequest  true  "Endpoint"
+// @Success      201      {object}  response.Response{data=domain.WebhookEndpoint}
+// @Failure      400      {object}  response.Response
+// @Failure      403      {object}  response.Response
+// @Router       /admin/webhooks [post]
+func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
+	var req domain.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	adminID := c.GetString(string(domain.KeyUserID))
+	endpoint, err := h.webhookUC.CreateEndpoint(c.Request.Context(), adminID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusCreated, "Webhook endpoint created", endpoint)
+}
+
+// GetEndpoint godoc
+// @Summary      Get a webhook endpoint
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Endpoint ID"
+// @Success      200  {object}  response.Response{data=domain.WebhookEndpoint}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetEndpoint(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	endpoint, err := h.webhookUC.GetEndpoint(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook endpoint retrieved", endpoint)
+}
+
+// UpdateEndpoint godoc
+// @Summary      Replace a webhook endpoint
+// @Description  Changes the URL, subscribed events or active flag; the secret is kept. Deliveries of an inactive endpoint wait until it is active again.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                            true  "Endpoint ID"
+// @Param        request  body      domain.WebhookEndpointRequest  true  "Endpoint"
+// @Success      200      {object}  response.Response{data=domain.WebhookEndpoint}
+// @Failure      400      {object}  response.Response
+// @Failure      404      {object}  response.Response
+// @Router       /admin/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	var req domain.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	endpoint, err := h.webhookUC.UpdateEndpoint(c.Request.Context(), id, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook endpoint updated", endpoint)
+}
+
+// DeleteEndpoint godoc
+// @Summary      Delete a webhook endpoint
+// @Description  Also deletes its delivery history
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Endpoint ID"
+// @Success      200  {object}  response.Response
+// @Failure      404  {object}  response.Response
+// @Router       /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	if err := h.webhookUC.DeleteEndpoint(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook endpoint deleted", nil)
+}
+
+// RotateSecret godoc
+// @Summary      Rotate a webhook signing secret
+// @Description  Returns the new secret, which is not shown again. Pending retries are signed with it.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Endpoint ID"
+// @Success      200  {object}  response.Response{data=domain.WebhookEndpoint}
+// @Failure      404  {object}  response.Response
+// @Router       /admin/webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	endpoint, err := h.webhookUC.RotateSecret(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook secret rotated", endpoint)
+}
+
+// ListDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Newest first, with attempts, last response and next retry
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        endpointId  query     int     false  "Endpoint ID"
+// @Param        eventType   query     string  false  "application.created, job.published, verification.approved or file.processed"
+// @Param        status      query     string  false  "PENDING, SUCCEEDED or FAILED"
+// @Param        page        query     int     false  "Page number"
+// @Param        pageSize    query     int     false  "Items per page (max 100)"
+// @Success      200         {object}  response.Response{data=domain.PaginatedResult[domain.WebhookDelivery]}
+// @Failure      400         {object}  response.Response
+// @Router       /admin/webhooks/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	filter := domain.WebhookDeliveryFilter{
+		EventType: c.Query("eventType"),
+		Status:    c.Query("status"),
+		Page:      page,
+		PageSize:  pageSize,
+	}
+	if raw := c.Query("endpointId"); raw != "" {
+		endpointID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.Error(apperror.BadRequest("Invalid webhook endpoint ID"))
+			return
+		}
+		filter.EndpointID = &endpointID
+	}
+
+	result, err := h.webhookUC.ListDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook deliveries retrieved", result)
+}
+
+// GetDelivery godoc
+// @Summary      Get a webhook delivery
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        deliveryId  path      string  true  "Delivery ID (the event id sent to the endpoint)"
+// @Success      200         {object}  response.Response{data=domain.WebhookDelivery}
+// @Failure      404         {object}  response.Response
+// @Router       /admin/webhooks/deliveries/{deliveryId} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, ok := parseWebhookDeliveryID(c)
+	if !ok {
+		return
+	}
+	delivery, err := h.webhookUC.GetDelivery(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook delivery retrieved", delivery)
+}
+
+// Redeliver godoc
+// @Summary      Redeliver a webhook
+// @Description  Queues a succeeded or failed delivery for a new round of attempts with the same event id
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        deliveryId  path      string  true  "Delivery ID"
+// @Success      200         {object}  response.Response{data=domain.WebhookDelivery}
+// @Failure      404         {object}  response.Response
+// @Failure      409         {object}  response.Response
+// @Router       /admin/webhooks/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, ok := parseWebhookDeliveryID(c)
+	if !ok {
+		return
+	}
+	delivery, err := h.webhookUC.Redeliver(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.Success(c, http.StatusOK, "Webhook delivery queued", delivery)
+}
+
+func parseWebhookEndpointID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid webhook endpoint ID"))
+		return 0, false
+	}
+	return id, true
+}
+
+func parseWebhookDeliveryID(c *gin.Context) (string, bool) {
+	id, err := uuid.Parse(c.Param("deliveryId"))
+	if err != nil {
+		c.Error(apperror.BadRequest("Invalid webhook delivery ID"))
+		return "", false
+	}
+	return id.String(), true
+}
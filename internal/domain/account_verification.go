@@ -51,6 +51,89 @@ var ValidReligions = []string{
 	ReligionHindu, ReligionBuddha, ReligionKonghucu, ReligionOther,
 }
 
+// CoEStatus constants (Certificate of Eligibility)
+const (
+	CoEStatusNone      = "NONE"
+	CoEStatusInProcess = "IN_PROCESS"
+	CoEStatusIssued    = "ISSUED"
+	CoEStatusExpired   = "EXPIRED"
+)
+
+// ValidCoEStatuses for validation
+var ValidCoEStatuses = []string{CoEStatusNone, CoEStatusInProcess, CoEStatusIssued, CoEStatusExpired}
+
+// SSW (Specified Skilled Worker / Tokutei Ginou) sectors
+const (
+	SSWSectorNursingCare             = "NURSING_CARE"
+	SSWSectorBuildingCleaning        = "BUILDING_CLEANING"
+	SSWSectorIndustrialManufacturing = "INDUSTRIAL_MANUFACTURING"
+	SSWSectorConstruction            = "CONSTRUCTION"
+	SSWSectorShipbuilding            = "SHIPBUILDING"
+	SSWSectorAutomobileRepair        = "AUTOMOBILE_REPAIR"
+	SSWSectorAviation                = "AVIATION"
+	SSWSectorAccommodation           = "ACCOMMODATION"
+	SSWSectorAgriculture             = "AGRICULTURE"
+	SSWSectorFishery                 = "FISHERY"
+	SSWSectorFoodManufacturing       = "FOOD_MANUFACTURING"
+	SSWSectorFoodService             = "FOOD_SERVICE"
+	SSWSectorAutomobileTransport     = "AUTOMOBILE_TRANSPORT"
+	SSWSectorRailway                 = "RAILWAY"
+	SSWSectorForestry                = "FORESTRY"
+	SSWSectorWoodIndustry            = "WOOD_INDUSTRY"
+)
+
+// ValidSSWSectors for validation
+var ValidSSWSectors = []string{
+	SSWSectorNursingCare, SSWSectorBuildingCleaning, SSWSectorIndustrialManufacturing,
+	SSWSectorConstruction, SSWSectorShipbuilding, SSWSectorAutomobileRepair,
+	SSWSectorAviation, SSWSectorAccommodation, SSWSectorAgriculture,
+	SSWSectorFishery, SSWSectorFoodManufacturing, SSWSectorFoodService,
+	SSWSectorAutomobileTransport, SSWSectorRailway, SSWSectorForestry, SSWSectorWoodIndustry,
+}
+
+// Emergency contact relationship constants
+const (
+	EmergencyContactParent   = "PARENT"
+	EmergencyContactGuardian = "GUARDIAN"
+	EmergencyContactSpouse   = "SPOUSE"
+	EmergencyContactSibling  = "SIBLING"
+	EmergencyContactRelative = "RELATIVE"
+	EmergencyContactFriend   = "FRIEND"
+	EmergencyContactOther    = "OTHER"
+)
+
+// ValidEmergencyContactRelationships for validation
+var ValidEmergencyContactRelationships = []string{
+	EmergencyContactParent, EmergencyContactGuardian, EmergencyContactSpouse,
+	EmergencyContactSibling, EmergencyContactRelative, EmergencyContactFriend, EmergencyContactOther,
+}
+
+// Candidate profile rule violations, returned in the error response as {"code": ...}
+const (
+	ProfileErrEmergencyContactIncomplete   = "EMERGENCY_CONTACT_INCOMPLETE"
+	ProfileErrEmergencyContactPhone        = "EMERGENCY_CONTACT_INVALID_PHONE"
+	ProfileErrEmergencyContactRelationship = "EMERGENCY_CONTACT_INVALID_RELATIONSHIP"
+	ProfileErrEmergencyContactSelf         = "EMERGENCY_CONTACT_SAME_AS_CANDIDATE"
+	ProfileErrGuardianConsentRequired      = "GUARDIAN_CONSENT_REQUIRED"
+)
+
+// ProfileRuleError rejects a candidate profile update with a machine-readable code
+type ProfileRuleError struct {
+	Code    string
+	Message string
+	Fields  []string // offending fields, when the rule names them
+}
+
+func (e *ProfileRuleError) Error() string { return e.Message }
+
+// SSWExamResult is one passed SSW skills evaluation exam
+type SSWExamResult struct {
+	Sector         string    `json:"sector"`
+	Level          int       `json:"level"` // 1 or 2
+	PassedDate     time.Time `json:"passed_date"`
+	CertificateURL *string   `json:"certificate_url,omitempty"`
+}
+
 // AccountVerification represents a verification record
 type AccountVerification struct {
 	ID          int64      `json:"id"`
@@ -65,6 +148,10 @@ type AccountVerification struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 
+	// Review SLA (pending only): due after N business days, skipping weekends and holidays
+	ReviewDueAt   *time.Time `json:"review_due_at,omitempty"`
+	ReviewOverdue bool       `json:"review_overdue,omitempty"`
+
 	// Personal Profile Fields
 	FirstName               *string `json:"first_name"`
 	LastName                *string `json:"last_name"`
@@ -86,6 +173,9 @@ type AccountVerification struct {
 	MaritalStatus *string    `json:"marital_status,omitempty"` // SINGLE, MARRIED, DIVORCED
 	ChildrenCount *int       `json:"children_count,omitempty"`
 
+	// Province of the domicile city; regional admins only see candidates in their provinces
+	DomicileProvince *string `json:"domicile_province,omitempty"`
+
 	// HR Candidate Data: Core Competencies
 	MainJobFields         []string `json:"main_job_fields,omitempty"`
 	GoldenSkill           *string  `json:"golden_skill,omitempty"`
@@ -114,6 +204,27 @@ type AccountVerification struct {
 	// Onboarding: Interview Preferences
 	WillingToInterviewOnsite *bool `json:"willing_to_interview_onsite,omitempty"`
 
+	// Visa Readiness: Certificate of Eligibility & SSW exams
+	CoEStatus      *string         `json:"coe_status,omitempty"` // NONE, IN_PROCESS, ISSUED, EXPIRED
+	CoEIssuedDate  *time.Time      `json:"coe_issued_date,omitempty"`
+	CoEExpiryDate  *time.Time      `json:"coe_expiry_date,omitempty"`
+	SSWExamResults []SSWExamResult `json:"ssw_exam_results,omitempty"`
+
+	// Expiring Documents: passport, JLPT certificate and pre-departure medical check
+	PassportURL               *string    `json:"passport_url,omitempty"`
+	PassportExpiryDate        *time.Time `json:"passport_expiry_date,omitempty"`
+	JLPTCertificateExpiryDate *time.Time `json:"jlpt_certificate_expiry_date,omitempty"` // set when the employer/program requires a recent result
+	MedicalCheckURL           *string    `json:"medical_check_url,omitempty"`
+	MedicalCheckExpiryDate    *time.Time `json:"medical_check_expiry_date,omitempty"`
+
+	// Emergency Contact: all three fields are set together or not at all
+	EmergencyContactName         *string `json:"emergency_contact_name,omitempty"`
+	EmergencyContactPhone        *string `json:"emergency_contact_phone,omitempty"`        // normalized to E.164
+	EmergencyContactRelationship *string `json:"emergency_contact_relationship,omitempty"` // PARENT, GUARDIAN, SPOUSE, ...
+
+	// Guardian Consent: signed consent document, required before a minor's profile is submitted
+	GuardianConsentURL *string `json:"guardian_consent_url,omitempty"`
+
 	// Additional data for display
 	UserProfile *UserProfileSummary `json:"user_profile,omitempty"`
 }
@@ -137,6 +248,45 @@ type VerificationResponse struct {
 	Experiences  []JapanWorkExperience `json:"experiences"`
 }
 
+// Profile completeness checklist sections
+const (
+	ProfileSectionPhoto           = "photo"
+	ProfileSectionCV              = "cv"
+	ProfileSectionJLPTCertificate = "jlpt_certificate"
+	ProfileSectionWorkExperience  = "work_experience" // any entry in work_experiences or japan_work_experiences
+	ProfileSectionPreferences     = "preferences"     // expected salary and at least one preferred location
+)
+
+// ProfileCompletenessWeight is the points a complete section adds to the score
+type ProfileCompletenessWeight struct {
+	Section string
+	Points  int
+}
+
+// ProfileCompletenessWeights sum to 100, in checklist order. The ATS search
+// scores candidates in SQL with the same weights.
+var ProfileCompletenessWeights = []ProfileCompletenessWeight{
+	{ProfileSectionPhoto, 15},
+	{ProfileSectionCV, 30},
+	{ProfileSectionJLPTCertificate, 20},
+	{ProfileSectionWorkExperience, 20},
+	{ProfileSectionPreferences, 15},
+}
+
+// ProfileChecklistItem is one section of the completeness checklist
+type ProfileChecklistItem struct {
+	Section  string `json:"section"`
+	Points   int    `json:"points"`
+	Complete bool   `json:"complete"`
+}
+
+// ProfileCompleteness is a candidate's 0-100 score with the sections still missing
+type ProfileCompleteness struct {
+	Score     int                    `json:"score"`
+	Checklist []ProfileChecklistItem `json:"checklist"`
+	Missing   []string               `json:"missing"`
+}
+
 // ComprehensiveVerificationResponse aggregates ALL candidate data for admin review
 type ComprehensiveVerificationResponse struct {
 	Verification     *AccountVerification   `json:"verification"`
@@ -161,6 +311,9 @@ type VerificationFilter struct {
 	Status string `json:"status,omitempty"`
 	Page   int    `json:"page"`
 	Limit  int    `json:"limit"`
+
+	// Lower-cased provinces of a regional admin; nil for unrestricted admins
+	ScopeProvinces []string `json:"-"`
 }
 
 // VerificationRepository interface
@@ -174,6 +327,9 @@ type VerificationRepository interface {
 	Create(ctx context.Context, verification *AccountVerification) (int64, error)
 	UpdateProfile(ctx context.Context, verification *AccountVerification, experiences []JapanWorkExperience) error
 	GetWorkExperiences(ctx context.Context, verificationID int64) ([]JapanWorkExperience, error)
+	// HasWorkExperience reports whether the candidate has any work history,
+	// unified (work_experiences) or legacy (japan_work_experiences)
+	HasWorkExperience(ctx context.Context, userID string) (bool, error)
 
 	// Comprehensive data for admin verification detail
 	GetComprehensiveByID(ctx context.Context, id int64) (*ComprehensiveVerificationResponse, error)
@@ -190,7 +346,13 @@ type VerificationUsecase interface {
 	GetVerificationStatus(ctx context.Context, userID string) (*VerificationResponse, error)
 	GetVerificationByID(ctx context.Context, id int64) (*VerificationResponse, error) // For admin detail view
 	UpdateCandidateProfile(ctx context.Context, userID string, verification *AccountVerification, experiences []JapanWorkExperience) error
+	// GetProfileCompleteness scores the candidate's own profile; no verification record scores 0
+	GetProfileCompleteness(ctx context.Context, userID string) (*ProfileCompleteness, error)
 
 	// Comprehensive data for admin verification detail
 	GetComprehensiveVerificationByID(ctx context.Context, id int64) (*ComprehensiveVerificationResponse, error)
+
+	// Required/optional/hidden fields per role (CANDIDATE, EMPLOYER)
+	GetVerificationSchema(ctx context.Context, role string) (*VerificationSchema, error)
+	UpdateVerificationSchema(ctx context.Context, adminID, role string, req UpdateVerificationSchemaRequest) (*VerificationSchema, error)
 }
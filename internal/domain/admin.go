@@ -11,6 +11,8 @@ type AdminStats struct {
 	TotalJobs         int64             `json:"totalJobs"`
 	ActiveJobs        int64             `json:"activeJobs"`
 	TotalApplications int64             `json:"totalApplications"`
+	TotalPlacements   int64             `json:"totalPlacements"`
+	ActivePlacements  int64             `json:"activePlacements"`
 	SystemHealth      SystemHealth      `json:"systemHealth"`
 }
 
@@ -92,7 +94,8 @@ type AdminRepository interface {
 	GetStats(ctx context.Context) (*AdminStats, error)
 
 	// Users
-	ListUsers(ctx context.Context, role string, page, pageSize int) ([]AdminUser, int64, error)
+	// ListUsers limits users to provinces when non-nil (regional admins)
+	ListUsers(ctx context.Context, role string, provinces []string, page, pageSize int) ([]AdminUser, int64, error)
 	DisableUser(ctx context.Context, userID string, disable bool) error
 	CreateUser(ctx context.Context, user AdminUser) error
 	UpdateUser(ctx context.Context, user AdminUser) error
@@ -114,6 +117,8 @@ type AdminUsecase interface {
 	GetStats(ctx context.Context) (*AdminStats, error)
 
 	// Users
+	// ListUsers only returns users in a regional admin's provinces; managing
+	// users is for unrestricted admins
 	ListUsers(ctx context.Context, role string, page, pageSize int) (*PaginatedResult[AdminUser], error)
 	DisableUser(ctx context.Context, userID string, disable bool) (*AdminUser, error)
 	CreateUser(ctx context.Context, req CreateUserRequest) (*AdminUser, error)
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMFAAlreadyEnrolled is returned when a new secret is requested for a confirmed enrollment
+var ErrMFAAlreadyEnrolled = errors.New("mfa already enrolled")
+
+// Wrong codes allowed before verification is locked for MFALockDuration
+const (
+	MaxMFAFailedAttempts = 5
+	MFALockDuration      = 15 * time.Minute
+)
+
+// MFA error codes returned with the 403 when an admin session is not verified
+const (
+	MFAErrorEnrollmentRequired   = "mfa_enrollment_required"
+	MFAErrorVerificationRequired = "mfa_verification_required"
+)
+
+// UserMFA is a platform user's TOTP enrollment
+type UserMFA struct {
+	UserID         string
+	Secret         string     // Base32
+	EnabledAt      *time.Time // nil while the enrollment is pending confirmation
+	FailedAttempts int
+	LockedUntil    *time.Time
+}
+
+// MFAStatus tells an admin whether this session may use admin routes
+type MFAStatus struct {
+	Required  bool       `json:"required"`
+	Enrolled  bool       `json:"enrolled"`
+	Verified  bool       `json:"verified"` // TOTP verified for the current session
+	EnabledAt *time.Time `json:"enabled_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // when this session must verify again
+}
+
+// MFAEnrollment is shown once so the admin can add it to an authenticator app
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpauthURL string `json:"otpauth_url"`
+}
+
+// MFACodeRequest carries a 6-digit TOTP code
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type AdminMFARepository interface {
+	Get(ctx context.Context, userID string) (*UserMFA, error)
+	// SavePending stores a new secret; ErrMFAAlreadyEnrolled once the enrollment is confirmed
+	SavePending(ctx context.Context, userID, secret string) error
+	Enable(ctx context.Context, userID string, at time.Time) error
+	// RecordFailure counts a wrong code and locks verification until lockUntil on the
+	// maxAttempts-th consecutive failure
+	RecordFailure(ctx context.Context, userID string, maxAttempts int, lockUntil time.Time) error
+	ResetFailures(ctx context.Context, userID string) error
+	// Delete removes the enrollment and every verified session of the user
+	Delete(ctx context.Context, userID string) error
+
+	CreateSession(ctx context.Context, userID, sessionID string, expiresAt time.Time) error
+	// SessionExpiry returns when the session's verification expires, or ErrNotFound
+	SessionExpiry(ctx context.Context, userID, sessionID string, now time.Time) (time.Time, error)
+}
+
+type AdminMFAUsecase interface {
+	Status(ctx context.Context, userID, sessionID string) (*MFAStatus, error)
+	// Enroll starts (or restarts) a pending enrollment with a new secret
+	Enroll(ctx context.Context, userID, email string) (*MFAEnrollment, error)
+	// ConfirmEnrollment enables TOTP with a code from the new secret and verifies the session
+	ConfirmEnrollment(ctx context.Context, userID, sessionID, code string) (*MFAStatus, error)
+	// Verify checks a code and marks the session verified
+	Verify(ctx context.Context, userID, sessionID, code string) (*MFAStatus, error)
+	// Reset removes another admin's enrollment, e.g. after a lost device (admin only)
+	Reset(ctx context.Context, adminID, targetUserID string) error
+}
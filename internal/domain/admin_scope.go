@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// KeyAdminProvinces holds the lower-cased provinces of a regional admin; unset
+// for unrestricted admins and everyone else
+const KeyAdminProvinces CtxKey = "AdminProvinces"
+
+// MaxAdminScopeProvinces caps the provinces of one regional admin
+const MaxAdminScopeProvinces = 40
+
+// AdminScope makes an admin regional: they only see candidates whose domicile
+// province and companies whose province is listed. Admins without a scope are
+// unrestricted and manage the scopes of the others.
+type AdminScope struct {
+	AdminUserID string    `json:"admin_user_id"`
+	Email       string    `json:"email"`
+	Provinces   []string  `json:"provinces"`
+	UpdatedBy   *string   `json:"updated_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MyAdminScope is the caller's own scope; Provinces is empty when unrestricted
+type MyAdminScope struct {
+	Unrestricted bool     `json:"unrestricted"`
+	Provinces    []string `json:"provinces"`
+}
+
+type SetAdminScopeRequest struct {
+	Provinces []string `json:"provinces" binding:"required,min=1,max=40,dive,required,max=120"`
+}
+
+// AdminProvincesFromContext returns the caller's regional scope, nil when the
+// request is not limited to any province
+func AdminProvincesFromContext(ctx context.Context) []string {
+	if v, ok := ctx.Value(KeyAdminProvinces).([]string); ok {
+		return v
+	}
+	v, _ := ctx.Value(string(KeyAdminProvinces)).([]string)
+	return v
+}
+
+// WithAdminProvinces returns ctx limited to the given lower-cased provinces
+func WithAdminProvinces(ctx context.Context, provinces []string) context.Context {
+	return context.WithValue(ctx, KeyAdminProvinces, provinces)
+}
+
+// ProvinceInScope reports whether a record in province is visible under scope.
+// Everything is visible without a scope; with one, records without a province
+// are not.
+func ProvinceInScope(scope []string, province *string) bool {
+	if scope == nil {
+		return true
+	}
+	if province == nil {
+		return false
+	}
+	p := strings.ToLower(strings.TrimSpace(*province))
+	for _, s := range scope {
+		if s == p {
+			return true
+		}
+	}
+	return false
+}
+
+type AdminScopeRepository interface {
+	// Get returns ErrNotFound for unrestricted admins
+	Get(ctx context.Context, adminUserID string) (*AdminScope, error)
+	List(ctx context.Context) ([]AdminScope, error)
+	Upsert(ctx context.Context, scope *AdminScope) error
+	Delete(ctx context.Context, adminUserID string) error
+}
+
+type AdminScopeUsecase interface {
+	// Provinces is the admin's lower-cased scope for AdminScopeMiddleware, nil
+	// when unrestricted
+	Provinces(ctx context.Context, adminUserID string) ([]string, error)
+	GetMyScope(ctx context.Context) (*MyAdminScope, error)
+
+	// Unrestricted admins only
+	ListScopes(ctx context.Context) ([]AdminScope, error)
+	SetScope(ctx context.Context, adminUserID string, req SetAdminScopeRequest) (*AdminScope, error)
+	ClearScope(ctx context.Context, adminUserID string) error
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "context"
+
+// Admin search result types
+const (
+	AdminSearchTypeUser      = "user"
+	AdminSearchTypeCandidate = "candidate"
+	AdminSearchTypeCompany   = "company"
+	AdminSearchTypeJob       = "job"
+)
+
+// AdminSearchTypes lists result types in the order groups are returned
+var AdminSearchTypes = []string{AdminSearchTypeUser, AdminSearchTypeCandidate, AdminSearchTypeCompany, AdminSearchTypeJob}
+
+// AdminSearchResult is one match with a link to its admin detail page
+type AdminSearchResult struct {
+	Type     string  `json:"type"`
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Link     string  `json:"link"`
+	Score    float64 `json:"score"` // trigram similarity, 1 for exact matches
+}
+
+// AdminSearchGroup holds the matches of one result type, best first
+type AdminSearchGroup struct {
+	Type    string              `json:"type"`
+	Results []AdminSearchResult `json:"results"`
+}
+
+// AdminSearchResponse is the global search result
+type AdminSearchResponse struct {
+	Query  string             `json:"query"`
+	Groups []AdminSearchGroup `json:"groups"`
+}
+
+type AdminSearchRepository interface {
+	SearchUsers(ctx context.Context, query string, limit int) ([]AdminSearchResult, error)
+	// SearchCandidates matches names, and phone numbers when phoneDigits is not empty.
+	// Candidates and companies are limited to provinces when it is non-nil.
+	SearchCandidates(ctx context.Context, query, phoneDigits string, provinces []string, limit int) ([]AdminSearchResult, error)
+	SearchCompanies(ctx context.Context, query string, provinces []string, limit int) ([]AdminSearchResult, error)
+	SearchJobs(ctx context.Context, query string, limit int) ([]AdminSearchResult, error)
+}
+
+type AdminSearchUsecase interface {
+	// Search runs the permitted result types in parallel; types limits the search to some of them
+	Search(ctx context.Context, query string, types []string, limit int) (*AdminSearchResponse, error)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Derived candidate aggregates maintained by the recompute job
+const (
+	AggregateTotalExperienceMonths = "total_experience_months" // candidate_profiles, from work_experiences
+	AggregateQuizBestScore         = "quiz_best_score"         // candidate_quiz_scores, from submitted attempts
+)
+
+// RecomputeAggregates lists every aggregate a run checks, in order
+var RecomputeAggregates = []string{AggregateTotalExperienceMonths, AggregateQuizBestScore}
+
+// Recompute run trigger sources and statuses
+const (
+	RecomputeTriggerScheduled = "SCHEDULED"
+	RecomputeTriggerManual    = "MANUAL"
+
+	RecomputeStatusRunning   = "RUNNING"
+	RecomputeStatusCompleted = "COMPLETED"
+	RecomputeStatusFailed    = "FAILED"
+)
+
+// MaxDriftItemsPerAggregate caps how many drifted rows a run records per aggregate;
+// all drifted rows are still fixed
+const MaxDriftItemsPerAggregate = 500
+
+// AggregateDrift is one stored value that disagreed with its source rows
+type AggregateDrift struct {
+	Aggregate     string  `json:"aggregate"`
+	UserID        string  `json:"user_id"`
+	SubjectRef    *string `json:"subject_ref,omitempty"` // e.g. quiz id
+	StoredValue   *int    `json:"stored_value"`
+	ComputedValue *int    `json:"computed_value"`
+}
+
+// AggregateRecomputeRun is one pass over all aggregates
+type AggregateRecomputeRun struct {
+	ID            int64            `json:"id"`
+	TriggerSource string           `json:"trigger_source"` // SCHEDULED, MANUAL
+	Status        string           `json:"status"`         // RUNNING, COMPLETED, FAILED
+	CheckedCount  int              `json:"checked_count"`
+	DriftCount    int              `json:"drift_count"`
+	FixedCount    int              `json:"fixed_count"`
+	ErrorMessage  *string          `json:"error_message,omitempty"`
+	StartedAt     time.Time        `json:"started_at"`
+	FinishedAt    *time.Time       `json:"finished_at,omitempty"`
+	DriftItems    []AggregateDrift `json:"drift_items,omitempty"` // detail view only
+}
+
+type AggregateRepository interface {
+	// CountRows returns how many stored values exist for the aggregate
+	CountRows(ctx context.Context, aggregate string) (int, error)
+	// FindDrift returns up to limit stored values that differ from a fresh computation,
+	// plus the total number of drifted rows
+	FindDrift(ctx context.Context, aggregate string, limit int) ([]AggregateDrift, int, error)
+	// FixDrift rewrites every drifted value and returns how many rows changed
+	FixDrift(ctx context.Context, aggregate string) (int, error)
+
+	CreateRun(ctx context.Context, run *AggregateRecomputeRun) error
+	// FinishRun stores the run's final counters, status and drift items
+	FinishRun(ctx context.Context, run *AggregateRecomputeRun) error
+	ListRuns(ctx context.Context, limit int) ([]AggregateRecomputeRun, error)
+	GetRun(ctx context.Context, id int64) (*AggregateRecomputeRun, error)
+}
+
+type AggregateUsecase interface {
+	// RunRecompute is called by the nightly worker
+	RunRecompute(ctx context.Context, triggerSource string) (*AggregateRecomputeRun, error)
+
+	// Admin
+	TriggerRecompute(ctx context.Context) (*AggregateRecomputeRun, error)
+	ListRuns(ctx context.Context) ([]AggregateRecomputeRun, error)
+	GetDriftReport(ctx context.Context, runID int64) (*AggregateRecomputeRun, error)
+}
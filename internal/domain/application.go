@@ -13,6 +13,25 @@ const (
 	ApplicationStatusRejected = "rejected"
 )
 
+// ApplicationStatuses lists every application status
+var ApplicationStatuses = []string{
+	ApplicationStatusApplied,
+	ApplicationStatusReviewed,
+	ApplicationStatusAccepted,
+	ApplicationStatusRejected,
+}
+
+// Screening question types
+const (
+	QuestionTypeText         = "TEXT"
+	QuestionTypeSingleChoice = "SINGLE_CHOICE"
+	QuestionTypeYesNo        = "YES_NO"
+	QuestionTypeFile         = "FILE"
+)
+
+// MaxScreeningQuestions caps questions per job
+const MaxScreeningQuestions = 20
+
 // Application represents a job application from a candidate
 type Application struct {
 	ID                    int64     `json:"id"`
@@ -21,10 +40,18 @@ type Application struct {
 	AccountVerificationID *int64    `json:"account_verification_id,omitempty"`
 	CvURL                 string    `json:"cv_url"` // Required
 	CoverLetter           *string   `json:"cover_letter,omitempty"`
-	Status                string    `json:"status"` // applied → reviewed → accepted / rejected
+	Status                string    `json:"status"`        // applied → reviewed → accepted / rejected
+	AutoRejected          bool      `json:"auto_rejected"` // rejected by a screening knock-out rule
 	CreatedAt             time.Time `json:"created_at"`
 	UpdatedAt             time.Time `json:"updated_at"`
 
+	// Pipeline stage (employer views only, see ApplicationStages)
+	Stage          string     `json:"stage,omitempty"`
+	StageChangedAt *time.Time `json:"stage_changed_at,omitempty"`
+
+	// Screening answers (written with the application, returned in detail views)
+	Answers []ApplicationAnswer `json:"answers,omitempty"`
+
 	// Joined data for list responses
 	CandidateName      *string `json:"candidate_name,omitempty"`
 	CandidatePhoto     *string `json:"candidate_photo,omitempty"`
@@ -32,6 +59,59 @@ type Application struct {
 	JobTitle           *string `json:"job_title,omitempty"`
 }
 
+// ScreeningQuestion is a question an employer attaches to a job.
+// KnockoutAnswers are hidden from candidates (see ForCandidate).
+type ScreeningQuestion struct {
+	ID              int64    `json:"id"`
+	JobID           int64    `json:"job_id"`
+	Position        int      `json:"position"`
+	Type            string   `json:"type"` // TEXT, SINGLE_CHOICE, YES_NO, FILE
+	Prompt          string   `json:"prompt"`
+	Options         []string `json:"options,omitempty"` // SINGLE_CHOICE only
+	Required        bool     `json:"required"`
+	KnockoutAnswers []string `json:"knockout_answers,omitempty"`
+}
+
+// ForCandidate strips the knock-out rules
+func (q ScreeningQuestion) ForCandidate() ScreeningQuestion {
+	q.KnockoutAnswers = nil
+	return q
+}
+
+// ScreeningQuestionInput is one question in a replace request; order is list order
+type ScreeningQuestionInput struct {
+	Type            string   `json:"type" binding:"required,oneof=TEXT SINGLE_CHOICE YES_NO FILE"`
+	Prompt          string   `json:"prompt" binding:"required,max=500"`
+	Options         []string `json:"options" binding:"omitempty,max=20,dive,required,max=200"`
+	Required        *bool    `json:"required"` // defaults to true
+	KnockoutAnswers []string `json:"knockout_answers" binding:"omitempty,dive,required"`
+}
+
+// ReplaceScreeningQuestionsRequest replaces all questions on a job
+type ReplaceScreeningQuestionsRequest struct {
+	Questions []ScreeningQuestionInput `json:"questions" binding:"omitempty,dive"`
+}
+
+// ScreeningAnswerInput is a candidate's answer at apply time.
+// YES_NO answers are "yes" or "no"; FILE questions use FileURL.
+type ScreeningAnswerInput struct {
+	QuestionID int64   `json:"question_id" binding:"required"`
+	Answer     string  `json:"answer" binding:"max=2000"`
+	FileURL    *string `json:"file_url" binding:"omitempty,url"`
+}
+
+// ApplicationAnswer is a stored answer; Prompt/Type are snapshots of the question
+type ApplicationAnswer struct {
+	ID            int64   `json:"id"`
+	ApplicationID int64   `json:"application_id"`
+	QuestionID    *int64  `json:"question_id,omitempty"` // nil once the question was removed
+	Prompt        string  `json:"prompt"`
+	Type          string  `json:"type"`
+	Answer        *string `json:"answer,omitempty"`
+	FileURL       *string `json:"file_url,omitempty"`
+	Disqualifying bool    `json:"disqualifying"`
+}
+
 // ApplicationDetailResponse contains full application details including candidate profile
 type ApplicationDetailResponse struct {
 	Application  *Application          `json:"application"`
@@ -39,24 +119,165 @@ type ApplicationDetailResponse struct {
 	Experiences  []JapanWorkExperience `json:"experiences,omitempty"`
 }
 
+// ApplicationExportColumns lists the columns an employer can select for an applicant export
+var ApplicationExportColumns = []string{
+	"application_id",
+	"candidate_name",
+	"email",
+	"phone",
+	"contact_unlocked",
+	"stage",
+	"auto_rejected",
+	"applied_at",
+	"last_updated_at",
+	"verification_status",
+	"japanese_level",
+	"domicile_city",
+	"cv_url",
+	"cover_letter",
+	"screening_answers",
+}
+
+// ApplicationExportRow is one applicant in a job export.
+// Email and Phone are only set when the company unlocked the candidate's contact
+// and the candidate's profile is not paused.
+type ApplicationExportRow struct {
+	ApplicationID      int64
+	CandidateUserID    string
+	CandidateName      string
+	Email              *string
+	Phone              *string
+	ContactUnlocked    bool
+	Status             string
+	Stage              string
+	AutoRejected       bool
+	AppliedAt          time.Time
+	UpdatedAt          time.Time
+	VerificationStatus *string
+	JapaneseLevel      *string
+	DomicileCity       *string
+	CvURL              string
+	CoverLetter        *string
+	ScreeningAnswers   *string // "prompt: answer" lines
+}
+
+// ApplicationExportRequest selects columns; request metadata goes to the PII access log
+type ApplicationExportRequest struct {
+	Columns   []string
+	IPAddress string
+	UserAgent string
+}
+
+// Candidate application history sorting and page size
+const (
+	ApplicationHistorySortApplied     = "applied_at"
+	ApplicationHistorySortUpdated     = "updated_at"
+	DefaultApplicationHistoryPageSize = 20
+	MaxApplicationHistoryPageSize     = 100
+)
+
+// ApplicationHistoryFilter selects and orders a candidate's applications
+type ApplicationHistoryFilter struct {
+	Status    string // applied, reviewed, accepted, rejected; empty for all
+	SortBy    string // applied_at, updated_at (default)
+	SortOrder string // asc, desc (default)
+	Page      int
+	PageSize  int
+}
+
+// CandidateApplicationView is one application on a candidate's history timeline
+type CandidateApplicationView struct {
+	ID             int64     `json:"id"`
+	Status         string    `json:"status"`
+	Stage          string    `json:"stage"`
+	StageChangedAt time.Time `json:"stage_changed_at"`
+	AutoRejected   bool      `json:"auto_rejected"`
+	AppliedAt      time.Time `json:"applied_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Job CandidateApplicationJob `json:"job"`
+
+	StageHistory []CandidateStageChange          `json:"stage_history"` // oldest first
+	Interviews   []CandidateApplicationInterview `json:"interviews"`    // by scheduled time
+	Feedback     *CandidateApplicationFeedback   `json:"feedback,omitempty"`
+}
+
+// CandidateApplicationJob is the job as the applicant sees it; the company of a
+// confidential job stays hidden
+type CandidateApplicationJob struct {
+	ID             int64   `json:"id"`
+	Title          string  `json:"title"`
+	Location       string  `json:"location"`
+	EmploymentType *string `json:"employment_type,omitempty"`
+	Status         string  `json:"status"` // the job's publication state, e.g. closed
+	CompanyName    *string `json:"company_name,omitempty"`
+	CompanyLogoURL *string `json:"company_logo_url,omitempty"`
+}
+
+// CandidateStageChange is a stage move without the employer's note and author
+type CandidateStageChange struct {
+	FromStage *string   `json:"from_stage,omitempty"`
+	ToStage   string    `json:"to_stage"`
+	Status    string    `json:"status"` // candidate-facing status of ToStage
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// CandidateApplicationInterview is a call booked for the application
+type CandidateApplicationInterview struct {
+	ID              int64     `json:"id"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Status          string    `json:"status"` // CONFIRMED, CANCELLED, COMPLETED, NO_ANSWER
+}
+
+// CandidateApplicationFeedback is interview feedback delivered to the candidate
+type CandidateApplicationFeedback struct {
+	TemplateKeys  []string  `json:"template_keys"`
+	Messages      []string  `json:"messages"` // template wordings in the request's language
+	CustomMessage *string   `json:"custom_message,omitempty"`
+	SentAt        time.Time `json:"sent_at"`
+}
+
 // ApplicationRepository defines data access methods for applications
 type ApplicationRepository interface {
-	Create(ctx context.Context, app *Application) error
+	Create(ctx context.Context, app *Application) error // also inserts app.Answers in the same transaction
 	GetByID(ctx context.Context, id int64) (*Application, error)
 	GetByJobID(ctx context.Context, jobID int64) ([]Application, error)
 	GetByUserID(ctx context.Context, userID string) ([]Application, error)
+	// ListCandidateHistory returns one page of the candidate's applications with
+	// their job, stage history, interviews and delivered feedback, and the total
+	ListCandidateHistory(ctx context.Context, userID string, filter ApplicationHistoryFilter) ([]CandidateApplicationView, int64, error)
 	CheckExists(ctx context.Context, jobID int64, userID string) (bool, error)
-	UpdateStatus(ctx context.Context, id int64, status string) error
+	// UpdateStatus also moves the pipeline stage to match (see ApplicationStageForStatus)
+	// and records the move in the stage history
+	UpdateStatus(ctx context.Context, id int64, status, changedBy string) error
+	GetAnswers(ctx context.Context, applicationID int64) ([]ApplicationAnswer, error)
+	// ListForExport returns the job's applicants with contact details gated for companyID
+	ListForExport(ctx context.Context, jobID, companyID int64) ([]ApplicationExportRow, error)
+}
+
+// ScreeningQuestionRepository stores per-job screening questions
+type ScreeningQuestionRepository interface {
+	ListByJobID(ctx context.Context, jobID int64) ([]ScreeningQuestion, error)
+	// ReplaceForJob swaps the whole question set in one transaction and fills IDs
+	ReplaceForJob(ctx context.Context, jobID int64, questions []ScreeningQuestion) error
 }
 
 // ApplicationUsecase defines business logic for applications
 type ApplicationUsecase interface {
 	// Candidate operations
-	ApplyToJob(ctx context.Context, userID string, jobID int64, cvURL, coverLetter string) (*Application, error)
+	ApplyToJob(ctx context.Context, userID string, jobID int64, cvURL, coverLetter string, answers []ScreeningAnswerInput) (*Application, error)
+	GetScreeningQuestions(ctx context.Context, jobID int64) ([]ScreeningQuestion, error) // candidate view
 	GetMyApplications(ctx context.Context, userID string) ([]Application, error)
+	// GetMyApplicationHistory is the candidate's application timeline; feedback is in locale
+	GetMyApplicationHistory(ctx context.Context, userID string, filter ApplicationHistoryFilter, locale string) (*PaginatedResult[CandidateApplicationView], error)
 
 	// Employer operations
 	ListByJobID(ctx context.Context, userID string, jobID int64) ([]Application, error)
 	GetApplicationDetail(ctx context.Context, userID string, applicationID int64) (*ApplicationDetailResponse, error)
 	UpdateApplicationStatus(ctx context.Context, userID string, applicationID int64, status string) error
+	GetJobScreeningQuestions(ctx context.Context, userID string, jobID int64) ([]ScreeningQuestion, error) // includes knock-out rules
+	ReplaceScreeningQuestions(ctx context.Context, userID string, jobID int64, req ReplaceScreeningQuestionsRequest) ([]ScreeningQuestion, error)
+	// ExportJobApplications returns an XLSX file and its name; each export is written to the PII access log
+	ExportJobApplications(ctx context.Context, userID string, jobID int64, req ApplicationExportRequest) ([]byte, string, error)
 }
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ApplicationDraft is an application a candidate started but has not submitted.
+// Answers are checked against the job's screening questions only on submit.
+type ApplicationDraft struct {
+	CandidateUserID string                 `json:"-"`
+	JobID           int64                  `json:"job_id"`
+	CvURL           *string                `json:"cv_url,omitempty"`
+	CoverLetter     *string                `json:"cover_letter,omitempty"`
+	Answers         []ScreeningAnswerInput `json:"answers"`
+	ReminderSentAt  *time.Time             `json:"reminder_sent_at,omitempty"`
+	ExpiresAt       time.Time              `json:"expires_at"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// SaveApplicationDraftRequest replaces the stored draft
+type SaveApplicationDraftRequest struct {
+	CvURL       string                 `json:"cv_url" binding:"max=2048"`
+	CoverLetter string                 `json:"cover_letter" binding:"max=10000"`
+	Answers     []ScreeningAnswerInput `json:"answers" binding:"omitempty,max=50,dive"`
+}
+
+// ApplicationDraftReminderCandidate is a draft due for a reminder, with what is
+// needed to address the candidate
+type ApplicationDraftReminderCandidate struct {
+	CandidateUserID string
+	JobID           int64
+	JobTitle        string
+	CompanyName     string
+	ExpiresAt       time.Time
+	Email           string
+	FirstName       *string
+	PreferredLocale *string
+}
+
+// ApplicationDraftRunResult summarizes one reminder worker run
+type ApplicationDraftRunResult struct {
+	Due        int       `json:"due"` // drafts due for a reminder
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+	Expired    int64     `json:"expired"` // drafts deleted past their expiry
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+type ApplicationDraftRepository interface {
+	Get(ctx context.Context, userID string, jobID int64) (*ApplicationDraft, error)
+	// Upsert stores the draft and clears reminder_sent_at so a later reminder can go out
+	Upsert(ctx context.Context, draft *ApplicationDraft) error
+	Delete(ctx context.Context, userID string, jobID int64) error
+
+	// ListDueReminders returns unexpired drafts last saved before savedBefore with no
+	// reminder yet, skipping unpublished jobs and jobs the candidate has applied to since
+	ListDueReminders(ctx context.Context, savedBefore, now time.Time, limit int) ([]ApplicationDraftReminderCandidate, error)
+	MarkReminded(ctx context.Context, userID string, jobID int64, at time.Time) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type ApplicationDraftUsecase interface {
+	GetDraft(ctx context.Context, userID string, jobID int64) (*ApplicationDraft, error)
+	SaveDraft(ctx context.Context, userID string, jobID int64, req SaveApplicationDraftRequest) (*ApplicationDraft, error)
+
+	// RunReminders is called by the background worker: reminds candidates of
+	// drafts left unsubmitted and deletes expired drafts
+	RunReminders(ctx context.Context) (*ApplicationDraftRunResult, error)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MinInsightsBenchmarkSample is the fewest hired candidates a benchmark is built from;
+// smaller groups are withheld so no individual can be singled out
+const MinInsightsBenchmarkSample = 10
+
+// InsightsTopSkills is how many of the hired candidates' most common skills are compared
+const InsightsTopSkills = 10
+
+// InterviewStages are the stages that count as an application reaching interview
+var InterviewStages = []string{ApplicationStageInterview, ApplicationStageOffer, ApplicationStageHired}
+
+// ApplicationStageCount is the number of applications currently in one stage
+type ApplicationStageCount struct {
+	Stage string `json:"stage"`
+	Count int64  `json:"count"`
+}
+
+// ApplicationActivityStats are the raw counts behind a candidate's insights
+type ApplicationActivityStats struct {
+	Total             int64
+	ByStage           []ApplicationStageCount
+	ReachedInterview  int64    // applications that were ever in an interview stage or later
+	Responded         int64    // applications an employer moved out of their first stage
+	AvgResponseHours  *float64 // mean time to that first move
+	AwaitingResponses int64
+}
+
+// CandidateBenchmarkProfile is the part of a candidate profile compared against hired candidates
+type CandidateBenchmarkProfile struct {
+	MainJobFields         []string
+	JapaneseLevel         *string
+	JapanExperienceMonths *int
+	Skills                []string
+}
+
+// HiredCandidateBenchmark aggregates hired candidates in the same job fields; it holds
+// no per-candidate data
+type HiredCandidateBenchmark struct {
+	SampleSize             int64
+	MedianJapaneseLevel    *string
+	MedianExperienceMonths *float64
+	TopSkills              []BenchmarkSkill
+}
+
+// BenchmarkSkill is a skill and the share of hired candidates who list it
+type BenchmarkSkill struct {
+	Name    string  `json:"name"`
+	Percent float64 `json:"percent"`
+	HaveIt  bool    `json:"have_it"` // whether the caller lists the skill
+}
+
+// ApplicationInsights is a candidate's application performance and how their profile
+// compares with hired candidates
+type ApplicationInsights struct {
+	TotalApplications int64                   `json:"total_applications"`
+	ByStage           []ApplicationStageCount `json:"by_stage"`
+	ReachedInterview  int64                   `json:"reached_interview"`
+	InterviewRate     *float64                `json:"interview_rate"` // percent; null without applications
+	Responded         int64                   `json:"responded"`
+	AvgResponseHours  *float64                `json:"avg_response_hours"` // null until an employer responds
+	AwaitingResponse  int64                   `json:"awaiting_response"`
+
+	Benchmark   *ProfileBenchmark `json:"benchmark"` // null when there is no target field or too few hires
+	GeneratedAt time.Time         `json:"generated_at"`
+}
+
+// ProfileBenchmark sets the candidate's profile against anonymized hired-candidate aggregates
+type ProfileBenchmark struct {
+	JobFields  []string `json:"job_fields"`
+	SampleSize int64    `json:"sample_size"`
+
+	JapaneseLevel                *string  `json:"japanese_level"`
+	TypicalJapaneseLevel         *string  `json:"typical_japanese_level"`
+	JapanExperienceMonths        *int     `json:"japan_experience_months"`
+	TypicalJapanExperienceMonths *float64 `json:"typical_japan_experience_months"`
+
+	TopSkills     []BenchmarkSkill `json:"top_skills"`
+	SkillCoverage *float64         `json:"skill_coverage"` // percent of the top skills the candidate lists
+}
+
+type ApplicationInsightsRepository interface {
+	GetActivityStats(ctx context.Context, candidateUserID string) (*ApplicationActivityStats, error)
+	// GetBenchmarkProfile returns ErrNotFound when the candidate has no verification profile
+	GetBenchmarkProfile(ctx context.Context, candidateUserID string) (*CandidateBenchmarkProfile, error)
+	// GetHiredBenchmark aggregates other candidates hired into any of the job fields
+	GetHiredBenchmark(ctx context.Context, jobFields []string, excludeUserID string, topSkills int) (*HiredCandidateBenchmark, error)
+}
+
+type ApplicationInsightsUsecase interface {
+	GetMyInsights(ctx context.Context, userID string) (*ApplicationInsights, error)
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Application pipeline stages, in board order
+const (
+	ApplicationStageApplied   = "applied"
+	ApplicationStageScreening = "screening"
+	ApplicationStageInterview = "interview"
+	ApplicationStageOffer     = "offer"
+	ApplicationStageHired     = "hired"
+	ApplicationStageRejected  = "rejected"
+)
+
+// ApplicationStages lists every stage in board order
+var ApplicationStages = []string{
+	ApplicationStageApplied,
+	ApplicationStageScreening,
+	ApplicationStageInterview,
+	ApplicationStageOffer,
+	ApplicationStageHired,
+	ApplicationStageRejected,
+}
+
+// OptionalApplicationStages can be turned off per job; the others always exist
+var OptionalApplicationStages = []string{
+	ApplicationStageScreening,
+	ApplicationStageInterview,
+	ApplicationStageOffer,
+}
+
+// ApplicationStageStatus is the candidate-facing status of each stage
+var ApplicationStageStatus = map[string]string{
+	ApplicationStageApplied:   ApplicationStatusApplied,
+	ApplicationStageScreening: ApplicationStatusReviewed,
+	ApplicationStageInterview: ApplicationStatusReviewed,
+	ApplicationStageOffer:     ApplicationStatusReviewed,
+	ApplicationStageHired:     ApplicationStatusAccepted,
+	ApplicationStageRejected:  ApplicationStatusRejected,
+}
+
+// ApplicationStageForStatus maps a status update onto the pipeline. "reviewed"
+// moves a new application to screening but keeps one already further along.
+func ApplicationStageForStatus(currentStage, status string) string {
+	switch status {
+	case ApplicationStatusReviewed:
+		if ApplicationStageStatus[currentStage] == ApplicationStatusReviewed {
+			return currentStage
+		}
+		return ApplicationStageScreening
+	case ApplicationStatusAccepted:
+		return ApplicationStageHired
+	case ApplicationStatusRejected:
+		return ApplicationStageRejected
+	default:
+		return ApplicationStageApplied
+	}
+}
+
+// ErrApplicationStageConflict is returned when the application moved since it was read
+var ErrApplicationStageConflict = errors.New("application stage changed concurrently")
+
+// ApplicationStageChange is one entry of an application's stage history
+type ApplicationStageChange struct {
+	ID            int64     `json:"id"`
+	ApplicationID int64     `json:"application_id"`
+	FromStage     *string   `json:"from_stage,omitempty"` // nil for the initial stage
+	ToStage       string    `json:"to_stage"`
+	Note          *string   `json:"note,omitempty"`
+	ChangedBy     *string   `json:"changed_by,omitempty"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+// ApplicationStageView is an application's current stage with its history
+type ApplicationStageView struct {
+	ApplicationID   int64                    `json:"application_id"`
+	JobID           int64                    `json:"job_id"`
+	Stage           string                   `json:"stage"`
+	Status          string                   `json:"status"`
+	StageChangedAt  time.Time                `json:"stage_changed_at"`
+	AvailableStages []string                 `json:"available_stages"` // the job's pipeline
+	History         []ApplicationStageChange `json:"history"`          // oldest first
+}
+
+// MoveApplicationStageRequest moves an application to another stage
+type MoveApplicationStageRequest struct {
+	Stage string `json:"stage" binding:"required,oneof=applied screening interview offer hired rejected"`
+	Note  string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// JobPipeline is the stage configuration of a job
+type JobPipeline struct {
+	JobID  int64    `json:"job_id"`
+	Stages []string `json:"stages"` // all stages the job uses, in board order
+}
+
+// UpdateJobPipelineRequest selects the optional stages a job uses
+type UpdateJobPipelineRequest struct {
+	OptionalStages []string `json:"optional_stages" binding:"omitempty,max=3,dive,oneof=screening interview offer"`
+}
+
+// JobFunnel counts a job's applications per stage
+type JobFunnel struct {
+	JobID    int64          `json:"job_id"`
+	JobTitle string         `json:"job_title"`
+	Total    int            `json:"total"`
+	Stages   map[string]int `json:"stages"` // every stage, zero when empty
+}
+
+// ApplicationStageTarget is what is needed to move an application
+type ApplicationStageTarget struct {
+	ApplicationID   int64
+	JobID           int64
+	JobTitle        string
+	JobCompanyID    int64
+	CandidateUserID string
+	Stage           string
+	Status          string
+	StageChangedAt  time.Time
+	PipelineStages  []string // optional stages the job uses; nil means all
+}
+
+type ApplicationStageRepository interface {
+	GetTarget(ctx context.Context, applicationID int64) (*ApplicationStageTarget, error)
+	// MoveStage sets the stage and its status if the application is still in
+	// fromStage, and records the change in the same transaction. It returns
+	// ErrApplicationStageConflict when the stage moved in the meantime.
+	MoveStage(ctx context.Context, change *ApplicationStageChange, status string) error
+	ListHistory(ctx context.Context, applicationID int64) ([]ApplicationStageChange, error)
+
+	// GetPipelineStages returns the job's optional stages; nil means all
+	GetPipelineStages(ctx context.Context, jobID int64) ([]string, error)
+	SetPipelineStages(ctx context.Context, jobID int64, optional []string) error
+
+	// ListFunnels counts applications per stage for the company's jobs, or one job
+	// when jobID is set
+	ListFunnels(ctx context.Context, companyID int64, jobID *int64) ([]JobFunnel, error)
+}
+
+type ApplicationStageUsecase interface {
+	GetStage(ctx context.Context, userID string, applicationID int64) (*ApplicationStageView, error)
+	MoveStage(ctx context.Context, userID string, applicationID int64, req MoveApplicationStageRequest) (*ApplicationStageView, error)
+
+	GetPipeline(ctx context.Context, userID string, jobID int64) (*JobPipeline, error)
+	UpdatePipeline(ctx context.Context, userID string, jobID int64, req UpdateJobPipelineRequest) (*JobPipeline, error)
+
+	GetJobFunnel(ctx context.Context, userID string, jobID int64) (*JobFunnel, error)
+	ListFunnels(ctx context.Context, userID string) ([]JobFunnel, error)
+}
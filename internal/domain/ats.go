@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -51,6 +52,7 @@ type ATSFilter struct {
 	JapanExperienceMin *int     `json:"japan_experience_min,omitempty"` // Months
 	JapanExperienceMax *int     `json:"japan_experience_max,omitempty"` // Months
 	HasLPKTraining     *bool    `json:"has_lpk_training,omitempty"`     // true/false/nil(any)
+	CohortIDs          []int64  `json:"cohort_ids,omitempty"`           // Members of any of these training cohorts (admin only)
 
 	// Competency & Language Group
 	EnglishCertTypes  []string `json:"english_cert_types,omitempty"`  // TOEFL, IELTS, TOEIC
@@ -73,11 +75,31 @@ type ATSFilter struct {
 	TotalExperienceMin *int     `json:"total_experience_min,omitempty"` // Months
 	TotalExperienceMax *int     `json:"total_experience_max,omitempty"` // Months
 
+	// Trust Signals Group
+	PhoneVerifiedOnly bool `json:"phone_verified_only,omitempty"` // Only candidates with OTP-verified phone
+
+	// Visa Readiness Group
+	CoEStatuses   []string `json:"coe_statuses,omitempty"`    // NONE, IN_PROCESS, ISSUED, EXPIRED
+	VisaReadyOnly bool     `json:"visa_ready_only,omitempty"` // Only candidates holding an unexpired CoE
+	SSWSectors    []string `json:"ssw_sectors,omitempty"`     // Passed SSW exam in any of these sectors
+	SSWLevel      *int     `json:"ssw_level,omitempty"`       // Minimum SSW level (1 or 2) for the sectors above
+
+	// Document Expiry Group
+	ExpiringDocumentsOnly bool `json:"expiring_documents_only,omitempty"` // Only candidates with critical documents expired or expiring soon
+
+	// Skill Quiz Group
+	QuizID       *int64 `json:"quiz_id,omitempty"`        // Quiz whose best score is shown, filtered and sorted on
+	QuizMinScore *int   `json:"quiz_min_score,omitempty"` // Minimum best score (percent) on QuizID
+
 	// Pagination & Sorting
 	Page      int    `json:"page"`
 	PageSize  int    `json:"page_size"`
-	SortBy    string `json:"sort_by,omitempty"`    // verified_at, japanese_level, age, expected_salary
+	SortBy    string `json:"sort_by,omitempty"`    // verified_at, japanese_level, age, expected_salary, quiz_score, profile_completeness
 	SortOrder string `json:"sort_order,omitempty"` // asc, desc
+
+	// Lower-cased provinces of a regional admin, set by the usecase; nil for
+	// unrestricted admins and employers
+	ScopeProvinces []string `json:"-"`
 }
 
 // ============================================================================
@@ -118,21 +140,62 @@ type ATSCandidate struct {
 	ExpectedSalary     *int64     `json:"expected_salary,omitempty"`
 	AvailableStartDate *time.Time `json:"available_start_date,omitempty"`
 
+	// Trust Signals
+	PhoneVerified bool `json:"phone_verified"` // Badge: phone confirmed via OTP
+
+	// Visa Readiness
+	CoEStatus     *string    `json:"coe_status,omitempty"`
+	CoEExpiryDate *time.Time `json:"coe_expiry_date,omitempty"`
+	VisaReady     bool       `json:"visa_ready"`            // Badge: CoE issued and not yet expired
+	SSWSectors    []string   `json:"ssw_sectors,omitempty"` // Sectors with a passed SSW exam
+
+	// Document Expiry
+	ExpiringDocuments []string `json:"expiring_documents,omitempty"` // Flag: critical documents expired or expiring within 30 days
+
+	// Skill Quiz
+	QuizScore *int `json:"quiz_score,omitempty"` // Best score on the filter's quiz_id
+
+	// Profile Completeness
+	ProfileCompleteness int `json:"profile_completeness"` // 0-100, weighted by ProfileCompletenessWeights
+
 	// Metadata
 	VerificationStatus string     `json:"verification_status"`
 	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
 	SubmittedAt        time.Time  `json:"submitted_at"`
+
+	// Employer Scope (employer search only)
+	AppliedToCompany bool `json:"applied_to_company,omitempty"` // Applied to one of the company's jobs (otherwise found via the talent pool)
+	PIIMasked        bool `json:"pii_masked,omitempty"`         // Name reduced to initials and photo hidden until the candidate grants access
 }
 
 // ============================================================================
 // ATS Export Request
 // ============================================================================
 
-// ATSExportRequest represents the export configuration
+// ATSExportRequest represents the export configuration; request metadata goes
+// to the export audit
 type ATSExportRequest struct {
-	Filter  ATSFilter `json:"filter"`
-	Columns []string  `json:"columns"` // Selected columns for export
-	Format  string    `json:"format"`  // "xlsx" or "csv"
+	Filter    ATSFilter `json:"filter"`
+	Columns   []string  `json:"columns"` // Selected columns for export
+	Format    string    `json:"format"`  // "xlsx", "csv" or "pdf" (ZIP of one-page profiles)
+	IPAddress string    `json:"-"`
+	UserAgent string    `json:"-"`
+}
+
+// MaxATSPDFExportCandidates caps a PDF profile export; larger selections must be narrowed by filters
+const MaxATSPDFExportCandidates = 500
+
+// ATSExportFile is an export ready to be streamed to the client
+type ATSExportFile struct {
+	Filename    string
+	ContentType string
+	// Write renders the file into w; PDF exports render candidate by candidate
+	Write func(w io.Writer) error
+}
+
+// CandidatePhotoFetcher downloads profile pictures for PDF profiles
+type CandidatePhotoFetcher interface {
+	FetchPhoto(ctx context.Context, url string) ([]byte, error)
 }
 
 // ExportableColumns lists all columns that can be exported
@@ -156,6 +219,14 @@ var ExportableColumns = []string{
 	"available_start_date",
 	"verification_status",
 	"verified_at",
+	"phone_verified",
+	"coe_status",
+	"coe_expiry_date",
+	"visa_ready",
+	"ssw_sectors",
+	"expiring_documents",
+	"quiz_score",
+	"profile_completeness",
 }
 
 // ============================================================================
@@ -172,6 +243,54 @@ type ATSFilterOptions struct {
 	MajorFields      []string `json:"major_fields"`
 	TechnicalSkills  []Skill  `json:"technical_skills"`
 	ComputerSkills   []Skill  `json:"computer_skills"`
+	CoEStatuses      []string `json:"coe_statuses"`
+	SSWSectors       []string `json:"ssw_sectors"`
+}
+
+// ============================================================================
+// Talent Pool & PII Grants (Employer ATS)
+// ============================================================================
+
+// Candidate profile visibility: which employers can find the candidate in ATS
+// search and unlock their contact
+const (
+	ProfileVisibilityAllEmployers     = "ALL_VERIFIED_EMPLOYERS" // STANDARD or PREMIUM companies, plus those applied to
+	ProfileVisibilityAppliedCompanies = "APPLIED_COMPANIES"      // companies applied to (default)
+	ProfileVisibilityHidden           = "HIDDEN"                 // no employer; applications still reach the company
+)
+
+// ProfileVisibilities lists the visibility settings, most visible first
+var ProfileVisibilities = []string{ProfileVisibilityAllEmployers, ProfileVisibilityAppliedCompanies, ProfileVisibilityHidden}
+
+// TalentPoolSettings is a candidate's visibility in employer ATS search, served
+// by both /candidates/me/privacy and /candidates/me/talent-pool
+type TalentPoolSettings struct {
+	Visibility string     `json:"visibility"` // ProfileVisibility*
+	Visible    bool       `json:"visible"`    // In the talent pool: Visibility is ALL_VERIFIED_EMPLOYERS
+	Grants     []PIIGrant `json:"grants"`     // Companies allowed to see name and photo
+}
+
+// PIIGrant lets a company see a candidate's name and photo in ATS search
+type PIIGrant struct {
+	CompanyID   int64     `json:"company_id"`
+	CompanyName string    `json:"company_name"`
+	GrantedAt   time.Time `json:"granted_at"`
+}
+
+// UpdateTalentPoolRequest toggles talent pool visibility. Joining sets
+// ALL_VERIFIED_EMPLOYERS; leaving falls back to APPLIED_COMPANIES and keeps HIDDEN.
+type UpdateTalentPoolRequest struct {
+	Visible *bool `json:"visible" binding:"required"`
+}
+
+// UpdatePrivacyRequest sets the candidate's profile visibility
+type UpdatePrivacyRequest struct {
+	Visibility string `json:"visibility" binding:"required,oneof=ALL_VERIFIED_EMPLOYERS APPLIED_COMPANIES HIDDEN"`
+}
+
+// GrantPIIAccessRequest grants a company access to the candidate's name and photo
+type GrantPIIAccessRequest struct {
+	CompanyID int64 `json:"company_id" binding:"required,min=1"`
 }
 
 // ============================================================================
@@ -183,6 +302,10 @@ type ATSRepository interface {
 	// Search candidates with filters
 	SearchCandidates(ctx context.Context, filter ATSFilter) ([]ATSCandidate, int64, error)
 
+	// Search candidates whose profile visibility lets the company find them, with
+	// name and photo masked unless the company has access
+	SearchCandidatesForCompany(ctx context.Context, companyID int64, filter ATSFilter) ([]ATSCandidate, int64, error)
+
 	// Get filter options (reference data)
 	GetFilterOptions(ctx context.Context) (*ATSFilterOptions, error)
 
@@ -191,6 +314,13 @@ type ATSRepository interface {
 
 	// Get distinct major fields from candidates
 	GetDistinctMajorFields(ctx context.Context) ([]string, error)
+
+	// Talent pool visibility and PII grants (ErrNotFound when the candidate has no profile)
+	GetTalentPoolSettings(ctx context.Context, candidateUserID string) (*TalentPoolSettings, error)
+	SetTalentPoolVisible(ctx context.Context, candidateUserID string, visible bool) error
+	SetProfileVisibility(ctx context.Context, candidateUserID, visibility string) error
+	GrantPIIAccess(ctx context.Context, candidateUserID string, companyID int64) error
+	RevokePIIAccess(ctx context.Context, candidateUserID string, companyID int64) error
 }
 
 // ATSUsecase defines business logic for ATS feature
@@ -201,6 +331,21 @@ type ATSUsecase interface {
 	// Get filter options for UI dropdowns
 	GetFilterOptions(ctx context.Context) (*ATSFilterOptions, error)
 
-	// Export candidates as file bytes
-	ExportCandidates(ctx context.Context, req ATSExportRequest) ([]byte, string, error)
+	// Export candidates as a spreadsheet or a ZIP of PDF profiles
+	ExportCandidates(ctx context.Context, req ATSExportRequest) (*ATSExportFile, error)
+
+	// Export audit trail, filterable by candidate for data-protection requests;
+	// unrestricted admins only
+	ListExportAudits(ctx context.Context, filter ATSExportAuditFilter) (*PaginatedResult[ATSExportAudit], error)
+	GetExportAudit(ctx context.Context, id int64) (*ATSExportAudit, error)
+
+	// Search candidates visible to the employer's company
+	SearchEmployerCandidates(ctx context.Context, userID string, filter ATSFilter) (*PaginatedResult[ATSCandidate], error)
+
+	// Candidate talent pool settings
+	GetTalentPoolSettings(ctx context.Context, userID string) (*TalentPoolSettings, error)
+	UpdateTalentPoolSettings(ctx context.Context, userID string, req UpdateTalentPoolRequest) (*TalentPoolSettings, error)
+	UpdatePrivacySettings(ctx context.Context, userID string, req UpdatePrivacyRequest) (*TalentPoolSettings, error)
+	GrantPIIAccess(ctx context.Context, userID string, req GrantPIIAccessRequest) (*TalentPoolSettings, error)
+	RevokePIIAccess(ctx context.Context, userID string, companyID int64) (*TalentPoolSettings, error)
 }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ATSExportAudit records one admin ATS export: who ran it, with which filter,
+// and a manifest of the candidates whose data left the system
+type ATSExportAudit struct {
+	ID           int64     `json:"id"`
+	ActorUserID  *string   `json:"actor_user_id"` // nil once the admin account is deleted
+	ActorEmail   *string   `json:"actor_email,omitempty"`
+	Format       string    `json:"format"`
+	Filter       ATSFilter `json:"filter"`
+	Columns      []string  `json:"columns"`
+	RowCount     int       `json:"row_count"`
+	ManifestHash string    `json:"manifest_hash"` // SHA-256 over the sorted "candidate_user_id:fingerprint" lines
+	IPAddress    string    `json:"ip_address,omitempty"`
+	UserAgent    string    `json:"user_agent,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Fingerprint of the candidate's row when listing by candidate
+	Fingerprint *string `json:"fingerprint,omitempty"`
+	// Every exported candidate, on the detail endpoint only
+	Rows []ATSExportAuditRow `json:"rows,omitempty"`
+}
+
+// ATSExportAuditRow is one candidate included in an export. The fingerprint is
+// the SHA-256 of the candidate's exported values, so a leaked file can be
+// matched against the export it came from.
+type ATSExportAuditRow struct {
+	CandidateUserID string `json:"candidate_user_id"`
+	Fingerprint     string `json:"fingerprint"`
+}
+
+// ATSExportAuditFilter narrows the export audit list
+type ATSExportAuditFilter struct {
+	CandidateUserID string // exports that included this candidate
+	ActorUserID     string // exports run by this admin
+	Page            int
+	PageSize        int
+}
+
+// ATSExportAuditRepository is append-only
+type ATSExportAuditRepository interface {
+	// Create inserts the audit and its rows in one transaction
+	Create(ctx context.Context, audit *ATSExportAudit) error
+	List(ctx context.Context, filter ATSExportAuditFilter) ([]ATSExportAudit, int64, error)
+	// Get returns the audit with its rows, or ErrNotFound
+	Get(ctx context.Context, id int64) (*ATSExportAudit, error)
+}
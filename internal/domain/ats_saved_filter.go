@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ATSSavedFilter is a named ATS filter set saved by an admin. Shared filters
+// are visible to every admin; only the owner changes or deletes one.
+type ATSSavedFilter struct {
+	ID          int64      `json:"id"`
+	OwnerUserID string     `json:"owner_user_id"`
+	OwnerEmail  string     `json:"owner_email"`
+	Name        string     `json:"name"`
+	Filter      ATSFilter  `json:"filter"` // without pagination; sorting is kept
+	Shared      bool       `json:"shared"`
+	UseCount    int64      `json:"use_count"` // runs through filter_id
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ATSSavedFilterRequest creates or replaces a saved filter. The filter uses the
+// ATS search parameter names; available_start_before is an RFC 3339 time.
+type ATSSavedFilterRequest struct {
+	Name   string    `json:"name" binding:"required,min=1,max=100"`
+	Shared bool      `json:"shared"`
+	Filter ATSFilter `json:"filter"`
+}
+
+type ATSSavedFilterRepository interface {
+	Create(ctx context.Context, f *ATSSavedFilter) error
+	// Update and Delete return ErrNotFound for an unknown filter
+	Update(ctx context.Context, f *ATSSavedFilter) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*ATSSavedFilter, error)
+	// ListVisible returns the user's own filters and those shared by other admins
+	ListVisible(ctx context.Context, userID string) ([]ATSSavedFilter, error)
+	MarkUsed(ctx context.Context, id int64) error
+}
+
+// ATSSavedFilterUsecase is admin-only
+type ATSSavedFilterUsecase interface {
+	ListFilters(ctx context.Context) ([]ATSSavedFilter, error)
+	GetFilter(ctx context.Context, id int64) (*ATSSavedFilter, error)
+	CreateFilter(ctx context.Context, req ATSSavedFilterRequest) (*ATSSavedFilter, error)
+	UpdateFilter(ctx context.Context, id int64, req ATSSavedFilterRequest) (*ATSSavedFilter, error)
+	DeleteFilter(ctx context.Context, id int64) error
+	// UseFilter returns a visible filter for the ATS search or export and counts the run
+	UseFilter(ctx context.Context, id int64) (*ATSFilter, error)
+}
@@ -85,8 +85,50 @@ type CandidateWithFullDetails struct {
 	Details         CandidateDetail        `json:"details"`
 	WorkExperiences []WorkExperience       `json:"work_experiences"`
 	Certificates    []CandidateCertificate `json:"certificates"`
-	SkillIDs        []int                  `json:"skill_ids"` // For updates
-	Skills          []Skill                `json:"skills"`    // For responses
+	SkillIDs        []int                  `json:"skill_ids"`   // For updates
+	Skills          []Skill                `json:"skills"`      // For responses
+	QuizScores      []CandidateQuizScore   `json:"quiz_scores"` // For responses; best skill quiz results
+	// For responses; AlumniStatus* from the candidate's placements, unset before the first
+	AlumniStatus *string `json:"alumni_status,omitempty"`
+}
+
+// CandidateStaleAfterMonths is how long a candidate can be away before their
+// profile leaves employer search until they refresh it
+const CandidateStaleAfterMonths = 12
+
+// CandidateProfileFreshness is what decides whether a candidate must refresh their profile
+type CandidateProfileFreshness struct {
+	LastActiveAt      *time.Time // before the current visit
+	RefreshRequiredAt *time.Time // set when a returning candidate was found stale
+}
+
+// CandidateProfileRefresh is the state of the refresh wizard: the fields the
+// candidate must confirm or update, and their documents with expiry status
+type CandidateProfileRefresh struct {
+	RefreshRequired    bool                      `json:"refresh_required"`
+	RefreshRequiredAt  *time.Time                `json:"refresh_required_at,omitempty"`
+	LastRefreshedAt    *time.Time                `json:"last_refreshed_at,omitempty"`
+	AvailableStartDate *time.Time                `json:"available_start_date,omitempty"`
+	JapanReturnDate    *time.Time                `json:"japan_return_date,omitempty"`
+	ExpectedSalary     *int64                    `json:"expected_salary,omitempty"`
+	PreferredLocations []string                  `json:"preferred_locations"`
+	Documents          []CandidateDocumentExpiry `json:"documents"` // every document with an expiry date
+}
+
+// RefreshCandidateProfileRequest confirms or updates availability, salary and
+// documents. New documents are uploaded through the document vault first.
+type RefreshCandidateProfileRequest struct {
+	AvailableStartDate string   `json:"available_start_date" binding:"required,datetime=2006-01-02"`
+	ExpectedSalary     *int64   `json:"expected_salary" binding:"required,min=0"`
+	PreferredLocations []string `json:"preferred_locations" binding:"omitempty,max=20,dive,required,max=100"` // unchanged when omitted
+	DocumentsConfirmed *bool    `json:"documents_confirmed" binding:"required"`
+}
+
+// CandidateProfileRefreshUpdate is a validated refresh
+type CandidateProfileRefreshUpdate struct {
+	AvailableStartDate time.Time
+	ExpectedSalary     int64
+	PreferredLocations []string // nil keeps the current locations
 }
 
 type CandidateRepository interface {
@@ -100,6 +142,14 @@ type CandidateRepository interface {
 
 	// Master Data Helpers
 	GetAllSkills(ctx context.Context) ([]Skill, error)
+
+	// Profile refresh (ErrNotFound when the candidate has no verification record)
+	GetProfileFreshness(ctx context.Context, userID string) (*CandidateProfileFreshness, error)
+	// FlagProfileRefresh marks the profile for refresh unless it already is
+	FlagProfileRefresh(ctx context.Context, userID string, at time.Time) error
+	GetProfileRefresh(ctx context.Context, userID string) (*CandidateProfileRefresh, error)
+	// CompleteProfileRefresh stores the refreshed fields and clears the flag
+	CompleteProfileRefresh(ctx context.Context, userID string, update CandidateProfileRefreshUpdate, at time.Time) error
 }
 
 type CandidateUsecase interface {
@@ -110,4 +160,10 @@ type CandidateUsecase interface {
 	GetFullProfile(ctx context.Context, userID string) (*CandidateWithFullDetails, error)
 	UpdateFullProfile(ctx context.Context, userID string, req *CandidateWithFullDetails) error
 	GetMasterSkills(ctx context.Context) ([]Skill, error)
+
+	// ProfileRefreshRequired reports whether a returning candidate must refresh their
+	// profile, flagging it when they come back after CandidateStaleAfterMonths
+	ProfileRefreshRequired(ctx context.Context, userID string) (bool, error)
+	GetProfileRefresh(ctx context.Context, userID string) (*CandidateProfileRefresh, error)
+	RefreshProfile(ctx context.Context, userID string, req RefreshCandidateProfileRequest) (*CandidateProfileRefresh, error)
 }
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Candidate activity types
+const (
+	ActivityScreeningCallScheduled = "SCREENING_CALL_SCHEDULED"
+	ActivityScreeningCallCancelled = "SCREENING_CALL_CANCELLED"
+)
+
+// MaxCandidateActivityLimit caps one page of the activity feed
+const MaxCandidateActivityLimit = 100
+
+// CandidateActivity is one entry of a candidate's activity feed
+type CandidateActivity struct {
+	ID           int64           `json:"id"`
+	UserID       string          `json:"user_id"`
+	ActivityType string          `json:"activity_type"`
+	Data         json.RawMessage `json:"data" swaggertype:"object"` // type-specific details
+	OccurredAt   time.Time       `json:"occurred_at"`
+}
+
+type CandidateActivityRepository interface {
+	// ListByUser returns activities newest first; beforeID > 0 continues after that entry
+	ListByUser(ctx context.Context, userID string, beforeID int64, limit int) ([]CandidateActivity, error)
+}
+
+type CandidateActivityUsecase interface {
+	ListMyActivity(ctx context.Context, userID string, beforeID int64, limit int) ([]CandidateActivity, error)
+}
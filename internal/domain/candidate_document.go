@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxCandidateDocuments caps how many vault documents a candidate can keep
+const MaxCandidateDocuments = 30
+
+// CandidateDocumentTypes are the document types the vault accepts
+var CandidateDocumentTypes = []string{DocumentTypePassport, DocumentTypeJLPT, DocumentTypeDiploma, DocumentTypeVisa}
+
+// CandidateDocumentTypesRequiringExpiry must be uploaded with an expiry date
+var CandidateDocumentTypesRequiringExpiry = []string{DocumentTypePassport, DocumentTypeVisa}
+
+// Vault document review status
+const (
+	CandidateDocumentPending  = "PENDING"
+	CandidateDocumentApproved = "APPROVED"
+	CandidateDocumentRejected = "REJECTED"
+)
+
+// Vault document expiry status, derived from the expiry date
+const (
+	DocumentExpiryValid    = "VALID"
+	DocumentExpiryExpiring = "EXPIRING" // within CriticalDocumentExpiryWindowDays
+	DocumentExpiryExpired  = "EXPIRED"
+)
+
+// CandidateDocument is a typed document in a candidate's vault
+type CandidateDocument struct {
+	ID             int64      `json:"id"`
+	UserID         string     `json:"user_id"`
+	DocumentType   string     `json:"document_type"` // PASSPORT, JLPT, DIPLOMA, VISA
+	FileURL        string     `json:"file_url"`
+	DocumentNumber *string    `json:"document_number,omitempty"`
+	Label          *string    `json:"label,omitempty"` // e.g. "N2", the institution of a diploma
+	IssueDate      *time.Time `json:"issue_date,omitempty"`
+	ExpiryDate     *time.Time `json:"expiry_date,omitempty"`
+	ExpiryStatus   *string    `json:"expiry_status,omitempty"` // VALID, EXPIRING, EXPIRED; unset without an expiry date
+	DaysLeft       *int       `json:"days_left,omitempty"`     // negative once expired
+	Status         string     `json:"status"`                  // PENDING, APPROVED, REJECTED
+	ReviewNotes    *string    `json:"review_notes,omitempty"`
+	ReviewedBy     *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// AdminCandidateDocument is a vault document with its owner, for the review queue
+type AdminCandidateDocument struct {
+	CandidateDocument
+	Email     string  `json:"email"`
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+}
+
+// CreateCandidateDocumentRequest adds a document to the vault. The file is
+// uploaded first through POST /upload?bucket=Candidate_Documents.
+type CreateCandidateDocumentRequest struct {
+	DocumentType   string  `json:"document_type" binding:"required,oneof=PASSPORT JLPT DIPLOMA VISA"`
+	FileURL        string  `json:"file_url" binding:"required,url,max=2000"`
+	DocumentNumber *string `json:"document_number" binding:"omitempty,max=100"`
+	Label          *string `json:"label" binding:"omitempty,max=200"`
+	IssueDate      *string `json:"issue_date" binding:"omitempty,datetime=2006-01-02"`
+	ExpiryDate     *string `json:"expiry_date" binding:"omitempty,datetime=2006-01-02"` // required for PASSPORT and VISA
+}
+
+// ReviewCandidateDocumentRequest approves or rejects a vault document
+type ReviewCandidateDocumentRequest struct {
+	Action string  `json:"action" binding:"required,oneof=APPROVE REJECT"`
+	Notes  *string `json:"notes" binding:"omitempty,max=1000"` // shown to the candidate; required to reject
+}
+
+// CandidateDocumentFilter selects documents in the admin review queue
+type CandidateDocumentFilter struct {
+	Status       string // PENDING by default
+	DocumentType string
+	Page         int
+	PageSize     int
+}
+
+type CandidateDocumentRepository interface {
+	Create(ctx context.Context, doc *CandidateDocument) error
+	CountByUser(ctx context.Context, userID string) (int, error)
+	ListByUser(ctx context.Context, userID string) ([]CandidateDocument, error)
+	GetByID(ctx context.Context, id int64) (*CandidateDocument, error)
+	// Delete removes a candidate's own document and returns its file URL;
+	// ErrNotFound when it is not theirs
+	Delete(ctx context.Context, userID string, id int64) (string, error)
+
+	List(ctx context.Context, filter CandidateDocumentFilter) ([]AdminCandidateDocument, int64, error)
+	// Review stores the status, notes and reviewer of a document
+	Review(ctx context.Context, doc *CandidateDocument) error
+}
+
+type CandidateDocumentUsecase interface {
+	ListMyDocuments(ctx context.Context, userID string) ([]CandidateDocument, error)
+	CreateDocument(ctx context.Context, userID string, req CreateCandidateDocumentRequest) (*CandidateDocument, error)
+	// DeleteDocument removes the document and queues its file for deletion
+	DeleteDocument(ctx context.Context, userID string, id int64) error
+
+	// ListDocuments returns the admin review queue
+	ListDocuments(ctx context.Context, filter CandidateDocumentFilter) (*PaginatedResult[AdminCandidateDocument], error)
+	ReviewDocument(ctx context.Context, adminID string, id int64, req ReviewCandidateDocumentRequest) (*CandidateDocument, error)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxCandidatePortfolioItems caps how many work samples a candidate can keep
+const MaxCandidatePortfolioItems = 30
+
+// Portfolio item types; IMAGE and PDF follow the uploaded file's content type
+const (
+	PortfolioItemImage = "IMAGE"
+	PortfolioItemPDF   = "PDF"
+	PortfolioItemLink  = "LINK"
+)
+
+// CandidatePortfolioItem is a work sample in a candidate's portfolio
+type CandidatePortfolioItem struct {
+	ID              int64     `json:"id"`
+	UserID          string    `json:"user_id"`
+	ItemType        string    `json:"item_type"` // IMAGE, PDF, LINK
+	Title           string    `json:"title"`
+	Description     *string   `json:"description,omitempty"`
+	FileURL         *string   `json:"file_url,omitempty"` // IMAGE and PDF
+	LinkURL         *string   `json:"link_url,omitempty"` // LINK
+	SizeBytes       int64     `json:"size_bytes"`         // counts against the storage quota; 0 for links
+	Position        int       `json:"position"`
+	IncludeInResume bool      `json:"include_in_resume"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CandidatePortfolioUsage is a candidate's consumption of the portfolio limits
+type CandidatePortfolioUsage struct {
+	Items      int   `json:"items"`
+	MaxItems   int   `json:"max_items"`
+	UsedBytes  int64 `json:"used_bytes"`
+	QuotaBytes int64 `json:"quota_bytes"`
+}
+
+// CandidatePortfolio is a candidate's own portfolio in display order
+type CandidatePortfolio struct {
+	Items []CandidatePortfolioItem `json:"items"`
+	Usage CandidatePortfolioUsage  `json:"usage"`
+}
+
+// EmployerCandidatePortfolio is the portfolio as an employer sees it. Until the
+// company unlocks the candidate's contact, links are left out and contact
+// details in descriptions are redacted, as in the resume PDF.
+type EmployerCandidatePortfolio struct {
+	CandidateUserID string                   `json:"candidate_user_id"`
+	Mode            string                   `json:"mode"` // ResumeMode*
+	Items           []CandidatePortfolioItem `json:"items"`
+}
+
+// CreatePortfolioItemRequest adds a work sample: either a file uploaded first
+// through POST /upload?bucket=Candidate_Portfolio, or a link.
+type CreatePortfolioItemRequest struct {
+	Title           string  `json:"title" binding:"required,max=200"`
+	Description     *string `json:"description" binding:"omitempty,max=2000"`
+	FileURL         *string `json:"file_url" binding:"omitempty,url,max=2000"`
+	LinkURL         *string `json:"link_url" binding:"omitempty,url,max=2000"`
+	IncludeInResume *bool   `json:"include_in_resume"` // true when omitted
+}
+
+// UpdatePortfolioItemRequest edits a work sample; replace a file by adding a new item
+type UpdatePortfolioItemRequest struct {
+	Title           string  `json:"title" binding:"required,max=200"`
+	Description     *string `json:"description" binding:"omitempty,max=2000"`
+	LinkURL         *string `json:"link_url" binding:"omitempty,url,max=2000"` // LINK items only
+	IncludeInResume bool    `json:"include_in_resume"`
+}
+
+// ReorderPortfolioRequest lists every item ID of the portfolio in the new display order
+type ReorderPortfolioRequest struct {
+	ItemIDs []int64 `json:"item_ids" binding:"required,min=1,max=30,dive,min=1"`
+}
+
+type CandidatePortfolioRepository interface {
+	// Create appends the item at the end of the portfolio
+	Create(ctx context.Context, item *CandidatePortfolioItem) error
+	ListByUser(ctx context.Context, userID string) ([]CandidatePortfolioItem, error)
+	GetByID(ctx context.Context, userID string, id int64) (*CandidatePortfolioItem, error)
+	Update(ctx context.Context, item *CandidatePortfolioItem) error
+	// Delete removes a candidate's own item and returns its file URL (nil for links);
+	// ErrNotFound when it is not theirs
+	Delete(ctx context.Context, userID string, id int64) (*string, error)
+	// Reorder sets the positions to the order of ids, which must be exactly the
+	// candidate's items; ErrNotFound otherwise
+	Reorder(ctx context.Context, userID string, ids []int64) error
+}
+
+type CandidatePortfolioUsecase interface {
+	GetMyPortfolio(ctx context.Context, userID string) (*CandidatePortfolio, error)
+	CreateItem(ctx context.Context, userID string, req CreatePortfolioItemRequest) (*CandidatePortfolioItem, error)
+	UpdateItem(ctx context.Context, userID string, id int64, req UpdatePortfolioItemRequest) (*CandidatePortfolioItem, error)
+	// DeleteItem removes the item and queues its file for deletion
+	DeleteItem(ctx context.Context, userID string, id int64) error
+	ReorderItems(ctx context.Context, userID string, req ReorderPortfolioRequest) (*CandidatePortfolio, error)
+
+	// GetCandidatePortfolio returns a candidate's portfolio to an employer whose
+	// company the candidate's privacy settings make them visible to
+	GetCandidatePortfolio(ctx context.Context, userID, candidateUserID string) (*EmployerCandidatePortfolio, error)
+}
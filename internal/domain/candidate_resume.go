@@ -0,0 +1,28 @@
+package domain
+
+import "context"
+
+// Resume rendering modes, chosen by the employer's unlock state for the candidate
+const (
+	ResumeModeRedacted = "redacted" // before unlock: initials only, no contact details
+	ResumeModeFull     = "full"     // after unlock: name and contact details
+)
+
+// CandidateResumeRequest carries request metadata for the PII access log
+type CandidateResumeRequest struct {
+	IPAddress string
+	UserAgent string
+}
+
+// CandidateResume is a rendered resume PDF
+type CandidateResume struct {
+	Data     []byte
+	Filename string
+	Mode     string
+}
+
+type CandidateResumeUsecase interface {
+	// GetResumePDF renders a candidate's resume for the employer's company:
+	// redacted until the company has unlocked the candidate's contact, full afterwards
+	GetResumePDF(ctx context.Context, userID, candidateUserID string, req CandidateResumeRequest) (*CandidateResume, error)
+}
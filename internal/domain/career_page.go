@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Career page limits
+const (
+	MaxCultureSections = 10
+	MaxCareerBenefits  = 20
+	MaxCareerPageJobs  = 50
+)
+
+// ReservedCompanySlugs cannot be claimed because they collide with routes or look official
+var ReservedCompanySlugs = []string{"admin", "api", "public", "new", "edit", "me", "jexpert", "j-expert"}
+
+// CultureSection is one block of culture content on a career page
+type CultureSection struct {
+	Title    string  `json:"title" binding:"required,max=120"`
+	Body     string  `json:"body" binding:"required,max=4000"`
+	ImageURL *string `json:"image_url,omitempty" binding:"omitempty,url"`
+}
+
+// CareerPage is a company's editable career page content
+type CareerPage struct {
+	CompanyID       int64            `json:"company_id"`
+	Slug            *string          `json:"slug"`
+	PrimaryColor    *string          `json:"primary_color"`   // #RRGGBB
+	SecondaryColor  *string          `json:"secondary_color"` // #RRGGBB
+	BannerURL       *string          `json:"banner_url"`
+	IntroVideoURL   *string          `json:"intro_video_url"`
+	Headline        *string          `json:"headline"`
+	CultureSections []CultureSection `json:"culture_sections"`
+	Benefits        []string         `json:"benefits"`
+	IsPublished     bool             `json:"is_published"`
+	UpdatedAt       *time.Time       `json:"updated_at,omitempty"` // nil until first saved
+}
+
+// CareerPageRequest replaces the career page content
+type CareerPageRequest struct {
+	PrimaryColor    *string          `json:"primary_color" binding:"omitempty,hexcolor,len=7"`
+	SecondaryColor  *string          `json:"secondary_color" binding:"omitempty,hexcolor,len=7"`
+	BannerURL       *string          `json:"banner_url" binding:"omitempty,url"`
+	IntroVideoURL   *string          `json:"intro_video_url" binding:"omitempty,url"`
+	Headline        *string          `json:"headline" binding:"omitempty,max=200"`
+	CultureSections []CultureSection `json:"culture_sections" binding:"omitempty,dive"`
+	Benefits        []string         `json:"benefits" binding:"omitempty,dive,required,max=200"`
+	IsPublished     bool             `json:"is_published"`
+}
+
+// UpdateSlugRequest claims a public slug for the company
+type UpdateSlugRequest struct {
+	Slug string `json:"slug" binding:"required,min=3,max=60"`
+}
+
+// SlugAvailability answers whether a slug can be claimed
+type SlugAvailability struct {
+	Slug      string `json:"slug"` // normalized
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// CareerPageBranding is the visual part of a public career page
+type CareerPageBranding struct {
+	LogoURL        *string `json:"logo_url"`
+	PrimaryColor   *string `json:"primary_color"`
+	SecondaryColor *string `json:"secondary_color"`
+	BannerURL      *string `json:"banner_url"`
+	IntroVideoURL  *string `json:"intro_video_url"`
+}
+
+// PublicCareerPage is everything a branded career page needs in one payload
+type PublicCareerPage struct {
+	Slug            string             `json:"slug"`
+	CompanyID       int64              `json:"company_id"`
+	CompanyName     string             `json:"company_name"`
+	Location        *string            `json:"location"`
+	Industry        *string            `json:"industry"`
+	Website         *string            `json:"website,omitempty"` // hidden with hide_company_details
+	CompanyStory    *string            `json:"company_story"`
+	Headline        *string            `json:"headline"`
+	Branding        CareerPageBranding `json:"branding"`
+	CultureSections []CultureSection   `json:"culture_sections"`
+	Benefits        []string           `json:"benefits"`
+	Jobs            []Job              `json:"jobs"`
+}
+
+type CareerPageRepository interface {
+	// GetByCompanyID returns ErrNotFound when the company has never saved a career page
+	GetByCompanyID(ctx context.Context, companyID int64) (*CareerPage, error)
+	Upsert(ctx context.Context, page *CareerPage) error
+
+	GetSlug(ctx context.Context, companyID int64) (*string, error)
+	// SetSlug returns a Conflict error when another company already holds the slug
+	SetSlug(ctx context.Context, companyID int64, slug string) error
+	SlugTaken(ctx context.Context, slug string, exceptCompanyID int64) (bool, error)
+
+	GetCompanyBySlug(ctx context.Context, slug string) (*CompanyProfile, error)
+	ListActiveJobs(ctx context.Context, companyID int64, limit int) ([]Job, error)
+}
+
+type CareerPageUsecase interface {
+	// Public
+	GetPublicCareerPage(ctx context.Context, slug string) (*PublicCareerPage, error)
+
+	// Employer (own company)
+	GetOwnCareerPage(ctx context.Context, userID string) (*CareerPage, error)
+	UpdateOwnCareerPage(ctx context.Context, userID string, req CareerPageRequest) (*CareerPage, error)
+	CheckSlug(ctx context.Context, userID, slug string) (*SlugAvailability, error)
+	UpdateSlug(ctx context.Context, userID, slug string) (*CareerPage, error)
+}
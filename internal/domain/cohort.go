@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ============================================================================
+// Candidate Cohorts (LPK training batches)
+// ============================================================================
+
+// MaxCohortBulkAssign caps how many candidates one bulk assignment may name
+const MaxCohortBulkAssign = 500
+
+// Cohort is a training batch of one LPK's candidates
+type Cohort struct {
+	ID                  int64          `json:"id"`
+	LPKID               int64          `json:"lpk_id"`
+	LPKName             string         `json:"lpk_name"`
+	Name                string         `json:"name"`
+	StartDate           string         `json:"start_date"` // YYYY-MM-DD
+	EndDate             string         `json:"end_date"`   // YYYY-MM-DD
+	TargetDepartureDate *string        `json:"target_departure_date,omitempty"`
+	Progress            CohortProgress `json:"progress"`
+	CreatedBy           *string        `json:"created_by,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+}
+
+// CohortProgress is the dashboard summary of a cohort's members. Members who
+// have since picked another LPK are not counted.
+type CohortProgress struct {
+	MemberCount      int64   `json:"member_count"`
+	VerifiedCount    int64   `json:"verified_count"`
+	PlacedCount      int64   `json:"placed_count"`      // with an accepted application
+	VerificationRate float64 `json:"verification_rate"` // percent of members verified
+	PlacementRate    float64 `json:"placement_rate"`    // percent of members placed
+}
+
+// CohortRequest creates or replaces a cohort
+type CohortRequest struct {
+	// LPKID is required from admins; LPK partners always manage their own LPK's cohorts
+	LPKID               int64   `json:"lpk_id" binding:"omitempty,min=1"`
+	Name                string  `json:"name" binding:"required,min=1,max=150"`
+	StartDate           string  `json:"start_date" binding:"required,datetime=2006-01-02"`
+	EndDate             string  `json:"end_date" binding:"required,datetime=2006-01-02"`
+	TargetDepartureDate *string `json:"target_departure_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// AssignCohortMembersRequest adds candidates to a cohort, moving them out of
+// their current one
+type AssignCohortMembersRequest struct {
+	CandidateRefs []int64 `json:"candidate_refs" binding:"required,min=1,max=500,dive,min=1"` // account_verifications.id
+}
+
+// CohortAssignResult reports a bulk assignment
+type CohortAssignResult struct {
+	Assigned int     `json:"assigned"`
+	Skipped  []int64 `json:"skipped"` // not candidates of the cohort's LPK
+}
+
+type CohortRepository interface {
+	Create(ctx context.Context, c *Cohort) error
+	// Update and Delete return ErrNotFound for an unknown cohort
+	Update(ctx context.Context, c *Cohort) error
+	Delete(ctx context.Context, id int64) error
+	// GetByID and List include each cohort's progress
+	GetByID(ctx context.Context, id int64) (*Cohort, error)
+	List(ctx context.Context, lpkID *int64) ([]Cohort, error)
+
+	// AssignMembers assigns the candidates of the cohort's LPK among refs and
+	// returns the refs it assigned
+	AssignMembers(ctx context.Context, cohortID, lpkID int64, refs []int64, assignedBy string) ([]int64, error)
+	// RemoveMember returns ErrNotFound when the candidate is not in the cohort
+	RemoveMember(ctx context.Context, cohortID, candidateRef int64) error
+	ListMembers(ctx context.Context, cohortID, lpkID int64, page, pageSize int) ([]LPKCandidateProgress, int64, error)
+}
+
+// CohortUsecase serves both admins (every LPK) and LPK partners (their own LPK)
+type CohortUsecase interface {
+	ListCohorts(ctx context.Context, lpkID *int64) ([]Cohort, error)
+	GetCohort(ctx context.Context, id int64) (*Cohort, error)
+	CreateCohort(ctx context.Context, req CohortRequest) (*Cohort, error)
+	UpdateCohort(ctx context.Context, id int64, req CohortRequest) (*Cohort, error)
+	DeleteCohort(ctx context.Context, id int64) error
+
+	AssignMembers(ctx context.Context, id int64, req AssignCohortMembersRequest) (*CohortAssignResult, error)
+	RemoveMember(ctx context.Context, id, candidateRef int64) error
+	ListMembers(ctx context.Context, id int64, page, pageSize int) (*PaginatedResult[LPKCandidateProgress], error)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxCompanyLocations caps the offices a company can list
+const MaxCompanyLocations = 50
+
+// Radius of a "near" job search
+const (
+	DefaultJobSearchRadiusKm = 25
+	MaxJobSearchRadiusKm     = 200
+)
+
+// CompanyLocation is one office or site of a company
+type CompanyLocation struct {
+	ID         int64   `json:"id"`
+	CompanyID  int64   `json:"company_id"`
+	Name       string  `json:"name"` // e.g. "Jakarta HQ", "Karawang plant"
+	Address    string  `json:"address"`
+	City       string  `json:"city"`
+	Province   *string `json:"province"`
+	PostalCode *string `json:"postal_code"`
+	Country    string  `json:"country"`
+	// Geocode entered by the employer (WGS84); both or neither are set
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	// Site contact; left out publicly when the company hides its details
+	ContactName  *string   `json:"contact_name,omitempty"`
+	ContactPhone *string   `json:"contact_phone,omitempty"` // normalized to E.164
+	ContactEmail *string   `json:"contact_email,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CompanyLocationRequest creates or replaces an office. Latitude and longitude
+// must be given together; an empty country means Indonesia.
+type CompanyLocationRequest struct {
+	Name         string   `json:"name" binding:"required,max=120"`
+	Address      string   `json:"address" binding:"required,max=500"`
+	City         string   `json:"city" binding:"required,max=120"`
+	Province     *string  `json:"province" binding:"omitempty,max=120"`
+	PostalCode   *string  `json:"postal_code" binding:"omitempty,max=20"`
+	Country      string   `json:"country" binding:"omitempty,max=120"`
+	Latitude     *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude    *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	ContactName  *string  `json:"contact_name" binding:"omitempty,max=120"`
+	ContactPhone *string  `json:"contact_phone" binding:"omitempty,max=30"`
+	ContactEmail *string  `json:"contact_email" binding:"omitempty,email"`
+}
+
+// DefaultCompanyLocationCountry is used when an office is saved without a country
+const DefaultCompanyLocationCountry = "Indonesia"
+
+// HideContact removes the site contact, for public responses of companies that
+// hide their details
+func (l *CompanyLocation) HideContact() {
+	l.ContactName = nil
+	l.ContactPhone = nil
+	l.ContactEmail = nil
+}
+
+// JobLocationMap is the map shown on the public job detail page for a job based
+// at one of its company's offices
+type JobLocationMap struct {
+	Name      string   `json:"name,omitempty"`
+	Address   string   `json:"address,omitempty"`
+	City      string   `json:"city"`
+	Province  *string  `json:"province,omitempty"`
+	Country   string   `json:"country"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	// Opens the pin, or a search for the address when the office has no geocode
+	MapURL string `json:"map_url"`
+}
+
+// JobLocationMapFor builds the map payload of an office. Confidential jobs only
+// get the city, as the exact address would name the company.
+func JobLocationMapFor(l *CompanyLocation, confidential bool) *JobLocationMap {
+	m := &JobLocationMap{City: l.City, Province: l.Province, Country: l.Country}
+	query := strings.Join([]string{l.City, l.Country}, ", ")
+	if !confidential {
+		m.Name, m.Address = l.Name, l.Address
+		m.Latitude, m.Longitude = l.Latitude, l.Longitude
+		query = strings.Join([]string{l.Address, l.City, l.Country}, ", ")
+	}
+	if m.Latitude != nil && m.Longitude != nil {
+		query = fmt.Sprintf("%.6f,%.6f", *m.Latitude, *m.Longitude)
+	}
+	m.MapURL = "https://www.google.com/maps/search/?api=1&query=" + url.QueryEscape(query)
+	return m
+}
+
+// GeoPoint is a WGS84 coordinate
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type CompanyLocationRepository interface {
+	ListByCompany(ctx context.Context, companyID int64) ([]CompanyLocation, error)
+	// GetByID returns ErrNotFound when the office does not belong to the company
+	GetByID(ctx context.Context, companyID, id int64) (*CompanyLocation, error)
+	CountByCompany(ctx context.Context, companyID int64) (int, error)
+	Create(ctx context.Context, location *CompanyLocation) error
+	Update(ctx context.Context, location *CompanyLocation) error
+	// Delete detaches the office's jobs, which keep their free-text location
+	Delete(ctx context.Context, companyID, id int64) error
+}
+
+type CompanyLocationUsecase interface {
+	// Employer (own company)
+	ListOwnLocations(ctx context.Context, userID string) ([]CompanyLocation, error)
+	CreateLocation(ctx context.Context, userID string, req CompanyLocationRequest) (*CompanyLocation, error)
+	UpdateLocation(ctx context.Context, userID string, id int64, req CompanyLocationRequest) (*CompanyLocation, error)
+	DeleteLocation(ctx context.Context, userID string, id int64) error
+
+	// Public: offices of a verified company
+	ListPublicLocations(ctx context.Context, companyID int64) ([]CompanyLocation, error)
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Company roles. The owner is the user who created the company profile; the
+// others join by invitation.
+const (
+	CompanyRoleOwner     = "OWNER"     // everything, including the profile, career page and team
+	CompanyRoleRecruiter = "RECRUITER" // jobs, applications and candidates
+	CompanyRoleViewer    = "VIEWER"    // read-only
+)
+
+// CompanyInvitationTTL is how long an invitation link stays valid
+const CompanyInvitationTTL = 7 * 24 * time.Hour
+
+// MaxCompanyMembers caps the invited members of a company (the owner not counted)
+const MaxCompanyMembers = 50
+
+// CompanyMember is a user who joined a company by invitation
+type CompanyMember struct {
+	ID        int64     `json:"id"`
+	CompanyID int64     `json:"company_id"`
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy *string   `json:"invited_by,omitempty"`
+	JoinedAt  time.Time `json:"joined_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CompanyInvitation is an emailed invitation to join a company. The token is
+// only returned when the invitation is created, so the owner can share the link
+// themselves when email is not configured; only its hash is stored.
+type CompanyInvitation struct {
+	ID        int64     `json:"id"`
+	CompanyID int64     `json:"company_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy *string   `json:"invited_by,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	Token     string    `json:"token,omitempty"`
+}
+
+// CompanyTeam is the owner, the members and the open invitations of a company
+type CompanyTeam struct {
+	OwnerUserID string              `json:"owner_user_id"`
+	OwnerEmail  string              `json:"owner_email"`
+	Members     []CompanyMember     `json:"members"`
+	Invitations []CompanyInvitation `json:"invitations"`
+}
+
+// CompanyMembership is the company a user works for and their role in it
+type CompanyMembership struct {
+	CompanyID   int64  `json:"company_id"`
+	CompanyName string `json:"company_name"`
+	Role        string `json:"role"`
+}
+
+type InviteCompanyMemberRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=RECRUITER VIEWER"`
+}
+
+type UpdateCompanyMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=RECRUITER VIEWER"`
+}
+
+type AcceptCompanyInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type CompanyMemberRepository interface {
+	// GetMembership resolves the user's company the same way
+	// CompanyProfileRepository.GetByUserID does: their own profile (OWNER, or
+	// RECRUITER of the survivor once it was merged away), else the company they
+	// joined. ErrNotFound when they belong to none.
+	GetMembership(ctx context.Context, userID string) (*CompanyMembership, error)
+	ListMembers(ctx context.Context, companyID int64) ([]CompanyMember, error)
+	CountMembers(ctx context.Context, companyID int64) (int, error)
+	UpdateMemberRole(ctx context.Context, companyID int64, userID, role string) (*CompanyMember, error)
+	RemoveMember(ctx context.Context, companyID int64, userID string) error
+
+	// CreateInvitation revokes any open invitation of the same address first
+	CreateInvitation(ctx context.Context, inv *CompanyInvitation, tokenHash string) error
+	ListPendingInvitations(ctx context.Context, companyID int64, now time.Time) ([]CompanyInvitation, error)
+	RevokeInvitation(ctx context.Context, companyID, id int64, now time.Time) error
+	// GetPendingInvitationByTokenHash returns ErrNotFound for unknown, used,
+	// revoked and expired tokens
+	GetPendingInvitationByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*CompanyInvitation, error)
+	// AcceptInvitation marks the invitation used and adds the member in one
+	// transaction; ErrNotFound when it was used or revoked meanwhile
+	AcceptInvitation(ctx context.Context, inv *CompanyInvitation, userID string, now time.Time) (*CompanyMember, error)
+}
+
+type CompanyMemberUsecase interface {
+	// MemberRole is the caller's company role, "" when they belong to no company
+	MemberRole(ctx context.Context, userID string) (string, error)
+	GetMyMembership(ctx context.Context, userID string) (*CompanyMembership, error)
+
+	// Any member
+	GetTeam(ctx context.Context, userID string) (*CompanyTeam, error)
+
+	// Owner only
+	InviteMember(ctx context.Context, userID string, req InviteCompanyMemberRequest) (*CompanyInvitation, error)
+	RevokeInvitation(ctx context.Context, userID string, invitationID int64) error
+	UpdateMemberRole(ctx context.Context, userID, memberUserID string, req UpdateCompanyMemberRequest) (*CompanyMember, error)
+	// RemoveMember is also how a member leaves (memberUserID == userID)
+	RemoveMember(ctx context.Context, userID, memberUserID string) error
+
+	// AcceptInvitation joins the caller's employer account to the inviting company
+	AcceptInvitation(ctx context.Context, userID string, req AcceptCompanyInvitationRequest) (*CompanyMembership, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Company merge audit entities and actions
+const (
+	MergeEntityJobs           = "jobs"
+	MergeEntityLocations      = "locations"
+	MergeEntityApplications   = "applications"
+	MergeEntityTeamMembers    = "team_members"
+	MergeEntityGalleryMedia   = "gallery_media"
+	MergeEntityCompanyProfile = "company_profile"
+
+	MergeActionReassigned = "REASSIGNED"
+	MergeActionCopied     = "COPIED"
+	MergeActionArchived   = "ARCHIVED"
+)
+
+// CompanyMergeRequest is the admin input for merging a duplicate into a surviving company
+type CompanyMergeRequest struct {
+	SurvivingCompanyID int64  `json:"surviving_company_id" binding:"required,gt=0"`
+	DuplicateCompanyID int64  `json:"duplicate_company_id" binding:"required,gt=0"`
+	Reason             string `json:"reason" binding:"required,min=5,max=500"`
+}
+
+// CompanyMergeAuditEntry records one kind of data a merge moved
+type CompanyMergeAuditEntry struct {
+	ID            int64                  `json:"id"`
+	Entity        string                 `json:"entity"`
+	Action        string                 `json:"action"`
+	AffectedCount int                    `json:"affected_count"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+// CompanyMerge is a completed merge
+type CompanyMerge struct {
+	ID                   int64                    `json:"id"`
+	SurvivingCompanyID   int64                    `json:"surviving_company_id"`
+	SurvivingCompanyName string                   `json:"surviving_company_name"`
+	DuplicateCompanyID   int64                    `json:"duplicate_company_id"`
+	DuplicateCompanyName string                   `json:"duplicate_company_name"`
+	MergedBy             *string                  `json:"merged_by,omitempty"`
+	Reason               string                   `json:"reason"`
+	CreatedAt            time.Time                `json:"created_at"`
+	AuditEntries         []CompanyMergeAuditEntry `json:"audit_entries,omitempty"` // detail view only
+}
+
+type CompanyMergeRepository interface {
+	// Merge moves everything from the duplicate to the survivor, archives the duplicate
+	// and writes the audit entries in one transaction
+	Merge(ctx context.Context, req CompanyMergeRequest, mergedBy string) (*CompanyMerge, error)
+	List(ctx context.Context, limit int) ([]CompanyMerge, error)
+	GetByID(ctx context.Context, id int64) (*CompanyMerge, error)
+}
+
+type CompanyMergeUsecase interface {
+	MergeCompanies(ctx context.Context, adminUserID string, req CompanyMergeRequest) (*CompanyMerge, error)
+	ListMerges(ctx context.Context) ([]CompanyMerge, error)
+	GetMerge(ctx context.Context, id int64) (*CompanyMerge, error)
+}
@@ -2,7 +2,10 @@ package domain
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // CompanyProfile represents an employer's company profile
@@ -12,6 +15,7 @@ type CompanyProfile struct {
 	CompanyName        string    `json:"company_name"`
 	LogoURL            *string   `json:"logo_url"`
 	Location           *string   `json:"location"`
+	Province           *string   `json:"province"` // regional admins only see companies in their provinces
 	CompanyStory       *string   `json:"company_story"`
 	Founded            *string   `json:"founded"`
 	Founder            *string   `json:"founder"`
@@ -24,8 +28,12 @@ type CompanyProfile struct {
 	GalleryImage1      *string   `json:"gallery_image_1"`
 	GalleryImage2      *string   `json:"gallery_image_2"`
 	GalleryImage3      *string   `json:"gallery_image_3"`
+	VerificationLevel  string    `json:"verification_level"` // BASIC, STANDARD or PREMIUM; set by admins only
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+	// Set when the profile was merged away as a duplicate (see CompanyMerge)
+	MergedIntoID *int64     `json:"merged_into_id,omitempty"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty"`
 }
 
 // PublicCompanyProfile is the public-facing version with conditional fields
@@ -38,6 +46,10 @@ type PublicCompanyProfile struct {
 	GalleryImage1 *string `json:"gallery_image_1"`
 	GalleryImage2 *string `json:"gallery_image_2"`
 	GalleryImage3 *string `json:"gallery_image_3"`
+	Industry      *string `json:"industry"`
+	Description   *string `json:"description"` // "About" section
+	// Verification badge: BASIC, STANDARD or PREMIUM
+	VerificationLevel string `json:"verification_level"`
 	// Conditional fields - only shown if viewer is verified or hide_company_details is false
 	Founded       *string `json:"founded,omitempty"`
 	Founder       *string `json:"founder,omitempty"`
@@ -48,6 +60,78 @@ type PublicCompanyProfile struct {
 	DetailsHidden bool `json:"details_hidden"`
 }
 
+// Company size bands shown instead of the company on confidential jobs
+var CompanySizeBands = []struct {
+	Max  int
+	Band string
+}{
+	{10, "1-10"},
+	{50, "11-50"},
+	{200, "51-200"},
+	{500, "201-500"},
+	{1000, "501-1000"},
+}
+
+// CompanySizeBandOver1000 is the band of companies above the largest CompanySizeBands entry
+const CompanySizeBandOver1000 = "1000+"
+
+// CompanySizeBandFor buckets the free-text employee count ("51-200", "about 80",
+// "1,000+") by its last number; nil when there is no number
+func CompanySizeBandFor(employeeCount *string) *string {
+	if employeeCount == nil {
+		return nil
+	}
+	text := strings.ReplaceAll(*employeeCount, ",", "")
+	fields := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+	if len(fields) == 0 {
+		return nil
+	}
+	count, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil {
+		return nil
+	}
+	if strings.HasSuffix(strings.TrimSpace(text), "+") {
+		count++
+	}
+	band := CompanySizeBandOver1000
+	for _, b := range CompanySizeBands {
+		if count <= b.Max {
+			band = b.Band
+			break
+		}
+	}
+	return &band
+}
+
+// MaxPublicCompanyPageJobs caps the jobs listed on a public company page
+const MaxPublicCompanyPageJobs = 50
+
+// PublicCompanyPage is a verified company's branding page with its active jobs
+type PublicCompanyPage struct {
+	PublicCompanyProfile
+	Jobs []Job `json:"jobs"`
+}
+
+// PublicCompanySummary is one verified company in the public company directory
+type PublicCompanySummary struct {
+	ID                int64   `json:"id"`
+	CompanyName       string  `json:"company_name"`
+	LogoURL           *string `json:"logo_url"`
+	Industry          *string `json:"industry"`
+	Location          *string `json:"location"`
+	VerificationLevel string  `json:"verification_level"`
+	ActiveJobCount    int     `json:"active_job_count"`
+}
+
+// PublicCompanyFilter narrows the public company directory
+type PublicCompanyFilter struct {
+	Query    string // company name contains
+	Industry string
+	Location string // contains
+	Page     int
+	PageSize int
+}
+
 // ViewerInfo contains information about the viewer for visibility logic
 type ViewerInfo struct {
 	IsAuthenticated    bool
@@ -65,7 +149,7 @@ func (v *ViewerInfo) ShouldShowCompanyDetails(hideCompanyDetails bool) bool {
 	if !v.IsAuthenticated {
 		return false
 	}
-	if v.Role == "admin" || v.Role == "employer" {
+	if v.Role == RoleAdmin || v.Role == RoleEmployer {
 		return true
 	}
 	// For candidates, must be verified
@@ -74,9 +158,17 @@ func (v *ViewerInfo) ShouldShowCompanyDetails(hideCompanyDetails bool) bool {
 
 // CompanyProfileRepository defines storage operations
 type CompanyProfileRepository interface {
+	// GetByUserID resolves merged duplicates to the surviving company and invited
+	// members to the company they joined
 	GetByUserID(ctx context.Context, userID string) (*CompanyProfile, error)
 	GetByID(ctx context.Context, id int64) (*CompanyProfile, error)
 	Upsert(ctx context.Context, profile *CompanyProfile) error
+
+	// Public pages only see verified, unarchived companies that were not merged away
+	// GetPublicByID returns ErrNotFound for any other company
+	GetPublicByID(ctx context.Context, id int64) (*CompanyProfile, error)
+	ListPublic(ctx context.Context, filter PublicCompanyFilter) ([]PublicCompanySummary, int64, error)
+	ListActiveJobs(ctx context.Context, companyID int64, limit int) ([]Job, error)
 }
 
 // CompanyProfileUsecase defines business logic operations
@@ -86,4 +178,6 @@ type CompanyProfileUsecase interface {
 	UpdateEmployerProfile(ctx context.Context, userID string, profile *CompanyProfile) error
 	// Public operations
 	GetPublicProfile(ctx context.Context, id int64, viewer *ViewerInfo) (*PublicCompanyProfile, error)
+	ListPublicCompanies(ctx context.Context, filter PublicCompanyFilter) (*PaginatedResult[PublicCompanySummary], error)
+	GetPublicCompanyPage(ctx context.Context, id int64, viewer *ViewerInfo) (*PublicCompanyPage, error)
 }
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Usage metrics shown on the employer usage dashboard
+const (
+	UsageMetricJobSlots = "job_slots" // jobs posted, limited by the plan's job_slots
+	UsageMetricUnlocks  = "unlocks"   // contact reveals, limited by the credit balance
+	UsageMetricExports  = "exports"   // application exports per month
+	UsageMetricAPICalls = "api_calls" // API-key calls per month (company_usage_counters)
+)
+
+// UsageNearLimitPercent is the share of a quota at which a metric is flagged as near its limit
+const UsageNearLimitPercent = 80
+
+// CompanyPlanQuotas are the limits of a company's current plan; nil means unlimited
+type CompanyPlanQuotas struct {
+	PlanCode        *string
+	PlanName        *string
+	JobSlots        *int64
+	MonthlyExports  *int64
+	MonthlyAPICalls *int64
+}
+
+// CompanyUsagePoint is a metric's usage in one month
+type CompanyUsagePoint struct {
+	Month string `json:"month"` // YYYY-MM
+	Used  int64  `json:"used"`
+}
+
+// CompanyUsageMetric is one metric's consumption against its quota
+type CompanyUsageMetric struct {
+	Metric    string              `json:"metric"`
+	Period    string              `json:"period"` // "total" for job slots, "month" for the rest
+	Used      int64               `json:"used"`
+	Limit     *int64              `json:"limit"`     // null when unlimited; for unlocks, used + credit balance
+	Remaining *int64              `json:"remaining"` // null when unlimited
+	NearLimit bool                `json:"near_limit"`
+	History   []CompanyUsagePoint `json:"history"`
+}
+
+// CompanyUsage is the usage dashboard of one company
+type CompanyUsage struct {
+	CompanyID   int64                `json:"company_id"`
+	PlanCode    *string              `json:"plan_code"`
+	PlanName    *string              `json:"plan_name"`
+	From        string               `json:"from"` // YYYY-MM
+	To          string               `json:"to"`   // YYYY-MM, the current month
+	Metrics     []CompanyUsageMetric `json:"metrics"`
+	GeneratedAt time.Time            `json:"generated_at"`
+}
+
+// CompanyUsageMonthCount is one (metric, month) row of a company's usage history
+type CompanyUsageMonthCount struct {
+	Metric string
+	Month  time.Time
+	Count  int64
+}
+
+type CompanyUsageRepository interface {
+	// GetPlanQuotas returns the limits of the company's active subscription,
+	// or an empty CompanyPlanQuotas when it has none
+	GetPlanQuotas(ctx context.Context, companyID int64) (*CompanyPlanQuotas, error)
+	CountJobs(ctx context.Context, companyID int64) (int64, error)
+	// CountByMonth returns usage per metric and month for [from, to)
+	CountByMonth(ctx context.Context, companyID int64, from, to time.Time) ([]CompanyUsageMonthCount, error)
+	// IncrementCounter adds n to a company's daily counter for metrics without a table of their own
+	IncrementCounter(ctx context.Context, companyID int64, metric string, day time.Time, n int64) error
+}
+
+type CompanyUsageUsecase interface {
+	// GetMyUsage returns the caller's company usage for the current month and the months before it
+	GetMyUsage(ctx context.Context, userID string, months int) (*CompanyUsage, error)
+}
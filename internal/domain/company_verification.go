@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Company verification levels, lowest first. Every company starts at BASIC
+// (employers only exist locally once their email is verified).
+const (
+	CompanyLevelBasic    = "BASIC"    // email verified
+	CompanyLevelStandard = "STANDARD" // verification documents reviewed
+	CompanyLevelPremium  = "PREMIUM"  // site visit or signed contract
+)
+
+// CompanyVerificationLevels lists the levels from lowest to highest
+var CompanyVerificationLevels = []string{CompanyLevelBasic, CompanyLevelStandard, CompanyLevelPremium}
+
+// CompanyLevelCapabilities is what a verification level unlocks
+type CompanyLevelCapabilities struct {
+	MaxOpenJobs      int  `json:"max_open_jobs"`     // active or scheduled jobs; 0 = unlimited
+	ATSAccess        bool `json:"ats_access"`        // candidate search over applicants and the talent pool
+	ConfidentialJobs bool `json:"confidential_jobs"` // jobs posted without showing the company publicly
+}
+
+// CompanyLevelCapabilityTable is enforced by the job and ATS usecases
+var CompanyLevelCapabilityTable = map[string]CompanyLevelCapabilities{
+	CompanyLevelBasic:    {MaxOpenJobs: 1, ATSAccess: false, ConfidentialJobs: false},
+	CompanyLevelStandard: {MaxOpenJobs: 10, ATSAccess: true, ConfidentialJobs: true},
+	CompanyLevelPremium:  {MaxOpenJobs: 0, ATSAccess: true, ConfidentialJobs: true},
+}
+
+// CompanyCapabilitiesFor returns the capabilities of a level; unknown levels get BASIC
+func CompanyCapabilitiesFor(level string) CompanyLevelCapabilities {
+	if caps, ok := CompanyLevelCapabilityTable[level]; ok {
+		return caps
+	}
+	return CompanyLevelCapabilityTable[CompanyLevelBasic]
+}
+
+// CompanyLevelRank orders levels; unknown levels rank lowest
+func CompanyLevelRank(level string) int {
+	for i, l := range CompanyVerificationLevels {
+		if l == level {
+			return i
+		}
+	}
+	return 0
+}
+
+// CompanyVerificationLevelChange is one entry of a company's level history
+type CompanyVerificationLevelChange struct {
+	ID        int64     `json:"id"`
+	CompanyID int64     `json:"company_id"`
+	FromLevel string    `json:"from_level"`
+	ToLevel   string    `json:"to_level"`
+	ChangedBy *string   `json:"changed_by,omitempty"` // unset for automatic changes
+	Reason    *string   `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CompanyVerification is a company's level with what it unlocks
+type CompanyVerification struct {
+	CompanyID    int64                            `json:"company_id"`
+	CompanyName  string                           `json:"company_name"`
+	Province     *string                          `json:"province,omitempty"`
+	Level        string                           `json:"level"`
+	Capabilities CompanyLevelCapabilities         `json:"capabilities"`
+	OpenJobs     int                              `json:"open_jobs"`
+	NextLevel    *string                          `json:"next_level,omitempty"` // unset at PREMIUM
+	UpdatedAt    *time.Time                       `json:"updated_at,omitempty"` // last level change
+	History      []CompanyVerificationLevelChange `json:"history,omitempty"`    // admin view only
+}
+
+// SetCompanyLevelRequest is an admin granting (or withdrawing) a level
+type SetCompanyLevelRequest struct {
+	Level  string `json:"level" binding:"required,oneof=BASIC STANDARD PREMIUM"`
+	Reason string `json:"reason" binding:"required,min=5,max=500"` // e.g. "Site visit 2026-03-02"
+}
+
+// CompanyVerificationFilter narrows the admin company list
+type CompanyVerificationFilter struct {
+	Level    string
+	Query    string // company name contains
+	Page     int
+	PageSize int
+
+	// Lower-cased provinces of a regional admin; nil for unrestricted admins
+	ScopeProvinces []string
+}
+
+type CompanyVerificationRepository interface {
+	Get(ctx context.Context, companyID int64) (*CompanyVerification, error)
+	List(ctx context.Context, filter CompanyVerificationFilter) ([]CompanyVerification, int64, error)
+	History(ctx context.Context, companyID int64) ([]CompanyVerificationLevelChange, error)
+	// SetLevel changes the level and records the change in one transaction;
+	// changedBy is nil for automatic changes
+	SetLevel(ctx context.Context, companyID int64, level string, changedBy *string, reason string) error
+	// RaiseLevel moves the employer's company up to level and records the change,
+	// leaving companies already at or above it untouched. It reports whether the
+	// level changed.
+	RaiseLevel(ctx context.Context, employerUserID, level, reason string) (bool, error)
+	// CountOpenJobs counts the company's active and scheduled jobs
+	CountOpenJobs(ctx context.Context, companyID int64) (int, error)
+}
+
+type CompanyVerificationUsecase interface {
+	// Employer
+	GetMyVerification(ctx context.Context, userID string) (*CompanyVerification, error)
+
+	// Admin
+	ListCompanies(ctx context.Context, filter CompanyVerificationFilter) (*PaginatedResult[CompanyVerification], error)
+	GetCompanyVerification(ctx context.Context, companyID int64) (*CompanyVerification, error)
+	SetLevel(ctx context.Context, adminID string, companyID int64, req SetCompanyLevelRequest) (*CompanyVerification, error)
+}
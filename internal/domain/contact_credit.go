@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Credit ledger entry types
+const (
+	CreditEntryPlanGrant  = "plan_grant" // credits included with a subscription plan
+	CreditEntryPurchase   = "purchase"   // credits bought by the company
+	CreditEntryReveal     = "reveal"     // one credit consumed by a contact reveal
+	CreditEntryAdjustment = "adjustment" // manual admin correction
+)
+
+// ContactRevealCost is the number of credits one candidate contact unlock consumes
+const ContactRevealCost = 1
+
+// ErrInsufficientCredits is returned when a company balance cannot cover a deduction
+var ErrInsufficientCredits = errors.New("insufficient credits")
+
+// ============================================================================
+// Contact Reveal Credits
+// ============================================================================
+
+// CompanyCreditBalance is the current credit balance of a company
+type CompanyCreditBalance struct {
+	CompanyID int64      `json:"company_id"`
+	Balance   int        `json:"balance"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CreditLedgerEntry is one append-only change to a company's balance.
+// Amount is positive for grants/purchases and negative for reveals.
+type CreditLedgerEntry struct {
+	ID              int64     `json:"id"`
+	CompanyID       int64     `json:"company_id"`
+	EntryType       string    `json:"entry_type"`
+	Amount          int       `json:"amount"`
+	BalanceAfter    int       `json:"balance_after"`
+	IdempotencyKey  *string   `json:"idempotency_key,omitempty"`
+	CandidateUserID *string   `json:"candidate_user_id,omitempty"`
+	ActorUserID     *string   `json:"actor_user_id,omitempty"`
+	Note            *string   `json:"note,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// CandidateContact holds the contact details unlocked by a reveal
+type CandidateContact struct {
+	CandidateUserID string  `json:"candidate_user_id"`
+	Name            string  `json:"name"`
+	Email           string  `json:"email"`
+	Phone           *string `json:"phone,omitempty"`
+}
+
+// ContactRevealResult is returned from a reveal. Charged is false when the company
+// had already unlocked this candidate or the request replayed an idempotency key.
+type ContactRevealResult struct {
+	Contact *CandidateContact  `json:"contact"`
+	Charged bool               `json:"charged"`
+	Balance int                `json:"balance"`
+	Entry   *CreditLedgerEntry `json:"ledger_entry,omitempty"`
+}
+
+// GrantCreditsRequest is the admin payload for adding credits to a company
+type GrantCreditsRequest struct {
+	EntryType string  `json:"entry_type" binding:"required,oneof=plan_grant purchase adjustment"`
+	Amount    int     `json:"amount" binding:"required,min=1,max=100000"`
+	Note      *string `json:"note" binding:"omitempty,max=500"`
+}
+
+type ContactCreditRepository interface {
+	GetBalance(ctx context.Context, companyID int64) (*CompanyCreditBalance, error)
+	// AddCredits increases the balance and writes entry. If entry.IdempotencyKey was
+	// already used by this company, the existing entry is loaded into entry and
+	// replayed is true.
+	AddCredits(ctx context.Context, entry *CreditLedgerEntry) (replayed bool, err error)
+	// ConsumeReveal deducts ContactRevealCost and records the reveal in one transaction.
+	// Returns a nil entry when the company already revealed this candidate.
+	ConsumeReveal(ctx context.Context, companyID int64, candidateUserID, actorUserID string, idempotencyKey *string) (entry *CreditLedgerEntry, charged bool, err error)
+	ListLedger(ctx context.Context, companyID int64, page, pageSize int) ([]CreditLedgerEntry, int64, error)
+	// GetCandidateContact returns contact details for an active (non-paused) candidate
+	// whose profile visibility lets the company find them, or whose contact it already
+	// unlocked; ErrNotFound otherwise
+	GetCandidateContact(ctx context.Context, companyID int64, candidateUserID string) (*CandidateContact, error)
+	// HasRevealed reports whether the company has unlocked the candidate's contact
+	HasRevealed(ctx context.Context, companyID int64, candidateUserID string) (bool, error)
+}
+
+type ContactCreditUsecase interface {
+	// Employer
+	GetMyBalance(ctx context.Context, userID string) (*CompanyCreditBalance, error)
+	ListMyLedger(ctx context.Context, userID string, page, pageSize int) (*PaginatedResult[CreditLedgerEntry], error)
+	RevealContact(ctx context.Context, userID, candidateUserID, idempotencyKey string) (*ContactRevealResult, error)
+
+	// Admin
+	GetCompanyBalance(ctx context.Context, companyID int64) (*CompanyCreditBalance, error)
+	ListCompanyLedger(ctx context.Context, companyID int64, page, pageSize int) (*PaginatedResult[CreditLedgerEntry], error)
+	GrantCredits(ctx context.Context, companyID int64, idempotencyKey string, req *GrantCreditsRequest) (*CreditLedgerEntry, error)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "context"
+
+// CVEducation is one school or degree found in a CV
+type CVEducation struct {
+	Institution string `json:"institution,omitempty"`
+	Degree      string `json:"degree,omitempty"` // SMP, SMA, SMK, D1-D4, S1-S3
+	Major       string `json:"major,omitempty"`
+	StartYear   *int   `json:"start_year,omitempty"`
+	EndYear     *int   `json:"end_year,omitempty"`
+}
+
+// CVDraft is what a parsed CV suggests for the candidate's profile. Nothing in
+// it is saved; the candidate reviews it and submits the profile as usual.
+type CVDraft struct {
+	FirstName        *string          `json:"first_name,omitempty"`
+	LastName         *string          `json:"last_name,omitempty"`
+	Email            *string          `json:"email,omitempty"`
+	Phone            *string          `json:"phone,omitempty"`
+	HighestEducation *string          `json:"highest_education,omitempty"`
+	MajorField       *string          `json:"major_field,omitempty"`
+	Education        []CVEducation    `json:"education"`
+	WorkExperiences  []WorkExperience `json:"work_experiences"` // country and type are a guess
+	Skills           []string         `json:"skills"`           // as written in the CV
+	MatchedSkills    []Skill          `json:"matched_skills"`   // skills found in the master list
+	Warnings         []string         `json:"warnings,omitempty"`
+}
+
+// CVPrefillResult reports which empty profile data an uploaded CV filled in
+type CVPrefillResult struct {
+	VerificationFields []string `json:"verification_fields"` // account_verifications columns set
+	EducationFields    []string `json:"education_fields"`    // candidate_profiles columns set
+	WorkExperiences    int      `json:"work_experiences"`
+	Skills             int      `json:"skills"`
+}
+
+type CVParseRepository interface {
+	// PrefillVerification sets the given fields only where the candidate's
+	// verification has them empty and returns the columns it set
+	PrefillVerification(ctx context.Context, userID string, firstName, lastName, phone, cvURL *string) ([]string, error)
+	// PrefillEducation does the same for an existing candidate profile
+	PrefillEducation(ctx context.Context, userID string, highestEducation, majorField *string) ([]string, error)
+	// AddWorkExperiences inserts the experiences only if the candidate has none yet
+	AddWorkExperiences(ctx context.Context, userID string, experiences []WorkExperience) (int, error)
+	ListSkills(ctx context.Context) ([]Skill, error)
+	// AddSkills links master skills to the candidate, skipping ones already linked
+	AddSkills(ctx context.Context, userID string, skillIDs []int) (int, error)
+}
+
+type CVParseUsecase interface {
+	// ParseCV returns a draft for the candidate to confirm; it saves nothing
+	ParseCV(ctx context.Context, userID, filename string, data []byte) (*CVDraft, error)
+	// PrefillFromCV fills the candidate's empty profile data from an uploaded CV.
+	// Existing data is never overwritten. It runs after the upload, detached from
+	// the request, so the caller checks the candidate role.
+	PrefillFromCV(ctx context.Context, userID, filename string, data []byte, cvURL string) (*CVPrefillResult, error)
+}
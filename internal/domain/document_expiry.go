@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Expiring candidate document types (candidate_document_expiries view)
+const (
+	DocumentTypePassport     = "PASSPORT"
+	DocumentTypeJLPT         = "JLPT"
+	DocumentTypeMedicalCheck = "MEDICAL_CHECK"
+	DocumentTypeCoE          = "COE"
+	DocumentTypeCertificate  = "CERTIFICATE" // TOEFL/IELTS/TOEIC/other, not critical
+	DocumentTypeDiploma      = "DIPLOMA"     // document vault only, not critical
+	DocumentTypeVisa         = "VISA"        // document vault only
+)
+
+// DocumentExpiryReminderDays are the reminder thresholds, largest first.
+// A candidate gets at most one reminder per document per threshold.
+var DocumentExpiryReminderDays = []int{90, 30, 7}
+
+// CriticalDocumentExpiryWindowDays is how close to expiry a critical document
+// must be for the ATS to flag the candidate (expired documents are flagged too)
+const CriticalDocumentExpiryWindowDays = 30
+
+// Reminder delivery status
+const (
+	DocumentReminderStatusSent   = "SENT"
+	DocumentReminderStatusFailed = "FAILED"
+)
+
+// CandidateDocumentExpiry is one expiring document
+type CandidateDocumentExpiry struct {
+	UserID        string    `json:"user_id"`
+	DocumentType  string    `json:"document_type"`
+	DocumentRef   string    `json:"document_ref,omitempty"`   // certificate or vault document id when a candidate has several
+	DocumentLabel *string   `json:"document_label,omitempty"` // e.g. "N2", "TOEIC"
+	ExpiryDate    time.Time `json:"expiry_date"`
+	Critical      bool      `json:"critical"`
+	DaysLeft      int       `json:"days_left"` // negative once expired
+}
+
+// DocumentExpiryReminderCandidate is a document due for a reminder, with what is
+// needed to address the candidate
+type DocumentExpiryReminderCandidate struct {
+	CandidateDocumentExpiry
+	ThresholdDays   int
+	Email           string
+	FirstName       *string
+	PreferredLocale *string
+}
+
+// DocumentExpiryReminder records one reminder delivery
+type DocumentExpiryReminder struct {
+	UserID        string
+	DocumentType  string
+	DocumentRef   string
+	ExpiryDate    time.Time
+	ThresholdDays int
+	Channel       string
+	Status        string // SENT, FAILED
+	ErrorMessage  *string
+}
+
+// DocumentExpiryRunResult summarizes one reminder worker run
+type DocumentExpiryRunResult struct {
+	Documents  int       `json:"documents"`  // documents due for a reminder
+	Candidates int       `json:"candidates"` // one message per candidate
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// DocumentExpiryMonth counts documents expiring in one calendar month
+type DocumentExpiryMonth struct {
+	Month      string           `json:"month"` // YYYY-MM
+	Total      int64            `json:"total"`
+	Candidates int64            `json:"candidates"`
+	ByType     map[string]int64 `json:"by_type"`
+}
+
+// DocumentExpiryReport covers expirations from the first day of From to the last day of To
+type DocumentExpiryReport struct {
+	From        string                `json:"from"` // YYYY-MM
+	To          string                `json:"to"`   // YYYY-MM
+	Months      []DocumentExpiryMonth `json:"months"`
+	Expired     int64                 `json:"expired"` // already expired, across all candidates
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// DocumentExpiryMonthCount is one (month, document type) row of the report
+type DocumentExpiryMonthCount struct {
+	Month        time.Time
+	DocumentType string
+	Count        int64
+}
+
+type DocumentExpiryRepository interface {
+	// ListDueReminders returns unexpired documents within the largest threshold whose
+	// current threshold has no successful reminder yet
+	ListDueReminders(ctx context.Context, today time.Time, thresholds []int, limit int) ([]DocumentExpiryReminderCandidate, error)
+	// RecordReminder stores a delivery; a failed reminder is overwritten by a later attempt
+	RecordReminder(ctx context.Context, r *DocumentExpiryReminder) error
+
+	ListByUser(ctx context.Context, userID string, today time.Time) ([]CandidateDocumentExpiry, error)
+
+	CountByMonth(ctx context.Context, from, to time.Time) ([]DocumentExpiryMonthCount, error)
+	// CountCandidatesByMonth returns distinct candidates per YYYY-MM (a candidate can have several documents)
+	CountCandidatesByMonth(ctx context.Context, from, to time.Time) (map[string]int64, error)
+	CountExpired(ctx context.Context, today time.Time) (int64, error)
+}
+
+type DocumentExpiryUsecase interface {
+	// RunReminders is called by the background worker
+	RunReminders(ctx context.Context) (*DocumentExpiryRunResult, error)
+	// TriggerReminders runs the reminders immediately (admin only)
+	TriggerReminders(ctx context.Context) (*DocumentExpiryRunResult, error)
+
+	ListMyDocuments(ctx context.Context, userID string) ([]CandidateDocumentExpiry, error)
+
+	GetReport(ctx context.Context, from, to string) (*DocumentExpiryReport, error)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DuplicateDocumentBuckets are the upload buckets whose images are checked for
+// reuse across accounts (JLPT certificates and vault documents)
+var DuplicateDocumentBuckets = []string{"JLPT", "Candidate_Documents"}
+
+// DuplicateDocumentMaxDistance is the largest perceptual hash distance (in bits
+// out of 64) at which two images count as the same document
+const DuplicateDocumentMaxDistance = 6
+
+// MaxDuplicateDocumentMatches caps the other accounts listed on one review task
+const MaxDuplicateDocumentMatches = 20
+
+// Duplicate document review status
+const (
+	DuplicateDocumentPending   = "PENDING"
+	DuplicateDocumentConfirmed = "CONFIRMED" // the document was reused
+	DuplicateDocumentDismissed = "DISMISSED" // false positive
+)
+
+// DocumentImageHash is the perceptual hash of an uploaded document image
+type DocumentImageHash struct {
+	ID        int64     `json:"id"`
+	UserID    string    `json:"user_id"`
+	FileID    *string   `json:"file_id,omitempty"`
+	Bucket    string    `json:"bucket"`
+	FileURL   string    `json:"file_url"`
+	Hash      uint64    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DuplicateDocumentMatch is an earlier upload by another account that looks like
+// the flagged image
+type DuplicateDocumentMatch struct {
+	HashID    int64     `json:"-"`
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	FileURL   string    `json:"file_url"`
+	Distance  int       `json:"distance"` // differing hash bits; 0 is a pixel-identical image
+	Link      string    `json:"link"`     // admin page of the account
+	CreatedAt time.Time `json:"uploaded_at"`
+}
+
+// DuplicateDocumentReview is a review task for an upload that matches documents
+// of other accounts
+type DuplicateDocumentReview struct {
+	ID          int64                    `json:"id"`
+	UserID      string                   `json:"user_id"`
+	Email       string                   `json:"email"`
+	Link        string                   `json:"link"` // admin page of the uploading account
+	Bucket      string                   `json:"bucket"`
+	FileURL     string                   `json:"file_url"`
+	Matches     []DuplicateDocumentMatch `json:"matches"` // closest first
+	Status      string                   `json:"status"`  // PENDING, CONFIRMED, DISMISSED
+	ReviewNotes *string                  `json:"review_notes,omitempty"`
+	ReviewedBy  *string                  `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time               `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+}
+
+// DocumentImageUpload is a stored upload to check for duplicates
+type DocumentImageUpload struct {
+	UserID  string
+	FileID  string
+	Bucket  string
+	FileURL string
+	Data    []byte
+}
+
+// ReviewDuplicateDocumentRequest resolves a duplicate document review task
+type ReviewDuplicateDocumentRequest struct {
+	Action string  `json:"action" binding:"required,oneof=CONFIRM DISMISS"`
+	Notes  *string `json:"notes" binding:"omitempty,max=1000"`
+}
+
+// DuplicateDocumentFilter selects review tasks
+type DuplicateDocumentFilter struct {
+	Status   string // PENDING by default
+	UserID   string // tasks where the account uploaded or matched
+	Page     int
+	PageSize int
+}
+
+type DuplicateDocumentRepository interface {
+	// CreateHash stores the hash and returns the earlier images of other accounts
+	// within maxDistance, closest first
+	CreateHash(ctx context.Context, h *DocumentImageHash, maxDistance, limit int) ([]DuplicateDocumentMatch, error)
+	// CreateReview opens a review task for the hash with its matches
+	CreateReview(ctx context.Context, hashID int64, matches []DuplicateDocumentMatch) (int64, error)
+	List(ctx context.Context, filter DuplicateDocumentFilter) ([]DuplicateDocumentReview, int64, error)
+	GetByID(ctx context.Context, id int64) (*DuplicateDocumentReview, error)
+	// Review stores the status, notes and reviewer of a task
+	Review(ctx context.Context, r *DuplicateDocumentReview) error
+}
+
+type DuplicateDocumentUsecase interface {
+	// CheckUpload hashes a stored document image and opens a review task when it
+	// matches documents of other accounts. Files that are not images are skipped.
+	CheckUpload(ctx context.Context, upload DocumentImageUpload) error
+
+	ListReviews(ctx context.Context, filter DuplicateDocumentFilter) (*PaginatedResult[DuplicateDocumentReview], error)
+	GetReview(ctx context.Context, id int64) (*DuplicateDocumentReview, error)
+	ReviewDuplicate(ctx context.Context, adminID string, id int64, req ReviewDuplicateDocumentRequest) (*DuplicateDocumentReview, error)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"go-recruitment-backend/pkg/email"
+	"time"
+)
+
+// EmailTrackingCategories are the email categories that can be tracked, and so
+// switched off by users. Security emails are never tracked.
+var EmailTrackingCategories = []string{email.CategoryAccount, email.CategoryApplications, email.CategoryTeam}
+
+// EmailMessage is one tracked email. It keeps the template and engagement
+// counts only, not the recipient.
+type EmailMessage struct {
+	ID             int64
+	Template       string
+	Category       string
+	OpenToken      string
+	Links          []EmailMessageLink
+	OpenCount      int
+	FirstOpenedAt  *time.Time
+	ClickCount     int
+	FirstClickedAt *time.Time
+	SentAt         time.Time
+}
+
+// EmailMessageLink is a link of a tracked email, reached through its token
+type EmailMessageLink struct {
+	Token string
+	URL   string
+}
+
+// EmailTrackingHit is what a tracking token resolved to: the link's URL for a
+// click, nil for an open
+type EmailTrackingHit struct {
+	URL *string
+}
+
+// EmailTrackingPreference is whether a user's emails may be tracked
+type EmailTrackingPreference struct {
+	Enabled            bool       `json:"enabled"`
+	DisabledCategories []string   `json:"disabled_categories"`
+	Categories         []string   `json:"categories"` // the categories that can be switched off
+	UpdatedAt          *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateEmailTrackingPreferenceRequest switches tracking off (or back on) for
+// all of the caller's emails or some categories
+type UpdateEmailTrackingPreferenceRequest struct {
+	Enabled            *bool    `json:"enabled" binding:"required"`
+	DisabledCategories []string `json:"disabled_categories"`
+}
+
+// EmailEngagementStats is the engagement of one template's tracked emails
+type EmailEngagementStats struct {
+	Template  string  `json:"template"`
+	Category  string  `json:"category"`
+	Sent      int64   `json:"sent"`
+	Opened    int64   `json:"opened"`  // emails opened at least once
+	Clicked   int64   `json:"clicked"` // emails with at least one click
+	Opens     int64   `json:"opens"`   // all opens, repeats included
+	Clicks    int64   `json:"clicks"`
+	OpenRate  float64 `json:"open_rate"`  // opened / sent
+	ClickRate float64 `json:"click_rate"` // clicked / sent
+}
+
+// EmailEngagementReport covers tracked emails sent between From and To
+// (inclusive days). Opens are a lower bound: mail clients that block images
+// never load the pixel.
+type EmailEngagementReport struct {
+	From        string                 `json:"from"` // YYYY-MM-DD
+	To          string                 `json:"to"`   // YYYY-MM-DD
+	Templates   []EmailEngagementStats `json:"templates"`
+	Totals      EmailEngagementStats   `json:"totals"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+type EmailTrackingRepository interface {
+	CreateMessage(ctx context.Context, m *EmailMessage) error
+	// RecordHit counts an open or click of token; ErrNotFound when unknown
+	RecordHit(ctx context.Context, token string, at time.Time) (*EmailTrackingHit, error)
+	GetStats(ctx context.Context, from, to time.Time) ([]EmailEngagementStats, error)
+	GetPreference(ctx context.Context, userID string) (*EmailTrackingPreference, error)
+	UpsertPreference(ctx context.Context, userID string, pref *EmailTrackingPreference) error
+}
+
+type EmailTrackingUsecase interface {
+	email.Tracker
+
+	// Hit records an open or click of a token from an email
+	Hit(ctx context.Context, token string) (*EmailTrackingHit, error)
+
+	GetPreference(ctx context.Context, userID string) (*EmailTrackingPreference, error)
+	UpdatePreference(ctx context.Context, userID string, req UpdateEmailTrackingPreferenceRequest) (*EmailTrackingPreference, error)
+
+	// Admin
+	GetReport(ctx context.Context, from, to string) (*EmailEngagementReport, error)
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Why a stored email address is undeliverable
+const (
+	EmailInvalidSyntax = "syntax"     // not a valid address
+	EmailNoMX          = "no_mx"      // the domain has no mail server
+	EmailDisposable    = "disposable" // throwaway mailbox provider
+)
+
+// EmailInvalidReasons are the values of UndeliverableEmail.Reason
+var EmailInvalidReasons = []string{EmailInvalidSyntax, EmailNoMX, EmailDisposable}
+
+// EmailValidation is the result of checking one user's email address
+type EmailValidation struct {
+	UserID    string
+	Email     string
+	Reason    *string // nil = deliverable
+	Detail    *string
+	CheckedAt time.Time
+}
+
+// EmailValidationCandidate is a user whose email is due for a check
+type EmailValidationCandidate struct {
+	UserID string
+	Email  string
+}
+
+// EmailValidationRunResult summarizes one validation worker run
+type EmailValidationRunResult struct {
+	Checked       int       `json:"checked"`
+	Deliverable   int       `json:"deliverable"`
+	Undeliverable int       `json:"undeliverable"`
+	Errors        int       `json:"errors"` // DNS lookups that failed temporarily; retried next run
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+}
+
+// EmailValidationReport counts users by the state of their email address
+type EmailValidationReport struct {
+	Users         int64            `json:"users"`
+	Unchecked     int64            `json:"unchecked"` // never checked, or changed since the last check
+	Deliverable   int64            `json:"deliverable"`
+	Undeliverable int64            `json:"undeliverable"`
+	ByReason      map[string]int64 `json:"by_reason"`
+	ByRole        map[string]int64 `json:"by_role"` // undeliverable users per role
+	LastCheckedAt *time.Time       `json:"last_checked_at,omitempty"`
+	GeneratedAt   time.Time        `json:"generated_at"`
+}
+
+// UndeliverableEmail is an account whose email cannot receive notifications, with
+// what an admin needs to decide whether to re-engage or prune it
+type UndeliverableEmail struct {
+	UserID       string     `json:"user_id"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	Reason       string     `json:"reason"`
+	Detail       *string    `json:"detail,omitempty"`
+	CheckedAt    time.Time  `json:"checked_at"`
+	LastActiveAt *time.Time `json:"last_active_at,omitempty"`
+	IsDisabled   bool       `json:"is_disabled"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Link         string     `json:"link"`
+}
+
+// UndeliverableEmailFilter selects accounts for the admin list
+type UndeliverableEmailFilter struct {
+	Reason   string // empty = every reason
+	Role     string
+	Page     int
+	PageSize int
+}
+
+type EmailValidationRepository interface {
+	// ListDue returns users whose email was never checked, changed since its last
+	// check, or was last checked at or before recheckBefore; unchecked ones first
+	ListDue(ctx context.Context, recheckBefore time.Time, limit int) ([]EmailValidationCandidate, error)
+	Save(ctx context.Context, v *EmailValidation) error
+	// IsUndeliverable reports whether the address's latest check found it undeliverable
+	IsUndeliverable(ctx context.Context, email string) (bool, error)
+
+	Report(ctx context.Context) (*EmailValidationReport, error)
+	ListUndeliverable(ctx context.Context, filter UndeliverableEmailFilter) ([]UndeliverableEmail, int64, error)
+}
+
+type EmailValidationUsecase interface {
+	// RunValidation is called by the background worker
+	RunValidation(ctx context.Context) (*EmailValidationRunResult, error)
+	// TriggerValidation runs a validation pass immediately (admin only)
+	TriggerValidation(ctx context.Context) (*EmailValidationRunResult, error)
+
+	GetReport(ctx context.Context) (*EmailValidationReport, error)
+	ListUndeliverable(ctx context.Context, filter UndeliverableEmailFilter) (*PaginatedResult[UndeliverableEmail], error)
+}
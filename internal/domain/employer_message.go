@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Template variables an employer can use in a message subject or body
+const (
+	MessageVarCandidateName = "candidate_name"
+	MessageVarJobTitle      = "job_title"
+	MessageVarCompanyName   = "company_name"
+)
+
+// MessageTemplateVariables lists the supported variables, written as {{name}}
+var MessageTemplateVariables = []string{MessageVarCandidateName, MessageVarJobTitle, MessageVarCompanyName}
+
+// ShortlistStages are the pipeline stages whose candidates can be messaged in bulk
+var ShortlistStages = []string{ApplicationStageScreening, ApplicationStageInterview, ApplicationStageOffer}
+
+// Per-recipient delivery status
+const (
+	MessageDeliveryPending = "PENDING" // stored, not yet handed to the notification system
+	MessageDeliverySent    = "SENT"
+	MessageDeliveryFailed  = "FAILED"
+	MessageDeliveryBlocked = "BLOCKED" // the candidate reported an earlier message from this company as spam
+)
+
+// MaxBulkMessageRecipients caps the applications in one bulk send
+const MaxBulkMessageRecipients = 100
+
+// MessageTemplate is a saved message an employer reuses
+type MessageTemplate struct {
+	ID        int64     `json:"id"`
+	CompanyID int64     `json:"company_id"`
+	Name      string    `json:"name"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MessageTemplateRequest creates or replaces a template
+type MessageTemplateRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	Subject string `json:"subject" binding:"required,max=200"`
+	Body    string `json:"body" binding:"required,max=5000"`
+}
+
+// SendBulkMessageRequest messages the candidates of shortlisted applications
+type SendBulkMessageRequest struct {
+	TemplateID     int64   `json:"template_id" binding:"required"`
+	ApplicationIDs []int64 `json:"application_ids" binding:"required,min=1,max=100,dive,required"`
+}
+
+// BulkMessage is one bulk send with its per-recipient delivery status
+type BulkMessage struct {
+	ID           int64     `json:"id"`
+	CompanyID    int64     `json:"company_id"`
+	TemplateID   *int64    `json:"template_id,omitempty"` // nil once the template is deleted
+	SenderUserID *string   `json:"sender_user_id,omitempty"`
+	Subject      string    `json:"subject"` // the template as sent, before substitution
+	Body         string    `json:"body"`
+	Recipients   int       `json:"recipients"`
+	Sent         int       `json:"sent"`
+	Failed       int       `json:"failed"`
+	Blocked      int       `json:"blocked"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	Deliveries []MessageDelivery `json:"deliveries,omitempty"`
+}
+
+// MessageDelivery is a bulk message rendered for one recipient
+type MessageDelivery struct {
+	ID              int64      `json:"id"`
+	MessageID       int64      `json:"message_id"`
+	ApplicationID   int64      `json:"application_id"`
+	CandidateUserID string     `json:"candidate_user_id"`
+	CandidateName   string     `json:"candidate_name"`
+	JobTitle        string     `json:"job_title"`
+	Subject         string     `json:"subject"`
+	Body            string     `json:"body"`
+	Status          string     `json:"status"`
+	ErrorMessage    *string    `json:"error_message,omitempty"`
+	SentAt          *time.Time `json:"sent_at,omitempty"`
+	ReportedSpamAt  *time.Time `json:"reported_spam_at,omitempty"`
+}
+
+// MessageRecipient is a shortlisted application resolved for a bulk send
+type MessageRecipient struct {
+	ApplicationID   int64
+	CompanyID       int64
+	Stage           string
+	CandidateUserID string
+	CandidateName   string
+	JobTitle        string
+}
+
+// CandidateMessage is a delivered employer message in the candidate's inbox
+type CandidateMessage struct {
+	ID             int64      `json:"id"` // delivery ID
+	CompanyName    string     `json:"company_name"`
+	JobTitle       string     `json:"job_title"`
+	Subject        string     `json:"subject"`
+	Body           string     `json:"body"`
+	SentAt         time.Time  `json:"sent_at"`
+	ReportedSpamAt *time.Time `json:"reported_spam_at,omitempty"`
+}
+
+// BulkMessageQuota is the company's bulk messaging allowance
+type BulkMessageQuota struct {
+	DailyLimit      int   `json:"daily_limit"`
+	SentToday       int   `json:"sent_today"` // recipients messaged since 00:00 UTC
+	Remaining       int   `json:"remaining"`
+	SpamReports     int64 `json:"spam_reports"` // within the spam window
+	SpamReportLimit int   `json:"spam_report_limit"`
+	SpamWindowDays  int   `json:"spam_window_days"`
+	Suspended       bool  `json:"suspended"` // too many spam reports; bulk sending is blocked
+}
+
+type EmployerMessageRepository interface {
+	ListTemplates(ctx context.Context, companyID int64) ([]MessageTemplate, error)
+	GetTemplate(ctx context.Context, companyID, id int64) (*MessageTemplate, error)
+	CreateTemplate(ctx context.Context, t *MessageTemplate) error
+	UpdateTemplate(ctx context.Context, t *MessageTemplate) error
+	DeleteTemplate(ctx context.Context, companyID, id int64) error
+
+	// GetRecipients resolves applications to the candidates behind them; IDs that
+	// do not exist are left out
+	GetRecipients(ctx context.Context, applicationIDs []int64) ([]MessageRecipient, error)
+	// ListSpamReporters returns which of the candidates reported this company's messages as spam
+	ListSpamReporters(ctx context.Context, companyID int64, candidateUserIDs []string) (map[string]bool, error)
+	CountDeliveriesSince(ctx context.Context, companyID int64, since time.Time) (int, error)
+	CountSpamReportsSince(ctx context.Context, companyID int64, since time.Time) (int64, error)
+
+	// CreateMessage stores the message and its deliveries, filling in their IDs
+	CreateMessage(ctx context.Context, m *BulkMessage) error
+	UpdateDelivery(ctx context.Context, d *MessageDelivery) error
+	ListMessages(ctx context.Context, companyID int64, limit, offset int) ([]BulkMessage, int64, error)
+	GetMessage(ctx context.Context, companyID, id int64) (*BulkMessage, error)
+
+	ListCandidateMessages(ctx context.Context, candidateUserID string, limit, offset int) ([]CandidateMessage, int64, error)
+	// ReportSpam marks a delivered message as spam; ErrNotFound when it is not the candidate's
+	ReportSpam(ctx context.Context, candidateUserID string, deliveryID int64, at time.Time) error
+}
+
+type EmployerMessageUsecase interface {
+	// Employer
+	ListTemplates(ctx context.Context, userID string) ([]MessageTemplate, error)
+	CreateTemplate(ctx context.Context, userID string, req MessageTemplateRequest) (*MessageTemplate, error)
+	UpdateTemplate(ctx context.Context, userID string, id int64, req MessageTemplateRequest) (*MessageTemplate, error)
+	DeleteTemplate(ctx context.Context, userID string, id int64) error
+	SendBulk(ctx context.Context, userID string, req SendBulkMessageRequest) (*BulkMessage, error)
+	ListMessages(ctx context.Context, userID string, page, pageSize int) (*PaginatedResult[BulkMessage], error)
+	GetMessage(ctx context.Context, userID string, id int64) (*BulkMessage, error)
+	GetQuota(ctx context.Context, userID string) (*BulkMessageQuota, error)
+
+	// Candidate
+	ListMyMessages(ctx context.Context, userID string, page, pageSize int) (*PaginatedResult[CandidateMessage], error)
+	ReportSpam(ctx context.Context, userID string, deliveryID int64) error
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+)
+
+// Machine-readable error codes returned as "code" in error responses. Codes are
+// stable: clients branch on them instead of the (translated) message. Errors
+// without a specific code carry the generic code of their status
+// (apperror.CodeForStatus).
+const (
+	// Jobs and applications
+	ErrCodeJobNotFound                = "JOB_NOT_FOUND"
+	ErrCodeApplicationNotFound        = "APPLICATION_NOT_FOUND"
+	ErrCodeAlreadyApplied             = "ALREADY_APPLIED"
+	ErrCodeApplicationDeadlinePassed  = "APPLICATION_DEADLINE_PASSED"
+	ErrCodeInsufficientContactCredits = "INSUFFICIENT_CONTACT_CREDITS"
+
+	// Accounts and profiles
+	ErrCodeUserNotFound             = "USER_NOT_FOUND"
+	ErrCodeCandidateNotFound        = "CANDIDATE_NOT_FOUND"
+	ErrCodeCandidateProfileNotFound = "CANDIDATE_PROFILE_NOT_FOUND"
+	ErrCodeCompanyProfileNotFound   = "COMPANY_PROFILE_NOT_FOUND"
+	ErrCodeProfileRefreshRequired   = "PROFILE_REFRESH_REQUIRED"
+
+	// Verification
+	ErrCodeVerificationNotFound = "VERIFICATION_NOT_FOUND"
+	ErrCodeProfileIncomplete    = "PROFILE_INCOMPLETE"
+	ErrCodeVerificationRequired = "VERIFICATION_REQUIRED"
+
+	// Access
+	ErrCodeCompanyRoleForbidden = "COMPANY_ROLE_FORBIDDEN"
+	ErrCodeAdminScopeForbidden  = "ADMIN_SCOPE_FORBIDDEN"
+	ErrCodeCSRFTokenInvalid     = "CSRF_TOKEN_INVALID"
+	ErrCodeFeatureDisabled      = "FEATURE_DISABLED"
+	ErrCodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+)
+
+// ErrorCodeInfo documents one error code for clients
+type ErrorCodeInfo struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// ErrorCodes enumerates every code the API returns, generic ones first
+var ErrorCodes = []ErrorCodeInfo{
+	{apperror.CodeBadRequest, http.StatusBadRequest, "The request is invalid"},
+	{apperror.CodeValidationFailed, http.StatusBadRequest, "Body fields failed validation; details lists them"},
+	{apperror.CodeUnauthorized, http.StatusUnauthorized, "Not signed in, or the session expired"},
+	{apperror.CodePaymentRequired, http.StatusPaymentRequired, "A paid resource is exhausted"},
+	{apperror.CodeForbidden, http.StatusForbidden, "The caller may not do this"},
+	{apperror.CodeNotFound, http.StatusNotFound, "The resource does not exist"},
+	{apperror.CodeConflict, http.StatusConflict, "The request conflicts with the current state"},
+	{apperror.CodeTooManyRequests, http.StatusTooManyRequests, "Rate limited; try again later"},
+	{apperror.CodeInternal, http.StatusInternalServerError, "Unexpected server error"},
+	{apperror.CodeServiceUnavailable, http.StatusServiceUnavailable, "Temporarily unavailable; try again shortly"},
+	{apperror.CodeError, 0, "Any other failure status"},
+
+	{ErrCodeJobNotFound, http.StatusNotFound, "The job does not exist or is not visible to the caller"},
+	{ErrCodeApplicationNotFound, http.StatusNotFound, "The application does not exist or is not visible to the caller"},
+	{ErrCodeAlreadyApplied, http.StatusBadRequest, "The candidate already applied to the job"},
+	{ErrCodeApplicationDeadlinePassed, http.StatusBadRequest, "The job no longer accepts applications"},
+	{ErrCodeInsufficientContactCredits, http.StatusPaymentRequired, "The company has no contact credits left"},
+	{ErrCodeUserNotFound, http.StatusNotFound, "The user does not exist"},
+	{ErrCodeCandidateNotFound, http.StatusNotFound, "The candidate does not exist or is not visible to the caller"},
+	{ErrCodeCandidateProfileNotFound, http.StatusNotFound, "The candidate has not created a profile"},
+	{ErrCodeCompanyProfileNotFound, http.StatusNotFound, "The company profile does not exist; employers create it first"},
+	{ErrCodeProfileRefreshRequired, http.StatusPreconditionRequired, "A returning candidate must refresh their profile first"},
+	{ErrCodeVerificationNotFound, http.StatusNotFound, "The verification does not exist or is outside the admin's provinces"},
+	{ErrCodeProfileIncomplete, http.StatusForbidden, "The candidate has not completed their profile"},
+	{ErrCodeVerificationRequired, http.StatusForbidden, "The candidate's profile is not verified yet"},
+	{ProfileErrEmergencyContactIncomplete, http.StatusBadRequest, "Emergency contact name, phone and relationship go together"},
+	{ProfileErrEmergencyContactPhone, http.StatusBadRequest, "The emergency contact phone is invalid"},
+	{ProfileErrEmergencyContactRelationship, http.StatusBadRequest, "The emergency contact relationship is not a known value"},
+	{ProfileErrEmergencyContactSelf, http.StatusBadRequest, "The emergency contact is the candidate"},
+	{ProfileErrGuardianConsentRequired, http.StatusBadRequest, "A minor needs guardian consent before submitting or being approved"},
+	{ErrCodeCompanyRoleForbidden, http.StatusForbidden, "The caller's company role does not allow this"},
+	{ErrCodeAdminScopeForbidden, http.StatusForbidden, "Regional admins cannot do this"},
+	{ErrCodeCSRFTokenInvalid, http.StatusForbidden, "The CSRF token is missing or invalid"},
+	{ErrCodeFeatureDisabled, http.StatusServiceUnavailable, "The feature is switched off"},
+	{ErrCodeIdempotencyKeyReused, http.StatusConflict, "The idempotency key was used for a different request"},
+}
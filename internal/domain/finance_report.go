@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Invoice status constants
+const (
+	InvoiceStatusIssued = "issued"
+	InvoiceStatusPaid   = "paid"
+	InvoiceStatusVoid   = "void"
+)
+
+// FinanceMonthFormat is the layout for month parameters (YYYY-MM)
+const FinanceMonthFormat = "2006-01"
+
+// MaxFinanceReportMonths caps the range of a summary request
+const MaxFinanceReportMonths = 24
+
+// ============================================================================
+// Admin Financial Reporting
+// ============================================================================
+
+// FinanceMonthSummary aggregates billing and credit activity for one calendar month
+type FinanceMonthSummary struct {
+	Month            string `json:"month"` // YYYY-MM
+	RevenueIDR       int64  `json:"revenue_idr"`
+	PaidInvoices     int64  `json:"paid_invoices"`
+	CreditsGranted   int64  `json:"credits_granted"`   // plan grants
+	CreditsPurchased int64  `json:"credits_purchased"` // purchases
+	CreditsConsumed  int64  `json:"credits_consumed"`  // contact reveals
+	ActiveCompanies  int64  `json:"active_companies"`  // subscribed at month end
+	ChurnedCompanies int64  `json:"churned_companies"` // subscriptions cancelled during the month
+}
+
+// FinanceSummary is the response for GET /admin/finance/summary
+type FinanceSummary struct {
+	From                string                `json:"from"`
+	To                  string                `json:"to"`
+	Totals              FinanceMonthSummary   `json:"totals"` // ActiveCompanies is taken from the last month
+	Months              []FinanceMonthSummary `json:"months"`
+	OutstandingInvoices int64                 `json:"outstanding_invoices"` // as of GeneratedAt
+	OutstandingIDR      int64                 `json:"outstanding_idr"`
+	OverdueInvoices     int64                 `json:"overdue_invoices"`
+	OverdueIDR          int64                 `json:"overdue_idr"`
+	GeneratedAt         time.Time             `json:"generated_at"`
+}
+
+// PlanRevenue is paid revenue for one plan in a month. PlanCode is nil for one-off charges.
+type PlanRevenue struct {
+	PlanCode     *string `json:"plan_code,omitempty"`
+	PlanName     string  `json:"plan_name"`
+	RevenueIDR   int64   `json:"revenue_idr"`
+	PaidInvoices int64   `json:"paid_invoices"`
+}
+
+// CompanyCreditUsage is credit movement for one company in a month
+type CompanyCreditUsage struct {
+	CompanyID       int64  `json:"company_id"`
+	CompanyName     string `json:"company_name"`
+	CreditsAdded    int64  `json:"credits_added"`
+	CreditsConsumed int64  `json:"credits_consumed"`
+}
+
+// ChurnedCompany is a company whose subscription was cancelled in the month
+type ChurnedCompany struct {
+	CompanyID   int64     `json:"company_id"`
+	CompanyName string    `json:"company_name"`
+	PlanCode    string    `json:"plan_code"`
+	StartedAt   time.Time `json:"started_at"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+// OutstandingInvoice is an issued, unpaid invoice
+type OutstandingInvoice struct {
+	ID          int64     `json:"id"`
+	CompanyID   int64     `json:"company_id"`
+	CompanyName string    `json:"company_name"`
+	PlanCode    *string   `json:"plan_code,omitempty"`
+	Description string    `json:"description"`
+	AmountIDR   int64     `json:"amount_idr"`
+	IssuedAt    time.Time `json:"issued_at"`
+	DueAt       time.Time `json:"due_at"`
+	Overdue     bool      `json:"overdue"`
+}
+
+// FinanceMonthDetail is the response for GET /admin/finance/months/:month
+type FinanceMonthDetail struct {
+	Summary             FinanceMonthSummary  `json:"summary"`
+	RevenueByPlan       []PlanRevenue        `json:"revenue_by_plan"`
+	CreditUsage         []CompanyCreditUsage `json:"credit_usage"`
+	ChurnedCompanies    []ChurnedCompany     `json:"churned_companies"`
+	OutstandingInvoices []OutstandingInvoice `json:"outstanding_invoices"` // issued during the month and still unpaid
+}
+
+type FinanceReportRepository interface {
+	HasFinanceAccess(ctx context.Context, userID string) (bool, error)
+
+	// from and to are the first instants of the first and last month (inclusive)
+	GetMonthlySummaries(ctx context.Context, from, to time.Time) ([]FinanceMonthSummary, error)
+	GetOutstandingTotals(ctx context.Context, now time.Time) (count, amount, overdueCount, overdueAmount int64, err error)
+
+	// Month detail; [start, end) covers one calendar month
+	GetRevenueByPlan(ctx context.Context, start, end time.Time) ([]PlanRevenue, error)
+	GetCreditUsage(ctx context.Context, start, end time.Time) ([]CompanyCreditUsage, error)
+	GetChurnedCompanies(ctx context.Context, start, end time.Time) ([]ChurnedCompany, error)
+	ListOutstandingInvoices(ctx context.Context, start, end, now time.Time) ([]OutstandingInvoice, error)
+}
+
+type FinanceReportUsecase interface {
+	GetSummary(ctx context.Context, from, to string) (*FinanceSummary, error)
+	GetMonthDetail(ctx context.Context, month string) (*FinanceMonthDetail, error)
+	ExportSummaryCSV(ctx context.Context, from, to string) ([]byte, string, error)
+	ExportMonthCSV(ctx context.Context, month string) ([]byte, string, error)
+}
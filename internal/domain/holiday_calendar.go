@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Holiday calendar countries (ISO 3166-1 alpha-2)
+const (
+	CalendarIndonesia = "ID" // candidates, LPKs, and platform admins
+	CalendarJapan     = "JP" // hiring companies
+)
+
+// HolidayDateFormat is the wire format for calendar dates
+const HolidayDateFormat = "2006-01-02"
+
+// ============================================================================
+// Public Holidays
+// ============================================================================
+
+// PublicHoliday is one non-working day in a country calendar
+type PublicHoliday struct {
+	ID          int64     `json:"id"`
+	CountryCode string    `json:"country_code"`
+	Date        string    `json:"date"` // YYYY-MM-DD
+	Name        string    `json:"name"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// HolidayRequest creates or replaces a calendar entry
+type HolidayRequest struct {
+	CountryCode string `json:"country_code" binding:"required,oneof=ID JP"`
+	Date        string `json:"date" binding:"required,datetime=2006-01-02"`
+	Name        string `json:"name" binding:"required,max=120"`
+	IsActive    *bool  `json:"is_active"` // defaults to true
+}
+
+// HolidayFilter narrows the holiday listing
+type HolidayFilter struct {
+	CountryCode string
+	Year        int
+}
+
+type HolidayRepository interface {
+	List(ctx context.Context, filter HolidayFilter) ([]PublicHoliday, error)
+	ListActive(ctx context.Context) ([]PublicHoliday, error)
+	Create(ctx context.Context, h *PublicHoliday, createdBy string) error
+	Update(ctx context.Context, h *PublicHoliday) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// BusinessCalendar answers working-day questions for other usecases.
+// Weekends and active public holidays of every listed country are non-working;
+// with no countries, only weekends are skipped.
+type BusinessCalendar interface {
+	IsBusinessDay(ctx context.Context, day time.Time, countries ...string) (bool, error)
+	// AddBusinessDays moves forward n working days, keeping the time of day
+	AddBusinessDays(ctx context.Context, from time.Time, n int, countries ...string) (time.Time, error)
+	// NextBusinessDay returns t itself if it is a working day, else the next one
+	NextBusinessDay(ctx context.Context, t time.Time, countries ...string) (time.Time, error)
+}
+
+type HolidayCalendarUsecase interface {
+	BusinessCalendar
+
+	ListHolidays(ctx context.Context, filter HolidayFilter) ([]PublicHoliday, error)
+	CreateHoliday(ctx context.Context, req HolidayRequest) (*PublicHoliday, error)
+	UpdateHoliday(ctx context.Context, id int64, req HolidayRequest) (*PublicHoliday, error)
+	DeleteHoliday(ctx context.Context, id int64) error
+
+	// SuggestInterviewDays lists the next working days shared by all countries
+	SuggestInterviewDays(ctx context.Context, from string, count int, countries []string) ([]string, error)
+}
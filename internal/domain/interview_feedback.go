@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Interview feedback status
+const (
+	InterviewFeedbackStatusPendingReview = "PENDING_REVIEW" // has a custom message, or review is required for all feedback
+	InterviewFeedbackStatusApproved      = "APPROVED"       // cleared for delivery, not delivered yet
+	InterviewFeedbackStatusSent          = "SENT"
+	InterviewFeedbackStatusSuppressed    = "SUPPRESSED" // the candidate opted out of feedback
+	InterviewFeedbackStatusRejected      = "REJECTED"   // an admin declined to send it
+)
+
+// Approved feedback wordings. Employers can only pick from these; anything else
+// goes in the custom message, which an admin reviews first.
+const (
+	InterviewFeedbackJapaneseLevel   = "JAPANESE_LEVEL"
+	InterviewFeedbackExperience      = "EXPERIENCE"
+	InterviewFeedbackTechnicalSkills = "TECHNICAL_SKILLS"
+	InterviewFeedbackCommunication   = "COMMUNICATION"
+	InterviewFeedbackAvailability    = "AVAILABILITY"
+	InterviewFeedbackDocuments       = "DOCUMENTS"
+	InterviewFeedbackStrongPool      = "STRONG_POOL"
+	InterviewFeedbackReapply         = "REAPPLY"
+)
+
+// InterviewFeedbackTemplateKeys lists the approved wordings in display order
+var InterviewFeedbackTemplateKeys = []string{
+	InterviewFeedbackJapaneseLevel,
+	InterviewFeedbackExperience,
+	InterviewFeedbackTechnicalSkills,
+	InterviewFeedbackCommunication,
+	InterviewFeedbackAvailability,
+	InterviewFeedbackDocuments,
+	InterviewFeedbackStrongPool,
+	InterviewFeedbackReapply,
+}
+
+// ErrInterviewFeedbackExists is returned when an application already has feedback
+var ErrInterviewFeedbackExists = errors.New("interview feedback already exists")
+
+// InterviewFeedbackTemplate is one approved wording in the request's language
+type InterviewFeedbackTemplate struct {
+	Key  string `json:"key"`
+	Text string `json:"text"`
+}
+
+// InterviewFeedback is feedback on one rejected application
+type InterviewFeedback struct {
+	ID              int64      `json:"id"`
+	ApplicationID   int64      `json:"application_id"`
+	CompanyID       int64      `json:"company_id"`
+	CandidateUserID string     `json:"candidate_user_id"`
+	AuthorUserID    *string    `json:"author_user_id,omitempty"`
+	TemplateKeys    []string   `json:"template_keys"`
+	CustomMessage   *string    `json:"custom_message,omitempty"`
+	Status          string     `json:"status"`
+	ReviewNote      *string    `json:"review_note,omitempty"` // admin's reason when rejected
+	ReviewedBy      *string    `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	SentAt          *time.Time `json:"sent_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	// Joined data
+	CompanyName string `json:"company_name"`
+	JobTitle    string `json:"job_title"`
+
+	// Messages are the template wordings in the request's language
+	Messages []string `json:"messages,omitempty"`
+}
+
+// InterviewFeedbackApplication is what is needed to check who may give feedback on an application
+type InterviewFeedbackApplication struct {
+	ApplicationID   int64
+	Status          string
+	CandidateUserID string
+	JobTitle        string
+	CompanyID       *int64
+	CompanyUserID   *string // the employer account owning the job's company
+	CompanyName     string
+}
+
+// SubmitInterviewFeedbackRequest is an employer's feedback on a rejected application
+type SubmitInterviewFeedbackRequest struct {
+	TemplateKeys  []string `json:"template_keys" binding:"required,min=1,max=5,dive,required"`
+	CustomMessage string   `json:"custom_message" binding:"omitempty,max=1000"`
+}
+
+// ReviewInterviewFeedbackRequest approves or rejects feedback held for review
+type ReviewInterviewFeedbackRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve reject"`
+	Note   string `json:"note" binding:"omitempty,max=500"`
+}
+
+// InterviewFeedbackPreference is the candidate's feedback subscription state
+type InterviewFeedbackPreference struct {
+	OptedOut   bool       `json:"opted_out"`
+	OptedOutAt *time.Time `json:"opted_out_at,omitempty"`
+}
+
+// UpdateInterviewFeedbackPreferenceRequest opts a candidate out of (or back into) feedback
+type UpdateInterviewFeedbackPreferenceRequest struct {
+	OptOut *bool `json:"opt_out" binding:"required"`
+}
+
+type InterviewFeedbackRepository interface {
+	GetApplication(ctx context.Context, applicationID int64) (*InterviewFeedbackApplication, error)
+
+	// Create stores new feedback; it returns ErrInterviewFeedbackExists when the
+	// application already has feedback
+	Create(ctx context.Context, f *InterviewFeedback) error
+	GetByID(ctx context.Context, id int64) (*InterviewFeedback, error)
+	GetByApplication(ctx context.Context, applicationID int64) (*InterviewFeedback, error)
+	ListByStatus(ctx context.Context, status string, limit int) ([]InterviewFeedback, error)
+	ListSentToCandidate(ctx context.Context, userID string) ([]InterviewFeedback, error)
+
+	// Review records an admin decision on feedback that has not been delivered yet
+	Review(ctx context.Context, id int64, status, reviewerID string, note *string) error
+	// MarkDelivered sets SENT or SUPPRESSED on approved feedback
+	MarkDelivered(ctx context.Context, id int64, status string) error
+
+	GetOptOut(ctx context.Context, userID string) (*time.Time, error)
+	SetOptOut(ctx context.Context, userID string, at *time.Time) error
+}
+
+type InterviewFeedbackUsecase interface {
+	// Employer
+	ListTemplates(ctx context.Context, locale string) []InterviewFeedbackTemplate
+	SubmitFeedback(ctx context.Context, userID string, applicationID int64, req SubmitInterviewFeedbackRequest, locale string) (*InterviewFeedback, error)
+	GetFeedback(ctx context.Context, userID string, applicationID int64, locale string) (*InterviewFeedback, error)
+
+	// Candidate
+	ListMyFeedback(ctx context.Context, userID, locale string) ([]InterviewFeedback, error)
+	GetPreference(ctx context.Context, userID string) (*InterviewFeedbackPreference, error)
+	UpdatePreference(ctx context.Context, userID string, optOut bool) (*InterviewFeedbackPreference, error)
+
+	// Admin
+	ListForReview(ctx context.Context, status, locale string) ([]InterviewFeedback, error)
+	ReviewFeedback(ctx context.Context, adminID string, id int64, req ReviewInterviewFeedbackRequest, locale string) (*InterviewFeedback, error)
+}
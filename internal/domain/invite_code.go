@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxInviteCodesPerBatch caps how many codes one generate request creates
+const MaxInviteCodesPerBatch = 500
+
+// Invite code status, derived from usage, expiry and revocation
+const (
+	InviteCodeActive    = "ACTIVE"
+	InviteCodeUsedUp    = "USED_UP"
+	InviteCodeExpired   = "EXPIRED"
+	InviteCodeRevoked   = "REVOKED"
+	InviteCodeStatusAll = "ALL"
+)
+
+// InviteCode lets a person register while registration is invite-only for their role
+type InviteCode struct {
+	ID        int64      `json:"id"`
+	Code      string     `json:"code"`
+	Role      *string    `json:"role,omitempty"` // candidate or employer; nil = either
+	MaxUses   int        `json:"max_uses"`       // 1 = single use
+	UseCount  int        `json:"use_count"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Note      *string    `json:"note,omitempty"`
+	Status    string     `json:"status"` // ACTIVE, USED_UP, EXPIRED, REVOKED
+	CreatedBy *string    `json:"created_by,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	Redemptions []InviteCodeRedemption `json:"redemptions,omitempty"` // only on the detail view
+}
+
+// InviteCodeRedemption records one registration with a code
+type InviteCodeRedemption struct {
+	ID        int64     `json:"id"`
+	CodeID    int64     `json:"code_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	UserID    *string   `json:"user_id,omitempty"` // set once the signup is accepted
+	IPAddress *string   `json:"ip_address,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GenerateInviteCodesRequest creates a batch of codes with the same limits
+type GenerateInviteCodesRequest struct {
+	Count         int     `json:"count" binding:"required,min=1,max=500"`
+	Role          *string `json:"role" binding:"omitempty,oneof=candidate employer"`
+	MaxUses       int     `json:"max_uses" binding:"omitempty,min=1,max=100000"` // default 1 (single use)
+	ExpiresInDays int     `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+	Note          *string `json:"note" binding:"omitempty,max=500"`
+}
+
+// InviteCodeFilter selects codes for the admin list
+type InviteCodeFilter struct {
+	Status   string // ACTIVE by default; ALL for every code
+	Role     string
+	Page     int
+	PageSize int
+}
+
+// RegistrationSettings tells the sign-up form which roles need an invite code
+type RegistrationSettings struct {
+	InviteOnlyRoles []string `json:"invite_only_roles"`
+}
+
+type InviteCodeRepository interface {
+	CreateMany(ctx context.Context, codes []*InviteCode) error
+	List(ctx context.Context, filter InviteCodeFilter) ([]InviteCode, int64, error)
+	GetByID(ctx context.Context, id int64) (*InviteCode, error)
+	ListRedemptions(ctx context.Context, codeID int64) ([]InviteCodeRedemption, error)
+	// Revoke returns ErrNotFound when the code does not exist or is already revoked
+	Revoke(ctx context.Context, id int64) error
+
+	// Redeem takes one use of a usable code for the redemption's role and records
+	// the redemption in one transaction; ErrNotFound when the code cannot be used
+	Redeem(ctx context.Context, code string, r *InviteCodeRedemption) error
+	// Release gives back the use of a redemption whose signup failed
+	Release(ctx context.Context, redemptionID int64) error
+	// Complete links a redemption to the registered user
+	Complete(ctx context.Context, redemptionID int64, userID string) error
+}
+
+type InviteCodeUsecase interface {
+	// Settings returns the roles whose registration needs an invite code
+	Settings() RegistrationSettings
+	// Reserve checks the invite gate for a registration and takes one use of the
+	// code. It returns the redemption ID, or 0 when the role needs no code.
+	Reserve(ctx context.Context, code, role, email, ipAddress string) (int64, error)
+	Release(ctx context.Context, redemptionID int64)
+	Complete(ctx context.Context, redemptionID int64, userID string)
+
+	Generate(ctx context.Context, adminID string, req GenerateInviteCodesRequest) ([]InviteCode, error)
+	List(ctx context.Context, filter InviteCodeFilter) (*PaginatedResult[InviteCode], error)
+	Get(ctx context.Context, id int64) (*InviteCode, error)
+	Revoke(ctx context.Context, id int64) (*InviteCode, error)
+}
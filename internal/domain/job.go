@@ -10,41 +10,303 @@ import (
 var ErrNotFound = errors.New("resource not found")
 
 type Job struct {
-	ID              int64     `json:"id"`
-	CompanyID       int64     `json:"company_id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	SalaryMin       float64   `json:"salary_min"`
-	SalaryMax       float64   `json:"salary_max"`
-	Location        string    `json:"location"`
-	CompanyStatus   string    `json:"company_status"`
-	EmploymentType  *string   `json:"employment_type"`
-	JobType         *string   `json:"job_type"`
-	ExperienceLevel *string   `json:"experience_level"`
-	Qualifications  *string   `json:"qualifications"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              int64   `json:"id"`
+	CompanyID       int64   `json:"company_id"`
+	Title           string  `json:"title"`
+	Description     string  `json:"description"`
+	SalaryMin       float64 `json:"salary_min"`
+	SalaryMax       float64 `json:"salary_max"`
+	Location        string  `json:"location"`
+	LocationID      *int64  `json:"location_id"` // office the job is based at, see CompanyLocation
+	CompanyStatus   string  `json:"company_status"`
+	EmploymentType  *string `json:"employment_type"`
+	JobType         *string `json:"job_type"`
+	ExperienceLevel *string `json:"experience_level"`
+	Qualifications  *string `json:"qualifications"`
+	// Minimum JLPT level (N1 hardest .. N5); nil means no Japanese requirement
+	JapaneseLevelRequired *string `json:"japanese_level_required"`
+	// Publication schedule; see JobStatus* for how it drives CompanyStatus
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	// Confidential postings hide the company publicly; see JobWithCompany.HideCompany
+	IsConfidential bool `json:"is_confidential"`
+	// Applications stop at ApplyDeadline or after MaxApplicants (knocked-out
+	// applications do not count); AutoClose also closes the job at that point
+	ApplyDeadline *time.Time `json:"apply_deadline"`
+	MaxApplicants *int       `json:"max_applicants"`
+	AutoClose     bool       `json:"auto_close"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
+// Job publication states (CompanyStatus)
+const (
+	JobStatusActive    = "active"    // published, until UnpublishAt if set
+	JobStatusScheduled = "scheduled" // publishes at PublishAt
+	JobStatusDraft     = "draft"     // scheduled publication was cancelled
+	JobStatusExpired   = "expired"   // unpublished at UnpublishAt
+	JobStatusClosed    = "closed"    // AutoClose reached ApplyDeadline or MaxApplicants; reopened by the employer
+)
+
+// EffectiveStatus is the job's status at now. It follows the schedule and the
+// apply deadline even before the scheduler worker has moved CompanyStatus; the
+// applicant cap is only known to the database.
+func (j *Job) EffectiveStatus(now time.Time) string {
+	if j.CompanyStatus != JobStatusActive && j.CompanyStatus != JobStatusScheduled {
+		return j.CompanyStatus
+	}
+	if j.UnpublishAt != nil && !j.UnpublishAt.After(now) {
+		return JobStatusExpired
+	}
+	if j.AutoClose && j.DeadlinePassed(now) {
+		return JobStatusClosed
+	}
+	if j.CompanyStatus == JobStatusScheduled && j.PublishAt != nil && !j.PublishAt.After(now) {
+		return JobStatusActive
+	}
+	return j.CompanyStatus
+}
+
+// DeadlinePassed reports whether the job stopped taking applications at its ApplyDeadline
+func (j *Job) DeadlinePassed(now time.Time) bool {
+	return j.ApplyDeadline != nil && !j.ApplyDeadline.After(now)
+}
+
+// IsPublished reports whether the job is publicly listed at now
+func (j *Job) IsPublished(now time.Time) bool {
+	return j.EffectiveStatus(now) == JobStatusActive
+}
+
+// JobScheduleRequest sets when a job publishes and unpublishes. A missing or past
+// publish_at publishes now (an already published job stays published); a missing
+// unpublish_at keeps the job up until it is unpublished by hand.
+type JobScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// JobScheduleRunResult summarizes one scheduler worker run
+type JobScheduleRunResult struct {
+	Published   int64 `json:"published"`
+	Unpublished int64 `json:"unpublished"`
+	Closed      int64 `json:"closed"` // AutoClose jobs past their apply deadline or applicant cap
+}
+
+// ReopenJobRequest reopens a closed job. A missing field keeps the current limit;
+// the job must not be due to close again right away.
+type ReopenJobRequest struct {
+	ApplyDeadline *time.Time `json:"apply_deadline"`
+	MaxApplicants *int       `json:"max_applicants" binding:"omitempty,min=1"`
+}
+
+// ErrJobApplicantCapReached is returned when a job already has MaxApplicants applications
+var ErrJobApplicantCapReached = errors.New("job applicant cap reached")
+
 // JobWithCompany extends Job with company profile information
 type JobWithCompany struct {
 	Job
-	CompanyName    string  `json:"company_name"`
-	CompanyLogoURL *string `json:"company_logo_url"`
-	CompanyWebsite *string `json:"company_website"`
-	Industry       *string `json:"industry"`
+	CompanyName              string  `json:"company_name"`
+	CompanyLogoURL           *string `json:"company_logo_url"`
+	CompanyWebsite           *string `json:"company_website"`
+	Industry                 *string `json:"industry"`
+	CompanyVerificationLevel string  `json:"company_verification_level"` // badge: BASIC, STANDARD or PREMIUM
+	CompanySizeBand          *string `json:"company_size_band"`          // see CompanySizeBandFor
+	// Set on public responses only
+	Popularity *JobPopularity `json:"popularity,omitempty"`
+}
+
+// ConfidentialCompanyName is shown instead of the name of a confidential job's company
+const ConfidentialCompanyName = "Confidential Company"
+
+// HideCompany removes what identifies the company of a confidential job.
+// Industry, size band and verification badge stay.
+func (j *JobWithCompany) HideCompany() {
+	j.CompanyID = 0
+	j.LocationID = nil
+	j.CompanyName = ConfidentialCompanyName
+	j.CompanyLogoURL = nil
+	j.CompanyWebsite = nil
+}
+
+// JobCard is the compact job shown in lists on the public job detail page
+type JobCard struct {
+	ID                       int64     `json:"id"`
+	CompanyID                int64     `json:"company_id"`
+	Title                    string    `json:"title"`
+	Location                 string    `json:"location"`
+	SalaryMin                float64   `json:"salary_min"`
+	SalaryMax                float64   `json:"salary_max"`
+	EmploymentType           *string   `json:"employment_type"`
+	JobType                  *string   `json:"job_type"`
+	ExperienceLevel          *string   `json:"experience_level"`
+	CompanyName              string    `json:"company_name"`
+	CompanyLogoURL           *string   `json:"company_logo_url"`
+	CompanyVerificationLevel string    `json:"company_verification_level"` // badge: BASIC, STANDARD or PREMIUM
+	IsConfidential           bool      `json:"is_confidential"`
+	CreatedAt                time.Time `json:"created_at"`
+}
+
+// HideCompany removes what identifies the company of a confidential job
+func (c *JobCard) HideCompany() {
+	c.CompanyID = 0
+	c.CompanyName = ConfidentialCompanyName
+	c.CompanyLogoURL = nil
+}
+
+// Application count bands; the exact count is not public
+const (
+	ApplicationCountBandUnder10 = "UNDER_10"
+	ApplicationCountBand10To49  = "10_TO_49"
+	ApplicationCountBand50To99  = "50_TO_99"
+	ApplicationCountBand100Plus = "100_PLUS"
+)
+
+// ApplicationCountBandFor buckets an application count for public display
+func ApplicationCountBandFor(count int) string {
+	switch {
+	case count < 10:
+		return ApplicationCountBandUnder10
+	case count < 50:
+		return ApplicationCountBand10To49
+	case count < 100:
+		return ApplicationCountBand50To99
+	default:
+		return ApplicationCountBand100Plus
+	}
+}
+
+// Job badges on public responses
+const (
+	JobBadgeNew         = "NEW"          // published in the last JobNewDays days
+	JobBadgeClosingSoon = "CLOSING_SOON" // unpublishes within JobClosingSoonDays days
+)
+
+const (
+	JobNewDays         = 3
+	JobClosingSoonDays = 7
+)
+
+// JobPopularity holds the popularity signals derived from applications and
+// job views. Counts may lag by the public cache TTL.
+type JobPopularity struct {
+	ApplicationCountBand string   `json:"application_count_band"`
+	ViewsLast7Days       int      `json:"views_last_7_days"`
+	Badges               []string `json:"badges"`
+}
+
+// JobPopularityStats are the raw counts behind JobPopularity
+type JobPopularityStats struct {
+	Applications   int
+	ViewsLast7Days int
+}
+
+// Badges returns the job's public badges at now
+func (j *Job) Badges(now time.Time) []string {
+	badges := []string{}
+	publishedAt := j.CreatedAt
+	if j.PublishAt != nil {
+		publishedAt = *j.PublishAt
+	}
+	if now.Sub(publishedAt) < JobNewDays*24*time.Hour {
+		badges = append(badges, JobBadgeNew)
+	}
+	if j.UnpublishAt != nil && j.UnpublishAt.After(now) && j.UnpublishAt.Sub(now) <= JobClosingSoonDays*24*time.Hour {
+		badges = append(badges, JobBadgeClosingSoon)
+	}
+	return badges
+}
+
+// PublicJobDetail is the read model behind the public job detail page
+type PublicJobDetail struct {
+	Job                  JobWithCompany `json:"job"`
+	SimilarJobs          []JobCard      `json:"similar_jobs"`
+	CompanyOtherJobs     []JobCard      `json:"company_other_jobs"`
+	ApplicationCountBand string         `json:"application_count_band"`
+	// Set when the job is based at one of its company's offices
+	LocationMap *JobLocationMap `json:"location_map,omitempty"`
+}
+
+// Job search sort orders
+const (
+	JobSearchSortRelevance = "relevance" // default when there is a keyword query
+	JobSearchSortNewest    = "newest"    // default otherwise
+	JobSearchSortSalary    = "salary"    // highest maximum salary first
+	JobSearchSortPopular   = "popular"   // most views and applications in the last 7 days first
+)
+
+// JobPopularityApplicationWeight is how many views one recent application is
+// worth when sorting by popularity
+const JobPopularityApplicationWeight = 5
+
+// JLPTLevels lists JLPT levels from hardest to easiest
+var JLPTLevels = []string{"N1", "N2", "N3", "N4", "N5"}
+
+// JobSearchParams is a public search over active jobs. Empty fields match
+// everything; values within a list are OR-ed and fields are AND-ed.
+type JobSearchParams struct {
+	Query           string   // full text over title, description and qualifications; supports "phrases", OR and -exclusions
+	Locations       []string // substring match on the job location
+	Cities          []string // lower-cased; city of the company office the job is based at
+	Near            *GeoPoint
+	RadiusKm        float64  // with Near: office within this distance; confidential jobs never match
+	SalaryMin       *float64 // the job's maximum salary must reach this
+	SalaryMax       *float64 // the job's minimum salary must not exceed this
+	EmploymentTypes []string
+	JapaneseLevel   string // the candidate's JLPT level: jobs requiring it, an easier level or none
+	Sort            string // relevance, newest, salary
+	Page            int
+	PageSize        int
+}
+
+// JobSearchResult is one search hit; Rank is only set for keyword searches
+type JobSearchResult struct {
+	JobWithCompany
+	Rank *float64 `json:"rank,omitempty"`
 }
 
 type JobRepository interface {
 	Create(ctx context.Context, job *Job) error
+	// CreateMany inserts the jobs in one transaction; none is created if one fails
+	CreateMany(ctx context.Context, jobs []*Job) error
 	GetByID(ctx context.Context, id int64) (*Job, error)
 	GetByIDWithCompany(ctx context.Context, id int64) (*JobWithCompany, error)
 	Fetch(ctx context.Context, limit, offset int) ([]Job, int64, error)
 	FetchWithCompany(ctx context.Context, limit, offset int) ([]JobWithCompany, int64, error)
-	FetchPublicActiveJobs(ctx context.Context, limit, offset int) ([]JobWithCompany, int64, error)
+	// FetchPublicActiveJobs sorts by JobSearchSortNewest or JobSearchSortPopular
+	FetchPublicActiveJobs(ctx context.Context, sort string, limit, offset int) ([]JobWithCompany, int64, error)
 	FetchByCompanyID(ctx context.Context, companyID int64, limit, offset int) ([]Job, int64, error)
 	Update(ctx context.Context, job *Job) error
 	Delete(ctx context.Context, id int64) error
+	// HasAdvancedApplication reports whether the candidate's application to the job
+	// was ever moved past applied (other than straight to rejected)
+	HasAdvancedApplication(ctx context.Context, jobID int64, userID string) (bool, error)
+
+	// Public job detail read model
+	FetchSimilarActiveJobs(ctx context.Context, job *JobWithCompany, limit int) ([]JobCard, error)
+	FetchActiveJobsByCompany(ctx context.Context, companyID, excludeJobID int64, limit int) ([]JobCard, error)
+
+	// Popularity signals
+	RecordView(ctx context.Context, jobID int64) error
+	// FetchPopularityStats returns stats for the given jobs; jobs without any are left out
+	FetchPopularityStats(ctx context.Context, jobIDs []int64) (map[int64]JobPopularityStats, error)
+
+	// SearchJobs runs a full-text and filtered search over active jobs
+	SearchJobs(ctx context.Context, params JobSearchParams, limit, offset int) ([]JobSearchResult, int64, error)
+
+	// Publication schedule
+	UpdateSchedule(ctx context.Context, job *Job) error
+	// PublishDueJobs activates due scheduled jobs and returns them with ID, CompanyID,
+	// Title and PublishAt filled
+	PublishDueJobs(ctx context.Context, now time.Time) ([]Job, error)
+	UnpublishDueJobs(ctx context.Context, now time.Time) (int64, error)
+
+	// Application limits
+	// CloseDueJobs closes AutoClose jobs past their apply deadline or applicant cap
+	CloseDueJobs(ctx context.Context, now time.Time) (int64, error)
+	// CountApplicants counts the applications that count towards MaxApplicants
+	CountApplicants(ctx context.Context, jobID int64) (int, error)
+	// Reopen saves the job's status and application limits
+	Reopen(ctx context.Context, job *Job) error
 }
 
 type JobUsecase interface {
@@ -53,8 +315,22 @@ type JobUsecase interface {
 	GetJobDetailsWithCompany(ctx context.Context, id int64) (*JobWithCompany, error)
 	ListJobs(ctx context.Context, page, pageSize int) ([]Job, int64, error)
 	ListJobsWithCompany(ctx context.Context, page, pageSize int) ([]JobWithCompany, int64, error)
-	ListPublicActiveJobs(ctx context.Context, page, pageSize int) ([]JobWithCompany, int64, error)
+	ListPublicActiveJobs(ctx context.Context, sort string, page, pageSize int) ([]JobWithCompany, int64, error)
+	GetPublicJob(ctx context.Context, id int64) (*JobWithCompany, error)
+	GetPublicJobDetail(ctx context.Context, id int64) (*PublicJobDetail, error)
+	SearchJobs(ctx context.Context, params JobSearchParams) (*PaginatedResult[JobSearchResult], error)
 	ListJobsByEmployer(ctx context.Context, userID string, page, pageSize int) ([]Job, int64, error)
 	UpdateJob(ctx context.Context, job *Job) error
 	DeleteJob(ctx context.Context, id int64) error
+
+	// Publication schedule (employer's own jobs) and the scheduler worker run
+	ScheduleJob(ctx context.Context, userID string, jobID int64, req JobScheduleRequest) (*Job, error)
+	CancelJobSchedule(ctx context.Context, userID string, jobID int64) (*Job, error)
+	RunScheduledTransitions(ctx context.Context) (*JobScheduleRunResult, error)
+
+	// ImportJobs creates the employer's jobs from a CSV or XLSX file
+	ImportJobs(ctx context.Context, userID, filename string, data []byte) (*JobImportResult, error)
+
+	// ReopenJob reopens one of the employer's closed jobs
+	ReopenJob(ctx context.Context, userID string, jobID int64, req ReopenJobRequest) (*Job, error)
 }
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// MaxJobImportRows caps the job rows of one import file
+const MaxJobImportRows = 200
+
+// JobImportColumns are the columns an import file may have, matched against its
+// header row case-insensitively; title, description, salary_min, salary_max and
+// location are required. Dates are RFC 3339, YYYY-MM-DD (end of that day, UTC) or
+// an Excel date.
+var JobImportColumns = []string{
+	"title",
+	"description",
+	"salary_min",
+	"salary_max",
+	"location",
+	"employment_type",
+	"job_type",
+	"experience_level",
+	"qualifications",
+	"japanese_level_required",
+	"apply_deadline",
+	"max_applicants",
+	"auto_close",
+}
+
+// JobImportRow is one job posting read from an import file. Its validation
+// mirrors the create job request.
+type JobImportRow struct {
+	Title                 string  `validate:"required,max=200"`
+	Description           string  `validate:"required"`
+	SalaryMin             float64 `validate:"gt=0"`
+	SalaryMax             float64 `validate:"gt=0,gtefield=SalaryMin"`
+	Location              string  `validate:"required,max=200"`
+	EmploymentType        string
+	JobType               string
+	ExperienceLevel       string
+	Qualifications        string
+	JapaneseLevelRequired string `validate:"omitempty,oneof=N1 N2 N3 N4 N5"`
+	ApplyDeadline         *time.Time
+	MaxApplicants         *int `validate:"omitempty,min=1"`
+	AutoClose             bool // defaults to true when the cell is empty
+}
+
+// JobImportResult reports an import row by row. Valid rows are created together
+// in one transaction; invalid rows are skipped.
+type JobImportResult struct {
+	Total   int                  `json:"total"`
+	Created int                  `json:"created"`
+	Failed  int                  `json:"failed"`
+	Rows    []JobImportRowResult `json:"rows"`
+}
+
+// JobImportRowResult is the outcome of one row
+type JobImportRowResult struct {
+	Row    int      `json:"row"` // line in the file; the header is row 1
+	Title  string   `json:"title"`
+	JobID  *int64   `json:"job_id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+
+	"go-recruitment-backend/pkg/jobs"
+)
+
+// Background job types handled on the job queue (pkg/jobs)
+const (
+	JobTypeContactEmail           = "contact_email"
+	JobTypeSecurityExportArtifact = "security_export_artifact"
+)
+
+// JobQueueStatusAll lists jobs of every status in the admin list
+const JobQueueStatusAll = "ALL"
+
+// JobEnqueuer schedules background work; *jobs.Queue implements it
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, jobType string, payload any) (int64, error)
+}
+
+type JobQueueUsecase interface {
+	// List returns dead jobs unless the filter asks for another status (admin only)
+	List(ctx context.Context, filter jobs.ListFilter) (*PaginatedResult[jobs.Job], error)
+	Counts(ctx context.Context) ([]jobs.TypeCount, error)
+	// Retry queues a dead job again with a fresh set of attempts
+	Retry(ctx context.Context, id int64) (*jobs.Job, error)
+}
@@ -7,3 +7,7 @@ const (
 	KeyUserEmail CtxKey = "Email"
 	KeyUserRole  CtxKey = "Role"
 )
+
+// KeySessionID holds the auth session (Supabase session_id claim); it stays the
+// same across token refreshes
+const KeySessionID CtxKey = "SessionID"
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Subsystem kill switches; each covers a group of endpoints.
+// Single endpoints are switched with keys of the form "METHOD /v1/route/:param".
+const (
+	KillSwitchUploads      = "uploads"
+	KillSwitchExports      = "exports"
+	KillSwitchRegistration = "registration"
+)
+
+// KillSwitchSubsystems lists the subsystem switches admins can toggle
+var KillSwitchSubsystems = []string{KillSwitchUploads, KillSwitchExports, KillSwitchRegistration}
+
+// Kill switch audit actions
+const (
+	KillSwitchActionDisabled    = "DISABLED"
+	KillSwitchActionEnabled     = "ENABLED"
+	KillSwitchActionAutoEnabled = "AUTO_ENABLED"
+)
+
+// MaxKillSwitchMinutes caps how long a timed maintenance window can run (7 days)
+const MaxKillSwitchMinutes = 7 * 24 * 60
+
+// KillSwitch is the current state of one endpoint or subsystem
+type KillSwitch struct {
+	Key        string     `json:"key"`
+	Disabled   bool       `json:"disabled"`
+	Message    *string    `json:"message,omitempty"`
+	ReenableAt *time.Time `json:"reenable_at,omitempty"` // nil = until re-enabled by an admin
+	UpdatedBy  *string    `json:"updated_by,omitempty"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"` // nil for subsystems never toggled
+}
+
+// ActiveAt reports whether the switch blocks traffic at t; a passed re-enable time
+// counts as enabled even before the worker records the re-enable
+func (s *KillSwitch) ActiveAt(t time.Time) bool {
+	return s.Disabled && (s.ReenableAt == nil || t.Before(*s.ReenableAt))
+}
+
+// KillSwitchAuditEntry records one toggle
+type KillSwitchAuditEntry struct {
+	ID         int64      `json:"id"`
+	SwitchKey  string     `json:"switch_key"`
+	Action     string     `json:"action"` // DISABLED, ENABLED, AUTO_ENABLED
+	Message    *string    `json:"message,omitempty"`
+	Reason     *string    `json:"reason,omitempty"`
+	ReenableAt *time.Time `json:"reenable_at,omitempty"`
+	ActorID    *string    `json:"actor_id,omitempty"` // nil for automatic re-enables
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// DisableKillSwitchRequest turns an endpoint or subsystem off
+type DisableKillSwitchRequest struct {
+	Key             string `json:"key" binding:"required,max=200"`
+	Message         string `json:"message" binding:"max=500"`
+	Reason          string `json:"reason" binding:"required,min=5,max=500"`
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1"` // 0 = until re-enabled
+}
+
+// EnableKillSwitchRequest turns an endpoint or subsystem back on
+type EnableKillSwitchRequest struct {
+	Key    string `json:"key" binding:"required,max=200"`
+	Reason string `json:"reason" binding:"required,min=5,max=500"`
+}
+
+type KillSwitchRepository interface {
+	List(ctx context.Context) ([]KillSwitch, error)
+	// Disable upserts the switch as disabled and writes the audit entry in one transaction
+	Disable(ctx context.Context, key string, message *string, reenableAt *time.Time, actorID, reason string) (*KillSwitch, error)
+	// Enable returns ErrNotFound when the switch is not disabled
+	Enable(ctx context.Context, key, actorID, reason string) (*KillSwitch, error)
+	// ReenableExpired re-enables switches whose timer has passed and audits them; returns their keys
+	ReenableExpired(ctx context.Context) ([]string, error)
+	// ListAudit returns the newest entries, optionally for one key
+	ListAudit(ctx context.Context, key string, limit int) ([]KillSwitchAuditEntry, error)
+}
+
+type KillSwitchUsecase interface {
+	// ActiveSwitch is the middleware hot path; it reads the in-memory snapshot only
+	ActiveSwitch(key string) (*KillSwitch, bool)
+	// Refresh persists expired timers and reloads the snapshot (called by the worker)
+	Refresh(ctx context.Context) error
+
+	// Admin
+	ListSwitches(ctx context.Context) ([]KillSwitch, error)
+	DisableSwitch(ctx context.Context, adminUserID string, req DisableKillSwitchRequest) (*KillSwitch, error)
+	EnableSwitch(ctx context.Context, adminUserID string, req EnableKillSwitchRequest) (*KillSwitch, error)
+	ListAudit(ctx context.Context, key string) ([]KillSwitchAuditEntry, error)
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ============================================================================
+// LPK Partner Integration (placement feed for partner systems)
+// ============================================================================
+
+// LPK API key limits
+const (
+	MaxActiveLPKAPIKeys     = 5
+	LPKAPIKeyHeader         = "X-API-Key"
+	DefaultLPKPlacementPage = 100
+	MaxLPKPlacementPage     = 500
+)
+
+// LPKPlacementSharing is a candidate's consent to share application status
+// changes with their LPK. It covers the LPK chosen when it was given.
+type LPKPlacementSharing struct {
+	LPKID     *int64     `json:"lpk_id,omitempty"` // the candidate's current LPK
+	LPKName   *string    `json:"lpk_name,omitempty"`
+	Enabled   bool       `json:"enabled"`
+	EnabledAt *time.Time `json:"enabled_at,omitempty"`
+}
+
+// UpdateLPKPlacementSharingRequest gives or withdraws the consent
+type UpdateLPKPlacementSharingRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// LPKPlacementEvent is one application status change of a consenting candidate,
+// as exposed to their LPK. Like the LPK portal it identifies the candidate by
+// reference only.
+type LPKPlacementEvent struct {
+	ID            int64     `json:"id"` // cursor: pass as since to read later events
+	LPKID         int64     `json:"-"`
+	CandidateRef  int64     `json:"candidate_ref"` // account_verifications.id
+	ApplicationID int64     `json:"application_id"`
+	JobID         int64     `json:"job_id"`
+	JobTitle      string    `json:"job_title"`
+	CompanyName   *string   `json:"company_name,omitempty"` // omitted for confidential jobs
+	Status        string    `json:"status"`                 // applied, reviewed, accepted, rejected
+	Placed        bool      `json:"placed"`                 // status is accepted
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// LPKPlacementFeed is a page of the pull API, oldest event first
+type LPKPlacementFeed struct {
+	Events     []LPKPlacementEvent `json:"events"`
+	NextCursor int64               `json:"next_cursor"` // since for the next request
+	HasMore    bool                `json:"has_more"`
+}
+
+// LPKAPIKey authenticates an LPK's system against the partner API
+type LPKAPIKey struct {
+	ID         int64      `json:"id"`
+	LPKID      int64      `json:"lpk_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`        // start of the key, to tell keys apart
+	Key        string     `json:"key,omitempty"` // only returned when created
+	CreatedBy  *string    `json:"created_by,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateLPKAPIKeyRequest names a new key
+type CreateLPKAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type LPKIntegrationRepository interface {
+	// Candidate consent (ErrNotFound when the candidate has no profile)
+	GetPlacementSharing(ctx context.Context, userID string) (*LPKPlacementSharing, error)
+	// SetPlacementSharing gives consent for the candidate's current LPK, or withdraws it
+	SetPlacementSharing(ctx context.Context, userID string, enabled bool, at time.Time) error
+
+	// RecordPlacementEvent logs the application's current status for the candidate's
+	// LPK and returns the event, or nil when the candidate does not share with an LPK
+	RecordPlacementEvent(ctx context.Context, applicationID int64, at time.Time) (*LPKPlacementEvent, error)
+	// ListPlacementEvents returns up to limit events after since, leaving out
+	// candidates who withdrew consent or left the LPK
+	ListPlacementEvents(ctx context.Context, lpkID int64, since int64, limit int) ([]LPKPlacementEvent, error)
+
+	// API keys
+	CreateAPIKey(ctx context.Context, key *LPKAPIKey, keyHash string) error
+	ListAPIKeys(ctx context.Context, lpkID int64) ([]LPKAPIKey, error)
+	CountActiveAPIKeys(ctx context.Context, lpkID int64) (int, error)
+	// RevokeAPIKey returns ErrNotFound for an unknown or already revoked key
+	RevokeAPIKey(ctx context.Context, lpkID, id int64, at time.Time) error
+	// FindActiveAPIKey returns ErrNotFound for unknown and revoked keys
+	FindActiveAPIKey(ctx context.Context, keyHash string) (*LPKAPIKey, error)
+	TouchAPIKey(ctx context.Context, id int64, at time.Time) error
+}
+
+// PlacementStatusRecorder is told when an application's status changes, so
+// LPK partner systems of consenting candidates hear about it
+type PlacementStatusRecorder interface {
+	RecordPlacementStatus(ctx context.Context, applicationID int64) error
+}
+
+type LPKIntegrationUsecase interface {
+	PlacementStatusRecorder
+
+	// Candidate
+	GetPlacementSharing(ctx context.Context, userID string) (*LPKPlacementSharing, error)
+	UpdatePlacementSharing(ctx context.Context, userID string, req UpdateLPKPlacementSharingRequest) (*LPKPlacementSharing, error)
+
+	// LPK partner (scoped to the caller's LPK)
+	ListAPIKeys(ctx context.Context) ([]LPKAPIKey, error)
+	CreateAPIKey(ctx context.Context, req CreateLPKAPIKeyRequest) (*LPKAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id int64) error
+	ListWebhooks(ctx context.Context) ([]WebhookEndpoint, error)
+	CreateWebhook(ctx context.Context, req WebhookEndpointRequest) (*WebhookEndpoint, error)
+	UpdateWebhook(ctx context.Context, id int64, req WebhookEndpointRequest) (*WebhookEndpoint, error)
+	RotateWebhookSecret(ctx context.Context, id int64) (*WebhookEndpoint, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) (*PaginatedResult[WebhookDelivery], error)
+
+	// Partner API, authenticated by an API key instead of a session
+	ListPlacements(ctx context.Context, apiKey string, since int64, limit int) (*LPKPlacementFeed, error)
+}
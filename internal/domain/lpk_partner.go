@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLPKPartnerRoleConflict is returned when an admin or employer account is
+// assigned as an LPK partner
+var ErrLPKPartnerRoleConflict = errors.New("admins and employers cannot become LPK partners")
+
+// ============================================================================
+// LPK Partner Portal
+// ============================================================================
+
+// LPKPartner links a partner user account to the LPK it represents
+type LPKPartner struct {
+	UserID     string    `json:"user_id"`
+	Email      string    `json:"email"`
+	LPKID      int64     `json:"lpk_id"`
+	LPKName    string    `json:"lpk_name"`
+	AssignedBy *string   `json:"assigned_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// LPKCandidateProgress is an anonymized view of a candidate's verification progress.
+// Contact details and full names are deliberately never exposed to LPK partners.
+type LPKCandidateProgress struct {
+	CandidateRef       int64      `json:"candidate_ref"` // account_verifications.id
+	Initials           string     `json:"initials"`
+	VerificationStatus string     `json:"verification_status"`
+	JapaneseLevel      *string    `json:"japanese_level,omitempty"`
+	OnboardingDone     bool       `json:"onboarding_completed"`
+	SubmittedAt        *time.Time `json:"submitted_at,omitempty"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+	ApplicationCount   int        `json:"application_count"`
+	Placed             bool       `json:"placed"`
+	EndorsementCount   int        `json:"endorsement_count"`
+}
+
+// LPKEndorsement is a note an LPK submits for one of its candidates
+type LPKEndorsement struct {
+	ID           int64     `json:"id"`
+	LPKID        int64     `json:"lpk_id"`
+	CandidateRef int64     `json:"candidate_ref"`
+	AuthorUserID *string   `json:"author_user_id,omitempty"`
+	Note         string    `json:"note"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LPKPlacementStats aggregates outcomes for candidates who selected an LPK
+type LPKPlacementStats struct {
+	LPKID               int64 `json:"lpk_id"`
+	TotalCandidates     int64 `json:"total_candidates"`
+	PendingCandidates   int64 `json:"pending_candidates"`
+	SubmittedCandidates int64 `json:"submitted_candidates"`
+	VerifiedCandidates  int64 `json:"verified_candidates"`
+	RejectedCandidates  int64 `json:"rejected_candidates"`
+	AppliedCandidates   int64 `json:"applied_candidates"`
+	PlacedCandidates    int64 `json:"placed_candidates"`
+	TotalApplications   int64 `json:"total_applications"`
+	// Placement records of the LPK's candidates; PlacedCandidates counts accepted applications
+	ActivePlacements     int64 `json:"active_placements"`
+	CompletedPlacements  int64 `json:"completed_placements"`
+	EndedEarlyPlacements int64 `json:"ended_early_placements"`
+}
+
+// AssignLPKPartnerRequest is the admin payload for granting partner access
+type AssignLPKPartnerRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	LPKID  int64  `json:"lpk_id" binding:"required,min=1"`
+}
+
+// CreateLPKEndorsementRequest is the payload for submitting an endorsement note
+type CreateLPKEndorsementRequest struct {
+	Note string `json:"note" binding:"required,min=1,max=2000"`
+}
+
+type LPKPartnerRepository interface {
+	// Partner account mapping
+	GetPartnerByUserID(ctx context.Context, userID string) (*LPKPartner, error)
+	ListPartners(ctx context.Context, lpkID *int64) ([]LPKPartner, error)
+	// AssignPartner gives the user the lpk role and maps it to the LPK; admins and
+	// employers are refused with ErrLPKPartnerRoleConflict
+	AssignPartner(ctx context.Context, userID string, lpkID int64, assignedBy string) error
+	// RevokePartner removes the mapping and returns the user to the candidate role
+	RevokePartner(ctx context.Context, userID string) error
+	LPKExists(ctx context.Context, lpkID int64) (bool, error)
+
+	// Scoped candidate data
+	ListCandidates(ctx context.Context, lpkID int64, status string, page, pageSize int) ([]LPKCandidateProgress, int64, error)
+	CandidateBelongsToLPK(ctx context.Context, verificationID, lpkID int64) (bool, error)
+	CreateEndorsement(ctx context.Context, e *LPKEndorsement) error
+	ListEndorsements(ctx context.Context, lpkID, verificationID int64) ([]LPKEndorsement, error)
+	GetPlacementStats(ctx context.Context, lpkID int64) (*LPKPlacementStats, error)
+}
+
+type LPKPartnerUsecase interface {
+	// Admin
+	ListPartners(ctx context.Context, lpkID *int64) ([]LPKPartner, error)
+	AssignPartner(ctx context.Context, req *AssignLPKPartnerRequest) (*LPKPartner, error)
+	RevokePartner(ctx context.Context, userID string) error
+
+	// LPK partner (scoped to caller's LPK)
+	GetMyPartnership(ctx context.Context) (*LPKPartner, error)
+	ListMyCandidates(ctx context.Context, status string, page, pageSize int) (*PaginatedResult[LPKCandidateProgress], error)
+	CreateEndorsement(ctx context.Context, candidateRef int64, req *CreateLPKEndorsementRequest) (*LPKEndorsement, error)
+	ListEndorsements(ctx context.Context, candidateRef int64) ([]LPKEndorsement, error)
+	GetPlacementStats(ctx context.Context) (*LPKPlacementStats, error)
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Operational tasks admins can start from the maintenance API
+const (
+	MaintenanceRefreshStats         = "refresh-stats"          // ANALYZE the search and reporting tables
+	MaintenanceRebuildSearchIndexes = "rebuild-search-indexes" // REINDEX CONCURRENTLY the full-text and trigram indexes
+	MaintenanceRecomputeAggregates  = "recompute-aggregates"   // the nightly candidate aggregate recompute
+)
+
+// MaintenanceTasks lists every task, in the order the task list shows them
+var MaintenanceTasks = []string{MaintenanceRefreshStats, MaintenanceRebuildSearchIndexes, MaintenanceRecomputeAggregates}
+
+// Maintenance run statuses
+const (
+	MaintenanceRunning   = "RUNNING"
+	MaintenanceCompleted = "COMPLETED"
+	MaintenanceFailed    = "FAILED"
+)
+
+// ErrMaintenanceTaskRunning is returned when the task already has a running run
+var ErrMaintenanceTaskRunning = errors.New("maintenance task already running")
+
+// MaintenanceRun is one execution of a maintenance task with its progress
+type MaintenanceRun struct {
+	ID           int64                   `json:"id"`
+	Task         string                  `json:"task"`
+	Status       string                  `json:"status"`
+	StepsTotal   int                     `json:"steps_total"`
+	StepsDone    int                     `json:"steps_done"`
+	CurrentStep  *string                 `json:"current_step,omitempty"`
+	Steps        []MaintenanceStepResult `json:"steps"` // finished steps, in order
+	ErrorMessage *string                 `json:"error_message,omitempty"`
+	StartedBy    *string                 `json:"started_by,omitempty"`
+	StartedAt    time.Time               `json:"started_at"`
+	HeartbeatAt  time.Time               `json:"heartbeat_at"` // a running run that stops beating is marked failed
+	FinishedAt   *time.Time              `json:"finished_at,omitempty"`
+}
+
+// MaintenanceStepResult is one finished step of a run
+type MaintenanceStepResult struct {
+	Step       string          `json:"step"` // table, index or aggregate the step worked on
+	DurationMs int64           `json:"duration_ms"`
+	Error      *string         `json:"error,omitempty"`
+	Detail     json.RawMessage `json:"detail,omitempty" swaggertype:"object"` // task-specific summary
+}
+
+// MaintenanceTaskInfo describes a task and its latest run
+type MaintenanceTaskInfo struct {
+	Task        string          `json:"task"`
+	Description string          `json:"description"`
+	LastRun     *MaintenanceRun `json:"last_run"`
+}
+
+type MaintenanceRepository interface {
+	// CreateRun inserts a RUNNING run, or returns ErrMaintenanceTaskRunning when
+	// the task already has one
+	CreateRun(ctx context.Context, run *MaintenanceRun) error
+	// AbandonStaleRuns fails running runs whose heartbeat is older than before,
+	// e.g. left behind by a crashed instance, and returns how many it failed
+	AbandonStaleRuns(ctx context.Context, before, at time.Time) (int, error)
+	// UpdateProgress stores the progress counters and refreshes the heartbeat
+	UpdateProgress(ctx context.Context, run *MaintenanceRun) error
+	FinishRun(ctx context.Context, run *MaintenanceRun) error
+	GetRun(ctx context.Context, id int64) (*MaintenanceRun, error)
+	ListRuns(ctx context.Context, task string, limit int) ([]MaintenanceRun, error)
+	LatestRuns(ctx context.Context) (map[string]MaintenanceRun, error)
+
+	// AnalyzeTable refreshes the planner statistics of one table
+	AnalyzeTable(ctx context.Context, table string) error
+	// ReindexConcurrently rebuilds one index without blocking writes
+	ReindexConcurrently(ctx context.Context, index string) error
+}
+
+type MaintenanceUsecase interface {
+	// StartTask starts the task in the background and returns its run; poll the
+	// run for progress
+	StartTask(ctx context.Context, adminID, task string) (*MaintenanceRun, error)
+	ListTasks(ctx context.Context) ([]MaintenanceTaskInfo, error)
+	ListRuns(ctx context.Context, task string) ([]MaintenanceRun, error)
+	GetRun(ctx context.Context, id int64) (*MaintenanceRun, error)
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Notification categories
+const (
+	NotificationCategoryApplicationReceived = "APPLICATION_RECEIVED" // employer: a candidate applied to a job
+	NotificationCategoryApplicationStatus   = "APPLICATION_STATUS"   // candidate: an employer decided on an application
+	NotificationCategoryAccount             = "ACCOUNT"              // verification and account changes
+	NotificationCategoryInterviewFeedback   = "INTERVIEW_FEEDBACK"   // candidate: an employer shared feedback on a rejected application
+	NotificationCategoryEmployerMessage     = "EMPLOYER_MESSAGE"     // candidate: an employer messaged shortlisted candidates
+	NotificationCategoryScreeningCall       = "SCREENING_CALL"       // candidate: a screening call was booked, cancelled or is coming up
+	NotificationCategoryPipelineSLA         = "PIPELINE_SLA"         // employer: applications waited past the company's pipeline SLA
+	NotificationCategoryAnnouncement        = "ANNOUNCEMENT"         // everyone, or one role: an admin announcement (in-app only)
+	NotificationCategoryFileProcessing      = "FILE_PROCESSING"      // an upload finished processing (in-app only)
+)
+
+// CriticalNotificationCategories are always delivered immediately, never batched into a digest
+var CriticalNotificationCategories = map[string]bool{
+	NotificationCategoryApplicationStatus: true,
+	NotificationCategoryAccount:           true,
+	NotificationCategoryScreeningCall:     true,
+}
+
+// MaxNotificationDigestAttempts is how often a digest item is retried before it is skipped
+const MaxNotificationDigestAttempts = 5
+
+// Notification is one event for one user. Subject and Body are i18n source
+// messages, formatted with their args after translation into the recipient's locale.
+type Notification struct {
+	UserID      string
+	Category    string
+	Subject     string
+	SubjectArgs []any
+	Body        string
+	BodyArgs    []any
+}
+
+// NotificationLines is a notification arg holding i18n source messages; each is
+// translated into the recipient's locale and written as a bullet on its own line
+type NotificationLines []string
+
+// NotificationRecipient is what is needed to address and localize a notification
+type NotificationRecipient struct {
+	UserID          string
+	Email           string
+	Role            string
+	PreferredLocale *string
+}
+
+// NotificationDigestItem is a localized event waiting for the user's next digest
+type NotificationDigestItem struct {
+	ID        int64
+	UserID    string
+	Category  string
+	Locale    string
+	Subject   string
+	Body      string
+	CreatedAt time.Time
+	Attempts  int
+	Email     string // recipient, filled when listing due digests
+}
+
+// NotificationDigestRunResult summarizes one digest worker run
+type NotificationDigestRunResult struct {
+	Items      int       `json:"items"`
+	Recipients int       `json:"recipients"` // one digest email per recipient
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// InAppNotification is an entry of a user's notification center
+type InAppNotification struct {
+	ID        int64      `json:"id"`
+	Category  string     `json:"category"`
+	Subject   string     `json:"subject"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationList is a page of the notification center with the unread count
+// for the header badge
+type NotificationList struct {
+	PaginatedResult[InAppNotification]
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// UnreadNotificationCount is the header badge
+type UnreadNotificationCount struct {
+	UnreadCount int64 `json:"unread_count"`
+}
+
+// NotificationsReadResult reports how many notifications were marked read
+type NotificationsReadResult struct {
+	Updated int64 `json:"updated"`
+}
+
+// AnnouncementRequest sends an in-app announcement to every active user, or
+// to the users of one role. It is shown as written, not translated.
+type AnnouncementRequest struct {
+	Subject string `json:"subject" binding:"required,min=1,max=200"`
+	Body    string `json:"body" binding:"required,min=1,max=5000"`
+	Role    string `json:"role" binding:"omitempty,oneof=candidate employer admin lpk"`
+}
+
+// AnnouncementResult reports an announcement
+type AnnouncementResult struct {
+	Recipients int64 `json:"recipients"`
+}
+
+type NotificationRepository interface {
+	GetRecipient(ctx context.Context, userID string) (*NotificationRecipient, error)
+	EnqueueDigestItem(ctx context.Context, item *NotificationDigestItem) error
+	// ListDueDigestItems returns unsent items of users whose oldest unsent item was created
+	// at or before cutoff, ordered by user and creation time
+	ListDueDigestItems(ctx context.Context, cutoff time.Time, maxAttempts, limit int) ([]NotificationDigestItem, error)
+	MarkDigestItemsSent(ctx context.Context, ids []int64) error
+	MarkDigestItemsFailed(ctx context.Context, ids []int64, errMsg string) error
+
+	// Notification center
+	CreateInApp(ctx context.Context, userID string, n *InAppNotification) error
+	// CreateAnnouncement records the announcement for every enabled user of role
+	// (all roles when empty) and returns how many
+	CreateAnnouncement(ctx context.Context, role, subject, body string) (int64, error)
+	ListInApp(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) ([]InAppNotification, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	// MarkRead returns ErrNotFound when the notification is not the user's
+	MarkRead(ctx context.Context, userID string, id int64) error
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+}
+
+// NotificationDispatcher delivers notifications, batching non-critical ones into
+// digests; every notification is also recorded in the notification center
+type NotificationDispatcher interface {
+	Dispatch(ctx context.Context, n *Notification) error
+}
+
+// InAppNotifier records a notification in the notification center only, for
+// events that are emailed some other way
+type InAppNotifier interface {
+	NotifyInApp(ctx context.Context, n *Notification) error
+}
+
+type NotificationUsecase interface {
+	NotificationDispatcher
+	InAppNotifier
+	// RunDigests sends due digests; called by the background worker
+	RunDigests(ctx context.Context) (*NotificationDigestRunResult, error)
+
+	// Notification center of the caller
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) (*NotificationList, error)
+	UnreadCount(ctx context.Context, userID string) (*UnreadNotificationCount, error)
+	MarkRead(ctx context.Context, userID string, id int64) error
+	MarkAllRead(ctx context.Context, userID string) (*NotificationsReadResult, error)
+	// Announce is for unrestricted admins
+	Announce(ctx context.Context, req AnnouncementRequest) (*AnnouncementResult, error)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Phone OTP policy
+const (
+	PhoneOTPLength      = 6
+	PhoneOTPTTL         = 10 * time.Minute
+	PhoneOTPMaxAttempts = 5
+	PhoneOTPResendWait  = 60 * time.Second
+	PhoneOTPMaxPerDay   = 5
+)
+
+// PhoneOTPChallenge is a pending one-time code sent to a candidate's phone
+type PhoneOTPChallenge struct {
+	ID         int64
+	UserID     string
+	Phone      string
+	CodeHash   string
+	Attempts   int
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// PhoneVerificationStatus is returned to the candidate
+type PhoneVerificationStatus struct {
+	Phone         *string    `json:"phone"`
+	Verified      bool       `json:"verified"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	OTPExpiresAt  *time.Time `json:"otp_expires_at,omitempty"`
+	ResendAfterAt *time.Time `json:"resend_after_at,omitempty"`
+}
+
+// VerifyPhoneRequest is the payload for POST /candidates/me/phone/verify
+type VerifyPhoneRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type PhoneVerificationRepository interface {
+	// GetPhoneStatus returns the profile phone and, if still matching, when it was verified
+	GetPhoneStatus(ctx context.Context, userID string) (phone *string, verifiedAt *time.Time, err error)
+	CreateChallenge(ctx context.Context, c *PhoneOTPChallenge) error
+	GetLatestChallenge(ctx context.Context, userID string) (*PhoneOTPChallenge, error)
+	CountChallengesSince(ctx context.Context, userID string, since time.Time) (int, error)
+	IncrementAttempts(ctx context.Context, challengeID int64) error
+	// MarkVerified consumes the challenge and stamps the verified phone in one transaction
+	MarkVerified(ctx context.Context, challengeID int64, userID, phone string) (time.Time, error)
+}
+
+type PhoneVerificationUsecase interface {
+	GetStatus(ctx context.Context, userID string) (*PhoneVerificationStatus, error)
+	SendOTP(ctx context.Context, userID string) (*PhoneVerificationStatus, error)
+	VerifyOTP(ctx context.Context, userID, code string) (*PhoneVerificationStatus, error)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PII access actions
+const (
+	PIIActionApplicationExport = "APPLICATION_EXPORT"
+	PIIActionResumeDownload    = "RESUME_DOWNLOAD" // full (unredacted) resume PDF
+)
+
+// PIIAccessLog records one bulk access to candidate personal data
+type PIIAccessLog struct {
+	ID             int64     `json:"id"`
+	ActorUserID    string    `json:"actor_user_id"`
+	CompanyID      *int64    `json:"company_id,omitempty"`
+	Action         string    `json:"action"`
+	ResourceType   string    `json:"resource_type"`
+	ResourceID     string    `json:"resource_id"`
+	SubjectUserIDs []string  `json:"subject_user_ids"`
+	Columns        []string  `json:"columns"`
+	IPAddress      string    `json:"ip_address,omitempty"`
+	UserAgent      string    `json:"user_agent,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PIIAccessLogRepository is append-only
+type PIIAccessLogRepository interface {
+	Create(ctx context.Context, entry *PIIAccessLog) error
+}
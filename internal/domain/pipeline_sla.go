@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PipelineSLAStages have a response time target; hired and rejected are final
+var PipelineSLAStages = []string{
+	ApplicationStageApplied,
+	ApplicationStageScreening,
+	ApplicationStageInterview,
+	ApplicationStageOffer,
+}
+
+// DefaultPipelineSLADays applies to stages a company has not configured
+var DefaultPipelineSLADays = map[string]int{
+	ApplicationStageApplied:   7,
+	ApplicationStageScreening: 7,
+	ApplicationStageInterview: 14,
+	ApplicationStageOffer:     7,
+}
+
+// Breach list filter values
+const (
+	PipelineSLABreachOpen     = "OPEN"
+	PipelineSLABreachResolved = "RESOLVED"
+)
+
+// PipelineSLAThreshold is how long applications may stay in one stage
+type PipelineSLAThreshold struct {
+	Stage     string `json:"stage"`
+	MaxDays   int    `json:"max_days"`   // 0 turns the SLA off for the stage
+	IsDefault bool   `json:"is_default"` // the platform default, not set by the company
+}
+
+// PipelineSLASettings are a company's thresholds for every SLA stage
+type PipelineSLASettings struct {
+	CompanyID  int64                  `json:"company_id"`
+	Thresholds []PipelineSLAThreshold `json:"thresholds"` // board order
+}
+
+// UpdatePipelineSLARequest sets thresholds; stages left out keep their value
+type UpdatePipelineSLARequest struct {
+	Thresholds []PipelineSLAThresholdInput `json:"thresholds" binding:"required,min=1,max=4,dive"`
+}
+
+type PipelineSLAThresholdInput struct {
+	Stage   string `json:"stage" binding:"required,oneof=applied screening interview offer"`
+	MaxDays *int   `json:"max_days" binding:"required,min=0,max=90"`
+}
+
+// PipelineSLABreach is an application that stayed in a stage past the threshold
+type PipelineSLABreach struct {
+	ID             int64      `json:"id"`
+	ApplicationID  int64      `json:"application_id"`
+	CompanyID      int64      `json:"company_id"`
+	JobID          int64      `json:"job_id"`
+	JobTitle       string     `json:"job_title"`
+	Stage          string     `json:"stage"`
+	ThresholdDays  int        `json:"threshold_days"`
+	StageEnteredAt time.Time  `json:"stage_entered_at"`
+	DaysInStage    int        `json:"days_in_stage"` // until now, or until it was resolved
+	BreachedAt     time.Time  `json:"breached_at"`
+	NotifiedAt     *time.Time `json:"notified_at,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"` // the application left the stage
+}
+
+// PipelineSLABreachFilter narrows an employer's breach list
+type PipelineSLABreachFilter struct {
+	Status   string // OPEN, RESOLVED or empty for both
+	Page     int
+	PageSize int
+}
+
+// PipelineSLABreachNotice is an unnotified breach with its employer recipient
+type PipelineSLABreachNotice struct {
+	BreachID      int64
+	CompanyID     int64
+	CompanyUserID string
+	JobTitle      string
+	Stage         string
+}
+
+// PipelineSLARunResult summarizes one SLA worker run
+type PipelineSLARunResult struct {
+	Resolved   int64     `json:"resolved"` // breaches closed because the application moved on
+	Breached   int64     `json:"breached"` // new breaches
+	Notified   int       `json:"notified"` // employers notified
+	Failed     int       `json:"failed"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// PipelineSLACompanyReport is one company's SLA record over the report period
+type PipelineSLACompanyReport struct {
+	CompanyID            int64   `json:"company_id"`
+	CompanyName          string  `json:"company_name"`
+	Applications         int64   `json:"applications"` // created, updated or breached in the period
+	AffectedApplications int64   `json:"affected_applications"`
+	Breaches             int64   `json:"breaches"`
+	OpenBreaches         int64   `json:"open_breaches"`
+	AvgDaysOverdue       float64 `json:"avg_days_overdue"`
+	// CandidateExperienceScore is the percentage of applications that never waited past an SLA
+	CandidateExperienceScore float64 `json:"candidate_experience_score"`
+}
+
+// PipelineSLAReportFilter selects companies with chronic breaches
+type PipelineSLAReportFilter struct {
+	Days        int `form:"days"`         // period, default 90
+	MinBreaches int `form:"min_breaches"` // default 5
+}
+
+type PipelineSLARepository interface {
+	// ListThresholds returns the company's configured thresholds by stage
+	ListThresholds(ctx context.Context, companyID int64) (map[string]int, error)
+	SetThresholds(ctx context.Context, companyID int64, thresholds map[string]int, updatedBy string) error
+
+	// ResolveBreaches closes open breaches whose application left the stage
+	ResolveBreaches(ctx context.Context) (int64, error)
+	// RecordBreaches records applications past their company's threshold, or
+	// the default, once per stage visit
+	RecordBreaches(ctx context.Context, defaults map[string]int) (int64, error)
+	ListUnnotified(ctx context.Context, limit int) ([]PipelineSLABreachNotice, error)
+	MarkNotified(ctx context.Context, ids []int64) error
+
+	ListBreaches(ctx context.Context, companyID int64, filter PipelineSLABreachFilter) ([]PipelineSLABreach, int64, error)
+	// CompanyReport lists companies with at least minBreaches breaches since, worst experience first
+	CompanyReport(ctx context.Context, since time.Time, minBreaches int) ([]PipelineSLACompanyReport, error)
+}
+
+type PipelineSLAUsecase interface {
+	// RunChecks is called by the background worker: resolves and records
+	// breaches and notifies employers of new ones
+	RunChecks(ctx context.Context) (*PipelineSLARunResult, error)
+
+	// Employer
+	GetSettings(ctx context.Context, userID string) (*PipelineSLASettings, error)
+	UpdateSettings(ctx context.Context, userID string, req UpdatePipelineSLARequest) (*PipelineSLASettings, error)
+	ListBreaches(ctx context.Context, userID string, filter PipelineSLABreachFilter) (*PaginatedResult[PipelineSLABreach], error)
+
+	// Admin
+	GetChronicBreachReport(ctx context.Context, filter PipelineSLAReportFilter) ([]PipelineSLACompanyReport, error)
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ============================================================================
+// Placements & Alumni
+// ============================================================================
+
+// Placement lifecycle
+const (
+	PlacementStatusActive     = "ACTIVE"      // working under the placement contract
+	PlacementStatusCompleted  = "COMPLETED"   // contract served to the end
+	PlacementStatusEndedEarly = "ENDED_EARLY" // left or let go before the contract ended
+)
+
+// PlacementMilestones are the check-ins after the start date, in months
+var PlacementMilestones = []int{3, 6, 12}
+
+// Check-in states and outcomes
+const (
+	PlacementCheckInPending   = "PENDING"
+	PlacementCheckInCompleted = "COMPLETED"
+	PlacementCheckInSkipped   = "SKIPPED" // the placement ended before the check-in was due
+
+	PlacementOutcomeOnTrack     = "ON_TRACK"
+	PlacementOutcomeAtRisk      = "AT_RISK"
+	PlacementOutcomeUnreachable = "UNREACHABLE"
+)
+
+// PlacementCheckInReminderDays is how far ahead of its due date a pending
+// check-in shows up in the admins' task list; overdue ones stay until done
+const PlacementCheckInReminderDays = 14
+
+// Alumni status on the candidate profile
+const (
+	AlumniStatusPlaced = "PLACED" // has an active placement
+	AlumniStatusAlumni = "ALUMNI" // every placement has ended
+)
+
+// Placement records a hire: where the candidate started working and for how long
+type Placement struct {
+	ID              int64              `json:"id"`
+	ApplicationID   int64              `json:"application_id"`
+	CandidateUserID string             `json:"candidate_user_id"`
+	CandidateEmail  string             `json:"candidate_email"`
+	CompanyID       int64              `json:"company_id"`
+	CompanyName     string             `json:"company_name"`
+	JobID           int64              `json:"job_id"`
+	JobTitle        string             `json:"job_title"`
+	StartDate       string             `json:"start_date"` // YYYY-MM-DD
+	ContractMonths  int                `json:"contract_months"`
+	ContractEndDate string             `json:"contract_end_date"` // YYYY-MM-DD
+	Status          string             `json:"status"`
+	EndedOn         *string            `json:"ended_on,omitempty"`
+	EndReason       *string            `json:"end_reason,omitempty"`
+	Notes           *string            `json:"notes,omitempty"`
+	CheckIns        []PlacementCheckIn `json:"check_ins,omitempty"` // GetPlacement only
+	CreatedBy       *string            `json:"created_by,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+}
+
+// PlacementCheckIn is one post-placement milestone
+type PlacementCheckIn struct {
+	ID              int64      `json:"id"`
+	PlacementID     int64      `json:"placement_id"`
+	MilestoneMonths int        `json:"milestone_months"`
+	DueDate         string     `json:"due_date"` // YYYY-MM-DD
+	Status          string     `json:"status"`
+	Outcome         *string    `json:"outcome,omitempty"`
+	Notes           *string    `json:"notes,omitempty"`
+	CompletedBy     *string    `json:"completed_by,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
+
+// PlacementCheckInTask is a pending check-in in the admins' task list
+type PlacementCheckInTask struct {
+	PlacementCheckIn
+	CandidateUserID string `json:"candidate_user_id"`
+	CandidateEmail  string `json:"candidate_email"`
+	CompanyName     string `json:"company_name"`
+	JobTitle        string `json:"job_title"`
+	Overdue         bool   `json:"overdue"`
+}
+
+// CreatePlacementRequest records the placement of a hired (accepted) application
+type CreatePlacementRequest struct {
+	ApplicationID  int64   `json:"application_id" binding:"required,min=1"`
+	StartDate      string  `json:"start_date" binding:"required,datetime=2006-01-02"`
+	ContractMonths int     `json:"contract_months" binding:"required,min=1,max=120"`
+	Notes          *string `json:"notes" binding:"omitempty,max=2000"`
+}
+
+// EndPlacementRequest closes an active placement
+type EndPlacementRequest struct {
+	Status  string  `json:"status" binding:"required,oneof=COMPLETED ENDED_EARLY"`
+	EndedOn string  `json:"ended_on" binding:"required,datetime=2006-01-02"`
+	Reason  *string `json:"reason" binding:"omitempty,max=2000"`
+}
+
+// CompleteCheckInRequest records the outcome of a check-in
+type CompleteCheckInRequest struct {
+	Outcome string  `json:"outcome" binding:"required,oneof=ON_TRACK AT_RISK UNREACHABLE"`
+	Notes   *string `json:"notes" binding:"omitempty,max=2000"`
+}
+
+// PlacementFilter narrows the placement list
+type PlacementFilter struct {
+	Status    string // ACTIVE, COMPLETED, ENDED_EARLY; empty for all
+	CompanyID *int64
+}
+
+// PlacementStats feeds the placement section of the admin analytics
+type PlacementStats struct {
+	Total           int64                     `json:"total"`
+	Active          int64                     `json:"active"`
+	Completed       int64                     `json:"completed"`
+	EndedEarly      int64                     `json:"ended_early"`
+	CheckInsDue     int64                     `json:"check_ins_due"` // pending, due within PlacementCheckInReminderDays
+	CheckInsOverdue int64                     `json:"check_ins_overdue"`
+	Milestones      []PlacementMilestoneStats `json:"milestones"`
+}
+
+// PlacementMilestoneStats is retention at one milestone, over the placements
+// that have reached it
+type PlacementMilestoneStats struct {
+	Months        int     `json:"months"`
+	Reached       int64   `json:"reached"`        // placements started at least this many months ago
+	Retained      int64   `json:"retained"`       // of those, not ended early before the milestone
+	CheckedIn     int64   `json:"checked_in"`     // check-ins completed
+	RetentionRate float64 `json:"retention_rate"` // percent of reached placements retained
+}
+
+type PlacementRepository interface {
+	// Create inserts the placement and its check-ins; a second placement of the
+	// same application is a conflict
+	Create(ctx context.Context, p *Placement, checkIns []PlacementCheckIn) error
+	// GetByID includes the check-ins
+	GetByID(ctx context.Context, id int64) (*Placement, error)
+	List(ctx context.Context, filter PlacementFilter, page, pageSize int) ([]Placement, int64, error)
+	// End closes an active placement and skips its pending check-ins due after
+	// endedOn; ErrNotFound when the placement is not active
+	End(ctx context.Context, id int64, status, endedOn string, reason *string) error
+	// CompleteCheckIn returns ErrNotFound when the check-in is not pending
+	CompleteCheckIn(ctx context.Context, placementID int64, months int, outcome string, notes *string, completedBy string) error
+	// ListDueCheckIns returns pending check-ins due within days, overdue ones first
+	ListDueCheckIns(ctx context.Context, days int) ([]PlacementCheckInTask, error)
+	// GetStats leaves the milestones' RetentionRate to the caller
+	GetStats(ctx context.Context, dueWithinDays int) (*PlacementStats, error)
+}
+
+// PlacementUsecase is admin-only
+type PlacementUsecase interface {
+	CreatePlacement(ctx context.Context, req CreatePlacementRequest) (*Placement, error)
+	GetPlacement(ctx context.Context, id int64) (*Placement, error)
+	ListPlacements(ctx context.Context, filter PlacementFilter, page, pageSize int) (*PaginatedResult[Placement], error)
+	EndPlacement(ctx context.Context, id int64, req EndPlacementRequest) (*Placement, error)
+	CompleteCheckIn(ctx context.Context, id int64, months int, req CompleteCheckInRequest) (*Placement, error)
+	ListCheckInTasks(ctx context.Context) ([]PlacementCheckInTask, error)
+	GetStats(ctx context.Context) (*PlacementStats, error)
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Quiz categories
+const (
+	QuizCategoryJapaneseVocabulary = "JAPANESE_VOCABULARY"
+	QuizCategorySafety             = "SAFETY"
+	QuizCategoryOther              = "OTHER"
+)
+
+// Quiz attempt statuses
+const (
+	QuizAttemptInProgress = "IN_PROGRESS"
+	QuizAttemptSubmitted  = "SUBMITTED"
+	QuizAttemptExpired    = "EXPIRED" // time limit passed before submission; not scored
+)
+
+// Quiz limits
+const (
+	MaxQuizQuestions = 50
+	// QuizSubmitGrace absorbs network latency on submissions sent right at the deadline
+	QuizSubmitGrace = 15 * time.Second
+)
+
+// SkillQuiz is a short admin-authored self-assessment
+type SkillQuiz struct {
+	ID                  int64          `json:"id"`
+	Title               string         `json:"title"`
+	Description         *string        `json:"description"`
+	Category            string         `json:"category"` // JAPANESE_VOCABULARY, SAFETY, OTHER
+	TimeLimitSeconds    int            `json:"time_limit_seconds"`
+	PassScore           int            `json:"pass_score"` // percent
+	RetakeCooldownHours int            `json:"retake_cooldown_hours"`
+	IsPublished         bool           `json:"is_published"`
+	QuestionCount       int            `json:"question_count"`
+	Questions           []QuizQuestion `json:"questions,omitempty"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+}
+
+// QuizQuestion is a multiple-choice question.
+// CorrectOption is hidden from candidates (see ForCandidate).
+type QuizQuestion struct {
+	ID            int64    `json:"id"`
+	QuizID        int64    `json:"quiz_id"`
+	Position      int      `json:"position"`
+	Prompt        string   `json:"prompt"`
+	Options       []string `json:"options"`
+	CorrectOption *int     `json:"correct_option,omitempty"` // 0-based index into Options
+}
+
+// ForCandidate strips the answer key
+func (q QuizQuestion) ForCandidate() QuizQuestion {
+	q.CorrectOption = nil
+	return q
+}
+
+// QuizQuestionInput is one question in a quiz request; order is list order
+type QuizQuestionInput struct {
+	Prompt        string   `json:"prompt" binding:"required,max=500"`
+	Options       []string `json:"options" binding:"required,min=2,max=6,dive,required,max=200"`
+	CorrectOption *int     `json:"correct_option" binding:"required,min=0"`
+}
+
+// QuizRequest creates or replaces a quiz including its questions
+type QuizRequest struct {
+	Title               string              `json:"title" binding:"required,max=200"`
+	Description         *string             `json:"description" binding:"omitempty,max=2000"`
+	Category            string              `json:"category" binding:"required,oneof=JAPANESE_VOCABULARY SAFETY OTHER"`
+	TimeLimitSeconds    int                 `json:"time_limit_seconds" binding:"required,min=60,max=7200"`
+	PassScore           *int                `json:"pass_score" binding:"omitempty,min=0,max=100"`            // defaults to 70
+	RetakeCooldownHours *int                `json:"retake_cooldown_hours" binding:"omitempty,min=0,max=720"` // defaults to 24
+	IsPublished         bool                `json:"is_published"`
+	Questions           []QuizQuestionInput `json:"questions" binding:"required,min=1,dive"`
+}
+
+// QuizAttempt is one timed run of a quiz by a candidate
+type QuizAttempt struct {
+	ID             int64      `json:"id"`
+	QuizID         int64      `json:"quiz_id"`
+	UserID         string     `json:"user_id"`
+	Status         string     `json:"status"` // IN_PROGRESS, SUBMITTED, EXPIRED
+	StartedAt      time.Time  `json:"started_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	SubmittedAt    *time.Time `json:"submitted_at,omitempty"`
+	CorrectCount   *int       `json:"correct_count,omitempty"`
+	TotalQuestions *int       `json:"total_questions,omitempty"`
+	ScorePercent   *int       `json:"score_percent,omitempty"`
+	Passed         *bool      `json:"passed,omitempty"` // set on the submit response
+}
+
+// QuizAttemptSession is what a candidate needs to take the quiz
+type QuizAttemptSession struct {
+	Attempt QuizAttempt `json:"attempt"`
+	Quiz    SkillQuiz   `json:"quiz"` // questions without the answer key
+}
+
+// QuizAnswerInput is the candidate's choice for one question
+type QuizAnswerInput struct {
+	QuestionID     int64 `json:"question_id" binding:"required"`
+	SelectedOption *int  `json:"selected_option" binding:"required,min=0"`
+}
+
+// SubmitQuizRequest submits an attempt; unanswered questions count as wrong
+type SubmitQuizRequest struct {
+	Answers []QuizAnswerInput `json:"answers" binding:"omitempty,dive"`
+}
+
+// CandidateQuizScore is a candidate's best result on a quiz, shown on the profile
+type CandidateQuizScore struct {
+	QuizID        int64     `json:"quiz_id"`
+	QuizTitle     string    `json:"quiz_title"`
+	Category      string    `json:"category"`
+	BestScore     int       `json:"best_score"`
+	Passed        bool      `json:"passed"`
+	AttemptCount  int       `json:"attempt_count"`
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+}
+
+// CandidateQuizOverview is a published quiz from the candidate's point of view
+type CandidateQuizOverview struct {
+	Quiz              SkillQuiz           `json:"quiz"`
+	Score             *CandidateQuizScore `json:"score,omitempty"`
+	OpenAttemptID     *int64              `json:"open_attempt_id,omitempty"`
+	RetakeAvailableAt *time.Time          `json:"retake_available_at,omitempty"` // set while the cooldown runs
+}
+
+type QuizRepository interface {
+	ListQuizzes(ctx context.Context, publishedOnly bool) ([]SkillQuiz, error)
+	// GetQuiz returns the quiz without questions
+	GetQuiz(ctx context.Context, id int64) (*SkillQuiz, error)
+	ListQuestions(ctx context.Context, quizID int64) ([]QuizQuestion, error)
+	// CreateQuiz and UpdateQuiz write the quiz and replace its questions in one transaction
+	CreateQuiz(ctx context.Context, quiz *SkillQuiz, createdBy string) error
+	UpdateQuiz(ctx context.Context, quiz *SkillQuiz) error
+
+	GetAttempt(ctx context.Context, attemptID int64) (*QuizAttempt, error)
+	// GetLatestAttempt returns the most recently started attempt in any status
+	GetLatestAttempt(ctx context.Context, userID string, quizID int64) (*QuizAttempt, error)
+	// ListLatestAttempts returns the most recent attempt per quiz
+	ListLatestAttempts(ctx context.Context, userID string) ([]QuizAttempt, error)
+	// CreateAttempt returns a Conflict error when an attempt is already open
+	CreateAttempt(ctx context.Context, attempt *QuizAttempt) error
+	// CloseAttempt stores the outcome of an open attempt; a SUBMITTED attempt also
+	// updates the candidate's best score. Returns ErrNotFound if it was already closed.
+	CloseAttempt(ctx context.Context, attempt *QuizAttempt, passed bool) error
+
+	ListUserScores(ctx context.Context, userID string) ([]CandidateQuizScore, error)
+}
+
+type QuizUsecase interface {
+	// Admin authoring
+	ListQuizzes(ctx context.Context) ([]SkillQuiz, error)
+	GetQuiz(ctx context.Context, id int64) (*SkillQuiz, error)
+	CreateQuiz(ctx context.Context, userID string, req QuizRequest) (*SkillQuiz, error)
+	UpdateQuiz(ctx context.Context, id int64, req QuizRequest) (*SkillQuiz, error)
+
+	// Candidate
+	ListAvailableQuizzes(ctx context.Context, userID string) ([]CandidateQuizOverview, error)
+	StartAttempt(ctx context.Context, userID string, quizID int64) (*QuizAttemptSession, error)
+	SubmitAttempt(ctx context.Context, userID string, attemptID int64, req SubmitQuizRequest) (*QuizAttempt, error)
+	GetMyScores(ctx context.Context, userID string) ([]CandidateQuizScore, error)
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Realtime event types pushed over GET /v1/ws
+const (
+	RealtimeEventApplicationStatus    = "application.status_changed" // to the candidate
+	RealtimeEventApplicationReceived  = "application.received"       // to the employer
+	RealtimeEventVerificationDecision = "verification.decided"       // to the verified or rejected user
+	RealtimeEventJobMatch             = "job.match"                  // to the candidate
+)
+
+// RealtimeEvent is pushed to every open connection of a user
+type RealtimeEvent struct {
+	Type       string    `json:"type"`
+	Data       any       `json:"data,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ApplicationStatusEvent is the data of application.status_changed
+type ApplicationStatusEvent struct {
+	ApplicationID int64  `json:"application_id"`
+	JobID         int64  `json:"job_id"`
+	Status        string `json:"status"`
+}
+
+// ApplicationReceivedEvent is the data of application.received
+type ApplicationReceivedEvent struct {
+	ApplicationID int64  `json:"application_id"`
+	JobID         int64  `json:"job_id"`
+	JobTitle      string `json:"job_title"`
+}
+
+// VerificationDecisionEvent is the data of verification.decided
+type VerificationDecisionEvent struct {
+	VerificationID int64  `json:"verification_id"`
+	Status         string `json:"status"`
+	Notes          string `json:"notes,omitempty"`
+}
+
+// JobMatchEvent is the data of job.match
+type JobMatchEvent struct {
+	JobID         int64  `json:"job_id"`
+	Title         string `json:"title"`
+	SavedSearchID int64  `json:"saved_search_id"`
+}
+
+// RealtimePublisher pushes events to a user's open connections. Delivery is
+// best-effort: a user without an open connection misses the event, so anything
+// that matters must also be readable through the REST API.
+type RealtimePublisher interface {
+	Publish(ctx context.Context, userID string, event RealtimeEvent) error
+}
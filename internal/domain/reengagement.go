@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Re-engagement campaigns
+const (
+	CampaignIncompleteProfile  = "INCOMPLETE_PROFILE"  // verification not yet submitted
+	CampaignStalledApplication = "STALLED_APPLICATION" // applications with no status change for a while
+)
+
+// ReengagementCampaigns lists campaigns in report order
+var ReengagementCampaigns = []string{CampaignIncompleteProfile, CampaignStalledApplication}
+
+// Nudge delivery status
+const (
+	NudgeStatusSent   = "SENT"
+	NudgeStatusFailed = "FAILED"
+)
+
+// Profile steps reported as missing in an incomplete-profile nudge
+const (
+	StepBasicInfo       = "basic_info"
+	StepProfilePicture  = "profile_picture"
+	StepPhone           = "phone"
+	StepPhoneVerified   = "phone_verified"
+	StepCV              = "cv"
+	StepJapaneseLevel   = "japanese_level"
+	StepDomicileCity    = "domicile_city"
+	StepOnboarding      = "onboarding"
+	StepSubmitForReview = "submit_verification"
+)
+
+// ============================================================================
+// Worker
+// ============================================================================
+
+// ReengagementCandidate is an inactive candidate eligible for a nudge
+// (not paused, not opted out, outside the frequency cap)
+type ReengagementCandidate struct {
+	UserID          string
+	Email           string
+	PreferredLocale *string
+	LastActiveAt    time.Time
+
+	// Verification progress; VerificationStatus is nil when no record exists
+	VerificationStatus  *string
+	FirstName           *string
+	LastName            *string
+	ProfilePictureURL   *string
+	Phone               *string
+	PhoneVerified       bool
+	CvURL               *string
+	JapaneseLevel       *string
+	DomicileCity        *string
+	OnboardingCompleted bool
+	PreferredLocations  []string
+
+	StalledApplications int // applied/reviewed applications untouched since the stalled cutoff
+}
+
+// ReengagementCriteria selects candidates for one worker run
+type ReengagementCriteria struct {
+	InactiveBefore time.Time // last activity strictly before this
+	StalledBefore  time.Time // application updated_at cutoff
+	CooldownSince  time.Time // skip candidates nudged successfully since this
+	Limit          int
+}
+
+// ReengagementNudge records one delivery attempt
+type ReengagementNudge struct {
+	ID              int64      `json:"id"`
+	UserID          string     `json:"user_id"`
+	Campaign        string     `json:"campaign"`
+	Channel         string     `json:"channel"`
+	MissingSteps    []string   `json:"missing_steps"`
+	SuggestedJobIDs []int64    `json:"suggested_job_ids"`
+	Status          string     `json:"status"`
+	ErrorMessage    *string    `json:"error_message,omitempty"`
+	SentAt          time.Time  `json:"sent_at"`
+	ReturnedAt      *time.Time `json:"returned_at,omitempty"`
+}
+
+// CandidateNotification is a personalized, already-localized message for one candidate
+type CandidateNotification struct {
+	UserID   string
+	Email    string
+	Locale   string
+	Campaign string
+	Subject  string
+	Body     string // plain text
+}
+
+// CandidateNotifier delivers messages to candidates
+type CandidateNotifier interface {
+	Channel() string
+	NotifyCandidate(ctx context.Context, n *CandidateNotification) error
+}
+
+// ReengagementRunResult summarizes one worker run
+type ReengagementRunResult struct {
+	Evaluated  int       `json:"evaluated"`
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+	Skipped    int       `json:"skipped"` // nothing worth nudging about
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// ============================================================================
+// Candidate preference
+// ============================================================================
+
+// ReengagementPreference is the candidate's nudge subscription state
+type ReengagementPreference struct {
+	OptedOut   bool       `json:"opted_out"`
+	OptedOutAt *time.Time `json:"opted_out_at,omitempty"`
+}
+
+// UpdateReengagementPreferenceRequest opts a candidate out of (or back into) nudges
+type UpdateReengagementPreferenceRequest struct {
+	OptOut *bool `json:"opt_out" binding:"required"`
+}
+
+// ============================================================================
+// Admin reporting
+// ============================================================================
+
+// ReengagementCampaignStats is one campaign's effectiveness over a date range.
+// Returned: candidate was active again after the nudge.
+// Converted: within the attribution window the candidate submitted their
+// verification (incomplete profile) or applied to a job (stalled application).
+type ReengagementCampaignStats struct {
+	Campaign       string  `json:"campaign"`
+	Sent           int64   `json:"sent"`
+	Failed         int64   `json:"failed"`
+	Returned       int64   `json:"returned"`
+	Converted      int64   `json:"converted"`
+	ReturnRate     float64 `json:"return_rate"`     // returned / sent
+	ConversionRate float64 `json:"conversion_rate"` // converted / sent
+}
+
+// ReengagementReport covers nudges sent between From and To (inclusive days)
+type ReengagementReport struct {
+	From            string                      `json:"from"` // YYYY-MM-DD
+	To              string                      `json:"to"`   // YYYY-MM-DD
+	AttributionDays int                         `json:"attribution_days"`
+	Campaigns       []ReengagementCampaignStats `json:"campaigns"`
+	Totals          ReengagementCampaignStats   `json:"totals"`
+	OptedOut        int64                       `json:"opted_out"` // candidates currently opted out
+	GeneratedAt     time.Time                   `json:"generated_at"`
+}
+
+// ============================================================================
+// Repository & Usecase Interfaces
+// ============================================================================
+
+type ReengagementRepository interface {
+	ListCandidates(ctx context.Context, criteria ReengagementCriteria) ([]ReengagementCandidate, error)
+	// ListMatchingJobs returns active jobs posted since the given time that the
+	// candidate has not applied to, preferred locations first
+	ListMatchingJobs(ctx context.Context, userID string, since time.Time, locations []string, limit int) ([]Job, error)
+	CreateNudge(ctx context.Context, nudge *ReengagementNudge) error
+
+	// TouchActivity stores last_active_at and marks nudges sent since returnSince as returned
+	TouchActivity(ctx context.Context, userID string, at, returnSince time.Time) error
+
+	GetOptOut(ctx context.Context, userID string) (*time.Time, error)
+	SetOptOut(ctx context.Context, userID string, at *time.Time) error
+
+	GetCampaignStats(ctx context.Context, from, to time.Time, attributionDays int) ([]ReengagementCampaignStats, error)
+	CountOptedOut(ctx context.Context) (int64, error)
+}
+
+type ReengagementUsecase interface {
+	// RunCampaigns is called by the background worker
+	RunCampaigns(ctx context.Context) (*ReengagementRunResult, error)
+	// TriggerRun runs the campaigns immediately (admin only)
+	TriggerRun(ctx context.Context) (*ReengagementRunResult, error)
+
+	// RecordActivity is called on authenticated requests; writes are throttled
+	RecordActivity(ctx context.Context, userID string)
+
+	GetPreference(ctx context.Context, userID string) (*ReengagementPreference, error)
+	UpdatePreference(ctx context.Context, userID string, optOut bool) (*ReengagementPreference, error)
+
+	GetReport(ctx context.Context, from, to string) (*ReengagementReport, error)
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Data classes with a retention policy
+const (
+	RetentionEntityApplications          = "applications"           // by last update; anonymize drops CV, cover letter and screening answers
+	RetentionEntityRejectedVerifications = "rejected_verifications" // by rejection; anonymize drops identity documents and personal details
+)
+
+// RetentionEntities lists every data class, in the order reports show them
+var RetentionEntities = []string{RetentionEntityApplications, RetentionEntityRejectedVerifications}
+
+// What enforcement does with expired records
+const (
+	RetentionActionAnonymize = "ANONYMIZE"
+	RetentionActionDelete    = "DELETE"
+)
+
+// RetentionEntityActions lists the actions each data class supports. A
+// verification row belongs to the account, so it is only ever anonymized.
+var RetentionEntityActions = map[string][]string{
+	RetentionEntityApplications:          {RetentionActionAnonymize, RetentionActionDelete},
+	RetentionEntityRejectedVerifications: {RetentionActionAnonymize},
+}
+
+// RetentionDryRunMaxAge is how recent a dry run must be before an admin can
+// enforce; policy changes after it require a new one
+const RetentionDryRunMaxAge = 24 * time.Hour
+
+// Retention run triggers and statuses
+const (
+	RetentionTriggerScheduled = "SCHEDULED"
+	RetentionTriggerManual    = "MANUAL"
+
+	RetentionRunRunning   = "RUNNING"
+	RetentionRunCompleted = "COMPLETED"
+	RetentionRunFailed    = "FAILED"
+)
+
+// RetentionPolicy is the admin-configured retention rule of one data class
+type RetentionPolicy struct {
+	Entity           string    `json:"entity"`
+	RetentionDays    int       `json:"retention_days"`
+	Action           string    `json:"action"` // ANONYMIZE or DELETE
+	Enabled          bool      `json:"enabled"`
+	SupportedActions []string  `json:"supported_actions"`
+	UpdatedBy        *string   `json:"updated_by,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// UpdateRetentionPolicyRequest replaces a data class's retention rule
+type UpdateRetentionPolicyRequest struct {
+	RetentionDays int    `json:"retention_days" binding:"required,min=30,max=3650"`
+	Action        string `json:"action" binding:"required,oneof=ANONYMIZE DELETE"`
+	Enabled       *bool  `json:"enabled" binding:"required"`
+}
+
+// RetentionEntityResult is what a run found (and did) for one data class
+type RetentionEntityResult struct {
+	Entity         string     `json:"entity"`
+	Action         string     `json:"action"`
+	RetentionDays  int        `json:"retention_days"`
+	Cutoff         time.Time  `json:"cutoff"` // records last changed before this are expired
+	Enabled        bool       `json:"enabled"`
+	Eligible       int        `json:"eligible"`
+	OldestRecordAt *time.Time `json:"oldest_record_at,omitempty"`
+	Processed      int        `json:"processed"` // always 0 in a dry run
+	Error          *string    `json:"error,omitempty"`
+}
+
+// RetentionRun is one dry run or enforcement pass over every policy
+type RetentionRun struct {
+	ID            int64                   `json:"id"`
+	TriggerSource string                  `json:"trigger_source"` // SCHEDULED, MANUAL
+	DryRun        bool                    `json:"dry_run"`
+	Status        string                  `json:"status"` // RUNNING, COMPLETED, FAILED
+	Results       []RetentionEntityResult `json:"results"`
+	ErrorMessage  *string                 `json:"error_message,omitempty"`
+	StartedBy     *string                 `json:"started_by,omitempty"`
+	StartedAt     time.Time               `json:"started_at"`
+	FinishedAt    *time.Time              `json:"finished_at,omitempty"`
+}
+
+type RetentionRepository interface {
+	ListPolicies(ctx context.Context) ([]RetentionPolicy, error)
+	// UpdatePolicy returns ErrNotFound for an unknown entity
+	UpdatePolicy(ctx context.Context, policy *RetentionPolicy) error
+
+	// CountExpired counts the entity's records that the action would still
+	// change, with the oldest one's timestamp
+	CountExpired(ctx context.Context, entity, action string, cutoff time.Time) (int, *time.Time, error)
+	// EnforceBatch anonymizes or deletes up to limit expired records and
+	// returns how many it changed
+	EnforceBatch(ctx context.Context, entity, action string, cutoff time.Time, limit int) (int, error)
+
+	CreateRun(ctx context.Context, run *RetentionRun) error
+	FinishRun(ctx context.Context, run *RetentionRun) error
+	// LatestDryRun returns the most recent completed dry run, or ErrNotFound
+	LatestDryRun(ctx context.Context) (*RetentionRun, error)
+	ListRuns(ctx context.Context, limit int) ([]RetentionRun, error)
+	GetRun(ctx context.Context, id int64) (*RetentionRun, error)
+}
+
+type RetentionUsecase interface {
+	// RunRetention is called by the nightly worker; a dry run only reports
+	RunRetention(ctx context.Context, triggerSource string, dryRun bool, startedBy *string) (*RetentionRun, error)
+
+	// Admin
+	ListPolicies(ctx context.Context) ([]RetentionPolicy, error)
+	UpdatePolicy(ctx context.Context, adminID, entity string, req UpdateRetentionPolicyRequest) (*RetentionPolicy, error)
+	DryRun(ctx context.Context, adminID string) (*RetentionRun, error)
+	// Enforce requires a completed dry run within RetentionDryRunMaxAge
+	Enforce(ctx context.Context, adminID string) (*RetentionRun, error)
+	ListRuns(ctx context.Context) ([]RetentionRun, error)
+	GetRun(ctx context.Context, id int64) (*RetentionRun, error)
+}
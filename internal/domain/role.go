@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// User roles (users.role). This lowercase form is the canonical one: it is
+// what the auth middleware puts in the request context and what usecases
+// compare against.
+const (
+	RoleCandidate = "candidate"
+	RoleEmployer  = "employer"
+	RoleAdmin     = "admin"
+	RoleLPK       = "lpk" // LPK (training center) partner; granted only with an LPK mapping
+)
+
+// Roles lists every valid user role
+var Roles = []string{RoleCandidate, RoleEmployer, RoleAdmin, RoleLPK}
+
+// Verification roles (account_verifications.role and verification_field_rules.role)
+// are stored uppercase; use VerificationRole to convert.
+const (
+	VerificationRoleCandidate = "CANDIDATE"
+	VerificationRoleEmployer  = "EMPLOYER"
+	VerificationRoleAdmin     = "ADMIN"
+)
+
+// ErrInvalidRole is returned by ParseRole for unknown roles
+var ErrInvalidRole = errors.New("invalid role")
+
+// NormalizeRole trims and lowercases a role without validating it
+func NormalizeRole(role string) string {
+	return strings.ToLower(strings.TrimSpace(role))
+}
+
+// ParseRole returns the canonical form of role, accepting any casing
+// ("ADMIN", " Admin "), or ErrInvalidRole if it is not a known role
+func ParseRole(role string) (string, error) {
+	normalized := NormalizeRole(role)
+	for _, r := range Roles {
+		if normalized == r {
+			return r, nil
+		}
+	}
+	return "", ErrInvalidRole
+}
+
+// IsValidRole reports whether role is a known role in any casing
+func IsValidRole(role string) bool {
+	_, err := ParseRole(role)
+	return err == nil
+}
+
+// VerificationRole converts a user role to its uppercase account_verifications form
+func VerificationRole(role string) string {
+	return strings.ToUpper(NormalizeRole(role))
+}
+
+// RoleFromContext returns the caller's canonical role from either the typed
+// context key (context.WithValue) or the Gin string key (c.Set)
+func RoleFromContext(ctx context.Context) string {
+	return NormalizeRole(contextString(ctx, KeyUserRole))
+}
+
+// UserIDFromContext returns the caller's user ID from either context key
+func UserIDFromContext(ctx context.Context) string {
+	return contextString(ctx, KeyUserID)
+}
+
+func contextString(ctx context.Context, key CtxKey) string {
+	if v, ok := ctx.Value(key).(string); ok && v != "" {
+		return v
+	}
+	v, _ := ctx.Value(string(key)).(string)
+	return v
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxSavedSearchesPerUser caps how many searches a candidate can keep
+const MaxSavedSearchesPerUser = 10
+
+// MaxSavedSearchAlertJobs is how many new jobs one alert lists per search; the
+// rest are counted and linked to
+const MaxSavedSearchAlertJobs = 10
+
+// Saved search alert frequency
+const (
+	SavedSearchFrequencyDaily  = "DAILY"
+	SavedSearchFrequencyWeekly = "WEEKLY"
+)
+
+// SavedSearchFrequencyInterval is the minimum time between two alerts of a search
+var SavedSearchFrequencyInterval = map[string]time.Duration{
+	SavedSearchFrequencyDaily:  24 * time.Hour,
+	SavedSearchFrequencyWeekly: 7 * 24 * time.Hour,
+}
+
+// Saved search alert delivery status
+const (
+	SavedSearchAlertStatusSent   = "SENT"
+	SavedSearchAlertStatusFailed = "FAILED"
+)
+
+// JobSearchFilter selects active jobs. Empty fields match everything; values
+// within a list are OR-ed and fields are AND-ed, as in the ATS filter.
+type JobSearchFilter struct {
+	Keywords         string   `json:"keywords,omitempty" binding:"omitempty,max=200"` // every word must appear in the title or description
+	Locations        []string `json:"locations,omitempty" binding:"omitempty,max=20,dive,required,max=100"`
+	SalaryMin        *float64 `json:"salary_min,omitempty" binding:"omitempty,min=0"` // job's maximum salary must reach this
+	EmploymentTypes  []string `json:"employment_types,omitempty" binding:"omitempty,max=20,dive,required,max=50"`
+	JobTypes         []string `json:"job_types,omitempty" binding:"omitempty,max=20,dive,required,max=50"`
+	ExperienceLevels []string `json:"experience_levels,omitempty" binding:"omitempty,max=20,dive,required,max=50"`
+	Industries       []string `json:"industries,omitempty" binding:"omitempty,max=20,dive,required,max=100"`
+}
+
+// SavedSearch is a candidate's named job filter with optional email alerts
+type SavedSearch struct {
+	ID             int64           `json:"id"`
+	UserID         string          `json:"user_id"`
+	Name           string          `json:"name"`
+	Filter         JobSearchFilter `json:"filter"`
+	AlertsEnabled  bool            `json:"alerts_enabled"`
+	AlertFrequency string          `json:"alert_frequency"` // DAILY, WEEKLY
+	AlertedUntil   time.Time       `json:"alerted_until"`   // jobs created before this were already alerted
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// SavedSearchRequest creates or replaces a saved search
+type SavedSearchRequest struct {
+	Name           string          `json:"name" binding:"required,max=100"`
+	Filter         JobSearchFilter `json:"filter"`
+	AlertsEnabled  *bool           `json:"alerts_enabled"`                                         // defaults to true
+	AlertFrequency string          `json:"alert_frequency" binding:"omitempty,oneof=DAILY WEEKLY"` // defaults to DAILY
+}
+
+// SavedSearchAlertCandidate is a saved search due for an alert, with what is
+// needed to address the candidate
+type SavedSearchAlertCandidate struct {
+	SavedSearch
+	Email           string
+	FirstName       *string
+	PreferredLocale *string
+}
+
+// SavedSearchAlert records one alert delivery
+type SavedSearchAlert struct {
+	SavedSearchID int64
+	UserID        string
+	JobCount      int
+	Channel       string
+	Status        string // SENT, FAILED
+	ErrorMessage  *string
+}
+
+// SavedSearchRunResult summarizes one alert worker run
+type SavedSearchRunResult struct {
+	Searches   int       `json:"searches"`   // searches due for an alert
+	Matched    int       `json:"matched"`    // searches with new jobs
+	Candidates int       `json:"candidates"` // one message per candidate
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+type SavedSearchRepository interface {
+	Create(ctx context.Context, s *SavedSearch) error
+	ListByUser(ctx context.Context, userID string) ([]SavedSearch, error)
+	CountByUser(ctx context.Context, userID string) (int, error)
+	Update(ctx context.Context, s *SavedSearch) error
+	Delete(ctx context.Context, userID string, id int64) error
+
+	// ListDueAlerts returns alert-enabled searches of candidates who have not paused
+	// their profile, last alerted at least one frequency interval before now,
+	// ordered by user
+	ListDueAlerts(ctx context.Context, now time.Time, limit int) ([]SavedSearchAlertCandidate, error)
+	// FindNewJobs returns active jobs matching filter created in (since, until], newest
+	// first, with the total number of matches
+	FindNewJobs(ctx context.Context, filter JobSearchFilter, since, until time.Time, limit int) ([]JobCard, int, error)
+	// MarkAlerted moves the search's watermark forward to until
+	MarkAlerted(ctx context.Context, id int64, until time.Time) error
+	RecordAlert(ctx context.Context, a *SavedSearchAlert) error
+}
+
+type SavedSearchUsecase interface {
+	CreateSavedSearch(ctx context.Context, userID string, req SavedSearchRequest) (*SavedSearch, error)
+	ListMySavedSearches(ctx context.Context, userID string) ([]SavedSearch, error)
+	UpdateSavedSearch(ctx context.Context, userID string, id int64, req SavedSearchRequest) (*SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, userID string, id int64) error
+
+	// RunAlerts is called by the background worker
+	RunAlerts(ctx context.Context) (*SavedSearchRunResult, error)
+	// TriggerAlerts runs the alerts immediately (admin only)
+	TriggerAlerts(ctx context.Context) (*SavedSearchRunResult, error)
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultContactTimezone applies to candidates who did not choose one
+const DefaultContactTimezone = "Asia/Jakarta"
+
+// DefaultContactHours applies to candidates who did not set contact hours:
+// weekdays 09:00-17:00 in DefaultContactTimezone
+var DefaultContactHours = []ContactHoursWindow{
+	{Weekday: 1, Start: "09:00", End: "17:00"},
+	{Weekday: 2, Start: "09:00", End: "17:00"},
+	{Weekday: 3, Start: "09:00", End: "17:00"},
+	{Weekday: 4, Start: "09:00", End: "17:00"},
+	{Weekday: 5, Start: "09:00", End: "17:00"},
+}
+
+// Screening call limits
+const (
+	MaxContactHoursWindows      = 21
+	DefaultScreeningCallMinutes = 15
+	ScreeningCallMinLeadMinutes = 15 // a call must be booked at least this far ahead
+	ScreeningCallMaxDaysAhead   = 60
+	ScreeningCallSlotSearchDays = 14 // how far ahead open slots are listed
+)
+
+// Screening call status
+const (
+	ScreeningCallConfirmed = "CONFIRMED"
+	ScreeningCallCancelled = "CANCELLED"
+	ScreeningCallCompleted = "COMPLETED"
+	ScreeningCallNoAnswer  = "NO_ANSWER"
+)
+
+// ErrScreeningCallOverlap is returned when the candidate already has a call at that time
+var ErrScreeningCallOverlap = errors.New("candidate already has a call at that time")
+
+// ContactHoursWindow is a weekly time range in the candidate's time zone
+type ContactHoursWindow struct {
+	Weekday int    `json:"weekday" binding:"min=1,max=7"`           // ISO weekday, 1 = Monday
+	Start   string `json:"start" binding:"required,datetime=15:04"` // HH:MM
+	End     string `json:"end" binding:"required,datetime=15:04"`   // HH:MM, after start
+}
+
+// CandidateContactHours is when a candidate prefers to be called
+type CandidateContactHours struct {
+	UserID    string               `json:"user_id"`
+	Timezone  string               `json:"timezone"` // IANA name, e.g. Asia/Jakarta
+	Windows   []ContactHoursWindow `json:"windows"`
+	IsDefault bool                 `json:"is_default"` // the candidate has not set any; DefaultContactHours apply
+	UpdatedAt *time.Time           `json:"updated_at,omitempty"`
+}
+
+// ContactHoursRequest replaces a candidate's contact hours
+type ContactHoursRequest struct {
+	Timezone string               `json:"timezone" binding:"omitempty,max=64"` // defaults to Asia/Jakarta
+	Windows  []ContactHoursWindow `json:"windows" binding:"required,min=1,max=21,dive"`
+}
+
+// ScreeningCall is a call booked with a candidate
+type ScreeningCall struct {
+	ID              int64      `json:"id"`
+	CandidateUserID string     `json:"candidate_user_id"`
+	CompanyID       *int64     `json:"company_id,omitempty"` // unset when booked by an admin
+	CompanyName     *string    `json:"company_name,omitempty"`
+	ApplicationID   *int64     `json:"application_id,omitempty"`
+	JobTitle        *string    `json:"job_title,omitempty"`
+	BookedBy        *string    `json:"booked_by,omitempty"`
+	ScheduledAt     time.Time  `json:"scheduled_at"`
+	DurationMinutes int        `json:"duration_minutes"`
+	Notes           *string    `json:"notes,omitempty"`
+	Status          string     `json:"status"` // CONFIRMED, CANCELLED, COMPLETED, NO_ANSWER
+	CancelReason    *string    `json:"cancel_reason,omitempty"`
+	ReminderSentAt  *time.Time `json:"reminder_sent_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ScreeningCallSlot is an open range within the candidate's contact hours
+type ScreeningCallSlot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// ScreeningCallAvailability lists when a candidate can be called
+type ScreeningCallAvailability struct {
+	CandidateUserID string                `json:"candidate_user_id"`
+	ContactHours    CandidateContactHours `json:"contact_hours"`
+	Slots           []ScreeningCallSlot   `json:"slots"` // contact hours minus booked calls, next 14 days
+}
+
+// BookScreeningCallRequest books a call. Employers pass their applicant's
+// application_id; admins pass candidate_user_id (and optionally application_id).
+type BookScreeningCallRequest struct {
+	CandidateUserID string    `json:"candidate_user_id" binding:"omitempty,uuid"`
+	ApplicationID   *int64    `json:"application_id" binding:"omitempty,min=1"`
+	ScheduledAt     time.Time `json:"scheduled_at" binding:"required"`
+	DurationMinutes int       `json:"duration_minutes" binding:"omitempty,oneof=15 30 45 60"` // defaults to 15
+	Notes           *string   `json:"notes" binding:"omitempty,max=1000"`                     // shown to the candidate
+}
+
+// CancelScreeningCallRequest cancels a confirmed call
+type CancelScreeningCallRequest struct {
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+// ScreeningCallOutcomeRequest records how a call went
+type ScreeningCallOutcomeRequest struct {
+	Status string `json:"status" binding:"required,oneof=COMPLETED NO_ANSWER"`
+}
+
+// ScreeningCallFilter narrows the call list of a booker
+type ScreeningCallFilter struct {
+	CompanyID       int64 // employers only see their company's calls
+	CandidateUserID string
+	Status          string
+	Upcoming        bool // scheduled from now on, soonest first
+	Limit           int
+}
+
+// ScreeningCallTarget is an application a call can be booked for
+type ScreeningCallTarget struct {
+	ApplicationID   int64
+	CandidateUserID string
+	CompanyID       int64
+	CompanyName     string
+	JobTitle        string
+}
+
+// ScreeningCallReminderRunResult summarizes one reminder worker run
+type ScreeningCallReminderRunResult struct {
+	Due        int       `json:"due"`
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+type ScreeningCallRepository interface {
+	// GetContactHours returns ErrNotFound when the candidate has not set any
+	GetContactHours(ctx context.Context, userID string) (*CandidateContactHours, error)
+	UpsertContactHours(ctx context.Context, hours *CandidateContactHours) error
+
+	GetTarget(ctx context.Context, applicationID int64) (*ScreeningCallTarget, error)
+	IsCandidate(ctx context.Context, userID string) (bool, error)
+
+	// Create inserts a confirmed call and its activity entry, or returns
+	// ErrScreeningCallOverlap when it overlaps another confirmed call of the candidate
+	Create(ctx context.Context, call *ScreeningCall) error
+	Get(ctx context.Context, id int64) (*ScreeningCall, error)
+	List(ctx context.Context, filter ScreeningCallFilter) ([]ScreeningCall, error)
+	ListByCandidate(ctx context.Context, userID string) ([]ScreeningCall, error)
+	// ListConfirmedBetween returns the candidate's confirmed calls overlapping [from, to)
+	ListConfirmedBetween(ctx context.Context, userID string, from, to time.Time) ([]ScreeningCall, error)
+	// Cancel cancels a confirmed call and records the activity entry; ErrNotFound
+	// when it is no longer confirmed
+	Cancel(ctx context.Context, call *ScreeningCall) error
+	// SetOutcome moves a confirmed call to COMPLETED or NO_ANSWER; ErrNotFound
+	// when it is no longer confirmed
+	SetOutcome(ctx context.Context, call *ScreeningCall) error
+
+	// ListDueReminders returns confirmed calls starting between now and before
+	// that have no reminder yet
+	ListDueReminders(ctx context.Context, now, before time.Time, limit int) ([]ScreeningCall, error)
+	MarkReminded(ctx context.Context, id int64, at time.Time) error
+}
+
+type ScreeningCallUsecase interface {
+	// Candidate
+	GetMyContactHours(ctx context.Context, userID string) (*CandidateContactHours, error)
+	UpdateMyContactHours(ctx context.Context, userID string, req ContactHoursRequest) (*CandidateContactHours, error)
+	ListMyCalls(ctx context.Context, userID string) ([]ScreeningCall, error)
+	CancelMyCall(ctx context.Context, userID string, id int64, req CancelScreeningCallRequest) (*ScreeningCall, error)
+
+	// Admins and employers
+	GetAvailability(ctx context.Context, userID, candidateUserID string, applicationID int64) (*ScreeningCallAvailability, error)
+	BookCall(ctx context.Context, userID string, req BookScreeningCallRequest) (*ScreeningCall, error)
+	ListCalls(ctx context.Context, userID string, filter ScreeningCallFilter) ([]ScreeningCall, error)
+	CancelCall(ctx context.Context, userID string, id int64, req CancelScreeningCallRequest) (*ScreeningCall, error)
+	RecordOutcome(ctx context.Context, userID string, id int64, req ScreeningCallOutcomeRequest) (*ScreeningCall, error)
+
+	// RunReminders is called by the background worker
+	RunReminders(ctx context.Context) (*ScreeningCallReminderRunResult, error)
+}
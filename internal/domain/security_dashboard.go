@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"io"
 	"time"
 
 	"go-recruitment-backend/pkg/security"
@@ -9,25 +11,31 @@ import (
 
 // SecurityDashboardStats contains aggregated statistics for the dashboard
 type SecurityDashboardStats struct {
-	TotalEvents        int64            `json:"totalEvents"`
-	EventsBySeverity   map[string]int64 `json:"eventsBySeverity"`
-	EventsByType       map[string]int64 `json:"eventsByType"`
-	TopIPs             []IPSummary      `json:"topIps"`
-	FailedLogins24h    int64            `json:"failedLogins24h"`
-	BlockedAttempts24h int64            `json:"blockedAttempts24h"`
-	CriticalEvents24h  int64            `json:"criticalEvents24h"`
-	ActiveBreakGlass   int              `json:"activeBreakGlass"`
-	IntegrityStatus    string           `json:"integrityStatus"` // intact, degraded, compromised
-	LastAnchorDate     *time.Time       `json:"lastAnchorDate,omitempty"`
+	TotalEvents          int64            `json:"totalEvents"`
+	EventsBySeverity     map[string]int64 `json:"eventsBySeverity"`
+	EventsByType         map[string]int64 `json:"eventsByType"`
+	TopIPs               []IPSummary      `json:"topIps"`
+	FailedLogins24h      int64            `json:"failedLogins24h"`
+	BlockedAttempts24h   int64            `json:"blockedAttempts24h"`
+	CriticalEvents24h    int64            `json:"criticalEvents24h"`
+	ActiveBreakGlass     int              `json:"activeBreakGlass"`
+	IntegrityStatus      string           `json:"integrityStatus"`                // intact, degraded, compromised
+	IntegrityStatusLabel string           `json:"integrityStatusLabel,omitempty"` // IntegrityStatus in the operator's language
+	LastAnchorDate       *time.Time       `json:"lastAnchorDate,omitempty"`
+	// Dead-man switch for the anchoring pipeline; a missed heartbeat degrades IntegrityStatus
+	AnchorHeartbeat *AnchorHeartbeatStatus `json:"anchorHeartbeat,omitempty"`
+	// Anomaly detection alerts not yet resolved (open or acknowledged)
+	UnresolvedAlerts int64 `json:"unresolvedAlerts"`
 }
 
 // IPSummary represents aggregated stats for an IP address
 type IPSummary struct {
-	IP              string `json:"ip"`
-	EventCount      int64  `json:"eventCount"`
-	FailedLogins    int64  `json:"failedLogins"`
-	LastSeen        string `json:"lastSeen"`
-	HighestSeverity string `json:"highestSeverity"`
+	IP                   string `json:"ip"`
+	EventCount           int64  `json:"eventCount"`
+	FailedLogins         int64  `json:"failedLogins"`
+	LastSeen             string `json:"lastSeen"`
+	HighestSeverity      string `json:"highestSeverity"`
+	HighestSeverityLabel string `json:"highestSeverityLabel,omitempty"` // HighestSeverity in the operator's language
 }
 
 // SecurityEventFilter defines filters for querying security events
@@ -38,22 +46,26 @@ type SecurityEventFilter struct {
 	Severities []string   `json:"severities,omitempty"`
 	SearchIP   string     `json:"searchIp,omitempty"`
 	SearchUser string     `json:"searchUser,omitempty"`
-	Limit      int        `json:"limit"`
-	Offset     int        `json:"offset"`
+	// DeviceClasses filters on the client parsed from the user agent (security.DeviceClasses)
+	DeviceClasses []string `json:"deviceClasses,omitempty"`
+	Limit         int      `json:"limit"`
+	Offset        int      `json:"offset"`
 }
 
 // SecurityEventView represents a security event for display
 type SecurityEventView struct {
-	ID           int64                  `json:"id"`
-	Timestamp    time.Time              `json:"timestamp"`
-	EventType    string                 `json:"eventType"`
-	Severity     string                 `json:"severity"`
-	SubjectType  string                 `json:"subjectType,omitempty"`
-	SubjectValue string                 `json:"subjectValue,omitempty"`
-	IP           string                 `json:"ip,omitempty"`
-	UserAgent    string                 `json:"userAgent,omitempty"`
-	RequestID    string                 `json:"requestId,omitempty"`
-	Details      map[string]interface{} `json:"details,omitempty"`
+	ID            int64                  `json:"id"`
+	Timestamp     time.Time              `json:"timestamp"`
+	EventType     string                 `json:"eventType"`
+	Severity      string                 `json:"severity"`
+	SeverityLabel string                 `json:"severityLabel,omitempty"` // Severity in the operator's language
+	SubjectType   string                 `json:"subjectType,omitempty"`
+	SubjectValue  string                 `json:"subjectValue,omitempty"`
+	IP            string                 `json:"ip,omitempty"`
+	UserAgent     string                 `json:"userAgent,omitempty"`
+	Client        security.UserAgentInfo `json:"client"` // Browser, OS and device class parsed from UserAgent
+	RequestID     string                 `json:"requestId,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
 }
 
 // HeatmapData represents time-bucketed event counts for visualization
@@ -90,12 +102,16 @@ type ExportRequest struct {
 	RequestedAt     time.Time           `json:"requestedAt"`
 	Filter          SecurityEventFilter `json:"filter"`
 	Justification   string              `json:"justification"`
-	Status          string              `json:"status"` // pending, approved, rejected, expired
+	Status          string              `json:"status"`                // pending, approved, rejected, expired
+	StatusLabel     string              `json:"statusLabel,omitempty"` // Status in the operator's language
 	ApprovedBy      *string             `json:"approvedBy,omitempty"`
 	ApprovedAt      *time.Time          `json:"approvedAt,omitempty"`
 	RejectionReason *string             `json:"rejectionReason,omitempty"`
 	DownloadCount   int                 `json:"downloadCount"`
 	DownloadExpires *time.Time          `json:"downloadExpires,omitempty"`
+	// Set on approval; governs what downloads return (may be narrower than Filter)
+	ApprovedFilter *SecurityEventFilter `json:"approvedFilter,omitempty"`
+	RedactIPs      bool                 `json:"redactIps"`
 }
 
 // CreateExportRequest represents a request to create a data export
@@ -111,18 +127,126 @@ type ApproveExportRequest struct {
 	RejectionReason string `json:"rejectionReason,omitempty"`
 }
 
+// ApproveExportScope optionally narrows an export at approval time. Empty fields
+// keep the requested value; a field can only be narrowed, never widened.
+type ApproveExportScope struct {
+	StartTime  *time.Time `json:"startTime,omitempty"`
+	EndTime    *time.Time `json:"endTime,omitempty"`
+	EventTypes []string   `json:"eventTypes,omitempty"`
+	RedactIPs  bool       `json:"redactIps"`
+}
+
+// ErrInvalidExportScope is returned when an approval tries to widen the requested scope
+var ErrInvalidExportScope = errors.New("invalid export scope")
+
+// Export download formats
+const (
+	ExportFormatJSON    = "json"
+	ExportFormatCSV     = "csv"
+	ExportFormatParquet = "parquet"
+	ExportFormatXLSX    = "xlsx"
+	ExportFormatJSONL   = "jsonl"
+)
+
+// StreamExportFormats are the formats a direct download streams in chunks
+// instead of rendering in memory
+var StreamExportFormats = []string{ExportFormatCSV, ExportFormatXLSX, ExportFormatJSONL}
+
+// Export artifact statuses
+const (
+	ExportArtifactPending    = "pending"
+	ExportArtifactProcessing = "processing"
+	ExportArtifactReady      = "ready"
+	ExportArtifactFailed     = "failed"
+)
+
+// Export errors surfaced to the download handlers
+var (
+	ErrExportTooLarge        = errors.New("export too large for direct download")
+	ErrExportStorageDisabled = errors.New("export storage not configured")
+)
+
+// ExportFile is an export rendered in memory for direct download
+type ExportFile struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+	RowCount    int
+}
+
+// ExportStream is an approved export ready to be streamed to the client. Every
+// format ends with a watermark naming the export and the downloader.
+type ExportStream struct {
+	Filename    string
+	ContentType string
+	// Write reads the events in chunks and writes each chunk to w as it is read
+	Write func(w io.Writer) error
+}
+
+// ExportWatermark identifies who downloaded an export, so a leaked file can be traced
+type ExportWatermark struct {
+	ExportID     string    `json:"exportId"`
+	DownloadedBy string    `json:"downloadedBy"`
+	DownloadedAt time.Time `json:"downloadedAt"`
+	Rows         int       `json:"rows"`
+}
+
+// ExportArtifact is a worker-generated export file kept in object storage
+type ExportArtifact struct {
+	ExportID     string     `json:"exportId"`
+	Format       string     `json:"format"`
+	Status       string     `json:"status"`                // pending, processing, ready, failed
+	StatusLabel  string     `json:"statusLabel,omitempty"` // Status in the operator's language
+	ObjectKey    string     `json:"-"`
+	RowCount     int64      `json:"rowCount"`
+	SizeBytes    int64      `json:"sizeBytes"`
+	ErrorMessage *string    `json:"errorMessage,omitempty"`
+	DownloadURL  string     `json:"downloadUrl,omitempty"` // signed, only when ready
+	URLExpiresAt *time.Time `json:"urlExpiresAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"`
+}
+
+// SecurityExportStore stores export artifacts and issues signed download URLs
+type SecurityExportStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
 // BreakGlassRequest represents a request to activate break-glass
 type BreakGlassRequest struct {
 	Justification   string `json:"justification" binding:"required,min=50"`
 	DurationMinutes int    `json:"durationMinutes" binding:"required,oneof=15 30 60"`
 }
 
-// BreakGlassResponse represents an active break-glass session
+// BreakGlassResponse represents a break-glass session, or a request awaiting
+// a second SECURITY_ADMIN when dual approval is on
 type BreakGlassResponse struct {
-	SessionID     string    `json:"sessionId"`
-	ActivatedAt   time.Time `json:"activatedAt"`
-	ExpiresAt     time.Time `json:"expiresAt"`
-	RemainingMins int       `json:"remainingMinutes"`
+	SessionID     string     `json:"sessionId"`
+	Status        string     `json:"status"` // PENDING or APPROVED
+	ActivatedAt   *time.Time `json:"activatedAt,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	RemainingMins int        `json:"remainingMinutes"` // of the session, or of the approval window while pending
+	// Pending requests only
+	RequestedAt      *time.Time `json:"requestedAt,omitempty"`
+	ApprovalDeadline *time.Time `json:"approvalDeadline,omitempty"`
+	DurationMinutes  int        `json:"durationMinutes,omitempty"`
+}
+
+// PendingBreakGlassRequest is a break-glass request a co-approver can decide
+type PendingBreakGlassRequest struct {
+	SessionID         string    `json:"sessionId"`
+	RequesterID       string    `json:"requesterId"`
+	RequesterUsername string    `json:"requesterUsername"`
+	Justification     string    `json:"justification"`
+	DurationMinutes   int       `json:"durationMinutes"`
+	RequestedAt       time.Time `json:"requestedAt"`
+	ApprovalDeadline  time.Time `json:"approvalDeadline"`
+}
+
+// DenyBreakGlassRequest is the co-approver's reason for denying a request
+type DenyBreakGlassRequest struct {
+	Reason string `json:"reason" binding:"required,min=10"`
 }
 
 // IntegrityVerificationRequest represents a request to verify log integrity
@@ -131,6 +255,124 @@ type IntegrityVerificationRequest struct {
 	EndDate   string `json:"endDate" binding:"required"`   // YYYY-MM-DD
 }
 
+// Integrity verification trigger sources
+const (
+	IntegrityTriggerScheduled = "SCHEDULED"
+	IntegrityTriggerManual    = "MANUAL"
+)
+
+// IntegrityVerificationRun is one recorded integrity verification
+type IntegrityVerificationRun struct {
+	ID                int64     `json:"id"`
+	TriggerSource     string    `json:"triggerSource"` // SCHEDULED, MANUAL
+	TriggeredBy       *string   `json:"triggeredBy,omitempty"`
+	StartDate         time.Time `json:"startDate"`
+	EndDate           time.Time `json:"endDate"`
+	Status            string    `json:"status"`                // intact, degraded, compromised, error
+	StatusLabel       string    `json:"statusLabel,omitempty"` // Status in the operator's language
+	TotalEvents       int64     `json:"totalEvents"`
+	ChainBreaks       int64     `json:"chainBreaks"`
+	AnchorMismatches  int64     `json:"anchorMismatches"`
+	MissingAnchors    int64     `json:"missingAnchors"`
+	FirstBreakEventID *int64    `json:"firstBreakEventId,omitempty"`
+	FailedDates       []string  `json:"failedDates"`
+	ErrorMessage      *string   `json:"errorMessage,omitempty"`
+	AlertedAdmins     int       `json:"alertedAdmins"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+// SecurityAlertNotifier delivers integrity alerts to security operators
+type SecurityAlertNotifier interface {
+	SendSecurityAlert(ctx context.Context, to, subject, body string) error
+}
+
+// SecurityAlertWebhook posts integrity alerts to an external endpoint (pager, chat)
+type SecurityAlertWebhook interface {
+	PostSecurityAlert(ctx context.Context, event string, payload map[string]interface{}) error
+}
+
+// Anchoring heartbeat statuses
+const (
+	AnchorHeartbeatOK      = "ok"
+	AnchorHeartbeatStale   = "stale"   // last heartbeat older than the window
+	AnchorHeartbeatMissing = "missing" // no heartbeat recorded yet
+)
+
+// AnchorHeartbeat is recorded after each successful anchor run
+type AnchorHeartbeat struct {
+	ID         int64     `json:"id"`
+	AnchorDate time.Time `json:"anchorDate"`
+	EventCount int       `json:"eventCount"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// AnchorHeartbeatStatus tells whether the anchoring pipeline is still running
+type AnchorHeartbeatStatus struct {
+	Status          string     `json:"status"`                // ok, stale, missing
+	StatusLabel     string     `json:"statusLabel,omitempty"` // Status in the operator's language
+	LastHeartbeatAt *time.Time `json:"lastHeartbeatAt,omitempty"`
+	LastAnchorDate  *time.Time `json:"lastAnchorDate,omitempty"`
+	WindowHours     int        `json:"windowHours"`
+	OverdueMinutes  int64      `json:"overdueMinutes,omitempty"`
+	CheckedAt       time.Time  `json:"checkedAt"`
+	Alerted         bool       `json:"alerted,omitempty"` // This check sent the outage alert
+}
+
+// Security alert statuses
+const (
+	SecurityAlertOpen         = "OPEN"
+	SecurityAlertAcknowledged = "ACKNOWLEDGED"
+	SecurityAlertResolved     = "RESOLVED"
+)
+
+// ErrSecurityAlertState is returned when an alert cannot move to the requested status
+var ErrSecurityAlertState = errors.New("security alert status does not allow this")
+
+// SecurityAlert is an incident raised by anomaly detection (security.AnomalyRules)
+type SecurityAlert struct {
+	ID             int64                  `json:"id"`
+	Rule           string                 `json:"rule"`
+	DedupKey       string                 `json:"dedupKey"` // IP, hashed user or event ID, depending on the rule
+	Severity       string                 `json:"severity"`
+	SeverityLabel  string                 `json:"severityLabel,omitempty"` // Severity in the operator's language
+	Title          string                 `json:"title"`
+	EventCount     int                    `json:"eventCount"`
+	FirstSeenAt    time.Time              `json:"firstSeenAt"`
+	LastSeenAt     time.Time              `json:"lastSeenAt"`
+	Details        map[string]interface{} `json:"details,omitempty"`
+	Status         string                 `json:"status"`                // OPEN, ACKNOWLEDGED, RESOLVED
+	StatusLabel    string                 `json:"statusLabel,omitempty"` // Status in the operator's language
+	NotifiedAdmins int                    `json:"notifiedAdmins"`
+	AcknowledgedBy *string                `json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt *time.Time             `json:"acknowledgedAt,omitempty"`
+	ResolvedBy     *string                `json:"resolvedBy,omitempty"`
+	ResolvedAt     *time.Time             `json:"resolvedAt,omitempty"`
+	Resolution     *string                `json:"resolution,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+	UpdatedAt      time.Time              `json:"updatedAt"`
+}
+
+// SecurityAlertFilter narrows the alert list
+type SecurityAlertFilter struct {
+	Statuses []string
+	Rule     string
+	Limit    int
+	Offset   int
+}
+
+// ResolveSecurityAlertRequest closes an alert with what was found
+type ResolveSecurityAlertRequest struct {
+	Resolution string `json:"resolution" binding:"required,min=10,max=2000"`
+}
+
+// AnomalyDetectionRun summarises one pass of the detection rules
+type AnomalyDetectionRun struct {
+	EventsScanned int       `json:"eventsScanned"`
+	Anomalies     int       `json:"anomalies"`
+	NewAlerts     int       `json:"newAlerts"`
+	CheckedAt     time.Time `json:"checkedAt"`
+}
+
 // SecurityDashboardRepository defines data access for the security dashboard
 type SecurityDashboardRepository interface {
 	// Stats
@@ -138,6 +380,8 @@ type SecurityDashboardRepository interface {
 
 	// Events
 	ListEvents(ctx context.Context, filter SecurityEventFilter) ([]SecurityEventView, int64, error)
+	// ListEventsBefore pages through a filter newest first by ID (beforeID 0 starts at the newest)
+	ListEventsBefore(ctx context.Context, filter SecurityEventFilter, beforeID int64, limit int) ([]SecurityEventView, error)
 	GetAuthFailureHeatmap(ctx context.Context, startTime, endTime time.Time, bucketSize string) (*HeatmapData, error)
 	GetPrivilegedActionTimeline(ctx context.Context, limit, offset int) ([]PrivilegedActionView, int64, error)
 
@@ -145,13 +389,42 @@ type SecurityDashboardRepository interface {
 	CreateExportRequest(ctx context.Context, userID string, req CreateExportRequest) (*ExportRequest, error)
 	GetExportRequest(ctx context.Context, exportID string) (*ExportRequest, error)
 	ListExportRequests(ctx context.Context, status string, limit, offset int) ([]ExportRequest, int64, error)
-	ApproveExportRequest(ctx context.Context, exportID, approverID string) error
+	ApproveExportRequest(ctx context.Context, exportID, approverID string, approved SecurityEventFilter, redactIPs bool) error
 	RejectExportRequest(ctx context.Context, exportID, approverID, reason string) error
 	IncrementDownloadCount(ctx context.Context, exportID string) error
 
+	// Export artifacts
+	CreateExportArtifact(ctx context.Context, exportID, format string) (*ExportArtifact, bool, error)
+	GetExportArtifact(ctx context.Context, exportID, format string) (*ExportArtifact, error)
+	UpdateExportArtifact(ctx context.Context, artifact *ExportArtifact) error
+
 	// Integrity
 	GetLastAnchor(ctx context.Context) (*security.HashAnchor, error)
 	ListAnchors(ctx context.Context, limit, offset int) ([]security.HashAnchor, int64, error)
+	// RecordAnchorVerification marks anchors in the range verified, or failed for failedDates
+	RecordAnchorVerification(ctx context.Context, startDate, endDate time.Time, failedDates []string) error
+	CreateIntegrityRun(ctx context.Context, run *IntegrityVerificationRun) error
+	ListIntegrityRuns(ctx context.Context, limit, offset int) ([]IntegrityVerificationRun, int64, error)
+	ListSecurityAdminEmails(ctx context.Context) ([]string, error)
+
+	// Anchoring heartbeat
+	GetLastAnchorHeartbeat(ctx context.Context) (*AnchorHeartbeat, error) // ErrNotFound when none yet
+	// RecordAnchorHeartbeatAlert claims the alert for an outage; false if already alerted
+	RecordAnchorHeartbeatAlert(ctx context.Context, lastHeartbeatID int64) (bool, error)
+
+	// Anomaly detection alerts
+	ListDetectionEvents(ctx context.Context, types []security.EventType, since time.Time) ([]security.DetectionEvent, error)
+	// UpsertSecurityAlert folds the alert into the unresolved alert of the same
+	// rule and key, or creates it. It reports whether a new alert was created;
+	// nothing is written when a resolved alert already covers LastSeenAt.
+	UpsertSecurityAlert(ctx context.Context, alert *SecurityAlert) (bool, error)
+	SetSecurityAlertNotified(ctx context.Context, alertID int64, notified int) error
+	GetSecurityAlert(ctx context.Context, alertID int64) (*SecurityAlert, error)
+	ListSecurityAlerts(ctx context.Context, filter SecurityAlertFilter) ([]SecurityAlert, int64, error)
+	// AcknowledgeSecurityAlert moves an OPEN alert to ACKNOWLEDGED (ErrSecurityAlertState otherwise)
+	AcknowledgeSecurityAlert(ctx context.Context, alertID int64, userID string) error
+	// ResolveSecurityAlert closes an OPEN or ACKNOWLEDGED alert (ErrSecurityAlertState otherwise)
+	ResolveSecurityAlert(ctx context.Context, alertID int64, userID, resolution string) error
 }
 
 // SecurityDashboardUsecase defines business logic for the security dashboard
@@ -166,16 +439,39 @@ type SecurityDashboardUsecase interface {
 
 	// Export workflow
 	RequestExport(ctx context.Context, userID string, req CreateExportRequest) (*ExportRequest, error)
-	ApproveExport(ctx context.Context, exportID, approverID string) error
+	ApproveExport(ctx context.Context, exportID, approverID string, scope ApproveExportScope) error
 	RejectExport(ctx context.Context, exportID, approverID, reason string) error
 	GetExportData(ctx context.Context, exportID, userID string) ([]SecurityEventView, error)
+	GetExportFile(ctx context.Context, exportID, userID, format string) (*ExportFile, error)
+	StreamExport(ctx context.Context, exportID, userID, format string) (*ExportStream, error)
+	RequestExportArtifact(ctx context.Context, exportID, userID, format string) (*ExportArtifact, error)
+	GetExportArtifact(ctx context.Context, exportID, userID, format string) (*ExportArtifact, error)
 
 	// Break-glass
 	ActivateBreakGlass(ctx context.Context, userID string, req BreakGlassRequest) (*BreakGlassResponse, error)
 	GetActiveBreakGlass(ctx context.Context, userID string) (*BreakGlassResponse, error)
 	RevokeBreakGlass(ctx context.Context, sessionID, reason string) error
+	// Dual approval: the caller's own pending request, and other admins' requests to decide
+	GetPendingBreakGlass(ctx context.Context, userID string) (*BreakGlassResponse, error)
+	ListPendingBreakGlass(ctx context.Context, approverID string) ([]PendingBreakGlassRequest, error)
+	ApproveBreakGlass(ctx context.Context, sessionID, approverID string) (*BreakGlassResponse, error)
+	DenyBreakGlass(ctx context.Context, sessionID, approverID, reason string) error
 
 	// Integrity
-	VerifyIntegrity(ctx context.Context, startDate, endDate time.Time) (*security.IntegrityReport, error)
+	VerifyIntegrity(ctx context.Context, userID string, startDate, endDate time.Time) (*security.IntegrityReport, error)
+	RunScheduledVerification(ctx context.Context, days int) (*IntegrityVerificationRun, error)
+	GetIntegrityHistory(ctx context.Context, page, pageSize int) ([]IntegrityVerificationRun, int64, error)
 	GetIntegrityStatus(ctx context.Context) (string, *time.Time, error)
+
+	// Anchoring heartbeat
+	GetAnchorHeartbeat(ctx context.Context) (*AnchorHeartbeatStatus, error)
+	// CheckAnchorHeartbeat alerts security admins once per outage when the heartbeat is overdue
+	CheckAnchorHeartbeat(ctx context.Context) (*AnchorHeartbeatStatus, error)
+
+	// Anomaly detection
+	// RunAnomalyDetection evaluates the rules and alerts security admins of new alerts
+	RunAnomalyDetection(ctx context.Context) (*AnomalyDetectionRun, error)
+	ListAlerts(ctx context.Context, filter SecurityAlertFilter) ([]SecurityAlert, int64, error)
+	AcknowledgeAlert(ctx context.Context, alertID int64, userID string) (*SecurityAlert, error)
+	ResolveAlert(ctx context.Context, alertID int64, userID, resolution string) (*SecurityAlert, error)
 }
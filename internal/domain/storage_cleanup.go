@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UploadBuckets are the storage buckets the upload endpoint writes to. Only
+// these are swept for orphans.
+var UploadBuckets = []string{
+	"Profile_Picture",
+	"JLPT",
+	"CV",
+	"Guardian_Consent",
+	"Candidate_Documents",
+	"Candidate_Portfolio",
+	"Company_Logo",
+	"Company_Gallery",
+	"company_gallery", // Supabase bucket names as shown
+	"COMPANY_GALLERY", // Uppercase version
+	"profile_company", // Supabase bucket names as shown
+}
+
+// PrivateUploadBuckets hold documents that are only readable through signed
+// URLs. Their stored URLs identify the file but do not serve it.
+var PrivateUploadBuckets = []string{
+	"CV",
+	"JLPT",
+	"Candidate_Documents",
+	"Guardian_Consent",
+}
+
+// Why a stored object is being deleted
+const (
+	StorageDeletionReplaced = "REPLACED" // the user uploaded a replacement
+	StorageDeletionOrphan   = "ORPHAN"   // found by the sweep with no live file record
+)
+
+// Storage deletion statuses
+const (
+	StorageDeletionPending = "PENDING"
+	StorageDeletionDeleted = "DELETED"
+	StorageDeletionFailed  = "FAILED" // gave up after the last attempt; an admin can retry
+)
+
+// Orphan sweep statuses
+const (
+	StorageSweepRunning   = "RUNNING"
+	StorageSweepCompleted = "COMPLETED"
+	StorageSweepFailed    = "FAILED"
+)
+
+// Orphan sweep triggers
+const (
+	StorageSweepScheduled = "SCHEDULED"
+	StorageSweepManual    = "MANUAL"
+)
+
+// StorageDeletion is a queued removal of one stored object
+type StorageDeletion struct {
+	ID             int64      `json:"id"`
+	Bucket         string     `json:"bucket"`
+	ObjectPath     string     `json:"object_path"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	ReclaimedBytes *int64     `json:"reclaimed_bytes,omitempty"` // set once deleted
+	SweepID        *int64     `json:"sweep_id,omitempty"`        // the sweep that found an orphan
+	CreatedAt      time.Time  `json:"created_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+// StorageOrphanSweep is one comparison of the bucket listings against uploaded_files
+type StorageOrphanSweep struct {
+	ID             int64      `json:"id"`
+	TriggerSource  string     `json:"trigger_source"` // SCHEDULED, MANUAL
+	Status         string     `json:"status"`
+	BucketsScanned int        `json:"buckets_scanned"`
+	BucketErrors   []string   `json:"bucket_errors"` // buckets that could not be listed
+	ObjectsScanned int        `json:"objects_scanned"`
+	OrphansFound   int        `json:"orphans_found"`
+	OrphanBytes    int64      `json:"orphan_bytes"` // size of the orphans queued for deletion
+	ErrorMessage   *string    `json:"error_message,omitempty"`
+	StartedAt      time.Time  `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at,omitempty"`
+}
+
+// StorageCleanupSummary is the admin overview of storage cleanup
+type StorageCleanupSummary struct {
+	ReclaimedBytes       int64               `json:"reclaimed_bytes"` // all time
+	ReclaimedBytes30Days int64               `json:"reclaimed_bytes_30_days"`
+	DeletedObjects       int64               `json:"deleted_objects"`
+	DeletedObjects30Days int64               `json:"deleted_objects_30_days"`
+	PendingDeletions     int64               `json:"pending_deletions"`
+	FailedDeletions      int64               `json:"failed_deletions"`
+	LastSweep            *StorageOrphanSweep `json:"last_sweep"`
+	GeneratedAt          time.Time           `json:"generated_at"`
+}
+
+// StorageDeletionRunResult summarizes one deletion worker run
+type StorageDeletionRunResult struct {
+	Due            int   `json:"due"`
+	Deleted        int   `json:"deleted"`
+	Retrying       int   `json:"retrying"`
+	Failed         int   `json:"failed"`
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+}
+
+type StorageCleanupRepository interface {
+	// EnqueueDeletion queues the object unless it is already queued; it reports
+	// whether a new deletion was queued
+	EnqueueDeletion(ctx context.Context, d *StorageDeletion) (bool, error)
+	ClaimDueDeletions(ctx context.Context, now, leaseUntil time.Time, limit int) ([]StorageDeletion, error)
+	RecordDeletionAttempt(ctx context.Context, d *StorageDeletion) error
+	ListDeletions(ctx context.Context, status string, limit, offset int) ([]StorageDeletion, int64, error)
+	GetDeletion(ctx context.Context, id int64) (*StorageDeletion, error)
+	RetryDeletion(ctx context.Context, id int64, at time.Time) error
+
+	// FindFileByURL returns the live uploaded_files record stored at publicURL, or ErrNotFound
+	FindFileByURL(ctx context.Context, publicURL string) (*UploadedFile, error)
+	// SupersedeFile marks the record as replaced so the sweep no longer protects it
+	SupersedeFile(ctx context.Context, id string, at time.Time) error
+	// ListLiveURLs returns the public URLs of the bucket's files that are not superseded
+	ListLiveURLs(ctx context.Context, bucket string) ([]string, error)
+	// TrackingStartedAt is when the first upload was recorded; nil when there is none.
+	// Objects stored earlier predate the metadata table and are never swept.
+	TrackingStartedAt(ctx context.Context) (*time.Time, error)
+
+	CreateSweep(ctx context.Context, s *StorageOrphanSweep) error
+	FinishSweep(ctx context.Context, s *StorageOrphanSweep) error
+	ListSweeps(ctx context.Context, limit int) ([]StorageOrphanSweep, error)
+	GetSummary(ctx context.Context, since time.Time) (*StorageCleanupSummary, error)
+}
+
+type StorageCleanupUsecase interface {
+	// QueueReplacedFile queues the object at oldURL for deletion after the user
+	// uploaded its replacement. Files recorded for another user are left alone.
+	QueueReplacedFile(ctx context.Context, userID, oldURL string) error
+	// RunDeletions processes due deletions; called by the background worker
+	RunDeletions(ctx context.Context) (*StorageDeletionRunResult, error)
+	// RunSweep compares the upload buckets against uploaded_files and queues
+	// orphaned objects for deletion; called by the background worker
+	RunSweep(ctx context.Context, triggerSource string) (*StorageOrphanSweep, error)
+
+	// Admin
+	GetSummary(ctx context.Context) (*StorageCleanupSummary, error)
+	ListDeletions(ctx context.Context, status string, page, pageSize int) (*PaginatedResult[StorageDeletion], error)
+	RetryDeletion(ctx context.Context, id int64) (*StorageDeletion, error)
+	ListSweeps(ctx context.Context) ([]StorageOrphanSweep, error)
+	TriggerSweep(ctx context.Context) (*StorageOrphanSweep, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Supabase auth events consumed by POST /v1/webhooks/supabase
+const (
+	SupabaseEventUserConfirmed    = "user.confirmed"    // the user confirmed their email address
+	SupabaseEventUserDeleted      = "user.deleted"      // the user was deleted in Supabase Auth
+	SupabaseEventPasswordRecovery = "password.recovery" // the user asked for a password reset email
+)
+
+// SupabaseWebhookTolerance is how far a webhook's signed timestamp may be from
+// now; older deliveries are rejected as replays
+const SupabaseWebhookTolerance = 5 * time.Minute
+
+// What a Supabase webhook did
+const (
+	SupabaseWebhookSynced    = "SYNCED"    // user row created or linked, email confirmation recorded
+	SupabaseWebhookDeleted   = "DELETED"   // user row removed
+	SupabaseWebhookLogged    = "LOGGED"    // security event only
+	SupabaseWebhookIgnored   = "IGNORED"   // unknown event type, or a user that was never synced
+	SupabaseWebhookDuplicate = "DUPLICATE" // webhook id already processed
+)
+
+// SupabaseWebhookSignature holds the Standard Webhooks headers Supabase signs
+// deliveries with
+type SupabaseWebhookSignature struct {
+	ID        string // webhook-id
+	Timestamp string // webhook-timestamp, Unix seconds
+	Signature string // webhook-signature, space-separated "v1,<base64 HMAC-SHA256>"
+}
+
+// SupabaseAuthEvent is the body of an auth webhook
+type SupabaseAuthEvent struct {
+	Type string           `json:"type"`
+	User SupabaseAuthUser `json:"user"`
+}
+
+// SupabaseAuthUser is the Supabase Auth user an event is about
+type SupabaseAuthUser struct {
+	ID               string     `json:"id"`
+	Email            string     `json:"email"`
+	EmailConfirmedAt *time.Time `json:"email_confirmed_at"`
+}
+
+// SupabaseWebhookResult reports how a delivery was handled
+type SupabaseWebhookResult struct {
+	Type   string `json:"type"`
+	Action string `json:"action"`
+}
+
+type SupabaseWebhookRepository interface {
+	// Claim records a webhook id; false when it was already recorded
+	Claim(ctx context.Context, webhookID, eventType, userID string) (bool, error)
+	// Release forgets a claimed id so a failed delivery can be retried
+	Release(ctx context.Context, webhookID string) error
+}
+
+type SupabaseWebhookUsecase interface {
+	HandleAuthEvent(ctx context.Context, sig SupabaseWebhookSignature, body []byte) (*SupabaseWebhookResult, error)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// File processing status constants
+const (
+	FileStatusUploaded   = "uploaded"   // Received and validated, not yet processed
+	FileStatusProcessing = "processing" // Compression / storage upload in progress
+	FileStatusReady      = "ready"      // Stored; PublicURL is usable
+	FileStatusFailed     = "failed"     // Processing failed; see ErrorMessage
+)
+
+// UploadedFile tracks an upload through server-side processing
+type UploadedFile struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"user_id"`
+	Bucket           string     `json:"bucket"`
+	OriginalFilename string     `json:"original_filename"`
+	ContentType      string     `json:"content_type"`
+	SizeBytes        int64      `json:"size_bytes"`
+	Status           string     `json:"status"`
+	PublicURL        *string    `json:"url,omitempty"`
+	ErrorMessage     *string    `json:"error,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+}
+
+// IsTerminal reports whether processing has finished (successfully or not)
+func (f *UploadedFile) IsTerminal() bool {
+	return f.Status == FileStatusReady || f.Status == FileStatusFailed
+}
+
+// SignedFileURL is a download URL for a stored file. ExpiresAt is nil for files
+// in public buckets, whose URL does not expire.
+type SignedFileURL struct {
+	URL       string     `json:"url"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type UploadedFileRepository interface {
+	Create(ctx context.Context, f *UploadedFile) error
+	GetByID(ctx context.Context, id string) (*UploadedFile, error)
+	UpdateStatus(ctx context.Context, id, status string, publicURL, errorMessage *string) (*UploadedFile, error)
+	// FindOwnerByURL returns the user a stored file belongs to: its uploader, or for
+	// files uploaded before uploads were recorded, the profile or application using it
+	FindOwnerByURL(ctx context.Context, fileURL string) (string, error)
+	// CompanyCanAccessUser reports whether the company may see the user's documents:
+	// the user applied to it, their privacy settings make them visible to it, or it
+	// unlocked their contact
+	CompanyCanAccessUser(ctx context.Context, companyID int64, userID string) (bool, error)
+}
+
+// FileProcessingNotifier is told when a file reaches a terminal status
+type FileProcessingNotifier interface {
+	FileProcessed(ctx context.Context, file *UploadedFile)
+}
+
+type UploadedFileUsecase interface {
+	// StoreObject writes the file to storage and returns the URL to store for it
+	StoreObject(ctx context.Context, bucket, path, contentType string, data []byte) (string, error)
+	Register(ctx context.Context, f *UploadedFile) error
+	MarkProcessing(ctx context.Context, id string) error
+	MarkReady(ctx context.Context, id, publicURL string) error
+	MarkFailed(ctx context.Context, id, reason string) error
+	GetStatus(ctx context.Context, id string) (*UploadedFile, error)
+	// SignURL returns a download URL for a stored file the caller may read
+	SignURL(ctx context.Context, fileURL string) (*SignedFileURL, error)
+}
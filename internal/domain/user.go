@@ -5,13 +5,40 @@ import (
 	"time"
 )
 
+// MaxProfilePauseDays caps how long a candidate can pause their profile in one go
+const MaxProfilePauseDays = 365
+
 type User struct {
-	ID                  string    `json:"id"` // Supabase UUID
-	Email               string    `json:"email"`
-	Role                string    `json:"role"`
-	OnboardingCompleted *bool     `json:"onboarding_completed,omitempty"` // Computed field, not in users table
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	ID                  string     `json:"id"` // Supabase UUID
+	Email               string     `json:"email"`
+	Role                string     `json:"role"`
+	OnboardingCompleted *bool      `json:"onboarding_completed,omitempty"` // Computed field, not in users table
+	PausedAt            *time.Time `json:"paused_at,omitempty"`
+	PausedUntil         *time.Time `json:"paused_until,omitempty"`
+	PauseReason         *string    `json:"pause_reason,omitempty"`
+	ProfilePaused       bool       `json:"profile_paused"`             // Computed field, true while PausedUntil is in the future
+	PreferredLocale     *string    `json:"preferred_locale,omitempty"` // id, en, ja; nil follows Accept-Language
+	EmailConfirmedAt    *time.Time `json:"email_confirmed_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// IsPaused reports whether the user's profile pause is still in effect at the given time.
+// Pauses expire on their own, so an elapsed PausedUntil means the profile is active again.
+func (u *User) IsPaused(now time.Time) bool {
+	return u.PausedUntil != nil && u.PausedUntil.After(now)
+}
+
+// PauseProfileRequest is the payload for pausing a candidate profile
+type PauseProfileRequest struct {
+	Until  time.Time `json:"until" binding:"required"`
+	Reason string    `json:"reason" binding:"omitempty,max=500"`
+}
+
+// UpdateLocaleRequest is the payload for setting the preferred language.
+// An empty locale clears the preference.
+type UpdateLocaleRequest struct {
+	Locale string `json:"locale" binding:"omitempty,oneof=id en ja"`
 }
 
 type UserRepository interface {
@@ -20,6 +47,10 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
 	UpdateByEmail(ctx context.Context, email string, user *User) error // Update user by email, including ID change
+	SetPause(ctx context.Context, userID string, until *time.Time, reason *string) error
+	SetPreferredLocale(ctx context.Context, userID string, locale *string) error
+	SetEmailConfirmed(ctx context.Context, userID string, at time.Time) error
+	Delete(ctx context.Context, userID string) error
 }
 
 type AuthUsecase interface {
@@ -28,4 +59,14 @@ type AuthUsecase interface {
 	AssignRole(ctx context.Context, userID string, role string) error
 	GetCurrentUser(ctx context.Context, id string) (*User, error)
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
+
+	// Profile pause (candidate self-service)
+	PauseProfile(ctx context.Context, userID string, req *PauseProfileRequest) (*User, error)
+	ResumeProfile(ctx context.Context, userID string) (*User, error)
+
+	// Language preference
+	UpdatePreferredLocale(ctx context.Context, userID string, locale string) (*User, error)
+
+	// NotifyPasswordChanged tells the user by email; the change itself happens in Supabase Auth
+	NotifyPasswordChanged(ctx context.Context, userID string)
 }
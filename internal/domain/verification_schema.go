@@ -0,0 +1,52 @@
+package domain
+
+import "context"
+
+// Field requirements in the verification schema
+const (
+	FieldRequired = "REQUIRED" // must be filled before a profile is submitted or approved
+	FieldOptional = "OPTIONAL"
+	FieldHidden   = "HIDDEN" // not collected; a candidate's stored value is dropped on the next update
+)
+
+// ProfileErrRequiredFieldsMissing is returned when an admin approves a profile with empty required fields
+const ProfileErrRequiredFieldsMissing = "REQUIRED_FIELDS_MISSING"
+
+// VerificationFieldRule is the requirement of one profile field
+type VerificationFieldRule struct {
+	Field       string `json:"field"` // JSON name on the verification profile (candidates) or company profile (employers)
+	Requirement string `json:"requirement"`
+	Default     string `json:"default"` // built-in requirement when no admin override exists
+}
+
+// VerificationSchema lists every configurable field of a role, in form order
+type VerificationSchema struct {
+	Role   string                  `json:"role"`
+	Fields []VerificationFieldRule `json:"fields"`
+}
+
+// VerificationFieldRuleInput sets one field's requirement
+type VerificationFieldRuleInput struct {
+	Field       string `json:"field" binding:"required"`
+	Requirement string `json:"requirement" binding:"required,oneof=REQUIRED OPTIONAL HIDDEN"`
+}
+
+// UpdateVerificationSchemaRequest overrides the listed fields; others are unchanged
+type UpdateVerificationSchemaRequest struct {
+	Fields []VerificationFieldRuleInput `json:"fields" binding:"required,min=1,max=100,dive"`
+}
+
+// VerificationFieldOverride is a stored admin override
+type VerificationFieldOverride struct {
+	Role        string
+	Field       string
+	Requirement string
+	UpdatedBy   *string
+}
+
+type VerificationSchemaRepository interface {
+	// ListOverrides returns field -> requirement for the role
+	ListOverrides(ctx context.Context, role string) (map[string]string, error)
+	// UpsertOverrides stores all overrides in one transaction
+	UpsertOverrides(ctx context.Context, overrides []VerificationFieldOverride) error
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Warehouse export file formats
+const (
+	WarehouseFormatParquet = "parquet"
+	WarehouseFormatCSV     = "csv"
+)
+
+// Warehouse export outcomes
+const (
+	WarehouseExportCompleted = "COMPLETED"
+	WarehouseExportFailed    = "FAILED"
+)
+
+// Warehouse column types (mapped to Parquet logical types)
+const (
+	WarehouseInt64     = "int64"
+	WarehouseTimestamp = "timestamp"
+	WarehouseString    = "string"
+)
+
+// WarehouseColumn is one exported column. Pseudonymized columns hold a keyed hash
+// of the source value, stable across tables and runs so datasets can be joined.
+type WarehouseColumn struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Nullable     bool   `json:"nullable"`
+	Pseudonymize bool   `json:"pseudonymized,omitempty"`
+}
+
+// WarehouseTable describes one exported dataset. Bump SchemaVersion whenever
+// Columns change; the next run re-exports the table under the new version.
+type WarehouseTable struct {
+	Name            string            `json:"table"`
+	SchemaVersion   int               `json:"schema_version"`
+	WatermarkColumn string            `json:"watermark_column"` // must also be in Columns, as must "id"
+	Columns         []WarehouseColumn `json:"columns"`
+}
+
+// WarehouseTables lists every exported dataset. Free text, names, contact
+// details, file URLs and network identifiers are never exported.
+var WarehouseTables = []WarehouseTable{
+	{
+		Name: "jobs", SchemaVersion: 1, WatermarkColumn: "updated_at",
+		Columns: []WarehouseColumn{
+			{Name: "id", Type: WarehouseInt64},
+			{Name: "company_id", Type: WarehouseInt64},
+			{Name: "title", Type: WarehouseString},
+			{Name: "location", Type: WarehouseString},
+			{Name: "employment_type", Type: WarehouseString, Nullable: true},
+			{Name: "job_type", Type: WarehouseString, Nullable: true},
+			{Name: "experience_level", Type: WarehouseString, Nullable: true},
+			{Name: "salary_min", Type: WarehouseInt64, Nullable: true},
+			{Name: "salary_max", Type: WarehouseInt64, Nullable: true},
+			{Name: "company_status", Type: WarehouseString},
+			{Name: "created_at", Type: WarehouseTimestamp},
+			{Name: "updated_at", Type: WarehouseTimestamp},
+		},
+	},
+	{
+		Name: "applications", SchemaVersion: 1, WatermarkColumn: "updated_at",
+		Columns: []WarehouseColumn{
+			{Name: "id", Type: WarehouseInt64},
+			{Name: "job_id", Type: WarehouseInt64},
+			{Name: "candidate_id", Type: WarehouseString, Pseudonymize: true},
+			{Name: "status", Type: WarehouseString},
+			{Name: "stage", Type: WarehouseString},
+			{Name: "auto_rejected", Type: WarehouseString}, // "true"/"false"
+			{Name: "stage_changed_at", Type: WarehouseTimestamp},
+			{Name: "created_at", Type: WarehouseTimestamp},
+			{Name: "updated_at", Type: WarehouseTimestamp},
+		},
+	},
+	{
+		Name: "verifications", SchemaVersion: 1, WatermarkColumn: "updated_at",
+		Columns: []WarehouseColumn{
+			{Name: "id", Type: WarehouseInt64},
+			{Name: "user_id", Type: WarehouseString, Pseudonymize: true},
+			{Name: "role", Type: WarehouseString},
+			{Name: "status", Type: WarehouseString},
+			{Name: "gender", Type: WarehouseString, Nullable: true},
+			{Name: "birth_year", Type: WarehouseInt64, Nullable: true},
+			{Name: "domicile_city", Type: WarehouseString, Nullable: true},
+			{Name: "japanese_level", Type: WarehouseString, Nullable: true},
+			{Name: "lpk_id", Type: WarehouseInt64, Nullable: true},
+			{Name: "submitted_at", Type: WarehouseTimestamp},
+			{Name: "verified_at", Type: WarehouseTimestamp, Nullable: true},
+			{Name: "created_at", Type: WarehouseTimestamp},
+			{Name: "updated_at", Type: WarehouseTimestamp},
+		},
+	},
+	{
+		Name: "application_stage_events", SchemaVersion: 1, WatermarkColumn: "changed_at",
+		Columns: []WarehouseColumn{
+			{Name: "id", Type: WarehouseInt64},
+			{Name: "application_id", Type: WarehouseInt64},
+			{Name: "from_stage", Type: WarehouseString, Nullable: true},
+			{Name: "to_stage", Type: WarehouseString},
+			{Name: "changed_at", Type: WarehouseTimestamp},
+		},
+	},
+	{
+		Name: "security_events", SchemaVersion: 1, WatermarkColumn: "created_at",
+		Columns: []WarehouseColumn{
+			{Name: "id", Type: WarehouseInt64},
+			{Name: "event_type", Type: WarehouseString},
+			{Name: "level", Type: WarehouseString, Nullable: true},
+			{Name: "service", Type: WarehouseString, Nullable: true},
+			{Name: "environment", Type: WarehouseString, Nullable: true},
+			{Name: "created_at", Type: WarehouseTimestamp},
+		},
+	},
+}
+
+// WarehouseCursor is a position in a table's (watermark, id) order
+type WarehouseCursor struct {
+	At time.Time
+	ID int64
+}
+
+// WarehouseExportState is the export bookkeeping of one table
+type WarehouseExportState struct {
+	Table            string     `json:"table"`
+	SchemaVersion    int        `json:"schema_version"`
+	Watermark        *time.Time `json:"watermark,omitempty"` // rows changed up to here have been exported
+	LastSuccessAt    *time.Time `json:"last_success_at,omitempty"`
+	LastRowCount     int64      `json:"last_row_count"`
+	LastFileCount    int        `json:"last_file_count"`
+	LastObjectPrefix *string    `json:"last_object_prefix,omitempty"`
+	LastAttemptAt    *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatus       *string    `json:"last_status,omitempty"` // COMPLETED, FAILED
+	LastError        *string    `json:"last_error,omitempty"`
+}
+
+// WarehouseExportRun summarizes one pass over all tables
+type WarehouseExportRun struct {
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt time.Time              `json:"finished_at"`
+	Tables     []WarehouseExportState `json:"tables"`
+	Failed     int                    `json:"failed"`
+}
+
+// WarehouseStore is the object storage exports are written to
+type WarehouseStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+}
+
+type WarehouseRepository interface {
+	// GetState returns nil when the table has never been exported
+	GetState(ctx context.Context, table string) (*WarehouseExportState, error)
+	ListStates(ctx context.Context) ([]WarehouseExportState, error)
+	SaveState(ctx context.Context, state *WarehouseExportState) error
+
+	// ExportRows returns up to limit rows after the cursor whose watermark column is
+	// at most until, ordered by (watermark, id), one value per spec column
+	ExportRows(ctx context.Context, table WarehouseTable, after WarehouseCursor, until time.Time, limit int) ([][]any, error)
+}
+
+type WarehouseUsecase interface {
+	// RunExport is called by the nightly worker
+	RunExport(ctx context.Context) (*WarehouseExportRun, error)
+
+	// Admin
+	TriggerExport(ctx context.Context) (*WarehouseExportRun, error)
+	ListExports(ctx context.Context) ([]WarehouseExportState, error)
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Webhook event types third-party integrations can subscribe to
+const (
+	WebhookEventApplicationCreated   = "application.created"
+	WebhookEventJobPublished         = "job.published"
+	WebhookEventVerificationApproved = "verification.approved"
+	WebhookEventFileProcessed        = "file.processed"
+)
+
+// WebhookEventTypes lists every event type an endpoint can subscribe to
+var WebhookEventTypes = []string{WebhookEventApplicationCreated, WebhookEventJobPublished, WebhookEventVerificationApproved, WebhookEventFileProcessed}
+
+// WebhookEventPlacementStatusChanged is sent to LPK partner endpoints only
+const WebhookEventPlacementStatusChanged = "placement.status_changed"
+
+// LPKWebhookEventTypes lists the event types an LPK partner endpoint can subscribe to
+var LPKWebhookEventTypes = []string{WebhookEventPlacementStatusChanged}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "PENDING"   // waiting for its first or next attempt
+	WebhookDeliverySucceeded = "SUCCEEDED" // the endpoint answered 2xx
+	WebhookDeliveryFailed    = "FAILED"    // gave up after the last attempt
+)
+
+// Signature headers sent with every delivery. The signature is
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed with the endpoint secret>".
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+)
+
+// WebhookEndpoint is a URL that receives subscribed events. Admins register
+// platform endpoints; LPK partners register endpoints scoped to their LPK.
+type WebhookEndpoint struct {
+	ID          int64     `json:"id"`
+	LPKID       *int64    `json:"lpk_id,omitempty"` // nil for platform endpoints
+	URL         string    `json:"url"`
+	Description *string   `json:"description,omitempty"`
+	EventTypes  []string  `json:"event_types"`
+	Active      bool      `json:"active"`
+	Secret      string    `json:"secret,omitempty"` // only returned when created or rotated
+	CreatedBy   *string   `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookEndpointRequest registers or replaces an endpoint
+type WebhookEndpointRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=2000"`
+	Description *string  `json:"description" binding:"omitempty,max=500"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1,dive,required"`
+	Active      *bool    `json:"active"` // defaults to true
+}
+
+// WebhookEvent is the JSON body POSTed to an endpoint
+type WebhookEvent struct {
+	ID         string          `json:"id"` // the delivery ID, stable across retries
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// WebhookDelivery is one event queued for one endpoint, with its retry state
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	EndpointID     int64           `json:"endpoint_id"`
+	EndpointURL    string          `json:"endpoint_url,omitempty"`
+	EndpointSecret string          `json:"-"` // filled when claimed for sending
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"` // nil once delivered or given up
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// WebhookDeliveryFilter narrows the delivery history
+type WebhookDeliveryFilter struct {
+	EndpointID *int64
+	LPKID      *int64 // only deliveries to this LPK's endpoints
+	EventType  string
+	Status     string
+	Page       int
+	PageSize   int
+}
+
+// WebhookDeliveryRunResult summarizes one delivery worker run
+type WebhookDeliveryRunResult struct {
+	Due       int       `json:"due"`
+	Succeeded int       `json:"succeeded"`
+	Retrying  int       `json:"retrying"`
+	Failed    int       `json:"failed"` // gave up after the last attempt
+	StartedAt time.Time `json:"started_at"`
+}
+
+// WebhookRepository scopes endpoints by lpkID: nil means platform endpoints,
+// otherwise the endpoints of that LPK. UpdateEndpoint scopes by e.LPKID.
+type WebhookRepository interface {
+	ListEndpoints(ctx context.Context, lpkID *int64) ([]WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id int64, lpkID *int64) (*WebhookEndpoint, error)
+	CreateEndpoint(ctx context.Context, e *WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, e *WebhookEndpoint) error
+	UpdateSecret(ctx context.Context, id int64, lpkID *int64, secret string, at time.Time) error
+	DeleteEndpoint(ctx context.Context, id int64, lpkID *int64) error
+
+	// EnqueueEvent queues one PENDING delivery per active platform endpoint
+	// subscribed to the event type and returns how many were queued
+	EnqueueEvent(ctx context.Context, eventType string, payload json.RawMessage, at time.Time) (int, error)
+	// EnqueuePartnerEvent does the same for the endpoints of one LPK
+	EnqueuePartnerEvent(ctx context.Context, lpkID int64, eventType string, payload json.RawMessage, at time.Time) (int, error)
+	// ClaimDueDeliveries returns up to limit pending deliveries due at now, with their
+	// endpoint's URL and secret, and pushes their next attempt to leaseUntil so
+	// other instances skip them while they are being sent
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]WebhookDelivery, error)
+	// RecordAttempt stores an attempt's outcome; NextAttemptAt is nil when the delivery
+	// succeeded or was given up
+	RecordAttempt(ctx context.Context, d *WebhookDelivery) error
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]WebhookDelivery, int64, error)
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+	// Redeliver queues a delivery for another round of attempts
+	Redeliver(ctx context.Context, id string, at time.Time) error
+}
+
+// WebhookPublisher queues an event for every endpoint subscribed to it. Delivery
+// happens in the background, so publishing never waits on third parties.
+type WebhookPublisher interface {
+	PublishWebhook(ctx context.Context, eventType string, data any) error
+}
+
+type WebhookUsecase interface {
+	WebhookPublisher
+	// RunDeliveries sends due deliveries; called by the background worker
+	RunDeliveries(ctx context.Context) (*WebhookDeliveryRunResult, error)
+
+	// Admin
+	ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id int64) (*WebhookEndpoint, error)
+	CreateEndpoint(ctx context.Context, adminID string, req WebhookEndpointRequest) (*WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, id int64, req WebhookEndpointRequest) (*WebhookEndpoint, error)
+	RotateSecret(ctx context.Context, id int64) (*WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id int64) error
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) (*PaginatedResult[WebhookDelivery], error)
+	GetDelivery(ctx context.Context, id string) (*WebhookDelivery, error)
+	Redeliver(ctx context.Context, id string) (*WebhookDelivery, error)
+}
+
+// ApplicationCreatedWebhook is the data of an application.created event
+type ApplicationCreatedWebhook struct {
+	ApplicationID   int64     `json:"application_id"`
+	JobID           int64     `json:"job_id"`
+	CompanyID       int64     `json:"company_id"`
+	CandidateUserID string    `json:"candidate_user_id"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// JobPublishedWebhook is the data of a job.published event
+type JobPublishedWebhook struct {
+	JobID       int64     `json:"job_id"`
+	CompanyID   int64     `json:"company_id"`
+	Title       string    `json:"title"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// VerificationApprovedWebhook is the data of a verification.approved event
+type VerificationApprovedWebhook struct {
+	VerificationID int64     `json:"verification_id"`
+	UserID         string    `json:"user_id"`
+	Role           string    `json:"role"`
+	ApprovedAt     time.Time `json:"approved_at"`
+}
+
+// FileProcessedWebhook is the data of a file.processed event. The file's URL is
+// left out since private files are only served through signed URLs.
+type FileProcessedWebhook struct {
+	FileID      string     `json:"file_id"`
+	UserID      string     `json:"user_id"`
+	Bucket      string     `json:"bucket"`
+	Status      string     `json:"status"` // ready or failed
+	Error       *string    `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
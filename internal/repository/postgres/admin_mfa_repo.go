@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type adminMFARepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAdminMFARepository creates a new admin MFA repository
+func NewAdminMFARepository(db *pgxpool.Pool) domain.AdminMFARepository {
+	return &adminMFARepo{db: db}
+}
+
+func (r *adminMFARepo) Get(ctx context.Context, userID string) (*domain.UserMFA, error) {
+	m := domain.UserMFA{UserID: userID}
+	err := r.db.QueryRow(ctx, `
+		SELECT totp_secret, enabled_at, failed_attempts, locked_until
+		FROM user_mfa WHERE user_id = $1`, userID,
+	).Scan(&m.Secret, &m.EnabledAt, &m.FailedAttempts, &m.LockedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *adminMFARepo) SavePending(ctx context.Context, userID, secret string) error {
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO user_mfa (user_id, totp_secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET totp_secret = EXCLUDED.totp_secret, failed_attempts = 0, locked_until = NULL, updated_at = NOW()
+		WHERE user_mfa.enabled_at IS NULL`, userID, secret)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrMFAAlreadyEnrolled
+	}
+	return nil
+}
+
+func (r *adminMFARepo) Enable(ctx context.Context, userID string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_mfa SET enabled_at = $2, failed_attempts = 0, locked_until = NULL, updated_at = NOW()
+		WHERE user_id = $1`, userID, at)
+	return err
+}
+
+func (r *adminMFARepo) RecordFailure(ctx context.Context, userID string, maxAttempts int, lockUntil time.Time) error {
+	// The counter restarts once the lock is set, so each lock needs maxAttempts new failures
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_mfa
+		SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+		    locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END,
+		    updated_at = NOW()
+		WHERE user_id = $1`, userID, maxAttempts, lockUntil)
+	return err
+}
+
+func (r *adminMFARepo) ResetFailures(ctx context.Context, userID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_mfa SET failed_attempts = 0, locked_until = NULL, updated_at = NOW()
+		WHERE user_id = $1 AND (failed_attempts > 0 OR locked_until IS NOT NULL)`, userID)
+	return err
+}
+
+func (r *adminMFARepo) Delete(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `DELETE FROM user_mfa WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_sessions WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *adminMFARepo) CreateSession(ctx context.Context, userID, sessionID string, expiresAt time.Time) error {
+	// Expired verifications of the user are dropped on the way
+	_, err := r.db.Exec(ctx, `
+		WITH expired AS (
+			DELETE FROM mfa_sessions WHERE user_id = $1 AND expires_at <= NOW()
+		)
+		INSERT INTO mfa_sessions (session_id, user_id, expires_at)
+		VALUES ($2, $1, $3)
+		ON CONFLICT (session_id) DO UPDATE
+		SET verified_at = NOW(), expires_at = EXCLUDED.expires_at
+		WHERE mfa_sessions.user_id = EXCLUDED.user_id`, userID, sessionID, expiresAt)
+	return err
+}
+
+func (r *adminMFARepo) SessionExpiry(ctx context.Context, userID, sessionID string, now time.Time) (time.Time, error) {
+	var expiresAt time.Time
+	err := r.db.QueryRow(ctx, `
+		SELECT expires_at FROM mfa_sessions
+		WHERE session_id = $1 AND user_id = $2 AND expires_at > $3`, sessionID, userID, now,
+	).Scan(&expiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, domain.ErrNotFound
+	}
+	return expiresAt, err
+}
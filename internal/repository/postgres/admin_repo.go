@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"time"
 
@@ -74,97 +75,67 @@ func (r *adminRepo) GetStats(ctx context.Context) (*domain.AdminStats, error) {
 		r.db.QueryRow(ctx, `SELECT COUNT(*) FROM applications`).Scan(&stats.TotalApplications)
 	}
 
+	// Placements
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'ACTIVE') FROM placements`).Scan(&stats.TotalPlacements, &stats.ActivePlacements)
+	if err != nil {
+		stats.TotalPlacements, stats.ActivePlacements = 0, 0
+	}
+
 	return stats, nil
 }
 
-// ListUsers fetches paginated users with optional role filter
-func (r *adminRepo) ListUsers(ctx context.Context, role string, page, pageSize int) ([]domain.AdminUser, int64, error) {
+// ListUsers fetches paginated users with optional role filter; a non-nil
+// provinces limits them to candidates and employers in those provinces
+func (r *adminRepo) ListUsers(ctx context.Context, role string, provinces []string, page, pageSize int) ([]domain.AdminUser, int64, error) {
 	var total int64
-	var users []domain.AdminUser
+	users := []domain.AdminUser{}
 
 	offset := (page - 1) * pageSize
 
-	// Try to add is_disabled column if it doesn't exist (ignore errors)
-	_, _ = r.db.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS is_disabled BOOLEAN DEFAULT false`)
-
-	// Count query
-	countQuery := `SELECT COUNT(*) FROM users`
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
 	if role != "" {
-		countQuery += ` WHERE role = $1`
-		err := r.db.QueryRow(ctx, countQuery, role).Scan(&total)
-		if err != nil {
-			return nil, 0, err
-		}
-	} else {
-		err := r.db.QueryRow(ctx, countQuery).Scan(&total)
-		if err != nil {
-			return nil, 0, err
-		}
+		where += fmt.Sprintf(" AND u.role = $%d", argIndex)
+		args = append(args, role)
+		argIndex++
+	}
+	if provinces != nil {
+		where += " AND " + userScopeCondition(argIndex)
+		args = append(args, provinces)
+		argIndex++
 	}
 
-	// Data query - try with is_disabled first, fallback to simpler query
-	if role != "" {
-		query := `SELECT id, email, role, COALESCE(is_disabled, false), created_at, updated_at 
-		          FROM users WHERE role = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
-		rows, err := r.db.Query(ctx, query, role, pageSize, offset)
-		if err != nil {
-			// Fallback without is_disabled
-			query = `SELECT id, email, role, false, created_at, updated_at 
-			         FROM users WHERE role = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
-			rows, err = r.db.Query(ctx, query, role, pageSize, offset)
-			if err != nil {
-				return []domain.AdminUser{}, 0, nil
-			}
-		}
-		defer rows.Close()
-		for rows.Next() {
-			var u domain.AdminUser
-			var createdAt, updatedAt time.Time
-			if err := rows.Scan(&u.ID, &u.Email, &u.Role, &u.IsDisabled, &createdAt, &updatedAt); err != nil {
-				continue
-			}
-			u.CreatedAt = createdAt.Format(time.RFC3339)
-			u.UpdatedAt = updatedAt.Format(time.RFC3339)
-			users = append(users, u)
-		}
-	} else {
-		query := `SELECT id, email, role, COALESCE(is_disabled, false), created_at, updated_at 
-		          FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-		rows, err := r.db.Query(ctx, query, pageSize, offset)
-		if err != nil {
-			// Fallback without is_disabled
-			query = `SELECT id, email, role, false, created_at, updated_at 
-			         FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-			rows, err = r.db.Query(ctx, query, pageSize, offset)
-			if err != nil {
-				return []domain.AdminUser{}, 0, nil
-			}
-		}
-		defer rows.Close()
-		for rows.Next() {
-			var u domain.AdminUser
-			var createdAt, updatedAt time.Time
-			if err := rows.Scan(&u.ID, &u.Email, &u.Role, &u.IsDisabled, &createdAt, &updatedAt); err != nil {
-				continue
-			}
-			u.CreatedAt = createdAt.Format(time.RFC3339)
-			u.UpdatedAt = updatedAt.Format(time.RFC3339)
-			users = append(users, u)
-		}
+	// Count query
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM users u`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
 	}
 
-	if users == nil {
-		users = []domain.AdminUser{}
+	// Data query
+	query := `SELECT u.id, u.email, u.role, COALESCE(u.is_disabled, false), u.created_at, u.updated_at
+	          FROM users u` + where + fmt.Sprintf(` ORDER BY u.created_at DESC LIMIT $%d OFFSET $%d`, argIndex, argIndex+1)
+	args = append(args, pageSize, offset)
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var u domain.AdminUser
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&u.ID, &u.Email, &u.Role, &u.IsDisabled, &createdAt, &updatedAt); err != nil {
+			continue
+		}
+		u.CreatedAt = createdAt.Format(time.RFC3339)
+		u.UpdatedAt = updatedAt.Format(time.RFC3339)
+		users = append(users, u)
 	}
 
-	return users, total, nil
+	return users, total, rows.Err()
 }
 
 // DisableUser enables or disables a user
 func (r *adminRepo) DisableUser(ctx context.Context, userID string, disable bool) error {
-	// First try to add is_disabled column if it doesn't exist
-	_, _ = r.db.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS is_disabled BOOLEAN DEFAULT false`)
-
 	query := `UPDATE users SET is_disabled = $2, updated_at = $3 WHERE id = $1`
 	_, err := r.db.Exec(ctx, query, userID, disable, time.Now())
 	return err
@@ -172,9 +143,6 @@ func (r *adminRepo) DisableUser(ctx context.Context, userID string, disable bool
 
 // CreateUser inserts a new user
 func (r *adminRepo) CreateUser(ctx context.Context, u domain.AdminUser) error {
-	// First try to add is_disabled column if it doesn't exist
-	_, _ = r.db.Exec(ctx, `ALTER TABLE users ADD COLUMN IF NOT EXISTS is_disabled BOOLEAN DEFAULT false`)
-
 	query := `INSERT INTO users (id, email, role, is_disabled, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`
 	created, _ := time.Parse(time.RFC3339, u.CreatedAt)
 	updated, _ := time.Parse(time.RFC3339, u.UpdatedAt)
@@ -302,10 +270,6 @@ func (r *adminRepo) ListJobsForAdmin(ctx context.Context, status string, page, p
 
 	offset := (page - 1) * pageSize
 
-	// First ensure the needed columns exist
-	_, _ = r.db.Exec(ctx, `ALTER TABLE jobs ADD COLUMN IF NOT EXISTS status VARCHAR(20) DEFAULT 'active'`)
-	_, _ = r.db.Exec(ctx, `ALTER TABLE jobs ADD COLUMN IF NOT EXISTS is_flagged BOOLEAN DEFAULT false`)
-
 	// Count query
 	countQuery := `SELECT COUNT(*) FROM jobs`
 	if status != "" {
@@ -385,8 +349,6 @@ func (r *adminRepo) ListJobsForAdmin(ctx context.Context, status string, page, p
 
 // HideJob hides or unhides a job
 func (r *adminRepo) HideJob(ctx context.Context, jobID int64, hide bool) error {
-	_, _ = r.db.Exec(ctx, `ALTER TABLE jobs ADD COLUMN IF NOT EXISTS status VARCHAR(20) DEFAULT 'active'`)
-
 	status := "active"
 	if hide {
 		status = "hidden"
@@ -399,9 +361,6 @@ func (r *adminRepo) HideJob(ctx context.Context, jobID int64, hide bool) error {
 
 // FlagJob flags or unflags a job
 func (r *adminRepo) FlagJob(ctx context.Context, jobID int64, flag bool, reason string) error {
-	_, _ = r.db.Exec(ctx, `ALTER TABLE jobs ADD COLUMN IF NOT EXISTS is_flagged BOOLEAN DEFAULT false`)
-	_, _ = r.db.Exec(ctx, `ALTER TABLE jobs ADD COLUMN IF NOT EXISTS flag_reason TEXT`)
-
 	query := `UPDATE jobs SET is_flagged = $2, flag_reason = $3, updated_at = $4 WHERE id = $1`
 	_, err := r.db.Exec(ctx, query, jobID, flag, reason, time.Now())
 	return err
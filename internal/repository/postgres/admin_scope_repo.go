@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type adminScopeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAdminScopeRepository(db *pgxpool.Pool) domain.AdminScopeRepository {
+	return &adminScopeRepo{db: db}
+}
+
+const adminScopeSelect = `
+	SELECT s.admin_user_id::TEXT, u.email, s.provinces, s.updated_by::TEXT, s.created_at, s.updated_at
+	FROM admin_scopes s
+	JOIN users u ON u.id = s.admin_user_id`
+
+func scanAdminScope(row pgx.Row) (*domain.AdminScope, error) {
+	var s domain.AdminScope
+	if err := row.Scan(&s.AdminUserID, &s.Email, &s.Provinces, &s.UpdatedBy, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *adminScopeRepo) Get(ctx context.Context, adminUserID string) (*domain.AdminScope, error) {
+	s, err := scanAdminScope(r.db.QueryRow(ctx, adminScopeSelect+` WHERE s.admin_user_id = $1`, adminUserID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return s, err
+}
+
+func (r *adminScopeRepo) List(ctx context.Context) ([]domain.AdminScope, error) {
+	rows, err := r.db.Query(ctx, adminScopeSelect+` ORDER BY u.email`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scopes := []domain.AdminScope{}
+	for rows.Next() {
+		s, err := scanAdminScope(rows)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, *s)
+	}
+	return scopes, rows.Err()
+}
+
+func (r *adminScopeRepo) Upsert(ctx context.Context, s *domain.AdminScope) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO admin_scopes (admin_user_id, provinces, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (admin_user_id) DO UPDATE SET
+			provinces = EXCLUDED.provinces,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at`,
+		s.AdminUserID, s.Provinces, s.UpdatedBy, s.UpdatedAt,
+	).Scan(&s.CreatedAt)
+}
+
+func (r *adminScopeRepo) Delete(ctx context.Context, adminUserID string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM admin_scopes WHERE admin_user_id = $1`, adminUserID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// provinceScopeCondition limits a query to rows whose province column is in the
+// lower-cased admin scope bound at argIndex
+func provinceScopeCondition(column string, argIndex int) string {
+	return fmt.Sprintf("LOWER(%s) = ANY($%d)", column, argIndex)
+}
+
+// verificationScopeCondition limits account verifications (aliased av) to the
+// admin scope at argIndex: candidates by domicile province, employers by their
+// company's province
+func verificationScopeCondition(argIndex int) string {
+	return fmt.Sprintf(`(CASE WHEN av.role = 'EMPLOYER'
+		THEN (SELECT LOWER(sc.province) FROM company_profiles sc WHERE sc.user_id = av.user_id)
+		ELSE LOWER(av.domicile_province) END) = ANY($%d)`, argIndex)
+}
+
+// userScopeCondition limits users (aliased u) to the admin scope at argIndex:
+// candidates by domicile province, employers by the province of the company
+// they own or joined. Other admins are out of scope.
+func userScopeCondition(argIndex int) string {
+	return fmt.Sprintf(`(
+		(u.role = 'candidate' AND EXISTS (
+			SELECT 1 FROM account_verifications sv
+			WHERE sv.user_id = u.id AND LOWER(sv.domicile_province) = ANY($%[1]d)
+		))
+		OR (u.role = 'employer' AND EXISTS (
+			SELECT 1 FROM company_profiles sc
+			WHERE (sc.user_id = u.id OR sc.id IN (SELECT sm.company_id FROM company_members sm WHERE sm.user_id = u.id))
+			  AND LOWER(sc.province) = ANY($%[1]d)
+		))
+	)`, argIndex)
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type adminSearchRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAdminSearchRepository creates a new admin search repository
+func NewAdminSearchRepository(db *pgxpool.Pool) domain.AdminSearchRepository {
+	return &adminSearchRepo{db: db}
+}
+
+// Each query matches substrings with ILIKE (served by the trigram indexes) and ranks
+// by trigram similarity. Rows are scanned as (id, title, subtitle, score).
+
+func (r *adminSearchRepo) SearchUsers(ctx context.Context, query string, limit int) ([]domain.AdminSearchResult, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id::TEXT, email, role, similarity(email, $1)
+		FROM users
+		WHERE email ILIKE $2
+		ORDER BY LOWER(email) = LOWER($1) DESC, similarity(email, $1) DESC, email
+		LIMIT $3`, query, containsPattern(query), limit)
+	return scanAdminSearchResults(rows, err, domain.AdminSearchTypeUser, "/admin/users/")
+}
+
+func (r *adminSearchRepo) SearchCandidates(ctx context.Context, query, phoneDigits string, provinces []string, limit int) ([]domain.AdminSearchResult, error) {
+	// The expressions match idx_account_verifications_name_trgm / _phone_trgm
+	rows, err := r.db.Query(ctx, `
+		WITH c AS (
+			SELECT av.id, u.email, av.status,
+			       COALESCE(av.first_name, '') || ' ' || COALESCE(av.last_name, '') AS name,
+			       regexp_replace(COALESCE(av.phone, ''), '[^0-9]', '', 'g') AS phone_digits
+			FROM account_verifications av
+			JOIN users u ON u.id = av.user_id
+			WHERE av.role = 'CANDIDATE'
+			  AND ($5::TEXT[] IS NULL OR LOWER(av.domicile_province) = ANY($5))
+			  AND ((COALESCE(av.first_name, '') || ' ' || COALESCE(av.last_name, '')) ILIKE $2
+			       OR ($3 <> '' AND regexp_replace(COALESCE(av.phone, ''), '[^0-9]', '', 'g') LIKE '%' || $3 || '%'))
+		)
+		SELECT id::TEXT, TRIM(name), email || ' - ' || status,
+		       CASE WHEN $3 <> '' AND phone_digits LIKE '%' || $3 || '%' THEN 1 ELSE similarity(name, $1) END AS score
+		FROM c
+		ORDER BY score DESC, name
+		LIMIT $4`, query, containsPattern(query), phoneDigits, limit, provinces)
+	return scanAdminSearchResults(rows, err, domain.AdminSearchTypeCandidate, "/admin/verifications/")
+}
+
+func (r *adminSearchRepo) SearchCompanies(ctx context.Context, query string, provinces []string, limit int) ([]domain.AdminSearchResult, error) {
+	// Merged duplicates are left out; the survivor is what admins work on
+	rows, err := r.db.Query(ctx, `
+		SELECT cp.id::TEXT, cp.company_name, COALESCE(cp.location, ''), similarity(cp.company_name, $1)
+		FROM company_profiles cp
+		WHERE cp.company_name ILIKE $2 AND cp.merged_into_id IS NULL
+		  AND ($4::TEXT[] IS NULL OR LOWER(cp.province) = ANY($4))
+		ORDER BY similarity(cp.company_name, $1) DESC, cp.company_name
+		LIMIT $3`, query, containsPattern(query), limit, provinces)
+	return scanAdminSearchResults(rows, err, domain.AdminSearchTypeCompany, "/admin/companies/")
+}
+
+func (r *adminSearchRepo) SearchJobs(ctx context.Context, query string, limit int) ([]domain.AdminSearchResult, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT j.id::TEXT, j.title, COALESCE(cp.company_name, '') || ' - ' || j.location, similarity(j.title, $1)
+		FROM jobs j
+		LEFT JOIN company_profiles cp ON cp.id = j.company_id
+		WHERE j.title ILIKE $2
+		ORDER BY similarity(j.title, $1) DESC, j.created_at DESC
+		LIMIT $3`, query, containsPattern(query), limit)
+	return scanAdminSearchResults(rows, err, domain.AdminSearchTypeJob, "/admin/jobs/")
+}
+
+func scanAdminSearchResults(rows pgx.Rows, err error, resultType, linkPrefix string) ([]domain.AdminSearchResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []domain.AdminSearchResult{}
+	for rows.Next() {
+		res := domain.AdminSearchResult{Type: resultType}
+		var score float32
+		if err := rows.Scan(&res.ID, &res.Title, &res.Subtitle, &score); err != nil {
+			return nil, err
+		}
+		res.Score = float64(score)
+		res.Link = linkPrefix + res.ID
+		results = append(results, res)
+	}
+	return results, rows.Err()
+}
+
+// containsPattern builds an ILIKE pattern matching query anywhere, with wildcards in query escaped
+func containsPattern(query string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(query)
+	return "%" + escaped + "%"
+}
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// aggregateQueries holds the SQL for one derived aggregate. driftQuery selects
+// (user_id, subject_ref, stored, computed, total drifted) limited by $1.
+type aggregateQueries struct {
+	countQuery string
+	driftQuery string
+	fixQuery   string
+}
+
+var aggregateSQL = map[string]aggregateQueries{
+	domain.AggregateTotalExperienceMonths: {
+		countQuery: `SELECT COUNT(*) FROM candidate_profiles`,
+		driftQuery: `
+			SELECT user_id, NULL::TEXT, stored, computed, COUNT(*) OVER ()
+			FROM (
+				SELECT cp.user_id, cp.total_experience_months AS stored,
+				       compute_total_experience_months(cp.user_id) AS computed
+				FROM candidate_profiles cp
+			) d
+			WHERE stored IS DISTINCT FROM computed
+			ORDER BY user_id
+			LIMIT $1`,
+		fixQuery: `
+			UPDATE candidate_profiles cp
+			SET total_experience_months = compute_total_experience_months(cp.user_id)
+			WHERE cp.total_experience_months IS DISTINCT FROM compute_total_experience_months(cp.user_id)`,
+	},
+	domain.AggregateQuizBestScore: {
+		countQuery: `SELECT COUNT(*) FROM candidate_quiz_scores`,
+		driftQuery: `
+			SELECT s.user_id, s.quiz_id::TEXT, s.best_score, m.best, COUNT(*) OVER ()
+			FROM candidate_quiz_scores s
+			LEFT JOIN LATERAL (
+				SELECT MAX(a.score_percent) AS best
+				FROM skill_quiz_attempts a
+				WHERE a.user_id = s.user_id AND a.quiz_id = s.quiz_id AND a.status = 'SUBMITTED'
+			) m ON TRUE
+			WHERE s.best_score IS DISTINCT FROM m.best
+			ORDER BY s.user_id, s.quiz_id
+			LIMIT $1`,
+		// Rows without any submitted attempt are reported but left for manual review
+		fixQuery: `
+			UPDATE candidate_quiz_scores s
+			SET best_score = m.best
+			FROM (
+				SELECT user_id, quiz_id, MAX(score_percent) AS best
+				FROM skill_quiz_attempts
+				WHERE status = 'SUBMITTED'
+				GROUP BY user_id, quiz_id
+			) m
+			WHERE m.user_id = s.user_id AND m.quiz_id = s.quiz_id AND s.best_score <> m.best`,
+	},
+}
+
+type aggregateRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAggregateRepository creates a new derived aggregate repository
+func NewAggregateRepository(db *pgxpool.Pool) domain.AggregateRepository {
+	return &aggregateRepo{db: db}
+}
+
+func queriesFor(aggregate string) (aggregateQueries, error) {
+	q, ok := aggregateSQL[aggregate]
+	if !ok {
+		return aggregateQueries{}, fmt.Errorf("unknown aggregate: %s", aggregate)
+	}
+	return q, nil
+}
+
+func (r *aggregateRepo) CountRows(ctx context.Context, aggregate string) (int, error) {
+	q, err := queriesFor(aggregate)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	err = r.db.QueryRow(ctx, q.countQuery).Scan(&count)
+	return count, err
+}
+
+func (r *aggregateRepo) FindDrift(ctx context.Context, aggregate string, limit int) ([]domain.AggregateDrift, int, error) {
+	q, err := queriesFor(aggregate)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, q.driftQuery, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	drift := []domain.AggregateDrift{}
+	total := 0
+	for rows.Next() {
+		d := domain.AggregateDrift{Aggregate: aggregate}
+		if err := rows.Scan(&d.UserID, &d.SubjectRef, &d.StoredValue, &d.ComputedValue, &total); err != nil {
+			return nil, 0, err
+		}
+		drift = append(drift, d)
+	}
+	return drift, total, rows.Err()
+}
+
+func (r *aggregateRepo) FixDrift(ctx context.Context, aggregate string) (int, error) {
+	q, err := queriesFor(aggregate)
+	if err != nil {
+		return 0, err
+	}
+	result, err := r.db.Exec(ctx, q.fixQuery)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (r *aggregateRepo) CreateRun(ctx context.Context, run *domain.AggregateRecomputeRun) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO aggregate_recompute_runs (trigger_source, status)
+		VALUES ($1, $2)
+		RETURNING id, started_at`,
+		run.TriggerSource, run.Status,
+	).Scan(&run.ID, &run.StartedAt)
+}
+
+func (r *aggregateRepo) FinishRun(ctx context.Context, run *domain.AggregateRecomputeRun) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE aggregate_recompute_runs
+		SET status = $2, checked_count = $3, drift_count = $4, fixed_count = $5,
+		    error_message = $6, finished_at = NOW()
+		WHERE id = $1
+		RETURNING finished_at`,
+		run.ID, run.Status, run.CheckedCount, run.DriftCount, run.FixedCount, run.ErrorMessage,
+	).Scan(&run.FinishedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range run.DriftItems {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO aggregate_drift_items (run_id, aggregate, user_id, subject_ref, stored_value, computed_value)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			run.ID, d.Aggregate, d.UserID, d.SubjectRef, d.StoredValue, d.ComputedValue,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+const aggregateRunColumns = `id, trigger_source, status, checked_count, drift_count, fixed_count,
+	error_message, started_at, finished_at`
+
+func scanAggregateRun(row pgx.Row, run *domain.AggregateRecomputeRun) error {
+	return row.Scan(
+		&run.ID, &run.TriggerSource, &run.Status, &run.CheckedCount, &run.DriftCount, &run.FixedCount,
+		&run.ErrorMessage, &run.StartedAt, &run.FinishedAt,
+	)
+}
+
+func (r *aggregateRepo) ListRuns(ctx context.Context, limit int) ([]domain.AggregateRecomputeRun, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+aggregateRunColumns+`
+		FROM aggregate_recompute_runs
+		ORDER BY started_at DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []domain.AggregateRecomputeRun{}
+	for rows.Next() {
+		var run domain.AggregateRecomputeRun
+		if err := scanAggregateRun(rows, &run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *aggregateRepo) GetRun(ctx context.Context, id int64) (*domain.AggregateRecomputeRun, error) {
+	var run domain.AggregateRecomputeRun
+	err := scanAggregateRun(r.db.QueryRow(ctx, `SELECT `+aggregateRunColumns+` FROM aggregate_recompute_runs WHERE id = $1`, id), &run)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT aggregate, user_id, subject_ref, stored_value, computed_value
+		FROM aggregate_drift_items
+		WHERE run_id = $1
+		ORDER BY aggregate, id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	run.DriftItems = []domain.AggregateDrift{}
+	for rows.Next() {
+		var d domain.AggregateDrift
+		if err := rows.Scan(&d.Aggregate, &d.UserID, &d.SubjectRef, &d.StoredValue, &d.ComputedValue); err != nil {
+			return nil, err
+		}
+		run.DriftItems = append(run.DriftItems, d)
+	}
+	return &run, rows.Err()
+}
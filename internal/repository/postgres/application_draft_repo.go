@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type applicationDraftRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewApplicationDraftRepository creates a new application draft repository
+func NewApplicationDraftRepository(db *pgxpool.Pool) domain.ApplicationDraftRepository {
+	return &applicationDraftRepo{db: db}
+}
+
+func (r *applicationDraftRepo) Get(ctx context.Context, userID string, jobID int64) (*domain.ApplicationDraft, error) {
+	d := domain.ApplicationDraft{CandidateUserID: userID, JobID: jobID}
+	var answers []byte
+	err := r.db.QueryRow(ctx, `
+		SELECT cv_url, cover_letter, answers, reminder_sent_at, expires_at, created_at, updated_at
+		FROM application_drafts
+		WHERE candidate_user_id = $1 AND job_id = $2`, userID, jobID,
+	).Scan(&d.CvURL, &d.CoverLetter, &answers, &d.ReminderSentAt, &d.ExpiresAt, &d.CreatedAt, &d.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(answers, &d.Answers); err != nil {
+		return nil, fmt.Errorf("decode application draft answers: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *applicationDraftRepo) Upsert(ctx context.Context, d *domain.ApplicationDraft) error {
+	if d.Answers == nil {
+		d.Answers = []domain.ScreeningAnswerInput{}
+	}
+	answers, err := json.Marshal(d.Answers)
+	if err != nil {
+		return err
+	}
+	return r.db.QueryRow(ctx, `
+		INSERT INTO application_drafts (candidate_user_id, job_id, cv_url, cover_letter, answers, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (candidate_user_id, job_id) DO UPDATE
+		SET cv_url = EXCLUDED.cv_url, cover_letter = EXCLUDED.cover_letter, answers = EXCLUDED.answers,
+		    expires_at = EXCLUDED.expires_at, reminder_sent_at = NULL, updated_at = NOW()
+		RETURNING reminder_sent_at, created_at, updated_at`,
+		d.CandidateUserID, d.JobID, d.CvURL, d.CoverLetter, answers, d.ExpiresAt,
+	).Scan(&d.ReminderSentAt, &d.CreatedAt, &d.UpdatedAt)
+}
+
+func (r *applicationDraftRepo) Delete(ctx context.Context, userID string, jobID int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM application_drafts WHERE candidate_user_id = $1 AND job_id = $2`, userID, jobID)
+	return err
+}
+
+func (r *applicationDraftRepo) ListDueReminders(ctx context.Context, savedBefore, now time.Time, limit int) ([]domain.ApplicationDraftReminderCandidate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT d.candidate_user_id, d.job_id, j.title, CASE WHEN j.is_confidential THEN '' ELSE COALESCE(cp.company_name, '') END, d.expires_at,
+		       u.email, av.first_name, u.preferred_locale
+		FROM application_drafts d
+		JOIN jobs j ON j.id = d.job_id
+		LEFT JOIN company_profiles cp ON j.company_id = cp.id
+		JOIN users u ON u.id = d.candidate_user_id
+		LEFT JOIN account_verifications av ON av.user_id = d.candidate_user_id
+		WHERE d.reminder_sent_at IS NULL
+		  AND d.updated_at <= $1
+		  AND d.expires_at > $2
+		  AND `+publishedJobExpr+`
+		  AND (j.apply_deadline IS NULL OR j.apply_deadline > $2)
+		  AND NOT EXISTS (
+			SELECT 1 FROM applications a
+			WHERE a.job_id = d.job_id AND a.candidate_user_id = d.candidate_user_id
+		  )
+		ORDER BY d.updated_at
+		LIMIT $3`, savedBefore, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []domain.ApplicationDraftReminderCandidate
+	for rows.Next() {
+		var d domain.ApplicationDraftReminderCandidate
+		if err := rows.Scan(
+			&d.CandidateUserID, &d.JobID, &d.JobTitle, &d.CompanyName, &d.ExpiresAt,
+			&d.Email, &d.FirstName, &d.PreferredLocale,
+		); err != nil {
+			return nil, err
+		}
+		due = append(due, d)
+	}
+	return due, rows.Err()
+}
+
+func (r *applicationDraftRepo) MarkReminded(ctx context.Context, userID string, jobID int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE application_drafts SET reminder_sent_at = $3
+		WHERE candidate_user_id = $1 AND job_id = $2`, userID, jobID, at)
+	return err
+}
+
+func (r *applicationDraftRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM application_drafts WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
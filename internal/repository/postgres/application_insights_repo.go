@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type applicationInsightsRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewApplicationInsightsRepository creates a new application insights repository
+func NewApplicationInsightsRepository(db *pgxpool.Pool) domain.ApplicationInsightsRepository {
+	return &applicationInsightsRepo{db: db}
+}
+
+func (r *applicationInsightsRepo) GetActivityStats(ctx context.Context, candidateUserID string) (*domain.ApplicationActivityStats, error) {
+	stats := &domain.ApplicationActivityStats{}
+
+	// An application reached interview when it is, or ever was, in an interview stage
+	// or later. The first history row with a from_stage is the employer's first move.
+	err := r.db.QueryRow(ctx, `
+		WITH mine AS (
+			SELECT a.id, a.stage, a.created_at,
+				a.stage = ANY($2) OR EXISTS (
+					SELECT 1 FROM application_stage_history h
+					WHERE h.application_id = a.id AND h.to_stage = ANY($2)
+				) AS reached_interview,
+				(
+					SELECT MIN(h.changed_at) FROM application_stage_history h
+					WHERE h.application_id = a.id AND h.from_stage IS NOT NULL
+				) AS first_response_at
+			FROM applications a
+			WHERE a.candidate_user_id = $1
+		)
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE reached_interview),
+			COUNT(first_response_at),
+			AVG(EXTRACT(EPOCH FROM first_response_at - created_at) / 3600),
+			COUNT(*) FILTER (WHERE first_response_at IS NULL AND stage = $3)
+		FROM mine`,
+		candidateUserID, domain.InterviewStages, domain.ApplicationStageApplied,
+	).Scan(&stats.Total, &stats.ReachedInterview, &stats.Responded, &stats.AvgResponseHours, &stats.AwaitingResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT stage, COUNT(*) FROM applications
+		WHERE candidate_user_id = $1
+		GROUP BY stage
+		ORDER BY COUNT(*) DESC, stage`, candidateUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats.ByStage = []domain.ApplicationStageCount{}
+	for rows.Next() {
+		var sc domain.ApplicationStageCount
+		if err := rows.Scan(&sc.Stage, &sc.Count); err != nil {
+			return nil, err
+		}
+		stats.ByStage = append(stats.ByStage, sc)
+	}
+	return stats, rows.Err()
+}
+
+func (r *applicationInsightsRepo) GetBenchmarkProfile(ctx context.Context, candidateUserID string) (*domain.CandidateBenchmarkProfile, error) {
+	p := &domain.CandidateBenchmarkProfile{}
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(av.main_job_fields, '{}'), av.japanese_level, av.japan_experience_duration,
+			ARRAY(
+				SELECT s.name FROM candidate_skills cs
+				JOIN skills s ON s.id = cs.skill_id
+				WHERE cs.user_id = av.user_id
+			)
+		FROM account_verifications av
+		WHERE av.user_id = $1`, candidateUserID,
+	).Scan(&p.MainJobFields, &p.JapaneseLevel, &p.JapanExperienceMonths, &p.Skills)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *applicationInsightsRepo) GetHiredBenchmark(ctx context.Context, jobFields []string, excludeUserID string, topSkills int) (*domain.HiredCandidateBenchmark, error) {
+	b := &domain.HiredCandidateBenchmark{}
+
+	// JLPT levels are ranked 1 (N1) to 5 (N5) so the median can be taken; candidates
+	// without a certificate are left out of the level median but still counted.
+	var medianRank *int
+	err := r.db.QueryRow(ctx, `
+		WITH hired AS (
+			SELECT DISTINCT av.user_id, av.japanese_level, av.japan_experience_duration
+			FROM applications a
+			JOIN account_verifications av ON av.user_id = a.candidate_user_id
+			WHERE a.stage = $3 AND av.main_job_fields && $1 AND av.user_id <> $2
+		)
+		SELECT COUNT(*),
+			PERCENTILE_DISC(0.5) WITHIN GROUP (ORDER BY array_position($4::TEXT[], japanese_level)),
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY japan_experience_duration)
+		FROM hired`,
+		jobFields, excludeUserID, domain.ApplicationStageHired, domain.JLPTLevels,
+	).Scan(&b.SampleSize, &medianRank, &b.MedianExperienceMonths)
+	if err != nil {
+		return nil, err
+	}
+	if medianRank != nil && *medianRank >= 1 && *medianRank <= len(domain.JLPTLevels) {
+		level := domain.JLPTLevels[*medianRank-1]
+		b.MedianJapaneseLevel = &level
+	}
+
+	rows, err := r.db.Query(ctx, `
+		WITH hired AS (
+			SELECT DISTINCT av.user_id
+			FROM applications a
+			JOIN account_verifications av ON av.user_id = a.candidate_user_id
+			WHERE a.stage = $3 AND av.main_job_fields && $1 AND av.user_id <> $2
+		)
+		SELECT s.name, COUNT(*) * 100.0 / NULLIF((SELECT COUNT(*) FROM hired), 0)
+		FROM hired h
+		JOIN candidate_skills cs ON cs.user_id = h.user_id
+		JOIN skills s ON s.id = cs.skill_id
+		GROUP BY s.name
+		ORDER BY COUNT(*) DESC, s.name
+		LIMIT $4`,
+		jobFields, excludeUserID, domain.ApplicationStageHired, topSkills)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	b.TopSkills = []domain.BenchmarkSkill{}
+	for rows.Next() {
+		var s domain.BenchmarkSkill
+		if err := rows.Scan(&s.Name, &s.Percent); err != nil {
+			return nil, err
+		}
+		b.TopSkills = append(b.TopSkills, s)
+	}
+	return b, rows.Err()
+}
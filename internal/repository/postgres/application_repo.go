@@ -2,9 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,11 +20,14 @@ func NewApplicationRepository(db *pgxpool.Pool) domain.ApplicationRepository {
 	return &applicationRepo{db: db}
 }
 
-// Create inserts a new application
+// Create inserts a new application together with its screening answers. The job's
+// applicant cap is checked under its row lock, so concurrent applications cannot
+// overshoot it (ErrJobApplicantCapReached), and an auto-closing job is closed by
+// the application that fills it.
 func (r *applicationRepo) Create(ctx context.Context, app *domain.Application) error {
 	query := `
-		INSERT INTO applications (job_id, candidate_user_id, account_verification_id, cv_url, cover_letter, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO applications (job_id, candidate_user_id, account_verification_id, cv_url, cover_letter, status, auto_rejected, created_at, updated_at, stage, stage_changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $8)
 		RETURNING id`
 
 	now := time.Now()
@@ -30,17 +36,76 @@ func (r *applicationRepo) Create(ctx context.Context, app *domain.Application) e
 	if app.Status == "" {
 		app.Status = domain.ApplicationStatusApplied
 	}
+	app.Stage = domain.ApplicationStageForStatus(domain.ApplicationStageApplied, app.Status)
+	app.StageChangedAt = &now
 
-	return r.db.QueryRow(ctx, query,
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var full bool
+	err = tx.QueryRow(ctx, `
+		SELECT j.max_applicants IS NOT NULL AND `+applicantCountExpr+` >= j.max_applicants
+		FROM jobs j WHERE j.id = $1 FOR UPDATE`, app.JobID).Scan(&full)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	if full {
+		return domain.ErrJobApplicantCapReached
+	}
+
+	err = tx.QueryRow(ctx, query,
 		app.JobID,
 		app.CandidateUserID,
 		app.AccountVerificationID,
 		app.CvURL,
 		app.CoverLetter,
 		app.Status,
+		app.AutoRejected,
 		app.CreatedAt,
 		app.UpdatedAt,
+		app.Stage,
 	).Scan(&app.ID)
+	if err != nil {
+		return err
+	}
+
+	// Initial stage history entry
+	initial := &domain.ApplicationStageChange{ApplicationID: app.ID, ToStage: app.Stage, ChangedAt: now}
+	if err := insertStageChange(ctx, tx, initial); err != nil {
+		return err
+	}
+
+	answerQuery := `
+		INSERT INTO application_answers (application_id, question_id, prompt, question_type, answer_text, file_url, is_disqualifying)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+	for i := range app.Answers {
+		answer := &app.Answers[i]
+		answer.ApplicationID = app.ID
+		if err := tx.QueryRow(ctx, answerQuery,
+			answer.ApplicationID, answer.QuestionID, answer.Prompt, answer.Type,
+			answer.Answer, answer.FileURL, answer.Disqualifying,
+		).Scan(&answer.ID); err != nil {
+			return err
+		}
+	}
+
+	// The application that fills an auto-closing job closes it
+	if _, err := tx.Exec(ctx, `
+		UPDATE jobs j SET company_status = 'closed', updated_at = $2
+		WHERE j.id = $1 AND j.auto_close AND j.company_status IN ('active', 'scheduled')
+		  AND j.max_applicants IS NOT NULL AND `+applicantCountExpr+` >= j.max_applicants`,
+		app.JobID, now); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 // GetByID retrieves an application by ID with joined candidate data
@@ -48,7 +113,8 @@ func (r *applicationRepo) GetByID(ctx context.Context, id int64) (*domain.Applic
 	query := `
 		SELECT 
 			a.id, a.job_id, a.candidate_user_id, a.account_verification_id, 
-			a.cv_url, a.cover_letter, a.status, a.created_at, a.updated_at,
+			a.cv_url, a.cover_letter, a.status, a.auto_rejected, a.created_at, a.updated_at,
+			a.stage, a.stage_changed_at,
 			COALESCE(av.first_name || ' ' || av.last_name, u.email) as candidate_name,
 			av.profile_picture_url as candidate_photo,
 			av.status as verification_status,
@@ -62,7 +128,8 @@ func (r *applicationRepo) GetByID(ctx context.Context, id int64) (*domain.Applic
 	var app domain.Application
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&app.ID, &app.JobID, &app.CandidateUserID, &app.AccountVerificationID,
-		&app.CvURL, &app.CoverLetter, &app.Status, &app.CreatedAt, &app.UpdatedAt,
+		&app.CvURL, &app.CoverLetter, &app.Status, &app.AutoRejected, &app.CreatedAt, &app.UpdatedAt,
+		&app.Stage, &app.StageChangedAt,
 		&app.CandidateName, &app.CandidatePhoto, &app.VerificationStatus, &app.JobTitle,
 	)
 	if err != nil {
@@ -76,7 +143,8 @@ func (r *applicationRepo) GetByJobID(ctx context.Context, jobID int64) ([]domain
 	query := `
 		SELECT 
 			a.id, a.job_id, a.candidate_user_id, a.account_verification_id, 
-			a.cv_url, a.cover_letter, a.status, a.created_at, a.updated_at,
+			a.cv_url, a.cover_letter, a.status, a.auto_rejected, a.created_at, a.updated_at,
+			a.stage, a.stage_changed_at,
 			COALESCE(av.first_name || ' ' || av.last_name, u.email) as candidate_name,
 			av.profile_picture_url as candidate_photo,
 			av.status as verification_status
@@ -97,7 +165,8 @@ func (r *applicationRepo) GetByJobID(ctx context.Context, jobID int64) ([]domain
 		var app domain.Application
 		if err := rows.Scan(
 			&app.ID, &app.JobID, &app.CandidateUserID, &app.AccountVerificationID,
-			&app.CvURL, &app.CoverLetter, &app.Status, &app.CreatedAt, &app.UpdatedAt,
+			&app.CvURL, &app.CoverLetter, &app.Status, &app.AutoRejected, &app.CreatedAt, &app.UpdatedAt,
+			&app.Stage, &app.StageChangedAt,
 			&app.CandidateName, &app.CandidatePhoto, &app.VerificationStatus,
 		); err != nil {
 			return nil, err
@@ -148,15 +217,271 @@ func (r *applicationRepo) CheckExists(ctx context.Context, jobID int64, userID s
 	return exists, err
 }
 
-// UpdateStatus updates the status of an application and sets updated_at
-func (r *applicationRepo) UpdateStatus(ctx context.Context, id int64, status string) error {
-	query := `UPDATE applications SET status = $2, updated_at = $3 WHERE id = $1`
-	result, err := r.db.Exec(ctx, query, id, status, time.Now())
+// UpdateStatus updates the status of an application and sets updated_at. The
+// pipeline stage follows the status and the move is recorded in the stage history.
+func (r *applicationRepo) UpdateStatus(ctx context.Context, id int64, status, changedBy string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var currentStage string
+	err = tx.QueryRow(ctx, `SELECT stage FROM applications WHERE id = $1 FOR UPDATE`, id).Scan(&currentStage)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+
+	now := time.Now()
+	stage := domain.ApplicationStageForStatus(currentStage, status)
+	query := `
+		UPDATE applications SET status = $2, updated_at = $3, stage = $4,
+			stage_changed_at = CASE WHEN stage = $4 THEN stage_changed_at ELSE $3 END
+		WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, id, status, now, stage); err != nil {
+		return err
+	}
+
+	if stage != currentStage {
+		change := &domain.ApplicationStageChange{ApplicationID: id, FromStage: &currentStage, ToStage: stage, ChangedAt: now}
+		if changedBy != "" {
+			change.ChangedBy = &changedBy
+		}
+		if err := insertStageChange(ctx, tx, change); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetAnswers returns the screening answers stored with an application
+func (r *applicationRepo) GetAnswers(ctx context.Context, applicationID int64) ([]domain.ApplicationAnswer, error) {
+	query := `
+		SELECT id, application_id, question_id, prompt, question_type, answer_text, file_url, is_disqualifying
+		FROM application_answers
+		WHERE application_id = $1
+		ORDER BY id`
+
+	rows, err := r.db.Query(ctx, query, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []domain.ApplicationAnswer
+	for rows.Next() {
+		var a domain.ApplicationAnswer
+		if err := rows.Scan(
+			&a.ID, &a.ApplicationID, &a.QuestionID, &a.Prompt, &a.Type, &a.Answer, &a.FileURL, &a.Disqualifying,
+		); err != nil {
+			return nil, err
+		}
+		answers = append(answers, a)
+	}
+	return answers, rows.Err()
+}
+
+// ListForExport retrieves a job's applicants for an employer export. Contact details
+// are only selected when the company has revealed the candidate and the candidate's
+// profile is not paused.
+func (r *applicationRepo) ListForExport(ctx context.Context, jobID, companyID int64) ([]domain.ApplicationExportRow, error) {
+	query := `
+		SELECT
+			a.id, a.candidate_user_id,
+			TRIM(CONCAT(av.first_name, ' ', av.last_name)) AS candidate_name,
+			CASE WHEN c.unlocked THEN u.email END AS email,
+			CASE WHEN c.unlocked THEN av.phone END AS phone,
+			c.unlocked,
+			a.status, a.stage, a.auto_rejected, a.created_at, a.updated_at,
+			av.status, av.japanese_level, av.domicile_city,
+			a.cv_url, a.cover_letter,
+			(
+				SELECT STRING_AGG(aa.prompt || ': ' || COALESCE(aa.answer_text, aa.file_url, ''), E'\n' ORDER BY aa.id)
+				FROM application_answers aa
+				WHERE aa.application_id = a.id
+			) AS screening_answers
+		FROM applications a
+		JOIN users u ON a.candidate_user_id = u.id
+		LEFT JOIN account_verifications av ON a.account_verification_id = av.id
+		CROSS JOIN LATERAL (
+			SELECT EXISTS (
+				SELECT 1 FROM candidate_contact_reveals cr
+				WHERE cr.company_id = $2 AND cr.candidate_user_id = a.candidate_user_id
+			) AND (u.paused_until IS NULL OR u.paused_until <= NOW()) AS unlocked
+		) c
+		WHERE a.job_id = $1
+		ORDER BY a.created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, jobID, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exportRows := []domain.ApplicationExportRow{}
+	for rows.Next() {
+		var row domain.ApplicationExportRow
+		if err := rows.Scan(
+			&row.ApplicationID, &row.CandidateUserID, &row.CandidateName,
+			&row.Email, &row.Phone, &row.ContactUnlocked,
+			&row.Status, &row.Stage, &row.AutoRejected, &row.AppliedAt, &row.UpdatedAt,
+			&row.VerificationStatus, &row.JapaneseLevel, &row.DomicileCity,
+			&row.CvURL, &row.CoverLetter, &row.ScreeningAnswers,
+		); err != nil {
+			return nil, err
+		}
+		exportRows = append(exportRows, row)
+	}
+	return exportRows, rows.Err()
+}
+
+// ListCandidateHistory reads one page of applications joined with their jobs,
+// then loads the page's stage history, calls and sent feedback in one query each
+func (r *applicationRepo) ListCandidateHistory(ctx context.Context, userID string, filter domain.ApplicationHistoryFilter) ([]domain.CandidateApplicationView, int64, error) {
+	args := []interface{}{userID}
+	where := "a.candidate_user_id = $1"
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += " AND a.status = $2"
+	}
+
+	// SortBy and SortOrder are validated by the usecase
+	orderBy := "a.updated_at"
+	if filter.SortBy == domain.ApplicationHistorySortApplied {
+		orderBy = "a.created_at"
+	}
+	direction := "DESC"
+	if filter.SortOrder == "asc" {
+		direction = "ASC"
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`
+		SELECT a.id, a.status, a.stage, a.stage_changed_at, a.auto_rejected, a.created_at, a.updated_at,
+			j.id, j.title, j.location, j.employment_type, j.company_status,
+			CASE WHEN j.is_confidential THEN NULL ELSE cp.company_name END,
+			CASE WHEN j.is_confidential THEN NULL ELSE cp.logo_url END,
+			COUNT(*) OVER ()
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN company_profiles cp ON cp.id = j.company_id
+		WHERE %s
+		ORDER BY %s %s, a.id %s
+		LIMIT $%d OFFSET $%d`, where, orderBy, direction, direction, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	views := []domain.CandidateApplicationView{}
+	index := map[int64]int{}
+	var total int64
+	for rows.Next() {
+		var v domain.CandidateApplicationView
+		if err := rows.Scan(
+			&v.ID, &v.Status, &v.Stage, &v.StageChangedAt, &v.AutoRejected, &v.AppliedAt, &v.UpdatedAt,
+			&v.Job.ID, &v.Job.Title, &v.Job.Location, &v.Job.EmploymentType, &v.Job.Status,
+			&v.Job.CompanyName, &v.Job.CompanyLogoURL, &total,
+		); err != nil {
+			return nil, 0, err
+		}
+		v.StageHistory = []domain.CandidateStageChange{}
+		v.Interviews = []domain.CandidateApplicationInterview{}
+		index[v.ID] = len(views)
+		views = append(views, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(views) == 0 {
+		return views, total, nil
+	}
+
+	ids := make([]int64, 0, len(views))
+	for _, v := range views {
+		ids = append(ids, v.ID)
+	}
+	if err := r.loadCandidateStageHistory(ctx, ids, views, index); err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadCandidateInterviews(ctx, userID, ids, views, index); err != nil {
+		return nil, 0, err
+	}
+	if err := r.loadCandidateFeedback(ctx, ids, views, index); err != nil {
+		return nil, 0, err
+	}
+	return views, total, nil
+}
+
+func (r *applicationRepo) loadCandidateStageHistory(ctx context.Context, ids []int64, views []domain.CandidateApplicationView, index map[int64]int) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT application_id, from_stage, to_stage, changed_at
+		FROM application_stage_history
+		WHERE application_id = ANY($1)
+		ORDER BY application_id, changed_at, id`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var appID int64
+		var c domain.CandidateStageChange
+		if err := rows.Scan(&appID, &c.FromStage, &c.ToStage, &c.ChangedAt); err != nil {
+			return err
+		}
+		c.Status = domain.ApplicationStageStatus[c.ToStage]
+		v := &views[index[appID]]
+		v.StageHistory = append(v.StageHistory, c)
+	}
+	return rows.Err()
+}
+
+func (r *applicationRepo) loadCandidateInterviews(ctx context.Context, userID string, ids []int64, views []domain.CandidateApplicationView, index map[int64]int) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT application_id, id, scheduled_at, duration_minutes, status
+		FROM screening_calls
+		WHERE candidate_user_id = $1 AND application_id = ANY($2)
+		ORDER BY scheduled_at, id`, userID, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var appID int64
+		var i domain.CandidateApplicationInterview
+		if err := rows.Scan(&appID, &i.ID, &i.ScheduledAt, &i.DurationMinutes, &i.Status); err != nil {
+			return err
+		}
+		v := &views[index[appID]]
+		v.Interviews = append(v.Interviews, i)
+	}
+	return rows.Err()
+}
+
+// loadCandidateFeedback only reads delivered feedback; feedback under review or
+// suppressed by the candidate's opt-out never reaches them
+func (r *applicationRepo) loadCandidateFeedback(ctx context.Context, ids []int64, views []domain.CandidateApplicationView, index map[int64]int) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT application_id, template_keys, custom_message, sent_at
+		FROM interview_feedback
+		WHERE application_id = ANY($1) AND status = 'SENT'`, ids)
 	if err != nil {
 		return err
 	}
-	if result.RowsAffected() == 0 {
-		return domain.ErrNotFound
+	defer rows.Close()
+
+	for rows.Next() {
+		var appID int64
+		var f domain.CandidateApplicationFeedback
+		if err := rows.Scan(&appID, &f.TemplateKeys, &f.CustomMessage, &f.SentAt); err != nil {
+			return err
+		}
+		views[index[appID]].Feedback = &f
 	}
-	return nil
+	return rows.Err()
 }
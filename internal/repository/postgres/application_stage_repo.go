@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type applicationStageRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewApplicationStageRepository creates a new application stage repository
+func NewApplicationStageRepository(db *pgxpool.Pool) domain.ApplicationStageRepository {
+	return &applicationStageRepo{db: db}
+}
+
+// insertStageChange records a stage move and fills in its ID
+func insertStageChange(ctx context.Context, tx pgx.Tx, change *domain.ApplicationStageChange) error {
+	return tx.QueryRow(ctx, `
+		INSERT INTO application_stage_history (application_id, from_stage, to_stage, note, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, change.ApplicationID, change.FromStage, change.ToStage, change.Note, change.ChangedBy, change.ChangedAt,
+	).Scan(&change.ID)
+}
+
+func (r *applicationStageRepo) GetTarget(ctx context.Context, applicationID int64) (*domain.ApplicationStageTarget, error) {
+	query := `
+		SELECT a.id, a.job_id, j.title, j.company_id, a.candidate_user_id,
+			a.stage, a.status, a.stage_changed_at, j.pipeline_stages
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		WHERE a.id = $1`
+
+	var t domain.ApplicationStageTarget
+	err := r.db.QueryRow(ctx, query, applicationID).Scan(
+		&t.ApplicationID, &t.JobID, &t.JobTitle, &t.JobCompanyID, &t.CandidateUserID,
+		&t.Stage, &t.Status, &t.StageChangedAt, &t.PipelineStages,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *applicationStageRepo) MoveStage(ctx context.Context, change *domain.ApplicationStageChange, status string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if change.ChangedAt.IsZero() {
+		change.ChangedAt = time.Now()
+	}
+
+	// Only move if nobody else did since the caller read the stage
+	result, err := tx.Exec(ctx, `
+		UPDATE applications SET stage = $3, status = $4, stage_changed_at = $5, updated_at = $5
+		WHERE id = $1 AND stage = $2`,
+		change.ApplicationID, change.FromStage, change.ToStage, status, change.ChangedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrApplicationStageConflict
+	}
+
+	if err := insertStageChange(ctx, tx, change); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *applicationStageRepo) ListHistory(ctx context.Context, applicationID int64) ([]domain.ApplicationStageChange, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, application_id, from_stage, to_stage, note, changed_by, changed_at
+		FROM application_stage_history
+		WHERE application_id = $1
+		ORDER BY changed_at, id`, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []domain.ApplicationStageChange{}
+	for rows.Next() {
+		var c domain.ApplicationStageChange
+		if err := rows.Scan(&c.ID, &c.ApplicationID, &c.FromStage, &c.ToStage, &c.Note, &c.ChangedBy, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, c)
+	}
+	return history, rows.Err()
+}
+
+func (r *applicationStageRepo) GetPipelineStages(ctx context.Context, jobID int64) ([]string, error) {
+	var stages []string
+	err := r.db.QueryRow(ctx, `SELECT pipeline_stages FROM jobs WHERE id = $1`, jobID).Scan(&stages)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return stages, nil
+}
+
+func (r *applicationStageRepo) SetPipelineStages(ctx context.Context, jobID int64, optional []string) error {
+	result, err := r.db.Exec(ctx, `UPDATE jobs SET pipeline_stages = $2, updated_at = NOW() WHERE id = $1`, jobID, optional)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *applicationStageRepo) ListFunnels(ctx context.Context, companyID int64, jobID *int64) ([]domain.JobFunnel, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT j.id, j.title, a.stage, COUNT(a.id)
+		FROM jobs j
+		LEFT JOIN applications a ON a.job_id = j.id
+		WHERE j.company_id = $1 AND ($2::BIGINT IS NULL OR j.id = $2)
+		GROUP BY j.id, j.title, a.stage
+		ORDER BY j.id DESC`, companyID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	funnels := []domain.JobFunnel{}
+	byJob := map[int64]int{}
+	for rows.Next() {
+		var (
+			id    int64
+			title string
+			stage *string
+			count int
+		)
+		if err := rows.Scan(&id, &title, &stage, &count); err != nil {
+			return nil, err
+		}
+
+		i, ok := byJob[id]
+		if !ok {
+			f := domain.JobFunnel{JobID: id, JobTitle: title, Stages: make(map[string]int, len(domain.ApplicationStages))}
+			for _, s := range domain.ApplicationStages {
+				f.Stages[s] = 0
+			}
+			funnels = append(funnels, f)
+			i = len(funnels) - 1
+			byJob[id] = i
+		}
+		// A job without applications yields one row with a NULL stage
+		if stage != nil {
+			funnels[i].Stages[*stage] = count
+			funnels[i].Total += count
+		}
+	}
+	return funnels, rows.Err()
+}
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type atsExportAuditRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewATSExportAuditRepository creates the append-only ATS export audit repository
+func NewATSExportAuditRepository(db *pgxpool.Pool) domain.ATSExportAuditRepository {
+	return &atsExportAuditRepo{db: db}
+}
+
+func (r *atsExportAuditRepo) Create(ctx context.Context, audit *domain.ATSExportAudit) error {
+	filter, err := json.Marshal(audit.Filter)
+	if err != nil {
+		return err
+	}
+	columns := audit.Columns
+	if columns == nil {
+		columns = []string{}
+	}
+	candidates := make([]string, len(audit.Rows))
+	fingerprints := make([]string, len(audit.Rows))
+	for i, row := range audit.Rows {
+		candidates[i], fingerprints[i] = row.CandidateUserID, row.Fingerprint
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO ats_export_audits (
+			actor_user_id, format, filter, columns, row_count, manifest_hash, ip_address, user_agent
+		) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''))
+		RETURNING id, created_at`,
+		audit.ActorUserID, audit.Format, filter, columns, audit.RowCount, audit.ManifestHash,
+		audit.IPAddress, audit.UserAgent,
+	).Scan(&audit.ID, &audit.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if len(audit.Rows) > 0 {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO ats_export_audit_rows (audit_id, candidate_user_id, fingerprint)
+			SELECT $1, r.candidate_user_id, r.fingerprint
+			FROM unnest($2::uuid[], $3::text[]) AS r(candidate_user_id, fingerprint)`,
+			audit.ID, candidates, fingerprints)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// atsExportAuditSelect reads an audit with its actor's email; $1 is the candidate
+// whose fingerprint is returned, or NULL
+const atsExportAuditSelect = `
+		SELECT a.id, a.actor_user_id, u.email, a.format, a.filter, a.columns, a.row_count,
+			a.manifest_hash, COALESCE(a.ip_address, ''), COALESCE(a.user_agent, ''), a.created_at,
+			(SELECT r.fingerprint FROM ats_export_audit_rows r
+			 WHERE r.audit_id = a.id AND r.candidate_user_id = $1::uuid)
+		FROM ats_export_audits a
+		LEFT JOIN users u ON u.id = a.actor_user_id`
+
+func scanATSExportAudit(row pgx.Row) (*domain.ATSExportAudit, error) {
+	var a domain.ATSExportAudit
+	var filter []byte
+	if err := row.Scan(&a.ID, &a.ActorUserID, &a.ActorEmail, &a.Format, &filter, &a.Columns, &a.RowCount,
+		&a.ManifestHash, &a.IPAddress, &a.UserAgent, &a.CreatedAt, &a.Fingerprint); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filter, &a.Filter); err != nil {
+		return nil, fmt.Errorf("invalid filter on export audit %d: %w", a.ID, err)
+	}
+	return &a, nil
+}
+
+func (r *atsExportAuditRepo) List(ctx context.Context, filter domain.ATSExportAuditFilter) ([]domain.ATSExportAudit, int64, error) {
+	var candidate *string
+	if filter.CandidateUserID != "" {
+		candidate = &filter.CandidateUserID
+	}
+	where := ` WHERE ($1::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM ats_export_audit_rows r WHERE r.audit_id = a.id AND r.candidate_user_id = $1::uuid
+		))`
+	args := []interface{}{candidate}
+	argIndex := 2
+
+	if filter.ActorUserID != "" {
+		where += fmt.Sprintf(" AND a.actor_user_id = $%d", argIndex)
+		args = append(args, filter.ActorUserID)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM ats_export_audits a`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := atsExportAuditSelect + where +
+		fmt.Sprintf(" ORDER BY a.created_at DESC, a.id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	audits := []domain.ATSExportAudit{}
+	for rows.Next() {
+		a, err := scanATSExportAudit(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		audits = append(audits, *a)
+	}
+	return audits, total, rows.Err()
+}
+
+func (r *atsExportAuditRepo) Get(ctx context.Context, id int64) (*domain.ATSExportAudit, error) {
+	audit, err := scanATSExportAudit(r.db.QueryRow(ctx, atsExportAuditSelect+` WHERE a.id = $2`, nil, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT candidate_user_id, fingerprint
+		FROM ats_export_audit_rows
+		WHERE audit_id = $1
+		ORDER BY candidate_user_id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audit.Rows = []domain.ATSExportAuditRow{}
+	for rows.Next() {
+		var row domain.ATSExportAuditRow
+		if err := rows.Scan(&row.CandidateUserID, &row.Fingerprint); err != nil {
+			return nil, err
+		}
+		audit.Rows = append(audit.Rows, row)
+	}
+	return audit, rows.Err()
+}
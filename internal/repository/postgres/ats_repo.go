@@ -2,14 +2,78 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// visaReadyExpr matches candidates holding a Certificate of Eligibility that can
+// still be used for a visa application
+const visaReadyExpr = "(av.coe_status = 'ISSUED' AND (av.coe_expiry_date IS NULL OR av.coe_expiry_date >= CURRENT_DATE))"
+
+// expiringDocumentsExpr lists the candidate's critical documents (passport, JLPT,
+// medical check, CoE) that have expired or expire within the flag window
+var expiringDocumentsExpr = fmt.Sprintf(`(
+				SELECT ARRAY_AGG(DISTINCT e.document_type ORDER BY e.document_type)
+				FROM candidate_document_expiries e
+				WHERE e.user_id = av.user_id AND e.critical
+				  AND e.expiry_date <= CURRENT_DATE + %d
+			)`, domain.CriticalDocumentExpiryWindowDays)
+
+// profileCompletenessSectionExprs match a complete section of the candidate's
+// profile the way VerificationUsecase.GetProfileCompleteness checks it
+var profileCompletenessSectionExprs = map[string]string{
+	domain.ProfileSectionPhoto:           "NULLIF(TRIM(av.profile_picture_url), '') IS NOT NULL",
+	domain.ProfileSectionCV:              "NULLIF(TRIM(av.cv_url), '') IS NOT NULL",
+	domain.ProfileSectionJLPTCertificate: "NULLIF(TRIM(av.japanese_certificate_url), '') IS NOT NULL",
+	domain.ProfileSectionWorkExperience: `(EXISTS (SELECT 1 FROM work_experiences we WHERE we.user_id = av.user_id)
+				OR EXISTS (SELECT 1 FROM japan_work_experiences jwe WHERE jwe.account_verification_id = av.id))`,
+	domain.ProfileSectionPreferences: "(av.expected_salary IS NOT NULL AND COALESCE(cardinality(av.preferred_locations), 0) > 0)",
+}
+
+// profileCompletenessExpr scores the candidate's profile 0-100 with
+// domain.ProfileCompletenessWeights
+var profileCompletenessExpr = func() string {
+	terms := make([]string, 0, len(domain.ProfileCompletenessWeights))
+	for _, w := range domain.ProfileCompletenessWeights {
+		terms = append(terms, fmt.Sprintf("CASE WHEN %s THEN %d ELSE 0 END", profileCompletenessSectionExprs[w.Section], w.Points))
+	}
+	return "LEAST(" + strings.Join(terms, "\n\t\t\t\t+ ") + ", 100)"
+}()
+
+// maskedNameExpr reduces the candidate's name to initials ("B. S.")
+const maskedNameExpr = `COALESCE(NULLIF(CONCAT_WS(' ',
+					UPPER(LEFT(NULLIF(TRIM(av.first_name), ''), 1)) || '.',
+					UPPER(LEFT(NULLIF(TRIM(av.last_name), ''), 1)) || '.'), ''), 'Candidate')`
+
+// appliedToCompanyExpr matches candidates (av) who applied to one of the jobs of
+// the company in parameter companyArg
+func appliedToCompanyExpr(companyArg int) string {
+	return fmt.Sprintf(`EXISTS (
+				SELECT 1 FROM applications a
+				JOIN jobs j ON j.id = a.job_id
+				WHERE a.candidate_user_id = av.user_id AND j.company_id = $%d
+			)`, companyArg)
+}
+
+// candidateVisibleExpr matches candidates (av) whose profile visibility lets the
+// company in parameter companyArg find them: companies applied to, and with
+// ALL_VERIFIED_EMPLOYERS any company above BASIC. HIDDEN candidates match no company.
+func candidateVisibleExpr(companyArg int) string {
+	return fmt.Sprintf(`(av.profile_visibility <> '%[2]s' AND (%[3]s OR (
+				av.profile_visibility = '%[4]s' AND EXISTS (
+					SELECT 1 FROM company_profiles vc WHERE vc.id = $%[1]d AND vc.verification_level <> '%[5]s'
+				)
+			)))`,
+		companyArg, domain.ProfileVisibilityHidden, appliedToCompanyExpr(companyArg),
+		domain.ProfileVisibilityAllEmployers, domain.CompanyLevelBasic)
+}
+
 type atsRepo struct {
 	db *pgxpool.Pool
 }
@@ -21,11 +85,54 @@ func NewATSRepository(db *pgxpool.Pool) domain.ATSRepository {
 
 // SearchCandidates fetches candidates matching the filter criteria
 func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter) ([]domain.ATSCandidate, int64, error) {
+	return r.searchCandidates(ctx, filter, 0)
+}
+
+// SearchCandidatesForCompany fetches candidates matching the filter whose profile
+// visibility lets the company find them (see candidateVisibleExpr). Name and photo are
+// masked in SQL, so they never leave the database, unless the candidate granted
+// the company access or the company unlocked the candidate's contact.
+func (r *atsRepo) SearchCandidatesForCompany(ctx context.Context, companyID int64, filter domain.ATSFilter) ([]domain.ATSCandidate, int64, error) {
+	return r.searchCandidates(ctx, filter, companyID)
+}
+
+// searchCandidates runs the ATS search; a non-zero companyID applies the employer scope
+func (r *atsRepo) searchCandidates(ctx context.Context, filter domain.ATSFilter, companyID int64) ([]domain.ATSCandidate, int64, error) {
 	// Build dynamic WHERE clause
-	conditions := []string{"av.status IN ('VERIFIED', 'SUBMITTED')"}
+	conditions := []string{
+		"av.status IN ('VERIFIED', 'SUBMITTED')",
+		// Exclude candidates who have paused their profile
+		"NOT EXISTS (SELECT 1 FROM users pu WHERE pu.id = av.user_id AND pu.paused_until > NOW())",
+		// Exclude stale profiles: away too long, or back but not refreshed yet
+		"av.profile_refresh_required_at IS NULL",
+		fmt.Sprintf("NOT EXISTS (SELECT 1 FROM users su WHERE su.id = av.user_id AND COALESCE(su.last_active_at, su.updated_at) < NOW() - INTERVAL '%d months')",
+			domain.CandidateStaleAfterMonths),
+	}
 	args := []interface{}{}
 	argIndex := 1
 
+	// Employer scope: candidates visible to the company only, PII masked without access
+	appliedExpr := "FALSE"
+	maskedExpr := "FALSE"
+	if companyID != 0 {
+		appliedExpr = appliedToCompanyExpr(argIndex)
+		maskedExpr = fmt.Sprintf(`NOT EXISTS (
+				SELECT 1 FROM candidate_pii_grants g WHERE g.candidate_user_id = av.user_id AND g.company_id = $%[1]d
+			) AND NOT EXISTS (
+				SELECT 1 FROM candidate_contact_reveals cr WHERE cr.candidate_user_id = av.user_id AND cr.company_id = $%[1]d
+			)`, argIndex)
+		conditions = append(conditions, candidateVisibleExpr(argIndex))
+		args = append(args, companyID)
+		argIndex++
+	}
+
+	// Regional admin scope
+	if filter.ScopeProvinces != nil {
+		conditions = append(conditions, provinceScopeCondition("av.domicile_province", argIndex))
+		args = append(args, filter.ScopeProvinces)
+		argIndex++
+	}
+
 	// Japanese Proficiency Group
 	if len(filter.JapaneseLevels) > 0 {
 		placeholders := make([]string, len(filter.JapaneseLevels))
@@ -57,6 +164,15 @@ func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter)
 		}
 	}
 
+	if len(filter.CohortIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM candidate_cohort_members ccm WHERE ccm.account_verification_id = av.id AND ccm.cohort_id = ANY($%d))",
+			argIndex,
+		))
+		args = append(args, filter.CohortIDs)
+		argIndex++
+	}
+
 	// Competency & Language Group
 	if len(filter.EnglishCertTypes) > 0 {
 		placeholders := make([]string, len(filter.EnglishCertTypes))
@@ -173,28 +289,66 @@ func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter)
 		argIndex++
 	}
 
-	whereClause := strings.Join(conditions, " AND ")
+	if filter.PhoneVerifiedOnly {
+		conditions = append(conditions, phoneVerifiedExpr)
+	}
 
-	// Sorting
-	sortColumn := "av.verified_at"
-	sortOrder := "DESC NULLS LAST"
-	switch filter.SortBy {
-	case "japanese_level":
-		sortColumn = "av.japanese_level"
-	case "age":
-		sortColumn = "av.birth_date"
-		sortOrder = "ASC NULLS LAST" // Older birth date = younger age
-		if filter.SortOrder == "desc" {
-			sortOrder = "DESC NULLS LAST"
+	// Visa Readiness Group
+	if len(filter.CoEStatuses) > 0 {
+		placeholders := make([]string, len(filter.CoEStatuses))
+		for i, status := range filter.CoEStatuses {
+			placeholders[i] = fmt.Sprintf("$%d", argIndex)
+			args = append(args, status)
+			argIndex++
 		}
-	case "expected_salary":
-		sortColumn = "av.expected_salary"
+		conditions = append(conditions, fmt.Sprintf("av.coe_status IN (%s)", strings.Join(placeholders, ",")))
 	}
-	if filter.SortOrder == "asc" && filter.SortBy != "age" {
-		sortOrder = "ASC NULLS LAST"
+
+	if filter.VisaReadyOnly {
+		conditions = append(conditions, visaReadyExpr)
 	}
 
-	orderClause := fmt.Sprintf("%s %s", sortColumn, sortOrder)
+	if filter.ExpiringDocumentsOnly {
+		conditions = append(conditions, expiringDocumentsExpr+" IS NOT NULL")
+	}
+
+	// SSW passes use JSONB containment so the GIN index applies; level 2 is the
+	// highest SSW level, so "minimum level 2" means an exact level 2 match.
+	// With no sectors, an empty object matches any recorded pass.
+	if len(filter.SSWSectors) > 0 || filter.SSWLevel != nil {
+		sectors := filter.SSWSectors
+		if len(sectors) == 0 {
+			sectors = []string{""}
+		}
+		matches := make([]string, len(sectors))
+		for i, sector := range sectors {
+			fields := []string{}
+			if sector != "" {
+				fields = append(fields, fmt.Sprintf("'sector', $%d::text", argIndex))
+				args = append(args, sector)
+				argIndex++
+			}
+			if filter.SSWLevel != nil && *filter.SSWLevel >= 2 {
+				fields = append(fields, "'level', 2")
+			}
+			matches[i] = fmt.Sprintf("av.ssw_exam_results @> jsonb_build_array(jsonb_build_object(%s))", strings.Join(fields, ", "))
+		}
+		conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+
+	// Skill quiz best score
+	if filter.QuizID != nil && filter.QuizMinScore != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM candidate_quiz_scores qs WHERE qs.user_id = av.user_id AND qs.quiz_id = $%d AND qs.best_score >= $%d)",
+			argIndex, argIndex+1,
+		))
+		args = append(args, *filter.QuizID, *filter.QuizMinScore)
+		argIndex += 2
+	}
+
+	whereClause := strings.Join(conditions, " AND ")
+
+	orderClause := atsOrderClause(filter)
 
 	// Count query
 	countQuery := fmt.Sprintf(`
@@ -227,13 +381,23 @@ func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter)
 	}
 	offset := (page - 1) * pageSize
 
+	// Best score on the selected quiz; its argument is added after the count query,
+	// which does not reference it
+	quizScoreExpr := "NULL::INT"
+	if filter.QuizID != nil {
+		quizScoreExpr = fmt.Sprintf("(SELECT qs.best_score FROM candidate_quiz_scores qs WHERE qs.user_id = av.user_id AND qs.quiz_id = $%d)", argIndex)
+		args = append(args, *filter.QuizID)
+		argIndex++
+	}
+
 	// Main query
 	query := fmt.Sprintf(`
 		SELECT DISTINCT ON (av.user_id)
 			av.user_id,
 			av.id AS verification_id,
-			COALESCE(CONCAT(av.first_name, ' ', av.last_name), 'Unknown') AS full_name,
-			av.profile_picture_url,
+			CASE WHEN `+maskedExpr+` THEN `+maskedNameExpr+`
+				ELSE COALESCE(CONCAT(av.first_name, ' ', av.last_name), 'Unknown') END AS full_name,
+			CASE WHEN `+maskedExpr+` THEN NULL ELSE av.profile_picture_url END AS profile_picture_url,
 			EXTRACT(YEAR FROM AGE(av.birth_date))::INT AS age,
 			av.gender,
 			av.domicile_city,
@@ -251,6 +415,17 @@ func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter)
 			av.status AS verification_status,
 			av.verified_at,
 			av.submitted_at,
+			`+phoneVerifiedExpr+` AS phone_verified,
+			av.coe_status,
+			av.coe_expiry_date,
+			`+visaReadyExpr+` AS visa_ready,
+			(
+				SELECT ARRAY_AGG(DISTINCT r->>'sector' ORDER BY r->>'sector')
+				FROM jsonb_array_elements(av.ssw_exam_results) r
+			) AS ssw_sectors,
+			`+expiringDocumentsExpr+` AS expiring_documents,
+			`+quizScoreExpr+` AS quiz_score,
+			`+profileCompletenessExpr+` AS profile_completeness,
 			(
 				SELECT job_title FROM work_experiences 
 				WHERE user_id = av.user_id 
@@ -261,7 +436,9 @@ func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter)
 				SELECT ARRAY_AGG(s.name) FROM candidate_skills cs2
 				JOIN skills s ON cs2.skill_id = s.id
 				WHERE cs2.user_id = av.user_id
-			) AS skills
+			) AS skills,
+			`+appliedExpr+` AS applied_to_company,
+			`+maskedExpr+` AS pii_masked
 		FROM account_verifications av
 		LEFT JOIN candidate_profiles cp ON av.user_id = cp.user_id
 		LEFT JOIN lpk_list lpk ON av.lpk_id = lpk.id
@@ -312,8 +489,18 @@ func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter)
 			&c.VerificationStatus,
 			&c.VerifiedAt,
 			&c.SubmittedAt,
+			&c.PhoneVerified,
+			&c.CoEStatus,
+			&c.CoEExpiryDate,
+			&c.VisaReady,
+			&c.SSWSectors,
+			&c.ExpiringDocuments,
+			&c.QuizScore,
+			&c.ProfileCompleteness,
 			&c.LastPosition,
 			&skills,
+			&c.AppliedToCompany,
+			&c.PIIMasked,
 		)
 		if err != nil {
 			continue
@@ -329,6 +516,33 @@ func (r *atsRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter)
 	return candidates, total, nil
 }
 
+// atsOrderClause orders the search by filter.SortBy; unknown keys sort by
+// verification date, newest first
+func atsOrderClause(filter domain.ATSFilter) string {
+	sortColumn := "av.verified_at"
+	sortOrder := "DESC NULLS LAST"
+	switch filter.SortBy {
+	case "japanese_level":
+		sortColumn = "av.japanese_level"
+	case "age":
+		sortColumn = "av.birth_date"
+		sortOrder = "ASC NULLS LAST" // Older birth date = younger age
+		if filter.SortOrder == "desc" {
+			sortOrder = "DESC NULLS LAST"
+		}
+	case "expected_salary":
+		sortColumn = "av.expected_salary"
+	case "quiz_score":
+		sortColumn = "quiz_score" // output column, see quizScoreExpr
+	case "profile_completeness":
+		sortColumn = "profile_completeness" // output column, see profileCompletenessExpr
+	}
+	if filter.SortOrder == "asc" && filter.SortBy != "age" {
+		sortOrder = "ASC NULLS LAST"
+	}
+	return fmt.Sprintf("%s %s", sortColumn, sortOrder)
+}
+
 // GetFilterOptions returns all available filter options
 func (r *atsRepo) GetFilterOptions(ctx context.Context) (*domain.ATSFilterOptions, error) {
 	options := &domain.ATSFilterOptions{
@@ -336,6 +550,8 @@ func (r *atsRepo) GetFilterOptions(ctx context.Context) (*domain.ATSFilterOption
 		Genders:          []string{domain.GenderMale, domain.GenderFemale},
 		EducationLevels:  []string{domain.EducationHighSchool, domain.EducationDiploma, domain.EducationBachelor, domain.EducationMaster},
 		EnglishCertTypes: []string{domain.EnglishCertTOEFL, domain.EnglishCertIELTS, domain.EnglishCertTOEIC},
+		CoEStatuses:      domain.ValidCoEStatuses,
+		SSWSectors:       domain.ValidSSWSectors,
 	}
 
 	// Get domicile cities
@@ -424,3 +640,103 @@ func (r *atsRepo) GetDistinctMajorFields(ctx context.Context) ([]string, error)
 
 	return majors, nil
 }
+
+// GetTalentPoolSettings returns the candidate's profile visibility and PII grants
+func (r *atsRepo) GetTalentPoolSettings(ctx context.Context, candidateUserID string) (*domain.TalentPoolSettings, error) {
+	settings := &domain.TalentPoolSettings{Grants: []domain.PIIGrant{}}
+	err := r.db.QueryRow(ctx,
+		`SELECT profile_visibility FROM account_verifications WHERE user_id = $1`,
+		candidateUserID,
+	).Scan(&settings.Visibility)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	settings.Visible = settings.Visibility == domain.ProfileVisibilityAllEmployers
+
+	rows, err := r.db.Query(ctx, `
+		SELECT g.company_id, cp.company_name, g.granted_at
+		FROM candidate_pii_grants g
+		JOIN company_profiles cp ON cp.id = g.company_id
+		WHERE g.candidate_user_id = $1
+		ORDER BY g.granted_at DESC
+	`, candidateUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var g domain.PIIGrant
+		if err := rows.Scan(&g.CompanyID, &g.CompanyName, &g.GrantedAt); err != nil {
+			return nil, err
+		}
+		settings.Grants = append(settings.Grants, g)
+	}
+	return settings, rows.Err()
+}
+
+// SetTalentPoolVisible opts the candidate in to or out of the talent pool; opting
+// out of it leaves a HIDDEN profile hidden
+func (r *atsRepo) SetTalentPoolVisible(ctx context.Context, candidateUserID string, visible bool) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE account_verifications SET
+			profile_visibility = CASE
+				WHEN $2 THEN $3
+				WHEN profile_visibility = $3 THEN $4
+				ELSE profile_visibility
+			END,
+			updated_at = NOW()
+		WHERE user_id = $1`,
+		candidateUserID, visible, domain.ProfileVisibilityAllEmployers, domain.ProfileVisibilityAppliedCompanies,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetProfileVisibility sets which employers can find the candidate
+func (r *atsRepo) SetProfileVisibility(ctx context.Context, candidateUserID, visibility string) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE account_verifications SET profile_visibility = $2, updated_at = NOW() WHERE user_id = $1`,
+		candidateUserID, visibility,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GrantPIIAccess lets the company see the candidate's name and photo; granting twice is a no-op
+func (r *atsRepo) GrantPIIAccess(ctx context.Context, candidateUserID string, companyID int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO candidate_pii_grants (candidate_user_id, company_id)
+		VALUES ($1, $2)
+		ON CONFLICT (candidate_user_id, company_id) DO NOTHING
+	`, candidateUserID, companyID)
+	return err
+}
+
+// RevokePIIAccess removes the company's grant (ErrNotFound when there was none)
+func (r *atsRepo) RevokePIIAccess(ctx context.Context, candidateUserID string, companyID int64) error {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM candidate_pii_grants WHERE candidate_user_id = $1 AND company_id = $2`,
+		candidateUserID, companyID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
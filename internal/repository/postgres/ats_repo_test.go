@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestATSOrderClause(t *testing.T) {
+	cases := []struct {
+		sortBy    string
+		sortOrder string
+		want      string
+	}{
+		{"", "", "av.verified_at DESC NULLS LAST"},
+		{"unknown", "asc", "av.verified_at ASC NULLS LAST"},
+		{"japanese_level", "desc", "av.japanese_level DESC NULLS LAST"},
+		{"age", "", "av.birth_date ASC NULLS LAST"},
+		{"age", "desc", "av.birth_date DESC NULLS LAST"},
+		{"expected_salary", "asc", "av.expected_salary ASC NULLS LAST"},
+		{"quiz_score", "desc", "quiz_score DESC NULLS LAST"},
+		{"profile_completeness", "", "profile_completeness DESC NULLS LAST"},
+		{"profile_completeness", "desc", "profile_completeness DESC NULLS LAST"},
+		{"profile_completeness", "asc", "profile_completeness ASC NULLS LAST"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.sortBy+" "+tc.sortOrder, func(t *testing.T) {
+			got := atsOrderClause(domain.ATSFilter{SortBy: tc.sortBy, SortOrder: tc.sortOrder})
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestProfileCompletenessExpr(t *testing.T) {
+	assert.True(t, strings.HasPrefix(profileCompletenessExpr, "LEAST("), "the score is capped at 100")
+	assert.True(t, strings.HasSuffix(profileCompletenessExpr, ", 100)"), "the score is capped at 100")
+
+	for _, w := range domain.ProfileCompletenessWeights {
+		expr, ok := profileCompletenessSectionExprs[w.Section]
+		if assert.True(t, ok, "no SQL check for section %s", w.Section) {
+			assert.Contains(t, profileCompletenessExpr, fmt.Sprintf("CASE WHEN %s THEN %d ELSE 0 END", expr, w.Points))
+		}
+	}
+	assert.Len(t, profileCompletenessSectionExprs, len(domain.ProfileCompletenessWeights))
+}
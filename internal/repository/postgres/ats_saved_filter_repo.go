@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type atsSavedFilterRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewATSSavedFilterRepository(db *pgxpool.Pool) domain.ATSSavedFilterRepository {
+	return &atsSavedFilterRepo{db: db}
+}
+
+const atsSavedFilterSelect = `
+	SELECT f.id, f.owner_user_id, u.email, f.name, f.filter, f.shared, f.use_count, f.last_used_at, f.created_at, f.updated_at
+	FROM ats_saved_filters f
+	JOIN users u ON u.id = f.owner_user_id`
+
+func scanATSSavedFilter(row pgx.Row) (*domain.ATSSavedFilter, error) {
+	var f domain.ATSSavedFilter
+	var filter []byte
+	if err := row.Scan(&f.ID, &f.OwnerUserID, &f.OwnerEmail, &f.Name, &filter, &f.Shared, &f.UseCount, &f.LastUsedAt, &f.CreatedAt, &f.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filter, &f.Filter); err != nil {
+		return nil, fmt.Errorf("invalid filter on saved filter %d: %w", f.ID, err)
+	}
+	return &f, nil
+}
+
+func (r *atsSavedFilterRepo) Create(ctx context.Context, f *domain.ATSSavedFilter) error {
+	filter, err := json.Marshal(f.Filter)
+	if err != nil {
+		return err
+	}
+	err = r.db.QueryRow(ctx, `
+		INSERT INTO ats_saved_filters (owner_user_id, name, filter, shared)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`,
+		f.OwnerUserID, f.Name, filter, f.Shared,
+	).Scan(&f.ID, &f.CreatedAt, &f.UpdatedAt)
+	return mapATSSavedFilterError(err)
+}
+
+func (r *atsSavedFilterRepo) Update(ctx context.Context, f *domain.ATSSavedFilter) error {
+	filter, err := json.Marshal(f.Filter)
+	if err != nil {
+		return err
+	}
+	err = r.db.QueryRow(ctx, `
+		UPDATE ats_saved_filters
+		SET name = $2, filter = $3, shared = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		f.ID, f.Name, filter, f.Shared,
+	).Scan(&f.UpdatedAt)
+	return mapATSSavedFilterError(err)
+}
+
+func (r *atsSavedFilterRepo) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM ats_saved_filters WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *atsSavedFilterRepo) GetByID(ctx context.Context, id int64) (*domain.ATSSavedFilter, error) {
+	f, err := scanATSSavedFilter(r.db.QueryRow(ctx, atsSavedFilterSelect+` WHERE f.id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return f, err
+}
+
+func (r *atsSavedFilterRepo) ListVisible(ctx context.Context, userID string) ([]domain.ATSSavedFilter, error) {
+	rows, err := r.db.Query(ctx, atsSavedFilterSelect+`
+		WHERE f.owner_user_id = $1 OR f.shared
+		ORDER BY f.owner_user_id = $1 DESC, f.last_used_at DESC NULLS LAST, f.name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filters := []domain.ATSSavedFilter{}
+	for rows.Next() {
+		f, err := scanATSSavedFilter(rows)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, *f)
+	}
+	return filters, rows.Err()
+}
+
+func (r *atsSavedFilterRepo) MarkUsed(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE ats_saved_filters SET use_count = use_count + 1, last_used_at = NOW()
+		WHERE id = $1`, id)
+	return err
+}
+
+func mapATSSavedFilterError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return apperror.Conflict("You already have a saved filter with this name")
+	}
+	return err
+}
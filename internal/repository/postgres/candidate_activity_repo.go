@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type candidateActivityRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCandidateActivityRepository creates a new candidate activity feed repository
+func NewCandidateActivityRepository(db *pgxpool.Pool) domain.CandidateActivityRepository {
+	return &candidateActivityRepo{db: db}
+}
+
+// insertCandidateActivity records a feed entry in the transaction of the change it describes
+func insertCandidateActivity(ctx context.Context, tx pgx.Tx, a *domain.CandidateActivity) error {
+	return tx.QueryRow(ctx, `
+		INSERT INTO candidate_activities (user_id, activity_type, data, occurred_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, a.UserID, a.ActivityType, a.Data, a.OccurredAt,
+	).Scan(&a.ID)
+}
+
+func (r *candidateActivityRepo) ListByUser(ctx context.Context, userID string, beforeID int64, limit int) ([]domain.CandidateActivity, error) {
+	where := " WHERE user_id = $1"
+	args := []interface{}{userID}
+	argIndex := 2
+
+	if beforeID > 0 {
+		where += fmt.Sprintf(" AND id < $%d", argIndex)
+		args = append(args, beforeID)
+		argIndex++
+	}
+
+	query := `SELECT id, user_id, activity_type, data, occurred_at FROM candidate_activities` + where +
+		fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", argIndex)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := []domain.CandidateActivity{}
+	for rows.Next() {
+		var a domain.CandidateActivity
+		if err := rows.Scan(&a.ID, &a.UserID, &a.ActivityType, &a.Data, &a.OccurredAt); err != nil {
+			return nil, err
+		}
+		activities = append(activities, a)
+	}
+	return activities, rows.Err()
+}
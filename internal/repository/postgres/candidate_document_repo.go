@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type candidateDocumentRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCandidateDocumentRepository creates a new candidate document vault repository
+func NewCandidateDocumentRepository(db *pgxpool.Pool) domain.CandidateDocumentRepository {
+	return &candidateDocumentRepo{db: db}
+}
+
+const candidateDocumentColumns = `cd.id, cd.user_id, cd.document_type, cd.file_url, cd.document_number, cd.label,
+	cd.issue_date, cd.expiry_date, cd.status, cd.review_notes, cd.reviewed_by, cd.reviewed_at, cd.created_at, cd.updated_at`
+
+func candidateDocumentDest(d *domain.CandidateDocument) []any {
+	return []any{
+		&d.ID, &d.UserID, &d.DocumentType, &d.FileURL, &d.DocumentNumber, &d.Label,
+		&d.IssueDate, &d.ExpiryDate, &d.Status, &d.ReviewNotes, &d.ReviewedBy, &d.ReviewedAt, &d.CreatedAt, &d.UpdatedAt,
+	}
+}
+
+func (r *candidateDocumentRepo) Create(ctx context.Context, d *domain.CandidateDocument) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO candidate_documents (user_id, document_type, file_url, document_number, label, issue_date, expiry_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at, updated_at`,
+		d.UserID, d.DocumentType, d.FileURL, d.DocumentNumber, d.Label, d.IssueDate, d.ExpiryDate,
+	).Scan(&d.ID, &d.Status, &d.CreatedAt, &d.UpdatedAt)
+}
+
+func (r *candidateDocumentRepo) CountByUser(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM candidate_documents WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *candidateDocumentRepo) ListByUser(ctx context.Context, userID string) ([]domain.CandidateDocument, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+candidateDocumentColumns+`
+		FROM candidate_documents cd
+		WHERE cd.user_id = $1
+		ORDER BY cd.document_type, cd.created_at DESC, cd.id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	docs := []domain.CandidateDocument{}
+	for rows.Next() {
+		var d domain.CandidateDocument
+		if err := rows.Scan(candidateDocumentDest(&d)...); err != nil {
+			return nil, err
+		}
+		docs = append(docs, d)
+	}
+	return docs, rows.Err()
+}
+
+func (r *candidateDocumentRepo) GetByID(ctx context.Context, id int64) (*domain.CandidateDocument, error) {
+	var d domain.CandidateDocument
+	err := r.db.QueryRow(ctx, `SELECT `+candidateDocumentColumns+` FROM candidate_documents cd WHERE cd.id = $1`, id).
+		Scan(candidateDocumentDest(&d)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *candidateDocumentRepo) Delete(ctx context.Context, userID string, id int64) (string, error) {
+	var fileURL string
+	err := r.db.QueryRow(ctx, `
+		DELETE FROM candidate_documents WHERE id = $1 AND user_id = $2
+		RETURNING file_url`, id, userID,
+	).Scan(&fileURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", domain.ErrNotFound
+	}
+	return fileURL, err
+}
+
+func (r *candidateDocumentRepo) List(ctx context.Context, filter domain.CandidateDocumentFilter) ([]domain.AdminCandidateDocument, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND cd.status = $%d", argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.DocumentType != "" {
+		where += fmt.Sprintf(" AND cd.document_type = $%d", argIndex)
+		args = append(args, filter.DocumentType)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM candidate_documents cd`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Oldest first, so the queue is worked through in upload order
+	query := `
+		SELECT ` + candidateDocumentColumns + `, u.email, av.first_name, av.last_name
+		FROM candidate_documents cd
+		JOIN users u ON u.id = cd.user_id
+		LEFT JOIN account_verifications av ON av.user_id = cd.user_id` + where +
+		fmt.Sprintf(" ORDER BY cd.created_at, cd.id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	docs := []domain.AdminCandidateDocument{}
+	for rows.Next() {
+		var d domain.AdminCandidateDocument
+		dest := append(candidateDocumentDest(&d.CandidateDocument), &d.Email, &d.FirstName, &d.LastName)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, 0, err
+		}
+		docs = append(docs, d)
+	}
+	return docs, total, rows.Err()
+}
+
+func (r *candidateDocumentRepo) Review(ctx context.Context, d *domain.CandidateDocument) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE candidate_documents
+		SET status = $2, review_notes = $3, reviewed_by = $4, reviewed_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		d.ID, d.Status, d.ReviewNotes, d.ReviewedBy, d.ReviewedAt,
+	).Scan(&d.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
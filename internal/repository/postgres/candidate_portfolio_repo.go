@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type candidatePortfolioRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCandidatePortfolioRepository creates a new candidate portfolio repository
+func NewCandidatePortfolioRepository(db *pgxpool.Pool) domain.CandidatePortfolioRepository {
+	return &candidatePortfolioRepo{db: db}
+}
+
+const candidatePortfolioColumns = `id, user_id, item_type, title, description, file_url, link_url, size_bytes,
+	position, include_in_resume, created_at, updated_at`
+
+func candidatePortfolioDest(p *domain.CandidatePortfolioItem) []any {
+	return []any{
+		&p.ID, &p.UserID, &p.ItemType, &p.Title, &p.Description, &p.FileURL, &p.LinkURL, &p.SizeBytes,
+		&p.Position, &p.IncludeInResume, &p.CreatedAt, &p.UpdatedAt,
+	}
+}
+
+func (r *candidatePortfolioRepo) Create(ctx context.Context, p *domain.CandidatePortfolioItem) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO candidate_portfolio_items (user_id, item_type, title, description, file_url, link_url, size_bytes, include_in_resume, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+			(SELECT COALESCE(MAX(position) + 1, 0) FROM candidate_portfolio_items WHERE user_id = $1))
+		RETURNING id, position, created_at, updated_at`,
+		p.UserID, p.ItemType, p.Title, p.Description, p.FileURL, p.LinkURL, p.SizeBytes, p.IncludeInResume,
+	).Scan(&p.ID, &p.Position, &p.CreatedAt, &p.UpdatedAt)
+}
+
+func (r *candidatePortfolioRepo) ListByUser(ctx context.Context, userID string) ([]domain.CandidatePortfolioItem, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+candidatePortfolioColumns+`
+		FROM candidate_portfolio_items
+		WHERE user_id = $1
+		ORDER BY position, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []domain.CandidatePortfolioItem{}
+	for rows.Next() {
+		var p domain.CandidatePortfolioItem
+		if err := rows.Scan(candidatePortfolioDest(&p)...); err != nil {
+			return nil, err
+		}
+		items = append(items, p)
+	}
+	return items, rows.Err()
+}
+
+func (r *candidatePortfolioRepo) GetByID(ctx context.Context, userID string, id int64) (*domain.CandidatePortfolioItem, error) {
+	var p domain.CandidatePortfolioItem
+	err := r.db.QueryRow(ctx, `
+		SELECT `+candidatePortfolioColumns+`
+		FROM candidate_portfolio_items
+		WHERE id = $1 AND user_id = $2`, id, userID,
+	).Scan(candidatePortfolioDest(&p)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *candidatePortfolioRepo) Update(ctx context.Context, p *domain.CandidatePortfolioItem) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE candidate_portfolio_items
+		SET title = $3, description = $4, link_url = $5, include_in_resume = $6, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING updated_at`,
+		p.ID, p.UserID, p.Title, p.Description, p.LinkURL, p.IncludeInResume,
+	).Scan(&p.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+func (r *candidatePortfolioRepo) Delete(ctx context.Context, userID string, id int64) (*string, error) {
+	var fileURL *string
+	err := r.db.QueryRow(ctx, `
+		DELETE FROM candidate_portfolio_items WHERE id = $1 AND user_id = $2
+		RETURNING file_url`, id, userID,
+	).Scan(&fileURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return fileURL, err
+}
+
+func (r *candidatePortfolioRepo) Reorder(ctx context.Context, userID string, ids []int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the items so concurrent reorders apply one after the other
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM candidate_portfolio_items WHERE user_id = $1 ORDER BY id FOR UPDATE`, userID)
+	if err != nil {
+		return err
+	}
+	var current []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		current = append(current, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	sorted := slices.Clone(ids)
+	slices.Sort(sorted)
+	if !slices.Equal(current, sorted) {
+		return domain.ErrNotFound
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE candidate_portfolio_items p
+		SET position = o.position - 1, updated_at = NOW()
+		FROM unnest($2::BIGINT[]) WITH ORDINALITY AS o(id, position)
+		WHERE p.id = o.id AND p.user_id = $1`, userID, ids)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -89,6 +89,7 @@ func (r *candidateRepository) GetFullProfile(ctx context.Context, userID string)
 		Certificates:    []domain.CandidateCertificate{},
 		Skills:          []domain.Skill{},
 		SkillIDs:        []int{},
+		QuizScores:      []domain.CandidateQuizScore{},
 	}
 
 	// 2. Get Dictionary Details
@@ -188,6 +189,31 @@ func (r *candidateRepository) GetFullProfile(ctx context.Context, userID string)
 		result.Certificates = append(result.Certificates, c)
 	}
 
+	// 6. Get Skill Quiz Scores (best result per quiz)
+	quizQuery := `SELECT s.quiz_id, q.title, q.category, s.best_score, s.passed, s.attempt_count, s.last_attempt_at
+	              FROM candidate_quiz_scores s JOIN skill_quizzes q ON q.id = s.quiz_id
+	              WHERE s.user_id = $1 ORDER BY q.category, q.title`
+	quizRows, err := r.db.Query(ctx, quizQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quiz scores: %w", err)
+	}
+	defer quizRows.Close()
+
+	for quizRows.Next() {
+		var q domain.CandidateQuizScore
+		if err := quizRows.Scan(&q.QuizID, &q.QuizTitle, &q.Category, &q.BestScore, &q.Passed, &q.AttemptCount, &q.LastAttemptAt); err != nil {
+			return nil, err
+		}
+		result.QuizScores = append(result.QuizScores, q)
+	}
+
+	// 7. Alumni status: placed while a placement is active, alumni once all have ended
+	alumniQuery := `SELECT CASE WHEN bool_or(status = 'ACTIVE') THEN 'PLACED' WHEN COUNT(*) > 0 THEN 'ALUMNI' END
+	                FROM placements WHERE candidate_user_id = $1`
+	if err := r.db.QueryRow(ctx, alumniQuery, userID).Scan(&result.AlumniStatus); err != nil {
+		return nil, fmt.Errorf("failed to fetch alumni status: %w", err)
+	}
+
 	return result, nil
 }
 
@@ -367,3 +393,67 @@ func (r *candidateRepository) GetAllSkills(ctx context.Context) ([]domain.Skill,
 	}
 	return skills, nil
 }
+
+// =================================================================================================
+// PROFILE REFRESH
+// =================================================================================================
+
+func (r *candidateRepository) GetProfileFreshness(ctx context.Context, userID string) (*domain.CandidateProfileFreshness, error) {
+	var f domain.CandidateProfileFreshness
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(u.last_active_at, u.updated_at), av.profile_refresh_required_at
+		FROM account_verifications av
+		JOIN users u ON u.id = av.user_id
+		WHERE av.user_id = $1 AND av.role = 'CANDIDATE'`, userID,
+	).Scan(&f.LastActiveAt, &f.RefreshRequiredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *candidateRepository) FlagProfileRefresh(ctx context.Context, userID string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE account_verifications SET profile_refresh_required_at = $2
+		WHERE user_id = $1 AND role = 'CANDIDATE' AND profile_refresh_required_at IS NULL`, userID, at)
+	return err
+}
+
+func (r *candidateRepository) GetProfileRefresh(ctx context.Context, userID string) (*domain.CandidateProfileRefresh, error) {
+	var p domain.CandidateProfileRefresh
+	err := r.db.QueryRow(ctx, `
+		SELECT profile_refresh_required_at, profile_refreshed_at, available_start_date, japan_return_date,
+			expected_salary, COALESCE(preferred_locations, '{}')
+		FROM account_verifications
+		WHERE user_id = $1 AND role = 'CANDIDATE'`, userID,
+	).Scan(&p.RefreshRequiredAt, &p.LastRefreshedAt, &p.AvailableStartDate, &p.JapanReturnDate,
+		&p.ExpectedSalary, &p.PreferredLocations)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	p.RefreshRequired = p.RefreshRequiredAt != nil
+	return &p, nil
+}
+
+func (r *candidateRepository) CompleteProfileRefresh(ctx context.Context, userID string, update domain.CandidateProfileRefreshUpdate, at time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE account_verifications
+		SET available_start_date = $2, expected_salary = $3,
+			preferred_locations = COALESCE($4, preferred_locations),
+			profile_refresh_required_at = NULL, profile_refreshed_at = $5, updated_at = $5
+		WHERE user_id = $1 AND role = 'CANDIDATE'`,
+		userID, update.AvailableStartDate, update.ExpectedSalary, update.PreferredLocations, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type careerPageRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCareerPageRepository(db *pgxpool.Pool) domain.CareerPageRepository {
+	return &careerPageRepo{db: db}
+}
+
+func (r *careerPageRepo) GetByCompanyID(ctx context.Context, companyID int64) (*domain.CareerPage, error) {
+	query := `
+		SELECT cp.company_id, p.slug, cp.primary_color, cp.secondary_color, cp.banner_url, cp.intro_video_url,
+		       cp.headline, cp.culture_sections, cp.benefits, cp.is_published, cp.updated_at
+		FROM company_career_pages cp
+		JOIN company_profiles p ON p.id = cp.company_id
+		WHERE cp.company_id = $1
+	`
+
+	var page domain.CareerPage
+	err := r.db.QueryRow(ctx, query, companyID).Scan(
+		&page.CompanyID, &page.Slug, &page.PrimaryColor, &page.SecondaryColor, &page.BannerURL, &page.IntroVideoURL,
+		&page.Headline, &page.CultureSections, &page.Benefits, &page.IsPublished, &page.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &page, nil
+}
+
+func (r *careerPageRepo) Upsert(ctx context.Context, page *domain.CareerPage) error {
+	query := `
+		INSERT INTO company_career_pages (
+			company_id, primary_color, secondary_color, banner_url, intro_video_url,
+			headline, culture_sections, benefits, is_published
+		) VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7::jsonb, '[]'::jsonb), $8, $9)
+		ON CONFLICT (company_id) DO UPDATE SET
+			primary_color = EXCLUDED.primary_color,
+			secondary_color = EXCLUDED.secondary_color,
+			banner_url = EXCLUDED.banner_url,
+			intro_video_url = EXCLUDED.intro_video_url,
+			headline = EXCLUDED.headline,
+			culture_sections = EXCLUDED.culture_sections,
+			benefits = EXCLUDED.benefits,
+			is_published = EXCLUDED.is_published,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	benefits := page.Benefits
+	if benefits == nil {
+		benefits = []string{}
+	}
+	return r.db.QueryRow(ctx, query,
+		page.CompanyID, page.PrimaryColor, page.SecondaryColor, page.BannerURL, page.IntroVideoURL,
+		page.Headline, page.CultureSections, benefits, page.IsPublished,
+	).Scan(&page.UpdatedAt)
+}
+
+func (r *careerPageRepo) GetSlug(ctx context.Context, companyID int64) (*string, error) {
+	var slug *string
+	err := r.db.QueryRow(ctx, `SELECT slug FROM company_profiles WHERE id = $1`, companyID).Scan(&slug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return slug, nil
+}
+
+func (r *careerPageRepo) SetSlug(ctx context.Context, companyID int64, slug string) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE company_profiles SET slug = $2, updated_at = NOW() WHERE id = $1`,
+		companyID, slug,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return apperror.Conflict("This URL is already taken by another company")
+		}
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *careerPageRepo) SlugTaken(ctx context.Context, slug string, exceptCompanyID int64) (bool, error) {
+	var taken bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM company_profiles WHERE LOWER(slug) = LOWER($1) AND id <> $2)`,
+		slug, exceptCompanyID,
+	).Scan(&taken)
+	return taken, err
+}
+
+func (r *careerPageRepo) GetCompanyBySlug(ctx context.Context, slug string) (*domain.CompanyProfile, error) {
+	query := `
+		SELECT id, user_id, company_name, logo_url, location, company_story,
+		       founded, founder, headquarters, employee_count, website,
+		       industry, description, hide_company_details,
+		       gallery_image_1, gallery_image_2, gallery_image_3,
+		       created_at, updated_at
+		FROM company_profiles
+		WHERE LOWER(slug) = LOWER($1)`
+
+	var profile domain.CompanyProfile
+	err := r.db.QueryRow(ctx, query, slug).Scan(
+		&profile.ID, &profile.UserID, &profile.CompanyName,
+		&profile.LogoURL, &profile.Location, &profile.CompanyStory,
+		&profile.Founded, &profile.Founder, &profile.Headquarters,
+		&profile.EmployeeCount, &profile.Website,
+		&profile.Industry, &profile.Description, &profile.HideCompanyDetails,
+		&profile.GalleryImage1, &profile.GalleryImage2, &profile.GalleryImage3,
+		&profile.CreatedAt, &profile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// ListActiveJobs returns the company's active jobs, newest first
+func (r *careerPageRepo) ListActiveJobs(ctx context.Context, companyID int64, limit int) ([]domain.Job, error) {
+	return listPublishedCompanyJobs(ctx, r.db, companyID, limit)
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type cohortRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCohortRepository(db *pgxpool.Pool) domain.CohortRepository {
+	return &cohortRepo{db: db}
+}
+
+// cohortMemberJoin joins a cohort's members who still belong to its LPK
+const cohortMemberJoin = `
+	LEFT JOIN candidate_cohort_members m ON m.cohort_id = c.id
+	LEFT JOIN account_verifications av ON av.id = m.account_verification_id AND av.lpk_id = c.lpk_id`
+
+const cohortSelect = `
+	SELECT c.id, c.lpk_id, l.name, c.name,
+		to_char(c.start_date, 'YYYY-MM-DD'), to_char(c.end_date, 'YYYY-MM-DD'), to_char(c.target_departure_date, 'YYYY-MM-DD'),
+		c.created_by, c.created_at, c.updated_at,
+		COUNT(av.id),
+		COUNT(av.id) FILTER (WHERE av.status = 'VERIFIED'),
+		COUNT(av.id) FILTER (WHERE EXISTS(SELECT 1 FROM applications a WHERE a.candidate_user_id = av.user_id AND a.status = 'accepted'))
+	FROM candidate_cohorts c
+	JOIN lpk_list l ON l.id = c.lpk_id` + cohortMemberJoin
+
+func scanCohort(row pgx.Row) (*domain.Cohort, error) {
+	var c domain.Cohort
+	err := row.Scan(
+		&c.ID, &c.LPKID, &c.LPKName, &c.Name, &c.StartDate, &c.EndDate, &c.TargetDepartureDate,
+		&c.CreatedBy, &c.CreatedAt, &c.UpdatedAt,
+		&c.Progress.MemberCount, &c.Progress.VerifiedCount, &c.Progress.PlacedCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *cohortRepo) Create(ctx context.Context, c *domain.Cohort) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO candidate_cohorts (lpk_id, name, start_date, end_date, target_departure_date, created_by)
+		VALUES ($1, $2, $3::date, $4::date, $5::date, $6)
+		RETURNING id, created_at, updated_at`,
+		c.LPKID, c.Name, c.StartDate, c.EndDate, c.TargetDepartureDate, c.CreatedBy,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	return mapCohortError(err)
+}
+
+func (r *cohortRepo) Update(ctx context.Context, c *domain.Cohort) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE candidate_cohorts
+		SET name = $2, start_date = $3::date, end_date = $4::date, target_departure_date = $5::date, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`,
+		c.ID, c.Name, c.StartDate, c.EndDate, c.TargetDepartureDate,
+	).Scan(&c.UpdatedAt)
+	return mapCohortError(err)
+}
+
+func (r *cohortRepo) Delete(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM candidate_cohorts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *cohortRepo) GetByID(ctx context.Context, id int64) (*domain.Cohort, error) {
+	c, err := scanCohort(r.db.QueryRow(ctx, cohortSelect+`
+		WHERE c.id = $1
+		GROUP BY c.id, l.name`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return c, err
+}
+
+func (r *cohortRepo) List(ctx context.Context, lpkID *int64) ([]domain.Cohort, error) {
+	rows, err := r.db.Query(ctx, cohortSelect+`
+		WHERE $1::int IS NULL OR c.lpk_id = $1
+		GROUP BY c.id, l.name
+		ORDER BY c.start_date DESC, c.id DESC`, lpkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cohorts := []domain.Cohort{}
+	for rows.Next() {
+		c, err := scanCohort(rows)
+		if err != nil {
+			return nil, err
+		}
+		cohorts = append(cohorts, *c)
+	}
+	return cohorts, rows.Err()
+}
+
+func (r *cohortRepo) AssignMembers(ctx context.Context, cohortID, lpkID int64, refs []int64, assignedBy string) ([]int64, error) {
+	rows, err := r.db.Query(ctx, `
+		INSERT INTO candidate_cohort_members (account_verification_id, cohort_id, assigned_by)
+		SELECT av.id, $1, $4
+		FROM account_verifications av
+		WHERE av.id = ANY($3) AND av.lpk_id = $2 AND av.role = 'CANDIDATE'
+		ON CONFLICT (account_verification_id) DO UPDATE
+		SET cohort_id = EXCLUDED.cohort_id, assigned_by = EXCLUDED.assigned_by, assigned_at = NOW()
+		RETURNING account_verification_id`,
+		cohortID, lpkID, refs, assignedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assigned := []int64{}
+	for rows.Next() {
+		var ref int64
+		if err := rows.Scan(&ref); err != nil {
+			return nil, err
+		}
+		assigned = append(assigned, ref)
+	}
+	return assigned, rows.Err()
+}
+
+func (r *cohortRepo) RemoveMember(ctx context.Context, cohortID, candidateRef int64) error {
+	tag, err := r.db.Exec(ctx, `
+		DELETE FROM candidate_cohort_members WHERE cohort_id = $1 AND account_verification_id = $2`,
+		cohortID, candidateRef,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ListMembers returns the anonymized progress of the cohort's members, like the
+// LPK portal's candidate list
+func (r *cohortRepo) ListMembers(ctx context.Context, cohortID, lpkID int64, page, pageSize int) ([]domain.LPKCandidateProgress, int64, error) {
+	const from = `
+		FROM candidate_cohort_members m
+		JOIN account_verifications av ON av.id = m.account_verification_id
+		WHERE m.cohort_id = $1 AND av.lpk_id = $2`
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from, cohortID, lpkID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+lpkCandidateProgressColumns+from+`
+		ORDER BY m.assigned_at DESC, av.id
+		LIMIT $3 OFFSET $4`,
+		cohortID, lpkID, pageSize, (page-1)*pageSize,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	defer rows.Close()
+
+	members, err := scanLPKCandidateProgress(rows)
+	return members, total, err
+}
+
+func mapCohortError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return apperror.Conflict("The LPK already has a cohort with this name")
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type companyLocationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyLocationRepository(db *pgxpool.Pool) domain.CompanyLocationRepository {
+	return &companyLocationRepo{db: db}
+}
+
+const companyLocationColumns = `id, company_id, name, address, city, province, postal_code, country,
+	latitude, longitude, contact_name, contact_phone, contact_email, created_at, updated_at`
+
+func scanCompanyLocation(row pgx.Row) (*domain.CompanyLocation, error) {
+	var l domain.CompanyLocation
+	err := row.Scan(
+		&l.ID, &l.CompanyID, &l.Name, &l.Address, &l.City, &l.Province, &l.PostalCode, &l.Country,
+		&l.Latitude, &l.Longitude, &l.ContactName, &l.ContactPhone, &l.ContactEmail, &l.CreatedAt, &l.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// ListByCompany returns the company's offices in the order they were added
+func (r *companyLocationRepo) ListByCompany(ctx context.Context, companyID int64) ([]domain.CompanyLocation, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+companyLocationColumns+`
+		FROM company_locations WHERE company_id = $1 ORDER BY id`, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := []domain.CompanyLocation{}
+	for rows.Next() {
+		l, err := scanCompanyLocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, *l)
+	}
+	return locations, rows.Err()
+}
+
+func (r *companyLocationRepo) GetByID(ctx context.Context, companyID, id int64) (*domain.CompanyLocation, error) {
+	l, err := scanCompanyLocation(r.db.QueryRow(ctx, `SELECT `+companyLocationColumns+`
+		FROM company_locations WHERE id = $1 AND company_id = $2`, id, companyID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return l, err
+}
+
+func (r *companyLocationRepo) CountByCompany(ctx context.Context, companyID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM company_locations WHERE company_id = $1`, companyID).Scan(&count)
+	return count, err
+}
+
+func (r *companyLocationRepo) Create(ctx context.Context, l *domain.CompanyLocation) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO company_locations (company_id, name, address, city, province, postal_code, country,
+			latitude, longitude, contact_name, contact_phone, contact_email, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $13)
+		RETURNING id`,
+		l.CompanyID, l.Name, l.Address, l.City, l.Province, l.PostalCode, l.Country,
+		l.Latitude, l.Longitude, l.ContactName, l.ContactPhone, l.ContactEmail, l.CreatedAt,
+	).Scan(&l.ID)
+}
+
+func (r *companyLocationRepo) Update(ctx context.Context, l *domain.CompanyLocation) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE company_locations SET
+			name = $3, address = $4, city = $5, province = $6, postal_code = $7, country = $8,
+			latitude = $9, longitude = $10, contact_name = $11, contact_phone = $12, contact_email = $13,
+			updated_at = $14
+		WHERE id = $1 AND company_id = $2`,
+		l.ID, l.CompanyID, l.Name, l.Address, l.City, l.Province, l.PostalCode, l.Country,
+		l.Latitude, l.Longitude, l.ContactName, l.ContactPhone, l.ContactEmail, l.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes the office; jobs.location_id is cleared by ON DELETE SET NULL
+func (r *companyLocationRepo) Delete(ctx context.Context, companyID, id int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM company_locations WHERE id = $1 AND company_id = $2`, id, companyID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type companyMemberRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyMemberRepository(db *pgxpool.Pool) domain.CompanyMemberRepository {
+	return &companyMemberRepo{db: db}
+}
+
+const companyMemberSelect = `
+	SELECT m.id, m.company_id, m.user_id::TEXT, u.email, m.role, m.invited_by::TEXT, m.joined_at, m.updated_at
+	FROM company_members m
+	JOIN users u ON u.id = m.user_id`
+
+func scanCompanyMember(row pgx.Row) (*domain.CompanyMember, error) {
+	var m domain.CompanyMember
+	if err := row.Scan(&m.ID, &m.CompanyID, &m.UserID, &m.Email, &m.Role, &m.InvitedBy, &m.JoinedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+const companyInvitationColumns = `id, company_id, email, role, invited_by::TEXT, expires_at, created_at`
+
+func scanCompanyInvitation(row pgx.Row) (*domain.CompanyInvitation, error) {
+	var inv domain.CompanyInvitation
+	if err := row.Scan(&inv.ID, &inv.CompanyID, &inv.Email, &inv.Role, &inv.InvitedBy, &inv.ExpiresAt, &inv.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// GetMembership prefers the user's own profile over a joined company, matching
+// companyProfileRepo.GetByUserID
+func (r *companyMemberRepo) GetMembership(ctx context.Context, userID string) (*domain.CompanyMembership, error) {
+	var m domain.CompanyMembership
+	err := r.db.QueryRow(ctx, `
+		SELECT cp.id, cp.company_name, t.role
+		FROM (
+			SELECT COALESCE(merged_into_id, id) AS company_id,
+			       CASE WHEN merged_into_id IS NULL THEN 'OWNER' ELSE 'RECRUITER' END AS role,
+			       0 AS rank
+			FROM company_profiles WHERE user_id = $1
+			UNION ALL
+			SELECT company_id, role, 1 FROM company_members WHERE user_id = $1
+		) t
+		JOIN company_profiles cp ON cp.id = t.company_id
+		ORDER BY t.rank
+		LIMIT 1`, userID,
+	).Scan(&m.CompanyID, &m.CompanyName, &m.Role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *companyMemberRepo) ListMembers(ctx context.Context, companyID int64) ([]domain.CompanyMember, error) {
+	rows, err := r.db.Query(ctx, companyMemberSelect+` WHERE m.company_id = $1 ORDER BY m.joined_at`, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []domain.CompanyMember{}
+	for rows.Next() {
+		m, err := scanCompanyMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *m)
+	}
+	return members, rows.Err()
+}
+
+func (r *companyMemberRepo) CountMembers(ctx context.Context, companyID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM company_members WHERE company_id = $1`, companyID).Scan(&count)
+	return count, err
+}
+
+func (r *companyMemberRepo) UpdateMemberRole(ctx context.Context, companyID int64, userID, role string) (*domain.CompanyMember, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE company_members SET role = $3, updated_at = NOW()
+		WHERE company_id = $1 AND user_id = $2`, companyID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, domain.ErrNotFound
+	}
+	m, err := scanCompanyMember(r.db.QueryRow(ctx, companyMemberSelect+` WHERE m.company_id = $1 AND m.user_id = $2`, companyID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return m, err
+}
+
+func (r *companyMemberRepo) RemoveMember(ctx context.Context, companyID int64, userID string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM company_members WHERE company_id = $1 AND user_id = $2`, companyID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *companyMemberRepo) CreateInvitation(ctx context.Context, inv *domain.CompanyInvitation, tokenHash string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE company_invitations SET revoked_at = $3
+		WHERE company_id = $1 AND LOWER(email) = LOWER($2) AND accepted_at IS NULL AND revoked_at IS NULL`,
+		inv.CompanyID, inv.Email, inv.CreatedAt); err != nil {
+		return err
+	}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO company_invitations (company_id, email, role, token_hash, invited_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`,
+		inv.CompanyID, inv.Email, inv.Role, tokenHash, inv.InvitedBy, inv.ExpiresAt, inv.CreatedAt,
+	).Scan(&inv.ID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *companyMemberRepo) ListPendingInvitations(ctx context.Context, companyID int64, now time.Time) ([]domain.CompanyInvitation, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+companyInvitationColumns+`
+		FROM company_invitations
+		WHERE company_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY created_at DESC`, companyID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []domain.CompanyInvitation{}
+	for rows.Next() {
+		inv, err := scanCompanyInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *inv)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *companyMemberRepo) RevokeInvitation(ctx context.Context, companyID, id int64, now time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE company_invitations SET revoked_at = $3
+		WHERE id = $1 AND company_id = $2 AND accepted_at IS NULL AND revoked_at IS NULL`, id, companyID, now)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *companyMemberRepo) GetPendingInvitationByTokenHash(ctx context.Context, tokenHash string, now time.Time) (*domain.CompanyInvitation, error) {
+	inv, err := scanCompanyInvitation(r.db.QueryRow(ctx, `SELECT `+companyInvitationColumns+`
+		FROM company_invitations
+		WHERE token_hash = $1 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $2`, tokenHash, now))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return inv, err
+}
+
+func (r *companyMemberRepo) AcceptInvitation(ctx context.Context, inv *domain.CompanyInvitation, userID string, now time.Time) (*domain.CompanyMember, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE company_invitations SET accepted_at = $2, accepted_by = $3
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`, inv.ID, now, userID)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	m := &domain.CompanyMember{CompanyID: inv.CompanyID, UserID: userID, Email: inv.Email, Role: inv.Role, InvitedBy: inv.InvitedBy, JoinedAt: now, UpdatedAt: now}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO company_members (company_id, user_id, role, invited_by, joined_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		RETURNING id`,
+		m.CompanyID, userID, m.Role, m.InvitedBy, now,
+	).Scan(&m.ID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
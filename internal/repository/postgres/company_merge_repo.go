@@ -0,0 +1,330 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type companyMergeRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCompanyMergeRepository creates a new company merge repository
+func NewCompanyMergeRepository(db *pgxpool.Pool) domain.CompanyMergeRepository {
+	return &companyMergeRepo{db: db}
+}
+
+// mergeCompany is the part of a company profile a merge reads under lock
+type mergeCompany struct {
+	id         int64
+	userID     string
+	name       string
+	archived   bool
+	logoURL    *string
+	gallery    [3]*string
+	hasGallery bool
+}
+
+func (r *companyMergeRepo) Merge(ctx context.Context, req domain.CompanyMergeRequest, mergedBy string) (*domain.CompanyMerge, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Lock both companies (in id order, so concurrent merges cannot deadlock)
+	rows, err := tx.Query(ctx, `
+		SELECT id, user_id, company_name, archived_at IS NOT NULL, logo_url,
+		       gallery_image_1, gallery_image_2, gallery_image_3
+		FROM company_profiles
+		WHERE id IN ($1, $2)
+		ORDER BY id
+		FOR UPDATE`, req.SurvivingCompanyID, req.DuplicateCompanyID)
+	if err != nil {
+		return nil, err
+	}
+	companies := map[int64]*mergeCompany{}
+	for rows.Next() {
+		c := &mergeCompany{}
+		if err := rows.Scan(&c.id, &c.userID, &c.name, &c.archived, &c.logoURL, &c.gallery[0], &c.gallery[1], &c.gallery[2]); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		c.hasGallery = c.gallery[0] != nil && *c.gallery[0] != ""
+		companies[c.id] = c
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	survivor, duplicate := companies[req.SurvivingCompanyID], companies[req.DuplicateCompanyID]
+	if survivor == nil || duplicate == nil {
+		return nil, domain.ErrNotFound
+	}
+	if survivor.archived || duplicate.archived {
+		return nil, apperror.Conflict("One of these companies has already been merged")
+	}
+
+	merge := &domain.CompanyMerge{
+		SurvivingCompanyID:   survivor.id,
+		SurvivingCompanyName: survivor.name,
+		DuplicateCompanyID:   duplicate.id,
+		DuplicateCompanyName: duplicate.name,
+		Reason:               req.Reason,
+	}
+	if mergedBy != "" {
+		merge.MergedBy = &mergedBy
+	}
+	var audit []domain.CompanyMergeAuditEntry
+
+	// 2. Applications hang off jobs, so count them before the jobs move
+	var applicationCount int
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		WHERE j.company_id = $1`, duplicate.id).Scan(&applicationCount); err != nil {
+		return nil, err
+	}
+
+	// 3. Jobs
+	jobIDs, err := collectInt64s(tx.Query(ctx, `
+		UPDATE jobs SET company_id = $1, updated_at = NOW()
+		WHERE company_id = $2
+		RETURNING id`, survivor.id, duplicate.id))
+	if err != nil {
+		return nil, err
+	}
+	audit = append(audit,
+		domain.CompanyMergeAuditEntry{
+			Entity: domain.MergeEntityJobs, Action: domain.MergeActionReassigned,
+			AffectedCount: len(jobIDs), Details: map[string]interface{}{"job_ids": jobIDs},
+		},
+		domain.CompanyMergeAuditEntry{
+			Entity: domain.MergeEntityApplications, Action: domain.MergeActionReassigned,
+			AffectedCount: applicationCount, Details: map[string]interface{}{"via": "jobs"},
+		},
+	)
+
+	// Offices move with the jobs based at them
+	locationIDs, err := collectInt64s(tx.Query(ctx, `
+		UPDATE company_locations SET company_id = $1, updated_at = NOW()
+		WHERE company_id = $2
+		RETURNING id`, survivor.id, duplicate.id))
+	if err != nil {
+		return nil, err
+	}
+	audit = append(audit, domain.CompanyMergeAuditEntry{
+		Entity: domain.MergeEntityLocations, Action: domain.MergeActionReassigned,
+		AffectedCount: len(locationIDs), Details: map[string]interface{}{"location_ids": locationIDs},
+	})
+
+	// 4. Team members: the duplicate's owner, owners of earlier merges into it and
+	// its invited members, who keep their role. Open invitations are revoked.
+	memberIDs, err := collectStrings(tx.Query(ctx, `
+		UPDATE company_profiles SET merged_into_id = $1, updated_at = NOW()
+		WHERE merged_into_id = $2
+		RETURNING user_id::TEXT`, survivor.id, duplicate.id))
+	if err != nil {
+		return nil, err
+	}
+	invitedIDs, err := collectStrings(tx.Query(ctx, `
+		UPDATE company_members SET company_id = $1, updated_at = NOW()
+		WHERE company_id = $2
+		RETURNING user_id::TEXT`, survivor.id, duplicate.id))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE company_invitations SET revoked_at = NOW()
+		WHERE company_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`, duplicate.id); err != nil {
+		return nil, err
+	}
+	memberIDs = append(append([]string{duplicate.userID}, memberIDs...), invitedIDs...)
+	audit = append(audit, domain.CompanyMergeAuditEntry{
+		Entity: domain.MergeEntityTeamMembers, Action: domain.MergeActionReassigned,
+		AffectedCount: len(memberIDs), Details: map[string]interface{}{"user_ids": memberIDs},
+	})
+
+	// 5. Gallery media: fill the survivor's empty logo/gallery from the duplicate
+	// (the gallery is all-or-nothing, so it is only copied as a set)
+	copied := []string{}
+	logoURL, gallery := survivor.logoURL, survivor.gallery
+	if (logoURL == nil || *logoURL == "") && duplicate.logoURL != nil && *duplicate.logoURL != "" {
+		logoURL = duplicate.logoURL
+		copied = append(copied, "logo_url")
+	}
+	if !survivor.hasGallery && duplicate.hasGallery {
+		gallery = duplicate.gallery
+		copied = append(copied, "gallery_image_1", "gallery_image_2", "gallery_image_3")
+	}
+	if len(copied) > 0 {
+		if _, err := tx.Exec(ctx, `
+			UPDATE company_profiles
+			SET logo_url = $2, gallery_image_1 = $3, gallery_image_2 = $4, gallery_image_3 = $5, updated_at = NOW()
+			WHERE id = $1`,
+			survivor.id, logoURL, gallery[0], gallery[1], gallery[2],
+		); err != nil {
+			return nil, err
+		}
+	}
+	audit = append(audit, domain.CompanyMergeAuditEntry{
+		Entity: domain.MergeEntityGalleryMedia, Action: domain.MergeActionCopied,
+		AffectedCount: len(copied), Details: map[string]interface{}{"fields": copied},
+	})
+
+	// 6. Archive the duplicate; its slug is released and its career page unpublished
+	if _, err := tx.Exec(ctx, `
+		UPDATE company_profiles
+		SET merged_into_id = $1, archived_at = NOW(), slug = NULL, updated_at = NOW()
+		WHERE id = $2`, survivor.id, duplicate.id); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE company_career_pages SET is_published = FALSE, updated_at = NOW()
+		WHERE company_id = $1`, duplicate.id); err != nil {
+		return nil, err
+	}
+	audit = append(audit, domain.CompanyMergeAuditEntry{
+		Entity: domain.MergeEntityCompanyProfile, Action: domain.MergeActionArchived,
+		AffectedCount: 1, Details: map[string]interface{}{"company_id": duplicate.id, "company_name": duplicate.name},
+	})
+
+	// 7. Merge record + audit entries
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO company_merges (surviving_company_id, duplicate_company_id, merged_by, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		survivor.id, duplicate.id, merge.MergedBy, merge.Reason,
+	).Scan(&merge.ID, &merge.CreatedAt); err != nil {
+		return nil, err
+	}
+	for i := range audit {
+		details, err := json.Marshal(audit[i].Details)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO company_merge_audit_entries (merge_id, entity, action, affected_count, details)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at`,
+			merge.ID, audit[i].Entity, audit[i].Action, audit[i].AffectedCount, details,
+		).Scan(&audit[i].ID, &audit[i].CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	merge.AuditEntries = audit
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+const companyMergeSelect = `
+	SELECT m.id, m.surviving_company_id, s.company_name, m.duplicate_company_id, d.company_name,
+	       m.merged_by::TEXT, m.reason, m.created_at
+	FROM company_merges m
+	JOIN company_profiles s ON s.id = m.surviving_company_id
+	JOIN company_profiles d ON d.id = m.duplicate_company_id`
+
+func scanCompanyMerge(row pgx.Row, m *domain.CompanyMerge) error {
+	return row.Scan(
+		&m.ID, &m.SurvivingCompanyID, &m.SurvivingCompanyName, &m.DuplicateCompanyID, &m.DuplicateCompanyName,
+		&m.MergedBy, &m.Reason, &m.CreatedAt,
+	)
+}
+
+func (r *companyMergeRepo) List(ctx context.Context, limit int) ([]domain.CompanyMerge, error) {
+	rows, err := r.db.Query(ctx, companyMergeSelect+` ORDER BY m.created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	merges := []domain.CompanyMerge{}
+	for rows.Next() {
+		var m domain.CompanyMerge
+		if err := scanCompanyMerge(rows, &m); err != nil {
+			return nil, err
+		}
+		merges = append(merges, m)
+	}
+	return merges, rows.Err()
+}
+
+func (r *companyMergeRepo) GetByID(ctx context.Context, id int64) (*domain.CompanyMerge, error) {
+	var m domain.CompanyMerge
+	if err := scanCompanyMerge(r.db.QueryRow(ctx, companyMergeSelect+` WHERE m.id = $1`, id), &m); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, entity, action, affected_count, details, created_at
+		FROM company_merge_audit_entries
+		WHERE merge_id = $1
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	m.AuditEntries = []domain.CompanyMergeAuditEntry{}
+	for rows.Next() {
+		var e domain.CompanyMergeAuditEntry
+		var details []byte
+		if err := rows.Scan(&e.ID, &e.Entity, &e.Action, &e.AffectedCount, &details, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(details, &e.Details); err != nil {
+			return nil, err
+		}
+		m.AuditEntries = append(m.AuditEntries, e)
+	}
+	return &m, rows.Err()
+}
+
+// collectInt64s drains a single-column BIGINT result
+func collectInt64s(rows pgx.Rows, err error) ([]int64, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// collectStrings drains a single-column TEXT result
+func collectStrings(rows pgx.Rows, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []string{}
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
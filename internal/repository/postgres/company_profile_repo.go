@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"time"
 
@@ -18,16 +19,19 @@ func NewCompanyProfileRepository(db *pgxpool.Pool) domain.CompanyProfileReposito
 	return &companyProfileRepo{db: db}
 }
 
-// GetByUserID retrieves a company profile by the employer's user ID
+// GetByUserID retrieves the company profile an employer works for: their own
+// (the survivor once it was merged away), else the company they joined as a member
 func (r *companyProfileRepo) GetByUserID(ctx context.Context, userID string) (*domain.CompanyProfile, error) {
 	query := `
 		SELECT id, user_id, company_name, logo_url, location, company_story, 
 		       founded, founder, headquarters, employee_count, website,
 		       industry, description, hide_company_details,
-		       gallery_image_1, gallery_image_2, gallery_image_3,
-		       created_at, updated_at
+		       gallery_image_1, gallery_image_2, gallery_image_3, verification_level,
+		       created_at, updated_at, merged_into_id, archived_at, province
 		FROM company_profiles 
-		WHERE user_id = $1`
+		WHERE id = COALESCE(
+			(SELECT COALESCE(merged_into_id, id) FROM company_profiles WHERE user_id = $1),
+			(SELECT company_id FROM company_members WHERE user_id = $1))`
 
 	var profile domain.CompanyProfile
 	err := r.db.QueryRow(ctx, query, userID).Scan(
@@ -36,8 +40,8 @@ func (r *companyProfileRepo) GetByUserID(ctx context.Context, userID string) (*d
 		&profile.Founded, &profile.Founder, &profile.Headquarters,
 		&profile.EmployeeCount, &profile.Website,
 		&profile.Industry, &profile.Description, &profile.HideCompanyDetails,
-		&profile.GalleryImage1, &profile.GalleryImage2, &profile.GalleryImage3,
-		&profile.CreatedAt, &profile.UpdatedAt,
+		&profile.GalleryImage1, &profile.GalleryImage2, &profile.GalleryImage3, &profile.VerificationLevel,
+		&profile.CreatedAt, &profile.UpdatedAt, &profile.MergedIntoID, &profile.ArchivedAt, &profile.Province,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -54,8 +58,8 @@ func (r *companyProfileRepo) GetByID(ctx context.Context, id int64) (*domain.Com
 		SELECT id, user_id, company_name, logo_url, location, company_story, 
 		       founded, founder, headquarters, employee_count, website,
 		       industry, description, hide_company_details,
-		       gallery_image_1, gallery_image_2, gallery_image_3,
-		       created_at, updated_at
+		       gallery_image_1, gallery_image_2, gallery_image_3, verification_level,
+		       created_at, updated_at, merged_into_id, archived_at, province
 		FROM company_profiles 
 		WHERE id = $1`
 
@@ -66,8 +70,8 @@ func (r *companyProfileRepo) GetByID(ctx context.Context, id int64) (*domain.Com
 		&profile.Founded, &profile.Founder, &profile.Headquarters,
 		&profile.EmployeeCount, &profile.Website,
 		&profile.Industry, &profile.Description, &profile.HideCompanyDetails,
-		&profile.GalleryImage1, &profile.GalleryImage2, &profile.GalleryImage3,
-		&profile.CreatedAt, &profile.UpdatedAt,
+		&profile.GalleryImage1, &profile.GalleryImage2, &profile.GalleryImage3, &profile.VerificationLevel,
+		&profile.CreatedAt, &profile.UpdatedAt, &profile.MergedIntoID, &profile.ArchivedAt, &profile.Province,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -89,8 +93,8 @@ func (r *companyProfileRepo) Upsert(ctx context.Context, profile *domain.Company
 			founded, founder, headquarters, employee_count, website,
 			industry, description, hide_company_details,
 			gallery_image_1, gallery_image_2, gallery_image_3,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+			created_at, updated_at, province
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		ON CONFLICT (user_id) DO UPDATE SET
 			company_name = EXCLUDED.company_name,
 			logo_url = EXCLUDED.logo_url,
@@ -107,7 +111,8 @@ func (r *companyProfileRepo) Upsert(ctx context.Context, profile *domain.Company
 			gallery_image_1 = EXCLUDED.gallery_image_1,
 			gallery_image_2 = EXCLUDED.gallery_image_2,
 			gallery_image_3 = EXCLUDED.gallery_image_3,
-			updated_at = EXCLUDED.updated_at
+			updated_at = EXCLUDED.updated_at,
+			province = EXCLUDED.province
 		RETURNING id, created_at`
 
 	err := r.db.QueryRow(ctx, query,
@@ -115,8 +120,125 @@ func (r *companyProfileRepo) Upsert(ctx context.Context, profile *domain.Company
 		profile.Founded, profile.Founder, profile.Headquarters, profile.EmployeeCount, profile.Website,
 		profile.Industry, profile.Description, profile.HideCompanyDetails,
 		profile.GalleryImage1, profile.GalleryImage2, profile.GalleryImage3,
-		now, now,
+		now, now, profile.Province,
 	).Scan(&profile.ID, &profile.CreatedAt)
 
 	return err
 }
+
+// publicCompanyCondition limits public pages to verified employers' live profiles
+const publicCompanyCondition = `cp.merged_into_id IS NULL AND cp.archived_at IS NULL
+	AND EXISTS (
+		SELECT 1 FROM account_verifications av
+		WHERE av.user_id = cp.user_id AND av.role = 'EMPLOYER' AND av.status = 'VERIFIED'
+	)`
+
+// GetPublicByID retrieves a company profile that may be shown publicly
+func (r *companyProfileRepo) GetPublicByID(ctx context.Context, id int64) (*domain.CompanyProfile, error) {
+	query := `
+		SELECT cp.id, cp.user_id, cp.company_name, cp.logo_url, cp.location, cp.company_story,
+		       cp.founded, cp.founder, cp.headquarters, cp.employee_count, cp.website,
+		       cp.industry, cp.description, cp.hide_company_details,
+		       cp.gallery_image_1, cp.gallery_image_2, cp.gallery_image_3, cp.verification_level,
+		       cp.created_at, cp.updated_at, cp.merged_into_id, cp.archived_at, cp.province
+		FROM company_profiles cp
+		WHERE cp.id = $1 AND ` + publicCompanyCondition
+
+	var profile domain.CompanyProfile
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&profile.ID, &profile.UserID, &profile.CompanyName,
+		&profile.LogoURL, &profile.Location, &profile.CompanyStory,
+		&profile.Founded, &profile.Founder, &profile.Headquarters,
+		&profile.EmployeeCount, &profile.Website,
+		&profile.Industry, &profile.Description, &profile.HideCompanyDetails,
+		&profile.GalleryImage1, &profile.GalleryImage2, &profile.GalleryImage3, &profile.VerificationLevel,
+		&profile.CreatedAt, &profile.UpdatedAt, &profile.MergedIntoID, &profile.ArchivedAt, &profile.Province,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// ListPublic returns the public company directory sorted by name. Only
+// always-public fields are selected; hidden details never leave the database.
+func (r *companyProfileRepo) ListPublic(ctx context.Context, filter domain.PublicCompanyFilter) ([]domain.PublicCompanySummary, int64, error) {
+	where := " WHERE " + publicCompanyCondition
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Query != "" {
+		where += fmt.Sprintf(" AND cp.company_name ILIKE '%%' || $%d || '%%'", argIndex)
+		args = append(args, filter.Query)
+		argIndex++
+	}
+	if filter.Industry != "" {
+		where += fmt.Sprintf(" AND LOWER(cp.industry) = LOWER($%d)", argIndex)
+		args = append(args, filter.Industry)
+		argIndex++
+	}
+	if filter.Location != "" {
+		where += fmt.Sprintf(" AND cp.location ILIKE '%%' || $%d || '%%'", argIndex)
+		args = append(args, filter.Location)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM company_profiles cp`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT cp.id, cp.company_name, cp.logo_url, cp.industry, cp.location, cp.verification_level,
+		       (SELECT COUNT(*) FROM jobs j WHERE j.company_id = cp.id AND NOT j.is_confidential AND ` + publishedJobExpr + `)
+		FROM company_profiles cp` + where +
+		fmt.Sprintf(" ORDER BY cp.company_name, cp.id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	companies := []domain.PublicCompanySummary{}
+	for rows.Next() {
+		var c domain.PublicCompanySummary
+		if err := rows.Scan(&c.ID, &c.CompanyName, &c.LogoURL, &c.Industry, &c.Location, &c.VerificationLevel, &c.ActiveJobCount); err != nil {
+			return nil, 0, err
+		}
+		companies = append(companies, c)
+	}
+	return companies, total, rows.Err()
+}
+
+// ListActiveJobs returns the company's active jobs, newest first
+func (r *companyProfileRepo) ListActiveJobs(ctx context.Context, companyID int64, limit int) ([]domain.Job, error) {
+	return listPublishedCompanyJobs(ctx, r.db, companyID, limit)
+}
+
+// listPublishedCompanyJobs is shared by the career page and the public company page.
+// Confidential jobs are left out, as they must not be tied to the company publicly.
+func listPublishedCompanyJobs(ctx context.Context, db *pgxpool.Pool, companyID int64, limit int) ([]domain.Job, error) {
+	query := `SELECT j.id, j.company_id, j.title, j.description, j.salary_min, j.salary_max, j.location, j.company_status, j.employment_type, j.job_type, j.experience_level, j.qualifications, j.created_at, j.updated_at
+              FROM jobs j WHERE j.company_id = $1 AND NOT j.is_confidential AND ` + publishedJobExpr + ` ORDER BY j.created_at DESC LIMIT $2`
+
+	rows, err := db.Query(ctx, query, companyID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.Job{}
+	for rows.Next() {
+		var job domain.Job
+		if err := rows.Scan(&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.CompanyStatus, &job.EmploymentType, &job.JobType, &job.ExperienceLevel, &job.Qualifications, &job.CreatedAt, &job.UpdatedAt); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
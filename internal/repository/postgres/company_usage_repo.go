@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type companyUsageRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCompanyUsageRepository creates a new company usage repository
+func NewCompanyUsageRepository(db *pgxpool.Pool) domain.CompanyUsageRepository {
+	return &companyUsageRepo{db: db}
+}
+
+func (r *companyUsageRepo) GetPlanQuotas(ctx context.Context, companyID int64) (*domain.CompanyPlanQuotas, error) {
+	q := &domain.CompanyPlanQuotas{}
+	err := r.db.QueryRow(ctx, `
+		SELECT p.code, p.name, p.job_slots, p.monthly_exports, p.monthly_api_calls
+		FROM company_subscriptions s
+		JOIN billing_plans p ON p.code = s.plan_code
+		WHERE s.company_id = $1 AND s.cancelled_at IS NULL`, companyID,
+	).Scan(&q.PlanCode, &q.PlanName, &q.JobSlots, &q.MonthlyExports, &q.MonthlyAPICalls)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	// No active subscription means no plan limits
+	return q, nil
+}
+
+func (r *companyUsageRepo) CountJobs(ctx context.Context, companyID int64) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE company_id = $1`, companyID).Scan(&count)
+	return count, err
+}
+
+func (r *companyUsageRepo) CountByMonth(ctx context.Context, companyID int64, from, to time.Time) ([]domain.CompanyUsageMonthCount, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT $4::TEXT AS metric, DATE_TRUNC('month', created_at)::DATE AS month, COUNT(*)
+		FROM jobs
+		WHERE company_id = $1 AND created_at >= $2 AND created_at < $3
+		GROUP BY 2
+		UNION ALL
+		SELECT $5::TEXT, DATE_TRUNC('month', created_at)::DATE, COUNT(*)
+		FROM company_credit_ledger
+		WHERE company_id = $1 AND entry_type = 'reveal' AND created_at >= $2 AND created_at < $3
+		GROUP BY 2
+		UNION ALL
+		SELECT $6::TEXT, DATE_TRUNC('month', created_at)::DATE, COUNT(*)
+		FROM pii_access_logs
+		WHERE company_id = $1 AND action = $8 AND created_at >= $2 AND created_at < $3
+		GROUP BY 2
+		UNION ALL
+		SELECT metric, DATE_TRUNC('month', period_date)::DATE, SUM(count)::BIGINT
+		FROM company_usage_counters
+		WHERE company_id = $1 AND metric = $7 AND period_date >= $2::DATE AND period_date < $3::DATE
+		GROUP BY 1, 2
+		ORDER BY 1, 2`,
+		companyID, from, to,
+		domain.UsageMetricJobSlots, domain.UsageMetricUnlocks, domain.UsageMetricExports, domain.UsageMetricAPICalls,
+		domain.PIIActionApplicationExport,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []domain.CompanyUsageMonthCount
+	for rows.Next() {
+		var c domain.CompanyUsageMonthCount
+		if err := rows.Scan(&c.Metric, &c.Month, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+func (r *companyUsageRepo) IncrementCounter(ctx context.Context, companyID int64, metric string, day time.Time, n int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO company_usage_counters (company_id, metric, period_date, count)
+		VALUES ($1, $2, $3::DATE, $4)
+		ON CONFLICT (company_id, metric, period_date) DO UPDATE
+		SET count = company_usage_counters.count + EXCLUDED.count, updated_at = NOW()`,
+		companyID, metric, day, n,
+	)
+	return err
+}
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type companyVerificationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCompanyVerificationRepository creates a new company verification level repository
+func NewCompanyVerificationRepository(db *pgxpool.Pool) domain.CompanyVerificationRepository {
+	return &companyVerificationRepo{db: db}
+}
+
+// openJobsExpr counts a company's active and scheduled jobs that have not passed
+// unpublish_at, or their apply deadline when they close automatically
+const openJobsExpr = `(
+	SELECT COUNT(*) FROM jobs j
+	WHERE j.company_id = cp.id AND j.company_status IN ('active', 'scheduled')
+	  AND (j.unpublish_at IS NULL OR j.unpublish_at > NOW())
+	  AND NOT (j.auto_close AND j.apply_deadline IS NOT NULL AND j.apply_deadline <= NOW())
+)`
+
+const companyVerificationSelect = `
+	SELECT cp.id, cp.company_name, cp.province, cp.verification_level, ` + openJobsExpr + `, cp.verification_level_updated_at
+	FROM company_profiles cp`
+
+func scanCompanyVerification(row pgx.Row) (*domain.CompanyVerification, error) {
+	var v domain.CompanyVerification
+	if err := row.Scan(&v.CompanyID, &v.CompanyName, &v.Province, &v.Level, &v.OpenJobs, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *companyVerificationRepo) Get(ctx context.Context, companyID int64) (*domain.CompanyVerification, error) {
+	v, err := scanCompanyVerification(r.db.QueryRow(ctx, companyVerificationSelect+` WHERE cp.id = $1`, companyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func (r *companyVerificationRepo) List(ctx context.Context, filter domain.CompanyVerificationFilter) ([]domain.CompanyVerification, int64, error) {
+	where := " WHERE cp.merged_into_id IS NULL"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Level != "" {
+		where += fmt.Sprintf(" AND cp.verification_level = $%d", argIndex)
+		args = append(args, filter.Level)
+		argIndex++
+	}
+	if filter.Query != "" {
+		where += fmt.Sprintf(" AND cp.company_name ILIKE '%%' || $%d || '%%'", argIndex)
+		args = append(args, filter.Query)
+		argIndex++
+	}
+	if filter.ScopeProvinces != nil {
+		where += " AND " + provinceScopeCondition("cp.province", argIndex)
+		args = append(args, filter.ScopeProvinces)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM company_profiles cp`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := companyVerificationSelect + where +
+		fmt.Sprintf(" ORDER BY cp.company_name, cp.id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	companies := []domain.CompanyVerification{}
+	for rows.Next() {
+		v, err := scanCompanyVerification(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		companies = append(companies, *v)
+	}
+	return companies, total, rows.Err()
+}
+
+func (r *companyVerificationRepo) History(ctx context.Context, companyID int64) ([]domain.CompanyVerificationLevelChange, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, company_id, from_level, to_level, changed_by, reason, created_at
+		FROM company_verification_level_changes
+		WHERE company_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := []domain.CompanyVerificationLevelChange{}
+	for rows.Next() {
+		var c domain.CompanyVerificationLevelChange
+		if err := rows.Scan(&c.ID, &c.CompanyID, &c.FromLevel, &c.ToLevel, &c.ChangedBy, &c.Reason, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+func (r *companyVerificationRepo) SetLevel(ctx context.Context, companyID int64, level string, changedBy *string, reason string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var from string
+	err = tx.QueryRow(ctx, `SELECT verification_level FROM company_profiles WHERE id = $1 FOR UPDATE`, companyID).Scan(&from)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	if from == level {
+		return nil
+	}
+
+	if err := updateCompanyLevel(ctx, tx, companyID, from, level, changedBy, reason); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *companyVerificationRepo) RaiseLevel(ctx context.Context, employerUserID, level, reason string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var companyID int64
+	var from string
+	err = tx.QueryRow(ctx, `
+		SELECT id, verification_level FROM company_profiles
+		WHERE user_id = $1 AND merged_into_id IS NULL
+		FOR UPDATE
+	`, employerUserID).Scan(&companyID, &from)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil // no profile yet
+		}
+		return false, err
+	}
+	if domain.CompanyLevelRank(from) >= domain.CompanyLevelRank(level) {
+		return false, nil
+	}
+
+	if err := updateCompanyLevel(ctx, tx, companyID, from, level, nil, reason); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// updateCompanyLevel sets the level and records the change in the caller's transaction
+func updateCompanyLevel(ctx context.Context, tx pgx.Tx, companyID int64, from, to string, changedBy *string, reason string) error {
+	if _, err := tx.Exec(ctx, `
+		UPDATE company_profiles
+		SET verification_level = $2, verification_level_updated_at = NOW()
+		WHERE id = $1
+	`, companyID, to); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO company_verification_level_changes (company_id, from_level, to_level, changed_by, reason)
+		VALUES ($1, $2, $3, $4, $5)
+	`, companyID, from, to, changedBy, reason)
+	return err
+}
+
+func (r *companyVerificationRepo) CountOpenJobs(ctx context.Context, companyID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT `+openJobsExpr+` FROM company_profiles cp WHERE cp.id = $1`, companyID).Scan(&count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return count, err
+}
@@ -0,0 +1,258 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const creditLedgerColumns = `id, company_id, entry_type, amount, balance_after, idempotency_key,
+	candidate_user_id, actor_user_id, note, created_at`
+
+type contactCreditRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewContactCreditRepository(db *pgxpool.Pool) domain.ContactCreditRepository {
+	return &contactCreditRepo{db: db}
+}
+
+func (r *contactCreditRepo) GetBalance(ctx context.Context, companyID int64) (*domain.CompanyCreditBalance, error) {
+	b := &domain.CompanyCreditBalance{CompanyID: companyID}
+	err := r.db.QueryRow(ctx,
+		`SELECT balance, updated_at FROM company_credit_balances WHERE company_id = $1`, companyID,
+	).Scan(&b.Balance, &b.UpdatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	// No row yet means the company has never received credits
+	return b, nil
+}
+
+func (r *contactCreditRepo) AddCredits(ctx context.Context, entry *domain.CreditLedgerEntry) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Lock the balance row; every balance change for a company serializes here
+	balance, err := lockBalance(ctx, tx, entry.CompanyID)
+	if err != nil {
+		return false, err
+	}
+
+	// 2. Replay: same key already applied
+	if entry.IdempotencyKey != nil {
+		existing, err := getLedgerEntryByKey(ctx, tx, entry.CompanyID, *entry.IdempotencyKey)
+		if err == nil {
+			*entry = *existing
+			return true, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return false, err
+		}
+	}
+
+	// 3. Apply
+	entry.BalanceAfter = balance + entry.Amount
+	if err := applyLedgerEntry(ctx, tx, entry); err != nil {
+		return false, err
+	}
+
+	return false, tx.Commit(ctx)
+}
+
+func (r *contactCreditRepo) ConsumeReveal(ctx context.Context, companyID int64, candidateUserID, actorUserID string, idempotencyKey *string) (*domain.CreditLedgerEntry, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Lock the balance row before any checks so concurrent reveals cannot double-charge
+	balance, err := lockBalance(ctx, tx, companyID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// 2. Already revealed: free
+	var revealed bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM candidate_contact_reveals WHERE company_id = $1 AND candidate_user_id = $2)`,
+		companyID, candidateUserID,
+	).Scan(&revealed)
+	if err != nil {
+		return nil, false, err
+	}
+	if revealed {
+		return nil, false, nil
+	}
+
+	// 3. Replay of an earlier deduction
+	if idempotencyKey != nil {
+		existing, err := getLedgerEntryByKey(ctx, tx, companyID, *idempotencyKey)
+		if err == nil {
+			return existing, false, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, err
+		}
+	}
+
+	// 4. Deduct
+	if balance < domain.ContactRevealCost {
+		return nil, false, domain.ErrInsufficientCredits
+	}
+	entry := &domain.CreditLedgerEntry{
+		CompanyID:       companyID,
+		EntryType:       domain.CreditEntryReveal,
+		Amount:          -domain.ContactRevealCost,
+		BalanceAfter:    balance - domain.ContactRevealCost,
+		IdempotencyKey:  idempotencyKey,
+		CandidateUserID: &candidateUserID,
+		ActorUserID:     &actorUserID,
+	}
+	if err := applyLedgerEntry(ctx, tx, entry); err != nil {
+		return nil, false, err
+	}
+
+	// 5. Record the unlock
+	_, err = tx.Exec(ctx, `
+		INSERT INTO candidate_contact_reveals (company_id, candidate_user_id, ledger_entry_id, revealed_by)
+		VALUES ($1, $2, $3, $4)
+	`, companyID, candidateUserID, entry.ID, actorUserID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, err
+	}
+	return entry, true, nil
+}
+
+func (r *contactCreditRepo) ListLedger(ctx context.Context, companyID int64, page, pageSize int) ([]domain.CreditLedgerEntry, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM company_credit_ledger WHERE company_id = $1`, companyID,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	rows, err := r.db.Query(ctx, `
+		SELECT `+creditLedgerColumns+`
+		FROM company_credit_ledger
+		WHERE company_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, companyID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []domain.CreditLedgerEntry{}
+	for rows.Next() {
+		var e domain.CreditLedgerEntry
+		if err := scanLedgerEntry(rows, &e); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, total, rows.Err()
+}
+
+func (r *contactCreditRepo) GetCandidateContact(ctx context.Context, companyID int64, candidateUserID string) (*domain.CandidateContact, error) {
+	query := `
+		SELECT u.id, TRIM(CONCAT(av.first_name, ' ', av.last_name)), u.email, av.phone
+		FROM users u
+		JOIN account_verifications av ON av.user_id = u.id
+		WHERE u.id = $1
+			AND av.role = 'CANDIDATE'
+			AND (u.paused_until IS NULL OR u.paused_until <= NOW())
+			AND (` + candidateVisibleExpr(2) + ` OR EXISTS (
+				SELECT 1 FROM candidate_contact_reveals cr WHERE cr.candidate_user_id = u.id AND cr.company_id = $2
+			))
+	`
+	var c domain.CandidateContact
+	err := r.db.QueryRow(ctx, query, candidateUserID, companyID).Scan(&c.CandidateUserID, &c.Name, &c.Email, &c.Phone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *contactCreditRepo) HasRevealed(ctx context.Context, companyID int64, candidateUserID string) (bool, error) {
+	var revealed bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM candidate_contact_reveals WHERE company_id = $1 AND candidate_user_id = $2)`,
+		companyID, candidateUserID,
+	).Scan(&revealed)
+	return revealed, err
+}
+
+// lockBalance ensures a balance row exists and locks it for the rest of the transaction
+func lockBalance(ctx context.Context, tx pgx.Tx, companyID int64) (int, error) {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO company_credit_balances (company_id) VALUES ($1) ON CONFLICT (company_id) DO NOTHING`,
+		companyID,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var balance int
+	err = tx.QueryRow(ctx,
+		`SELECT balance FROM company_credit_balances WHERE company_id = $1 FOR UPDATE`, companyID,
+	).Scan(&balance)
+	return balance, err
+}
+
+// applyLedgerEntry writes entry and sets the balance to entry.BalanceAfter
+func applyLedgerEntry(ctx context.Context, tx pgx.Tx, entry *domain.CreditLedgerEntry) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE company_credit_balances SET balance = $2, updated_at = NOW() WHERE company_id = $1`,
+		entry.CompanyID, entry.BalanceAfter,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(ctx, `
+		INSERT INTO company_credit_ledger
+			(company_id, entry_type, amount, balance_after, idempotency_key, candidate_user_id, actor_user_id, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`, entry.CompanyID, entry.EntryType, entry.Amount, entry.BalanceAfter, entry.IdempotencyKey,
+		entry.CandidateUserID, entry.ActorUserID, entry.Note,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+func getLedgerEntryByKey(ctx context.Context, tx pgx.Tx, companyID int64, key string) (*domain.CreditLedgerEntry, error) {
+	row := tx.QueryRow(ctx, `
+		SELECT `+creditLedgerColumns+`
+		FROM company_credit_ledger
+		WHERE company_id = $1 AND idempotency_key = $2
+	`, companyID, key)
+
+	var e domain.CreditLedgerEntry
+	if err := scanLedgerEntry(row, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func scanLedgerEntry(row pgx.Row, e *domain.CreditLedgerEntry) error {
+	return row.Scan(
+		&e.ID, &e.CompanyID, &e.EntryType, &e.Amount, &e.BalanceAfter, &e.IdempotencyKey,
+		&e.CandidateUserID, &e.ActorUserID, &e.Note, &e.CreatedAt,
+	)
+}
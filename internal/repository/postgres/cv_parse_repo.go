@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type cvParseRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewCVParseRepository creates a new CV parse repository
+func NewCVParseRepository(db *pgxpool.Pool) domain.CVParseRepository {
+	return &cvParseRepo{db: db}
+}
+
+// prefillColumns locks the row, keeps the values whose column is empty and
+// writes them in one UPDATE. It returns the columns it set.
+func prefillColumns(ctx context.Context, db *pgxpool.Pool, table, userID string, values map[string]*string) ([]string, error) {
+	var columns []string
+	for col, v := range values {
+		if v != nil && strings.TrimSpace(*v) != "" {
+			columns = append(columns, col)
+		}
+	}
+	sort.Strings(columns)
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	current := make([]*string, len(columns))
+	dest := make([]any, len(columns))
+	for i := range current {
+		dest[i] = &current[i]
+	}
+	err = tx.QueryRow(ctx,
+		`SELECT `+strings.Join(columns, ", ")+` FROM `+table+` WHERE user_id = $1 FOR UPDATE`, userID,
+	).Scan(dest...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	var (
+		set  []string
+		sets []string
+		args = []any{userID}
+	)
+	for i, col := range columns {
+		if current[i] != nil && strings.TrimSpace(*current[i]) != "" {
+			continue
+		}
+		args = append(args, *values[col])
+		sets = append(sets, fmt.Sprintf("%s = $%d", col, len(args)))
+		set = append(set, col)
+	}
+	if len(set) == 0 {
+		return nil, nil
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE `+table+` SET `+strings.Join(sets, ", ")+`, updated_at = NOW() WHERE user_id = $1`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return set, tx.Commit(ctx)
+}
+
+func (r *cvParseRepo) PrefillVerification(ctx context.Context, userID string, firstName, lastName, phone, cvURL *string) ([]string, error) {
+	return prefillColumns(ctx, r.db, "account_verifications", userID, map[string]*string{
+		"first_name": firstName,
+		"last_name":  lastName,
+		"phone":      phone,
+		"cv_url":     cvURL,
+	})
+}
+
+func (r *cvParseRepo) PrefillEducation(ctx context.Context, userID string, highestEducation, majorField *string) ([]string, error) {
+	return prefillColumns(ctx, r.db, "candidate_profiles", userID, map[string]*string{
+		"highest_education": highestEducation,
+		"major_field":       majorField,
+	})
+}
+
+func (r *cvParseRepo) AddWorkExperiences(ctx context.Context, userID string, experiences []domain.WorkExperience) (int, error) {
+	if len(experiences) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize with other prefills of the same candidate so "has none yet" holds
+	if _, err := tx.Exec(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+		return 0, err
+	}
+	var existing int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM work_experiences WHERE user_id = $1`, userID).Scan(&existing); err != nil {
+		return 0, err
+	}
+	if existing > 0 {
+		return 0, nil
+	}
+
+	for _, we := range experiences {
+		start, err := time.Parse("2006-01-02", we.StartDate)
+		if err != nil {
+			return 0, err
+		}
+		var end *time.Time
+		if we.EndDate != nil && *we.EndDate != "" {
+			t, err := time.Parse("2006-01-02", *we.EndDate)
+			if err != nil {
+				return 0, err
+			}
+			end = &t
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO work_experiences (
+				user_id, country_code, experience_type, company_name, job_title, start_date, end_date, description
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			userID, we.CountryCode, we.ExperienceType, we.CompanyName, we.JobTitle, start, end, we.Description,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(experiences), tx.Commit(ctx)
+}
+
+func (r *cvParseRepo) ListSkills(ctx context.Context) ([]domain.Skill, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, name, category FROM skills ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var skills []domain.Skill
+	for rows.Next() {
+		var s domain.Skill
+		if err := rows.Scan(&s.ID, &s.Name, &s.Category); err != nil {
+			return nil, err
+		}
+		skills = append(skills, s)
+	}
+	return skills, rows.Err()
+}
+
+func (r *cvParseRepo) AddSkills(ctx context.Context, userID string, skillIDs []int) (int, error) {
+	if len(skillIDs) == 0 {
+		return 0, nil
+	}
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO candidate_skills (user_id, skill_id)
+		SELECT $1, unnest($2::int[])
+		ON CONFLICT DO NOTHING`, userID, skillIDs)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
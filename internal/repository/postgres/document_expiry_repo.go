@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type documentExpiryRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewDocumentExpiryRepository creates a new document expiry repository
+func NewDocumentExpiryRepository(db *pgxpool.Pool) domain.DocumentExpiryRepository {
+	return &documentExpiryRepo{db: db}
+}
+
+func (r *documentExpiryRepo) ListDueReminders(ctx context.Context, today time.Time, thresholds []int, limit int) ([]domain.DocumentExpiryReminderCandidate, error) {
+	// The current threshold is the smallest one the document is within, so a missed
+	// 90-day reminder is not sent late once the 30-day one is due
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT e.user_id, e.document_type, e.document_ref, e.document_label, e.expiry_date, e.critical,
+			       (e.expiry_date - $1::DATE) AS days_left,
+			       (SELECT MIN(t) FROM UNNEST($2::INT[]) t WHERE t >= e.expiry_date - $1::DATE) AS threshold_days
+			FROM candidate_document_expiries e
+			WHERE e.expiry_date >= $1::DATE
+			  AND e.expiry_date <= $1::DATE + (SELECT MAX(t) FROM UNNEST($2::INT[]) t)
+		)
+		SELECT d.user_id, d.document_type, d.document_ref, d.document_label, d.expiry_date, d.critical,
+		       d.days_left, d.threshold_days, u.email, av.first_name, u.preferred_locale
+		FROM due d
+		JOIN users u ON u.id = d.user_id
+		LEFT JOIN account_verifications av ON av.user_id = d.user_id
+		WHERE NOT EXISTS (
+			SELECT 1 FROM document_expiry_reminders r
+			WHERE r.user_id = d.user_id
+			  AND r.document_type = d.document_type
+			  AND r.document_ref = d.document_ref
+			  AND r.expiry_date = d.expiry_date
+			  AND r.threshold_days = d.threshold_days
+			  AND r.status = 'SENT'
+		)
+		ORDER BY d.user_id, d.expiry_date
+		LIMIT $3`, today, thresholds, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []domain.DocumentExpiryReminderCandidate
+	for rows.Next() {
+		var d domain.DocumentExpiryReminderCandidate
+		if err := rows.Scan(
+			&d.UserID, &d.DocumentType, &d.DocumentRef, &d.DocumentLabel, &d.ExpiryDate, &d.Critical,
+			&d.DaysLeft, &d.ThresholdDays, &d.Email, &d.FirstName, &d.PreferredLocale,
+		); err != nil {
+			return nil, err
+		}
+		due = append(due, d)
+	}
+	return due, rows.Err()
+}
+
+func (r *documentExpiryRepo) RecordReminder(ctx context.Context, rem *domain.DocumentExpiryReminder) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO document_expiry_reminders
+			(user_id, document_type, document_ref, expiry_date, threshold_days, channel, status, error_message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, document_type, document_ref, expiry_date, threshold_days) DO UPDATE
+		SET channel = EXCLUDED.channel, status = EXCLUDED.status,
+		    error_message = EXCLUDED.error_message, sent_at = NOW()
+		WHERE document_expiry_reminders.status = 'FAILED'`,
+		rem.UserID, rem.DocumentType, rem.DocumentRef, rem.ExpiryDate, rem.ThresholdDays,
+		rem.Channel, rem.Status, rem.ErrorMessage,
+	)
+	return err
+}
+
+func (r *documentExpiryRepo) ListByUser(ctx context.Context, userID string, today time.Time) ([]domain.CandidateDocumentExpiry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, document_type, document_ref, document_label, expiry_date, critical,
+		       (expiry_date - $2::DATE) AS days_left
+		FROM candidate_document_expiries
+		WHERE user_id = $1
+		ORDER BY expiry_date, document_type`, userID, today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	documents := []domain.CandidateDocumentExpiry{}
+	for rows.Next() {
+		var d domain.CandidateDocumentExpiry
+		if err := rows.Scan(&d.UserID, &d.DocumentType, &d.DocumentRef, &d.DocumentLabel, &d.ExpiryDate, &d.Critical, &d.DaysLeft); err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+	return documents, rows.Err()
+}
+
+func (r *documentExpiryRepo) CountByMonth(ctx context.Context, from, to time.Time) ([]domain.DocumentExpiryMonthCount, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DATE_TRUNC('month', expiry_date)::DATE AS month, document_type, COUNT(*)
+		FROM candidate_document_expiries
+		WHERE expiry_date >= $1::DATE AND expiry_date < $2::DATE
+		GROUP BY 1, 2
+		ORDER BY 1, 2`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []domain.DocumentExpiryMonthCount
+	for rows.Next() {
+		var c domain.DocumentExpiryMonthCount
+		if err := rows.Scan(&c.Month, &c.DocumentType, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+func (r *documentExpiryRepo) CountCandidatesByMonth(ctx context.Context, from, to time.Time) (map[string]int64, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT TO_CHAR(DATE_TRUNC('month', expiry_date), 'YYYY-MM') AS month, COUNT(DISTINCT user_id)
+		FROM candidate_document_expiries
+		WHERE expiry_date >= $1::DATE AND expiry_date < $2::DATE
+		GROUP BY 1`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := map[string]int64{}
+	for rows.Next() {
+		var month string
+		var count int64
+		if err := rows.Scan(&month, &count); err != nil {
+			return nil, err
+		}
+		candidates[month] = count
+	}
+	return candidates, rows.Err()
+}
+
+func (r *documentExpiryRepo) CountExpired(ctx context.Context, today time.Time) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM candidate_document_expiries WHERE expiry_date < $1::DATE`, today,
+	).Scan(&count)
+	return count, err
+}
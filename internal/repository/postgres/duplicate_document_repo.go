@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// adminUserLink is the admin page of an account, as linked from admin search
+const adminUserLink = "/admin/users/"
+
+type duplicateDocumentRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewDuplicateDocumentRepository creates a new duplicate document repository
+func NewDuplicateDocumentRepository(db *pgxpool.Pool) domain.DuplicateDocumentRepository {
+	return &duplicateDocumentRepo{db: db}
+}
+
+func (r *duplicateDocumentRepo) CreateHash(ctx context.Context, h *domain.DocumentImageHash, maxDistance, limit int) ([]domain.DuplicateDocumentMatch, error) {
+	// The hash is stored in a signed BIGINT; the bits are the same
+	phash := int64(h.Hash)
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO document_image_hashes (user_id, file_id, bucket, file_url, phash)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		h.UserID, h.FileID, h.Bucket, h.FileURL, phash,
+	).Scan(&h.ID, &h.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	// Hamming distance as the number of 1s in the XOR of the hashes. Every hash is
+	// compared; the closest image of each other account is kept.
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, email, file_url, distance, created_at
+		FROM (
+			SELECT DISTINCT ON (h.user_id) h.id, h.user_id::TEXT, u.email, h.file_url, d.distance, h.created_at
+			FROM document_image_hashes h
+			JOIN users u ON u.id = h.user_id
+			CROSS JOIN LATERAL (
+				SELECT length(replace((h.phash # $1)::bit(64)::TEXT, '0', '')) AS distance
+			) d
+			WHERE h.user_id <> $2 AND d.distance <= $3
+			ORDER BY h.user_id, d.distance, h.created_at
+		) m
+		ORDER BY distance, created_at
+		LIMIT $4`, phash, h.UserID, maxDistance, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := []domain.DuplicateDocumentMatch{}
+	for rows.Next() {
+		var m domain.DuplicateDocumentMatch
+		if err := rows.Scan(&m.HashID, &m.UserID, &m.Email, &m.FileURL, &m.Distance, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		m.Link = adminUserLink + m.UserID
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+func (r *duplicateDocumentRepo) CreateReview(ctx context.Context, hashID int64, matches []domain.DuplicateDocumentMatch) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int64
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO duplicate_document_reviews (hash_id) VALUES ($1) RETURNING id`, hashID,
+	).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	hashIDs := make([]int64, len(matches))
+	distances := make([]int32, len(matches))
+	for i, m := range matches {
+		hashIDs[i] = m.HashID
+		distances[i] = int32(m.Distance)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO duplicate_document_matches (review_id, hash_id, distance)
+		SELECT $1, m.hash_id, m.distance
+		FROM unnest($2::bigint[], $3::int[]) AS m(hash_id, distance)`,
+		id, hashIDs, distances,
+	); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit(ctx)
+}
+
+const duplicateDocumentReviewQuery = `
+	SELECT dr.id, h.user_id::TEXT, u.email, h.bucket, h.file_url, dr.status,
+	       dr.review_notes, dr.reviewed_by::TEXT, dr.reviewed_at, dr.created_at
+	FROM duplicate_document_reviews dr
+	JOIN document_image_hashes h ON h.id = dr.hash_id
+	JOIN users u ON u.id = h.user_id`
+
+func scanDuplicateDocumentReview(row pgx.Row, d *domain.DuplicateDocumentReview) error {
+	if err := row.Scan(
+		&d.ID, &d.UserID, &d.Email, &d.Bucket, &d.FileURL, &d.Status,
+		&d.ReviewNotes, &d.ReviewedBy, &d.ReviewedAt, &d.CreatedAt,
+	); err != nil {
+		return err
+	}
+	d.Link = adminUserLink + d.UserID
+	d.Matches = []domain.DuplicateDocumentMatch{}
+	return nil
+}
+
+func (r *duplicateDocumentRepo) List(ctx context.Context, filter domain.DuplicateDocumentFilter) ([]domain.DuplicateDocumentReview, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND dr.status = $%d", argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.UserID != "" {
+		where += fmt.Sprintf(` AND (h.user_id = $%d::uuid OR EXISTS (
+			SELECT 1 FROM duplicate_document_matches m
+			JOIN document_image_hashes mh ON mh.id = m.hash_id
+			WHERE m.review_id = dr.id AND mh.user_id = $%d::uuid))`, argIndex, argIndex)
+		args = append(args, filter.UserID)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM duplicate_document_reviews dr
+		JOIN document_image_hashes h ON h.id = dr.hash_id`+where, args...,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Oldest first, so the queue is worked through in upload order
+	query := duplicateDocumentReviewQuery + where +
+		fmt.Sprintf(" ORDER BY dr.created_at, dr.id LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	reviews := []domain.DuplicateDocumentReview{}
+	for rows.Next() {
+		var d domain.DuplicateDocumentReview
+		if err := scanDuplicateDocumentReview(rows, &d); err != nil {
+			return nil, 0, err
+		}
+		reviews = append(reviews, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.loadMatches(ctx, reviews); err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+func (r *duplicateDocumentRepo) GetByID(ctx context.Context, id int64) (*domain.DuplicateDocumentReview, error) {
+	var d domain.DuplicateDocumentReview
+	err := scanDuplicateDocumentReview(r.db.QueryRow(ctx, duplicateDocumentReviewQuery+` WHERE dr.id = $1`, id), &d)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []domain.DuplicateDocumentReview{d}
+	if err := r.loadMatches(ctx, reviews); err != nil {
+		return nil, err
+	}
+	return &reviews[0], nil
+}
+
+// loadMatches fills in the matched accounts of the reviews, closest first
+func (r *duplicateDocumentRepo) loadMatches(ctx context.Context, reviews []domain.DuplicateDocumentReview) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(reviews))
+	byID := make(map[int64]int, len(reviews))
+	for i, d := range reviews {
+		ids[i] = d.ID
+		byID[d.ID] = i
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT m.review_id, h.id, h.user_id::TEXT, u.email, h.file_url, m.distance, h.created_at
+		FROM duplicate_document_matches m
+		JOIN document_image_hashes h ON h.id = m.hash_id
+		JOIN users u ON u.id = h.user_id
+		WHERE m.review_id = ANY($1)
+		ORDER BY m.review_id, m.distance, h.created_at`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewID int64
+		var m domain.DuplicateDocumentMatch
+		if err := rows.Scan(&reviewID, &m.HashID, &m.UserID, &m.Email, &m.FileURL, &m.Distance, &m.CreatedAt); err != nil {
+			return err
+		}
+		m.Link = adminUserLink + m.UserID
+		d := &reviews[byID[reviewID]]
+		d.Matches = append(d.Matches, m)
+	}
+	return rows.Err()
+}
+
+func (r *duplicateDocumentRepo) Review(ctx context.Context, d *domain.DuplicateDocumentReview) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE duplicate_document_reviews
+		SET status = $2, review_notes = $3, reviewed_by = $4, reviewed_at = $5
+		WHERE id = $1`,
+		d.ID, d.Status, d.ReviewNotes, d.ReviewedBy, d.ReviewedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type emailTrackingRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewEmailTrackingRepository(db *pgxpool.Pool) domain.EmailTrackingRepository {
+	return &emailTrackingRepo{db: db}
+}
+
+func (r *emailTrackingRepo) CreateMessage(ctx context.Context, m *domain.EmailMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO email_messages (template, category, open_token, sent_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		m.Template, m.Category, m.OpenToken, m.SentAt,
+	).Scan(&m.ID); err != nil {
+		return err
+	}
+	for _, link := range m.Links {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO email_message_links (token, message_id, url) VALUES ($1, $2, $3)`,
+			link.Token, m.ID, link.URL,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// RecordHit counts a click when token is a link's, an open when it is a
+// message's. A click also marks the message opened: the pixel may be blocked.
+func (r *emailTrackingRepo) RecordHit(ctx context.Context, token string, at time.Time) (*domain.EmailTrackingHit, error) {
+	var url string
+	err := r.db.QueryRow(ctx, `
+		WITH link AS (
+			UPDATE email_message_links SET click_count = click_count + 1
+			WHERE token = $1
+			RETURNING message_id, url
+		)
+		UPDATE email_messages m SET
+			click_count = m.click_count + 1,
+			first_clicked_at = COALESCE(m.first_clicked_at, $2),
+			first_opened_at = COALESCE(m.first_opened_at, $2)
+		FROM link
+		WHERE m.id = link.message_id
+		RETURNING link.url`,
+		token, at,
+	).Scan(&url)
+	if err == nil {
+		return &domain.EmailTrackingHit{URL: &url}, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	result, err := r.db.Exec(ctx, `
+		UPDATE email_messages SET
+			open_count = open_count + 1,
+			first_opened_at = COALESCE(first_opened_at, $2)
+		WHERE open_token = $1`,
+		token, at,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.RowsAffected() == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.EmailTrackingHit{}, nil
+}
+
+func (r *emailTrackingRepo) GetStats(ctx context.Context, from, to time.Time) ([]domain.EmailEngagementStats, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT template, MIN(category),
+			COUNT(*),
+			COUNT(first_opened_at),
+			COUNT(first_clicked_at),
+			COALESCE(SUM(open_count), 0),
+			COALESCE(SUM(click_count), 0)
+		FROM email_messages
+		WHERE sent_at >= $1 AND sent_at < $2
+		GROUP BY template
+		ORDER BY template`,
+		from, to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []domain.EmailEngagementStats{}
+	for rows.Next() {
+		var s domain.EmailEngagementStats
+		if err := rows.Scan(&s.Template, &s.Category, &s.Sent, &s.Opened, &s.Clicked, &s.Opens, &s.Clicks); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+func (r *emailTrackingRepo) GetPreference(ctx context.Context, userID string) (*domain.EmailTrackingPreference, error) {
+	var p domain.EmailTrackingPreference
+	err := r.db.QueryRow(ctx,
+		`SELECT enabled, disabled_categories, updated_at FROM email_tracking_preferences WHERE user_id = $1`,
+		userID,
+	).Scan(&p.Enabled, &p.DisabledCategories, &p.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *emailTrackingRepo) UpsertPreference(ctx context.Context, userID string, p *domain.EmailTrackingPreference) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO email_tracking_preferences (user_id, enabled, disabled_categories, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			disabled_categories = EXCLUDED.disabled_categories,
+			updated_at = EXCLUDED.updated_at`,
+		userID, p.Enabled, p.DisabledCategories, p.UpdatedAt,
+	)
+	return err
+}
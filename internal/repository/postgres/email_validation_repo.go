@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type emailValidationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewEmailValidationRepository creates a new email validation repository
+func NewEmailValidationRepository(db *pgxpool.Pool) domain.EmailValidationRepository {
+	return &emailValidationRepo{db: db}
+}
+
+func (r *emailValidationRepo) ListDue(ctx context.Context, recheckBefore time.Time, limit int) ([]domain.EmailValidationCandidate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT u.id::TEXT, u.email
+		FROM users u
+		LEFT JOIN email_validations ev ON ev.user_id = u.id
+		WHERE ev.user_id IS NULL OR ev.email <> u.email OR ev.checked_at <= $1
+		ORDER BY (ev.user_id IS NULL OR ev.email <> u.email) DESC, ev.checked_at NULLS FIRST, u.created_at
+		LIMIT $2`, recheckBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []domain.EmailValidationCandidate
+	for rows.Next() {
+		var c domain.EmailValidationCandidate
+		if err := rows.Scan(&c.UserID, &c.Email); err != nil {
+			return nil, err
+		}
+		due = append(due, c)
+	}
+	return due, rows.Err()
+}
+
+func (r *emailValidationRepo) Save(ctx context.Context, v *domain.EmailValidation) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO email_validations (user_id, email, reason, detail, checked_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET email = EXCLUDED.email, reason = EXCLUDED.reason, detail = EXCLUDED.detail, checked_at = EXCLUDED.checked_at`,
+		v.UserID, v.Email, v.Reason, v.Detail, v.CheckedAt)
+	return err
+}
+
+func (r *emailValidationRepo) IsUndeliverable(ctx context.Context, email string) (bool, error) {
+	var undeliverable bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM email_validations ev
+			JOIN users u ON u.id = ev.user_id AND u.email = ev.email
+			WHERE LOWER(ev.email) = LOWER($1) AND ev.reason IS NOT NULL
+		)`, email).Scan(&undeliverable)
+	return undeliverable, err
+}
+
+func (r *emailValidationRepo) Report(ctx context.Context) (*domain.EmailValidationReport, error) {
+	report := &domain.EmailValidationReport{ByReason: map[string]int64{}, ByRole: map[string]int64{}}
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE ev.user_id IS NULL OR ev.email <> u.email),
+		       COUNT(*) FILTER (WHERE ev.email = u.email AND ev.reason IS NULL),
+		       COUNT(*) FILTER (WHERE ev.email = u.email AND ev.reason IS NOT NULL),
+		       MAX(ev.checked_at)
+		FROM users u
+		LEFT JOIN email_validations ev ON ev.user_id = u.id`,
+	).Scan(&report.Users, &report.Unchecked, &report.Deliverable, &report.Undeliverable, &report.LastCheckedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT ev.reason, u.role, COUNT(*)
+		FROM email_validations ev
+		JOIN users u ON u.id = ev.user_id AND u.email = ev.email
+		WHERE ev.reason IS NOT NULL
+		GROUP BY ev.reason, u.role`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var reason, role string
+		var count int64
+		if err := rows.Scan(&reason, &role, &count); err != nil {
+			return nil, err
+		}
+		report.ByReason[reason] += count
+		report.ByRole[role] += count
+	}
+	return report, rows.Err()
+}
+
+func (r *emailValidationRepo) ListUndeliverable(ctx context.Context, filter domain.UndeliverableEmailFilter) ([]domain.UndeliverableEmail, int64, error) {
+	where := " WHERE ev.reason IS NOT NULL"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Reason != "" {
+		where += fmt.Sprintf(" AND ev.reason = $%d", argIndex)
+		args = append(args, filter.Reason)
+		argIndex++
+	}
+	if filter.Role != "" {
+		where += fmt.Sprintf(" AND u.role = $%d", argIndex)
+		args = append(args, filter.Role)
+		argIndex++
+	}
+	from := ` FROM email_validations ev JOIN users u ON u.id = ev.user_id AND u.email = ev.email`
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*)`+from+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Accounts inactive the longest first: the likeliest to prune
+	query := `SELECT u.id::TEXT, u.email, u.role, ev.reason, ev.detail, ev.checked_at, u.last_active_at,
+	                 COALESCE(u.is_disabled, false), u.created_at` + from + where +
+		fmt.Sprintf(" ORDER BY u.last_active_at NULLS FIRST, u.created_at LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	accounts := []domain.UndeliverableEmail{}
+	for rows.Next() {
+		var a domain.UndeliverableEmail
+		if err := rows.Scan(&a.UserID, &a.Email, &a.Role, &a.Reason, &a.Detail, &a.CheckedAt, &a.LastActiveAt, &a.IsDisabled, &a.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		a.Link = adminUserLink + a.UserID
+		accounts = append(accounts, a)
+	}
+	return accounts, total, rows.Err()
+}
@@ -0,0 +1,349 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type employerMessageRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewEmployerMessageRepository creates a new employer message repository
+func NewEmployerMessageRepository(db *pgxpool.Pool) domain.EmployerMessageRepository {
+	return &employerMessageRepo{db: db}
+}
+
+// ============================================================================
+// Templates
+// ============================================================================
+
+func (r *employerMessageRepo) ListTemplates(ctx context.Context, companyID int64) ([]domain.MessageTemplate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, company_id, name, subject, body, created_at, updated_at
+		FROM employer_message_templates
+		WHERE company_id = $1
+		ORDER BY name, id`, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []domain.MessageTemplate{}
+	for rows.Next() {
+		var t domain.MessageTemplate
+		if err := rows.Scan(&t.ID, &t.CompanyID, &t.Name, &t.Subject, &t.Body, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func (r *employerMessageRepo) GetTemplate(ctx context.Context, companyID, id int64) (*domain.MessageTemplate, error) {
+	var t domain.MessageTemplate
+	err := r.db.QueryRow(ctx, `
+		SELECT id, company_id, name, subject, body, created_at, updated_at
+		FROM employer_message_templates
+		WHERE id = $1 AND company_id = $2`, id, companyID,
+	).Scan(&t.ID, &t.CompanyID, &t.Name, &t.Subject, &t.Body, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *employerMessageRepo) CreateTemplate(ctx context.Context, t *domain.MessageTemplate) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO employer_message_templates (company_id, name, subject, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`,
+		t.CompanyID, t.Name, t.Subject, t.Body,
+	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+}
+
+func (r *employerMessageRepo) UpdateTemplate(ctx context.Context, t *domain.MessageTemplate) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE employer_message_templates
+		SET name = $3, subject = $4, body = $5, updated_at = NOW()
+		WHERE id = $1 AND company_id = $2
+		RETURNING created_at, updated_at`,
+		t.ID, t.CompanyID, t.Name, t.Subject, t.Body,
+	).Scan(&t.CreatedAt, &t.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+func (r *employerMessageRepo) DeleteTemplate(ctx context.Context, companyID, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM employer_message_templates WHERE id = $1 AND company_id = $2`, id, companyID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ============================================================================
+// Sending
+// ============================================================================
+
+func (r *employerMessageRepo) GetRecipients(ctx context.Context, applicationIDs []int64) ([]domain.MessageRecipient, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT a.id, j.company_id, a.stage, a.candidate_user_id::text,
+		       TRIM(CONCAT(av.first_name, ' ', av.last_name)), j.title
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN account_verifications av ON av.id = a.account_verification_id
+		WHERE a.id = ANY($1)`, applicationIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []domain.MessageRecipient
+	for rows.Next() {
+		var rc domain.MessageRecipient
+		if err := rows.Scan(&rc.ApplicationID, &rc.CompanyID, &rc.Stage, &rc.CandidateUserID, &rc.CandidateName, &rc.JobTitle); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, rc)
+	}
+	return recipients, rows.Err()
+}
+
+func (r *employerMessageRepo) ListSpamReporters(ctx context.Context, companyID int64, candidateUserIDs []string) (map[string]bool, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT candidate_user_id::text
+		FROM employer_message_deliveries
+		WHERE company_id = $1 AND candidate_user_id = ANY($2::uuid[]) AND reported_spam_at IS NOT NULL`,
+		companyID, candidateUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reporters := map[string]bool{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		reporters[userID] = true
+	}
+	return reporters, rows.Err()
+}
+
+// CountDeliveriesSince counts recipients messaged since the time; blocked recipients do not count
+func (r *employerMessageRepo) CountDeliveriesSince(ctx context.Context, companyID int64, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM employer_message_deliveries
+		WHERE company_id = $1 AND created_at >= $2 AND status <> 'BLOCKED'`, companyID, since,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *employerMessageRepo) CountSpamReportsSince(ctx context.Context, companyID int64, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM employer_message_deliveries
+		WHERE company_id = $1 AND reported_spam_at >= $2`, companyID, since,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *employerMessageRepo) CreateMessage(ctx context.Context, m *domain.BulkMessage) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO employer_messages (company_id, template_id, sender_user_id, subject, body)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		m.CompanyID, m.TemplateID, m.SenderUserID, m.Subject, m.Body,
+	).Scan(&m.ID, &m.CreatedAt); err != nil {
+		return err
+	}
+
+	for i := range m.Deliveries {
+		d := &m.Deliveries[i]
+		d.MessageID = m.ID
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO employer_message_deliveries
+				(message_id, company_id, application_id, candidate_user_id, subject, body, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id`,
+			m.ID, m.CompanyID, d.ApplicationID, d.CandidateUserID, d.Subject, d.Body, d.Status, m.CreatedAt,
+		).Scan(&d.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *employerMessageRepo) UpdateDelivery(ctx context.Context, d *domain.MessageDelivery) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE employer_message_deliveries SET status = $2, error_message = $3, sent_at = $4
+		WHERE id = $1`, d.ID, d.Status, d.ErrorMessage, d.SentAt)
+	return err
+}
+
+// ============================================================================
+// History
+// ============================================================================
+
+const bulkMessageSelect = `
+	SELECT m.id, m.company_id, m.template_id, m.sender_user_id::text, m.subject, m.body, m.created_at,
+	       COUNT(d.id),
+	       COUNT(d.id) FILTER (WHERE d.status = 'SENT'),
+	       COUNT(d.id) FILTER (WHERE d.status = 'FAILED'),
+	       COUNT(d.id) FILTER (WHERE d.status = 'BLOCKED')
+	FROM employer_messages m
+	LEFT JOIN employer_message_deliveries d ON d.message_id = m.id`
+
+func scanBulkMessage(row pgx.Row) (*domain.BulkMessage, error) {
+	var m domain.BulkMessage
+	if err := row.Scan(
+		&m.ID, &m.CompanyID, &m.TemplateID, &m.SenderUserID, &m.Subject, &m.Body, &m.CreatedAt,
+		&m.Recipients, &m.Sent, &m.Failed, &m.Blocked,
+	); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *employerMessageRepo) ListMessages(ctx context.Context, companyID int64, limit, offset int) ([]domain.BulkMessage, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM employer_messages WHERE company_id = $1`, companyID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, bulkMessageSelect+`
+		WHERE m.company_id = $1
+		GROUP BY m.id
+		ORDER BY m.created_at DESC
+		LIMIT $2 OFFSET $3`, companyID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	messages := []domain.BulkMessage{}
+	for rows.Next() {
+		m, err := scanBulkMessage(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		messages = append(messages, *m)
+	}
+	return messages, total, rows.Err()
+}
+
+func (r *employerMessageRepo) GetMessage(ctx context.Context, companyID, id int64) (*domain.BulkMessage, error) {
+	m, err := scanBulkMessage(r.db.QueryRow(ctx, bulkMessageSelect+`
+		WHERE m.id = $1 AND m.company_id = $2
+		GROUP BY m.id`, id, companyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT d.id, d.message_id, d.application_id, d.candidate_user_id::text,
+		       TRIM(CONCAT(av.first_name, ' ', av.last_name)), COALESCE(j.title, ''),
+		       d.subject, d.body, d.status, d.error_message, d.sent_at, d.reported_spam_at
+		FROM employer_message_deliveries d
+		LEFT JOIN applications a ON a.id = d.application_id
+		LEFT JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN account_verifications av ON av.id = a.account_verification_id
+		WHERE d.message_id = $1
+		ORDER BY d.id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	m.Deliveries = []domain.MessageDelivery{}
+	for rows.Next() {
+		var d domain.MessageDelivery
+		if err := rows.Scan(
+			&d.ID, &d.MessageID, &d.ApplicationID, &d.CandidateUserID,
+			&d.CandidateName, &d.JobTitle,
+			&d.Subject, &d.Body, &d.Status, &d.ErrorMessage, &d.SentAt, &d.ReportedSpamAt,
+		); err != nil {
+			return nil, err
+		}
+		m.Deliveries = append(m.Deliveries, d)
+	}
+	return m, rows.Err()
+}
+
+// ============================================================================
+// Candidate inbox
+// ============================================================================
+
+func (r *employerMessageRepo) ListCandidateMessages(ctx context.Context, candidateUserID string, limit, offset int) ([]domain.CandidateMessage, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM employer_message_deliveries
+		WHERE candidate_user_id = $1 AND status = 'SENT'`, candidateUserID,
+	).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT d.id, COALESCE(cp.company_name, 'Unknown Company'), COALESCE(j.title, ''),
+		       d.subject, d.body, d.sent_at, d.reported_spam_at
+		FROM employer_message_deliveries d
+		LEFT JOIN company_profiles cp ON cp.id = d.company_id
+		LEFT JOIN applications a ON a.id = d.application_id
+		LEFT JOIN jobs j ON j.id = a.job_id
+		WHERE d.candidate_user_id = $1 AND d.status = 'SENT'
+		ORDER BY d.sent_at DESC
+		LIMIT $2 OFFSET $3`, candidateUserID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	messages := []domain.CandidateMessage{}
+	for rows.Next() {
+		var m domain.CandidateMessage
+		if err := rows.Scan(&m.ID, &m.CompanyName, &m.JobTitle, &m.Subject, &m.Body, &m.SentAt, &m.ReportedSpamAt); err != nil {
+			return nil, 0, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, total, rows.Err()
+}
+
+// ReportSpam keeps the first report time when a message is reported again
+func (r *employerMessageRepo) ReportSpam(ctx context.Context, candidateUserID string, deliveryID int64, at time.Time) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE employer_message_deliveries SET reported_spam_at = COALESCE(reported_spam_at, $3)
+		WHERE id = $1 AND candidate_user_id = $2 AND status = 'SENT'`, deliveryID, candidateUserID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
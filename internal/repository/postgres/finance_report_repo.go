@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type financeReportRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewFinanceReportRepository(db *pgxpool.Pool) domain.FinanceReportRepository {
+	return &financeReportRepo{db: db}
+}
+
+func (r *financeReportRepo) HasFinanceAccess(ctx context.Context, userID string) (bool, error) {
+	var allowed bool
+	err := r.db.QueryRow(ctx, `SELECT finance_access FROM users WHERE id = $1`, userID).Scan(&allowed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return allowed, nil
+}
+
+func (r *financeReportRepo) GetMonthlySummaries(ctx context.Context, from, to time.Time) ([]domain.FinanceMonthSummary, error) {
+	query := `
+		WITH months AS (
+			SELECT m AS month_start, m + INTERVAL '1 month' AS month_end
+			FROM generate_series($1::timestamptz, $2::timestamptz, INTERVAL '1 month') AS m
+		)
+		SELECT
+			to_char(mo.month_start AT TIME ZONE 'UTC', 'YYYY-MM'),
+			COALESCE((SELECT SUM(i.amount_idr) FROM company_invoices i
+				WHERE i.status = 'paid' AND i.paid_at >= mo.month_start AND i.paid_at < mo.month_end), 0),
+			(SELECT COUNT(*) FROM company_invoices i
+				WHERE i.status = 'paid' AND i.paid_at >= mo.month_start AND i.paid_at < mo.month_end),
+			COALESCE((SELECT SUM(l.amount) FROM company_credit_ledger l
+				WHERE l.entry_type = 'plan_grant' AND l.created_at >= mo.month_start AND l.created_at < mo.month_end), 0),
+			COALESCE((SELECT SUM(l.amount) FROM company_credit_ledger l
+				WHERE l.entry_type = 'purchase' AND l.created_at >= mo.month_start AND l.created_at < mo.month_end), 0),
+			COALESCE((SELECT -SUM(l.amount) FROM company_credit_ledger l
+				WHERE l.entry_type = 'reveal' AND l.created_at >= mo.month_start AND l.created_at < mo.month_end), 0),
+			(SELECT COUNT(*) FROM company_subscriptions s
+				WHERE s.started_at < mo.month_end AND (s.cancelled_at IS NULL OR s.cancelled_at >= mo.month_end)),
+			(SELECT COUNT(*) FROM company_subscriptions s
+				WHERE s.cancelled_at >= mo.month_start AND s.cancelled_at < mo.month_end)
+		FROM months mo
+		ORDER BY mo.month_start
+	`
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := []domain.FinanceMonthSummary{}
+	for rows.Next() {
+		var s domain.FinanceMonthSummary
+		if err := rows.Scan(
+			&s.Month, &s.RevenueIDR, &s.PaidInvoices,
+			&s.CreditsGranted, &s.CreditsPurchased, &s.CreditsConsumed,
+			&s.ActiveCompanies, &s.ChurnedCompanies,
+		); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, rows.Err()
+}
+
+func (r *financeReportRepo) GetOutstandingTotals(ctx context.Context, now time.Time) (int64, int64, int64, int64, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(amount_idr), 0),
+			COUNT(*) FILTER (WHERE due_at < $1),
+			COALESCE(SUM(amount_idr) FILTER (WHERE due_at < $1), 0)
+		FROM company_invoices
+		WHERE status = 'issued'
+	`
+	var count, amount, overdueCount, overdueAmount int64
+	err := r.db.QueryRow(ctx, query, now).Scan(&count, &amount, &overdueCount, &overdueAmount)
+	return count, amount, overdueCount, overdueAmount, err
+}
+
+func (r *financeReportRepo) GetRevenueByPlan(ctx context.Context, start, end time.Time) ([]domain.PlanRevenue, error) {
+	query := `
+		SELECT i.plan_code, COALESCE(p.name, 'One-off charges'), SUM(i.amount_idr), COUNT(*)
+		FROM company_invoices i
+		LEFT JOIN billing_plans p ON p.code = i.plan_code
+		WHERE i.status = 'paid' AND i.paid_at >= $1 AND i.paid_at < $2
+		GROUP BY i.plan_code, p.name
+		ORDER BY SUM(i.amount_idr) DESC
+	`
+	rows, err := r.db.Query(ctx, query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revenue := []domain.PlanRevenue{}
+	for rows.Next() {
+		var p domain.PlanRevenue
+		if err := rows.Scan(&p.PlanCode, &p.PlanName, &p.RevenueIDR, &p.PaidInvoices); err != nil {
+			return nil, err
+		}
+		revenue = append(revenue, p)
+	}
+	return revenue, rows.Err()
+}
+
+func (r *financeReportRepo) GetCreditUsage(ctx context.Context, start, end time.Time) ([]domain.CompanyCreditUsage, error) {
+	query := `
+		SELECT
+			cp.id, cp.company_name,
+			COALESCE(SUM(l.amount) FILTER (WHERE l.amount > 0), 0),
+			COALESCE(-SUM(l.amount) FILTER (WHERE l.entry_type = 'reveal'), 0)
+		FROM company_credit_ledger l
+		JOIN company_profiles cp ON cp.id = l.company_id
+		WHERE l.created_at >= $1 AND l.created_at < $2
+		GROUP BY cp.id, cp.company_name
+		ORDER BY 4 DESC, cp.company_name
+	`
+	rows, err := r.db.Query(ctx, query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := []domain.CompanyCreditUsage{}
+	for rows.Next() {
+		var u domain.CompanyCreditUsage
+		if err := rows.Scan(&u.CompanyID, &u.CompanyName, &u.CreditsAdded, &u.CreditsConsumed); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+func (r *financeReportRepo) GetChurnedCompanies(ctx context.Context, start, end time.Time) ([]domain.ChurnedCompany, error) {
+	query := `
+		SELECT cp.id, cp.company_name, s.plan_code, s.started_at, s.cancelled_at
+		FROM company_subscriptions s
+		JOIN company_profiles cp ON cp.id = s.company_id
+		WHERE s.cancelled_at >= $1 AND s.cancelled_at < $2
+		ORDER BY s.cancelled_at
+	`
+	rows, err := r.db.Query(ctx, query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	churned := []domain.ChurnedCompany{}
+	for rows.Next() {
+		var c domain.ChurnedCompany
+		if err := rows.Scan(&c.CompanyID, &c.CompanyName, &c.PlanCode, &c.StartedAt, &c.CancelledAt); err != nil {
+			return nil, err
+		}
+		churned = append(churned, c)
+	}
+	return churned, rows.Err()
+}
+
+func (r *financeReportRepo) ListOutstandingInvoices(ctx context.Context, start, end, now time.Time) ([]domain.OutstandingInvoice, error) {
+	query := `
+		SELECT i.id, cp.id, cp.company_name, i.plan_code, i.description, i.amount_idr,
+			i.issued_at, i.due_at, i.due_at < $3
+		FROM company_invoices i
+		JOIN company_profiles cp ON cp.id = i.company_id
+		WHERE i.status = 'issued' AND i.issued_at >= $1 AND i.issued_at < $2
+		ORDER BY i.due_at
+	`
+	rows, err := r.db.Query(ctx, query, start, end, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invoices := []domain.OutstandingInvoice{}
+	for rows.Next() {
+		var i domain.OutstandingInvoice
+		if err := rows.Scan(
+			&i.ID, &i.CompanyID, &i.CompanyName, &i.PlanCode, &i.Description, &i.AmountIDR,
+			&i.IssuedAt, &i.DueAt, &i.Overdue,
+		); err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, i)
+	}
+	return invoices, rows.Err()
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type holidayRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewHolidayRepository(db *pgxpool.Pool) domain.HolidayRepository {
+	return &holidayRepo{db: db}
+}
+
+const holidayColumns = `id, country_code, to_char(holiday_date, 'YYYY-MM-DD'), name, is_active, created_at, updated_at`
+
+func (r *holidayRepo) List(ctx context.Context, filter domain.HolidayFilter) ([]domain.PublicHoliday, error) {
+	query := `SELECT ` + holidayColumns + ` FROM public_holidays WHERE 1=1`
+	args := []interface{}{}
+
+	if filter.CountryCode != "" {
+		args = append(args, filter.CountryCode)
+		query += fmt.Sprintf(" AND country_code = $%d", len(args))
+	}
+	if filter.Year > 0 {
+		args = append(args, filter.Year)
+		query += fmt.Sprintf(" AND EXTRACT(YEAR FROM holiday_date) = $%d", len(args))
+	}
+	query += " ORDER BY holiday_date, country_code"
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *holidayRepo) ListActive(ctx context.Context) ([]domain.PublicHoliday, error) {
+	query := `SELECT ` + holidayColumns + ` FROM public_holidays WHERE is_active = TRUE ORDER BY holiday_date`
+	return r.query(ctx, query)
+}
+
+func (r *holidayRepo) Create(ctx context.Context, h *domain.PublicHoliday, createdBy string) error {
+	query := `
+		INSERT INTO public_holidays (country_code, holiday_date, name, is_active, created_by)
+		VALUES ($1, $2::date, $3, $4, NULLIF($5, '')::uuid)
+		RETURNING id, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, h.CountryCode, h.Date, h.Name, h.IsActive, createdBy).
+		Scan(&h.ID, &h.CreatedAt, &h.UpdatedAt)
+	return mapHolidayError(err)
+}
+
+func (r *holidayRepo) Update(ctx context.Context, h *domain.PublicHoliday) error {
+	query := `
+		UPDATE public_holidays
+		SET country_code = $2, holiday_date = $3::date, name = $4, is_active = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, h.ID, h.CountryCode, h.Date, h.Name, h.IsActive).
+		Scan(&h.CreatedAt, &h.UpdatedAt)
+	return mapHolidayError(err)
+}
+
+func (r *holidayRepo) Delete(ctx context.Context, id int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM public_holidays WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *holidayRepo) query(ctx context.Context, query string, args ...interface{}) ([]domain.PublicHoliday, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holidays := []domain.PublicHoliday{}
+	for rows.Next() {
+		var h domain.PublicHoliday
+		if err := rows.Scan(&h.ID, &h.CountryCode, &h.Date, &h.Name, &h.IsActive, &h.CreatedAt, &h.UpdatedAt); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, h)
+	}
+	return holidays, rows.Err()
+}
+
+func mapHolidayError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return apperror.Conflict("A holiday already exists on this date for this country")
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type interviewFeedbackRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewInterviewFeedbackRepository creates a new interview feedback repository
+func NewInterviewFeedbackRepository(db *pgxpool.Pool) domain.InterviewFeedbackRepository {
+	return &interviewFeedbackRepo{db: db}
+}
+
+func (r *interviewFeedbackRepo) GetApplication(ctx context.Context, applicationID int64) (*domain.InterviewFeedbackApplication, error) {
+	var a domain.InterviewFeedbackApplication
+	err := r.db.QueryRow(ctx, `
+		SELECT a.id, a.status, a.candidate_user_id, j.title, cp.id, cp.user_id,
+		       COALESCE(cp.company_name, 'Unknown Company')
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN company_profiles cp ON cp.id = j.company_id
+		WHERE a.id = $1`, applicationID,
+	).Scan(&a.ApplicationID, &a.Status, &a.CandidateUserID, &a.JobTitle, &a.CompanyID, &a.CompanyUserID, &a.CompanyName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *interviewFeedbackRepo) Create(ctx context.Context, f *domain.InterviewFeedback) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO interview_feedback
+			(application_id, company_id, candidate_user_id, author_user_id, template_keys, custom_message, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (application_id) DO NOTHING
+		RETURNING id, created_at`,
+		f.ApplicationID, f.CompanyID, f.CandidateUserID, f.AuthorUserID, f.TemplateKeys, f.CustomMessage, f.Status,
+	).Scan(&f.ID, &f.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrInterviewFeedbackExists
+	}
+	return err
+}
+
+const interviewFeedbackSelect = `
+	SELECT f.id, f.application_id, f.company_id, f.candidate_user_id, f.author_user_id,
+	       f.template_keys, f.custom_message, f.status, f.review_note, f.reviewed_by,
+	       f.reviewed_at, f.sent_at, f.created_at,
+	       COALESCE(cp.company_name, 'Unknown Company'), COALESCE(j.title, '')
+	FROM interview_feedback f
+	LEFT JOIN company_profiles cp ON cp.id = f.company_id
+	LEFT JOIN applications a ON a.id = f.application_id
+	LEFT JOIN jobs j ON j.id = a.job_id`
+
+func scanInterviewFeedback(row pgx.Row) (*domain.InterviewFeedback, error) {
+	var f domain.InterviewFeedback
+	if err := row.Scan(
+		&f.ID, &f.ApplicationID, &f.CompanyID, &f.CandidateUserID, &f.AuthorUserID,
+		&f.TemplateKeys, &f.CustomMessage, &f.Status, &f.ReviewNote, &f.ReviewedBy,
+		&f.ReviewedAt, &f.SentAt, &f.CreatedAt,
+		&f.CompanyName, &f.JobTitle,
+	); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *interviewFeedbackRepo) getOne(ctx context.Context, where string, arg any) (*domain.InterviewFeedback, error) {
+	f, err := scanInterviewFeedback(r.db.QueryRow(ctx, interviewFeedbackSelect+" WHERE "+where, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (r *interviewFeedbackRepo) GetByID(ctx context.Context, id int64) (*domain.InterviewFeedback, error) {
+	return r.getOne(ctx, "f.id = $1", id)
+}
+
+func (r *interviewFeedbackRepo) GetByApplication(ctx context.Context, applicationID int64) (*domain.InterviewFeedback, error) {
+	return r.getOne(ctx, "f.application_id = $1", applicationID)
+}
+
+func (r *interviewFeedbackRepo) list(ctx context.Context, query string, args ...any) ([]domain.InterviewFeedback, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feedback := []domain.InterviewFeedback{}
+	for rows.Next() {
+		f, err := scanInterviewFeedback(rows)
+		if err != nil {
+			return nil, err
+		}
+		feedback = append(feedback, *f)
+	}
+	return feedback, rows.Err()
+}
+
+// ListByStatus returns the oldest first, so the review queue is worked in order
+func (r *interviewFeedbackRepo) ListByStatus(ctx context.Context, status string, limit int) ([]domain.InterviewFeedback, error) {
+	return r.list(ctx, interviewFeedbackSelect+` WHERE f.status = $1 ORDER BY f.created_at LIMIT $2`, status, limit)
+}
+
+func (r *interviewFeedbackRepo) ListSentToCandidate(ctx context.Context, userID string) ([]domain.InterviewFeedback, error) {
+	return r.list(ctx, interviewFeedbackSelect+` WHERE f.candidate_user_id = $1 AND f.status = 'SENT' ORDER BY f.sent_at DESC`, userID)
+}
+
+func (r *interviewFeedbackRepo) Review(ctx context.Context, id int64, status, reviewerID string, note *string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE interview_feedback
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = NOW()
+		WHERE id = $1 AND status IN ('PENDING_REVIEW', 'APPROVED')`, id, status, reviewerID, note)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *interviewFeedbackRepo) MarkDelivered(ctx context.Context, id int64, status string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE interview_feedback
+		SET status = $2, sent_at = CASE WHEN $2 = 'SENT' THEN NOW() END
+		WHERE id = $1 AND status = 'APPROVED'`, id, status)
+	return err
+}
+
+func (r *interviewFeedbackRepo) GetOptOut(ctx context.Context, userID string) (*time.Time, error) {
+	var optOutAt *time.Time
+	err := r.db.QueryRow(ctx, `SELECT interview_feedback_opt_out_at FROM users WHERE id = $1`, userID).Scan(&optOutAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return optOutAt, nil
+}
+
+func (r *interviewFeedbackRepo) SetOptOut(ctx context.Context, userID string, at *time.Time) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE users SET interview_feedback_opt_out_at = $2, updated_at = NOW() WHERE id = $1`,
+		userID, at,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
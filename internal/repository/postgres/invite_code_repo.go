@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type inviteCodeRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewInviteCodeRepository creates a new invite code repository
+func NewInviteCodeRepository(db *pgxpool.Pool) domain.InviteCodeRepository {
+	return &inviteCodeRepo{db: db}
+}
+
+// inviteCodeStatusExpr derives domain.InviteCode.Status; revocation wins over
+// usage, and usage over expiry
+const inviteCodeStatusExpr = `CASE
+		WHEN ic.revoked_at IS NOT NULL THEN 'REVOKED'
+		WHEN ic.use_count >= ic.max_uses THEN 'USED_UP'
+		WHEN ic.expires_at IS NOT NULL AND ic.expires_at <= NOW() THEN 'EXPIRED'
+		ELSE 'ACTIVE' END`
+
+const inviteCodeColumns = `ic.id, ic.code, ic.role, ic.max_uses, ic.use_count, ic.expires_at, ic.note,
+	` + inviteCodeStatusExpr + `, ic.created_by::TEXT, ic.revoked_at, ic.created_at`
+
+func inviteCodeDest(c *domain.InviteCode) []interface{} {
+	return []interface{}{
+		&c.ID, &c.Code, &c.Role, &c.MaxUses, &c.UseCount, &c.ExpiresAt, &c.Note,
+		&c.Status, &c.CreatedBy, &c.RevokedAt, &c.CreatedAt,
+	}
+}
+
+func (r *inviteCodeRepo) CreateMany(ctx context.Context, codes []*domain.InviteCode) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, c := range codes {
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO invite_codes (code, role, max_uses, expires_at, note, created_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at`,
+			c.Code, c.Role, c.MaxUses, c.ExpiresAt, c.Note, c.CreatedBy,
+		).Scan(&c.ID, &c.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *inviteCodeRepo) List(ctx context.Context, filter domain.InviteCodeFilter) ([]domain.InviteCode, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND "+inviteCodeStatusExpr+" = $%d", argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.Role != "" {
+		where += fmt.Sprintf(" AND ic.role = $%d", argIndex)
+		args = append(args, filter.Role)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM invite_codes ic`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + inviteCodeColumns + ` FROM invite_codes ic` + where +
+		fmt.Sprintf(" ORDER BY ic.created_at DESC, ic.id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	codes := []domain.InviteCode{}
+	for rows.Next() {
+		var c domain.InviteCode
+		if err := rows.Scan(inviteCodeDest(&c)...); err != nil {
+			return nil, 0, err
+		}
+		codes = append(codes, c)
+	}
+	return codes, total, rows.Err()
+}
+
+func (r *inviteCodeRepo) GetByID(ctx context.Context, id int64) (*domain.InviteCode, error) {
+	var c domain.InviteCode
+	err := r.db.QueryRow(ctx, `SELECT `+inviteCodeColumns+` FROM invite_codes ic WHERE ic.id = $1`, id).Scan(inviteCodeDest(&c)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *inviteCodeRepo) ListRedemptions(ctx context.Context, codeID int64) ([]domain.InviteCodeRedemption, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, code_id, email, role, user_id::TEXT, ip_address, created_at
+		FROM invite_code_redemptions
+		WHERE code_id = $1
+		ORDER BY created_at DESC, id DESC`, codeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	redemptions := []domain.InviteCodeRedemption{}
+	for rows.Next() {
+		var rd domain.InviteCodeRedemption
+		if err := rows.Scan(&rd.ID, &rd.CodeID, &rd.Email, &rd.Role, &rd.UserID, &rd.IPAddress, &rd.CreatedAt); err != nil {
+			return nil, err
+		}
+		redemptions = append(redemptions, rd)
+	}
+	return redemptions, rows.Err()
+}
+
+func (r *inviteCodeRepo) Revoke(ctx context.Context, id int64) error {
+	tag, err := r.db.Exec(ctx, `UPDATE invite_codes SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *inviteCodeRepo) Redeem(ctx context.Context, code string, rd *domain.InviteCodeRedemption) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// The conditional increment takes the use atomically, so concurrent
+	// registrations cannot exceed max_uses
+	err = tx.QueryRow(ctx, `
+		UPDATE invite_codes SET use_count = use_count + 1
+		WHERE code = $1
+		  AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > NOW())
+		  AND use_count < max_uses
+		  AND (role IS NULL OR role = $2)
+		RETURNING id`, code, rd.Role,
+	).Scan(&rd.CodeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO invite_code_redemptions (code_id, email, role, ip_address)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		rd.CodeID, rd.Email, rd.Role, rd.IPAddress,
+	).Scan(&rd.ID, &rd.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *inviteCodeRepo) Release(ctx context.Context, redemptionID int64) error {
+	_, err := r.db.Exec(ctx, `
+		WITH released AS (
+			DELETE FROM invite_code_redemptions WHERE id = $1 AND user_id IS NULL RETURNING code_id
+		)
+		UPDATE invite_codes ic SET use_count = ic.use_count - 1
+		FROM released WHERE ic.id = released.code_id`, redemptionID)
+	return err
+}
+
+func (r *inviteCodeRepo) Complete(ctx context.Context, redemptionID int64, userID string) error {
+	_, err := r.db.Exec(ctx, `UPDATE invite_code_redemptions SET user_id = $2 WHERE id = $1`, redemptionID, userID)
+	return err
+}
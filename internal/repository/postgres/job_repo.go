@@ -2,11 +2,33 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go-recruitment-backend/internal/domain"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// publishedJobExpr matches publicly listed jobs. It compares the schedule times and
+// the apply deadline itself, so listings are exact between scheduler worker runs
+// (see Job.IsPublished).
+const publishedJobExpr = `(j.company_status = 'active' OR (j.company_status = 'scheduled' AND j.publish_at <= NOW()))
+	AND (j.unpublish_at IS NULL OR j.unpublish_at > NOW())
+	AND NOT (j.auto_close AND j.apply_deadline IS NOT NULL AND j.apply_deadline <= NOW())`
+
+// applicantCountExpr counts the applications to job j that count towards its
+// max_applicants; knocked-out applications do not
+const applicantCountExpr = `(SELECT COUNT(*) FROM applications a WHERE a.job_id = j.id AND NOT a.auto_rejected)`
+
+// popularityScoreExpr ranks jobs by views and applications in the last 7 days
+var popularityScoreExpr = fmt.Sprintf(`(
+		COALESCE((SELECT SUM(v.views) FROM job_view_counts v WHERE v.job_id = j.id AND v.view_date > CURRENT_DATE - 7), 0)
+		+ %d * (SELECT COUNT(*) FROM applications a WHERE a.job_id = j.id AND a.created_at > NOW() - INTERVAL '7 days')
+	)`, domain.JobPopularityApplicationWeight)
+
 type jobRepo struct {
 	db *pgxpool.Pool
 }
@@ -15,24 +37,45 @@ func NewJobRepository(db *pgxpool.Pool) domain.JobRepository {
 	return &jobRepo{db: db}
 }
 
-func (r *jobRepo) Create(ctx context.Context, job *domain.Job) error {
-	query := `INSERT INTO jobs (company_id, title, description, salary_min, salary_max, location, company_status, employment_type, job_type, experience_level, qualifications, created_at, updated_at) 
-              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
-	err := r.db.QueryRow(ctx, query,
-		job.CompanyID, job.Title, job.Description, job.SalaryMin, job.SalaryMax, job.Location, job.CompanyStatus,
+const jobInsertQuery = `INSERT INTO jobs (company_id, title, description, salary_min, salary_max, location, location_id, company_status, employment_type, job_type, experience_level, qualifications, japanese_level_required, publish_at, unpublish_at, is_confidential, apply_deadline, max_applicants, auto_close, created_at, updated_at) 
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21) RETURNING id`
+
+func jobInsertArgs(job *domain.Job) []interface{} {
+	return []interface{}{
+		job.CompanyID, job.Title, job.Description, job.SalaryMin, job.SalaryMax, job.Location, job.LocationID, job.CompanyStatus,
 		job.EmploymentType, job.JobType, job.ExperienceLevel, job.Qualifications,
-		job.CreatedAt, job.UpdatedAt,
-	).Scan(&job.ID)
-	return err
+		job.JapaneseLevelRequired, job.PublishAt, job.UnpublishAt, job.IsConfidential,
+		job.ApplyDeadline, job.MaxApplicants, job.AutoClose, job.CreatedAt, job.UpdatedAt,
+	}
+}
+
+func (r *jobRepo) Create(ctx context.Context, job *domain.Job) error {
+	return r.db.QueryRow(ctx, jobInsertQuery, jobInsertArgs(job)...).Scan(&job.ID)
+}
+
+// CreateMany inserts the jobs in one transaction, so an import creates all of its valid rows or none
+func (r *jobRepo) CreateMany(ctx context.Context, jobs []*domain.Job) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, job := range jobs {
+		if err := tx.QueryRow(ctx, jobInsertQuery, jobInsertArgs(job)...).Scan(&job.ID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *jobRepo) GetByID(ctx context.Context, id int64) (*domain.Job, error) {
-	query := `SELECT id, company_id, title, description, salary_min, salary_max, location, company_status, employment_type, job_type, experience_level, qualifications, created_at, updated_at FROM jobs WHERE id = $1`
+	query := `SELECT id, company_id, title, description, salary_min, salary_max, location, location_id, company_status, employment_type, job_type, experience_level, qualifications, japanese_level_required, publish_at, unpublish_at, is_confidential, apply_deadline, max_applicants, auto_close, created_at, updated_at FROM jobs WHERE id = $1`
 	var job domain.Job
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.CompanyStatus,
+		&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.LocationID, &job.CompanyStatus,
 		&job.EmploymentType, &job.JobType, &job.ExperienceLevel, &job.Qualifications,
-		&job.CreatedAt, &job.UpdatedAt,
+		&job.JapaneseLevelRequired, &job.PublishAt, &job.UnpublishAt, &job.IsConfidential, &job.ApplyDeadline, &job.MaxApplicants, &job.AutoClose, &job.CreatedAt, &job.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -45,31 +88,39 @@ func (r *jobRepo) GetByIDWithCompany(ctx context.Context, id int64) (*domain.Job
 	query := `
 		SELECT 
 			j.id, j.company_id, j.title, j.description, j.salary_min, j.salary_max, 
-			j.location, j.company_status, j.employment_type, j.job_type, 
-			j.experience_level, j.qualifications, j.created_at, j.updated_at,
+			j.location, j.location_id, j.company_status, j.employment_type, j.job_type, 
+			j.experience_level, j.qualifications, j.japanese_level_required, j.publish_at, j.unpublish_at, j.is_confidential, j.apply_deadline, j.max_applicants, j.auto_close, j.created_at, j.updated_at,
 			COALESCE(cp.company_name, 'Unknown Company') as company_name,
 			cp.logo_url,
 			cp.website,
-			cp.industry
+			cp.industry,
+			COALESCE(cp.verification_level, 'BASIC'),
+			cp.employee_count
 		FROM jobs j
 		LEFT JOIN company_profiles cp ON j.company_id = cp.id
 		WHERE j.id = $1`
 
 	var job domain.JobWithCompany
+	var employeeCount *string
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax,
-		&job.Location, &job.CompanyStatus, &job.EmploymentType, &job.JobType,
-		&job.ExperienceLevel, &job.Qualifications, &job.CreatedAt, &job.UpdatedAt,
-		&job.CompanyName, &job.CompanyLogoURL, &job.CompanyWebsite, &job.Industry,
+		&job.Location, &job.LocationID, &job.CompanyStatus, &job.EmploymentType, &job.JobType,
+		&job.ExperienceLevel, &job.Qualifications, &job.JapaneseLevelRequired, &job.PublishAt, &job.UnpublishAt, &job.IsConfidential, &job.ApplyDeadline, &job.MaxApplicants, &job.AutoClose, &job.CreatedAt, &job.UpdatedAt,
+		&job.CompanyName, &job.CompanyLogoURL, &job.CompanyWebsite, &job.Industry, &job.CompanyVerificationLevel,
+		&employeeCount,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
 		return nil, err
 	}
+	job.CompanySizeBand = domain.CompanySizeBandFor(employeeCount)
 	return &job, nil
 }
 
 func (r *jobRepo) Fetch(ctx context.Context, limit, offset int) ([]domain.Job, int64, error) {
-	query := `SELECT id, company_id, title, description, salary_min, salary_max, location, company_status, employment_type, job_type, experience_level, qualifications, created_at, updated_at 
+	query := `SELECT id, company_id, title, description, salary_min, salary_max, location, location_id, company_status, employment_type, job_type, experience_level, qualifications, japanese_level_required, publish_at, unpublish_at, is_confidential, apply_deadline, max_applicants, auto_close, created_at, updated_at 
               FROM jobs ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
 	rows, err := r.db.Query(ctx, query, limit, offset)
@@ -81,7 +132,7 @@ func (r *jobRepo) Fetch(ctx context.Context, limit, offset int) ([]domain.Job, i
 	var jobs []domain.Job
 	for rows.Next() {
 		var job domain.Job
-		if err := rows.Scan(&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.CompanyStatus, &job.EmploymentType, &job.JobType, &job.ExperienceLevel, &job.Qualifications, &job.CreatedAt, &job.UpdatedAt); err != nil {
+		if err := rows.Scan(&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.LocationID, &job.CompanyStatus, &job.EmploymentType, &job.JobType, &job.ExperienceLevel, &job.Qualifications, &job.JapaneseLevelRequired, &job.PublishAt, &job.UnpublishAt, &job.IsConfidential, &job.ApplyDeadline, &job.MaxApplicants, &job.AutoClose, &job.CreatedAt, &job.UpdatedAt); err != nil {
 			return nil, 0, err
 		}
 		jobs = append(jobs, job)
@@ -100,12 +151,14 @@ func (r *jobRepo) FetchWithCompany(ctx context.Context, limit, offset int) ([]do
 	query := `
 		SELECT 
 			j.id, j.company_id, j.title, j.description, j.salary_min, j.salary_max, 
-			j.location, j.company_status, j.employment_type, j.job_type, 
-			j.experience_level, j.qualifications, j.created_at, j.updated_at,
+			j.location, j.location_id, j.company_status, j.employment_type, j.job_type, 
+			j.experience_level, j.qualifications, j.japanese_level_required, j.publish_at, j.unpublish_at, j.is_confidential, j.apply_deadline, j.max_applicants, j.auto_close, j.created_at, j.updated_at,
 			COALESCE(cp.company_name, 'Unknown Company') as company_name,
 			cp.logo_url,
 			cp.website,
-			cp.industry
+			cp.industry,
+			COALESCE(cp.verification_level, 'BASIC'),
+			cp.employee_count
 		FROM jobs j
 		LEFT JOIN company_profiles cp ON j.company_id = cp.id
 		ORDER BY j.created_at DESC 
@@ -120,14 +173,17 @@ func (r *jobRepo) FetchWithCompany(ctx context.Context, limit, offset int) ([]do
 	var jobs []domain.JobWithCompany
 	for rows.Next() {
 		var job domain.JobWithCompany
+		var employeeCount *string
 		if err := rows.Scan(
 			&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax,
-			&job.Location, &job.CompanyStatus, &job.EmploymentType, &job.JobType,
-			&job.ExperienceLevel, &job.Qualifications, &job.CreatedAt, &job.UpdatedAt,
-			&job.CompanyName, &job.CompanyLogoURL, &job.CompanyWebsite, &job.Industry,
+			&job.Location, &job.LocationID, &job.CompanyStatus, &job.EmploymentType, &job.JobType,
+			&job.ExperienceLevel, &job.Qualifications, &job.JapaneseLevelRequired, &job.PublishAt, &job.UnpublishAt, &job.IsConfidential, &job.ApplyDeadline, &job.MaxApplicants, &job.AutoClose, &job.CreatedAt, &job.UpdatedAt,
+			&job.CompanyName, &job.CompanyLogoURL, &job.CompanyWebsite, &job.Industry, &job.CompanyVerificationLevel,
+			&employeeCount,
 		); err != nil {
 			return nil, 0, err
 		}
+		job.CompanySizeBand = domain.CompanySizeBandFor(employeeCount)
 		jobs = append(jobs, job)
 	}
 
@@ -141,20 +197,27 @@ func (r *jobRepo) FetchWithCompany(ctx context.Context, limit, offset int) ([]do
 
 // FetchPublicActiveJobs retrieves only ACTIVE jobs with company data for public access
 // SECURITY: This method hardcodes the 'active' filter - no client-side bypass possible
-func (r *jobRepo) FetchPublicActiveJobs(ctx context.Context, limit, offset int) ([]domain.JobWithCompany, int64, error) {
+func (r *jobRepo) FetchPublicActiveJobs(ctx context.Context, sort string, limit, offset int) ([]domain.JobWithCompany, int64, error) {
+	orderBy := "j.created_at DESC"
+	if sort == domain.JobSearchSortPopular {
+		orderBy = popularityScoreExpr + " DESC, j.created_at DESC"
+	}
+
 	query := `
 		SELECT 
 			j.id, j.company_id, j.title, j.description, j.salary_min, j.salary_max, 
-			j.location, j.company_status, j.employment_type, j.job_type, 
-			j.experience_level, j.qualifications, j.created_at, j.updated_at,
+			j.location, j.location_id, j.company_status, j.employment_type, j.job_type, 
+			j.experience_level, j.qualifications, j.japanese_level_required, j.publish_at, j.unpublish_at, j.is_confidential, j.apply_deadline, j.max_applicants, j.auto_close, j.created_at, j.updated_at,
 			COALESCE(cp.company_name, 'Unknown Company') as company_name,
 			cp.logo_url,
 			cp.website,
-			cp.industry
+			cp.industry,
+			COALESCE(cp.verification_level, 'BASIC'),
+			cp.employee_count
 		FROM jobs j
 		LEFT JOIN company_profiles cp ON j.company_id = cp.id
-		WHERE j.company_status = 'active'
-		ORDER BY j.created_at DESC 
+		WHERE ` + publishedJobExpr + `
+		ORDER BY ` + orderBy + `, j.id DESC
 		LIMIT $1 OFFSET $2`
 
 	rows, err := r.db.Query(ctx, query, limit, offset)
@@ -166,19 +229,22 @@ func (r *jobRepo) FetchPublicActiveJobs(ctx context.Context, limit, offset int)
 	var jobs []domain.JobWithCompany
 	for rows.Next() {
 		var job domain.JobWithCompany
+		var employeeCount *string
 		if err := rows.Scan(
 			&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax,
-			&job.Location, &job.CompanyStatus, &job.EmploymentType, &job.JobType,
-			&job.ExperienceLevel, &job.Qualifications, &job.CreatedAt, &job.UpdatedAt,
-			&job.CompanyName, &job.CompanyLogoURL, &job.CompanyWebsite, &job.Industry,
+			&job.Location, &job.LocationID, &job.CompanyStatus, &job.EmploymentType, &job.JobType,
+			&job.ExperienceLevel, &job.Qualifications, &job.JapaneseLevelRequired, &job.PublishAt, &job.UnpublishAt, &job.IsConfidential, &job.ApplyDeadline, &job.MaxApplicants, &job.AutoClose, &job.CreatedAt, &job.UpdatedAt,
+			&job.CompanyName, &job.CompanyLogoURL, &job.CompanyWebsite, &job.Industry, &job.CompanyVerificationLevel,
+			&employeeCount,
 		); err != nil {
 			return nil, 0, err
 		}
+		job.CompanySizeBand = domain.CompanySizeBandFor(employeeCount)
 		jobs = append(jobs, job)
 	}
 
 	var total int64
-	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs WHERE company_status = 'active'`).Scan(&total); err != nil {
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs j WHERE `+publishedJobExpr).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
@@ -187,7 +253,7 @@ func (r *jobRepo) FetchPublicActiveJobs(ctx context.Context, limit, offset int)
 
 // FetchByCompanyID retrieves jobs for a specific company (employer's jobs only)
 func (r *jobRepo) FetchByCompanyID(ctx context.Context, companyID int64, limit, offset int) ([]domain.Job, int64, error) {
-	query := `SELECT id, company_id, title, description, salary_min, salary_max, location, company_status, employment_type, job_type, experience_level, qualifications, created_at, updated_at 
+	query := `SELECT id, company_id, title, description, salary_min, salary_max, location, location_id, company_status, employment_type, job_type, experience_level, qualifications, japanese_level_required, publish_at, unpublish_at, is_confidential, apply_deadline, max_applicants, auto_close, created_at, updated_at 
               FROM jobs WHERE company_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
 
 	rows, err := r.db.Query(ctx, query, companyID, limit, offset)
@@ -199,7 +265,7 @@ func (r *jobRepo) FetchByCompanyID(ctx context.Context, companyID int64, limit,
 	var jobs []domain.Job
 	for rows.Next() {
 		var job domain.Job
-		if err := rows.Scan(&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.CompanyStatus, &job.EmploymentType, &job.JobType, &job.ExperienceLevel, &job.Qualifications, &job.CreatedAt, &job.UpdatedAt); err != nil {
+		if err := rows.Scan(&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.LocationID, &job.CompanyStatus, &job.EmploymentType, &job.JobType, &job.ExperienceLevel, &job.Qualifications, &job.JapaneseLevelRequired, &job.PublishAt, &job.UnpublishAt, &job.IsConfidential, &job.ApplyDeadline, &job.MaxApplicants, &job.AutoClose, &job.CreatedAt, &job.UpdatedAt); err != nil {
 			return nil, 0, err
 		}
 		jobs = append(jobs, job)
@@ -224,12 +290,19 @@ func (r *jobRepo) Update(ctx context.Context, job *domain.Job) error {
 		job_type = $8, 
 		experience_level = $9, 
 		qualifications = $10, 
+		japanese_level_required = $12, 
+		is_confidential = $13, 
+		apply_deadline = $14, 
+		max_applicants = $15, 
+		auto_close = $16, 
+		location_id = $17, 
 		updated_at = $11 
 	WHERE id = $1`
 	result, err := r.db.Exec(ctx, query,
 		job.ID, job.Title, job.Description, job.SalaryMin, job.SalaryMax, job.Location,
 		job.EmploymentType, job.JobType, job.ExperienceLevel, job.Qualifications,
-		job.UpdatedAt,
+		job.UpdatedAt, job.JapaneseLevelRequired, job.IsConfidential,
+		job.ApplyDeadline, job.MaxApplicants, job.AutoClose, job.LocationID,
 	)
 	if err != nil {
 		return err
@@ -251,3 +324,354 @@ func (r *jobRepo) Delete(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// HasAdvancedApplication checks the current stage and the stage history, so a
+// candidate who was advanced and later rejected keeps seeing the company
+func (r *jobRepo) HasAdvancedApplication(ctx context.Context, jobID int64, userID string) (bool, error) {
+	var advanced bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM applications a
+			WHERE a.job_id = $1 AND a.candidate_user_id = $2
+			  AND (a.stage NOT IN ('applied', 'rejected') OR EXISTS(
+				SELECT 1 FROM application_stage_history h
+				WHERE h.application_id = a.id AND h.to_stage NOT IN ('applied', 'rejected')
+			  ))
+		)`, jobID, userID).Scan(&advanced)
+	return advanced, err
+}
+
+const jobCardSelect = `
+	SELECT j.id, j.company_id, j.title, j.location, j.salary_min, j.salary_max,
+	       j.employment_type, j.job_type, j.experience_level,
+	       COALESCE(cp.company_name, 'Unknown Company'), cp.logo_url, COALESCE(cp.verification_level, 'BASIC'),
+	       j.is_confidential, j.created_at
+	FROM jobs j
+	LEFT JOIN company_profiles cp ON j.company_id = cp.id`
+
+// scanJobCards reads public job cards; confidential jobs come back with the company hidden
+func scanJobCards(rows pgx.Rows) ([]domain.JobCard, error) {
+	defer rows.Close()
+
+	cards := []domain.JobCard{}
+	for rows.Next() {
+		var c domain.JobCard
+		if err := rows.Scan(
+			&c.ID, &c.CompanyID, &c.Title, &c.Location, &c.SalaryMin, &c.SalaryMax,
+			&c.EmploymentType, &c.JobType, &c.ExperienceLevel,
+			&c.CompanyName, &c.CompanyLogoURL, &c.CompanyVerificationLevel,
+			&c.IsConfidential, &c.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if c.IsConfidential {
+			c.HideCompany()
+		}
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
+
+// FetchSimilarActiveJobs ranks other companies' active jobs by how many of job type,
+// experience level, employment type, industry and location they share with the job
+func (r *jobRepo) FetchSimilarActiveJobs(ctx context.Context, job *domain.JobWithCompany, limit int) ([]domain.JobCard, error) {
+	query := jobCardSelect + `
+		CROSS JOIN LATERAL (
+			SELECT (j.job_type IS NOT DISTINCT FROM $3 AND $3 IS NOT NULL)::INT * 3
+			     + (j.experience_level IS NOT DISTINCT FROM $4 AND $4 IS NOT NULL)::INT * 2
+			     + (j.employment_type IS NOT DISTINCT FROM $5 AND $5 IS NOT NULL)::INT
+			     + (cp.industry IS NOT DISTINCT FROM $6 AND $6 IS NOT NULL)::INT
+			     + (LOWER(j.location) = LOWER($7))::INT AS score
+		) s
+		WHERE ` + publishedJobExpr + ` AND j.id <> $1 AND j.company_id <> $2 AND s.score > 0
+		ORDER BY s.score DESC, j.created_at DESC
+		LIMIT $8`
+
+	rows, err := r.db.Query(ctx, query,
+		job.ID, job.CompanyID, job.JobType, job.ExperienceLevel, job.EmploymentType, job.Industry, job.Location, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobCards(rows)
+}
+
+// FetchActiveJobsByCompany returns the company's other active openings, newest first.
+// Confidential jobs are left out, as listing them here would name their company.
+func (r *jobRepo) FetchActiveJobsByCompany(ctx context.Context, companyID, excludeJobID int64, limit int) ([]domain.JobCard, error) {
+	query := jobCardSelect + `
+		WHERE j.company_id = $1 AND j.id <> $2 AND NOT j.is_confidential AND ` + publishedJobExpr + `
+		ORDER BY j.created_at DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, query, companyID, excludeJobID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanJobCards(rows)
+}
+
+// RecordView counts one public view of the job for today
+func (r *jobRepo) RecordView(ctx context.Context, jobID int64) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO job_view_counts (job_id, view_date, views)
+		VALUES ($1, CURRENT_DATE, 1)
+		ON CONFLICT (job_id, view_date) DO UPDATE SET views = job_view_counts.views + 1`,
+		jobID)
+	return err
+}
+
+// FetchPopularityStats counts all applications and the views of the last 7 days per job
+func (r *jobRepo) FetchPopularityStats(ctx context.Context, jobIDs []int64) (map[int64]domain.JobPopularityStats, error) {
+	stats := map[int64]domain.JobPopularityStats{}
+	if len(jobIDs) == 0 {
+		return stats, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT j.id,
+		       (SELECT COUNT(*) FROM applications a WHERE a.job_id = j.id),
+		       COALESCE((SELECT SUM(v.views) FROM job_view_counts v WHERE v.job_id = j.id AND v.view_date > CURRENT_DATE - 7), 0)
+		FROM jobs j
+		WHERE j.id = ANY($1)`, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var s domain.JobPopularityStats
+		if err := rows.Scan(&id, &s.Applications, &s.ViewsLast7Days); err != nil {
+			return nil, err
+		}
+		stats[id] = s
+	}
+	return stats, rows.Err()
+}
+
+// SearchJobs matches active jobs against the weighted search_vector (title over
+// description over qualifications) and the filters. Keyword hits are ranked with
+// ts_rank_cd, normalized to 0..1.
+func (r *jobRepo) SearchJobs(ctx context.Context, params domain.JobSearchParams, limit, offset int) ([]domain.JobSearchResult, int64, error) {
+	// Build dynamic WHERE clause
+	conditions := []string{publishedJobExpr}
+	args := []interface{}{}
+	argIndex := 1
+
+	rankExpr := "NULL::FLOAT8"
+	if params.Query != "" {
+		conditions = append(conditions, fmt.Sprintf("j.search_vector @@ websearch_to_tsquery('simple', $%d)", argIndex))
+		rankExpr = fmt.Sprintf("ts_rank_cd(j.search_vector, websearch_to_tsquery('simple', $%d), 32)::FLOAT8", argIndex)
+		args = append(args, params.Query)
+		argIndex++
+	}
+	if len(params.Locations) > 0 {
+		// Locations are free text, so match any of them as a substring
+		matches := make([]string, len(params.Locations))
+		for i, location := range params.Locations {
+			matches[i] = fmt.Sprintf("j.location ILIKE $%d", argIndex)
+			args = append(args, containsPattern(location))
+			argIndex++
+		}
+		conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+	if len(params.Cities) > 0 {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM company_locations cl WHERE cl.id = j.location_id AND LOWER(cl.city) = ANY($%d))", argIndex))
+		args = append(args, params.Cities)
+		argIndex++
+	}
+	if params.Near != nil {
+		// Great-circle distance of the job's office; confidential jobs are left out,
+		// as a small radius would give their office away
+		conditions = append(conditions, fmt.Sprintf(`NOT j.is_confidential AND EXISTS (
+			SELECT 1 FROM company_locations cl
+			WHERE cl.id = j.location_id AND cl.latitude IS NOT NULL
+			  AND 2 * 6371 * ASIN(SQRT(
+				POWER(SIN(RADIANS(cl.latitude - $%d) / 2), 2)
+				+ COS(RADIANS($%d)) * COS(RADIANS(cl.latitude)) * POWER(SIN(RADIANS(cl.longitude - $%d) / 2), 2)
+			  )) <= $%d)`, argIndex, argIndex, argIndex+1, argIndex+2))
+		args = append(args, params.Near.Latitude, params.Near.Longitude, params.RadiusKm)
+		argIndex += 3
+	}
+	if params.SalaryMin != nil {
+		conditions = append(conditions, fmt.Sprintf("j.salary_max >= $%d", argIndex))
+		args = append(args, *params.SalaryMin)
+		argIndex++
+	}
+	if params.SalaryMax != nil {
+		conditions = append(conditions, fmt.Sprintf("j.salary_min <= $%d", argIndex))
+		args = append(args, *params.SalaryMax)
+		argIndex++
+	}
+	if len(params.EmploymentTypes) > 0 {
+		conditions = append(conditions, fmt.Sprintf("j.employment_type = ANY($%d)", argIndex))
+		args = append(args, params.EmploymentTypes)
+		argIndex++
+	}
+	if params.JapaneseLevel != "" {
+		// N1 is the hardest level, so an N3 candidate qualifies for N3, N4 and N5 jobs
+		conditions = append(conditions, fmt.Sprintf("(j.japanese_level_required IS NULL OR j.japanese_level_required >= $%d)", argIndex))
+		args = append(args, params.JapaneseLevel)
+		argIndex++
+	}
+	whereClause := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs j`+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []domain.JobSearchResult{}, 0, nil
+	}
+
+	orderBy := "j.created_at DESC, j.id DESC"
+	switch params.Sort {
+	case domain.JobSearchSortRelevance:
+		if params.Query != "" {
+			orderBy = "rank DESC, " + orderBy
+		}
+	case domain.JobSearchSortSalary:
+		orderBy = "j.salary_max DESC, " + orderBy
+	case domain.JobSearchSortPopular:
+		orderBy = popularityScoreExpr + " DESC, " + orderBy
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			j.id, j.company_id, j.title, j.description, j.salary_min, j.salary_max,
+			j.location, j.location_id, j.company_status, j.employment_type, j.job_type,
+			j.experience_level, j.qualifications, j.japanese_level_required, j.publish_at, j.unpublish_at, j.is_confidential, j.apply_deadline, j.max_applicants, j.auto_close, j.created_at, j.updated_at,
+			COALESCE(cp.company_name, 'Unknown Company') as company_name,
+			cp.logo_url,
+			cp.website,
+			cp.industry,
+			COALESCE(cp.verification_level, 'BASIC'),
+			cp.employee_count,
+			%s AS rank
+		FROM jobs j
+		LEFT JOIN company_profiles cp ON j.company_id = cp.id
+		%s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		rankExpr, whereClause, orderBy, argIndex, argIndex+1,
+	)
+	rows, err := r.db.Query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	results := []domain.JobSearchResult{}
+	for rows.Next() {
+		var job domain.JobSearchResult
+		var employeeCount *string
+		if err := rows.Scan(
+			&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax,
+			&job.Location, &job.LocationID, &job.CompanyStatus, &job.EmploymentType, &job.JobType,
+			&job.ExperienceLevel, &job.Qualifications, &job.JapaneseLevelRequired, &job.PublishAt, &job.UnpublishAt, &job.IsConfidential, &job.ApplyDeadline, &job.MaxApplicants, &job.AutoClose, &job.CreatedAt, &job.UpdatedAt,
+			&job.CompanyName, &job.CompanyLogoURL, &job.CompanyWebsite, &job.Industry, &job.CompanyVerificationLevel,
+			&employeeCount, &job.Rank,
+		); err != nil {
+			return nil, 0, err
+		}
+		job.CompanySizeBand = domain.CompanySizeBandFor(employeeCount)
+		results = append(results, job)
+	}
+	return results, total, rows.Err()
+}
+
+// UpdateSchedule saves the job's publication status and schedule
+func (r *jobRepo) UpdateSchedule(ctx context.Context, job *domain.Job) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE jobs SET company_status = $2, publish_at = $3, unpublish_at = $4, updated_at = $5
+		WHERE id = $1`,
+		job.ID, job.CompanyStatus, job.PublishAt, job.UnpublishAt, job.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// PublishDueJobs activates scheduled jobs whose publish time has come
+func (r *jobRepo) PublishDueJobs(ctx context.Context, now time.Time) ([]domain.Job, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE jobs SET company_status = 'active', updated_at = $1
+		WHERE company_status = 'scheduled' AND publish_at <= $1
+		RETURNING id, company_id, title, publish_at`,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		var job domain.Job
+		if err := rows.Scan(&job.ID, &job.CompanyID, &job.Title, &job.PublishAt); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// UnpublishDueJobs expires jobs whose unpublish time has come, including scheduled
+// jobs whose whole window passed before they were published
+func (r *jobRepo) UnpublishDueJobs(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE jobs SET company_status = 'expired', updated_at = $1
+		WHERE company_status IN ('active', 'scheduled') AND unpublish_at <= $1`,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// CloseDueJobs closes auto-closing jobs whose apply deadline passed or whose
+// applicant cap is reached
+func (r *jobRepo) CloseDueJobs(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE jobs j SET company_status = 'closed', updated_at = $1
+		WHERE j.company_status IN ('active', 'scheduled') AND j.auto_close
+		  AND (j.apply_deadline <= $1 OR (j.max_applicants IS NOT NULL AND `+applicantCountExpr+` >= j.max_applicants))`,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// CountApplicants counts the job's applications that count towards max_applicants
+func (r *jobRepo) CountApplicants(ctx context.Context, jobID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT `+applicantCountExpr+` FROM jobs j WHERE j.id = $1`, jobID).Scan(&count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, domain.ErrNotFound
+	}
+	return count, err
+}
+
+// Reopen saves the job's status and application limits
+func (r *jobRepo) Reopen(ctx context.Context, job *domain.Job) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE jobs SET company_status = $2, apply_deadline = $3, max_applicants = $4, updated_at = $5
+		WHERE id = $1`,
+		job.ID, job.CompanyStatus, job.ApplyDeadline, job.MaxApplicants, job.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type killSwitchRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewKillSwitchRepository creates a new kill switch repository
+func NewKillSwitchRepository(db *pgxpool.Pool) domain.KillSwitchRepository {
+	return &killSwitchRepo{db: db}
+}
+
+const killSwitchColumns = `key, disabled, message, reenable_at, updated_by::TEXT, updated_at`
+
+func scanKillSwitch(row pgx.Row, s *domain.KillSwitch) error {
+	return row.Scan(&s.Key, &s.Disabled, &s.Message, &s.ReenableAt, &s.UpdatedBy, &s.UpdatedAt)
+}
+
+func (r *killSwitchRepo) List(ctx context.Context) ([]domain.KillSwitch, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+killSwitchColumns+` FROM kill_switches ORDER BY key`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	switches := []domain.KillSwitch{}
+	for rows.Next() {
+		var s domain.KillSwitch
+		if err := scanKillSwitch(rows, &s); err != nil {
+			return nil, err
+		}
+		switches = append(switches, s)
+	}
+	return switches, rows.Err()
+}
+
+func (r *killSwitchRepo) Disable(ctx context.Context, key string, message *string, reenableAt *time.Time, actorID, reason string) (*domain.KillSwitch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var s domain.KillSwitch
+	if err := scanKillSwitch(tx.QueryRow(ctx, `
+		INSERT INTO kill_switches (key, disabled, message, reenable_at, updated_by, updated_at)
+		VALUES ($1, TRUE, $2, $3, $4, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET disabled = TRUE, message = EXCLUDED.message, reenable_at = EXCLUDED.reenable_at,
+		    updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		RETURNING `+killSwitchColumns, key, message, reenableAt, actorID), &s); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO kill_switch_audit_entries (switch_key, action, message, reason, reenable_at, actor_id)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		key, domain.KillSwitchActionDisabled, message, reason, reenableAt, actorID,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *killSwitchRepo) Enable(ctx context.Context, key, actorID, reason string) (*domain.KillSwitch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var s domain.KillSwitch
+	if err := scanKillSwitch(tx.QueryRow(ctx, `
+		UPDATE kill_switches
+		SET disabled = FALSE, reenable_at = NULL, updated_by = $2, updated_at = NOW()
+		WHERE key = $1 AND disabled
+		RETURNING `+killSwitchColumns, key, actorID), &s); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO kill_switch_audit_entries (switch_key, action, reason, actor_id)
+		VALUES ($1, $2, $3, $4)`,
+		key, domain.KillSwitchActionEnabled, reason, actorID,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *killSwitchRepo) ReenableExpired(ctx context.Context) ([]string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// The row update is the claim, so several API instances never audit the same re-enable twice
+	keys, err := collectStrings(tx.Query(ctx, `
+		UPDATE kill_switches
+		SET disabled = FALSE, reenable_at = NULL, updated_by = NULL, updated_at = NOW()
+		WHERE disabled AND reenable_at IS NOT NULL AND reenable_at <= NOW()
+		RETURNING key`))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return keys, nil
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO kill_switch_audit_entries (switch_key, action, reason)
+		SELECT k, $2, 'Maintenance window ended' FROM UNNEST($1::TEXT[]) AS k`,
+		keys, domain.KillSwitchActionAutoEnabled,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *killSwitchRepo) ListAudit(ctx context.Context, key string, limit int) ([]domain.KillSwitchAuditEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, switch_key, action, message, reason, reenable_at, actor_id::TEXT, created_at
+		FROM kill_switch_audit_entries
+		WHERE ($1 = '' OR switch_key = $1)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`, key, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []domain.KillSwitchAuditEntry{}
+	for rows.Next() {
+		var e domain.KillSwitchAuditEntry
+		if err := rows.Scan(&e.ID, &e.SwitchKey, &e.Action, &e.Message, &e.Reason, &e.ReenableAt, &e.ActorID, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
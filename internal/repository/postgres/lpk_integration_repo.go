@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type lpkIntegrationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewLPKIntegrationRepository creates a new LPK partner integration repository
+func NewLPKIntegrationRepository(db *pgxpool.Pool) domain.LPKIntegrationRepository {
+	return &lpkIntegrationRepo{db: db}
+}
+
+// ============================================================================
+// Candidate consent
+// ============================================================================
+
+func (r *lpkIntegrationRepo) GetPlacementSharing(ctx context.Context, userID string) (*domain.LPKPlacementSharing, error) {
+	var s domain.LPKPlacementSharing
+	var sharingLPKID *int64
+	err := r.db.QueryRow(ctx, `
+		SELECT av.lpk_id, l.name, av.placement_sharing_lpk_id, av.placement_sharing_at
+		FROM account_verifications av
+		LEFT JOIN lpk_list l ON l.id = av.lpk_id
+		WHERE av.user_id = $1 AND av.role = 'CANDIDATE'`, userID,
+	).Scan(&s.LPKID, &s.LPKName, &sharingLPKID, &s.EnabledAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Consent given for an earlier LPK does not carry over
+	s.Enabled = s.LPKID != nil && sharingLPKID != nil && *s.LPKID == *sharingLPKID
+	if !s.Enabled {
+		s.EnabledAt = nil
+	}
+	return &s, nil
+}
+
+func (r *lpkIntegrationRepo) SetPlacementSharing(ctx context.Context, userID string, enabled bool, at time.Time) error {
+	query := `
+		UPDATE account_verifications
+		SET placement_sharing_lpk_id = NULL, placement_sharing_at = NULL
+		WHERE user_id = $1 AND role = 'CANDIDATE'`
+	args := []interface{}{userID}
+	if enabled {
+		query = `
+			UPDATE account_verifications
+			SET placement_sharing_lpk_id = lpk_id, placement_sharing_at = $2
+			WHERE user_id = $1 AND role = 'CANDIDATE'`
+		args = append(args, at)
+	}
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ============================================================================
+// Placement events
+// ============================================================================
+
+// lpkPlacementEventColumns select an LPKPlacementEvent from lpk_placement_events e
+// joined with its application a and job j
+const lpkPlacementEventColumns = `e.id, e.lpk_id, e.account_verification_id, e.application_id, j.id, j.title,
+	CASE WHEN j.is_confidential THEN NULL ELSE cp.company_name END, e.status, e.occurred_at`
+
+func scanLPKPlacementEvent(row pgx.Row, e *domain.LPKPlacementEvent) error {
+	err := row.Scan(&e.ID, &e.LPKID, &e.CandidateRef, &e.ApplicationID, &e.JobID, &e.JobTitle, &e.CompanyName, &e.Status, &e.OccurredAt)
+	e.Placed = e.Status == domain.ApplicationStatusAccepted
+	return err
+}
+
+func (r *lpkIntegrationRepo) RecordPlacementEvent(ctx context.Context, applicationID int64, at time.Time) (*domain.LPKPlacementEvent, error) {
+	var event domain.LPKPlacementEvent
+	err := scanLPKPlacementEvent(r.db.QueryRow(ctx, `
+		WITH e AS (
+			INSERT INTO lpk_placement_events (lpk_id, account_verification_id, application_id, status, occurred_at)
+			SELECT av.lpk_id, av.id, a.id, a.status, $2
+			FROM applications a
+			JOIN account_verifications av ON av.user_id = a.candidate_user_id AND av.role = 'CANDIDATE'
+			WHERE a.id = $1 AND av.lpk_id IS NOT NULL AND av.placement_sharing_lpk_id = av.lpk_id
+			RETURNING *
+		)
+		SELECT `+lpkPlacementEventColumns+`
+		FROM e
+		JOIN applications a ON a.id = e.application_id
+		JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN company_profiles cp ON cp.id = j.company_id`,
+		applicationID, at,
+	), &event)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *lpkIntegrationRepo) ListPlacementEvents(ctx context.Context, lpkID int64, since int64, limit int) ([]domain.LPKPlacementEvent, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+lpkPlacementEventColumns+`
+		FROM lpk_placement_events e
+		JOIN account_verifications av ON av.id = e.account_verification_id
+		JOIN applications a ON a.id = e.application_id
+		JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN company_profiles cp ON cp.id = j.company_id
+		WHERE e.lpk_id = $1 AND e.id > $2
+			AND av.lpk_id = e.lpk_id AND av.placement_sharing_lpk_id = e.lpk_id
+		ORDER BY e.id
+		LIMIT $3`, lpkID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []domain.LPKPlacementEvent{}
+	for rows.Next() {
+		var e domain.LPKPlacementEvent
+		if err := scanLPKPlacementEvent(rows, &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// ============================================================================
+// API keys
+// ============================================================================
+
+const lpkAPIKeyColumns = `id, lpk_id, name, key_prefix, created_by, last_used_at, revoked_at, created_at`
+
+func scanLPKAPIKey(row pgx.Row, k *domain.LPKAPIKey) error {
+	return row.Scan(&k.ID, &k.LPKID, &k.Name, &k.Prefix, &k.CreatedBy, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt)
+}
+
+func (r *lpkIntegrationRepo) CreateAPIKey(ctx context.Context, key *domain.LPKAPIKey, keyHash string) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO lpk_api_keys (lpk_id, name, key_prefix, key_hash, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		key.LPKID, key.Name, key.Prefix, keyHash, key.CreatedBy,
+	).Scan(&key.ID, &key.CreatedAt)
+}
+
+func (r *lpkIntegrationRepo) ListAPIKeys(ctx context.Context, lpkID int64) ([]domain.LPKAPIKey, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+lpkAPIKeyColumns+` FROM lpk_api_keys WHERE lpk_id = $1 ORDER BY created_at DESC`, lpkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []domain.LPKAPIKey{}
+	for rows.Next() {
+		var k domain.LPKAPIKey
+		if err := scanLPKAPIKey(rows, &k); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *lpkIntegrationRepo) CountActiveAPIKeys(ctx context.Context, lpkID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM lpk_api_keys WHERE lpk_id = $1 AND revoked_at IS NULL`, lpkID).Scan(&count)
+	return count, err
+}
+
+func (r *lpkIntegrationRepo) RevokeAPIKey(ctx context.Context, lpkID, id int64, at time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE lpk_api_keys SET revoked_at = $3
+		WHERE id = $1 AND lpk_id = $2 AND revoked_at IS NULL`, id, lpkID, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *lpkIntegrationRepo) FindActiveAPIKey(ctx context.Context, keyHash string) (*domain.LPKAPIKey, error) {
+	var k domain.LPKAPIKey
+	err := scanLPKAPIKey(r.db.QueryRow(ctx,
+		`SELECT `+lpkAPIKeyColumns+` FROM lpk_api_keys WHERE key_hash = $1 AND revoked_at IS NULL`, keyHash), &k)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &k, nil
+}
+
+// TouchAPIKey records use at most once a minute, so polling does not write on every request
+func (r *lpkIntegrationRepo) TouchAPIKey(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE lpk_api_keys SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $2 - INTERVAL '1 minute')`, id, at)
+	return err
+}
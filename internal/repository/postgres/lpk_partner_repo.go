@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type lpkPartnerRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLPKPartnerRepository(db *pgxpool.Pool) domain.LPKPartnerRepository {
+	return &lpkPartnerRepo{db: db}
+}
+
+// ============================================================================
+// Partner account mapping
+// ============================================================================
+
+func (r *lpkPartnerRepo) GetPartnerByUserID(ctx context.Context, userID string) (*domain.LPKPartner, error) {
+	query := `
+		SELECT p.user_id, u.email, p.lpk_id, l.name, p.assigned_by, p.created_at
+		FROM lpk_partner_users p
+		JOIN users u ON u.id = p.user_id
+		JOIN lpk_list l ON l.id = p.lpk_id
+		WHERE p.user_id = $1
+	`
+	var p domain.LPKPartner
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&p.UserID, &p.Email, &p.LPKID, &p.LPKName, &p.AssignedBy, &p.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *lpkPartnerRepo) ListPartners(ctx context.Context, lpkID *int64) ([]domain.LPKPartner, error) {
+	query := `
+		SELECT p.user_id, u.email, p.lpk_id, l.name, p.assigned_by, p.created_at
+		FROM lpk_partner_users p
+		JOIN users u ON u.id = p.user_id
+		JOIN lpk_list l ON l.id = p.lpk_id
+	`
+	args := []interface{}{}
+	if lpkID != nil {
+		query += ` WHERE p.lpk_id = $1`
+		args = append(args, *lpkID)
+	}
+	query += ` ORDER BY l.name, u.email`
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	partners := []domain.LPKPartner{}
+	for rows.Next() {
+		var p domain.LPKPartner
+		if err := rows.Scan(&p.UserID, &p.Email, &p.LPKID, &p.LPKName, &p.AssignedBy, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		partners = append(partners, p)
+	}
+	return partners, rows.Err()
+}
+
+// AssignPartner maps a user to an LPK and switches their role to 'lpk' in one transaction
+func (r *lpkPartnerRepo) AssignPartner(ctx context.Context, userID string, lpkID int64, assignedBy string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var role string
+	err = tx.QueryRow(ctx, `SELECT role FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if role == domain.RoleAdmin || role == domain.RoleEmployer {
+		return domain.ErrLPKPartnerRoleConflict
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1`, userID, domain.RoleLPK); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO lpk_partner_users (user_id, lpk_id, assigned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET lpk_id = EXCLUDED.lpk_id, assigned_by = EXCLUDED.assigned_by, created_at = NOW()
+	`, userID, lpkID, assignedBy)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *lpkPartnerRepo) RevokePartner(ctx context.Context, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `DELETE FROM lpk_partner_users WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	// Portal access follows the role, so it goes with the mapping
+	_, err = tx.Exec(ctx, `UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1 AND role = $3`,
+		userID, domain.RoleCandidate, domain.RoleLPK)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *lpkPartnerRepo) LPKExists(ctx context.Context, lpkID int64) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM lpk_list WHERE id = $1)`, lpkID).Scan(&exists)
+	return exists, err
+}
+
+// ============================================================================
+// Scoped candidate data
+// ============================================================================
+
+// ListCandidates returns anonymized progress for candidates who selected the LPK.
+// Only initials are selected; names, contact details and documents never leave the DB.
+func (r *lpkPartnerRepo) ListCandidates(ctx context.Context, lpkID int64, status string, page, pageSize int) ([]domain.LPKCandidateProgress, int64, error) {
+	conditions := []string{"av.lpk_id = $1", "av.role = 'CANDIDATE'"}
+	args := []interface{}{lpkID}
+	argIndex := 2
+
+	if status != "" {
+		conditions = append(conditions, fmt.Sprintf("av.status = $%d", argIndex))
+		args = append(args, status)
+		argIndex++
+	}
+	whereClause := strings.Join(conditions, " AND ")
+
+	var total int64
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM account_verifications av WHERE %s`, whereClause)
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	offset := (page - 1) * pageSize
+	query := fmt.Sprintf(`
+		SELECT `+lpkCandidateProgressColumns+`
+		FROM account_verifications av
+		WHERE %s
+		ORDER BY av.updated_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argIndex, argIndex+1)
+	args = append(args, pageSize, offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	defer rows.Close()
+
+	candidates, err := scanLPKCandidateProgress(rows)
+	return candidates, total, err
+}
+
+// lpkCandidateProgressColumns select an LPKCandidateProgress from
+// account_verifications av, counting the endorsements of the candidate's LPK
+const lpkCandidateProgressColumns = `
+			av.id,
+			UPPER(LEFT(COALESCE(av.first_name, ''), 1)) || UPPER(LEFT(COALESCE(av.last_name, ''), 1)),
+			av.status,
+			av.japanese_level,
+			av.onboarding_completed_at IS NOT NULL,
+			CASE WHEN av.status = 'PENDING' THEN NULL ELSE av.submitted_at END,
+			av.verified_at,
+			(SELECT COUNT(*) FROM applications a WHERE a.candidate_user_id = av.user_id),
+			EXISTS(SELECT 1 FROM applications a WHERE a.candidate_user_id = av.user_id AND a.status = 'accepted'),
+			(SELECT COUNT(*) FROM lpk_endorsements e WHERE e.account_verification_id = av.id AND e.lpk_id = av.lpk_id)`
+
+func scanLPKCandidateProgress(rows pgx.Rows) ([]domain.LPKCandidateProgress, error) {
+	candidates := []domain.LPKCandidateProgress{}
+	for rows.Next() {
+		var c domain.LPKCandidateProgress
+		if err := rows.Scan(
+			&c.CandidateRef, &c.Initials, &c.VerificationStatus, &c.JapaneseLevel, &c.OnboardingDone,
+			&c.SubmittedAt, &c.VerifiedAt, &c.ApplicationCount, &c.Placed, &c.EndorsementCount,
+		); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+func (r *lpkPartnerRepo) CandidateBelongsToLPK(ctx context.Context, verificationID, lpkID int64) (bool, error) {
+	var belongs bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM account_verifications WHERE id = $1 AND lpk_id = $2 AND role = 'CANDIDATE')
+	`, verificationID, lpkID).Scan(&belongs)
+	return belongs, err
+}
+
+func (r *lpkPartnerRepo) CreateEndorsement(ctx context.Context, e *domain.LPKEndorsement) error {
+	query := `
+		INSERT INTO lpk_endorsements (lpk_id, account_verification_id, author_user_id, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query, e.LPKID, e.CandidateRef, e.AuthorUserID, e.Note).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (r *lpkPartnerRepo) ListEndorsements(ctx context.Context, lpkID, verificationID int64) ([]domain.LPKEndorsement, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, lpk_id, account_verification_id, author_user_id, note, created_at
+		FROM lpk_endorsements
+		WHERE lpk_id = $1 AND account_verification_id = $2
+		ORDER BY created_at DESC
+	`, lpkID, verificationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	endorsements := []domain.LPKEndorsement{}
+	for rows.Next() {
+		var e domain.LPKEndorsement
+		if err := rows.Scan(&e.ID, &e.LPKID, &e.CandidateRef, &e.AuthorUserID, &e.Note, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		endorsements = append(endorsements, e)
+	}
+	return endorsements, rows.Err()
+}
+
+func (r *lpkPartnerRepo) GetPlacementStats(ctx context.Context, lpkID int64) (*domain.LPKPlacementStats, error) {
+	stats := &domain.LPKPlacementStats{LPKID: lpkID}
+
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE av.status = 'PENDING'),
+			COUNT(*) FILTER (WHERE av.status = 'SUBMITTED'),
+			COUNT(*) FILTER (WHERE av.status = 'VERIFIED'),
+			COUNT(*) FILTER (WHERE av.status = 'REJECTED'),
+			COUNT(*) FILTER (WHERE EXISTS(SELECT 1 FROM applications a WHERE a.candidate_user_id = av.user_id)),
+			COUNT(*) FILTER (WHERE EXISTS(SELECT 1 FROM applications a WHERE a.candidate_user_id = av.user_id AND a.status = 'accepted')),
+			COALESCE((
+				SELECT COUNT(*) FROM applications a
+				JOIN account_verifications av2 ON av2.user_id = a.candidate_user_id
+				WHERE av2.lpk_id = $1 AND av2.role = 'CANDIDATE'
+			), 0)
+		FROM account_verifications av
+		WHERE av.lpk_id = $1 AND av.role = 'CANDIDATE'
+	`
+	err := r.db.QueryRow(ctx, query, lpkID).Scan(
+		&stats.TotalCandidates, &stats.PendingCandidates, &stats.SubmittedCandidates,
+		&stats.VerifiedCandidates, &stats.RejectedCandidates,
+		&stats.AppliedCandidates, &stats.PlacedCandidates, &stats.TotalApplications,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE p.status = 'ACTIVE'),
+			COUNT(*) FILTER (WHERE p.status = 'COMPLETED'),
+			COUNT(*) FILTER (WHERE p.status = 'ENDED_EARLY')
+		FROM placements p
+		JOIN account_verifications av ON av.user_id = p.candidate_user_id
+		WHERE av.lpk_id = $1 AND av.role = 'CANDIDATE'`, lpkID,
+	).Scan(&stats.ActivePlacements, &stats.CompletedPlacements, &stats.EndedEarlyPlacements)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
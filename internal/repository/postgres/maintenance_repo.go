@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type maintenanceRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewMaintenanceRepository creates a new maintenance repository
+func NewMaintenanceRepository(db *pgxpool.Pool) domain.MaintenanceRepository {
+	return &maintenanceRepo{db: db}
+}
+
+const maintenanceRunColumns = `id, task, status, steps_total, steps_done, current_step, steps, error_message,
+	started_by, started_at, heartbeat_at, finished_at`
+
+func scanMaintenanceRun(row pgx.Row, r *domain.MaintenanceRun) error {
+	return row.Scan(&r.ID, &r.Task, &r.Status, &r.StepsTotal, &r.StepsDone, &r.CurrentStep, &r.Steps, &r.ErrorMessage,
+		&r.StartedBy, &r.StartedAt, &r.HeartbeatAt, &r.FinishedAt)
+}
+
+func (r *maintenanceRepo) CreateRun(ctx context.Context, run *domain.MaintenanceRun) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO maintenance_runs (task, status, steps_total, started_by, started_at, heartbeat_at)
+		VALUES ($1, 'RUNNING', $2, $3, $4, $4)
+		ON CONFLICT (task) WHERE status = 'RUNNING' DO NOTHING
+		RETURNING id, status, heartbeat_at`,
+		run.Task, run.StepsTotal, run.StartedBy, run.StartedAt,
+	).Scan(&run.ID, &run.Status, &run.HeartbeatAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrMaintenanceTaskRunning
+	}
+	return err
+}
+
+func (r *maintenanceRepo) AbandonStaleRuns(ctx context.Context, before, at time.Time) (int, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE maintenance_runs
+		SET status = 'FAILED', error_message = 'Abandoned: no progress reported', finished_at = $2
+		WHERE status = 'RUNNING' AND heartbeat_at < $1`, before, at)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (r *maintenanceRepo) UpdateProgress(ctx context.Context, run *domain.MaintenanceRun) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE maintenance_runs
+		SET steps_total = $2, steps_done = $3, current_step = $4, heartbeat_at = $5
+		WHERE id = $1 AND status = 'RUNNING'`,
+		run.ID, run.StepsTotal, run.StepsDone, run.CurrentStep, run.HeartbeatAt,
+	)
+	return err
+}
+
+func (r *maintenanceRepo) FinishRun(ctx context.Context, run *domain.MaintenanceRun) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE maintenance_runs
+		SET status = $2, steps_total = $3, steps_done = $4, current_step = $5, steps = $6, error_message = $7,
+			heartbeat_at = $8, finished_at = $8
+		WHERE id = $1`,
+		run.ID, run.Status, run.StepsTotal, run.StepsDone, run.CurrentStep, run.Steps, run.ErrorMessage, run.FinishedAt,
+	)
+	return err
+}
+
+func (r *maintenanceRepo) GetRun(ctx context.Context, id int64) (*domain.MaintenanceRun, error) {
+	var run domain.MaintenanceRun
+	err := scanMaintenanceRun(r.db.QueryRow(ctx, `SELECT `+maintenanceRunColumns+` FROM maintenance_runs WHERE id = $1`, id), &run)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *maintenanceRepo) ListRuns(ctx context.Context, task string, limit int) ([]domain.MaintenanceRun, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if task != "" {
+		where += fmt.Sprintf(" AND task = $%d", argIndex)
+		args = append(args, task)
+		argIndex++
+	}
+
+	query := `SELECT ` + maintenanceRunColumns + ` FROM maintenance_runs` + where +
+		fmt.Sprintf(" ORDER BY started_at DESC, id DESC LIMIT $%d", argIndex)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []domain.MaintenanceRun{}
+	for rows.Next() {
+		var run domain.MaintenanceRun
+		if err := scanMaintenanceRun(rows, &run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *maintenanceRepo) LatestRuns(ctx context.Context) (map[string]domain.MaintenanceRun, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT ON (task) `+maintenanceRunColumns+`
+		FROM maintenance_runs
+		ORDER BY task, started_at DESC, id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	latest := map[string]domain.MaintenanceRun{}
+	for rows.Next() {
+		var run domain.MaintenanceRun
+		if err := scanMaintenanceRun(rows, &run); err != nil {
+			return nil, err
+		}
+		latest[run.Task] = run
+	}
+	return latest, rows.Err()
+}
+
+// AnalyzeTable takes the name from the usecase's fixed task definitions; it is
+// quoted as an identifier all the same
+func (r *maintenanceRepo) AnalyzeTable(ctx context.Context, table string) error {
+	_, err := r.db.Exec(ctx, `ANALYZE `+pgx.Identifier{table}.Sanitize())
+	return err
+}
+
+// ReindexConcurrently cannot run inside a transaction, so it goes straight to the pool
+func (r *maintenanceRepo) ReindexConcurrently(ctx context.Context, index string) error {
+	_, err := r.db.Exec(ctx, `REINDEX INDEX CONCURRENTLY `+pgx.Identifier{index}.Sanitize())
+	return err
+}
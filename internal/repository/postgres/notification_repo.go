@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type notificationRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *pgxpool.Pool) domain.NotificationRepository {
+	return &notificationRepo{db: db}
+}
+
+func (r *notificationRepo) GetRecipient(ctx context.Context, userID string) (*domain.NotificationRecipient, error) {
+	rec := &domain.NotificationRecipient{UserID: userID}
+	err := r.db.QueryRow(ctx,
+		`SELECT email, role, preferred_locale FROM users WHERE id = $1`, userID,
+	).Scan(&rec.Email, &rec.Role, &rec.PreferredLocale)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (r *notificationRepo) EnqueueDigestItem(ctx context.Context, item *domain.NotificationDigestItem) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO notification_digest_items (user_id, category, locale, subject, body)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		item.UserID, item.Category, item.Locale, item.Subject, item.Body,
+	).Scan(&item.ID, &item.CreatedAt)
+}
+
+func (r *notificationRepo) ListDueDigestItems(ctx context.Context, cutoff time.Time, maxAttempts, limit int) ([]domain.NotificationDigestItem, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due_users AS (
+			SELECT user_id
+			FROM notification_digest_items
+			WHERE sent_at IS NULL AND attempts < $2
+			GROUP BY user_id
+			HAVING MIN(created_at) <= $1
+		)
+		SELECT i.id, i.user_id, i.category, i.locale, i.subject, i.body, i.created_at, i.attempts, u.email
+		FROM notification_digest_items i
+		JOIN due_users d ON d.user_id = i.user_id
+		JOIN users u ON u.id = i.user_id
+		WHERE i.sent_at IS NULL AND i.attempts < $2
+		ORDER BY i.user_id, i.created_at
+		LIMIT $3`, cutoff, maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.NotificationDigestItem
+	for rows.Next() {
+		var i domain.NotificationDigestItem
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Category, &i.Locale, &i.Subject, &i.Body, &i.CreatedAt, &i.Attempts, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	return items, rows.Err()
+}
+
+func (r *notificationRepo) MarkDigestItemsSent(ctx context.Context, ids []int64) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notification_digest_items SET sent_at = NOW(), last_error = NULL
+		WHERE id = ANY($1) AND sent_at IS NULL`, ids)
+	return err
+}
+
+func (r *notificationRepo) MarkDigestItemsFailed(ctx context.Context, ids []int64, errMsg string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE notification_digest_items SET attempts = attempts + 1, last_error = $2
+		WHERE id = ANY($1) AND sent_at IS NULL`, ids, errMsg)
+	return err
+}
+
+func (r *notificationRepo) CreateInApp(ctx context.Context, userID string, n *domain.InAppNotification) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO notifications (user_id, category, subject, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		userID, n.Category, n.Subject, n.Body,
+	).Scan(&n.ID, &n.CreatedAt)
+}
+
+func (r *notificationRepo) CreateAnnouncement(ctx context.Context, role, subject, body string) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO notifications (user_id, category, subject, body)
+		SELECT id, $2, $3, $4 FROM users
+		WHERE NOT COALESCE(is_disabled, false) AND ($1 = '' OR role = $1)`,
+		role, domain.NotificationCategoryAnnouncement, subject, body,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *notificationRepo) ListInApp(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) ([]domain.InAppNotification, int64, error) {
+	const where = ` WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)`
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notifications`+where, userID, unreadOnly).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, category, subject, body, read_at, created_at
+		FROM notifications`+where+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`,
+		userID, unreadOnly, pageSize, (page-1)*pageSize,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []domain.InAppNotification{}
+	for rows.Next() {
+		var n domain.InAppNotification
+		if err := rows.Scan(&n.ID, &n.Category, &n.Subject, &n.Body, &n.ReadAt, &n.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, total, rows.Err()
+}
+
+func (r *notificationRepo) CountUnread(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	return count, err
+}
+
+// MarkRead keeps the first read time of a notification read before
+func (r *notificationRepo) MarkRead(ctx context.Context, userID string, id int64) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *notificationRepo) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	tag, err := r.db.Exec(ctx, `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/logger"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -204,8 +205,8 @@ func (r *onboardingRepo) SaveOnboardingData(ctx context.Context, userID string,
 	} else if req.LPKSelection.OtherName != nil && *req.LPKSelection.OtherName != "" {
 		lpkType = "other"
 	}
-	fmt.Printf("[Onboarding] userID=%s interests=%d lpk_type=%s company_prefs=%d\n",
-		userID, len(req.Interests), lpkType, len(req.CompanyPreferences))
+	logger.FromContext(ctx).Info("Onboarding submitted",
+		"user_id", userID, "interests", len(req.Interests), "lpk_type", lpkType, "company_prefs", len(req.CompanyPreferences))
 
 	// Start transaction for atomicity
 	tx, err := r.db.Begin(ctx)
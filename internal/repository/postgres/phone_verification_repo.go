@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// phoneVerifiedExpr is true only while the verified number still equals the profile phone
+const phoneVerifiedExpr = "(av.phone_verified_at IS NOT NULL AND av.phone_verified_number IS NOT DISTINCT FROM av.phone)"
+
+type phoneVerificationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPhoneVerificationRepository(db *pgxpool.Pool) domain.PhoneVerificationRepository {
+	return &phoneVerificationRepo{db: db}
+}
+
+func (r *phoneVerificationRepo) GetPhoneStatus(ctx context.Context, userID string) (*string, *time.Time, error) {
+	query := `
+		SELECT av.phone, CASE WHEN ` + phoneVerifiedExpr + ` THEN av.phone_verified_at ELSE NULL END
+		FROM account_verifications av
+		WHERE av.user_id = $1
+	`
+	var phone *string
+	var verifiedAt *time.Time
+	err := r.db.QueryRow(ctx, query, userID).Scan(&phone, &verifiedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return phone, verifiedAt, nil
+}
+
+func (r *phoneVerificationRepo) CreateChallenge(ctx context.Context, c *domain.PhoneOTPChallenge) error {
+	query := `
+		INSERT INTO phone_otp_challenges (user_id, phone, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query, c.UserID, c.Phone, c.CodeHash, c.ExpiresAt).Scan(&c.ID, &c.CreatedAt)
+}
+
+func (r *phoneVerificationRepo) GetLatestChallenge(ctx context.Context, userID string) (*domain.PhoneOTPChallenge, error) {
+	query := `
+		SELECT id, user_id, phone, code_hash, attempts, expires_at, consumed_at, created_at
+		FROM phone_otp_challenges
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	var c domain.PhoneOTPChallenge
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&c.ID, &c.UserID, &c.Phone, &c.CodeHash, &c.Attempts, &c.ExpiresAt, &c.ConsumedAt, &c.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *phoneVerificationRepo) CountChallengesSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM phone_otp_challenges WHERE user_id = $1 AND created_at >= $2`,
+		userID, since,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *phoneVerificationRepo) IncrementAttempts(ctx context.Context, challengeID int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE phone_otp_challenges SET attempts = attempts + 1 WHERE id = $1`, challengeID)
+	return err
+}
+
+func (r *phoneVerificationRepo) MarkVerified(ctx context.Context, challengeID int64, userID, phone string) (time.Time, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Consume the challenge; the consumed_at guard makes replay impossible
+	result, err := tx.Exec(ctx, `
+		UPDATE phone_otp_challenges SET consumed_at = NOW()
+		WHERE id = $1 AND consumed_at IS NULL
+	`, challengeID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if result.RowsAffected() == 0 {
+		return time.Time{}, domain.ErrNotFound
+	}
+
+	// Stamp the verified number only if the profile phone hasn't changed meanwhile
+	var verifiedAt time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE account_verifications
+		SET phone_verified_at = NOW(), phone_verified_number = phone, updated_at = NOW()
+		WHERE user_id = $1 AND phone = $2
+		RETURNING phone_verified_at
+	`, userID, phone).Scan(&verifiedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, domain.ErrNotFound
+		}
+		return time.Time{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return time.Time{}, err
+	}
+	return verifiedAt, nil
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type piiAccessLogRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewPIIAccessLogRepository creates a new PII access log repository
+func NewPIIAccessLogRepository(db *pgxpool.Pool) domain.PIIAccessLogRepository {
+	return &piiAccessLogRepo{db: db}
+}
+
+func (r *piiAccessLogRepo) Create(ctx context.Context, entry *domain.PIIAccessLog) error {
+	query := `
+		INSERT INTO pii_access_logs (
+			actor_user_id, company_id, action, resource_type, resource_id,
+			subject_user_ids, columns, ip_address, user_agent
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''))
+		RETURNING id, created_at`
+
+	subjects, columns := entry.SubjectUserIDs, entry.Columns
+	if subjects == nil {
+		subjects = []string{}
+	}
+	if columns == nil {
+		columns = []string{}
+	}
+	return r.db.QueryRow(ctx, query,
+		entry.ActorUserID, entry.CompanyID, entry.Action, entry.ResourceType, entry.ResourceID,
+		subjects, columns, entry.IPAddress, entry.UserAgent,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pipelineSLARepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPipelineSLARepository(db *pgxpool.Pool) domain.PipelineSLARepository {
+	return &pipelineSLARepo{db: db}
+}
+
+func (r *pipelineSLARepo) ListThresholds(ctx context.Context, companyID int64) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, `SELECT stage, max_days FROM company_pipeline_sla WHERE company_id = $1`, companyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	thresholds := map[string]int{}
+	for rows.Next() {
+		var stage string
+		var days int
+		if err := rows.Scan(&stage, &days); err != nil {
+			return nil, err
+		}
+		thresholds[stage] = days
+	}
+	return thresholds, rows.Err()
+}
+
+func (r *pipelineSLARepo) SetThresholds(ctx context.Context, companyID int64, thresholds map[string]int, updatedBy string) error {
+	batch := &pgx.Batch{}
+	for stage, days := range thresholds {
+		batch.Queue(`
+			INSERT INTO company_pipeline_sla (company_id, stage, max_days, updated_by)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (company_id, stage) DO UPDATE
+			SET max_days = EXCLUDED.max_days, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+			companyID, stage, days, updatedBy,
+		)
+	}
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+func (r *pipelineSLARepo) ResolveBreaches(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE pipeline_sla_breaches b
+		SET resolved_at = NOW()
+		FROM applications a
+		WHERE b.resolved_at IS NULL AND a.id = b.application_id
+		  AND (a.stage <> b.stage OR a.stage_changed_at <> b.stage_entered_at)`)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *pipelineSLARepo) RecordBreaches(ctx context.Context, defaults map[string]int) (int64, error) {
+	stages := make([]string, 0, len(defaults))
+	days := make([]int, 0, len(defaults))
+	for stage, d := range defaults {
+		stages = append(stages, stage)
+		days = append(days, d)
+	}
+
+	tag, err := r.db.Exec(ctx, `
+		WITH thresholds AS (
+			SELECT a.id, a.job_id, j.company_id, a.stage, a.stage_changed_at,
+				COALESCE(t.max_days, d.max_days) AS max_days
+			FROM applications a
+			JOIN jobs j ON j.id = a.job_id
+			JOIN unnest($1::text[], $2::int[]) AS d(stage, max_days) ON d.stage = a.stage
+			LEFT JOIN company_pipeline_sla t ON t.company_id = j.company_id AND t.stage = a.stage
+			WHERE a.retention_anonymized_at IS NULL
+		)
+		INSERT INTO pipeline_sla_breaches (application_id, company_id, job_id, stage, threshold_days, stage_entered_at)
+		SELECT id, company_id, job_id, stage, max_days, stage_changed_at
+		FROM thresholds
+		WHERE max_days > 0 AND stage_changed_at < NOW() - make_interval(days => max_days)
+		ON CONFLICT (application_id, stage, stage_entered_at) DO NOTHING`,
+		stages, days,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *pipelineSLARepo) ListUnnotified(ctx context.Context, limit int) ([]domain.PipelineSLABreachNotice, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT b.id, b.company_id, cp.user_id, j.title, b.stage
+		FROM pipeline_sla_breaches b
+		JOIN company_profiles cp ON cp.id = b.company_id
+		JOIN jobs j ON j.id = b.job_id
+		WHERE b.notified_at IS NULL AND b.resolved_at IS NULL
+		ORDER BY b.company_id, b.id
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notices := []domain.PipelineSLABreachNotice{}
+	for rows.Next() {
+		var n domain.PipelineSLABreachNotice
+		if err := rows.Scan(&n.BreachID, &n.CompanyID, &n.CompanyUserID, &n.JobTitle, &n.Stage); err != nil {
+			return nil, err
+		}
+		notices = append(notices, n)
+	}
+	return notices, rows.Err()
+}
+
+func (r *pipelineSLARepo) MarkNotified(ctx context.Context, ids []int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE pipeline_sla_breaches SET notified_at = NOW() WHERE id = ANY($1)`, ids)
+	return err
+}
+
+func (r *pipelineSLARepo) ListBreaches(ctx context.Context, companyID int64, filter domain.PipelineSLABreachFilter) ([]domain.PipelineSLABreach, int64, error) {
+	conditions := []string{"b.company_id = $1"}
+	switch filter.Status {
+	case domain.PipelineSLABreachOpen:
+		conditions = append(conditions, "b.resolved_at IS NULL")
+	case domain.PipelineSLABreachResolved:
+		conditions = append(conditions, "b.resolved_at IS NOT NULL")
+	}
+	whereClause := strings.Join(conditions, " AND ")
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM pipeline_sla_breaches b WHERE `+whereClause, companyID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT b.id, b.application_id, b.company_id, b.job_id, j.title, b.stage, b.threshold_days, b.stage_entered_at,
+			EXTRACT(DAY FROM COALESCE(b.resolved_at, NOW()) - b.stage_entered_at)::int,
+			b.breached_at, b.notified_at, b.resolved_at
+		FROM pipeline_sla_breaches b
+		JOIN jobs j ON j.id = b.job_id
+		WHERE `+whereClause+`
+		ORDER BY b.breached_at DESC, b.id DESC
+		LIMIT $2 OFFSET $3`,
+		companyID, filter.PageSize, (filter.Page-1)*filter.PageSize,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	defer rows.Close()
+
+	breaches := []domain.PipelineSLABreach{}
+	for rows.Next() {
+		var b domain.PipelineSLABreach
+		if err := rows.Scan(
+			&b.ID, &b.ApplicationID, &b.CompanyID, &b.JobID, &b.JobTitle, &b.Stage, &b.ThresholdDays, &b.StageEnteredAt,
+			&b.DaysInStage, &b.BreachedAt, &b.NotifiedAt, &b.ResolvedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		breaches = append(breaches, b)
+	}
+	return breaches, total, rows.Err()
+}
+
+// CompanyReport counts, per company, the applications with any activity in the
+// period and the breaches recorded in it
+func (r *pipelineSLARepo) CompanyReport(ctx context.Context, since time.Time, minBreaches int) ([]domain.PipelineSLACompanyReport, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH breaches AS (
+			SELECT company_id,
+				COUNT(*) AS breaches,
+				COUNT(*) FILTER (WHERE resolved_at IS NULL) AS open_breaches,
+				COUNT(DISTINCT application_id) AS affected,
+				AVG(EXTRACT(EPOCH FROM COALESCE(resolved_at, NOW()) - stage_entered_at) / 86400 - threshold_days) AS avg_overdue
+			FROM pipeline_sla_breaches
+			WHERE breached_at >= $1
+			GROUP BY company_id
+			HAVING COUNT(*) >= $2
+		),
+		apps AS (
+			SELECT j.company_id, COUNT(*) AS applications
+			FROM applications a
+			JOIN jobs j ON j.id = a.job_id
+			WHERE j.company_id IN (SELECT company_id FROM breaches)
+			  AND (a.created_at >= $1 OR a.updated_at >= $1 OR a.stage_changed_at >= $1
+			       OR EXISTS (SELECT 1 FROM pipeline_sla_breaches pb WHERE pb.application_id = a.id AND pb.breached_at >= $1))
+			GROUP BY j.company_id
+		)
+		SELECT b.company_id, cp.company_name, COALESCE(apps.applications, 0), b.affected, b.breaches, b.open_breaches,
+			ROUND(b.avg_overdue::numeric, 1)::float8
+		FROM breaches b
+		JOIN company_profiles cp ON cp.id = b.company_id
+		LEFT JOIN apps ON apps.company_id = b.company_id`,
+		since, minBreaches,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := []domain.PipelineSLACompanyReport{}
+	for rows.Next() {
+		var c domain.PipelineSLACompanyReport
+		if err := rows.Scan(
+			&c.CompanyID, &c.CompanyName, &c.Applications, &c.AffectedApplications, &c.Breaches, &c.OpenBreaches, &c.AvgDaysOverdue,
+		); err != nil {
+			return nil, err
+		}
+		report = append(report, c)
+	}
+	return report, rows.Err()
+}
@@ -0,0 +1,281 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type placementRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPlacementRepository(db *pgxpool.Pool) domain.PlacementRepository {
+	return &placementRepo{db: db}
+}
+
+const placementSelect = `
+	SELECT p.id, p.application_id, p.candidate_user_id, u.email, p.company_id, COALESCE(cp.company_name, ''),
+		p.job_id, COALESCE(j.title, ''), to_char(p.start_date, 'YYYY-MM-DD'), p.contract_months,
+		to_char(p.contract_end_date, 'YYYY-MM-DD'), p.status, to_char(p.ended_on, 'YYYY-MM-DD'), p.end_reason,
+		p.notes, p.created_by, p.created_at, p.updated_at
+	FROM placements p
+	JOIN users u ON u.id = p.candidate_user_id
+	LEFT JOIN company_profiles cp ON cp.id = p.company_id
+	LEFT JOIN jobs j ON j.id = p.job_id`
+
+const placementCheckInColumns = `
+	c.id, c.placement_id, c.milestone_months, to_char(c.due_date, 'YYYY-MM-DD'), c.status, c.outcome,
+	c.notes, c.completed_by, c.completed_at`
+
+func scanPlacement(row pgx.Row) (*domain.Placement, error) {
+	var p domain.Placement
+	err := row.Scan(
+		&p.ID, &p.ApplicationID, &p.CandidateUserID, &p.CandidateEmail, &p.CompanyID, &p.CompanyName,
+		&p.JobID, &p.JobTitle, &p.StartDate, &p.ContractMonths,
+		&p.ContractEndDate, &p.Status, &p.EndedOn, &p.EndReason,
+		&p.Notes, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Create fills the candidate, company and job from the application
+func (r *placementRepo) Create(ctx context.Context, p *domain.Placement, checkIns []domain.PlacementCheckIn) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO placements (application_id, candidate_user_id, company_id, job_id, start_date, contract_months, contract_end_date, notes, created_by)
+		SELECT a.id, a.candidate_user_id, j.company_id, a.job_id, $2::date, $3, $4::date, $5, $6
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		WHERE a.id = $1
+		RETURNING id, candidate_user_id, company_id, job_id, status, created_at, updated_at`,
+		p.ApplicationID, p.StartDate, p.ContractMonths, p.ContractEndDate, p.Notes, p.CreatedBy,
+	).Scan(&p.ID, &p.CandidateUserID, &p.CompanyID, &p.JobID, &p.Status, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return mapPlacementError(err)
+	}
+
+	for i := range checkIns {
+		c := &checkIns[i]
+		c.PlacementID = p.ID
+		err := tx.QueryRow(ctx, `
+			INSERT INTO placement_checkins (placement_id, milestone_months, due_date)
+			VALUES ($1, $2, $3::date)
+			RETURNING id, status`,
+			c.PlacementID, c.MilestoneMonths, c.DueDate,
+		).Scan(&c.ID, &c.Status)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *placementRepo) GetByID(ctx context.Context, id int64) (*domain.Placement, error) {
+	p, err := scanPlacement(r.db.QueryRow(ctx, placementSelect+` WHERE p.id = $1`, id))
+	if err != nil {
+		return nil, mapPlacementError(err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+placementCheckInColumns+`
+		FROM placement_checkins c
+		WHERE c.placement_id = $1
+		ORDER BY c.milestone_months`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	p.CheckIns = []domain.PlacementCheckIn{}
+	for rows.Next() {
+		var c domain.PlacementCheckIn
+		if err := rows.Scan(
+			&c.ID, &c.PlacementID, &c.MilestoneMonths, &c.DueDate, &c.Status, &c.Outcome,
+			&c.Notes, &c.CompletedBy, &c.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		p.CheckIns = append(p.CheckIns, c)
+	}
+	return p, rows.Err()
+}
+
+func (r *placementRepo) List(ctx context.Context, filter domain.PlacementFilter, page, pageSize int) ([]domain.Placement, int64, error) {
+	const where = ` WHERE ($1 = '' OR p.status = $1) AND ($2::bigint IS NULL OR p.company_id = $2)`
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM placements p`+where, filter.Status, filter.CompanyID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, placementSelect+where+`
+		ORDER BY p.start_date DESC, p.id DESC
+		LIMIT $3 OFFSET $4`,
+		filter.Status, filter.CompanyID, pageSize, (page-1)*pageSize,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list query failed: %w", err)
+	}
+	defer rows.Close()
+
+	placements := []domain.Placement{}
+	for rows.Next() {
+		p, err := scanPlacement(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		placements = append(placements, *p)
+	}
+	return placements, total, rows.Err()
+}
+
+func (r *placementRepo) End(ctx context.Context, id int64, status, endedOn string, reason *string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE placements
+		SET status = $2, ended_on = $3::date, end_reason = $4, updated_at = NOW()
+		WHERE id = $1 AND status = 'ACTIVE'`,
+		id, status, endedOn, reason,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	// Check-ins due by the end date are still owed; later ones will never happen
+	_, err = tx.Exec(ctx, `
+		UPDATE placement_checkins SET status = 'SKIPPED'
+		WHERE placement_id = $1 AND status = 'PENDING' AND due_date > $2::date`,
+		id, endedOn,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *placementRepo) CompleteCheckIn(ctx context.Context, placementID int64, months int, outcome string, notes *string, completedBy string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE placement_checkins
+		SET status = 'COMPLETED', outcome = $3, notes = $4, completed_by = $5, completed_at = NOW()
+		WHERE placement_id = $1 AND milestone_months = $2 AND status = 'PENDING'`,
+		placementID, months, outcome, notes, completedBy,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *placementRepo) ListDueCheckIns(ctx context.Context, days int) ([]domain.PlacementCheckInTask, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+placementCheckInColumns+`,
+		p.candidate_user_id, u.email, COALESCE(cp.company_name, ''), COALESCE(j.title, ''), c.due_date < CURRENT_DATE
+		FROM placement_checkins c
+		JOIN placements p ON p.id = c.placement_id
+		JOIN users u ON u.id = p.candidate_user_id
+		LEFT JOIN company_profiles cp ON cp.id = p.company_id
+		LEFT JOIN jobs j ON j.id = p.job_id
+		WHERE c.status = 'PENDING' AND c.due_date <= CURRENT_DATE + $1::int
+		ORDER BY c.due_date, c.id`, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []domain.PlacementCheckInTask{}
+	for rows.Next() {
+		var t domain.PlacementCheckInTask
+		if err := rows.Scan(
+			&t.ID, &t.PlacementID, &t.MilestoneMonths, &t.DueDate, &t.Status, &t.Outcome,
+			&t.Notes, &t.CompletedBy, &t.CompletedAt,
+			&t.CandidateUserID, &t.CandidateEmail, &t.CompanyName, &t.JobTitle, &t.Overdue,
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+func (r *placementRepo) GetStats(ctx context.Context, dueWithinDays int) (*domain.PlacementStats, error) {
+	var s domain.PlacementStats
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'ACTIVE'),
+			COUNT(*) FILTER (WHERE status = 'COMPLETED'),
+			COUNT(*) FILTER (WHERE status = 'ENDED_EARLY')
+		FROM placements`,
+	).Scan(&s.Total, &s.Active, &s.Completed, &s.EndedEarly)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE due_date >= CURRENT_DATE AND due_date <= CURRENT_DATE + $1::int),
+			COUNT(*) FILTER (WHERE due_date < CURRENT_DATE)
+		FROM placement_checkins
+		WHERE status = 'PENDING'`, dueWithinDays,
+	).Scan(&s.CheckInsDue, &s.CheckInsOverdue)
+	if err != nil {
+		return nil, err
+	}
+
+	// A placement reached a milestone once that many months have passed since
+	// its start; it was retained unless it ended early before then
+	s.Milestones = []domain.PlacementMilestoneStats{}
+	for _, months := range domain.PlacementMilestones {
+		m := domain.PlacementMilestoneStats{Months: months}
+		err := r.db.QueryRow(ctx, `
+			SELECT COUNT(*),
+				COUNT(*) FILTER (WHERE NOT (p.status = 'ENDED_EARLY' AND p.ended_on < p.start_date + make_interval(months => $1))),
+				COUNT(*) FILTER (WHERE EXISTS(
+					SELECT 1 FROM placement_checkins c
+					WHERE c.placement_id = p.id AND c.milestone_months = $1 AND c.status = 'COMPLETED'))
+			FROM placements p
+			WHERE p.start_date + make_interval(months => $1) <= CURRENT_DATE`, months,
+		).Scan(&m.Reached, &m.Retained, &m.CheckedIn)
+		if err != nil {
+			return nil, err
+		}
+		s.Milestones = append(s.Milestones, m)
+	}
+	return &s, nil
+}
+
+func mapPlacementError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return apperror.Conflict("A placement is already recorded for this application")
+	}
+	return err
+}
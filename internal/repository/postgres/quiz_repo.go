@@ -0,0 +1,304 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const quizColumns = `q.id, q.title, q.description, q.category, q.time_limit_seconds, q.pass_score,
+	q.retake_cooldown_hours, q.is_published,
+	(SELECT COUNT(*) FROM skill_quiz_questions qq WHERE qq.quiz_id = q.id)::INT,
+	q.created_at, q.updated_at`
+
+const quizAttemptColumns = `id, quiz_id, user_id, status, started_at, expires_at, submitted_at,
+	correct_count, total_questions, score_percent`
+
+type quizRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewQuizRepository creates a new skill quiz repository
+func NewQuizRepository(db *pgxpool.Pool) domain.QuizRepository {
+	return &quizRepo{db: db}
+}
+
+func scanQuiz(row pgx.Row, q *domain.SkillQuiz) error {
+	return row.Scan(
+		&q.ID, &q.Title, &q.Description, &q.Category, &q.TimeLimitSeconds, &q.PassScore,
+		&q.RetakeCooldownHours, &q.IsPublished, &q.QuestionCount, &q.CreatedAt, &q.UpdatedAt,
+	)
+}
+
+func scanQuizAttempt(row pgx.Row, a *domain.QuizAttempt) error {
+	return row.Scan(
+		&a.ID, &a.QuizID, &a.UserID, &a.Status, &a.StartedAt, &a.ExpiresAt, &a.SubmittedAt,
+		&a.CorrectCount, &a.TotalQuestions, &a.ScorePercent,
+	)
+}
+
+func (r *quizRepo) ListQuizzes(ctx context.Context, publishedOnly bool) ([]domain.SkillQuiz, error) {
+	query := `SELECT ` + quizColumns + ` FROM skill_quizzes q`
+	if publishedOnly {
+		query += ` WHERE q.is_published = TRUE`
+	}
+	query += ` ORDER BY q.category, q.title`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quizzes := []domain.SkillQuiz{}
+	for rows.Next() {
+		var q domain.SkillQuiz
+		if err := scanQuiz(rows, &q); err != nil {
+			return nil, err
+		}
+		quizzes = append(quizzes, q)
+	}
+	return quizzes, rows.Err()
+}
+
+func (r *quizRepo) GetQuiz(ctx context.Context, id int64) (*domain.SkillQuiz, error) {
+	var q domain.SkillQuiz
+	err := scanQuiz(r.db.QueryRow(ctx, `SELECT `+quizColumns+` FROM skill_quizzes q WHERE q.id = $1`, id), &q)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (r *quizRepo) ListQuestions(ctx context.Context, quizID int64) ([]domain.QuizQuestion, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, quiz_id, position, prompt, options, correct_option
+		FROM skill_quiz_questions
+		WHERE quiz_id = $1
+		ORDER BY position`, quizID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []domain.QuizQuestion{}
+	for rows.Next() {
+		var q domain.QuizQuestion
+		if err := rows.Scan(&q.ID, &q.QuizID, &q.Position, &q.Prompt, &q.Options, &q.CorrectOption); err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+	return questions, rows.Err()
+}
+
+func (r *quizRepo) CreateQuiz(ctx context.Context, quiz *domain.SkillQuiz, createdBy string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO skill_quizzes (title, description, category, time_limit_seconds, pass_score, retake_cooldown_hours, is_published, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`,
+		quiz.Title, quiz.Description, quiz.Category, quiz.TimeLimitSeconds, quiz.PassScore,
+		quiz.RetakeCooldownHours, quiz.IsPublished, createdBy,
+	).Scan(&quiz.ID, &quiz.CreatedAt, &quiz.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertQuizQuestions(ctx, tx, quiz); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *quizRepo) UpdateQuiz(ctx context.Context, quiz *domain.SkillQuiz) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE skill_quizzes
+		SET title = $2, description = $3, category = $4, time_limit_seconds = $5, pass_score = $6,
+		    retake_cooldown_hours = $7, is_published = $8, updated_at = NOW()
+		WHERE id = $1
+		RETURNING created_at, updated_at`,
+		quiz.ID, quiz.Title, quiz.Description, quiz.Category, quiz.TimeLimitSeconds, quiz.PassScore,
+		quiz.RetakeCooldownHours, quiz.IsPublished,
+	).Scan(&quiz.CreatedAt, &quiz.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM skill_quiz_questions WHERE quiz_id = $1`, quiz.ID); err != nil {
+		return err
+	}
+	if err := insertQuizQuestions(ctx, tx, quiz); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func insertQuizQuestions(ctx context.Context, tx pgx.Tx, quiz *domain.SkillQuiz) error {
+	query := `
+		INSERT INTO skill_quiz_questions (quiz_id, position, prompt, options, correct_option)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+	for i := range quiz.Questions {
+		q := &quiz.Questions[i]
+		q.QuizID = quiz.ID
+		if err := tx.QueryRow(ctx, query, q.QuizID, q.Position, q.Prompt, q.Options, q.CorrectOption).Scan(&q.ID); err != nil {
+			return err
+		}
+	}
+	quiz.QuestionCount = len(quiz.Questions)
+	return nil
+}
+
+func (r *quizRepo) GetAttempt(ctx context.Context, attemptID int64) (*domain.QuizAttempt, error) {
+	var a domain.QuizAttempt
+	err := scanQuizAttempt(r.db.QueryRow(ctx, `SELECT `+quizAttemptColumns+` FROM skill_quiz_attempts WHERE id = $1`, attemptID), &a)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *quizRepo) GetLatestAttempt(ctx context.Context, userID string, quizID int64) (*domain.QuizAttempt, error) {
+	var a domain.QuizAttempt
+	err := scanQuizAttempt(r.db.QueryRow(ctx, `
+		SELECT `+quizAttemptColumns+`
+		FROM skill_quiz_attempts
+		WHERE user_id = $1 AND quiz_id = $2
+		ORDER BY started_at DESC
+		LIMIT 1`, userID, quizID), &a)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *quizRepo) ListLatestAttempts(ctx context.Context, userID string) ([]domain.QuizAttempt, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT ON (quiz_id) `+quizAttemptColumns+`
+		FROM skill_quiz_attempts
+		WHERE user_id = $1
+		ORDER BY quiz_id, started_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []domain.QuizAttempt{}
+	for rows.Next() {
+		var a domain.QuizAttempt
+		if err := scanQuizAttempt(rows, &a); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	return attempts, rows.Err()
+}
+
+func (r *quizRepo) CreateAttempt(ctx context.Context, attempt *domain.QuizAttempt) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO skill_quiz_attempts (quiz_id, user_id, status, started_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		attempt.QuizID, attempt.UserID, attempt.Status, attempt.StartedAt, attempt.ExpiresAt,
+	).Scan(&attempt.ID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return apperror.Conflict("A quiz attempt is already in progress")
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *quizRepo) CloseAttempt(ctx context.Context, attempt *domain.QuizAttempt, passed bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Only an open attempt can be closed, so a double submit cannot score twice
+	result, err := tx.Exec(ctx, `
+		UPDATE skill_quiz_attempts
+		SET status = $2, submitted_at = $3, correct_count = $4, total_questions = $5, score_percent = $6
+		WHERE id = $1 AND status = 'IN_PROGRESS'`,
+		attempt.ID, attempt.Status, attempt.SubmittedAt, attempt.CorrectCount, attempt.TotalQuestions, attempt.ScorePercent,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	if attempt.Status == domain.QuizAttemptSubmitted && attempt.ScorePercent != nil {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO candidate_quiz_scores (user_id, quiz_id, best_score, passed, attempt_count, last_attempt_at)
+			VALUES ($1, $2, $3, $4, 1, $5)
+			ON CONFLICT (user_id, quiz_id) DO UPDATE SET
+				best_score = GREATEST(candidate_quiz_scores.best_score, EXCLUDED.best_score),
+				passed = candidate_quiz_scores.passed OR EXCLUDED.passed,
+				attempt_count = candidate_quiz_scores.attempt_count + 1,
+				last_attempt_at = EXCLUDED.last_attempt_at`,
+			attempt.UserID, attempt.QuizID, *attempt.ScorePercent, passed, attempt.SubmittedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *quizRepo) ListUserScores(ctx context.Context, userID string) ([]domain.CandidateQuizScore, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.quiz_id, q.title, q.category, s.best_score, s.passed, s.attempt_count, s.last_attempt_at
+		FROM candidate_quiz_scores s
+		JOIN skill_quizzes q ON q.id = s.quiz_id
+		WHERE s.user_id = $1
+		ORDER BY q.category, q.title`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scores := []domain.CandidateQuizScore{}
+	for rows.Next() {
+		var s domain.CandidateQuizScore
+		if err := rows.Scan(&s.QuizID, &s.QuizTitle, &s.Category, &s.BestScore, &s.Passed, &s.AttemptCount, &s.LastAttemptAt); err != nil {
+			return nil, err
+		}
+		scores = append(scores, s)
+	}
+	return scores, rows.Err()
+}
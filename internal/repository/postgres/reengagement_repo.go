@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type reengagementRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReengagementRepository(db *pgxpool.Pool) domain.ReengagementRepository {
+	return &reengagementRepo{db: db}
+}
+
+// ListCandidates returns inactive candidates with an unsubmitted verification or
+// stalled applications, least recently active first. Paused and opted-out
+// candidates, and anyone nudged successfully since CooldownSince, are excluded.
+func (r *reengagementRepo) ListCandidates(ctx context.Context, criteria domain.ReengagementCriteria) ([]domain.ReengagementCandidate, error) {
+	query := `
+		SELECT u.id, u.email, u.preferred_locale, COALESCE(u.last_active_at, u.updated_at),
+		       av.status, av.first_name, av.last_name, av.profile_picture_url, av.phone,
+		       COALESCE(` + phoneVerifiedExpr + `, false),
+		       av.cv_url, av.japanese_level, av.domicile_city,
+		       av.onboarding_completed_at IS NOT NULL, COALESCE(av.preferred_locations, '{}'),
+		       stalled.total
+		FROM users u
+		LEFT JOIN account_verifications av ON av.user_id = u.id
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS total
+			FROM applications a
+			WHERE a.candidate_user_id = u.id
+			  AND a.status IN ('applied', 'reviewed')
+			  AND a.updated_at < $2
+		) stalled ON true
+		WHERE u.role = 'candidate'
+		  AND u.reengagement_opt_out_at IS NULL
+		  AND (u.paused_until IS NULL OR u.paused_until <= NOW())
+		  AND COALESCE(u.last_active_at, u.updated_at) < $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM reengagement_nudges n
+			WHERE n.user_id = u.id AND n.status = 'SENT' AND n.sent_at >= $3
+		  )
+		  AND (av.id IS NULL OR av.status IN ('PENDING', 'REJECTED') OR stalled.total > 0)
+		ORDER BY COALESCE(u.last_active_at, u.updated_at) ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(ctx, query, criteria.InactiveBefore, criteria.StalledBefore, criteria.CooldownSince, criteria.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []domain.ReengagementCandidate
+	for rows.Next() {
+		var c domain.ReengagementCandidate
+		if err := rows.Scan(
+			&c.UserID, &c.Email, &c.PreferredLocale, &c.LastActiveAt,
+			&c.VerificationStatus, &c.FirstName, &c.LastName, &c.ProfilePictureURL, &c.Phone,
+			&c.PhoneVerified,
+			&c.CvURL, &c.JapaneseLevel, &c.DomicileCity,
+			&c.OnboardingCompleted, &c.PreferredLocations,
+			&c.StalledApplications,
+		); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+func (r *reengagementRepo) ListMatchingJobs(ctx context.Context, userID string, since time.Time, locations []string, limit int) ([]domain.Job, error) {
+	query := `
+		SELECT j.id, j.company_id, j.title, j.description, j.salary_min, j.salary_max, j.location,
+		       j.company_status, j.employment_type, j.job_type, j.experience_level, j.qualifications,
+		       j.created_at, j.updated_at
+		FROM jobs j
+		WHERE ` + publishedJobExpr + `
+		  AND COALESCE(j.publish_at, j.created_at) >= $2
+		  AND NOT EXISTS (
+			SELECT 1 FROM applications a WHERE a.job_id = j.id AND a.candidate_user_id = $1
+		  )
+		ORDER BY COALESCE(j.location = ANY($3), false) DESC, j.created_at DESC
+		LIMIT $4
+	`
+
+	if locations == nil {
+		locations = []string{}
+	}
+	rows, err := r.db.Query(ctx, query, userID, since, locations, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		var job domain.Job
+		if err := rows.Scan(&job.ID, &job.CompanyID, &job.Title, &job.Description, &job.SalaryMin, &job.SalaryMax, &job.Location, &job.CompanyStatus, &job.EmploymentType, &job.JobType, &job.ExperienceLevel, &job.Qualifications, &job.CreatedAt, &job.UpdatedAt); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *reengagementRepo) CreateNudge(ctx context.Context, nudge *domain.ReengagementNudge) error {
+	query := `
+		INSERT INTO reengagement_nudges (user_id, campaign, channel, missing_steps, suggested_job_ids, status, error_message, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	missing, jobIDs := nudge.MissingSteps, nudge.SuggestedJobIDs
+	if missing == nil {
+		missing = []string{}
+	}
+	if jobIDs == nil {
+		jobIDs = []int64{}
+	}
+	return r.db.QueryRow(ctx, query,
+		nudge.UserID, nudge.Campaign, nudge.Channel, missing, jobIDs, nudge.Status, nudge.ErrorMessage, nudge.SentAt,
+	).Scan(&nudge.ID)
+}
+
+func (r *reengagementRepo) TouchActivity(ctx context.Context, userID string, at, returnSince time.Time) error {
+	if _, err := r.db.Exec(ctx,
+		`UPDATE users SET last_active_at = $2 WHERE id = $1 AND (last_active_at IS NULL OR last_active_at < $2)`,
+		userID, at,
+	); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE reengagement_nudges
+		SET returned_at = $2
+		WHERE user_id = $1 AND status = 'SENT' AND returned_at IS NULL
+		  AND sent_at >= $3 AND sent_at <= $2
+	`
+	_, err := r.db.Exec(ctx, query, userID, at, returnSince)
+	return err
+}
+
+func (r *reengagementRepo) GetOptOut(ctx context.Context, userID string) (*time.Time, error) {
+	var optOutAt *time.Time
+	err := r.db.QueryRow(ctx, `SELECT reengagement_opt_out_at FROM users WHERE id = $1`, userID).Scan(&optOutAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return optOutAt, nil
+}
+
+func (r *reengagementRepo) SetOptOut(ctx context.Context, userID string, at *time.Time) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE users SET reengagement_opt_out_at = $2, updated_at = NOW() WHERE id = $1`,
+		userID, at,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetCampaignStats aggregates nudges sent in [from, to). Conversion is evaluated
+// per nudge against the attribution window starting at sent_at.
+func (r *reengagementRepo) GetCampaignStats(ctx context.Context, from, to time.Time, attributionDays int) ([]domain.ReengagementCampaignStats, error) {
+	query := `
+		SELECT n.campaign,
+		       COUNT(*) FILTER (WHERE n.status = 'SENT'),
+		       COUNT(*) FILTER (WHERE n.status = 'FAILED'),
+		       COUNT(*) FILTER (WHERE n.status = 'SENT' AND n.returned_at IS NOT NULL),
+		       COUNT(*) FILTER (WHERE n.status = 'SENT' AND CASE n.campaign
+		           WHEN 'INCOMPLETE_PROFILE' THEN EXISTS (
+		               SELECT 1 FROM account_verifications av
+		               WHERE av.user_id = n.user_id
+		                 AND av.status IN ('SUBMITTED', 'VERIFIED')
+		                 AND av.submitted_at >= n.sent_at
+		                 AND av.submitted_at < n.sent_at + make_interval(days => $3)
+		           )
+		           ELSE EXISTS (
+		               SELECT 1 FROM applications a
+		               WHERE a.candidate_user_id = n.user_id
+		                 AND a.created_at >= n.sent_at
+		                 AND a.created_at < n.sent_at + make_interval(days => $3)
+		           )
+		       END)
+		FROM reengagement_nudges n
+		WHERE n.sent_at >= $1 AND n.sent_at < $2
+		GROUP BY n.campaign
+	`
+
+	rows, err := r.db.Query(ctx, query, from, to, attributionDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []domain.ReengagementCampaignStats
+	for rows.Next() {
+		var s domain.ReengagementCampaignStats
+		if err := rows.Scan(&s.Campaign, &s.Sent, &s.Failed, &s.Returned, &s.Converted); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+func (r *reengagementRepo) CountOptedOut(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM users WHERE role = 'candidate' AND reengagement_opt_out_at IS NOT NULL`,
+	).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type retentionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRetentionRepository(db *pgxpool.Pool) domain.RetentionRepository {
+	return &retentionRepo{db: db}
+}
+
+// expiredRecordQueries select the expired records of each data class and action
+// as (id, ts), ts being the time the retention period counts from
+var expiredRecordQueries = map[string]map[string]string{
+	domain.RetentionEntityApplications: {
+		domain.RetentionActionAnonymize: `
+			SELECT id, updated_at AS ts FROM applications
+			WHERE updated_at < $1 AND retention_anonymized_at IS NULL`,
+		domain.RetentionActionDelete: `
+			SELECT id, updated_at AS ts FROM applications
+			WHERE updated_at < $1`,
+	},
+	domain.RetentionEntityRejectedVerifications: {
+		domain.RetentionActionAnonymize: `
+			SELECT id, updated_at AS ts FROM account_verifications
+			WHERE status = 'REJECTED' AND updated_at < $1
+			  AND (retention_anonymized_at IS NULL OR retention_anonymized_at < updated_at)`,
+	},
+}
+
+// enforceStatements change one batch; $1 is the cutoff and $2 the batch size.
+// Anonymizing keeps the record (and updated_at) for reporting but drops the
+// personal content.
+var enforceStatements = map[string]map[string]string{
+	domain.RetentionEntityApplications: {
+		domain.RetentionActionAnonymize: `
+			WITH batch AS (%s ORDER BY id LIMIT $2),
+			answers AS (
+				DELETE FROM application_answers WHERE application_id IN (SELECT id FROM batch)
+			)
+			UPDATE applications
+			SET cv_url = '', cover_letter = NULL, retention_anonymized_at = NOW()
+			WHERE id IN (SELECT id FROM batch)`,
+		domain.RetentionActionDelete: `
+			WITH batch AS (%s ORDER BY id LIMIT $2)
+			DELETE FROM applications WHERE id IN (SELECT id FROM batch)`,
+	},
+	domain.RetentionEntityRejectedVerifications: {
+		domain.RetentionActionAnonymize: `
+			WITH batch AS (%s ORDER BY id LIMIT $2)
+			UPDATE account_verifications
+			SET phone = NULL, birth_date = NULL, religion = NULL, height_cm = NULL, weight_kg = NULL,
+			    marital_status = NULL, children_count = NULL,
+			    cv_url = NULL, portfolio_url = NULL, japanese_certificate_url = NULL, supporting_certificates_url = NULL,
+			    passport_url = NULL, passport_expiry_date = NULL, medical_check_url = NULL, guardian_consent_url = NULL,
+			    emergency_contact_name = NULL, emergency_contact_phone = NULL, emergency_contact_relationship = NULL,
+			    retention_anonymized_at = NOW()
+			WHERE id IN (SELECT id FROM batch)`,
+	},
+}
+
+func expiredRecordQuery(entity, action string) (string, error) {
+	query, ok := expiredRecordQueries[entity][action]
+	if !ok {
+		return "", fmt.Errorf("no retention query for %s %s", entity, action)
+	}
+	return query, nil
+}
+
+func (r *retentionRepo) ListPolicies(ctx context.Context) ([]domain.RetentionPolicy, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT entity, retention_days, action, enabled, updated_by, updated_at
+		FROM retention_policies
+		ORDER BY entity`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := []domain.RetentionPolicy{}
+	for rows.Next() {
+		var p domain.RetentionPolicy
+		if err := rows.Scan(&p.Entity, &p.RetentionDays, &p.Action, &p.Enabled, &p.UpdatedBy, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		p.SupportedActions = domain.RetentionEntityActions[p.Entity]
+		policies = append(policies, p)
+	}
+	return policies, rows.Err()
+}
+
+func (r *retentionRepo) UpdatePolicy(ctx context.Context, policy *domain.RetentionPolicy) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE retention_policies
+		SET retention_days = $2, action = $3, enabled = $4, updated_by = $5, updated_at = NOW()
+		WHERE entity = $1
+		RETURNING updated_at`,
+		policy.Entity, policy.RetentionDays, policy.Action, policy.Enabled, policy.UpdatedBy,
+	).Scan(&policy.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+func (r *retentionRepo) CountExpired(ctx context.Context, entity, action string, cutoff time.Time) (int, *time.Time, error) {
+	query, err := expiredRecordQuery(entity, action)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var count int
+	var oldest *time.Time
+	err = r.db.QueryRow(ctx, `SELECT COUNT(*), MIN(ts) FROM (`+query+`) expired`, cutoff).Scan(&count, &oldest)
+	return count, oldest, err
+}
+
+func (r *retentionRepo) EnforceBatch(ctx context.Context, entity, action string, cutoff time.Time, limit int) (int, error) {
+	query, err := expiredRecordQuery(entity, action)
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := r.db.Exec(ctx, fmt.Sprintf(enforceStatements[entity][action], query), cutoff, limit)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *retentionRepo) CreateRun(ctx context.Context, run *domain.RetentionRun) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO retention_runs (trigger_source, dry_run, status, started_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, started_at`,
+		run.TriggerSource, run.DryRun, run.Status, run.StartedBy,
+	).Scan(&run.ID, &run.StartedAt)
+}
+
+func (r *retentionRepo) FinishRun(ctx context.Context, run *domain.RetentionRun) error {
+	results, err := json.Marshal(run.Results)
+	if err != nil {
+		return err
+	}
+	return r.db.QueryRow(ctx, `
+		UPDATE retention_runs
+		SET status = $2, results = $3, error_message = $4, finished_at = NOW()
+		WHERE id = $1
+		RETURNING finished_at`,
+		run.ID, run.Status, results, run.ErrorMessage,
+	).Scan(&run.FinishedAt)
+}
+
+const retentionRunColumns = `id, trigger_source, dry_run, status, results, error_message, started_by, started_at, finished_at`
+
+func scanRetentionRun(row pgx.Row) (*domain.RetentionRun, error) {
+	var run domain.RetentionRun
+	var results []byte
+	if err := row.Scan(
+		&run.ID, &run.TriggerSource, &run.DryRun, &run.Status, &results, &run.ErrorMessage,
+		&run.StartedBy, &run.StartedAt, &run.FinishedAt,
+	); err != nil {
+		return nil, err
+	}
+	run.Results = []domain.RetentionEntityResult{}
+	if err := json.Unmarshal(results, &run.Results); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *retentionRepo) LatestDryRun(ctx context.Context) (*domain.RetentionRun, error) {
+	run, err := scanRetentionRun(r.db.QueryRow(ctx, `
+		SELECT `+retentionRunColumns+`
+		FROM retention_runs
+		WHERE dry_run AND status = 'COMPLETED'
+		ORDER BY started_at DESC
+		LIMIT 1`))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return run, err
+}
+
+func (r *retentionRepo) ListRuns(ctx context.Context, limit int) ([]domain.RetentionRun, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+retentionRunColumns+`
+		FROM retention_runs
+		ORDER BY started_at DESC
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []domain.RetentionRun{}
+	for rows.Next() {
+		run, err := scanRetentionRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *retentionRepo) GetRun(ctx context.Context, id int64) (*domain.RetentionRun, error) {
+	run, err := scanRetentionRun(r.db.QueryRow(ctx, `SELECT `+retentionRunColumns+` FROM retention_runs WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return run, err
+}
@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type savedSearchRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewSavedSearchRepository creates a new saved search repository
+func NewSavedSearchRepository(db *pgxpool.Pool) domain.SavedSearchRepository {
+	return &savedSearchRepo{db: db}
+}
+
+const savedSearchColumns = `id, user_id, name, filters, alerts_enabled, alert_frequency, alerted_until, created_at, updated_at`
+
+type savedSearchScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSavedSearch(row savedSearchScanner, extra ...any) (*domain.SavedSearch, error) {
+	var s domain.SavedSearch
+	var filters []byte
+	dest := append([]any{
+		&s.ID, &s.UserID, &s.Name, &filters, &s.AlertsEnabled, &s.AlertFrequency,
+		&s.AlertedUntil, &s.CreatedAt, &s.UpdatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(filters, &s.Filter); err != nil {
+		return nil, fmt.Errorf("decode saved search %d filters: %w", s.ID, err)
+	}
+	return &s, nil
+}
+
+func (r *savedSearchRepo) Create(ctx context.Context, s *domain.SavedSearch) error {
+	filters, err := json.Marshal(s.Filter)
+	if err != nil {
+		return err
+	}
+	return r.db.QueryRow(ctx, `
+		INSERT INTO saved_searches (user_id, name, filters, alerts_enabled, alert_frequency)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, alerted_until, created_at, updated_at`,
+		s.UserID, s.Name, filters, s.AlertsEnabled, s.AlertFrequency,
+	).Scan(&s.ID, &s.AlertedUntil, &s.CreatedAt, &s.UpdatedAt)
+}
+
+func (r *savedSearchRepo) ListByUser(ctx context.Context, userID string) ([]domain.SavedSearch, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+savedSearchColumns+` FROM saved_searches WHERE user_id = $1 ORDER BY created_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	searches := []domain.SavedSearch{}
+	for rows.Next() {
+		s, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, *s)
+	}
+	return searches, rows.Err()
+}
+
+func (r *savedSearchRepo) CountByUser(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM saved_searches WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+// Update replaces the name, filter and alert settings. Turning alerts back on
+// resets the watermark so the first alert does not list jobs posted while they were off.
+func (r *savedSearchRepo) Update(ctx context.Context, s *domain.SavedSearch) error {
+	filters, err := json.Marshal(s.Filter)
+	if err != nil {
+		return err
+	}
+	err = r.db.QueryRow(ctx, `
+		UPDATE saved_searches
+		SET name = $3, filters = $4, alert_frequency = $6, updated_at = NOW(),
+		    alerted_until = CASE WHEN $5 AND NOT alerts_enabled THEN NOW() ELSE alerted_until END,
+		    alerts_enabled = $5
+		WHERE id = $1 AND user_id = $2
+		RETURNING alerted_until, created_at, updated_at`,
+		s.ID, s.UserID, s.Name, filters, s.AlertsEnabled, s.AlertFrequency,
+	).Scan(&s.AlertedUntil, &s.CreatedAt, &s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+func (r *savedSearchRepo) Delete(ctx context.Context, userID string, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *savedSearchRepo) ListDueAlerts(ctx context.Context, now time.Time, limit int) ([]domain.SavedSearchAlertCandidate, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.id, s.user_id, s.name, s.filters, s.alerts_enabled, s.alert_frequency,
+		       s.alerted_until, s.created_at, s.updated_at,
+		       u.email, av.first_name, u.preferred_locale
+		FROM saved_searches s
+		JOIN users u ON u.id = s.user_id
+		LEFT JOIN account_verifications av ON av.user_id = s.user_id
+		WHERE s.alerts_enabled
+		  AND u.role = 'candidate'
+		  -- Paused profiles get no job alerts until the pause ends
+		  AND (u.paused_until IS NULL OR u.paused_until <= $1::TIMESTAMPTZ)
+		  AND s.alerted_until <= $1::TIMESTAMPTZ - CASE s.alert_frequency WHEN 'WEEKLY' THEN INTERVAL '7 days' ELSE INTERVAL '1 day' END
+		ORDER BY s.user_id, s.created_at
+		LIMIT $2`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []domain.SavedSearchAlertCandidate
+	for rows.Next() {
+		var c domain.SavedSearchAlertCandidate
+		s, err := scanSavedSearch(rows, &c.Email, &c.FirstName, &c.PreferredLocale)
+		if err != nil {
+			return nil, err
+		}
+		c.SavedSearch = *s
+		due = append(due, c)
+	}
+	return due, rows.Err()
+}
+
+func (r *savedSearchRepo) FindNewJobs(ctx context.Context, filter domain.JobSearchFilter, since, until time.Time, limit int) ([]domain.JobCard, int, error) {
+	// Build dynamic WHERE clause
+	// Scheduled jobs count as new when they go live
+	conditions := []string{publishedJobExpr, "COALESCE(j.publish_at, j.created_at) > $1", "COALESCE(j.publish_at, j.created_at) <= $2"}
+	args := []interface{}{since, until}
+	argIndex := 3
+
+	for _, word := range strings.Fields(filter.Keywords) {
+		conditions = append(conditions, fmt.Sprintf("(j.title ILIKE $%d OR j.description ILIKE $%d)", argIndex, argIndex))
+		args = append(args, containsPattern(word))
+		argIndex++
+	}
+	if len(filter.Locations) > 0 {
+		// Locations are free text, so match any of them as a substring
+		matches := make([]string, len(filter.Locations))
+		for i, location := range filter.Locations {
+			matches[i] = fmt.Sprintf("j.location ILIKE $%d", argIndex)
+			args = append(args, containsPattern(location))
+			argIndex++
+		}
+		conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+	if filter.SalaryMin != nil {
+		conditions = append(conditions, fmt.Sprintf("j.salary_max >= $%d", argIndex))
+		args = append(args, *filter.SalaryMin)
+		argIndex++
+	}
+	if len(filter.EmploymentTypes) > 0 {
+		conditions = append(conditions, fmt.Sprintf("j.employment_type = ANY($%d)", argIndex))
+		args = append(args, filter.EmploymentTypes)
+		argIndex++
+	}
+	if len(filter.JobTypes) > 0 {
+		conditions = append(conditions, fmt.Sprintf("j.job_type = ANY($%d)", argIndex))
+		args = append(args, filter.JobTypes)
+		argIndex++
+	}
+	if len(filter.ExperienceLevels) > 0 {
+		conditions = append(conditions, fmt.Sprintf("j.experience_level = ANY($%d)", argIndex))
+		args = append(args, filter.ExperienceLevels)
+		argIndex++
+	}
+	if len(filter.Industries) > 0 {
+		conditions = append(conditions, fmt.Sprintf("cp.industry = ANY($%d)", argIndex))
+		args = append(args, filter.Industries)
+		argIndex++
+	}
+	whereClause := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM jobs j LEFT JOIN company_profiles cp ON j.company_id = cp.id` + whereClause
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []domain.JobCard{}, 0, nil
+	}
+
+	query := jobCardSelect + whereClause + fmt.Sprintf(" ORDER BY j.created_at DESC LIMIT $%d", argIndex)
+	rows, err := r.db.Query(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs, err := scanJobCards(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
+
+func (r *savedSearchRepo) MarkAlerted(ctx context.Context, id int64, until time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE saved_searches SET alerted_until = GREATEST(alerted_until, $2) WHERE id = $1`, id, until)
+	return err
+}
+
+func (r *savedSearchRepo) RecordAlert(ctx context.Context, a *domain.SavedSearchAlert) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO saved_search_alerts (saved_search_id, user_id, job_count, channel, status, error_message)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		a.SavedSearchID, a.UserID, a.JobCount, a.Channel, a.Status, a.ErrorMessage,
+	)
+	return err
+}
@@ -0,0 +1,312 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type screeningCallRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewScreeningCallRepository creates a new screening call repository
+func NewScreeningCallRepository(db *pgxpool.Pool) domain.ScreeningCallRepository {
+	return &screeningCallRepo{db: db}
+}
+
+const screeningCallSelect = `
+	SELECT sc.id, sc.candidate_user_id, sc.company_id, cp.company_name, sc.application_id, j.title, sc.booked_by,
+		sc.scheduled_at, sc.duration_minutes, sc.notes, sc.status, sc.cancel_reason, sc.reminder_sent_at,
+		sc.created_at, sc.updated_at
+	FROM screening_calls sc
+	LEFT JOIN company_profiles cp ON cp.id = sc.company_id
+	LEFT JOIN applications a ON a.id = sc.application_id
+	LEFT JOIN jobs j ON j.id = a.job_id`
+
+func scanScreeningCall(row pgx.Row, c *domain.ScreeningCall) error {
+	return row.Scan(&c.ID, &c.CandidateUserID, &c.CompanyID, &c.CompanyName, &c.ApplicationID, &c.JobTitle, &c.BookedBy,
+		&c.ScheduledAt, &c.DurationMinutes, &c.Notes, &c.Status, &c.CancelReason, &c.ReminderSentAt,
+		&c.CreatedAt, &c.UpdatedAt)
+}
+
+func (r *screeningCallRepo) queryCalls(ctx context.Context, query string, args ...any) ([]domain.ScreeningCall, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	calls := []domain.ScreeningCall{}
+	for rows.Next() {
+		var c domain.ScreeningCall
+		if err := scanScreeningCall(rows, &c); err != nil {
+			return nil, err
+		}
+		calls = append(calls, c)
+	}
+	return calls, rows.Err()
+}
+
+// screeningCallActivity is the data of the screening call feed entries
+type screeningCallActivity struct {
+	CallID          int64     `json:"call_id"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	CompanyName     *string   `json:"company_name,omitempty"`
+	JobTitle        *string   `json:"job_title,omitempty"`
+	CancelReason    *string   `json:"cancel_reason,omitempty"`
+}
+
+func screeningCallActivityEntry(c *domain.ScreeningCall, activityType string, at time.Time) (*domain.CandidateActivity, error) {
+	data, err := json.Marshal(screeningCallActivity{
+		CallID:          c.ID,
+		ScheduledAt:     c.ScheduledAt,
+		DurationMinutes: c.DurationMinutes,
+		CompanyName:     c.CompanyName,
+		JobTitle:        c.JobTitle,
+		CancelReason:    c.CancelReason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.CandidateActivity{UserID: c.CandidateUserID, ActivityType: activityType, Data: data, OccurredAt: at}, nil
+}
+
+// ============================================================================
+// Contact hours
+// ============================================================================
+
+func (r *screeningCallRepo) GetContactHours(ctx context.Context, userID string) (*domain.CandidateContactHours, error) {
+	hours := &domain.CandidateContactHours{UserID: userID}
+	var updatedAt time.Time
+	err := r.db.QueryRow(ctx,
+		`SELECT timezone, windows, updated_at FROM candidate_contact_hours WHERE user_id = $1`, userID,
+	).Scan(&hours.Timezone, &hours.Windows, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	hours.UpdatedAt = &updatedAt
+	return hours, nil
+}
+
+func (r *screeningCallRepo) UpsertContactHours(ctx context.Context, hours *domain.CandidateContactHours) error {
+	var updatedAt time.Time
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO candidate_contact_hours (user_id, timezone, windows, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone, windows = EXCLUDED.windows, updated_at = NOW()
+		RETURNING updated_at`,
+		hours.UserID, hours.Timezone, hours.Windows,
+	).Scan(&updatedAt)
+	if err != nil {
+		return err
+	}
+	hours.UpdatedAt = &updatedAt
+	return nil
+}
+
+// ============================================================================
+// Calls
+// ============================================================================
+
+func (r *screeningCallRepo) GetTarget(ctx context.Context, applicationID int64) (*domain.ScreeningCallTarget, error) {
+	t := &domain.ScreeningCallTarget{ApplicationID: applicationID}
+	err := r.db.QueryRow(ctx, `
+		SELECT a.candidate_user_id, j.company_id, COALESCE(cp.company_name, 'Unknown Company'), j.title
+		FROM applications a
+		JOIN jobs j ON j.id = a.job_id
+		LEFT JOIN company_profiles cp ON cp.id = j.company_id
+		WHERE a.id = $1`, applicationID,
+	).Scan(&t.CandidateUserID, &t.CompanyID, &t.CompanyName, &t.JobTitle)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *screeningCallRepo) IsCandidate(ctx context.Context, userID string) (bool, error) {
+	var ok bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM users WHERE id = $1 AND role = 'candidate')`, userID,
+	).Scan(&ok)
+	return ok, err
+}
+
+func (r *screeningCallRepo) Create(ctx context.Context, c *domain.ScreeningCall) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize bookings per candidate so two overlapping calls cannot both pass the check
+	if _, err := tx.Exec(ctx,
+		`SELECT pg_advisory_xact_lock(hashtext('screening_calls:' || $1::TEXT))`, c.CandidateUserID,
+	); err != nil {
+		return err
+	}
+
+	var overlaps bool
+	if err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM screening_calls
+			WHERE candidate_user_id = $1 AND status = 'CONFIRMED'
+			  AND scheduled_at < $3
+			  AND scheduled_at + make_interval(mins => duration_minutes) > $2
+		)`, c.CandidateUserID, c.ScheduledAt, c.ScheduledAt.Add(time.Duration(c.DurationMinutes)*time.Minute),
+	).Scan(&overlaps); err != nil {
+		return err
+	}
+	if overlaps {
+		return domain.ErrScreeningCallOverlap
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO screening_calls (candidate_user_id, company_id, application_id, booked_by, scheduled_at, duration_minutes, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at, updated_at`,
+		c.CandidateUserID, c.CompanyID, c.ApplicationID, c.BookedBy, c.ScheduledAt, c.DurationMinutes, c.Notes,
+	).Scan(&c.ID, &c.Status, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	activity, err := screeningCallActivityEntry(c, domain.ActivityScreeningCallScheduled, c.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if err := insertCandidateActivity(ctx, tx, activity); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *screeningCallRepo) Get(ctx context.Context, id int64) (*domain.ScreeningCall, error) {
+	var c domain.ScreeningCall
+	if err := scanScreeningCall(r.db.QueryRow(ctx, screeningCallSelect+` WHERE sc.id = $1`, id), &c); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *screeningCallRepo) List(ctx context.Context, filter domain.ScreeningCallFilter) ([]domain.ScreeningCall, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.CompanyID != 0 {
+		where += fmt.Sprintf(" AND sc.company_id = $%d", argIndex)
+		args = append(args, filter.CompanyID)
+		argIndex++
+	}
+	if filter.CandidateUserID != "" {
+		where += fmt.Sprintf(" AND sc.candidate_user_id = $%d", argIndex)
+		args = append(args, filter.CandidateUserID)
+		argIndex++
+	}
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND sc.status = $%d", argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+
+	order := " ORDER BY sc.scheduled_at DESC, sc.id DESC"
+	if filter.Upcoming {
+		where += " AND sc.scheduled_at >= NOW()"
+		order = " ORDER BY sc.scheduled_at, sc.id"
+	}
+
+	query := screeningCallSelect + where + order + fmt.Sprintf(" LIMIT $%d", argIndex)
+	args = append(args, filter.Limit)
+	return r.queryCalls(ctx, query, args...)
+}
+
+func (r *screeningCallRepo) ListByCandidate(ctx context.Context, userID string) ([]domain.ScreeningCall, error) {
+	return r.queryCalls(ctx, screeningCallSelect+`
+		WHERE sc.candidate_user_id = $1
+		ORDER BY sc.scheduled_at DESC, sc.id DESC
+		LIMIT 100`, userID)
+}
+
+func (r *screeningCallRepo) ListConfirmedBetween(ctx context.Context, userID string, from, to time.Time) ([]domain.ScreeningCall, error) {
+	return r.queryCalls(ctx, screeningCallSelect+`
+		WHERE sc.candidate_user_id = $1 AND sc.status = 'CONFIRMED'
+		  AND sc.scheduled_at < $3
+		  AND sc.scheduled_at + make_interval(mins => sc.duration_minutes) > $2
+		ORDER BY sc.scheduled_at`, userID, from, to)
+}
+
+func (r *screeningCallRepo) Cancel(ctx context.Context, c *domain.ScreeningCall) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE screening_calls
+		SET status = 'CANCELLED', cancel_reason = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'CONFIRMED'
+		RETURNING status, updated_at`,
+		c.ID, c.CancelReason,
+	).Scan(&c.Status, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+
+	activity, err := screeningCallActivityEntry(c, domain.ActivityScreeningCallCancelled, c.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := insertCandidateActivity(ctx, tx, activity); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *screeningCallRepo) SetOutcome(ctx context.Context, c *domain.ScreeningCall) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE screening_calls
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'CONFIRMED'
+		RETURNING updated_at`,
+		c.ID, c.Status,
+	).Scan(&c.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+func (r *screeningCallRepo) ListDueReminders(ctx context.Context, now, before time.Time, limit int) ([]domain.ScreeningCall, error) {
+	return r.queryCalls(ctx, screeningCallSelect+`
+		WHERE sc.status = 'CONFIRMED' AND sc.reminder_sent_at IS NULL
+		  AND sc.scheduled_at > $1 AND sc.scheduled_at <= $2
+		ORDER BY sc.scheduled_at
+		LIMIT $3`, now, before, limit)
+}
+
+func (r *screeningCallRepo) MarkReminded(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE screening_calls SET reminder_sent_at = $2 WHERE id = $1`, id, at)
+	return err
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type screeningQuestionRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewScreeningQuestionRepository creates a new screening question repository
+func NewScreeningQuestionRepository(db *pgxpool.Pool) domain.ScreeningQuestionRepository {
+	return &screeningQuestionRepo{db: db}
+}
+
+// ListByJobID returns a job's questions in display order
+func (r *screeningQuestionRepo) ListByJobID(ctx context.Context, jobID int64) ([]domain.ScreeningQuestion, error) {
+	query := `
+		SELECT id, job_id, position, question_type, prompt, options, is_required, knockout_answers
+		FROM job_screening_questions
+		WHERE job_id = $1
+		ORDER BY position`
+
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions := []domain.ScreeningQuestion{}
+	for rows.Next() {
+		var q domain.ScreeningQuestion
+		if err := rows.Scan(
+			&q.ID, &q.JobID, &q.Position, &q.Type, &q.Prompt, &q.Options, &q.Required, &q.KnockoutAnswers,
+		); err != nil {
+			return nil, err
+		}
+		questions = append(questions, q)
+	}
+	return questions, rows.Err()
+}
+
+// ReplaceForJob deletes the job's questions and inserts the new set.
+// Existing answers keep their prompt snapshot; their question_id is cleared.
+func (r *screeningQuestionRepo) ReplaceForJob(ctx context.Context, jobID int64, questions []domain.ScreeningQuestion) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM job_screening_questions WHERE job_id = $1`, jobID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO job_screening_questions (job_id, position, question_type, prompt, options, is_required, knockout_answers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+	for i := range questions {
+		q := &questions[i]
+		q.JobID = jobID
+		options, knockouts := q.Options, q.KnockoutAnswers
+		if options == nil {
+			options = []string{}
+		}
+		if knockouts == nil {
+			knockouts = []string{}
+		}
+		if err := tx.QueryRow(ctx, query,
+			q.JobID, q.Position, q.Type, q.Prompt, options, q.Required, knockouts,
+		).Scan(&q.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
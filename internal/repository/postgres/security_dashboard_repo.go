@@ -3,12 +3,14 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/security"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -115,6 +117,14 @@ func (r *SecurityDashboardRepository) GetStats(ctx context.Context) (*domain.Sec
 		stats.ActiveBreakGlass = 0
 	}
 
+	// Unresolved anomaly alerts
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM security_alerts WHERE status <> 'RESOLVED'
+	`).Scan(&stats.UnresolvedAlerts)
+	if err != nil {
+		stats.UnresolvedAlerts = 0
+	}
+
 	// Top IPs
 	topIPQuery := `
 		SELECT ip_address::text, COUNT(*) as event_count,
@@ -164,9 +174,8 @@ func (r *SecurityDashboardRepository) GetStats(ctx context.Context) (*domain.Sec
 	return stats, nil
 }
 
-// ListEvents returns filtered security events
-func (r *SecurityDashboardRepository) ListEvents(ctx context.Context, filter domain.SecurityEventFilter) ([]domain.SecurityEventView, int64, error) {
-	baseQuery := `
+// securityEventColumns select a SecurityEventView, scanned by scanSecurityEvents
+const securityEventColumns = `
 		SELECT id, created_at, event_type, 
 		       COALESCE(severity::text, 'UNKNOWN'), 
 		       COALESCE(subject_type, ''), 
@@ -174,69 +183,95 @@ func (r *SecurityDashboardRepository) ListEvents(ctx context.Context, filter dom
 		       COALESCE(ip_address::text, ''),
 		       COALESCE(user_agent, ''),
 		       COALESCE(request_id, ''),
-		       COALESCE(details, '{}'::jsonb)
-		FROM security_events
-		WHERE 1=1
-	`
-	countQuery := `SELECT COUNT(*) FROM security_events WHERE 1=1`
+		       COALESCE(details, '{}'::jsonb),
+		       COALESCE(ua_browser, ''), COALESCE(ua_os, ''), ua_device_class, ua_scanner
+		FROM security_events`
 
+// securityEventConditions builds the WHERE clause of a filter (starting with
+// "WHERE 1=1") and its arguments
+func securityEventConditions(filter domain.SecurityEventFilter) (string, []interface{}) {
+	where := " WHERE 1=1"
 	args := []interface{}{}
 	argIndex := 1
 
-	// Build WHERE clauses
 	if filter.StartTime != nil {
-		baseQuery += fmt.Sprintf(" AND created_at >= $%d", argIndex)
-		countQuery += fmt.Sprintf(" AND created_at >= $%d", argIndex)
+		where += fmt.Sprintf(" AND created_at >= $%d", argIndex)
 		args = append(args, *filter.StartTime)
 		argIndex++
 	}
 	if filter.EndTime != nil {
-		baseQuery += fmt.Sprintf(" AND created_at <= $%d", argIndex)
-		countQuery += fmt.Sprintf(" AND created_at <= $%d", argIndex)
+		where += fmt.Sprintf(" AND created_at <= $%d", argIndex)
 		args = append(args, *filter.EndTime)
 		argIndex++
 	}
 	if len(filter.EventTypes) > 0 {
-		baseQuery += fmt.Sprintf(" AND event_type = ANY($%d)", argIndex)
-		countQuery += fmt.Sprintf(" AND event_type = ANY($%d)", argIndex)
+		where += fmt.Sprintf(" AND event_type = ANY($%d)", argIndex)
 		args = append(args, filter.EventTypes)
 		argIndex++
 	}
 	if len(filter.Severities) > 0 {
-		baseQuery += fmt.Sprintf(" AND severity::text = ANY($%d)", argIndex)
-		countQuery += fmt.Sprintf(" AND severity::text = ANY($%d)", argIndex)
+		where += fmt.Sprintf(" AND severity::text = ANY($%d)", argIndex)
 		args = append(args, filter.Severities)
 		argIndex++
 	}
 	if filter.SearchIP != "" {
-		baseQuery += fmt.Sprintf(" AND ip_address::text LIKE $%d", argIndex)
-		countQuery += fmt.Sprintf(" AND ip_address::text LIKE $%d", argIndex)
+		where += fmt.Sprintf(" AND ip_address::text LIKE $%d", argIndex)
 		args = append(args, filter.SearchIP+"%")
 		argIndex++
 	}
 	if filter.SearchUser != "" {
-		baseQuery += fmt.Sprintf(" AND subject_value ILIKE $%d", argIndex)
-		countQuery += fmt.Sprintf(" AND subject_value ILIKE $%d", argIndex)
+		where += fmt.Sprintf(" AND subject_value ILIKE $%d", argIndex)
 		args = append(args, "%"+filter.SearchUser+"%")
 		argIndex++
 	}
+	if len(filter.DeviceClasses) > 0 {
+		where += fmt.Sprintf(" AND ua_device_class = ANY($%d)", argIndex)
+		args = append(args, filter.DeviceClasses)
+	}
+	return where, args
+}
+
+// ListEvents returns filtered security events
+func (r *SecurityDashboardRepository) ListEvents(ctx context.Context, filter domain.SecurityEventFilter) ([]domain.SecurityEventView, int64, error) {
+	where, args := securityEventConditions(filter)
 
 	// Get total count
 	var total int64
-	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM security_events`+where, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count events: %w", err)
 	}
 
 	// Add ordering and pagination
-	baseQuery += " ORDER BY created_at DESC"
-	baseQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	query := securityEventColumns + where + " ORDER BY created_at DESC"
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
 	args = append(args, filter.Limit, filter.Offset)
 
-	// Execute query
-	rows, err := r.db.Query(ctx, baseQuery, args...)
+	events, err := r.queryEvents(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// ListEventsBefore returns up to limit events of the filter with an ID below
+// beforeID (0 starts at the newest), newest first. Unlike offsets, the cursor
+// stays stable while new events are written, so exports can read in chunks.
+func (r *SecurityDashboardRepository) ListEventsBefore(ctx context.Context, filter domain.SecurityEventFilter, beforeID int64, limit int) ([]domain.SecurityEventView, error) {
+	where, args := securityEventConditions(filter)
+	if beforeID > 0 {
+		args = append(args, beforeID)
+		where += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+	args = append(args, limit)
+	return r.queryEvents(ctx, securityEventColumns+where+fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args)), args...)
+}
+
+// queryEvents runs a query selecting securityEventColumns
+func (r *SecurityDashboardRepository) queryEvents(ctx context.Context, query string, args ...interface{}) ([]domain.SecurityEventView, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to query events: %w", err)
+		return nil, fmt.Errorf("failed to query events: %w", err)
 	}
 	defer rows.Close()
 
@@ -244,20 +279,28 @@ func (r *SecurityDashboardRepository) ListEvents(ctx context.Context, filter dom
 	for rows.Next() {
 		var e domain.SecurityEventView
 		var detailsJSON []byte
+		var deviceClass *string
 		if err := rows.Scan(
 			&e.ID, &e.Timestamp, &e.EventType, &e.Severity,
 			&e.SubjectType, &e.SubjectValue, &e.IP, &e.UserAgent,
 			&e.RequestID, &detailsJSON,
+			&e.Client.Browser, &e.Client.OS, &deviceClass, &e.Client.Scanner,
 		); err != nil {
 			continue
 		}
 		if len(detailsJSON) > 0 {
 			json.Unmarshal(detailsJSON, &e.Details)
 		}
+		if deviceClass != nil {
+			e.Client.DeviceClass = *deviceClass
+		} else {
+			// Written before user agents were parsed at write time
+			e.Client = security.ParseUserAgent(e.UserAgent)
+		}
 		events = append(events, e)
 	}
 
-	return events, total, nil
+	return events, rows.Err()
 }
 
 // GetAuthFailureHeatmap returns time-bucketed auth failure counts
@@ -408,21 +451,41 @@ func (r *SecurityDashboardRepository) CreateExportRequest(ctx context.Context, u
 func (r *SecurityDashboardRepository) GetExportRequest(ctx context.Context, exportID string) (*domain.ExportRequest, error) {
 	query := `
 		SELECT id, requested_by, created_at, justification, status,
-		       approved_by, approved_at, download_count, download_expires_at
+		       approved_by, approved_at, download_count, download_expires_at,
+		       filter_start_time, filter_end_time,
+		       COALESCE(filter_event_types, '{}'),
+		       COALESCE(filter_severity::text[], '{}'),
+		       COALESCE(filter_ip, ''), COALESCE(filter_subject, ''),
+		       approved_start_time, approved_end_time,
+		       COALESCE(approved_event_types, '{}'),
+		       COALESCE(approved_severity::text[], '{}'),
+		       COALESCE(approved_ip, ''), COALESCE(approved_subject, ''),
+		       redact_ips
 		FROM export_requests
 		WHERE id = $1
 	`
 
 	export := &domain.ExportRequest{}
+	var approved domain.SecurityEventFilter
 	err := r.db.QueryRow(ctx, query, exportID).Scan(
 		&export.ID, &export.RequestedBy, &export.RequestedAt,
 		&export.Justification, &export.Status,
 		&export.ApprovedBy, &export.ApprovedAt,
 		&export.DownloadCount, &export.DownloadExpires,
+		&export.Filter.StartTime, &export.Filter.EndTime,
+		&export.Filter.EventTypes, &export.Filter.Severities,
+		&export.Filter.SearchIP, &export.Filter.SearchUser,
+		&approved.StartTime, &approved.EndTime,
+		&approved.EventTypes, &approved.Severities,
+		&approved.SearchIP, &approved.SearchUser,
+		&export.RedactIPs,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("export request not found: %w", err)
 	}
+	if export.Status == "approved" {
+		export.ApprovedFilter = &approved
+	}
 
 	return export, nil
 }
@@ -458,17 +521,27 @@ func (r *SecurityDashboardRepository) ListExportRequests(ctx context.Context, st
 	return exports, total, nil
 }
 
-// ApproveExportRequest approves an export request
-func (r *SecurityDashboardRepository) ApproveExportRequest(ctx context.Context, exportID, approverID string) error {
+// ApproveExportRequest approves an export request with the scope the approver granted
+func (r *SecurityDashboardRepository) ApproveExportRequest(ctx context.Context, exportID, approverID string, approved domain.SecurityEventFilter, redactIPs bool) error {
 	query := `
 		UPDATE export_requests 
 		SET status = 'approved', 
 		    approved_by = $2, 
 		    approved_at = NOW(),
-		    download_expires_at = NOW() + INTERVAL '24 hours'
+		    download_expires_at = NOW() + INTERVAL '24 hours',
+		    approved_start_time = $3,
+		    approved_end_time = $4,
+		    approved_event_types = $5,
+		    approved_severity = $6::text[]::security_severity[],
+		    approved_ip = $7,
+		    approved_subject = $8,
+		    redact_ips = $9
 		WHERE id = $1 AND status = 'pending'
 	`
-	result, err := r.db.Exec(ctx, query, exportID, approverID)
+	result, err := r.db.Exec(ctx, query, exportID, approverID,
+		approved.StartTime, approved.EndTime, approved.EventTypes, approved.Severities,
+		approved.SearchIP, approved.SearchUser, redactIPs,
+	)
 	if err != nil {
 		return err
 	}
@@ -505,6 +578,77 @@ func (r *SecurityDashboardRepository) IncrementDownloadCount(ctx context.Context
 	return err
 }
 
+// CreateExportArtifact registers a pending artifact for an export format.
+// The bool is true when the caller should generate it: either no artifact
+// existed yet, or a previous attempt failed or was abandoned (e.g. the server
+// restarted mid-run) and has been reset.
+func (r *SecurityDashboardRepository) CreateExportArtifact(ctx context.Context, exportID, format string) (*domain.ExportArtifact, bool, error) {
+	query := `
+		INSERT INTO security_export_artifacts (export_id, format)
+		VALUES ($1, $2)
+		ON CONFLICT (export_id, format) DO UPDATE
+		SET status = 'pending', error_message = NULL, created_at = NOW(), completed_at = NULL
+		WHERE security_export_artifacts.status = 'failed'
+		   OR (security_export_artifacts.status IN ('pending', 'processing')
+		       AND security_export_artifacts.created_at < NOW() - INTERVAL '1 hour')
+		RETURNING ` + exportArtifactColumns
+
+	artifact, err := scanExportArtifact(r.db.QueryRow(ctx, query, exportID, format))
+	if err == nil {
+		return artifact, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+
+	// Already pending, processing or ready
+	artifact, err = r.GetExportArtifact(ctx, exportID, format)
+	return artifact, false, err
+}
+
+// GetExportArtifact returns the artifact for an export format
+func (r *SecurityDashboardRepository) GetExportArtifact(ctx context.Context, exportID, format string) (*domain.ExportArtifact, error) {
+	query := `SELECT ` + exportArtifactColumns + ` FROM security_export_artifacts WHERE export_id = $1 AND format = $2`
+	artifact, err := scanExportArtifact(r.db.QueryRow(ctx, query, exportID, format))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return artifact, nil
+}
+
+// UpdateExportArtifact persists the worker's progress
+func (r *SecurityDashboardRepository) UpdateExportArtifact(ctx context.Context, artifact *domain.ExportArtifact) error {
+	query := `
+		UPDATE security_export_artifacts
+		SET status = $3, object_key = NULLIF($4, ''), row_count = $5, size_bytes = $6,
+		    error_message = $7, completed_at = $8
+		WHERE export_id = $1 AND format = $2
+	`
+	_, err := r.db.Exec(ctx, query,
+		artifact.ExportID, artifact.Format, artifact.Status, artifact.ObjectKey,
+		artifact.RowCount, artifact.SizeBytes, artifact.ErrorMessage, artifact.CompletedAt,
+	)
+	return err
+}
+
+const exportArtifactColumns = `export_id, format, status, COALESCE(object_key, ''), row_count, size_bytes,
+		error_message, created_at, completed_at`
+
+func scanExportArtifact(row pgx.Row) (*domain.ExportArtifact, error) {
+	a := &domain.ExportArtifact{}
+	err := row.Scan(
+		&a.ExportID, &a.Format, &a.Status, &a.ObjectKey, &a.RowCount, &a.SizeBytes,
+		&a.ErrorMessage, &a.CreatedAt, &a.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
 // GetLastAnchor returns the most recent hash anchor
 func (r *SecurityDashboardRepository) GetLastAnchor(ctx context.Context) (*security.HashAnchor, error) {
 	query := `
@@ -552,3 +696,330 @@ func (r *SecurityDashboardRepository) ListAnchors(ctx context.Context, limit, of
 
 	return anchors, total, nil
 }
+
+// RecordAnchorVerification stamps every anchor in the range with the verification outcome
+func (r *SecurityDashboardRepository) RecordAnchorVerification(ctx context.Context, startDate, endDate time.Time, failedDates []string) error {
+	if failedDates == nil {
+		failedDates = []string{}
+	}
+	_, err := r.db.Exec(ctx, `
+		UPDATE hash_anchors
+		SET verified_at = NOW(),
+		    verification_status = CASE WHEN anchor_date = ANY($3::TEXT[]::DATE[]) THEN 'failed' ELSE 'verified' END
+		WHERE anchor_date BETWEEN $1::DATE AND $2::DATE
+	`, startDate, endDate, failedDates)
+	return err
+}
+
+// CreateIntegrityRun records a verification run
+func (r *SecurityDashboardRepository) CreateIntegrityRun(ctx context.Context, run *domain.IntegrityVerificationRun) error {
+	failedDates := run.FailedDates
+	if failedDates == nil {
+		failedDates = []string{}
+	}
+	return r.db.QueryRow(ctx, `
+		INSERT INTO integrity_verification_runs
+			(trigger_source, triggered_by, start_date, end_date, status, total_events, chain_breaks,
+			 anchor_mismatches, missing_anchors, first_break_event_id, failed_dates, error_message, alerted_admins)
+		VALUES ($1, $2, $3::DATE, $4::DATE, $5, $6, $7, $8, $9, $10, $11::TEXT[]::DATE[], $12, $13)
+		RETURNING id, created_at
+	`,
+		run.TriggerSource, run.TriggeredBy, run.StartDate, run.EndDate, run.Status, run.TotalEvents, run.ChainBreaks,
+		run.AnchorMismatches, run.MissingAnchors, run.FirstBreakEventID, failedDates, run.ErrorMessage, run.AlertedAdmins,
+	).Scan(&run.ID, &run.CreatedAt)
+}
+
+// ListIntegrityRuns lists verification runs, newest first
+func (r *SecurityDashboardRepository) ListIntegrityRuns(ctx context.Context, limit, offset int) ([]domain.IntegrityVerificationRun, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM integrity_verification_runs`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, trigger_source, triggered_by::TEXT, start_date, end_date, status, total_events, chain_breaks,
+		       anchor_mismatches, missing_anchors, first_break_event_id, failed_dates::TEXT[], error_message,
+		       alerted_admins, created_at
+		FROM integrity_verification_runs
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	runs := []domain.IntegrityVerificationRun{}
+	for rows.Next() {
+		var run domain.IntegrityVerificationRun
+		if err := rows.Scan(
+			&run.ID, &run.TriggerSource, &run.TriggeredBy, &run.StartDate, &run.EndDate, &run.Status,
+			&run.TotalEvents, &run.ChainBreaks, &run.AnchorMismatches, &run.MissingAnchors,
+			&run.FirstBreakEventID, &run.FailedDates, &run.ErrorMessage, &run.AlertedAdmins, &run.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, total, rows.Err()
+}
+
+// ListSecurityAdminEmails returns the email addresses of active security admins
+func (r *SecurityDashboardRepository) ListSecurityAdminEmails(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT email FROM security_users
+		WHERE role = 'SECURITY_ADMIN' AND is_active = TRUE
+		ORDER BY email
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+	return emails, rows.Err()
+}
+
+// GetLastAnchorHeartbeat returns the most recent anchoring heartbeat
+func (r *SecurityDashboardRepository) GetLastAnchorHeartbeat(ctx context.Context) (*domain.AnchorHeartbeat, error) {
+	hb := &domain.AnchorHeartbeat{}
+	err := r.db.QueryRow(ctx, `
+		SELECT id, anchor_date, event_count, created_at
+		FROM anchor_heartbeats
+		ORDER BY created_at DESC
+		LIMIT 1
+	`).Scan(&hb.ID, &hb.AnchorDate, &hb.EventCount, &hb.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return hb, nil
+}
+
+// RecordAnchorHeartbeatAlert claims the alert for the outage after lastHeartbeatID
+func (r *SecurityDashboardRepository) RecordAnchorHeartbeatAlert(ctx context.Context, lastHeartbeatID int64) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO anchor_heartbeat_alerts (last_heartbeat_id) VALUES ($1)
+		ON CONFLICT (last_heartbeat_id) DO NOTHING
+	`, lastHeartbeatID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ListDetectionEvents returns events of the given types since the cutoff, oldest first
+func (r *SecurityDashboardRepository) ListDetectionEvents(ctx context.Context, types []security.EventType, since time.Time) ([]security.DetectionEvent, error) {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, event_type, COALESCE(subject_value, ''), COALESCE(host(ip_address), ''), created_at
+		FROM security_events
+		WHERE event_type = ANY($1) AND created_at >= $2
+		ORDER BY created_at, id
+	`, names, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []security.DetectionEvent{}
+	for rows.Next() {
+		var e security.DetectionEvent
+		var eventType string
+		if err := rows.Scan(&e.ID, &eventType, &e.SubjectValue, &e.IP, &e.Timestamp); err != nil {
+			return nil, err
+		}
+		e.Type = security.EventType(eventType)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// UpsertSecurityAlert updates the unresolved alert of the same incident or creates one
+func (r *SecurityDashboardRepository) UpsertSecurityAlert(ctx context.Context, alert *domain.SecurityAlert) (bool, error) {
+	details, err := json.Marshal(alert.Details)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	// The same incident seen again: keep the alert current
+	err = tx.QueryRow(ctx, `
+		UPDATE security_alerts
+		SET event_count = GREATEST(event_count, $3), severity = $4, title = $5, details = $6,
+		    first_seen_at = LEAST(first_seen_at, $7), last_seen_at = GREATEST(last_seen_at, $8), updated_at = NOW()
+		WHERE rule = $1 AND dedup_key = $2 AND status <> 'RESOLVED'
+		RETURNING id
+	`, alert.Rule, alert.DedupKey, alert.EventCount, alert.Severity, alert.Title, details,
+		alert.FirstSeenAt, alert.LastSeenAt).Scan(&alert.ID)
+	if err == nil {
+		return false, tx.Commit(ctx)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
+
+	// New incident, unless a resolved alert already covered these events. A
+	// concurrent run that got there first wins the unique index.
+	err = tx.QueryRow(ctx, `
+		INSERT INTO security_alerts
+			(rule, dedup_key, severity, title, event_count, first_seen_at, last_seen_at, details)
+		SELECT $1, $2, $3, $4, $5::INT, $6::TIMESTAMPTZ, $7::TIMESTAMPTZ, $8::JSONB
+		WHERE NOT EXISTS (
+			SELECT 1 FROM security_alerts
+			WHERE rule = $1 AND dedup_key = $2 AND last_seen_at >= $7
+		)
+		ON CONFLICT (rule, dedup_key) WHERE status <> 'RESOLVED' DO NOTHING
+		RETURNING id, status, created_at, updated_at
+	`, alert.Rule, alert.DedupKey, alert.Severity, alert.Title, alert.EventCount,
+		alert.FirstSeenAt, alert.LastSeenAt, details).Scan(&alert.ID, &alert.Status, &alert.CreatedAt, &alert.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// SetSecurityAlertNotified records how many security admins were alerted
+func (r *SecurityDashboardRepository) SetSecurityAlertNotified(ctx context.Context, alertID int64, notified int) error {
+	_, err := r.db.Exec(ctx, `UPDATE security_alerts SET notified_admins = $2 WHERE id = $1`, alertID, notified)
+	return err
+}
+
+const securityAlertSelect = `
+	SELECT id, rule, dedup_key, severity, title, event_count, first_seen_at, last_seen_at, details,
+	       status, notified_admins, acknowledged_by::TEXT, acknowledged_at, resolved_by::TEXT, resolved_at,
+	       resolution, created_at, updated_at
+	FROM security_alerts`
+
+func scanSecurityAlert(row pgx.Row) (*domain.SecurityAlert, error) {
+	var a domain.SecurityAlert
+	var details []byte
+	if err := row.Scan(
+		&a.ID, &a.Rule, &a.DedupKey, &a.Severity, &a.Title, &a.EventCount, &a.FirstSeenAt, &a.LastSeenAt, &details,
+		&a.Status, &a.NotifiedAdmins, &a.AcknowledgedBy, &a.AcknowledgedAt, &a.ResolvedBy, &a.ResolvedAt,
+		&a.Resolution, &a.CreatedAt, &a.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if len(details) > 0 {
+		json.Unmarshal(details, &a.Details)
+	}
+	return &a, nil
+}
+
+// GetSecurityAlert returns one alert
+func (r *SecurityDashboardRepository) GetSecurityAlert(ctx context.Context, alertID int64) (*domain.SecurityAlert, error) {
+	a, err := scanSecurityAlert(r.db.QueryRow(ctx, securityAlertSelect+` WHERE id = $1`, alertID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	return a, err
+}
+
+// ListSecurityAlerts lists alerts, newest first
+func (r *SecurityDashboardRepository) ListSecurityAlerts(ctx context.Context, filter domain.SecurityAlertFilter) ([]domain.SecurityAlert, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if len(filter.Statuses) > 0 {
+		where += fmt.Sprintf(" AND status = ANY($%d)", argIndex)
+		args = append(args, filter.Statuses)
+		argIndex++
+	}
+	if filter.Rule != "" {
+		where += fmt.Sprintf(" AND rule = $%d", argIndex)
+		args = append(args, filter.Rule)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM security_alerts`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := securityAlertSelect + where +
+		fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	alerts := []domain.SecurityAlert{}
+	for rows.Next() {
+		a, err := scanSecurityAlert(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		alerts = append(alerts, *a)
+	}
+	return alerts, total, rows.Err()
+}
+
+// AcknowledgeSecurityAlert moves an open alert to ACKNOWLEDGED
+func (r *SecurityDashboardRepository) AcknowledgeSecurityAlert(ctx context.Context, alertID int64, userID string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE security_alerts
+		SET status = 'ACKNOWLEDGED', acknowledged_by = $2, acknowledged_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'OPEN'
+	`, alertID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.securityAlertStateError(ctx, alertID)
+	}
+	return nil
+}
+
+// ResolveSecurityAlert closes an open or acknowledged alert
+func (r *SecurityDashboardRepository) ResolveSecurityAlert(ctx context.Context, alertID int64, userID, resolution string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE security_alerts
+		SET status = 'RESOLVED', resolved_by = $2, resolved_at = NOW(), resolution = $3, updated_at = NOW()
+		WHERE id = $1 AND status <> 'RESOLVED'
+	`, alertID, userID, resolution)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.securityAlertStateError(ctx, alertID)
+	}
+	return nil
+}
+
+// securityAlertStateError tells a missing alert from one in the wrong status
+func (r *SecurityDashboardRepository) securityAlertStateError(ctx context.Context, alertID int64) error {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM security_alerts WHERE id = $1)`, alertID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrNotFound
+	}
+	return domain.ErrSecurityAlertState
+}
@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type storageCleanupRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewStorageCleanupRepository creates a new storage cleanup repository
+func NewStorageCleanupRepository(db *pgxpool.Pool) domain.StorageCleanupRepository {
+	return &storageCleanupRepo{db: db}
+}
+
+// ============================================================================
+// Deletion queue
+// ============================================================================
+
+const storageDeletionColumns = `id, bucket, object_path, reason, status, attempts, next_attempt_at, last_error,
+	reclaimed_bytes, sweep_id, created_at, deleted_at`
+
+func scanStorageDeletion(row pgx.Row, d *domain.StorageDeletion) error {
+	return row.Scan(&d.ID, &d.Bucket, &d.ObjectPath, &d.Reason, &d.Status, &d.Attempts, &d.NextAttemptAt, &d.LastError,
+		&d.ReclaimedBytes, &d.SweepID, &d.CreatedAt, &d.DeletedAt)
+}
+
+func (r *storageCleanupRepo) EnqueueDeletion(ctx context.Context, d *domain.StorageDeletion) (bool, error) {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO storage_deletions (bucket, object_path, reason, next_attempt_at, sweep_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $4)
+		ON CONFLICT (bucket, object_path) WHERE status <> 'DELETED' DO NOTHING
+		RETURNING id, status, created_at`,
+		d.Bucket, d.ObjectPath, d.Reason, d.NextAttemptAt, d.SweepID,
+	).Scan(&d.ID, &d.Status, &d.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ClaimDueDeletions leases due deletions with SKIP LOCKED, so instances running
+// the worker at the same time never delete the same object twice
+func (r *storageCleanupRepo) ClaimDueDeletions(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.StorageDeletion, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT id FROM storage_deletions
+			WHERE status = 'PENDING' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE storage_deletions d
+		SET next_attempt_at = $2
+		FROM due
+		WHERE d.id = due.id
+		RETURNING d.id, d.bucket, d.object_path, d.reason, d.status, d.attempts, d.next_attempt_at, d.last_error,
+			d.reclaimed_bytes, d.sweep_id, d.created_at, d.deleted_at`,
+		now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deletions := []domain.StorageDeletion{}
+	for rows.Next() {
+		var d domain.StorageDeletion
+		if err := scanStorageDeletion(rows, &d); err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, d)
+	}
+	return deletions, rows.Err()
+}
+
+func (r *storageCleanupRepo) RecordDeletionAttempt(ctx context.Context, d *domain.StorageDeletion) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE storage_deletions
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, reclaimed_bytes = $6, deleted_at = $7
+		WHERE id = $1`,
+		d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastError, d.ReclaimedBytes, d.DeletedAt,
+	)
+	return err
+}
+
+func (r *storageCleanupRepo) ListDeletions(ctx context.Context, status string, limit, offset int) ([]domain.StorageDeletion, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if status != "" {
+		where += fmt.Sprintf(" AND status = $%d", argIndex)
+		args = append(args, status)
+		argIndex++
+	}
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM storage_deletions`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + storageDeletionColumns + ` FROM storage_deletions` + where +
+		fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deletions := []domain.StorageDeletion{}
+	for rows.Next() {
+		var d domain.StorageDeletion
+		if err := scanStorageDeletion(rows, &d); err != nil {
+			return nil, 0, err
+		}
+		deletions = append(deletions, d)
+	}
+	return deletions, total, rows.Err()
+}
+
+func (r *storageCleanupRepo) GetDeletion(ctx context.Context, id int64) (*domain.StorageDeletion, error) {
+	var d domain.StorageDeletion
+	err := scanStorageDeletion(r.db.QueryRow(ctx, `SELECT `+storageDeletionColumns+` FROM storage_deletions WHERE id = $1`, id), &d)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *storageCleanupRepo) RetryDeletion(ctx context.Context, id int64, at time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE storage_deletions
+		SET status = 'PENDING', attempts = 0, next_attempt_at = $2
+		WHERE id = $1`, id, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ============================================================================
+// File records
+// ============================================================================
+
+func (r *storageCleanupRepo) FindFileByURL(ctx context.Context, publicURL string) (*domain.UploadedFile, error) {
+	query := `SELECT ` + uploadedFileColumns + ` FROM uploaded_files
+		WHERE public_url = $1 AND superseded_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT 1`
+	return scanUploadedFile(r.db.QueryRow(ctx, query, publicURL))
+}
+
+func (r *storageCleanupRepo) SupersedeFile(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE uploaded_files SET superseded_at = $2, updated_at = $2 WHERE id = $1 AND superseded_at IS NULL`, id, at)
+	return err
+}
+
+func (r *storageCleanupRepo) ListLiveURLs(ctx context.Context, bucket string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT public_url FROM uploaded_files
+		WHERE bucket = $1 AND public_url IS NOT NULL AND superseded_at IS NULL`, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	urls := []string{}
+	for rows.Next() {
+		var u string
+		if err := rows.Scan(&u); err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+	return urls, rows.Err()
+}
+
+func (r *storageCleanupRepo) TrackingStartedAt(ctx context.Context) (*time.Time, error) {
+	var startedAt *time.Time
+	if err := r.db.QueryRow(ctx, `SELECT MIN(created_at) FROM uploaded_files`).Scan(&startedAt); err != nil {
+		return nil, err
+	}
+	return startedAt, nil
+}
+
+// ============================================================================
+// Orphan sweeps
+// ============================================================================
+
+const storageSweepColumns = `id, trigger_source, status, buckets_scanned, bucket_errors, objects_scanned, orphans_found,
+	orphan_bytes, error_message, started_at, finished_at`
+
+func scanStorageSweep(row pgx.Row, s *domain.StorageOrphanSweep) error {
+	return row.Scan(&s.ID, &s.TriggerSource, &s.Status, &s.BucketsScanned, &s.BucketErrors, &s.ObjectsScanned, &s.OrphansFound,
+		&s.OrphanBytes, &s.ErrorMessage, &s.StartedAt, &s.FinishedAt)
+}
+
+func (r *storageCleanupRepo) CreateSweep(ctx context.Context, s *domain.StorageOrphanSweep) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO storage_orphan_sweeps (trigger_source, status, started_at)
+		VALUES ($1, $2, $3)
+		RETURNING id`,
+		s.TriggerSource, s.Status, s.StartedAt,
+	).Scan(&s.ID)
+}
+
+func (r *storageCleanupRepo) FinishSweep(ctx context.Context, s *domain.StorageOrphanSweep) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE storage_orphan_sweeps
+		SET status = $2, buckets_scanned = $3, bucket_errors = $4, objects_scanned = $5, orphans_found = $6,
+			orphan_bytes = $7, error_message = $8, finished_at = $9
+		WHERE id = $1`,
+		s.ID, s.Status, s.BucketsScanned, s.BucketErrors, s.ObjectsScanned, s.OrphansFound,
+		s.OrphanBytes, s.ErrorMessage, s.FinishedAt,
+	)
+	return err
+}
+
+func (r *storageCleanupRepo) ListSweeps(ctx context.Context, limit int) ([]domain.StorageOrphanSweep, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+storageSweepColumns+` FROM storage_orphan_sweeps ORDER BY started_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sweeps := []domain.StorageOrphanSweep{}
+	for rows.Next() {
+		var s domain.StorageOrphanSweep
+		if err := scanStorageSweep(rows, &s); err != nil {
+			return nil, err
+		}
+		sweeps = append(sweeps, s)
+	}
+	return sweeps, rows.Err()
+}
+
+// GetSummary totals the reclaimed space overall and since the given time, and
+// counts the deletions still open
+func (r *storageCleanupRepo) GetSummary(ctx context.Context, since time.Time) (*domain.StorageCleanupSummary, error) {
+	summary := &domain.StorageCleanupSummary{}
+	err := r.db.QueryRow(ctx, `
+		SELECT
+			COALESCE(SUM(reclaimed_bytes) FILTER (WHERE status = 'DELETED'), 0),
+			COALESCE(SUM(reclaimed_bytes) FILTER (WHERE status = 'DELETED' AND deleted_at >= $1), 0),
+			COUNT(*) FILTER (WHERE status = 'DELETED'),
+			COUNT(*) FILTER (WHERE status = 'DELETED' AND deleted_at >= $1),
+			COUNT(*) FILTER (WHERE status = 'PENDING'),
+			COUNT(*) FILTER (WHERE status = 'FAILED')
+		FROM storage_deletions`, since,
+	).Scan(&summary.ReclaimedBytes, &summary.ReclaimedBytes30Days, &summary.DeletedObjects, &summary.DeletedObjects30Days,
+		&summary.PendingDeletions, &summary.FailedDeletions)
+	if err != nil {
+		return nil, err
+	}
+
+	var last domain.StorageOrphanSweep
+	err = scanStorageSweep(r.db.QueryRow(ctx, `SELECT `+storageSweepColumns+` FROM storage_orphan_sweeps ORDER BY started_at DESC LIMIT 1`), &last)
+	if err == nil {
+		summary.LastSweep = &last
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return summary, nil
+}
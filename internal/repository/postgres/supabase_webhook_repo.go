@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type supabaseWebhookRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSupabaseWebhookRepository(db *pgxpool.Pool) domain.SupabaseWebhookRepository {
+	return &supabaseWebhookRepo{db: db}
+}
+
+func (r *supabaseWebhookRepo) Claim(ctx context.Context, webhookID, eventType, userID string) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+		INSERT INTO supabase_webhook_events (webhook_id, event_type, user_id)
+		VALUES ($1, $2, NULLIF($3, '')::UUID)
+		ON CONFLICT (webhook_id) DO NOTHING`,
+		webhookID, eventType, userID,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+func (r *supabaseWebhookRepo) Release(ctx context.Context, webhookID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM supabase_webhook_events WHERE webhook_id = $1`, webhookID)
+	return err
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type uploadedFileRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewUploadedFileRepository(db *pgxpool.Pool) domain.UploadedFileRepository {
+	return &uploadedFileRepo{db: db}
+}
+
+const uploadedFileColumns = `id, user_id, bucket, original_filename, content_type, size_bytes,
+	status, public_url, error_message, created_at, updated_at, completed_at`
+
+func scanUploadedFile(row pgx.Row) (*domain.UploadedFile, error) {
+	var f domain.UploadedFile
+	err := row.Scan(
+		&f.ID, &f.UserID, &f.Bucket, &f.OriginalFilename, &f.ContentType, &f.SizeBytes,
+		&f.Status, &f.PublicURL, &f.ErrorMessage, &f.CreatedAt, &f.UpdatedAt, &f.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *uploadedFileRepo) Create(ctx context.Context, f *domain.UploadedFile) error {
+	query := `
+		INSERT INTO uploaded_files (user_id, bucket, original_filename, content_type, size_bytes, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		f.UserID, f.Bucket, f.OriginalFilename, f.ContentType, f.SizeBytes, f.Status,
+	).Scan(&f.ID, &f.CreatedAt, &f.UpdatedAt)
+}
+
+func (r *uploadedFileRepo) GetByID(ctx context.Context, id string) (*domain.UploadedFile, error) {
+	query := `SELECT ` + uploadedFileColumns + ` FROM uploaded_files WHERE id = $1`
+	return scanUploadedFile(r.db.QueryRow(ctx, query, id))
+}
+
+// UpdateStatus moves a file to a new status. Terminal statuses also stamp completed_at.
+func (r *uploadedFileRepo) UpdateStatus(ctx context.Context, id, status string, publicURL, errorMessage *string) (*domain.UploadedFile, error) {
+	query := `
+		UPDATE uploaded_files
+		SET status = $2,
+		    public_url = COALESCE($3, public_url),
+		    error_message = $4,
+		    updated_at = NOW(),
+		    completed_at = CASE WHEN $2 IN ('ready', 'failed') THEN NOW() ELSE NULL END
+		WHERE id = $1
+		RETURNING ` + uploadedFileColumns
+	return scanUploadedFile(r.db.QueryRow(ctx, query, id, status, publicURL, errorMessage))
+}
+
+func (r *uploadedFileRepo) FindOwnerByURL(ctx context.Context, fileURL string) (string, error) {
+	query := `
+		SELECT owner FROM (
+			SELECT user_id::text AS owner, 1 AS source FROM uploaded_files WHERE public_url = $1
+			UNION ALL
+			SELECT user_id::text, 2 FROM account_verifications WHERE cv_url = $1 OR japanese_certificate_url = $1 OR guardian_consent_url = $1
+			UNION ALL
+			SELECT candidate_user_id::text, 3 FROM applications WHERE cv_url = $1
+			UNION ALL
+			SELECT user_id::text, 4 FROM candidate_documents WHERE file_url = $1
+		) owners
+		ORDER BY source
+		LIMIT 1
+	`
+	var owner string
+	if err := r.db.QueryRow(ctx, query, fileURL).Scan(&owner); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", err
+	}
+	return owner, nil
+}
+
+func (r *uploadedFileRepo) CompanyCanAccessUser(ctx context.Context, companyID int64, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM account_verifications av
+			WHERE av.user_id = $1
+				AND (` + candidateVisibleExpr(2) + ` OR ` + appliedToCompanyExpr(2) + ` OR EXISTS (
+					SELECT 1 FROM candidate_contact_reveals cr WHERE cr.candidate_user_id = av.user_id AND cr.company_id = $2
+				))
+		)
+	`
+	var ok bool
+	err := r.db.QueryRow(ctx, query, userID, companyID).Scan(&ok)
+	return ok, err
+}
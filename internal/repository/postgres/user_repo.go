@@ -3,9 +3,10 @@ package postgres
 import (
 	"context"
 	"errors"
-	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -40,26 +41,30 @@ func (r *userRepo) Create(ctx context.Context, user *domain.User) error {
 }
 
 func (r *userRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
-	query := `SELECT id, email, role, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT id, email, role, paused_at, paused_until, pause_reason, preferred_locale, email_confirmed_at, created_at, updated_at FROM users WHERE id = $1`
 	var user domain.User
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&user.ID, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.Role, &user.PausedAt, &user.PausedUntil, &user.PauseReason, &user.PreferredLocale,
+		&user.EmailConfirmedAt, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+	user.ProfilePaused = user.IsPaused(time.Now())
 	return &user, nil
 }
 
 func (r *userRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	query := `SELECT id, email, role, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT id, email, role, paused_at, paused_until, pause_reason, preferred_locale, email_confirmed_at, created_at, updated_at FROM users WHERE email = $1`
 	var user domain.User
 	err := r.db.QueryRow(ctx, query, email).Scan(
-		&user.ID, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.Role, &user.PausedAt, &user.PausedUntil, &user.PauseReason, &user.PreferredLocale,
+		&user.EmailConfirmedAt, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+	user.ProfilePaused = user.IsPaused(time.Now())
 	return &user, nil
 }
 
@@ -69,14 +74,50 @@ func (r *userRepo) Update(ctx context.Context, user *domain.User) error {
 	return err
 }
 
+// SetPause sets or clears (until == nil) the profile pause window for a user.
+func (r *userRepo) SetPause(ctx context.Context, userID string, until *time.Time, reason *string) error {
+	query := `
+		UPDATE users
+		SET paused_at = CASE WHEN $2::timestamptz IS NULL THEN NULL ELSE NOW() END,
+		    paused_until = $2,
+		    pause_reason = $3,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+	result, err := r.db.Exec(ctx, query, userID, until, reason)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// SetPreferredLocale sets or clears (locale == nil) the user's language preference.
+func (r *userRepo) SetPreferredLocale(ctx context.Context, userID string, locale *string) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE users SET preferred_locale = $2, updated_at = NOW() WHERE id = $1`,
+		userID, locale,
+	)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
 // UpdateByEmail updates a user record by email, including changing the ID.
 // This is used when user's Supabase ID changes (e.g., account recreation).
 func (r *userRepo) UpdateByEmail(ctx context.Context, email string, user *domain.User) error {
-	fmt.Printf("[DEBUG UpdateByEmail] Starting for email: %s, new ID: %s\n", email, user.ID)
+	l := logger.FromContext(ctx).With("op", "UpdateByEmail", "new_user_id", user.ID)
+	l.Debug("Re-keying user by email")
 
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		fmt.Printf("[DEBUG UpdateByEmail] Failed to begin transaction: %v\n", err)
+		l.Error("Failed to begin transaction", "error", err)
 		return err
 	}
 	defer tx.Rollback(ctx)
@@ -85,10 +126,10 @@ func (r *userRepo) UpdateByEmail(ctx context.Context, email string, user *domain
 	var oldID string
 	err = tx.QueryRow(ctx, "SELECT id FROM users WHERE email = $1", email).Scan(&oldID)
 	if err != nil {
-		fmt.Printf("[DEBUG UpdateByEmail] Failed to get old ID: %v\n", err)
+		l.Error("Failed to get old ID", "error", err)
 		return apperror.Internal(err)
 	}
-	fmt.Printf("[DEBUG UpdateByEmail] Old ID: %s\n", oldID)
+	l = l.With("old_user_id", oldID)
 
 	// 2. Update users table
 	// This will automatically cascade to company_profiles, candidate_profiles, etc.
@@ -96,10 +137,10 @@ func (r *userRepo) UpdateByEmail(ctx context.Context, email string, user *domain
 	query := `UPDATE users SET id = $1, role = $2, updated_at = $3 WHERE email = $4`
 	_, err = tx.Exec(ctx, query, user.ID, user.Role, user.UpdatedAt, email)
 	if err != nil {
-		fmt.Printf("[DEBUG UpdateByEmail] Failed to update users table: %v\n", err)
+		l.Error("Failed to update users table", "error", err)
 		return apperror.Internal(err)
 	}
-	fmt.Printf("[DEBUG UpdateByEmail] Updated users table successfully\n")
+	l.Debug("Updated users table")
 
 	// 3. Manually update tables without Foreign Key constraints (candidate onboarding data)
 	// These tables use user_id as TEXT and don't cascade automatically
@@ -107,22 +148,49 @@ func (r *userRepo) UpdateByEmail(ctx context.Context, email string, user *domain
 	// candidate_interests
 	_, err = tx.Exec(ctx, "UPDATE candidate_interests SET user_id = $1 WHERE user_id = $2", user.ID, oldID)
 	if err != nil {
-		fmt.Printf("[DEBUG UpdateByEmail] Failed to update candidate_interests: %v\n", err)
+		l.Error("Failed to update candidate_interests", "error", err)
 		return apperror.Internal(err)
 	}
 
 	// candidate_company_preferences
 	_, err = tx.Exec(ctx, "UPDATE candidate_company_preferences SET user_id = $1 WHERE user_id = $2", user.ID, oldID)
 	if err != nil {
-		fmt.Printf("[DEBUG UpdateByEmail] Failed to update candidate_company_preferences: %v\n", err)
+		l.Error("Failed to update candidate_company_preferences", "error", err)
 		return apperror.Internal(err)
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		fmt.Printf("[DEBUG UpdateByEmail] Failed to commit transaction: %v\n", err)
+		l.Error("Failed to commit transaction", "error", err)
 		return apperror.Internal(err)
 	}
-	fmt.Printf("[DEBUG UpdateByEmail] Transaction committed successfully!\n")
+	l.Debug("User re-keyed")
+	return nil
+}
+
+// SetEmailConfirmed records when the user confirmed their email address
+func (r *userRepo) SetEmailConfirmed(ctx context.Context, userID string, at time.Time) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE users SET email_confirmed_at = $2, updated_at = NOW() WHERE id = $1`,
+		userID, at,
+	)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes a user; ErrNotFound when there is none
+func (r *userRepo) Delete(ctx context.Context, userID string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
 	return nil
 }
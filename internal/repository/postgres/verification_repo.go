@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"go-recruitment-backend/internal/domain"
-	"log"
+	"go-recruitment-backend/pkg/logger"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -30,7 +30,11 @@ func (r *verificationRepo) GetByUserID(ctx context.Context, userID string) (*dom
 			main_job_fields, golden_skill, japanese_speaking_level,
 			expected_salary, japan_return_date, available_start_date, preferred_locations, preferred_industries,
 			supporting_certificates_url, gender,
-			height_cm, weight_kg, religion, jlpt_certificate_issue_year, willing_to_interview_onsite
+			height_cm, weight_kg, religion, jlpt_certificate_issue_year, willing_to_interview_onsite,
+			coe_status, coe_issued_date, coe_expiry_date, ssw_exam_results,
+			passport_url, passport_expiry_date, jlpt_certificate_expiry_date, medical_check_url, medical_check_expiry_date,
+			emergency_contact_name, emergency_contact_phone, emergency_contact_relationship, guardian_consent_url,
+			domicile_province
 		FROM account_verifications
 		WHERE user_id = $1
 	`
@@ -43,6 +47,10 @@ func (r *verificationRepo) GetByUserID(ctx context.Context, userID string) (*dom
 		&v.ExpectedSalary, &v.JapanReturnDate, &v.AvailableStartDate, &v.PreferredLocations, &v.PreferredIndustries,
 		&v.SupportingCertificatesURL, &v.Gender,
 		&v.HeightCm, &v.WeightKg, &v.Religion, &v.JLPTCertificateIssueYear, &v.WillingToInterviewOnsite,
+		&v.CoEStatus, &v.CoEIssuedDate, &v.CoEExpiryDate, &v.SSWExamResults,
+		&v.PassportURL, &v.PassportExpiryDate, &v.JLPTCertificateExpiryDate, &v.MedicalCheckURL, &v.MedicalCheckExpiryDate,
+		&v.EmergencyContactName, &v.EmergencyContactPhone, &v.EmergencyContactRelationship, &v.GuardianConsentURL,
+		&v.DomicileProvince,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -63,6 +71,10 @@ func (r *verificationRepo) GetByID(ctx context.Context, id int64) (*domain.Accou
 			av.expected_salary, av.japan_return_date, av.available_start_date, av.preferred_locations, av.preferred_industries,
 			av.supporting_certificates_url, av.gender,
 			av.height_cm, av.weight_kg, av.religion, av.jlpt_certificate_issue_year, av.willing_to_interview_onsite,
+			av.coe_status, av.coe_issued_date, av.coe_expiry_date, av.ssw_exam_results,
+			av.passport_url, av.passport_expiry_date, av.jlpt_certificate_expiry_date, av.medical_check_url, av.medical_check_expiry_date,
+			av.emergency_contact_name, av.emergency_contact_phone, av.emergency_contact_relationship, av.guardian_consent_url,
+			av.domicile_province,
 			u.email
 		FROM account_verifications av
 		JOIN users u ON av.user_id = u.id
@@ -77,6 +89,10 @@ func (r *verificationRepo) GetByID(ctx context.Context, id int64) (*domain.Accou
 		&v.ExpectedSalary, &v.JapanReturnDate, &v.AvailableStartDate, &v.PreferredLocations, &v.PreferredIndustries,
 		&v.SupportingCertificatesURL, &v.Gender,
 		&v.HeightCm, &v.WeightKg, &v.Religion, &v.JLPTCertificateIssueYear, &v.WillingToInterviewOnsite,
+		&v.CoEStatus, &v.CoEIssuedDate, &v.CoEExpiryDate, &v.SSWExamResults,
+		&v.PassportURL, &v.PassportExpiryDate, &v.JLPTCertificateExpiryDate, &v.MedicalCheckURL, &v.MedicalCheckExpiryDate,
+		&v.EmergencyContactName, &v.EmergencyContactPhone, &v.EmergencyContactRelationship, &v.GuardianConsentURL,
+		&v.DomicileProvince,
 		&v.UserEmail,
 	)
 	if err != nil {
@@ -96,6 +112,10 @@ func (r *verificationRepo) List(ctx context.Context, filter domain.VerificationF
 			av.expected_salary, av.japan_return_date, av.available_start_date, av.preferred_locations, av.preferred_industries,
 			av.supporting_certificates_url, av.gender,
 			av.height_cm, av.weight_kg, av.religion, av.jlpt_certificate_issue_year, av.willing_to_interview_onsite,
+			av.coe_status, av.coe_issued_date, av.coe_expiry_date, av.ssw_exam_results,
+			av.passport_url, av.passport_expiry_date, av.jlpt_certificate_expiry_date, av.medical_check_url, av.medical_check_expiry_date,
+			av.emergency_contact_name, av.emergency_contact_phone, av.emergency_contact_relationship, av.guardian_consent_url,
+			av.domicile_province,
 			u.email,
 			COALESCE(
 				CASE 
@@ -110,7 +130,7 @@ func (r *verificationRepo) List(ctx context.Context, filter domain.VerificationF
 		LEFT JOIN company_profiles comp ON av.user_id = comp.user_id AND av.role = 'EMPLOYER'
 		WHERE 1=1
 	`
-	countQuery := `SELECT COUNT(*) FROM account_verifications WHERE 1=1`
+	countQuery := `SELECT COUNT(*) FROM account_verifications av WHERE 1=1`
 
 	args := []interface{}{}
 	argCounter := 1
@@ -129,6 +149,14 @@ func (r *verificationRepo) List(ctx context.Context, filter domain.VerificationF
 		argCounter++
 	}
 
+	if filter.ScopeProvinces != nil {
+		condition := " AND " + verificationScopeCondition(argCounter)
+		baseQuery += condition
+		countQuery += condition
+		args = append(args, filter.ScopeProvinces)
+		argCounter++
+	}
+
 	// Count total
 	var total int64
 	err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total)
@@ -160,17 +188,21 @@ func (r *verificationRepo) List(ctx context.Context, filter domain.VerificationF
 			&v.ExpectedSalary, &v.JapanReturnDate, &v.AvailableStartDate, &v.PreferredLocations, &v.PreferredIndustries,
 			&v.SupportingCertificatesURL, &v.Gender,
 			&v.HeightCm, &v.WeightKg, &v.Religion, &v.JLPTCertificateIssueYear, &v.WillingToInterviewOnsite,
+			&v.CoEStatus, &v.CoEIssuedDate, &v.CoEExpiryDate, &v.SSWExamResults,
+			&v.PassportURL, &v.PassportExpiryDate, &v.JLPTCertificateExpiryDate, &v.MedicalCheckURL, &v.MedicalCheckExpiryDate,
+			&v.EmergencyContactName, &v.EmergencyContactPhone, &v.EmergencyContactRelationship, &v.GuardianConsentURL,
+			&v.DomicileProvince,
 			&v.UserEmail, &profileName,
 		)
 		if err != nil {
-			log.Printf("ERROR scanning verification row: %v", err)
+			logger.FromContext(ctx).Error("Failed to scan verification row", "error", err)
 			continue // Log and continue to diagnose the issue
 		}
 
 		v.UserProfile = &domain.UserProfileSummary{
 			Name: profileName,
 		}
-		if v.Role == "EMPLOYER" {
+		if v.Role == domain.VerificationRoleEmployer {
 			v.UserProfile.CompanyName = profileName
 		}
 
@@ -203,8 +235,12 @@ func (r *verificationRepo) Create(ctx context.Context, v *domain.AccountVerifica
 			main_job_fields, golden_skill, japanese_speaking_level,
 			expected_salary, japan_return_date, available_start_date, preferred_locations, preferred_industries,
 			supporting_certificates_url, gender,
-			height_cm, weight_kg, religion, jlpt_certificate_issue_year, willing_to_interview_onsite
-		) VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36)
+			height_cm, weight_kg, religion, jlpt_certificate_issue_year, willing_to_interview_onsite,
+			coe_status, coe_issued_date, coe_expiry_date, ssw_exam_results,
+			passport_url, passport_expiry_date, jlpt_certificate_expiry_date, medical_check_url, medical_check_expiry_date,
+			emergency_contact_name, emergency_contact_phone, emergency_contact_relationship, guardian_consent_url,
+			domicile_province
+		) VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, COALESCE($40::jsonb, '[]'::jsonb), $41, $42, $43, $44, $45, $46, $47, $48, $49, $50)
 		RETURNING id
 	`
 	var id int64
@@ -216,6 +252,10 @@ func (r *verificationRepo) Create(ctx context.Context, v *domain.AccountVerifica
 		v.ExpectedSalary, v.JapanReturnDate, v.AvailableStartDate, v.PreferredLocations, v.PreferredIndustries,
 		v.SupportingCertificatesURL, v.Gender,
 		v.HeightCm, v.WeightKg, v.Religion, v.JLPTCertificateIssueYear, v.WillingToInterviewOnsite,
+		v.CoEStatus, v.CoEIssuedDate, v.CoEExpiryDate, v.SSWExamResults,
+		v.PassportURL, v.PassportExpiryDate, v.JLPTCertificateExpiryDate, v.MedicalCheckURL, v.MedicalCheckExpiryDate,
+		v.EmergencyContactName, v.EmergencyContactPhone, v.EmergencyContactRelationship, v.GuardianConsentURL,
+		v.DomicileProvince,
 	).Scan(&id)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create verification: %w", err)
@@ -267,7 +307,21 @@ func (r *verificationRepo) UpdateProfile(ctx context.Context, v *domain.AccountV
 			weight_kg = $32,
 			religion = $33,
 			jlpt_certificate_issue_year = $34,
-			willing_to_interview_onsite = $35
+			willing_to_interview_onsite = $35,
+			coe_status = $36,
+			coe_issued_date = $37,
+			coe_expiry_date = $38,
+			ssw_exam_results = COALESCE($39::jsonb, '[]'::jsonb),
+			passport_url = $40,
+			passport_expiry_date = $41,
+			jlpt_certificate_expiry_date = $42,
+			medical_check_url = $43,
+			medical_check_expiry_date = $44,
+			emergency_contact_name = $45,
+			emergency_contact_phone = $46,
+			emergency_contact_relationship = $47,
+			guardian_consent_url = $48,
+			domicile_province = $49
 		WHERE id = $1
 	`
 	_, err = tx.Exec(ctx, updateQuery,
@@ -306,6 +360,20 @@ func (r *verificationRepo) UpdateProfile(ctx context.Context, v *domain.AccountV
 		v.Religion,
 		v.JLPTCertificateIssueYear,
 		v.WillingToInterviewOnsite,
+		v.CoEStatus,
+		v.CoEIssuedDate,
+		v.CoEExpiryDate,
+		v.SSWExamResults,
+		v.PassportURL,
+		v.PassportExpiryDate,
+		v.JLPTCertificateExpiryDate,
+		v.MedicalCheckURL,
+		v.MedicalCheckExpiryDate,
+		v.EmergencyContactName,
+		v.EmergencyContactPhone,
+		v.EmergencyContactRelationship,
+		v.GuardianConsentURL,
+		v.DomicileProvince,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update profile: %w", err)
@@ -376,6 +444,18 @@ func (r *verificationRepo) GetWorkExperiences(ctx context.Context, verificationI
 	return experiences, nil
 }
 
+func (r *verificationRepo) HasWorkExperience(ctx context.Context, userID string) (bool, error) {
+	var has bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM work_experiences WHERE user_id = $1)
+		    OR EXISTS (
+		        SELECT 1 FROM japan_work_experiences jwe
+		        JOIN account_verifications av ON av.id = jwe.account_verification_id
+		        WHERE av.user_id = $1
+		    )`, userID).Scan(&has)
+	return has, err
+}
+
 // UpdateSubmittedAt updates the submitted_at timestamp for a user's verification record
 // Called when professional profile is updated to ensure admin sorting works correctly
 func (r *verificationRepo) UpdateSubmittedAt(ctx context.Context, userID string, submittedAt time.Time) error {
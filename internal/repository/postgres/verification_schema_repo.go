@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type verificationSchemaRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewVerificationSchemaRepository creates a new verification field rule repository
+func NewVerificationSchemaRepository(db *pgxpool.Pool) domain.VerificationSchemaRepository {
+	return &verificationSchemaRepo{db: db}
+}
+
+func (r *verificationSchemaRepo) ListOverrides(ctx context.Context, role string) (map[string]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT field, requirement FROM verification_field_rules WHERE role = $1`, role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := map[string]string{}
+	for rows.Next() {
+		var field, requirement string
+		if err := rows.Scan(&field, &requirement); err != nil {
+			return nil, err
+		}
+		overrides[field] = requirement
+	}
+	return overrides, rows.Err()
+}
+
+func (r *verificationSchemaRepo) UpsertOverrides(ctx context.Context, overrides []domain.VerificationFieldOverride) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, o := range overrides {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO verification_field_rules (role, field, requirement, updated_by, updated_at)
+			VALUES ($1, $2, $3, $4, NOW())
+			ON CONFLICT (role, field) DO UPDATE
+			SET requirement = EXCLUDED.requirement, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+			o.Role, o.Field, o.Requirement, o.UpdatedBy,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type warehouseRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewWarehouseRepository creates a new warehouse export repository
+func NewWarehouseRepository(db *pgxpool.Pool) domain.WarehouseRepository {
+	return &warehouseRepo{db: db}
+}
+
+// warehouseSource is where an exported table reads from. Expressions are cast so
+// pgx returns int64, string, time.Time or nil.
+type warehouseSource struct {
+	from    string
+	columns map[string]string // spec column -> SQL expression
+}
+
+var warehouseSources = map[string]warehouseSource{
+	"jobs": {
+		from: "jobs t",
+		columns: map[string]string{
+			"id":               "t.id",
+			"company_id":       "t.company_id",
+			"title":            "t.title",
+			"location":         "t.location",
+			"employment_type":  "t.employment_type",
+			"job_type":         "t.job_type",
+			"experience_level": "t.experience_level",
+			"salary_min":       "ROUND(t.salary_min)::BIGINT",
+			"salary_max":       "ROUND(t.salary_max)::BIGINT",
+			"company_status":   "t.company_status",
+			"created_at":       "t.created_at",
+			"updated_at":       "t.updated_at",
+		},
+	},
+	"applications": {
+		from: "applications t",
+		columns: map[string]string{
+			"id":               "t.id",
+			"job_id":           "t.job_id",
+			"candidate_id":     "t.candidate_user_id::TEXT",
+			"status":           "t.status",
+			"stage":            "t.stage",
+			"auto_rejected":    "t.auto_rejected::TEXT",
+			"stage_changed_at": "t.stage_changed_at",
+			"created_at":       "t.created_at",
+			"updated_at":       "t.updated_at",
+		},
+	},
+	"verifications": {
+		from: "account_verifications t",
+		columns: map[string]string{
+			"id":             "t.id",
+			"user_id":        "t.user_id::TEXT",
+			"role":           "t.role",
+			"status":         "t.status",
+			"gender":         "t.gender::TEXT",
+			"birth_year":     "EXTRACT(YEAR FROM t.birth_date)::BIGINT",
+			"domicile_city":  "t.domicile_city",
+			"japanese_level": "t.japanese_level::TEXT",
+			"lpk_id":         "t.lpk_id::BIGINT",
+			"submitted_at":   "t.submitted_at",
+			"verified_at":    "t.verified_at",
+			"created_at":     "t.created_at",
+			"updated_at":     "t.updated_at",
+		},
+	},
+	"application_stage_events": {
+		from: "application_stage_history t",
+		columns: map[string]string{
+			"id":             "t.id",
+			"application_id": "t.application_id",
+			"from_stage":     "t.from_stage",
+			"to_stage":       "t.to_stage",
+			"changed_at":     "t.changed_at",
+		},
+	},
+	"security_events": {
+		from: "security_events t",
+		columns: map[string]string{
+			"id":          "t.id",
+			"event_type":  "t.event_type::TEXT",
+			"level":       "t.level::TEXT",
+			"service":     "t.service::TEXT",
+			"environment": "t.environment::TEXT",
+			"created_at":  "t.created_at",
+		},
+	},
+}
+
+const warehouseStateColumns = `table_name, schema_version, watermark, last_success_at, last_row_count, last_file_count,
+	last_object_prefix, last_attempt_at, last_status, last_error`
+
+func scanWarehouseState(row pgx.Row, s *domain.WarehouseExportState) error {
+	return row.Scan(
+		&s.Table, &s.SchemaVersion, &s.Watermark, &s.LastSuccessAt, &s.LastRowCount, &s.LastFileCount,
+		&s.LastObjectPrefix, &s.LastAttemptAt, &s.LastStatus, &s.LastError,
+	)
+}
+
+func (r *warehouseRepo) GetState(ctx context.Context, table string) (*domain.WarehouseExportState, error) {
+	var s domain.WarehouseExportState
+	err := scanWarehouseState(r.db.QueryRow(ctx,
+		`SELECT `+warehouseStateColumns+` FROM warehouse_export_state WHERE table_name = $1`, table,
+	), &s)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *warehouseRepo) ListStates(ctx context.Context) ([]domain.WarehouseExportState, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+warehouseStateColumns+` FROM warehouse_export_state ORDER BY table_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var states []domain.WarehouseExportState
+	for rows.Next() {
+		var s domain.WarehouseExportState
+		if err := scanWarehouseState(rows, &s); err != nil {
+			return nil, err
+		}
+		states = append(states, s)
+	}
+	return states, rows.Err()
+}
+
+func (r *warehouseRepo) SaveState(ctx context.Context, s *domain.WarehouseExportState) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO warehouse_export_state (`+warehouseStateColumns+`, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		ON CONFLICT (table_name) DO UPDATE SET
+			schema_version = EXCLUDED.schema_version,
+			watermark = EXCLUDED.watermark,
+			last_success_at = EXCLUDED.last_success_at,
+			last_row_count = EXCLUDED.last_row_count,
+			last_file_count = EXCLUDED.last_file_count,
+			last_object_prefix = EXCLUDED.last_object_prefix,
+			last_attempt_at = EXCLUDED.last_attempt_at,
+			last_status = EXCLUDED.last_status,
+			last_error = EXCLUDED.last_error,
+			updated_at = NOW()`,
+		s.Table, s.SchemaVersion, s.Watermark, s.LastSuccessAt, s.LastRowCount, s.LastFileCount,
+		s.LastObjectPrefix, s.LastAttemptAt, s.LastStatus, s.LastError,
+	)
+	return err
+}
+
+func (r *warehouseRepo) ExportRows(ctx context.Context, table domain.WarehouseTable, after domain.WarehouseCursor, until time.Time, limit int) ([][]any, error) {
+	source, ok := warehouseSources[table.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown warehouse table %q", table.Name)
+	}
+	watermark, ok := source.columns[table.WatermarkColumn]
+	if !ok {
+		return nil, fmt.Errorf("%s: no source for watermark column %q", table.Name, table.WatermarkColumn)
+	}
+
+	exprs := make([]string, 0, len(table.Columns))
+	for _, col := range table.Columns {
+		expr, ok := source.columns[col.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: no source for column %q", table.Name, col.Name)
+		}
+		exprs = append(exprs, expr)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE (%s, t.id) > ($1, $2) AND %s <= $3
+		ORDER BY %s, t.id
+		LIMIT $4`,
+		strings.Join(exprs, ", "), source.from, watermark, watermark, watermark,
+	)
+	rows, err := r.db.Query(ctx, query, after.At, after.ID, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out [][]any
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, values)
+	}
+	return out, rows.Err()
+}
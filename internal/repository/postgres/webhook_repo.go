@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type webhookRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *pgxpool.Pool) domain.WebhookRepository {
+	return &webhookRepo{db: db}
+}
+
+// ============================================================================
+// Endpoints
+// ============================================================================
+
+const webhookEndpointColumns = `id, lpk_id, url, description, event_types, active, created_by, created_at, updated_at`
+
+func scanWebhookEndpoint(row pgx.Row, e *domain.WebhookEndpoint) error {
+	return row.Scan(&e.ID, &e.LPKID, &e.URL, &e.Description, &e.EventTypes, &e.Active, &e.CreatedBy, &e.CreatedAt, &e.UpdatedAt)
+}
+
+func (r *webhookRepo) ListEndpoints(ctx context.Context, lpkID *int64) ([]domain.WebhookEndpoint, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+webhookEndpointColumns+` FROM webhook_endpoints WHERE lpk_id IS NOT DISTINCT FROM $1 ORDER BY id`, lpkID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	endpoints := []domain.WebhookEndpoint{}
+	for rows.Next() {
+		var e domain.WebhookEndpoint
+		if err := scanWebhookEndpoint(rows, &e); err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, rows.Err()
+}
+
+func (r *webhookRepo) GetEndpoint(ctx context.Context, id int64, lpkID *int64) (*domain.WebhookEndpoint, error) {
+	var e domain.WebhookEndpoint
+	err := scanWebhookEndpoint(r.db.QueryRow(ctx,
+		`SELECT `+webhookEndpointColumns+` FROM webhook_endpoints WHERE id = $1 AND lpk_id IS NOT DISTINCT FROM $2`, id, lpkID), &e)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *webhookRepo) CreateEndpoint(ctx context.Context, e *domain.WebhookEndpoint) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO webhook_endpoints (url, description, event_types, secret, active, created_by, lpk_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`,
+		e.URL, e.Description, e.EventTypes, e.Secret, e.Active, e.CreatedBy, e.LPKID,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+}
+
+func (r *webhookRepo) UpdateEndpoint(ctx context.Context, e *domain.WebhookEndpoint) error {
+	err := r.db.QueryRow(ctx, `
+		UPDATE webhook_endpoints
+		SET url = $2, description = $3, event_types = $4, active = $5, updated_at = NOW()
+		WHERE id = $1 AND lpk_id IS NOT DISTINCT FROM $6
+		RETURNING created_by, created_at, updated_at`,
+		e.ID, e.URL, e.Description, e.EventTypes, e.Active, e.LPKID,
+	).Scan(&e.CreatedBy, &e.CreatedAt, &e.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+func (r *webhookRepo) UpdateSecret(ctx context.Context, id int64, lpkID *int64, secret string, at time.Time) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE webhook_endpoints SET secret = $3, updated_at = $4 WHERE id = $1 AND lpk_id IS NOT DISTINCT FROM $2`, id, lpkID, secret, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *webhookRepo) DeleteEndpoint(ctx context.Context, id int64, lpkID *int64) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM webhook_endpoints WHERE id = $1 AND lpk_id IS NOT DISTINCT FROM $2`, id, lpkID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// ============================================================================
+// Deliveries
+// ============================================================================
+
+func (r *webhookRepo) EnqueueEvent(ctx context.Context, eventType string, payload json.RawMessage, at time.Time) (int, error) {
+	result, err := r.db.Exec(ctx, `
+		INSERT INTO webhook_deliveries (endpoint_id, event_type, payload, next_attempt_at, created_at)
+		SELECT id, $1, $2, $3, $3
+		FROM webhook_endpoints
+		WHERE active AND lpk_id IS NULL AND $1 = ANY(event_types)`,
+		eventType, payload, at,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+func (r *webhookRepo) EnqueuePartnerEvent(ctx context.Context, lpkID int64, eventType string, payload json.RawMessage, at time.Time) (int, error) {
+	result, err := r.db.Exec(ctx, `
+		INSERT INTO webhook_deliveries (endpoint_id, event_type, payload, next_attempt_at, created_at)
+		SELECT id, $2, $3, $4, $4
+		FROM webhook_endpoints
+		WHERE active AND lpk_id = $1 AND $2 = ANY(event_types)`,
+		lpkID, eventType, payload, at,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// ClaimDueDeliveries leases due deliveries with SKIP LOCKED, so instances running
+// the worker at the same time never send the same attempt twice. Deliveries of
+// deactivated endpoints wait until the endpoint is active again.
+func (r *webhookRepo) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhook_endpoints e ON e.id = d.endpoint_id
+			WHERE d.status = 'PENDING' AND d.next_attempt_at <= $1 AND e.active
+			ORDER BY d.next_attempt_at
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $2
+		FROM due, webhook_endpoints e
+		WHERE d.id = due.id AND e.id = d.endpoint_id
+		RETURNING d.id, d.endpoint_id, e.url, e.secret, d.event_type, d.payload, d.status, d.attempts, d.created_at`,
+		now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.EndpointID, &d.EndpointURL, &d.EndpointSecret, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *webhookRepo) RecordAttempt(ctx context.Context, d *domain.WebhookDelivery) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5,
+			last_error = $6, last_attempt_at = $7, delivered_at = $8
+		WHERE id = $1`,
+		d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastStatusCode, d.LastError, d.LastAttemptAt, d.DeliveredAt,
+	)
+	return err
+}
+
+const webhookDeliveryColumns = `d.id, d.endpoint_id, e.url, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at,
+	d.last_status_code, d.last_error, d.last_attempt_at, d.delivered_at, d.created_at`
+
+func scanWebhookDelivery(row pgx.Row, d *domain.WebhookDelivery) error {
+	return row.Scan(&d.ID, &d.EndpointID, &d.EndpointURL, &d.EventType, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.LastStatusCode, &d.LastError, &d.LastAttemptAt, &d.DeliveredAt, &d.CreatedAt)
+}
+
+func (r *webhookRepo) ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.EndpointID != nil {
+		where += fmt.Sprintf(" AND d.endpoint_id = $%d", argIndex)
+		args = append(args, *filter.EndpointID)
+		argIndex++
+	}
+	if filter.LPKID != nil {
+		where += fmt.Sprintf(" AND e.lpk_id = $%d", argIndex)
+		args = append(args, *filter.LPKID)
+		argIndex++
+	}
+	if filter.EventType != "" {
+		where += fmt.Sprintf(" AND d.event_type = $%d", argIndex)
+		args = append(args, filter.EventType)
+		argIndex++
+	}
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND d.status = $%d", argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM webhook_deliveries d JOIN webhook_endpoints e ON e.id = d.endpoint_id` + where
+	if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id` + where +
+		fmt.Sprintf(" ORDER BY d.created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &d); err != nil {
+			return nil, 0, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, total, rows.Err()
+}
+
+func (r *webhookRepo) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	err := scanWebhookDelivery(r.db.QueryRow(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE d.id = $1`, id), &d)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *webhookRepo) Redeliver(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = $2
+		WHERE id = $1`, id, at)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/security"
+	"net/http"
+	"time"
+)
+
+// AdminMFAConfig tunes two-factor authentication for admin accounts
+type AdminMFAConfig struct {
+	Required   bool          // admin routes need a verified session
+	SessionTTL time.Duration // how long one verification lasts
+	Issuer     string        // shown in authenticator apps
+}
+
+type adminMFAUsecase struct {
+	repo domain.AdminMFARepository
+	cfg  AdminMFAConfig
+	now  func() time.Time
+}
+
+func NewAdminMFAUsecase(repo domain.AdminMFARepository, cfg AdminMFAConfig) domain.AdminMFAUsecase {
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = 12 * time.Hour
+	}
+	if cfg.Issuer == "" {
+		cfg.Issuer = "J-Expert Admin"
+	}
+	return &adminMFAUsecase{repo: repo, cfg: cfg, now: time.Now}
+}
+
+func (u *adminMFAUsecase) Status(ctx context.Context, userID, sessionID string) (*domain.MFAStatus, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	status := &domain.MFAStatus{Required: u.cfg.Required}
+	mfa, err := u.repo.Get(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && mfa.EnabledAt == nil) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch two-factor status: " + err.Error()))
+	}
+	status.Enrolled, status.EnabledAt = true, mfa.EnabledAt
+
+	if sessionID == "" {
+		return status, nil
+	}
+	expiresAt, err := u.repo.SessionExpiry(ctx, userID, sessionID, u.now())
+	if errors.Is(err, domain.ErrNotFound) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch two-factor status: " + err.Error()))
+	}
+	status.Verified, status.ExpiresAt = true, &expiresAt
+	return status, nil
+}
+
+func (u *adminMFAUsecase) Enroll(ctx context.Context, userID, email string) (*domain.MFAEnrollment, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	secret, url, err := security.GenerateTOTPKey(u.cfg.Issuer, email)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if err := u.repo.SavePending(ctx, userID, secret); err != nil {
+		if errors.Is(err, domain.ErrMFAAlreadyEnrolled) {
+			return nil, apperror.Conflict("Two-factor authentication is already enabled")
+		}
+		return nil, apperror.Internal(errors.New("Failed to start two-factor enrollment: " + err.Error()))
+	}
+	return &domain.MFAEnrollment{Secret: secret, OtpauthURL: url}, nil
+}
+
+func (u *adminMFAUsecase) ConfirmEnrollment(ctx context.Context, userID, sessionID, code string) (*domain.MFAStatus, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	mfa, err := u.repo.Get(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, apperror.BadRequest("No pending two-factor enrollment, start one first")
+	}
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch two-factor status: " + err.Error()))
+	}
+	if mfa.EnabledAt != nil {
+		return nil, apperror.Conflict("Two-factor authentication is already enabled")
+	}
+	if err := u.checkCode(ctx, mfa, code); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Enable(ctx, userID, u.now()); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to enable two-factor authentication: " + err.Error()))
+	}
+	logger.FromContext(ctx).Info("Admin two-factor authentication enabled", "user_id", userID)
+	return u.verifySession(ctx, userID, sessionID)
+}
+
+func (u *adminMFAUsecase) Verify(ctx context.Context, userID, sessionID, code string) (*domain.MFAStatus, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	mfa, err := u.repo.Get(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && mfa.EnabledAt == nil) {
+		return nil, apperror.BadRequest("Two-factor authentication is not enabled, enroll first")
+	}
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch two-factor status: " + err.Error()))
+	}
+	if err := u.checkCode(ctx, mfa, code); err != nil {
+		return nil, err
+	}
+	return u.verifySession(ctx, userID, sessionID)
+}
+
+// checkCode validates a code, counting wrong ones towards a temporary lock
+func (u *adminMFAUsecase) checkCode(ctx context.Context, mfa *domain.UserMFA, code string) error {
+	now := u.now()
+	if mfa.LockedUntil != nil && mfa.LockedUntil.After(now) {
+		return apperror.New(http.StatusTooManyRequests, "Too many wrong two-factor codes, try again later", nil)
+	}
+	if !security.ValidateTOTPCode(code, mfa.Secret, now) {
+		if err := u.repo.RecordFailure(ctx, mfa.UserID, domain.MaxMFAFailedAttempts, now.Add(domain.MFALockDuration)); err != nil {
+			logger.FromContext(ctx).Error("Failed to record wrong two-factor code", "user_id", mfa.UserID, "error", err)
+		}
+		return apperror.BadRequest("Invalid two-factor code")
+	}
+	if err := u.repo.ResetFailures(ctx, mfa.UserID); err != nil {
+		logger.FromContext(ctx).Warn("Failed to reset two-factor failures", "user_id", mfa.UserID, "error", err)
+	}
+	return nil
+}
+
+// verifySession marks the current session verified until the session TTL passes
+func (u *adminMFAUsecase) verifySession(ctx context.Context, userID, sessionID string) (*domain.MFAStatus, error) {
+	if sessionID == "" {
+		return nil, apperror.BadRequest("The access token has no session, log in again")
+	}
+	expiresAt := u.now().Add(u.cfg.SessionTTL)
+	if err := u.repo.CreateSession(ctx, userID, sessionID, expiresAt); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to verify session: " + err.Error()))
+	}
+	return u.Status(ctx, userID, sessionID)
+}
+
+func (u *adminMFAUsecase) Reset(ctx context.Context, adminID, targetUserID string) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	if adminID == targetUserID {
+		return apperror.BadRequest("Another admin must reset your two-factor authentication")
+	}
+
+	if err := u.repo.Delete(ctx, targetUserID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Two-factor enrollment not found")
+		}
+		return apperror.Internal(errors.New("Failed to reset two-factor authentication: " + err.Error()))
+	}
+	logger.FromContext(ctx).Info("Admin two-factor authentication reset", "admin_id", adminID, "user_id", targetUserID)
+	return nil
+}
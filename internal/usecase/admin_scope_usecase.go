@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"strings"
+	"time"
+)
+
+type adminScopeUsecase struct {
+	repo     domain.AdminScopeRepository
+	userRepo domain.UserRepository
+	now      func() time.Time
+}
+
+func NewAdminScopeUsecase(repo domain.AdminScopeRepository, userRepo domain.UserRepository) domain.AdminScopeUsecase {
+	return &adminScopeUsecase{repo: repo, userRepo: userRepo, now: time.Now}
+}
+
+// requireUnrestrictedAdmin allows admins without a regional scope only
+func requireUnrestrictedAdmin(ctx context.Context) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	if domain.AdminProvincesFromContext(ctx) != nil {
+		return apperror.Forbidden("Regional admins cannot do this").WithCode(domain.ErrCodeAdminScopeForbidden)
+	}
+	return nil
+}
+
+func (u *adminScopeUsecase) Provinces(ctx context.Context, adminUserID string) ([]string, error) {
+	scope, err := u.repo.Get(ctx, adminUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	provinces := make([]string, len(scope.Provinces))
+	for i, p := range scope.Provinces {
+		provinces[i] = strings.ToLower(p)
+	}
+	return provinces, nil
+}
+
+func (u *adminScopeUsecase) GetMyScope(ctx context.Context) (*domain.MyAdminScope, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	scope, err := u.repo.Get(ctx, domain.UserIDFromContext(ctx))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return &domain.MyAdminScope{Unrestricted: true, Provinces: []string{}}, nil
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch admin scope: " + err.Error()))
+	}
+	return &domain.MyAdminScope{Provinces: scope.Provinces}, nil
+}
+
+func (u *adminScopeUsecase) ListScopes(ctx context.Context) ([]domain.AdminScope, error) {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
+		return nil, err
+	}
+	scopes, err := u.repo.List(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch admin scopes: " + err.Error()))
+	}
+	return scopes, nil
+}
+
+// SetScope makes another admin regional, replacing any previous provinces.
+// Provinces keep their spelling; matching ignores case.
+func (u *adminScopeUsecase) SetScope(ctx context.Context, adminUserID string, req domain.SetAdminScopeRequest) (*domain.AdminScope, error) {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
+		return nil, err
+	}
+	callerID := domain.UserIDFromContext(ctx)
+	if adminUserID == callerID {
+		return nil, apperror.BadRequest("You cannot limit your own scope")
+	}
+	target, err := u.userRepo.GetByID(ctx, adminUserID)
+	if err != nil || target == nil {
+		return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+	}
+	if domain.NormalizeRole(target.Role) != domain.RoleAdmin {
+		return nil, apperror.BadRequest("Only admins can be given a regional scope")
+	}
+
+	provinces := []string{}
+	seen := map[string]bool{}
+	for _, p := range req.Provinces {
+		p = strings.TrimSpace(p)
+		key := strings.ToLower(p)
+		if p == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		provinces = append(provinces, p)
+	}
+	if len(provinces) == 0 {
+		return nil, apperror.BadRequest("At least one province is required")
+	}
+	if len(provinces) > domain.MaxAdminScopeProvinces {
+		return nil, apperror.BadRequest("Too many provinces")
+	}
+
+	scope := &domain.AdminScope{
+		AdminUserID: adminUserID,
+		Email:       target.Email,
+		Provinces:   provinces,
+		UpdatedBy:   &callerID,
+		UpdatedAt:   u.now(),
+	}
+	if err := u.repo.Upsert(ctx, scope); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save admin scope: " + err.Error()))
+	}
+	return scope, nil
+}
+
+// ClearScope makes a regional admin unrestricted again
+func (u *adminScopeUsecase) ClearScope(ctx context.Context, adminUserID string) error {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
+		return err
+	}
+	if err := u.repo.Delete(ctx, adminUserID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Admin scope not found")
+		}
+		return apperror.Internal(errors.New("Failed to remove admin scope: " + err.Error()))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	minAdminSearchQueryLength = 3 // trigram indexes only help from 3 characters
+	maxAdminSearchQueryLength = 100
+	defaultAdminSearchLimit   = 5
+	maxAdminSearchLimit       = 20
+	minAdminSearchPhoneDigits = 6
+)
+
+type adminSearchUsecase struct {
+	repo domain.AdminSearchRepository
+}
+
+func NewAdminSearchUsecase(repo domain.AdminSearchRepository) domain.AdminSearchUsecase {
+	return &adminSearchUsecase{repo: repo}
+}
+
+// adminSearchPermissions decides per result type whether the caller may see it.
+// Every type is admin-only; regional admins only search candidates and
+// companies, limited to their provinces.
+var adminSearchPermissions = map[string]func(ctx context.Context) error{
+	domain.AdminSearchTypeUser:      requireUnrestrictedAdmin,
+	domain.AdminSearchTypeCandidate: func(ctx context.Context) error { return requireRole(ctx, domain.RoleAdmin) },
+	domain.AdminSearchTypeCompany:   func(ctx context.Context) error { return requireRole(ctx, domain.RoleAdmin) },
+	domain.AdminSearchTypeJob:       requireUnrestrictedAdmin,
+}
+
+func (u *adminSearchUsecase) Search(ctx context.Context, query string, types []string, limit int) (*domain.AdminSearchResponse, error) {
+	// 1. Validate input
+	query = strings.TrimSpace(query)
+	if n := len([]rune(query)); n < minAdminSearchQueryLength || n > maxAdminSearchQueryLength {
+		return nil, apperror.BadRequest(fmt.Sprintf("Search query must be %d to %d characters", minAdminSearchQueryLength, maxAdminSearchQueryLength))
+	}
+	if limit == 0 {
+		limit = defaultAdminSearchLimit
+	}
+	if limit < 1 || limit > maxAdminSearchLimit {
+		return nil, apperror.BadRequest(fmt.Sprintf("limit must be between 1 and %d", maxAdminSearchLimit))
+	}
+	if len(types) == 0 {
+		types = domain.AdminSearchTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(domain.AdminSearchTypes, t) {
+			return nil, apperror.BadRequest("Invalid search type: " + t)
+		}
+	}
+
+	// 2. Types the caller may not see are left out, not reported as errors
+	var permitted []string
+	for _, t := range domain.AdminSearchTypes {
+		if slices.Contains(types, t) && adminSearchPermissions[t](ctx) == nil {
+			permitted = append(permitted, t)
+		}
+	}
+	if len(permitted) == 0 {
+		return nil, apperror.Forbidden("Admin access required")
+	}
+
+	// 3. Search every permitted type in parallel
+	phoneDigits := searchPhoneDigits(query)
+	provinces := domain.AdminProvincesFromContext(ctx)
+	groups := make([]domain.AdminSearchGroup, len(permitted))
+	errs := make([]error, len(permitted))
+	var wg sync.WaitGroup
+	for i, t := range permitted {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var results []domain.AdminSearchResult
+			switch t {
+			case domain.AdminSearchTypeUser:
+				results, errs[i] = u.repo.SearchUsers(ctx, query, limit)
+			case domain.AdminSearchTypeCandidate:
+				results, errs[i] = u.repo.SearchCandidates(ctx, query, phoneDigits, provinces, limit)
+			case domain.AdminSearchTypeCompany:
+				results, errs[i] = u.repo.SearchCompanies(ctx, query, provinces, limit)
+			case domain.AdminSearchTypeJob:
+				results, errs[i] = u.repo.SearchJobs(ctx, query, limit)
+			}
+			groups[i] = domain.AdminSearchGroup{Type: t, Results: results}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to search: " + err.Error()))
+	}
+	return &domain.AdminSearchResponse{Query: query, Groups: groups}, nil
+}
+
+// searchPhoneDigits returns the digits of a query that looks like a phone number
+// (digits with +, spaces, dashes, dots or parentheses), or "" otherwise
+func searchPhoneDigits(query string) string {
+	var digits strings.Builder
+	for _, r := range query {
+		switch {
+		case unicode.IsDigit(r):
+			digits.WriteRune(r)
+		case strings.ContainsRune("+-(). ", r):
+		default:
+			return ""
+		}
+	}
+	if digits.Len() < minAdminSearchPhoneDigits {
+		return ""
+	}
+	// Match 0812..., 62812... and +62812... alike by dropping the trunk or country prefix
+	n := digits.String()
+	switch {
+	case strings.HasPrefix(n, "62"):
+		n = n[2:]
+	case strings.HasPrefix(n, "0"):
+		n = n[1:]
+	}
+	return n
+}
@@ -12,11 +12,12 @@ import (
 )
 
 type adminUsecase struct {
-	adminRepo domain.AdminRepository
+	adminRepo       domain.AdminRepository
+	publicJobsCache *PublicJobCache
 }
 
-func NewAdminUsecase(adminRepo domain.AdminRepository) domain.AdminUsecase {
-	return &adminUsecase{adminRepo: adminRepo}
+func NewAdminUsecase(adminRepo domain.AdminRepository, publicJobsCache *PublicJobCache) domain.AdminUsecase {
+	return &adminUsecase{adminRepo: adminRepo, publicJobsCache: publicJobsCache}
 }
 
 // GetStats returns dashboard statistics
@@ -47,7 +48,7 @@ func (u *adminUsecase) ListUsers(ctx context.Context, role string, page, pageSiz
 		pageSize = 10
 	}
 
-	users, total, err := u.adminRepo.ListUsers(ctx, role, page, pageSize)
+	users, total, err := u.adminRepo.ListUsers(ctx, role, domain.AdminProvincesFromContext(ctx), page, pageSize)
 	if err != nil {
 		return nil, apperror.Internal(errors.New("Failed to fetch users: " + err.Error()))
 	}
@@ -65,7 +66,7 @@ func (u *adminUsecase) ListUsers(ctx context.Context, role string, page, pageSiz
 
 // DisableUser enables or disables a user
 func (u *adminUsecase) DisableUser(ctx context.Context, userID string, disable bool) (*domain.AdminUser, error) {
-	if err := u.requireAdmin(ctx); err != nil {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
 		return nil, err
 	}
 
@@ -79,7 +80,7 @@ func (u *adminUsecase) DisableUser(ctx context.Context, userID string, disable b
 	}
 
 	// Fetch updated user
-	users, _, err := u.adminRepo.ListUsers(ctx, "", 1, 1)
+	users, _, err := u.adminRepo.ListUsers(ctx, "", nil, 1, 1)
 	if err != nil || len(users) == 0 {
 		// Return minimal response
 		return &domain.AdminUser{ID: userID, IsDisabled: disable}, nil
@@ -97,7 +98,7 @@ func (u *adminUsecase) DisableUser(ctx context.Context, userID string, disable b
 
 // CreateUser creates a new user (DB only)
 func (u *adminUsecase) CreateUser(ctx context.Context, req domain.CreateUserRequest) (*domain.AdminUser, error) {
-	if err := u.requireAdmin(ctx); err != nil {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
 		return nil, err
 	}
 
@@ -120,7 +121,7 @@ func (u *adminUsecase) CreateUser(ctx context.Context, req domain.CreateUserRequ
 
 // UpdateUser updates an existing user
 func (u *adminUsecase) UpdateUser(ctx context.Context, userID string, req domain.UpdateUserRequest) (*domain.AdminUser, error) {
-	if err := u.requireAdmin(ctx); err != nil {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
 		return nil, err
 	}
 
@@ -145,7 +146,7 @@ func (u *adminUsecase) UpdateUser(ctx context.Context, userID string, req domain
 
 // DeleteUser deletes a user
 func (u *adminUsecase) DeleteUser(ctx context.Context, userID string) error {
-	if err := u.requireAdmin(ctx); err != nil {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
 		return err
 	}
 
@@ -247,6 +248,7 @@ func (u *adminUsecase) HideJob(ctx context.Context, jobID int64, hide bool) (*do
 	if err != nil {
 		return nil, apperror.Internal(errors.New("Failed to update job: " + err.Error()))
 	}
+	u.publicJobsCache.Invalidate(ctx)
 
 	status := "active"
 	if hide {
@@ -271,23 +273,8 @@ func (u *adminUsecase) FlagJob(ctx context.Context, jobID int64, flag bool, reas
 }
 
 // requireAdmin checks if the current user has admin role
-// Works with both Gin context (c.Set) and standard context.WithValue
 func (u *adminUsecase) requireAdmin(ctx context.Context) error {
-	var role string
-
-	// First try Gin context string key (from c.Set)
-	if r, ok := ctx.Value(string(domain.KeyUserRole)).(string); ok {
-		role = r
-	}
-
-	// Fallback to CtxKey type (from context.WithValue)
-	if role == "" {
-		if r, ok := ctx.Value(domain.KeyUserRole).(string); ok {
-			role = r
-		}
-	}
-
-	if role != "admin" {
+	if domain.RoleFromContext(ctx) != domain.RoleAdmin {
 		return apperror.Forbidden("Admin access required")
 	}
 	return nil
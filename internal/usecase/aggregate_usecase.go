@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"sync"
+)
+
+// recomputeRunListLimit is how many past runs the admin list returns
+const recomputeRunListLimit = 30
+
+type aggregateUsecase struct {
+	repo    domain.AggregateRepository
+	running sync.Mutex
+}
+
+func NewAggregateUsecase(repo domain.AggregateRepository) domain.AggregateUsecase {
+	return &aggregateUsecase{repo: repo}
+}
+
+// RunRecompute checks every derived aggregate against its source rows, records the
+// drift it finds and rewrites the drifted values
+func (u *aggregateUsecase) RunRecompute(ctx context.Context, triggerSource string) (*domain.AggregateRecomputeRun, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("An aggregate recompute is already in progress")
+	}
+	defer u.running.Unlock()
+
+	// 1. Open the run so a crash still leaves a trace
+	run := &domain.AggregateRecomputeRun{
+		TriggerSource: triggerSource,
+		Status:        domain.RecomputeStatusRunning,
+		DriftItems:    []domain.AggregateDrift{},
+	}
+	if err := u.repo.CreateRun(ctx, run); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to start recompute: " + err.Error()))
+	}
+
+	// 2. Detect drift before fixing it, so the report shows the stored values
+	runErr := u.recomputeAll(ctx, run)
+	run.Status = domain.RecomputeStatusCompleted
+	if runErr != nil {
+		msg := runErr.Error()
+		run.Status = domain.RecomputeStatusFailed
+		run.ErrorMessage = &msg
+	}
+
+	// 3. Persist the outcome; the context may be cancelled, so use a fresh one
+	if err := u.repo.FinishRun(context.WithoutCancel(ctx), run); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save recompute run: " + err.Error()))
+	}
+	if runErr != nil {
+		return run, apperror.Internal(errors.New("Aggregate recompute failed: " + runErr.Error()))
+	}
+	return run, nil
+}
+
+func (u *aggregateUsecase) recomputeAll(ctx context.Context, run *domain.AggregateRecomputeRun) error {
+	for _, aggregate := range domain.RecomputeAggregates {
+		checked, err := u.repo.CountRows(ctx, aggregate)
+		if err != nil {
+			return fmt.Errorf("%s: count: %w", aggregate, err)
+		}
+		drift, total, err := u.repo.FindDrift(ctx, aggregate, domain.MaxDriftItemsPerAggregate)
+		if err != nil {
+			return fmt.Errorf("%s: find drift: %w", aggregate, err)
+		}
+
+		run.CheckedCount += checked
+		run.DriftCount += total
+		run.DriftItems = append(run.DriftItems, drift...)
+
+		if total == 0 {
+			continue
+		}
+		fixed, err := u.repo.FixDrift(ctx, aggregate)
+		if err != nil {
+			return fmt.Errorf("%s: fix drift: %w", aggregate, err)
+		}
+		run.FixedCount += fixed
+
+		logger.FromContext(ctx).Info("Aggregate recompute", "aggregate", aggregate, "drifted", total, "fixed", fixed)
+	}
+	return nil
+}
+
+func (u *aggregateUsecase) TriggerRecompute(ctx context.Context) (*domain.AggregateRecomputeRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunRecompute(ctx, domain.RecomputeTriggerManual)
+}
+
+func (u *aggregateUsecase) ListRuns(ctx context.Context) ([]domain.AggregateRecomputeRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	runs, err := u.repo.ListRuns(ctx, recomputeRunListLimit)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch recompute runs: " + err.Error()))
+	}
+	return runs, nil
+}
+
+func (u *aggregateUsecase) GetDriftReport(ctx context.Context, runID int64) (*domain.AggregateRecomputeRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	run, err := u.repo.GetRun(ctx, runID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Recompute run not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch recompute run: " + err.Error()))
+	}
+	return run, nil
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ApplicationDraftConfig tunes application drafts and their reminder worker
+type ApplicationDraftConfig struct {
+	ReminderAfter time.Duration // time since the last save before the reminder
+	ExpiryAfter   time.Duration // time since the last save before the draft is deleted
+	MaxPerRun     int           // reminders sent per run
+	FrontendURL   string        // base for links in messages
+}
+
+type applicationDraftUsecase struct {
+	repo            domain.ApplicationDraftRepository
+	jobRepo         domain.JobRepository
+	applicationRepo domain.ApplicationRepository
+	notifier        domain.CandidateNotifier
+	cfg             ApplicationDraftConfig
+	now             func() time.Time
+
+	running sync.Mutex
+}
+
+func NewApplicationDraftUsecase(repo domain.ApplicationDraftRepository, jobRepo domain.JobRepository, applicationRepo domain.ApplicationRepository, notifier domain.CandidateNotifier, cfg ApplicationDraftConfig) domain.ApplicationDraftUsecase {
+	if notifier == nil {
+		notifier = logCandidateNotifier{}
+	}
+	if cfg.ReminderAfter <= 0 {
+		cfg.ReminderAfter = 24 * time.Hour
+	}
+	if cfg.ExpiryAfter <= cfg.ReminderAfter {
+		cfg.ExpiryAfter = cfg.ReminderAfter + 24*time.Hour
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = 500
+	}
+	return &applicationDraftUsecase{repo: repo, jobRepo: jobRepo, applicationRepo: applicationRepo, notifier: notifier, cfg: cfg, now: time.Now}
+}
+
+func (u *applicationDraftUsecase) GetDraft(ctx context.Context, userID string, jobID int64) (*domain.ApplicationDraft, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	draft, err := u.repo.Get(ctx, userID, jobID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, apperror.NotFound("Application draft not found")
+	}
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch application draft: " + err.Error()))
+	}
+	// Expired drafts are deleted by the worker; until then they are already gone for the candidate
+	if !draft.ExpiresAt.After(u.now()) {
+		return nil, apperror.NotFound("Application draft not found")
+	}
+	return draft, nil
+}
+
+func (u *applicationDraftUsecase) SaveDraft(ctx context.Context, userID string, jobID int64, req domain.SaveApplicationDraftRequest) (*domain.ApplicationDraft, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	// 1. Only jobs that can still be applied to
+	job, err := u.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+	now := u.now()
+	if !job.IsPublished(now) {
+		return nil, apperror.BadRequest("Cannot apply to inactive job")
+	}
+	if job.DeadlinePassed(now) {
+		return nil, apperror.BadRequest("The application deadline for this job has passed").WithCode(domain.ErrCodeApplicationDeadlinePassed)
+	}
+	exists, err := u.applicationRepo.CheckExists(ctx, jobID, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if exists {
+		return nil, apperror.BadRequest("You have already applied to this job").WithCode(domain.ErrCodeAlreadyApplied)
+	}
+
+	// 2. Replace the draft; every save restarts the reminder and expiry clocks
+	draft := &domain.ApplicationDraft{
+		CandidateUserID: userID,
+		JobID:           jobID,
+		CvURL:           optionalString(req.CvURL),
+		CoverLetter:     optionalString(req.CoverLetter),
+		Answers:         req.Answers,
+		ExpiresAt:       now.UTC().Add(u.cfg.ExpiryAfter),
+	}
+	if err := u.repo.Upsert(ctx, draft); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save application draft: " + err.Error()))
+	}
+	return draft, nil
+}
+
+func (u *applicationDraftUsecase) RunReminders(ctx context.Context) (*domain.ApplicationDraftRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("An application draft reminder run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	result := &domain.ApplicationDraftRunResult{StartedAt: now}
+
+	// 1. Drop expired drafts first so they are never reminded about
+	expired, err := u.repo.DeleteExpired(ctx, now)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to delete expired application drafts: " + err.Error()))
+	}
+	result.Expired = expired
+
+	// 2. Drafts left alone for the reminder delay, one message each
+	due, err := u.repo.ListDueReminders(ctx, now.Add(-u.cfg.ReminderAfter), now, u.cfg.MaxPerRun)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch application drafts due for a reminder: " + err.Error()))
+	}
+	result.Due = len(due)
+
+	for _, d := range due {
+		// Failures are not marked, so they are retried next run
+		if err := u.notifier.NotifyCandidate(ctx, u.renderReminder(d)); err != nil {
+			logger.FromContext(ctx).Error("Application draft: failed to send reminder", "user_id", d.CandidateUserID, "job_id", d.JobID, "error", err)
+			result.Failed++
+			continue
+		}
+		result.Sent++
+		if err := u.repo.MarkReminded(ctx, d.CandidateUserID, d.JobID, now); err != nil {
+			logger.FromContext(ctx).Error("Application draft: failed to mark reminder", "user_id", d.CandidateUserID, "job_id", d.JobID, "error", err)
+		}
+	}
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+func (u *applicationDraftUsecase) renderReminder(d domain.ApplicationDraftReminderCandidate) *domain.CandidateNotification {
+	locale := i18n.LocaleID // candidates are Indonesian unless they chose otherwise
+	if d.PreferredLocale != nil && i18n.IsSupported(*d.PreferredLocale) {
+		locale = *d.PreferredLocale
+	}
+	t := func(msg string) string { return i18n.T(locale, msg) }
+
+	var b strings.Builder
+	if d.FirstName != nil && strings.TrimSpace(*d.FirstName) != "" {
+		b.WriteString(fmt.Sprintf(t("Hello %s,"), strings.TrimSpace(*d.FirstName)))
+	} else {
+		b.WriteString(t("Hello,"))
+	}
+	b.WriteString("\n\n")
+
+	job := d.JobTitle
+	if d.CompanyName != "" {
+		job += ", " + d.CompanyName
+	}
+	b.WriteString(fmt.Sprintf(t("You started an application for %s but have not submitted it yet."), job) + "\n")
+	b.WriteString(fmt.Sprintf(t("Your answers are saved until %s."), d.ExpiresAt.Format("2006-01-02")) + "\n\n")
+	b.WriteString(fmt.Sprintf(t("Continue your application here: %s"), fmt.Sprintf("%s/jobs/%d/apply", u.cfg.FrontendURL, d.JobID)) + "\n")
+
+	return &domain.CandidateNotification{
+		UserID:   d.CandidateUserID,
+		Email:    d.Email,
+		Locale:   locale,
+		Campaign: "APPLICATION_DRAFT",
+		Subject:  t("Finish your application"),
+		Body:     b.String(),
+	}
+}
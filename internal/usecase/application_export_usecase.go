@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// applicationExportHeaders are the friendly column names in the XLSX
+var applicationExportHeaders = map[string]string{
+	"application_id":      "APPLICATION ID",
+	"candidate_name":      "CANDIDATE NAME",
+	"email":               "EMAIL",
+	"phone":               "PHONE",
+	"contact_unlocked":    "CONTACT UNLOCKED",
+	"stage":               "STAGE",
+	"auto_rejected":       "AUTO-REJECTED (SCREENING)",
+	"applied_at":          "APPLIED AT",
+	"last_updated_at":     "LAST UPDATED AT",
+	"verification_status": "VERIFICATION STATUS",
+	"japanese_level":      "JLPT LEVEL",
+	"domicile_city":       "DOMICILE CITY",
+	"cv_url":              "CV URL",
+	"cover_letter":        "COVER LETTER",
+	"screening_answers":   "SCREENING ANSWERS",
+}
+
+// ExportJobApplications builds an XLSX of a job's applicants for the owning employer.
+// Contact columns stay empty unless the company unlocked the candidate's contact.
+func (uc *applicationUsecase) ExportJobApplications(ctx context.Context, userID string, jobID int64, req domain.ApplicationExportRequest) ([]byte, string, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, "", err
+	}
+
+	// 1. Validate columns (default: all)
+	columns := req.Columns
+	if len(columns) == 0 {
+		columns = domain.ApplicationExportColumns
+	}
+	seen := make(map[string]bool, len(columns))
+	unique := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if !slices.Contains(domain.ApplicationExportColumns, col) {
+			return nil, "", apperror.BadRequest("Invalid export column: " + col)
+		}
+		if !seen[col] {
+			seen[col] = true
+			unique = append(unique, col)
+		}
+	}
+	columns = unique
+
+	// 2. The job must belong to the employer's company
+	company, err := uc.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, "", apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, "", apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+	job, err := uc.jobRepo.GetByID(ctx, jobID)
+	if err != nil || job.CompanyID != company.ID {
+		return nil, "", apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+
+	// 3. Load applicants
+	rows, err := uc.applicationRepo.ListForExport(ctx, jobID, company.ID)
+	if err != nil {
+		return nil, "", apperror.Internal(errors.New("Failed to fetch applications: " + err.Error()))
+	}
+
+	// 4. Record the access before any data leaves the server
+	subjects := make([]string, 0, len(rows))
+	for _, row := range rows {
+		subjects = append(subjects, row.CandidateUserID)
+	}
+	companyID := company.ID
+	entry := &domain.PIIAccessLog{
+		ActorUserID:    userID,
+		CompanyID:      &companyID,
+		Action:         domain.PIIActionApplicationExport,
+		ResourceType:   "job",
+		ResourceID:     strconv.FormatInt(jobID, 10),
+		SubjectUserIDs: subjects,
+		Columns:        columns,
+		IPAddress:      req.IPAddress,
+		UserAgent:      req.UserAgent,
+	}
+	if err := uc.piiLogRepo.Create(ctx, entry); err != nil {
+		return nil, "", apperror.Internal(errors.New("Failed to record export: " + err.Error()))
+	}
+
+	// 5. Build the workbook
+	data, err := buildApplicationExportXLSX(rows, columns)
+	if err != nil {
+		return nil, "", apperror.Internal(errors.New("Failed to build export: " + err.Error()))
+	}
+	filename := fmt.Sprintf("job_%d_applications_%s.xlsx", jobID, time.Now().Format("20060102_150405"))
+	return data, filename, nil
+}
+
+func buildApplicationExportXLSX(rows []domain.ApplicationExportRow, columns []string) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+	sheetName := "Applications"
+	f.SetSheetName("Sheet1", sheetName)
+
+	// Headers, same styling as the ATS export
+	for i, col := range columns {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, applicationExportHeaders[col])
+	}
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:      &excelize.Font{Bold: true, Color: "#FFFFFF"},
+		Fill:      excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#1E3A5F"}},
+		Alignment: &excelize.Alignment{Horizontal: "center"},
+	})
+	endCell, _ := excelize.CoordinatesToCellName(len(columns), 1)
+	f.SetCellStyle(sheetName, "A1", endCell, headerStyle)
+
+	// Data rows
+	for rowIdx, row := range rows {
+		for colIdx, col := range columns {
+			cell, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+2)
+			f.SetCellValue(sheetName, cell, applicationExportValue(row, col))
+		}
+	}
+
+	for i := range columns {
+		colName, _ := excelize.ColumnNumberToName(i + 1)
+		f.SetColWidth(sheetName, colName, colName, 20)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// applicationExportValue extracts one cell value from an export row
+func applicationExportValue(row domain.ApplicationExportRow, column string) interface{} {
+	switch column {
+	case "application_id":
+		return row.ApplicationID
+	case "candidate_name":
+		return row.CandidateName
+	case "email":
+		return strOrEmpty(row.Email)
+	case "phone":
+		return strOrEmpty(row.Phone)
+	case "contact_unlocked":
+		return yesNo(row.ContactUnlocked)
+	case "stage":
+		return row.Stage
+	case "auto_rejected":
+		return yesNo(row.AutoRejected)
+	case "applied_at":
+		return row.AppliedAt.Format("2006-01-02 15:04")
+	case "last_updated_at":
+		return row.UpdatedAt.Format("2006-01-02 15:04")
+	case "verification_status":
+		return strOrEmpty(row.VerificationStatus)
+	case "japanese_level":
+		return strOrEmpty(row.JapaneseLevel)
+	case "domicile_city":
+		return strOrEmpty(row.DomicileCity)
+	case "cv_url":
+		return row.CvURL
+	case "cover_letter":
+		return strOrEmpty(row.CoverLetter)
+	case "screening_answers":
+		return strOrEmpty(row.ScreeningAnswers)
+	default:
+		return ""
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"math"
+	"time"
+)
+
+type applicationInsightsUsecase struct {
+	insightsRepo domain.ApplicationInsightsRepository
+	now          func() time.Time
+}
+
+func NewApplicationInsightsUsecase(insightsRepo domain.ApplicationInsightsRepository) domain.ApplicationInsightsUsecase {
+	return &applicationInsightsUsecase{insightsRepo: insightsRepo, now: time.Now}
+}
+
+func (u *applicationInsightsUsecase) GetMyInsights(ctx context.Context, userID string) (*domain.ApplicationInsights, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	// 1. The candidate's own application outcomes
+	stats, err := u.insightsRepo.GetActivityStats(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch application stats: " + err.Error()))
+	}
+
+	insights := &domain.ApplicationInsights{
+		TotalApplications: stats.Total,
+		ByStage:           stats.ByStage,
+		ReachedInterview:  stats.ReachedInterview,
+		Responded:         stats.Responded,
+		AwaitingResponse:  stats.AwaitingResponses,
+		GeneratedAt:       u.now().UTC(),
+	}
+	if stats.Total > 0 {
+		rate := round1(float64(stats.ReachedInterview) * 100 / float64(stats.Total))
+		insights.InterviewRate = &rate
+	}
+	if stats.AvgResponseHours != nil {
+		hours := round1(*stats.AvgResponseHours)
+		insights.AvgResponseHours = &hours
+	}
+
+	// 2. Benchmarks against hired candidates in the same target fields
+	benchmark, err := u.buildBenchmark(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	insights.Benchmark = benchmark
+
+	return insights, nil
+}
+
+// buildBenchmark returns nil when the candidate has no target field yet or too few
+// candidates were hired in it to report without identifying anyone
+func (u *applicationInsightsUsecase) buildBenchmark(ctx context.Context, userID string) (*domain.ProfileBenchmark, error) {
+	profile, err := u.insightsRepo.GetBenchmarkProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch candidate profile: " + err.Error()))
+	}
+	if len(profile.MainJobFields) == 0 {
+		return nil, nil
+	}
+
+	hired, err := u.insightsRepo.GetHiredBenchmark(ctx, profile.MainJobFields, userID, domain.InsightsTopSkills)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch hiring benchmarks: " + err.Error()))
+	}
+	if hired.SampleSize < domain.MinInsightsBenchmarkSample {
+		return nil, nil
+	}
+
+	benchmark := &domain.ProfileBenchmark{
+		JobFields:             profile.MainJobFields,
+		SampleSize:            hired.SampleSize,
+		JapaneseLevel:         profile.JapaneseLevel,
+		TypicalJapaneseLevel:  hired.MedianJapaneseLevel,
+		JapanExperienceMonths: profile.JapanExperienceMonths,
+		TopSkills:             hired.TopSkills,
+	}
+	if hired.MedianExperienceMonths != nil {
+		months := round1(*hired.MedianExperienceMonths)
+		benchmark.TypicalJapanExperienceMonths = &months
+	}
+
+	mine := make(map[string]bool, len(profile.Skills))
+	for _, s := range profile.Skills {
+		mine[s] = true
+	}
+	have := 0
+	for i := range benchmark.TopSkills {
+		benchmark.TopSkills[i].Percent = round1(benchmark.TopSkills[i].Percent)
+		if mine[benchmark.TopSkills[i].Name] {
+			benchmark.TopSkills[i].HaveIt = true
+			have++
+		}
+	}
+	if len(benchmark.TopSkills) > 0 {
+		coverage := round1(float64(have) * 100 / float64(len(benchmark.TopSkills)))
+		benchmark.SkillCoverage = &coverage
+	}
+	return benchmark, nil
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
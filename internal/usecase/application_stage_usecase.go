@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"slices"
+	"strings"
+	"time"
+)
+
+type applicationStageUsecase struct {
+	stageRepo     domain.ApplicationStageRepository
+	jobRepo       domain.JobRepository
+	profileRepo   domain.CompanyProfileRepository
+	notifications domain.NotificationDispatcher
+	events        domain.RealtimePublisher
+	placements    domain.PlacementStatusRecorder
+}
+
+// NewApplicationStageUsecase creates a new application stage usecase
+func NewApplicationStageUsecase(
+	stageRepo domain.ApplicationStageRepository,
+	jobRepo domain.JobRepository,
+	profileRepo domain.CompanyProfileRepository,
+	notifications domain.NotificationDispatcher,
+	events domain.RealtimePublisher,
+	placements domain.PlacementStatusRecorder,
+) domain.ApplicationStageUsecase {
+	return &applicationStageUsecase{
+		stageRepo:     stageRepo,
+		jobRepo:       jobRepo,
+		profileRepo:   profileRepo,
+		notifications: notifications,
+		events:        events,
+		placements:    placements,
+	}
+}
+
+// GetStage returns the application's stage, the job's pipeline and the stage history
+func (u *applicationStageUsecase) GetStage(ctx context.Context, userID string, applicationID int64) (*domain.ApplicationStageView, error) {
+	target, err := u.employerApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	return u.stageView(ctx, target)
+}
+
+// MoveStage moves the application to another stage of its job's pipeline. The
+// candidate is only notified when the candidate-facing status changes.
+func (u *applicationStageUsecase) MoveStage(ctx context.Context, userID string, applicationID int64, req domain.MoveApplicationStageRequest) (*domain.ApplicationStageView, error) {
+	// 1. The application must belong to one of the employer's jobs
+	target, err := u.employerApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validate the target stage
+	if req.Stage == target.Stage {
+		return nil, apperror.BadRequest("Application is already in this stage")
+	}
+	if !slices.Contains(pipelineStages(target.PipelineStages), req.Stage) {
+		return nil, apperror.BadRequest("This job does not use the selected stage")
+	}
+
+	// 3. Move, failing if someone else moved it first
+	change := &domain.ApplicationStageChange{
+		ApplicationID: applicationID,
+		FromStage:     &target.Stage,
+		ToStage:       req.Stage,
+		ChangedBy:     &userID,
+		ChangedAt:     time.Now(),
+	}
+	if note := strings.TrimSpace(req.Note); note != "" {
+		change.Note = &note
+	}
+	status := domain.ApplicationStageStatus[req.Stage]
+	if err := u.stageRepo.MoveStage(ctx, change, status); err != nil {
+		if errors.Is(err, domain.ErrApplicationStageConflict) {
+			return nil, apperror.Conflict("Application was moved by someone else. Please refresh and try again")
+		}
+		return nil, apperror.Internal(errors.New("Failed to move application: " + err.Error()))
+	}
+
+	// 4. Tell the candidate about status changes only; internal stages stay private
+	if status != target.Status {
+		u.notifyStatus(ctx, target, status)
+		publishRealtime(ctx, u.events, target.CandidateUserID, domain.RealtimeEvent{
+			Type: domain.RealtimeEventApplicationStatus,
+			Data: domain.ApplicationStatusEvent{ApplicationID: target.ApplicationID, JobID: target.JobID, Status: status},
+		})
+		recordPlacementStatus(ctx, u.placements, target.ApplicationID)
+	}
+
+	target.Stage = req.Stage
+	target.Status = status
+	target.StageChangedAt = change.ChangedAt
+	return u.stageView(ctx, target)
+}
+
+// GetPipeline returns the stages a job uses
+func (u *applicationStageUsecase) GetPipeline(ctx context.Context, userID string, jobID int64) (*domain.JobPipeline, error) {
+	if _, err := u.employerJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	optional, err := u.stageRepo.GetPipelineStages(ctx, jobID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch pipeline: " + err.Error()))
+	}
+	return &domain.JobPipeline{JobID: jobID, Stages: pipelineStages(optional)}, nil
+}
+
+// UpdatePipeline selects the optional stages a job uses. Applications already in
+// a removed stage stay there until they are moved.
+func (u *applicationStageUsecase) UpdatePipeline(ctx context.Context, userID string, jobID int64, req domain.UpdateJobPipelineRequest) (*domain.JobPipeline, error) {
+	if _, err := u.employerJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	// Store in board order without duplicates
+	optional := []string{}
+	for _, stage := range domain.OptionalApplicationStages {
+		if slices.Contains(req.OptionalStages, stage) {
+			optional = append(optional, stage)
+		}
+	}
+	if err := u.stageRepo.SetPipelineStages(ctx, jobID, optional); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to update pipeline: " + err.Error()))
+	}
+	return &domain.JobPipeline{JobID: jobID, Stages: pipelineStages(optional)}, nil
+}
+
+// GetJobFunnel counts one job's applications per stage
+func (u *applicationStageUsecase) GetJobFunnel(ctx context.Context, userID string, jobID int64) (*domain.JobFunnel, error) {
+	company, err := u.employerJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	funnels, err := u.stageRepo.ListFunnels(ctx, company.ID, &jobID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch funnel: " + err.Error()))
+	}
+	if len(funnels) == 0 {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+	return &funnels[0], nil
+}
+
+// ListFunnels counts applications per stage for each of the employer's jobs
+func (u *applicationStageUsecase) ListFunnels(ctx context.Context, userID string) ([]domain.JobFunnel, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	funnels, err := u.stageRepo.ListFunnels(ctx, company.ID, nil)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch funnels: " + err.Error()))
+	}
+	return funnels, nil
+}
+
+func (u *applicationStageUsecase) employerCompany(ctx context.Context, userID string) (*domain.CompanyProfile, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+	return company, nil
+}
+
+// employerJob returns the employer's company if it owns the job. Other
+// companies' jobs are reported as not found.
+func (u *applicationStageUsecase) employerJob(ctx context.Context, userID string, jobID int64) (*domain.CompanyProfile, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	job, err := u.jobRepo.GetByID(ctx, jobID)
+	if err != nil || job.CompanyID != company.ID {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+	return company, nil
+}
+
+func (u *applicationStageUsecase) employerApplication(ctx context.Context, userID string, applicationID int64) (*domain.ApplicationStageTarget, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := u.stageRepo.GetTarget(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch application: " + err.Error()))
+	}
+	if target.JobCompanyID != company.ID {
+		return nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
+	}
+	return target, nil
+}
+
+func (u *applicationStageUsecase) stageView(ctx context.Context, target *domain.ApplicationStageTarget) (*domain.ApplicationStageView, error) {
+	history, err := u.stageRepo.ListHistory(ctx, target.ApplicationID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch stage history: " + err.Error()))
+	}
+	return &domain.ApplicationStageView{
+		ApplicationID:   target.ApplicationID,
+		JobID:           target.JobID,
+		Stage:           target.Stage,
+		Status:          target.Status,
+		StageChangedAt:  target.StageChangedAt,
+		AvailableStages: pipelineStages(target.PipelineStages),
+		History:         history,
+	}, nil
+}
+
+func (u *applicationStageUsecase) notifyStatus(ctx context.Context, target *domain.ApplicationStageTarget, status string) {
+	body, ok := applicationStatusMessages[status]
+	if u.notifications == nil || !ok {
+		return
+	}
+	if err := u.notifications.Dispatch(ctx, &domain.Notification{
+		UserID:      target.CandidateUserID,
+		Category:    domain.NotificationCategoryApplicationStatus,
+		Subject:     "Your application for %s was updated",
+		SubjectArgs: []any{target.JobTitle},
+		Body:        body,
+		BodyArgs:    []any{target.JobTitle},
+	}); err != nil {
+		logger.FromContext(ctx).Error("Application notification: failed to dispatch", "application_id", target.ApplicationID, "error", err)
+	}
+}
+
+// pipelineStages expands a job's optional stages into its full board; nil means all
+func pipelineStages(optional []string) []string {
+	stages := make([]string, 0, len(domain.ApplicationStages))
+	for _, stage := range domain.ApplicationStages {
+		if optional == nil || !slices.Contains(domain.OptionalApplicationStages, stage) || slices.Contains(optional, stage) {
+			stages = append(stages, stage)
+		}
+	}
+	return stages
+}
@@ -2,14 +2,29 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"slices"
+	"strings"
+	"time"
 )
 
 type applicationUsecase struct {
 	applicationRepo  domain.ApplicationRepository
 	jobRepo          domain.JobRepository
 	verificationRepo domain.VerificationRepository
+	questionRepo     domain.ScreeningQuestionRepository
+	draftRepo        domain.ApplicationDraftRepository
+	profileRepo      domain.CompanyProfileRepository
+	piiLogRepo       domain.PIIAccessLogRepository
+	notifications    domain.NotificationDispatcher
+	events           domain.RealtimePublisher
+	hooks            domain.WebhookPublisher
+	placements       domain.PlacementStatusRecorder
 }
 
 // NewApplicationUsecase creates a new application usecase
@@ -17,16 +32,34 @@ func NewApplicationUsecase(
 	appRepo domain.ApplicationRepository,
 	jobRepo domain.JobRepository,
 	verificationRepo domain.VerificationRepository,
+	questionRepo domain.ScreeningQuestionRepository,
+	draftRepo domain.ApplicationDraftRepository,
+	profileRepo domain.CompanyProfileRepository,
+	piiLogRepo domain.PIIAccessLogRepository,
+	notifications domain.NotificationDispatcher,
+	events domain.RealtimePublisher,
+	hooks domain.WebhookPublisher,
+	placements domain.PlacementStatusRecorder,
 ) domain.ApplicationUsecase {
 	return &applicationUsecase{
 		applicationRepo:  appRepo,
 		jobRepo:          jobRepo,
 		verificationRepo: verificationRepo,
+		questionRepo:     questionRepo,
+		draftRepo:        draftRepo,
+		profileRepo:      profileRepo,
+		piiLogRepo:       piiLogRepo,
+		notifications:    notifications,
+		events:           events,
+		hooks:            hooks,
+		placements:       placements,
 	}
 }
 
-// ApplyToJob allows a verified candidate to apply to an active job
-func (uc *applicationUsecase) ApplyToJob(ctx context.Context, userID string, jobID int64, cvURL, coverLetter string) (*domain.Application, error) {
+// ApplyToJob allows a verified candidate to apply to an active job.
+// Screening answers are validated against the job's questions; a disqualifying
+// answer stores the application as rejected (auto_rejected).
+func (uc *applicationUsecase) ApplyToJob(ctx context.Context, userID string, jobID int64, cvURL, coverLetter string, answers []domain.ScreeningAnswerInput) (*domain.Application, error) {
 	// 1. Validate CV is provided (required)
 	if cvURL == "" {
 		return nil, apperror.BadRequest("CV is required to submit an application")
@@ -35,19 +68,23 @@ func (uc *applicationUsecase) ApplyToJob(ctx context.Context, userID string, job
 	// 2. Validate job exists and is active
 	job, err := uc.jobRepo.GetByID(ctx, jobID)
 	if err != nil {
-		return nil, apperror.NotFound("Job not found")
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
 	}
-	if job.CompanyStatus != "active" {
+	now := time.Now()
+	if !job.IsPublished(now) {
 		return nil, apperror.BadRequest("Cannot apply to inactive job")
 	}
+	if job.DeadlinePassed(now) {
+		return nil, apperror.BadRequest("The application deadline for this job has passed").WithCode(domain.ErrCodeApplicationDeadlinePassed)
+	}
 
 	// 3. Validate candidate is verified
 	verification, err := uc.verificationRepo.GetByUserID(ctx, userID)
 	if err != nil || verification == nil {
-		return nil, apperror.Forbidden("Complete your profile before applying")
+		return nil, apperror.Forbidden("Complete your profile before applying").WithCode(domain.ErrCodeProfileIncomplete)
 	}
 	if verification.Status != domain.VerificationStatusVerified {
-		return nil, apperror.Forbidden("Your profile must be verified before you can apply")
+		return nil, apperror.Forbidden("Your profile must be verified before you can apply").WithCode(domain.ErrCodeVerificationRequired)
 	}
 
 	// 4. Check for duplicate application
@@ -56,10 +93,20 @@ func (uc *applicationUsecase) ApplyToJob(ctx context.Context, userID string, job
 		return nil, apperror.Internal(err)
 	}
 	if exists {
-		return nil, apperror.BadRequest("You have already applied to this job")
+		return nil, apperror.BadRequest("You have already applied to this job").WithCode(domain.ErrCodeAlreadyApplied)
+	}
+
+	// 5. Screening questions and knock-out rules
+	questions, err := uc.questionRepo.ListByJobID(ctx, jobID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	storedAnswers, knockedOut, err := evaluateScreeningAnswers(questions, answers)
+	if err != nil {
+		return nil, err
 	}
 
-	// 5. Create application
+	// 6. Create application
 	var coverLetterPtr *string
 	if coverLetter != "" {
 		coverLetterPtr = &coverLetter
@@ -72,12 +119,41 @@ func (uc *applicationUsecase) ApplyToJob(ctx context.Context, userID string, job
 		CvURL:                 cvURL,
 		CoverLetter:           coverLetterPtr,
 		Status:                domain.ApplicationStatusApplied,
+		Answers:               storedAnswers,
+	}
+	if knockedOut {
+		app.Status = domain.ApplicationStatusRejected
+		app.AutoRejected = true
 	}
 
 	if err := uc.applicationRepo.Create(ctx, app); err != nil {
+		if errors.Is(err, domain.ErrJobApplicantCapReached) {
+			return nil, apperror.BadRequest("This job is no longer accepting applications")
+		}
 		return nil, apperror.Internal(err)
 	}
 
+	// 7. The draft has served its purpose (the reminder worker also skips applied jobs)
+	if err := uc.draftRepo.Delete(ctx, userID, jobID); err != nil {
+		logger.FromContext(ctx).Warn("Failed to delete application draft", "job_id", jobID, "error", err)
+	}
+
+	// 8. Tell the employer; knocked-out applications are not worth an email
+	if !app.AutoRejected {
+		uc.notifyApplicationReceived(ctx, job, app, verification)
+	}
+
+	// 9. Let subscribed integrations (e.g. HR tools) sync the new application
+	publishWebhook(ctx, uc.hooks, domain.WebhookEventApplicationCreated, domain.ApplicationCreatedWebhook{
+		ApplicationID:   app.ID,
+		JobID:           app.JobID,
+		CompanyID:       job.CompanyID,
+		CandidateUserID: app.CandidateUserID,
+		Status:          app.Status,
+		CreatedAt:       app.CreatedAt,
+	})
+	recordPlacementStatus(ctx, uc.placements, app.ID)
+
 	return app, nil
 }
 
@@ -86,6 +162,53 @@ func (uc *applicationUsecase) GetMyApplications(ctx context.Context, userID stri
 	return uc.applicationRepo.GetByUserID(ctx, userID)
 }
 
+// GetMyApplicationHistory returns a page of the candidate's applications with
+// their stage history, interviews and delivered feedback
+func (uc *applicationUsecase) GetMyApplicationHistory(ctx context.Context, userID string, filter domain.ApplicationHistoryFilter, locale string) (*domain.PaginatedResult[domain.CandidateApplicationView], error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	if filter.Status != "" && !slices.Contains(domain.ApplicationStatuses, filter.Status) {
+		return nil, apperror.BadRequest("Invalid status. Must be one of: applied, reviewed, accepted, rejected")
+	}
+	if filter.SortBy == "" {
+		filter.SortBy = domain.ApplicationHistorySortUpdated
+	}
+	if filter.SortBy != domain.ApplicationHistorySortUpdated && filter.SortBy != domain.ApplicationHistorySortApplied {
+		return nil, apperror.BadRequest("Invalid sort_by. Must be applied_at or updated_at")
+	}
+	if filter.SortOrder == "" {
+		filter.SortOrder = "desc"
+	}
+	if filter.SortOrder != "asc" && filter.SortOrder != "desc" {
+		return nil, apperror.BadRequest("Invalid sort_order. Must be asc or desc")
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > domain.MaxApplicationHistoryPageSize {
+		filter.PageSize = domain.DefaultApplicationHistoryPageSize
+	}
+
+	views, total, err := uc.applicationRepo.ListCandidateHistory(ctx, userID, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch application history: " + err.Error()))
+	}
+	for i := range views {
+		if f := views[i].Feedback; f != nil {
+			f.Messages = interviewFeedbackMessages(f.TemplateKeys, locale)
+		}
+	}
+
+	return &domain.PaginatedResult[domain.CandidateApplicationView]{
+		Data:       views,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
 // ListByJobID returns all applications for a job (employer only, validated by ownership)
 func (uc *applicationUsecase) ListByJobID(ctx context.Context, userID string, jobID int64) ([]domain.Application, error) {
 	// 1. Validate employer owns this job
@@ -102,7 +225,7 @@ func (uc *applicationUsecase) GetApplicationDetail(ctx context.Context, userID s
 	// 1. Get application
 	app, err := uc.applicationRepo.GetByID(ctx, applicationID)
 	if err != nil {
-		return nil, apperror.NotFound("Application not found")
+		return nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
 	}
 
 	// 2. Validate employer owns the job
@@ -121,6 +244,11 @@ func (uc *applicationUsecase) GetApplicationDetail(ctx context.Context, userID s
 		}
 	}
 
+	// 4. Screening answers
+	if app.Answers, err = uc.applicationRepo.GetAnswers(ctx, app.ID); err != nil {
+		return nil, apperror.Internal(err)
+	}
+
 	return &domain.ApplicationDetailResponse{
 		Application:  app,
 		Verification: verification,
@@ -144,7 +272,7 @@ func (uc *applicationUsecase) UpdateApplicationStatus(ctx context.Context, userI
 	// 2. Get application
 	app, err := uc.applicationRepo.GetByID(ctx, applicationID)
 	if err != nil {
-		return apperror.NotFound("Application not found")
+		return apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
 	}
 
 	// 3. Validate employer owns the job
@@ -152,8 +280,266 @@ func (uc *applicationUsecase) UpdateApplicationStatus(ctx context.Context, userI
 		return err
 	}
 
-	// 4. Update status (also updates updated_at in repository)
-	return uc.applicationRepo.UpdateStatus(ctx, applicationID, status)
+	// 4. Update status (also updates updated_at and the pipeline stage in repository)
+	if err := uc.applicationRepo.UpdateStatus(ctx, applicationID, status, userID); err != nil {
+		return err
+	}
+
+	// 5. Tell the candidate
+	uc.notifyApplicationStatus(ctx, app, status)
+	publishRealtime(ctx, uc.events, app.CandidateUserID, domain.RealtimeEvent{
+		Type: domain.RealtimeEventApplicationStatus,
+		Data: domain.ApplicationStatusEvent{ApplicationID: app.ID, JobID: app.JobID, Status: status},
+	})
+	if status != app.Status {
+		recordPlacementStatus(ctx, uc.placements, app.ID)
+	}
+	return nil
+}
+
+// GetScreeningQuestions returns a job's questions without knock-out rules (candidate view)
+func (uc *applicationUsecase) GetScreeningQuestions(ctx context.Context, jobID int64) ([]domain.ScreeningQuestion, error) {
+	if _, err := uc.jobRepo.GetByID(ctx, jobID); err != nil {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+
+	questions, err := uc.questionRepo.ListByJobID(ctx, jobID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	for i := range questions {
+		questions[i] = questions[i].ForCandidate()
+	}
+	return questions, nil
+}
+
+// GetJobScreeningQuestions returns a job's questions including knock-out rules (employer view)
+func (uc *applicationUsecase) GetJobScreeningQuestions(ctx context.Context, userID string, jobID int64) ([]domain.ScreeningQuestion, error) {
+	if err := uc.validateJobOwnership(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	questions, err := uc.questionRepo.ListByJobID(ctx, jobID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return questions, nil
+}
+
+// ReplaceScreeningQuestions replaces the job's question set. Answers already
+// submitted keep their own copy of the question text.
+func (uc *applicationUsecase) ReplaceScreeningQuestions(ctx context.Context, userID string, jobID int64, req domain.ReplaceScreeningQuestionsRequest) ([]domain.ScreeningQuestion, error) {
+	// 1. Validate employer owns the job
+	if err := uc.validateJobOwnership(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	// 2. Validate questions
+	if len(req.Questions) > domain.MaxScreeningQuestions {
+		return nil, apperror.BadRequest(fmt.Sprintf("A job can have at most %d screening questions", domain.MaxScreeningQuestions))
+	}
+	questions := make([]domain.ScreeningQuestion, 0, len(req.Questions))
+	for i, input := range req.Questions {
+		q, err := buildScreeningQuestion(input)
+		if err != nil {
+			return nil, apperror.BadRequest(fmt.Sprintf("Question %d: %s", i+1, err.Error()))
+		}
+		q.Position = i + 1
+		questions = append(questions, q)
+	}
+
+	// 3. Save
+	if err := uc.questionRepo.ReplaceForJob(ctx, jobID, questions); err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return questions, nil
+}
+
+// buildScreeningQuestion normalizes an input and checks type-specific rules
+func buildScreeningQuestion(input domain.ScreeningQuestionInput) (domain.ScreeningQuestion, error) {
+	q := domain.ScreeningQuestion{
+		Type:     input.Type,
+		Prompt:   strings.TrimSpace(input.Prompt),
+		Required: input.Required == nil || *input.Required,
+	}
+	if q.Prompt == "" {
+		return q, errors.New("prompt is required")
+	}
+
+	switch input.Type {
+	case domain.QuestionTypeSingleChoice:
+		if len(input.Options) < 2 {
+			return q, errors.New("single-choice questions need at least 2 options")
+		}
+		for _, option := range input.Options {
+			option = strings.TrimSpace(option)
+			if slices.Contains(q.Options, option) {
+				return q, fmt.Errorf("duplicate option %q", option)
+			}
+			q.Options = append(q.Options, option)
+		}
+		for _, knockout := range input.KnockoutAnswers {
+			if !slices.Contains(q.Options, strings.TrimSpace(knockout)) {
+				return q, fmt.Errorf("knock-out answer %q is not one of the options", knockout)
+			}
+			q.KnockoutAnswers = append(q.KnockoutAnswers, strings.TrimSpace(knockout))
+		}
+		if len(q.KnockoutAnswers) == len(q.Options) {
+			return q, errors.New("at least one option must not be a knock-out answer")
+		}
+	case domain.QuestionTypeYesNo:
+		if len(input.Options) > 0 {
+			return q, errors.New("yes/no questions cannot have options")
+		}
+		for _, knockout := range input.KnockoutAnswers {
+			knockout = strings.ToLower(strings.TrimSpace(knockout))
+			if knockout != "yes" && knockout != "no" {
+				return q, errors.New("yes/no knock-out answers must be \"yes\" or \"no\"")
+			}
+			if !slices.Contains(q.KnockoutAnswers, knockout) {
+				q.KnockoutAnswers = append(q.KnockoutAnswers, knockout)
+			}
+		}
+		if len(q.KnockoutAnswers) == 2 {
+			return q, errors.New("yes and no cannot both be knock-out answers")
+		}
+	default: // TEXT, FILE: free-form, no automatic knock-out
+		if len(input.Options) > 0 || len(input.KnockoutAnswers) > 0 {
+			return q, errors.New("options and knock-out answers are only allowed on single-choice and yes/no questions")
+		}
+	}
+
+	// Only required questions can knock a candidate out; an optional one could just be skipped
+	if len(q.KnockoutAnswers) > 0 && !q.Required {
+		return q, errors.New("knock-out questions must be required")
+	}
+	return q, nil
+}
+
+// evaluateScreeningAnswers validates answers against the job's questions and
+// reports whether any answer is disqualifying
+func evaluateScreeningAnswers(questions []domain.ScreeningQuestion, inputs []domain.ScreeningAnswerInput) ([]domain.ApplicationAnswer, bool, error) {
+	byQuestion := make(map[int64]domain.ScreeningAnswerInput, len(inputs))
+	for _, input := range inputs {
+		if _, dup := byQuestion[input.QuestionID]; dup {
+			return nil, false, apperror.BadRequest("Each screening question can only be answered once")
+		}
+		byQuestion[input.QuestionID] = input
+	}
+
+	var answers []domain.ApplicationAnswer
+	knockedOut := false
+	for _, q := range questions {
+		input, ok := byQuestion[q.ID]
+		delete(byQuestion, q.ID)
+
+		answer := strings.TrimSpace(input.Answer)
+		answered := ok && (answer != "" || (input.FileURL != nil && *input.FileURL != ""))
+		if !answered {
+			if q.Required {
+				return nil, false, apperror.BadRequest("Please answer the required question: " + q.Prompt)
+			}
+			continue
+		}
+
+		questionID := q.ID
+		stored := domain.ApplicationAnswer{QuestionID: &questionID, Prompt: q.Prompt, Type: q.Type}
+		switch q.Type {
+		case domain.QuestionTypeFile:
+			if input.FileURL == nil || *input.FileURL == "" {
+				return nil, false, apperror.BadRequest("Please upload a file for: " + q.Prompt)
+			}
+			stored.FileURL = input.FileURL
+		case domain.QuestionTypeYesNo:
+			answer = strings.ToLower(answer)
+			if answer != "yes" && answer != "no" {
+				return nil, false, apperror.BadRequest("Please answer yes or no for: " + q.Prompt)
+			}
+		case domain.QuestionTypeSingleChoice:
+			if !slices.Contains(q.Options, answer) {
+				return nil, false, apperror.BadRequest("Please choose one of the options for: " + q.Prompt)
+			}
+		}
+		if q.Type != domain.QuestionTypeFile {
+			stored.Answer = &answer
+			stored.Disqualifying = slices.Contains(q.KnockoutAnswers, answer)
+		}
+
+		knockedOut = knockedOut || stored.Disqualifying
+		answers = append(answers, stored)
+	}
+
+	if len(byQuestion) > 0 {
+		return nil, false, apperror.BadRequest("Answers were submitted for questions that are not on this job")
+	}
+	return answers, knockedOut, nil
+}
+
+// notifyApplicationReceived notifies the job's employer; they get a digest, not one email
+// per application, and a live update if they are connected
+func (uc *applicationUsecase) notifyApplicationReceived(ctx context.Context, job *domain.Job, app *domain.Application, verification *domain.AccountVerification) {
+	if uc.notifications == nil && uc.events == nil {
+		return
+	}
+	company, err := uc.profileRepo.GetByID(ctx, job.CompanyID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Application notification: failed to load company", "company_id", job.CompanyID, "job_id", job.ID, "error", err)
+		return
+	}
+
+	publishRealtime(ctx, uc.events, company.UserID, domain.RealtimeEvent{
+		Type: domain.RealtimeEventApplicationReceived,
+		Data: domain.ApplicationReceivedEvent{ApplicationID: app.ID, JobID: job.ID, JobTitle: job.Title},
+	})
+	if uc.notifications == nil {
+		return
+	}
+
+	n := &domain.Notification{
+		UserID:      company.UserID,
+		Category:    domain.NotificationCategoryApplicationReceived,
+		Subject:     "New application for %s",
+		SubjectArgs: []any{job.Title},
+		Body:        "A candidate applied to %s.",
+		BodyArgs:    []any{job.Title},
+	}
+	if verification.FirstName != nil && strings.TrimSpace(*verification.FirstName) != "" {
+		n.Body = "%s applied to %s."
+		n.BodyArgs = []any{strings.TrimSpace(*verification.FirstName), job.Title}
+	}
+	if err := uc.notifications.Dispatch(ctx, n); err != nil {
+		logger.FromContext(ctx).Error("Application notification: failed to dispatch", "job_id", job.ID, "error", err)
+	}
+}
+
+// notifyApplicationStatus tells the candidate about a decision right away
+func (uc *applicationUsecase) notifyApplicationStatus(ctx context.Context, app *domain.Application, status string) {
+	if uc.notifications == nil {
+		return
+	}
+	job, err := uc.jobRepo.GetByID(ctx, app.JobID)
+	if err != nil {
+		logger.FromContext(ctx).Error("Application notification: failed to load job", "job_id", app.JobID, "error", err)
+		return
+	}
+
+	if err := uc.notifications.Dispatch(ctx, &domain.Notification{
+		UserID:      app.CandidateUserID,
+		Category:    domain.NotificationCategoryApplicationStatus,
+		Subject:     "Your application for %s was updated",
+		SubjectArgs: []any{job.Title},
+		Body:        applicationStatusMessages[status],
+		BodyArgs:    []any{job.Title},
+	}); err != nil {
+		logger.FromContext(ctx).Error("Application notification: failed to dispatch", "application_id", app.ID, "error", err)
+	}
+}
+
+// applicationStatusMessages are i18n source messages for status notifications
+var applicationStatusMessages = map[string]string{
+	domain.ApplicationStatusReviewed: "Your application for %s has been reviewed by the employer.",
+	domain.ApplicationStatusAccepted: "Congratulations! Your application for %s has been accepted.",
+	domain.ApplicationStatusRejected: "Your application for %s was not selected this time.",
 }
 
 // validateJobOwnership checks if the user can access the job's applications
@@ -163,7 +549,7 @@ func (uc *applicationUsecase) validateJobOwnership(ctx context.Context, userID s
 	// Verify job exists
 	_, err := uc.jobRepo.GetByID(ctx, jobID)
 	if err != nil {
-		return apperror.NotFound("Job not found")
+		return apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
 	}
 
 	// TODO: Implement proper company ownership validation when company_profiles table is linked
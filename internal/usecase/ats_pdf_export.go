@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/pdf"
+	"go-recruitment-backend/pkg/storage"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxProfilePhotoBytes bounds a downloaded profile picture (uploads are compressed well below this)
+const maxProfilePhotoBytes = 5 << 20
+
+// exportPDFProfiles streams a ZIP holding one PDF profile per candidate. Each
+// profile is rendered and written before the next photo is fetched, so memory
+// stays bounded by a single candidate however large the selection.
+func (u *atsUsecase) exportPDFProfiles(ctx context.Context, candidates []domain.ATSCandidate) *domain.ATSExportFile {
+	now := time.Now().UTC()
+	return &domain.ATSExportFile{
+		Filename:    fmt.Sprintf("ats_profiles_%s.zip", now.Format("20060102_150405")),
+		ContentType: "application/zip",
+		Write: func(w io.Writer) error {
+			zw := zip.NewWriter(w)
+			for i, c := range candidates {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				entry, err := zw.Create(fmt.Sprintf("%03d_%s.pdf", i+1, atsProfileFileLabel(c.FullName)))
+				if err != nil {
+					return err
+				}
+				doc := renderATSProfile(ctx, c, u.fetchPhoto(ctx, c), now)
+				if _, err := doc.WriteTo(entry); err != nil {
+					return fmt.Errorf("failed to write profile for %s: %w", c.UserID, err)
+				}
+			}
+			return zw.Close()
+		},
+	}
+}
+
+// fetchPhoto returns the candidate's profile picture, or nil when there is none
+// or it cannot be downloaded (the profile is still useful without it)
+func (u *atsUsecase) fetchPhoto(ctx context.Context, c domain.ATSCandidate) []byte {
+	if u.photos == nil || c.ProfilePictureURL == nil || *c.ProfilePictureURL == "" {
+		return nil
+	}
+	photo, err := u.photos.FetchPhoto(ctx, *c.ProfilePictureURL)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Skipping profile photo in PDF export", "user_id", c.UserID, "error", err)
+		return nil
+	}
+	return photo
+}
+
+// renderATSProfile lays out a one-page candidate profile with the photo top right
+func renderATSProfile(ctx context.Context, c domain.ATSCandidate, photo []byte, now time.Time) *pdf.Document {
+	doc := pdf.New("Candidate Profile - " + c.FullName)
+	doc.SetFooter("Confidential - candidate personal data")
+
+	if photo != nil {
+		if err := doc.Photo(photo, 90); err != nil {
+			logger.FromContext(ctx).Warn("Skipping unreadable profile photo", "user_id", c.UserID, "error", err)
+		}
+	}
+
+	doc.Heading(c.FullName)
+	if c.Age != nil {
+		doc.Field("Age", fmt.Sprintf("%d", *c.Age))
+	}
+	doc.Field("Gender", derefString(c.Gender))
+	doc.Field("Domicile", derefString(c.DomicileCity))
+	doc.Field("Verification", c.VerificationStatus)
+	if c.PhoneVerified {
+		doc.Field("Phone", "Verified")
+	}
+
+	doc.Subheading("Japanese & Japan Experience")
+	doc.Field("JLPT level", derefString(c.JapaneseLevel))
+	if c.JapanExperienceMonths != nil {
+		doc.Field("Experience in Japan", fmt.Sprintf("%d months", *c.JapanExperienceMonths))
+	}
+	doc.Field("LPK training", derefString(c.LPKTrainingName))
+	doc.Field("CoE status", derefString(c.CoEStatus))
+	if c.VisaReady {
+		doc.Field("Visa ready", "Yes")
+	}
+	doc.Field("SSW passed sectors", strings.Join(c.SSWSectors, ", "))
+
+	doc.Subheading("Experience & Education")
+	doc.Field("Last position", derefString(c.LastPosition))
+	if c.TotalExperienceMonths != nil {
+		doc.Field("Total experience", fmt.Sprintf("%d months", *c.TotalExperienceMonths))
+	}
+	doc.Field("Education", derefString(c.HighestEducation))
+	doc.Field("Major", derefString(c.MajorField))
+
+	doc.Subheading("Skills")
+	if len(c.Skills) > 0 {
+		doc.Paragraph(strings.Join(c.Skills, ", "))
+	}
+	if c.EnglishCertType != nil {
+		english := *c.EnglishCertType
+		if c.EnglishScore != nil {
+			english += fmt.Sprintf(" %g", *c.EnglishScore)
+		}
+		doc.Field("English", english)
+	}
+
+	doc.Subheading("Availability")
+	if c.ExpectedSalary != nil {
+		doc.Field("Expected salary", fmt.Sprintf("IDR %d", *c.ExpectedSalary))
+	}
+	if c.AvailableStartDate != nil {
+		doc.Field("Available from", c.AvailableStartDate.Format("2006-01-02"))
+	}
+
+	doc.Space(12)
+	doc.Note("Generated " + now.Format("2006-01-02 15:04 UTC"))
+	return doc
+}
+
+// atsProfileFileLabel is the ASCII file name part for a candidate's profile
+func atsProfileFileLabel(name string) string {
+	return resumeFileLabel(&domain.CandidateContact{Name: name}, domain.ResumeModeFull)
+}
+
+// publicStoragePhotoFetcher downloads profile pictures from the public
+// Profile_Picture bucket. Any other URL is refused, so a tampered profile cannot
+// make the server fetch arbitrary hosts.
+type publicStoragePhotoFetcher struct {
+	prefix string
+	client *http.Client
+}
+
+// NewPublicStoragePhotoFetcher fetches photos stored in store
+func NewPublicStoragePhotoFetcher(store storage.Store) domain.CandidatePhotoFetcher {
+	return &publicStoragePhotoFetcher{
+		prefix: store.URL("Profile_Picture", ""),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (f *publicStoragePhotoFetcher) FetchPhoto(ctx context.Context, url string) ([]byte, error) {
+	if !strings.HasPrefix(url, f.prefix) || strings.Contains(strings.TrimPrefix(url, f.prefix), "..") {
+		return nil, errors.New("photo is not in the profile picture bucket")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("photo download returned %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxProfilePhotoBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxProfilePhotoBytes {
+		return nil, errors.New("photo is too large")
+	}
+	return data, nil
+}
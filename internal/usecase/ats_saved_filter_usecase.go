@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"strings"
+)
+
+type atsSavedFilterUsecase struct {
+	repo domain.ATSSavedFilterRepository
+}
+
+// NewATSSavedFilterUsecase creates the usecase for admins' saved ATS filters
+func NewATSSavedFilterUsecase(repo domain.ATSSavedFilterRepository) domain.ATSSavedFilterUsecase {
+	return &atsSavedFilterUsecase{repo: repo}
+}
+
+func (u *atsSavedFilterUsecase) ListFilters(ctx context.Context) ([]domain.ATSSavedFilter, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+
+	filters, err := u.repo.ListVisible(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch saved filters: " + err.Error()))
+	}
+	return filters, nil
+}
+
+func (u *atsSavedFilterUsecase) GetFilter(ctx context.Context, id int64) (*domain.ATSSavedFilter, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.visibleFilter(ctx, id)
+}
+
+func (u *atsSavedFilterUsecase) CreateFilter(ctx context.Context, req domain.ATSSavedFilterRequest) (*domain.ATSSavedFilter, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	filter, err := savedATSFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	saved := &domain.ATSSavedFilter{
+		OwnerUserID: userID,
+		Name:        strings.TrimSpace(req.Name),
+		Filter:      filter,
+		Shared:      req.Shared,
+	}
+	if err := u.repo.Create(ctx, saved); err != nil {
+		return nil, wrapATSSavedFilterError(err, "Failed to save filter: ")
+	}
+	return u.visibleFilter(ctx, saved.ID)
+}
+
+func (u *atsSavedFilterUsecase) UpdateFilter(ctx context.Context, id int64, req domain.ATSSavedFilterRequest) (*domain.ATSSavedFilter, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	saved, err := u.ownFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := savedATSFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	saved.Name = strings.TrimSpace(req.Name)
+	saved.Filter = filter
+	saved.Shared = req.Shared
+	if err := u.repo.Update(ctx, saved); err != nil {
+		return nil, wrapATSSavedFilterError(err, "Failed to update saved filter: ")
+	}
+	return saved, nil
+}
+
+func (u *atsSavedFilterUsecase) DeleteFilter(ctx context.Context, id int64) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	if _, err := u.ownFilter(ctx, id); err != nil {
+		return err
+	}
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return wrapATSSavedFilterError(err, "Failed to delete saved filter: ")
+	}
+	return nil
+}
+
+func (u *atsSavedFilterUsecase) UseFilter(ctx context.Context, id int64) (*domain.ATSFilter, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	saved, err := u.visibleFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// A missed count must not fail the search
+	if err := u.repo.MarkUsed(ctx, id); err != nil {
+		logger.FromContext(ctx).Warn("Failed to count saved filter run", "filter_id", id, "error", err)
+	}
+	return &saved.Filter, nil
+}
+
+// visibleFilter loads a filter the caller owns or another admin shared; others
+// are reported as not found
+func (u *atsSavedFilterUsecase) visibleFilter(ctx context.Context, id int64) (*domain.ATSSavedFilter, error) {
+	saved, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, wrapATSSavedFilterError(err, "Failed to fetch saved filter: ")
+	}
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	if saved.OwnerUserID != userID && !saved.Shared {
+		return nil, apperror.NotFound("Saved filter not found")
+	}
+	return saved, nil
+}
+
+// ownFilter loads a filter the caller may change
+func (u *atsSavedFilterUsecase) ownFilter(ctx context.Context, id int64) (*domain.ATSSavedFilter, error) {
+	saved, err := u.visibleFilter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	if saved.OwnerUserID != userID {
+		return nil, apperror.Forbidden("Only the owner can change a saved filter")
+	}
+	return saved, nil
+}
+
+// savedATSFilter validates the filter like a search and drops its pagination
+func savedATSFilter(req domain.ATSSavedFilterRequest) (domain.ATSFilter, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return domain.ATSFilter{}, apperror.BadRequest("Filter name is required")
+	}
+	filter := req.Filter
+	if err := validateATSFilter(&filter); err != nil {
+		return domain.ATSFilter{}, apperror.BadRequest(err.Error())
+	}
+	filter.Page, filter.PageSize = 0, 0
+	return filter, nil
+}
+
+func wrapATSSavedFilterError(err error, prefix string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return apperror.NotFound("Saved filter not found")
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return apperror.Internal(errors.New(prefix + err.Error()))
+}
@@ -3,8 +3,16 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/tracing"
+	"io"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -13,16 +21,84 @@ import (
 )
 
 type atsUsecase struct {
-	repo domain.ATSRepository
+	repo         domain.ATSRepository
+	companyRepo  domain.CompanyProfileRepository
+	exportAudits domain.ATSExportAuditRepository
+	photos       domain.CandidatePhotoFetcher
 }
 
-// NewATSUsecase creates a new ATS usecase instance
-func NewATSUsecase(repo domain.ATSRepository) domain.ATSUsecase {
-	return &atsUsecase{repo: repo}
+// NewATSUsecase creates a new ATS usecase instance. Every export is recorded in
+// exportAudits before it is returned. photos may be nil, in which case PDF
+// profiles are rendered without pictures.
+func NewATSUsecase(repo domain.ATSRepository, companyRepo domain.CompanyProfileRepository, exportAudits domain.ATSExportAuditRepository, photos domain.CandidatePhotoFetcher) domain.ATSUsecase {
+	return &atsUsecase{repo: repo, companyRepo: companyRepo, exportAudits: exportAudits, photos: photos}
 }
 
 // SearchCandidates searches candidates with validation and returns paginated results
 func (u *atsUsecase) SearchCandidates(ctx context.Context, filter domain.ATSFilter) (*domain.PaginatedResult[domain.ATSCandidate], error) {
+	ctx, span := tracing.Start(ctx, "ats.SearchCandidates")
+	defer span.End()
+
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := validateATSFilter(&filter); err != nil {
+		return nil, err
+	}
+	traceATSFilter(span, filter)
+	filter.ScopeProvinces = domain.AdminProvincesFromContext(ctx)
+
+	candidates, total, err := u.repo.SearchCandidates(ctx, filter)
+	if err != nil {
+		span.SetError(err)
+		return nil, fmt.Errorf("failed to search candidates: %w", err)
+	}
+	span.Set("ats.total", total)
+
+	return atsPage(candidates, total, filter), nil
+}
+
+// SearchEmployerCandidates searches the candidates visible to the employer's company:
+// applicants to its jobs and talent pool members, with PII masked by the repository
+func (u *atsUsecase) SearchEmployerCandidates(ctx context.Context, userID string, filter domain.ATSFilter) (*domain.PaginatedResult[domain.ATSCandidate], error) {
+	ctx, span := tracing.Start(ctx, "ats.SearchEmployerCandidates")
+	defer span.End()
+
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+	if err := requireATSAccess(company); err != nil {
+		return nil, err
+	}
+
+	// Cohorts are internal to admins and LPKs
+	filter.CohortIDs = nil
+	if err := validateATSFilter(&filter); err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+	traceATSFilter(span, filter)
+	span.Set("company.id", company.ID)
+
+	candidates, total, err := u.repo.SearchCandidatesForCompany(ctx, company.ID, filter)
+	if err != nil {
+		span.SetError(err)
+		return nil, apperror.Internal(errors.New("Failed to search candidates: " + err.Error()))
+	}
+	span.Set("ats.total", total)
+
+	return atsPage(candidates, total, filter), nil
+}
+
+// validateATSFilter applies pagination defaults and rejects inconsistent filters
+func validateATSFilter(filter *domain.ATSFilter) error {
 	// Validate and set defaults
 	if filter.Page < 1 {
 		filter.Page = 1
@@ -37,35 +113,60 @@ func (u *atsUsecase) SearchCandidates(ctx context.Context, filter domain.ATSFilt
 	// Validate age range
 	if filter.AgeMin != nil && filter.AgeMax != nil {
 		if *filter.AgeMin > *filter.AgeMax {
-			return nil, fmt.Errorf("minimum age cannot be greater than maximum age")
+			return fmt.Errorf("minimum age cannot be greater than maximum age")
 		}
 	}
 	if filter.AgeMin != nil && (*filter.AgeMin < 18 || *filter.AgeMin > 100) {
-		return nil, fmt.Errorf("age must be between 18 and 100")
+		return fmt.Errorf("age must be between 18 and 100")
 	}
 	if filter.AgeMax != nil && (*filter.AgeMax < 18 || *filter.AgeMax > 100) {
-		return nil, fmt.Errorf("age must be between 18 and 100")
+		return fmt.Errorf("age must be between 18 and 100")
 	}
 
 	// Validate salary range
 	if filter.ExpectedSalaryMin != nil && filter.ExpectedSalaryMax != nil {
 		if *filter.ExpectedSalaryMin > *filter.ExpectedSalaryMax {
-			return nil, fmt.Errorf("minimum salary cannot be greater than maximum salary")
+			return fmt.Errorf("minimum salary cannot be greater than maximum salary")
 		}
 	}
 
 	// Validate experience range
 	if filter.TotalExperienceMin != nil && filter.TotalExperienceMax != nil {
 		if *filter.TotalExperienceMin > *filter.TotalExperienceMax {
-			return nil, fmt.Errorf("minimum experience cannot be greater than maximum experience")
+			return fmt.Errorf("minimum experience cannot be greater than maximum experience")
 		}
 	}
 
-	candidates, total, err := u.repo.SearchCandidates(ctx, filter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search candidates: %w", err)
+	// Validate visa readiness filters
+	if filter.SSWLevel != nil && (*filter.SSWLevel < 1 || *filter.SSWLevel > 2) {
+		return fmt.Errorf("ssw level must be 1 or 2")
+	}
+	for _, status := range filter.CoEStatuses {
+		if !slices.Contains(domain.ValidCoEStatuses, status) {
+			return fmt.Errorf("invalid coe status: %s", status)
+		}
+	}
+	for _, sector := range filter.SSWSectors {
+		if !slices.Contains(domain.ValidSSWSectors, sector) {
+			return fmt.Errorf("invalid ssw sector: %s", sector)
+		}
 	}
 
+	// Validate quiz score filters
+	return validateQuizScoreFilter(*filter)
+}
+
+// traceATSFilter records the shape of a search, not its values, so slow filter
+// combinations can be found without logging candidate criteria
+func traceATSFilter(span *tracing.Span, filter domain.ATSFilter) {
+	span.Set("ats.page", filter.Page)
+	span.Set("ats.page_size", filter.PageSize)
+	span.Set("ats.sort_by", filter.SortBy)
+	span.Set("ats.sort_order", filter.SortOrder)
+}
+
+// atsPage wraps one page of search results
+func atsPage(candidates []domain.ATSCandidate, total int64, filter domain.ATSFilter) *domain.PaginatedResult[domain.ATSCandidate] {
 	totalPages := int(total) / filter.PageSize
 	if int(total)%filter.PageSize > 0 {
 		totalPages++
@@ -77,23 +178,59 @@ func (u *atsUsecase) SearchCandidates(ctx context.Context, filter domain.ATSFilt
 		Page:       filter.Page,
 		PageSize:   filter.PageSize,
 		TotalPages: totalPages,
-	}, nil
+	}
 }
 
 // GetFilterOptions returns all available filter options for the UI
 func (u *atsUsecase) GetFilterOptions(ctx context.Context) (*domain.ATSFilterOptions, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
 	return u.repo.GetFilterOptions(ctx)
 }
 
-// ExportCandidates exports candidates to Excel or CSV format
-func (u *atsUsecase) ExportCandidates(ctx context.Context, req domain.ATSExportRequest) ([]byte, string, error) {
-	// Limit export to 10,000 rows
+// ExportCandidates exports candidates to Excel or CSV format, or as a ZIP of
+// one-page PDF profiles
+func (u *atsUsecase) ExportCandidates(ctx context.Context, req domain.ATSExportRequest) (*domain.ATSExportFile, error) {
+	ctx, span := tracing.Start(ctx, "ats.ExportCandidates")
+	defer span.End()
+	span.Set("ats.format", req.Format)
+
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if req.Format == "" {
+		req.Format = "xlsx"
+	}
+	if !slices.Contains([]string{"xlsx", "csv", "pdf"}, req.Format) {
+		return nil, fmt.Errorf("unsupported export format: %s", req.Format)
+	}
+
+	// Limit export to 10,000 rows (PDF profiles are far heavier per row)
 	req.Filter.Page = 1
 	req.Filter.PageSize = 10000
+	if req.Format == "pdf" {
+		req.Filter.PageSize = domain.MaxATSPDFExportCandidates
+	}
+	if err := validateQuizScoreFilter(req.Filter); err != nil {
+		return nil, err
+	}
+	req.Filter.ScopeProvinces = domain.AdminProvincesFromContext(ctx)
 
-	candidates, _, err := u.repo.SearchCandidates(ctx, req.Filter)
+	candidates, total, err := u.repo.SearchCandidates(ctx, req.Filter)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to fetch candidates for export: %w", err)
+		return nil, fmt.Errorf("failed to fetch candidates for export: %w", err)
+	}
+	if req.Format == "pdf" {
+		if total > domain.MaxATSPDFExportCandidates {
+			return nil, apperror.BadRequest(fmt.Sprintf("PDF export is limited to %d candidates; narrow the filters", domain.MaxATSPDFExportCandidates))
+		}
+		// A profile shows every exportable field
+		if err := u.recordExport(ctx, req, candidates, domain.ExportableColumns); err != nil {
+			return nil, err
+		}
+		return u.exportPDFProfiles(ctx, candidates), nil
 	}
 
 	if len(req.Columns) == 0 {
@@ -125,18 +262,119 @@ func (u *atsUsecase) ExportCandidates(ctx context.Context, req domain.ATSExportR
 	}
 	for _, col := range req.Columns {
 		if !validColumns[col] {
-			return nil, "", fmt.Errorf("invalid export column: %s", col)
+			return nil, fmt.Errorf("invalid export column: %s", col)
 		}
 	}
 
-	switch req.Format {
-	case "csv":
-		return u.exportCSV(candidates, req.Columns)
-	case "xlsx", "":
-		return u.exportExcel(candidates, req.Columns)
-	default:
-		return nil, "", fmt.Errorf("unsupported export format: %s", req.Format)
+	if err := u.recordExport(ctx, req, candidates, req.Columns); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	var filename, contentType string
+	if req.Format == "csv" {
+		data, filename, err = u.exportCSV(candidates, req.Columns)
+		contentType = "text/csv"
+	} else {
+		data, filename, err = u.exportExcel(candidates, req.Columns)
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ATSExportFile{
+		Filename:    filename,
+		ContentType: contentType,
+		Write: func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		},
+	}, nil
+}
+
+// recordExport writes the export audit before any candidate data leaves the server
+func (u *atsUsecase) recordExport(ctx context.Context, req domain.ATSExportRequest, candidates []domain.ATSCandidate, columns []string) error {
+	rows, manifest := u.exportManifest(candidates, columns)
+	actorID := domain.UserIDFromContext(ctx)
+	audit := &domain.ATSExportAudit{
+		ActorUserID:  &actorID,
+		Format:       req.Format,
+		Filter:       req.Filter,
+		Columns:      columns,
+		RowCount:     len(rows),
+		ManifestHash: manifest,
+		IPAddress:    req.IPAddress,
+		UserAgent:    req.UserAgent,
+		Rows:         rows,
+	}
+	if err := u.exportAudits.Create(ctx, audit); err != nil {
+		return apperror.Internal(errors.New("Failed to record export: " + err.Error()))
+	}
+	return nil
+}
+
+// exportManifest fingerprints each candidate's exported values and hashes the
+// fingerprints, sorted by candidate, into the manifest of the export
+func (u *atsUsecase) exportManifest(candidates []domain.ATSCandidate, columns []string) ([]domain.ATSExportAuditRow, string) {
+	rows := make([]domain.ATSExportAuditRow, 0, len(candidates))
+	for _, c := range candidates {
+		h := sha256.New()
+		io.WriteString(h, c.UserID)
+		for _, col := range columns {
+			fmt.Fprintf(h, "\x1f%s=%v", col, u.getCandidateFieldValue(c, col))
+		}
+		rows = append(rows, domain.ATSExportAuditRow{CandidateUserID: c.UserID, Fingerprint: hex.EncodeToString(h.Sum(nil))})
+	}
+	slices.SortFunc(rows, func(a, b domain.ATSExportAuditRow) int {
+		return strings.Compare(a.CandidateUserID, b.CandidateUserID)
+	})
+
+	manifest := sha256.New()
+	for _, row := range rows {
+		fmt.Fprintf(manifest, "%s:%s\n", row.CandidateUserID, row.Fingerprint)
 	}
+	return rows, hex.EncodeToString(manifest.Sum(nil))
+}
+
+// ListExportAudits lists recorded ATS exports, newest first; filtering by candidate
+// answers "who exported my data"
+func (u *atsUsecase) ListExportAudits(ctx context.Context, filter domain.ATSExportAuditFilter) (*domain.PaginatedResult[domain.ATSExportAudit], error) {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	audits, total, err := u.exportAudits.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch export audits: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.ATSExportAudit]{
+		Data:       audits,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+// GetExportAudit returns one recorded export with its candidate manifest
+func (u *atsUsecase) GetExportAudit(ctx context.Context, id int64) (*domain.ATSExportAudit, error) {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
+		return nil, err
+	}
+	audit, err := u.exportAudits.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Export audit not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch export audit: " + err.Error()))
+	}
+	return audit, nil
 }
 
 // exportExcel generates an Excel file from candidate data
@@ -166,6 +404,14 @@ func (u *atsUsecase) exportExcel(candidates []domain.ATSCandidate, columns []str
 		"available_start_date":    "AVAILABLE START DATE",
 		"verification_status":     "VERIFICATION STATUS",
 		"verified_at":             "VERIFIED AT",
+		"phone_verified":          "PHONE VERIFIED",
+		"coe_status":              "COE STATUS",
+		"coe_expiry_date":         "COE EXPIRY DATE",
+		"visa_ready":              "VISA READY",
+		"ssw_sectors":             "SSW PASSED SECTORS",
+		"expiring_documents":      "EXPIRING DOCUMENTS",
+		"quiz_score":              "QUIZ SCORE (%)",
+		"profile_completeness":    "PROFILE COMPLETENESS (%)",
 	}
 
 	// Write headers
@@ -334,7 +580,150 @@ func (u *atsUsecase) getCandidateFieldValue(c domain.ATSCandidate, field string)
 			return c.VerifiedAt.Format("2006-01-02")
 		}
 		return ""
+	case "phone_verified":
+		if c.PhoneVerified {
+			return "yes"
+		}
+		return "no"
+	case "coe_status":
+		if c.CoEStatus != nil {
+			return *c.CoEStatus
+		}
+		return ""
+	case "coe_expiry_date":
+		if c.CoEExpiryDate != nil {
+			return c.CoEExpiryDate.Format("2006-01-02")
+		}
+		return ""
+	case "visa_ready":
+		if c.VisaReady {
+			return "yes"
+		}
+		return "no"
+	case "ssw_sectors":
+		if len(c.SSWSectors) > 0 {
+			return strings.Join(c.SSWSectors, ", ")
+		}
+		return ""
+	case "expiring_documents":
+		if len(c.ExpiringDocuments) > 0 {
+			return strings.Join(c.ExpiringDocuments, ", ")
+		}
+		return ""
+	case "quiz_score":
+		if c.QuizScore != nil {
+			return strconv.Itoa(*c.QuizScore)
+		}
+		return ""
+	case "profile_completeness":
+		return strconv.Itoa(c.ProfileCompleteness)
 	default:
 		return ""
 	}
 }
+
+// validateQuizScoreFilter checks that quiz score filtering and sorting name a quiz
+func validateQuizScoreFilter(filter domain.ATSFilter) error {
+	if filter.QuizMinScore != nil {
+		if filter.QuizID == nil {
+			return fmt.Errorf("quiz_min_score requires quiz_id")
+		}
+		if *filter.QuizMinScore < 0 || *filter.QuizMinScore > 100 {
+			return fmt.Errorf("quiz min score must be between 0 and 100")
+		}
+	}
+	if filter.SortBy == "quiz_score" && filter.QuizID == nil {
+		return fmt.Errorf("sorting by quiz_score requires quiz_id")
+	}
+	return nil
+}
+
+// GetTalentPoolSettings returns the candidate's talent pool visibility and PII grants
+func (u *atsUsecase) GetTalentPoolSettings(ctx context.Context, userID string) (*domain.TalentPoolSettings, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	return u.talentPoolSettings(ctx, userID)
+}
+
+// UpdateTalentPoolSettings opts the candidate in to or out of employer search
+func (u *atsUsecase) UpdateTalentPoolSettings(ctx context.Context, userID string, req domain.UpdateTalentPoolRequest) (*domain.TalentPoolSettings, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.SetTalentPoolVisible(ctx, userID, *req.Visible); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate profile not found").WithCode(domain.ErrCodeCandidateProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to update talent pool visibility: " + err.Error()))
+	}
+	return u.talentPoolSettings(ctx, userID)
+}
+
+// UpdatePrivacySettings sets which employers can find the candidate
+func (u *atsUsecase) UpdatePrivacySettings(ctx context.Context, userID string, req domain.UpdatePrivacyRequest) (*domain.TalentPoolSettings, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(domain.ProfileVisibilities, req.Visibility) {
+		return nil, apperror.BadRequest("Invalid profile visibility")
+	}
+
+	if err := u.repo.SetProfileVisibility(ctx, userID, req.Visibility); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate profile not found").WithCode(domain.ErrCodeCandidateProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to update profile visibility: " + err.Error()))
+	}
+	return u.talentPoolSettings(ctx, userID)
+}
+
+// GrantPIIAccess lets a company see the candidate's name and photo in its ATS search
+func (u *atsUsecase) GrantPIIAccess(ctx context.Context, userID string, req domain.GrantPIIAccessRequest) (*domain.TalentPoolSettings, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	company, err := u.companyRepo.GetByID(ctx, req.CompanyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company not found")
+		}
+		return nil, apperror.Internal(err)
+	}
+	if company.MergedIntoID != nil || company.ArchivedAt != nil {
+		return nil, apperror.NotFound("Company not found")
+	}
+
+	if err := u.repo.GrantPIIAccess(ctx, userID, company.ID); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to grant access: " + err.Error()))
+	}
+	return u.talentPoolSettings(ctx, userID)
+}
+
+// RevokePIIAccess masks the candidate's name and photo for the company again
+func (u *atsUsecase) RevokePIIAccess(ctx context.Context, userID string, companyID int64) (*domain.TalentPoolSettings, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.RevokePIIAccess(ctx, userID, companyID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Access grant not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to revoke access: " + err.Error()))
+	}
+	return u.talentPoolSettings(ctx, userID)
+}
+
+func (u *atsUsecase) talentPoolSettings(ctx context.Context, userID string) (*domain.TalentPoolSettings, error) {
+	settings, err := u.repo.GetTalentPoolSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate profile not found").WithCode(domain.ErrCodeCandidateProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch talent pool settings: " + err.Error()))
+	}
+	return settings, nil
+}
@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockATSRepo struct {
+	domain.ATSRepository
+	mock.Mock
+}
+
+func (m *MockATSRepo) SearchCandidates(ctx context.Context, filter domain.ATSFilter) ([]domain.ATSCandidate, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.ATSCandidate), args.Get(1).(int64), args.Error(2)
+}
+
+type MockATSExportAuditRepo struct {
+	domain.ATSExportAuditRepository
+	mock.Mock
+}
+
+func (m *MockATSExportAuditRepo) Create(ctx context.Context, audit *domain.ATSExportAudit) error {
+	return m.Called(ctx, audit).Error(0)
+}
+
+func adminContext() context.Context {
+	ctx := context.WithValue(context.Background(), domain.KeyUserID, "admin-1")
+	return context.WithValue(ctx, domain.KeyUserRole, domain.RoleAdmin)
+}
+
+func TestATSUsecase_SearchCandidatesSortByProfileCompleteness(t *testing.T) {
+	repo := new(MockATSRepo)
+	repo.On("SearchCandidates", mock.Anything, mock.MatchedBy(func(f domain.ATSFilter) bool {
+		return f.SortBy == "profile_completeness" && f.SortOrder == "desc"
+	})).Return([]domain.ATSCandidate{{UserID: "c-1", ProfileCompleteness: 85}, {UserID: "c-2", ProfileCompleteness: 40}}, int64(2), nil)
+
+	uc := usecase.NewATSUsecase(repo, nil, nil, nil)
+	got, err := uc.SearchCandidates(adminContext(), domain.ATSFilter{SortBy: "profile_completeness", SortOrder: "desc"})
+	require.NoError(t, err)
+	require.Len(t, got.Data, 2)
+	assert.Equal(t, 85, got.Data[0].ProfileCompleteness)
+	repo.AssertExpectations(t)
+}
+
+func TestATSUsecase_ExportProfileCompleteness(t *testing.T) {
+	assert.Contains(t, domain.ExportableColumns, "profile_completeness")
+
+	repo := new(MockATSRepo)
+	repo.On("SearchCandidates", mock.Anything, mock.Anything).
+		Return([]domain.ATSCandidate{{UserID: "c-1", ProfileCompleteness: 85}, {UserID: "c-2"}}, int64(2), nil)
+	audits := new(MockATSExportAuditRepo)
+	audits.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.ATSExportAudit) bool {
+		return a.RowCount == 2 && assert.ObjectsAreEqual([]string{"profile_completeness"}, a.Columns)
+	})).Return(nil)
+
+	uc := usecase.NewATSUsecase(repo, nil, audits, nil)
+	file, err := uc.ExportCandidates(adminContext(), domain.ATSExportRequest{Format: "csv", Columns: []string{"profile_completeness"}})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, file.Write(&buf))
+	assert.Equal(t, "profile_completeness\n85\n0\n", buf.String())
+	audits.AssertExpectations(t)
+}
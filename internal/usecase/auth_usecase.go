@@ -2,20 +2,28 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/i18n"
+	"strings"
 	"time"
 )
 
 type authUsecase struct {
 	userRepo domain.UserRepository
+	mailer   *email.EmailService
 }
 
-func NewAuthUsecase(userRepo domain.UserRepository) domain.AuthUsecase {
-	return &authUsecase{userRepo: userRepo}
+// NewAuthUsecase creates the auth usecase; mailer sends account emails and may be nil
+func NewAuthUsecase(userRepo domain.UserRepository, mailer *email.EmailService) domain.AuthUsecase {
+	return &authUsecase{userRepo: userRepo, mailer: mailer}
 }
 
 func (u *authUsecase) EnsureUserExists(ctx context.Context, user *domain.User) error {
+	user.Role = canonicalRole(user.Role)
 	existing, err := u.userRepo.GetByID(ctx, user.ID)
 	// If exists, check if we need to sync fields (e.g. Role)
 	if existing != nil && err == nil {
@@ -29,7 +37,7 @@ func (u *authUsecase) EnsureUserExists(ctx context.Context, user *domain.User) e
 
 	// Default to 'candidate' if no role
 	if user.Role == "" {
-		user.Role = "candidate"
+		user.Role = domain.RoleCandidate
 	}
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
@@ -42,6 +50,8 @@ func (u *authUsecase) EnsureUserExists(ctx context.Context, user *domain.User) e
 // CRITICAL: This method is idempotent - it handles ID mismatches gracefully
 // and will NEVER fail on existing users due to duplicate email constraints.
 func (u *authUsecase) SyncUserFromAuth(ctx context.Context, user *domain.User) error {
+	user.Role = canonicalRole(user.Role)
+
 	// Step 1: Try to find by ID (happy path - normal returning user)
 	existing, err := u.userRepo.GetByID(ctx, user.ID)
 	if existing != nil && err == nil {
@@ -72,7 +82,7 @@ func (u *authUsecase) SyncUserFromAuth(ctx context.Context, user *domain.User) e
 
 	// Step 3: Truly new user - neither ID nor email exists, create record
 	if user.Role == "" {
-		user.Role = "candidate"
+		user.Role = domain.RoleCandidate
 	}
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
@@ -81,17 +91,25 @@ func (u *authUsecase) SyncUserFromAuth(ctx context.Context, user *domain.User) e
 
 func (u *authUsecase) AssignRole(ctx context.Context, userID string, role string) error {
 	// Security: Only admin can assign roles
-	ctxRole, ok := ctx.Value(domain.KeyUserRole).(string)
-	if !ok || ctxRole != "admin" {
+	if domain.RoleFromContext(ctx) != domain.RoleAdmin {
 		return apperror.Forbidden("Only admins can assign roles")
 	}
+	if domain.AdminProvincesFromContext(ctx) != nil {
+		return apperror.Forbidden("Regional admins cannot assign roles").WithCode(domain.ErrCodeAdminScopeForbidden)
+	}
+
+	// LPK partners are assigned with their LPK through /admin/lpk-partners
+	role, err := domain.ParseRole(role)
+	if err != nil || role == domain.RoleLPK {
+		return apperror.BadRequest("Role must be one of: candidate, employer, admin")
+	}
 
 	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 	if user == nil {
-		return apperror.NotFound("User not found")
+		return apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
 	}
 
 	user.Role = role
@@ -99,6 +117,16 @@ func (u *authUsecase) AssignRole(ctx context.Context, userID string, role string
 	return u.userRepo.Update(ctx, user)
 }
 
+// canonicalRole normalizes a role coming from the auth provider; unknown
+// roles are dropped so they are never stored
+func canonicalRole(role string) string {
+	canonical, err := domain.ParseRole(role)
+	if err != nil {
+		return ""
+	}
+	return canonical
+}
+
 func (u *authUsecase) GetCurrentUser(ctx context.Context, id string) (*domain.User, error) {
 	return u.userRepo.GetByID(ctx, id)
 }
@@ -114,3 +142,81 @@ func (u *authUsecase) CheckEmailExists(ctx context.Context, email string) (bool,
 	}
 	return user != nil, nil
 }
+
+// PauseProfile hides a candidate from employer/admin search and job alerts until req.Until.
+// The pause lifts itself once the end date passes; ResumeProfile ends it early.
+func (u *authUsecase) PauseProfile(ctx context.Context, userID string, req *domain.PauseProfileRequest) (*domain.User, error) {
+	// 1. Only candidates can pause their own profile
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+	}
+	if user.Role != domain.RoleCandidate {
+		return nil, apperror.Forbidden("Only candidates can pause their profile")
+	}
+
+	// 2. Validate pause window
+	now := time.Now()
+	if !req.Until.After(now) {
+		return nil, apperror.BadRequest("Pause end date must be in the future")
+	}
+	if req.Until.After(now.AddDate(0, 0, domain.MaxProfilePauseDays)) {
+		return nil, apperror.BadRequest(fmt.Sprintf("Profile can be paused for at most %d days", domain.MaxProfilePauseDays))
+	}
+
+	// 3. Persist
+	until := req.Until.UTC()
+	var reason *string
+	if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+		reason = &trimmed
+	}
+	if err := u.userRepo.SetPause(ctx, userID, &until, reason); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		return nil, err
+	}
+
+	return u.userRepo.GetByID(ctx, userID)
+}
+
+// ResumeProfile clears an active pause so the candidate is visible again immediately.
+func (u *authUsecase) ResumeProfile(ctx context.Context, userID string) (*domain.User, error) {
+	if err := u.userRepo.SetPause(ctx, userID, nil, nil); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		return nil, err
+	}
+
+	return u.userRepo.GetByID(ctx, userID)
+}
+
+// UpdatePreferredLocale saves the language used for API messages. An empty locale
+// clears the preference so Accept-Language is used again.
+func (u *authUsecase) UpdatePreferredLocale(ctx context.Context, userID string, locale string) (*domain.User, error) {
+	var value *string
+	if locale != "" {
+		if !i18n.IsSupported(locale) {
+			return nil, apperror.BadRequest("Unsupported language")
+		}
+		value = &locale
+	}
+
+	if err := u.userRepo.SetPreferredLocale(ctx, userID, value); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		return nil, err
+	}
+
+	return u.userRepo.GetByID(ctx, userID)
+}
+
+// NotifyPasswordChanged emails the user that their password was changed, so an
+// unexpected change does not go unnoticed
+func (u *authUsecase) NotifyPasswordChanged(ctx context.Context, userID string) {
+	sendUserEmail(ctx, u.mailer, u.userRepo, userID, email.TemplatePasswordChanged, email.PasswordChangedData{
+		ChangedAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+)
+
+type candidateActivityUsecase struct {
+	repo domain.CandidateActivityRepository
+}
+
+func NewCandidateActivityUsecase(repo domain.CandidateActivityRepository) domain.CandidateActivityUsecase {
+	return &candidateActivityUsecase{repo: repo}
+}
+
+func (u *candidateActivityUsecase) ListMyActivity(ctx context.Context, userID string, beforeID int64, limit int) ([]domain.CandidateActivity, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > domain.MaxCandidateActivityLimit {
+		limit = 20
+	}
+
+	activities, err := u.repo.ListByUser(ctx, userID, beforeID, limit)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch activity: " + err.Error()))
+	}
+	return activities, nil
+}
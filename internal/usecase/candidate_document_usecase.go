@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// candidateDocumentBucket is the upload bucket vault files must come from
+const candidateDocumentBucket = "Candidate_Documents"
+
+type candidateDocumentUsecase struct {
+	repo          domain.CandidateDocumentRepository
+	files         domain.StorageCleanupRepository
+	cleanupUC     domain.StorageCleanupUsecase
+	notifications domain.NotificationDispatcher
+	now           func() time.Time
+}
+
+func NewCandidateDocumentUsecase(
+	repo domain.CandidateDocumentRepository,
+	files domain.StorageCleanupRepository,
+	cleanupUC domain.StorageCleanupUsecase,
+	notifications domain.NotificationDispatcher,
+) domain.CandidateDocumentUsecase {
+	return &candidateDocumentUsecase{repo: repo, files: files, cleanupUC: cleanupUC, notifications: notifications, now: time.Now}
+}
+
+// ============================================================================
+// Candidate
+// ============================================================================
+
+func (u *candidateDocumentUsecase) ListMyDocuments(ctx context.Context, userID string) ([]domain.CandidateDocument, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	docs, err := u.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch documents: " + err.Error()))
+	}
+	today := u.now().UTC().Truncate(24 * time.Hour)
+	for i := range docs {
+		setDocumentExpiryStatus(&docs[i], today)
+	}
+	return docs, nil
+}
+
+func (u *candidateDocumentUsecase) CreateDocument(ctx context.Context, userID string, req domain.CreateCandidateDocumentRequest) (*domain.CandidateDocument, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	// 1. Validate the dates
+	issueDate, err := parseDocumentDate(req.IssueDate, "issue_date")
+	if err != nil {
+		return nil, err
+	}
+	expiryDate, err := parseDocumentDate(req.ExpiryDate, "expiry_date")
+	if err != nil {
+		return nil, err
+	}
+	today := u.now().UTC().Truncate(24 * time.Hour)
+	if expiryDate == nil && slices.Contains(domain.CandidateDocumentTypesRequiringExpiry, req.DocumentType) {
+		return nil, apperror.BadRequest("expiry_date is required for " + req.DocumentType)
+	}
+	if issueDate != nil && issueDate.After(today) {
+		return nil, apperror.BadRequest("issue_date cannot be in the future")
+	}
+	if issueDate != nil && expiryDate != nil && expiryDate.Before(*issueDate) {
+		return nil, apperror.BadRequest("expiry_date cannot be before issue_date")
+	}
+
+	// 2. The file must be the candidate's own upload to the document bucket
+	if bucket, _, ok := parseStorageURL(req.FileURL); !ok || bucket != candidateDocumentBucket {
+		return nil, apperror.BadRequest("file_url must be uploaded through /upload?bucket=" + candidateDocumentBucket)
+	}
+	file, err := u.files.FindFileByURL(ctx, req.FileURL)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.BadRequest("file_url does not match an uploaded file")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch uploaded file: " + err.Error()))
+	}
+	if file.UserID != userID {
+		return nil, apperror.BadRequest("file_url does not match an uploaded file")
+	}
+
+	// 3. Enforce the per-candidate limit
+	count, err := u.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count documents: " + err.Error()))
+	}
+	if count >= domain.MaxCandidateDocuments {
+		return nil, apperror.Conflict(fmt.Sprintf("You can keep at most %d documents", domain.MaxCandidateDocuments))
+	}
+
+	// 4. Store it for review; expiry reminders start right away
+	doc := &domain.CandidateDocument{
+		UserID:         userID,
+		DocumentType:   req.DocumentType,
+		FileURL:        req.FileURL,
+		DocumentNumber: trimmedOrNil(req.DocumentNumber),
+		Label:          trimmedOrNil(req.Label),
+		IssueDate:      issueDate,
+		ExpiryDate:     expiryDate,
+	}
+	if err := u.repo.Create(ctx, doc); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save document: " + err.Error()))
+	}
+	setDocumentExpiryStatus(doc, today)
+	return doc, nil
+}
+
+func (u *candidateDocumentUsecase) DeleteDocument(ctx context.Context, userID string, id int64) error {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return err
+	}
+
+	fileURL, err := u.repo.Delete(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Document not found")
+		}
+		return apperror.Internal(errors.New("Failed to delete document: " + err.Error()))
+	}
+
+	// The record is gone either way; a file left behind is found by the orphan sweep
+	if err := u.cleanupUC.QueueReplacedFile(ctx, userID, fileURL); err != nil {
+		logger.FromContext(ctx).Error("Document vault: failed to queue file deletion", "document_id", id, "error", err)
+	}
+	return nil
+}
+
+// ============================================================================
+// Admin
+// ============================================================================
+
+func (u *candidateDocumentUsecase) ListDocuments(ctx context.Context, filter domain.CandidateDocumentFilter) (*domain.PaginatedResult[domain.AdminCandidateDocument], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	switch filter.Status {
+	case "":
+		filter.Status = domain.CandidateDocumentPending
+	case "ALL":
+		filter.Status = ""
+	case domain.CandidateDocumentPending, domain.CandidateDocumentApproved, domain.CandidateDocumentRejected:
+	default:
+		return nil, apperror.BadRequest("Invalid status: " + filter.Status)
+	}
+	if filter.DocumentType != "" && !slices.Contains(domain.CandidateDocumentTypes, filter.DocumentType) {
+		return nil, apperror.BadRequest("Invalid document type: " + filter.DocumentType)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	docs, total, err := u.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch documents: " + err.Error()))
+	}
+	today := u.now().UTC().Truncate(24 * time.Hour)
+	for i := range docs {
+		setDocumentExpiryStatus(&docs[i].CandidateDocument, today)
+	}
+
+	return &domain.PaginatedResult[domain.AdminCandidateDocument]{
+		Data:       docs,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+func (u *candidateDocumentUsecase) ReviewDocument(ctx context.Context, adminID string, id int64, req domain.ReviewCandidateDocumentRequest) (*domain.CandidateDocument, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	notes := trimmedOrNil(req.Notes)
+	if req.Action == "REJECT" && notes == nil {
+		return nil, apperror.BadRequest("notes are required to reject a document")
+	}
+
+	doc, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Document not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch document: " + err.Error()))
+	}
+
+	now := u.now().UTC()
+	doc.Status = domain.CandidateDocumentApproved
+	if req.Action == "REJECT" {
+		doc.Status = domain.CandidateDocumentRejected
+	}
+	doc.ReviewNotes = notes
+	doc.ReviewedAt = &now
+	doc.ReviewedBy = nil
+	if adminID != "" {
+		doc.ReviewedBy = &adminID
+	}
+	if err := u.repo.Review(ctx, doc); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Document not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to review document: " + err.Error()))
+	}
+
+	u.notifyReview(ctx, doc)
+	setDocumentExpiryStatus(doc, now.Truncate(24*time.Hour))
+	return doc, nil
+}
+
+// notifyReview tells the candidate about the decision; delivery failures are only logged
+func (u *candidateDocumentUsecase) notifyReview(ctx context.Context, doc *domain.CandidateDocument) {
+	if u.notifications == nil {
+		return
+	}
+	document := domain.NotificationLines{documentTypeLabels[doc.DocumentType]}
+	n := &domain.Notification{
+		UserID:   doc.UserID,
+		Category: domain.NotificationCategoryAccount,
+		Subject:  "Your document was approved",
+		Body:     "Your document has been reviewed and approved:\n%s",
+		BodyArgs: []any{document},
+	}
+	if doc.Status == domain.CandidateDocumentRejected {
+		n.Subject = "Your document was not approved"
+		n.Body = "We could not approve your document:\n%s\n\nReviewer notes:\n%s\n\nPlease upload a corrected document."
+		n.BodyArgs = append(n.BodyArgs, *doc.ReviewNotes)
+	}
+	if err := u.notifications.Dispatch(ctx, n); err != nil {
+		logger.FromContext(ctx).Error("Document vault: failed to notify review", "document_id", doc.ID, "error", err)
+	}
+}
+
+// setDocumentExpiryStatus derives the expiry flag shown next to a vault document
+func setDocumentExpiryStatus(doc *domain.CandidateDocument, today time.Time) {
+	if doc.ExpiryDate == nil {
+		return
+	}
+	daysLeft := int(doc.ExpiryDate.Sub(today).Hours() / 24)
+	status := domain.DocumentExpiryValid
+	switch {
+	case daysLeft < 0:
+		status = domain.DocumentExpiryExpired
+	case daysLeft <= domain.CriticalDocumentExpiryWindowDays:
+		status = domain.DocumentExpiryExpiring
+	}
+	doc.DaysLeft = &daysLeft
+	doc.ExpiryStatus = &status
+}
+
+// parseDocumentDate parses an optional YYYY-MM-DD date
+func parseDocumentDate(value *string, field string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", *value)
+	if err != nil {
+		return nil, apperror.BadRequest(field + " must be a date (YYYY-MM-DD)")
+	}
+	return &t, nil
+}
+
+// trimmedOrNil returns nil for a missing or blank value
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"net/url"
+	"strings"
+)
+
+// candidatePortfolioBucket is the upload bucket portfolio files must come from
+const candidatePortfolioBucket = "Candidate_Portfolio"
+
+type candidatePortfolioUsecase struct {
+	repo             domain.CandidatePortfolioRepository
+	files            domain.StorageCleanupRepository
+	cleanupUC        domain.StorageCleanupUsecase
+	creditRepo       domain.ContactCreditRepository
+	companyRepo      domain.CompanyProfileRepository
+	verificationRepo domain.VerificationRepository
+	quotaBytes       int64
+}
+
+// NewCandidatePortfolioUsecase creates the portfolio usecase; quotaBytes caps the
+// stored file size of one candidate's portfolio
+func NewCandidatePortfolioUsecase(
+	repo domain.CandidatePortfolioRepository,
+	files domain.StorageCleanupRepository,
+	cleanupUC domain.StorageCleanupUsecase,
+	creditRepo domain.ContactCreditRepository,
+	companyRepo domain.CompanyProfileRepository,
+	verificationRepo domain.VerificationRepository,
+	quotaBytes int64,
+) domain.CandidatePortfolioUsecase {
+	return &candidatePortfolioUsecase{
+		repo:             repo,
+		files:            files,
+		cleanupUC:        cleanupUC,
+		creditRepo:       creditRepo,
+		companyRepo:      companyRepo,
+		verificationRepo: verificationRepo,
+		quotaBytes:       quotaBytes,
+	}
+}
+
+// ============================================================================
+// Candidate
+// ============================================================================
+
+func (u *candidatePortfolioUsecase) GetMyPortfolio(ctx context.Context, userID string) (*domain.CandidatePortfolio, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	return u.portfolio(ctx, userID)
+}
+
+func (u *candidatePortfolioUsecase) CreateItem(ctx context.Context, userID string, req domain.CreatePortfolioItemRequest) (*domain.CandidatePortfolioItem, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, apperror.BadRequest("title is required")
+	}
+	fileURL, linkURL := trimmedOrNil(req.FileURL), trimmedOrNil(req.LinkURL)
+	if (fileURL == nil) == (linkURL == nil) {
+		return nil, apperror.BadRequest("Provide either file_url or link_url")
+	}
+
+	// 1. Enforce the item limit
+	items, err := u.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch portfolio: " + err.Error()))
+	}
+	usage := u.usage(items)
+	if usage.Items >= domain.MaxCandidatePortfolioItems {
+		return nil, apperror.Conflict(fmt.Sprintf("You can keep at most %d portfolio items", domain.MaxCandidatePortfolioItems))
+	}
+
+	item := &domain.CandidatePortfolioItem{
+		UserID:          userID,
+		Title:           title,
+		Description:     trimmedOrNil(req.Description),
+		IncludeInResume: req.IncludeInResume == nil || *req.IncludeInResume,
+	}
+	if linkURL != nil {
+		if !isWebLink(*linkURL) {
+			return nil, apperror.BadRequest("link_url must be an http or https URL")
+		}
+		item.ItemType = domain.PortfolioItemLink
+		item.LinkURL = linkURL
+	} else {
+		// 2. A file must be the candidate's own image or PDF upload to the portfolio bucket
+		if bucket, _, ok := parseStorageURL(*fileURL); !ok || bucket != candidatePortfolioBucket {
+			return nil, apperror.BadRequest("file_url must be uploaded through /upload?bucket=" + candidatePortfolioBucket)
+		}
+		file, err := u.files.FindFileByURL(ctx, *fileURL)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, apperror.BadRequest("file_url does not match an uploaded file")
+			}
+			return nil, apperror.Internal(errors.New("Failed to fetch uploaded file: " + err.Error()))
+		}
+		if file.UserID != userID {
+			return nil, apperror.BadRequest("file_url does not match an uploaded file")
+		}
+		switch {
+		case strings.HasPrefix(file.ContentType, "image/"):
+			item.ItemType = domain.PortfolioItemImage
+		case file.ContentType == "application/pdf":
+			item.ItemType = domain.PortfolioItemPDF
+		default:
+			return nil, apperror.BadRequest("Portfolio files must be images or PDFs")
+		}
+		for _, existing := range items {
+			if existing.FileURL != nil && *existing.FileURL == *fileURL {
+				return nil, apperror.Conflict("This file is already in your portfolio")
+			}
+		}
+
+		// 3. Enforce the storage quota
+		if usage.UsedBytes+file.SizeBytes > usage.QuotaBytes {
+			return nil, apperror.Conflict(fmt.Sprintf("Portfolio storage quota exceeded: %s of %s used",
+				formatMegabytes(usage.UsedBytes), formatMegabytes(usage.QuotaBytes)))
+		}
+		item.FileURL = fileURL
+		item.SizeBytes = file.SizeBytes
+	}
+
+	// 4. Append it to the portfolio
+	if err := u.repo.Create(ctx, item); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save portfolio item: " + err.Error()))
+	}
+	return item, nil
+}
+
+func (u *candidatePortfolioUsecase) UpdateItem(ctx context.Context, userID string, id int64, req domain.UpdatePortfolioItemRequest) (*domain.CandidatePortfolioItem, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	item, err := u.repo.GetByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Portfolio item not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch portfolio item: " + err.Error()))
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, apperror.BadRequest("title is required")
+	}
+	if linkURL := trimmedOrNil(req.LinkURL); linkURL != nil {
+		if item.ItemType != domain.PortfolioItemLink {
+			return nil, apperror.BadRequest("link_url can only be changed on link items")
+		}
+		if !isWebLink(*linkURL) {
+			return nil, apperror.BadRequest("link_url must be an http or https URL")
+		}
+		item.LinkURL = linkURL
+	}
+	item.Title = title
+	item.Description = trimmedOrNil(req.Description)
+	item.IncludeInResume = req.IncludeInResume
+
+	if err := u.repo.Update(ctx, item); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Portfolio item not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update portfolio item: " + err.Error()))
+	}
+	return item, nil
+}
+
+func (u *candidatePortfolioUsecase) DeleteItem(ctx context.Context, userID string, id int64) error {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return err
+	}
+
+	fileURL, err := u.repo.Delete(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Portfolio item not found")
+		}
+		return apperror.Internal(errors.New("Failed to delete portfolio item: " + err.Error()))
+	}
+
+	// The item is gone either way; a file left behind is found by the orphan sweep
+	if fileURL != nil {
+		if err := u.cleanupUC.QueueReplacedFile(ctx, userID, *fileURL); err != nil {
+			logger.FromContext(ctx).Error("Portfolio: failed to queue file deletion", "item_id", id, "error", err)
+		}
+	}
+	return nil
+}
+
+func (u *candidatePortfolioUsecase) ReorderItems(ctx context.Context, userID string, req domain.ReorderPortfolioRequest) (*domain.CandidatePortfolio, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(req.ItemIDs))
+	for _, id := range req.ItemIDs {
+		if seen[id] {
+			return nil, apperror.BadRequest("item_ids must not repeat an item")
+		}
+		seen[id] = true
+	}
+	if err := u.repo.Reorder(ctx, userID, req.ItemIDs); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.BadRequest("item_ids must list every item of your portfolio")
+		}
+		return nil, apperror.Internal(errors.New("Failed to reorder portfolio: " + err.Error()))
+	}
+	return u.portfolio(ctx, userID)
+}
+
+// ============================================================================
+// Employer
+// ============================================================================
+
+func (u *candidatePortfolioUsecase) GetCandidatePortfolio(ctx context.Context, userID, candidateUserID string) (*domain.EmployerCandidatePortfolio, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	// Same audience as the resume: active, verified candidates whose privacy
+	// settings make them visible to the company (or whose contact it unlocked)
+	if _, err := u.creditRepo.GetCandidateContact(ctx, company.ID, candidateUserID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate not found").WithCode(domain.ErrCodeCandidateNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch candidate: " + err.Error()))
+	}
+	verification, err := u.verificationRepo.GetByUserID(ctx, candidateUserID)
+	if err != nil || verification == nil || verification.Status != domain.VerificationStatusVerified {
+		return nil, apperror.NotFound("Candidate not found").WithCode(domain.ErrCodeCandidateNotFound)
+	}
+
+	revealed, err := u.creditRepo.HasRevealed(ctx, company.ID, candidateUserID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to check contact unlock: " + err.Error()))
+	}
+	mode := domain.ResumeModeRedacted
+	if revealed {
+		mode = domain.ResumeModeFull
+	}
+
+	items, err := u.repo.ListByUser(ctx, candidateUserID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch portfolio: " + err.Error()))
+	}
+	if mode == domain.ResumeModeRedacted {
+		for i := range items {
+			redactPortfolioItem(&items[i])
+		}
+	}
+	return &domain.EmployerCandidatePortfolio{CandidateUserID: candidateUserID, Mode: mode, Items: items}, nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func (u *candidatePortfolioUsecase) portfolio(ctx context.Context, userID string) (*domain.CandidatePortfolio, error) {
+	items, err := u.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch portfolio: " + err.Error()))
+	}
+	return &domain.CandidatePortfolio{Items: items, Usage: u.usage(items)}, nil
+}
+
+func (u *candidatePortfolioUsecase) usage(items []domain.CandidatePortfolioItem) domain.CandidatePortfolioUsage {
+	usage := domain.CandidatePortfolioUsage{
+		Items:      len(items),
+		MaxItems:   domain.MaxCandidatePortfolioItems,
+		QuotaBytes: u.quotaBytes,
+	}
+	for _, item := range items {
+		usage.UsedBytes += item.SizeBytes
+	}
+	return usage
+}
+
+// redactPortfolioItem hides what identifies the candidate before the contact
+// unlock: links, and contact details typed into the description
+func redactPortfolioItem(item *domain.CandidatePortfolioItem) {
+	item.LinkURL = nil
+	if item.Description != nil {
+		redacted := redactContactDetails(*item.Description)
+		item.Description = &redacted
+	}
+}
+
+func isWebLink(raw string) bool {
+	link, err := url.Parse(raw)
+	return err == nil && link.Host != "" && (link.Scheme == "https" || link.Scheme == "http")
+}
+
+func formatMegabytes(bytes int64) string {
+	return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/pdf"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type candidateResumeUsecase struct {
+	verificationRepo domain.VerificationRepository
+	creditRepo       domain.ContactCreditRepository
+	companyRepo      domain.CompanyProfileRepository
+	piiLogRepo       domain.PIIAccessLogRepository
+	portfolioRepo    domain.CandidatePortfolioRepository
+	now              func() time.Time
+}
+
+func NewCandidateResumeUsecase(
+	verificationRepo domain.VerificationRepository,
+	creditRepo domain.ContactCreditRepository,
+	companyRepo domain.CompanyProfileRepository,
+	piiLogRepo domain.PIIAccessLogRepository,
+	portfolioRepo domain.CandidatePortfolioRepository,
+) domain.CandidateResumeUsecase {
+	return &candidateResumeUsecase{
+		verificationRepo: verificationRepo,
+		creditRepo:       creditRepo,
+		companyRepo:      companyRepo,
+		piiLogRepo:       piiLogRepo,
+		portfolioRepo:    portfolioRepo,
+		now:              time.Now,
+	}
+}
+
+func (u *candidateResumeUsecase) GetResumePDF(ctx context.Context, userID, candidateUserID string, req domain.CandidateResumeRequest) (*domain.CandidateResume, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	// 1. The unlock belongs to the company, not the user
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	// 2. Only active (non-paused), verified candidates visible to the company have a resume
+	contact, err := u.creditRepo.GetCandidateContact(ctx, company.ID, candidateUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate not found").WithCode(domain.ErrCodeCandidateNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch candidate: " + err.Error()))
+	}
+	verification, err := u.verificationRepo.GetByUserID(ctx, candidateUserID)
+	if err != nil || verification == nil || verification.Status != domain.VerificationStatusVerified {
+		return nil, apperror.NotFound("Candidate not found").WithCode(domain.ErrCodeCandidateNotFound)
+	}
+	experiences, err := u.verificationRepo.GetWorkExperiences(ctx, verification.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch work experience: " + err.Error()))
+	}
+	portfolio, err := u.portfolioRepo.ListByUser(ctx, candidateUserID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch portfolio: " + err.Error()))
+	}
+	portfolio = slices.DeleteFunc(portfolio, func(item domain.CandidatePortfolioItem) bool { return !item.IncludeInResume })
+
+	// 3. Mode follows the company's unlock state
+	revealed, err := u.creditRepo.HasRevealed(ctx, company.ID, candidateUserID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to check contact unlock: " + err.Error()))
+	}
+	mode := domain.ResumeModeRedacted
+	if revealed {
+		mode = domain.ResumeModeFull
+	}
+
+	// 4. A full resume is personal data leaving the server; record it first
+	if mode == domain.ResumeModeFull {
+		companyID := company.ID
+		if err := u.piiLogRepo.Create(ctx, &domain.PIIAccessLog{
+			ActorUserID:    userID,
+			CompanyID:      &companyID,
+			Action:         domain.PIIActionResumeDownload,
+			ResourceType:   "candidate",
+			ResourceID:     candidateUserID,
+			SubjectUserIDs: []string{candidateUserID},
+			Columns:        []string{"name", "email", "phone", "website_url", "portfolio_url"},
+			IPAddress:      req.IPAddress,
+			UserAgent:      req.UserAgent,
+		}); err != nil {
+			return nil, apperror.Internal(errors.New("Failed to record resume download: " + err.Error()))
+		}
+	}
+
+	data := renderCandidateResume(contact, verification, experiences, portfolio, mode, u.now().UTC())
+	return &domain.CandidateResume{
+		Data:     data,
+		Filename: fmt.Sprintf("resume_%s_%s.pdf", resumeFileLabel(contact, mode), mode),
+		Mode:     mode,
+	}, nil
+}
+
+// renderCandidateResume lays out the resume. Redacted mode shows initials and leaves out
+// contact details, including emails and phone numbers typed into free text. The
+// portfolio section lists the work samples the candidate chose to include.
+func renderCandidateResume(contact *domain.CandidateContact, v *domain.AccountVerification, experiences []domain.JapanWorkExperience, portfolio []domain.CandidatePortfolioItem, mode string, now time.Time) []byte {
+	redacted := mode == domain.ResumeModeRedacted
+	text := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		if redacted {
+			return redactContactDetails(*s)
+		}
+		return *s
+	}
+
+	name := contact.Name
+	if redacted {
+		name = initials(contact.Name)
+	}
+
+	doc := pdf.New("Resume - " + name)
+	if redacted {
+		doc.SetFooter("Redacted resume - unlock the candidate's contact to see full details")
+	} else {
+		doc.SetFooter("Confidential - candidate personal data")
+	}
+
+	doc.Heading(name)
+	doc.Field("Occupation", text(v.Occupation))
+	doc.Field("Domicile", derefString(v.DomicileCity))
+	if !redacted {
+		doc.Field("Email", contact.Email)
+		doc.Field("Phone", derefString(contact.Phone))
+		doc.Field("Website", derefString(v.WebsiteURL))
+		doc.Field("Portfolio", derefString(v.PortfolioURL))
+	}
+
+	if intro := text(v.Intro); intro != "" {
+		doc.Subheading("Profile")
+		doc.Paragraph(intro)
+	}
+
+	doc.Subheading("Japanese & Japan Experience")
+	doc.Field("JLPT level", derefString(v.JapaneseLevel))
+	doc.Field("Speaking level", derefString(v.JapaneseSpeakingLevel))
+	if v.JapanExperienceDuration != nil {
+		doc.Field("Experience in Japan", fmt.Sprintf("%d months", *v.JapanExperienceDuration))
+	}
+	if v.JapanReturnDate != nil {
+		doc.Field("Returned from Japan", v.JapanReturnDate.Format("January 2006"))
+	}
+	doc.Field("CoE status", derefString(v.CoEStatus))
+	for _, exam := range v.SSWExamResults {
+		doc.Bullet(fmt.Sprintf("SSW %s level %d, passed %s", exam.Sector, exam.Level, exam.PassedDate.Format("2006-01-02")))
+	}
+
+	doc.Subheading("Skills & Preferences")
+	doc.Field("Golden skill", text(v.GoldenSkill))
+	doc.Field("Job fields", strings.Join(v.MainJobFields, ", "))
+	doc.Field("Preferred locations", strings.Join(v.PreferredLocations, ", "))
+	doc.Field("Preferred industries", strings.Join(v.PreferredIndustries, ", "))
+	if v.AvailableStartDate != nil {
+		doc.Field("Available from", v.AvailableStartDate.Format("2006-01-02"))
+	}
+
+	if len(experiences) > 0 {
+		doc.Subheading("Work Experience in Japan")
+		for _, e := range experiences {
+			end := "present"
+			if e.EndDate != nil {
+				end = e.EndDate.Format("2006-01")
+			}
+			doc.Bullet(fmt.Sprintf("%s, %s (%s - %s)", e.JobTitle, e.CompanyName, e.StartDate.Format("2006-01"), end))
+			if d := text(e.Description); d != "" {
+				doc.Paragraph(d)
+			}
+		}
+	}
+
+	if len(portfolio) > 0 {
+		doc.Subheading("Portfolio")
+		for _, item := range portfolio {
+			doc.Bullet(fmt.Sprintf("%s (%s)", item.Title, portfolioItemLabels[item.ItemType]))
+			if d := text(item.Description); d != "" {
+				doc.Paragraph(d)
+			}
+			// Links can identify the candidate, so they wait for the unlock
+			if !redacted {
+				doc.Field("Link", derefString(item.LinkURL)+derefString(item.FileURL))
+			}
+		}
+	}
+
+	doc.Space(12)
+	doc.Note("Generated " + now.Format("2006-01-02 15:04 UTC"))
+	return doc.Bytes()
+}
+
+var portfolioItemLabels = map[string]string{
+	domain.PortfolioItemImage: "Image",
+	domain.PortfolioItemPDF:   "PDF",
+	domain.PortfolioItemLink:  "Link",
+}
+
+// initials turns "Budi Santoso" into "B. S."
+func initials(name string) string {
+	var parts []string
+	for _, word := range strings.Fields(name) {
+		r := []rune(word)[0]
+		if unicode.IsLetter(r) {
+			parts = append(parts, string(unicode.ToUpper(r))+".")
+		}
+	}
+	if len(parts) == 0 {
+		return "Candidate"
+	}
+	return strings.Join(parts, " ")
+}
+
+var (
+	resumeEmailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	resumePhonePattern = regexp.MustCompile(`\+?\d[\d\s\-().]{7,}\d`)
+	resumeURLPattern   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+)
+
+// redactContactDetails hides emails, phone numbers and links in free text
+func redactContactDetails(s string) string {
+	s = resumeEmailPattern.ReplaceAllString(s, "[redacted]")
+	s = resumeURLPattern.ReplaceAllString(s, "[redacted]")
+	// Years and date ranges look like phone numbers; real numbers have at least 9 digits
+	return resumePhonePattern.ReplaceAllStringFunc(s, func(m string) string {
+		digits := 0
+		for _, r := range m {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if digits < 9 {
+			return m
+		}
+		return "[redacted]"
+	})
+}
+
+// resumeFileLabel names the file (ASCII only, for Content-Disposition) without
+// leaking the name of a redacted candidate
+func resumeFileLabel(contact *domain.CandidateContact, mode string) string {
+	name := contact.Name
+	if mode == domain.ResumeModeRedacted {
+		name = strings.ReplaceAll(initials(contact.Name), ".", "")
+	}
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			return r
+		case r == ' ':
+			return '_'
+		}
+		return -1
+	}, strings.TrimSpace(name))
+	if label == "" {
+		return "candidate"
+	}
+	return label
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
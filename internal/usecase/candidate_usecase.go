@@ -2,26 +2,38 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
-	"log"
+	"go-recruitment-backend/pkg/logger"
+	"sync"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// freshProfileCheckInterval is how long a candidate found fresh is not checked
+// again; staleness takes months of absence, so a cached answer cannot go wrong
+const freshProfileCheckInterval = time.Hour
+
 type candidateUsecase struct {
 	repo             domain.CandidateRepository
 	verificationRepo domain.VerificationRepository
+	docExpiryRepo    domain.DocumentExpiryRepository
 	validate         *validator.Validate
+	now              func() time.Time
+
+	freshChecked sync.Map // userID -> time.Time of the last check that found the profile fresh
 }
 
-func NewCandidateUsecase(repo domain.CandidateRepository, verificationRepo domain.VerificationRepository, validate *validator.Validate) domain.CandidateUsecase {
+func NewCandidateUsecase(repo domain.CandidateRepository, verificationRepo domain.VerificationRepository, docExpiryRepo domain.DocumentExpiryRepository, validate *validator.Validate) domain.CandidateUsecase {
 	return &candidateUsecase{
 		repo:             repo,
 		verificationRepo: verificationRepo,
+		docExpiryRepo:    docExpiryRepo,
 		validate:         validate,
+		now:              time.Now,
 	}
 }
 
@@ -115,7 +127,7 @@ func (u *candidateUsecase) UpdateFullProfile(ctx context.Context, userID string,
 	if u.verificationRepo != nil {
 		if err := u.verificationRepo.UpdateSubmittedAt(ctx, authID, time.Now()); err != nil {
 			// Log but don't fail - profile was saved successfully
-			log.Printf("Warning: failed to update verification submitted_at: %v", err)
+			logger.FromContext(ctx).Warn("Failed to update verification submitted_at", "error", err)
 		}
 	}
 
@@ -125,3 +137,92 @@ func (u *candidateUsecase) UpdateFullProfile(ctx context.Context, userID string,
 func (u *candidateUsecase) GetMasterSkills(ctx context.Context) ([]domain.Skill, error) {
 	return u.repo.GetAllSkills(ctx)
 }
+
+// ============================================================================
+// Profile Refresh (returning after a long absence)
+// ============================================================================
+
+// ProfileRefreshRequired is called before the request is recorded as activity,
+// so last_active_at still tells how long the candidate was away. A candidate
+// away for CandidateStaleAfterMonths is flagged; the flag then stays until
+// RefreshProfile, however active they are in between.
+func (u *candidateUsecase) ProfileRefreshRequired(ctx context.Context, userID string) (bool, error) {
+	now := u.now()
+	if checked, ok := u.freshChecked.Load(userID); ok && now.Sub(checked.(time.Time)) < freshProfileCheckInterval {
+		return false, nil
+	}
+
+	freshness, err := u.repo.GetProfileFreshness(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// No profile yet, so nothing employers could find
+			u.freshChecked.Store(userID, now)
+			return false, nil
+		}
+		return false, err
+	}
+	if freshness.RefreshRequiredAt != nil {
+		return true, nil
+	}
+	if freshness.LastActiveAt != nil && freshness.LastActiveAt.Before(now.AddDate(0, -domain.CandidateStaleAfterMonths, 0)) {
+		if err := u.repo.FlagProfileRefresh(ctx, userID, now.UTC()); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	u.freshChecked.Store(userID, now)
+	return false, nil
+}
+
+func (u *candidateUsecase) GetProfileRefresh(ctx context.Context, userID string) (*domain.CandidateProfileRefresh, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+	refresh, err := u.repo.GetProfileRefresh(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate profile not found").WithCode(domain.ErrCodeCandidateProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch profile refresh: " + err.Error()))
+	}
+
+	refresh.Documents, err = u.docExpiryRepo.ListByUser(ctx, userID, u.now().UTC())
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch documents: " + err.Error()))
+	}
+	return refresh, nil
+}
+
+// RefreshProfile stores the confirmed availability and salary and puts the
+// profile back into employer search. Candidates can also refresh unprompted.
+func (u *candidateUsecase) RefreshProfile(ctx context.Context, userID string, req domain.RefreshCandidateProfileRequest) (*domain.CandidateProfileRefresh, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+	if !*req.DocumentsConfirmed {
+		return nil, apperror.BadRequest("Please confirm that your documents are up to date")
+	}
+	startDate, err := time.Parse("2006-01-02", req.AvailableStartDate)
+	if err != nil {
+		return nil, apperror.BadRequest("Invalid available start date")
+	}
+	now := u.now().UTC()
+	if startDate.Before(now.Truncate(24 * time.Hour)) {
+		return nil, apperror.BadRequest("Available start date cannot be in the past")
+	}
+
+	update := domain.CandidateProfileRefreshUpdate{
+		AvailableStartDate: startDate,
+		ExpectedSalary:     *req.ExpectedSalary,
+		PreferredLocations: req.PreferredLocations,
+	}
+	if err := u.repo.CompleteProfileRefresh(ctx, userID, update, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate profile not found").WithCode(domain.ErrCodeCandidateProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to refresh profile: " + err.Error()))
+	}
+	u.freshChecked.Store(userID, u.now())
+	return u.GetProfileRefresh(ctx, userID)
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var companySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type careerPageUsecase struct {
+	repo        domain.CareerPageRepository
+	profileRepo domain.CompanyProfileRepository
+}
+
+func NewCareerPageUsecase(repo domain.CareerPageRepository, profileRepo domain.CompanyProfileRepository) domain.CareerPageUsecase {
+	return &careerPageUsecase{repo: repo, profileRepo: profileRepo}
+}
+
+func (u *careerPageUsecase) GetPublicCareerPage(ctx context.Context, slug string) (*domain.PublicCareerPage, error) {
+	// 1. Resolve company; unpublished pages are indistinguishable from missing ones
+	company, err := u.repo.GetCompanyBySlug(ctx, normalizeCompanySlug(slug))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Career page not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company: " + err.Error()))
+	}
+	page, err := u.repo.GetByCompanyID(ctx, company.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Career page not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch career page: " + err.Error()))
+	}
+	if !page.IsPublished {
+		return nil, apperror.NotFound("Career page not found")
+	}
+
+	// 2. Active jobs
+	jobs, err := u.repo.ListActiveJobs(ctx, company.ID, domain.MaxCareerPageJobs)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch company jobs: " + err.Error()))
+	}
+
+	public := &domain.PublicCareerPage{
+		Slug:         *page.Slug,
+		CompanyID:    company.ID,
+		CompanyName:  company.CompanyName,
+		Location:     company.Location,
+		Industry:     company.Industry,
+		CompanyStory: company.CompanyStory,
+		Headline:     page.Headline,
+		Branding: domain.CareerPageBranding{
+			LogoURL:        company.LogoURL,
+			PrimaryColor:   page.PrimaryColor,
+			SecondaryColor: page.SecondaryColor,
+			BannerURL:      page.BannerURL,
+			IntroVideoURL:  page.IntroVideoURL,
+		},
+		CultureSections: page.CultureSections,
+		Benefits:        page.Benefits,
+		Jobs:            jobs,
+	}
+	// Same rule as the public company profile: contact details only when not hidden
+	if !company.HideCompanyDetails {
+		public.Website = company.Website
+	}
+	return public, nil
+}
+
+func (u *careerPageUsecase) GetOwnCareerPage(ctx context.Context, userID string) (*domain.CareerPage, error) {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := u.repo.GetByCompanyID(ctx, company.ID)
+	if err == nil {
+		return page, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, apperror.Internal(errors.New("Failed to fetch career page: " + err.Error()))
+	}
+
+	// Not saved yet: empty draft, but keep a slug claimed earlier
+	slug, err := u.repo.GetSlug(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch career page: " + err.Error()))
+	}
+	return &domain.CareerPage{
+		CompanyID:       company.ID,
+		Slug:            slug,
+		CultureSections: []domain.CultureSection{},
+		Benefits:        []string{},
+	}, nil
+}
+
+func (u *careerPageUsecase) UpdateOwnCareerPage(ctx context.Context, userID string, req domain.CareerPageRequest) (*domain.CareerPage, error) {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Validate content beyond binding rules
+	if len(req.CultureSections) > domain.MaxCultureSections {
+		return nil, apperror.BadRequest(fmt.Sprintf("At most %d culture sections are allowed", domain.MaxCultureSections))
+	}
+	if len(req.Benefits) > domain.MaxCareerBenefits {
+		return nil, apperror.BadRequest(fmt.Sprintf("At most %d benefits are allowed", domain.MaxCareerBenefits))
+	}
+
+	// 2. A published page needs a public URL
+	slug, err := u.repo.GetSlug(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch career page: " + err.Error()))
+	}
+	if req.IsPublished && slug == nil {
+		return nil, apperror.BadRequest("Set a career page URL before publishing")
+	}
+
+	page := &domain.CareerPage{
+		CompanyID:       company.ID,
+		Slug:            slug,
+		PrimaryColor:    upperHexColor(req.PrimaryColor),
+		SecondaryColor:  upperHexColor(req.SecondaryColor),
+		BannerURL:       req.BannerURL,
+		IntroVideoURL:   req.IntroVideoURL,
+		Headline:        req.Headline,
+		CultureSections: req.CultureSections,
+		Benefits:        req.Benefits,
+		IsPublished:     req.IsPublished,
+	}
+	if page.CultureSections == nil {
+		page.CultureSections = []domain.CultureSection{}
+	}
+	if page.Benefits == nil {
+		page.Benefits = []string{}
+	}
+
+	// 3. Save
+	if err := u.repo.Upsert(ctx, page); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save career page: " + err.Error()))
+	}
+	return page, nil
+}
+
+func (u *careerPageUsecase) CheckSlug(ctx context.Context, userID, slug string) (*domain.SlugAvailability, error) {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug = normalizeCompanySlug(slug)
+	result := &domain.SlugAvailability{Slug: slug}
+	if reason := companySlugProblem(slug); reason != "" {
+		result.Reason = reason
+		return result, nil
+	}
+
+	taken, err := u.repo.SlugTaken(ctx, slug, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to check slug: " + err.Error()))
+	}
+	if taken {
+		result.Reason = "This URL is already taken by another company"
+		return result, nil
+	}
+	result.Available = true
+	return result, nil
+}
+
+func (u *careerPageUsecase) UpdateSlug(ctx context.Context, userID, slug string) (*domain.CareerPage, error) {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Validate format and reserved words
+	slug = normalizeCompanySlug(slug)
+	if reason := companySlugProblem(slug); reason != "" {
+		return nil, apperror.BadRequest(reason)
+	}
+
+	// 2. Uniqueness: checked up front for a clear message, enforced by the unique index
+	taken, err := u.repo.SlugTaken(ctx, slug, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to check slug: " + err.Error()))
+	}
+	if taken {
+		return nil, apperror.Conflict("This URL is already taken by another company")
+	}
+	if err := u.repo.SetSlug(ctx, company.ID, slug); err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, err
+		}
+		return nil, apperror.Internal(errors.New("Failed to update slug: " + err.Error()))
+	}
+
+	return u.GetOwnCareerPage(ctx, userID)
+}
+
+// ownCompany resolves the employer's company profile; a career page needs one first
+func (u *careerPageUsecase) ownCompany(ctx context.Context, userID string) (*domain.CompanyProfile, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Create your company profile before setting up a career page")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+	return company, nil
+}
+
+func normalizeCompanySlug(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
+
+// companySlugProblem returns why a normalized slug cannot be used, or "" if it can
+func companySlugProblem(slug string) string {
+	switch {
+	case len(slug) < 3 || len(slug) > 60:
+		return "URL must be between 3 and 60 characters"
+	case !companySlugPattern.MatchString(slug):
+		return "URL may only contain lowercase letters, numbers and single hyphens"
+	case slices.Contains(domain.ReservedCompanySlugs, slug):
+		return "This URL is reserved"
+	}
+	return ""
+}
+
+func upperHexColor(color *string) *string {
+	if color == nil || *color == "" {
+		return nil
+	}
+	upper := strings.ToUpper(*color)
+	return &upper
+}
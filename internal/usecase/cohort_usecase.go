@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+type cohortUsecase struct {
+	repo    domain.CohortRepository
+	lpkRepo domain.LPKPartnerRepository
+}
+
+// NewCohortUsecase creates the training cohort usecase shared by admins and LPK partners
+func NewCohortUsecase(repo domain.CohortRepository, lpkRepo domain.LPKPartnerRepository) domain.CohortUsecase {
+	return &cohortUsecase{repo: repo, lpkRepo: lpkRepo}
+}
+
+func (u *cohortUsecase) ListCohorts(ctx context.Context, lpkID *int64) ([]domain.Cohort, error) {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scope != nil {
+		lpkID = scope
+	}
+
+	cohorts, err := u.repo.List(ctx, lpkID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch cohorts: " + err.Error()))
+	}
+	for i := range cohorts {
+		withCohortRates(&cohorts[i])
+	}
+	return cohorts, nil
+}
+
+func (u *cohortUsecase) GetCohort(ctx context.Context, id int64) (*domain.Cohort, error) {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.getCohort(ctx, scope, id)
+}
+
+func (u *cohortUsecase) CreateCohort(ctx context.Context, req domain.CohortRequest) (*domain.Cohort, error) {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Partners create for their own LPK; admins must name one
+	lpkID := req.LPKID
+	if scope != nil {
+		lpkID = *scope
+	} else {
+		if lpkID == 0 {
+			return nil, apperror.BadRequest("lpk_id is required")
+		}
+		exists, err := u.lpkRepo.LPKExists(ctx, lpkID)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		if !exists {
+			return nil, apperror.NotFound("LPK not found")
+		}
+	}
+
+	// 2. Validate and persist
+	if err := validateCohortDates(req); err != nil {
+		return nil, err
+	}
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	cohort := &domain.Cohort{
+		LPKID:               lpkID,
+		Name:                strings.TrimSpace(req.Name),
+		StartDate:           req.StartDate,
+		EndDate:             req.EndDate,
+		TargetDepartureDate: req.TargetDepartureDate,
+		CreatedBy:           &userID,
+	}
+	if err := u.repo.Create(ctx, cohort); err != nil {
+		return nil, wrapCohortError(err, "Failed to create cohort: ")
+	}
+
+	return u.getCohort(ctx, scope, cohort.ID)
+}
+
+func (u *cohortUsecase) UpdateCohort(ctx context.Context, id int64, req domain.CohortRequest) (*domain.Cohort, error) {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cohort, err := u.getCohort(ctx, scope, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.LPKID != 0 && req.LPKID != cohort.LPKID {
+		return nil, apperror.BadRequest("A cohort cannot move to another LPK")
+	}
+	if err := validateCohortDates(req); err != nil {
+		return nil, err
+	}
+
+	cohort.Name = strings.TrimSpace(req.Name)
+	cohort.StartDate = req.StartDate
+	cohort.EndDate = req.EndDate
+	cohort.TargetDepartureDate = req.TargetDepartureDate
+	if err := u.repo.Update(ctx, cohort); err != nil {
+		return nil, wrapCohortError(err, "Failed to update cohort: ")
+	}
+	return cohort, nil
+}
+
+func (u *cohortUsecase) DeleteCohort(ctx context.Context, id int64) error {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := u.getCohort(ctx, scope, id); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return wrapCohortError(err, "Failed to delete cohort: ")
+	}
+	return nil
+}
+
+func (u *cohortUsecase) AssignMembers(ctx context.Context, id int64, req domain.AssignCohortMembersRequest) (*domain.CohortAssignResult, error) {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cohort, err := u.getCohort(ctx, scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := slices.Compact(slices.Sorted(slices.Values(req.CandidateRefs)))
+	if len(refs) > domain.MaxCohortBulkAssign {
+		return nil, apperror.BadRequest("Too many candidates in one assignment")
+	}
+
+	// Only the cohort's LPK candidates are assigned; the rest are reported back
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	assigned, err := u.repo.AssignMembers(ctx, cohort.ID, cohort.LPKID, refs, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to assign candidates: " + err.Error()))
+	}
+
+	result := &domain.CohortAssignResult{Assigned: len(assigned), Skipped: []int64{}}
+	for _, ref := range refs {
+		if !slices.Contains(assigned, ref) {
+			result.Skipped = append(result.Skipped, ref)
+		}
+	}
+	return result, nil
+}
+
+func (u *cohortUsecase) RemoveMember(ctx context.Context, id, candidateRef int64) error {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := u.getCohort(ctx, scope, id); err != nil {
+		return err
+	}
+
+	if err := u.repo.RemoveMember(ctx, id, candidateRef); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Candidate is not in this cohort")
+		}
+		return apperror.Internal(errors.New("Failed to remove candidate: " + err.Error()))
+	}
+	return nil
+}
+
+func (u *cohortUsecase) ListMembers(ctx context.Context, id int64, page, pageSize int) (*domain.PaginatedResult[domain.LPKCandidateProgress], error) {
+	scope, err := u.cohortScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cohort, err := u.getCohort(ctx, scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	members, total, err := u.repo.ListMembers(ctx, cohort.ID, cohort.LPKID, page, pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch cohort members: " + err.Error()))
+	}
+
+	return &domain.PaginatedResult[domain.LPKCandidateProgress]{
+		Data:       members,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+	}, nil
+}
+
+// cohortScope returns the LPK whose cohorts the caller manages: nil for admins
+// (every LPK), the partner's own LPK for LPK partners
+func (u *cohortUsecase) cohortScope(ctx context.Context) (*int64, error) {
+	switch domain.RoleFromContext(ctx) {
+	case domain.RoleAdmin:
+		return nil, nil
+	case domain.RoleLPK:
+		userID, _ := ctx.Value(domain.KeyUserID).(string)
+		partner, err := u.lpkRepo.GetPartnerByUserID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, apperror.Forbidden("No LPK partnership assigned to this account")
+			}
+			return nil, apperror.Internal(err)
+		}
+		return &partner.LPKID, nil
+	default:
+		return nil, apperror.Forbidden("Access denied")
+	}
+}
+
+// getCohort loads a cohort within the caller's scope; other LPKs' cohorts are
+// reported as not found
+func (u *cohortUsecase) getCohort(ctx context.Context, scope *int64, id int64) (*domain.Cohort, error) {
+	cohort, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, wrapCohortError(err, "Failed to fetch cohort: ")
+	}
+	if scope != nil && cohort.LPKID != *scope {
+		return nil, apperror.NotFound("Cohort not found")
+	}
+	withCohortRates(cohort)
+	return cohort, nil
+}
+
+// validateCohortDates checks the training period and that departure does not
+// precede it
+func validateCohortDates(req domain.CohortRequest) error {
+	if strings.TrimSpace(req.Name) == "" {
+		return apperror.BadRequest("Cohort name is required")
+	}
+	start, err := time.Parse(domain.HolidayDateFormat, req.StartDate)
+	if err != nil {
+		return apperror.BadRequest("Invalid start_date")
+	}
+	end, err := time.Parse(domain.HolidayDateFormat, req.EndDate)
+	if err != nil {
+		return apperror.BadRequest("Invalid end_date")
+	}
+	if end.Before(start) {
+		return apperror.BadRequest("end_date must not be before start_date")
+	}
+	if req.TargetDepartureDate != nil {
+		departure, err := time.Parse(domain.HolidayDateFormat, *req.TargetDepartureDate)
+		if err != nil {
+			return apperror.BadRequest("Invalid target_departure_date")
+		}
+		if departure.Before(start) {
+			return apperror.BadRequest("target_departure_date must not be before start_date")
+		}
+	}
+	return nil
+}
+
+// withCohortRates fills the verification and placement percentages
+func withCohortRates(c *domain.Cohort) {
+	p := &c.Progress
+	p.VerificationRate, p.PlacementRate = 0, 0
+	if p.MemberCount == 0 {
+		return
+	}
+	p.VerificationRate = math.Round(float64(p.VerifiedCount)*1000/float64(p.MemberCount)) / 10
+	p.PlacementRate = math.Round(float64(p.PlacedCount)*1000/float64(p.MemberCount)) / 10
+}
+
+func wrapCohortError(err error, prefix string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return apperror.NotFound("Cohort not found")
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return apperror.Internal(errors.New(prefix + err.Error()))
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"strings"
+	"time"
+)
+
+type companyLocationUsecase struct {
+	repo        domain.CompanyLocationRepository
+	profileRepo domain.CompanyProfileRepository
+	publicCache *PublicJobCache // job detail pages embed the office map; nil disables caching
+}
+
+func NewCompanyLocationUsecase(repo domain.CompanyLocationRepository, profileRepo domain.CompanyProfileRepository, publicCache *PublicJobCache) domain.CompanyLocationUsecase {
+	return &companyLocationUsecase{repo: repo, profileRepo: profileRepo, publicCache: publicCache}
+}
+
+func (u *companyLocationUsecase) ListOwnLocations(ctx context.Context, userID string) ([]domain.CompanyLocation, error) {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := u.repo.ListByCompany(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch locations: " + err.Error()))
+	}
+	return locations, nil
+}
+
+func (u *companyLocationUsecase) CreateLocation(ctx context.Context, userID string, req domain.CompanyLocationRequest) (*domain.CompanyLocation, error) {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := u.repo.CountByCompany(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count locations: " + err.Error()))
+	}
+	if count >= domain.MaxCompanyLocations {
+		return nil, apperror.BadRequest(fmt.Sprintf("A company can list at most %d locations", domain.MaxCompanyLocations))
+	}
+
+	location := &domain.CompanyLocation{CompanyID: company.ID, CreatedAt: time.Now()}
+	if err := applyCompanyLocationRequest(location, req); err != nil {
+		return nil, err
+	}
+	location.UpdatedAt = location.CreatedAt
+	if err := u.repo.Create(ctx, location); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create location: " + err.Error()))
+	}
+	return location, nil
+}
+
+func (u *companyLocationUsecase) UpdateLocation(ctx context.Context, userID string, id int64, req domain.CompanyLocationRequest) (*domain.CompanyLocation, error) {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := u.repo.GetByID(ctx, company.ID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Location not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch location: " + err.Error()))
+	}
+
+	if err := applyCompanyLocationRequest(location, req); err != nil {
+		return nil, err
+	}
+	location.UpdatedAt = time.Now()
+	if err := u.repo.Update(ctx, location); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Location not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update location: " + err.Error()))
+	}
+	// Public job pages show the office on a map
+	u.publicCache.Invalidate(ctx)
+	return location, nil
+}
+
+func (u *companyLocationUsecase) DeleteLocation(ctx context.Context, userID string, id int64) error {
+	company, err := u.ownCompany(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.Delete(ctx, company.ID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Location not found")
+		}
+		return apperror.Internal(errors.New("Failed to delete location: " + err.Error()))
+	}
+	u.publicCache.Invalidate(ctx)
+	return nil
+}
+
+// ListPublicLocations lists a verified company's offices; site contacts follow
+// the company's hide_company_details setting
+func (u *companyLocationUsecase) ListPublicLocations(ctx context.Context, companyID int64) ([]domain.CompanyLocation, error) {
+	company, err := u.profileRepo.GetPublicByID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+	locations, err := u.repo.ListByCompany(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch locations: " + err.Error()))
+	}
+	if company.HideCompanyDetails {
+		for i := range locations {
+			locations[i].HideContact()
+		}
+	}
+	return locations, nil
+}
+
+func (u *companyLocationUsecase) ownCompany(ctx context.Context, userID string) (*domain.CompanyProfile, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Create your company profile before adding locations")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+	return company, nil
+}
+
+// applyCompanyLocationRequest copies the request onto the office, trimming text
+// and normalizing the contact phone
+func applyCompanyLocationRequest(l *domain.CompanyLocation, req domain.CompanyLocationRequest) error {
+	l.Name = strings.TrimSpace(req.Name)
+	l.Address = strings.TrimSpace(req.Address)
+	l.City = strings.TrimSpace(req.City)
+	if l.Name == "" || l.Address == "" || l.City == "" {
+		return apperror.BadRequest("Name, address and city are required")
+	}
+	l.Country = strings.TrimSpace(req.Country)
+	if l.Country == "" {
+		l.Country = domain.DefaultCompanyLocationCountry
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return apperror.BadRequest("Latitude and longitude must be given together")
+	}
+	l.Province, l.PostalCode = trimmedOrNil(req.Province), trimmedOrNil(req.PostalCode)
+	l.Latitude, l.Longitude = req.Latitude, req.Longitude
+	l.ContactName, l.ContactEmail = trimmedOrNil(req.ContactName), trimmedOrNil(req.ContactEmail)
+
+	l.ContactPhone = nil
+	if phone := trimmedOrNil(req.ContactPhone); phone != nil {
+		normalized, err := normalizePhoneE164(*phone)
+		if err != nil {
+			return apperror.BadRequest("Invalid contact phone number")
+		}
+		l.ContactPhone = &normalized
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type companyMemberUsecase struct {
+	repo        domain.CompanyMemberRepository
+	profileRepo domain.CompanyProfileRepository
+	userRepo    domain.UserRepository
+	mailer      *email.EmailService // invitation emails; nil or unconfigured only returns the token
+	now         func() time.Time
+}
+
+func NewCompanyMemberUsecase(repo domain.CompanyMemberRepository, profileRepo domain.CompanyProfileRepository, userRepo domain.UserRepository, mailer *email.EmailService) domain.CompanyMemberUsecase {
+	return &companyMemberUsecase{repo: repo, profileRepo: profileRepo, userRepo: userRepo, mailer: mailer, now: time.Now}
+}
+
+func (u *companyMemberUsecase) MemberRole(ctx context.Context, userID string) (string, error) {
+	membership, err := u.repo.GetMembership(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return membership.Role, nil
+}
+
+func (u *companyMemberUsecase) GetMyMembership(ctx context.Context, userID string) (*domain.CompanyMembership, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	return u.membership(ctx, userID)
+}
+
+// GetTeam lists the owner, the members and, for the owner, the open invitations
+func (u *companyMemberUsecase) GetTeam(ctx context.Context, userID string) (*domain.CompanyTeam, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	membership, err := u.membership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	company, err := u.profileRepo.GetByID(ctx, membership.CompanyID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+
+	team := &domain.CompanyTeam{OwnerUserID: company.UserID, Invitations: []domain.CompanyInvitation{}}
+	if owner, err := u.userRepo.GetByID(ctx, company.UserID); err == nil && owner != nil {
+		team.OwnerEmail = owner.Email
+	}
+	if team.Members, err = u.repo.ListMembers(ctx, company.ID); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch members: " + err.Error()))
+	}
+	if membership.Role == domain.CompanyRoleOwner {
+		if team.Invitations, err = u.repo.ListPendingInvitations(ctx, company.ID, u.now()); err != nil {
+			return nil, apperror.Internal(errors.New("Failed to fetch invitations: " + err.Error()))
+		}
+	}
+	return team, nil
+}
+
+// InviteMember emails an invitation link and returns the invitation with its
+// token. Inviting the same address again replaces the open invitation.
+func (u *companyMemberUsecase) InviteMember(ctx context.Context, userID string, req domain.InviteCompanyMemberRequest) (*domain.CompanyInvitation, error) {
+	membership, err := u.ownerMembership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	address := strings.ToLower(strings.TrimSpace(req.Email))
+	if address == "" {
+		return nil, apperror.BadRequest("Email is required")
+	}
+
+	// An existing account must be free to join; one that does not exist yet is
+	// checked again when the invitation is accepted
+	invitee, err := u.userRepo.GetByEmail(ctx, address)
+	if err == nil && invitee != nil {
+		if _, err := u.repo.GetMembership(ctx, invitee.ID); err == nil {
+			return nil, apperror.Conflict("This user already belongs to a company")
+		} else if !errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Internal(errors.New("Failed to check membership: " + err.Error()))
+		}
+	} else {
+		invitee = nil
+	}
+
+	count, err := u.repo.CountMembers(ctx, membership.CompanyID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count members: " + err.Error()))
+	}
+	if count >= domain.MaxCompanyMembers {
+		return nil, apperror.Conflict("Team member limit reached; remove a member first")
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to generate invitation token: " + err.Error()))
+	}
+	token := hex.EncodeToString(b)
+	now := u.now().UTC()
+	inv := &domain.CompanyInvitation{
+		CompanyID: membership.CompanyID,
+		Email:     address,
+		Role:      req.Role,
+		InvitedBy: &userID,
+		ExpiresAt: now.Add(domain.CompanyInvitationTTL),
+		CreatedAt: now,
+	}
+	if err := u.repo.CreateInvitation(ctx, inv, hashCompanyInvitationToken(token)); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create invitation: " + err.Error()))
+	}
+	inv.Token = token
+
+	u.sendInvite(ctx, inv, membership.CompanyName, userID, invitee)
+	return inv, nil
+}
+
+func (u *companyMemberUsecase) RevokeInvitation(ctx context.Context, userID string, invitationID int64) error {
+	membership, err := u.ownerMembership(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.RevokeInvitation(ctx, membership.CompanyID, invitationID, u.now().UTC()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Invitation not found")
+		}
+		return apperror.Internal(errors.New("Failed to revoke invitation: " + err.Error()))
+	}
+	return nil
+}
+
+func (u *companyMemberUsecase) UpdateMemberRole(ctx context.Context, userID, memberUserID string, req domain.UpdateCompanyMemberRequest) (*domain.CompanyMember, error) {
+	membership, err := u.ownerMembership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := u.repo.UpdateMemberRole(ctx, membership.CompanyID, memberUserID, req.Role)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Member not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update member: " + err.Error()))
+	}
+	return member, nil
+}
+
+// RemoveMember lets the owner remove anyone but themselves, and members leave
+func (u *companyMemberUsecase) RemoveMember(ctx context.Context, userID, memberUserID string) error {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return err
+	}
+	membership, err := u.membership(ctx, userID)
+	if err != nil {
+		return err
+	}
+	leaving := memberUserID == userID
+	if leaving && membership.Role == domain.CompanyRoleOwner {
+		return apperror.BadRequest("The company owner cannot leave the company")
+	}
+	if !leaving && membership.Role != domain.CompanyRoleOwner {
+		return apperror.Forbidden("Only the company owner can manage the team")
+	}
+
+	if err := u.repo.RemoveMember(ctx, membership.CompanyID, memberUserID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Member not found")
+		}
+		return apperror.Internal(errors.New("Failed to remove member: " + err.Error()))
+	}
+	return nil
+}
+
+// AcceptInvitation joins the caller to the inviting company. The invitation is
+// bound to its address, and an employer who owns or joined a company must leave
+// it first.
+func (u *companyMemberUsecase) AcceptInvitation(ctx context.Context, userID string, req domain.AcceptCompanyInvitationRequest) (*domain.CompanyMembership, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, apperror.Forbidden("Only employer accounts can join a company")
+	}
+	now := u.now().UTC()
+	inv, err := u.repo.GetPendingInvitationByTokenHash(ctx, hashCompanyInvitationToken(strings.TrimSpace(req.Token)), now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Invitation is invalid or has expired")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch invitation: " + err.Error()))
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Email), inv.Email) {
+		return nil, apperror.Forbidden("This invitation was sent to a different email address")
+	}
+	if _, err := u.repo.GetMembership(ctx, userID); err == nil {
+		return nil, apperror.Conflict("You already belong to a company")
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return nil, apperror.Internal(errors.New("Failed to check membership: " + err.Error()))
+	}
+
+	if _, err := u.repo.AcceptInvitation(ctx, inv, userID, now); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Invitation is invalid or has expired")
+		}
+		return nil, apperror.Internal(errors.New("Failed to accept invitation: " + err.Error()))
+	}
+	return u.membership(ctx, userID)
+}
+
+func (u *companyMemberUsecase) membership(ctx context.Context, userID string) (*domain.CompanyMembership, error) {
+	membership, err := u.repo.GetMembership(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch membership: " + err.Error()))
+	}
+	return membership, nil
+}
+
+func (u *companyMemberUsecase) ownerMembership(ctx context.Context, userID string) (*domain.CompanyMembership, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	membership, err := u.membership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership.Role != domain.CompanyRoleOwner {
+		return nil, apperror.Forbidden("Only the company owner can manage the team")
+	}
+	return membership, nil
+}
+
+// sendInvite emails the invitation in the background, in the invitee's language
+// when they already have an account. The invitation already exists, so a failure
+// is only logged; the owner can share the returned link instead.
+func (u *companyMemberUsecase) sendInvite(ctx context.Context, inv *domain.CompanyInvitation, companyName, inviterID string, invitee *domain.User) {
+	if u.mailer == nil || !u.mailer.IsConfigured() {
+		return
+	}
+	locale := i18n.LocaleEN
+	if invitee != nil {
+		locale = notificationLocale(&domain.NotificationRecipient{Role: invitee.Role, PreferredLocale: invitee.PreferredLocale})
+	}
+	roleLabel := "Recruiter"
+	if inv.Role == domain.CompanyRoleViewer {
+		roleLabel = "Viewer"
+	}
+	data := email.CompanyInviteData{
+		CompanyName: companyName,
+		RoleLabel:   roleLabel,
+		ExpiresAt:   inv.ExpiresAt,
+		ActionURL:   u.mailer.Link("/company-invitations/accept?token=" + url.QueryEscape(inv.Token)),
+	}
+	to := inv.Email
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		l := logger.FromContext(ctx).With("template", email.TemplateCompanyInvite, "invitation_id", inv.ID)
+		data.InviterEmail = companyName
+		if inviter, err := u.userRepo.GetByID(ctx, inviterID); err == nil && inviter != nil {
+			data.InviterEmail = inviter.Email
+		}
+		if err := u.mailer.SendTemplate(ctx, email.TemplateMessage{
+			Template: email.TemplateCompanyInvite,
+			Locale:   locale,
+			To:       []string{to},
+			Data:     data,
+		}); err != nil {
+			l.Warn("Company invitation email: failed to send", "error", err)
+		}
+	}()
+}
+
+// hashCompanyInvitationToken is the stored form of an invitation token
+func hashCompanyInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"strings"
+)
+
+// companyMergeListLimit is how many past merges the admin list returns
+const companyMergeListLimit = 100
+
+type companyMergeUsecase struct {
+	repo domain.CompanyMergeRepository
+}
+
+func NewCompanyMergeUsecase(repo domain.CompanyMergeRepository) domain.CompanyMergeUsecase {
+	return &companyMergeUsecase{repo: repo}
+}
+
+// MergeCompanies folds a duplicate company profile into the surviving one
+func (u *companyMergeUsecase) MergeCompanies(ctx context.Context, adminUserID string, req domain.CompanyMergeRequest) (*domain.CompanyMerge, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.SurvivingCompanyID == req.DuplicateCompanyID {
+		return nil, apperror.BadRequest("A company cannot be merged into itself")
+	}
+	if req.Reason == "" {
+		return nil, apperror.BadRequest("A merge reason is required")
+	}
+
+	merge, err := u.repo.Merge(ctx, req, adminUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, err
+		}
+		return nil, apperror.Internal(errors.New("Failed to merge companies: " + err.Error()))
+	}
+	return merge, nil
+}
+
+func (u *companyMergeUsecase) ListMerges(ctx context.Context) ([]domain.CompanyMerge, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	merges, err := u.repo.List(ctx, companyMergeListLimit)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch company merges: " + err.Error()))
+	}
+	return merges, nil
+}
+
+func (u *companyMergeUsecase) GetMerge(ctx context.Context, id int64) (*domain.CompanyMerge, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	merge, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company merge not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company merge: " + err.Error()))
+	}
+	return merge, nil
+}
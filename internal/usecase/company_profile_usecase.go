@@ -2,8 +2,11 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"math"
+	"strings"
 )
 
 type companyProfileUsecase struct {
@@ -57,6 +60,12 @@ func (uc *companyProfileUsecase) UpdateEmployerProfile(ctx context.Context, user
 		return apperror.BadRequest("Gallery must have exactly 3 images")
 	}
 
+	// Members of a merged duplicate see the surviving profile but cannot edit it
+	existing, err := uc.profileRepo.GetByUserID(ctx, userID)
+	if err == nil && existing.UserID != userID {
+		return apperror.Forbidden("Only the company owner can edit this profile")
+	}
+
 	// Force user ID from context (security: prevent IDOR)
 	profile.UserID = userID
 
@@ -68,21 +77,79 @@ func (uc *companyProfileUsecase) GetPublicProfile(ctx context.Context, id int64,
 	profile, err := uc.profileRepo.GetByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrNotFound {
-			return nil, apperror.NotFound("Company profile not found")
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
 		}
 		return nil, err
 	}
+	if profile.ArchivedAt != nil {
+		return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+	}
+
+	return toPublicProfile(profile, viewer), nil
+}
+
+// ListPublicCompanies returns the verified company directory
+func (uc *companyProfileUsecase) ListPublicCompanies(ctx context.Context, filter domain.PublicCompanyFilter) (*domain.PaginatedResult[domain.PublicCompanySummary], error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.Industry = strings.TrimSpace(filter.Industry)
+	filter.Location = strings.TrimSpace(filter.Location)
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	companies, total, err := uc.profileRepo.ListPublic(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch companies: " + err.Error()))
+	}
+
+	return &domain.PaginatedResult[domain.PublicCompanySummary]{
+		Data:       companies,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+// GetPublicCompanyPage returns a verified company's branding page with its active jobs
+func (uc *companyProfileUsecase) GetPublicCompanyPage(ctx context.Context, id int64, viewer *domain.ViewerInfo) (*domain.PublicCompanyPage, error) {
+	profile, err := uc.profileRepo.GetPublicByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+
+	jobs, err := uc.profileRepo.ListActiveJobs(ctx, profile.ID, domain.MaxPublicCompanyPageJobs)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch company jobs: " + err.Error()))
+	}
+
+	return &domain.PublicCompanyPage{
+		PublicCompanyProfile: *toPublicProfile(profile, viewer),
+		Jobs:                 jobs,
+	}, nil
+}
 
-	// Build public profile
+// toPublicProfile projects a profile for public viewing; founder, size and
+// contact details follow the company's hide_company_details choice
+func toPublicProfile(profile *domain.CompanyProfile, viewer *domain.ViewerInfo) *domain.PublicCompanyProfile {
 	publicProfile := &domain.PublicCompanyProfile{
-		ID:            profile.ID,
-		CompanyName:   profile.CompanyName,
-		LogoURL:       profile.LogoURL,
-		Location:      profile.Location,
-		CompanyStory:  profile.CompanyStory,
-		GalleryImage1: profile.GalleryImage1,
-		GalleryImage2: profile.GalleryImage2,
-		GalleryImage3: profile.GalleryImage3,
+		ID:                profile.ID,
+		CompanyName:       profile.CompanyName,
+		LogoURL:           profile.LogoURL,
+		Location:          profile.Location,
+		CompanyStory:      profile.CompanyStory,
+		GalleryImage1:     profile.GalleryImage1,
+		GalleryImage2:     profile.GalleryImage2,
+		GalleryImage3:     profile.GalleryImage3,
+		Industry:          profile.Industry,
+		Description:       profile.Description,
+		VerificationLevel: profile.VerificationLevel,
 	}
 
 	// Apply visibility rules
@@ -100,5 +167,5 @@ func (uc *companyProfileUsecase) GetPublicProfile(ctx context.Context, id int64,
 		publicProfile.DetailsHidden = profile.HideCompanyDetails
 	}
 
-	return publicProfile, nil
+	return publicProfile
 }
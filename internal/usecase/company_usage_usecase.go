@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"time"
+)
+
+const (
+	defaultCompanyUsageMonths = 6
+	maxCompanyUsageMonths     = 24
+)
+
+type companyUsageUsecase struct {
+	usageRepo   domain.CompanyUsageRepository
+	creditRepo  domain.ContactCreditRepository
+	companyRepo domain.CompanyProfileRepository
+	now         func() time.Time
+}
+
+func NewCompanyUsageUsecase(usageRepo domain.CompanyUsageRepository, creditRepo domain.ContactCreditRepository, companyRepo domain.CompanyProfileRepository) domain.CompanyUsageUsecase {
+	return &companyUsageUsecase{usageRepo: usageRepo, creditRepo: creditRepo, companyRepo: companyRepo, now: time.Now}
+}
+
+func (u *companyUsageUsecase) GetMyUsage(ctx context.Context, userID string, months int) (*domain.CompanyUsage, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	if months == 0 {
+		months = defaultCompanyUsageMonths
+	}
+	if months < 1 || months > maxCompanyUsageMonths {
+		return nil, apperror.BadRequest(fmt.Sprintf("months must be between 1 and %d", maxCompanyUsageMonths))
+	}
+
+	// 1. Usage belongs to the company, not the user
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	// 2. Quotas, current totals and the monthly history
+	now := u.now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	fromMonth := thisMonth.AddDate(0, -(months - 1), 0)
+
+	quotas, err := u.usageRepo.GetPlanQuotas(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch plan quotas: " + err.Error()))
+	}
+	jobs, err := u.usageRepo.CountJobs(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch company usage: " + err.Error()))
+	}
+	balance, err := u.creditRepo.GetBalance(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch credit balance: " + err.Error()))
+	}
+	counts, err := u.usageRepo.CountByMonth(ctx, company.ID, fromMonth, thisMonth.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch company usage: " + err.Error()))
+	}
+
+	// 3. Every month in the range is listed, including months without usage
+	history := map[string]map[string]int64{}
+	for _, c := range counts {
+		if history[c.Metric] == nil {
+			history[c.Metric] = map[string]int64{}
+		}
+		history[c.Metric][c.Month.Format("2006-01")] = c.Count
+	}
+	series := func(metric string) []domain.CompanyUsagePoint {
+		points := []domain.CompanyUsagePoint{}
+		for m := fromMonth; !m.After(thisMonth); m = m.AddDate(0, 1, 0) {
+			key := m.Format("2006-01")
+			points = append(points, domain.CompanyUsagePoint{Month: key, Used: history[metric][key]})
+		}
+		return points
+	}
+	current := thisMonth.Format("2006-01")
+
+	// Unlocks are limited by credits: what was used this month plus what is left
+	unlocksUsed := history[domain.UsageMetricUnlocks][current]
+	unlocksLimit := unlocksUsed + int64(balance.Balance)
+
+	return &domain.CompanyUsage{
+		CompanyID: company.ID,
+		PlanCode:  quotas.PlanCode,
+		PlanName:  quotas.PlanName,
+		From:      fromMonth.Format("2006-01"),
+		To:        current,
+		Metrics: []domain.CompanyUsageMetric{
+			usageMetric(domain.UsageMetricJobSlots, "total", jobs, quotas.JobSlots, series(domain.UsageMetricJobSlots)),
+			usageMetric(domain.UsageMetricUnlocks, "month", unlocksUsed, &unlocksLimit, series(domain.UsageMetricUnlocks)),
+			usageMetric(domain.UsageMetricExports, "month", history[domain.UsageMetricExports][current], quotas.MonthlyExports, series(domain.UsageMetricExports)),
+			usageMetric(domain.UsageMetricAPICalls, "month", history[domain.UsageMetricAPICalls][current], quotas.MonthlyAPICalls, series(domain.UsageMetricAPICalls)),
+		},
+		GeneratedAt: now,
+	}, nil
+}
+
+// usageMetric fills remaining and the near-limit flag; a nil limit means unlimited
+func usageMetric(metric, period string, used int64, limit *int64, history []domain.CompanyUsagePoint) domain.CompanyUsageMetric {
+	m := domain.CompanyUsageMetric{Metric: metric, Period: period, Used: used, Limit: limit, History: history}
+	if limit != nil {
+		remaining := *limit - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		m.Remaining = &remaining
+		m.NearLimit = used*100 >= *limit*domain.UsageNearLimitPercent
+	}
+	return m
+}
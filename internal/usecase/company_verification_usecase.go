@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type companyVerificationUsecase struct {
+	repo          domain.CompanyVerificationRepository
+	companyRepo   domain.CompanyProfileRepository
+	notifications domain.NotificationDispatcher
+}
+
+// NewCompanyVerificationUsecase creates the company verification level usecase.
+// Employers are notified of level changes through notifications, which may be nil.
+func NewCompanyVerificationUsecase(repo domain.CompanyVerificationRepository, companyRepo domain.CompanyProfileRepository, notifications domain.NotificationDispatcher) domain.CompanyVerificationUsecase {
+	return &companyVerificationUsecase{repo: repo, companyRepo: companyRepo, notifications: notifications}
+}
+
+func (u *companyVerificationUsecase) GetMyVerification(ctx context.Context, userID string) (*domain.CompanyVerification, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+	return u.get(ctx, company.ID, false)
+}
+
+func (u *companyVerificationUsecase) ListCompanies(ctx context.Context, filter domain.CompanyVerificationFilter) (*domain.PaginatedResult[domain.CompanyVerification], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if filter.Level != "" && !slices.Contains(domain.CompanyVerificationLevels, filter.Level) {
+		return nil, apperror.BadRequest("Invalid verification level: " + filter.Level)
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+	filter.ScopeProvinces = domain.AdminProvincesFromContext(ctx)
+
+	companies, total, err := u.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch companies: " + err.Error()))
+	}
+	for i := range companies {
+		withCapabilities(&companies[i])
+	}
+
+	return &domain.PaginatedResult[domain.CompanyVerification]{
+		Data:       companies,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+func (u *companyVerificationUsecase) GetCompanyVerification(ctx context.Context, companyID int64) (*domain.CompanyVerification, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.get(ctx, companyID, true)
+}
+
+func (u *companyVerificationUsecase) SetLevel(ctx context.Context, adminID string, companyID int64, req domain.SetCompanyLevelRequest) (*domain.CompanyVerification, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	company, err := u.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+	if !domain.ProvinceInScope(domain.AdminProvincesFromContext(ctx), company.Province) {
+		return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+	}
+	if company.MergedIntoID != nil {
+		return nil, apperror.Conflict("The company was merged into another company; set the level on the surviving company")
+	}
+
+	if err := u.repo.SetLevel(ctx, companyID, req.Level, &adminID, strings.TrimSpace(req.Reason)); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to update verification level: " + err.Error()))
+	}
+	if req.Level != company.VerificationLevel {
+		u.notifyLevelChange(ctx, company.UserID, company.VerificationLevel, req.Level)
+	}
+	return u.get(ctx, companyID, true)
+}
+
+func (u *companyVerificationUsecase) get(ctx context.Context, companyID int64, withHistory bool) (*domain.CompanyVerification, error) {
+	v, err := u.repo.Get(ctx, companyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch verification level: " + err.Error()))
+	}
+	if !domain.ProvinceInScope(domain.AdminProvincesFromContext(ctx), v.Province) {
+		return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+	}
+	withCapabilities(v)
+
+	if withHistory {
+		v.History, err = u.repo.History(ctx, companyID)
+		if err != nil {
+			return nil, apperror.Internal(errors.New("Failed to fetch verification history: " + err.Error()))
+		}
+	}
+	return v, nil
+}
+
+// notifyLevelChange tells the employer what their new level unlocks; failures are only logged
+func (u *companyVerificationUsecase) notifyLevelChange(ctx context.Context, employerUserID, from, to string) {
+	if u.notifications == nil {
+		return
+	}
+	caps := domain.CompanyCapabilitiesFor(to)
+	features := domain.NotificationLines{"ATS candidate search requires STANDARD verification or higher"}
+	if caps.ATSAccess {
+		features = domain.NotificationLines{"ATS candidate search is included"}
+	}
+	if caps.ConfidentialJobs {
+		features = append(features, "Confidential job postings are included")
+	} else {
+		features = append(features, "Confidential job postings require STANDARD verification or higher")
+	}
+	n := &domain.Notification{
+		UserID:      employerUserID,
+		Category:    domain.NotificationCategoryAccount,
+		Subject:     "Your company verification level is now %s",
+		SubjectArgs: []any{to},
+		Body:        "Your company verification level changed from %s to %s. You can keep any number of jobs open.\n\n%s",
+		BodyArgs:    []any{from, to, features},
+	}
+	if caps.MaxOpenJobs > 0 {
+		n.Body = "Your company verification level changed from %s to %s. You can keep up to %d jobs open at a time.\n\n%s"
+		n.BodyArgs = []any{from, to, caps.MaxOpenJobs, features}
+	}
+	if err := u.notifications.Dispatch(ctx, n); err != nil {
+		logger.FromContext(ctx).Error("Company verification: failed to notify employer", "user_id", employerUserID, "error", err)
+	}
+}
+
+// withCapabilities fills in what the level unlocks and the level above it
+func withCapabilities(v *domain.CompanyVerification) {
+	v.Capabilities = domain.CompanyCapabilitiesFor(v.Level)
+	if rank := domain.CompanyLevelRank(v.Level); rank+1 < len(domain.CompanyVerificationLevels) {
+		next := domain.CompanyVerificationLevels[rank+1]
+		v.NextLevel = &next
+	}
+}
+
+// requireOpenJobSlot fails when the company's verification level allows no more open jobs
+func requireOpenJobSlot(ctx context.Context, levels domain.CompanyVerificationRepository, company *domain.CompanyProfile) error {
+	return requireOpenJobSlots(ctx, levels, company, 1)
+}
+
+// requireOpenJobSlots fails when the company's verification level does not allow n more open jobs
+func requireOpenJobSlots(ctx context.Context, levels domain.CompanyVerificationRepository, company *domain.CompanyProfile, n int) error {
+	limit := domain.CompanyCapabilitiesFor(company.VerificationLevel).MaxOpenJobs
+	if levels == nil || limit == 0 {
+		return nil
+	}
+	open, err := levels.CountOpenJobs(ctx, company.ID)
+	if err != nil {
+		return apperror.Internal(errors.New("Failed to count open jobs: " + err.Error()))
+	}
+	if open+n > limit {
+		return apperror.Forbidden("Open job limit of your verification level reached: " + strconv.Itoa(limit))
+	}
+	return nil
+}
+
+// requireATSAccess fails when the company's verification level does not include the ATS
+func requireATSAccess(company *domain.CompanyProfile) error {
+	if !domain.CompanyCapabilitiesFor(company.VerificationLevel).ATSAccess {
+		return apperror.Forbidden("ATS access requires STANDARD verification or higher")
+	}
+	return nil
+}
+
+// requireConfidentialJobs fails when the company's verification level does not include confidential jobs
+func requireConfidentialJobs(company *domain.CompanyProfile) error {
+	if !domain.CompanyCapabilitiesFor(company.VerificationLevel).ConfidentialJobs {
+		return apperror.Forbidden("Confidential job postings require STANDARD verification or higher")
+	}
+	return nil
+}
@@ -2,24 +2,29 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/jobs"
 	"strings"
 )
 
 type contactUsecase struct {
 	emailService *email.EmailService
+	jobQueue     domain.JobEnqueuer // nil sends during the request
 }
 
-// NewContactUsecase creates a new contact usecase
-func NewContactUsecase(emailService *email.EmailService) domain.ContactUsecase {
+// NewContactUsecase creates a new contact usecase. With a job queue the email is
+// sent in the background (register NewContactEmailJob for domain.JobTypeContactEmail).
+func NewContactUsecase(emailService *email.EmailService, jobQueue domain.JobEnqueuer) domain.ContactUsecase {
 	return &contactUsecase{
 		emailService: emailService,
+		jobQueue:     jobQueue,
 	}
 }
 
-// SendContactMessage validates the contact request and sends the email
+// SendContactMessage validates the contact request and sends (or queues) the email
 func (uc *contactUsecase) SendContactMessage(ctx context.Context, req *domain.ContactRequest) error {
 	// Validate input (additional validation beyond binding)
 	if strings.TrimSpace(req.Name) == "" {
@@ -48,10 +53,32 @@ func (uc *contactUsecase) SendContactMessage(ctx context.Context, req *domain.Co
 		Message:     strings.TrimSpace(req.Message),
 	}
 
+	// Queue the email; failed sends are retried by the job queue
+	if uc.jobQueue != nil {
+		if _, err := uc.jobQueue.Enqueue(ctx, domain.JobTypeContactEmail, emailData); err != nil {
+			return fmt.Errorf("failed to queue contact email: %w", err)
+		}
+		return nil
+	}
+
 	// Send the email
-	if err := uc.emailService.SendContactEmail(emailData); err != nil {
+	if err := uc.emailService.SendContactEmail(ctx, emailData); err != nil {
 		return fmt.Errorf("failed to send contact email: %w", err)
 	}
 
 	return nil
 }
+
+// NewContactEmailJob sends queued contact form messages
+func NewContactEmailJob(emailService *email.EmailService) jobs.Handler {
+	return func(ctx context.Context, job *jobs.Job) error {
+		var data email.ContactEmailData
+		if err := json.Unmarshal(job.Payload, &data); err != nil {
+			return fmt.Errorf("decode contact email job: %w", err)
+		}
+		if err := emailService.SendContactEmail(ctx, data); err != nil {
+			return fmt.Errorf("failed to send contact email: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/security"
+	"math"
+	"net/http"
+	"strings"
+)
+
+const maxIdempotencyKeyLength = 100
+
+type contactCreditUsecase struct {
+	creditRepo  domain.ContactCreditRepository
+	companyRepo domain.CompanyProfileRepository
+}
+
+func NewContactCreditUsecase(creditRepo domain.ContactCreditRepository, companyRepo domain.CompanyProfileRepository) domain.ContactCreditUsecase {
+	return &contactCreditUsecase{creditRepo: creditRepo, companyRepo: companyRepo}
+}
+
+// ============================================================================
+// Employer
+// ============================================================================
+
+func (u *contactCreditUsecase) GetMyBalance(ctx context.Context, userID string) (*domain.CompanyCreditBalance, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := u.creditRepo.GetBalance(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch credit balance: " + err.Error()))
+	}
+	return balance, nil
+}
+
+func (u *contactCreditUsecase) ListMyLedger(ctx context.Context, userID string, page, pageSize int) (*domain.PaginatedResult[domain.CreditLedgerEntry], error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.listLedger(ctx, company.ID, page, pageSize)
+}
+
+func (u *contactCreditUsecase) RevealContact(ctx context.Context, userID, candidateUserID, idempotencyKey string) (*domain.ContactRevealResult, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := normalizeIdempotencyKey(idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Candidate must exist, be active and visible to the company before any credit is spent
+	contact, err := u.creditRepo.GetCandidateContact(ctx, company.ID, candidateUserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate not found").WithCode(domain.ErrCodeCandidateNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	// 2. Deduct (no-op if already revealed or replayed)
+	entry, charged, err := u.creditRepo.ConsumeReveal(ctx, company.ID, candidateUserID, userID, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrInsufficientCredits) {
+			return nil, apperror.New(http.StatusPaymentRequired, "Insufficient credits to reveal contact", err).WithCode(domain.ErrCodeInsufficientContactCredits)
+		}
+		return nil, apperror.Internal(errors.New("Failed to reveal contact: " + err.Error()))
+	}
+
+	// 3. A replayed key must belong to the same candidate
+	if entry != nil && (entry.CandidateUserID == nil || *entry.CandidateUserID != candidateUserID) {
+		return nil, apperror.Conflict("Idempotency key already used for a different request").WithCode(domain.ErrCodeIdempotencyKeyReused)
+	}
+
+	// 4. Audit
+	if charged {
+		security.DefaultLogger().Log(ctx, security.SecurityEvent{
+			Event:        "CONTACT_REVEALED",
+			SubjectType:  "user_id",
+			SubjectValue: security.HashValue(candidateUserID),
+			Details: map[string]interface{}{
+				"company_id": company.ID,
+				"ledger_id":  entry.ID,
+			},
+		})
+	}
+
+	balance, err := u.creditRepo.GetBalance(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	return &domain.ContactRevealResult{
+		Contact: contact,
+		Charged: charged,
+		Balance: balance.Balance,
+		Entry:   entry,
+	}, nil
+}
+
+// ============================================================================
+// Admin
+// ============================================================================
+
+func (u *contactCreditUsecase) GetCompanyBalance(ctx context.Context, companyID int64) (*domain.CompanyCreditBalance, error) {
+	if err := u.requireAdminCompany(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	balance, err := u.creditRepo.GetBalance(ctx, companyID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch credit balance: " + err.Error()))
+	}
+	return balance, nil
+}
+
+func (u *contactCreditUsecase) ListCompanyLedger(ctx context.Context, companyID int64, page, pageSize int) (*domain.PaginatedResult[domain.CreditLedgerEntry], error) {
+	if err := u.requireAdminCompany(ctx, companyID); err != nil {
+		return nil, err
+	}
+	return u.listLedger(ctx, companyID, page, pageSize)
+}
+
+func (u *contactCreditUsecase) GrantCredits(ctx context.Context, companyID int64, idempotencyKey string, req *domain.GrantCreditsRequest) (*domain.CreditLedgerEntry, error) {
+	if err := u.requireAdminCompany(ctx, companyID); err != nil {
+		return nil, err
+	}
+	adminID, _ := ctx.Value(domain.KeyUserID).(string)
+
+	key, err := normalizeIdempotencyKey(idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domain.CreditLedgerEntry{
+		CompanyID:      companyID,
+		EntryType:      req.EntryType,
+		Amount:         req.Amount,
+		IdempotencyKey: key,
+		Note:           req.Note,
+	}
+	if adminID != "" {
+		entry.ActorUserID = &adminID
+	}
+
+	replayed, err := u.creditRepo.AddCredits(ctx, entry)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to grant credits: " + err.Error()))
+	}
+	if replayed && (entry.EntryType != req.EntryType || entry.Amount != req.Amount) {
+		return nil, apperror.Conflict("Idempotency key already used for a different request").WithCode(domain.ErrCodeIdempotencyKeyReused)
+	}
+
+	return entry, nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// employerCompany resolves the caller's company; credits belong to the company, not the user
+func (u *contactCreditUsecase) employerCompany(ctx context.Context, userID string) (*domain.CompanyProfile, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+	return company, nil
+}
+
+func (u *contactCreditUsecase) requireAdminCompany(ctx context.Context, companyID int64) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	if _, err := u.companyRepo.GetByID(ctx, companyID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Company not found")
+		}
+		return apperror.Internal(err)
+	}
+	return nil
+}
+
+func (u *contactCreditUsecase) listLedger(ctx context.Context, companyID int64, page, pageSize int) (*domain.PaginatedResult[domain.CreditLedgerEntry], error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	entries, total, err := u.creditRepo.ListLedger(ctx, companyID, page, pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch credit ledger: " + err.Error()))
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return &domain.PaginatedResult[domain.CreditLedgerEntry]{
+		Data:       entries,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// normalizeIdempotencyKey returns nil for an empty key
+func normalizeIdempotencyKey(key string) (*string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, nil
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, apperror.BadRequest("Idempotency-Key is too long")
+	}
+	return &key, nil
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/resumeparser"
+	"strings"
+)
+
+type cvParseUsecase struct {
+	repo domain.CVParseRepository
+}
+
+func NewCVParseUsecase(repo domain.CVParseRepository) domain.CVParseUsecase {
+	return &cvParseUsecase{repo: repo}
+}
+
+func (u *cvParseUsecase) ParseCV(ctx context.Context, userID, filename string, data []byte) (*domain.CVDraft, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	resume, err := parseResume(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	skills, err := u.repo.ListSkills(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to load skills: " + err.Error()))
+	}
+	return buildCVDraft(resume, skills), nil
+}
+
+func (u *cvParseUsecase) PrefillFromCV(ctx context.Context, userID, filename string, data []byte, cvURL string) (*domain.CVPrefillResult, error) {
+	resume, err := parseResume(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := u.repo.ListSkills(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to load skills: " + err.Error()))
+	}
+	draft := buildCVDraft(resume, skills)
+	result := &domain.CVPrefillResult{}
+
+	// 1. Identity and the CV link on the verification, where still empty
+	var url *string
+	if cvURL != "" {
+		url = &cvURL
+	}
+	result.VerificationFields, err = u.repo.PrefillVerification(ctx, userID, draft.FirstName, draft.LastName, draft.Phone, url)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, apperror.Internal(errors.New("Failed to prefill verification: " + err.Error()))
+	}
+
+	// 2. Education on the candidate profile, if one exists
+	result.EducationFields, err = u.repo.PrefillEducation(ctx, userID, draft.HighestEducation, draft.MajorField)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, apperror.Internal(errors.New("Failed to prefill education: " + err.Error()))
+	}
+
+	// 3. Work history only for candidates who have not entered any
+	result.WorkExperiences, err = u.repo.AddWorkExperiences(ctx, userID, draft.WorkExperiences)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to prefill work experience: " + err.Error()))
+	}
+
+	// 4. Skills from the master list are added alongside existing ones
+	ids := make([]int, 0, len(draft.MatchedSkills))
+	for _, s := range draft.MatchedSkills {
+		ids = append(ids, s.ID)
+	}
+	result.Skills, err = u.repo.AddSkills(ctx, userID, ids)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to prefill skills: " + err.Error()))
+	}
+
+	return result, nil
+}
+
+// parseResume maps parser errors to client errors
+func parseResume(filename string, data []byte) (*resumeparser.Resume, error) {
+	resume, err := resumeparser.Parse(filename, data)
+	switch {
+	case errors.Is(err, resumeparser.ErrUnsupportedFormat):
+		return nil, apperror.BadRequest("Only PDF and DOCX CVs can be parsed")
+	case errors.Is(err, resumeparser.ErrEncrypted):
+		return nil, apperror.BadRequest("Password-protected PDFs cannot be parsed")
+	case errors.Is(err, resumeparser.ErrNoText):
+		return nil, apperror.BadRequest("No text found in the CV. Scanned documents cannot be parsed")
+	case err != nil:
+		return nil, apperror.BadRequest("The CV could not be read")
+	}
+	return resume, nil
+}
+
+// buildCVDraft converts the parsed resume into profile fields
+func buildCVDraft(r *resumeparser.Resume, skills []domain.Skill) *domain.CVDraft {
+	draft := &domain.CVDraft{
+		Education:       []domain.CVEducation{},
+		WorkExperiences: []domain.WorkExperience{},
+		Skills:          r.Skills,
+		MatchedSkills:   []domain.Skill{},
+	}
+	if draft.Skills == nil {
+		draft.Skills = []string{}
+	}
+
+	if r.Name != "" {
+		first, last := resumeparser.SplitName(r.Name)
+		draft.FirstName = optionalString(first)
+		draft.LastName = optionalString(last)
+	} else {
+		draft.Warnings = append(draft.Warnings, "Name not found")
+	}
+	draft.Email = optionalString(r.Email)
+	draft.Phone = optionalString(r.Phone)
+
+	for _, e := range r.Education {
+		ed := domain.CVEducation{Institution: e.Institution, Degree: e.Degree, Major: e.Major}
+		if e.StartYear > 0 {
+			ed.StartYear = &e.StartYear
+		}
+		if e.EndYear > 0 {
+			ed.EndYear = &e.EndYear
+		}
+		draft.Education = append(draft.Education, ed)
+	}
+	if best, ok := resumeparser.HighestDegree(r.Education); ok {
+		draft.HighestEducation = optionalString(best.Degree)
+		draft.MajorField = optionalString(best.Major)
+	}
+
+	for _, e := range r.Experience {
+		// work_experiences needs a start date and both names
+		if e.Start == nil || e.Company == "" || e.Title == "" {
+			draft.Warnings = append(draft.Warnings, "Incomplete work experience skipped: "+strings.TrimSpace(e.Title+" "+e.Company))
+			continue
+		}
+		country := guessExperienceCountry(e.Company + " " + e.Description)
+		experienceType := "LOCAL"
+		if country != "ID" {
+			experienceType = "OVERSEAS"
+		}
+		we := domain.WorkExperience{
+			CountryCode:    country,
+			ExperienceType: experienceType,
+			CompanyName:    e.Company,
+			JobTitle:       e.Title,
+			StartDate:      e.Start.Format("2006-01-02"),
+			Description:    e.Description,
+		}
+		if e.End != nil {
+			end := e.End.Format("2006-01-02")
+			we.EndDate = &end
+		}
+		draft.WorkExperiences = append(draft.WorkExperiences, we)
+	}
+
+	draft.MatchedSkills = matchSkills(r.Skills, skills)
+	return draft
+}
+
+// japanMarkers suggest a job was in Japan; everything else is assumed local (Indonesia)
+var japanMarkers = []string{
+	"japan", "jepang", "tokyo", "osaka", "aichi", "nagoya", "saitama", "chiba", "kanagawa", "yokohama",
+	"hokkaido", "fukuoka", "hiroshima", "shizuoka", "gifu", "ibaraki", "gunma", "tochigi", "kyoto", "hyogo",
+	"k.k.", "kabushiki", "株式会社", "有限会社", "日本",
+}
+
+func guessExperienceCountry(text string) string {
+	lower := strings.ToLower(text)
+	for _, m := range japanMarkers {
+		if strings.Contains(lower, m) {
+			return "JP"
+		}
+	}
+	return "ID"
+}
+
+// matchSkills finds master skills named in the CV, ignoring case and punctuation
+func matchSkills(written []string, master []domain.Skill) []domain.Skill {
+	byName := make(map[string]domain.Skill, len(master))
+	for _, s := range master {
+		byName[normalizeSkill(s.Name)] = s
+	}
+
+	matched := []domain.Skill{}
+	seen := map[int]bool{}
+	for _, w := range written {
+		if s, ok := byName[normalizeSkill(w)]; ok && !seen[s.ID] {
+			seen[s.ID] = true
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+func normalizeSkill(s string) string {
+	s = strings.ToLower(s)
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '.' || r == '_'
+	}), " ")
+}
+
+func optionalString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
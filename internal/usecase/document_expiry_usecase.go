@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
+	"strings"
+	"sync"
+	"time"
+)
+
+const maxDocumentExpiryReportMonths = 24
+
+// DocumentExpiryConfig tunes the document expiry reminder worker
+type DocumentExpiryConfig struct {
+	MaxPerRun   int    // documents handled per run
+	FrontendURL string // base for links in messages
+}
+
+type documentExpiryUsecase struct {
+	repo     domain.DocumentExpiryRepository
+	notifier domain.CandidateNotifier
+	cfg      DocumentExpiryConfig
+	now      func() time.Time
+
+	running sync.Mutex
+}
+
+func NewDocumentExpiryUsecase(repo domain.DocumentExpiryRepository, notifier domain.CandidateNotifier, cfg DocumentExpiryConfig) domain.DocumentExpiryUsecase {
+	if notifier == nil {
+		notifier = logCandidateNotifier{}
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = 500
+	}
+	return &documentExpiryUsecase{repo: repo, notifier: notifier, cfg: cfg, now: time.Now}
+}
+
+func (u *documentExpiryUsecase) RunReminders(ctx context.Context) (*domain.DocumentExpiryRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A document expiry reminder run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	result := &domain.DocumentExpiryRunResult{StartedAt: now}
+
+	// 1. Documents whose current threshold (90/30/7 days) has not been reminded yet
+	due, err := u.repo.ListDueReminders(ctx, today, domain.DocumentExpiryReminderDays, u.cfg.MaxPerRun)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch expiring documents: " + err.Error()))
+	}
+	result.Documents = len(due)
+
+	// 2. One message per candidate listing all of their due documents (rows are ordered by user)
+	for start := 0; start < len(due); {
+		end := start + 1
+		for end < len(due) && due[end].UserID == due[start].UserID {
+			end++
+		}
+		documents := due[start:end]
+		start = end
+		result.Candidates++
+
+		status, errMsg := domain.DocumentReminderStatusSent, (*string)(nil)
+		if err := u.notifier.NotifyCandidate(ctx, u.renderReminder(documents)); err != nil {
+			msg := err.Error()
+			status, errMsg = domain.DocumentReminderStatusFailed, &msg
+			result.Failed++
+		} else {
+			result.Sent++
+		}
+
+		// 3. Record per document so each threshold is reminded once; failures are retried next run
+		for _, d := range documents {
+			if err := u.repo.RecordReminder(ctx, &domain.DocumentExpiryReminder{
+				UserID:        d.UserID,
+				DocumentType:  d.DocumentType,
+				DocumentRef:   d.DocumentRef,
+				ExpiryDate:    d.ExpiryDate,
+				ThresholdDays: d.ThresholdDays,
+				Channel:       u.notifier.Channel(),
+				Status:        status,
+				ErrorMessage:  errMsg,
+			}); err != nil {
+				logger.FromContext(ctx).Error("Document expiry: failed to record reminder", "user_id", d.UserID, "error", err)
+			}
+		}
+	}
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+func (u *documentExpiryUsecase) TriggerReminders(ctx context.Context) (*domain.DocumentExpiryRunResult, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunReminders(ctx)
+}
+
+func (u *documentExpiryUsecase) ListMyDocuments(ctx context.Context, userID string) ([]domain.CandidateDocumentExpiry, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	documents, err := u.repo.ListByUser(ctx, userID, u.now().UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch document expiries: " + err.Error()))
+	}
+	return documents, nil
+}
+
+func (u *documentExpiryUsecase) GetReport(ctx context.Context, from, to string) (*domain.DocumentExpiryReport, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	fromMonth, toMonth, err := parseDocumentExpiryRange(from, to, now)
+	if err != nil {
+		return nil, err
+	}
+	endExclusive := toMonth.AddDate(0, 1, 0)
+
+	counts, err := u.repo.CountByMonth(ctx, fromMonth, endExclusive)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to build document expiry report: " + err.Error()))
+	}
+	candidates, err := u.repo.CountCandidatesByMonth(ctx, fromMonth, endExclusive)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to build document expiry report: " + err.Error()))
+	}
+	expired, err := u.repo.CountExpired(ctx, now.Truncate(24*time.Hour))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to build document expiry report: " + err.Error()))
+	}
+
+	// Every month in the range is listed, including months with no expirations
+	report := &domain.DocumentExpiryReport{
+		From:        fromMonth.Format("2006-01"),
+		To:          toMonth.Format("2006-01"),
+		Months:      []domain.DocumentExpiryMonth{},
+		Expired:     expired,
+		GeneratedAt: now,
+	}
+	index := map[string]int{}
+	for m := fromMonth; !m.After(toMonth); m = m.AddDate(0, 1, 0) {
+		key := m.Format("2006-01")
+		index[key] = len(report.Months)
+		report.Months = append(report.Months, domain.DocumentExpiryMonth{
+			Month:      key,
+			Candidates: candidates[key],
+			ByType:     map[string]int64{},
+		})
+	}
+	for _, c := range counts {
+		i, ok := index[c.Month.Format("2006-01")]
+		if !ok {
+			continue
+		}
+		report.Months[i].ByType[c.DocumentType] = c.Count
+		report.Months[i].Total += c.Count
+	}
+	return report, nil
+}
+
+func (u *documentExpiryUsecase) renderReminder(documents []domain.DocumentExpiryReminderCandidate) *domain.CandidateNotification {
+	first := documents[0]
+	locale := i18n.LocaleID // candidates are Indonesian unless they chose otherwise
+	if first.PreferredLocale != nil && i18n.IsSupported(*first.PreferredLocale) {
+		locale = *first.PreferredLocale
+	}
+	t := func(msg string) string { return i18n.T(locale, msg) }
+
+	var b strings.Builder
+	if first.FirstName != nil && strings.TrimSpace(*first.FirstName) != "" {
+		b.WriteString(fmt.Sprintf(t("Hello %s,"), strings.TrimSpace(*first.FirstName)))
+	} else {
+		b.WriteString(t("Hello,"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(t("The following documents will expire soon:"))
+	b.WriteString("\n")
+	for _, d := range documents {
+		label := t(documentTypeLabels[d.DocumentType])
+		if d.DocumentLabel != nil && *d.DocumentLabel != "" {
+			label += " (" + *d.DocumentLabel + ")"
+		}
+		b.WriteString(fmt.Sprintf("- "+t("%s: expires on %s (%d days left)"), label, d.ExpiryDate.Format("2006-01-02"), d.DaysLeft))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n" + t("Please renew them and upload the new documents so your applications are not delayed.") + "\n")
+	b.WriteString(fmt.Sprintf(t("Update your documents here: %s"), u.cfg.FrontendURL+"/candidate/profile") + "\n")
+
+	return &domain.CandidateNotification{
+		UserID:   first.UserID,
+		Email:    first.Email,
+		Locale:   locale,
+		Campaign: "DOCUMENT_EXPIRY",
+		Subject:  t("Your documents are about to expire"),
+		Body:     b.String(),
+	}
+}
+
+// documentTypeLabels are i18n source messages for document types
+var documentTypeLabels = map[string]string{
+	domain.DocumentTypePassport:     "Passport",
+	domain.DocumentTypeJLPT:         "JLPT certificate",
+	domain.DocumentTypeMedicalCheck: "Medical check",
+	domain.DocumentTypeCoE:          "Certificate of Eligibility (CoE)",
+	domain.DocumentTypeCertificate:  "Certificate",
+	domain.DocumentTypeDiploma:      "Diploma",
+	domain.DocumentTypeVisa:         "Visa",
+}
+
+// parseDocumentExpiryRange parses YYYY-MM bounds; defaults to this month and the 11 after it
+func parseDocumentExpiryRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	fromMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if from != "" {
+		f, err := time.Parse("2006-01", from)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperror.BadRequest("Invalid 'from' month, expected YYYY-MM")
+		}
+		fromMonth = f
+	}
+
+	toMonth := fromMonth.AddDate(0, 11, 0)
+	if to != "" {
+		t, err := time.Parse("2006-01", to)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperror.BadRequest("Invalid 'to' month, expected YYYY-MM")
+		}
+		toMonth = t
+	}
+
+	if fromMonth.After(toMonth) {
+		return time.Time{}, time.Time{}, apperror.BadRequest("'from' must not be after 'to'")
+	}
+	if !toMonth.Before(fromMonth.AddDate(0, maxDocumentExpiryReportMonths, 0)) {
+		return time.Time{}, time.Time{}, apperror.BadRequest(fmt.Sprintf("Range cannot exceed %d months", maxDocumentExpiryReportMonths))
+	}
+	return fromMonth, toMonth, nil
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/security"
+	"image"
+	_ "image/gif"  // Register GIF decoder
+	_ "image/jpeg" // Register JPEG decoder
+	_ "image/png"  // Register PNG decoder
+	"math"
+	"slices"
+	"time"
+)
+
+type duplicateDocumentUsecase struct {
+	repo domain.DuplicateDocumentRepository
+	now  func() time.Time
+}
+
+func NewDuplicateDocumentUsecase(repo domain.DuplicateDocumentRepository) domain.DuplicateDocumentUsecase {
+	return &duplicateDocumentUsecase{repo: repo, now: time.Now}
+}
+
+// ============================================================================
+// Upload check
+// ============================================================================
+
+func (u *duplicateDocumentUsecase) CheckUpload(ctx context.Context, upload domain.DocumentImageUpload) error {
+	if !slices.Contains(domain.DuplicateDocumentBuckets, upload.Bucket) {
+		return nil
+	}
+	// PDFs and other files have no pixels to compare
+	img, _, err := image.Decode(bytes.NewReader(upload.Data))
+	if err != nil {
+		return nil
+	}
+
+	h := &domain.DocumentImageHash{
+		UserID:  upload.UserID,
+		Bucket:  upload.Bucket,
+		FileURL: upload.FileURL,
+		Hash:    security.PerceptualHash(img),
+	}
+	if upload.FileID != "" {
+		h.FileID = &upload.FileID
+	}
+	matches, err := u.repo.CreateHash(ctx, h, domain.DuplicateDocumentMaxDistance, domain.MaxDuplicateDocumentMatches)
+	if err != nil {
+		return fmt.Errorf("store document hash: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	reviewID, err := u.repo.CreateReview(ctx, h.ID, matches)
+	if err != nil {
+		return fmt.Errorf("create duplicate document review: %w", err)
+	}
+	logger.FromContext(ctx).Warn("Duplicate document flagged for review",
+		"review_id", reviewID, "user_id", upload.UserID, "bucket", upload.Bucket, "matched_accounts", len(matches))
+	return nil
+}
+
+// ============================================================================
+// Admin
+// ============================================================================
+
+func (u *duplicateDocumentUsecase) ListReviews(ctx context.Context, filter domain.DuplicateDocumentFilter) (*domain.PaginatedResult[domain.DuplicateDocumentReview], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	switch filter.Status {
+	case "":
+		filter.Status = domain.DuplicateDocumentPending
+	case "ALL":
+		filter.Status = ""
+	case domain.DuplicateDocumentPending, domain.DuplicateDocumentConfirmed, domain.DuplicateDocumentDismissed:
+	default:
+		return nil, apperror.BadRequest("Invalid status: " + filter.Status)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	reviews, total, err := u.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch duplicate documents: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.DuplicateDocumentReview]{
+		Data:       reviews,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+func (u *duplicateDocumentUsecase) GetReview(ctx context.Context, id int64) (*domain.DuplicateDocumentReview, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	review, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Duplicate document review not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch duplicate document: " + err.Error()))
+	}
+	return review, nil
+}
+
+func (u *duplicateDocumentUsecase) ReviewDuplicate(ctx context.Context, adminID string, id int64, req domain.ReviewDuplicateDocumentRequest) (*domain.DuplicateDocumentReview, error) {
+	review, err := u.GetReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	review.Status = domain.DuplicateDocumentConfirmed
+	if req.Action == "DISMISS" {
+		review.Status = domain.DuplicateDocumentDismissed
+	}
+	review.ReviewNotes = trimmedOrNil(req.Notes)
+	review.ReviewedAt = &now
+	review.ReviewedBy = nil
+	if adminID != "" {
+		review.ReviewedBy = &adminID
+	}
+	if err := u.repo.Review(ctx, review); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Duplicate document review not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to review duplicate document: " + err.Error()))
+	}
+	return review, nil
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"go-recruitment-backend/config"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/email"
+	"slices"
+	"time"
+)
+
+type emailTrackingUsecase struct {
+	repo               domain.EmailTrackingRepository
+	baseURL            string
+	disabledCategories []string
+	now                func() time.Time
+}
+
+// NewEmailTrackingUsecase tracks emails through links to baseURL/t/:token;
+// nothing is tracked while cfg.EmailTrackingBaseURL is empty
+func NewEmailTrackingUsecase(repo domain.EmailTrackingRepository, cfg *config.Config) domain.EmailTrackingUsecase {
+	return &emailTrackingUsecase{
+		repo:               repo,
+		baseURL:            cfg.EmailTrackingBaseURL,
+		disabledCategories: cfg.EmailTrackingDisabledCategories,
+		now:                time.Now,
+	}
+}
+
+// Track records a message with one token for the open pixel and one per
+// distinct link, unless tracking is off for the template's category or the user
+func (u *emailTrackingUsecase) Track(ctx context.Context, template, userID string, links []string) (*email.Tracking, error) {
+	category := email.TemplateCategories[template]
+	if u.baseURL == "" || !slices.Contains(domain.EmailTrackingCategories, category) || slices.Contains(u.disabledCategories, category) {
+		return nil, nil
+	}
+	pref, err := u.repo.GetPreference(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if pref != nil && (!pref.Enabled || slices.Contains(pref.DisabledCategories, category)) {
+		return nil, nil
+	}
+
+	openToken, err := newEmailTrackingToken()
+	if err != nil {
+		return nil, err
+	}
+	msg := &domain.EmailMessage{Template: template, Category: category, OpenToken: openToken, SentAt: u.now().UTC()}
+	tracking := &email.Tracking{PixelURL: u.trackingURL(openToken), Links: map[string]string{}}
+	for _, link := range links {
+		if _, ok := tracking.Links[link]; ok {
+			continue
+		}
+		token, err := newEmailTrackingToken()
+		if err != nil {
+			return nil, err
+		}
+		msg.Links = append(msg.Links, domain.EmailMessageLink{Token: token, URL: link})
+		tracking.Links[link] = u.trackingURL(token)
+	}
+
+	if err := u.repo.CreateMessage(ctx, msg); err != nil {
+		return nil, err
+	}
+	return tracking, nil
+}
+
+func (u *emailTrackingUsecase) trackingURL(token string) string {
+	return u.baseURL + "/t/" + token
+}
+
+func newEmailTrackingToken() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (u *emailTrackingUsecase) Hit(ctx context.Context, token string) (*domain.EmailTrackingHit, error) {
+	hit, err := u.repo.RecordHit(ctx, token, u.now().UTC())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Link not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to record email tracking hit: " + err.Error()))
+	}
+	return hit, nil
+}
+
+func (u *emailTrackingUsecase) GetPreference(ctx context.Context, userID string) (*domain.EmailTrackingPreference, error) {
+	pref, err := u.repo.GetPreference(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		pref, err = &domain.EmailTrackingPreference{Enabled: true, DisabledCategories: []string{}}, nil
+	}
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch email tracking preference: " + err.Error()))
+	}
+	pref.Categories = domain.EmailTrackingCategories
+	return pref, nil
+}
+
+func (u *emailTrackingUsecase) UpdatePreference(ctx context.Context, userID string, req domain.UpdateEmailTrackingPreferenceRequest) (*domain.EmailTrackingPreference, error) {
+	disabled := []string{}
+	for _, category := range req.DisabledCategories {
+		if !slices.Contains(domain.EmailTrackingCategories, category) {
+			return nil, apperror.BadRequest("Unknown email category: " + category)
+		}
+		if !slices.Contains(disabled, category) {
+			disabled = append(disabled, category)
+		}
+	}
+
+	now := u.now().UTC()
+	pref := &domain.EmailTrackingPreference{
+		Enabled:            *req.Enabled,
+		DisabledCategories: disabled,
+		Categories:         domain.EmailTrackingCategories,
+		UpdatedAt:          &now,
+	}
+	if err := u.repo.UpsertPreference(ctx, userID, pref); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save email tracking preference: " + err.Error()))
+	}
+	return pref, nil
+}
+
+// GetReport is per-template engagement of emails sent in the range, for
+// unrestricted admins (defaults to the last 30 days including today)
+func (u *emailTrackingUsecase) GetReport(ctx context.Context, from, to string) (*domain.EmailEngagementReport, error) {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	fromDay, toDay, err := parseReengagementRange(from, to, now)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := u.repo.GetStats(ctx, fromDay, toDay.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch email engagement: " + err.Error()))
+	}
+
+	report := &domain.EmailEngagementReport{
+		From:        fromDay.Format(domain.HolidayDateFormat),
+		To:          toDay.Format(domain.HolidayDateFormat),
+		Templates:   stats,
+		Totals:      domain.EmailEngagementStats{Template: "ALL"},
+		GeneratedAt: now,
+	}
+	for i := range report.Templates {
+		s := &report.Templates[i]
+		setEmailEngagementRates(s)
+		report.Totals.Sent += s.Sent
+		report.Totals.Opened += s.Opened
+		report.Totals.Clicked += s.Clicked
+		report.Totals.Opens += s.Opens
+		report.Totals.Clicks += s.Clicks
+	}
+	setEmailEngagementRates(&report.Totals)
+	return report, nil
+}
+
+func setEmailEngagementRates(s *domain.EmailEngagementStats) {
+	if s.Sent == 0 {
+		return
+	}
+	s.OpenRate = float64(s.Opened) / float64(s.Sent)
+	s.ClickRate = float64(s.Clicked) / float64(s.Sent)
+}
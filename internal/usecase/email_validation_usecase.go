@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"net"
+	"net/mail"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// emailDomainLookupTimeout bounds the DNS lookups for one domain
+const emailDomainLookupTimeout = 10 * time.Second
+
+// disposableEmailDomains are throwaway mailbox providers; subdomains match too.
+// EmailValidationConfig.DisposableDomains extends the list.
+var disposableEmailDomains = []string{
+	"10minutemail.com", "discard.email", "dispostable.com", "emailondeck.com", "fakeinbox.com",
+	"getnada.com", "guerrillamail.com", "guerrillamail.net", "guerrillamail.org", "mailinator.com",
+	"maildrop.cc", "mailnesia.com", "mintemail.com", "mohmal.com", "sharklasers.com",
+	"temp-mail.org", "tempmail.com", "tempr.email", "throwawaymail.com", "trashmail.com",
+	"yopmail.com",
+}
+
+// mxResolver is the part of net.Resolver the validation uses
+type mxResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailValidationConfig tunes the email validation worker
+type EmailValidationConfig struct {
+	RecheckAfter      time.Duration // deliverable and undeliverable addresses are checked again after this
+	MaxPerRun         int           // addresses checked per run
+	DisposableDomains []string      // added to the built-in list
+}
+
+type emailValidationUsecase struct {
+	repo       domain.EmailValidationRepository
+	resolver   mxResolver
+	disposable []string
+	cfg        EmailValidationConfig
+	now        func() time.Time
+
+	running sync.Mutex
+}
+
+func NewEmailValidationUsecase(repo domain.EmailValidationRepository, cfg EmailValidationConfig) domain.EmailValidationUsecase {
+	if cfg.RecheckAfter <= 0 {
+		cfg.RecheckAfter = 30 * 24 * time.Hour
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = 1000
+	}
+	return &emailValidationUsecase{
+		repo:       repo,
+		resolver:   net.DefaultResolver,
+		disposable: append(slices.Clone(disposableEmailDomains), cfg.DisposableDomains...),
+		cfg:        cfg,
+		now:        time.Now,
+	}
+}
+
+// emailDomainCheck is the outcome for one domain, shared by its addresses within a run
+type emailDomainCheck struct {
+	reason string // empty = deliverable
+	detail string
+	err    error // temporary DNS failure
+}
+
+func (u *emailValidationUsecase) RunValidation(ctx context.Context) (*domain.EmailValidationRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("An email validation run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	result := &domain.EmailValidationRunResult{StartedAt: now}
+
+	// 1. Addresses never checked, changed since, or due for a recheck
+	due, err := u.repo.ListDue(ctx, now.Add(-u.cfg.RecheckAfter), u.cfg.MaxPerRun)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch emails to validate: " + err.Error()))
+	}
+
+	// 2. Check each address; DNS results are cached per domain for the run
+	domains := map[string]emailDomainCheck{}
+	for _, c := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		check := u.checkAddress(ctx, c.Email, domains)
+		if check.err != nil {
+			// Left unchecked so the next run tries again
+			logger.FromContext(ctx).Warn("Email validation: lookup failed", "user_id", c.UserID, "error", check.err)
+			result.Errors++
+			continue
+		}
+
+		v := &domain.EmailValidation{UserID: c.UserID, Email: c.Email, CheckedAt: u.now().UTC()}
+		if check.reason != "" {
+			v.Reason, v.Detail = &check.reason, &check.detail
+		}
+		if err := u.repo.Save(ctx, v); err != nil {
+			logger.FromContext(ctx).Error("Email validation: failed to save result", "user_id", c.UserID, "error", err)
+			result.Errors++
+			continue
+		}
+		result.Checked++
+		if check.reason != "" {
+			result.Undeliverable++
+		} else {
+			result.Deliverable++
+		}
+	}
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+// checkAddress validates the syntax, then the domain against the disposable list and its mail servers
+func (u *emailValidationUsecase) checkAddress(ctx context.Context, email string, domains map[string]emailDomainCheck) emailDomainCheck {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || addr.Name != "" {
+		return emailDomainCheck{reason: domain.EmailInvalidSyntax, detail: "not a valid email address"}
+	}
+	host := strings.ToLower(addr.Address[strings.LastIndex(addr.Address, "@")+1:])
+	if !strings.Contains(host, ".") || strings.HasPrefix(host, "[") {
+		return emailDomainCheck{reason: domain.EmailInvalidSyntax, detail: "the domain is not a public host name"}
+	}
+
+	if check, ok := domains[host]; ok {
+		return check
+	}
+	check := u.checkDomain(ctx, host)
+	domains[host] = check
+	return check
+}
+
+func (u *emailValidationUsecase) checkDomain(ctx context.Context, host string) emailDomainCheck {
+	for _, d := range u.disposable {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return emailDomainCheck{reason: domain.EmailDisposable, detail: "disposable provider " + d}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, emailDomainLookupTimeout)
+	defer cancel()
+
+	mxs, err := u.resolver.LookupMX(ctx, host)
+	if err == nil {
+		// A single "." record is a null MX: the domain explicitly accepts no mail
+		if len(mxs) == 1 && (mxs[0].Host == "." || mxs[0].Host == "") {
+			return emailDomainCheck{reason: domain.EmailNoMX, detail: host + " does not accept email"}
+		}
+		return emailDomainCheck{}
+	}
+	if !isDNSNotFound(err) {
+		return emailDomainCheck{err: err}
+	}
+
+	// Without MX records mail goes to the domain's own address (implicit MX)
+	if _, err := u.resolver.LookupHost(ctx, host); err != nil {
+		if isDNSNotFound(err) {
+			return emailDomainCheck{reason: domain.EmailNoMX, detail: host + " has no mail server"}
+		}
+		return emailDomainCheck{err: err}
+	}
+	return emailDomainCheck{}
+}
+
+func isDNSNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+func (u *emailValidationUsecase) TriggerValidation(ctx context.Context) (*domain.EmailValidationRunResult, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunValidation(ctx)
+}
+
+func (u *emailValidationUsecase) GetReport(ctx context.Context) (*domain.EmailValidationReport, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	report, err := u.repo.Report(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to build email validation report: " + err.Error()))
+	}
+	report.GeneratedAt = u.now().UTC()
+	return report, nil
+}
+
+func (u *emailValidationUsecase) ListUndeliverable(ctx context.Context, filter domain.UndeliverableEmailFilter) (*domain.PaginatedResult[domain.UndeliverableEmail], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if filter.Reason != "" && !slices.Contains(domain.EmailInvalidReasons, filter.Reason) {
+		return nil, apperror.BadRequest("Invalid reason: " + filter.Reason)
+	}
+	if filter.Role != "" && filter.Role != domain.RoleCandidate && filter.Role != domain.RoleEmployer && filter.Role != domain.RoleAdmin {
+		return nil, apperror.BadRequest("Invalid role: " + filter.Role)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	accounts, total, err := u.repo.ListUndeliverable(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch undeliverable emails: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.UndeliverableEmail]{
+		Data:       accounts,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+// deliverableCandidateNotifier skips addresses the validation worker found undeliverable
+type deliverableCandidateNotifier struct {
+	next domain.CandidateNotifier
+	repo domain.EmailValidationRepository
+}
+
+// NewDeliverableCandidateNotifier wraps a notifier so undeliverable addresses are not sent to
+func NewDeliverableCandidateNotifier(next domain.CandidateNotifier, repo domain.EmailValidationRepository) domain.CandidateNotifier {
+	return deliverableCandidateNotifier{next: next, repo: repo}
+}
+
+func (n deliverableCandidateNotifier) Channel() string { return n.next.Channel() }
+
+func (n deliverableCandidateNotifier) NotifyCandidate(ctx context.Context, msg *domain.CandidateNotification) error {
+	if msg.Email != "" {
+		undeliverable, err := n.repo.IsUndeliverable(ctx, msg.Email)
+		if err != nil {
+			// Fail open: a lookup error must not block notifications
+			logger.FromContext(ctx).Warn("Email validation: lookup failed, sending anyway", "user_id", msg.UserID, "error", err)
+		} else if undeliverable {
+			logger.FromContext(ctx).Info("Candidate notification skipped: undeliverable email", "user_id", msg.UserID, "campaign", msg.Campaign)
+			return nil
+		}
+	}
+	return n.next.NotifyCandidate(ctx, msg)
+}
@@ -0,0 +1,428 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EmployerMessageConfig limits employer bulk messaging
+type EmployerMessageConfig struct {
+	DailyCap        int // recipients per company per UTC day
+	SpamReportLimit int // spam reports within SpamWindow that suspend bulk sending
+	SpamWindow      time.Duration
+}
+
+type employerMessageUsecase struct {
+	repo               domain.EmployerMessageRepository
+	companyProfileRepo domain.CompanyProfileRepository
+	notifications      domain.NotificationDispatcher
+	cfg                EmployerMessageConfig
+	now                func() time.Time
+}
+
+func NewEmployerMessageUsecase(repo domain.EmployerMessageRepository, companyProfileRepo domain.CompanyProfileRepository, notifications domain.NotificationDispatcher, cfg EmployerMessageConfig) domain.EmployerMessageUsecase {
+	return &employerMessageUsecase{repo: repo, companyProfileRepo: companyProfileRepo, notifications: notifications, cfg: cfg, now: time.Now}
+}
+
+// messageVariablePattern matches {{variable}}, allowing spaces inside the braces
+var messageVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]*)\s*\}\}`)
+
+// ============================================================================
+// Templates
+// ============================================================================
+
+func (u *employerMessageUsecase) ListTemplates(ctx context.Context, userID string) ([]domain.MessageTemplate, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := u.repo.ListTemplates(ctx, company.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch message templates: " + err.Error()))
+	}
+	return templates, nil
+}
+
+func (u *employerMessageUsecase) CreateTemplate(ctx context.Context, userID string, req domain.MessageTemplateRequest) (*domain.MessageTemplate, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := newMessageTemplate(company.ID, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.CreateTemplate(ctx, template); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save message template: " + err.Error()))
+	}
+	return template, nil
+}
+
+func (u *employerMessageUsecase) UpdateTemplate(ctx context.Context, userID string, id int64, req domain.MessageTemplateRequest) (*domain.MessageTemplate, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	template, err := newMessageTemplate(company.ID, req)
+	if err != nil {
+		return nil, err
+	}
+	template.ID = id
+	if err := u.repo.UpdateTemplate(ctx, template); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Message template not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to save message template: " + err.Error()))
+	}
+	return template, nil
+}
+
+func (u *employerMessageUsecase) DeleteTemplate(ctx context.Context, userID string, id int64) error {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.DeleteTemplate(ctx, company.ID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Message template not found")
+		}
+		return apperror.Internal(errors.New("Failed to delete message template: " + err.Error()))
+	}
+	return nil
+}
+
+// newMessageTemplate trims the request and rejects variables that cannot be filled in
+func newMessageTemplate(companyID int64, req domain.MessageTemplateRequest) (*domain.MessageTemplate, error) {
+	template := &domain.MessageTemplate{
+		CompanyID: companyID,
+		Name:      strings.TrimSpace(req.Name),
+		Subject:   strings.TrimSpace(req.Subject),
+		Body:      strings.TrimSpace(req.Body),
+	}
+	if template.Name == "" || template.Subject == "" || template.Body == "" {
+		return nil, apperror.BadRequest("Name, subject and body are required")
+	}
+	for _, text := range []string{template.Subject, template.Body} {
+		for _, m := range messageVariablePattern.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(domain.MessageTemplateVariables, m[1]) {
+				return nil, apperror.BadRequest("Unknown template variable: " + m[0])
+			}
+		}
+	}
+	return template, nil
+}
+
+// renderMessage fills in the template variables for one recipient
+func renderMessage(text string, vars map[string]string) string {
+	return messageVariablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := messageVariablePattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// ============================================================================
+// Sending
+// ============================================================================
+
+// SendBulk messages the candidates of the given shortlisted applications. Every
+// recipient gets a delivery record; candidates who reported this company's
+// messages as spam are blocked rather than messaged again.
+func (u *employerMessageUsecase) SendBulk(ctx context.Context, userID string, req domain.SendBulkMessageRequest) (*domain.BulkMessage, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.ApplicationIDs) > domain.MaxBulkMessageRecipients {
+		return nil, apperror.BadRequest("Too many applications selected")
+	}
+
+	// 1. Companies with too many spam reports cannot send
+	quota, err := u.quota(ctx, company.ID)
+	if err != nil {
+		return nil, err
+	}
+	if quota.Suspended {
+		return nil, apperror.Forbidden("Bulk messaging is suspended because candidates reported your messages as spam")
+	}
+
+	template, err := u.repo.GetTemplate(ctx, company.ID, req.TemplateID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Message template not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch message template: " + err.Error()))
+	}
+
+	// 2. Only the company's own shortlisted applications; one message per candidate
+	recipients, err := u.shortlistedRecipients(ctx, company.ID, req.ApplicationIDs)
+	if err != nil {
+		return nil, err
+	}
+	candidateIDs := make([]string, len(recipients))
+	for i, rc := range recipients {
+		candidateIDs[i] = rc.CandidateUserID
+	}
+	reporters, err := u.repo.ListSpamReporters(ctx, company.ID, candidateIDs)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to check spam reports: " + err.Error()))
+	}
+
+	// 3. Daily cap; blocked recipients do not count against it
+	sending := 0
+	for _, rc := range recipients {
+		if !reporters[rc.CandidateUserID] {
+			sending++
+		}
+	}
+	if sending > quota.Remaining {
+		return nil, apperror.New(http.StatusTooManyRequests, fmt.Sprintf("Daily message limit reached; recipients left today: %d", quota.Remaining), nil)
+	}
+
+	// 4. Record every delivery before sending, so a failure part way is visible
+	message := &domain.BulkMessage{
+		CompanyID:    company.ID,
+		TemplateID:   &template.ID,
+		SenderUserID: &userID,
+		Subject:      template.Subject,
+		Body:         template.Body,
+		Deliveries:   make([]domain.MessageDelivery, 0, len(recipients)),
+	}
+	for _, rc := range recipients {
+		name := rc.CandidateName
+		if name == "" {
+			name = "Candidate"
+		}
+		vars := map[string]string{
+			domain.MessageVarCandidateName: name,
+			domain.MessageVarJobTitle:      rc.JobTitle,
+			domain.MessageVarCompanyName:   company.CompanyName,
+		}
+		status := domain.MessageDeliveryPending
+		if reporters[rc.CandidateUserID] {
+			status = domain.MessageDeliveryBlocked
+		}
+		message.Deliveries = append(message.Deliveries, domain.MessageDelivery{
+			ApplicationID:   rc.ApplicationID,
+			CandidateUserID: rc.CandidateUserID,
+			CandidateName:   rc.CandidateName,
+			JobTitle:        rc.JobTitle,
+			Subject:         renderMessage(template.Subject, vars),
+			Body:            renderMessage(template.Body, vars),
+			Status:          status,
+		})
+	}
+	if err := u.repo.CreateMessage(ctx, message); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save message: " + err.Error()))
+	}
+
+	// 5. Deliver through the notification system
+	for i := range message.Deliveries {
+		d := &message.Deliveries[i]
+		if d.Status == domain.MessageDeliveryBlocked {
+			message.Blocked++
+			continue
+		}
+		u.deliver(ctx, company.CompanyName, d)
+		if d.Status == domain.MessageDeliverySent {
+			message.Sent++
+		} else {
+			message.Failed++
+		}
+	}
+	message.Recipients = len(message.Deliveries)
+	return message, nil
+}
+
+// deliver sends one rendered message and records the outcome on d
+func (u *employerMessageUsecase) deliver(ctx context.Context, companyName string, d *domain.MessageDelivery) {
+	err := u.notifications.Dispatch(ctx, &domain.Notification{
+		UserID:      d.CandidateUserID,
+		Category:    domain.NotificationCategoryEmployerMessage,
+		Subject:     "%s",
+		SubjectArgs: []any{d.Subject},
+		Body:        "Message from %s about your application for %s:\n\n%s\n\nIf this message is unwanted, you can report it as spam in your messages.",
+		BodyArgs:    []any{companyName, d.JobTitle, d.Body},
+	})
+	if err != nil {
+		msg := err.Error()
+		d.Status, d.ErrorMessage = domain.MessageDeliveryFailed, &msg
+	} else {
+		now := u.now().UTC()
+		d.Status, d.SentAt = domain.MessageDeliverySent, &now
+	}
+	if err := u.repo.UpdateDelivery(ctx, d); err != nil {
+		logger.FromContext(ctx).Error("Failed to record message delivery", "delivery_id", d.ID, "status", d.Status, "error", err)
+	}
+}
+
+// shortlistedRecipients resolves the applications, keeping the first one per candidate
+func (u *employerMessageUsecase) shortlistedRecipients(ctx context.Context, companyID int64, applicationIDs []int64) ([]domain.MessageRecipient, error) {
+	found, err := u.repo.GetRecipients(ctx, applicationIDs)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch applications: " + err.Error()))
+	}
+	byID := make(map[int64]domain.MessageRecipient, len(found))
+	for _, rc := range found {
+		byID[rc.ApplicationID] = rc
+	}
+
+	seen := map[string]bool{}
+	recipients := make([]domain.MessageRecipient, 0, len(applicationIDs))
+	for _, id := range applicationIDs {
+		rc, ok := byID[id]
+		if !ok || rc.CompanyID != companyID {
+			return nil, apperror.NotFound(fmt.Sprintf("Application not found: %d", id))
+		}
+		if !slices.Contains(domain.ShortlistStages, rc.Stage) {
+			return nil, apperror.BadRequest(fmt.Sprintf("Application is not shortlisted: %d", id))
+		}
+		if seen[rc.CandidateUserID] {
+			continue
+		}
+		seen[rc.CandidateUserID] = true
+		recipients = append(recipients, rc)
+	}
+	return recipients, nil
+}
+
+func (u *employerMessageUsecase) GetQuota(ctx context.Context, userID string) (*domain.BulkMessageQuota, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.quota(ctx, company.ID)
+}
+
+func (u *employerMessageUsecase) quota(ctx context.Context, companyID int64) (*domain.BulkMessageQuota, error) {
+	now := u.now().UTC()
+	sentToday, err := u.repo.CountDeliveriesSince(ctx, companyID, now.Truncate(24*time.Hour))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count sent messages: " + err.Error()))
+	}
+	reports, err := u.repo.CountSpamReportsSince(ctx, companyID, now.Add(-u.cfg.SpamWindow))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count spam reports: " + err.Error()))
+	}
+	return &domain.BulkMessageQuota{
+		DailyLimit:      u.cfg.DailyCap,
+		SentToday:       sentToday,
+		Remaining:       max(u.cfg.DailyCap-sentToday, 0),
+		SpamReports:     reports,
+		SpamReportLimit: u.cfg.SpamReportLimit,
+		SpamWindowDays:  int(u.cfg.SpamWindow / (24 * time.Hour)),
+		Suspended:       u.cfg.SpamReportLimit > 0 && reports >= int64(u.cfg.SpamReportLimit),
+	}, nil
+}
+
+// ============================================================================
+// History
+// ============================================================================
+
+func (u *employerMessageUsecase) ListMessages(ctx context.Context, userID string, page, pageSize int) (*domain.PaginatedResult[domain.BulkMessage], error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	page, pageSize = normalizeMessagePage(page, pageSize)
+	messages, total, err := u.repo.ListMessages(ctx, company.ID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch messages: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.BulkMessage]{
+		Data:       messages,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+	}, nil
+}
+
+func (u *employerMessageUsecase) GetMessage(ctx context.Context, userID string, id int64) (*domain.BulkMessage, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	message, err := u.repo.GetMessage(ctx, company.ID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Message not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch message: " + err.Error()))
+	}
+	return message, nil
+}
+
+// employerCompany returns the company of the employer making the request
+func (u *employerMessageUsecase) employerCompany(ctx context.Context, userID string) (*domain.CompanyProfile, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	company, err := u.companyProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+	return company, nil
+}
+
+// ============================================================================
+// Candidate inbox
+// ============================================================================
+
+func (u *employerMessageUsecase) ListMyMessages(ctx context.Context, userID string, page, pageSize int) (*domain.PaginatedResult[domain.CandidateMessage], error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	page, pageSize = normalizeMessagePage(page, pageSize)
+	messages, total, err := u.repo.ListCandidateMessages(ctx, userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch messages: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.CandidateMessage]{
+		Data:       messages,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+	}, nil
+}
+
+// ReportSpam flags a received message. The company can no longer message this
+// candidate, and enough reports suspend its bulk messaging.
+func (u *employerMessageUsecase) ReportSpam(ctx context.Context, userID string, deliveryID int64) error {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return err
+	}
+	if err := u.repo.ReportSpam(ctx, userID, deliveryID, u.now().UTC()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Message not found")
+		}
+		return apperror.Internal(errors.New("Failed to report message: " + err.Error()))
+	}
+	return nil
+}
+
+func normalizeMessagePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	return page, pageSize
+}
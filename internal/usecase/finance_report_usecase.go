@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/security"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type financeReportUsecase struct {
+	repo domain.FinanceReportRepository
+}
+
+func NewFinanceReportUsecase(repo domain.FinanceReportRepository) domain.FinanceReportUsecase {
+	return &financeReportUsecase{repo: repo}
+}
+
+func (u *financeReportUsecase) GetSummary(ctx context.Context, from, to string) (*domain.FinanceSummary, error) {
+	if err := u.requireFinanceAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	// 1. Resolve range (defaults to the last 12 months including the current one)
+	now := time.Now().UTC()
+	fromMonth, toMonth, err := parseFinanceRange(from, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Monthly rows
+	months, err := u.repo.GetMonthlySummaries(ctx, fromMonth, toMonth)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch finance summary: " + err.Error()))
+	}
+
+	// 3. Point-in-time outstanding balance
+	count, amount, overdueCount, overdueAmount, err := u.repo.GetOutstandingTotals(ctx, now)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch outstanding invoices: " + err.Error()))
+	}
+
+	summary := &domain.FinanceSummary{
+		From:                fromMonth.Format(domain.FinanceMonthFormat),
+		To:                  toMonth.Format(domain.FinanceMonthFormat),
+		Totals:              sumFinanceMonths(months),
+		Months:              months,
+		OutstandingInvoices: count,
+		OutstandingIDR:      amount,
+		OverdueInvoices:     overdueCount,
+		OverdueIDR:          overdueAmount,
+		GeneratedAt:         now,
+	}
+	return summary, nil
+}
+
+func (u *financeReportUsecase) GetMonthDetail(ctx context.Context, month string) (*domain.FinanceMonthDetail, error) {
+	if err := u.requireFinanceAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	start, err := parseFinanceMonth(month)
+	if err != nil {
+		return nil, err
+	}
+	end := start.AddDate(0, 1, 0)
+	now := time.Now().UTC()
+
+	summaries, err := u.repo.GetMonthlySummaries(ctx, start, start)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch finance summary: " + err.Error()))
+	}
+	detail := &domain.FinanceMonthDetail{Summary: domain.FinanceMonthSummary{Month: month}}
+	if len(summaries) > 0 {
+		detail.Summary = summaries[0]
+	}
+
+	if detail.RevenueByPlan, err = u.repo.GetRevenueByPlan(ctx, start, end); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch revenue by plan: " + err.Error()))
+	}
+	if detail.CreditUsage, err = u.repo.GetCreditUsage(ctx, start, end); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch credit usage: " + err.Error()))
+	}
+	if detail.ChurnedCompanies, err = u.repo.GetChurnedCompanies(ctx, start, end); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch churned companies: " + err.Error()))
+	}
+	if detail.OutstandingInvoices, err = u.repo.ListOutstandingInvoices(ctx, start, end, now); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch outstanding invoices: " + err.Error()))
+	}
+
+	return detail, nil
+}
+
+func (u *financeReportUsecase) ExportSummaryCSV(ctx context.Context, from, to string) ([]byte, string, error) {
+	summary, err := u.GetSummary(ctx, from, to)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows := [][]string{{
+		"month", "revenue_idr", "paid_invoices", "credits_granted", "credits_purchased",
+		"credits_consumed", "active_companies", "churned_companies",
+	}}
+	for _, m := range append(summary.Months, summary.Totals) {
+		rows = append(rows, []string{
+			m.Month, i64(m.RevenueIDR), i64(m.PaidInvoices), i64(m.CreditsGranted), i64(m.CreditsPurchased),
+			i64(m.CreditsConsumed), i64(m.ActiveCompanies), i64(m.ChurnedCompanies),
+		})
+	}
+
+	data, err := writeSpreadsheetCSV(rows)
+	if err != nil {
+		return nil, "", apperror.Internal(err)
+	}
+	u.logExport(ctx, "summary", summary.From+".."+summary.To)
+
+	filename := fmt.Sprintf("finance_summary_%s_%s.csv", summary.From, summary.To)
+	return data, filename, nil
+}
+
+func (u *financeReportUsecase) ExportMonthCSV(ctx context.Context, month string) ([]byte, string, error) {
+	detail, err := u.GetMonthDetail(ctx, month)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One flat file with a section column so it opens cleanly in a spreadsheet
+	rows := [][]string{{"section", "company_id", "company_name", "plan_code", "description", "amount_idr", "count", "date"}}
+	for _, p := range detail.RevenueByPlan {
+		rows = append(rows, []string{"revenue_by_plan", "", "", strOrEmpty(p.PlanCode), p.PlanName, i64(p.RevenueIDR), i64(p.PaidInvoices), ""})
+	}
+	for _, c := range detail.CreditUsage {
+		rows = append(rows, []string{"credits_added", i64(c.CompanyID), c.CompanyName, "", "", "", i64(c.CreditsAdded), ""})
+		rows = append(rows, []string{"credits_consumed", i64(c.CompanyID), c.CompanyName, "", "", "", i64(c.CreditsConsumed), ""})
+	}
+	for _, c := range detail.ChurnedCompanies {
+		rows = append(rows, []string{"churned", i64(c.CompanyID), c.CompanyName, c.PlanCode, "", "", "", c.CancelledAt.Format(time.RFC3339)})
+	}
+	for _, inv := range detail.OutstandingInvoices {
+		section := "outstanding"
+		if inv.Overdue {
+			section = "overdue"
+		}
+		rows = append(rows, []string{section, i64(inv.CompanyID), inv.CompanyName, strOrEmpty(inv.PlanCode), inv.Description, i64(inv.AmountIDR), "", inv.DueAt.Format(time.RFC3339)})
+	}
+
+	data, err := writeSpreadsheetCSV(rows)
+	if err != nil {
+		return nil, "", apperror.Internal(err)
+	}
+	u.logExport(ctx, "month", month)
+
+	return data, fmt.Sprintf("finance_%s.csv", month), nil
+}
+
+// requireFinanceAccess allows admins with the finance_access flag only
+func (u *financeReportUsecase) requireFinanceAccess(ctx context.Context) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	if userID == "" {
+		return apperror.Unauthorized("Not authenticated")
+	}
+
+	allowed, err := u.repo.HasFinanceAccess(ctx, userID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !allowed {
+		return apperror.Forbidden("Finance access required")
+	}
+	return nil
+}
+
+func (u *financeReportUsecase) logExport(ctx context.Context, report, period string) {
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	security.DefaultLogger().Log(ctx, security.SecurityEvent{
+		Event:        "FINANCE_REPORT_EXPORTED",
+		SubjectType:  "user_id",
+		SubjectValue: security.HashValue(userID),
+		Details: map[string]interface{}{
+			"report": report,
+			"period": period,
+		},
+	})
+}
+
+// parseFinanceRange parses from/to months (YYYY-MM). Empty values default to the
+// trailing 12 months ending with the current month.
+func parseFinanceRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	toMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		t, err := parseFinanceMonth(to)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		toMonth = t
+	}
+
+	fromMonth := toMonth.AddDate(0, -11, 0)
+	if from != "" {
+		f, err := parseFinanceMonth(from)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		fromMonth = f
+	}
+
+	if fromMonth.After(toMonth) {
+		return time.Time{}, time.Time{}, apperror.BadRequest("'from' must not be after 'to'")
+	}
+	if fromMonth.AddDate(0, domain.MaxFinanceReportMonths, 0).Before(toMonth.AddDate(0, 1, 0)) {
+		return time.Time{}, time.Time{}, apperror.BadRequest(fmt.Sprintf("Range cannot exceed %d months", domain.MaxFinanceReportMonths))
+	}
+	return fromMonth, toMonth, nil
+}
+
+func parseFinanceMonth(month string) (time.Time, error) {
+	t, err := time.Parse(domain.FinanceMonthFormat, month)
+	if err != nil {
+		return time.Time{}, apperror.BadRequest("Invalid month, expected YYYY-MM")
+	}
+	return t, nil
+}
+
+// sumFinanceMonths totals flow metrics; ActiveCompanies is a stock so the last month is used
+func sumFinanceMonths(months []domain.FinanceMonthSummary) domain.FinanceMonthSummary {
+	total := domain.FinanceMonthSummary{Month: "TOTAL"}
+	for _, m := range months {
+		total.RevenueIDR += m.RevenueIDR
+		total.PaidInvoices += m.PaidInvoices
+		total.CreditsGranted += m.CreditsGranted
+		total.CreditsPurchased += m.CreditsPurchased
+		total.CreditsConsumed += m.CreditsConsumed
+		total.ChurnedCompanies += m.ChurnedCompanies
+	}
+	if len(months) > 0 {
+		total.ActiveCompanies = months[len(months)-1].ActiveCompanies
+	}
+	return total
+}
+
+func writeSpreadsheetCSV(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, row := range rows {
+		for i, v := range row {
+			row[i] = escapeSpreadsheetFormula(v)
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// escapeSpreadsheetFormula prevents company names like "=HYPERLINK(...)" from
+// being evaluated when the file is opened in a spreadsheet
+func escapeSpreadsheetFormula(v string) string {
+	if v != "" && strings.ContainsAny(v[:1], "=+-@") {
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return v // plain negative numbers are safe
+		}
+		return "'" + v
+	}
+	return v
+}
+
+func i64(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func strOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
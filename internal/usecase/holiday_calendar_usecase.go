@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	holidayCacheTTL = 10 * time.Minute
+
+	// Working-day searches give up after this many calendar days
+	maxBusinessDaySearch = 366
+
+	maxInterviewDaySuggestions = 30
+)
+
+type holidayCalendarUsecase struct {
+	repo domain.HolidayRepository
+
+	// country code → set of YYYY-MM-DD; holiday tables are small, so all active
+	// entries are cached and reloaded after admin edits or the TTL
+	mu       sync.RWMutex
+	holidays map[string]map[string]bool
+	loadedAt time.Time
+}
+
+func NewHolidayCalendarUsecase(repo domain.HolidayRepository) domain.HolidayCalendarUsecase {
+	return &holidayCalendarUsecase{repo: repo}
+}
+
+func (u *holidayCalendarUsecase) IsBusinessDay(ctx context.Context, day time.Time, countries ...string) (bool, error) {
+	holidays, err := u.activeHolidays(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isBusinessDay(holidays, day, countries), nil
+}
+
+func (u *holidayCalendarUsecase) AddBusinessDays(ctx context.Context, from time.Time, n int, countries ...string) (time.Time, error) {
+	holidays, err := u.activeHolidays(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t := from
+	for added := 0; added < n; added++ {
+		if t, err = nextBusinessDay(holidays, t.AddDate(0, 0, 1), countries); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return t, nil
+}
+
+func (u *holidayCalendarUsecase) NextBusinessDay(ctx context.Context, t time.Time, countries ...string) (time.Time, error) {
+	holidays, err := u.activeHolidays(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return nextBusinessDay(holidays, t, countries)
+}
+
+func (u *holidayCalendarUsecase) ListHolidays(ctx context.Context, filter domain.HolidayFilter) ([]domain.PublicHoliday, error) {
+	filter.CountryCode = strings.ToUpper(filter.CountryCode)
+	if filter.CountryCode != "" && !isCalendarCountry(filter.CountryCode) {
+		return nil, apperror.BadRequest("Unsupported country, expected ID or JP")
+	}
+
+	holidays, err := u.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch holidays: " + err.Error()))
+	}
+	return holidays, nil
+}
+
+func (u *holidayCalendarUsecase) CreateHoliday(ctx context.Context, req domain.HolidayRequest) (*domain.PublicHoliday, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	holiday := holidayFromRequest(req)
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	if err := u.repo.Create(ctx, holiday, userID); err != nil {
+		return nil, wrapHolidayError(err, "Failed to create holiday: ")
+	}
+
+	u.invalidate()
+	return holiday, nil
+}
+
+func (u *holidayCalendarUsecase) UpdateHoliday(ctx context.Context, id int64, req domain.HolidayRequest) (*domain.PublicHoliday, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	holiday := holidayFromRequest(req)
+	holiday.ID = id
+	if err := u.repo.Update(ctx, holiday); err != nil {
+		return nil, wrapHolidayError(err, "Failed to update holiday: ")
+	}
+
+	u.invalidate()
+	return holiday, nil
+}
+
+func (u *holidayCalendarUsecase) DeleteHoliday(ctx context.Context, id int64) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return wrapHolidayError(err, "Failed to delete holiday: ")
+	}
+
+	u.invalidate()
+	return nil
+}
+
+func (u *holidayCalendarUsecase) SuggestInterviewDays(ctx context.Context, from string, count int, countries []string) ([]string, error) {
+	// 1. Validate input (defaults: from tomorrow, 5 days, both calendars)
+	if count < 1 {
+		count = 5
+	}
+	if count > maxInterviewDaySuggestions {
+		count = maxInterviewDaySuggestions
+	}
+	if len(countries) == 0 {
+		countries = []string{domain.CalendarIndonesia, domain.CalendarJapan}
+	}
+	for i, c := range countries {
+		countries[i] = strings.ToUpper(strings.TrimSpace(c))
+		if !isCalendarCountry(countries[i]) {
+			return nil, apperror.BadRequest("Unsupported country, expected ID or JP")
+		}
+	}
+
+	start := time.Now().UTC().AddDate(0, 0, 1)
+	if from != "" {
+		t, err := time.Parse(domain.HolidayDateFormat, from)
+		if err != nil {
+			return nil, apperror.BadRequest("Invalid date, expected YYYY-MM-DD")
+		}
+		start = t
+	}
+
+	// 2. Walk forward collecting days that are working days everywhere
+	holidays, err := u.activeHolidays(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]string, 0, count)
+	day, err := nextBusinessDay(holidays, start, countries)
+	for err == nil && len(days) < count {
+		days = append(days, day.Format(domain.HolidayDateFormat))
+		day, err = nextBusinessDay(holidays, day.AddDate(0, 0, 1), countries)
+	}
+	if err != nil && len(days) == 0 {
+		return nil, apperror.Internal(err)
+	}
+	return days, nil
+}
+
+// activeHolidays returns the cached holiday sets, reloading when stale
+func (u *holidayCalendarUsecase) activeHolidays(ctx context.Context) (map[string]map[string]bool, error) {
+	u.mu.RLock()
+	if u.holidays != nil && time.Since(u.loadedAt) < holidayCacheTTL {
+		holidays := u.holidays
+		u.mu.RUnlock()
+		return holidays, nil
+	}
+	u.mu.RUnlock()
+
+	list, err := u.repo.ListActive(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to load holiday calendar: " + err.Error()))
+	}
+
+	holidays := map[string]map[string]bool{}
+	for _, h := range list {
+		if holidays[h.CountryCode] == nil {
+			holidays[h.CountryCode] = map[string]bool{}
+		}
+		holidays[h.CountryCode][h.Date] = true
+	}
+
+	u.mu.Lock()
+	u.holidays = holidays
+	u.loadedAt = time.Now()
+	u.mu.Unlock()
+	return holidays, nil
+}
+
+func (u *holidayCalendarUsecase) invalidate() {
+	u.mu.Lock()
+	u.holidays = nil
+	u.mu.Unlock()
+}
+
+func isBusinessDay(holidays map[string]map[string]bool, day time.Time, countries []string) bool {
+	if wd := day.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	date := day.Format(domain.HolidayDateFormat)
+	for _, c := range countries {
+		if holidays[c][date] {
+			return false
+		}
+	}
+	return true
+}
+
+func nextBusinessDay(holidays map[string]map[string]bool, t time.Time, countries []string) (time.Time, error) {
+	for i := 0; i < maxBusinessDaySearch; i++ {
+		if isBusinessDay(holidays, t, countries) {
+			return t, nil
+		}
+		t = t.AddDate(0, 0, 1)
+	}
+	return time.Time{}, errors.New("no working days found in calendar range")
+}
+
+func isCalendarCountry(code string) bool {
+	return code == domain.CalendarIndonesia || code == domain.CalendarJapan
+}
+
+func holidayFromRequest(req domain.HolidayRequest) *domain.PublicHoliday {
+	active := true
+	if req.IsActive != nil {
+		active = *req.IsActive
+	}
+	return &domain.PublicHoliday{
+		CountryCode: strings.ToUpper(req.CountryCode),
+		Date:        req.Date,
+		Name:        strings.TrimSpace(req.Name),
+		IsActive:    active,
+	}
+}
+
+func wrapHolidayError(err error, prefix string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return apperror.NotFound("Holiday not found")
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return apperror.Internal(errors.New(prefix + err.Error()))
+}
@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
+	"strings"
+	"time"
+)
+
+// maxInterviewFeedbackReviewList caps the admin review queue returned at once
+const maxInterviewFeedbackReviewList = 200
+
+// InterviewFeedbackConfig tunes interview feedback sharing
+type InterviewFeedbackConfig struct {
+	RequireReview bool // hold all feedback for admin review, not only feedback with a custom message
+}
+
+type interviewFeedbackUsecase struct {
+	repo          domain.InterviewFeedbackRepository
+	notifications domain.NotificationDispatcher
+	cfg           InterviewFeedbackConfig
+	now           func() time.Time
+}
+
+func NewInterviewFeedbackUsecase(repo domain.InterviewFeedbackRepository, notifications domain.NotificationDispatcher, cfg InterviewFeedbackConfig) domain.InterviewFeedbackUsecase {
+	return &interviewFeedbackUsecase{repo: repo, notifications: notifications, cfg: cfg, now: time.Now}
+}
+
+// interviewFeedbackTemplates are the i18n source messages of the approved wordings
+var interviewFeedbackTemplates = map[string]string{
+	domain.InterviewFeedbackJapaneseLevel:   "This role needed a higher level of Japanese than you have shown so far.",
+	domain.InterviewFeedbackExperience:      "Other candidates had more work experience directly related to this role.",
+	domain.InterviewFeedbackTechnicalSkills: "Strengthening the technical skills listed in the job requirements would make your application stronger.",
+	domain.InterviewFeedbackCommunication:   "Giving more concrete examples of your experience would help you in future interviews.",
+	domain.InterviewFeedbackAvailability:    "Your available start date did not fit the schedule for this role.",
+	domain.InterviewFeedbackDocuments:       "Some of the documents we needed were missing or incomplete.",
+	domain.InterviewFeedbackStrongPool:      "We received many strong applications and the decision was a close one.",
+	domain.InterviewFeedbackReapply:         "We would welcome your application for future openings.",
+}
+
+func (u *interviewFeedbackUsecase) ListTemplates(ctx context.Context, locale string) []domain.InterviewFeedbackTemplate {
+	templates := make([]domain.InterviewFeedbackTemplate, 0, len(domain.InterviewFeedbackTemplateKeys))
+	for _, key := range domain.InterviewFeedbackTemplateKeys {
+		templates = append(templates, domain.InterviewFeedbackTemplate{Key: key, Text: i18n.T(locale, interviewFeedbackTemplates[key])})
+	}
+	return templates
+}
+
+func (u *interviewFeedbackUsecase) SubmitFeedback(ctx context.Context, userID string, applicationID int64, req domain.SubmitInterviewFeedbackRequest, locale string) (*domain.InterviewFeedback, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	// 1. Only the employer owning the job can give feedback, and only on rejected applications
+	app, err := u.ownedApplication(ctx, userID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != domain.ApplicationStatusRejected {
+		return nil, apperror.BadRequest("Feedback can only be shared on rejected applications")
+	}
+
+	// 2. Approved wordings only; each at most once
+	seen := map[string]bool{}
+	for _, key := range req.TemplateKeys {
+		if _, ok := interviewFeedbackTemplates[key]; !ok {
+			return nil, apperror.BadRequest("Unknown feedback template: " + key)
+		}
+		if seen[key] {
+			return nil, apperror.BadRequest("Duplicate feedback template: " + key)
+		}
+		seen[key] = true
+	}
+
+	// 3. A custom message is free text, so an admin reviews it before the candidate sees it
+	feedback := &domain.InterviewFeedback{
+		ApplicationID:   app.ApplicationID,
+		CompanyID:       *app.CompanyID,
+		CandidateUserID: app.CandidateUserID,
+		AuthorUserID:    &userID,
+		TemplateKeys:    req.TemplateKeys,
+		Status:          domain.InterviewFeedbackStatusApproved,
+		CompanyName:     app.CompanyName,
+		JobTitle:        app.JobTitle,
+	}
+	if message := strings.TrimSpace(req.CustomMessage); message != "" {
+		feedback.CustomMessage = &message
+	}
+	if feedback.CustomMessage != nil || u.cfg.RequireReview {
+		feedback.Status = domain.InterviewFeedbackStatusPendingReview
+	}
+	if err := u.repo.Create(ctx, feedback); err != nil {
+		if errors.Is(err, domain.ErrInterviewFeedbackExists) {
+			return nil, apperror.Conflict("Feedback was already shared on this application")
+		}
+		return nil, apperror.Internal(errors.New("Failed to save interview feedback: " + err.Error()))
+	}
+
+	// 4. Send right away unless it waits for review
+	if feedback.Status == domain.InterviewFeedbackStatusApproved {
+		if err := u.deliver(ctx, feedback); err != nil {
+			return nil, err
+		}
+	}
+	u.localize(feedback, locale)
+	return feedback, nil
+}
+
+func (u *interviewFeedbackUsecase) GetFeedback(ctx context.Context, userID string, applicationID int64, locale string) (*domain.InterviewFeedback, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	if _, err := u.ownedApplication(ctx, userID, applicationID); err != nil {
+		return nil, err
+	}
+
+	feedback, err := u.repo.GetByApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("No feedback was shared on this application")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch interview feedback: " + err.Error()))
+	}
+	u.localize(feedback, locale)
+	return feedback, nil
+}
+
+// ownedApplication loads the application and checks that userID owns the job's company
+func (u *interviewFeedbackUsecase) ownedApplication(ctx context.Context, userID string, applicationID int64) (*domain.InterviewFeedbackApplication, error) {
+	app, err := u.repo.GetApplication(ctx, applicationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch application: " + err.Error()))
+	}
+	if app.CompanyID == nil || app.CompanyUserID == nil || *app.CompanyUserID != userID {
+		return nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
+	}
+	return app, nil
+}
+
+func (u *interviewFeedbackUsecase) ListMyFeedback(ctx context.Context, userID, locale string) ([]domain.InterviewFeedback, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	feedback, err := u.repo.ListSentToCandidate(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch interview feedback: " + err.Error()))
+	}
+	for i := range feedback {
+		u.localize(&feedback[i], locale)
+		// Review details are internal
+		feedback[i].AuthorUserID, feedback[i].ReviewedBy, feedback[i].ReviewNote = nil, nil, nil
+	}
+	return feedback, nil
+}
+
+func (u *interviewFeedbackUsecase) GetPreference(ctx context.Context, userID string) (*domain.InterviewFeedbackPreference, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	optOutAt, err := u.repo.GetOptOut(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch feedback preference: " + err.Error()))
+	}
+	return &domain.InterviewFeedbackPreference{OptedOut: optOutAt != nil, OptedOutAt: optOutAt}, nil
+}
+
+func (u *interviewFeedbackUsecase) UpdatePreference(ctx context.Context, userID string, optOut bool) (*domain.InterviewFeedbackPreference, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	var at *time.Time
+	if optOut {
+		now := u.now().UTC()
+		at = &now
+	}
+	if err := u.repo.SetOptOut(ctx, userID, at); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to update feedback preference: " + err.Error()))
+	}
+	return &domain.InterviewFeedbackPreference{OptedOut: optOut, OptedOutAt: at}, nil
+}
+
+func (u *interviewFeedbackUsecase) ListForReview(ctx context.Context, status, locale string) ([]domain.InterviewFeedback, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		status = domain.InterviewFeedbackStatusPendingReview
+	}
+	switch status {
+	case domain.InterviewFeedbackStatusPendingReview, domain.InterviewFeedbackStatusApproved,
+		domain.InterviewFeedbackStatusSent, domain.InterviewFeedbackStatusSuppressed, domain.InterviewFeedbackStatusRejected:
+	default:
+		return nil, apperror.BadRequest("Invalid status: " + status)
+	}
+
+	feedback, err := u.repo.ListByStatus(ctx, status, maxInterviewFeedbackReviewList)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch interview feedback: " + err.Error()))
+	}
+	for i := range feedback {
+		u.localize(&feedback[i], locale)
+	}
+	return feedback, nil
+}
+
+func (u *interviewFeedbackUsecase) ReviewFeedback(ctx context.Context, adminID string, id int64, req domain.ReviewInterviewFeedbackRequest, locale string) (*domain.InterviewFeedback, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	// 1. Record the decision. Approved feedback whose delivery failed can be approved again to retry.
+	status := domain.InterviewFeedbackStatusApproved
+	if req.Action == "reject" {
+		status = domain.InterviewFeedbackStatusRejected
+	}
+	var note *string
+	if n := strings.TrimSpace(req.Note); n != "" {
+		note = &n
+	}
+	if err := u.repo.Review(ctx, id, status, adminID, note); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("No undelivered feedback with this ID")
+		}
+		return nil, apperror.Internal(errors.New("Failed to review interview feedback: " + err.Error()))
+	}
+
+	feedback, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch interview feedback: " + err.Error()))
+	}
+
+	// 2. Approved feedback goes out now
+	if feedback.Status == domain.InterviewFeedbackStatusApproved {
+		if err := u.deliver(ctx, feedback); err != nil {
+			return nil, err
+		}
+	}
+	u.localize(feedback, locale)
+	return feedback, nil
+}
+
+// deliver sends approved feedback through the notification system, or suppresses
+// it when the candidate has opted out. The opt-out is checked at delivery, so it
+// also covers feedback that was waiting for review.
+func (u *interviewFeedbackUsecase) deliver(ctx context.Context, feedback *domain.InterviewFeedback) error {
+	optOutAt, err := u.repo.GetOptOut(ctx, feedback.CandidateUserID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return apperror.Internal(errors.New("Failed to fetch feedback preference: " + err.Error()))
+	}
+
+	status := domain.InterviewFeedbackStatusSuppressed
+	if err == nil && optOutAt == nil {
+		lines := make(domain.NotificationLines, 0, len(feedback.TemplateKeys))
+		for _, key := range feedback.TemplateKeys {
+			lines = append(lines, interviewFeedbackTemplates[key])
+		}
+		n := &domain.Notification{
+			UserID:      feedback.CandidateUserID,
+			Category:    domain.NotificationCategoryInterviewFeedback,
+			Subject:     "Feedback on your application for %s",
+			SubjectArgs: []any{feedback.JobTitle},
+			Body:        "%s shared feedback on your application for %s:\n\n%s\n\nWe wish you every success in your job search.",
+			BodyArgs:    []any{feedback.CompanyName, feedback.JobTitle, lines},
+		}
+		if feedback.CustomMessage != nil {
+			n.Body = "%s shared feedback on your application for %s:\n\n%s\n\nMessage from the employer:\n%s\n\nWe wish you every success in your job search."
+			n.BodyArgs = append(n.BodyArgs, *feedback.CustomMessage)
+		}
+		if err := u.notifications.Dispatch(ctx, n); err != nil {
+			return apperror.Internal(errors.New("Failed to send interview feedback: " + err.Error()))
+		}
+		status = domain.InterviewFeedbackStatusSent
+	}
+
+	if err := u.repo.MarkDelivered(ctx, feedback.ID, status); err != nil {
+		return apperror.Internal(errors.New("Failed to update interview feedback: " + err.Error()))
+	}
+	feedback.Status = status
+	if status == domain.InterviewFeedbackStatusSent {
+		now := u.now().UTC()
+		feedback.SentAt = &now
+	}
+	return nil
+}
+
+// localize fills Messages with the template wordings in locale
+func (u *interviewFeedbackUsecase) localize(feedback *domain.InterviewFeedback, locale string) {
+	feedback.Messages = interviewFeedbackMessages(feedback.TemplateKeys, locale)
+}
+
+// interviewFeedbackMessages returns the wordings of template keys in locale
+func interviewFeedbackMessages(keys []string, locale string) []string {
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if msg, ok := interviewFeedbackTemplates[key]; ok {
+			messages = append(messages, i18n.T(locale, msg))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// inviteCodeAlphabet leaves out 0/O and 1/I so codes can be read out and typed
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// inviteCodeLength gives 32^10 possible codes
+const inviteCodeLength = 10
+
+type inviteCodeUsecase struct {
+	repo            domain.InviteCodeRepository
+	inviteOnlyRoles []string
+	now             func() time.Time
+}
+
+// NewInviteCodeUsecase gates registration for inviteOnlyRoles behind invite codes;
+// an empty list keeps registration open
+func NewInviteCodeUsecase(repo domain.InviteCodeRepository, inviteOnlyRoles []string) domain.InviteCodeUsecase {
+	return &inviteCodeUsecase{repo: repo, inviteOnlyRoles: inviteOnlyRoles, now: time.Now}
+}
+
+// ============================================================================
+// Registration gate
+// ============================================================================
+
+func (u *inviteCodeUsecase) Settings() domain.RegistrationSettings {
+	return domain.RegistrationSettings{InviteOnlyRoles: append([]string{}, u.inviteOnlyRoles...)}
+}
+
+func (u *inviteCodeUsecase) Reserve(ctx context.Context, code, role, email, ipAddress string) (int64, error) {
+	if !slices.Contains(u.inviteOnlyRoles, role) {
+		return 0, nil
+	}
+	code = normalizeInviteCode(code)
+	if code == "" {
+		return 0, apperror.BadRequest("An invite code is required to register")
+	}
+
+	rd := &domain.InviteCodeRedemption{Email: strings.ToLower(strings.TrimSpace(email)), Role: role}
+	if ipAddress != "" {
+		rd.IPAddress = &ipAddress
+	}
+	if err := u.repo.Redeem(ctx, code, rd); err != nil {
+		// Unknown, used up, expired, revoked and wrong-role codes look the same
+		if errors.Is(err, domain.ErrNotFound) {
+			return 0, apperror.BadRequest("Invalid or expired invite code")
+		}
+		return 0, apperror.Internal(errors.New("Failed to redeem invite code: " + err.Error()))
+	}
+	return rd.ID, nil
+}
+
+func (u *inviteCodeUsecase) Release(ctx context.Context, redemptionID int64) {
+	if redemptionID == 0 {
+		return
+	}
+	// The signup may have failed because the client went away; release anyway
+	if err := u.repo.Release(context.WithoutCancel(ctx), redemptionID); err != nil {
+		logger.FromContext(ctx).Error("Invite code: failed to release use", "redemption_id", redemptionID, "error", err)
+	}
+}
+
+func (u *inviteCodeUsecase) Complete(ctx context.Context, redemptionID int64, userID string) {
+	if redemptionID == 0 || userID == "" {
+		return
+	}
+	if err := u.repo.Complete(ctx, redemptionID, userID); err != nil {
+		logger.FromContext(ctx).Error("Invite code: failed to link redemption", "redemption_id", redemptionID, "error", err)
+	}
+}
+
+// normalizeInviteCode makes codes case-insensitive and ignores spaces and dashes
+func normalizeInviteCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+func generateInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(inviteCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// ============================================================================
+// Admin
+// ============================================================================
+
+func (u *inviteCodeUsecase) Generate(ctx context.Context, adminID string, req domain.GenerateInviteCodesRequest) ([]domain.InviteCode, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if req.Count < 1 || req.Count > domain.MaxInviteCodesPerBatch {
+		return nil, apperror.BadRequest("count must be between 1 and 500")
+	}
+
+	maxUses := req.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		t := u.now().UTC().AddDate(0, 0, req.ExpiresInDays)
+		expiresAt = &t
+	}
+	var createdBy *string
+	if adminID != "" {
+		createdBy = &adminID
+	}
+
+	codes := make([]*domain.InviteCode, req.Count)
+	for i := range codes {
+		code, err := generateInviteCode()
+		if err != nil {
+			return nil, apperror.Internal(errors.New("Failed to generate invite code: " + err.Error()))
+		}
+		codes[i] = &domain.InviteCode{
+			Code:      code,
+			Role:      req.Role,
+			MaxUses:   maxUses,
+			ExpiresAt: expiresAt,
+			Note:      trimmedOrNil(req.Note),
+			Status:    domain.InviteCodeActive,
+			CreatedBy: createdBy,
+		}
+	}
+	if err := u.repo.CreateMany(ctx, codes); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create invite codes: " + err.Error()))
+	}
+
+	created := make([]domain.InviteCode, len(codes))
+	for i, c := range codes {
+		created[i] = *c
+	}
+	return created, nil
+}
+
+func (u *inviteCodeUsecase) List(ctx context.Context, filter domain.InviteCodeFilter) (*domain.PaginatedResult[domain.InviteCode], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	switch filter.Status {
+	case "":
+		filter.Status = domain.InviteCodeActive
+	case domain.InviteCodeStatusAll:
+		filter.Status = ""
+	case domain.InviteCodeActive, domain.InviteCodeUsedUp, domain.InviteCodeExpired, domain.InviteCodeRevoked:
+	default:
+		return nil, apperror.BadRequest("Invalid status: " + filter.Status)
+	}
+	if filter.Role != "" && filter.Role != domain.RoleCandidate && filter.Role != domain.RoleEmployer {
+		return nil, apperror.BadRequest("Invalid role: " + filter.Role)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	codes, total, err := u.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch invite codes: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.InviteCode]{
+		Data:       codes,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+func (u *inviteCodeUsecase) Get(ctx context.Context, id int64) (*domain.InviteCode, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	code, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Invite code not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch invite code: " + err.Error()))
+	}
+	code.Redemptions, err = u.repo.ListRedemptions(ctx, id)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch invite code usage: " + err.Error()))
+	}
+	return code, nil
+}
+
+func (u *inviteCodeUsecase) Revoke(ctx context.Context, id int64) (*domain.InviteCode, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Revoke(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Invite code not found or already revoked")
+		}
+		return nil, apperror.Internal(errors.New("Failed to revoke invite code: " + err.Error()))
+	}
+	return u.Get(ctx, id)
+}
@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/validation"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// jobImportRequiredColumns must be in the header row of an import file
+var jobImportRequiredColumns = []string{"title", "description", "salary_min", "salary_max", "location"}
+
+// jobImportLabels name validation errors after the file's columns
+var jobImportLabels = map[string]string{
+	"Title":                 "title",
+	"Description":           "description",
+	"SalaryMin":             "salary_min",
+	"SalaryMax":             "salary_max",
+	"Location":              "location",
+	"JapaneseLevelRequired": "japanese_level_required",
+	"MaxApplicants":         "max_applicants",
+}
+
+// ImportJobs creates the employer's jobs from the rows of a CSV or XLSX file. Every
+// row is validated; the valid ones are created in one transaction and the report
+// tells which job each row became and why the others were skipped.
+func (u *jobUsecase) ImportJobs(ctx context.Context, userID, filename string, data []byte) (*domain.JobImportResult, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	company, err := u.companyProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	// 1. Read the sheet and map the header row
+	records, err := readJobImportFile(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	header := slices.IndexFunc(records, func(record []string) bool { return !isBlankRecord(record) })
+	if header < 0 {
+		return nil, apperror.BadRequest("The file has no job rows")
+	}
+	columns, err := jobImportHeader(records[header])
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	for _, record := range records[header+1:] {
+		if !isBlankRecord(record) {
+			total++
+		}
+	}
+	if total == 0 {
+		return nil, apperror.BadRequest("The file has no job rows")
+	}
+	if total > domain.MaxJobImportRows {
+		return nil, apperror.BadRequest(fmt.Sprintf("Too many job rows: %d (maximum %d)", total, domain.MaxJobImportRows))
+	}
+
+	// 2. Validate every row
+	now := time.Now()
+	result := &domain.JobImportResult{Total: total, Rows: make([]domain.JobImportRowResult, 0, total)}
+	var jobs []*domain.Job
+	var jobRows []int
+	for i, record := range records[header+1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		row, errs := parseJobImportRow(columns, record)
+		report := domain.JobImportRowResult{Row: header + i + 2, Title: row.Title}
+		if len(errs) == 0 {
+			if err := u.validate.Struct(row); err != nil {
+				errs = validation.FormatValidationErrorsWithLabels(err, jobImportLabels)
+			}
+		}
+		if row.ApplyDeadline != nil && !row.ApplyDeadline.After(now) {
+			errs = append(errs, "apply_deadline: Harus di masa mendatang")
+		}
+		if len(errs) > 0 {
+			report.Errors = errs
+			result.Rows = append(result.Rows, report)
+			continue
+		}
+		jobs = append(jobs, jobFromImportRow(row, company.ID, now))
+		jobRows = append(jobRows, len(result.Rows))
+		result.Rows = append(result.Rows, report)
+	}
+	result.Created = len(jobs)
+	result.Failed = result.Total - result.Created
+	if len(jobs) == 0 {
+		return result, nil
+	}
+
+	// 3. Create the valid rows together
+	if err := requireOpenJobSlots(ctx, u.levels, company, len(jobs)); err != nil {
+		return nil, err
+	}
+	if err := u.jobRepo.CreateMany(ctx, jobs); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create jobs: " + err.Error()))
+	}
+	for i, job := range jobs {
+		result.Rows[jobRows[i]].JobID = &job.ID
+	}
+	u.publicCache.Invalidate(ctx)
+	for _, job := range jobs {
+		u.publishJobWebhook(ctx, job)
+	}
+	return result, nil
+}
+
+// readJobImportFile returns the rows of a CSV file or of the first sheet of an XLSX file
+func readJobImportFile(filename string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		r.FieldsPerRecord = -1
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, apperror.BadRequest("Invalid CSV file: " + err.Error())
+		}
+		return records, nil
+	case ".xlsx":
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, apperror.BadRequest("Invalid XLSX file")
+		}
+		defer f.Close()
+		// Raw values keep numbers and dates independent of the cell format
+		records, err := f.GetRows(f.GetSheetName(0), excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, apperror.BadRequest("Invalid XLSX file")
+		}
+		return records, nil
+	default:
+		return nil, apperror.BadRequest("Upload a .csv or .xlsx file")
+	}
+}
+
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// jobImportHeader maps each known column of the header row to its index
+func jobImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(domain.JobImportColumns, name) {
+			return nil, apperror.BadRequest(fmt.Sprintf("Unknown column: %s (expected %s)", name, strings.Join(domain.JobImportColumns, ", ")))
+		}
+		columns[name] = i
+	}
+	for _, name := range jobImportRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, apperror.BadRequest("Missing column: " + name)
+		}
+	}
+	return columns, nil
+}
+
+// parseJobImportRow reads the cells of one row; cells that cannot be read are
+// reported and left empty
+func parseJobImportRow(columns map[string]int, record []string) (*domain.JobImportRow, []string) {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var errs []string
+
+	row := &domain.JobImportRow{
+		Title:                 cell("title"),
+		Description:           cell("description"),
+		Location:              cell("location"),
+		EmploymentType:        cell("employment_type"),
+		JobType:               cell("job_type"),
+		ExperienceLevel:       cell("experience_level"),
+		Qualifications:        cell("qualifications"),
+		JapaneseLevelRequired: strings.ToUpper(cell("japanese_level_required")),
+		AutoClose:             true,
+	}
+	for name, target := range map[string]*float64{"salary_min": &row.SalaryMin, "salary_max": &row.SalaryMax} {
+		if v := cell(name); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				errs = append(errs, name+": Harus berupa angka")
+				continue
+			}
+			*target = f
+		}
+	}
+	if v := cell("apply_deadline"); v != "" {
+		deadline, err := parseImportDeadline(v)
+		if err != nil {
+			errs = append(errs, "apply_deadline: Format tanggal tidak valid (YYYY-MM-DD atau RFC 3339)")
+		} else {
+			row.ApplyDeadline = &deadline
+		}
+	}
+	if v := cell("max_applicants"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			errs = append(errs, "max_applicants: Harus bilangan bulat")
+		} else {
+			row.MaxApplicants = &n
+		}
+	}
+	if v := cell("auto_close"); v != "" {
+		autoClose, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, "auto_close: Harus true atau false")
+		} else {
+			row.AutoClose = autoClose
+		}
+	}
+	slices.Sort(errs)
+	return row, errs
+}
+
+// parseImportDeadline reads an RFC 3339 time, a YYYY-MM-DD date or an Excel date
+// serial; a date without a time means the end of that day (UTC)
+func parseImportDeadline(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	endOfDay := func(t time.Time) time.Time { return t.Truncate(24 * time.Hour).Add(24*time.Hour - time.Second) }
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return endOfDay(t), nil
+	}
+	serial, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := excelize.ExcelDateToTime(serial, false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if serial == float64(int64(serial)) {
+		return endOfDay(t), nil
+	}
+	return t, nil
+}
+
+func jobFromImportRow(row *domain.JobImportRow, companyID int64, now time.Time) *domain.Job {
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	return &domain.Job{
+		CompanyID:             companyID,
+		Title:                 row.Title,
+		Description:           row.Description,
+		SalaryMin:             row.SalaryMin,
+		SalaryMax:             row.SalaryMax,
+		Location:              row.Location,
+		CompanyStatus:         domain.JobStatusActive,
+		EmploymentType:        optional(row.EmploymentType),
+		JobType:               optional(row.JobType),
+		ExperienceLevel:       optional(row.ExperienceLevel),
+		Qualifications:        optional(row.Qualifications),
+		JapaneseLevelRequired: optional(row.JapaneseLevelRequired),
+		ApplyDeadline:         row.ApplyDeadline,
+		MaxApplicants:         row.MaxApplicants,
+		AutoClose:             row.AutoClose,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+}
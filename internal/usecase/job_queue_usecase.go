@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/jobs"
+	"math"
+)
+
+type jobQueueUsecase struct {
+	queue *jobs.Queue
+}
+
+// NewJobQueueUsecase exposes the background job queue to admins
+func NewJobQueueUsecase(queue *jobs.Queue) domain.JobQueueUsecase {
+	return &jobQueueUsecase{queue: queue}
+}
+
+func (u *jobQueueUsecase) List(ctx context.Context, filter jobs.ListFilter) (*domain.PaginatedResult[jobs.Job], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	switch filter.Status {
+	case "":
+		filter.Status = jobs.StatusDead
+	case domain.JobQueueStatusAll:
+		filter.Status = ""
+	case jobs.StatusQueued, jobs.StatusRunning, jobs.StatusDone, jobs.StatusDead:
+	default:
+		return nil, apperror.BadRequest("Invalid status: " + filter.Status)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	list, total, err := u.queue.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch background jobs: " + err.Error()))
+	}
+	return &domain.PaginatedResult[jobs.Job]{
+		Data:       list,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+func (u *jobQueueUsecase) Counts(ctx context.Context) ([]jobs.TypeCount, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	counts, err := u.queue.Counts(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count background jobs: " + err.Error()))
+	}
+	return counts, nil
+}
+
+func (u *jobQueueUsecase) Retry(ctx context.Context, id int64) (*jobs.Job, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	job, err := u.queue.Retry(ctx, id)
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		return nil, apperror.NotFound("Background job not found")
+	case errors.Is(err, jobs.ErrNotDead):
+		return nil, apperror.Conflict("Only dead background jobs can be retried")
+	case err != nil:
+		return nil, apperror.Internal(errors.New("Failed to retry background job: " + err.Error()))
+	}
+	return job, nil
+}
@@ -2,20 +2,46 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"slices"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	similarJobsLimit      = 6
+	companyOtherJobsLimit = 6
+
+	maxJobSearchPageSize = 50
 )
 
 type jobUsecase struct {
 	jobRepo            domain.JobRepository
 	companyProfileRepo domain.CompanyProfileRepository
+	publicCache        *PublicJobCache                      // nil disables caching of public pages
+	hooks              domain.WebhookPublisher              // job.published events; may be nil
+	levels             domain.CompanyVerificationRepository // open job limits per verification level; nil disables them
+	validate           *validator.Validate                  // validates imported job rows
+	locations          domain.CompanyLocationRepository     // offices jobs are based at
 }
 
-func NewJobUsecase(jobRepo domain.JobRepository, companyProfileRepo domain.CompanyProfileRepository) domain.JobUsecase {
+func NewJobUsecase(jobRepo domain.JobRepository, companyProfileRepo domain.CompanyProfileRepository, publicCache *PublicJobCache, hooks domain.WebhookPublisher, levels domain.CompanyVerificationRepository, validate *validator.Validate, locations domain.CompanyLocationRepository) domain.JobUsecase {
 	return &jobUsecase{
 		jobRepo:            jobRepo,
 		companyProfileRepo: companyProfileRepo,
+		publicCache:        publicCache,
+		hooks:              hooks,
+		levels:             levels,
+		validate:           validate,
+		locations:          locations,
 	}
 }
 
@@ -25,10 +51,20 @@ func (u *jobUsecase) CreateJob(ctx context.Context, userID string, job *domain.J
 	// Get employer's company profile to set CompanyID
 	companyProfile, err := u.companyProfileRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return apperror.NotFound("Employer profile not found. Please create a company profile first.")
+		return apperror.NotFound("Employer profile not found. Please create a company profile first.").WithCode(domain.ErrCodeCompanyProfileNotFound)
 	}
 	job.CompanyID = companyProfile.ID
 
+	// The verification level caps how many jobs can be open at once
+	if err := requireOpenJobSlot(ctx, u.levels, companyProfile); err != nil {
+		return err
+	}
+	if job.IsConfidential {
+		if err := requireConfidentialJobs(companyProfile); err != nil {
+			return err
+		}
+	}
+
 	// Business Validation
 	if job.SalaryMin > job.SalaryMax {
 		return apperror.BadRequest("SalaryMin cannot be greater than SalaryMax")
@@ -36,11 +72,32 @@ func (u *jobUsecase) CreateJob(ctx context.Context, userID string, job *domain.J
 	if job.Title == "" {
 		return apperror.BadRequest("Title is required")
 	}
+	if err := u.applyJobLocation(ctx, job); err != nil {
+		return err
+	}
 
-	job.CreatedAt = time.Now()
-	job.UpdatedAt = time.Now()
+	// Optional publication schedule and application limits
+	now := time.Now()
+	if job.DeadlinePassed(now) {
+		return apperror.BadRequest("apply_deadline must be in the future")
+	}
+	schedule := domain.JobScheduleRequest{PublishAt: job.PublishAt, UnpublishAt: job.UnpublishAt}
+	job.PublishAt, job.UnpublishAt = nil, nil
+	if err := applyJobSchedule(job, schedule, now); err != nil {
+		return err
+	}
+
+	job.CreatedAt = now
+	job.UpdatedAt = now
 
-	return u.jobRepo.Create(ctx, job)
+	if err := u.jobRepo.Create(ctx, job); err != nil {
+		return err
+	}
+	u.publicCache.Invalidate(ctx)
+	if job.CompanyStatus == domain.JobStatusActive {
+		u.publishJobWebhook(ctx, job)
+	}
+	return nil
 }
 
 func (u *jobUsecase) GetJobDetails(ctx context.Context, id int64) (*domain.Job, error) {
@@ -52,15 +109,181 @@ func (u *jobUsecase) GetJobDetails(ctx context.Context, id int64) (*domain.Job,
 	return job, nil
 }
 
-// GetJobDetailsWithCompany returns job with company profile data
+// GetJobDetailsWithCompany returns job with company profile data. The company of a
+// confidential job is hidden unless the caller may see it (see canSeeCompany).
 func (u *jobUsecase) GetJobDetailsWithCompany(ctx context.Context, id int64) (*domain.JobWithCompany, error) {
 	job, err := u.jobRepo.GetByIDWithCompany(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := u.hideCompanyFromCaller(ctx, job); err != nil {
+		return nil, err
+	}
 	return job, nil
 }
 
+// hideCompanyFromCaller hides the company of a confidential job from callers who may not see it
+func (u *jobUsecase) hideCompanyFromCaller(ctx context.Context, job *domain.JobWithCompany) error {
+	if !job.IsConfidential {
+		return nil
+	}
+	visible, err := u.canSeeCompany(ctx, job)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		job.HideCompany()
+	}
+	return nil
+}
+
+// canSeeCompany reports whether the caller may see who posted a confidential job:
+// admins, the posting company, and candidates whose application the employer advanced
+func (u *jobUsecase) canSeeCompany(ctx context.Context, job *domain.JobWithCompany) (bool, error) {
+	userID := domain.UserIDFromContext(ctx)
+	switch domain.RoleFromContext(ctx) {
+	case domain.RoleAdmin:
+		return true, nil
+	case domain.RoleEmployer:
+		company, err := u.companyProfileRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return false, nil
+			}
+			return false, apperror.Internal(err)
+		}
+		return company.ID == job.CompanyID, nil
+	case domain.RoleCandidate:
+		advanced, err := u.jobRepo.HasAdvancedApplication(ctx, job.ID, userID)
+		if err != nil {
+			return false, apperror.Internal(errors.New("Failed to check application: " + err.Error()))
+		}
+		return advanced, nil
+	}
+	return false, nil
+}
+
+// hideConfidentialCompany hides the company of a confidential job on public responses
+func hideConfidentialCompany(job *domain.JobWithCompany) {
+	if job.IsConfidential {
+		job.HideCompany()
+	}
+}
+
+// GetPublicJob returns a published job with its company, cached briefly
+func (u *jobUsecase) GetPublicJob(ctx context.Context, id int64) (*domain.JobWithCompany, error) {
+	job, err := loadPublicJobs(ctx, u.publicCache, func(gen string) string { return u.publicCache.jobKey(gen, id) }, u.publicCache.detailTTL(),
+		func() (*domain.JobWithCompany, error) {
+			job, err := u.jobRepo.GetByIDWithCompany(ctx, id)
+			if err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+				}
+				return nil, apperror.Internal(errors.New("Failed to fetch job: " + err.Error()))
+			}
+			if err := u.attachPopularity(ctx, []*domain.JobWithCompany{job}); err != nil {
+				return nil, err
+			}
+			hideConfidentialCompany(job)
+			return job, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	// SECURITY: Only published jobs are public; a cached job may have reached its unpublish time since
+	now := time.Now()
+	if !job.IsPublished(now) {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+	setJobBadges(job, now)
+	u.recordView(ctx, id)
+	return job, nil
+}
+
+// GetPublicJobDetail assembles everything the public job detail page shows in one call.
+// The related lists and application count are loaded concurrently and the result is cached.
+func (u *jobUsecase) GetPublicJobDetail(ctx context.Context, id int64) (*domain.PublicJobDetail, error) {
+	detail, err := loadPublicJobs(ctx, u.publicCache, func(gen string) string { return u.publicCache.pageKey(gen, id) }, u.publicCache.detailTTL(),
+		func() (*domain.PublicJobDetail, error) { return u.loadPublicJobDetail(ctx, id) })
+	if err != nil {
+		return nil, err
+	}
+	// A cached job may have reached its unpublish time since
+	now := time.Now()
+	if !detail.Job.IsPublished(now) {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+	setJobBadges(&detail.Job, now)
+	u.recordView(ctx, id)
+	return detail, nil
+}
+
+func (u *jobUsecase) loadPublicJobDetail(ctx context.Context, id int64) (*domain.PublicJobDetail, error) {
+	// 1. The job itself; only active jobs are public
+	job, err := u.jobRepo.GetByIDWithCompany(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch job: " + err.Error()))
+	}
+	if !job.IsPublished(time.Now()) {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+
+	// 2. Related data in parallel
+	detail := &domain.PublicJobDetail{}
+	var (
+		wg                                   sync.WaitGroup
+		similarErr, otherErr, cntErr, locErr error
+		stats                                map[int64]domain.JobPopularityStats
+		location                             *domain.CompanyLocation
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		detail.SimilarJobs, similarErr = u.jobRepo.FetchSimilarActiveJobs(ctx, job, similarJobsLimit)
+	}()
+	go func() {
+		defer wg.Done()
+		stats, cntErr = u.jobRepo.FetchPopularityStats(ctx, []int64{job.ID})
+	}()
+	if job.IsConfidential {
+		// The company's other openings would name it
+		detail.CompanyOtherJobs = []domain.JobCard{}
+	} else {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			detail.CompanyOtherJobs, otherErr = u.jobRepo.FetchActiveJobsByCompany(ctx, job.CompanyID, job.ID, companyOtherJobsLimit)
+		}()
+	}
+	if job.LocationID != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			location, locErr = u.locations.GetByID(ctx, job.CompanyID, *job.LocationID)
+			if errors.Is(locErr, domain.ErrNotFound) {
+				// Deleted since the job was read; the page falls back to the free-text location
+				locErr = nil
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(similarErr, otherErr, cntErr, locErr); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to load job detail: " + err.Error()))
+	}
+	if location != nil {
+		detail.LocationMap = domain.JobLocationMapFor(location, job.IsConfidential)
+	}
+	job.Popularity = popularityFor(stats[job.ID])
+	hideConfidentialCompany(job)
+	detail.Job = *job
+	detail.ApplicationCountBand = job.Popularity.ApplicationCountBand
+	return detail, nil
+}
+
 func (u *jobUsecase) ListJobs(ctx context.Context, page, pageSize int) ([]domain.Job, int64, error) {
 	// Removed context.WithTimeout
 	if page < 1 {
@@ -84,12 +307,28 @@ func (u *jobUsecase) ListJobsWithCompany(ctx context.Context, page, pageSize int
 	}
 	offset := (page - 1) * pageSize
 
-	return u.jobRepo.FetchWithCompany(ctx, pageSize, offset)
+	jobs, total, err := u.jobRepo.FetchWithCompany(ctx, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range jobs {
+		if err := u.hideCompanyFromCaller(ctx, &jobs[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return jobs, total, nil
 }
 
-// ListPublicActiveJobs returns only active jobs for public access
+// ListPublicActiveJobs returns only active jobs for public access, cached briefly per page
 // SECURITY: This enforces server-side filtering - client cannot bypass
-func (u *jobUsecase) ListPublicActiveJobs(ctx context.Context, page, pageSize int) ([]domain.JobWithCompany, int64, error) {
+func (u *jobUsecase) ListPublicActiveJobs(ctx context.Context, sort string, page, pageSize int) ([]domain.JobWithCompany, int64, error) {
+	switch sort {
+	case "":
+		sort = domain.JobSearchSortNewest
+	case domain.JobSearchSortNewest, domain.JobSearchSortPopular:
+	default:
+		return nil, 0, apperror.BadRequest("Invalid sort order")
+	}
 	if page < 1 {
 		page = 1
 	}
@@ -98,7 +337,150 @@ func (u *jobUsecase) ListPublicActiveJobs(ctx context.Context, page, pageSize in
 	}
 	offset := (page - 1) * pageSize
 
-	return u.jobRepo.FetchPublicActiveJobs(ctx, pageSize, offset)
+	list, err := loadPublicJobs(ctx, u.publicCache, func(gen string) string { return u.publicCache.listKey(gen, sort, page, pageSize) }, u.publicCache.listTTL(),
+		func() (cachedJobList, error) {
+			jobs, total, err := u.jobRepo.FetchPublicActiveJobs(ctx, sort, pageSize, offset)
+			if err != nil {
+				return cachedJobList{}, err
+			}
+			if err := u.attachPopularity(ctx, jobPointers(jobs)); err != nil {
+				return cachedJobList{}, err
+			}
+			for i := range jobs {
+				hideConfidentialCompany(&jobs[i])
+			}
+			return cachedJobList{Jobs: jobs, Total: total}, nil
+		})
+	if err != nil {
+		return nil, 0, err
+	}
+	now := time.Now()
+	for i := range list.Jobs {
+		setJobBadges(&list.Jobs[i], now)
+	}
+	return list.Jobs, list.Total, nil
+}
+
+// SearchJobs searches active jobs. Relevance sorting applies to keyword
+// searches; without a keyword the newest jobs come first.
+func (u *jobUsecase) SearchJobs(ctx context.Context, params domain.JobSearchParams) (*domain.PaginatedResult[domain.JobSearchResult], error) {
+	params.Query = strings.TrimSpace(params.Query)
+	if len(params.Query) > 200 {
+		return nil, apperror.BadRequest("Search query is too long")
+	}
+	if params.SalaryMin != nil && params.SalaryMax != nil && *params.SalaryMin > *params.SalaryMax {
+		return nil, apperror.BadRequest("Minimum salary cannot be greater than maximum salary")
+	}
+	if params.JapaneseLevel != "" && !slices.Contains(domain.JLPTLevels, params.JapaneseLevel) {
+		return nil, apperror.BadRequest("Invalid Japanese level")
+	}
+	for i, city := range params.Cities {
+		params.Cities[i] = strings.ToLower(city)
+	}
+	if params.Near != nil {
+		if params.Near.Latitude < -90 || params.Near.Latitude > 90 || params.Near.Longitude < -180 || params.Near.Longitude > 180 {
+			return nil, apperror.BadRequest("Invalid coordinates")
+		}
+		if params.RadiusKm == 0 {
+			params.RadiusKm = domain.DefaultJobSearchRadiusKm
+		}
+		if params.RadiusKm < 1 || params.RadiusKm > domain.MaxJobSearchRadiusKm {
+			return nil, apperror.BadRequest(fmt.Sprintf("radius_km must be between 1 and %d", domain.MaxJobSearchRadiusKm))
+		}
+	}
+	switch params.Sort {
+	case "":
+		params.Sort = domain.JobSearchSortNewest
+		if params.Query != "" {
+			params.Sort = domain.JobSearchSortRelevance
+		}
+	case domain.JobSearchSortRelevance, domain.JobSearchSortNewest, domain.JobSearchSortSalary, domain.JobSearchSortPopular:
+	default:
+		return nil, apperror.BadRequest("Invalid sort order")
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > maxJobSearchPageSize {
+		params.PageSize = 10
+	}
+	offset := (params.Page - 1) * params.PageSize
+
+	jobs, total, err := u.jobRepo.SearchJobs(ctx, params, params.PageSize, offset)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to search jobs: " + err.Error()))
+	}
+	results := make([]*domain.JobWithCompany, len(jobs))
+	for i := range jobs {
+		results[i] = &jobs[i].JobWithCompany
+	}
+	if err := u.attachPopularity(ctx, results); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, job := range results {
+		setJobBadges(job, now)
+		hideConfidentialCompany(job)
+	}
+
+	return &domain.PaginatedResult[domain.JobSearchResult]{
+		Data:       jobs,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(params.PageSize))),
+	}, nil
+}
+
+// attachPopularity sets the application count band and recent views on jobs
+// with one query. Badges depend on the time and are set by setJobBadges after
+// the (possibly cached) jobs are loaded.
+func (u *jobUsecase) attachPopularity(ctx context.Context, jobs []*domain.JobWithCompany) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	stats, err := u.jobRepo.FetchPopularityStats(ctx, ids)
+	if err != nil {
+		return apperror.Internal(errors.New("Failed to load job popularity: " + err.Error()))
+	}
+	for _, job := range jobs {
+		job.Popularity = popularityFor(stats[job.ID])
+	}
+	return nil
+}
+
+func popularityFor(stats domain.JobPopularityStats) *domain.JobPopularity {
+	return &domain.JobPopularity{
+		ApplicationCountBand: domain.ApplicationCountBandFor(stats.Applications),
+		ViewsLast7Days:       stats.ViewsLast7Days,
+	}
+}
+
+// setJobBadges fills the time-dependent badges of a job with popularity data
+func setJobBadges(job *domain.JobWithCompany, now time.Time) {
+	if job.Popularity != nil {
+		job.Popularity.Badges = job.Badges(now)
+	}
+}
+
+// recordView counts a public job view; failures only cost a view
+func (u *jobUsecase) recordView(ctx context.Context, jobID int64) {
+	if err := u.jobRepo.RecordView(ctx, jobID); err != nil {
+		logger.FromContext(ctx).Warn("Failed to record job view", "job_id", jobID, "error", err)
+	}
+}
+
+func jobPointers(jobs []domain.JobWithCompany) []*domain.JobWithCompany {
+	pointers := make([]*domain.JobWithCompany, len(jobs))
+	for i := range jobs {
+		pointers[i] = &jobs[i]
+	}
+	return pointers
 }
 
 // ListJobsByEmployer returns jobs belonging to a specific employer based on their user ID
@@ -106,7 +488,7 @@ func (u *jobUsecase) ListJobsByEmployer(ctx context.Context, userID string, page
 	// Get employer's company profile to find company ID
 	companyProfile, err := u.companyProfileRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return nil, 0, apperror.NotFound("Employer profile not found. Please create a company profile first.")
+		return nil, 0, apperror.NotFound("Employer profile not found. Please create a company profile first.").WithCode(domain.ErrCodeCompanyProfileNotFound)
 	}
 
 	if page < 1 {
@@ -117,7 +499,255 @@ func (u *jobUsecase) ListJobsByEmployer(ctx context.Context, userID string, page
 	}
 	offset := (page - 1) * pageSize
 
-	return u.jobRepo.FetchByCompanyID(ctx, companyProfile.ID, pageSize, offset)
+	jobs, total, err := u.jobRepo.FetchByCompanyID(ctx, companyProfile.ID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Show the scheduled state as it is now, not as of the last scheduler run
+	now := time.Now()
+	for i := range jobs {
+		jobs[i].CompanyStatus = jobs[i].EffectiveStatus(now)
+	}
+	return jobs, total, nil
+}
+
+// ScheduleJob sets when one of the employer's jobs publishes and unpublishes
+func (u *jobUsecase) ScheduleJob(ctx context.Context, userID string, jobID int64, req domain.JobScheduleRequest) (*domain.Job, error) {
+	job, err := u.employerJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job.CompanyStatus = job.EffectiveStatus(now)
+	wasPublished := job.CompanyStatus == domain.JobStatusActive
+	if !wasPublished && job.CompanyStatus != domain.JobStatusScheduled {
+		// Reopening a draft or expired job takes an open job slot
+		company, err := u.companyProfileRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, apperror.Internal(err)
+		}
+		if err := requireOpenJobSlot(ctx, u.levels, company); err != nil {
+			return nil, err
+		}
+	}
+	if err := applyJobSchedule(job, req, now); err != nil {
+		return nil, err
+	}
+	job, err = u.saveJobSchedule(ctx, job, now)
+	if err != nil {
+		return nil, err
+	}
+	if !wasPublished && job.CompanyStatus == domain.JobStatusActive {
+		u.publishJobWebhook(ctx, job)
+	}
+	return job, nil
+}
+
+// CancelJobSchedule cancels a pending publication (the job becomes a draft) or,
+// for a published job, its automatic unpublishing
+func (u *jobUsecase) CancelJobSchedule(ctx context.Context, userID string, jobID int64) (*domain.Job, error) {
+	job, err := u.employerJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job.CompanyStatus = job.EffectiveStatus(now)
+	switch {
+	case job.CompanyStatus == domain.JobStatusScheduled:
+		job.CompanyStatus = domain.JobStatusDraft
+		job.PublishAt = nil
+		job.UnpublishAt = nil
+	case job.CompanyStatus == domain.JobStatusActive && job.UnpublishAt != nil:
+		job.UnpublishAt = nil
+	default:
+		return nil, apperror.Conflict("Job has no schedule to cancel")
+	}
+	return u.saveJobSchedule(ctx, job, now)
+}
+
+// RunScheduledTransitions publishes and unpublishes jobs whose scheduled time has
+// come, and closes auto-closing jobs past their apply deadline or applicant cap
+func (u *jobUsecase) RunScheduledTransitions(ctx context.Context) (*domain.JobScheduleRunResult, error) {
+	now := time.Now()
+	result := &domain.JobScheduleRunResult{}
+
+	// Unpublish first, so a job whose whole window passed goes straight to expired,
+	// then close, so a scheduled job whose deadline passed is never published
+	var err error
+	if result.Unpublished, err = u.jobRepo.UnpublishDueJobs(ctx, now); err != nil {
+		return nil, err
+	}
+	if result.Closed, err = u.jobRepo.CloseDueJobs(ctx, now); err != nil {
+		return nil, err
+	}
+	published, err := u.jobRepo.PublishDueJobs(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	result.Published = int64(len(published))
+
+	if result.Published+result.Unpublished+result.Closed > 0 {
+		u.publicCache.Invalidate(ctx)
+	}
+	for i := range published {
+		u.publishJobWebhook(ctx, &published[i])
+	}
+	return result, nil
+}
+
+// ReopenJob reopens one of the employer's closed jobs, optionally with a later
+// apply deadline or a higher applicant cap. The job must not be due to close again.
+func (u *jobUsecase) ReopenJob(ctx context.Context, userID string, jobID int64, req domain.ReopenJobRequest) (*domain.Job, error) {
+	job, err := u.employerJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if job.EffectiveStatus(now) != domain.JobStatusClosed {
+		return nil, apperror.Conflict("Only closed jobs can be reopened")
+	}
+	if req.ApplyDeadline != nil {
+		job.ApplyDeadline = req.ApplyDeadline
+	}
+	if req.MaxApplicants != nil {
+		job.MaxApplicants = req.MaxApplicants
+	}
+	if job.DeadlinePassed(now) {
+		return nil, apperror.BadRequest("Set a future apply_deadline to reopen the job")
+	}
+	if job.MaxApplicants != nil {
+		applicants, err := u.jobRepo.CountApplicants(ctx, job.ID)
+		if err != nil {
+			return nil, apperror.Internal(errors.New("Failed to count applicants: " + err.Error()))
+		}
+		if applicants >= *job.MaxApplicants {
+			return nil, apperror.BadRequest("Raise max_applicants above the current number of applicants to reopen the job")
+		}
+	}
+	job.CompanyStatus = domain.JobStatusActive
+	if job.EffectiveStatus(now) != domain.JobStatusActive {
+		return nil, apperror.BadRequest("The job's unpublish time has passed; schedule it again instead")
+	}
+
+	// Reopening takes an open job slot
+	company, err := u.companyProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if err := requireOpenJobSlot(ctx, u.levels, company); err != nil {
+		return nil, err
+	}
+
+	job.UpdatedAt = now
+	if err := u.jobRepo.Reopen(ctx, job); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to reopen job: " + err.Error()))
+	}
+	u.publicCache.Invalidate(ctx)
+	return job, nil
+}
+
+// publishJobWebhook tells subscribed integrations that a job went live
+func (u *jobUsecase) publishJobWebhook(ctx context.Context, job *domain.Job) {
+	publishedAt := time.Now().UTC()
+	if job.PublishAt != nil {
+		publishedAt = job.PublishAt.UTC()
+	}
+	publishWebhook(ctx, u.hooks, domain.WebhookEventJobPublished, domain.JobPublishedWebhook{
+		JobID:       job.ID,
+		CompanyID:   job.CompanyID,
+		Title:       job.Title,
+		PublishedAt: publishedAt,
+	})
+}
+
+// employerJob loads a job owned by the employer's company
+func (u *jobUsecase) employerJob(ctx context.Context, userID string, jobID int64) (*domain.Job, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.companyProfileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	job, err := u.jobRepo.GetByID(ctx, jobID)
+	if err != nil || job.CompanyID != company.ID {
+		return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+	}
+	return job, nil
+}
+
+// ownedJob is the job the caller may edit or delete: any job for admins, their
+// company's jobs for the owner and recruiters (viewers are stopped earlier by
+// CompanyRoleGate)
+func (u *jobUsecase) ownedJob(ctx context.Context, jobID int64) (*domain.Job, error) {
+	if domain.RoleFromContext(ctx) == domain.RoleAdmin {
+		job, err := u.jobRepo.GetByID(ctx, jobID)
+		if err != nil {
+			return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+		}
+		return job, nil
+	}
+	return u.employerJob(ctx, domain.UserIDFromContext(ctx), jobID)
+}
+
+func (u *jobUsecase) saveJobSchedule(ctx context.Context, job *domain.Job, now time.Time) (*domain.Job, error) {
+	job.UpdatedAt = now
+	if err := u.jobRepo.UpdateSchedule(ctx, job); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Job not found").WithCode(domain.ErrCodeJobNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to update job schedule: " + err.Error()))
+	}
+	u.publicCache.Invalidate(ctx)
+	return job, nil
+}
+
+// applyJobSchedule sets the job's status and schedule. A future publish_at schedules
+// the job; otherwise it is published now, keeping the original publish time of an
+// already published job.
+func applyJobSchedule(job *domain.Job, req domain.JobScheduleRequest, now time.Time) error {
+	publishAt := req.PublishAt
+	if publishAt != nil && !publishAt.After(now) {
+		publishAt = nil
+	}
+
+	start := now
+	if publishAt != nil {
+		start = *publishAt
+	}
+	if req.UnpublishAt != nil && !req.UnpublishAt.After(start) {
+		return apperror.BadRequest("Unpublish time must be after the publish time")
+	}
+
+	switch {
+	case publishAt != nil:
+		t := publishAt.UTC()
+		job.CompanyStatus = domain.JobStatusScheduled
+		job.PublishAt = &t
+	case job.CompanyStatus != domain.JobStatusActive:
+		t := now.UTC()
+		job.CompanyStatus = domain.JobStatusActive
+		job.PublishAt = &t
+	}
+
+	job.UnpublishAt = nil
+	if req.UnpublishAt != nil {
+		t := req.UnpublishAt.UTC()
+		job.UnpublishAt = &t
+	}
+	return nil
 }
 
 func (u *jobUsecase) UpdateJob(ctx context.Context, job *domain.Job) error {
@@ -129,11 +759,67 @@ func (u *jobUsecase) UpdateJob(ctx context.Context, job *domain.Job) error {
 		return apperror.BadRequest("Title is required")
 	}
 
+	current, err := u.ownedJob(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+	job.CompanyID = current.CompanyID
+
+	if job.IsConfidential || job.LocationID != nil {
+		if err := u.applyJobLocation(ctx, job); err != nil {
+			return err
+		}
+
+		// Making a job confidential needs the level; a company that was lowered keeps its confidential jobs
+		if job.IsConfidential && !current.IsConfidential {
+			company, err := u.companyProfileRepo.GetByID(ctx, current.CompanyID)
+			if err != nil {
+				return apperror.Internal(err)
+			}
+			if err := requireConfidentialJobs(company); err != nil {
+				return err
+			}
+		}
+	}
+
 	job.UpdatedAt = time.Now()
 
-	return u.jobRepo.Update(ctx, job)
+	if err := u.jobRepo.Update(ctx, job); err != nil {
+		return err
+	}
+	u.publicCache.Invalidate(ctx)
+	return nil
+}
+
+// applyJobLocation checks that the job's office belongs to its company and
+// defaults the free-text location to the office's city
+func (u *jobUsecase) applyJobLocation(ctx context.Context, job *domain.Job) error {
+	if job.LocationID == nil {
+		if strings.TrimSpace(job.Location) == "" {
+			return apperror.BadRequest("Location is required")
+		}
+		return nil
+	}
+	location, err := u.locations.GetByID(ctx, job.CompanyID, *job.LocationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.BadRequest("Location not found among your company's locations")
+		}
+		return apperror.Internal(errors.New("Failed to fetch location: " + err.Error()))
+	}
+	if strings.TrimSpace(job.Location) == "" {
+		job.Location = location.City
+	}
+	return nil
 }
 
 func (u *jobUsecase) DeleteJob(ctx context.Context, id int64) error {
-	return u.jobRepo.Delete(ctx, id)
+	if _, err := u.ownedJob(ctx, id); err != nil {
+		return err
+	}
+	if err := u.jobRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	u.publicCache.Invalidate(ctx)
+	return nil
 }
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// killSwitchAuditListLimit is how many audit entries the admin list returns
+const killSwitchAuditListLimit = 200
+
+// killSwitchAdminPrefix is never switchable, so admins cannot lock themselves out
+const killSwitchAdminPrefix = "/v1/admin/kill-switches"
+
+// endpointKillSwitchKey matches "METHOD /v1/route/:param" (gin route templates)
+var endpointKillSwitchKey = regexp.MustCompile(`^(GET|POST|PUT|PATCH|DELETE) /v1(/[A-Za-z0-9_\-:.*]+)*$`)
+
+type killSwitchUsecase struct {
+	repo domain.KillSwitchRepository
+
+	// Snapshot of disabled switches read by the middleware on every request
+	mu       sync.RWMutex
+	disabled map[string]domain.KillSwitch
+}
+
+func NewKillSwitchUsecase(repo domain.KillSwitchRepository) domain.KillSwitchUsecase {
+	return &killSwitchUsecase{repo: repo, disabled: map[string]domain.KillSwitch{}}
+}
+
+func (u *killSwitchUsecase) ActiveSwitch(key string) (*domain.KillSwitch, bool) {
+	u.mu.RLock()
+	s, ok := u.disabled[key]
+	u.mu.RUnlock()
+	if !ok || !s.ActiveAt(time.Now()) {
+		return nil, false
+	}
+	return &s, true
+}
+
+// Refresh records re-enables whose timer has passed, then reloads the snapshot.
+// Other API instances pick up admin toggles through this on the next tick.
+func (u *killSwitchUsecase) Refresh(ctx context.Context) error {
+	keys, err := u.repo.ReenableExpired(ctx)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		logger.FromContext(ctx).Info("Kill switch re-enabled: maintenance window ended", "key", key)
+	}
+	return u.reload(ctx)
+}
+
+func (u *killSwitchUsecase) reload(ctx context.Context) error {
+	switches, err := u.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	disabled := make(map[string]domain.KillSwitch, len(switches))
+	for _, s := range switches {
+		if s.Disabled {
+			disabled[s.Key] = s
+		}
+	}
+
+	u.mu.Lock()
+	u.disabled = disabled
+	u.mu.Unlock()
+	return nil
+}
+
+func (u *killSwitchUsecase) ListSwitches(ctx context.Context) ([]domain.KillSwitch, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	switches, err := u.repo.List(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch kill switches: " + err.Error()))
+	}
+
+	// Subsystems are always listed, even before their first toggle
+	for _, key := range domain.KillSwitchSubsystems {
+		if !slices.ContainsFunc(switches, func(s domain.KillSwitch) bool { return s.Key == key }) {
+			switches = append(switches, domain.KillSwitch{Key: key})
+		}
+	}
+	return switches, nil
+}
+
+func (u *killSwitchUsecase) DisableSwitch(ctx context.Context, adminUserID string, req domain.DisableKillSwitchRequest) (*domain.KillSwitch, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	// 1. Validate the key and the window
+	key, err := normalizeKillSwitchKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if req.DurationMinutes > domain.MaxKillSwitchMinutes {
+		return nil, apperror.BadRequest("Maintenance window cannot exceed 7 days")
+	}
+	var reenableAt *time.Time
+	if req.DurationMinutes > 0 {
+		t := time.Now().Add(time.Duration(req.DurationMinutes) * time.Minute)
+		reenableAt = &t
+	}
+	var message *string
+	if m := strings.TrimSpace(req.Message); m != "" {
+		message = &m
+	}
+
+	// 2. Persist + audit
+	s, err := u.repo.Disable(ctx, key, message, reenableAt, adminUserID, strings.TrimSpace(req.Reason))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to disable kill switch: " + err.Error()))
+	}
+	logger.FromContext(ctx).Warn("Kill switch disabled", "key", key, "admin_user_id", adminUserID, "reenable_at", reenableAt)
+
+	// 3. Apply on this instance immediately
+	if err := u.reload(ctx); err != nil {
+		logger.FromContext(ctx).Error("Failed to reload kill switches", "error", err)
+	}
+	return s, nil
+}
+
+func (u *killSwitchUsecase) EnableSwitch(ctx context.Context, adminUserID string, req domain.EnableKillSwitchRequest) (*domain.KillSwitch, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	key, err := normalizeKillSwitchKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := u.repo.Enable(ctx, key, adminUserID, strings.TrimSpace(req.Reason))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Kill switch is not disabled")
+		}
+		return nil, apperror.Internal(errors.New("Failed to enable kill switch: " + err.Error()))
+	}
+	logger.FromContext(ctx).Info("Kill switch enabled", "key", key, "admin_user_id", adminUserID)
+
+	if err := u.reload(ctx); err != nil {
+		logger.FromContext(ctx).Error("Failed to reload kill switches", "error", err)
+	}
+	return s, nil
+}
+
+func (u *killSwitchUsecase) ListAudit(ctx context.Context, key string) ([]domain.KillSwitchAuditEntry, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	entries, err := u.repo.ListAudit(ctx, strings.TrimSpace(key), killSwitchAuditListLimit)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch kill switch audit: " + err.Error()))
+	}
+	return entries, nil
+}
+
+// normalizeKillSwitchKey accepts a subsystem name or "METHOD /v1/route"
+func normalizeKillSwitchKey(raw string) (string, error) {
+	key := strings.TrimSpace(raw)
+	if slices.Contains(domain.KillSwitchSubsystems, strings.ToLower(key)) {
+		return strings.ToLower(key), nil
+	}
+
+	method, path, ok := strings.Cut(key, " ")
+	if !ok {
+		return "", apperror.BadRequest("Unknown kill switch; use a subsystem name or \"METHOD /v1/route\"")
+	}
+	path = strings.TrimRight(strings.TrimSpace(path), "/")
+	key = strings.ToUpper(method) + " " + path
+	if !endpointKillSwitchKey.MatchString(key) {
+		return "", apperror.BadRequest("Unknown kill switch; use a subsystem name or \"METHOD /v1/route\"")
+	}
+	if strings.HasPrefix(path, killSwitchAdminPrefix) {
+		return "", apperror.BadRequest("Kill switch administration cannot be disabled")
+	}
+	return key, nil
+}
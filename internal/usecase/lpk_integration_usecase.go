@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"strings"
+	"time"
+)
+
+// lpkAPIKeyPrefixLength is how much of a key is kept in clear to tell keys apart
+const lpkAPIKeyPrefixLength = 12
+
+type lpkIntegrationUsecase struct {
+	repo          domain.LPKIntegrationRepository
+	lpkRepo       domain.LPKPartnerRepository
+	webhookRepo   domain.WebhookRepository
+	allowInsecure bool // allow http:// partner webhook URLs (local development only)
+	now           func() time.Time
+}
+
+// NewLPKIntegrationUsecase creates the LPK partner placement feed. Partner
+// webhook endpoints follow the same URL rules as platform endpoints.
+func NewLPKIntegrationUsecase(repo domain.LPKIntegrationRepository, lpkRepo domain.LPKPartnerRepository, webhookRepo domain.WebhookRepository, allowInsecure bool) domain.LPKIntegrationUsecase {
+	return &lpkIntegrationUsecase{repo: repo, lpkRepo: lpkRepo, webhookRepo: webhookRepo, allowInsecure: allowInsecure, now: time.Now}
+}
+
+// recordPlacementStatus tells the recorder when one is configured; the status
+// change already succeeded, so a failure is logged and never fails the caller
+func recordPlacementStatus(ctx context.Context, placements domain.PlacementStatusRecorder, applicationID int64) {
+	if placements == nil {
+		return
+	}
+	if err := placements.RecordPlacementStatus(ctx, applicationID); err != nil {
+		logger.FromContext(ctx).Warn("Failed to record placement status", "application_id", applicationID, "error", err)
+	}
+}
+
+// RecordPlacementStatus logs the application's status for the candidate's LPK
+// and queues it for the LPK's webhooks. Candidates without consent are skipped.
+func (u *lpkIntegrationUsecase) RecordPlacementStatus(ctx context.Context, applicationID int64) error {
+	at := u.now().UTC()
+	event, err := u.repo.RecordPlacementEvent(ctx, applicationID, at)
+	if err != nil || event == nil {
+		return err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = u.webhookRepo.EnqueuePartnerEvent(ctx, event.LPKID, domain.WebhookEventPlacementStatusChanged, payload, at)
+	return err
+}
+
+// ============================================================================
+// Candidate: consent
+// ============================================================================
+
+func (u *lpkIntegrationUsecase) GetPlacementSharing(ctx context.Context, userID string) (*domain.LPKPlacementSharing, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	sharing, err := u.repo.GetPlacementSharing(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Candidate profile not found").WithCode(domain.ErrCodeCandidateProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch placement sharing: " + err.Error()))
+	}
+	return sharing, nil
+}
+
+// UpdatePlacementSharing gives consent for the candidate's current LPK, or
+// withdraws it. Events already sent to the LPK's systems stay with them.
+func (u *lpkIntegrationUsecase) UpdatePlacementSharing(ctx context.Context, userID string, req domain.UpdateLPKPlacementSharingRequest) (*domain.LPKPlacementSharing, error) {
+	sharing, err := u.GetPlacementSharing(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if *req.Enabled && sharing.LPKID == nil {
+		return nil, apperror.BadRequest("Choose your LPK before sharing placements with it")
+	}
+
+	if err := u.repo.SetPlacementSharing(ctx, userID, *req.Enabled, u.now().UTC()); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to update placement sharing: " + err.Error()))
+	}
+	return u.GetPlacementSharing(ctx, userID)
+}
+
+// ============================================================================
+// LPK partner: API keys
+// ============================================================================
+
+func (u *lpkIntegrationUsecase) ListAPIKeys(ctx context.Context) ([]domain.LPKAPIKey, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := u.repo.ListAPIKeys(ctx, partner.LPKID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch API keys: " + err.Error()))
+	}
+	return keys, nil
+}
+
+// CreateAPIKey issues a key for the caller's LPK and returns it in full, which
+// is not shown again. Only its hash is stored.
+func (u *lpkIntegrationUsecase) CreateAPIKey(ctx context.Context, req domain.CreateLPKAPIKeyRequest) (*domain.LPKAPIKey, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperror.BadRequest("API key name is required")
+	}
+
+	active, err := u.repo.CountActiveAPIKeys(ctx, partner.LPKID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count API keys: " + err.Error()))
+	}
+	if active >= domain.MaxActiveLPKAPIKeys {
+		return nil, apperror.Conflict("API key limit reached; revoke an unused key first")
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to generate API key: " + err.Error()))
+	}
+	secret := "lpk_" + hex.EncodeToString(b)
+	key := &domain.LPKAPIKey{
+		LPKID:     partner.LPKID,
+		Name:      name,
+		Prefix:    secret[:lpkAPIKeyPrefixLength],
+		CreatedBy: &partner.UserID,
+	}
+	if err := u.repo.CreateAPIKey(ctx, key, hashLPKAPIKey(secret)); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create API key: " + err.Error()))
+	}
+	key.Key = secret
+	return key, nil
+}
+
+func (u *lpkIntegrationUsecase) RevokeAPIKey(ctx context.Context, id int64) error {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.RevokeAPIKey(ctx, partner.LPKID, id, u.now().UTC()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("API key not found")
+		}
+		return apperror.Internal(errors.New("Failed to revoke API key: " + err.Error()))
+	}
+	return nil
+}
+
+// hashLPKAPIKey is the stored form of a key. Keys are random, so a plain hash
+// is enough to make a leaked table useless.
+func hashLPKAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ============================================================================
+// LPK partner: webhooks
+// ============================================================================
+
+func (u *lpkIntegrationUsecase) ListWebhooks(ctx context.Context) ([]domain.WebhookEndpoint, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := u.webhookRepo.ListEndpoints(ctx, &partner.LPKID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch webhook endpoints: " + err.Error()))
+	}
+	return endpoints, nil
+}
+
+// CreateWebhook registers an endpoint for the caller's LPK and returns its
+// signing secret, which is not shown again
+func (u *lpkIntegrationUsecase) CreateWebhook(ctx context.Context, req domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := &domain.WebhookEndpoint{LPKID: &partner.LPKID}
+	if err := applyWebhookEndpointRequest(endpoint, req, domain.LPKWebhookEventTypes, u.allowInsecure); err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	endpoint.Secret = secret
+	endpoint.CreatedBy = &partner.UserID
+
+	if err := u.webhookRepo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create webhook endpoint: " + err.Error()))
+	}
+	return endpoint, nil
+}
+
+func (u *lpkIntegrationUsecase) UpdateWebhook(ctx context.Context, id int64, req domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := &domain.WebhookEndpoint{ID: id, LPKID: &partner.LPKID}
+	if err := applyWebhookEndpointRequest(endpoint, req, domain.LPKWebhookEventTypes, u.allowInsecure); err != nil {
+		return nil, err
+	}
+	if err := u.webhookRepo.UpdateEndpoint(ctx, endpoint); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Webhook endpoint not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update webhook endpoint: " + err.Error()))
+	}
+	return endpoint, nil
+}
+
+func (u *lpkIntegrationUsecase) RotateWebhookSecret(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if err := u.webhookRepo.UpdateSecret(ctx, id, &partner.LPKID, secret, u.now().UTC()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Webhook endpoint not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to rotate webhook secret: " + err.Error()))
+	}
+
+	endpoint, err := u.webhookRepo.GetEndpoint(ctx, id, &partner.LPKID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch webhook endpoint: " + err.Error()))
+	}
+	endpoint.Secret = secret
+	return endpoint, nil
+}
+
+func (u *lpkIntegrationUsecase) DeleteWebhook(ctx context.Context, id int64) error {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return err
+	}
+	if err := u.webhookRepo.DeleteEndpoint(ctx, id, &partner.LPKID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Webhook endpoint not found")
+		}
+		return apperror.Internal(errors.New("Failed to delete webhook endpoint: " + err.Error()))
+	}
+	return nil
+}
+
+func (u *lpkIntegrationUsecase) ListWebhookDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) (*domain.PaginatedResult[domain.WebhookDelivery], error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter.LPKID = &partner.LPKID
+	return listWebhookDeliveries(ctx, u.webhookRepo, filter, domain.LPKWebhookEventTypes)
+}
+
+// ============================================================================
+// Partner API
+// ============================================================================
+
+// ListPlacements returns the placement events of the key's LPK after the since
+// cursor. Partner systems poll with the next_cursor of the previous page.
+func (u *lpkIntegrationUsecase) ListPlacements(ctx context.Context, apiKey string, since int64, limit int) (*domain.LPKPlacementFeed, error) {
+	apiKey = strings.TrimSpace(apiKey)
+	if apiKey == "" {
+		return nil, apperror.Unauthorized("API key is required")
+	}
+	key, err := u.repo.FindActiveAPIKey(ctx, hashLPKAPIKey(apiKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Unauthorized("Invalid API key")
+		}
+		return nil, apperror.Internal(errors.New("Failed to check API key: " + err.Error()))
+	}
+	if err := u.repo.TouchAPIKey(ctx, key.ID, u.now().UTC()); err != nil {
+		logger.FromContext(ctx).Warn("Failed to record API key use", "key_id", key.ID, "error", err)
+	}
+
+	if since < 0 {
+		since = 0
+	}
+	if limit < 1 {
+		limit = domain.DefaultLPKPlacementPage
+	}
+	if limit > domain.MaxLPKPlacementPage {
+		limit = domain.MaxLPKPlacementPage
+	}
+
+	// One extra row tells whether another page follows
+	events, err := u.repo.ListPlacementEvents(ctx, key.LPKID, since, limit+1)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch placement events: " + err.Error()))
+	}
+	feed := &domain.LPKPlacementFeed{Events: events, NextCursor: since}
+	if len(events) > limit {
+		feed.Events = events[:limit]
+		feed.HasMore = true
+	}
+	if n := len(feed.Events); n > 0 {
+		feed.NextCursor = feed.Events[n-1].ID
+	}
+	return feed, nil
+}
+
+func (u *lpkIntegrationUsecase) currentPartner(ctx context.Context) (*domain.LPKPartner, error) {
+	if err := requireRole(ctx, domain.RoleLPK); err != nil {
+		return nil, err
+	}
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	if userID == "" {
+		return nil, apperror.Unauthorized("Not authenticated")
+	}
+	partner, err := u.lpkRepo.GetPartnerByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Forbidden("No LPK partnership assigned to this account")
+		}
+		return nil, apperror.Internal(err)
+	}
+	return partner, nil
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"math"
+	"strings"
+)
+
+type lpkPartnerUsecase struct {
+	lpkRepo domain.LPKPartnerRepository
+}
+
+func NewLPKPartnerUsecase(lpkRepo domain.LPKPartnerRepository) domain.LPKPartnerUsecase {
+	return &lpkPartnerUsecase{lpkRepo: lpkRepo}
+}
+
+// ============================================================================
+// Admin: partner account management
+// ============================================================================
+
+func (u *lpkPartnerUsecase) ListPartners(ctx context.Context, lpkID *int64) ([]domain.LPKPartner, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	partners, err := u.lpkRepo.ListPartners(ctx, lpkID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch LPK partners: " + err.Error()))
+	}
+	return partners, nil
+}
+
+func (u *lpkPartnerUsecase) AssignPartner(ctx context.Context, req *domain.AssignLPKPartnerRequest) (*domain.LPKPartner, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	adminID, _ := ctx.Value(domain.KeyUserID).(string)
+
+	// 1. Validate LPK exists
+	exists, err := u.lpkRepo.LPKExists(ctx, req.LPKID)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if !exists {
+		return nil, apperror.NotFound("LPK not found")
+	}
+
+	// 2. Admins cannot demote themselves into a partner account
+	if req.UserID == adminID {
+		return nil, apperror.BadRequest("Cannot assign your own account as an LPK partner")
+	}
+
+	// 3. Assign mapping and role
+	if err := u.lpkRepo.AssignPartner(ctx, req.UserID, req.LPKID, adminID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		if errors.Is(err, domain.ErrLPKPartnerRoleConflict) {
+			return nil, apperror.Conflict("Admin and employer accounts cannot be assigned as LPK partners")
+		}
+		return nil, apperror.Internal(errors.New("Failed to assign LPK partner: " + err.Error()))
+	}
+
+	return u.lpkRepo.GetPartnerByUserID(ctx, req.UserID)
+}
+
+func (u *lpkPartnerUsecase) RevokePartner(ctx context.Context, userID string) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+
+	if err := u.lpkRepo.RevokePartner(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("LPK partner not found")
+		}
+		return apperror.Internal(errors.New("Failed to revoke LPK partner: " + err.Error()))
+	}
+	return nil
+}
+
+// ============================================================================
+// LPK partner: scoped access
+// ============================================================================
+
+func (u *lpkPartnerUsecase) GetMyPartnership(ctx context.Context) (*domain.LPKPartner, error) {
+	return u.currentPartner(ctx)
+}
+
+func (u *lpkPartnerUsecase) ListMyCandidates(ctx context.Context, status string, page, pageSize int) (*domain.PaginatedResult[domain.LPKCandidateProgress], error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status = strings.ToUpper(strings.TrimSpace(status))
+	switch status {
+	case "", domain.VerificationStatusPending, domain.VerificationStatusSubmitted,
+		domain.VerificationStatusVerified, domain.VerificationStatusRejected:
+	default:
+		return nil, apperror.BadRequest("Invalid verification status filter")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	candidates, total, err := u.lpkRepo.ListCandidates(ctx, partner.LPKID, status, page, pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch candidates: " + err.Error()))
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return &domain.PaginatedResult[domain.LPKCandidateProgress]{
+		Data:       candidates,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+func (u *lpkPartnerUsecase) CreateEndorsement(ctx context.Context, candidateRef int64, req *domain.CreateLPKEndorsementRequest) (*domain.LPKEndorsement, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Validate note
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, apperror.BadRequest("Endorsement note is required")
+	}
+
+	// 2. Candidate must have selected this LPK
+	if err := u.requireCandidateInScope(ctx, candidateRef, partner.LPKID); err != nil {
+		return nil, err
+	}
+
+	// 3. Persist
+	endorsement := &domain.LPKEndorsement{
+		LPKID:        partner.LPKID,
+		CandidateRef: candidateRef,
+		AuthorUserID: &partner.UserID,
+		Note:         note,
+	}
+	if err := u.lpkRepo.CreateEndorsement(ctx, endorsement); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save endorsement: " + err.Error()))
+	}
+
+	return endorsement, nil
+}
+
+func (u *lpkPartnerUsecase) ListEndorsements(ctx context.Context, candidateRef int64) ([]domain.LPKEndorsement, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.requireCandidateInScope(ctx, candidateRef, partner.LPKID); err != nil {
+		return nil, err
+	}
+
+	endorsements, err := u.lpkRepo.ListEndorsements(ctx, partner.LPKID, candidateRef)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch endorsements: " + err.Error()))
+	}
+	return endorsements, nil
+}
+
+func (u *lpkPartnerUsecase) GetPlacementStats(ctx context.Context) (*domain.LPKPlacementStats, error) {
+	partner, err := u.currentPartner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := u.lpkRepo.GetPlacementStats(ctx, partner.LPKID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch placement statistics: " + err.Error()))
+	}
+	return stats, nil
+}
+
+// currentPartner resolves the caller's LPK. Role alone is not enough:
+// the user must also have an active mapping in lpk_partner_users.
+func (u *lpkPartnerUsecase) currentPartner(ctx context.Context) (*domain.LPKPartner, error) {
+	if err := requireRole(ctx, domain.RoleLPK); err != nil {
+		return nil, err
+	}
+
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	if userID == "" {
+		return nil, apperror.Unauthorized("Not authenticated")
+	}
+
+	partner, err := u.lpkRepo.GetPartnerByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Forbidden("No LPK partnership assigned to this account")
+		}
+		return nil, apperror.Internal(err)
+	}
+	return partner, nil
+}
+
+// requireCandidateInScope returns NotFound (not Forbidden) for out-of-scope
+// candidates so partners cannot probe which references exist.
+func (u *lpkPartnerUsecase) requireCandidateInScope(ctx context.Context, candidateRef, lpkID int64) error {
+	belongs, err := u.lpkRepo.CandidateBelongsToLPK(ctx, candidateRef, lpkID)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if !belongs {
+		return apperror.NotFound("Candidate not found").WithCode(domain.ErrCodeCandidateNotFound)
+	}
+	return nil
+}
+
+// requireRole checks the caller's canonical role from the request context
+func requireRole(ctx context.Context, role string) error {
+	if domain.RoleFromContext(ctx) != role {
+		return apperror.Forbidden("Access denied")
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// maintenanceHeartbeat is how often a running task reports it is alive
+	maintenanceHeartbeat = 30 * time.Second
+	// maintenanceStaleAfter is how long a run may go without a heartbeat before it
+	// counts as abandoned, so a crashed instance does not block the task forever
+	maintenanceStaleAfter = 5 * time.Minute
+	// maintenanceRunListLimit is how many past runs the admin list returns
+	maintenanceRunListLimit = 50
+)
+
+// maintenanceStatsTables are analyzed by refresh-stats: the tables behind search,
+// the admin dashboards and the reports. The reports query them live, so their
+// planner statistics are what goes stale.
+var maintenanceStatsTables = []string{
+	"users",
+	"account_verifications",
+	"candidate_profiles",
+	"candidate_skills",
+	"work_experiences",
+	"company_profiles",
+	"jobs",
+	"applications",
+	"application_stage_history",
+}
+
+// maintenanceSearchIndexes are rebuilt by rebuild-search-indexes: the job
+// full-text index and the trigram indexes of admin search
+var maintenanceSearchIndexes = []string{
+	"idx_jobs_search_vector",
+	"idx_jobs_title_trgm",
+	"idx_users_email_trgm",
+	"idx_account_verifications_name_trgm",
+	"idx_account_verifications_phone_trgm",
+	"idx_company_profiles_name_trgm",
+}
+
+// maintenanceTask is the definition of one task: its steps run in order and stop
+// at the first failure
+type maintenanceTask struct {
+	description string
+	steps       []string
+	runStep     func(ctx context.Context, step string) (any, error)
+}
+
+type maintenanceUsecase struct {
+	repo  domain.MaintenanceRepository
+	tasks map[string]maintenanceTask
+	now   func() time.Time
+}
+
+// NewMaintenanceUsecase creates the admin maintenance task runner
+func NewMaintenanceUsecase(repo domain.MaintenanceRepository, aggregateUC domain.AggregateUsecase) domain.MaintenanceUsecase {
+	u := &maintenanceUsecase{repo: repo, now: time.Now}
+	u.tasks = map[string]maintenanceTask{
+		domain.MaintenanceRefreshStats: {
+			description: "Refresh the planner statistics of the search and reporting tables",
+			steps:       maintenanceStatsTables,
+			runStep: func(ctx context.Context, table string) (any, error) {
+				return nil, repo.AnalyzeTable(ctx, table)
+			},
+		},
+		domain.MaintenanceRebuildSearchIndexes: {
+			description: "Rebuild the job search and admin search indexes without blocking writes",
+			steps:       maintenanceSearchIndexes,
+			runStep: func(ctx context.Context, index string) (any, error) {
+				return nil, repo.ReindexConcurrently(ctx, index)
+			},
+		},
+		domain.MaintenanceRecomputeAggregates: {
+			description: "Recompute derived candidate aggregates and fix drifted values",
+			steps:       []string{"candidate_aggregates"},
+			runStep: func(ctx context.Context, _ string) (any, error) {
+				run, err := aggregateUC.RunRecompute(ctx, domain.RecomputeTriggerManual)
+				if run != nil {
+					run.DriftItems = nil // the recompute run's own report lists them
+				}
+				return run, err
+			},
+		},
+	}
+	return u
+}
+
+// StartTask opens a run and executes the task in the background. A task that is
+// already running, on this or any other instance, is refused.
+func (u *maintenanceUsecase) StartTask(ctx context.Context, adminID, taskName string) (*domain.MaintenanceRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	task, ok := u.tasks[taskName]
+	if !ok {
+		return nil, apperror.NotFound("Unknown maintenance task: " + taskName)
+	}
+
+	now := u.now().UTC()
+	if abandoned, err := u.repo.AbandonStaleRuns(ctx, now.Add(-maintenanceStaleAfter), now); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to check running maintenance tasks: " + err.Error()))
+	} else if abandoned > 0 {
+		logger.FromContext(ctx).Warn("Maintenance: marked abandoned runs failed", "count", abandoned)
+	}
+
+	run := &domain.MaintenanceRun{
+		Task:       taskName,
+		StepsTotal: len(task.steps),
+		Steps:      []domain.MaintenanceStepResult{},
+		StartedAt:  now,
+	}
+	if adminID != "" {
+		run.StartedBy = &adminID
+	}
+	if err := u.repo.CreateRun(ctx, run); err != nil {
+		if errors.Is(err, domain.ErrMaintenanceTaskRunning) {
+			return nil, apperror.Conflict("Maintenance task is already running")
+		}
+		return nil, apperror.Internal(errors.New("Failed to start maintenance task: " + err.Error()))
+	}
+
+	// Detached from the request, but keeps its logger and caller
+	snapshot := *run
+	go u.execute(context.WithoutCancel(ctx), run, task)
+	return &snapshot, nil
+}
+
+// execute runs the task's steps, reporting progress after each one and a
+// heartbeat in between, then stores the outcome
+func (u *maintenanceUsecase) execute(ctx context.Context, run *domain.MaintenanceRun, task maintenanceTask) {
+	l := logger.FromContext(ctx).With("task", run.Task, "run_id", run.ID)
+
+	var mu sync.Mutex
+	report := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		run.HeartbeatAt = u.now().UTC()
+		if err := u.repo.UpdateProgress(ctx, run); err != nil {
+			l.Warn("Maintenance: failed to record progress", "error", err)
+		}
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(maintenanceHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				report()
+			}
+		}
+	}()
+
+	var runErr error
+	for _, step := range task.steps {
+		mu.Lock()
+		run.CurrentStep = &step
+		mu.Unlock()
+		report()
+
+		started := u.now()
+		detail, err := task.runStep(ctx, step)
+		result := domain.MaintenanceStepResult{Step: step, DurationMs: u.now().Sub(started).Milliseconds()}
+		if detail != nil {
+			result.Detail, _ = json.Marshal(detail)
+		}
+		if err != nil {
+			msg := err.Error()
+			result.Error = &msg
+			runErr = fmt.Errorf("%s: %w", step, err)
+		}
+
+		mu.Lock()
+		run.Steps = append(run.Steps, result)
+		if err == nil {
+			run.StepsDone++
+		}
+		mu.Unlock()
+		if runErr != nil {
+			break
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	finishedAt := u.now().UTC()
+	run.FinishedAt = &finishedAt
+	run.CurrentStep = nil
+	run.Status = domain.MaintenanceCompleted
+	if runErr != nil {
+		msg := runErr.Error()
+		run.Status = domain.MaintenanceFailed
+		run.ErrorMessage = &msg
+	}
+	if err := u.repo.FinishRun(ctx, run); err != nil {
+		l.Error("Maintenance: failed to record result", "error", err)
+		return
+	}
+	l.Info("Maintenance task finished", "status", run.Status, "steps_done", run.StepsDone, "steps_total", run.StepsTotal)
+}
+
+func (u *maintenanceUsecase) ListTasks(ctx context.Context) ([]domain.MaintenanceTaskInfo, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	latest, err := u.repo.LatestRuns(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch maintenance runs: " + err.Error()))
+	}
+
+	tasks := make([]domain.MaintenanceTaskInfo, 0, len(domain.MaintenanceTasks))
+	for _, name := range domain.MaintenanceTasks {
+		info := domain.MaintenanceTaskInfo{Task: name, Description: u.tasks[name].description}
+		if run, ok := latest[name]; ok {
+			info.LastRun = &run
+		}
+		tasks = append(tasks, info)
+	}
+	return tasks, nil
+}
+
+func (u *maintenanceUsecase) ListRuns(ctx context.Context, task string) ([]domain.MaintenanceRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if task != "" && !slices.Contains(domain.MaintenanceTasks, task) {
+		return nil, apperror.BadRequest("Unknown maintenance task: " + task)
+	}
+	runs, err := u.repo.ListRuns(ctx, task, maintenanceRunListLimit)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch maintenance runs: " + err.Error()))
+	}
+	return runs, nil
+}
+
+func (u *maintenanceUsecase) GetRun(ctx context.Context, id int64) (*domain.MaintenanceRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	run, err := u.repo.GetRun(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Maintenance run not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch maintenance run: " + err.Error()))
+	}
+	return run, nil
+}
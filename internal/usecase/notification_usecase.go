@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxNotificationDigestEntries caps the events listed in one digest email; the rest are counted
+const maxNotificationDigestEntries = 20
+
+// NotificationConfig tunes notification digests
+type NotificationConfig struct {
+	DigestWindow time.Duration // 0 delivers everything immediately
+	MaxPerRun    int           // digest items handled per run
+	FrontendURL  string        // base for links in digests
+}
+
+type notificationUsecase struct {
+	repo     domain.NotificationRepository
+	notifier domain.CandidateNotifier
+	cfg      NotificationConfig
+	now      func() time.Time
+
+	running sync.Mutex
+}
+
+// NewNotificationUsecase creates the notification dispatcher. notifier may be nil,
+// in which case notifications are only logged.
+func NewNotificationUsecase(repo domain.NotificationRepository, notifier domain.CandidateNotifier, cfg NotificationConfig) domain.NotificationUsecase {
+	if notifier == nil {
+		notifier = logCandidateNotifier{}
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = 1000
+	}
+	return &notificationUsecase{repo: repo, notifier: notifier, cfg: cfg, now: time.Now}
+}
+
+// Dispatch sends critical notifications now and queues the rest for the recipient's next digest
+func (u *notificationUsecase) Dispatch(ctx context.Context, n *domain.Notification) error {
+	// 1. Localize for the recipient
+	recipient, subject, body, err := u.localize(ctx, n)
+	if err != nil {
+		return err
+	}
+	locale := notificationLocale(recipient)
+
+	// 2. The notification center gets everything; email delivery does not depend on it
+	if err := u.repo.CreateInApp(ctx, recipient.UserID, &domain.InAppNotification{Category: n.Category, Subject: subject, Body: body}); err != nil {
+		logger.FromContext(ctx).Warn("Failed to record in-app notification", "user_id", recipient.UserID, "category", n.Category, "error", err)
+	}
+
+	// 3. Critical categories are never batched
+	if u.cfg.DigestWindow <= 0 || domain.CriticalNotificationCategories[n.Category] {
+		return u.notifier.NotifyCandidate(ctx, &domain.CandidateNotification{
+			UserID:   recipient.UserID,
+			Email:    recipient.Email,
+			Locale:   locale,
+			Campaign: n.Category,
+			Subject:  subject,
+			Body:     body,
+		})
+	}
+
+	// 4. Everything else waits for the digest
+	if err := u.repo.EnqueueDigestItem(ctx, &domain.NotificationDigestItem{
+		UserID:   recipient.UserID,
+		Category: n.Category,
+		Locale:   locale,
+		Subject:  subject,
+		Body:     body,
+	}); err != nil {
+		return errors.New("Failed to queue notification: " + err.Error())
+	}
+	return nil
+}
+
+// NotifyInApp records the notification in the recipient's notification center without emailing it
+func (u *notificationUsecase) NotifyInApp(ctx context.Context, n *domain.Notification) error {
+	recipient, subject, body, err := u.localize(ctx, n)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.CreateInApp(ctx, recipient.UserID, &domain.InAppNotification{Category: n.Category, Subject: subject, Body: body}); err != nil {
+		return errors.New("Failed to record notification: " + err.Error())
+	}
+	return nil
+}
+
+// localize resolves the recipient and translates the subject and body into their locale
+func (u *notificationUsecase) localize(ctx context.Context, n *domain.Notification) (*domain.NotificationRecipient, string, string, error) {
+	recipient, err := u.repo.GetRecipient(ctx, n.UserID)
+	if err != nil {
+		return nil, "", "", errors.New("Failed to resolve notification recipient: " + err.Error())
+	}
+	locale := notificationLocale(recipient)
+	subject := fmt.Sprintf(i18n.T(locale, n.Subject), localizeNotificationArgs(locale, n.SubjectArgs)...)
+	body := fmt.Sprintf(i18n.T(locale, n.Body), localizeNotificationArgs(locale, n.BodyArgs)...)
+	return recipient, subject, body, nil
+}
+
+// localizeNotificationArgs translates NotificationLines args; other args are used as given
+func localizeNotificationArgs(locale string, args []any) []any {
+	localized := make([]any, len(args))
+	for i, arg := range args {
+		lines, ok := arg.(domain.NotificationLines)
+		if !ok {
+			localized[i] = arg
+			continue
+		}
+		translated := make([]string, len(lines))
+		for j, line := range lines {
+			translated[j] = "- " + i18n.T(locale, line)
+		}
+		localized[i] = strings.Join(translated, "\n")
+	}
+	return localized
+}
+
+func (u *notificationUsecase) RunDigests(ctx context.Context) (*domain.NotificationDigestRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A notification digest run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	result := &domain.NotificationDigestRunResult{StartedAt: now}
+
+	// 1. Items of users whose oldest queued event has waited a full window
+	items, err := u.repo.ListDueDigestItems(ctx, now.Add(-u.cfg.DigestWindow), domain.MaxNotificationDigestAttempts, u.cfg.MaxPerRun)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch notification digests: " + err.Error()))
+	}
+	result.Items = len(items)
+
+	// 2. One email per user summarizing all of their items (rows are ordered by user)
+	for start := 0; start < len(items); {
+		end := start + 1
+		for end < len(items) && items[end].UserID == items[start].UserID {
+			end++
+		}
+		batch := items[start:end]
+		start = end
+		result.Recipients++
+
+		ids := make([]int64, len(batch))
+		for i, item := range batch {
+			ids[i] = item.ID
+		}
+
+		// 3. Failed digests are retried next run, up to MaxNotificationDigestAttempts
+		if err := u.notifier.NotifyCandidate(ctx, u.renderDigest(batch)); err != nil {
+			result.Failed++
+			if err := u.repo.MarkDigestItemsFailed(ctx, ids, err.Error()); err != nil {
+				logger.FromContext(ctx).Error("Notification digest: failed to record failure", "user_id", batch[0].UserID, "error", err)
+			}
+			continue
+		}
+		result.Sent++
+		if err := u.repo.MarkDigestItemsSent(ctx, ids); err != nil {
+			logger.FromContext(ctx).Error("Notification digest: failed to mark digest sent", "user_id", batch[0].UserID, "error", err)
+		}
+	}
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+func (u *notificationUsecase) renderDigest(items []domain.NotificationDigestItem) *domain.CandidateNotification {
+	// The latest item carries the recipient's current locale
+	last := items[len(items)-1]
+	t := func(msg string) string { return i18n.T(last.Locale, msg) }
+
+	var b strings.Builder
+	b.WriteString(t("Hello,") + "\n\n")
+	b.WriteString(t("Here is what happened since your last update:") + "\n\n")
+	for i, item := range items {
+		if i == maxNotificationDigestEntries {
+			b.WriteString(fmt.Sprintf(t("...and %d more"), len(items)-i) + "\n\n")
+			break
+		}
+		b.WriteString(fmt.Sprintf("- [%s] %s\n  %s\n\n", item.CreatedAt.UTC().Format("2006-01-02 15:04 UTC"), item.Subject, strings.ReplaceAll(item.Body, "\n", "\n  ")))
+	}
+	b.WriteString(fmt.Sprintf(t("Open your dashboard: %s"), u.cfg.FrontendURL) + "\n")
+
+	subject := t("You have 1 new notification")
+	if len(items) > 1 {
+		subject = fmt.Sprintf(t("You have %d new notifications"), len(items))
+	}
+	return &domain.CandidateNotification{
+		UserID:   last.UserID,
+		Email:    last.Email,
+		Locale:   last.Locale,
+		Campaign: "NOTIFICATION_DIGEST",
+		Subject:  subject,
+		Body:     b.String(),
+	}
+}
+
+// notificationLocale is the recipient's chosen locale; candidates default to
+// Indonesian and everyone else to English
+func notificationLocale(r *domain.NotificationRecipient) string {
+	if r.PreferredLocale != nil && i18n.IsSupported(*r.PreferredLocale) {
+		return *r.PreferredLocale
+	}
+	if r.Role == domain.RoleCandidate {
+		return i18n.LocaleID
+	}
+	return i18n.LocaleEN
+}
+
+func (u *notificationUsecase) ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) (*domain.NotificationList, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	notifications, total, err := u.repo.ListInApp(ctx, userID, unreadOnly, page, pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch notifications: " + err.Error()))
+	}
+	unread, err := u.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count unread notifications: " + err.Error()))
+	}
+
+	return &domain.NotificationList{
+		PaginatedResult: domain.PaginatedResult[domain.InAppNotification]{
+			Data:       notifications,
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+		},
+		UnreadCount: unread,
+	}, nil
+}
+
+func (u *notificationUsecase) UnreadCount(ctx context.Context, userID string) (*domain.UnreadNotificationCount, error) {
+	unread, err := u.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count unread notifications: " + err.Error()))
+	}
+	return &domain.UnreadNotificationCount{UnreadCount: unread}, nil
+}
+
+func (u *notificationUsecase) MarkRead(ctx context.Context, userID string, id int64) error {
+	if err := u.repo.MarkRead(ctx, userID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Notification not found")
+		}
+		return apperror.Internal(errors.New("Failed to mark notification read: " + err.Error()))
+	}
+	return nil
+}
+
+func (u *notificationUsecase) MarkAllRead(ctx context.Context, userID string) (*domain.NotificationsReadResult, error) {
+	updated, err := u.repo.MarkAllRead(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to mark notifications read: " + err.Error()))
+	}
+	return &domain.NotificationsReadResult{Updated: updated}, nil
+}
+
+func (u *notificationUsecase) Announce(ctx context.Context, req domain.AnnouncementRequest) (*domain.AnnouncementResult, error) {
+	if err := requireUnrestrictedAdmin(ctx); err != nil {
+		return nil, err
+	}
+	subject, body := strings.TrimSpace(req.Subject), strings.TrimSpace(req.Body)
+	if subject == "" || body == "" {
+		return nil, apperror.BadRequest("Subject and body are required")
+	}
+
+	recipients, err := u.repo.CreateAnnouncement(ctx, req.Role, subject, body)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to send announcement: " + err.Error()))
+	}
+	adminID, _ := ctx.Value(domain.KeyUserID).(string)
+	logger.FromContext(ctx).Info("Announcement sent", "admin_id", adminID, "role", req.Role, "recipients", recipients)
+	return &domain.AnnouncementResult{Recipients: recipients}, nil
+}
@@ -45,8 +45,8 @@ func (u *onboardingUsecase) SearchLPK(ctx context.Context, query string) ([]doma
 
 func (u *onboardingUsecase) GetOnboardingStatus(ctx context.Context, userID string) (*domain.OnboardingStatus, error) {
 	// Security: Verify context user matches requested user
-	ctxUserID, ok := ctx.Value(string(domain.KeyUserID)).(string)
-	if !ok || ctxUserID == "" {
+	ctxUserID := domain.UserIDFromContext(ctx)
+	if ctxUserID == "" {
 		return nil, apperror.Unauthorized("User not authenticated")
 	}
 
@@ -68,14 +68,8 @@ func (u *onboardingUsecase) GetOnboardingStatus(ctx context.Context, userID stri
 
 func (u *onboardingUsecase) GetOnboardingData(ctx context.Context, userID string) (*domain.OnboardingData, error) {
 	// Security: Verify context user matches requested user
-	// Try compatible string key first (Gin context)
-	ctxUserID, ok := ctx.Value(string(domain.KeyUserID)).(string)
-	if !ok || ctxUserID == "" {
-		// Fallback to typed key (standard context / tests)
-		ctxUserID, ok = ctx.Value(domain.KeyUserID).(string)
-	}
-
-	if !ok || ctxUserID == "" {
+	ctxUserID := domain.UserIDFromContext(ctx)
+	if ctxUserID == "" {
 		return nil, apperror.Unauthorized("User not authenticated")
 	}
 
@@ -97,8 +91,8 @@ func (u *onboardingUsecase) GetOnboardingData(ctx context.Context, userID string
 
 func (u *onboardingUsecase) CompleteOnboarding(ctx context.Context, userID string, req *domain.OnboardingSubmitRequest) error {
 	// Security: Verify context user matches requested user
-	ctxUserID, ok := ctx.Value(string(domain.KeyUserID)).(string)
-	if !ok || ctxUserID == "" {
+	ctxUserID := domain.UserIDFromContext(ctx)
+	if ctxUserID == "" {
 		return apperror.Unauthorized("User not authenticated")
 	}
 
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/security"
+	"go-recruitment-backend/pkg/sms"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const phoneOTPMessage = "Kode verifikasi J-Expert Anda: %s. Berlaku 10 menit. Jangan bagikan kode ini kepada siapa pun."
+
+type phoneVerificationUsecase struct {
+	repo       domain.PhoneVerificationRepository
+	smsService *sms.SMSService
+}
+
+func NewPhoneVerificationUsecase(repo domain.PhoneVerificationRepository, smsService *sms.SMSService) domain.PhoneVerificationUsecase {
+	return &phoneVerificationUsecase{repo: repo, smsService: smsService}
+}
+
+func (u *phoneVerificationUsecase) GetStatus(ctx context.Context, userID string) (*domain.PhoneVerificationStatus, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	phone, verifiedAt, err := u.repo.GetPhoneStatus(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Verification profile not found")
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	status := &domain.PhoneVerificationStatus{
+		Phone:      phone,
+		Verified:   verifiedAt != nil,
+		VerifiedAt: verifiedAt,
+	}
+
+	// Surface a pending challenge so the UI can show the countdown
+	if latest, err := u.repo.GetLatestChallenge(ctx, userID); err == nil && latest.ConsumedAt == nil && latest.ExpiresAt.After(time.Now()) {
+		resendAt := latest.CreatedAt.Add(domain.PhoneOTPResendWait)
+		status.OTPExpiresAt = &latest.ExpiresAt
+		status.ResendAfterAt = &resendAt
+	}
+
+	return status, nil
+}
+
+func (u *phoneVerificationUsecase) SendOTP(ctx context.Context, userID string) (*domain.PhoneVerificationStatus, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Load the self-reported phone
+	phone, verifiedAt, err := u.repo.GetPhoneStatus(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Verification profile not found")
+		}
+		return nil, apperror.Internal(err)
+	}
+	if phone == nil || strings.TrimSpace(*phone) == "" {
+		return nil, apperror.BadRequest("Nomor telepon belum diisi di profil")
+	}
+	if verifiedAt != nil {
+		return nil, apperror.Conflict("Nomor telepon sudah terverifikasi")
+	}
+
+	to, err := normalizePhoneE164(*phone)
+	if err != nil {
+		return nil, apperror.BadRequest(err.Error())
+	}
+
+	// 2. Throttle: resend cooldown and daily cap
+	now := time.Now()
+	if latest, err := u.repo.GetLatestChallenge(ctx, userID); err == nil {
+		if wait := latest.CreatedAt.Add(domain.PhoneOTPResendWait); wait.After(now) {
+			return nil, apperror.New(http.StatusTooManyRequests, fmt.Sprintf("Tunggu %d detik sebelum meminta kode baru", int(wait.Sub(now).Seconds())+1), nil)
+		}
+	}
+	sent, err := u.repo.CountChallengesSince(ctx, userID, now.Add(-24*time.Hour))
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if sent >= domain.PhoneOTPMaxPerDay {
+		return nil, apperror.New(http.StatusTooManyRequests, "Batas pengiriman kode harian tercapai. Coba lagi besok.", nil)
+	}
+
+	// 3. Generate and store hashed code
+	code, err := generateNumericOTP(domain.PhoneOTPLength)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	challenge := &domain.PhoneOTPChallenge{
+		UserID:    userID,
+		Phone:     *phone,
+		CodeHash:  hashOTP(userID, code),
+		ExpiresAt: now.Add(domain.PhoneOTPTTL),
+	}
+	if err := u.repo.CreateChallenge(ctx, challenge); err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	// 4. Send
+	if err := u.smsService.Send(ctx, to, fmt.Sprintf(phoneOTPMessage, code)); err != nil {
+		return nil, apperror.Internal(fmt.Errorf("failed to send OTP: %w", err))
+	}
+
+	resendAt := challenge.CreatedAt.Add(domain.PhoneOTPResendWait)
+	return &domain.PhoneVerificationStatus{
+		Phone:         phone,
+		Verified:      false,
+		OTPExpiresAt:  &challenge.ExpiresAt,
+		ResendAfterAt: &resendAt,
+	}, nil
+}
+
+func (u *phoneVerificationUsecase) VerifyOTP(ctx context.Context, userID, code string) (*domain.PhoneVerificationStatus, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Load the active challenge
+	challenge, err := u.repo.GetLatestChallenge(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.BadRequest("Kode verifikasi belum diminta")
+		}
+		return nil, apperror.Internal(err)
+	}
+	if challenge.ConsumedAt != nil || challenge.ExpiresAt.Before(time.Now()) {
+		return nil, apperror.BadRequest("Kode verifikasi sudah kedaluwarsa. Minta kode baru.")
+	}
+	if challenge.Attempts >= domain.PhoneOTPMaxAttempts {
+		return nil, apperror.New(http.StatusTooManyRequests, "Terlalu banyak percobaan. Minta kode baru.", nil)
+	}
+
+	// 2. Compare in constant time; count every attempt
+	if !security.ConstantTimeCompare(challenge.CodeHash, hashOTP(userID, code)) {
+		_ = u.repo.IncrementAttempts(ctx, challenge.ID)
+		security.DefaultLogger().Log(ctx, security.SecurityEvent{
+			Event:        security.EventValidationFailed,
+			SubjectType:  "user_id",
+			SubjectValue: security.HashValue(userID),
+			Details:      map[string]interface{}{"reason": "invalid_phone_otp", "attempt": challenge.Attempts + 1},
+		})
+		return nil, apperror.BadRequest("Kode verifikasi salah")
+	}
+
+	// 3. Consume challenge and stamp verified phone
+	verifiedAt, err := u.repo.MarkVerified(ctx, challenge.ID, userID, challenge.Phone)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Conflict("Nomor telepon berubah sejak kode dikirim. Minta kode baru.")
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	phone := challenge.Phone
+	return &domain.PhoneVerificationStatus{
+		Phone:      &phone,
+		Verified:   true,
+		VerifiedAt: &verifiedAt,
+	}, nil
+}
+
+// requireSelfCandidate ensures the caller is the candidate acting on their own record
+func requireSelfCandidate(ctx context.Context, userID string) error {
+	authID, _ := ctx.Value(domain.KeyUserID).(string)
+	if authID == "" {
+		return apperror.Unauthorized("Not authenticated")
+	}
+	if authID != userID {
+		return apperror.Forbidden("Access denied")
+	}
+	return requireRole(ctx, domain.RoleCandidate)
+}
+
+// normalizePhoneE164 converts Indonesian-style numbers (08xx, 628xx, +628xx) to E.164
+func normalizePhoneE164(raw string) (string, error) {
+	var digits strings.Builder
+	for i, r := range strings.TrimSpace(raw) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			digits.WriteRune(r)
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+			// formatting characters
+		default:
+			return "", errors.New("Format nomor telepon tidak valid")
+		}
+	}
+
+	n := digits.String()
+	switch {
+	case strings.HasPrefix(n, "+"):
+	case strings.HasPrefix(n, "0"):
+		n = "+62" + n[1:]
+	case strings.HasPrefix(n, "62"):
+		n = "+" + n
+	default:
+		return "", errors.New("Format nomor telepon tidak valid")
+	}
+
+	// E.164: up to 15 digits after '+'
+	if len(n) < 9 || len(n) > 16 {
+		return "", errors.New("Format nomor telepon tidak valid")
+	}
+	return n, nil
+}
+
+func generateNumericOTP(length int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + d.Int64()))
+	}
+	return b.String(), nil
+}
+
+// hashOTP binds the code to the user so hashes can't be reused across accounts
+func hashOTP(userID, code string) string {
+	sum := sha256.Sum256([]byte(userID + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PipelineSLAConfig tunes the pipeline SLA worker
+type PipelineSLAConfig struct {
+	MaxNotificationsPerRun int // breaches notified per run; the rest wait for the next run
+}
+
+type pipelineSLAUsecase struct {
+	repo          domain.PipelineSLARepository
+	profileRepo   domain.CompanyProfileRepository
+	notifications domain.NotificationDispatcher
+	cfg           PipelineSLAConfig
+	now           func() time.Time
+
+	running sync.Mutex
+}
+
+func NewPipelineSLAUsecase(repo domain.PipelineSLARepository, profileRepo domain.CompanyProfileRepository, notifications domain.NotificationDispatcher, cfg PipelineSLAConfig) domain.PipelineSLAUsecase {
+	if cfg.MaxNotificationsPerRun <= 0 {
+		cfg.MaxNotificationsPerRun = 500
+	}
+	return &pipelineSLAUsecase{repo: repo, profileRepo: profileRepo, notifications: notifications, cfg: cfg, now: time.Now}
+}
+
+func (u *pipelineSLAUsecase) RunChecks(ctx context.Context) (*domain.PipelineSLARunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A pipeline SLA run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	result := &domain.PipelineSLARunResult{StartedAt: u.now().UTC()}
+
+	// 1. Close breaches whose application has moved on, then record new ones
+	resolved, err := u.repo.ResolveBreaches(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to resolve SLA breaches: " + err.Error()))
+	}
+	result.Resolved = resolved
+
+	breached, err := u.repo.RecordBreaches(ctx, domain.DefaultPipelineSLADays)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to record SLA breaches: " + err.Error()))
+	}
+	result.Breached = breached
+
+	// 2. One notification per employer listing its new breaches (rows are ordered by company)
+	notices, err := u.repo.ListUnnotified(ctx, u.cfg.MaxNotificationsPerRun)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch unnotified SLA breaches: " + err.Error()))
+	}
+	for start := 0; start < len(notices); {
+		end := start + 1
+		for end < len(notices) && notices[end].CompanyID == notices[start].CompanyID {
+			end++
+		}
+		batch := notices[start:end]
+		start = end
+
+		if err := u.notifyEmployer(ctx, batch); err != nil {
+			logger.FromContext(ctx).Error("Pipeline SLA: failed to notify employer", "company_id", batch[0].CompanyID, "error", err)
+			result.Failed++
+			continue
+		}
+		ids := make([]int64, len(batch))
+		for i, n := range batch {
+			ids[i] = n.BreachID
+		}
+		if err := u.repo.MarkNotified(ctx, ids); err != nil {
+			return nil, apperror.Internal(errors.New("Failed to mark SLA breaches notified: " + err.Error()))
+		}
+		result.Notified++
+	}
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+// notifyEmployer sends one message per company, one line per job and stage
+func (u *pipelineSLAUsecase) notifyEmployer(ctx context.Context, notices []domain.PipelineSLABreachNotice) error {
+	if u.notifications == nil {
+		return nil
+	}
+
+	counts := map[string]int{}
+	var keys []string
+	for _, n := range notices {
+		key := fmt.Sprintf("%s (%s)", n.JobTitle, n.Stage)
+		if counts[key] == 0 {
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		lines[i] = fmt.Sprintf("- %s: %d", key, counts[key])
+	}
+
+	return u.notifications.Dispatch(ctx, &domain.Notification{
+		UserID:      notices[0].CompanyUserID,
+		Category:    domain.NotificationCategoryPipelineSLA,
+		Subject:     "%d applications are waiting longer than your response targets",
+		SubjectArgs: []any{len(notices)},
+		Body:        "These applications have stayed in one stage longer than your pipeline SLA allows:\n%s\n\nMove them forward or adjust your thresholds in the hiring pipeline settings.",
+		BodyArgs:    []any{strings.Join(lines, "\n")},
+	})
+}
+
+func (u *pipelineSLAUsecase) GetSettings(ctx context.Context, userID string) (*domain.PipelineSLASettings, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.settings(ctx, company.ID)
+}
+
+func (u *pipelineSLAUsecase) UpdateSettings(ctx context.Context, userID string, req domain.UpdatePipelineSLARequest) (*domain.PipelineSLASettings, error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds := make(map[string]int, len(req.Thresholds))
+	for _, t := range req.Thresholds {
+		if _, dup := thresholds[t.Stage]; dup {
+			return nil, apperror.BadRequest("Each stage may only be set once")
+		}
+		thresholds[t.Stage] = *t.MaxDays
+	}
+
+	if err := u.repo.SetThresholds(ctx, company.ID, thresholds, userID); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to update pipeline SLA: " + err.Error()))
+	}
+	return u.settings(ctx, company.ID)
+}
+
+func (u *pipelineSLAUsecase) ListBreaches(ctx context.Context, userID string, filter domain.PipelineSLABreachFilter) (*domain.PaginatedResult[domain.PipelineSLABreach], error) {
+	company, err := u.employerCompany(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Status = strings.ToUpper(filter.Status)
+	if filter.Status != "" && filter.Status != domain.PipelineSLABreachOpen && filter.Status != domain.PipelineSLABreachResolved {
+		return nil, apperror.BadRequest("Invalid breach status")
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	breaches, total, err := u.repo.ListBreaches(ctx, company.ID, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch SLA breaches: " + err.Error()))
+	}
+
+	return &domain.PaginatedResult[domain.PipelineSLABreach]{
+		Data:       breaches,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	}, nil
+}
+
+func (u *pipelineSLAUsecase) GetChronicBreachReport(ctx context.Context, filter domain.PipelineSLAReportFilter) ([]domain.PipelineSLACompanyReport, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if filter.Days <= 0 {
+		filter.Days = 90
+	}
+	if filter.Days > 365 {
+		return nil, apperror.BadRequest("The report period cannot exceed 365 days")
+	}
+	if filter.MinBreaches <= 0 {
+		filter.MinBreaches = 5
+	}
+
+	since := u.now().UTC().AddDate(0, 0, -filter.Days)
+	report, err := u.repo.CompanyReport(ctx, since, filter.MinBreaches)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to build pipeline SLA report: " + err.Error()))
+	}
+
+	// Candidate experience: the share of applications that never waited past an SLA
+	for i := range report {
+		c := &report[i]
+		c.CandidateExperienceScore = 0
+		if c.Applications > 0 && c.Applications > c.AffectedApplications {
+			c.CandidateExperienceScore = math.Round(float64(c.Applications-c.AffectedApplications)*1000/float64(c.Applications)) / 10
+		}
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].CandidateExperienceScore != report[j].CandidateExperienceScore {
+			return report[i].CandidateExperienceScore < report[j].CandidateExperienceScore
+		}
+		return report[i].Breaches > report[j].Breaches
+	})
+	return report, nil
+}
+
+// settings merges the company's thresholds over the platform defaults
+func (u *pipelineSLAUsecase) settings(ctx context.Context, companyID int64) (*domain.PipelineSLASettings, error) {
+	configured, err := u.repo.ListThresholds(ctx, companyID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch pipeline SLA: " + err.Error()))
+	}
+
+	settings := &domain.PipelineSLASettings{CompanyID: companyID, Thresholds: make([]domain.PipelineSLAThreshold, 0, len(domain.PipelineSLAStages))}
+	for _, stage := range domain.PipelineSLAStages {
+		days, ok := configured[stage]
+		if !ok {
+			days = domain.DefaultPipelineSLADays[stage]
+		}
+		settings.Thresholds = append(settings.Thresholds, domain.PipelineSLAThreshold{Stage: stage, MaxDays: days, IsDefault: !ok})
+	}
+	return settings, nil
+}
+
+func (u *pipelineSLAUsecase) employerCompany(ctx context.Context, userID string) (*domain.CompanyProfile, error) {
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+
+	company, err := u.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch company profile: " + err.Error()))
+	}
+	return company, nil
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"math"
+	"slices"
+	"time"
+)
+
+type placementUsecase struct {
+	repo    domain.PlacementRepository
+	appRepo domain.ApplicationRepository
+}
+
+// NewPlacementUsecase creates the placement and alumni tracking usecase
+func NewPlacementUsecase(repo domain.PlacementRepository, appRepo domain.ApplicationRepository) domain.PlacementUsecase {
+	return &placementUsecase{repo: repo, appRepo: appRepo}
+}
+
+func (u *placementUsecase) CreatePlacement(ctx context.Context, req domain.CreatePlacementRequest) (*domain.Placement, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	// 1. Only hires are placed
+	app, err := u.appRepo.GetByID(ctx, req.ApplicationID)
+	if err != nil {
+		return nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
+	}
+	if app.Status != domain.ApplicationStatusAccepted {
+		return nil, apperror.BadRequest("Only accepted applications can be placed")
+	}
+
+	// 2. Contract end and check-in due dates follow from the start date
+	start, err := time.Parse(domain.HolidayDateFormat, req.StartDate)
+	if err != nil {
+		return nil, apperror.BadRequest("Invalid start_date")
+	}
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	placement := &domain.Placement{
+		ApplicationID:   app.ID,
+		StartDate:       req.StartDate,
+		ContractMonths:  req.ContractMonths,
+		ContractEndDate: start.AddDate(0, req.ContractMonths, 0).Format(domain.HolidayDateFormat),
+		Notes:           req.Notes,
+		CreatedBy:       &userID,
+	}
+	checkIns := make([]domain.PlacementCheckIn, 0, len(domain.PlacementMilestones))
+	for _, months := range domain.PlacementMilestones {
+		checkIns = append(checkIns, domain.PlacementCheckIn{
+			MilestoneMonths: months,
+			DueDate:         start.AddDate(0, months, 0).Format(domain.HolidayDateFormat),
+		})
+	}
+
+	if err := u.repo.Create(ctx, placement, checkIns); err != nil {
+		return nil, wrapPlacementError(err, "Failed to create placement: ")
+	}
+	return u.getPlacement(ctx, placement.ID)
+}
+
+func (u *placementUsecase) GetPlacement(ctx context.Context, id int64) (*domain.Placement, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.getPlacement(ctx, id)
+}
+
+func (u *placementUsecase) ListPlacements(ctx context.Context, filter domain.PlacementFilter, page, pageSize int) (*domain.PaginatedResult[domain.Placement], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if filter.Status != "" && !slices.Contains([]string{domain.PlacementStatusActive, domain.PlacementStatusCompleted, domain.PlacementStatusEndedEarly}, filter.Status) {
+		return nil, apperror.BadRequest("Invalid placement status")
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	placements, total, err := u.repo.List(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch placements: " + err.Error()))
+	}
+
+	return &domain.PaginatedResult[domain.Placement]{
+		Data:       placements,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(pageSize))),
+	}, nil
+}
+
+func (u *placementUsecase) EndPlacement(ctx context.Context, id int64, req domain.EndPlacementRequest) (*domain.Placement, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	placement, err := u.getPlacement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if placement.Status != domain.PlacementStatusActive {
+		return nil, apperror.Conflict("The placement has already ended")
+	}
+	if req.EndedOn < placement.StartDate {
+		return nil, apperror.BadRequest("ended_on must not be before start_date")
+	}
+
+	if err := u.repo.End(ctx, id, req.Status, req.EndedOn, req.Reason); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Conflict("The placement has already ended")
+		}
+		return nil, apperror.Internal(errors.New("Failed to end placement: " + err.Error()))
+	}
+	return u.getPlacement(ctx, id)
+}
+
+func (u *placementUsecase) CompleteCheckIn(ctx context.Context, id int64, months int, req domain.CompleteCheckInRequest) (*domain.Placement, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(domain.PlacementMilestones, months) {
+		return nil, apperror.BadRequest("Check-ins are at 3, 6 and 12 months")
+	}
+	if _, err := u.getPlacement(ctx, id); err != nil {
+		return nil, err
+	}
+
+	userID, _ := ctx.Value(domain.KeyUserID).(string)
+	if err := u.repo.CompleteCheckIn(ctx, id, months, req.Outcome, req.Notes, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Conflict("The check-in is already completed or skipped")
+		}
+		return nil, apperror.Internal(errors.New("Failed to complete check-in: " + err.Error()))
+	}
+	return u.getPlacement(ctx, id)
+}
+
+func (u *placementUsecase) ListCheckInTasks(ctx context.Context) ([]domain.PlacementCheckInTask, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	tasks, err := u.repo.ListDueCheckIns(ctx, domain.PlacementCheckInReminderDays)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch check-in tasks: " + err.Error()))
+	}
+	return tasks, nil
+}
+
+func (u *placementUsecase) GetStats(ctx context.Context) (*domain.PlacementStats, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	stats, err := u.repo.GetStats(ctx, domain.PlacementCheckInReminderDays)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch placement stats: " + err.Error()))
+	}
+	for i := range stats.Milestones {
+		m := &stats.Milestones[i]
+		if m.Reached > 0 {
+			m.RetentionRate = math.Round(float64(m.Retained)*1000/float64(m.Reached)) / 10
+		}
+	}
+	return stats, nil
+}
+
+func (u *placementUsecase) getPlacement(ctx context.Context, id int64) (*domain.Placement, error) {
+	placement, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, wrapPlacementError(err, "Failed to fetch placement: ")
+	}
+	return placement, nil
+}
+
+func wrapPlacementError(err error, prefix string) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return apperror.NotFound("Placement not found")
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+	return apperror.Internal(errors.New(prefix + err.Error()))
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/cache"
+	"go-recruitment-backend/pkg/logger"
+	"time"
+)
+
+// publicJobGenerationKey holds the generation that prefixes every public job
+// cache key. Bumping it invalidates all cached pages at once on every instance,
+// without scanning for keys; the old entries expire with their TTL.
+const publicJobGenerationKey = "jobs:public:gen"
+
+// PublicJobCache caches the public job list and detail responses. A nil
+// *PublicJobCache disables caching.
+type PublicJobCache struct {
+	store        cache.Cache
+	listExpiry   time.Duration
+	detailExpiry time.Duration
+}
+
+// NewPublicJobCache caches list pages for listTTL and job details for detailTTL
+func NewPublicJobCache(store cache.Cache, listTTL, detailTTL time.Duration) *PublicJobCache {
+	return &PublicJobCache{store: store, listExpiry: listTTL, detailExpiry: detailTTL}
+}
+
+type cachedJobList struct {
+	Jobs  []domain.JobWithCompany `json:"jobs"`
+	Total int64                   `json:"total"`
+}
+
+// Invalidate drops every cached public job page, after a job is created,
+// edited, deleted, hidden or (un)published
+func (c *PublicJobCache) Invalidate(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	if _, err := c.store.Incr(ctx, publicJobGenerationKey); err != nil {
+		logger.FromContext(ctx).Warn("Failed to invalidate public job cache", "error", err)
+	}
+}
+
+func (c *PublicJobCache) listTTL() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.listExpiry
+}
+
+func (c *PublicJobCache) detailTTL() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return c.detailExpiry
+}
+
+func (c *PublicJobCache) listKey(gen, sort string, page, pageSize int) string {
+	return fmt.Sprintf("jobs:public:%s:list:%s:%d:%d", gen, sort, page, pageSize)
+}
+
+func (c *PublicJobCache) jobKey(gen string, id int64) string {
+	return fmt.Sprintf("jobs:public:%s:job:%d", gen, id)
+}
+
+func (c *PublicJobCache) pageKey(gen string, id int64) string {
+	return fmt.Sprintf("jobs:public:%s:page:%d", gen, id)
+}
+
+// generation returns the current generation; ok is false when the cache is unreachable
+func (c *PublicJobCache) generation(ctx context.Context) (string, bool) {
+	gen, found, err := c.store.Get(ctx, publicJobGenerationKey)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Public job cache unavailable", "error", err)
+		return "", false
+	}
+	if !found {
+		return "0", true
+	}
+	return string(gen), true
+}
+
+// loadPublicJobs returns the cached value for the key built by keyFn, or calls fetch and
+// caches its result for ttl. Cache failures fall through to fetch.
+func loadPublicJobs[T any](ctx context.Context, c *PublicJobCache, keyFn func(gen string) string, ttl time.Duration, fetch func() (T, error)) (T, error) {
+	if c == nil || ttl <= 0 {
+		return fetch()
+	}
+	gen, ok := c.generation(ctx)
+	if !ok {
+		return fetch()
+	}
+	key := keyFn(gen)
+
+	if data, found, err := c.store.Get(ctx, key); err == nil && found {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	if data, err := json.Marshal(value); err == nil {
+		if err := c.store.Set(ctx, key, data, ttl); err != nil {
+			logger.FromContext(ctx).Warn("Failed to cache public jobs", "key", key, "error", err)
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Defaults for optional quiz settings
+const (
+	defaultQuizPassScore     = 70
+	defaultQuizCooldownHours = 24
+)
+
+type quizUsecase struct {
+	repo domain.QuizRepository
+}
+
+func NewQuizUsecase(repo domain.QuizRepository) domain.QuizUsecase {
+	return &quizUsecase{repo: repo}
+}
+
+// ============================================================================
+// Admin authoring
+// ============================================================================
+
+func (u *quizUsecase) ListQuizzes(ctx context.Context) ([]domain.SkillQuiz, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	quizzes, err := u.repo.ListQuizzes(ctx, false)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch quizzes: " + err.Error()))
+	}
+	return quizzes, nil
+}
+
+func (u *quizUsecase) GetQuiz(ctx context.Context, id int64) (*domain.SkillQuiz, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.loadQuiz(ctx, id)
+}
+
+func (u *quizUsecase) CreateQuiz(ctx context.Context, userID string, req domain.QuizRequest) (*domain.SkillQuiz, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	quiz, err := buildSkillQuiz(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.repo.CreateQuiz(ctx, quiz, userID); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create quiz: " + err.Error()))
+	}
+	return quiz, nil
+}
+
+func (u *quizUsecase) UpdateQuiz(ctx context.Context, id int64, req domain.QuizRequest) (*domain.SkillQuiz, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	quiz, err := buildSkillQuiz(req)
+	if err != nil {
+		return nil, err
+	}
+	quiz.ID = id
+
+	// Best scores already earned are kept; open attempts are scored against the new questions
+	if err := u.repo.UpdateQuiz(ctx, quiz); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Quiz not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update quiz: " + err.Error()))
+	}
+	return quiz, nil
+}
+
+// ============================================================================
+// Candidate
+// ============================================================================
+
+func (u *quizUsecase) ListAvailableQuizzes(ctx context.Context, userID string) ([]domain.CandidateQuizOverview, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Published quizzes, own scores and latest attempts
+	quizzes, err := u.repo.ListQuizzes(ctx, true)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch quizzes: " + err.Error()))
+	}
+	scores, err := u.repo.ListUserScores(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch quiz scores: " + err.Error()))
+	}
+	attempts, err := u.repo.ListLatestAttempts(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch quiz attempts: " + err.Error()))
+	}
+
+	scoreByQuiz := make(map[int64]domain.CandidateQuizScore, len(scores))
+	for _, s := range scores {
+		scoreByQuiz[s.QuizID] = s
+	}
+	attemptByQuiz := make(map[int64]domain.QuizAttempt, len(attempts))
+	for _, a := range attempts {
+		attemptByQuiz[a.QuizID] = a
+	}
+
+	// 2. Combine
+	now := time.Now()
+	overviews := make([]domain.CandidateQuizOverview, 0, len(quizzes))
+	for _, quiz := range quizzes {
+		overview := domain.CandidateQuizOverview{Quiz: quiz}
+		if s, ok := scoreByQuiz[quiz.ID]; ok {
+			overview.Score = &s
+		}
+		if a, ok := attemptByQuiz[quiz.ID]; ok {
+			if a.Status == domain.QuizAttemptInProgress && now.Before(a.ExpiresAt) {
+				overview.OpenAttemptID = &a.ID
+			} else if retakeAt := quizRetakeAvailableAt(quiz, a); now.Before(retakeAt) {
+				overview.RetakeAvailableAt = &retakeAt
+			}
+		}
+		overviews = append(overviews, overview)
+	}
+	return overviews, nil
+}
+
+func (u *quizUsecase) StartAttempt(ctx context.Context, userID string, quizID int64) (*domain.QuizAttemptSession, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Quiz must be published
+	quiz, err := u.loadQuiz(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+	if !quiz.IsPublished {
+		return nil, apperror.NotFound("Quiz not found")
+	}
+
+	// 2. Resume an open attempt; close it first if its time ran out
+	now := time.Now()
+	latest, err := u.repo.GetLatestAttempt(ctx, userID, quizID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, apperror.Internal(errors.New("Failed to fetch quiz attempts: " + err.Error()))
+	}
+	if latest != nil && latest.Status == domain.QuizAttemptInProgress {
+		if now.Before(latest.ExpiresAt) {
+			return quizSession(quiz, latest), nil
+		}
+		if err := u.expireAttempt(ctx, latest, now); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Anti-retake throttle, counted from the start of the previous attempt
+	if latest != nil {
+		if retakeAt := quizRetakeAvailableAt(*quiz, *latest); now.Before(retakeAt) {
+			return nil, apperror.New(http.StatusTooManyRequests, "You can retake this quiz after: "+retakeAt.UTC().Format(time.RFC3339), nil)
+		}
+	}
+
+	// 4. Start with a server-side deadline
+	attempt := &domain.QuizAttempt{
+		QuizID:    quizID,
+		UserID:    userID,
+		Status:    domain.QuizAttemptInProgress,
+		StartedAt: now,
+		ExpiresAt: now.Add(time.Duration(quiz.TimeLimitSeconds) * time.Second),
+	}
+	if err := u.repo.CreateAttempt(ctx, attempt); err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, err
+		}
+		return nil, apperror.Internal(errors.New("Failed to start quiz: " + err.Error()))
+	}
+	return quizSession(quiz, attempt), nil
+}
+
+func (u *quizUsecase) SubmitAttempt(ctx context.Context, userID string, attemptID int64, req domain.SubmitQuizRequest) (*domain.QuizAttempt, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// 1. Own open attempt only
+	attempt, err := u.repo.GetAttempt(ctx, attemptID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Quiz attempt not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch quiz attempt: " + err.Error()))
+	}
+	if attempt.UserID != userID {
+		return nil, apperror.NotFound("Quiz attempt not found")
+	}
+	if attempt.Status != domain.QuizAttemptInProgress {
+		return nil, apperror.Conflict("This quiz attempt has already been closed")
+	}
+
+	// 2. Time limit (with a small grace for latency)
+	now := time.Now()
+	if now.After(attempt.ExpiresAt.Add(domain.QuizSubmitGrace)) {
+		if err := u.expireAttempt(ctx, attempt, now); err != nil {
+			return nil, err
+		}
+		return nil, apperror.BadRequest("Time limit exceeded; this attempt was not scored")
+	}
+
+	// 3. Score against the current questions
+	quiz, err := u.loadQuiz(ctx, attempt.QuizID)
+	if err != nil {
+		return nil, err
+	}
+	correct, err := scoreQuizAnswers(quiz.Questions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+	total := len(quiz.Questions)
+	score := 0
+	if total > 0 {
+		score = (correct*100 + total/2) / total
+	}
+	passed := score >= quiz.PassScore
+
+	// 4. Close and record the best score
+	attempt.Status = domain.QuizAttemptSubmitted
+	attempt.SubmittedAt = &now
+	attempt.CorrectCount = &correct
+	attempt.TotalQuestions = &total
+	attempt.ScorePercent = &score
+	if err := u.repo.CloseAttempt(ctx, attempt, passed); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Conflict("This quiz attempt has already been closed")
+		}
+		return nil, apperror.Internal(errors.New("Failed to submit quiz: " + err.Error()))
+	}
+	attempt.Passed = &passed
+	return attempt, nil
+}
+
+func (u *quizUsecase) GetMyScores(ctx context.Context, userID string) ([]domain.CandidateQuizScore, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	scores, err := u.repo.ListUserScores(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch quiz scores: " + err.Error()))
+	}
+	return scores, nil
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// loadQuiz returns the quiz with its questions, including the answer key
+func (u *quizUsecase) loadQuiz(ctx context.Context, id int64) (*domain.SkillQuiz, error) {
+	quiz, err := u.repo.GetQuiz(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Quiz not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch quiz: " + err.Error()))
+	}
+	questions, err := u.repo.ListQuestions(ctx, id)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch quiz questions: " + err.Error()))
+	}
+	quiz.Questions = questions
+	return quiz, nil
+}
+
+// expireAttempt closes a timed-out attempt without a score; a concurrent close is not an error
+func (u *quizUsecase) expireAttempt(ctx context.Context, attempt *domain.QuizAttempt, now time.Time) error {
+	attempt.Status = domain.QuizAttemptExpired
+	attempt.SubmittedAt = &now
+	if err := u.repo.CloseAttempt(ctx, attempt, false); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return apperror.Internal(errors.New("Failed to close quiz attempt: " + err.Error()))
+	}
+	return nil
+}
+
+// buildSkillQuiz validates a quiz request and applies defaults
+func buildSkillQuiz(req domain.QuizRequest) (*domain.SkillQuiz, error) {
+	if len(req.Questions) > domain.MaxQuizQuestions {
+		return nil, apperror.BadRequest(fmt.Sprintf("At most %d questions are allowed", domain.MaxQuizQuestions))
+	}
+
+	quiz := &domain.SkillQuiz{
+		Title:               strings.TrimSpace(req.Title),
+		Description:         req.Description,
+		Category:            req.Category,
+		TimeLimitSeconds:    req.TimeLimitSeconds,
+		PassScore:           defaultQuizPassScore,
+		RetakeCooldownHours: defaultQuizCooldownHours,
+		IsPublished:         req.IsPublished,
+		Questions:           make([]domain.QuizQuestion, 0, len(req.Questions)),
+	}
+	if req.PassScore != nil {
+		quiz.PassScore = *req.PassScore
+	}
+	if req.RetakeCooldownHours != nil {
+		quiz.RetakeCooldownHours = *req.RetakeCooldownHours
+	}
+
+	for i, in := range req.Questions {
+		if *in.CorrectOption >= len(in.Options) {
+			return nil, apperror.BadRequest(fmt.Sprintf("Question %d: correct option is out of range", i+1))
+		}
+		correct := *in.CorrectOption
+		quiz.Questions = append(quiz.Questions, domain.QuizQuestion{
+			Position:      i + 1,
+			Prompt:        strings.TrimSpace(in.Prompt),
+			Options:       in.Options,
+			CorrectOption: &correct,
+		})
+	}
+	quiz.QuestionCount = len(quiz.Questions)
+	return quiz, nil
+}
+
+// scoreQuizAnswers counts correct answers; unanswered questions count as wrong
+func scoreQuizAnswers(questions []domain.QuizQuestion, answers []domain.QuizAnswerInput) (int, error) {
+	byID := make(map[int64]domain.QuizQuestion, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+
+	seen := make(map[int64]bool, len(answers))
+	correct := 0
+	for _, a := range answers {
+		q, ok := byID[a.QuestionID]
+		if !ok {
+			return 0, apperror.BadRequest("Answer refers to a question that is not part of this quiz")
+		}
+		if seen[a.QuestionID] {
+			return 0, apperror.BadRequest("Each question can only be answered once")
+		}
+		seen[a.QuestionID] = true
+
+		if *a.SelectedOption >= len(q.Options) {
+			return 0, apperror.BadRequest("Selected option is out of range")
+		}
+		if q.CorrectOption != nil && *a.SelectedOption == *q.CorrectOption {
+			correct++
+		}
+	}
+	return correct, nil
+}
+
+// quizRetakeAvailableAt is when the next attempt may start
+func quizRetakeAvailableAt(quiz domain.SkillQuiz, latest domain.QuizAttempt) time.Time {
+	return latest.StartedAt.Add(time.Duration(quiz.RetakeCooldownHours) * time.Hour)
+}
+
+func quizSession(quiz *domain.SkillQuiz, attempt *domain.QuizAttempt) *domain.QuizAttemptSession {
+	public := *quiz
+	public.Questions = make([]domain.QuizQuestion, len(quiz.Questions))
+	for i, q := range quiz.Questions {
+		public.Questions[i] = q.ForCandidate()
+	}
+	return &domain.QuizAttemptSession{Attempt: *attempt, Quiz: public}
+}
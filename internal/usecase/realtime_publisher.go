@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/realtime"
+	"time"
+)
+
+type realtimePublisher struct {
+	broker realtime.Broker
+}
+
+// NewRealtimePublisher publishes domain events through a realtime broker: the
+// in-process hub, or Redis fan-out when several instances run
+func NewRealtimePublisher(broker realtime.Broker) domain.RealtimePublisher {
+	return &realtimePublisher{broker: broker}
+}
+
+func (p *realtimePublisher) Publish(ctx context.Context, userID string, event domain.RealtimeEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	return p.broker.Publish(ctx, userID, realtime.Event{
+		Type:       event.Type,
+		Data:       event.Data,
+		OccurredAt: event.OccurredAt,
+	})
+}
+
+// publishRealtime publishes when a publisher is configured; a failed push only
+// costs the live update, so it is logged and never fails the caller
+func publishRealtime(ctx context.Context, events domain.RealtimePublisher, userID string, event domain.RealtimeEvent) {
+	if events == nil || userID == "" {
+		return
+	}
+	if err := events.Publish(ctx, userID, event); err != nil {
+		logger.FromContext(ctx).Warn("Failed to publish realtime event", "type", event.Type, "user_id", userID, "error", err)
+	}
+}
@@ -0,0 +1,441 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// last_active_at is written at most this often per user
+	activityWriteInterval = 15 * time.Minute
+
+	maxSuggestedJobs          = 3
+	maxReengagementReportDays = 366
+)
+
+// ReengagementConfig tunes the re-engagement worker
+type ReengagementConfig struct {
+	InactiveDays    int    // candidates idle at least this long are nudged
+	StalledDays     int    // applications without a status change this long count as stalled
+	CooldownDays    int    // frequency cap: at most one nudge per candidate per period
+	MaxPerRun       int    // candidates evaluated per run
+	AttributionDays int    // window for counting a return/conversion after a nudge
+	FrontendURL     string // base for links in messages
+}
+
+type reengagementUsecase struct {
+	repo     domain.ReengagementRepository
+	notifier domain.CandidateNotifier
+	cfg      ReengagementConfig
+	now      func() time.Time
+
+	running      sync.Mutex
+	lastActivity sync.Map // userID → time.Time of the last activity write
+}
+
+func NewReengagementUsecase(repo domain.ReengagementRepository, notifier domain.CandidateNotifier, cfg ReengagementConfig) domain.ReengagementUsecase {
+	if notifier == nil {
+		notifier = logCandidateNotifier{}
+	}
+	if cfg.InactiveDays <= 0 {
+		cfg.InactiveDays = 14
+	}
+	if cfg.StalledDays <= 0 {
+		cfg.StalledDays = 21
+	}
+	if cfg.CooldownDays <= 0 {
+		cfg.CooldownDays = 30
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = 200
+	}
+	if cfg.AttributionDays <= 0 {
+		cfg.AttributionDays = 7
+	}
+	return &reengagementUsecase{repo: repo, notifier: notifier, cfg: cfg, now: time.Now}
+}
+
+func (u *reengagementUsecase) RunCampaigns(ctx context.Context) (*domain.ReengagementRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A re-engagement run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	result := &domain.ReengagementRunResult{StartedAt: now}
+
+	// 1. Eligible candidates (inactive, not paused, not opted out, outside the cap)
+	candidates, err := u.repo.ListCandidates(ctx, domain.ReengagementCriteria{
+		InactiveBefore: now.AddDate(0, 0, -u.cfg.InactiveDays),
+		StalledBefore:  now.AddDate(0, 0, -u.cfg.StalledDays),
+		CooldownSince:  now.AddDate(0, 0, -u.cfg.CooldownDays),
+		Limit:          u.cfg.MaxPerRun,
+	})
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch re-engagement candidates: " + err.Error()))
+	}
+
+	// 2. Personalize, deliver and record each nudge
+	for i := range candidates {
+		c := &candidates[i]
+		result.Evaluated++
+
+		nudge, notification, err := u.buildNudge(ctx, c, now)
+		if err != nil {
+			return nil, apperror.Internal(errors.New("Failed to build re-engagement nudge: " + err.Error()))
+		}
+		if nudge == nil {
+			result.Skipped++
+			continue
+		}
+
+		if err := u.notifier.NotifyCandidate(ctx, notification); err != nil {
+			msg := err.Error()
+			nudge.Status = domain.NudgeStatusFailed
+			nudge.ErrorMessage = &msg
+			result.Failed++
+		} else {
+			result.Sent++
+		}
+
+		if err := u.repo.CreateNudge(ctx, nudge); err != nil {
+			logger.FromContext(ctx).Error("Re-engagement: failed to record nudge", "user_id", c.UserID, "error", err)
+		}
+	}
+
+	// 3. Drop stale activity throttle entries so the map stays bounded
+	u.lastActivity.Range(func(key, value any) bool {
+		if now.Sub(value.(time.Time)) > activityWriteInterval {
+			u.lastActivity.Delete(key)
+		}
+		return true
+	})
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+func (u *reengagementUsecase) TriggerRun(ctx context.Context) (*domain.ReengagementRunResult, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunCampaigns(ctx)
+}
+
+func (u *reengagementUsecase) RecordActivity(ctx context.Context, userID string) {
+	if userID == "" {
+		return
+	}
+	now := u.now().UTC()
+	if last, ok := u.lastActivity.Load(userID); ok && now.Sub(last.(time.Time)) < activityWriteInterval {
+		return
+	}
+	u.lastActivity.Store(userID, now)
+
+	// Written off the request path; a lost update only delays the next one
+	l := logger.FromContext(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		returnSince := now.AddDate(0, 0, -u.cfg.AttributionDays)
+		if err := u.repo.TouchActivity(ctx, userID, now, returnSince); err != nil {
+			l.Warn("Re-engagement: failed to record activity", "user_id", userID, "error", err)
+		}
+	}()
+}
+
+func (u *reengagementUsecase) GetPreference(ctx context.Context, userID string) (*domain.ReengagementPreference, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	optOutAt, err := u.repo.GetOptOut(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch re-engagement preference: " + err.Error()))
+	}
+	return &domain.ReengagementPreference{OptedOut: optOutAt != nil, OptedOutAt: optOutAt}, nil
+}
+
+func (u *reengagementUsecase) UpdatePreference(ctx context.Context, userID string, optOut bool) (*domain.ReengagementPreference, error) {
+	if err := requireSelfCandidate(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var at *time.Time
+	if optOut {
+		now := u.now().UTC()
+		at = &now
+	}
+	if err := u.repo.SetOptOut(ctx, userID, at); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("User not found").WithCode(domain.ErrCodeUserNotFound)
+		}
+		return nil, apperror.Internal(errors.New("Failed to update re-engagement preference: " + err.Error()))
+	}
+	return &domain.ReengagementPreference{OptedOut: optOut, OptedOutAt: at}, nil
+}
+
+func (u *reengagementUsecase) GetReport(ctx context.Context, from, to string) (*domain.ReengagementReport, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	// 1. Resolve range (defaults to the last 30 days including today)
+	now := u.now().UTC()
+	fromDay, toDay, err := parseReengagementRange(from, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Per-campaign counts
+	rows, err := u.repo.GetCampaignStats(ctx, fromDay, toDay.AddDate(0, 0, 1), u.cfg.AttributionDays)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch re-engagement stats: " + err.Error()))
+	}
+	optedOut, err := u.repo.CountOptedOut(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count opted-out candidates: " + err.Error()))
+	}
+
+	// 3. Every campaign appears, even with no nudges in range
+	byCampaign := make(map[string]domain.ReengagementCampaignStats, len(rows))
+	for _, row := range rows {
+		byCampaign[row.Campaign] = row
+	}
+	report := &domain.ReengagementReport{
+		From:            fromDay.Format(domain.HolidayDateFormat),
+		To:              toDay.Format(domain.HolidayDateFormat),
+		AttributionDays: u.cfg.AttributionDays,
+		Totals:          domain.ReengagementCampaignStats{Campaign: "ALL"},
+		OptedOut:        optedOut,
+		GeneratedAt:     now,
+	}
+	for _, campaign := range domain.ReengagementCampaigns {
+		stats := byCampaign[campaign]
+		stats.Campaign = campaign
+		setReengagementRates(&stats)
+		report.Campaigns = append(report.Campaigns, stats)
+
+		report.Totals.Sent += stats.Sent
+		report.Totals.Failed += stats.Failed
+		report.Totals.Returned += stats.Returned
+		report.Totals.Converted += stats.Converted
+	}
+	setReengagementRates(&report.Totals)
+	return report, nil
+}
+
+// buildNudge picks the campaign for a candidate and renders the message.
+// Returns nil when there is nothing worth telling them.
+func (u *reengagementUsecase) buildNudge(ctx context.Context, c *domain.ReengagementCandidate, now time.Time) (*domain.ReengagementNudge, *domain.CandidateNotification, error) {
+	var campaign string
+	var missing []string
+	switch {
+	case c.VerificationStatus == nil ||
+		*c.VerificationStatus == domain.VerificationStatusPending ||
+		*c.VerificationStatus == domain.VerificationStatusRejected:
+		campaign = domain.CampaignIncompleteProfile
+		missing = reengagementMissingSteps(c)
+	case c.StalledApplications > 0:
+		campaign = domain.CampaignStalledApplication
+	default:
+		return nil, nil, nil
+	}
+
+	jobs, err := u.repo.ListMatchingJobs(ctx, c.UserID, c.LastActiveAt, c.PreferredLocations, maxSuggestedJobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	// A stalled-application nudge without new jobs has nothing new to offer
+	if campaign == domain.CampaignStalledApplication && len(jobs) == 0 {
+		return nil, nil, nil
+	}
+
+	jobIDs := make([]int64, 0, len(jobs))
+	for _, job := range jobs {
+		jobIDs = append(jobIDs, job.ID)
+	}
+
+	nudge := &domain.ReengagementNudge{
+		UserID:          c.UserID,
+		Campaign:        campaign,
+		Channel:         u.notifier.Channel(),
+		MissingSteps:    missing,
+		SuggestedJobIDs: jobIDs,
+		Status:          domain.NudgeStatusSent,
+		SentAt:          now,
+	}
+	return nudge, u.renderNudge(c, campaign, missing, jobs), nil
+}
+
+func (u *reengagementUsecase) renderNudge(c *domain.ReengagementCandidate, campaign string, missing []string, jobs []domain.Job) *domain.CandidateNotification {
+	locale := i18n.LocaleID // candidates are Indonesian unless they chose otherwise
+	if c.PreferredLocale != nil && i18n.IsSupported(*c.PreferredLocale) {
+		locale = *c.PreferredLocale
+	}
+	t := func(msg string) string { return i18n.T(locale, msg) }
+
+	var b strings.Builder
+	if c.FirstName != nil && strings.TrimSpace(*c.FirstName) != "" {
+		b.WriteString(fmt.Sprintf(t("Hello %s,"), strings.TrimSpace(*c.FirstName)))
+	} else {
+		b.WriteString(t("Hello,"))
+	}
+	b.WriteString("\n\n")
+
+	subject := t("Your J Expert profile is almost ready")
+	link := u.cfg.FrontendURL + "/candidate/profile"
+	if campaign == domain.CampaignIncompleteProfile {
+		b.WriteString(t("Your profile still needs a few steps before companies can see it:"))
+		b.WriteString("\n")
+		for _, step := range missing {
+			b.WriteString("- " + t(reengagementStepLabels[step]) + "\n")
+		}
+	} else {
+		subject = t("New jobs for you on J Expert")
+		link = u.cfg.FrontendURL + "/candidate/applications"
+		b.WriteString(fmt.Sprintf(t("You have %d application(s) still waiting for a response."), c.StalledApplications))
+		b.WriteString("\n")
+	}
+
+	if len(jobs) > 0 {
+		b.WriteString("\n" + t("New jobs that may interest you:") + "\n")
+		for _, job := range jobs {
+			b.WriteString(fmt.Sprintf("- %s (%s): %s/jobs/%d\n", job.Title, job.Location, u.cfg.FrontendURL, job.ID))
+		}
+	}
+
+	b.WriteString("\n" + fmt.Sprintf(t("Continue here: %s"), link) + "\n\n")
+	b.WriteString(t("You can turn off these reminders in your account settings."))
+
+	return &domain.CandidateNotification{
+		UserID:   c.UserID,
+		Email:    c.Email,
+		Locale:   locale,
+		Campaign: campaign,
+		Subject:  subject,
+		Body:     b.String(),
+	}
+}
+
+// reengagementStepLabels are i18n source messages for missing profile steps
+var reengagementStepLabels = map[string]string{
+	domain.StepBasicInfo:       "Complete your name",
+	domain.StepProfilePicture:  "Upload a profile picture",
+	domain.StepPhone:           "Add your phone number",
+	domain.StepPhoneVerified:   "Verify your phone number",
+	domain.StepCV:              "Upload your CV",
+	domain.StepJapaneseLevel:   "Set your Japanese level (JLPT)",
+	domain.StepDomicileCity:    "Add your domicile city",
+	domain.StepOnboarding:      "Finish the onboarding wizard",
+	domain.StepSubmitForReview: "Submit your profile for verification",
+}
+
+// reengagementMissingSteps lists what stands between the candidate and a
+// submitted verification, in the order the profile form asks for it
+func reengagementMissingSteps(c *domain.ReengagementCandidate) []string {
+	blank := func(s *string) bool { return s == nil || strings.TrimSpace(*s) == "" }
+
+	var steps []string
+	if blank(c.FirstName) || blank(c.LastName) {
+		steps = append(steps, domain.StepBasicInfo)
+	}
+	if blank(c.ProfilePictureURL) {
+		steps = append(steps, domain.StepProfilePicture)
+	}
+	if blank(c.Phone) {
+		steps = append(steps, domain.StepPhone)
+	} else if !c.PhoneVerified {
+		steps = append(steps, domain.StepPhoneVerified)
+	}
+	if blank(c.CvURL) {
+		steps = append(steps, domain.StepCV)
+	}
+	if blank(c.JapaneseLevel) {
+		steps = append(steps, domain.StepJapaneseLevel)
+	}
+	if blank(c.DomicileCity) {
+		steps = append(steps, domain.StepDomicileCity)
+	}
+	if !c.OnboardingCompleted {
+		steps = append(steps, domain.StepOnboarding)
+	}
+	return append(steps, domain.StepSubmitForReview)
+}
+
+func setReengagementRates(s *domain.ReengagementCampaignStats) {
+	if s.Sent == 0 {
+		return
+	}
+	s.ReturnRate = float64(s.Returned) / float64(s.Sent)
+	s.ConversionRate = float64(s.Converted) / float64(s.Sent)
+}
+
+func parseReengagementRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	toDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		t, err := time.Parse(domain.HolidayDateFormat, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperror.BadRequest("Invalid 'to' date, expected YYYY-MM-DD")
+		}
+		toDay = t
+	}
+
+	fromDay := toDay.AddDate(0, 0, -29)
+	if from != "" {
+		f, err := time.Parse(domain.HolidayDateFormat, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperror.BadRequest("Invalid 'from' date, expected YYYY-MM-DD")
+		}
+		fromDay = f
+	}
+
+	if fromDay.After(toDay) {
+		return time.Time{}, time.Time{}, apperror.BadRequest("'from' must not be after 'to'")
+	}
+	if toDay.Sub(fromDay) >= maxReengagementReportDays*24*time.Hour {
+		return time.Time{}, time.Time{}, apperror.BadRequest(fmt.Sprintf("Range cannot exceed %d days", maxReengagementReportDays))
+	}
+	return fromDay, toDay, nil
+}
+
+// emailCandidateNotifier delivers candidate notifications over SMTP
+type emailCandidateNotifier struct {
+	emailService *email.EmailService
+}
+
+// NewEmailCandidateNotifier sends candidate notifications as plain-text email
+func NewEmailCandidateNotifier(emailService *email.EmailService) domain.CandidateNotifier {
+	return emailCandidateNotifier{emailService: emailService}
+}
+
+func (emailCandidateNotifier) Channel() string { return "EMAIL" }
+
+func (n emailCandidateNotifier) NotifyCandidate(ctx context.Context, msg *domain.CandidateNotification) error {
+	if msg.Email == "" {
+		return errors.New("candidate has no email address")
+	}
+	return n.emailService.SendTextEmail(ctx, msg.Email, msg.Subject, msg.Body)
+}
+
+// logCandidateNotifier is the default notifier until a delivery channel is configured
+type logCandidateNotifier struct{}
+
+func (logCandidateNotifier) Channel() string { return "LOG" }
+
+func (logCandidateNotifier) NotifyCandidate(ctx context.Context, msg *domain.CandidateNotification) error {
+	logger.FromContext(ctx).Info("Candidate notification", "user_id", msg.UserID, "campaign", msg.Campaign, "locale", msg.Locale, "subject", msg.Subject)
+	return nil
+}
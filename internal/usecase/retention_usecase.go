@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"slices"
+	"sync"
+	"time"
+)
+
+// retentionRunListLimit is how many past runs the admin list returns
+const retentionRunListLimit = 30
+
+type retentionUsecase struct {
+	repo      domain.RetentionRepository
+	batchSize int
+	running   sync.Mutex
+	now       func() time.Time
+}
+
+// NewRetentionUsecase creates the retention policy engine; enforcement changes at
+// most batchSize records per statement
+func NewRetentionUsecase(repo domain.RetentionRepository, batchSize int) domain.RetentionUsecase {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &retentionUsecase{repo: repo, batchSize: batchSize, now: time.Now}
+}
+
+// RunRetention evaluates every policy against its data class and, unless it is a
+// dry run, anonymizes or deletes the expired records of the enabled ones
+func (u *retentionUsecase) RunRetention(ctx context.Context, triggerSource string, dryRun bool, startedBy *string) (*domain.RetentionRun, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A retention run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	policies, err := u.repo.ListPolicies(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch retention policies: " + err.Error()))
+	}
+
+	// 1. Open the run so a crash still leaves a trace
+	run := &domain.RetentionRun{
+		TriggerSource: triggerSource,
+		DryRun:        dryRun,
+		Status:        domain.RetentionRunRunning,
+		Results:       []domain.RetentionEntityResult{},
+		StartedBy:     startedBy,
+	}
+	if err := u.repo.CreateRun(ctx, run); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to start retention run: " + err.Error()))
+	}
+
+	// 2. Each policy is applied on its own; one failing does not stop the others
+	var failed []string
+	for _, policy := range policies {
+		result := u.applyPolicy(ctx, policy, dryRun)
+		if result.Error != nil {
+			failed = append(failed, policy.Entity)
+		}
+		run.Results = append(run.Results, result)
+	}
+	run.Status = domain.RetentionRunCompleted
+	if len(failed) > 0 {
+		msg := fmt.Sprintf("Retention failed for: %v", failed)
+		run.Status = domain.RetentionRunFailed
+		run.ErrorMessage = &msg
+	}
+
+	// 3. Persist the outcome; the context may be cancelled, so use a fresh one
+	if err := u.repo.FinishRun(context.WithoutCancel(ctx), run); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save retention run: " + err.Error()))
+	}
+	if run.ErrorMessage != nil {
+		return run, apperror.Internal(errors.New(*run.ErrorMessage))
+	}
+	return run, nil
+}
+
+func (u *retentionUsecase) applyPolicy(ctx context.Context, policy domain.RetentionPolicy, dryRun bool) domain.RetentionEntityResult {
+	result := domain.RetentionEntityResult{
+		Entity:        policy.Entity,
+		Action:        policy.Action,
+		RetentionDays: policy.RetentionDays,
+		Cutoff:        u.now().UTC().AddDate(0, 0, -policy.RetentionDays),
+		Enabled:       policy.Enabled,
+	}
+	fail := func(step string, err error) domain.RetentionEntityResult {
+		msg := step + ": " + err.Error()
+		result.Error = &msg
+		logger.FromContext(ctx).Error("Retention policy failed", "entity", policy.Entity, "step", step, "error", err)
+		return result
+	}
+
+	eligible, oldest, err := u.repo.CountExpired(ctx, policy.Entity, policy.Action, result.Cutoff)
+	if err != nil {
+		return fail("count", err)
+	}
+	result.Eligible = eligible
+	result.OldestRecordAt = oldest
+
+	if dryRun || !policy.Enabled || eligible == 0 {
+		return result
+	}
+
+	// Work in batches so a large backlog does not hold long locks
+	for {
+		n, err := u.repo.EnforceBatch(ctx, policy.Entity, policy.Action, result.Cutoff, u.batchSize)
+		result.Processed += n
+		if err != nil {
+			return fail("enforce", err)
+		}
+		if n < u.batchSize {
+			break
+		}
+	}
+
+	logger.FromContext(ctx).Info("Retention policy enforced",
+		"entity", policy.Entity, "action", policy.Action, "processed", result.Processed)
+	return result
+}
+
+func (u *retentionUsecase) ListPolicies(ctx context.Context) ([]domain.RetentionPolicy, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	policies, err := u.repo.ListPolicies(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch retention policies: " + err.Error()))
+	}
+	return policies, nil
+}
+
+func (u *retentionUsecase) UpdatePolicy(ctx context.Context, adminID, entity string, req domain.UpdateRetentionPolicyRequest) (*domain.RetentionPolicy, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	supported, ok := domain.RetentionEntityActions[entity]
+	if !ok {
+		return nil, apperror.NotFound("Retention policy not found")
+	}
+	if !slices.Contains(supported, req.Action) {
+		return nil, apperror.BadRequest(fmt.Sprintf("%s supports only: %v", entity, supported))
+	}
+
+	policy := &domain.RetentionPolicy{
+		Entity:           entity,
+		RetentionDays:    req.RetentionDays,
+		Action:           req.Action,
+		Enabled:          *req.Enabled,
+		SupportedActions: supported,
+		UpdatedBy:        &adminID,
+	}
+	if err := u.repo.UpdatePolicy(ctx, policy); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Retention policy not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update retention policy: " + err.Error()))
+	}
+
+	logger.FromContext(ctx).Info("Retention policy updated",
+		"entity", entity, "retention_days", policy.RetentionDays, "action", policy.Action, "enabled", policy.Enabled, "admin_id", adminID)
+	return policy, nil
+}
+
+func (u *retentionUsecase) DryRun(ctx context.Context, adminID string) (*domain.RetentionRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunRetention(ctx, domain.RetentionTriggerManual, true, &adminID)
+}
+
+func (u *retentionUsecase) Enforce(ctx context.Context, adminID string) (*domain.RetentionRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	// The admin must have seen a report of the current policies
+	latest, err := u.repo.LatestDryRun(ctx)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.BadRequest("Run a dry run first")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch latest dry run: " + err.Error()))
+	}
+	if u.now().Sub(latest.StartedAt) > domain.RetentionDryRunMaxAge {
+		return nil, apperror.BadRequest("The latest dry run is too old; run a dry run first")
+	}
+	policies, err := u.repo.ListPolicies(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch retention policies: " + err.Error()))
+	}
+	for _, policy := range policies {
+		if policy.UpdatedAt.After(latest.StartedAt) {
+			return nil, apperror.BadRequest("Retention policies changed since the latest dry run; run a dry run first")
+		}
+	}
+
+	return u.RunRetention(ctx, domain.RetentionTriggerManual, false, &adminID)
+}
+
+func (u *retentionUsecase) ListRuns(ctx context.Context) ([]domain.RetentionRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	runs, err := u.repo.ListRuns(ctx, retentionRunListLimit)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch retention runs: " + err.Error()))
+	}
+	return runs, nil
+}
+
+func (u *retentionUsecase) GetRun(ctx context.Context, id int64) (*domain.RetentionRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	run, err := u.repo.GetRun(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Retention run not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch retention run: " + err.Error()))
+	}
+	return run, nil
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/logger"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SavedSearchConfig tunes the saved search alert worker
+type SavedSearchConfig struct {
+	MaxPerRun   int    // saved searches handled per run
+	FrontendURL string // base for links in messages
+}
+
+type savedSearchUsecase struct {
+	repo     domain.SavedSearchRepository
+	notifier domain.CandidateNotifier
+	events   domain.RealtimePublisher
+	cfg      SavedSearchConfig
+	now      func() time.Time
+
+	running sync.Mutex
+}
+
+func NewSavedSearchUsecase(repo domain.SavedSearchRepository, notifier domain.CandidateNotifier, events domain.RealtimePublisher, cfg SavedSearchConfig) domain.SavedSearchUsecase {
+	if notifier == nil {
+		notifier = logCandidateNotifier{}
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = 1000
+	}
+	return &savedSearchUsecase{repo: repo, notifier: notifier, events: events, cfg: cfg, now: time.Now}
+}
+
+func (u *savedSearchUsecase) CreateSavedSearch(ctx context.Context, userID string, req domain.SavedSearchRequest) (*domain.SavedSearch, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	// 1. Enforce the per-candidate limit
+	count, err := u.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to count saved searches: " + err.Error()))
+	}
+	if count >= domain.MaxSavedSearchesPerUser {
+		return nil, apperror.Conflict(fmt.Sprintf("You can keep at most %d saved searches", domain.MaxSavedSearchesPerUser))
+	}
+
+	// 2. Store it; alerts only cover jobs posted from now on
+	search := &domain.SavedSearch{UserID: userID}
+	if err := applySavedSearchRequest(search, req); err != nil {
+		return nil, err
+	}
+	if err := u.repo.Create(ctx, search); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create saved search: " + err.Error()))
+	}
+	return search, nil
+}
+
+func (u *savedSearchUsecase) ListMySavedSearches(ctx context.Context, userID string) ([]domain.SavedSearch, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	searches, err := u.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch saved searches: " + err.Error()))
+	}
+	return searches, nil
+}
+
+func (u *savedSearchUsecase) UpdateSavedSearch(ctx context.Context, userID string, id int64, req domain.SavedSearchRequest) (*domain.SavedSearch, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	search := &domain.SavedSearch{ID: id, UserID: userID}
+	if err := applySavedSearchRequest(search, req); err != nil {
+		return nil, err
+	}
+	if err := u.repo.Update(ctx, search); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Saved search not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update saved search: " + err.Error()))
+	}
+	return search, nil
+}
+
+func (u *savedSearchUsecase) DeleteSavedSearch(ctx context.Context, userID string, id int64) error {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return err
+	}
+
+	if err := u.repo.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Saved search not found")
+		}
+		return apperror.Internal(errors.New("Failed to delete saved search: " + err.Error()))
+	}
+	return nil
+}
+
+// applySavedSearchRequest copies the request onto search with defaults applied
+func applySavedSearchRequest(search *domain.SavedSearch, req domain.SavedSearchRequest) error {
+	search.Name = strings.TrimSpace(req.Name)
+	if search.Name == "" {
+		return apperror.BadRequest("Name is required")
+	}
+	search.Filter = req.Filter
+	search.Filter.Keywords = strings.TrimSpace(search.Filter.Keywords)
+
+	search.AlertsEnabled = req.AlertsEnabled == nil || *req.AlertsEnabled
+	search.AlertFrequency = req.AlertFrequency
+	if search.AlertFrequency == "" {
+		search.AlertFrequency = domain.SavedSearchFrequencyDaily
+	}
+	if _, ok := domain.SavedSearchFrequencyInterval[search.AlertFrequency]; !ok {
+		return apperror.BadRequest("alert_frequency must be DAILY or WEEKLY")
+	}
+	return nil
+}
+
+// savedSearchMatch is one search with the new jobs found for it
+type savedSearchMatch struct {
+	search domain.SavedSearchAlertCandidate
+	jobs   []domain.JobCard
+	total  int
+}
+
+func (u *savedSearchUsecase) RunAlerts(ctx context.Context) (*domain.SavedSearchRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A saved search alert run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	result := &domain.SavedSearchRunResult{StartedAt: now}
+
+	// 1. Alert-enabled searches whose frequency interval has passed
+	due, err := u.repo.ListDueAlerts(ctx, now, u.cfg.MaxPerRun)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch due saved searches: " + err.Error()))
+	}
+	result.Searches = len(due)
+
+	// 2. One message per candidate covering all of their searches with new jobs (rows are ordered by user)
+	for start := 0; start < len(due); {
+		end := start + 1
+		for end < len(due) && due[end].UserID == due[start].UserID {
+			end++
+		}
+		searches := due[start:end]
+		start = end
+
+		var matches []savedSearchMatch
+		for _, s := range searches {
+			jobs, total, err := u.repo.FindNewJobs(ctx, s.Filter, s.AlertedUntil, now, domain.MaxSavedSearchAlertJobs)
+			if err != nil {
+				logger.FromContext(ctx).Error("Saved search: failed to find new jobs", "saved_search_id", s.ID, "error", err)
+				continue
+			}
+			if total == 0 {
+				// Nothing new: move the watermark so the next run does not look at the same window again
+				u.markAlerted(ctx, s.ID, now)
+				continue
+			}
+			matches = append(matches, savedSearchMatch{search: s, jobs: jobs, total: total})
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		result.Matched += len(matches)
+		result.Candidates++
+
+		status, errMsg := domain.SavedSearchAlertStatusSent, (*string)(nil)
+		if err := u.notifier.NotifyCandidate(ctx, u.renderAlert(matches)); err != nil {
+			msg := err.Error()
+			status, errMsg = domain.SavedSearchAlertStatusFailed, &msg
+			result.Failed++
+		} else {
+			result.Sent++
+		}
+
+		// 3. Record per search; the watermark only moves after a successful send, so failures are retried next run
+		for _, m := range matches {
+			if err := u.repo.RecordAlert(ctx, &domain.SavedSearchAlert{
+				SavedSearchID: m.search.ID,
+				UserID:        m.search.UserID,
+				JobCount:      m.total,
+				Channel:       u.notifier.Channel(),
+				Status:        status,
+				ErrorMessage:  errMsg,
+			}); err != nil {
+				logger.FromContext(ctx).Error("Saved search: failed to record alert", "saved_search_id", m.search.ID, "error", err)
+			}
+			if status != domain.SavedSearchAlertStatusSent {
+				continue
+			}
+			u.markAlerted(ctx, m.search.ID, now)
+			for _, job := range m.jobs {
+				publishRealtime(ctx, u.events, m.search.UserID, domain.RealtimeEvent{
+					Type: domain.RealtimeEventJobMatch,
+					Data: domain.JobMatchEvent{JobID: job.ID, Title: job.Title, SavedSearchID: m.search.ID},
+				})
+			}
+		}
+	}
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+func (u *savedSearchUsecase) markAlerted(ctx context.Context, id int64, until time.Time) {
+	if err := u.repo.MarkAlerted(ctx, id, until); err != nil {
+		logger.FromContext(ctx).Error("Saved search: failed to move alert watermark", "saved_search_id", id, "error", err)
+	}
+}
+
+func (u *savedSearchUsecase) TriggerAlerts(ctx context.Context) (*domain.SavedSearchRunResult, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunAlerts(ctx)
+}
+
+func (u *savedSearchUsecase) renderAlert(matches []savedSearchMatch) *domain.CandidateNotification {
+	first := matches[0].search
+	locale := i18n.LocaleID // candidates are Indonesian unless they chose otherwise
+	if first.PreferredLocale != nil && i18n.IsSupported(*first.PreferredLocale) {
+		locale = *first.PreferredLocale
+	}
+	t := func(msg string) string { return i18n.T(locale, msg) }
+
+	var b strings.Builder
+	if first.FirstName != nil && strings.TrimSpace(*first.FirstName) != "" {
+		b.WriteString(fmt.Sprintf(t("Hello %s,"), strings.TrimSpace(*first.FirstName)))
+	} else {
+		b.WriteString(t("Hello,"))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(t("New jobs were posted that match your saved searches."))
+	b.WriteString("\n")
+
+	for _, m := range matches {
+		b.WriteString("\n" + fmt.Sprintf(t("%s (%d new)"), m.search.Name, m.total) + "\n")
+		for _, job := range m.jobs {
+			b.WriteString(fmt.Sprintf("- %s, %s (%s): %s/jobs/%d\n", job.Title, job.CompanyName, job.Location, u.cfg.FrontendURL, job.ID))
+		}
+		if more := m.total - len(m.jobs); more > 0 {
+			b.WriteString(fmt.Sprintf(t("...and %d more"), more) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + fmt.Sprintf(t("Manage your saved searches and alerts here: %s"), u.cfg.FrontendURL+"/candidate/saved-searches") + "\n")
+
+	return &domain.CandidateNotification{
+		UserID:   first.UserID,
+		Email:    first.Email,
+		Locale:   locale,
+		Campaign: "SAVED_SEARCH_ALERT",
+		Subject:  t("New jobs matching your saved searches"),
+		Body:     b.String(),
+	}
+}
@@ -0,0 +1,618 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScreeningCallConfig tunes screening call reminders
+type ScreeningCallConfig struct {
+	ReminderLead time.Duration // how long before a call the candidate is reminded
+	MaxPerRun    int           // reminders sent per run
+	FrontendURL  string        // base for links in messages
+}
+
+// screeningCallCallerName is who calls when an admin booked the call
+const screeningCallCallerName = "J Expert Recruitment"
+
+type screeningCallUsecase struct {
+	repo          domain.ScreeningCallRepository
+	companyRepo   domain.CompanyProfileRepository
+	notifications domain.NotificationDispatcher
+	cfg           ScreeningCallConfig
+	now           func() time.Time
+
+	running sync.Mutex
+}
+
+func NewScreeningCallUsecase(
+	repo domain.ScreeningCallRepository,
+	companyRepo domain.CompanyProfileRepository,
+	notifications domain.NotificationDispatcher,
+	cfg ScreeningCallConfig,
+) domain.ScreeningCallUsecase {
+	if cfg.ReminderLead <= 0 {
+		cfg.ReminderLead = time.Hour
+	}
+	if cfg.MaxPerRun <= 0 {
+		cfg.MaxPerRun = 500
+	}
+	return &screeningCallUsecase{repo: repo, companyRepo: companyRepo, notifications: notifications, cfg: cfg, now: time.Now}
+}
+
+// ============================================================================
+// Candidate
+// ============================================================================
+
+func (u *screeningCallUsecase) GetMyContactHours(ctx context.Context, userID string) (*domain.CandidateContactHours, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	hours, _, err := u.contactHours(ctx, userID)
+	return hours, err
+}
+
+func (u *screeningCallUsecase) UpdateMyContactHours(ctx context.Context, userID string, req domain.ContactHoursRequest) (*domain.CandidateContactHours, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone == "" {
+		timezone = domain.DefaultContactTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, apperror.BadRequest("Unknown timezone: " + timezone)
+	}
+	windows, err := normalizeContactWindows(req.Windows)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := &domain.CandidateContactHours{UserID: userID, Timezone: timezone, Windows: windows}
+	if err := u.repo.UpsertContactHours(ctx, hours); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to save contact hours: " + err.Error()))
+	}
+	return hours, nil
+}
+
+func (u *screeningCallUsecase) ListMyCalls(ctx context.Context, userID string) ([]domain.ScreeningCall, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	calls, err := u.repo.ListByCandidate(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch screening calls: " + err.Error()))
+	}
+	return calls, nil
+}
+
+func (u *screeningCallUsecase) CancelMyCall(ctx context.Context, userID string, id int64, req domain.CancelScreeningCallRequest) (*domain.ScreeningCall, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	call, err := u.getCall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if call.CandidateUserID != userID {
+		return nil, apperror.NotFound("Screening call not found")
+	}
+	if err := u.cancel(ctx, call, req); err != nil {
+		return nil, err
+	}
+
+	// Let whoever booked it know the slot is free again
+	if call.BookedBy != nil {
+		u.notifyBooker(ctx, call)
+	}
+	return call, nil
+}
+
+// ============================================================================
+// Admins and employers
+// ============================================================================
+
+// screeningCallCaller is who books: an admin, or an employer acting for their company
+type screeningCallCaller struct {
+	company *domain.CompanyProfile // nil for admins
+}
+
+func (u *screeningCallUsecase) caller(ctx context.Context, userID string) (*screeningCallCaller, error) {
+	if requireRole(ctx, domain.RoleAdmin) == nil {
+		return &screeningCallCaller{}, nil
+	}
+	if err := requireRole(ctx, domain.RoleEmployer); err != nil {
+		return nil, err
+	}
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Company profile not found").WithCode(domain.ErrCodeCompanyProfileNotFound)
+		}
+		return nil, apperror.Internal(err)
+	}
+	return &screeningCallCaller{company: company}, nil
+}
+
+// bookingTarget resolves the candidate to call. Employers may only call their
+// own applicants; admins may call any candidate.
+func (u *screeningCallUsecase) bookingTarget(ctx context.Context, caller *screeningCallCaller, candidateUserID string, applicationID int64) (string, *domain.ScreeningCallTarget, error) {
+	if applicationID > 0 {
+		target, err := u.repo.GetTarget(ctx, applicationID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return "", nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
+			}
+			return "", nil, apperror.Internal(errors.New("Failed to fetch application: " + err.Error()))
+		}
+		if caller.company != nil && target.CompanyID != caller.company.ID {
+			return "", nil, apperror.NotFound("Application not found").WithCode(domain.ErrCodeApplicationNotFound)
+		}
+		if candidateUserID != "" && candidateUserID != target.CandidateUserID {
+			return "", nil, apperror.BadRequest("candidate_user_id does not match the application")
+		}
+		return target.CandidateUserID, target, nil
+	}
+
+	if caller.company != nil {
+		return "", nil, apperror.BadRequest("application_id is required")
+	}
+	if candidateUserID == "" {
+		return "", nil, apperror.BadRequest("candidate_user_id or application_id is required")
+	}
+	ok, err := u.repo.IsCandidate(ctx, candidateUserID)
+	if err != nil {
+		return "", nil, apperror.Internal(errors.New("Failed to fetch candidate: " + err.Error()))
+	}
+	if !ok {
+		return "", nil, apperror.NotFound("Candidate not found").WithCode(domain.ErrCodeCandidateNotFound)
+	}
+	return candidateUserID, nil, nil
+}
+
+func (u *screeningCallUsecase) GetAvailability(ctx context.Context, userID, candidateUserID string, applicationID int64) (*domain.ScreeningCallAvailability, error) {
+	caller, err := u.caller(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	candidateID, _, err := u.bookingTarget(ctx, caller, candidateUserID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	hours, loc, err := u.contactHours(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := u.now().UTC().Add(domain.ScreeningCallMinLeadMinutes * time.Minute)
+	to := from.AddDate(0, 0, domain.ScreeningCallSlotSearchDays)
+	booked, err := u.repo.ListConfirmedBetween(ctx, candidateID, from, to)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch screening calls: " + err.Error()))
+	}
+
+	return &domain.ScreeningCallAvailability{
+		CandidateUserID: candidateID,
+		ContactHours:    *hours,
+		Slots:           openContactSlots(hours.Windows, loc, from, to, booked),
+	}, nil
+}
+
+func (u *screeningCallUsecase) BookCall(ctx context.Context, userID string, req domain.BookScreeningCallRequest) (*domain.ScreeningCall, error) {
+	caller, err := u.caller(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var applicationID int64
+	if req.ApplicationID != nil {
+		applicationID = *req.ApplicationID
+	}
+	candidateID, target, err := u.bookingTarget(ctx, caller, req.CandidateUserID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. The slot must be ahead, within the booking horizon and the candidate's contact hours
+	duration := req.DurationMinutes
+	if duration == 0 {
+		duration = domain.DefaultScreeningCallMinutes
+	}
+	now := u.now().UTC()
+	scheduledAt := req.ScheduledAt.UTC().Truncate(time.Minute)
+	if scheduledAt.Before(now.Add(domain.ScreeningCallMinLeadMinutes * time.Minute)) {
+		return nil, apperror.BadRequest("Calls must be booked at least 15 minutes ahead")
+	}
+	if scheduledAt.After(now.AddDate(0, 0, domain.ScreeningCallMaxDaysAhead)) {
+		return nil, apperror.BadRequest("Calls can be booked at most 60 days ahead")
+	}
+	hours, loc, err := u.contactHours(ctx, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	if !withinContactHours(hours.Windows, loc, scheduledAt, duration) {
+		return nil, apperror.BadRequest("The call must be within the candidate's contact hours")
+	}
+
+	// 2. Book it; the candidate's feed gets an entry in the same transaction
+	call := &domain.ScreeningCall{
+		CandidateUserID: candidateID,
+		ScheduledAt:     scheduledAt,
+		DurationMinutes: duration,
+		Notes:           trimmedOrNil(req.Notes),
+		BookedBy:        &userID,
+	}
+	if target != nil {
+		call.ApplicationID = &target.ApplicationID
+		call.JobTitle = &target.JobTitle
+	}
+	if caller.company != nil {
+		call.CompanyID = &caller.company.ID
+		call.CompanyName = &caller.company.CompanyName
+	}
+	if err := u.repo.Create(ctx, call); err != nil {
+		if errors.Is(err, domain.ErrScreeningCallOverlap) {
+			return nil, apperror.Conflict("The candidate already has a call at that time")
+		}
+		return nil, apperror.Internal(errors.New("Failed to book screening call: " + err.Error()))
+	}
+
+	// 3. Tell the candidate
+	u.notifyCandidate(ctx, call, loc, screeningCallScheduledMessages)
+	return call, nil
+}
+
+func (u *screeningCallUsecase) ListCalls(ctx context.Context, userID string, filter domain.ScreeningCallFilter) ([]domain.ScreeningCall, error) {
+	caller, err := u.caller(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	filter.CompanyID = 0
+	if caller.company != nil {
+		filter.CompanyID = caller.company.ID
+	}
+	if filter.Status != "" && !slices.Contains(screeningCallStatuses, filter.Status) {
+		return nil, apperror.BadRequest("Invalid status: " + filter.Status)
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 50
+	}
+
+	calls, err := u.repo.List(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch screening calls: " + err.Error()))
+	}
+	return calls, nil
+}
+
+func (u *screeningCallUsecase) CancelCall(ctx context.Context, userID string, id int64, req domain.CancelScreeningCallRequest) (*domain.ScreeningCall, error) {
+	call, err := u.callerCall(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.cancel(ctx, call, req); err != nil {
+		return nil, err
+	}
+
+	_, loc, err := u.contactHours(ctx, call.CandidateUserID)
+	if err != nil {
+		loc = time.UTC
+	}
+	u.notifyCandidate(ctx, call, loc, screeningCallCancelledMessages)
+	return call, nil
+}
+
+func (u *screeningCallUsecase) RecordOutcome(ctx context.Context, userID string, id int64, req domain.ScreeningCallOutcomeRequest) (*domain.ScreeningCall, error) {
+	call, err := u.callerCall(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if call.ScheduledAt.After(u.now()) {
+		return nil, apperror.BadRequest("The call has not started yet")
+	}
+
+	call.Status = req.Status
+	if err := u.repo.SetOutcome(ctx, call); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.Conflict("Only confirmed calls can be updated")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update screening call: " + err.Error()))
+	}
+	return call, nil
+}
+
+// callerCall fetches a call the admin or employer may manage
+func (u *screeningCallUsecase) callerCall(ctx context.Context, userID string, id int64) (*domain.ScreeningCall, error) {
+	caller, err := u.caller(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	call, err := u.getCall(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if caller.company != nil && (call.CompanyID == nil || *call.CompanyID != caller.company.ID) {
+		return nil, apperror.NotFound("Screening call not found")
+	}
+	return call, nil
+}
+
+func (u *screeningCallUsecase) getCall(ctx context.Context, id int64) (*domain.ScreeningCall, error) {
+	call, err := u.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Screening call not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch screening call: " + err.Error()))
+	}
+	return call, nil
+}
+
+func (u *screeningCallUsecase) cancel(ctx context.Context, call *domain.ScreeningCall, req domain.CancelScreeningCallRequest) error {
+	call.CancelReason = trimmedOrNil(req.Reason)
+	if err := u.repo.Cancel(ctx, call); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.Conflict("Only confirmed calls can be cancelled")
+		}
+		return apperror.Internal(errors.New("Failed to cancel screening call: " + err.Error()))
+	}
+	return nil
+}
+
+// ============================================================================
+// Reminders
+// ============================================================================
+
+func (u *screeningCallUsecase) RunReminders(ctx context.Context) (*domain.ScreeningCallReminderRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A screening call reminder run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	result := &domain.ScreeningCallReminderRunResult{StartedAt: now}
+
+	due, err := u.repo.ListDueReminders(ctx, now, now.Add(u.cfg.ReminderLead), u.cfg.MaxPerRun)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch screening calls due for a reminder: " + err.Error()))
+	}
+	result.Due = len(due)
+
+	for i := range due {
+		call := &due[i]
+		_, loc, err := u.contactHours(ctx, call.CandidateUserID)
+		if err != nil {
+			loc = time.UTC
+		}
+		// Failures are not marked, so they are retried next run
+		if err := u.notifications.Dispatch(ctx, screeningCallNotification(call, loc, screeningCallReminderMessages, u.cfg.FrontendURL)); err != nil {
+			logger.FromContext(ctx).Error("Screening call: failed to send reminder", "call_id", call.ID, "error", err)
+			result.Failed++
+			continue
+		}
+		result.Sent++
+		if err := u.repo.MarkReminded(ctx, call.ID, now); err != nil {
+			logger.FromContext(ctx).Error("Screening call: failed to mark reminder", "call_id", call.ID, "error", err)
+		}
+	}
+
+	result.FinishedAt = u.now().UTC()
+	return result, nil
+}
+
+// ============================================================================
+// Contact hours
+// ============================================================================
+
+// contactHours returns the candidate's contact hours, or the defaults when
+// they have not set any, with their time zone loaded
+func (u *screeningCallUsecase) contactHours(ctx context.Context, userID string) (*domain.CandidateContactHours, *time.Location, error) {
+	hours, err := u.repo.GetContactHours(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		hours = &domain.CandidateContactHours{
+			UserID:    userID,
+			Timezone:  domain.DefaultContactTimezone,
+			Windows:   domain.DefaultContactHours,
+			IsDefault: true,
+		}
+	} else if err != nil {
+		return nil, nil, apperror.Internal(errors.New("Failed to fetch contact hours: " + err.Error()))
+	}
+	loc, err := time.LoadLocation(hours.Timezone)
+	if err != nil {
+		return nil, nil, apperror.Internal(errors.New("Failed to load timezone " + hours.Timezone + ": " + err.Error()))
+	}
+	return hours, loc, nil
+}
+
+// normalizeContactWindows validates the windows and sorts them by weekday and start
+func normalizeContactWindows(windows []domain.ContactHoursWindow) ([]domain.ContactHoursWindow, error) {
+	sorted := slices.Clone(windows)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Weekday != sorted[j].Weekday {
+			return sorted[i].Weekday < sorted[j].Weekday
+		}
+		return sorted[i].Start < sorted[j].Start
+	})
+	for i, w := range sorted {
+		start, end := clockMinutes(w.Start), clockMinutes(w.End)
+		if start < 0 || end < 0 || end <= start {
+			return nil, apperror.BadRequest("Each contact window must end after it starts (HH:MM)")
+		}
+		if i > 0 && sorted[i-1].Weekday == w.Weekday && clockMinutes(sorted[i-1].End) > start {
+			return nil, apperror.BadRequest("Contact windows on the same day must not overlap")
+		}
+	}
+	return sorted, nil
+}
+
+// withinContactHours reports whether the call fits entirely in one window
+func withinContactHours(windows []domain.ContactHoursWindow, loc *time.Location, start time.Time, minutes int) bool {
+	local := start.In(loc)
+	weekday := isoWeekday(local)
+	from := local.Hour()*60 + local.Minute()
+	to := from + minutes
+	for _, w := range windows {
+		if w.Weekday == weekday && from >= clockMinutes(w.Start) && to <= clockMinutes(w.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// openContactSlots lists the contact windows between from and to, minus booked
+// calls, keeping ranges long enough for the shortest call
+func openContactSlots(windows []domain.ContactHoursWindow, loc *time.Location, from, to time.Time, booked []domain.ScreeningCall) []domain.ScreeningCallSlot {
+	minLength := domain.DefaultScreeningCallMinutes * time.Minute
+	slots := []domain.ScreeningCallSlot{}
+
+	day := from.In(loc)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, w := range windows {
+			if w.Weekday != isoWeekday(day) {
+				continue
+			}
+			start := day.Add(time.Duration(clockMinutes(w.Start)) * time.Minute)
+			end := day.Add(time.Duration(clockMinutes(w.End)) * time.Minute)
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(to) {
+				end = to
+			}
+
+			// booked is sorted by start; cut each call out of the window
+			for _, call := range booked {
+				callEnd := call.ScheduledAt.Add(time.Duration(call.DurationMinutes) * time.Minute)
+				if !callEnd.After(start) || !call.ScheduledAt.Before(end) {
+					continue
+				}
+				if call.ScheduledAt.Sub(start) >= minLength {
+					slots = append(slots, domain.ScreeningCallSlot{Start: start.UTC(), End: call.ScheduledAt.UTC()})
+				}
+				start = callEnd
+			}
+			if end.Sub(start) >= minLength {
+				slots = append(slots, domain.ScreeningCallSlot{Start: start.UTC(), End: end.UTC()})
+			}
+		}
+	}
+	return slots
+}
+
+// clockMinutes parses HH:MM into minutes after midnight, or -1
+func clockMinutes(clock string) int {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return -1
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// isoWeekday numbers Monday 1 through Sunday 7
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+// ============================================================================
+// Notifications
+// ============================================================================
+
+var screeningCallStatuses = []string{
+	domain.ScreeningCallConfirmed, domain.ScreeningCallCancelled, domain.ScreeningCallCompleted, domain.ScreeningCallNoAnswer,
+}
+
+// screeningCallMessages are the i18n source messages of one candidate notification
+type screeningCallMessages struct {
+	subject, body, bodyWithNote string
+}
+
+var (
+	screeningCallScheduledMessages = screeningCallMessages{
+		subject:      "Screening call scheduled for %s",
+		body:         "%s will call you on %s for about %d minutes.\n\nPlease keep your phone nearby. If you cannot take the call, cancel it here: %s",
+		bodyWithNote: "%s will call you on %s for about %d minutes.\n\nPlease keep your phone nearby. If you cannot take the call, cancel it here: %s\n\nNote from the caller:\n%s",
+	}
+	screeningCallReminderMessages = screeningCallMessages{
+		subject:      "Reminder: screening call at %s",
+		body:         "%s will call you on %s for about %d minutes.\n\nPlease keep your phone nearby. If you cannot take the call, cancel it here: %s",
+		bodyWithNote: "%s will call you on %s for about %d minutes.\n\nPlease keep your phone nearby. If you cannot take the call, cancel it here: %s\n\nNote from the caller:\n%s",
+	}
+	screeningCallCancelledMessages = screeningCallMessages{
+		subject:      "Screening call on %s cancelled",
+		body:         "%s cancelled the screening call planned for %s (%d minutes).\n\nYour calls: %s",
+		bodyWithNote: "%s cancelled the screening call planned for %s (%d minutes).\n\nYour calls: %s\n\nReason:\n%s",
+	}
+)
+
+// screeningCallNotification addresses the candidate, with the time in their time zone
+func screeningCallNotification(call *domain.ScreeningCall, loc *time.Location, messages screeningCallMessages, frontendURL string) *domain.Notification {
+	caller := screeningCallCallerName
+	if call.CompanyName != nil {
+		caller = *call.CompanyName
+	}
+	at := call.ScheduledAt.In(loc).Format("2006-01-02 15:04 MST")
+
+	n := &domain.Notification{
+		UserID:      call.CandidateUserID,
+		Category:    domain.NotificationCategoryScreeningCall,
+		Subject:     messages.subject,
+		SubjectArgs: []any{at},
+		Body:        messages.body,
+		BodyArgs:    []any{caller, at, call.DurationMinutes, frontendURL + "/candidate/calls"},
+	}
+	note := call.Notes
+	if messages == screeningCallCancelledMessages {
+		note = call.CancelReason
+	}
+	if note != nil {
+		n.Body = messages.bodyWithNote
+		n.BodyArgs = append(n.BodyArgs, *note)
+	}
+	return n
+}
+
+// notifyCandidate sends a call notification; delivery failures are only logged
+func (u *screeningCallUsecase) notifyCandidate(ctx context.Context, call *domain.ScreeningCall, loc *time.Location, messages screeningCallMessages) {
+	if u.notifications == nil {
+		return
+	}
+	if err := u.notifications.Dispatch(ctx, screeningCallNotification(call, loc, messages, u.cfg.FrontendURL)); err != nil {
+		logger.FromContext(ctx).Error("Screening call: failed to notify candidate", "call_id", call.ID, "error", err)
+	}
+}
+
+// notifyBooker tells the admin or employer who booked the call that the candidate cancelled it
+func (u *screeningCallUsecase) notifyBooker(ctx context.Context, call *domain.ScreeningCall) {
+	if u.notifications == nil {
+		return
+	}
+	at := call.ScheduledAt.UTC().Format("2006-01-02 15:04 MST")
+	n := &domain.Notification{
+		UserID:      *call.BookedBy,
+		Category:    domain.NotificationCategoryScreeningCall,
+		Subject:     "A candidate cancelled the screening call on %s",
+		SubjectArgs: []any{at},
+		Body:        "The candidate cancelled the screening call planned for %s. Book another slot within their contact hours: %s",
+		BodyArgs:    []any{at, u.cfg.FrontendURL},
+	}
+	if call.CancelReason != nil {
+		n.Body = "The candidate cancelled the screening call planned for %s. Book another slot within their contact hours: %s\n\nReason:\n%s"
+		n.BodyArgs = append(n.BodyArgs, *call.CancelReason)
+	}
+	if err := u.notifications.Dispatch(ctx, n); err != nil {
+		logger.FromContext(ctx).Error("Screening call: failed to notify booker", "call_id", call.ID, "error", err)
+	}
+}
@@ -1,15 +1,40 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/jobs"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/parquet"
 	"go-recruitment-backend/pkg/security"
 )
 
+const (
+	// Larger exports are only available through the background worker
+	maxInlineExportRows = 10000
+	// Hard ceiling for a single worker-generated export
+	maxExportRows = 500000
+
+	maxConcurrentExportJobs = 2
+	exportJobTimeout        = 10 * time.Minute
+	exportURLTTL            = 15 * time.Minute
+
+	// Anchoring runs daily; the slack covers a late run
+	defaultAnchorHeartbeatWindow = 26 * time.Hour
+)
+
 // SecurityDashboardUsecase implements the security dashboard business logic
 type SecurityDashboardUsecase struct {
 	repo             domain.SecurityDashboardRepository
@@ -17,6 +42,23 @@ type SecurityDashboardUsecase struct {
 	integrityService *security.LogIntegrityService
 	logger           *security.SecurityLogger
 
+	// Object storage for worker-generated exports (nil disables the worker path)
+	exportStore domain.SecurityExportStore
+	exportSlots chan struct{}
+	// Runs artifact generation on the job queue (nil uses an in-process goroutine)
+	jobQueue domain.JobEnqueuer
+
+	// Delivers integrity and anomaly alerts to security admins (nil logs only)
+	alertNotifier domain.SecurityAlertNotifier
+	alertWebhooks []domain.SecurityAlertWebhook
+
+	// Rules over recent security events; one detection run at a time per instance
+	detector    *security.AnomalyDetector
+	detectionMu sync.Mutex
+
+	// Anchoring dead-man switch: alert when no anchor run succeeded for this long
+	heartbeatWindow time.Duration
+
 	// Cache for stats (1 minute TTL)
 	statsCache    *domain.SecurityDashboardStats
 	statsCacheAt  time.Time
@@ -35,10 +77,48 @@ func NewSecurityDashboardUsecase(
 		authService:      authService,
 		integrityService: integrityService,
 		logger:           security.DefaultLogger(),
+		exportSlots:      make(chan struct{}, maxConcurrentExportJobs),
+		heartbeatWindow:  defaultAnchorHeartbeatWindow,
+		detector:         security.NewAnomalyDetector(security.DefaultAnomalyDetectorConfig()),
 		statsCacheTTL:    1 * time.Minute,
 	}
 }
 
+// SetExportStore enables worker-generated exports with signed download URLs
+func (u *SecurityDashboardUsecase) SetExportStore(store domain.SecurityExportStore) {
+	u.exportStore = store
+}
+
+// SetJobQueue generates export artifacts on the background job queue, with retries;
+// register RunExportArtifactJob for domain.JobTypeSecurityExportArtifact
+func (u *SecurityDashboardUsecase) SetJobQueue(queue domain.JobEnqueuer) {
+	u.jobQueue = queue
+}
+
+// SetAlertNotifier enables email alerts to security admins on failed integrity verification
+func (u *SecurityDashboardUsecase) SetAlertNotifier(notifier domain.SecurityAlertNotifier) {
+	u.alertNotifier = notifier
+}
+
+// SetAlertWebhook also posts security alerts to an external webhook; call it once per webhook
+func (u *SecurityDashboardUsecase) SetAlertWebhook(webhook domain.SecurityAlertWebhook) {
+	u.alertWebhooks = append(u.alertWebhooks, webhook)
+}
+
+// SetAnomalyDetector replaces the default anomaly detection rules
+func (u *SecurityDashboardUsecase) SetAnomalyDetector(detector *security.AnomalyDetector) {
+	if detector != nil {
+		u.detector = detector
+	}
+}
+
+// SetAnchorHeartbeatWindow sets how long anchoring may go without a successful run
+func (u *SecurityDashboardUsecase) SetAnchorHeartbeatWindow(window time.Duration) {
+	if window > 0 {
+		u.heartbeatWindow = window
+	}
+}
+
 // GetStats returns cached dashboard statistics
 func (u *SecurityDashboardUsecase) GetStats(ctx context.Context) (*domain.SecurityDashboardStats, error) {
 	// Check cache
@@ -55,6 +135,14 @@ func (u *SecurityDashboardUsecase) GetStats(ctx context.Context) (*domain.Securi
 	if err != nil {
 		return nil, err
 	}
+	if heartbeat, err := u.GetAnchorHeartbeat(ctx); err != nil {
+		logger.FromContext(ctx).Error("Security stats: failed to check anchor heartbeat", "error", err)
+	} else {
+		stats.AnchorHeartbeat = heartbeat
+		if heartbeat.Status != domain.AnchorHeartbeatOK && stats.IntegrityStatus == "intact" {
+			stats.IntegrityStatus = "degraded"
+		}
+	}
 
 	// Update cache
 	u.statsMutex.Lock()
@@ -123,8 +211,9 @@ func (u *SecurityDashboardUsecase) RequestExport(ctx context.Context, userID str
 	return u.repo.CreateExportRequest(ctx, userID, req)
 }
 
-// ApproveExport approves an export request
-func (u *SecurityDashboardUsecase) ApproveExport(ctx context.Context, exportID, approverID string) error {
+// ApproveExport approves an export request, optionally with a narrower scope than requested.
+// The approved scope is what downloads return; the difference is recorded in the approval event.
+func (u *SecurityDashboardUsecase) ApproveExport(ctx context.Context, exportID, approverID string, scope domain.ApproveExportScope) error {
 	// Get the export request first
 	export, err := u.repo.GetExportRequest(ctx, exportID)
 	if err != nil {
@@ -140,19 +229,26 @@ func (u *SecurityDashboardUsecase) ApproveExport(ctx context.Context, exportID,
 		return fmt.Errorf("cannot approve own export request")
 	}
 
-	err = u.repo.ApproveExportRequest(ctx, exportID, approverID)
+	approved, delta, err := narrowExportScope(export.Filter, scope)
+	if err != nil {
+		return err
+	}
+
+	err = u.repo.ApproveExportRequest(ctx, exportID, approverID, approved, scope.RedactIPs)
 	if err != nil {
 		return err
 	}
 
-	// Log approval
+	// Log approval with what was cut from the request
 	u.logger.Log(ctx, security.SecurityEvent{
 		Event:        security.EventDataExportApproved,
 		SubjectType:  "export_request",
 		SubjectValue: exportID,
 		Details: map[string]interface{}{
-			"approver_id":  security.HashValue(approverID),
-			"requester_id": security.HashValue(export.RequestedBy),
+			"approver_id":    security.HashValue(approverID),
+			"requester_id":   security.HashValue(export.RequestedBy),
+			"scope_narrowed": len(delta) > 0,
+			"scope_delta":    delta,
 		},
 	})
 
@@ -186,7 +282,134 @@ func (u *SecurityDashboardUsecase) RejectExport(ctx context.Context, exportID, a
 
 // GetExportData retrieves export data for download
 func (u *SecurityDashboardUsecase) GetExportData(ctx context.Context, exportID, userID string) ([]domain.SecurityEventView, error) {
-	// Verify export is approved and not expired
+	export, err := u.authorizeExportDownload(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch the events within the approved scope
+	events, err := u.loadExportEvents(ctx, export, maxInlineExportRows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Increment download count
+	u.repo.IncrementDownloadCount(ctx, exportID)
+	u.logExportDownload(ctx, userID, exportID, domain.ExportFormatJSON, len(events))
+
+	return events, nil
+}
+
+// GetExportFile renders an approved export as a file (json, csv or parquet)
+// for direct download. Large ranges return domain.ErrExportTooLarge and must
+// go through RequestExportArtifact instead.
+func (u *SecurityDashboardUsecase) GetExportFile(ctx context.Context, exportID, userID, format string) (*domain.ExportFile, error) {
+	export, err := u.authorizeExportDownload(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := u.loadExportEvents(ctx, export, maxInlineExportRows)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := renderExportFile(exportID, format, events)
+	if err != nil {
+		return nil, err
+	}
+
+	u.repo.IncrementDownloadCount(ctx, exportID)
+	u.logExportDownload(ctx, userID, exportID, format, file.RowCount)
+
+	return file, nil
+}
+
+// RequestExportArtifact queues background generation of an export file.
+// Calling it again for the same format returns the existing artifact.
+func (u *SecurityDashboardUsecase) RequestExportArtifact(ctx context.Context, exportID, userID, format string) (*domain.ExportArtifact, error) {
+	if u.exportStore == nil {
+		return nil, domain.ErrExportStorageDisabled
+	}
+
+	export, err := u.authorizeExportDownload(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, created, err := u.repo.CreateExportArtifact(ctx, exportID, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export artifact: %w", err)
+	}
+
+	if created && u.jobQueue != nil {
+		if _, err := u.jobQueue.Enqueue(ctx, domain.JobTypeSecurityExportArtifact, exportArtifactJob{ExportID: exportID, Format: format}); err != nil {
+			// Failed artifacts are reset by the next request
+			msg := "failed to queue export"
+			artifact.Status, artifact.ErrorMessage = domain.ExportArtifactFailed, &msg
+			if uerr := u.repo.UpdateExportArtifact(ctx, artifact); uerr != nil {
+				logger.FromContext(ctx).Error("Security export: failed to save result", "error", uerr)
+			}
+			return nil, fmt.Errorf("failed to queue export artifact: %w", err)
+		}
+	} else if created {
+		go func() {
+			u.exportSlots <- struct{}{}
+			defer func() { <-u.exportSlots }()
+
+			workerCtx, cancel := context.WithTimeout(context.Background(), exportJobTimeout)
+			defer cancel()
+			u.generateExportArtifact(workerCtx, export, artifact, true)
+		}()
+	}
+
+	return artifact, nil
+}
+
+// GetExportArtifact returns artifact status, with a signed URL once it is ready
+func (u *SecurityDashboardUsecase) GetExportArtifact(ctx context.Context, exportID, userID, format string) (*domain.ExportArtifact, error) {
+	if u.exportStore == nil {
+		return nil, domain.ErrExportStorageDisabled
+	}
+
+	export, err := u.authorizeExportDownload(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact, err := u.repo.GetExportArtifact(ctx, exportID, format)
+	if err != nil {
+		return nil, err
+	}
+	if artifact.Status != domain.ExportArtifactReady {
+		return artifact, nil
+	}
+
+	// The signed URL never outlives the approval window
+	ttl := exportURLTTL
+	if export.DownloadExpires != nil {
+		if remaining := time.Until(*export.DownloadExpires); remaining < ttl {
+			ttl = remaining
+		}
+	}
+
+	url, err := u.exportStore.PresignGet(ctx, artifact.ObjectKey, ttl)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	artifact.DownloadURL = url
+	artifact.URLExpiresAt = &expiresAt
+
+	u.repo.IncrementDownloadCount(ctx, exportID)
+	u.logExportDownload(ctx, userID, exportID, format, int(artifact.RowCount))
+
+	return artifact, nil
+}
+
+// authorizeExportDownload verifies the export is approved, not expired, and
+// that the caller is its requester or approver
+func (u *SecurityDashboardUsecase) authorizeExportDownload(ctx context.Context, exportID, userID string) (*domain.ExportRequest, error) {
 	export, err := u.repo.GetExportRequest(ctx, exportID)
 	if err != nil {
 		return nil, fmt.Errorf("export request not found")
@@ -207,12 +430,206 @@ func (u *SecurityDashboardUsecase) GetExportData(ctx context.Context, exportID,
 		}
 	}
 
-	// Increment download count
-	u.repo.IncrementDownloadCount(ctx, exportID)
+	return export, nil
+}
+
+// loadExportEvents fetches every event within the approved scope, up to limit,
+// redacting IPs when the approver asked for it
+func (u *SecurityDashboardUsecase) loadExportEvents(ctx context.Context, export *domain.ExportRequest, limit int) ([]domain.SecurityEventView, error) {
+	if export.ApprovedFilter == nil {
+		return nil, fmt.Errorf("export request has no approved scope")
+	}
+	filter := *export.ApprovedFilter
+	filter.Limit = limit
+	filter.Offset = 0
+
+	events, total, err := u.repo.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if total > int64(limit) {
+		return nil, domain.ErrExportTooLarge
+	}
+	if export.RedactIPs {
+		for i := range events {
+			redactEventIPs(&events[i])
+		}
+	}
+	return events, nil
+}
+
+// narrowExportScope applies an approver's scope to the requested filter. Fields can
+// only be narrowed; the returned delta lists each changed field as requested/approved.
+func narrowExportScope(requested domain.SecurityEventFilter, scope domain.ApproveExportScope) (domain.SecurityEventFilter, map[string]interface{}, error) {
+	approved := requested
+	delta := map[string]interface{}{}
+
+	if scope.StartTime != nil {
+		if requested.StartTime != nil && scope.StartTime.Before(*requested.StartTime) {
+			return approved, nil, fmt.Errorf("%w: start time is before the requested start", domain.ErrInvalidExportScope)
+		}
+		if !timesEqual(requested.StartTime, scope.StartTime) {
+			approved.StartTime = scope.StartTime
+			delta["start_time"] = map[string]interface{}{"requested": requested.StartTime, "approved": scope.StartTime}
+		}
+	}
+	if scope.EndTime != nil {
+		if requested.EndTime != nil && scope.EndTime.After(*requested.EndTime) {
+			return approved, nil, fmt.Errorf("%w: end time is after the requested end", domain.ErrInvalidExportScope)
+		}
+		if !timesEqual(requested.EndTime, scope.EndTime) {
+			approved.EndTime = scope.EndTime
+			delta["end_time"] = map[string]interface{}{"requested": requested.EndTime, "approved": scope.EndTime}
+		}
+	}
+	if approved.StartTime != nil && approved.EndTime != nil && approved.StartTime.After(*approved.EndTime) {
+		return approved, nil, fmt.Errorf("%w: start time is after end time", domain.ErrInvalidExportScope)
+	}
+
+	// An empty requested list means all event types, so any list narrows it
+	if len(scope.EventTypes) > 0 {
+		types := []string{}
+		for _, t := range scope.EventTypes {
+			if len(requested.EventTypes) > 0 && !slices.Contains(requested.EventTypes, t) {
+				return approved, nil, fmt.Errorf("%w: event type %q was not requested", domain.ErrInvalidExportScope, t)
+			}
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+		if len(types) != len(requested.EventTypes) {
+			approved.EventTypes = types
+			delta["event_types"] = map[string]interface{}{"requested": requested.EventTypes, "approved": types}
+		}
+	}
+
+	if scope.RedactIPs {
+		delta["ip_addresses"] = "redacted"
+	}
+	return approved, delta, nil
+}
+
+func timesEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// redactEventIPs removes client IPs from an exported event, including copies in details
+func redactEventIPs(e *domain.SecurityEventView) {
+	if e.IP != "" {
+		e.IP = "[redacted]"
+	}
+	for _, key := range []string{"ip", "ip_address", "client_ip"} {
+		if _, ok := e.Details[key]; ok {
+			e.Details[key] = "[redacted]"
+		}
+	}
+	if e.SubjectType == "ip" {
+		e.SubjectValue = "[redacted]"
+	}
+}
+
+// exportArtifactJob is the payload of domain.JobTypeSecurityExportArtifact
+type exportArtifactJob struct {
+	ExportID string `json:"export_id"`
+	Format   string `json:"format"`
+}
+
+// RunExportArtifactJob generates a queued export artifact. A failure before the
+// job's last attempt leaves the artifact pending while the queue retries it.
+func (u *SecurityDashboardUsecase) RunExportArtifactJob(ctx context.Context, job *jobs.Job) error {
+	if u.exportStore == nil {
+		return domain.ErrExportStorageDisabled
+	}
+	var p exportArtifactJob
+	if err := json.Unmarshal(job.Payload, &p); err != nil {
+		return fmt.Errorf("decode export artifact job: %w", err)
+	}
+	export, err := u.repo.GetExportRequest(ctx, p.ExportID)
+	if err != nil {
+		return fmt.Errorf("load export request: %w", err)
+	}
+	artifact, err := u.repo.GetExportArtifact(ctx, p.ExportID, p.Format)
+	if err != nil {
+		return fmt.Errorf("load export artifact: %w", err)
+	}
+	if artifact.Status == domain.ExportArtifactReady {
+		return nil
+	}
 
-	// Fetch the events based on filter
-	events, _, err := u.repo.ListEvents(ctx, export.Filter)
-	return events, err
+	u.exportSlots <- struct{}{}
+	defer func() { <-u.exportSlots }()
+
+	ctx, cancel := context.WithTimeout(ctx, exportJobTimeout)
+	defer cancel()
+	return u.generateExportArtifact(ctx, export, artifact, job.FinalAttempt())
+}
+
+// generateExportArtifact runs on the export worker: render, upload, record. With
+// final unset a failure is recorded as pending, since it will be retried.
+func (u *SecurityDashboardUsecase) generateExportArtifact(ctx context.Context, export *domain.ExportRequest, artifact *domain.ExportArtifact, final bool) error {
+	l := logger.FromContext(ctx).With("export_id", artifact.ExportID, "format", artifact.Format)
+	artifact.Status = domain.ExportArtifactProcessing
+	if err := u.repo.UpdateExportArtifact(ctx, artifact); err != nil {
+		l.Error("Security export: failed to mark processing", "error", err)
+	}
+
+	err := func() error {
+		events, err := u.loadExportEvents(ctx, export, maxExportRows)
+		if err != nil {
+			return err
+		}
+		file, err := renderExportFile(export.ID, artifact.Format, events)
+		if err != nil {
+			return err
+		}
+
+		key := fmt.Sprintf("security-exports/%s/%s", export.ID, file.Filename)
+		if err := u.exportStore.Put(ctx, key, file.ContentType, file.Data); err != nil {
+			return err
+		}
+
+		artifact.ObjectKey = key
+		artifact.RowCount = int64(file.RowCount)
+		artifact.SizeBytes = int64(len(file.Data))
+		return nil
+	}()
+
+	now := time.Now()
+	artifact.CompletedAt = &now
+	artifact.Status = domain.ExportArtifactReady
+	if err != nil {
+		msg := err.Error()
+		artifact.Status = domain.ExportArtifactFailed
+		artifact.ErrorMessage = &msg
+		if !final {
+			artifact.Status, artifact.CompletedAt = domain.ExportArtifactPending, nil
+		}
+	}
+
+	// Use a fresh context so a timed-out job is still recorded as failed
+	saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := u.repo.UpdateExportArtifact(saveCtx, artifact); err != nil {
+		l.Error("Security export: failed to save result", "error", err)
+	}
+	l.Info("Security export finished", "status", artifact.Status, "rows", artifact.RowCount, "bytes", artifact.SizeBytes)
+	return err
+}
+
+func (u *SecurityDashboardUsecase) logExportDownload(ctx context.Context, userID, exportID, format string, rows int) {
+	u.logger.Log(ctx, security.SecurityEvent{
+		Event:        security.EventDataExportDownloaded,
+		SubjectType:  "user_id",
+		SubjectValue: security.HashValue(userID),
+		Details: map[string]interface{}{
+			"export_id": exportID,
+			"format":    format,
+			"rows":      rows,
+		},
+	})
 }
 
 // ActivateBreakGlass activates a time-limited DEVELOPER_ROOT session
@@ -229,21 +646,23 @@ func (u *SecurityDashboardUsecase) ActivateBreakGlass(ctx context.Context, userI
 		return nil, err
 	}
 	if active {
-		return nil, fmt.Errorf("break-glass session already active, expires at %s", existing.ExpiresAt.Format(time.RFC3339))
+		return nil, fmt.Errorf("break-glass session already active, expires at: %s", existing.ExpiresAt.Format(time.RFC3339))
+	}
+	pending, waiting, err := u.authService.CheckBreakGlassPending(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if waiting {
+		return nil, fmt.Errorf("break-glass request already awaiting approval until: %s", pending.ExpiresAt.Format(time.RFC3339))
 	}
 
-	// Activate
+	// Activate, or request approval when dual approval is on
 	session, err := u.authService.ActivateBreakGlass(ctx, userID, req.Justification, req.DurationMinutes)
 	if err != nil {
 		return nil, err
 	}
 
-	return &domain.BreakGlassResponse{
-		SessionID:     session.ID,
-		ActivatedAt:   session.ActivatedAt,
-		ExpiresAt:     session.ExpiresAt,
-		RemainingMins: int(session.ExpiresAt.Sub(time.Now()).Minutes()),
-	}, nil
+	return breakGlassResponse(session), nil
 }
 
 // GetActiveBreakGlass returns the current active break-glass session
@@ -256,12 +675,80 @@ func (u *SecurityDashboardUsecase) GetActiveBreakGlass(ctx context.Context, user
 		return nil, nil
 	}
 
-	return &domain.BreakGlassResponse{
+	return breakGlassResponse(session), nil
+}
+
+// GetPendingBreakGlass returns the caller's request awaiting a second admin
+func (u *SecurityDashboardUsecase) GetPendingBreakGlass(ctx context.Context, userID string) (*domain.BreakGlassResponse, error) {
+	session, pending, err := u.authService.CheckBreakGlassPending(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !pending {
+		return nil, nil
+	}
+
+	return breakGlassResponse(session), nil
+}
+
+// ListPendingBreakGlass returns the requests the approver may decide; their own are left out
+func (u *SecurityDashboardUsecase) ListPendingBreakGlass(ctx context.Context, approverID string) ([]domain.PendingBreakGlassRequest, error) {
+	sessions, err := u.authService.ListPendingBreakGlass(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []domain.PendingBreakGlassRequest{}
+	for _, s := range sessions {
+		if s.SecurityUserID == approverID {
+			continue
+		}
+		requests = append(requests, domain.PendingBreakGlassRequest{
+			SessionID:         s.ID,
+			RequesterID:       s.SecurityUserID,
+			RequesterUsername: s.Username,
+			Justification:     s.Justification,
+			DurationMinutes:   s.DurationMinutes,
+			RequestedAt:       s.ActivatedAt,
+			ApprovalDeadline:  s.ExpiresAt,
+		})
+	}
+	return requests, nil
+}
+
+// ApproveBreakGlass activates another admin's pending request
+func (u *SecurityDashboardUsecase) ApproveBreakGlass(ctx context.Context, sessionID, approverID string) (*domain.BreakGlassResponse, error) {
+	session, err := u.authService.ApproveBreakGlass(ctx, sessionID, approverID)
+	if err != nil {
+		return nil, err
+	}
+	return breakGlassResponse(session), nil
+}
+
+// DenyBreakGlass closes another admin's pending request
+func (u *SecurityDashboardUsecase) DenyBreakGlass(ctx context.Context, sessionID, approverID, reason string) error {
+	if len(reason) < 10 {
+		return fmt.Errorf("denial reason must be at least 10 characters")
+	}
+	return u.authService.DenyBreakGlass(ctx, sessionID, approverID, reason)
+}
+
+// breakGlassResponse reports an active session, or a pending request's approval window
+func breakGlassResponse(session *security.BreakGlassSession) *domain.BreakGlassResponse {
+	resp := &domain.BreakGlassResponse{
 		SessionID:     session.ID,
-		ActivatedAt:   session.ActivatedAt,
-		ExpiresAt:     session.ExpiresAt,
+		Status:        session.Status,
 		RemainingMins: int(session.ExpiresAt.Sub(time.Now()).Minutes()),
-	}, nil
+	}
+	if session.Status == security.BreakGlassPending {
+		resp.RequestedAt = &session.ActivatedAt
+		resp.ApprovalDeadline = &session.ExpiresAt
+		resp.DurationMinutes = session.DurationMinutes
+		return resp
+	}
+	resp.ActivatedAt = &session.ActivatedAt
+	resp.ExpiresAt = &session.ExpiresAt
+	return resp
 }
 
 // RevokeBreakGlass revokes an active break-glass session
@@ -272,12 +759,338 @@ func (u *SecurityDashboardUsecase) RevokeBreakGlass(ctx context.Context, session
 	return u.authService.RevokeBreakGlass(ctx, sessionID, reason)
 }
 
-// VerifyIntegrity performs a full integrity check
-func (u *SecurityDashboardUsecase) VerifyIntegrity(ctx context.Context, startDate, endDate time.Time) (*security.IntegrityReport, error) {
+// VerifyIntegrity performs a full integrity check requested by a security admin
+func (u *SecurityDashboardUsecase) VerifyIntegrity(ctx context.Context, userID string, startDate, endDate time.Time) (*security.IntegrityReport, error) {
+	report, _, err := u.runIntegrityVerification(ctx, domain.IntegrityTriggerManual, userID, startDate, endDate)
+	return report, err
+}
+
+// RunScheduledVerification verifies the last `days` complete UTC days, as the weekly worker does
+func (u *SecurityDashboardUsecase) RunScheduledVerification(ctx context.Context, days int) (*domain.IntegrityVerificationRun, error) {
+	if days <= 0 {
+		days = 7
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	startDate := today.AddDate(0, 0, -days)
+	endDate := today.Add(-time.Second)
+
+	_, run, err := u.runIntegrityVerification(ctx, domain.IntegrityTriggerScheduled, "", startDate, endDate)
+	return run, err
+}
+
+// GetIntegrityHistory returns recorded verification runs with pagination
+func (u *SecurityDashboardUsecase) GetIntegrityHistory(ctx context.Context, page, pageSize int) ([]domain.IntegrityVerificationRun, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	return u.repo.ListIntegrityRuns(ctx, pageSize, offset)
+}
+
+// runIntegrityVerification verifies the range, stamps the anchors, alerts admins
+// on a break and records the run. Failed runs are recorded too.
+func (u *SecurityDashboardUsecase) runIntegrityVerification(ctx context.Context, trigger, userID string, startDate, endDate time.Time) (*security.IntegrityReport, *domain.IntegrityVerificationRun, error) {
 	if u.integrityService == nil {
-		return nil, fmt.Errorf("integrity service not configured")
+		return nil, nil, fmt.Errorf("integrity service not configured")
+	}
+
+	run := &domain.IntegrityVerificationRun{
+		TriggerSource: trigger,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	}
+	if userID != "" {
+		run.TriggeredBy = &userID
+	}
+
+	// 1. Verify the hash chain and anchors
+	report, verifyErr := u.integrityService.VerifyIntegrity(ctx, startDate, endDate)
+	if verifyErr != nil {
+		msg := verifyErr.Error()
+		run.Status = "error"
+		run.ErrorMessage = &msg
+		if err := u.repo.CreateIntegrityRun(ctx, run); err != nil {
+			logger.FromContext(ctx).Error("Integrity verification: failed to record run", "error", err)
+		}
+		return nil, run, verifyErr
+	}
+	run.Status = report.Status
+	run.TotalEvents = report.TotalEvents
+	run.ChainBreaks = report.ChainBreaks
+	run.AnchorMismatches = report.AnchorMismatches
+	run.MissingAnchors = report.MissingAnchors
+	run.FirstBreakEventID = report.FirstBreakEventID
+	run.FailedDates = report.FailedDates
+
+	// 2. Persist the outcome on the anchors, which drives GET /integrity/status
+	if err := u.repo.RecordAnchorVerification(ctx, startDate, endDate, report.FailedDates); err != nil {
+		logger.FromContext(ctx).Error("Integrity verification: failed to update anchor status", "error", err)
+	}
+
+	// 3. Alert security admins on any chain break or anchor mismatch
+	if report.ChainBreaks > 0 || report.AnchorMismatches > 0 {
+		u.logger.Log(ctx, security.SecurityEvent{
+			Event: security.EventHashChainBreak,
+			Details: map[string]interface{}{
+				"trigger":           trigger,
+				"chain_breaks":      report.ChainBreaks,
+				"anchor_mismatches": report.AnchorMismatches,
+				"failed_dates":      report.FailedDates,
+			},
+		})
+		run.AlertedAdmins = u.alertIntegrityFailure(ctx, trigger, report)
+	}
+
+	// 4. Record the run for history
+	if err := u.repo.CreateIntegrityRun(ctx, run); err != nil {
+		logger.FromContext(ctx).Error("Integrity verification: failed to record run", "error", err)
+	}
+
+	return report, run, nil
+}
+
+// alertIntegrityFailure notifies every active SECURITY_ADMIN and returns how many were reached
+func (u *SecurityDashboardUsecase) alertIntegrityFailure(ctx context.Context, trigger string, report *security.IntegrityReport) int {
+	logger.FromContext(ctx).Error("Integrity verification found chain breaks or anchor mismatches",
+		"trigger", trigger, "start_date", report.StartDate.Format("2006-01-02"), "end_date", report.EndDate.Format("2006-01-02"),
+		"chain_breaks", report.ChainBreaks, "anchor_mismatches", report.AnchorMismatches, "failed_dates", report.FailedDates)
+
+	subject := "[Security] Log integrity verification failed"
+	body := fmt.Sprintf(
+		"The %s integrity verification for %s to %s reported status %q.\n\n"+
+			"Chain breaks: %d\nAnchor mismatches: %d\nAffected dates: %v\n\n"+
+			"Review the verification history in the security dashboard.",
+		trigger, report.StartDate.Format("2006-01-02"), report.EndDate.Format("2006-01-02"), report.Status,
+		report.ChainBreaks, report.AnchorMismatches, report.FailedDates,
+	)
+	return u.alertSecurityAdmins(ctx, subject, body)
+}
+
+// alertSecurityAdmins emails every active SECURITY_ADMIN and returns how many were reached
+func (u *SecurityDashboardUsecase) alertSecurityAdmins(ctx context.Context, subject, body string) int {
+	if u.alertNotifier == nil {
+		return 0
+	}
+	emails, err := u.repo.ListSecurityAdminEmails(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Security alert: failed to list security admins", "error", err)
+		return 0
+	}
+
+	alerted := 0
+	for _, email := range emails {
+		if err := u.alertNotifier.SendSecurityAlert(ctx, email, subject, body); err != nil {
+			logger.FromContext(ctx).Error("Security alert: failed to alert security admin", "email", email, "error", err)
+			continue
+		}
+		alerted++
+	}
+	return alerted
+}
+
+// GetAnchorHeartbeat reports whether an anchor run succeeded within the heartbeat window
+func (u *SecurityDashboardUsecase) GetAnchorHeartbeat(ctx context.Context) (*domain.AnchorHeartbeatStatus, error) {
+	status, _, err := u.anchorHeartbeat(ctx)
+	return status, err
+}
+
+// CheckAnchorHeartbeat alerts security admins and the webhook when the heartbeat
+// is overdue. Each outage (keyed by the last heartbeat seen) is alerted once,
+// however many instances run the check.
+func (u *SecurityDashboardUsecase) CheckAnchorHeartbeat(ctx context.Context) (*domain.AnchorHeartbeatStatus, error) {
+	status, lastHeartbeatID, err := u.anchorHeartbeat(ctx)
+	if err != nil || status.Status == domain.AnchorHeartbeatOK {
+		return status, err
+	}
+
+	claimed, err := u.repo.RecordAnchorHeartbeatAlert(ctx, lastHeartbeatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record heartbeat alert: %w", err)
+	}
+	if !claimed {
+		return status, nil
+	}
+	status.Alerted = true
+
+	details := map[string]interface{}{
+		"status":          status.Status,
+		"window_hours":    status.WindowHours,
+		"overdue_minutes": status.OverdueMinutes,
+	}
+	last := "never"
+	if status.LastHeartbeatAt != nil {
+		last = status.LastHeartbeatAt.Format(time.RFC3339)
+		details["last_heartbeat_at"] = last
+		details["last_anchor_date"] = status.LastAnchorDate.Format("2006-01-02")
+	}
+	u.logger.Log(ctx, security.SecurityEvent{Event: security.EventAnchorHeartbeatMissed, Details: details})
+	logger.FromContext(ctx).Error("Anchoring heartbeat missed", "status", status.Status, "last_heartbeat_at", last, "window_hours", status.WindowHours)
+
+	subject := "[Security] Log anchoring has stopped"
+	body := fmt.Sprintf(
+		"No successful anchor run has been recorded within the last %d hours (last heartbeat: %s).\n\n"+
+			"Until anchoring resumes, new security events are not anchored to object storage and tampering "+
+			"with them cannot be detected. Check the anchoring job and its S3 credentials.",
+		status.WindowHours, last,
+	)
+	u.alertSecurityAdmins(ctx, subject, body)
+	u.postAlertWebhooks(ctx, string(security.EventAnchorHeartbeatMissed), details)
+	return status, nil
+}
+
+// postAlertWebhooks posts the alert to every configured webhook; failures are only logged
+func (u *SecurityDashboardUsecase) postAlertWebhooks(ctx context.Context, event string, details map[string]interface{}) {
+	for _, webhook := range u.alertWebhooks {
+		if err := webhook.PostSecurityAlert(ctx, event, details); err != nil {
+			logger.FromContext(ctx).Error("Security alert: webhook failed", "event", event, "error", err)
+		}
+	}
+}
+
+// anchorHeartbeat evaluates the last heartbeat against the window; the ID is 0 when there is none
+func (u *SecurityDashboardUsecase) anchorHeartbeat(ctx context.Context) (*domain.AnchorHeartbeatStatus, int64, error) {
+	now := time.Now().UTC()
+	status := &domain.AnchorHeartbeatStatus{
+		Status:      domain.AnchorHeartbeatMissing,
+		WindowHours: int(u.heartbeatWindow.Hours()),
+		CheckedAt:   now,
+	}
+
+	hb, err := u.repo.GetLastAnchorHeartbeat(ctx)
+	if errors.Is(err, domain.ErrNotFound) {
+		return status, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load anchor heartbeat: %w", err)
+	}
+
+	status.Status = domain.AnchorHeartbeatOK
+	status.LastHeartbeatAt = &hb.CreatedAt
+	status.LastAnchorDate = &hb.AnchorDate
+	if overdue := now.Sub(hb.CreatedAt.Add(u.heartbeatWindow)); overdue > 0 {
+		status.Status = domain.AnchorHeartbeatStale
+		status.OverdueMinutes = int64(overdue.Minutes())
+	}
+	return status, hb.ID, nil
+}
+
+// RunAnomalyDetection evaluates the detection rules over recent security events.
+// Each new alert is logged and sent to security admins and the webhooks; alerts
+// for an incident that is already open are only updated.
+func (u *SecurityDashboardUsecase) RunAnomalyDetection(ctx context.Context) (*domain.AnomalyDetectionRun, error) {
+	if !u.detectionMu.TryLock() {
+		return nil, errors.New("an anomaly detection run is already in progress")
+	}
+	defer u.detectionMu.Unlock()
+
+	now := time.Now().UTC()
+	events, err := u.repo.ListDetectionEvents(ctx, u.detector.EventTypes(), now.Add(-u.detector.Lookback()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load security events: %w", err)
+	}
+
+	anomalies := u.detector.Detect(events, now)
+	run := &domain.AnomalyDetectionRun{EventsScanned: len(events), Anomalies: len(anomalies), CheckedAt: now}
+	for _, a := range anomalies {
+		alert := &domain.SecurityAlert{
+			Rule:        a.Rule,
+			DedupKey:    a.DedupKey,
+			Severity:    string(a.Severity),
+			Title:       a.Title,
+			EventCount:  a.EventCount,
+			FirstSeenAt: a.FirstSeen,
+			LastSeenAt:  a.LastSeen,
+			Details:     a.Details,
+			Status:      domain.SecurityAlertOpen,
+		}
+		created, err := u.repo.UpsertSecurityAlert(ctx, alert)
+		if err != nil {
+			logger.FromContext(ctx).Error("Anomaly detection: failed to save alert", "rule", a.Rule, "error", err)
+			continue
+		}
+		if created {
+			run.NewAlerts++
+			u.raiseSecurityAlert(ctx, alert)
+		}
+	}
+	return run, nil
+}
+
+// raiseSecurityAlert logs a new alert and notifies security admins and the webhooks
+func (u *SecurityDashboardUsecase) raiseSecurityAlert(ctx context.Context, alert *domain.SecurityAlert) {
+	details := map[string]interface{}{
+		"alert_id":    alert.ID,
+		"rule":        alert.Rule,
+		"severity":    alert.Severity,
+		"title":       alert.Title,
+		"event_count": alert.EventCount,
+		"first_seen":  alert.FirstSeenAt.Format(time.RFC3339),
+		"last_seen":   alert.LastSeenAt.Format(time.RFC3339),
+	}
+	u.logger.Log(ctx, security.SecurityEvent{Event: security.EventSecurityAlertRaised, Details: details})
+	logger.FromContext(ctx).Warn("Security alert raised", "alert_id", alert.ID, "rule", alert.Rule, "title", alert.Title)
+
+	subject := fmt.Sprintf("[Security] %s alert: %s", alert.Severity, alert.Title)
+	body := fmt.Sprintf(
+		"Anomaly detection raised alert #%d (%s).\n\n%s\n\nEvents: %d, from %s to %s\n%s\n"+
+			"Acknowledge or resolve the alert in the security dashboard.",
+		alert.ID, alert.Rule, alert.Title, alert.EventCount,
+		alert.FirstSeenAt.Format(time.RFC3339), alert.LastSeenAt.Format(time.RFC3339), formatAlertDetails(alert.Details),
+	)
+	notified := u.alertSecurityAdmins(ctx, subject, body)
+	if notified > 0 {
+		if err := u.repo.SetSecurityAlertNotified(ctx, alert.ID, notified); err != nil {
+			logger.FromContext(ctx).Error("Security alert: failed to record notification", "alert_id", alert.ID, "error", err)
+		}
 	}
-	return u.integrityService.VerifyIntegrity(ctx, startDate, endDate)
+	u.postAlertWebhooks(ctx, string(security.EventSecurityAlertRaised), details)
+}
+
+// formatAlertDetails lists the rule details one per line, sorted by key
+func formatAlertDetails(details map[string]interface{}) string {
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s: %v\n", k, details[k])
+	}
+	return b.String()
+}
+
+// ListAlerts lists anomaly alerts, newest first
+func (u *SecurityDashboardUsecase) ListAlerts(ctx context.Context, filter domain.SecurityAlertFilter) ([]domain.SecurityAlert, int64, error) {
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return u.repo.ListSecurityAlerts(ctx, filter)
+}
+
+// AcknowledgeAlert marks an open alert as being looked at
+func (u *SecurityDashboardUsecase) AcknowledgeAlert(ctx context.Context, alertID int64, userID string) (*domain.SecurityAlert, error) {
+	if err := u.repo.AcknowledgeSecurityAlert(ctx, alertID, userID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetSecurityAlert(ctx, alertID)
+}
+
+// ResolveAlert closes an alert with what was found. A later burst of the same
+// incident raises a new alert.
+func (u *SecurityDashboardUsecase) ResolveAlert(ctx context.Context, alertID int64, userID, resolution string) (*domain.SecurityAlert, error) {
+	if err := u.repo.ResolveSecurityAlert(ctx, alertID, userID, strings.TrimSpace(resolution)); err != nil {
+		return nil, err
+	}
+	return u.repo.GetSecurityAlert(ctx, alertID)
 }
 
 // GetIntegrityStatus returns current integrity status
@@ -295,9 +1108,178 @@ func (u *SecurityDashboardUsecase) GetIntegrityStatus(ctx context.Context) (stri
 	return status, &anchor.AnchorDate, nil
 }
 
+// securityEventParquetColumns is the typed schema analysts get in notebooks
+var securityEventParquetColumns = []parquet.Column{
+	{Name: "id", Type: parquet.Int64},
+	{Name: "timestamp", Type: parquet.TimestampMillis},
+	{Name: "event_type", Type: parquet.String},
+	{Name: "severity", Type: parquet.String},
+	{Name: "subject_type", Type: parquet.String, Optional: true},
+	{Name: "subject_value", Type: parquet.String, Optional: true},
+	{Name: "ip", Type: parquet.String, Optional: true},
+	{Name: "user_agent", Type: parquet.String, Optional: true},
+	{Name: "browser", Type: parquet.String, Optional: true},
+	{Name: "os", Type: parquet.String, Optional: true},
+	{Name: "device_class", Type: parquet.String, Optional: true},
+	{Name: "request_id", Type: parquet.String, Optional: true},
+	{Name: "details", Type: parquet.JSON, Optional: true},
+}
+
+// renderExportFile encodes events in the requested download format
+func renderExportFile(exportID, format string, events []domain.SecurityEventView) (*domain.ExportFile, error) {
+	if events == nil {
+		events = []domain.SecurityEventView{}
+	}
+	file := &domain.ExportFile{
+		Filename: fmt.Sprintf("security_events_%s.%s", exportID, format),
+		RowCount: len(events),
+	}
+
+	switch format {
+	case domain.ExportFormatJSON:
+		data, err := json.Marshal(events)
+		if err != nil {
+			return nil, err
+		}
+		file.ContentType = "application/json"
+		file.Data = data
+
+	case domain.ExportFormatCSV:
+		rows := [][]string{slices.Clone(securityEventHeader)}
+		for _, e := range events {
+			rows = append(rows, securityEventRecord(e))
+		}
+		data, err := writeSpreadsheetCSV(rows)
+		if err != nil {
+			return nil, err
+		}
+		file.ContentType = "text/csv"
+		file.Data = data
+
+	case domain.ExportFormatParquet:
+		rows := make([][]interface{}, 0, len(events))
+		for _, e := range events {
+			var details interface{}
+			if len(e.Details) > 0 {
+				b, err := json.Marshal(e.Details)
+				if err != nil {
+					return nil, err
+				}
+				details = b
+			}
+			rows = append(rows, []interface{}{
+				e.ID, e.Timestamp, e.EventType, e.Severity,
+				nullIfEmpty(e.SubjectType), nullIfEmpty(e.SubjectValue), nullIfEmpty(e.IP),
+				nullIfEmpty(e.UserAgent), nullIfEmpty(e.Client.Browser), nullIfEmpty(e.Client.OS), nullIfEmpty(e.Client.DeviceClass),
+				nullIfEmpty(e.RequestID), details,
+			})
+		}
+		data, err := parquet.Encode(securityEventParquetColumns, rows, "go-recruitment-backend security export")
+		if err != nil {
+			return nil, err
+		}
+		file.ContentType = "application/vnd.apache.parquet"
+		file.Data = data
+
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	return file, nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
 	}
 	return b
 }
+
+// emailSecurityAlertNotifier delivers security alerts over SMTP
+type emailSecurityAlertNotifier struct {
+	emailService *email.EmailService
+}
+
+// NewEmailSecurityAlertNotifier sends security alerts as plain-text email
+func NewEmailSecurityAlertNotifier(emailService *email.EmailService) domain.SecurityAlertNotifier {
+	return emailSecurityAlertNotifier{emailService: emailService}
+}
+
+func (n emailSecurityAlertNotifier) SendSecurityAlert(ctx context.Context, to, subject, body string) error {
+	return n.emailService.SendTextEmail(ctx, to, subject, body)
+}
+
+// webhookSecurityAlertPoster posts security alerts as JSON
+type webhookSecurityAlertPoster struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSecurityAlertPoster posts security alerts to url as
+// {"event": ..., "service": ..., "sentAt": ..., "details": {...}}
+func NewWebhookSecurityAlertPoster(url string) domain.SecurityAlertWebhook {
+	return &webhookSecurityAlertPoster{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *webhookSecurityAlertPoster) PostSecurityAlert(ctx context.Context, event string, payload map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"event":   event,
+		"service": "j-expert-backend",
+		"sentAt":  time.Now().UTC().Format(time.RFC3339),
+		"details": payload,
+	})
+	if err != nil {
+		return err
+	}
+	return postAlertJSON(ctx, w.client, w.url, body)
+}
+
+// slackSecurityAlertPoster posts security alerts to a Slack incoming webhook
+type slackSecurityAlertPoster struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlackSecurityAlertPoster posts security alerts to a Slack incoming webhook as a text message
+func NewSlackSecurityAlertPoster(url string) domain.SecurityAlertWebhook {
+	return &slackSecurityAlertPoster{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *slackSecurityAlertPoster) PostSecurityAlert(ctx context.Context, event string, payload map[string]interface{}) error {
+	text := fmt.Sprintf(":rotating_light: *%s* on j-expert-backend", event)
+	if title, ok := payload["title"].(string); ok {
+		text += "\n" + title
+	}
+	text += "\n```" + formatAlertDetails(payload) + "```"
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	return postAlertJSON(ctx, w.client, w.url, body)
+}
+
+// postAlertJSON posts body to url and fails on any non-2xx response
+func postAlertJSON(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"go-recruitment-backend/internal/domain"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportStreamChunk is how many events a streamed export reads and writes at a time
+const exportStreamChunk = 1000
+
+// securityEventHeader names the columns of CSV and XLSX exports
+var securityEventHeader = []string{
+	"id", "timestamp", "event_type", "severity", "subject_type", "subject_value",
+	"ip", "user_agent", "browser", "os", "device_class", "request_id", "details",
+}
+
+// securityEventRecord is an event as a CSV or XLSX row, in securityEventHeader order
+func securityEventRecord(e domain.SecurityEventView) []string {
+	details, _ := json.Marshal(e.Details)
+	return []string{
+		i64(e.ID), e.Timestamp.UTC().Format(time.RFC3339Nano), e.EventType, e.Severity, e.SubjectType, e.SubjectValue,
+		e.IP, e.UserAgent, e.Client.Browser, e.Client.OS, e.Client.DeviceClass, e.RequestID, string(details),
+	}
+}
+
+var exportStreamContentTypes = map[string]string{
+	domain.ExportFormatCSV:   "text/csv",
+	domain.ExportFormatXLSX:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	domain.ExportFormatJSONL: "application/x-ndjson",
+}
+
+// StreamExport prepares an approved export for direct download in csv, xlsx or
+// jsonl. Events are read in chunks while the response is written, so the row
+// limit is that of worker-generated exports rather than inline downloads.
+func (u *SecurityDashboardUsecase) StreamExport(ctx context.Context, exportID, userID, format string) (*domain.ExportStream, error) {
+	if !slices.Contains(domain.StreamExportFormats, format) {
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+	export, err := u.authorizeExportDownload(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if export.ApprovedFilter == nil {
+		return nil, fmt.Errorf("export request has no approved scope")
+	}
+
+	filter := *export.ApprovedFilter
+	filter.Limit, filter.Offset = 0, 0
+	_, total, err := u.repo.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if total > maxExportRows {
+		return nil, domain.ErrExportTooLarge
+	}
+
+	u.repo.IncrementDownloadCount(ctx, exportID)
+	mark := domain.ExportWatermark{ExportID: exportID, DownloadedBy: userID, DownloadedAt: time.Now().UTC()}
+
+	// next returns the following chunk of the approved scope, empty at the end
+	var beforeID int64
+	next := func() ([]domain.SecurityEventView, error) {
+		events, err := u.repo.ListEventsBefore(ctx, filter, beforeID, exportStreamChunk)
+		if err != nil || len(events) == 0 {
+			return nil, err
+		}
+		beforeID = events[len(events)-1].ID
+		if export.RedactIPs {
+			for i := range events {
+				redactEventIPs(&events[i])
+			}
+		}
+		return events, nil
+	}
+
+	return &domain.ExportStream{
+		Filename:    fmt.Sprintf("security_events_%s.%s", exportID, format),
+		ContentType: exportStreamContentTypes[format],
+		Write: func(w io.Writer) error {
+			rows, err := writeExportStream(w, format, &mark, next)
+			u.logExportDownload(ctx, userID, exportID, format, rows)
+			return err
+		},
+	}, nil
+}
+
+// writeExportStream writes every chunk next returns, then the watermark footer,
+// and returns how many events were written
+func writeExportStream(w io.Writer, format string, mark *domain.ExportWatermark, next func() ([]domain.SecurityEventView, error)) (int, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return writeExportCSV(w, mark, next)
+	case domain.ExportFormatXLSX:
+		return writeExportXLSX(w, mark, next)
+	case domain.ExportFormatJSONL:
+		return writeExportJSONL(w, mark, next)
+	}
+	return 0, fmt.Errorf("unsupported export format: %s", format)
+}
+
+func writeExportCSV(w io.Writer, mark *domain.ExportWatermark, next func() ([]domain.SecurityEventView, error)) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(securityEventHeader); err != nil {
+		return 0, err
+	}
+	for {
+		events, err := next()
+		if err != nil {
+			return mark.Rows, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		for _, e := range events {
+			record := securityEventRecord(e)
+			for i, v := range record {
+				record[i] = escapeSpreadsheetFormula(v)
+			}
+			if err := cw.Write(record); err != nil {
+				return mark.Rows, err
+			}
+		}
+		mark.Rows += len(events)
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return mark.Rows, err
+		}
+		flushExportChunk(w)
+	}
+
+	cw.Write([]string{"# watermark", "export_id=" + mark.ExportID, "downloaded_by=" + mark.DownloadedBy,
+		"downloaded_at=" + mark.DownloadedAt.Format(time.RFC3339), "rows=" + i64(int64(mark.Rows))})
+	cw.Flush()
+	return mark.Rows, cw.Error()
+}
+
+// writeExportJSONL writes one event per line; the last line is {"watermark": {...}}
+func writeExportJSONL(w io.Writer, mark *domain.ExportWatermark, next func() ([]domain.SecurityEventView, error)) (int, error) {
+	enc := json.NewEncoder(w)
+	for {
+		events, err := next()
+		if err != nil {
+			return mark.Rows, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		for _, e := range events {
+			if err := enc.Encode(e); err != nil {
+				return mark.Rows, err
+			}
+		}
+		mark.Rows += len(events)
+		flushExportChunk(w)
+	}
+	return mark.Rows, enc.Encode(map[string]interface{}{"watermark": mark})
+}
+
+// writeExportXLSX uses excelize's stream writer, which spills rows to a temporary
+// file instead of memory. An XLSX is a ZIP whose index comes last, so the file
+// is sent once every chunk has been read.
+func writeExportXLSX(w io.Writer, mark *domain.ExportWatermark, next func() ([]domain.SecurityEventView, error)) (int, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+	sw, err := f.NewStreamWriter(f.GetSheetName(0))
+	if err != nil {
+		return 0, err
+	}
+
+	row := 1
+	setRow := func(values []string) error {
+		cells := make([]interface{}, len(values))
+		for i, v := range values {
+			cells[i] = v
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, row)
+		row++
+		return sw.SetRow(cell, cells)
+	}
+
+	if err := setRow(securityEventHeader); err != nil {
+		return 0, err
+	}
+	for {
+		events, err := next()
+		if err != nil {
+			return mark.Rows, err
+		}
+		if len(events) == 0 {
+			break
+		}
+		for _, e := range events {
+			if err := setRow(securityEventRecord(e)); err != nil {
+				return mark.Rows, err
+			}
+		}
+		mark.Rows += len(events)
+	}
+
+	row++ // blank row before the watermark
+	footer := fmt.Sprintf("Export %s downloaded by %s at %s (%d rows)",
+		mark.ExportID, mark.DownloadedBy, mark.DownloadedAt.Format(time.RFC3339), mark.Rows)
+	if err := setRow([]string{footer}); err != nil {
+		return mark.Rows, err
+	}
+	if err := sw.Flush(); err != nil {
+		return mark.Rows, err
+	}
+	_, err = f.WriteTo(w)
+	return mark.Rows, err
+}
+
+// flushExportChunk pushes a written chunk to the client, so a long export keeps
+// the connection busy instead of sitting in the response buffer
+func flushExportChunk(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/storage"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StorageCleanupConfig tunes the deletion queue and the orphan sweep
+type StorageCleanupConfig struct {
+	MaxAttempts int           // attempts before a deletion is given up
+	BaseBackoff time.Duration // wait after the first failure, doubled after each further one
+	MaxBackoff  time.Duration
+	BatchSize   int           // deletions claimed per run
+	OrphanGrace time.Duration // objects younger than this are never orphans (uploads still being recorded)
+}
+
+type storageCleanupUsecase struct {
+	repo  domain.StorageCleanupRepository
+	store storage.Store
+	cfg   StorageCleanupConfig
+	now   func() time.Time
+
+	deleting sync.Mutex
+	sweeping sync.Mutex
+}
+
+// NewStorageCleanupUsecase creates the storage deletion queue and orphan sweep
+func NewStorageCleanupUsecase(repo domain.StorageCleanupRepository, store storage.Store, cfg StorageCleanupConfig) domain.StorageCleanupUsecase {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = time.Minute
+	}
+	if cfg.MaxBackoff < cfg.BaseBackoff {
+		cfg.MaxBackoff = 6 * time.Hour
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.OrphanGrace <= 0 {
+		cfg.OrphanGrace = 24 * time.Hour
+	}
+	return &storageCleanupUsecase{repo: repo, store: store, cfg: cfg, now: time.Now}
+}
+
+// parseStorageURL returns the bucket and object path of a stored file URL, or
+// false when it is not an object in one of the upload buckets
+func parseStorageURL(raw string) (bucket, path string, ok bool) {
+	bucket, path, ok = storage.ParseURL(raw)
+	if !ok || strings.Contains(path, "..") || !slices.Contains(domain.UploadBuckets, bucket) {
+		return "", "", false
+	}
+	return bucket, path, true
+}
+
+// ============================================================================
+// Deletion queue
+// ============================================================================
+
+// QueueReplacedFile queues the replaced object instead of deleting it inline, so
+// a storage outage only delays the deletion. Files recorded for another user are
+// kept; files uploaded before file records existed are queued as before.
+func (u *storageCleanupUsecase) QueueReplacedFile(ctx context.Context, userID, oldURL string) error {
+	bucket, path, ok := parseStorageURL(oldURL)
+	if !ok {
+		logger.FromContext(ctx).Warn("Replaced file URL is not in an upload bucket, not deleting", "url", oldURL)
+		return nil
+	}
+
+	now := u.now().UTC()
+	file, err := u.repo.FindFileByURL(ctx, oldURL)
+	switch {
+	case err == nil:
+		if file.UserID != userID {
+			logger.FromContext(ctx).Warn("Replaced file belongs to another user, not deleting", "file_id", file.ID)
+			return nil
+		}
+		if err := u.repo.SupersedeFile(ctx, file.ID, now); err != nil {
+			return errors.New("Failed to mark file replaced: " + err.Error())
+		}
+	case !errors.Is(err, domain.ErrNotFound):
+		return errors.New("Failed to fetch replaced file: " + err.Error())
+	}
+
+	_, err = u.repo.EnqueueDeletion(ctx, &domain.StorageDeletion{
+		Bucket:        bucket,
+		ObjectPath:    path,
+		Reason:        domain.StorageDeletionReplaced,
+		NextAttemptAt: &now,
+	})
+	if err != nil {
+		return errors.New("Failed to queue file deletion: " + err.Error())
+	}
+	return nil
+}
+
+func (u *storageCleanupUsecase) RunDeletions(ctx context.Context) (*domain.StorageDeletionRunResult, error) {
+	if !u.deleting.TryLock() {
+		return nil, apperror.Conflict("A storage deletion run is already in progress")
+	}
+	defer u.deleting.Unlock()
+
+	// Lease due deletions long enough to process them; a crashed run releases them when the lease ends
+	now := u.now().UTC()
+	deletions, err := u.repo.ClaimDueDeletions(ctx, now, now.Add(10*time.Minute), u.cfg.BatchSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch due storage deletions: " + err.Error()))
+	}
+
+	result := &domain.StorageDeletionRunResult{Due: len(deletions)}
+	for i := range deletions {
+		d := &deletions[i]
+		u.attemptDeletion(ctx, d)
+		switch d.Status {
+		case domain.StorageDeletionDeleted:
+			result.Deleted++
+			result.ReclaimedBytes += *d.ReclaimedBytes
+		case domain.StorageDeletionFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+	return result, nil
+}
+
+// attemptDeletion deletes one object and records the outcome, scheduling the next
+// attempt with exponential backoff until MaxAttempts. An object that is already
+// gone counts as deleted with nothing reclaimed.
+func (u *storageCleanupUsecase) attemptDeletion(ctx context.Context, d *domain.StorageDeletion) {
+	size, _, deleteErr := u.store.Delete(ctx, d.Bucket, d.ObjectPath)
+
+	at := u.now().UTC()
+	d.Attempts++
+	d.LastError = nil
+	d.NextAttemptAt = nil
+
+	switch {
+	case deleteErr == nil:
+		d.Status = domain.StorageDeletionDeleted
+		d.ReclaimedBytes = &size
+		d.DeletedAt = &at
+	case d.Attempts >= u.cfg.MaxAttempts:
+		msg := deleteErr.Error()
+		d.LastError = &msg
+		d.Status = domain.StorageDeletionFailed
+	default:
+		msg := deleteErr.Error()
+		d.LastError = &msg
+		d.Status = domain.StorageDeletionPending
+		next := at.Add(u.backoff(d.Attempts))
+		d.NextAttemptAt = &next
+	}
+
+	if err := u.repo.RecordDeletionAttempt(ctx, d); err != nil {
+		logger.FromContext(ctx).Error("Storage deletion: failed to record attempt", "deletion_id", d.ID, "error", err)
+	}
+}
+
+// backoff is the wait after the given number of failed attempts
+func (u *storageCleanupUsecase) backoff(attempts int) time.Duration {
+	wait := u.cfg.BaseBackoff
+	for i := 1; i < attempts && wait < u.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > u.cfg.MaxBackoff {
+		return u.cfg.MaxBackoff
+	}
+	return wait
+}
+
+// ============================================================================
+// Orphan sweep
+// ============================================================================
+
+// RunSweep lists every upload bucket and queues objects that no live file record
+// points at. Objects stored before the first file record, or within the grace
+// period, are skipped: the first predate tracking, the second may belong to an
+// upload that is still being processed. A bucket that cannot be listed is
+// recorded and skipped.
+func (u *storageCleanupUsecase) RunSweep(ctx context.Context, triggerSource string) (*domain.StorageOrphanSweep, error) {
+	if !u.sweeping.TryLock() {
+		return nil, apperror.Conflict("An orphan sweep is already in progress")
+	}
+	defer u.sweeping.Unlock()
+
+	sweep := &domain.StorageOrphanSweep{
+		TriggerSource: triggerSource,
+		Status:        domain.StorageSweepRunning,
+		BucketErrors:  []string{},
+		StartedAt:     u.now().UTC(),
+	}
+	if err := u.repo.CreateSweep(ctx, sweep); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to start orphan sweep: " + err.Error()))
+	}
+
+	if err := u.sweep(ctx, sweep); err != nil {
+		msg := err.Error()
+		sweep.Status = domain.StorageSweepFailed
+		sweep.ErrorMessage = &msg
+	} else {
+		sweep.Status = domain.StorageSweepCompleted
+	}
+	finishedAt := u.now().UTC()
+	sweep.FinishedAt = &finishedAt
+
+	if err := u.repo.FinishSweep(ctx, sweep); err != nil {
+		logger.FromContext(ctx).Error("Orphan sweep: failed to record result", "sweep_id", sweep.ID, "error", err)
+	}
+	return sweep, nil
+}
+
+func (u *storageCleanupUsecase) sweep(ctx context.Context, sweep *domain.StorageOrphanSweep) error {
+	trackedSince, err := u.repo.TrackingStartedAt(ctx)
+	if err != nil {
+		return errors.New("Failed to fetch first file record: " + err.Error())
+	}
+	if trackedSince == nil {
+		return nil // nothing recorded yet, so nothing can be told apart from an orphan
+	}
+	cutoff := sweep.StartedAt.Add(-u.cfg.OrphanGrace)
+
+	for _, bucket := range domain.UploadBuckets {
+		objects, err := u.store.List(ctx, bucket)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Orphan sweep: failed to list bucket", "bucket", bucket, "error", err)
+			sweep.BucketErrors = append(sweep.BucketErrors, bucket)
+			continue
+		}
+		urls, err := u.repo.ListLiveURLs(ctx, bucket)
+		if err != nil {
+			return errors.New("Failed to fetch file records: " + err.Error())
+		}
+		live := make(map[string]bool, len(urls))
+		for _, raw := range urls {
+			if b, path, ok := parseStorageURL(raw); ok {
+				live[b+"/"+path] = true
+			}
+		}
+		sweep.BucketsScanned++
+
+		for _, obj := range objects {
+			sweep.ObjectsScanned++
+			if obj.CreatedAt.Before(*trackedSince) || obj.CreatedAt.After(cutoff) || live[bucket+"/"+obj.Path] {
+				continue
+			}
+			queued, err := u.repo.EnqueueDeletion(ctx, &domain.StorageDeletion{
+				Bucket:        bucket,
+				ObjectPath:    obj.Path,
+				Reason:        domain.StorageDeletionOrphan,
+				NextAttemptAt: &sweep.StartedAt,
+				SweepID:       &sweep.ID,
+			})
+			if err != nil {
+				return errors.New("Failed to queue orphan deletion: " + err.Error())
+			}
+			if queued {
+				sweep.OrphansFound++
+				sweep.OrphanBytes += obj.SizeBytes
+			}
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Admin
+// ============================================================================
+
+// GetSummary reports the space reclaimed overall and in the last 30 days
+func (u *storageCleanupUsecase) GetSummary(ctx context.Context) (*domain.StorageCleanupSummary, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	now := u.now().UTC()
+	summary, err := u.repo.GetSummary(ctx, now.AddDate(0, 0, -30))
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch storage cleanup summary: " + err.Error()))
+	}
+	summary.GeneratedAt = now
+	return summary, nil
+}
+
+func (u *storageCleanupUsecase) ListDeletions(ctx context.Context, status string, page, pageSize int) (*domain.PaginatedResult[domain.StorageDeletion], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	switch status {
+	case "", domain.StorageDeletionPending, domain.StorageDeletionDeleted, domain.StorageDeletionFailed:
+	default:
+		return nil, apperror.BadRequest("Invalid storage deletion status: " + status)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	deletions, total, err := u.repo.ListDeletions(ctx, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch storage deletions: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.StorageDeletion]{
+		Data:       deletions,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: int((total + int64(pageSize) - 1) / int64(pageSize)),
+	}, nil
+}
+
+// RetryDeletion queues a given-up deletion for a fresh round of attempts
+func (u *storageCleanupUsecase) RetryDeletion(ctx context.Context, id int64) (*domain.StorageDeletion, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	deletion, err := u.getDeletion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if deletion.Status != domain.StorageDeletionFailed {
+		return nil, apperror.Conflict("Only failed storage deletions can be retried")
+	}
+	if err := u.repo.RetryDeletion(ctx, id, u.now().UTC()); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to queue storage deletion: " + err.Error()))
+	}
+	return u.getDeletion(ctx, id)
+}
+
+func (u *storageCleanupUsecase) getDeletion(ctx context.Context, id int64) (*domain.StorageDeletion, error) {
+	deletion, err := u.repo.GetDeletion(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Storage deletion not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch storage deletion: " + err.Error()))
+	}
+	return deletion, nil
+}
+
+func (u *storageCleanupUsecase) ListSweeps(ctx context.Context) ([]domain.StorageOrphanSweep, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	sweeps, err := u.repo.ListSweeps(ctx, 50)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch orphan sweeps: " + err.Error()))
+	}
+	return sweeps, nil
+}
+
+// TriggerSweep runs an orphan sweep now; the orphans it finds are deleted by the
+// next deletion run
+func (u *storageCleanupUsecase) TriggerSweep(ctx context.Context) (*domain.StorageOrphanSweep, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunSweep(ctx, domain.StorageSweepManual)
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/security"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type supabaseWebhookUsecase struct {
+	repo     domain.SupabaseWebhookRepository
+	userRepo domain.UserRepository
+	authUC   domain.AuthUsecase
+	secret   []byte
+	now      func() time.Time
+}
+
+// NewSupabaseWebhookUsecase consumes Supabase auth webhooks signed with secret,
+// as shown in the Supabase dashboard ("v1,whsec_<base64>"). An empty secret
+// disables the webhook.
+func NewSupabaseWebhookUsecase(repo domain.SupabaseWebhookRepository, userRepo domain.UserRepository, authUC domain.AuthUsecase, secret string) domain.SupabaseWebhookUsecase {
+	return &supabaseWebhookUsecase{
+		repo:     repo,
+		userRepo: userRepo,
+		authUC:   authUC,
+		secret:   decodeWebhookSecret(secret),
+		now:      time.Now,
+	}
+}
+
+// decodeWebhookSecret returns the key of a Standard Webhooks secret; secrets
+// that are not base64 are used as they are
+func decodeWebhookSecret(secret string) []byte {
+	secret = strings.TrimPrefix(strings.TrimPrefix(secret, "v1,"), "whsec_")
+	if key, err := base64.StdEncoding.DecodeString(secret); err == nil {
+		return key
+	}
+	return []byte(secret)
+}
+
+func (u *supabaseWebhookUsecase) HandleAuthEvent(ctx context.Context, sig domain.SupabaseWebhookSignature, body []byte) (*domain.SupabaseWebhookResult, error) {
+	if len(u.secret) == 0 {
+		return nil, apperror.NotFound("Supabase webhooks are not enabled")
+	}
+
+	// 1. Signature and freshness
+	if !u.verify(sig, body) {
+		security.DefaultLogger().Log(ctx, security.SecurityEvent{
+			Event:   security.EventUnauthorizedAccess,
+			Details: map[string]interface{}{"reason": "invalid_supabase_webhook_signature", "webhook_id": sig.ID},
+		})
+		return nil, apperror.Unauthorized("Invalid webhook signature")
+	}
+
+	var event domain.SupabaseAuthEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.Type == "" || event.User.ID == "" {
+		return nil, apperror.BadRequest("Invalid auth event")
+	}
+	result := &domain.SupabaseWebhookResult{Type: event.Type}
+
+	// 2. Supabase retries deliveries: handle each webhook id once
+	claimed, err := u.repo.Claim(ctx, sig.ID, event.Type, event.User.ID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to record webhook: " + err.Error()))
+	}
+	if !claimed {
+		result.Action = domain.SupabaseWebhookDuplicate
+		return result, nil
+	}
+
+	// 3. Apply; a failure releases the id so the retry is handled
+	result.Action, err = u.apply(ctx, &event)
+	if err != nil {
+		if rerr := u.repo.Release(ctx, sig.ID); rerr != nil {
+			logger.FromContext(ctx).Warn("Failed to release Supabase webhook", "webhook_id", sig.ID, "error", rerr)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// verify checks a Standard Webhooks signature: HMAC-SHA256 of "id.timestamp.body"
+func (u *supabaseWebhookUsecase) verify(sig domain.SupabaseWebhookSignature, body []byte) bool {
+	if sig.ID == "" {
+		return false
+	}
+	ts, err := strconv.ParseInt(sig.Timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := u.now().Sub(time.Unix(ts, 0)); age > domain.SupabaseWebhookTolerance || age < -domain.SupabaseWebhookTolerance {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(sig.ID + "." + sig.Timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	for _, candidate := range strings.Fields(sig.Signature) {
+		version, value, ok := strings.Cut(candidate, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		if got, err := base64.StdEncoding.DecodeString(value); err == nil && hmac.Equal(got, expected) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *supabaseWebhookUsecase) apply(ctx context.Context, event *domain.SupabaseAuthEvent) (string, error) {
+	subject := security.HashValue(event.User.ID)
+
+	switch event.Type {
+	case domain.SupabaseEventUserConfirmed:
+		// Same sync as the first login; the role is left for onboarding
+		if err := u.authUC.SyncUserFromAuth(ctx, &domain.User{ID: event.User.ID, Email: event.User.Email}); err != nil {
+			return "", err
+		}
+		confirmedAt := u.now().UTC()
+		if event.User.EmailConfirmedAt != nil {
+			confirmedAt = *event.User.EmailConfirmedAt
+		}
+		if err := u.userRepo.SetEmailConfirmed(ctx, event.User.ID, confirmedAt); err != nil {
+			return "", apperror.Internal(errors.New("Failed to record email confirmation: " + err.Error()))
+		}
+		security.DefaultLogger().Log(ctx, security.SecurityEvent{
+			Event:        security.EventEmailConfirmed,
+			SubjectType:  "user_id",
+			SubjectValue: subject,
+			Details:      map[string]interface{}{"source": "supabase_webhook"},
+		})
+		return domain.SupabaseWebhookSynced, nil
+
+	case domain.SupabaseEventUserDeleted:
+		if err := u.userRepo.Delete(ctx, event.User.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.SupabaseWebhookIgnored, nil
+			}
+			return "", apperror.Internal(errors.New("Failed to delete user: " + err.Error()))
+		}
+		security.DefaultLogger().Log(ctx, security.SecurityEvent{
+			Event:        security.EventUserDeleted,
+			SubjectType:  "user_id",
+			SubjectValue: subject,
+			Details:      map[string]interface{}{"source": "supabase_webhook"},
+		})
+		return domain.SupabaseWebhookDeleted, nil
+
+	case domain.SupabaseEventPasswordRecovery:
+		security.DefaultLogger().Log(ctx, security.SecurityEvent{
+			Event:        security.EventPasswordReset,
+			SubjectType:  "user_id",
+			SubjectValue: subject,
+			Details:      map[string]interface{}{"source": "supabase_webhook", "stage": "recovery_requested"},
+		})
+		return domain.SupabaseWebhookLogged, nil
+	}
+	return domain.SupabaseWebhookIgnored, nil
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/logger"
+)
+
+// sendUserEmail emails a registered template to a user in their locale. It runs
+// in the background and only logs failures: the email accompanies an action that
+// already succeeded, so it must neither fail nor slow it down. Nothing is sent
+// when SMTP is not configured.
+func sendUserEmail(ctx context.Context, mailer *email.EmailService, users domain.UserRepository, userID, template string, data any) {
+	if mailer == nil || !mailer.IsConfigured() || userID == "" {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		l := logger.FromContext(ctx).With("template", template, "user_id", userID)
+		user, err := users.GetByID(ctx, userID)
+		if err != nil || user == nil || user.Email == "" {
+			l.Warn("Transactional email: recipient not found", "error", err)
+			return
+		}
+		locale := notificationLocale(&domain.NotificationRecipient{Role: user.Role, PreferredLocale: user.PreferredLocale})
+		if err := mailer.SendTemplate(ctx, email.TemplateMessage{
+			Template: template,
+			Locale:   locale,
+			To:       []string{user.Email},
+			Data:     data,
+			UserID:   userID,
+		}); err != nil {
+			l.Warn("Transactional email: failed to send", "error", err)
+		}
+	}()
+}
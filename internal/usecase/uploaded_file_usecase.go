@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/storage"
+	"slices"
+	"time"
+)
+
+type uploadedFileUsecase struct {
+	fileRepo     domain.UploadedFileRepository
+	companyRepo  domain.CompanyProfileRepository
+	store        storage.Store
+	signedURLTTL time.Duration
+	notifier     domain.FileProcessingNotifier
+}
+
+// NewUploadedFileUsecase creates the file status usecase. Signed URLs for private
+// buckets stop working after signedURLTTL. notifier may be nil, in which case
+// completion is only logged.
+func NewUploadedFileUsecase(
+	fileRepo domain.UploadedFileRepository,
+	companyRepo domain.CompanyProfileRepository,
+	store storage.Store,
+	signedURLTTL time.Duration,
+	notifier domain.FileProcessingNotifier,
+) domain.UploadedFileUsecase {
+	if notifier == nil {
+		notifier = logFileNotifier{}
+	}
+	return &uploadedFileUsecase{
+		fileRepo:     fileRepo,
+		companyRepo:  companyRepo,
+		store:        store,
+		signedURLTTL: signedURLTTL,
+		notifier:     notifier,
+	}
+}
+
+func (u *uploadedFileUsecase) StoreObject(ctx context.Context, bucket, path, contentType string, data []byte) (string, error) {
+	if err := u.store.Upload(ctx, bucket, path, contentType, data); err != nil {
+		return "", err
+	}
+	return u.store.URL(bucket, path), nil
+}
+
+func (u *uploadedFileUsecase) Register(ctx context.Context, f *domain.UploadedFile) error {
+	f.Status = domain.FileStatusUploaded
+	if err := u.fileRepo.Create(ctx, f); err != nil {
+		return apperror.Internal(errors.New("Failed to register upload: " + err.Error()))
+	}
+	return nil
+}
+
+func (u *uploadedFileUsecase) MarkProcessing(ctx context.Context, id string) error {
+	_, err := u.fileRepo.UpdateStatus(ctx, id, domain.FileStatusProcessing, nil, nil)
+	return err
+}
+
+func (u *uploadedFileUsecase) MarkReady(ctx context.Context, id, publicURL string) error {
+	file, err := u.fileRepo.UpdateStatus(ctx, id, domain.FileStatusReady, &publicURL, nil)
+	if err != nil {
+		return err
+	}
+	u.notifier.FileProcessed(ctx, file)
+	return nil
+}
+
+func (u *uploadedFileUsecase) MarkFailed(ctx context.Context, id, reason string) error {
+	file, err := u.fileRepo.UpdateStatus(ctx, id, domain.FileStatusFailed, nil, &reason)
+	if err != nil {
+		return err
+	}
+	u.notifier.FileProcessed(ctx, file)
+	return nil
+}
+
+// GetStatus returns the file if the caller owns it (admins may view any file)
+func (u *uploadedFileUsecase) GetStatus(ctx context.Context, id string) (*domain.UploadedFile, error) {
+	userID := domain.UserIDFromContext(ctx)
+	role := domain.RoleFromContext(ctx)
+	if userID == "" {
+		return nil, apperror.Unauthorized("Not authenticated")
+	}
+
+	file, err := u.fileRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("File not found")
+		}
+		return nil, apperror.Internal(err)
+	}
+
+	// Return NotFound rather than Forbidden so file IDs can't be probed
+	if file.UserID != userID && role != domain.RoleAdmin {
+		return nil, apperror.NotFound("File not found")
+	}
+
+	return file, nil
+}
+
+// SignURL signs files in private buckets for their owner, admins, and companies
+// that may see the owner's documents. Files in public buckets are returned as
+// stored. Unknown and forbidden files are both NotFound so URLs can't be probed.
+func (u *uploadedFileUsecase) SignURL(ctx context.Context, fileURL string) (*domain.SignedFileURL, error) {
+	userID := domain.UserIDFromContext(ctx)
+	role := domain.RoleFromContext(ctx)
+	if userID == "" {
+		return nil, apperror.Unauthorized("Not authenticated")
+	}
+
+	bucket, path, ok := parseStorageURL(fileURL)
+	if !ok {
+		return nil, apperror.BadRequest("url is not a stored file")
+	}
+	if !slices.Contains(domain.PrivateUploadBuckets, bucket) {
+		return &domain.SignedFileURL{URL: fileURL}, nil
+	}
+
+	if role != domain.RoleAdmin {
+		owner, err := u.fileRepo.FindOwnerByURL(ctx, fileURL)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, apperror.NotFound("File not found")
+			}
+			return nil, apperror.Internal(errors.New("Failed to fetch file owner: " + err.Error()))
+		}
+		if owner != userID {
+			if err := u.requireCompanyAccess(ctx, userID, role, owner); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	signed, err := u.store.SignedURL(ctx, bucket, path, u.signedURLTTL)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to sign file URL: " + err.Error()))
+	}
+	expiresAt := time.Now().Add(u.signedURLTTL).UTC()
+	return &domain.SignedFileURL{URL: signed, ExpiresAt: &expiresAt}, nil
+}
+
+// requireCompanyAccess lets an employer read the documents of candidates their
+// company may see
+func (u *uploadedFileUsecase) requireCompanyAccess(ctx context.Context, userID, role, ownerID string) error {
+	if role != domain.RoleEmployer {
+		return apperror.NotFound("File not found")
+	}
+	company, err := u.companyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("File not found")
+		}
+		return apperror.Internal(err)
+	}
+	allowed, err := u.fileRepo.CompanyCanAccessUser(ctx, company.ID, ownerID)
+	if err != nil {
+		return apperror.Internal(errors.New("Failed to check file access: " + err.Error()))
+	}
+	if !allowed {
+		return apperror.NotFound("File not found")
+	}
+	return nil
+}
+
+// logFileNotifier is the default notifier until a delivery channel is configured
+type logFileNotifier struct{}
+
+func (logFileNotifier) FileProcessed(ctx context.Context, file *domain.UploadedFile) {
+	logger.FromContext(ctx).Info("File processing finished", "file_id", file.ID, "status", file.Status)
+}
+
+type fileProcessingNotifier struct {
+	inbox domain.InAppNotifier
+	hooks domain.WebhookPublisher
+}
+
+// NewFileProcessingNotifier records finished files in the uploader's notification
+// center and publishes them as file.processed webhooks. Either may be nil.
+func NewFileProcessingNotifier(inbox domain.InAppNotifier, hooks domain.WebhookPublisher) domain.FileProcessingNotifier {
+	return &fileProcessingNotifier{inbox: inbox, hooks: hooks}
+}
+
+func (n *fileProcessingNotifier) FileProcessed(ctx context.Context, file *domain.UploadedFile) {
+	logFileNotifier{}.FileProcessed(ctx, file)
+
+	if n.inbox != nil {
+		msg := &domain.Notification{
+			UserID:      file.UserID,
+			Category:    domain.NotificationCategoryFileProcessing,
+			SubjectArgs: []any{file.OriginalFilename},
+			BodyArgs:    []any{file.OriginalFilename},
+		}
+		if file.Status == domain.FileStatusReady {
+			msg.Subject, msg.Body = "%s is ready", "Your upload %s was processed and saved."
+		} else {
+			msg.Subject, msg.Body = "%s could not be processed", "Your upload %s could not be processed. Please upload it again."
+		}
+		if err := n.inbox.NotifyInApp(ctx, msg); err != nil {
+			logger.FromContext(ctx).Warn("Failed to record file notification", "file_id", file.ID, "error", err)
+		}
+	}
+
+	publishWebhook(ctx, n.hooks, domain.WebhookEventFileProcessed, domain.FileProcessedWebhook{
+		FileID:      file.ID,
+		UserID:      file.UserID,
+		Bucket:      file.Bucket,
+		Status:      file.Status,
+		Error:       file.ErrorMessage,
+		CompletedAt: file.CompletedAt,
+	})
+}
@@ -0,0 +1,198 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/internal/usecase"
+	"go-recruitment-backend/pkg/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockUploadedFileRepo struct {
+	domain.UploadedFileRepository
+	mock.Mock
+}
+
+func (m *MockUploadedFileRepo) UpdateStatus(ctx context.Context, id, status string, publicURL, errorMessage *string) (*domain.UploadedFile, error) {
+	args := m.Called(ctx, id, status, publicURL, errorMessage)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UploadedFile), args.Error(1)
+}
+
+func (m *MockUploadedFileRepo) FindOwnerByURL(ctx context.Context, fileURL string) (string, error) {
+	args := m.Called(ctx, fileURL)
+	return args.String(0), args.Error(1)
+}
+
+type MockInAppNotifier struct {
+	mock.Mock
+}
+
+func (m *MockInAppNotifier) NotifyInApp(ctx context.Context, n *domain.Notification) error {
+	return m.Called(ctx, n).Error(0)
+}
+
+type MockWebhookPublisher struct {
+	mock.Mock
+}
+
+func (m *MockWebhookPublisher) PublishWebhook(ctx context.Context, eventType string, data any) error {
+	return m.Called(ctx, eventType, data).Error(0)
+}
+
+type MockStore struct {
+	storage.Store
+	mock.Mock
+}
+
+func (m *MockStore) SignedURL(ctx context.Context, bucket, path string, ttl time.Duration) (string, error) {
+	args := m.Called(ctx, bucket, path, ttl)
+	return args.String(0), args.Error(1)
+}
+
+// processedFile returns the file UpdateStatus reports once it reaches status
+func processedFile(status string) *domain.UploadedFile {
+	completedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	file := &domain.UploadedFile{
+		ID:               "file-1",
+		UserID:           "user-1",
+		Bucket:           "CV",
+		OriginalFilename: "resume.pdf",
+		Status:           status,
+		CompletedAt:      &completedAt,
+	}
+	if status == domain.FileStatusFailed {
+		reason := "storage upload failed"
+		file.ErrorMessage = &reason
+	}
+	return file
+}
+
+func TestUploadedFileUsecase_FinishedProcessingNotifies(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  string
+		finish  func(uc domain.UploadedFileUsecase) error
+		subject string
+	}{
+		{"ready", domain.FileStatusReady, func(uc domain.UploadedFileUsecase) error {
+			return uc.MarkReady(context.Background(), "file-1", "https://storage.example.com/CV/resume.pdf")
+		}, "%s is ready"},
+		{"failed", domain.FileStatusFailed, func(uc domain.UploadedFileUsecase) error {
+			return uc.MarkFailed(context.Background(), "file-1", "storage upload failed")
+		}, "%s could not be processed"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			file := processedFile(tc.status)
+			repo := new(MockUploadedFileRepo)
+			repo.On("UpdateStatus", mock.Anything, "file-1", tc.status, mock.Anything, mock.Anything).Return(file, nil)
+
+			inbox := new(MockInAppNotifier)
+			inbox.On("NotifyInApp", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+				return n.UserID == "user-1" && n.Category == domain.NotificationCategoryFileProcessing && n.Subject == tc.subject
+			})).Return(nil)
+
+			hooks := new(MockWebhookPublisher)
+			hooks.On("PublishWebhook", mock.Anything, domain.WebhookEventFileProcessed, domain.FileProcessedWebhook{
+				FileID:      "file-1",
+				UserID:      "user-1",
+				Bucket:      "CV",
+				Status:      tc.status,
+				Error:       file.ErrorMessage,
+				CompletedAt: file.CompletedAt,
+			}).Return(nil)
+
+			uc := usecase.NewUploadedFileUsecase(repo, nil, nil, time.Minute, usecase.NewFileProcessingNotifier(inbox, hooks))
+			require.NoError(t, tc.finish(uc))
+
+			repo.AssertExpectations(t)
+			inbox.AssertExpectations(t)
+			hooks.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUploadedFileUsecase_MarkProcessingDoesNotNotify(t *testing.T) {
+	repo := new(MockUploadedFileRepo)
+	repo.On("UpdateStatus", mock.Anything, "file-1", domain.FileStatusProcessing, mock.Anything, mock.Anything).
+		Return(processedFile(domain.FileStatusProcessing), nil)
+	inbox := new(MockInAppNotifier)
+	hooks := new(MockWebhookPublisher)
+
+	uc := usecase.NewUploadedFileUsecase(repo, nil, nil, time.Minute, usecase.NewFileProcessingNotifier(inbox, hooks))
+	require.NoError(t, uc.MarkProcessing(context.Background(), "file-1"))
+
+	inbox.AssertNotCalled(t, "NotifyInApp", mock.Anything, mock.Anything)
+	hooks.AssertNotCalled(t, "PublishWebhook", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUploadedFileUsecase_NotifierFailureDoesNotFailProcessing(t *testing.T) {
+	repo := new(MockUploadedFileRepo)
+	repo.On("UpdateStatus", mock.Anything, "file-1", domain.FileStatusReady, mock.Anything, mock.Anything).
+		Return(processedFile(domain.FileStatusReady), nil)
+	inbox := new(MockInAppNotifier)
+	inbox.On("NotifyInApp", mock.Anything, mock.Anything).Return(assert.AnError)
+	hooks := new(MockWebhookPublisher)
+	hooks.On("PublishWebhook", mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+
+	uc := usecase.NewUploadedFileUsecase(repo, nil, nil, time.Minute, usecase.NewFileProcessingNotifier(inbox, hooks))
+	assert.NoError(t, uc.MarkReady(context.Background(), "file-1", "https://storage.example.com/CV/resume.pdf"))
+	hooks.AssertExpectations(t)
+}
+
+func TestUploadedFileUsecase_SignURL(t *testing.T) {
+	cases := []struct {
+		name   string
+		url    string
+		caller string
+		role   string
+		signed bool // false: returned as stored
+		err    bool
+	}{
+		{"CV for its owner", "https://storage.example.com/CV/1_resume.pdf", "owner", domain.RoleCandidate, true, false},
+		{"vault document for its owner", "https://storage.example.com/Candidate_Documents/1_passport.jpg", "owner", domain.RoleCandidate, true, false},
+		{"vault document for an admin", "https://storage.example.com/Candidate_Documents/1_passport.jpg", "admin-1", domain.RoleAdmin, true, false},
+		{"vault document for another candidate", "https://storage.example.com/Candidate_Documents/1_passport.jpg", "someone-else", domain.RoleCandidate, false, true},
+		{"guardian consent for its owner", "https://storage.example.com/Guardian_Consent/1_consent.pdf", "owner", domain.RoleCandidate, true, false},
+		{"guardian consent for an LPK partner", "https://storage.example.com/Guardian_Consent/1_consent.pdf", "lpk-1", domain.RoleLPK, false, true},
+		{"profile picture", "https://storage.example.com/Profile_Picture/1_me.jpg", "someone-else", domain.RoleCandidate, false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(MockUploadedFileRepo)
+			repo.On("FindOwnerByURL", mock.Anything, tc.url).Return("owner", nil)
+			store := new(MockStore)
+			store.On("SignedURL", mock.Anything, mock.Anything, mock.Anything, time.Minute).Return("https://storage.example.com/signed?token=t", nil)
+
+			ctx := context.WithValue(context.Background(), domain.KeyUserID, tc.caller)
+			ctx = context.WithValue(ctx, domain.KeyUserRole, tc.role)
+			uc := usecase.NewUploadedFileUsecase(repo, nil, store, time.Minute, nil)
+
+			got, err := uc.SignURL(ctx, tc.url)
+			if tc.err {
+				assert.Error(t, err)
+				store.AssertNotCalled(t, "SignedURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			if tc.signed {
+				assert.Equal(t, "https://storage.example.com/signed?token=t", got.URL)
+				assert.NotNil(t, got.ExpiresAt)
+			} else {
+				assert.Equal(t, tc.url, got.URL)
+				assert.Nil(t, got.ExpiresAt)
+			}
+		})
+	}
+}
@@ -3,9 +3,11 @@ package usecase_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"go-recruitment-backend/internal/domain"
 	"go-recruitment-backend/internal/usecase"
+	"go-recruitment-backend/pkg/validation"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +35,50 @@ func (m *MockCandidateRepo) Update(ctx context.Context, profile *domain.Candidat
 	return m.Called(ctx, profile).Error(0)
 }
 
+func (m *MockCandidateRepo) GetFullProfile(ctx context.Context, userID string) (*domain.CandidateWithFullDetails, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CandidateWithFullDetails), args.Error(1)
+}
+
+func (m *MockCandidateRepo) UpsertFullProfile(ctx context.Context, fullProfile *domain.CandidateWithFullDetails) error {
+	return m.Called(ctx, fullProfile).Error(0)
+}
+
+func (m *MockCandidateRepo) GetAllSkills(ctx context.Context) ([]domain.Skill, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Skill), args.Error(1)
+}
+
+func (m *MockCandidateRepo) GetProfileFreshness(ctx context.Context, userID string) (*domain.CandidateProfileFreshness, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CandidateProfileFreshness), args.Error(1)
+}
+
+func (m *MockCandidateRepo) FlagProfileRefresh(ctx context.Context, userID string, at time.Time) error {
+	return m.Called(ctx, userID, at).Error(0)
+}
+
+func (m *MockCandidateRepo) GetProfileRefresh(ctx context.Context, userID string) (*domain.CandidateProfileRefresh, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CandidateProfileRefresh), args.Error(1)
+}
+
+func (m *MockCandidateRepo) CompleteProfileRefresh(ctx context.Context, userID string, update domain.CandidateProfileRefreshUpdate, at time.Time) error {
+	return m.Called(ctx, userID, update, at).Error(0)
+}
+
 type MockUserRepo struct {
 	mock.Mock
 }
@@ -57,30 +103,46 @@ func (m *MockUserRepo) GetByID(ctx context.Context, id string) (*domain.User, er
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
+func (m *MockUserRepo) UpdateByEmail(ctx context.Context, email string, user *domain.User) error {
+	return m.Called(ctx, email, user).Error(0)
+}
+func (m *MockUserRepo) SetPause(ctx context.Context, userID string, until *time.Time, reason *string) error {
+	return m.Called(ctx, userID, until, reason).Error(0)
+}
+func (m *MockUserRepo) SetPreferredLocale(ctx context.Context, userID string, locale *string) error {
+	return m.Called(ctx, userID, locale).Error(0)
+}
+func (m *MockUserRepo) SetEmailConfirmed(ctx context.Context, userID string, at time.Time) error {
+	return m.Called(ctx, userID, at).Error(0)
+}
+func (m *MockUserRepo) Delete(ctx context.Context, userID string) error {
+	return m.Called(ctx, userID).Error(0)
+}
 
 func TestCandidateIDOR(t *testing.T) {
 	mockRepo := new(MockCandidateRepo)
 	validate := validator.New()
-	uc := usecase.NewCandidateUsecase(mockRepo, nil, validate)
+	validation.RegisterValidators(validate)
+	uc := usecase.NewCandidateUsecase(mockRepo, nil, nil, validate)
 
 	t.Run("Should fail when Context UserID does not match Argument UserID", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), domain.KeyUserID, "user1")
 		_, err := uc.GetProfile(ctx, "user2")
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "only view your own profile")
+		assert.Contains(t, err.Error(), "Access denied")
 	})
 
 	t.Run("Should fail safely when Context UserID is nil", func(t *testing.T) {
 		ctx := context.Background() // keys missing
 		_, err := uc.GetProfile(ctx, "user1")
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "User not authenticated")
+		assert.Contains(t, err.Error(), "Not authenticated")
 	})
 }
 
 func TestAuthPrivilege(t *testing.T) {
 	mockRepo := new(MockUserRepo)
-	uc := usecase.NewAuthUsecase(mockRepo)
+	uc := usecase.NewAuthUsecase(mockRepo, nil)
 
 	t.Run("Should fail if role is not admin", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), domain.KeyUserRole, "candidate")
@@ -100,7 +162,8 @@ func TestAuthPrivilege(t *testing.T) {
 func TestCandidateUpdateValidation(t *testing.T) {
 	mockRepo := new(MockCandidateRepo)
 	validate := validator.New()
-	uc := usecase.NewCandidateUsecase(mockRepo, nil, validate)
+	validation.RegisterValidators(validate)
+	uc := usecase.NewCandidateUsecase(mockRepo, nil, nil, validate)
 
 	t.Run("Should fail if required fields are missing", func(t *testing.T) {
 		ctx := context.WithValue(context.Background(), domain.KeyUserID, "user1")
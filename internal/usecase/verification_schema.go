@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"strings"
+	"time"
+)
+
+// verificationField is one configurable profile field: its built-in default,
+// how to tell whether it is filled and how to drop it when hidden
+type verificationField[T any] struct {
+	name     string
+	required bool
+	filled   func(*T) bool
+	clear    func(*T)
+}
+
+// ptrField describes an optional pointer field; blank strings count as empty
+func ptrField[T, V any](name string, required bool, ptr func(*T) **V) verificationField[T] {
+	return verificationField[T]{
+		name:     name,
+		required: required,
+		filled: func(t *T) bool {
+			p := *ptr(t)
+			if p == nil {
+				return false
+			}
+			if s, ok := any(*p).(string); ok {
+				return strings.TrimSpace(s) != ""
+			}
+			return true
+		},
+		clear: func(t *T) { *ptr(t) = nil },
+	}
+}
+
+func sliceField[T, V any](name string, required bool, ptr func(*T) *[]V) verificationField[T] {
+	return verificationField[T]{
+		name:     name,
+		required: required,
+		filled:   func(t *T) bool { return len(*ptr(t)) > 0 },
+		clear:    func(t *T) { *ptr(t) = nil },
+	}
+}
+
+// candidateVerificationFields are the candidate verification form fields, in form order.
+// The required defaults are the fields a profile always needed to be submitted.
+// Guardian consent is not listed: it depends on the candidate's age.
+var candidateVerificationFields = []verificationField[domain.AccountVerification]{
+	// Identity & Profile
+	ptrField("profile_picture_url", true, func(v *domain.AccountVerification) **string { return &v.ProfilePictureURL }),
+	ptrField("first_name", true, func(v *domain.AccountVerification) **string { return &v.FirstName }),
+	ptrField("last_name", true, func(v *domain.AccountVerification) **string { return &v.LastName }),
+	ptrField("occupation", true, func(v *domain.AccountVerification) **string { return &v.Occupation }),
+	ptrField("phone", true, func(v *domain.AccountVerification) **string { return &v.Phone }),
+	ptrField("website_url", false, func(v *domain.AccountVerification) **string { return &v.WebsiteURL }),
+	ptrField("intro", false, func(v *domain.AccountVerification) **string { return &v.Intro }),
+	// Demographics
+	ptrField("gender", false, func(v *domain.AccountVerification) **string { return &v.Gender }),
+	ptrField("birth_date", true, func(v *domain.AccountVerification) **time.Time { return &v.BirthDate }),
+	ptrField("domicile_city", true, func(v *domain.AccountVerification) **string { return &v.DomicileCity }),
+	ptrField("domicile_province", false, func(v *domain.AccountVerification) **string { return &v.DomicileProvince }),
+	ptrField("marital_status", false, func(v *domain.AccountVerification) **string { return &v.MaritalStatus }),
+	ptrField("children_count", false, func(v *domain.AccountVerification) **int { return &v.ChildrenCount }),
+	ptrField("religion", false, func(v *domain.AccountVerification) **string { return &v.Religion }),
+	ptrField("height_cm", false, func(v *domain.AccountVerification) **int16 { return &v.HeightCm }),
+	ptrField("weight_kg", false, func(v *domain.AccountVerification) **float32 { return &v.WeightKg }),
+	// Experience & Japanese
+	ptrField("japan_experience_duration", true, func(v *domain.AccountVerification) **int { return &v.JapanExperienceDuration }),
+	ptrField("japanese_level", false, func(v *domain.AccountVerification) **string { return &v.JapaneseLevel }),
+	ptrField("japanese_speaking_level", false, func(v *domain.AccountVerification) **string { return &v.JapaneseSpeakingLevel }),
+	ptrField("japanese_certificate_url", false, func(v *domain.AccountVerification) **string { return &v.JapaneseCertificateURL }),
+	sliceField("main_job_fields", false, func(v *domain.AccountVerification) *[]string { return &v.MainJobFields }),
+	ptrField("golden_skill", false, func(v *domain.AccountVerification) **string { return &v.GoldenSkill }),
+	// Documents
+	ptrField("cv_url", true, func(v *domain.AccountVerification) **string { return &v.CvURL }),
+	ptrField("portfolio_url", false, func(v *domain.AccountVerification) **string { return &v.PortfolioURL }),
+	sliceField("supporting_certificates_url", false, func(v *domain.AccountVerification) *[]string { return &v.SupportingCertificatesURL }),
+	ptrField("passport_url", false, func(v *domain.AccountVerification) **string { return &v.PassportURL }),
+	ptrField("medical_check_url", false, func(v *domain.AccountVerification) **string { return &v.MedicalCheckURL }),
+	// Expectations & Availability
+	ptrField("expected_salary", false, func(v *domain.AccountVerification) **int64 { return &v.ExpectedSalary }),
+	ptrField("available_start_date", false, func(v *domain.AccountVerification) **time.Time { return &v.AvailableStartDate }),
+	sliceField("preferred_locations", false, func(v *domain.AccountVerification) *[]string { return &v.PreferredLocations }),
+	sliceField("preferred_industries", false, func(v *domain.AccountVerification) *[]string { return &v.PreferredIndustries }),
+	// Emergency contact: the three fields are one unit
+	{
+		name: "emergency_contact",
+		filled: func(v *domain.AccountVerification) bool {
+			return v.EmergencyContactName != nil && *v.EmergencyContactName != ""
+		},
+		clear: func(v *domain.AccountVerification) {
+			v.EmergencyContactName, v.EmergencyContactPhone, v.EmergencyContactRelationship = nil, nil, nil
+		},
+	},
+}
+
+// employerVerificationFields are the company profile fields reviewed for employer verification.
+// Employer profiles are edited elsewhere, so hidden fields are only hidden by the frontend.
+var employerVerificationFields = []verificationField[domain.CompanyProfile]{
+	{
+		name:     "company_name",
+		required: true,
+		filled:   func(p *domain.CompanyProfile) bool { return strings.TrimSpace(p.CompanyName) != "" },
+	},
+	ptrField("logo_url", false, func(p *domain.CompanyProfile) **string { return &p.LogoURL }),
+	ptrField("industry", false, func(p *domain.CompanyProfile) **string { return &p.Industry }),
+	ptrField("website", false, func(p *domain.CompanyProfile) **string { return &p.Website }),
+	ptrField("location", false, func(p *domain.CompanyProfile) **string { return &p.Location }),
+	ptrField("headquarters", false, func(p *domain.CompanyProfile) **string { return &p.Headquarters }),
+	ptrField("founded", false, func(p *domain.CompanyProfile) **string { return &p.Founded }),
+	ptrField("founder", false, func(p *domain.CompanyProfile) **string { return &p.Founder }),
+	ptrField("employee_count", false, func(p *domain.CompanyProfile) **string { return &p.EmployeeCount }),
+	ptrField("description", false, func(p *domain.CompanyProfile) **string { return &p.Description }),
+	ptrField("company_story", false, func(p *domain.CompanyProfile) **string { return &p.CompanyStory }),
+}
+
+// fieldRules resolves each field's requirement: the admin override, else the built-in default
+func fieldRules[T any](fields []verificationField[T], overrides map[string]string) []domain.VerificationFieldRule {
+	rules := make([]domain.VerificationFieldRule, len(fields))
+	for i, f := range fields {
+		rules[i] = domain.VerificationFieldRule{Field: f.name, Default: domain.FieldOptional}
+		if f.required {
+			rules[i].Default = domain.FieldRequired
+		}
+		rules[i].Requirement = rules[i].Default
+		if req, ok := overrides[f.name]; ok {
+			rules[i].Requirement = req
+		}
+	}
+	return rules
+}
+
+// missingRequiredFields lists the required fields that are empty on t
+func missingRequiredFields[T any](fields []verificationField[T], rules []domain.VerificationFieldRule, t *T) []string {
+	var missing []string
+	for i, f := range fields {
+		if rules[i].Requirement == domain.FieldRequired && !f.filled(t) {
+			missing = append(missing, f.name)
+		}
+	}
+	return missing
+}
+
+// clearHiddenFields drops values of fields that are not collected
+func clearHiddenFields[T any](fields []verificationField[T], rules []domain.VerificationFieldRule, t *T) {
+	for i, f := range fields {
+		if rules[i].Requirement == domain.FieldHidden && f.clear != nil {
+			f.clear(t)
+		}
+	}
+}
+
+func requiredFieldsMissing(missing []string) error {
+	return &domain.ProfileRuleError{
+		Code:    domain.ProfileErrRequiredFieldsMissing,
+		Message: "Required fields are missing: " + strings.Join(missing, ", "),
+		Fields:  missing,
+	}
+}
+
+func (uc *verificationUsecase) candidateFieldRules(ctx context.Context) ([]domain.VerificationFieldRule, error) {
+	overrides, err := uc.schemaRepo.ListOverrides(ctx, domain.VerificationRoleCandidate)
+	if err != nil {
+		return nil, err
+	}
+	return fieldRules(candidateVerificationFields, overrides), nil
+}
+
+// checkRequiredFields blocks approving a profile whose required fields are empty
+func (uc *verificationUsecase) checkRequiredFields(ctx context.Context, v *domain.AccountVerification) error {
+	var missing []string
+	switch v.Role {
+	case domain.VerificationRoleCandidate:
+		rules, err := uc.candidateFieldRules(ctx)
+		if err != nil {
+			return err
+		}
+		missing = missingRequiredFields(candidateVerificationFields, rules, v)
+	case domain.VerificationRoleEmployer:
+		overrides, err := uc.schemaRepo.ListOverrides(ctx, domain.VerificationRoleEmployer)
+		if err != nil {
+			return err
+		}
+		profile, err := uc.companyRepo.GetByUserID(ctx, v.UserID)
+		if errors.Is(err, domain.ErrNotFound) {
+			profile = &domain.CompanyProfile{}
+		} else if err != nil {
+			return err
+		}
+		missing = missingRequiredFields(employerVerificationFields, fieldRules(employerVerificationFields, overrides), profile)
+	}
+	if len(missing) > 0 {
+		return requiredFieldsMissing(missing)
+	}
+	return nil
+}
+
+func (uc *verificationUsecase) GetVerificationSchema(ctx context.Context, role string) (*domain.VerificationSchema, error) {
+	role = domain.VerificationRole(role)
+	if role != domain.VerificationRoleCandidate && role != domain.VerificationRoleEmployer {
+		return nil, apperror.BadRequest("role must be CANDIDATE or EMPLOYER")
+	}
+
+	overrides, err := uc.schemaRepo.ListOverrides(ctx, role)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch verification schema: " + err.Error()))
+	}
+	schema := &domain.VerificationSchema{Role: role}
+	if role == domain.VerificationRoleCandidate {
+		schema.Fields = fieldRules(candidateVerificationFields, overrides)
+	} else {
+		schema.Fields = fieldRules(employerVerificationFields, overrides)
+	}
+	return schema, nil
+}
+
+func (uc *verificationUsecase) UpdateVerificationSchema(ctx context.Context, adminID, role string, req domain.UpdateVerificationSchemaRequest) (*domain.VerificationSchema, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	// 1. Only fields the role's form actually has
+	current, err := uc.GetVerificationSchema(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(current.Fields))
+	for _, f := range current.Fields {
+		known[f.Field] = true
+	}
+	overrides := make([]domain.VerificationFieldOverride, 0, len(req.Fields))
+	for _, in := range req.Fields {
+		if !known[in.Field] {
+			return nil, apperror.BadRequest("Unknown verification field: " + in.Field)
+		}
+		overrides = append(overrides, domain.VerificationFieldOverride{
+			Role:        current.Role,
+			Field:       in.Field,
+			Requirement: in.Requirement,
+			UpdatedBy:   &adminID,
+		})
+	}
+
+	// 2. Store; the rules apply from the next profile update or approval
+	if err := uc.schemaRepo.UpsertOverrides(ctx, overrides); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to update verification schema: " + err.Error()))
+	}
+	return uc.GetVerificationSchema(ctx, current.Role)
+}
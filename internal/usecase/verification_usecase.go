@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/email"
+	"go-recruitment-backend/pkg/logger"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -12,12 +16,43 @@ import (
 type verificationUsecase struct {
 	verificationRepo domain.VerificationRepository
 	userRepo         domain.UserRepository // If needed for status updates on user table?
+	schemaRepo       domain.VerificationSchemaRepository
+	companyRepo      domain.CompanyProfileRepository
+	calendar         domain.BusinessCalendar
+	slaBusinessDays  int
+	consentUnderAge  int // candidates younger than this need guardian consent
+	events           domain.RealtimePublisher
+	hooks            domain.WebhookPublisher
+	mailer           *email.EmailService
+	companyLevels    domain.CompanyVerificationRepository
+	inbox            domain.InAppNotifier
 }
 
-func NewVerificationUsecase(repo domain.VerificationRepository, uRepo domain.UserRepository) domain.VerificationUsecase {
+// NewVerificationUsecase creates the verification usecase. Pending reviews are
+// given a due date slaBusinessDays working days (Indonesian calendar) after
+// submission; calendar may be nil to disable SLA tracking. Candidates younger
+// than consentUnderAge cannot submit (or be approved) without a guardian consent
+// document. Required fields come from the admin-configurable schema in
+// schemaRepo; employer fields are read from the company profile. Decisions are
+// pushed to the user through events and emailed through mailer, and approvals
+// are shared with integrations through hooks; any of them may be nil. Approving
+// an employer raises their company to the STANDARD verification level through
+// companyLevels, which may be nil too. Decisions are also recorded in the user's
+// notification center through inbox, which may be nil.
+func NewVerificationUsecase(repo domain.VerificationRepository, uRepo domain.UserRepository, schemaRepo domain.VerificationSchemaRepository, companyRepo domain.CompanyProfileRepository, calendar domain.BusinessCalendar, slaBusinessDays int, consentUnderAge int, events domain.RealtimePublisher, hooks domain.WebhookPublisher, mailer *email.EmailService, companyLevels domain.CompanyVerificationRepository, inbox domain.InAppNotifier) domain.VerificationUsecase {
 	return &verificationUsecase{
 		verificationRepo: repo,
 		userRepo:         uRepo,
+		schemaRepo:       schemaRepo,
+		companyRepo:      companyRepo,
+		calendar:         calendar,
+		slaBusinessDays:  slaBusinessDays,
+		consentUnderAge:  consentUnderAge,
+		events:           events,
+		hooks:            hooks,
+		mailer:           mailer,
+		companyLevels:    companyLevels,
+		inbox:            inbox,
 	}
 }
 
@@ -27,7 +62,7 @@ func (uc *verificationUsecase) GetPendingVerifications(ctx context.Context, page
 		Page:   page,
 		Limit:  limit,
 	}
-	return uc.verificationRepo.List(ctx, filter)
+	return uc.listWithSLA(ctx, filter)
 }
 
 func (uc *verificationUsecase) ListVerifications(ctx context.Context, filter domain.VerificationFilter) ([]domain.AccountVerification, int64, error) {
@@ -37,9 +72,44 @@ func (uc *verificationUsecase) ListVerifications(ctx context.Context, filter dom
 	if filter.Limit < 1 {
 		filter.Limit = 10
 	}
-	return uc.verificationRepo.List(ctx, filter)
+	return uc.listWithSLA(ctx, filter)
 }
 
+func (uc *verificationUsecase) listWithSLA(ctx context.Context, filter domain.VerificationFilter) ([]domain.AccountVerification, int64, error) {
+	filter.ScopeProvinces = domain.AdminProvincesFromContext(ctx)
+	verifications, total, err := uc.verificationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range verifications {
+		uc.applyReviewSLA(ctx, &verifications[i])
+	}
+	return verifications, total, nil
+}
+
+// applyReviewSLA sets the review due date on pending verifications.
+// SLA is informational, so calendar failures leave the fields empty.
+func (uc *verificationUsecase) applyReviewSLA(ctx context.Context, v *domain.AccountVerification) {
+	if uc.calendar == nil || uc.slaBusinessDays <= 0 || v == nil || v.Status != domain.VerificationStatusPending {
+		return
+	}
+	due, err := uc.calendar.AddBusinessDays(ctx, v.SubmittedAt.In(jakartaLocation), uc.slaBusinessDays, domain.CalendarIndonesia)
+	if err != nil {
+		return
+	}
+	v.ReviewDueAt = &due
+	v.ReviewOverdue = time.Now().After(due)
+}
+
+// jakartaLocation is used so business days follow the reviewers' calendar dates
+var jakartaLocation = func() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}()
+
 func (uc *verificationUsecase) VerifyUser(ctx context.Context, adminID string, verificationID int64, action string, notes string) error {
 	// 1. Get current verification
 	v, err := uc.verificationRepo.GetByID(ctx, verificationID)
@@ -47,7 +117,13 @@ func (uc *verificationUsecase) VerifyUser(ctx context.Context, adminID string, v
 		return err
 	}
 	if v == nil {
-		return errors.New("verification record not found")
+		return errVerificationNotFound
+	}
+	if ok, err := uc.inAdminScope(ctx, v); err != nil || !ok {
+		if err != nil {
+			return err
+		}
+		return errVerificationNotFound
 	}
 
 	// 2. Validate action
@@ -60,9 +136,91 @@ func (uc *verificationUsecase) VerifyUser(ctx context.Context, adminID string, v
 	} else {
 		return errors.New("invalid action: must be APPROVE or REJECT")
 	}
+	if newStatus == domain.VerificationStatusVerified {
+		if uc.missingGuardianConsent(v, time.Now()) {
+			return errGuardianConsentRequired
+		}
+		if err := uc.checkRequiredFields(ctx, v); err != nil {
+			return err
+		}
+	}
 
 	// 3. Update status
-	return uc.verificationRepo.UpdateStatus(ctx, verificationID, newStatus, adminID, notes)
+	if err := uc.verificationRepo.UpdateStatus(ctx, verificationID, newStatus, adminID, notes); err != nil {
+		return err
+	}
+
+	// 4. Reviewed employer documents unlock the STANDARD company level
+	if newStatus == domain.VerificationStatusVerified && v.Role == domain.VerificationRoleEmployer && uc.companyLevels != nil {
+		if _, err := uc.companyLevels.RaiseLevel(ctx, v.UserID, domain.CompanyLevelStandard, "Employer verification approved"); err != nil {
+			logger.FromContext(ctx).Error("Failed to raise company verification level", "user_id", v.UserID, "error", err)
+		}
+	}
+
+	// 5. Push the decision to the user if they are connected
+	publishRealtime(ctx, uc.events, v.UserID, domain.RealtimeEvent{
+		Type: domain.RealtimeEventVerificationDecision,
+		Data: domain.VerificationDecisionEvent{VerificationID: verificationID, Status: newStatus, Notes: notes},
+	})
+
+	// 6. Approvals are shared with subscribed integrations
+	if newStatus == domain.VerificationStatusVerified {
+		publishWebhook(ctx, uc.hooks, domain.WebhookEventVerificationApproved, domain.VerificationApprovedWebhook{
+			VerificationID: verificationID,
+			UserID:         v.UserID,
+			Role:           v.Role,
+			ApprovedAt:     time.Now().UTC(),
+		})
+	}
+
+	// 7. Recorded in the user's notification center
+	if uc.inbox != nil {
+		n := &domain.Notification{UserID: v.UserID, Category: domain.NotificationCategoryAccount}
+		switch {
+		case newStatus == domain.VerificationStatusVerified:
+			n.Subject, n.Body = "Your account is verified", "Your account verification was approved."
+		case notes != "":
+			n.Subject, n.Body, n.BodyArgs = "Your account verification was rejected", "Your account verification was rejected: %s", []any{notes}
+		default:
+			n.Subject, n.Body = "Your account verification was rejected", "Your account verification was rejected. Please review your details and submit again."
+		}
+		if err := uc.inbox.NotifyInApp(ctx, n); err != nil {
+			logger.FromContext(ctx).Warn("Failed to record verification notification", "user_id", v.UserID, "error", err)
+		}
+	}
+
+	// 8. And emailed to the user
+	if uc.mailer != nil {
+		name := verificationDisplayName(v)
+		if newStatus == domain.VerificationStatusVerified {
+			sendUserEmail(ctx, uc.mailer, uc.userRepo, v.UserID, email.TemplateVerificationApproved, email.VerificationApprovedData{
+				RecipientName: name,
+				ActionURL:     uc.mailer.Link(""),
+			})
+		} else {
+			resubmitPath := ""
+			if v.Role == domain.VerificationRoleCandidate {
+				resubmitPath = "/candidate/profile"
+			}
+			sendUserEmail(ctx, uc.mailer, uc.userRepo, v.UserID, email.TemplateVerificationRejected, email.VerificationRejectedData{
+				RecipientName: name,
+				Reason:        notes,
+				ActionURL:     uc.mailer.Link(resubmitPath),
+			})
+		}
+	}
+	return nil
+}
+
+// verificationDisplayName is the name the user gave in their verification, if any
+func verificationDisplayName(v *domain.AccountVerification) string {
+	var parts []string
+	for _, p := range []*string{v.FirstName, v.LastName} {
+		if p != nil && strings.TrimSpace(*p) != "" {
+			parts = append(parts, strings.TrimSpace(*p))
+		}
+	}
+	return strings.Join(parts, " ")
 }
 
 func (uc *verificationUsecase) GetVerificationStatus(ctx context.Context, userID string) (*domain.VerificationResponse, error) {
@@ -73,6 +231,7 @@ func (uc *verificationUsecase) GetVerificationStatus(ctx context.Context, userID
 	if v == nil {
 		return nil, nil // Or return a "not found" error or empty struct
 	}
+	uc.applyReviewSLA(ctx, v)
 
 	experiences, err := uc.verificationRepo.GetWorkExperiences(ctx, v.ID)
 	if err != nil {
@@ -85,6 +244,45 @@ func (uc *verificationUsecase) GetVerificationStatus(ctx context.Context, userID
 	}, nil
 }
 
+func (uc *verificationUsecase) GetProfileCompleteness(ctx context.Context, userID string) (*domain.ProfileCompleteness, error) {
+	if err := requireRole(ctx, domain.RoleCandidate); err != nil {
+		return nil, err
+	}
+	v, err := uc.verificationRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch profile: " + err.Error()))
+	}
+	hasExperience, err := uc.verificationRepo.HasWorkExperience(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch work experience: " + err.Error()))
+	}
+
+	complete := map[string]bool{domain.ProfileSectionWorkExperience: hasExperience}
+	if v != nil {
+		complete[domain.ProfileSectionPhoto] = filled(v.ProfilePictureURL)
+		complete[domain.ProfileSectionCV] = filled(v.CvURL)
+		complete[domain.ProfileSectionJLPTCertificate] = filled(v.JapaneseCertificateURL)
+		complete[domain.ProfileSectionPreferences] = v.ExpectedSalary != nil && len(v.PreferredLocations) > 0
+	}
+
+	result := &domain.ProfileCompleteness{Checklist: []domain.ProfileChecklistItem{}, Missing: []string{}}
+	for _, w := range domain.ProfileCompletenessWeights {
+		result.Checklist = append(result.Checklist, domain.ProfileChecklistItem{Section: w.Section, Points: w.Points, Complete: complete[w.Section]})
+		if complete[w.Section] {
+			result.Score += w.Points
+		} else {
+			result.Missing = append(result.Missing, w.Section)
+		}
+	}
+	result.Score = min(result.Score, 100)
+	return result, nil
+}
+
+// filled reports whether an optional text field holds a non-blank value
+func filled(s *string) bool {
+	return s != nil && strings.TrimSpace(*s) != ""
+}
+
 func (uc *verificationUsecase) GetVerificationByID(ctx context.Context, id int64) (*domain.VerificationResponse, error) {
 	v, err := uc.verificationRepo.GetByID(ctx, id)
 	if err != nil {
@@ -93,6 +291,10 @@ func (uc *verificationUsecase) GetVerificationByID(ctx context.Context, id int64
 	if v == nil {
 		return nil, nil
 	}
+	if ok, err := uc.inAdminScope(ctx, v); err != nil || !ok {
+		return nil, err
+	}
+	uc.applyReviewSLA(ctx, v)
 
 	experiences, err := uc.verificationRepo.GetWorkExperiences(ctx, v.ID)
 	if err != nil {
@@ -106,7 +308,12 @@ func (uc *verificationUsecase) GetVerificationByID(ctx context.Context, id int64
 }
 
 func (uc *verificationUsecase) UpdateCandidateProfile(ctx context.Context, userID string, verification *domain.AccountVerification, experiences []domain.JapanWorkExperience) error {
-	// 1. Validate enum fields (MANDATORY backend validation)
+	// 1. Drop fields the schema hides, then validate enum fields (MANDATORY backend validation)
+	rules, err := uc.candidateFieldRules(ctx)
+	if err != nil {
+		return err
+	}
+	clearHiddenFields(candidateVerificationFields, rules, verification)
 	if verification.MaritalStatus != nil && *verification.MaritalStatus != "" {
 		if !slices.Contains(domain.ValidMaritalStatuses, *verification.MaritalStatus) {
 			return errors.New("invalid marital_status: must be SINGLE, MARRIED, or DIVORCED")
@@ -117,6 +324,20 @@ func (uc *verificationUsecase) UpdateCandidateProfile(ctx context.Context, userI
 			return errors.New("invalid japanese_speaking_level: must be NATIVE, FLUENT, BASIC, or PASSIVE")
 		}
 	}
+	now := time.Now()
+	if err := validateVisaReadiness(verification, now); err != nil {
+		return err
+	}
+	if err := validateEmergencyContact(verification); err != nil {
+		return err
+	}
+
+	// A profile is complete once every required field in the schema is filled;
+	// a minor's complete profile is only submitted once guardian consent is uploaded
+	isComplete := len(missingRequiredFields(candidateVerificationFields, rules, verification)) == 0
+	if isComplete && uc.missingGuardianConsent(verification, now) {
+		return errGuardianConsentRequired
+	}
 
 	// 2. Check existence
 	existing, err := uc.verificationRepo.GetByUserID(ctx, userID)
@@ -126,7 +347,7 @@ func (uc *verificationUsecase) UpdateCandidateProfile(ctx context.Context, userI
 
 	// 3. Set up the verification record
 	verification.UserID = userID
-	verification.Role = "CANDIDATE"
+	verification.Role = domain.VerificationRoleCandidate
 
 	if existing == nil {
 		// Create a new verification record
@@ -141,78 +362,184 @@ func (uc *verificationUsecase) UpdateCandidateProfile(ctx context.Context, userI
 		verification.ID = existing.ID
 	}
 
-	// =========================================================================
-	// Mandatory Field Validation for Verification Completion
-	// =========================================================================
-	// MANDATORY fields (all must be filled for SUBMITTED status):
-	// - Profile Picture, First Name, Last Name, Occupation
-	// - Phone, Birth Date, Domicile City
-	// - Japan Experience Duration, CV Document
-	// - At least one Japan Work Experience
-	//
-	// OPTIONAL fields (do NOT affect completion status):
-	// - JLPT Certificate, Portfolio URL, Website URL, Intro
-	// =========================================================================
-
-	isComplete := true
+	// Always update submittedAt on every profile modification for accurate admin sorting
+	verification.SubmittedAt = now
 
-	// Identity & Profile (Mandatory)
-	if verification.ProfilePictureURL == nil || *verification.ProfilePictureURL == "" {
-		isComplete = false
+	if isComplete {
+		verification.Status = domain.VerificationStatusSubmitted
+	} else {
+		verification.Status = domain.VerificationStatusPending // Keep status as pending until complete
 	}
-	if verification.FirstName == nil || *verification.FirstName == "" {
-		isComplete = false
+
+	// Keep existing ID, UserID, CreatedAt, etc. The repository update query handles the updated fields.
+
+	return uc.verificationRepo.UpdateProfile(ctx, verification, experiences)
+}
+
+func (uc *verificationUsecase) GetComprehensiveVerificationByID(ctx context.Context, id int64) (*domain.ComprehensiveVerificationResponse, error) {
+	resp, err := uc.verificationRepo.GetComprehensiveByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	if verification.LastName == nil || *verification.LastName == "" {
-		isComplete = false
+	if resp != nil {
+		if ok, err := uc.inAdminScope(ctx, resp.Verification); err != nil || !ok {
+			return nil, err
+		}
+		uc.applyReviewSLA(ctx, resp.Verification)
 	}
-	if verification.Occupation == nil || *verification.Occupation == "" {
-		isComplete = false
+	return resp, nil
+}
+
+// inAdminScope reports whether a regional admin may see v: candidates by their
+// domicile province, employers by their company's province. Out-of-scope
+// records are treated as missing.
+func (uc *verificationUsecase) inAdminScope(ctx context.Context, v *domain.AccountVerification) (bool, error) {
+	scope := domain.AdminProvincesFromContext(ctx)
+	if scope == nil {
+		return true, nil
 	}
-	if verification.Phone == nil || *verification.Phone == "" {
-		isComplete = false
+	province := v.DomicileProvince
+	if v.Role == domain.VerificationRoleEmployer {
+		province = nil
+		profile, err := uc.companyRepo.GetByUserID(ctx, v.UserID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return false, err
+		}
+		if profile != nil {
+			province = profile.Province
+		}
 	}
+	return domain.ProvinceInScope(scope, province), nil
+}
 
-	// Demographics (Mandatory)
-	if verification.BirthDate == nil {
-		isComplete = false
+// validateVisaReadiness checks CoE and SSW exam fields and normalizes them for storage
+func validateVisaReadiness(v *domain.AccountVerification, now time.Time) error {
+	// 1. Certificate of Eligibility
+	if v.CoEStatus != nil && *v.CoEStatus == "" {
+		v.CoEStatus = nil
 	}
-	if verification.DomicileCity == nil || *verification.DomicileCity == "" {
-		isComplete = false
+	if v.CoEStatus != nil {
+		if !slices.Contains(domain.ValidCoEStatuses, *v.CoEStatus) {
+			return errors.New("invalid coe_status: must be NONE, IN_PROCESS, ISSUED, or EXPIRED")
+		}
+		switch *v.CoEStatus {
+		case domain.CoEStatusNone, domain.CoEStatusInProcess:
+			// Dates only exist once a CoE has been issued
+			v.CoEIssuedDate = nil
+			v.CoEExpiryDate = nil
+		case domain.CoEStatusIssued:
+			if v.CoEIssuedDate == nil {
+				return errors.New("coe_issued_date is required when coe_status is ISSUED")
+			}
+		}
 	}
-
-	// Experience (Mandatory)
-	if verification.JapanExperienceDuration == nil {
-		isComplete = false
+	if v.CoEIssuedDate != nil && v.CoEIssuedDate.After(now) {
+		return errors.New("coe_issued_date cannot be in the future")
+	}
+	if v.CoEIssuedDate != nil && v.CoEExpiryDate != nil && !v.CoEExpiryDate.After(*v.CoEIssuedDate) {
+		return errors.New("coe_expiry_date must be after coe_issued_date")
 	}
 
-	// CV Document Upload (MANDATORY - not JLPT certificate)
-	if verification.CvURL == nil || *verification.CvURL == "" {
-		isComplete = false
+	// 2. SSW exam passes
+	if v.SSWExamResults == nil {
+		v.SSWExamResults = []domain.SSWExamResult{}
+	}
+	seen := make(map[string]bool, len(v.SSWExamResults))
+	for i := range v.SSWExamResults {
+		r := &v.SSWExamResults[i]
+		r.Sector = strings.ToUpper(strings.TrimSpace(r.Sector))
+		if !slices.Contains(domain.ValidSSWSectors, r.Sector) {
+			return errors.New("invalid ssw_exam_results sector: " + r.Sector)
+		}
+		if r.Level != 1 && r.Level != 2 {
+			return errors.New("invalid ssw_exam_results level: must be 1 or 2")
+		}
+		if r.PassedDate.IsZero() || r.PassedDate.After(now) {
+			return errors.New("ssw_exam_results passed_date is required and cannot be in the future")
+		}
+		key := r.Sector + "/" + strconv.Itoa(r.Level)
+		if seen[key] {
+			return errors.New("duplicate ssw_exam_results entry for " + r.Sector)
+		}
+		seen[key] = true
 	}
 
-	// NOTE: JapaneseCertificateURL (JLPT) is now OPTIONAL - removed from mandatory checks
-	// NOTE: PortfolioURL is OPTIONAL - not checked
+	return nil
+}
 
-	// NOTE: Japan Work Experience (experiences) is no longer required via this form.
-	// Work experience is now captured in the unified work_experiences table through
-	// the Professional Profile form. The old japan_work_experiences is deprecated.
-	// Removed: if len(experiences) == 0 { isComplete = false }
+var errVerificationNotFound = apperror.NotFound("Verification not found").WithCode(domain.ErrCodeVerificationNotFound)
 
-	// Always update submittedAt on every profile modification for accurate admin sorting
-	verification.SubmittedAt = time.Now()
+var errGuardianConsentRequired = &domain.ProfileRuleError{
+	Code:    domain.ProfileErrGuardianConsentRequired,
+	Message: "Guardian consent document is required for candidates under the minimum age",
+}
 
-	if isComplete {
-		verification.Status = domain.VerificationStatusSubmitted
-	} else {
-		verification.Status = domain.VerificationStatusPending // Keep status as pending until complete
+// missingGuardianConsent reports whether the candidate is a minor without a consent document
+func (uc *verificationUsecase) missingGuardianConsent(v *domain.AccountVerification, now time.Time) bool {
+	if uc.consentUnderAge <= 0 || v.BirthDate == nil {
+		return false
 	}
+	if v.GuardianConsentURL != nil && *v.GuardianConsentURL != "" {
+		return false
+	}
+	return ageOn(*v.BirthDate, now.In(jakartaLocation)) < uc.consentUnderAge
+}
 
-	// Keep existing ID, UserID, CreatedAt, etc. The repository update query handles the updated fields.
-
-	return uc.verificationRepo.UpdateProfile(ctx, verification, experiences)
+// ageOn returns the age in completed years on the calendar date of now
+func ageOn(birth, now time.Time) int {
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+	return age
 }
 
-func (uc *verificationUsecase) GetComprehensiveVerificationByID(ctx context.Context, id int64) (*domain.ComprehensiveVerificationResponse, error) {
-	return uc.verificationRepo.GetComprehensiveByID(ctx, id)
+// validateEmergencyContact checks the emergency contact and normalizes it for storage
+func validateEmergencyContact(v *domain.AccountVerification) error {
+	for _, f := range []**string{&v.EmergencyContactName, &v.EmergencyContactPhone, &v.EmergencyContactRelationship} {
+		if *f != nil {
+			if trimmed := strings.TrimSpace(**f); trimmed == "" {
+				*f = nil
+			} else {
+				*f = &trimmed
+			}
+		}
+	}
+	name, phone, relationship := v.EmergencyContactName, v.EmergencyContactPhone, v.EmergencyContactRelationship
+	if name == nil && phone == nil && relationship == nil {
+		return nil
+	}
+	if name == nil || phone == nil || relationship == nil {
+		return &domain.ProfileRuleError{
+			Code:    domain.ProfileErrEmergencyContactIncomplete,
+			Message: "Emergency contact needs a name, phone number and relationship",
+		}
+	}
+
+	upper := strings.ToUpper(*relationship)
+	if !slices.Contains(domain.ValidEmergencyContactRelationships, upper) {
+		return &domain.ProfileRuleError{
+			Code:    domain.ProfileErrEmergencyContactRelationship,
+			Message: "Invalid emergency contact relationship",
+		}
+	}
+	v.EmergencyContactRelationship = &upper
+
+	normalized, err := normalizePhoneE164(*phone)
+	if err != nil {
+		return &domain.ProfileRuleError{
+			Code:    domain.ProfileErrEmergencyContactPhone,
+			Message: "Invalid emergency contact phone number",
+		}
+	}
+	v.EmergencyContactPhone = &normalized
+	if v.Phone != nil {
+		if own, err := normalizePhoneE164(*v.Phone); err == nil && own == normalized {
+			return &domain.ProfileRuleError{
+				Code:    domain.ProfileErrEmergencyContactSelf,
+				Message: "Emergency contact phone number must differ from your own",
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockVerificationRepo struct {
+	domain.VerificationRepository
+	mock.Mock
+}
+
+func (m *MockVerificationRepo) GetByUserID(ctx context.Context, userID string) (*domain.AccountVerification, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AccountVerification), args.Error(1)
+}
+
+func (m *MockVerificationRepo) HasWorkExperience(ctx context.Context, userID string) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestVerificationUsecase_GetProfileCompleteness(t *testing.T) {
+	salary := int64(250000)
+	full := &domain.AccountVerification{
+		ProfilePictureURL:      strPtr("https://storage.example.com/Profile_Picture/me.jpg"),
+		CvURL:                  strPtr("https://storage.example.com/CV/cv.pdf"),
+		JapaneseCertificateURL: strPtr("https://storage.example.com/JLPT/n3.pdf"),
+		ExpectedSalary:         &salary,
+		PreferredLocations:     []string{"Tokyo"},
+	}
+
+	cases := []struct {
+		name          string
+		profile       *domain.AccountVerification
+		hasExperience bool
+		wantScore     int
+		wantMissing   []string
+	}{
+		{
+			name:      "no profile",
+			wantScore: 0,
+			wantMissing: []string{domain.ProfileSectionPhoto, domain.ProfileSectionCV, domain.ProfileSectionJLPTCertificate,
+				domain.ProfileSectionWorkExperience, domain.ProfileSectionPreferences},
+		},
+		{
+			name:    "blank fields",
+			profile: &domain.AccountVerification{ProfilePictureURL: strPtr(" "), CvURL: strPtr(""), ExpectedSalary: &salary},
+			wantMissing: []string{domain.ProfileSectionPhoto, domain.ProfileSectionCV, domain.ProfileSectionJLPTCertificate,
+				domain.ProfileSectionWorkExperience, domain.ProfileSectionPreferences},
+		},
+		{
+			name:        "partial",
+			profile:     &domain.AccountVerification{ProfilePictureURL: full.ProfilePictureURL, CvURL: full.CvURL},
+			wantScore:   45,
+			wantMissing: []string{domain.ProfileSectionJLPTCertificate, domain.ProfileSectionWorkExperience, domain.ProfileSectionPreferences},
+		},
+		{
+			name:          "work experience only",
+			hasExperience: true,
+			wantScore:     20,
+			wantMissing: []string{domain.ProfileSectionPhoto, domain.ProfileSectionCV, domain.ProfileSectionJLPTCertificate,
+				domain.ProfileSectionPreferences},
+		},
+		{
+			name:          "full",
+			profile:       full,
+			hasExperience: true,
+			wantScore:     100,
+			wantMissing:   []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(MockVerificationRepo)
+			if tc.profile == nil {
+				repo.On("GetByUserID", mock.Anything, "user-1").Return(nil, nil)
+			} else {
+				repo.On("GetByUserID", mock.Anything, "user-1").Return(tc.profile, nil)
+			}
+			repo.On("HasWorkExperience", mock.Anything, "user-1").Return(tc.hasExperience, nil)
+
+			uc := usecase.NewVerificationUsecase(repo, nil, nil, nil, nil, 0, 0, nil, nil, nil, nil, nil)
+			ctx := context.WithValue(context.Background(), domain.KeyUserRole, domain.RoleCandidate)
+
+			got, err := uc.GetProfileCompleteness(ctx, "user-1")
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantScore, got.Score)
+			assert.Equal(t, tc.wantMissing, got.Missing)
+			require.Len(t, got.Checklist, len(domain.ProfileCompletenessWeights))
+			for i, item := range got.Checklist {
+				assert.Equal(t, domain.ProfileCompletenessWeights[i].Section, item.Section)
+				assert.Equal(t, !slices.Contains(tc.wantMissing, item.Section), item.Complete, item.Section)
+			}
+		})
+	}
+}
+
+func TestVerificationUsecase_GetProfileCompletenessCap(t *testing.T) {
+	total := 0
+	for _, w := range domain.ProfileCompletenessWeights {
+		total += w.Points
+	}
+	assert.Equal(t, 100, total, "the weights add up to a full profile")
+
+	// A profile can never score more than 100, even if the weights are changed
+	weights := domain.ProfileCompletenessWeights
+	t.Cleanup(func() { domain.ProfileCompletenessWeights = weights })
+	domain.ProfileCompletenessWeights = append(append([]domain.ProfileCompletenessWeight{}, weights...),
+		domain.ProfileCompletenessWeight{Section: domain.ProfileSectionWorkExperience, Points: 20})
+
+	salary := int64(250000)
+	repo := new(MockVerificationRepo)
+	repo.On("GetByUserID", mock.Anything, "user-1").Return(&domain.AccountVerification{
+		ProfilePictureURL:      strPtr("photo"),
+		CvURL:                  strPtr("cv"),
+		JapaneseCertificateURL: strPtr("jlpt"),
+		ExpectedSalary:         &salary,
+		PreferredLocations:     []string{"Osaka"},
+	}, nil)
+	repo.On("HasWorkExperience", mock.Anything, "user-1").Return(true, nil)
+	uc := usecase.NewVerificationUsecase(repo, nil, nil, nil, nil, 0, 0, nil, nil, nil, nil, nil)
+	ctx := context.WithValue(context.Background(), domain.KeyUserRole, domain.RoleCandidate)
+
+	got, err := uc.GetProfileCompleteness(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 100, got.Score)
+}
+
+func TestVerificationUsecase_GetProfileCompletenessCandidatesOnly(t *testing.T) {
+	uc := usecase.NewVerificationUsecase(new(MockVerificationRepo), nil, nil, nil, nil, 0, 0, nil, nil, nil, nil, nil)
+	ctx := context.WithValue(context.Background(), domain.KeyUserRole, domain.RoleEmployer)
+
+	_, err := uc.GetProfileCompleteness(ctx, "user-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/parquet"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// warehouseRowsPerFile caps one export file (and the rows held in memory)
+	warehouseRowsPerFile = 100000
+	// warehouseSettleDelay leaves recent rows for the next run, so a transaction
+	// that commits late with an earlier timestamp is not skipped by the watermark
+	warehouseSettleDelay = 5 * time.Minute
+	warehouseKeyPrefix   = "warehouse"
+)
+
+// WarehouseConfig tunes the data warehouse export
+type WarehouseConfig struct {
+	Format       string // parquet (default) or csv
+	PseudonymKey string // HMAC key for pseudonymized columns; exports are refused without it
+}
+
+type warehouseUsecase struct {
+	repo    domain.WarehouseRepository
+	store   domain.WarehouseStore
+	cfg     WarehouseConfig
+	running sync.Mutex
+}
+
+// NewWarehouseUsecase creates the warehouse export usecase. A nil store disables
+// exports; the admin status endpoint still works.
+func NewWarehouseUsecase(repo domain.WarehouseRepository, store domain.WarehouseStore, cfg WarehouseConfig) domain.WarehouseUsecase {
+	if cfg.Format != domain.WarehouseFormatCSV {
+		cfg.Format = domain.WarehouseFormatParquet
+	}
+	return &warehouseUsecase{repo: repo, store: store, cfg: cfg}
+}
+
+// RunExport exports every table's rows changed since its watermark. A failing
+// table is recorded and skipped; the others are still exported.
+func (u *warehouseUsecase) RunExport(ctx context.Context) (*domain.WarehouseExportRun, error) {
+	if u.store == nil {
+		return nil, apperror.BadRequest("Warehouse export storage is not configured")
+	}
+	if u.cfg.PseudonymKey == "" {
+		return nil, apperror.BadRequest("Warehouse pseudonym key is not configured")
+	}
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A warehouse export is already in progress")
+	}
+	defer u.running.Unlock()
+
+	run := &domain.WarehouseExportRun{StartedAt: time.Now().UTC()}
+	until := run.StartedAt.Add(-warehouseSettleDelay)
+	for _, table := range domain.WarehouseTables {
+		state := u.exportTable(ctx, table, run.StartedAt, until)
+		if state.LastStatus != nil && *state.LastStatus == domain.WarehouseExportFailed {
+			run.Failed++
+		}
+		run.Tables = append(run.Tables, *state)
+	}
+	run.FinishedAt = time.Now().UTC()
+
+	if run.Failed > 0 {
+		return run, apperror.Internal(fmt.Errorf("Warehouse export failed for %d table(s)", run.Failed))
+	}
+	return run, nil
+}
+
+// exportTable writes one table's increment and records the outcome in its state
+func (u *warehouseUsecase) exportTable(ctx context.Context, table domain.WarehouseTable, startedAt, until time.Time) *domain.WarehouseExportState {
+	l := logger.FromContext(ctx).With("table", table.Name, "schema_version", table.SchemaVersion)
+
+	// 1. Resume after the watermark; a new schema version starts over under its own prefix
+	state, err := u.repo.GetState(ctx, table.Name)
+	if err != nil {
+		state = &domain.WarehouseExportState{Table: table.Name, SchemaVersion: table.SchemaVersion}
+		return u.saveFailure(ctx, state, startedAt, fmt.Errorf("load state: %w", err))
+	}
+	if state == nil {
+		state = &domain.WarehouseExportState{Table: table.Name}
+	}
+	after := domain.WarehouseCursor{}
+	if state.Watermark != nil && state.SchemaVersion == table.SchemaVersion {
+		after = domain.WarehouseCursor{At: *state.Watermark, ID: math.MaxInt64}
+	} else if state.Watermark != nil {
+		l.Info("Warehouse schema changed, re-exporting table", "previous_version", state.SchemaVersion)
+	}
+	state.SchemaVersion = table.SchemaVersion
+
+	// 2. Write the increment in files of at most warehouseRowsPerFile rows
+	prefix := fmt.Sprintf("%s/%s/v%d/dt=%s", warehouseKeyPrefix, table.Name, table.SchemaVersion, startedAt.Format("2006-01-02"))
+	rowCount, fileCount, err := u.writeIncrement(ctx, table, prefix, after, until, startedAt)
+	if err != nil {
+		return u.saveFailure(ctx, state, startedAt, err)
+	}
+
+	// 3. Advance the watermark only once every file is written
+	state.Watermark = &until
+	state.LastSuccessAt = &startedAt
+	state.LastAttemptAt = &startedAt
+	state.LastRowCount = rowCount
+	state.LastFileCount = fileCount
+	state.LastObjectPrefix = &prefix
+	status := domain.WarehouseExportCompleted
+	state.LastStatus = &status
+	state.LastError = nil
+	if err := u.repo.SaveState(context.WithoutCancel(ctx), state); err != nil {
+		// The files are written, so the next run re-exports the same rows
+		l.Error("Warehouse export: failed to save state", "error", err)
+	}
+	l.Info("Warehouse export finished", "rows", rowCount, "files", fileCount, "prefix", prefix)
+	return state
+}
+
+func (u *warehouseUsecase) writeIncrement(ctx context.Context, table domain.WarehouseTable, prefix string, after domain.WarehouseCursor, until, startedAt time.Time) (int64, int, error) {
+	idIdx, watermarkIdx := -1, -1
+	for i, col := range table.Columns {
+		switch col.Name {
+		case "id":
+			idIdx = i
+		case table.WatermarkColumn:
+			watermarkIdx = i
+		}
+	}
+	if idIdx < 0 || watermarkIdx < 0 {
+		return 0, 0, errors.New("table spec needs id and watermark columns")
+	}
+
+	// The schema file lets BI tools pick up new versions without reading the code
+	schema, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return 0, 0, err
+	}
+	schemaKey := fmt.Sprintf("%s/%s/v%d/_schema.json", warehouseKeyPrefix, table.Name, table.SchemaVersion)
+	if err := u.store.Put(ctx, schemaKey, "application/json", schema); err != nil {
+		return 0, 0, fmt.Errorf("write schema: %w", err)
+	}
+
+	var rowCount int64
+	fileCount := 0
+	for {
+		rows, err := u.repo.ExportRows(ctx, table, after, until, warehouseRowsPerFile)
+		if err != nil {
+			return rowCount, fileCount, fmt.Errorf("read rows: %w", err)
+		}
+		if len(rows) == 0 {
+			return rowCount, fileCount, nil
+		}
+
+		// Advance the cursor before pseudonymizing rewrites any values
+		last := rows[len(rows)-1]
+		at, okAt := last[watermarkIdx].(time.Time)
+		id, okID := last[idIdx].(int64)
+		if !okAt || !okID {
+			return rowCount, fileCount, errors.New("unexpected id or watermark value")
+		}
+		after = domain.WarehouseCursor{At: at, ID: id}
+
+		data, contentType, err := u.encode(table, rows)
+		if err != nil {
+			return rowCount, fileCount, fmt.Errorf("encode: %w", err)
+		}
+		key := fmt.Sprintf("%s/part-%d-%05d.%s", prefix, startedAt.Unix(), fileCount, u.cfg.Format)
+		if err := u.store.Put(ctx, key, contentType, data); err != nil {
+			return rowCount, fileCount, err
+		}
+		rowCount += int64(len(rows))
+		fileCount++
+
+		if len(rows) < warehouseRowsPerFile {
+			return rowCount, fileCount, nil
+		}
+	}
+}
+
+// encode pseudonymizes the marked columns and renders the rows in the configured format
+func (u *warehouseUsecase) encode(table domain.WarehouseTable, rows [][]any) ([]byte, string, error) {
+	for _, row := range rows {
+		for i, col := range table.Columns {
+			if col.Pseudonymize && row[i] != nil {
+				row[i] = u.pseudonym(fmt.Sprint(row[i]))
+			}
+		}
+	}
+
+	if u.cfg.Format == domain.WarehouseFormatCSV {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		header := make([]string, len(table.Columns))
+		for i, col := range table.Columns {
+			header[i] = col.Name
+		}
+		if err := w.Write(header); err != nil {
+			return nil, "", err
+		}
+		record := make([]string, len(table.Columns))
+		for _, row := range rows {
+			for i, v := range row {
+				record[i] = warehouseCSVValue(v)
+			}
+			if err := w.Write(record); err != nil {
+				return nil, "", err
+			}
+		}
+		w.Flush()
+		return buf.Bytes(), "text/csv", w.Error()
+	}
+
+	columns := make([]parquet.Column, len(table.Columns))
+	for i, col := range table.Columns {
+		columns[i] = parquet.Column{Name: col.Name, Type: parquet.String, Optional: col.Nullable}
+		switch col.Type {
+		case domain.WarehouseInt64:
+			columns[i].Type = parquet.Int64
+		case domain.WarehouseTimestamp:
+			columns[i].Type = parquet.TimestampMillis
+		}
+	}
+	data, err := parquet.Encode(columns, rows, "go-recruitment-backend warehouse export")
+	return data, "application/vnd.apache.parquet", err
+}
+
+// pseudonym is a keyed hash: stable for joins, not reversible without the key
+func (u *warehouseUsecase) pseudonym(value string) string {
+	mac := hmac.New(sha256.New, []byte(u.cfg.PseudonymKey))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func warehouseCSVValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case time.Time:
+		return x.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func (u *warehouseUsecase) saveFailure(ctx context.Context, state *domain.WarehouseExportState, attemptAt time.Time, err error) *domain.WarehouseExportState {
+	logger.FromContext(ctx).Error("Warehouse export failed", "table", state.Table, "error", err)
+
+	msg := err.Error()
+	status := domain.WarehouseExportFailed
+	state.LastAttemptAt = &attemptAt
+	state.LastStatus = &status
+	state.LastError = &msg
+	if err := u.repo.SaveState(context.WithoutCancel(ctx), state); err != nil {
+		logger.FromContext(ctx).Error("Warehouse export: failed to save state", "table", state.Table, "error", err)
+	}
+	return state
+}
+
+func (u *warehouseUsecase) TriggerExport(ctx context.Context) (*domain.WarehouseExportRun, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.RunExport(ctx)
+}
+
+// ListExports returns every exported table's state, including tables not exported yet
+func (u *warehouseUsecase) ListExports(ctx context.Context) ([]domain.WarehouseExportState, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	states, err := u.repo.ListStates(ctx)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch warehouse exports: " + err.Error()))
+	}
+	byTable := make(map[string]domain.WarehouseExportState, len(states))
+	for _, s := range states {
+		byTable[s.Table] = s
+	}
+
+	result := make([]domain.WarehouseExportState, 0, len(domain.WarehouseTables))
+	for _, table := range domain.WarehouseTables {
+		s, ok := byTable[table.Name]
+		if !ok {
+			s = domain.WarehouseExportState{Table: table.Name, SchemaVersion: table.SchemaVersion}
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
@@ -0,0 +1,496 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/internal/domain"
+	"go-recruitment-backend/pkg/apperror"
+	"go-recruitment-backend/pkg/logger"
+	"go-recruitment-backend/pkg/tracing"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// webhookConcurrency is how many deliveries a run sends at once, so one slow
+	// endpoint does not hold up the others
+	webhookConcurrency = 8
+	// webhookErrorBodyLimit caps the response body kept as the error of a failed attempt
+	webhookErrorBodyLimit = 1024
+)
+
+// WebhookConfig tunes webhook delivery
+type WebhookConfig struct {
+	MaxAttempts   int           // attempts before a delivery is given up
+	BaseBackoff   time.Duration // wait after the first failure, doubled after each further one
+	MaxBackoff    time.Duration
+	Timeout       time.Duration // per request
+	BatchSize     int           // deliveries claimed per run
+	AllowInsecure bool          // allow http:// and private network targets (local development only)
+}
+
+type webhookUsecase struct {
+	repo   domain.WebhookRepository
+	cfg    WebhookConfig
+	client *http.Client
+	now    func() time.Time
+
+	running sync.Mutex
+}
+
+// NewWebhookUsecase creates the webhook publisher and delivery worker. Unless
+// cfg.AllowInsecure is set, endpoints must use https and deliveries refuse to
+// connect to loopback, private and link-local addresses.
+func NewWebhookUsecase(repo domain.WebhookRepository, cfg WebhookConfig) domain.WebhookUsecase {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = time.Minute
+	}
+	if cfg.MaxBackoff < cfg.BaseBackoff {
+		cfg.MaxBackoff = 6 * time.Hour
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowInsecure {
+		dialer.Control = refusePrivateAddress
+	}
+	client := &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: tracing.Transport(&http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: cfg.Timeout}),
+		// A redirect could point at an address the endpoint check never saw
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	return &webhookUsecase{repo: repo, cfg: cfg, client: client, now: time.Now}
+}
+
+// refusePrivateAddress is a dialer hook that blocks connections to internal
+// addresses, checked after DNS resolution so a public name cannot point inside
+func refusePrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("webhook target %s is not a public address", host)
+	}
+	return nil
+}
+
+// publishWebhook publishes when a publisher is configured; the triggering action
+// already succeeded, so a failure to queue is logged and never fails the caller
+func publishWebhook(ctx context.Context, hooks domain.WebhookPublisher, eventType string, data any) {
+	if hooks == nil {
+		return
+	}
+	if err := hooks.PublishWebhook(ctx, eventType, data); err != nil {
+		logger.FromContext(ctx).Warn("Failed to queue webhook event", "type", eventType, "error", err)
+	}
+}
+
+// ============================================================================
+// Publishing and delivery
+// ============================================================================
+
+func (u *webhookUsecase) PublishWebhook(ctx context.Context, eventType string, data any) error {
+	if !slices.Contains(domain.WebhookEventTypes, eventType) {
+		return fmt.Errorf("unknown webhook event type %q", eventType)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = u.repo.EnqueueEvent(ctx, eventType, payload, u.now().UTC())
+	return err
+}
+
+func (u *webhookUsecase) RunDeliveries(ctx context.Context) (*domain.WebhookDeliveryRunResult, error) {
+	if !u.running.TryLock() {
+		return nil, apperror.Conflict("A webhook delivery run is already in progress")
+	}
+	defer u.running.Unlock()
+
+	now := u.now().UTC()
+	result := &domain.WebhookDeliveryRunResult{StartedAt: now}
+
+	// 1. Lease due deliveries long enough to send them; a crashed run releases them when the lease ends
+	lease := now.Add(2*u.cfg.Timeout + time.Minute)
+	deliveries, err := u.repo.ClaimDueDeliveries(ctx, now, lease, u.cfg.BatchSize)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch due webhook deliveries: " + err.Error()))
+	}
+	result.Due = len(deliveries)
+
+	// 2. Send them a few at a time
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, webhookConcurrency)
+	for i := range deliveries {
+		d := &deliveries[i]
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-slots; wg.Done() }()
+			u.attempt(ctx, d)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch d.Status {
+			case domain.WebhookDeliverySucceeded:
+				result.Succeeded++
+			case domain.WebhookDeliveryFailed:
+				result.Failed++
+			default:
+				result.Retrying++
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// attempt sends one delivery and records the outcome, scheduling the next attempt
+// with exponential backoff until MaxAttempts
+func (u *webhookUsecase) attempt(ctx context.Context, d *domain.WebhookDelivery) {
+	statusCode, sendErr := u.send(ctx, d)
+
+	at := u.now().UTC()
+	d.Attempts++
+	d.LastAttemptAt = &at
+	d.LastStatusCode = nil
+	if statusCode > 0 {
+		d.LastStatusCode = &statusCode
+	}
+	d.LastError = nil
+	d.NextAttemptAt = nil
+
+	switch {
+	case sendErr == nil:
+		d.Status = domain.WebhookDeliverySucceeded
+		d.DeliveredAt = &at
+	case d.Attempts >= u.cfg.MaxAttempts:
+		msg := sendErr.Error()
+		d.LastError = &msg
+		d.Status = domain.WebhookDeliveryFailed
+	default:
+		msg := sendErr.Error()
+		d.LastError = &msg
+		d.Status = domain.WebhookDeliveryPending
+		next := at.Add(u.backoff(d.Attempts))
+		d.NextAttemptAt = &next
+	}
+
+	if err := u.repo.RecordAttempt(ctx, d); err != nil {
+		logger.FromContext(ctx).Error("Webhook delivery: failed to record attempt", "delivery_id", d.ID, "error", err)
+	}
+}
+
+// backoff is the wait after the given number of failed attempts
+func (u *webhookUsecase) backoff(attempts int) time.Duration {
+	wait := u.cfg.BaseBackoff
+	for i := 1; i < attempts && wait < u.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > u.cfg.MaxBackoff {
+		return u.cfg.MaxBackoff
+	}
+	return wait
+}
+
+// send POSTs the signed event; any 2xx response counts as delivered
+func (u *webhookUsecase) send(ctx context.Context, d *domain.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(domain.WebhookEvent{
+		ID:         d.ID,
+		Type:       d.EventType,
+		OccurredAt: d.CreatedAt.UTC(),
+		Data:       d.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.EndpointURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "j-expert-webhooks/1.0")
+	req.Header.Set(domain.WebhookHeaderEvent, d.EventType)
+	req.Header.Set(domain.WebhookHeaderDelivery, d.ID)
+	req.Header.Set(domain.WebhookHeaderSignature, signWebhook(d.EndpointSecret, u.now().UTC(), body))
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+		return resp.StatusCode, fmt.Errorf("endpoint returned %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookErrorBodyLimit))
+	return resp.StatusCode, nil
+}
+
+// signWebhook returns the X-Webhook-Signature value. The timestamp is signed with
+// the body so receivers can reject replayed requests.
+func signWebhook(secret string, at time.Time, body []byte) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ============================================================================
+// Admin
+// ============================================================================
+
+func (u *webhookUsecase) ListEndpoints(ctx context.Context) ([]domain.WebhookEndpoint, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	endpoints, err := u.repo.ListEndpoints(ctx, nil)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch webhook endpoints: " + err.Error()))
+	}
+	return endpoints, nil
+}
+
+func (u *webhookUsecase) GetEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.getEndpoint(ctx, id)
+}
+
+func (u *webhookUsecase) getEndpoint(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	endpoint, err := u.repo.GetEndpoint(ctx, id, nil)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Webhook endpoint not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch webhook endpoint: " + err.Error()))
+	}
+	return endpoint, nil
+}
+
+// CreateEndpoint registers an endpoint and returns its signing secret, which is
+// not shown again
+func (u *webhookUsecase) CreateEndpoint(ctx context.Context, adminID string, req domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	endpoint := &domain.WebhookEndpoint{}
+	if err := applyWebhookEndpointRequest(endpoint, req, domain.WebhookEventTypes, u.cfg.AllowInsecure); err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	endpoint.Secret = secret
+	if adminID != "" {
+		endpoint.CreatedBy = &adminID
+	}
+
+	if err := u.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to create webhook endpoint: " + err.Error()))
+	}
+	return endpoint, nil
+}
+
+func (u *webhookUsecase) UpdateEndpoint(ctx context.Context, id int64, req domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	endpoint := &domain.WebhookEndpoint{ID: id}
+	if err := applyWebhookEndpointRequest(endpoint, req, domain.WebhookEventTypes, u.cfg.AllowInsecure); err != nil {
+		return nil, err
+	}
+	if err := u.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Webhook endpoint not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to update webhook endpoint: " + err.Error()))
+	}
+	return endpoint, nil
+}
+
+// RotateSecret replaces the signing secret; queued retries are signed with the new one
+func (u *webhookUsecase) RotateSecret(ctx context.Context, id int64) (*domain.WebhookEndpoint, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	if err := u.repo.UpdateSecret(ctx, id, nil, secret, u.now().UTC()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Webhook endpoint not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to rotate webhook secret: " + err.Error()))
+	}
+
+	endpoint, err := u.getEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = secret
+	return endpoint, nil
+}
+
+// DeleteEndpoint removes the endpoint together with its delivery history
+func (u *webhookUsecase) DeleteEndpoint(ctx context.Context, id int64) error {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteEndpoint(ctx, id, nil); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return apperror.NotFound("Webhook endpoint not found")
+		}
+		return apperror.Internal(errors.New("Failed to delete webhook endpoint: " + err.Error()))
+	}
+	return nil
+}
+
+// ListDeliveries covers platform and LPK partner endpoints alike
+func (u *webhookUsecase) ListDeliveries(ctx context.Context, filter domain.WebhookDeliveryFilter) (*domain.PaginatedResult[domain.WebhookDelivery], error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return listWebhookDeliveries(ctx, u.repo, filter, slices.Concat(domain.WebhookEventTypes, domain.LPKWebhookEventTypes))
+}
+
+// listWebhookDeliveries validates the filter against the event types the caller
+// can see and returns a page of deliveries
+func listWebhookDeliveries(ctx context.Context, repo domain.WebhookRepository, filter domain.WebhookDeliveryFilter, eventTypes []string) (*domain.PaginatedResult[domain.WebhookDelivery], error) {
+	if filter.EventType != "" && !slices.Contains(eventTypes, filter.EventType) {
+		return nil, apperror.BadRequest("Invalid webhook event type: " + filter.EventType)
+	}
+	switch filter.Status {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliverySucceeded, domain.WebhookDeliveryFailed:
+	default:
+		return nil, apperror.BadRequest("Invalid webhook delivery status: " + filter.Status)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 20
+	}
+
+	deliveries, total, err := repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, apperror.Internal(errors.New("Failed to fetch webhook deliveries: " + err.Error()))
+	}
+	return &domain.PaginatedResult[domain.WebhookDelivery]{
+		Data:       deliveries,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int((total + int64(filter.PageSize) - 1) / int64(filter.PageSize)),
+	}, nil
+}
+
+func (u *webhookUsecase) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.getDelivery(ctx, id)
+}
+
+func (u *webhookUsecase) getDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	delivery, err := u.repo.GetDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, apperror.NotFound("Webhook delivery not found")
+		}
+		return nil, apperror.Internal(errors.New("Failed to fetch webhook delivery: " + err.Error()))
+	}
+	return delivery, nil
+}
+
+// Redeliver queues a finished delivery for a fresh round of attempts with the
+// same event ID, e.g. after the receiving side fixed an outage
+func (u *webhookUsecase) Redeliver(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	if err := requireRole(ctx, domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+	delivery, err := u.getDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		return nil, apperror.Conflict("Webhook delivery is already queued")
+	}
+	if err := u.repo.Redeliver(ctx, id, u.now().UTC()); err != nil {
+		return nil, apperror.Internal(errors.New("Failed to queue webhook delivery: " + err.Error()))
+	}
+	return u.getDelivery(ctx, id)
+}
+
+// applyWebhookEndpointRequest validates the request against the event types the
+// endpoint may subscribe to and copies it onto the endpoint
+func applyWebhookEndpointRequest(endpoint *domain.WebhookEndpoint, req domain.WebhookEndpointRequest, allowedTypes []string, allowInsecure bool) error {
+	target, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || target.Host == "" || (target.Scheme != "https" && target.Scheme != "http") {
+		return apperror.BadRequest("Invalid webhook URL")
+	}
+	if target.Scheme != "https" && !allowInsecure {
+		return apperror.BadRequest("Webhook URL must use https")
+	}
+	if target.User != nil {
+		return apperror.BadRequest("Webhook URL must not contain credentials")
+	}
+
+	eventTypes := []string{}
+	for _, t := range req.EventTypes {
+		if !slices.Contains(allowedTypes, t) {
+			return apperror.BadRequest("Invalid webhook event type: " + t)
+		}
+		if !slices.Contains(eventTypes, t) {
+			eventTypes = append(eventTypes, t)
+		}
+	}
+
+	endpoint.URL = target.String()
+	endpoint.Description = req.Description
+	endpoint.EventTypes = eventTypes
+	endpoint.Active = req.Active == nil || *req.Active
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("Failed to generate webhook secret: " + err.Error())
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
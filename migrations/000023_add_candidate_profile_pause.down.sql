@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000023_add_candidate_profile_pause.down.sql
+-- Purpose: Rollback candidate profile pause
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_users_paused_until;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS paused_at,
+DROP COLUMN IF EXISTS paused_until,
+DROP COLUMN IF EXISTS pause_reason;
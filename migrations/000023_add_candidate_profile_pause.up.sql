@@ -0,0 +1,18 @@
+-- ============================================================================
+-- Migration: 000023_add_candidate_profile_pause
+-- Purpose: Self-service "pause my profile" for candidates (distinct from deletion)
+-- ============================================================================
+
+-- A pause is active while paused_until is in the future. Once the end date
+-- passes the candidate is automatically visible again; no job is required.
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS paused_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS paused_until TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS pause_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_users_paused_until ON users(paused_until)
+    WHERE paused_until IS NOT NULL;
+
+COMMENT ON COLUMN users.paused_at IS 'When the candidate paused their profile';
+COMMENT ON COLUMN users.paused_until IS 'Candidate is hidden from search and job alerts until this time';
+COMMENT ON COLUMN users.pause_reason IS 'Optional free-text reason supplied by the candidate';
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000024_create_lpk_partner_portal (DOWN)
+-- Purpose: Rollback LPK partner portal
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_av_lpk_id;
+DROP TABLE IF EXISTS lpk_endorsements;
+DROP TABLE IF EXISTS lpk_partner_users;
+
+-- Demote any remaining partner accounts
+UPDATE users SET role = 'candidate' WHERE role = 'lpk';
@@ -0,0 +1,33 @@
+-- ============================================================================
+-- Migration: 000024_create_lpk_partner_portal
+-- Purpose: LPK (training center) partner accounts, endorsements, and scoping
+-- ============================================================================
+
+-- A. LPK Partner Users
+-- Maps a user with role 'lpk' to exactly one LPK. All partner portal access
+-- is scoped through this table; a user without a row here has no access.
+CREATE TABLE IF NOT EXISTS lpk_partner_users (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    lpk_id INTEGER NOT NULL REFERENCES lpk_list(id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lpk_partner_users_lpk_id ON lpk_partner_users(lpk_id);
+
+-- B. LPK Endorsement Notes
+-- Free-text notes an LPK submits for candidates who trained with them
+CREATE TABLE IF NOT EXISTS lpk_endorsements (
+    id BIGSERIAL PRIMARY KEY,
+    lpk_id INTEGER NOT NULL REFERENCES lpk_list(id) ON DELETE CASCADE,
+    account_verification_id BIGINT NOT NULL REFERENCES account_verifications(id) ON DELETE CASCADE,
+    author_user_id UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    note TEXT NOT NULL CHECK (char_length(note) BETWEEN 1 AND 2000),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lpk_endorsements_verification ON lpk_endorsements(account_verification_id);
+CREATE INDEX IF NOT EXISTS idx_lpk_endorsements_lpk_id ON lpk_endorsements(lpk_id);
+
+-- C. Index for scoping candidates by LPK
+CREATE INDEX IF NOT EXISTS idx_av_lpk_id ON account_verifications(lpk_id) WHERE lpk_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- ============================================================================
+-- Migration: 000025_create_uploaded_files (DOWN)
+-- ============================================================================
+
+DROP TABLE IF EXISTS uploaded_files;
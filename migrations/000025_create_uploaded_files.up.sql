@@ -0,0 +1,24 @@
+-- ============================================================================
+-- Migration: 000025_create_uploaded_files
+-- Purpose: Track server-side processing status of uploaded files
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS uploaded_files (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    bucket TEXT NOT NULL,
+    original_filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    status TEXT NOT NULL DEFAULT 'uploaded'
+        CHECK (status IN ('uploaded', 'processing', 'ready', 'failed')),
+    public_url TEXT,
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploaded_files_user_id ON uploaded_files(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_uploaded_files_status ON uploaded_files(status)
+    WHERE status IN ('uploaded', 'processing');
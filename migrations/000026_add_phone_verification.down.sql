@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000026_add_phone_verification (DOWN)
+-- ============================================================================
+
+DROP TABLE IF EXISTS phone_otp_challenges;
+
+ALTER TABLE account_verifications
+DROP COLUMN IF EXISTS phone_verified_at,
+DROP COLUMN IF EXISTS phone_verified_number;
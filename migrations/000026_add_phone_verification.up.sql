@@ -0,0 +1,28 @@
+-- ============================================================================
+-- Migration: 000026_add_phone_verification
+-- Purpose: Candidate phone verification via SMS OTP
+-- ============================================================================
+
+-- A. Verified phone on the verification record
+-- phone_verified_number holds the exact phone value that was verified; if the
+-- candidate later edits their phone the two no longer match and the badge lapses.
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS phone_verified_number TEXT;
+
+-- B. OTP challenges (codes stored as SHA-256 hashes only)
+CREATE TABLE IF NOT EXISTS phone_otp_challenges (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    phone TEXT NOT NULL,
+    code_hash TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    consumed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_phone_otp_user_created ON phone_otp_challenges(user_id, created_at DESC);
+
+COMMENT ON COLUMN account_verifications.phone_verified_at IS 'When the candidate confirmed ownership of phone_verified_number via OTP';
+COMMENT ON COLUMN account_verifications.phone_verified_number IS 'Phone value at verification time; badge only valid while it equals phone';
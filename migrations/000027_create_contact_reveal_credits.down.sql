@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000027_create_contact_reveal_credits (DOWN)
+-- Purpose: Rollback contact reveal credits
+-- ============================================================================
+
+DROP TABLE IF EXISTS candidate_contact_reveals;
+DROP INDEX IF EXISTS idx_credit_ledger_company_created;
+DROP TABLE IF EXISTS company_credit_ledger;
+DROP TABLE IF EXISTS company_credit_balances;
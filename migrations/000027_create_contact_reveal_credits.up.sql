@@ -0,0 +1,43 @@
+-- ============================================================================
+-- Migration: 000027_create_contact_reveal_credits
+-- Purpose: Company credit balances, credit ledger, and candidate contact reveals
+-- ============================================================================
+
+-- A. Company Credit Balances
+-- One row per company. Balance is only changed inside a transaction that also
+-- writes a ledger entry, so SUM(ledger.amount) always equals balance.
+CREATE TABLE IF NOT EXISTS company_credit_balances (
+    company_id BIGINT PRIMARY KEY REFERENCES company_profiles(id) ON DELETE CASCADE,
+    balance INTEGER NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- B. Credit Ledger
+-- Append-only. Positive amounts are grants/purchases, negative amounts are reveals.
+CREATE TABLE IF NOT EXISTS company_credit_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    entry_type TEXT NOT NULL CHECK (entry_type IN ('plan_grant', 'purchase', 'reveal', 'adjustment')),
+    amount INTEGER NOT NULL CHECK (amount <> 0),
+    balance_after INTEGER NOT NULL CHECK (balance_after >= 0),
+    idempotency_key TEXT,
+    candidate_user_id UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    actor_user_id UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (company_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_ledger_company_created ON company_credit_ledger(company_id, created_at DESC);
+
+-- C. Candidate Contact Reveals
+-- A company pays once per candidate; later reveals of the same candidate are free.
+CREATE TABLE IF NOT EXISTS candidate_contact_reveals (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    candidate_user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    ledger_entry_id BIGINT REFERENCES company_credit_ledger(id) ON DELETE SET NULL,
+    revealed_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (company_id, candidate_user_id)
+);
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- Migration: 000028_create_billing_finance (DOWN)
+-- Purpose: Rollback billing plans, invoices, and finance access
+-- ============================================================================
+
+ALTER TABLE users DROP COLUMN IF EXISTS finance_access;
+
+DROP INDEX IF EXISTS idx_company_invoices_outstanding;
+DROP INDEX IF EXISTS idx_company_invoices_paid_at;
+DROP TABLE IF EXISTS company_invoices;
+DROP INDEX IF EXISTS idx_company_subscriptions_cancelled;
+DROP TABLE IF EXISTS company_subscriptions;
+DROP TABLE IF EXISTS billing_plans;
@@ -0,0 +1,48 @@
+-- ============================================================================
+-- Migration: 000028_create_billing_finance
+-- Purpose: Billing plans, company subscriptions, invoices, and finance access
+-- ============================================================================
+
+-- A. Billing Plans
+CREATE TABLE IF NOT EXISTS billing_plans (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    monthly_price_idr BIGINT NOT NULL DEFAULT 0 CHECK (monthly_price_idr >= 0),
+    monthly_credits INTEGER NOT NULL DEFAULT 0 CHECK (monthly_credits >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- B. Company Subscriptions
+-- One current subscription per company. cancelled_at marks churn.
+CREATE TABLE IF NOT EXISTS company_subscriptions (
+    company_id BIGINT PRIMARY KEY REFERENCES company_profiles(id) ON DELETE CASCADE,
+    plan_code TEXT NOT NULL REFERENCES billing_plans(code) ON UPDATE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    cancelled_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_subscriptions_cancelled ON company_subscriptions(cancelled_at) WHERE cancelled_at IS NOT NULL;
+
+-- C. Company Invoices
+-- plan_code is NULL for one-off charges such as credit pack purchases
+CREATE TABLE IF NOT EXISTS company_invoices (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    plan_code TEXT REFERENCES billing_plans(code) ON UPDATE CASCADE,
+    description TEXT NOT NULL,
+    amount_idr BIGINT NOT NULL CHECK (amount_idr >= 0),
+    status TEXT NOT NULL DEFAULT 'issued' CHECK (status IN ('issued', 'paid', 'void')),
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    due_at TIMESTAMPTZ NOT NULL,
+    paid_at TIMESTAMPTZ,
+    CHECK (status <> 'paid' OR paid_at IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_invoices_paid_at ON company_invoices(paid_at) WHERE status = 'paid';
+CREATE INDEX IF NOT EXISTS idx_company_invoices_outstanding ON company_invoices(due_at) WHERE status = 'issued';
+
+-- D. Finance Access
+-- Finance reports require role 'admin' AND this flag. Granted manually per admin.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS finance_access BOOLEAN NOT NULL DEFAULT FALSE;
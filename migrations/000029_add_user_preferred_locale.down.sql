@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000029_add_user_preferred_locale (DOWN)
+-- Purpose: Remove user language preference
+-- ============================================================================
+
+ALTER TABLE users DROP COLUMN IF EXISTS preferred_locale;
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000029_add_user_preferred_locale
+-- Purpose: Store each user's preferred API/UI language (id, en, ja)
+-- ============================================================================
+
+-- NULL means "follow Accept-Language"
+ALTER TABLE users ADD COLUMN IF NOT EXISTS preferred_locale TEXT
+    CHECK (preferred_locale IN ('id', 'en', 'ja'));
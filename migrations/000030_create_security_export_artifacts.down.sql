@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000030_create_security_export_artifacts (DOWN)
+-- Purpose: Rollback security export artifacts
+-- ============================================================================
+
+DROP TABLE IF EXISTS security_export_artifacts;
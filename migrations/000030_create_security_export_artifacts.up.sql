@@ -0,0 +1,24 @@
+-- ============================================================================
+-- Migration: 000030_create_security_export_artifacts
+-- Purpose: Track worker-generated files (e.g. Parquet) for approved security
+--          exports that are too large to build inline
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS security_export_artifacts (
+    export_id UUID NOT NULL REFERENCES export_requests(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('json', 'csv', 'parquet')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    object_key TEXT,
+    row_count BIGINT NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    PRIMARY KEY (export_id, format)
+);
+
+ALTER TABLE security_export_artifacts ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY "Service role full access" ON security_export_artifacts
+    FOR ALL USING (auth.role() = 'service_role');
@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000031_create_public_holidays (DOWN)
+-- Purpose: Rollback public holiday calendars
+-- ============================================================================
+
+DROP TABLE IF EXISTS public_holidays;
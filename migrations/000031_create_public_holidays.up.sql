@@ -0,0 +1,65 @@
+-- ============================================================================
+-- Migration: 000031_create_public_holidays
+-- Purpose: Admin-managed public holiday calendars (Indonesia, Japan) used to
+--          compute business-day SLAs and scheduling suggestions
+-- ============================================================================
+
+-- ============================================================================
+-- A. Holiday calendar
+-- ============================================================================
+CREATE TABLE IF NOT EXISTS public_holidays (
+    id BIGSERIAL PRIMARY KEY,
+    country_code CHAR(2) NOT NULL CHECK (country_code IN ('ID', 'JP')),
+    holiday_date DATE NOT NULL,
+    name TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,  -- FALSE keeps the entry but treats the day as a working day
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (country_code, holiday_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_public_holidays_date ON public_holidays(holiday_date);
+
+-- ============================================================================
+-- B. Seed 2026 national holidays
+-- Indonesian religious holidays follow the annual joint ministerial decree
+-- (SKB 3 Menteri); admins should review the list when the decree is published.
+-- ============================================================================
+INSERT INTO public_holidays (country_code, holiday_date, name) VALUES
+    ('ID', '2026-01-01', 'Tahun Baru Masehi'),
+    ('ID', '2026-01-16', 'Isra Mikraj Nabi Muhammad SAW'),
+    ('ID', '2026-02-17', 'Tahun Baru Imlek'),
+    ('ID', '2026-03-19', 'Hari Suci Nyepi'),
+    ('ID', '2026-03-20', 'Idul Fitri'),
+    ('ID', '2026-03-21', 'Idul Fitri'),
+    ('ID', '2026-04-03', 'Wafat Yesus Kristus'),
+    ('ID', '2026-04-05', 'Kebangkitan Yesus Kristus (Paskah)'),
+    ('ID', '2026-05-01', 'Hari Buruh Internasional'),
+    ('ID', '2026-05-14', 'Kenaikan Yesus Kristus'),
+    ('ID', '2026-05-27', 'Idul Adha'),
+    ('ID', '2026-05-31', 'Hari Raya Waisak'),
+    ('ID', '2026-06-01', 'Hari Lahir Pancasila'),
+    ('ID', '2026-06-16', 'Tahun Baru Islam'),
+    ('ID', '2026-08-17', 'Hari Kemerdekaan Republik Indonesia'),
+    ('ID', '2026-08-25', 'Maulid Nabi Muhammad SAW'),
+    ('ID', '2026-12-25', 'Hari Raya Natal'),
+    ('JP', '2026-01-01', '元日'),
+    ('JP', '2026-01-12', '成人の日'),
+    ('JP', '2026-02-11', '建国記念の日'),
+    ('JP', '2026-02-23', '天皇誕生日'),
+    ('JP', '2026-03-20', '春分の日'),
+    ('JP', '2026-04-29', '昭和の日'),
+    ('JP', '2026-05-03', '憲法記念日'),
+    ('JP', '2026-05-04', 'みどりの日'),
+    ('JP', '2026-05-05', 'こどもの日'),
+    ('JP', '2026-05-06', '振替休日'),
+    ('JP', '2026-07-20', '海の日'),
+    ('JP', '2026-08-11', '山の日'),
+    ('JP', '2026-09-21', '敬老の日'),
+    ('JP', '2026-09-22', '国民の休日'),
+    ('JP', '2026-09-23', '秋分の日'),
+    ('JP', '2026-10-12', 'スポーツの日'),
+    ('JP', '2026-11-03', '文化の日'),
+    ('JP', '2026-11-23', '勤労感謝の日')
+ON CONFLICT (country_code, holiday_date) DO NOTHING;
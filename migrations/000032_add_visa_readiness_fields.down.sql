@@ -0,0 +1,14 @@
+-- ============================================================================
+-- Migration: 000032_add_visa_readiness_fields (DOWN)
+-- Purpose: Rollback CoE status and SSW exam results
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_av_ssw_exam_results;
+DROP INDEX IF EXISTS idx_av_coe_status;
+
+ALTER TABLE account_verifications
+DROP CONSTRAINT IF EXISTS chk_av_coe_dates,
+DROP COLUMN IF EXISTS ssw_exam_results,
+DROP COLUMN IF EXISTS coe_expiry_date,
+DROP COLUMN IF EXISTS coe_issued_date,
+DROP COLUMN IF EXISTS coe_status;
@@ -0,0 +1,39 @@
+-- ============================================================================
+-- Migration: 000032_add_visa_readiness_fields
+-- Purpose: Certificate of Eligibility (CoE) status and SSW exam results for ATS filtering
+-- ============================================================================
+
+-- A. Certificate of Eligibility (在留資格認定証明書)
+-- A CoE is only usable for a visa application until it expires (normally 3 months
+-- after issue), so "visa-ready" means ISSUED with an expiry date that has not passed.
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS coe_status TEXT
+    CHECK (coe_status IS NULL OR coe_status IN ('NONE', 'IN_PROCESS', 'ISSUED', 'EXPIRED')),
+ADD COLUMN IF NOT EXISTS coe_issued_date DATE,
+ADD COLUMN IF NOT EXISTS coe_expiry_date DATE,
+ADD CONSTRAINT chk_av_coe_dates
+    CHECK (coe_expiry_date IS NULL OR coe_issued_date IS NULL OR coe_expiry_date > coe_issued_date);
+
+-- B. Specified Skilled Worker (特定技能) exam passes
+-- JSON array of {sector, level, passed_date, certificate_url}; sector/level are
+-- validated at the application layer.
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS ssw_exam_results JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- ============================================================================
+-- Indexes for ATS Filtering
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_av_coe_status ON account_verifications(coe_status, coe_expiry_date);
+
+-- Supports containment filters: ssw_exam_results @> '[{"sector": "..."}]'
+CREATE INDEX IF NOT EXISTS idx_av_ssw_exam_results ON account_verifications USING GIN (ssw_exam_results jsonb_path_ops);
+
+-- ============================================================================
+-- Comments for Documentation
+-- ============================================================================
+
+COMMENT ON COLUMN account_verifications.coe_status IS 'Certificate of Eligibility status: NONE, IN_PROCESS, ISSUED, EXPIRED';
+COMMENT ON COLUMN account_verifications.coe_issued_date IS 'Date the CoE was issued by the Immigration Services Agency';
+COMMENT ON COLUMN account_verifications.coe_expiry_date IS 'Last date the CoE can be used for a visa application';
+COMMENT ON COLUMN account_verifications.ssw_exam_results IS 'Passed SSW skills exams: [{sector, level, passed_date, certificate_url}]';
@@ -0,0 +1,12 @@
+-- ============================================================================
+-- Migration: 000033_create_reengagement_nudges (DOWN)
+-- Purpose: Rollback re-engagement nudges, opt-out and activity tracking
+-- ============================================================================
+
+DROP TABLE IF EXISTS reengagement_nudges;
+
+DROP INDEX IF EXISTS idx_users_last_active_candidates;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS reengagement_opt_out_at,
+DROP COLUMN IF EXISTS last_active_at;
@@ -0,0 +1,38 @@
+-- ============================================================================
+-- Migration: 000033_create_reengagement_nudges
+-- Purpose: Candidate activity tracking, re-engagement opt-out and nudge history
+-- ============================================================================
+
+-- A. Activity and opt-out on users
+-- last_active_at is refreshed (throttled) by authenticated requests; existing rows
+-- start from updated_at so the first worker run does not treat everyone as inactive.
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS last_active_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS reengagement_opt_out_at TIMESTAMPTZ;
+
+UPDATE users SET last_active_at = updated_at WHERE last_active_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_last_active_candidates
+    ON users(last_active_at)
+    WHERE role = 'candidate' AND reengagement_opt_out_at IS NULL;
+
+-- B. One row per nudge attempt
+-- returned_at is set on the candidate's first activity after the nudge; conversion
+-- (verification submitted / new application) is derived at report time.
+CREATE TABLE IF NOT EXISTS reengagement_nudges (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    campaign TEXT NOT NULL CHECK (campaign IN ('INCOMPLETE_PROFILE', 'STALLED_APPLICATION')),
+    channel TEXT NOT NULL,
+    missing_steps TEXT[] NOT NULL DEFAULT '{}',
+    suggested_job_ids BIGINT[] NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL CHECK (status IN ('SENT', 'FAILED')),
+    error_message TEXT,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    returned_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_reengagement_nudges_user_sent ON reengagement_nudges(user_id, sent_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reengagement_nudges_sent_at ON reengagement_nudges(sent_at);
+
+COMMENT ON COLUMN users.reengagement_opt_out_at IS 'Set when the candidate opted out of re-engagement nudges; NULL means subscribed';
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000034_create_company_career_pages (DOWN)
+-- Purpose: Rollback company slugs and career pages
+-- ============================================================================
+
+DROP TABLE IF EXISTS company_career_pages;
+
+DROP INDEX IF EXISTS uq_company_profiles_slug;
+
+ALTER TABLE company_profiles
+DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,26 @@
+-- ============================================================================
+-- Migration: 000034_create_company_career_pages
+-- Purpose: Company slugs and branded career page content
+-- ============================================================================
+
+-- A. Public slug (used in /companies/public/:slug URLs), unique case-insensitively
+ALTER TABLE company_profiles
+ADD COLUMN IF NOT EXISTS slug TEXT
+    CHECK (slug IS NULL OR slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$');
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_company_profiles_slug ON company_profiles(LOWER(slug)) WHERE slug IS NOT NULL;
+
+-- B. Career page content (1 per company); only published pages are served publicly
+CREATE TABLE IF NOT EXISTS company_career_pages (
+    company_id BIGINT PRIMARY KEY REFERENCES company_profiles(id) ON DELETE CASCADE,
+    primary_color TEXT CHECK (primary_color IS NULL OR primary_color ~ '^#[0-9A-Fa-f]{6}$'),
+    secondary_color TEXT CHECK (secondary_color IS NULL OR secondary_color ~ '^#[0-9A-Fa-f]{6}$'),
+    banner_url TEXT,
+    intro_video_url TEXT,
+    headline TEXT,
+    culture_sections JSONB NOT NULL DEFAULT '[]', -- [{title, body, image_url}]
+    benefits TEXT[] NOT NULL DEFAULT '{}',
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Migration: 000035_create_job_screening_questions (DOWN)
+-- Purpose: Rollback screening questions, application answers and knock-out flag
+-- ============================================================================
+
+ALTER TABLE applications
+DROP COLUMN IF EXISTS auto_rejected;
+
+DROP TABLE IF EXISTS application_answers;
+DROP TABLE IF EXISTS job_screening_questions;
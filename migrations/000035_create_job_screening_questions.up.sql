@@ -0,0 +1,43 @@
+-- ============================================================================
+-- Migration: 000035_create_job_screening_questions
+-- Purpose: Per-job screening questions, application answers and knock-out rejection
+-- ============================================================================
+
+-- A. Questions employers attach to a job (replaced as a set)
+-- knockout_answers holds the answers that disqualify a candidate:
+-- 'yes'/'no' for YES_NO, option values for SINGLE_CHOICE; never shown to candidates.
+CREATE TABLE IF NOT EXISTS job_screening_questions (
+    id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    question_type TEXT NOT NULL CHECK (question_type IN ('TEXT', 'SINGLE_CHOICE', 'YES_NO', 'FILE')),
+    prompt TEXT NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    is_required BOOLEAN NOT NULL DEFAULT TRUE,
+    knockout_answers TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (job_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_screening_questions_job ON job_screening_questions(job_id);
+
+-- B. Answers stored with the application
+-- prompt/question_type are snapshots so answers stay readable after the job's
+-- questions are edited (question_id is then cleared).
+CREATE TABLE IF NOT EXISTS application_answers (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    question_id BIGINT REFERENCES job_screening_questions(id) ON DELETE SET NULL,
+    prompt TEXT NOT NULL,
+    question_type TEXT NOT NULL,
+    answer_text TEXT,
+    file_url TEXT,
+    is_disqualifying BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_answers_application ON application_answers(application_id);
+
+-- C. Applications rejected by a knock-out rule rather than by the employer
+ALTER TABLE applications
+ADD COLUMN IF NOT EXISTS auto_rejected BOOLEAN NOT NULL DEFAULT FALSE;
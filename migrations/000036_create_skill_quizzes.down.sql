@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000036_create_skill_quizzes (DOWN)
+-- Purpose: Rollback skill quizzes, attempts and candidate quiz scores
+-- ============================================================================
+
+DROP TABLE IF EXISTS candidate_quiz_scores;
+DROP TABLE IF EXISTS skill_quiz_attempts;
+DROP TABLE IF EXISTS skill_quiz_questions;
+DROP TABLE IF EXISTS skill_quizzes;
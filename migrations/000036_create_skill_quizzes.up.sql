@@ -0,0 +1,63 @@
+-- ============================================================================
+-- Migration: 000036_create_skill_quizzes
+-- Purpose: Admin-authored skill quizzes, timed candidate attempts and best scores
+-- ============================================================================
+
+-- A. Quizzes (e.g. Japanese vocabulary, workplace safety)
+CREATE TABLE IF NOT EXISTS skill_quizzes (
+    id BIGSERIAL PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT,
+    category TEXT NOT NULL CHECK (category IN ('JAPANESE_VOCABULARY', 'SAFETY', 'OTHER')),
+    time_limit_seconds INT NOT NULL CHECK (time_limit_seconds BETWEEN 60 AND 7200),
+    pass_score INT NOT NULL DEFAULT 70 CHECK (pass_score BETWEEN 0 AND 100),
+    retake_cooldown_hours INT NOT NULL DEFAULT 24 CHECK (retake_cooldown_hours >= 0),
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- B. Multiple-choice questions; correct_option is a 0-based index into options
+-- and is never sent to candidates.
+CREATE TABLE IF NOT EXISTS skill_quiz_questions (
+    id BIGSERIAL PRIMARY KEY,
+    quiz_id BIGINT NOT NULL REFERENCES skill_quizzes(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    prompt TEXT NOT NULL,
+    options TEXT[] NOT NULL,
+    correct_option INT NOT NULL CHECK (correct_option >= 0),
+    UNIQUE (quiz_id, position)
+);
+
+-- C. Attempts; expires_at is fixed when the attempt starts so the time limit
+-- is enforced server-side.
+CREATE TABLE IF NOT EXISTS skill_quiz_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    quiz_id BIGINT NOT NULL REFERENCES skill_quizzes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'IN_PROGRESS' CHECK (status IN ('IN_PROGRESS', 'SUBMITTED', 'EXPIRED')),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    submitted_at TIMESTAMPTZ,
+    correct_count INT,
+    total_questions INT,
+    score_percent INT CHECK (score_percent BETWEEN 0 AND 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_skill_quiz_attempts_user_quiz ON skill_quiz_attempts(user_id, quiz_id, started_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skill_quiz_attempts_open
+    ON skill_quiz_attempts(user_id, quiz_id) WHERE status = 'IN_PROGRESS';
+
+-- D. Best score per candidate and quiz, shown on the profile and filtered in the ATS
+CREATE TABLE IF NOT EXISTS candidate_quiz_scores (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quiz_id BIGINT NOT NULL REFERENCES skill_quizzes(id) ON DELETE CASCADE,
+    best_score INT NOT NULL CHECK (best_score BETWEEN 0 AND 100),
+    passed BOOLEAN NOT NULL DEFAULT FALSE,
+    attempt_count INT NOT NULL DEFAULT 1,
+    last_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, quiz_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_quiz_scores_quiz ON candidate_quiz_scores(quiz_id, best_score DESC);
@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000037_create_pii_access_logs (DOWN)
+-- Purpose: Rollback PII access log
+-- ============================================================================
+
+DROP TABLE IF EXISTS pii_access_logs;
@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Migration: 000037_create_pii_access_logs
+-- Purpose: Append-only log of bulk access to candidate personal data (exports)
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS pii_access_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    company_id BIGINT REFERENCES company_profiles(id) ON DELETE SET NULL,
+    action TEXT NOT NULL,                -- e.g. APPLICATION_EXPORT
+    resource_type TEXT NOT NULL,         -- e.g. job
+    resource_id TEXT NOT NULL,
+    subject_user_ids UUID[] NOT NULL DEFAULT '{}', -- candidates whose data was included
+    columns TEXT[] NOT NULL DEFAULT '{}',
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pii_access_logs_company_created ON pii_access_logs(company_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pii_access_logs_subjects ON pii_access_logs USING GIN (subject_user_ids);
@@ -0,0 +1,40 @@
+-- ============================================================================
+-- Migration: 000038_create_aggregate_recompute (DOWN)
+-- Purpose: Rollback aggregate triggers, recompute runs and drift items
+-- ============================================================================
+
+DROP TABLE IF EXISTS aggregate_drift_items;
+DROP TABLE IF EXISTS aggregate_recompute_runs;
+
+DROP TRIGGER IF EXISTS trigger_work_experiences_total ON work_experiences;
+DROP FUNCTION IF EXISTS refresh_total_experience_months();
+DROP FUNCTION IF EXISTS compute_total_experience_months(UUID);
+
+-- Restore handle_new_verification without the aggregate guard
+CREATE OR REPLACE FUNCTION handle_new_verification()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO account_verifications (user_id, role, status, submitted_at, updated_at)
+    VALUES (
+        NEW.user_id,
+        CASE
+            WHEN TG_TABLE_NAME = 'candidate_profiles' THEN 'CANDIDATE'
+            WHEN TG_TABLE_NAME = 'company_profiles' THEN 'EMPLOYER'
+            ELSE 'CANDIDATE' -- Default fallback, though should catch by TG_TABLE_NAME
+        END,
+        'PENDING',
+        NOW(),
+        NOW()
+    )
+    ON CONFLICT (user_id) DO UPDATE
+    SET
+        status = 'PENDING',
+        submitted_at = NOW(),
+        updated_at = NOW(),
+        verified_at = NULL,
+        verified_by = NULL
+    WHERE account_verifications.status = 'REJECTED'; -- Only reset to PENDING if currently REJECTED
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,107 @@
+-- ============================================================================
+-- Migration: 000038_create_aggregate_recompute
+-- Purpose: Keep derived candidate aggregates consistent (on-write trigger +
+--          nightly recompute) and record drift found by each recompute run
+-- ============================================================================
+
+-- A. Single definition of total work experience, shared by the trigger and the worker.
+-- Same formula as the 000021 backfill; open-ended roles count up to today, which is
+-- why the nightly run is still needed after the trigger.
+CREATE OR REPLACE FUNCTION compute_total_experience_months(p_user_id UUID)
+RETURNS INT AS $$
+    SELECT COALESCE(SUM(
+        EXTRACT(YEAR FROM AGE(COALESCE(we.end_date, CURRENT_DATE), we.start_date)) * 12 +
+        EXTRACT(MONTH FROM AGE(COALESCE(we.end_date, CURRENT_DATE), we.start_date))
+    ), 0)::INT
+    FROM work_experiences we
+    WHERE we.user_id = p_user_id;
+$$ LANGUAGE sql STABLE;
+
+-- B. Refresh on every work experience write
+CREATE OR REPLACE FUNCTION refresh_total_experience_months()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE candidate_profiles
+        SET total_experience_months = compute_total_experience_months(OLD.user_id)
+        WHERE user_id = OLD.user_id
+          AND total_experience_months IS DISTINCT FROM compute_total_experience_months(OLD.user_id);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE candidate_profiles
+        SET total_experience_months = compute_total_experience_months(NEW.user_id)
+        WHERE user_id = NEW.user_id
+          AND total_experience_months IS DISTINCT FROM compute_total_experience_months(NEW.user_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_work_experiences_total ON work_experiences;
+CREATE TRIGGER trigger_work_experiences_total
+AFTER INSERT OR UPDATE OR DELETE ON work_experiences
+FOR EACH ROW
+EXECUTE FUNCTION refresh_total_experience_months();
+
+-- C. An aggregate refresh is not a profile edit: without this guard, updating
+-- candidate_profiles.total_experience_months would re-open REJECTED verifications.
+CREATE OR REPLACE FUNCTION handle_new_verification()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND TG_TABLE_NAME = 'candidate_profiles'
+       AND (to_jsonb(NEW) - 'total_experience_months' - 'updated_at')
+           = (to_jsonb(OLD) - 'total_experience_months' - 'updated_at') THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO account_verifications (user_id, role, status, submitted_at, updated_at)
+    VALUES (
+        NEW.user_id,
+        CASE
+            WHEN TG_TABLE_NAME = 'candidate_profiles' THEN 'CANDIDATE'
+            WHEN TG_TABLE_NAME = 'company_profiles' THEN 'EMPLOYER'
+            ELSE 'CANDIDATE' -- Default fallback, though should catch by TG_TABLE_NAME
+        END,
+        'PENDING',
+        NOW(),
+        NOW()
+    )
+    ON CONFLICT (user_id) DO UPDATE
+    SET
+        status = 'PENDING',
+        submitted_at = NOW(),
+        updated_at = NOW(),
+        verified_at = NULL,
+        verified_by = NULL
+    WHERE account_verifications.status = 'REJECTED'; -- Only reset to PENDING if currently REJECTED
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- D. Recompute runs and the drift each one found (drift report)
+CREATE TABLE IF NOT EXISTS aggregate_recompute_runs (
+    id BIGSERIAL PRIMARY KEY,
+    trigger_source TEXT NOT NULL CHECK (trigger_source IN ('SCHEDULED', 'MANUAL')),
+    status TEXT NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    checked_count INT NOT NULL DEFAULT 0,
+    drift_count INT NOT NULL DEFAULT 0,
+    fixed_count INT NOT NULL DEFAULT 0,
+    error_message TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_aggregate_recompute_runs_started ON aggregate_recompute_runs(started_at DESC);
+
+CREATE TABLE IF NOT EXISTS aggregate_drift_items (
+    id BIGSERIAL PRIMARY KEY,
+    run_id BIGINT NOT NULL REFERENCES aggregate_recompute_runs(id) ON DELETE CASCADE,
+    aggregate TEXT NOT NULL,  -- e.g. total_experience_months, quiz_best_score
+    user_id UUID NOT NULL,
+    subject_ref TEXT,         -- e.g. quiz id for per-quiz aggregates
+    stored_value INT,
+    computed_value INT
+);
+
+CREATE INDEX IF NOT EXISTS idx_aggregate_drift_items_run ON aggregate_drift_items(run_id);
@@ -0,0 +1,47 @@
+-- ============================================================================
+-- Migration: 000039_create_company_merges (DOWN)
+-- Purpose: Rollback company merges
+-- ============================================================================
+
+-- Restore handle_new_verification as of 000038
+CREATE OR REPLACE FUNCTION handle_new_verification()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND TG_TABLE_NAME = 'candidate_profiles'
+       AND (to_jsonb(NEW) - 'total_experience_months' - 'updated_at')
+           = (to_jsonb(OLD) - 'total_experience_months' - 'updated_at') THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO account_verifications (user_id, role, status, submitted_at, updated_at)
+    VALUES (
+        NEW.user_id,
+        CASE
+            WHEN TG_TABLE_NAME = 'candidate_profiles' THEN 'CANDIDATE'
+            WHEN TG_TABLE_NAME = 'company_profiles' THEN 'EMPLOYER'
+            ELSE 'CANDIDATE' -- Default fallback, though should catch by TG_TABLE_NAME
+        END,
+        'PENDING',
+        NOW(),
+        NOW()
+    )
+    ON CONFLICT (user_id) DO UPDATE
+    SET
+        status = 'PENDING',
+        submitted_at = NOW(),
+        updated_at = NOW(),
+        verified_at = NULL,
+        verified_by = NULL
+    WHERE account_verifications.status = 'REJECTED'; -- Only reset to PENDING if currently REJECTED
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS company_merge_audit_entries;
+DROP TABLE IF EXISTS company_merges;
+
+DROP INDEX IF EXISTS idx_company_profiles_merged_into;
+ALTER TABLE company_profiles
+DROP COLUMN IF EXISTS archived_at,
+DROP COLUMN IF EXISTS merged_into_id;
@@ -0,0 +1,77 @@
+-- ============================================================================
+-- Migration: 000039_create_company_merges
+-- Purpose: Admin merge of duplicate company profiles with an audit trail
+-- ============================================================================
+
+-- A. Archived duplicates point at the surviving company; their owner keeps
+--    working on the survivor through merged_into_id
+ALTER TABLE company_profiles
+ADD COLUMN IF NOT EXISTS merged_into_id BIGINT REFERENCES company_profiles(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_company_profiles_merged_into ON company_profiles(merged_into_id) WHERE merged_into_id IS NOT NULL;
+
+-- B. One row per merge (a company can only be merged away once)
+CREATE TABLE IF NOT EXISTS company_merges (
+    id BIGSERIAL PRIMARY KEY,
+    surviving_company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    duplicate_company_id BIGINT NOT NULL UNIQUE REFERENCES company_profiles(id) ON DELETE CASCADE,
+    merged_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (surviving_company_id <> duplicate_company_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_merges_created ON company_merges(created_at DESC);
+
+-- C. Audit entries: what each merge moved
+CREATE TABLE IF NOT EXISTS company_merge_audit_entries (
+    id BIGSERIAL PRIMARY KEY,
+    merge_id BIGINT NOT NULL REFERENCES company_merges(id) ON DELETE CASCADE,
+    entity TEXT NOT NULL,            -- jobs, applications, team_members, gallery_media, company_profile
+    action TEXT NOT NULL,            -- REASSIGNED, COPIED, ARCHIVED
+    affected_count INT NOT NULL DEFAULT 0,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_merge_audit_merge ON company_merge_audit_entries(merge_id);
+
+-- D. Archiving a duplicate is not a profile edit: skip the verification reset for
+-- archived company profiles (keeps the 000038 aggregate guard)
+CREATE OR REPLACE FUNCTION handle_new_verification()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND TG_TABLE_NAME = 'candidate_profiles'
+       AND (to_jsonb(NEW) - 'total_experience_months' - 'updated_at')
+           = (to_jsonb(OLD) - 'total_experience_months' - 'updated_at') THEN
+        RETURN NEW;
+    END IF;
+    IF TG_TABLE_NAME = 'company_profiles' AND (to_jsonb(NEW) ->> 'archived_at') IS NOT NULL THEN
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO account_verifications (user_id, role, status, submitted_at, updated_at)
+    VALUES (
+        NEW.user_id,
+        CASE
+            WHEN TG_TABLE_NAME = 'candidate_profiles' THEN 'CANDIDATE'
+            WHEN TG_TABLE_NAME = 'company_profiles' THEN 'EMPLOYER'
+            ELSE 'CANDIDATE' -- Default fallback, though should catch by TG_TABLE_NAME
+        END,
+        'PENDING',
+        NOW(),
+        NOW()
+    )
+    ON CONFLICT (user_id) DO UPDATE
+    SET
+        status = 'PENDING',
+        submitted_at = NOW(),
+        updated_at = NOW(),
+        verified_at = NULL,
+        verified_by = NULL
+    WHERE account_verifications.status = 'REJECTED'; -- Only reset to PENDING if currently REJECTED
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
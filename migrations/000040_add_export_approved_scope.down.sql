@@ -0,0 +1,13 @@
+-- ============================================================================
+-- Migration: 000040_add_export_approved_scope (DOWN)
+-- Purpose: Rollback approved export scopes
+-- ============================================================================
+
+ALTER TABLE export_requests
+DROP COLUMN IF EXISTS redact_ips,
+DROP COLUMN IF EXISTS approved_subject,
+DROP COLUMN IF EXISTS approved_ip,
+DROP COLUMN IF EXISTS approved_severity,
+DROP COLUMN IF EXISTS approved_event_types,
+DROP COLUMN IF EXISTS approved_end_time,
+DROP COLUMN IF EXISTS approved_start_time;
@@ -0,0 +1,24 @@
+-- ============================================================================
+-- Migration: 000040_add_export_approved_scope
+-- Purpose: Store the scope an approver actually granted for a security export,
+--          which may be narrower than the requested filter
+-- ============================================================================
+
+ALTER TABLE export_requests
+ADD COLUMN IF NOT EXISTS approved_start_time TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS approved_end_time TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS approved_event_types TEXT[],
+ADD COLUMN IF NOT EXISTS approved_severity security_severity[],
+ADD COLUMN IF NOT EXISTS approved_ip TEXT,
+ADD COLUMN IF NOT EXISTS approved_subject TEXT,
+ADD COLUMN IF NOT EXISTS redact_ips BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Exports approved before scopes existed were approved as requested
+UPDATE export_requests
+SET approved_start_time = filter_start_time,
+    approved_end_time = filter_end_time,
+    approved_event_types = filter_event_types,
+    approved_severity = filter_severity,
+    approved_ip = filter_ip,
+    approved_subject = filter_subject
+WHERE status = 'approved';
@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000041_create_integrity_verification_runs (DOWN)
+-- Purpose: Rollback integrity verification history
+-- ============================================================================
+
+DROP TABLE IF EXISTS integrity_verification_runs;
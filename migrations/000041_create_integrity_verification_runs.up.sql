@@ -0,0 +1,26 @@
+-- ============================================================================
+-- Migration: 000041_create_integrity_verification_runs
+-- Purpose: History of security log integrity verifications (scheduled and manual)
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS integrity_verification_runs (
+    id BIGSERIAL PRIMARY KEY,
+    trigger_source VARCHAR(20) NOT NULL CHECK (trigger_source IN ('SCHEDULED', 'MANUAL')),
+    triggered_by UUID REFERENCES security_users(id) ON DELETE SET NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL,     -- intact, degraded, compromised, error
+    total_events BIGINT NOT NULL DEFAULT 0,
+    chain_breaks BIGINT NOT NULL DEFAULT 0,
+    anchor_mismatches BIGINT NOT NULL DEFAULT 0,
+    missing_anchors BIGINT NOT NULL DEFAULT 0,
+    first_break_event_id BIGINT,
+    failed_dates DATE[] NOT NULL DEFAULT '{}',
+    error_message TEXT,
+    alerted_admins INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_integrity_runs_created ON integrity_verification_runs(created_at DESC);
+
+ALTER TABLE integrity_verification_runs ENABLE ROW LEVEL SECURITY;
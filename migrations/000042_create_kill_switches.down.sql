@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000042_create_kill_switches (DOWN)
+-- Purpose: Rollback kill switches and their audit trail
+-- ============================================================================
+
+DROP TABLE IF EXISTS kill_switch_audit_entries;
+DROP TABLE IF EXISTS kill_switches;
@@ -0,0 +1,35 @@
+-- ============================================================================
+-- Migration: 000042_create_kill_switches
+-- Purpose: Runtime kill switches for endpoints and subsystems (maintenance
+--          windows) with an audit trail of every toggle
+-- ============================================================================
+
+-- A. Current state, one row per switch that has ever been toggled.
+-- key is a subsystem name (uploads, exports, registration) or an endpoint
+-- in the form "METHOD /v1/route/:param".
+CREATE TABLE IF NOT EXISTS kill_switches (
+    key VARCHAR(200) PRIMARY KEY,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT,                    -- shown to clients in the 503 response
+    reenable_at TIMESTAMPTZ,         -- NULL = stays off until re-enabled by an admin
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kill_switches_reenable
+    ON kill_switches(reenable_at) WHERE disabled AND reenable_at IS NOT NULL;
+
+-- B. Every toggle, including automatic re-enables (actor_id NULL)
+CREATE TABLE IF NOT EXISTS kill_switch_audit_entries (
+    id BIGSERIAL PRIMARY KEY,
+    switch_key VARCHAR(200) NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('DISABLED', 'ENABLED', 'AUTO_ENABLED')),
+    message TEXT,
+    reason TEXT,
+    reenable_at TIMESTAMPTZ,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_kill_switch_audit_key ON kill_switch_audit_entries(switch_key, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_kill_switch_audit_created ON kill_switch_audit_entries(created_at DESC);
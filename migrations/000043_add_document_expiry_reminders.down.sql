@@ -0,0 +1,18 @@
+-- ============================================================================
+-- Migration: 000043_add_document_expiry_reminders (DOWN)
+-- Purpose: Rollback document expiry dates, the expiry view and the reminder log
+-- ============================================================================
+
+DROP TABLE IF EXISTS document_expiry_reminders;
+DROP VIEW IF EXISTS candidate_document_expiries;
+
+DROP INDEX IF EXISTS idx_candidate_certificates_expires;
+DROP INDEX IF EXISTS idx_av_medical_check_expiry;
+DROP INDEX IF EXISTS idx_av_passport_expiry;
+
+ALTER TABLE account_verifications
+DROP COLUMN IF EXISTS medical_check_expiry_date,
+DROP COLUMN IF EXISTS medical_check_url,
+DROP COLUMN IF EXISTS jlpt_certificate_expiry_date,
+DROP COLUMN IF EXISTS passport_expiry_date,
+DROP COLUMN IF EXISTS passport_url;
@@ -0,0 +1,68 @@
+-- ============================================================================
+-- Migration: 000043_add_document_expiry_reminders
+-- Purpose: Expiry dates for candidate documents (passport, JLPT, medical check),
+--          one view over every expiring document, and a log of reminders sent
+-- ============================================================================
+
+-- A. Document metadata on the candidate's verification record (next to the CoE)
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS passport_url TEXT,
+ADD COLUMN IF NOT EXISTS passport_expiry_date DATE,
+ADD COLUMN IF NOT EXISTS jlpt_certificate_expiry_date DATE,
+ADD COLUMN IF NOT EXISTS medical_check_url TEXT,
+ADD COLUMN IF NOT EXISTS medical_check_expiry_date DATE;
+
+CREATE INDEX IF NOT EXISTS idx_av_passport_expiry ON account_verifications(passport_expiry_date)
+    WHERE passport_expiry_date IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_av_medical_check_expiry ON account_verifications(medical_check_expiry_date)
+    WHERE medical_check_expiry_date IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_candidate_certificates_expires ON candidate_certificates(expires_date)
+    WHERE expires_date IS NOT NULL;
+
+COMMENT ON COLUMN account_verifications.passport_expiry_date IS 'Passport expiry; reminders at 90/30/7 days';
+COMMENT ON COLUMN account_verifications.jlpt_certificate_expiry_date IS 'Date the JLPT result stops being accepted (program/employer rule)';
+COMMENT ON COLUMN account_verifications.medical_check_expiry_date IS 'Pre-departure medical check validity';
+
+-- B. Every expiring candidate document in one shape.
+-- document_ref distinguishes several documents of one type (certificate id); '' otherwise.
+-- critical documents block departure and are flagged in the ATS.
+CREATE OR REPLACE VIEW candidate_document_expiries AS
+    SELECT av.user_id, 'PASSPORT'::TEXT AS document_type, ''::TEXT AS document_ref,
+           NULL::TEXT AS document_label, av.passport_expiry_date AS expiry_date, TRUE AS critical
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.passport_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'JLPT', '', av.japanese_level, av.jlpt_certificate_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.jlpt_certificate_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'MEDICAL_CHECK', '', NULL, av.medical_check_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.medical_check_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'COE', '', NULL, av.coe_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.coe_status = 'ISSUED' AND av.coe_expiry_date IS NOT NULL
+UNION ALL
+    SELECT cc.user_id, 'CERTIFICATE', cc.id::TEXT, COALESCE(cc.certificate_name, cc.certificate_type),
+           cc.expires_date, FALSE
+    FROM candidate_certificates cc
+    WHERE cc.expires_date IS NOT NULL;
+
+-- C. Reminders sent per document, expiry date and threshold (90/30/7 days).
+-- A renewed document has a new expiry date, so its reminders start over.
+CREATE TABLE IF NOT EXISTS document_expiry_reminders (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document_type VARCHAR(20) NOT NULL,
+    document_ref TEXT NOT NULL DEFAULT '',
+    expiry_date DATE NOT NULL,
+    threshold_days INT NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('SENT', 'FAILED')),
+    error_message TEXT,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, document_type, document_ref, expiry_date, threshold_days)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_expiry_reminders_sent ON document_expiry_reminders(sent_at DESC);
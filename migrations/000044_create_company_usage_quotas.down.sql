@@ -0,0 +1,13 @@
+-- ============================================================================
+-- Migration: 000044_create_company_usage_quotas (DOWN)
+-- Purpose: Rollback plan quotas and usage counters
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_jobs_company_created;
+DROP INDEX IF EXISTS idx_credit_ledger_company_reveals;
+DROP TABLE IF EXISTS company_usage_counters;
+
+ALTER TABLE billing_plans
+    DROP COLUMN IF EXISTS monthly_api_calls,
+    DROP COLUMN IF EXISTS monthly_exports,
+    DROP COLUMN IF EXISTS job_slots;
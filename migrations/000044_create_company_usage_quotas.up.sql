@@ -0,0 +1,27 @@
+-- ============================================================================
+-- Migration: 000044_create_company_usage_quotas
+-- Purpose: Per-plan quotas and daily usage counters for the employer usage dashboard
+-- ============================================================================
+
+-- A. Plan Quotas
+-- NULL means the plan has no limit for that metric. Contact unlocks are limited
+-- by credits (monthly_credits + purchases), so they need no column here.
+ALTER TABLE billing_plans
+    ADD COLUMN IF NOT EXISTS job_slots INTEGER CHECK (job_slots >= 0),
+    ADD COLUMN IF NOT EXISTS monthly_exports INTEGER CHECK (monthly_exports >= 0),
+    ADD COLUMN IF NOT EXISTS monthly_api_calls INTEGER CHECK (monthly_api_calls >= 0);
+
+-- B. Usage Counters
+-- Daily counters for usage that has no table of its own (API-key calls).
+-- Incremented with INSERT ... ON CONFLICT DO UPDATE SET count = count + n.
+CREATE TABLE IF NOT EXISTS company_usage_counters (
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    metric TEXT NOT NULL,
+    period_date DATE NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0 CHECK (count >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (company_id, metric, period_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_credit_ledger_company_reveals ON company_credit_ledger(company_id, created_at) WHERE entry_type = 'reveal';
+CREATE INDEX IF NOT EXISTS idx_jobs_company_created ON jobs(company_id, created_at);
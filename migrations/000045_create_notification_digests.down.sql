@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000045_create_notification_digests (DOWN)
+-- Purpose: Rollback the notification digest queue
+-- ============================================================================
+
+DROP TABLE IF EXISTS notification_digest_items;
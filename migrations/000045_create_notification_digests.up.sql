@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Migration: 000045_create_notification_digests
+-- Purpose: Queue of non-critical notification events batched into digest emails
+-- ============================================================================
+
+-- Items are localized when queued. A user's items are sent as one digest once the
+-- oldest unsent item is older than the digest window; failed sends are retried.
+CREATE TABLE IF NOT EXISTS notification_digest_items (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_digest_items_pending ON notification_digest_items(user_id, created_at) WHERE sent_at IS NULL;
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000046_add_admin_search_indexes (DOWN)
+-- Purpose: Rollback admin global search indexes
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_jobs_title_trgm;
+DROP INDEX IF EXISTS idx_company_profiles_name_trgm;
+DROP INDEX IF EXISTS idx_account_verifications_phone_trgm;
+DROP INDEX IF EXISTS idx_account_verifications_name_trgm;
+DROP INDEX IF EXISTS idx_users_email_trgm;
+-- pg_trgm is left installed; other objects may depend on it
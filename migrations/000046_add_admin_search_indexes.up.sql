@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Migration: 000046_add_admin_search_indexes
+-- Purpose: Trigram indexes for admin global search (substring matches with ILIKE)
+-- ============================================================================
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- A. Users by email
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING GIN (email gin_trgm_ops);
+
+-- B. Candidates by name and phone (digits only, so formatting does not matter)
+CREATE INDEX IF NOT EXISTS idx_account_verifications_name_trgm ON account_verifications
+    USING GIN ((COALESCE(first_name, '') || ' ' || COALESCE(last_name, '')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_account_verifications_phone_trgm ON account_verifications
+    USING GIN ((regexp_replace(COALESCE(phone, ''), '[^0-9]', '', 'g')) gin_trgm_ops);
+
+-- C. Companies by name
+CREATE INDEX IF NOT EXISTS idx_company_profiles_name_trgm ON company_profiles USING GIN (company_name gin_trgm_ops);
+
+-- D. Jobs by title
+CREATE INDEX IF NOT EXISTS idx_jobs_title_trgm ON jobs USING GIN (title gin_trgm_ops);
@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000047_create_saved_searches (DOWN)
+-- Purpose: Rollback candidate saved searches and job alerts
+-- ============================================================================
+
+DROP TABLE IF EXISTS saved_search_alerts;
+DROP TABLE IF EXISTS saved_searches;
@@ -0,0 +1,36 @@
+-- ============================================================================
+-- Migration: 000047_create_saved_searches
+-- Purpose: Candidate saved job searches and the job alert emails sent for them
+-- ============================================================================
+
+-- filters holds the JSON job filter. alerted_until is the alert watermark: the next
+-- alert lists active jobs created after it, and it only moves forward once an alert
+-- was sent (or there was nothing to send), so failed sends are retried.
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    filters JSONB NOT NULL DEFAULT '{}'::JSONB,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    alert_frequency TEXT NOT NULL DEFAULT 'DAILY' CHECK (alert_frequency IN ('DAILY', 'WEEKLY')),
+    alerted_until TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user ON saved_searches(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_alerts_due ON saved_searches(alerted_until) WHERE alerts_enabled;
+
+-- One row per alert attempt, for delivery reporting
+CREATE TABLE IF NOT EXISTS saved_search_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    saved_search_id BIGINT NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    job_count INTEGER NOT NULL,
+    channel TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('SENT', 'FAILED')),
+    error_message TEXT,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_search_alerts_search ON saved_search_alerts(saved_search_id, sent_at DESC);
@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000048_create_interview_feedback (DOWN)
+-- Purpose: Rollback interview feedback sharing
+-- ============================================================================
+
+DROP TABLE IF EXISTS interview_feedback;
+ALTER TABLE users DROP COLUMN IF EXISTS interview_feedback_opt_out_at;
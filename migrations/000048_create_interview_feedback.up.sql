@@ -0,0 +1,28 @@
+-- ============================================================================
+-- Migration: 000048_create_interview_feedback
+-- Purpose: Constructive feedback employers share with rejected candidates
+-- ============================================================================
+
+-- Candidates can stop receiving interview feedback
+ALTER TABLE users ADD COLUMN IF NOT EXISTS interview_feedback_opt_out_at TIMESTAMPTZ;
+
+-- One feedback per application. template_keys are approved wordings (translated at
+-- delivery); a custom message always goes through admin review before it is sent.
+CREATE TABLE IF NOT EXISTS interview_feedback (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL UNIQUE REFERENCES applications(id) ON DELETE CASCADE,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    candidate_user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    author_user_id UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    template_keys TEXT[] NOT NULL,
+    custom_message TEXT,
+    status TEXT NOT NULL CHECK (status IN ('PENDING_REVIEW', 'APPROVED', 'SENT', 'SUPPRESSED', 'REJECTED')),
+    review_note TEXT,
+    reviewed_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_interview_feedback_status ON interview_feedback(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_interview_feedback_candidate ON interview_feedback(candidate_user_id, sent_at DESC) WHERE status = 'SENT';
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000049_create_application_stages (DOWN)
+-- Purpose: Rollback the application stage pipeline
+-- ============================================================================
+
+DROP TABLE IF EXISTS application_stage_history;
+ALTER TABLE jobs DROP COLUMN IF EXISTS pipeline_stages;
+DROP INDEX IF EXISTS idx_applications_job_stage;
+ALTER TABLE applications
+    DROP COLUMN IF EXISTS stage_changed_at,
+    DROP COLUMN IF EXISTS stage;
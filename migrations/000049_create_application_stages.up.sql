@@ -0,0 +1,40 @@
+-- ============================================================================
+-- Migration: 000049_create_application_stages
+-- Purpose: Kanban stage pipeline for applications with stage history and
+--          per-job stage configuration
+-- ============================================================================
+
+-- The stage is the employer's pipeline position; status stays the candidate-facing
+-- outcome (screening/interview/offer = reviewed, hired = accepted)
+ALTER TABLE applications
+    ADD COLUMN IF NOT EXISTS stage TEXT NOT NULL DEFAULT 'applied'
+        CHECK (stage IN ('applied', 'screening', 'interview', 'offer', 'hired', 'rejected')),
+    ADD COLUMN IF NOT EXISTS stage_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE applications SET
+    stage = CASE status
+        WHEN 'reviewed' THEN 'screening'
+        WHEN 'accepted' THEN 'hired'
+        WHEN 'rejected' THEN 'rejected'
+        ELSE 'applied'
+    END,
+    stage_changed_at = updated_at;
+
+CREATE INDEX IF NOT EXISTS idx_applications_job_stage ON applications(job_id, stage);
+
+-- Optional stages (screening, interview, offer) a job uses; NULL means all of them.
+-- applied, hired and rejected are always available.
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS pipeline_stages TEXT[];
+
+-- Every stage move, including the initial one when the application is created
+CREATE TABLE IF NOT EXISTS application_stage_history (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    from_stage TEXT,
+    to_stage TEXT NOT NULL,
+    note TEXT,
+    changed_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_stage_history_application ON application_stage_history(application_id, changed_at);
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000050_create_warehouse_exports (DOWN)
+-- Purpose: Rollback warehouse export state
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_application_stage_history_changed_at;
+DROP INDEX IF EXISTS idx_account_verifications_updated_at;
+DROP INDEX IF EXISTS idx_applications_updated_at;
+DROP INDEX IF EXISTS idx_jobs_updated_at;
+
+DROP TABLE IF EXISTS warehouse_export_state;
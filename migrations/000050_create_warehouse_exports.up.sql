@@ -0,0 +1,25 @@
+-- ============================================================================
+-- Migration: 000050_create_warehouse_exports
+-- Purpose: Track the nightly data warehouse export per table (incremental
+--          watermark, schema version, last successful export)
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS warehouse_export_state (
+    table_name TEXT PRIMARY KEY,          -- exported dataset, e.g. jobs, applications
+    schema_version INT NOT NULL,          -- a new version re-exports from the start
+    watermark TIMESTAMPTZ,                -- rows changed up to here are exported; NULL = never
+    last_success_at TIMESTAMPTZ,
+    last_row_count BIGINT NOT NULL DEFAULT 0,
+    last_file_count INT NOT NULL DEFAULT 0,
+    last_object_prefix TEXT,              -- partition written by the last successful export
+    last_attempt_at TIMESTAMPTZ,
+    last_status TEXT CHECK (last_status IN ('COMPLETED', 'FAILED')),
+    last_error TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Incremental windows on the source tables
+CREATE INDEX IF NOT EXISTS idx_jobs_updated_at ON jobs(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_applications_updated_at ON applications(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_account_verifications_updated_at ON account_verifications(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_application_stage_history_changed_at ON application_stage_history(changed_at, id);
@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000051_create_auth_refresh_tokens (DOWN)
+-- Purpose: Rollback refresh token records
+-- ============================================================================
+
+DROP TABLE IF EXISTS auth_refresh_tokens;
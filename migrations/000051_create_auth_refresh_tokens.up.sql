@@ -0,0 +1,32 @@
+-- ============================================================================
+-- Migration: 000051_create_auth_refresh_tokens
+-- Purpose: Server-side record of issued refresh tokens for rotation, reuse
+--          detection and revocation on logout
+-- ============================================================================
+
+-- Only a SHA-256 hash of each token is stored. Every rotation adds a row to the
+-- same family; presenting a token that was already rotated revokes the family.
+CREATE TABLE IF NOT EXISTS auth_refresh_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    family_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    token_hash TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'ROTATED', 'REVOKED')),
+    replaced_by BIGINT REFERENCES auth_refresh_tokens(id) ON DELETE SET NULL,
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    revoked_reason TEXT -- logout, reuse_detected, provider_rejected
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_refresh_tokens_family ON auth_refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_auth_refresh_tokens_user ON auth_refresh_tokens(user_id, status);
+CREATE INDEX IF NOT EXISTS idx_auth_refresh_tokens_expires ON auth_refresh_tokens(expires_at);
+
+-- Token hashes are credentials: only the backend (service role) may read them
+ALTER TABLE auth_refresh_tokens ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "Service role full access" ON auth_refresh_tokens
+    FOR ALL USING (auth.role() = 'service_role');
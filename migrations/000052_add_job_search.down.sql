@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000052_add_job_search (DOWN)
+-- Purpose: Rollback full-text job search and the JLPT requirement
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_jobs_active_created;
+DROP INDEX IF EXISTS idx_jobs_search_vector;
+ALTER TABLE jobs DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE jobs DROP COLUMN IF EXISTS japanese_level_required;
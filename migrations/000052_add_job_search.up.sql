@@ -0,0 +1,22 @@
+-- ============================================================================
+-- Migration: 000052_add_job_search
+-- Purpose: Full-text job search (weighted tsvector + GIN index) and the
+--          JLPT level a job requires
+-- ============================================================================
+
+-- Minimum JLPT level a job requires; NULL means no Japanese requirement
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS japanese_level_required VARCHAR(3)
+        CHECK (japanese_level_required IN ('N1', 'N2', 'N3', 'N4', 'N5'));
+
+-- Title matches rank above description matches, which rank above qualifications.
+-- 'simple' does no stemming: postings mix Indonesian, English and Japanese.
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', COALESCE(title, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(description, '')), 'B') ||
+        setweight(to_tsvector('simple', COALESCE(qualifications, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_search_vector ON jobs USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_jobs_active_created ON jobs(created_at DESC) WHERE company_status = 'active';
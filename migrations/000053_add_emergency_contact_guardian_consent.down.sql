@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000053_add_emergency_contact_guardian_consent (DOWN)
+-- Purpose: Rollback emergency contact and guardian consent fields
+-- ============================================================================
+
+ALTER TABLE account_verifications
+DROP CONSTRAINT IF EXISTS chk_av_emergency_contact_complete,
+DROP COLUMN IF EXISTS guardian_consent_url,
+DROP COLUMN IF EXISTS emergency_contact_relationship,
+DROP COLUMN IF EXISTS emergency_contact_phone,
+DROP COLUMN IF EXISTS emergency_contact_name;
@@ -0,0 +1,31 @@
+-- ============================================================================
+-- Migration: 000053_add_emergency_contact_guardian_consent
+-- Purpose: Candidate emergency contact and guardian consent document for minors
+-- ============================================================================
+
+-- A. Emergency contact
+-- The three fields are set together; the phone is stored in E.164 format.
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS emergency_contact_name TEXT,
+ADD COLUMN IF NOT EXISTS emergency_contact_phone TEXT,
+ADD COLUMN IF NOT EXISTS emergency_contact_relationship TEXT
+    CHECK (emergency_contact_relationship IS NULL OR emergency_contact_relationship IN
+        ('PARENT', 'GUARDIAN', 'SPOUSE', 'SIBLING', 'RELATIVE', 'FRIEND', 'OTHER')),
+ADD CONSTRAINT chk_av_emergency_contact_complete
+    CHECK ((emergency_contact_name IS NULL) = (emergency_contact_phone IS NULL)
+       AND (emergency_contact_name IS NULL) = (emergency_contact_relationship IS NULL));
+
+-- B. Guardian consent
+-- Candidates under the configured age (GUARDIAN_CONSENT_UNDER_AGE) cannot submit
+-- their profile for verification until a signed consent document is uploaded.
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS guardian_consent_url TEXT;
+
+-- ============================================================================
+-- Comments for Documentation
+-- ============================================================================
+
+COMMENT ON COLUMN account_verifications.emergency_contact_name IS 'Emergency contact full name';
+COMMENT ON COLUMN account_verifications.emergency_contact_phone IS 'Emergency contact phone number (E.164)';
+COMMENT ON COLUMN account_verifications.emergency_contact_relationship IS 'Emergency contact relationship: PARENT, GUARDIAN, SPOUSE, SIBLING, RELATIVE, FRIEND, OTHER';
+COMMENT ON COLUMN account_verifications.guardian_consent_url IS 'Signed guardian consent document, required for minors before submission';
@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000054_add_talent_pool_pii_grants (DOWN)
+-- Purpose: Rollback talent pool visibility and PII access grants
+-- ============================================================================
+
+DROP TABLE IF EXISTS candidate_pii_grants;
+
+DROP INDEX IF EXISTS idx_av_talent_pool_visible;
+
+ALTER TABLE account_verifications
+DROP COLUMN IF EXISTS talent_pool_visible;
@@ -0,0 +1,31 @@
+-- ============================================================================
+-- Migration: 000054_add_talent_pool_pii_grants
+-- Purpose: Candidate talent pool opt-in and per-company PII access grants for
+--          the employer-facing ATS search
+-- ============================================================================
+
+-- A. Talent pool visibility
+-- Employers only see candidates who applied to one of their jobs, or who opted in here.
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS talent_pool_visible BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_av_talent_pool_visible ON account_verifications(user_id) WHERE talent_pool_visible;
+
+-- B. PII access grants
+-- Name and photo are masked in employer ATS results unless the candidate granted
+-- the company access (or the company already unlocked the candidate's contact).
+CREATE TABLE IF NOT EXISTS candidate_pii_grants (
+    candidate_user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (candidate_user_id, company_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pii_grants_company ON candidate_pii_grants(company_id);
+
+-- ============================================================================
+-- Comments for Documentation
+-- ============================================================================
+
+COMMENT ON COLUMN account_verifications.talent_pool_visible IS 'Candidate opted in to appear in employer ATS search without applying';
+COMMENT ON TABLE candidate_pii_grants IS 'Companies a candidate allowed to see their name and photo in employer ATS search';
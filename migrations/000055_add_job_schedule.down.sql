@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Migration: 000055_add_job_schedule (DOWN)
+-- Purpose: Rollback job posting schedules
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_jobs_unpublish_due;
+DROP INDEX IF EXISTS idx_jobs_publish_due;
+
+-- Jobs that were waiting to publish are left unpublished
+UPDATE jobs SET company_status = 'draft' WHERE company_status = 'scheduled';
+
+ALTER TABLE jobs
+DROP CONSTRAINT IF EXISTS chk_jobs_schedule_order,
+DROP COLUMN IF EXISTS unpublish_at,
+DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,25 @@
+-- ============================================================================
+-- Migration: 000055_add_job_schedule
+-- Purpose: Scheduled publishing and automatic unpublishing of job postings
+-- ============================================================================
+
+-- company_status now also takes 'scheduled' (publishes at publish_at), 'draft'
+-- (scheduled publication cancelled) and 'expired' (unpublished at unpublish_at).
+-- The scheduler worker moves statuses; public queries also compare the times,
+-- so listings follow the schedule between worker runs.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ,
+ADD CONSTRAINT chk_jobs_schedule_order
+    CHECK (publish_at IS NULL OR unpublish_at IS NULL OR unpublish_at > publish_at);
+
+-- Due transitions for the scheduler worker
+CREATE INDEX IF NOT EXISTS idx_jobs_publish_due ON jobs(publish_at) WHERE company_status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_jobs_unpublish_due ON jobs(unpublish_at) WHERE company_status = 'active' AND unpublish_at IS NOT NULL;
+
+-- ============================================================================
+-- Comments for Documentation
+-- ============================================================================
+
+COMMENT ON COLUMN jobs.publish_at IS 'When the job went or goes live; job alerts use it instead of created_at';
+COMMENT ON COLUMN jobs.unpublish_at IS 'When the job is automatically unpublished (status expired)';
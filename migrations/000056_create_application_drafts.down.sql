@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000056_create_application_drafts (DOWN)
+-- Purpose: Rollback application drafts
+-- ============================================================================
+
+DROP TABLE IF EXISTS application_drafts;
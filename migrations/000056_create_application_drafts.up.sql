@@ -0,0 +1,32 @@
+-- ============================================================================
+-- Migration: 000056_create_application_drafts
+-- Purpose: Unsubmitted applications, with a resume reminder and automatic expiry
+-- ============================================================================
+
+-- One draft per candidate and job. Saving a draft moves expires_at forward and
+-- clears reminder_sent_at, so a candidate who comes back and leaves again gets
+-- another reminder. The draft is deleted when the application is submitted.
+CREATE TABLE IF NOT EXISTS application_drafts (
+    candidate_user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    cv_url TEXT,
+    cover_letter TEXT,
+    answers JSONB NOT NULL DEFAULT '[]'::JSONB,
+    reminder_sent_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (candidate_user_id, job_id)
+);
+
+-- Reminder worker: drafts untouched for a while with no reminder yet
+CREATE INDEX IF NOT EXISTS idx_application_drafts_reminder_due ON application_drafts(updated_at) WHERE reminder_sent_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_application_drafts_expires_at ON application_drafts(expires_at);
+
+-- ============================================================================
+-- Comments for Documentation
+-- ============================================================================
+
+COMMENT ON TABLE application_drafts IS 'Applications a candidate started but did not submit yet';
+COMMENT ON COLUMN application_drafts.answers IS 'Screening answers as [{"question_id", "answer", "file_url"}], validated on submit';
+COMMENT ON COLUMN application_drafts.reminder_sent_at IS 'When the resume-draft reminder went out; NULL until then';
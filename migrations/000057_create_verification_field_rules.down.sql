@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000057_create_verification_field_rules (DOWN)
+-- Purpose: Rollback configurable verification fields
+-- ============================================================================
+
+DROP TABLE IF EXISTS verification_field_rules;
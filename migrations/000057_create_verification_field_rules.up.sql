@@ -0,0 +1,23 @@
+-- ============================================================================
+-- Migration: 000057_create_verification_field_rules
+-- Purpose: Admin-configurable required/optional/hidden fields per verification role
+-- ============================================================================
+
+-- Overrides of the built-in field defaults. A field without a row keeps its
+-- default, so new profile fields need no migration to appear in the schema.
+CREATE TABLE IF NOT EXISTS verification_field_rules (
+    role TEXT NOT NULL CHECK (role IN ('CANDIDATE', 'EMPLOYER')),
+    field TEXT NOT NULL,
+    requirement TEXT NOT NULL CHECK (requirement IN ('REQUIRED', 'OPTIONAL', 'HIDDEN')),
+    updated_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (role, field)
+);
+
+-- ============================================================================
+-- Comments for Documentation
+-- ============================================================================
+
+COMMENT ON TABLE verification_field_rules IS 'Admin overrides of which verification fields are required, optional or hidden';
+COMMENT ON COLUMN verification_field_rules.field IS 'JSON field name on the candidate verification profile or company profile';
+COMMENT ON COLUMN verification_field_rules.requirement IS 'REQUIRED blocks submission/approval when empty; HIDDEN fields are not collected';
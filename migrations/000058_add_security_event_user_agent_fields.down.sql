@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_security_events_device_class;
+
+ALTER TABLE security_events
+    DROP COLUMN IF EXISTS ua_scanner,
+    DROP COLUMN IF EXISTS ua_device_class,
+    DROP COLUMN IF EXISTS ua_os,
+    DROP COLUMN IF EXISTS ua_browser;
@@ -0,0 +1,11 @@
+-- Client parsed from user_agent when the event is written (security.ParseUserAgent).
+-- Older rows stay NULL and are parsed when read; they age out with the 90-day retention.
+ALTER TABLE security_events
+    ADD COLUMN IF NOT EXISTS ua_browser VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS ua_os VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS ua_device_class VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS ua_scanner BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_security_events_device_class ON security_events(ua_device_class, created_at);
+
+COMMENT ON COLUMN security_events.ua_device_class IS 'desktop, mobile, tablet, bot or unknown; bots and scanners raise the severity';
@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000059_create_anchor_heartbeats (DOWN)
+-- Purpose: Rollback the anchoring heartbeat
+-- ============================================================================
+
+DROP TABLE IF EXISTS anchor_heartbeat_alerts;
+DROP TABLE IF EXISTS anchor_heartbeats;
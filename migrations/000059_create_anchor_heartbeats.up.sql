@@ -0,0 +1,24 @@
+-- ============================================================================
+-- Migration: 000059_create_anchor_heartbeats
+-- Purpose: Dead-man switch for the anchoring pipeline: one heartbeat per
+--          successful anchor run, and one alert per missed heartbeat
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS anchor_heartbeats (
+    id BIGSERIAL PRIMARY KEY,
+    anchor_date DATE NOT NULL,
+    event_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_anchor_heartbeats_created ON anchor_heartbeats(created_at DESC);
+
+-- Keyed by the last heartbeat seen when the alert fired (0 = never), so every
+-- instance running the check alerts once per outage
+CREATE TABLE IF NOT EXISTS anchor_heartbeat_alerts (
+    last_heartbeat_id BIGINT PRIMARY KEY,
+    alerted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE anchor_heartbeats ENABLE ROW LEVEL SECURITY;
+ALTER TABLE anchor_heartbeat_alerts ENABLE ROW LEVEL SECURITY;
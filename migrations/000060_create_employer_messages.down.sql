@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000060_create_employer_messages (DOWN)
+-- Purpose: Rollback employer bulk messaging
+-- ============================================================================
+
+DROP TABLE IF EXISTS employer_message_deliveries;
+DROP TABLE IF EXISTS employer_messages;
+DROP TABLE IF EXISTS employer_message_templates;
@@ -0,0 +1,52 @@
+-- ============================================================================
+-- Migration: 000060_create_employer_messages
+-- Purpose: Employer message templates and bulk messages to shortlisted
+--          candidates, with per-recipient delivery status and spam reports
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS employer_message_templates (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_employer_message_templates_company ON employer_message_templates(company_id, name);
+
+-- subject/body keep the template as sent, so later template edits do not rewrite history
+CREATE TABLE IF NOT EXISTS employer_messages (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    template_id BIGINT REFERENCES employer_message_templates(id) ON DELETE SET NULL,
+    sender_user_id UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_employer_messages_company ON employer_messages(company_id, created_at DESC);
+
+-- One row per recipient with the rendered text. company_id is denormalized for
+-- the daily cap and spam report counts.
+CREATE TABLE IF NOT EXISTS employer_message_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT NOT NULL REFERENCES employer_messages(id) ON DELETE CASCADE,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    application_id BIGINT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    candidate_user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'BLOCKED')),
+    error_message TEXT,
+    sent_at TIMESTAMPTZ,
+    reported_spam_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_employer_message_deliveries_message ON employer_message_deliveries(message_id);
+CREATE INDEX IF NOT EXISTS idx_employer_message_deliveries_company ON employer_message_deliveries(company_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_employer_message_deliveries_candidate ON employer_message_deliveries(candidate_user_id, sent_at DESC) WHERE status = 'SENT';
+CREATE INDEX IF NOT EXISTS idx_employer_message_deliveries_spam ON employer_message_deliveries(company_id, reported_spam_at) WHERE reported_spam_at IS NOT NULL;
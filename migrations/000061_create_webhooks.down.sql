@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000061_create_webhooks (DOWN)
+-- Purpose: Rollback webhook endpoints and deliveries
+-- ============================================================================
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000062_create_storage_cleanup (DOWN)
+-- Purpose: Rollback the storage deletion queue and orphan sweeps
+-- ============================================================================
+
+DROP TABLE IF EXISTS storage_deletions;
+DROP TABLE IF EXISTS storage_orphan_sweeps;
+DROP INDEX IF EXISTS idx_uploaded_files_public_url;
+ALTER TABLE uploaded_files DROP COLUMN IF EXISTS superseded_at;
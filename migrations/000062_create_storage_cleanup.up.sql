@@ -0,0 +1,50 @@
+-- ============================================================================
+-- Migration: 000062_create_storage_cleanup
+-- Purpose: Retry-safe deletion queue for stored files and the history of the
+--          orphan sweeps that compare bucket listings against uploaded_files
+-- ============================================================================
+
+-- A file replaced by a newer upload no longer protects its object from the sweep
+ALTER TABLE uploaded_files ADD COLUMN IF NOT EXISTS superseded_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_uploaded_files_public_url ON uploaded_files(public_url) WHERE public_url IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS storage_orphan_sweeps (
+    id BIGSERIAL PRIMARY KEY,
+    trigger_source TEXT NOT NULL CHECK (trigger_source IN ('SCHEDULED', 'MANUAL')),
+    status TEXT NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    buckets_scanned INTEGER NOT NULL DEFAULT 0,
+    bucket_errors TEXT[] NOT NULL DEFAULT '{}',
+    objects_scanned INTEGER NOT NULL DEFAULT 0,
+    orphans_found INTEGER NOT NULL DEFAULT 0,
+    orphan_bytes BIGINT NOT NULL DEFAULT 0,
+    error_message TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_storage_orphan_sweeps_started ON storage_orphan_sweeps(started_at DESC);
+
+-- One row per object to remove. next_attempt_at is NULL once the object is
+-- deleted or the deletion was given up; reclaimed_bytes is 0 when the object
+-- was already gone.
+CREATE TABLE IF NOT EXISTS storage_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    bucket TEXT NOT NULL,
+    object_path TEXT NOT NULL,
+    reason TEXT NOT NULL CHECK (reason IN ('REPLACED', 'ORPHAN')),
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'DELETED', 'FAILED')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_error TEXT,
+    reclaimed_bytes BIGINT,
+    sweep_id BIGINT REFERENCES storage_orphan_sweeps(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- An object is queued at most once until it is deleted
+CREATE UNIQUE INDEX IF NOT EXISTS idx_storage_deletions_open_object ON storage_deletions(bucket, object_path) WHERE status <> 'DELETED';
+CREATE INDEX IF NOT EXISTS idx_storage_deletions_due ON storage_deletions(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_storage_deletions_status ON storage_deletions(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_storage_deletions_deleted ON storage_deletions(deleted_at) WHERE status = 'DELETED';
@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000063_create_maintenance_runs (DOWN)
+-- Purpose: Rollback maintenance runs
+-- ============================================================================
+
+DROP TABLE IF EXISTS maintenance_runs;
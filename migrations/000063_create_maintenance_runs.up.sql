@@ -0,0 +1,26 @@
+-- ============================================================================
+-- Migration: 000063_create_maintenance_runs
+-- Purpose: Admin-triggered operational tasks (statistics refresh, search index
+--          rebuild, aggregate recompute) with their progress
+-- ============================================================================
+
+-- heartbeat_at is refreshed while a run makes progress; a RUNNING row whose
+-- heartbeat stopped was left behind by a crashed instance
+CREATE TABLE IF NOT EXISTS maintenance_runs (
+    id BIGSERIAL PRIMARY KEY,
+    task TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'RUNNING' CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    steps_total INTEGER NOT NULL DEFAULT 0,
+    steps_done INTEGER NOT NULL DEFAULT 0,
+    current_step TEXT,
+    steps JSONB NOT NULL DEFAULT '[]',
+    error_message TEXT,
+    started_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    heartbeat_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+-- A task runs at most once at a time, across all instances
+CREATE UNIQUE INDEX IF NOT EXISTS idx_maintenance_runs_running ON maintenance_runs(task) WHERE status = 'RUNNING';
+CREATE INDEX IF NOT EXISTS idx_maintenance_runs_task ON maintenance_runs(task, started_at DESC);
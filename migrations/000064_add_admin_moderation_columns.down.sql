@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000064_add_admin_moderation_columns (DOWN)
+-- Purpose: Rollback admin moderation columns
+-- ============================================================================
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS flag_reason;
+ALTER TABLE jobs DROP COLUMN IF EXISTS is_flagged;
+ALTER TABLE jobs DROP COLUMN IF EXISTS status;
+ALTER TABLE users DROP COLUMN IF EXISTS is_disabled;
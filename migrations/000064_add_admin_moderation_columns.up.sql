@@ -0,0 +1,13 @@
+-- ============================================================================
+-- Migration: 000064_add_admin_moderation_columns
+-- Purpose: Columns behind admin user disabling and job hiding/flagging, which
+--          the admin repository used to add on the fly from request handlers
+-- ============================================================================
+
+-- IF NOT EXISTS: databases that served those admin requests already have them
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_disabled BOOLEAN DEFAULT false;
+
+-- Admin visibility ('active' / 'hidden'), separate from the employer's company_status
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS status VARCHAR(20) DEFAULT 'active';
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS is_flagged BOOLEAN DEFAULT false;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS flag_reason TEXT;
@@ -0,0 +1,29 @@
+-- ============================================================================
+-- Migration: 000065_create_candidate_documents (DOWN)
+-- Purpose: Rollback the candidate document vault and restore the 000043 view
+-- ============================================================================
+
+CREATE OR REPLACE VIEW candidate_document_expiries AS
+    SELECT av.user_id, 'PASSPORT'::TEXT AS document_type, ''::TEXT AS document_ref,
+           NULL::TEXT AS document_label, av.passport_expiry_date AS expiry_date, TRUE AS critical
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.passport_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'JLPT', '', av.japanese_level, av.jlpt_certificate_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.jlpt_certificate_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'MEDICAL_CHECK', '', NULL, av.medical_check_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.medical_check_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'COE', '', NULL, av.coe_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.coe_status = 'ISSUED' AND av.coe_expiry_date IS NOT NULL
+UNION ALL
+    SELECT cc.user_id, 'CERTIFICATE', cc.id::TEXT, COALESCE(cc.certificate_name, cc.certificate_type),
+           cc.expires_date, FALSE
+    FROM candidate_certificates cc
+    WHERE cc.expires_date IS NOT NULL;
+
+DROP TABLE IF EXISTS candidate_documents;
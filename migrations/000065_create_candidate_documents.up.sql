@@ -0,0 +1,64 @@
+-- ============================================================================
+-- Migration: 000065_create_candidate_documents
+-- Purpose: Candidate document vault (passport, JLPT certificate, diploma, visa)
+--          with admin review, and vault expiry dates in the expiry view so the
+--          reminder worker and the ATS flag cover them
+-- ============================================================================
+
+-- A. Typed documents uploaded by candidates. A candidate may keep several of a
+-- type (e.g. a renewed passport next to the old one until it is reviewed).
+CREATE TABLE IF NOT EXISTS candidate_documents (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    document_type VARCHAR(20) NOT NULL CHECK (document_type IN ('PASSPORT', 'JLPT', 'DIPLOMA', 'VISA')),
+    file_url TEXT NOT NULL,
+    document_number TEXT,
+    label TEXT, -- e.g. "N2", the institution of a diploma
+    issue_date DATE,
+    expiry_date DATE,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    review_notes TEXT,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (expiry_date IS NULL OR issue_date IS NULL OR expiry_date >= issue_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_documents_user ON candidate_documents(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_candidate_documents_pending ON candidate_documents(created_at)
+    WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_candidate_documents_expiry ON candidate_documents(expiry_date)
+    WHERE expiry_date IS NOT NULL;
+
+COMMENT ON COLUMN candidate_documents.status IS 'Admin review; rejected documents get no expiry reminders';
+
+-- B. The expiry view from 000043 plus vault documents. document_ref is the vault
+-- id, so each document keeps its own reminder history.
+CREATE OR REPLACE VIEW candidate_document_expiries AS
+    SELECT av.user_id, 'PASSPORT'::TEXT AS document_type, ''::TEXT AS document_ref,
+           NULL::TEXT AS document_label, av.passport_expiry_date AS expiry_date, TRUE AS critical
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.passport_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'JLPT', '', av.japanese_level, av.jlpt_certificate_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.jlpt_certificate_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'MEDICAL_CHECK', '', NULL, av.medical_check_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.medical_check_expiry_date IS NOT NULL
+UNION ALL
+    SELECT av.user_id, 'COE', '', NULL, av.coe_expiry_date, TRUE
+    FROM account_verifications av
+    WHERE av.role = 'CANDIDATE' AND av.coe_status = 'ISSUED' AND av.coe_expiry_date IS NOT NULL
+UNION ALL
+    SELECT cc.user_id, 'CERTIFICATE', cc.id::TEXT, COALESCE(cc.certificate_name, cc.certificate_type),
+           cc.expires_date, FALSE
+    FROM candidate_certificates cc
+    WHERE cc.expires_date IS NOT NULL
+UNION ALL
+    SELECT cd.user_id, cd.document_type, cd.id::TEXT, cd.label, cd.expiry_date,
+           cd.document_type IN ('PASSPORT', 'JLPT', 'VISA')
+    FROM candidate_documents cd
+    WHERE cd.status <> 'REJECTED' AND cd.expiry_date IS NOT NULL;
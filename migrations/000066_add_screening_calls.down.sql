@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000066_add_screening_calls (DOWN)
+-- Purpose: Rollback contact hours, screening calls and the activity feed
+-- ============================================================================
+
+DROP TABLE IF EXISTS candidate_activities;
+DROP TABLE IF EXISTS screening_calls;
+DROP TABLE IF EXISTS candidate_contact_hours;
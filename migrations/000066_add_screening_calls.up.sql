@@ -0,0 +1,51 @@
+-- ============================================================================
+-- Migration: 000066_add_screening_calls
+-- Purpose: Candidate preferred contact hours, screening calls booked within
+--          them by admins/employers, and a candidate activity feed
+-- ============================================================================
+
+-- A. When a candidate can take calls: weekly windows in the candidate's time zone,
+-- e.g. [{"weekday": 1, "start": "09:00", "end": "12:00"}] (weekday 1 = Monday)
+CREATE TABLE IF NOT EXISTS candidate_contact_hours (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone TEXT NOT NULL DEFAULT 'Asia/Jakarta',
+    windows JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- B. Screening calls. Employers book for an application to one of their jobs;
+-- admins may book any candidate.
+CREATE TABLE IF NOT EXISTS screening_calls (
+    id BIGSERIAL PRIMARY KEY,
+    candidate_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id BIGINT REFERENCES company_profiles(id) ON DELETE CASCADE, -- NULL when booked by an admin
+    application_id BIGINT REFERENCES applications(id) ON DELETE SET NULL,
+    booked_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    duration_minutes INT NOT NULL CHECK (duration_minutes BETWEEN 5 AND 120),
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'CONFIRMED'
+        CHECK (status IN ('CONFIRMED', 'CANCELLED', 'COMPLETED', 'NO_ANSWER')),
+    cancel_reason TEXT,
+    reminder_sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_screening_calls_candidate ON screening_calls(candidate_user_id, scheduled_at DESC);
+CREATE INDEX IF NOT EXISTS idx_screening_calls_company ON screening_calls(company_id, scheduled_at DESC)
+    WHERE company_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_screening_calls_reminder_due ON screening_calls(scheduled_at)
+    WHERE status = 'CONFIRMED' AND reminder_sent_at IS NULL;
+
+-- C. What happened on a candidate's account, newest first. data holds the
+-- type-specific details (e.g. call id and time).
+CREATE TABLE IF NOT EXISTS candidate_activities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    activity_type VARCHAR(40) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_activities_user ON candidate_activities(user_id, id DESC);
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000067_add_company_verification_levels (DOWN)
+-- Purpose: Rollback company verification levels
+-- ============================================================================
+
+DROP TABLE IF EXISTS company_verification_level_changes;
+DROP INDEX IF EXISTS idx_company_profiles_verification_level;
+ALTER TABLE company_profiles DROP COLUMN IF EXISTS verification_level_updated_at;
+ALTER TABLE company_profiles DROP COLUMN IF EXISTS verification_level;
@@ -0,0 +1,40 @@
+-- ============================================================================
+-- Migration: 000067_add_company_verification_levels
+-- Purpose: Company verification levels (BASIC / STANDARD / PREMIUM) replacing
+--          the approved-or-not employer verification as the gate for open job
+--          slots and ATS access, with a history of level changes
+-- ============================================================================
+
+-- A. The level lives on the profile so job and ATS checks need no extra query.
+-- BASIC = email verified, which every local employer account already is.
+ALTER TABLE company_profiles ADD COLUMN IF NOT EXISTS verification_level VARCHAR(10) NOT NULL DEFAULT 'BASIC'
+    CHECK (verification_level IN ('BASIC', 'STANDARD', 'PREMIUM'));
+ALTER TABLE company_profiles ADD COLUMN IF NOT EXISTS verification_level_updated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_company_profiles_verification_level ON company_profiles(verification_level);
+
+-- B. Every level change, automatic (changed_by NULL) or granted by an admin
+CREATE TABLE IF NOT EXISTS company_verification_level_changes (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    from_level VARCHAR(10) NOT NULL,
+    to_level VARCHAR(10) NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_level_changes_company ON company_verification_level_changes(company_id, created_at DESC);
+
+-- C. Employers whose verification was approved have had their documents reviewed
+WITH promoted AS (
+    UPDATE company_profiles cp
+    SET verification_level = 'STANDARD', verification_level_updated_at = NOW()
+    FROM account_verifications av
+    WHERE av.user_id = cp.user_id AND av.role = 'EMPLOYER' AND av.status = 'VERIFIED'
+      AND cp.verification_level = 'BASIC'
+    RETURNING cp.id
+)
+INSERT INTO company_verification_level_changes (company_id, from_level, to_level, reason)
+SELECT id, 'BASIC', 'STANDARD', 'Employer verification approved before levels were introduced'
+FROM promoted;
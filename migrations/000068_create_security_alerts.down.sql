@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000068_create_security_alerts (DOWN)
+-- Purpose: Rollback security alerts
+-- ============================================================================
+
+DROP TABLE IF EXISTS security_alerts;
@@ -0,0 +1,35 @@
+-- ============================================================================
+-- Migration: 000068_create_security_alerts
+-- Purpose: Alerts raised by anomaly detection over security_events, with the
+--          acknowledge/resolve workflow of the security dashboard
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS security_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    rule VARCHAR(50) NOT NULL,
+    -- Same rule + key = same incident (IP, hashed user, break-glass event ID)
+    dedup_key VARCHAR(255) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    title TEXT NOT NULL,
+    event_count INT NOT NULL DEFAULT 0,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL,
+    details JSONB,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN'
+        CHECK (status IN ('OPEN', 'ACKNOWLEDGED', 'RESOLVED')),
+    notified_admins INT NOT NULL DEFAULT 0,
+    acknowledged_by UUID REFERENCES security_users(id) ON DELETE SET NULL,
+    acknowledged_at TIMESTAMPTZ,
+    resolved_by UUID REFERENCES security_users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    resolution TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- At most one unresolved alert per incident; detection runs update it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_security_alerts_unresolved
+    ON security_alerts(rule, dedup_key) WHERE status <> 'RESOLVED';
+CREATE INDEX IF NOT EXISTS idx_security_alerts_status ON security_alerts(status, created_at DESC);
+
+ALTER TABLE security_alerts ENABLE ROW LEVEL SECURITY;
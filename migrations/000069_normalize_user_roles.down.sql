@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000069_normalize_user_roles (DOWN)
+-- Purpose: Drop the users.role CHECK; normalized values are left as they are
+-- ============================================================================
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
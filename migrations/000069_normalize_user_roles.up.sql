@@ -0,0 +1,29 @@
+-- ============================================================================
+-- Migration: 000069_normalize_user_roles
+-- Purpose: Store users.role only in its canonical lowercase form
+--          ('candidate', 'employer', 'admin') and enforce it with a CHECK.
+--          account_verifications.role keeps its uppercase form.
+-- ============================================================================
+
+UPDATE users
+SET role = LOWER(TRIM(role)), updated_at = NOW()
+WHERE role <> LOWER(TRIM(role));
+
+UPDATE users
+SET role = 'candidate', updated_at = NOW()
+WHERE role = '';
+
+-- NOT VALID so rows with unknown roles don't block the deploy; they are
+-- validated below once none are left
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK (role IN ('candidate', 'employer', 'admin')) NOT VALID;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM users WHERE role NOT IN ('candidate', 'employer', 'admin')) THEN
+        ALTER TABLE users VALIDATE CONSTRAINT users_role_check;
+    ELSE
+        RAISE WARNING 'users has rows with unknown roles; users_role_check left NOT VALID';
+    END IF;
+END $$;
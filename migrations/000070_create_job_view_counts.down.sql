@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000070_create_job_view_counts (DOWN)
+-- Purpose: Rollback job view counts
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_applications_job_created;
+DROP TABLE IF EXISTS job_view_counts;
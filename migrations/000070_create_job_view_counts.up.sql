@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Migration: 000070_create_job_view_counts
+-- Purpose: Daily public view counts per job, behind the popularity signals
+--          (views in the last 7 days, "popular" sort) on public job listings
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS job_view_counts (
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    view_date DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (job_id, view_date)
+);
+
+-- Popularity sort counts recent applications per job
+CREATE INDEX IF NOT EXISTS idx_applications_job_created ON applications(job_id, created_at);
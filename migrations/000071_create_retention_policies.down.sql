@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Migration: 000071_create_retention_policies (DOWN)
+-- Purpose: Rollback retention policies
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_applications_retention;
+ALTER TABLE account_verifications DROP COLUMN IF EXISTS retention_anonymized_at;
+ALTER TABLE applications DROP COLUMN IF EXISTS retention_anonymized_at;
+DROP TABLE IF EXISTS retention_runs;
+DROP TABLE IF EXISTS retention_policies;
@@ -0,0 +1,44 @@
+-- ============================================================================
+-- Migration: 000071_create_retention_policies
+-- Purpose: Per data class retention rules configured by admins, the runs of
+--          the nightly enforcement worker (dry runs and enforcement), and
+--          markers for records that were already anonymized
+-- ============================================================================
+
+-- A. One rule per data class; the set of classes is fixed in code
+CREATE TABLE IF NOT EXISTS retention_policies (
+    entity VARCHAR(50) PRIMARY KEY,
+    retention_days INTEGER NOT NULL CHECK (retention_days > 0),
+    action VARCHAR(20) NOT NULL CHECK (action IN ('ANONYMIZE', 'DELETE')),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO retention_policies (entity, retention_days, action) VALUES
+    ('applications', 730, 'ANONYMIZE'),
+    ('rejected_verifications', 365, 'ANONYMIZE')
+ON CONFLICT (entity) DO NOTHING;
+
+-- B. Runs with their per-class results
+CREATE TABLE IF NOT EXISTS retention_runs (
+    id BIGSERIAL PRIMARY KEY,
+    trigger_source VARCHAR(20) NOT NULL CHECK (trigger_source IN ('SCHEDULED', 'MANUAL')),
+    dry_run BOOLEAN NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('RUNNING', 'COMPLETED', 'FAILED')),
+    results JSONB NOT NULL DEFAULT '[]',
+    error_message TEXT,
+    started_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_retention_runs_started ON retention_runs(started_at DESC);
+
+-- C. Anonymized records are kept; the marker stops them from being counted again.
+-- A verification that is resubmitted and rejected again is anonymized again.
+ALTER TABLE applications ADD COLUMN IF NOT EXISTS retention_anonymized_at TIMESTAMPTZ;
+ALTER TABLE account_verifications ADD COLUMN IF NOT EXISTS retention_anonymized_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_applications_retention ON applications(updated_at)
+    WHERE retention_anonymized_at IS NULL;
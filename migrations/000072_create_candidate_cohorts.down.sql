@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000072_create_candidate_cohorts (DOWN)
+-- Purpose: Rollback candidate cohorts
+-- ============================================================================
+
+DROP TABLE IF EXISTS candidate_cohort_members;
+DROP TABLE IF EXISTS candidate_cohorts;
@@ -0,0 +1,31 @@
+-- ============================================================================
+-- Migration: 000072_create_candidate_cohorts
+-- Purpose: Training cohorts (batches) of an LPK's candidates, managed by admins
+--          and LPK partners, with one cohort per candidate
+-- ============================================================================
+
+-- A. Cohorts belong to one LPK
+CREATE TABLE IF NOT EXISTS candidate_cohorts (
+    id BIGSERIAL PRIMARY KEY,
+    lpk_id INTEGER NOT NULL REFERENCES lpk_list(id) ON DELETE CASCADE,
+    name VARCHAR(150) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    target_departure_date DATE,
+    created_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT candidate_cohorts_dates_check CHECK (end_date >= start_date)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_candidate_cohorts_lpk_name ON candidate_cohorts(lpk_id, LOWER(name));
+
+-- B. Members; assigning a candidate to another cohort moves them
+CREATE TABLE IF NOT EXISTS candidate_cohort_members (
+    account_verification_id BIGINT PRIMARY KEY REFERENCES account_verifications(id) ON DELETE CASCADE,
+    cohort_id BIGINT NOT NULL REFERENCES candidate_cohorts(id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    assigned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_cohort_members_cohort ON candidate_cohort_members(cohort_id);
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000073_create_pipeline_sla (DOWN)
+-- Purpose: Rollback pipeline SLA thresholds and breaches
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_applications_stage_changed;
+DROP TABLE IF EXISTS pipeline_sla_breaches;
+DROP TABLE IF EXISTS company_pipeline_sla;
@@ -0,0 +1,37 @@
+-- ============================================================================
+-- Migration: 000073_create_pipeline_sla
+-- Purpose: Per company response time targets for each pipeline stage, and the
+--          breaches the SLA worker records when an application waits too long
+-- ============================================================================
+
+-- A. Company thresholds; stages without a row use the platform default
+CREATE TABLE IF NOT EXISTS company_pipeline_sla (
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL CHECK (stage IN ('applied', 'screening', 'interview', 'offer')),
+    max_days INTEGER NOT NULL CHECK (max_days BETWEEN 0 AND 90), -- 0 turns the SLA off
+    updated_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (company_id, stage)
+);
+
+-- B. One breach per stage visit; resolved once the application leaves the stage
+CREATE TABLE IF NOT EXISTS pipeline_sla_breaches (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    job_id BIGINT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL,
+    threshold_days INTEGER NOT NULL,
+    stage_entered_at TIMESTAMPTZ NOT NULL,
+    breached_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    notified_at TIMESTAMPTZ,
+    resolved_at TIMESTAMPTZ,
+    CONSTRAINT uq_pipeline_sla_breach_visit UNIQUE (application_id, stage, stage_entered_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_sla_breaches_company ON pipeline_sla_breaches(company_id, breached_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pipeline_sla_breaches_open ON pipeline_sla_breaches(application_id) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_pipeline_sla_breaches_unnotified ON pipeline_sla_breaches(company_id) WHERE notified_at IS NULL;
+
+-- C. The worker scans applications by how long they have been in their stage
+CREATE INDEX IF NOT EXISTS idx_applications_stage_changed ON applications(stage, stage_changed_at);
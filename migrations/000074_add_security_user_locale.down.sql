@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000074_add_security_user_locale (DOWN)
+-- Purpose: Remove security operator language preference
+-- ============================================================================
+
+ALTER TABLE security_users DROP COLUMN IF EXISTS preferred_locale;
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000074_add_security_user_locale
+-- Purpose: Store each security operator's dashboard language (en, id)
+-- ============================================================================
+
+-- NULL means "follow Accept-Language"
+ALTER TABLE security_users ADD COLUMN IF NOT EXISTS preferred_locale TEXT
+    CHECK (preferred_locale IN ('en', 'id'));
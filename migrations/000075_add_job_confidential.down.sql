@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000075_add_job_confidential (DOWN)
+-- Purpose: Remove confidential job postings
+-- ============================================================================
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS is_confidential;
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000075_add_job_confidential
+-- Purpose: Confidential job postings that hide the company on public pages
+-- ============================================================================
+
+-- A confidential job shows only the company's industry and size band publicly;
+-- candidates see the company once the employer advances their application
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS is_confidential BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Migration: 000076_add_candidate_profile_visibility (DOWN)
+-- Purpose: Restore the talent pool opt-in flag
+-- ============================================================================
+
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS talent_pool_visible BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE account_verifications SET talent_pool_visible = TRUE WHERE profile_visibility = 'ALL_VERIFIED_EMPLOYERS';
+
+CREATE INDEX IF NOT EXISTS idx_av_talent_pool_visible ON account_verifications(user_id) WHERE talent_pool_visible;
+
+DROP INDEX IF EXISTS idx_av_profile_visibility;
+
+ALTER TABLE account_verifications DROP COLUMN IF EXISTS profile_visibility;
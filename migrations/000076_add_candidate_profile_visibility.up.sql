@@ -0,0 +1,23 @@
+-- ============================================================================
+-- Migration: 000076_add_candidate_profile_visibility
+-- Purpose: Candidate privacy setting for employer-facing candidate search,
+--          replacing the talent pool opt-in flag
+-- ============================================================================
+
+-- ALL_VERIFIED_EMPLOYERS: every STANDARD or PREMIUM company, plus companies applied to
+-- APPLIED_COMPANIES: only companies the candidate applied to (the old default)
+-- HIDDEN: no employer search; applications still reach the company's pipeline
+ALTER TABLE account_verifications
+ADD COLUMN IF NOT EXISTS profile_visibility TEXT NOT NULL DEFAULT 'APPLIED_COMPANIES'
+    CHECK (profile_visibility IN ('ALL_VERIFIED_EMPLOYERS', 'APPLIED_COMPANIES', 'HIDDEN'));
+
+UPDATE account_verifications SET profile_visibility = 'ALL_VERIFIED_EMPLOYERS' WHERE talent_pool_visible;
+
+CREATE INDEX IF NOT EXISTS idx_av_profile_visibility ON account_verifications(user_id)
+    WHERE profile_visibility = 'ALL_VERIFIED_EMPLOYERS';
+
+DROP INDEX IF EXISTS idx_av_talent_pool_visible;
+
+ALTER TABLE account_verifications DROP COLUMN IF EXISTS talent_pool_visible;
+
+COMMENT ON COLUMN account_verifications.profile_visibility IS 'Which employers can find the candidate in ATS search and unlock their contact';
@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000077_create_ats_export_audits (DOWN)
+-- Purpose: Rollback ATS export audit
+-- ============================================================================
+
+DROP TABLE IF EXISTS ats_export_audit_rows;
+DROP TABLE IF EXISTS ats_export_audits;
@@ -0,0 +1,32 @@
+-- ============================================================================
+-- Migration: 000077_create_ats_export_audits
+-- Purpose: Append-only audit of admin ATS exports with a per-candidate row
+--          fingerprint, answering "who exported my data" requests
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS ats_export_audits (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    format TEXT NOT NULL,                 -- xlsx, csv or pdf
+    filter JSONB NOT NULL DEFAULT '{}',   -- ATS filter the export was run with
+    columns TEXT[] NOT NULL DEFAULT '{}',
+    row_count INT NOT NULL,
+    manifest_hash TEXT NOT NULL,          -- SHA-256 over the sorted candidate_user_id:fingerprint lines
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ats_export_audits_created ON ats_export_audits(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ats_export_audits_actor ON ats_export_audits(actor_user_id, created_at DESC);
+
+-- One row per exported candidate. No foreign key on the candidate: the trail
+-- outlives the account.
+CREATE TABLE IF NOT EXISTS ats_export_audit_rows (
+    audit_id BIGINT NOT NULL REFERENCES ats_export_audits(id) ON DELETE CASCADE,
+    candidate_user_id UUID NOT NULL,
+    fingerprint TEXT NOT NULL,            -- SHA-256 of the candidate's exported values
+    PRIMARY KEY (audit_id, candidate_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ats_export_audit_rows_candidate ON ats_export_audit_rows(candidate_user_id);
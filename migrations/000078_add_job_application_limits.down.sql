@@ -0,0 +1,14 @@
+-- ============================================================================
+-- Migration: 000078_add_job_application_limits (DOWN)
+-- Purpose: Rollback job application limits
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_jobs_apply_deadline_due;
+
+-- Closed jobs stay off public listings
+UPDATE jobs SET company_status = 'expired' WHERE company_status = 'closed';
+
+ALTER TABLE jobs
+DROP COLUMN IF EXISTS auto_close,
+DROP COLUMN IF EXISTS max_applicants,
+DROP COLUMN IF EXISTS apply_deadline;
@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Migration: 000078_add_job_application_limits
+-- Purpose: Application deadline and applicant cap on jobs, with automatic closing
+-- ============================================================================
+
+-- Applications stop at apply_deadline or once max_applicants (not counting
+-- knocked-out applications) is reached. With auto_close the job also moves to
+-- company_status 'closed' and leaves public listings until the employer reopens it.
+ALTER TABLE jobs
+ADD COLUMN IF NOT EXISTS apply_deadline TIMESTAMPTZ,
+ADD COLUMN IF NOT EXISTS max_applicants INT CHECK (max_applicants > 0),
+ADD COLUMN IF NOT EXISTS auto_close BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_apply_deadline_due ON jobs(apply_deadline)
+    WHERE company_status IN ('active', 'scheduled') AND auto_close AND apply_deadline IS NOT NULL;
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000079_create_duplicate_document_reviews (DOWN)
+-- Purpose: Rollback document image hashes and duplicate document reviews
+-- ============================================================================
+
+DROP TABLE IF EXISTS duplicate_document_matches;
+DROP TABLE IF EXISTS duplicate_document_reviews;
+DROP TABLE IF EXISTS document_image_hashes;
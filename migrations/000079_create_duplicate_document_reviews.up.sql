@@ -0,0 +1,42 @@
+-- ============================================================================
+-- Migration: 000079_create_duplicate_document_reviews
+-- Purpose: Perceptual hashes of uploaded document images and review tasks for
+--          images reused across accounts
+-- ============================================================================
+
+-- One row per uploaded certificate/vault image. phash is the 64-bit difference
+-- hash stored as BIGINT; near-duplicates are found by Hamming distance.
+CREATE TABLE IF NOT EXISTS document_image_hashes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    file_id UUID REFERENCES uploaded_files(id) ON DELETE SET NULL,
+    bucket TEXT NOT NULL,
+    file_url TEXT NOT NULL,
+    phash BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_image_hashes_user ON document_image_hashes(user_id);
+
+-- A review task per upload that matched other accounts' images
+CREATE TABLE IF NOT EXISTS duplicate_document_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    hash_id BIGINT NOT NULL UNIQUE REFERENCES document_image_hashes(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'CONFIRMED', 'DISMISSED')),
+    review_notes TEXT,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_duplicate_document_reviews_status ON duplicate_document_reviews(status, created_at);
+
+-- The earlier images a flagged upload matched
+CREATE TABLE IF NOT EXISTS duplicate_document_matches (
+    review_id BIGINT NOT NULL REFERENCES duplicate_document_reviews(id) ON DELETE CASCADE,
+    hash_id BIGINT NOT NULL REFERENCES document_image_hashes(id) ON DELETE CASCADE,
+    distance SMALLINT NOT NULL CHECK (distance BETWEEN 0 AND 64),
+    PRIMARY KEY (review_id, hash_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_duplicate_document_matches_hash ON duplicate_document_matches(hash_id);
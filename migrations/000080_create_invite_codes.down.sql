@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000080_create_invite_codes (DOWN)
+-- Purpose: Rollback invite codes
+-- ============================================================================
+
+DROP TABLE IF EXISTS invite_code_redemptions;
+DROP TABLE IF EXISTS invite_codes;
@@ -0,0 +1,35 @@
+-- ============================================================================
+-- Migration: 000080_create_invite_codes
+-- Purpose: Invite codes for soft launches where registration is invite-only
+-- ============================================================================
+
+-- A code is usable while it is not revoked, not expired and use_count < max_uses.
+-- role limits the code to one registration role; NULL accepts either.
+CREATE TABLE IF NOT EXISTS invite_codes (
+    id BIGSERIAL PRIMARY KEY,
+    code TEXT NOT NULL UNIQUE,
+    role TEXT CHECK (role IN ('candidate', 'employer')),
+    max_uses INT NOT NULL DEFAULT 1 CHECK (max_uses > 0),
+    use_count INT NOT NULL DEFAULT 0 CHECK (use_count >= 0 AND use_count <= max_uses),
+    expires_at TIMESTAMPTZ,
+    note TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invite_codes_created ON invite_codes(created_at DESC);
+
+-- One row per registration made with a code. user_id is filled in once the
+-- signup is accepted (the local user is created later, on first login).
+CREATE TABLE IF NOT EXISTS invite_code_redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    code_id BIGINT NOT NULL REFERENCES invite_codes(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL,
+    user_id UUID,
+    ip_address TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_invite_code_redemptions_code ON invite_code_redemptions(code_id, created_at DESC);
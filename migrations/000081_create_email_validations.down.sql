@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000081_create_email_validations (DOWN)
+-- Purpose: Rollback email validations
+-- ============================================================================
+
+DROP TABLE IF EXISTS email_validations;
@@ -0,0 +1,24 @@
+-- ============================================================================
+-- Migration: 000081_create_email_validations
+-- Purpose: Result of the last deliverability check of each user's email
+-- ============================================================================
+
+-- email is the address that was checked; when the user's email changes the row
+-- no longer matches and the address is checked again on the next run.
+-- reason is NULL for a deliverable address, otherwise why it is undeliverable:
+-- 'syntax' (not a valid address), 'no_mx' (the domain accepts no mail) or
+-- 'disposable' (a throwaway mailbox provider).
+CREATE TABLE IF NOT EXISTS email_validations (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    reason TEXT CHECK (reason IN ('syntax', 'no_mx', 'disposable')),
+    detail TEXT,
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_validations_checked ON email_validations(checked_at);
+
+-- Notification dispatch looks up undeliverable addresses by email
+CREATE INDEX IF NOT EXISTS idx_email_validations_undeliverable
+    ON email_validations(LOWER(email))
+    WHERE reason IS NOT NULL;
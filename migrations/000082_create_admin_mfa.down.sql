@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000082_create_admin_mfa (DOWN)
+-- Purpose: Rollback admin two-factor authentication
+-- ============================================================================
+
+DROP TABLE IF EXISTS mfa_sessions;
+DROP TABLE IF EXISTS user_mfa;
@@ -0,0 +1,27 @@
+-- ============================================================================
+-- Migration: 000082_create_admin_mfa
+-- Purpose: TOTP two-factor authentication for admin platform accounts
+-- ============================================================================
+
+-- totp_secret is Base32, like security_users.totp_secret. enabled_at is NULL
+-- while the enrollment waits for its first code.
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    totp_secret TEXT NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    failed_attempts INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Sessions that passed TOTP. session_id is the Supabase session_id claim, which
+-- survives token refreshes, so one verification lasts until expires_at.
+CREATE TABLE IF NOT EXISTS mfa_sessions (
+    session_id TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    verified_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfa_sessions_user ON mfa_sessions(user_id);
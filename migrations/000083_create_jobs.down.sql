@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000083_create_jobs (DOWN)
+-- Purpose: Rollback the background job queue
+-- ============================================================================
+
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,26 @@
+-- ============================================================================
+-- Migration: 000083_create_jobs
+-- Purpose: Background job queue (pkg/jobs) shared by all API instances
+-- ============================================================================
+
+-- Workers claim due queued jobs with FOR UPDATE SKIP LOCKED. A failed job goes
+-- back to queued with a later run_at until attempts reaches max_attempts, then
+-- it is dead and waits for an admin. locked_at lets a job whose worker died be
+-- claimed again.
+CREATE TABLE IF NOT EXISTS jobs (
+    id BIGSERIAL PRIMARY KEY,
+    type TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'done', 'dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 5 CHECK (max_attempts > 0),
+    run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_at TIMESTAMPTZ,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_due ON jobs(run_at, id) WHERE status IN ('queued', 'running');
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status, updated_at DESC);
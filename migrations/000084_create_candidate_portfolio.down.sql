@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000084_create_candidate_portfolio (DOWN)
+-- Purpose: Rollback the candidate work-sample portfolio
+-- ============================================================================
+
+DROP TABLE IF EXISTS candidate_portfolio_items;
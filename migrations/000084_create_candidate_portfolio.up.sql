@@ -0,0 +1,29 @@
+-- ============================================================================
+-- Migration: 000084_create_candidate_portfolio
+-- Purpose: Candidate work-sample portfolio (images, PDFs and links) shown to
+--          employers the candidate is visible to and in the resume PDF
+-- ============================================================================
+
+-- Files are uploaded to the Candidate_Portfolio bucket first; size_bytes is the
+-- uploaded size and counts against the candidate's storage quota (0 for links).
+CREATE TABLE IF NOT EXISTS candidate_portfolio_items (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_type VARCHAR(10) NOT NULL CHECK (item_type IN ('IMAGE', 'PDF', 'LINK')),
+    title TEXT NOT NULL,
+    description TEXT,
+    file_url TEXT,
+    link_url TEXT,
+    size_bytes BIGINT NOT NULL DEFAULT 0 CHECK (size_bytes >= 0),
+    position INT NOT NULL DEFAULT 0,
+    include_in_resume BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((item_type = 'LINK') = (link_url IS NOT NULL)),
+    CHECK ((item_type = 'LINK') = (file_url IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_portfolio_items_user ON candidate_portfolio_items(user_id, position, id);
+
+COMMENT ON COLUMN candidate_portfolio_items.position IS 'Display order chosen by the candidate, ascending';
+COMMENT ON COLUMN candidate_portfolio_items.include_in_resume IS 'Listed in the Portfolio section of the employer resume PDF';
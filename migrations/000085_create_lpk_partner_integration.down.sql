@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Migration: 000085_create_lpk_partner_integration (DOWN)
+-- Purpose: Rollback the LPK partner placement feed, API keys and partner webhooks
+-- ============================================================================
+
+DELETE FROM webhook_endpoints WHERE lpk_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_webhook_endpoints_lpk;
+ALTER TABLE webhook_endpoints DROP COLUMN IF EXISTS lpk_id;
+
+DROP TABLE IF EXISTS lpk_api_keys;
+DROP TABLE IF EXISTS lpk_placement_events;
+
+ALTER TABLE account_verifications
+    DROP COLUMN IF EXISTS placement_sharing_at,
+    DROP COLUMN IF EXISTS placement_sharing_lpk_id;
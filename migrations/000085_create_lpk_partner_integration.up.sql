@@ -0,0 +1,46 @@
+-- ============================================================================
+-- Migration: 000085_create_lpk_partner_integration
+-- Purpose: Placement status feed for LPK partner systems: candidate consent,
+--          the event log, partner API keys and LPK-scoped webhook endpoints
+-- ============================================================================
+
+-- Consent covers one LPK: it lapses when the candidate picks another LPK, since
+-- sharing only applies while placement_sharing_lpk_id = lpk_id
+ALTER TABLE account_verifications
+    ADD COLUMN IF NOT EXISTS placement_sharing_lpk_id INTEGER REFERENCES lpk_list(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS placement_sharing_at TIMESTAMPTZ;
+
+-- Application status changes of consenting candidates, recorded for the LPK the
+-- candidate had at the time. The id is the cursor of the pull API.
+CREATE TABLE IF NOT EXISTS lpk_placement_events (
+    id BIGSERIAL PRIMARY KEY,
+    lpk_id INTEGER NOT NULL REFERENCES lpk_list(id) ON DELETE CASCADE,
+    account_verification_id BIGINT NOT NULL REFERENCES account_verifications(id) ON DELETE CASCADE,
+    application_id BIGINT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lpk_placement_events_lpk ON lpk_placement_events(lpk_id, id);
+
+-- Only the SHA-256 of a key is stored; key_prefix tells keys apart in listings
+CREATE TABLE IF NOT EXISTS lpk_api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    lpk_id INTEGER NOT NULL REFERENCES lpk_list(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    key_prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    created_by UUID REFERENCES users(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_lpk_api_keys_lpk ON lpk_api_keys(lpk_id, created_at DESC);
+
+-- Endpoints registered by an LPK partner only receive that LPK's placement
+-- events; platform endpoints (lpk_id NULL) never do
+ALTER TABLE webhook_endpoints
+    ADD COLUMN IF NOT EXISTS lpk_id INTEGER REFERENCES lpk_list(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_lpk ON webhook_endpoints(lpk_id) WHERE lpk_id IS NOT NULL;
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Migration: 000086_add_candidate_profile_refresh (DOWN)
+-- Purpose: Rollback the candidate profile refresh flag
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_av_profile_refresh_required;
+
+ALTER TABLE account_verifications
+    DROP COLUMN IF EXISTS profile_refreshed_at,
+    DROP COLUMN IF EXISTS profile_refresh_required_at;
@@ -0,0 +1,16 @@
+-- ============================================================================
+-- Migration: 000086_add_candidate_profile_refresh
+-- Purpose: Profile refresh for candidates returning after a long absence:
+--          the flag that keeps them out of employer search until they confirm
+--          availability, salary and documents
+-- ============================================================================
+
+-- Set on the first request of a candidate idle for 12+ months (users.last_active_at
+-- is refreshed right after, so the flag keeps what the timestamp loses)
+ALTER TABLE account_verifications
+    ADD COLUMN IF NOT EXISTS profile_refresh_required_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS profile_refreshed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_av_profile_refresh_required
+    ON account_verifications(user_id)
+    WHERE profile_refresh_required_at IS NOT NULL;
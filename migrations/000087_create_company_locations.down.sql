@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Migration: 000087_create_company_locations (DOWN)
+-- Purpose: Rollback company office locations and job locations
+-- ============================================================================
+
+DROP INDEX IF EXISTS idx_jobs_location;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS company_locations;
@@ -0,0 +1,34 @@
+-- ============================================================================
+-- Migration: 000087_create_company_locations
+-- Purpose: Company office locations (address, geocode, site contact) and the
+--          office a job is based at
+-- ============================================================================
+
+-- Coordinates are entered by the employer (WGS84); both or neither are set
+CREATE TABLE IF NOT EXISTS company_locations (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    address TEXT NOT NULL,
+    city TEXT NOT NULL,
+    province TEXT,
+    postal_code TEXT,
+    country TEXT NOT NULL DEFAULT 'Indonesia',
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    contact_name TEXT,
+    contact_phone TEXT,
+    contact_email TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_locations_company ON company_locations(company_id, id);
+CREATE INDEX IF NOT EXISTS idx_company_locations_city ON company_locations(LOWER(city));
+
+-- Deleting an office keeps its jobs, which fall back to their free-text location
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS location_id BIGINT REFERENCES company_locations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_location ON jobs(location_id) WHERE location_id IS NOT NULL;
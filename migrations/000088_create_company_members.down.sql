@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000088_create_company_members (DOWN)
+-- Purpose: Rollback company members and invitations
+-- ============================================================================
+
+DROP TABLE IF EXISTS company_invitations;
+DROP TABLE IF EXISTS company_members;
@@ -0,0 +1,41 @@
+-- ============================================================================
+-- Migration: 000088_create_company_members
+-- Purpose: Multiple recruiter accounts per company: members with a company
+--          role and email invitations to join
+-- ============================================================================
+
+-- The company owner is company_profiles.user_id and has no row here. A user
+-- belongs to at most one company.
+CREATE TABLE IF NOT EXISTS company_members (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('RECRUITER', 'VIEWER')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_members_company ON company_members(company_id);
+
+-- Only the token hash is stored; the token itself is emailed once
+CREATE TABLE IF NOT EXISTS company_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL REFERENCES company_profiles(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('RECRUITER', 'VIEWER')),
+    token_hash TEXT NOT NULL UNIQUE,
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_invitations_company ON company_invitations(company_id);
+
+-- One open invitation per address and company; re-inviting replaces it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_invitations_pending
+    ON company_invitations(company_id, LOWER(email))
+    WHERE accepted_at IS NULL AND revoked_at IS NULL;
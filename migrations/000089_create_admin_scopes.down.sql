@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Migration: 000089_create_admin_scopes (DOWN)
+-- Purpose: Rollback regional admin scopes and province columns
+-- ============================================================================
+
+DROP TABLE IF EXISTS admin_scopes;
+
+DROP INDEX IF EXISTS idx_company_profiles_province;
+DROP INDEX IF EXISTS idx_account_verifications_domicile_province;
+
+ALTER TABLE company_profiles
+    DROP COLUMN IF EXISTS province;
+
+ALTER TABLE account_verifications
+    DROP COLUMN IF EXISTS domicile_province;
@@ -0,0 +1,28 @@
+-- ============================================================================
+-- Migration: 000089_create_admin_scopes
+-- Purpose: Regional admins: the provinces an admin may see, and the province
+--          of candidates (domicile) and companies the scope applies to
+-- ============================================================================
+
+ALTER TABLE account_verifications
+    ADD COLUMN IF NOT EXISTS domicile_province TEXT;
+
+ALTER TABLE company_profiles
+    ADD COLUMN IF NOT EXISTS province TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_account_verifications_domicile_province
+    ON account_verifications(LOWER(domicile_province))
+    WHERE domicile_province IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_company_profiles_province
+    ON company_profiles(LOWER(province))
+    WHERE province IS NOT NULL;
+
+-- An admin with a row here is regional; admins without one are unrestricted
+CREATE TABLE IF NOT EXISTS admin_scopes (
+    admin_user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    provinces TEXT[] NOT NULL CHECK (cardinality(provinces) > 0),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
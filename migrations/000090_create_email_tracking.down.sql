@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Migration: 000090_create_email_tracking (DOWN)
+-- Purpose: Rollback email open/click tracking and tracking preferences
+-- ============================================================================
+
+DROP TABLE IF EXISTS email_tracking_preferences;
+DROP TABLE IF EXISTS email_message_links;
+DROP TABLE IF EXISTS email_messages;
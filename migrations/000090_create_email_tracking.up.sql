@@ -0,0 +1,39 @@
+-- ============================================================================
+-- Migration: 000090_create_email_tracking
+-- Purpose: Open and click tracking of transactional emails, and users' tracking
+--          preferences. Messages do not record their recipient.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS email_messages (
+    id BIGSERIAL PRIMARY KEY,
+    template TEXT NOT NULL,
+    category TEXT NOT NULL,
+    open_token TEXT NOT NULL UNIQUE,
+    open_count INT NOT NULL DEFAULT 0,
+    first_opened_at TIMESTAMPTZ,
+    click_count INT NOT NULL DEFAULT 0,
+    first_clicked_at TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_messages_sent_at
+    ON email_messages(sent_at);
+
+-- One row per distinct link of a message; the redirect only ever goes to url
+CREATE TABLE IF NOT EXISTS email_message_links (
+    token TEXT PRIMARY KEY,
+    message_id BIGINT NOT NULL REFERENCES email_messages(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    click_count INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_message_links_message
+    ON email_message_links(message_id);
+
+-- Users without a row are tracked in every category the operator allows
+CREATE TABLE IF NOT EXISTS email_tracking_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    disabled_categories TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
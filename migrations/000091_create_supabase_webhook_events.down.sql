@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000091_create_supabase_webhook_events (DOWN)
+-- Purpose: Rollback Supabase auth webhook events and email confirmation time
+-- ============================================================================
+
+DROP TABLE IF EXISTS supabase_webhook_events;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_confirmed_at;
@@ -0,0 +1,19 @@
+-- ============================================================================
+-- Migration: 000091_create_supabase_webhook_events
+-- Purpose: Supabase auth webhooks: when a user confirmed their email, and the
+--          webhook ids already processed (Supabase retries deliveries)
+-- ============================================================================
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_confirmed_at TIMESTAMPTZ;
+
+-- user_id has no foreign key: user.deleted events outlive their user
+CREATE TABLE IF NOT EXISTS supabase_webhook_events (
+    webhook_id TEXT PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    user_id UUID,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_supabase_webhook_events_received_at
+    ON supabase_webhook_events(received_at);
@@ -0,0 +1,7 @@
+-- ============================================================================
+-- Migration: 000092_create_placements (DOWN)
+-- Purpose: Rollback placement records and post-placement check-ins
+-- ============================================================================
+
+DROP TABLE IF EXISTS placement_checkins;
+DROP TABLE IF EXISTS placements;
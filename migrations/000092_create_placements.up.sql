@@ -0,0 +1,47 @@
+-- ============================================================================
+-- Migration: 000092_create_placements
+-- Purpose: Placement records for hired candidates and their post-placement
+--          check-ins (3, 6 and 12 months after the start date)
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS placements (
+    id BIGSERIAL PRIMARY KEY,
+    application_id BIGINT NOT NULL UNIQUE REFERENCES applications(id) ON DELETE CASCADE,
+    candidate_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    company_id BIGINT NOT NULL,
+    job_id BIGINT NOT NULL,
+    start_date DATE NOT NULL,
+    contract_months INT NOT NULL CHECK (contract_months BETWEEN 1 AND 120),
+    contract_end_date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'ACTIVE' CHECK (status IN ('ACTIVE', 'COMPLETED', 'ENDED_EARLY')),
+    ended_on DATE,
+    end_reason TEXT,
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_placements_candidate
+    ON placements(candidate_user_id);
+
+CREATE INDEX IF NOT EXISTS idx_placements_company
+    ON placements(company_id);
+
+CREATE TABLE IF NOT EXISTS placement_checkins (
+    id BIGSERIAL PRIMARY KEY,
+    placement_id BIGINT NOT NULL REFERENCES placements(id) ON DELETE CASCADE,
+    milestone_months INT NOT NULL,
+    due_date DATE NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'COMPLETED', 'SKIPPED')),
+    outcome TEXT CHECK (outcome IN ('ON_TRACK', 'AT_RISK', 'UNREACHABLE')),
+    notes TEXT,
+    completed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT uq_placement_checkin_milestone UNIQUE (placement_id, milestone_months)
+);
+
+-- Admin task list: pending check-ins by due date
+CREATE INDEX IF NOT EXISTS idx_placement_checkins_pending_due
+    ON placement_checkins(due_date)
+    WHERE status = 'PENDING';
@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000093_create_notifications (DOWN)
+-- Purpose: Rollback the in-app notification center
+-- ============================================================================
+
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Migration: 000093_create_notifications
+-- Purpose: In-app notification center: every dispatched notification, verification
+--          results and admin announcements, with their read state
+-- ============================================================================
+
+-- Subject and body are localized when the notification is recorded, like digest items
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC, id DESC);
+
+-- Header badge: unread count per user
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
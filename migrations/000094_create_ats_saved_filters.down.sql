@@ -0,0 +1,6 @@
+-- ============================================================================
+-- Migration: 000094_create_ats_saved_filters (DOWN)
+-- Purpose: Rollback saved ATS filters
+-- ============================================================================
+
+DROP TABLE IF EXISTS ats_saved_filters;
@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Migration: 000094_create_ats_saved_filters
+-- Purpose: Named ATS filter sets admins save, share with other admins and
+--          re-run in the ATS search and export
+-- ============================================================================
+
+-- filter holds domain.ATSFilter as JSON, without pagination
+CREATE TABLE IF NOT EXISTS ats_saved_filters (
+    id BIGSERIAL PRIMARY KEY,
+    owner_user_id UUID NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    filter JSONB NOT NULL DEFAULT '{}',
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    use_count INTEGER NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_ats_saved_filter_owner_name UNIQUE (owner_user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ats_saved_filters_shared ON ats_saved_filters(shared) WHERE shared;
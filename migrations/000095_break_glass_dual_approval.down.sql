@@ -0,0 +1,17 @@
+-- ============================================================================
+-- Migration: 000095_break_glass_dual_approval (DOWN)
+-- Purpose: Rollback break-glass dual approval
+-- ============================================================================
+
+-- Requests that were never approved must not become active sessions
+DELETE FROM break_glass_sessions WHERE status <> 'APPROVED';
+
+DROP INDEX IF EXISTS idx_break_glass_pending;
+
+ALTER TABLE break_glass_sessions
+    DROP CONSTRAINT IF EXISTS break_glass_second_approver,
+    DROP COLUMN IF EXISTS denial_reason,
+    DROP COLUMN IF EXISTS decided_at,
+    DROP COLUMN IF EXISTS decided_by,
+    DROP COLUMN IF EXISTS duration_minutes,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,25 @@
+-- ============================================================================
+-- Migration: 000095_break_glass_dual_approval
+-- Purpose: Two-person rule for break-glass: a request stays PENDING until a
+--          second SECURITY_ADMIN approves or denies it
+-- ============================================================================
+
+-- Existing sessions were activated by one admin and count as APPROVED.
+-- While a request is PENDING, activated_at is the request time and expires_at
+-- the approval deadline; approval restarts both for the granted duration.
+ALTER TABLE break_glass_sessions
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'APPROVED'
+        CHECK (status IN ('PENDING', 'APPROVED', 'DENIED')),
+    ADD COLUMN IF NOT EXISTS duration_minutes INT CHECK (duration_minutes BETWEEN 1 AND 60),
+    ADD COLUMN IF NOT EXISTS decided_by UUID REFERENCES security_users(id),
+    ADD COLUMN IF NOT EXISTS decided_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS denial_reason TEXT;
+
+-- The co-approver can never be the requester
+ALTER TABLE break_glass_sessions
+    ADD CONSTRAINT break_glass_second_approver CHECK (decided_by IS NULL OR decided_by <> security_user_id);
+
+CREATE INDEX IF NOT EXISTS idx_break_glass_pending ON break_glass_sessions(expires_at)
+    WHERE status = 'PENDING' AND revoked_at IS NULL;
+
+COMMENT ON COLUMN break_glass_sessions.status IS 'PENDING until a second SECURITY_ADMIN decides; APPROVED sessions are active until expires_at';
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Migration: 000096_allow_lpk_user_role (DOWN)
+-- Purpose: Rollback to the users_role_check of 000069; existing partner rows
+--          are not checked
+-- ============================================================================
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK (role IN ('candidate', 'employer', 'admin')) NOT VALID;
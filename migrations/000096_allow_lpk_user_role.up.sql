@@ -0,0 +1,18 @@
+-- ============================================================================
+-- Migration: 000096_allow_lpk_user_role
+-- Purpose: Allow the 'lpk' role of LPK partner accounts in users_role_check;
+--          000069 left it out, so partners could not be assigned.
+-- ============================================================================
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users
+    ADD CONSTRAINT users_role_check CHECK (role IN ('candidate', 'employer', 'admin', 'lpk')) NOT VALID;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM users WHERE role NOT IN ('candidate', 'employer', 'admin', 'lpk')) THEN
+        ALTER TABLE users VALIDATE CONSTRAINT users_role_check;
+    ELSE
+        RAISE WARNING 'users has rows with unknown roles; users_role_check left NOT VALID';
+    END IF;
+END $$;
@@ -0,0 +1,9 @@
+// Package migrations embeds the versioned schema migrations so the API binary
+// can apply them at startup. Only the up files are embedded; down files and
+// seeds are run by hand.
+package migrations
+
+import "embed"
+
+//go:embed *.up.sql
+var FS embed.FS
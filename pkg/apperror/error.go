@@ -2,19 +2,74 @@ package apperror
 
 import "net/http"
 
+// Generic codes, used when an error has no more specific one. Specific codes
+// are enumerated in the domain package (domain.ErrorCodes).
+const (
+	CodeBadRequest         = "BAD_REQUEST"
+	CodeValidationFailed   = "VALIDATION_FAILED"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodePaymentRequired    = "PAYMENT_REQUIRED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodeTooManyRequests    = "TOO_MANY_REQUESTS"
+	CodeInternal           = "INTERNAL_ERROR"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeError              = "ERROR" // any other status
+)
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          CodeBadRequest,
+	http.StatusUnauthorized:        CodeUnauthorized,
+	http.StatusPaymentRequired:     CodePaymentRequired,
+	http.StatusForbidden:           CodeForbidden,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusConflict:            CodeConflict,
+	http.StatusTooManyRequests:     CodeTooManyRequests,
+	http.StatusInternalServerError: CodeInternal,
+	http.StatusServiceUnavailable:  CodeServiceUnavailable,
+}
+
+// CodeForStatus returns the generic code of an HTTP status
+func CodeForStatus(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	return CodeError
+}
+
+// AppError is an error with the HTTP status, stable code and message sent to
+// the client. Err is only logged.
 type AppError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Err     error  `json:"-"`
+	Status  int         `json:"status"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+	Err     error       `json:"-"`
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
-func New(code int, message string, err error) *AppError {
+// WithCode returns a copy of e with a specific code
+func (e *AppError) WithCode(code string) *AppError {
+	c := *e
+	c.Code = code
+	return &c
+}
+
+// WithDetails returns a copy of e with structured details for the client
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	c := *e
+	c.Details = details
+	return &c
+}
+
+func New(status int, message string, err error) *AppError {
 	return &AppError{
-		Code:    code,
+		Status:  status,
+		Code:    CodeForStatus(status),
 		Message: message,
 		Err:     err,
 	}
@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	// ErrInvalidRefreshToken covers unknown, expired, revoked and provider-rejected tokens
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused means an already rotated token was presented again;
+	// its whole family has been revoked
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+	// ErrRefreshTokenRotated means the token was rotated moments ago by a
+	// concurrent request; the client should retry with the newer token
+	ErrRefreshTokenRotated = errors.New("refresh token already rotated")
+	// ErrRefreshUnavailable means Supabase could not be reached; the token stays valid
+	ErrRefreshUnavailable = errors.New("token service unavailable")
+)
+
+// Revocation reasons stored with revoked tokens
+const (
+	RevokeReasonLogout   = "logout"
+	RevokeReasonReuse    = "reuse_detected"
+	RevokeReasonRejected = "provider_rejected"
+)
+
+// RefreshConfig configures refresh token handling
+type RefreshConfig struct {
+	SupabaseURL string
+	SupabaseKey string
+	// TTL is how long a refresh token is accepted after it was issued
+	TTL time.Duration
+	// ReuseGrace tolerates a rotated token presented again this soon, so two tabs
+	// refreshing at once do not revoke the session
+	ReuseGrace time.Duration
+}
+
+// TokenPair is what a login or refresh hands to the client
+type TokenPair struct {
+	UserID       string `json:"-"`
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // access token lifetime in seconds
+}
+
+// RefreshService stores, rotates and revokes Supabase refresh tokens. Only a
+// hash of each token is kept; every rotation extends the token's family, and
+// presenting a rotated token again revokes the family (reuse detection).
+type RefreshService struct {
+	db     *pgxpool.Pool
+	cfg    RefreshConfig
+	client *http.Client
+}
+
+func NewRefreshService(db *pgxpool.Pool, cfg RefreshConfig) *RefreshService {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * 24 * time.Hour
+	}
+	if cfg.ReuseGrace <= 0 {
+		cfg.ReuseGrace = 10 * time.Second
+	}
+	cfg.SupabaseURL = strings.TrimSuffix(cfg.SupabaseURL, "/")
+	return &RefreshService{db: db, cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Track records a refresh token issued at login, starting a new family
+func (s *RefreshService) Track(ctx context.Context, userID, refreshToken, ip, userAgent string) error {
+	if refreshToken == "" {
+		return ErrInvalidRefreshToken
+	}
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO auth_refresh_tokens (user_id, token_hash, ip_address, user_agent, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		userID, hashToken(refreshToken), ip, userAgent, time.Now().Add(s.cfg.TTL),
+	)
+	return err
+}
+
+// Rotate exchanges a refresh token for a new access and refresh token
+func (s *RefreshService) Rotate(ctx context.Context, refreshToken, ip, userAgent string) (*TokenPair, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+	hash := hashToken(refreshToken)
+
+	// 1. Claim the token; only one concurrent rotation can win
+	var (
+		id       int64
+		userID   string
+		familyID string
+	)
+	err := s.db.QueryRow(ctx, `
+		UPDATE auth_refresh_tokens SET status = 'ROTATED', rotated_at = NOW()
+		WHERE token_hash = $1 AND status = 'ACTIVE' AND expires_at > NOW()
+		RETURNING id, user_id::TEXT, family_id::TEXT`, hash,
+	).Scan(&id, &userID, &familyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.rejectUnclaimed(ctx, hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Let Supabase issue the new pair (it rotates its own token too)
+	pair, err := s.exchange(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, ErrInvalidRefreshToken) {
+			s.revokeID(ctx, id, RevokeReasonRejected)
+		} else {
+			// Supabase is unreachable: give the token back so the client can retry
+			_, _ = s.db.Exec(context.WithoutCancel(ctx),
+				`UPDATE auth_refresh_tokens SET status = 'ACTIVE', rotated_at = NULL WHERE id = $1`, id)
+		}
+		return nil, err
+	}
+	pair.UserID = userID
+
+	// 3. Record the new token in the same family
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var newID int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO auth_refresh_tokens (user_id, family_id, token_hash, ip_address, user_agent, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		userID, familyID, hashToken(pair.RefreshToken), ip, userAgent, time.Now().Add(s.cfg.TTL),
+	).Scan(&newID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE auth_refresh_tokens SET replaced_by = $2 WHERE id = $1`, id, newID); err != nil {
+		return nil, err
+	}
+	return pair, tx.Commit(ctx)
+}
+
+// rejectUnclaimed explains why a token could not be claimed and revokes the
+// family when a rotated token is replayed after the grace period
+func (s *RefreshService) rejectUnclaimed(ctx context.Context, hash string) error {
+	var (
+		status    string
+		familyID  string
+		rotatedAt *time.Time
+	)
+	err := s.db.QueryRow(ctx, `
+		SELECT status, family_id::TEXT, rotated_at FROM auth_refresh_tokens WHERE token_hash = $1`, hash,
+	).Scan(&status, &familyID, &rotatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+
+	if status != "ROTATED" || rotatedAt == nil {
+		return ErrInvalidRefreshToken
+	}
+	if time.Since(*rotatedAt) < s.cfg.ReuseGrace {
+		return ErrRefreshTokenRotated
+	}
+	if err := s.revokeFamily(ctx, familyID, RevokeReasonReuse); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// Revoke revokes the token's whole family, e.g. on logout. Unknown tokens are ignored.
+func (s *RefreshService) Revoke(ctx context.Context, refreshToken, reason string) error {
+	if refreshToken == "" {
+		return nil
+	}
+	var familyID string
+	err := s.db.QueryRow(ctx,
+		`SELECT family_id::TEXT FROM auth_refresh_tokens WHERE token_hash = $1`, hashToken(refreshToken),
+	).Scan(&familyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.revokeFamily(ctx, familyID, reason)
+}
+
+func (s *RefreshService) revokeFamily(ctx context.Context, familyID, reason string) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE auth_refresh_tokens SET status = 'REVOKED', revoked_at = NOW(), revoked_reason = $2
+		WHERE family_id = $1 AND status <> 'REVOKED'`, familyID, reason)
+	return err
+}
+
+func (s *RefreshService) revokeID(ctx context.Context, id int64, reason string) {
+	_, _ = s.db.Exec(context.WithoutCancel(ctx), `
+		UPDATE auth_refresh_tokens SET status = 'REVOKED', revoked_at = NOW(), revoked_reason = $2
+		WHERE id = $1`, id, reason)
+}
+
+// PurgeExpired deletes tokens that expired or were revoked over a week ago,
+// keeping recent ones for reuse detection and investigation
+func (s *RefreshService) PurgeExpired(ctx context.Context) (int64, error) {
+	tag, err := s.db.Exec(ctx, `
+		DELETE FROM auth_refresh_tokens
+		WHERE expires_at < NOW() - INTERVAL '7 days'
+			OR revoked_at < NOW() - INTERVAL '7 days'`)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// SignOut ends the Supabase session behind accessToken, which also invalidates
+// Supabase's own refresh tokens for it
+func (s *RefreshService) SignOut(ctx context.Context, accessToken string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.SupabaseURL+"/auth/v1/logout", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("apikey", s.cfg.SupabaseKey)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 401/403: the access token already expired, nothing left to sign out
+	if resp.StatusCode >= 400 && resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return fmt.Errorf("supabase logout failed: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// exchange calls Supabase's refresh_token grant
+func (s *RefreshService) exchange(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	body, _ := json.Marshal(map[string]string{"refresh_token": refreshToken})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		s.cfg.SupabaseURL+"/auth/v1/token?grant_type=refresh_token", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apikey", s.cfg.SupabaseKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRefreshUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 500:
+		return nil, fmt.Errorf("%w: status %d", ErrRefreshUnavailable, resp.StatusCode)
+	case resp.StatusCode >= 400:
+		return nil, ErrInvalidRefreshToken
+	}
+
+	var out struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRefreshUnavailable, err)
+	}
+	if out.AccessToken == "" || out.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: incomplete token response", ErrRefreshUnavailable)
+	}
+	return &TokenPair{AccessToken: out.AccessToken, RefreshToken: out.RefreshToken, ExpiresIn: out.ExpiresIn}, nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,58 @@
+// Package buildinfo identifies the running binary. Version, Commit and BuildTime
+// are set at link time:
+//
+//	go build -ldflags "-X go-recruitment-backend/pkg/buildinfo.Version=v1.4.0 \
+//	  -X go-recruitment-backend/pkg/buildinfo.Commit=$(git rev-parse HEAD)" ./cmd/api
+//
+// Without ldflags, the commit falls back to the VCS stamp Go embeds in binaries
+// built from a git checkout.
+package buildinfo
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info is the build identity exposed over the API
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build identity of the running binary
+func Get() Info {
+	once.Do(func() {
+		info = Info{Version: Version, Commit: Commit, BuildTime: BuildTime}
+		bi, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		info.GoVersion = bi.GoVersion
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			}
+		}
+	})
+	return info
+}
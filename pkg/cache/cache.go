@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache is a byte-valued key/value store with expiry. Callers treat it as best
+// effort: a miss or an error means loading from the source of truth.
+type Cache interface {
+	// Get returns the value and whether the key was found
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value; a zero ttl keeps it until deleted
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Incr atomically increments an integer counter, starting from 0
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// RedisCache stores entries in Redis, so every instance shares them
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedis creates a cache backed by client
+func NewRedis(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+func (c *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+// maxMemoryEntries bounds the in-memory cache; when full, expired entries are
+// swept and, if that is not enough, everything is dropped
+const maxMemoryEntries = 10000
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time // zero: no expiry
+}
+
+// MemoryCache is the in-process fallback when Redis is not configured. Entries
+// are per instance, so invalidation only reaches this instance.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+// NewMemory creates an empty in-process cache
+func NewMemory() *MemoryCache {
+	return &MemoryCache{entries: map[string]memoryEntry{}}
+}
+
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+	return entry.value, true, nil
+}
+
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.makeRoom()
+	c.entries[key] = entry
+	return nil
+}
+
+func (c *MemoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *MemoryCache) Incr(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	var n int64
+	if ok {
+		var err error
+		if n, err = strconv.ParseInt(string(entry.value), 10, 64); err != nil {
+			return 0, errors.New("cache: value is not an integer")
+		}
+	} else {
+		c.makeRoom()
+	}
+	n++
+	entry.value = []byte(strconv.FormatInt(n, 10))
+	c.entries[key] = entry
+	return n, nil
+}
+
+// makeRoom keeps the map under maxMemoryEntries; the caller holds mu
+func (c *MemoryCache) makeRoom() {
+	if len(c.entries) < maxMemoryEntries {
+		return
+	}
+	now := time.Now()
+	for key, entry := range c.entries {
+		if !entry.expiresAt.IsZero() && now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= maxMemoryEntries {
+		c.entries = map[string]memoryEntry{}
+	}
+}
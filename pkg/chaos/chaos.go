@@ -0,0 +1,116 @@
+// Package chaos injects faults for resilience testing: request latency, 5xx
+// responses from Supabase, and database errors, each on a configured percentage
+// of calls. It must never be enabled in production; the caller enforces that.
+package chaos
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-recruitment-backend/pkg/logger"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrInjected is the cause of every injected database error
+var ErrInjected = errors.New("chaos: injected fault")
+
+// Config sets how often each fault is injected; percentages are 0-100
+type Config struct {
+	LatencyPercent       int
+	Latency              time.Duration
+	SupabaseErrorPercent int
+	SupabaseHost         string // outbound requests to this host can fail
+	DBErrorPercent       int
+}
+
+// Injector decides per call whether to inject a fault
+type Injector struct {
+	cfg Config
+}
+
+// New returns an injector for cfg
+func New(cfg Config) *Injector {
+	return &Injector{cfg: cfg}
+}
+
+func roll(percent int) bool {
+	return percent > 0 && rand.IntN(100) < percent
+}
+
+// Delay sleeps for the configured latency on LatencyPercent of calls, or until ctx
+// is done. It reports whether a delay was injected.
+func (i *Injector) Delay(ctx context.Context) bool {
+	if !roll(i.cfg.LatencyPercent) {
+		return false
+	}
+	logger.FromContext(ctx).Info("Chaos: injecting latency", "latency_ms", i.cfg.Latency.Milliseconds())
+	t := time.NewTimer(i.cfg.Latency)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+	return true
+}
+
+// Transport wraps next so requests to the Supabase host fail with 503 on
+// SupabaseErrorPercent of calls, without reaching Supabase
+func (i *Injector) Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Host != i.cfg.SupabaseHost || !roll(i.cfg.SupabaseErrorPercent) {
+			return next.RoundTrip(req)
+		}
+		logger.FromContext(req.Context()).Info("Chaos: injecting Supabase 503", "method", req.Method, "path", req.URL.Path)
+		body := `{"msg":"chaos: injected upstream failure"}`
+		return &http.Response{
+			Status:        "503 Service Unavailable",
+			StatusCode:    http.StatusServiceUnavailable,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewBufferString(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// QueryTracer makes DBErrorPercent of queries fail. Set it on the pool every
+// repository uses and it acts as a wrapper around all of them: the query gets an
+// already-cancelled context, so pgx returns an error before sending anything and
+// the connection stays usable.
+func (i *Injector) QueryTracer() pgx.QueryTracer {
+	return queryTracer{i}
+}
+
+type queryTracer struct {
+	i *Injector
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !roll(t.i.cfg.DBErrorPercent) {
+		return ctx
+	}
+	sql := data.SQL
+	if len(sql) > 80 {
+		sql = sql[:80]
+	}
+	logger.FromContext(ctx).Info("Chaos: injecting database error", "sql", sql)
+	failed, cancel := context.WithCancelCause(ctx)
+	cancel(ErrInjected)
+	return failed
+}
+
+func (queryTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-recruitment-backend/pkg/logger"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationLockKey serializes migrations across instances starting at the same time
+const migrationLockKey = 7_351_004_117
+
+// ErrUnversionedDatabase means the database has tables but no migration history,
+// i.e. its migrations were applied by hand. Running them again would fail, so a
+// baseline is needed first.
+var ErrUnversionedDatabase = errors.New("database has tables but no migration history; set DB_MIGRATIONS_BASELINE to the last migration already applied")
+
+// Migrate applies the *.up.sql files in files that are not yet recorded in
+// schema_migrations, in file name order. Each migration runs in its own
+// transaction under an advisory lock, so a failed migration leaves nothing behind
+// and concurrent instances apply it once.
+//
+// baseline (a version such as "000061") records every migration up to and
+// including that version as applied without running it; it is only used for a
+// database whose migrations were applied by hand. It returns the names of the
+// migrations it ran.
+func Migrate(ctx context.Context, pool *pgxpool.Pool, files fs.FS, baseline string) ([]string, error) {
+	names, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	if _, err := pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			name TEXT PRIMARY KEY,
+			baselined BOOLEAN NOT NULL DEFAULT FALSE,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`); err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	if err := applyBaseline(ctx, pool, names, baseline); err != nil {
+		return nil, err
+	}
+
+	applied := []string{}
+	for _, name := range names {
+		sql, err := fs.ReadFile(files, name)
+		if err != nil {
+			return applied, err
+		}
+		ran, err := runMigration(ctx, pool, name, string(sql))
+		if err != nil {
+			return applied, fmt.Errorf("migration %s: %w", name, err)
+		}
+		if ran {
+			logger.Log.Info("Applied migration", "name", name)
+			applied = append(applied, name)
+		}
+	}
+	return applied, nil
+}
+
+// applyBaseline records the migrations up to baseline when the history is empty.
+// An empty history on a database that already has the users table without a
+// baseline is refused.
+func applyBaseline(ctx context.Context, pool *pgxpool.Pool, names []string, baseline string) error {
+	var recorded int
+	if err := pool.QueryRow(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&recorded); err != nil {
+		return err
+	}
+	if recorded > 0 {
+		return nil
+	}
+
+	if baseline == "" {
+		var hasTables bool
+		if err := pool.QueryRow(ctx, `SELECT to_regclass('public.users') IS NOT NULL`).Scan(&hasTables); err != nil {
+			return err
+		}
+		if hasTables {
+			return ErrUnversionedDatabase
+		}
+		return nil
+	}
+
+	baselined := 0
+	for _, name := range names {
+		if migrationVersion(name) > baseline {
+			break
+		}
+		if _, err := pool.Exec(ctx, `INSERT INTO schema_migrations (name, baselined) VALUES ($1, TRUE) ON CONFLICT DO NOTHING`, name); err != nil {
+			return fmt.Errorf("record baseline: %w", err)
+		}
+		baselined++
+	}
+	logger.Log.Info("Recorded migration baseline", "baseline", baseline, "migrations", baselined)
+	return nil
+}
+
+// migrationVersion is the numeric prefix of a migration file name
+func migrationVersion(name string) string {
+	version, _, _ := strings.Cut(name, "_")
+	return version
+}
+
+// runMigration applies one migration unless it was recorded meanwhile
+func runMigration(ctx context.Context, pool *pgxpool.Pool, name, sql string) (bool, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(migrationLockKey)); err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE name = $1)`, name).Scan(&exists); err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	// The pool uses the simple protocol, so a file with several statements runs as one batch
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return false, err
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (name) VALUES ($1)`, name); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
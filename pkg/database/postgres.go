@@ -2,14 +2,16 @@ package database
 
 import (
 	"context"
-	"log"
+	"go-recruitment-backend/pkg/logger"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewPostgresConnection(connString string) (*pgxpool.Pool, error) {
+// NewPostgresConnection opens the pool; tracer, if not nil, observes every query
+// (used for fault injection)
+func NewPostgresConnection(connString string, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, err
@@ -23,6 +25,9 @@ func NewPostgresConnection(connString string) (*pgxpool.Pool, error) {
 	config.MinConns = 5
 	config.MaxConnLifetime = time.Hour
 	config.MaxConnIdleTime = 30 * time.Minute
+	if tracer != nil {
+		config.ConnConfig.Tracer = tracer
+	}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
@@ -33,6 +38,6 @@ func NewPostgresConnection(connString string) (*pgxpool.Pool, error) {
 		return nil, err
 	}
 
-	log.Println("Database connection established successfully")
+	logger.Log.Info("Database connection established successfully")
 	return pool, nil
 }
@@ -2,190 +2,167 @@ package email
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"go-recruitment-backend/config"
-	"html/template"
+	"go-recruitment-backend/pkg/i18n"
+	"go-recruitment-backend/pkg/metrics"
+	"go-recruitment-backend/pkg/tracing"
+	"mime"
 	"net"
 	"net/smtp"
+	"slices"
+	"strings"
 )
 
 // EmailService handles sending emails via SMTP
 type EmailService struct {
-	host      string
-	port      string
-	username  string
-	password  string
-	fromEmail string
-	toEmail   string
+	host        string
+	port        string
+	username    string
+	password    string
+	fromEmail   string
+	contactTo   []string
+	contactCc   []string
+	frontendURL string
+	tracker     Tracker
 }
 
-// ContactEmailData holds the data for contact form emails
-type ContactEmailData struct {
-	SenderName  string
-	SenderEmail string
-	Subject     string
-	Message     string
+// Message is an email ready to send. Bcc recipients receive it without being
+// listed in the headers.
+type Message struct {
+	To      []string
+	Cc      []string
+	Bcc     []string
+	ReplyTo string
+	Subject string
+	Body    string
+	HTML    bool // Body is HTML instead of plain text
+}
+
+// TemplateMessage is a registered template to render in Locale and send
+type TemplateMessage struct {
+	Template string
+	Locale   string // id, en or ja; anything else renders in English
+	To       []string
+	Cc       []string
+	Bcc      []string
+	ReplyTo  string
+	Data     any    // the template's data struct, e.g. VerificationApprovedData
+	UserID   string // recipient's account; only emails to an account can be tracked
 }
 
 // NewEmailService creates a new email service with Brevo SMTP configuration
 func NewEmailService(cfg *config.Config) *EmailService {
 	return &EmailService{
-		host:      cfg.SMTPHost,
-		port:      cfg.SMTPPort,
-		username:  cfg.SMTPUsername,
-		password:  cfg.SMTPPassword,
-		fromEmail: cfg.SMTPFromEmail, // Verified sender email, NOT the SMTP login
-		toEmail:   cfg.ContactEmailTo,
+		host:        cfg.SMTPHost,
+		port:        cfg.SMTPPort,
+		username:    cfg.SMTPUsername,
+		password:    cfg.SMTPPassword,
+		fromEmail:   cfg.SMTPFromEmail, // Verified sender email, NOT the SMTP login
+		contactTo:   splitAddresses(cfg.ContactEmailTo),
+		contactCc:   splitAddresses(cfg.ContactEmailCc),
+		frontendURL: cfg.FrontendURL,
 	}
 }
 
-// contactEmailTemplate is the HTML template for contact form emails
-const contactEmailTemplate = `<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>New Contact Form Submission</title>
-    <style>
-        /* Reset & Base Styles */
-        body { margin: 0; padding: 0; font-family: 'Helvetica Neue', Arial, sans-serif; line-height: 1.6; color: #333333; background-color: #f4f4f4; -webkit-text-size-adjust: 100%; -ms-text-size-adjust: 100%; }
-        table { border-spacing: 0; width: 100%; }
-        td { padding: 0; }
-        img { border: 0; }
-
-        /* Container */
-        .wrapper { width: 100%; table-layout: fixed; background-color: #f4f4f4; padding-bottom: 40px; }
-        .main-container { background-color: #ffffff; margin: 0 auto; max-width: 600px; width: 100%; box-shadow: 0 1px 3px rgba(0,0,0,0.1); border-radius: 4px; overflow: hidden; }
-
-        /* Header */
-        .header { background-color: #0066cc; color: #ffffff; padding: 25px 20px; text-align: center; }
-        .header h1 { margin: 0; font-size: 24px; font-weight: 600; letter-spacing: 0.5px; }
-
-        /* Content */
-        .content { padding: 30px 25px; }
-        .field-group { margin-bottom: 20px; }
-        .label { font-size: 12px; text-transform: uppercase; letter-spacing: 1px; color: #888888; font-weight: bold; margin-bottom: 5px; }
-        .value { font-size: 16px; color: #333333; font-weight: 500; }
-        
-        /* Message Box */
-        .message-box { background-color: #f8f9fa; border-left: 4px solid #0066cc; padding: 15px; margin-top: 5px; font-style: italic; color: #555; white-space: pre-wrap; }
-
-        /* Footer */
-        .footer { background-color: #f4f4f4; padding: 20px; text-align: center; font-size: 12px; color: #999999; border-top: 1px solid #e1e1e1; }
-        .footer p { margin: 5px 0; }
-        .footer strong { color: #666; }
-        .company-address { margin: 15px 0; line-height: 1.5; }
-        .dev-credit { font-size: 11px; margin-top: 15px; opacity: 0.7; }
-        
-        /* Links */
-        a { color: #0066cc; text-decoration: none; }
-        a:hover { text-decoration: underline; }
-        .unsubscribe-link { color: #999999; text-decoration: underline; font-size: 11px; }
-
-        /* Mobile Responsive */
-        @media screen and (max-width: 600px) {
-            .main-container { width: 100% !important; }
-            .content { padding: 20px; }
-        }
-    </style>
-</head>
-<body>
-    <div class="wrapper">
-        <br> <div class="main-container">
-            <div class="header">
-                <h1>New Lead Received</h1>
-            </div>
-
-            <div class="content">
-                <div class="field-group">
-                    <div class="label">From</div>
-                    <div class="value">{{.SenderName}} (<a href="mailto:{{.SenderEmail}}">{{.SenderEmail}}</a>)</div>
-                </div>
-
-                <div class="field-group">
-                    <div class="label">Subject</div>
-                    <div class="value">{{.Subject}}</div>
-                </div>
-
-                <div class="field-group">
-                    <div class="label">Message Content</div>
-                    <div class="value message-box">{{.Message}}</div>
-                </div>
-
-                <div style="margin-top: 30px; text-align: center;">
-                    <a href="mailto:{{.SenderEmail}}?subject=Re: {{.Subject}}" style="background-color: #0066cc; color: white; padding: 12px 25px; text-decoration: none; border-radius: 4px; font-weight: bold; display: inline-block;">Reply to Sender</a>
-                </div>
-            </div>
-
-            <div class="footer">
-                <p>This is an automated notification from your website contact form.</p>
-                
-                <div class="company-address">
-                    <strong>J Expert Recruitment - Part of Exata Group</strong><br>
-                    Exata Office Tower, Ruko Rose Garden 5 No. 9<br>
-                    Jakasetia, Bekasi Selatan, Kota Bekasi<br>
-                    Jawa Barat, Indonesia 17148
-                </div>
+// splitAddresses parses a comma-separated address list
+func splitAddresses(list string) []string {
+	addresses := []string{}
+	for _, a := range strings.Split(list, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses
+}
 
-                <p>&copy; 2025 J Expert Recruitment. All rights reserved.</p>
-                <p class="dev-credit">Develop by Noxx Labs</p>
+// Link is the frontend URL of path, for links in emails
+func (s *EmailService) Link(path string) string {
+	return s.frontendURL + path
+}
 
-                <p style="margin-top: 20px;">
-                    <a href="#" class="unsubscribe-link">Unsubscribe</a> | 
-                    <a href="#" class="unsubscribe-link">Privacy Policy</a>
-                </p>
-            </div>
-        </div>
-        <br> </div>
-</body>
-</html>`
+// SendContactEmail sends a contact form email to the configured recipients
+func (s *EmailService) SendContactEmail(ctx context.Context, data ContactEmailData) error {
+	return s.SendTemplate(ctx, TemplateMessage{
+		Template: TemplateContact,
+		Locale:   i18n.LocaleEN,
+		To:       s.contactTo,
+		Cc:       s.contactCc,
+		ReplyTo:  data.SenderEmail,
+		Data:     data,
+	})
+}
 
-// SendContactEmail sends a contact form email to the configured recipient
-func (s *EmailService) SendContactEmail(data ContactEmailData) error {
-	// Parse and execute the template
-	tmpl, err := template.New("contact").Parse(contactEmailTemplate)
+// SendTemplate renders a registered template in the message's locale and sends it
+func (s *EmailService) SendTemplate(ctx context.Context, m TemplateMessage) error {
+	subject, body, err := Render(m.Template, m.Locale, m.Data)
 	if err != nil {
-		return fmt.Errorf("failed to parse email template: %w", err)
+		return err
 	}
+	if s.tracker != nil && m.UserID != "" {
+		body = s.addTracking(ctx, m, body)
+	}
+	return s.Send(ctx, &Message{To: m.To, Cc: m.Cc, Bcc: m.Bcc, ReplyTo: m.ReplyTo, Subject: subject, Body: body, HTML: true})
+}
 
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, data); err != nil {
-		return fmt.Errorf("failed to execute email template: %w", err)
+// SendTextEmail sends a plain-text email to an arbitrary recipient (candidate notifications)
+func (s *EmailService) SendTextEmail(ctx context.Context, to, subject, body string) error {
+	return s.Send(ctx, &Message{To: []string{to}, Subject: subject, Body: body})
+}
+
+// Send delivers a message to all of its To, Cc and Bcc recipients in one SMTP
+// transaction; ctx only carries the trace
+func (s *EmailService) Send(ctx context.Context, m *Message) error {
+	if len(m.To) == 0 {
+		return errors.New("email has no recipient")
+	}
+	recipients := slices.Concat(m.To, m.Cc, m.Bcc)
+	for _, addr := range append(recipients, m.ReplyTo) {
+		if strings.ContainsAny(addr, "\r\n") {
+			return fmt.Errorf("invalid email address %q", addr)
+		}
 	}
 
-	// Build the email message
-	subject := fmt.Sprintf("Contact Form: %s", data.Subject)
+	contentType := "text/plain"
+	if m.HTML {
+		contentType = "text/html"
+	}
 
-	// Construct MIME message
-	msg := []byte(fmt.Sprintf(
-		"From: %s\r\n"+
-			"To: %s\r\n"+
-			"Reply-To: %s\r\n"+
-			"Subject: %s\r\n"+
-			"MIME-Version: 1.0\r\n"+
-			"Content-Type: text/html; charset=UTF-8\r\n"+
-			"\r\n"+
-			"%s",
-		s.fromEmail,
-		s.toEmail,
-		data.SenderEmail,
-		subject,
-		body.String(),
-	))
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.fromEmail)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(m.To, ", "))
+	if len(m.Cc) > 0 {
+		fmt.Fprintf(&msg, "Cc: %s\r\n", strings.Join(m.Cc, ", "))
+	}
+	if m.ReplyTo != "" {
+		fmt.Fprintf(&msg, "Reply-To: %s\r\n", m.ReplyTo)
+	}
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", m.Subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s; charset=UTF-8\r\n", contentType)
+	msg.WriteString("\r\n")
+	msg.WriteString(m.Body)
 
 	// Send via STARTTLS (required by Brevo on port 587)
-	err = s.sendMailWithStartTLS(msg)
-	if err != nil {
+	_, span := tracing.StartKind(ctx, "smtp send", tracing.KindClient)
+	span.Set("server.address", s.host)
+	span.Set("email.recipients", len(recipients))
+	defer span.End()
+	if err := s.sendMailWithStartTLS(recipients, msg.Bytes()); err != nil {
+		span.SetError(err)
+		metrics.EmailSendFailures.Inc()
 		return fmt.Errorf("failed to send email: %w", err)
 	}
-
+	metrics.EmailsSent.Inc()
 	return nil
 }
 
 // sendMailWithStartTLS sends email using STARTTLS which is required by Brevo
-func (s *EmailService) sendMailWithStartTLS(msg []byte) error {
-	addr := fmt.Sprintf("%s:%s", s.host, s.port)
+func (s *EmailService) sendMailWithStartTLS(recipients []string, msg []byte) error {
+	addr := net.JoinHostPort(s.host, s.port)
 
 	// Connect to SMTP server
 	conn, err := net.Dial("tcp", addr)
@@ -225,9 +202,11 @@ func (s *EmailService) sendMailWithStartTLS(msg []byte) error {
 		return fmt.Errorf("MAIL FROM failed: %w", err)
 	}
 
-	// Set recipient
-	if err = client.Rcpt(s.toEmail); err != nil {
-		return fmt.Errorf("RCPT TO failed: %w", err)
+	// Set recipients
+	for _, to := range recipients {
+		if err = client.Rcpt(to); err != nil {
+			return fmt.Errorf("RCPT TO %s failed: %w", to, err)
+		}
 	}
 
 	// Send message body
@@ -0,0 +1,352 @@
+package email
+
+import (
+	"fmt"
+	"go-recruitment-backend/pkg/i18n"
+	"html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Transactional email templates
+const (
+	TemplateContact              = "contact"               // website contact form, to the company inbox
+	TemplateApplicationReceived  = "application_received"  // employer: a candidate applied to a job
+	TemplateVerificationApproved = "verification_approved" // user: account verification approved
+	TemplateVerificationRejected = "verification_rejected" // user: account verification rejected
+	TemplateInterviewInvite      = "interview_invite"      // candidate: invited to an interview
+	TemplatePasswordChanged      = "password_changed"      // user: the account password was changed
+	TemplateCompanyInvite        = "company_invite"        // invitee: asked to join a company's team
+)
+
+// Template categories, the unit users and operators switch tracking off by
+const (
+	CategoryAccount      = "account"      // verification decisions
+	CategoryApplications = "applications" // applications and interviews
+	CategoryTeam         = "team"         // company team invitations
+	CategoryContact      = "contact"      // website contact form
+	CategorySecurity     = "security"     // password and sign-in notices; never tracked
+)
+
+// TemplateCategories maps each template to its category
+var TemplateCategories = map[string]string{
+	TemplateContact:              CategoryContact,
+	TemplateApplicationReceived:  CategoryApplications,
+	TemplateVerificationApproved: CategoryAccount,
+	TemplateVerificationRejected: CategoryAccount,
+	TemplateInterviewInvite:      CategoryApplications,
+	TemplatePasswordChanged:      CategorySecurity,
+	TemplateCompanyInvite:        CategoryTeam,
+}
+
+// ContactEmailData holds the data for contact form emails
+type ContactEmailData struct {
+	SenderName  string
+	SenderEmail string
+	Subject     string
+	Message     string
+}
+
+// ApplicationReceivedData is the data of TemplateApplicationReceived
+type ApplicationReceivedData struct {
+	RecipientName string
+	CandidateName string
+	JobTitle      string
+	ActionURL     string // the application in the employer dashboard
+}
+
+// VerificationApprovedData is the data of TemplateVerificationApproved
+type VerificationApprovedData struct {
+	RecipientName string
+	ActionURL     string // the user's dashboard
+}
+
+// VerificationRejectedData is the data of TemplateVerificationRejected
+type VerificationRejectedData struct {
+	RecipientName string
+	Reason        string // reviewer notes, optional
+	ActionURL     string // where the user corrects and resubmits
+}
+
+// InterviewInviteData is the data of TemplateInterviewInvite
+type InterviewInviteData struct {
+	RecipientName string
+	CompanyName   string
+	JobTitle      string
+	ScheduledAt   time.Time // shown in its own location
+	Location      string    // optional
+	MeetingURL    string    // optional, online interviews
+	Notes         string    // optional
+}
+
+// PasswordChangedData is the data of TemplatePasswordChanged
+type PasswordChangedData struct {
+	RecipientName string
+	ChangedAt     time.Time
+}
+
+// CompanyInviteData is the data of TemplateCompanyInvite
+type CompanyInviteData struct {
+	CompanyName  string
+	InviterEmail string
+	RoleLabel    string // "Recruiter" or "Viewer", translated in the template
+	ExpiresAt    time.Time
+	ActionURL    string // accepts the invitation; carries the token
+}
+
+// emailTemplate is one template compiled for one locale
+type emailTemplate struct {
+	subject *texttemplate.Template
+	body    *template.Template
+}
+
+// templateSources are the subject and body of each template. Text goes through
+// the "t" function: the source is an i18n message in English, translated into the
+// rendering locale and formatted with the remaining arguments.
+var templateSources = map[string]struct{ subject, body string }{
+	TemplateContact: {
+		subject: `{{t "Contact Form: %s" .Subject}}`,
+		body: `{{define "title"}}{{t "New Lead Received"}}{{end}}
+{{define "footer_note"}}{{t "This is an automated notification from your website contact form."}}{{end}}
+{{define "content"}}
+<div class="field-group">
+    <div class="label">{{t "From"}}</div>
+    <div class="value">{{.SenderName}} (<a href="mailto:{{.SenderEmail}}">{{.SenderEmail}}</a>)</div>
+</div>
+<div class="field-group">
+    <div class="label">{{t "Subject"}}</div>
+    <div class="value">{{.Subject}}</div>
+</div>
+<div class="field-group">
+    <div class="label">{{t "Message Content"}}</div>
+    <div class="value message-box">{{.Message}}</div>
+</div>
+<div class="action"><a class="button" href="mailto:{{.SenderEmail}}?subject=Re: {{.Subject}}">{{t "Reply to Sender"}}</a></div>
+{{end}}`,
+	},
+	TemplateApplicationReceived: {
+		subject: `{{t "New application for %s" .JobTitle}}`,
+		body: `{{define "title"}}{{t "New Application Received"}}{{end}}
+{{define "content"}}
+{{template "greeting" .RecipientName}}
+<p>{{t "%s has applied to your job %s." .CandidateName .JobTitle}}</p>
+{{with .ActionURL}}<div class="action"><a class="button" href="{{.}}">{{t "Review Application"}}</a></div>{{end}}
+{{end}}`,
+	},
+	TemplateVerificationApproved: {
+		subject: `{{t "Your account has been verified"}}`,
+		body: `{{define "title"}}{{t "Account Verified"}}{{end}}
+{{define "content"}}
+{{template "greeting" .RecipientName}}
+<p>{{t "Good news: your account verification has been approved. You now have full access to J Expert Recruitment."}}</p>
+{{with .ActionURL}}<div class="action"><a class="button" href="{{.}}">{{t "Go to Dashboard"}}</a></div>{{end}}
+{{end}}`,
+	},
+	TemplateVerificationRejected: {
+		subject: `{{t "Your account verification needs attention"}}`,
+		body: `{{define "title"}}{{t "Verification Not Approved"}}{{end}}
+{{define "content"}}
+{{template "greeting" .RecipientName}}
+<p>{{t "We could not approve your account verification."}}</p>
+{{with .Reason}}
+<div class="field-group">
+    <div class="label">{{t "Reviewer notes"}}</div>
+    <div class="value message-box">{{.}}</div>
+</div>
+{{end}}
+<p>{{t "Please update your information and submit it again."}}</p>
+{{with .ActionURL}}<div class="action"><a class="button" href="{{.}}">{{t "Update Verification"}}</a></div>{{end}}
+{{end}}`,
+	},
+	TemplateInterviewInvite: {
+		subject: `{{t "Interview invitation: %s" .JobTitle}}`,
+		body: `{{define "title"}}{{t "Interview Invitation"}}{{end}}
+{{define "content"}}
+{{template "greeting" .RecipientName}}
+<p>{{t "%s would like to invite you to an interview for %s." .CompanyName .JobTitle}}</p>
+<div class="field-group">
+    <div class="label">{{t "Date and time"}}</div>
+    <div class="value">{{datetime .ScheduledAt}}</div>
+</div>
+{{with .Location}}
+<div class="field-group">
+    <div class="label">{{t "Location"}}</div>
+    <div class="value">{{.}}</div>
+</div>
+{{end}}
+{{with .Notes}}
+<div class="field-group">
+    <div class="label">{{t "Notes"}}</div>
+    <div class="value message-box">{{.}}</div>
+</div>
+{{end}}
+{{with .MeetingURL}}<div class="action"><a class="button" href="{{.}}">{{t "Join Meeting"}}</a></div>{{end}}
+{{end}}`,
+	},
+	TemplatePasswordChanged: {
+		subject: `{{t "Your password was changed"}}`,
+		body: `{{define "title"}}{{t "Password Changed"}}{{end}}
+{{define "content"}}
+{{template "greeting" .RecipientName}}
+<p>{{t "The password of your J Expert Recruitment account was changed on %s." (datetime .ChangedAt)}}</p>
+<p>{{t "If you did not make this change, reset your password right away and contact us."}}</p>
+{{end}}`,
+	},
+	TemplateCompanyInvite: {
+		subject: `{{t "Join %s on J Expert Recruitment" .CompanyName}}`,
+		body: `{{define "title"}}{{t "Team Invitation"}}{{end}}
+{{define "content"}}
+<p>{{t "%s has invited you to join %s as a %s." .InviterEmail .CompanyName (t .RoleLabel)}}</p>
+<p>{{t "Sign in or register an employer account with this email address, then accept the invitation. It expires on %s." (datetime .ExpiresAt)}}</p>
+<div class="action"><a class="button" href="{{.ActionURL}}">{{t "Accept Invitation"}}</a></div>
+{{end}}`,
+	},
+}
+
+// layoutTemplate wraps every body: a template defines "title" and "content" and
+// may override "footer_note"
+const layoutTemplate = `<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{template "title" .}}</title>
+    <style>
+        /* Reset & Base Styles */
+        body { margin: 0; padding: 0; font-family: 'Helvetica Neue', Arial, sans-serif; line-height: 1.6; color: #333333; background-color: #f4f4f4; -webkit-text-size-adjust: 100%; -ms-text-size-adjust: 100%; }
+        table { border-spacing: 0; width: 100%; }
+        td { padding: 0; }
+        img { border: 0; }
+
+        /* Container */
+        .wrapper { width: 100%; table-layout: fixed; background-color: #f4f4f4; padding-bottom: 40px; }
+        .main-container { background-color: #ffffff; margin: 0 auto; max-width: 600px; width: 100%; box-shadow: 0 1px 3px rgba(0,0,0,0.1); border-radius: 4px; overflow: hidden; }
+
+        /* Header */
+        .header { background-color: #0066cc; color: #ffffff; padding: 25px 20px; text-align: center; }
+        .header h1 { margin: 0; font-size: 24px; font-weight: 600; letter-spacing: 0.5px; }
+
+        /* Content */
+        .content { padding: 30px 25px; }
+        .field-group { margin-bottom: 20px; }
+        .label { font-size: 12px; text-transform: uppercase; letter-spacing: 1px; color: #888888; font-weight: bold; margin-bottom: 5px; }
+        .value { font-size: 16px; color: #333333; font-weight: 500; }
+
+        /* Message Box */
+        .message-box { background-color: #f8f9fa; border-left: 4px solid #0066cc; padding: 15px; margin-top: 5px; font-style: italic; color: #555; white-space: pre-wrap; }
+
+        /* Call to action */
+        .action { margin-top: 30px; text-align: center; }
+        .button { background-color: #0066cc; color: #ffffff !important; padding: 12px 25px; text-decoration: none; border-radius: 4px; font-weight: bold; display: inline-block; }
+
+        /* Footer */
+        .footer { background-color: #f4f4f4; padding: 20px; text-align: center; font-size: 12px; color: #999999; border-top: 1px solid #e1e1e1; }
+        .footer p { margin: 5px 0; }
+        .footer strong { color: #666; }
+        .company-address { margin: 15px 0; line-height: 1.5; }
+        .dev-credit { font-size: 11px; margin-top: 15px; opacity: 0.7; }
+
+        /* Links */
+        a { color: #0066cc; text-decoration: none; }
+        a:hover { text-decoration: underline; }
+
+        /* Mobile Responsive */
+        @media screen and (max-width: 600px) {
+            .main-container { width: 100% !important; }
+            .content { padding: 20px; }
+        }
+    </style>
+</head>
+<body>
+    <div class="wrapper">
+        <br> <div class="main-container">
+            <div class="header">
+                <h1>{{template "title" .}}</h1>
+            </div>
+
+            <div class="content">
+                {{template "content" .}}
+            </div>
+
+            <div class="footer">
+                <p>{{block "footer_note" .}}{{t "This is an automated message from J Expert Recruitment."}}{{end}}</p>
+
+                <div class="company-address">
+                    <strong>J Expert Recruitment - Part of Exata Group</strong><br>
+                    Exata Office Tower, Ruko Rose Garden 5 No. 9<br>
+                    Jakasetia, Bekasi Selatan, Kota Bekasi<br>
+                    Jawa Barat, Indonesia 17148
+                </div>
+
+                <p>&copy; 2025 J Expert Recruitment. {{t "All rights reserved."}}</p>
+                <p class="dev-credit">Develop by Noxx Labs</p>
+            </div>
+        </div>
+        <br> </div>
+</body>
+</html>
+{{define "greeting"}}<p>{{if .}}{{t "Hello %s," .}}{{else}}{{t "Hello,"}}{{end}}</p>{{end}}`
+
+// templates maps template → locale → compiled template. Each locale is compiled
+// separately so "t" is bound to it and rendering needs no locking.
+var templates = map[string]map[string]*emailTemplate{}
+
+func init() {
+	for name, src := range templateSources {
+		templates[name] = map[string]*emailTemplate{}
+		for _, locale := range i18n.SupportedLocales {
+			funcs := templateFuncs(locale)
+			subject, err := texttemplate.New(name).Funcs(funcs).Parse(src.subject)
+			if err != nil {
+				panic(fmt.Sprintf("email: invalid subject of %s: %v", name, err))
+			}
+			body, err := template.New(name).Funcs(funcs).Parse(layoutTemplate)
+			if err == nil {
+				body, err = body.Parse(src.body)
+			}
+			if err != nil {
+				panic(fmt.Sprintf("email: invalid body of %s: %v", name, err))
+			}
+			templates[name][locale] = &emailTemplate{subject: subject, body: body}
+		}
+	}
+}
+
+// templateFuncs are the functions available to templates rendered in locale
+func templateFuncs(locale string) map[string]any {
+	return map[string]any{
+		"t": func(message string, args ...any) string {
+			if len(args) == 0 {
+				return i18n.T(locale, message)
+			}
+			return fmt.Sprintf(i18n.T(locale, message), args...)
+		},
+		"datetime": func(t time.Time) string {
+			return t.Format("2006-01-02 15:04 MST")
+		},
+	}
+}
+
+// Render returns the subject and HTML body of a template in locale. Unsupported
+// locales fall back to English.
+func Render(name, locale string, data any) (string, string, error) {
+	byLocale, ok := templates[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown email template %q", name)
+	}
+	tmpl, ok := byLocale[locale]
+	if !ok {
+		tmpl = byLocale[i18n.LocaleEN]
+	}
+
+	var subject strings.Builder
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject of %s: %w", name, err)
+	}
+	var body strings.Builder
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", fmt.Errorf("failed to render body of %s: %w", name, err)
+	}
+	return subject.String(), body.String(), nil
+}
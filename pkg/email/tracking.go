@@ -0,0 +1,59 @@
+package email
+
+import (
+	"context"
+	"go-recruitment-backend/pkg/logger"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Tracker prepares open and click tracking for an email to a user. It returns
+// nil when the email must not be tracked (category or user preference).
+type Tracker interface {
+	Track(ctx context.Context, template, userID string, links []string) (*Tracking, error)
+}
+
+// Tracking is what a tracked email links to instead of the original URLs
+type Tracking struct {
+	PixelURL string            // 1x1 image counting opens
+	Links    map[string]string // original URL → redirect URL counting clicks
+}
+
+// SetTracker enables tracking of template emails sent with a UserID
+func (s *EmailService) SetTracker(t Tracker) {
+	s.tracker = t
+}
+
+var trackableHref = regexp.MustCompile(`href="(https?://[^"]+)"`)
+
+// addTracking rewrites the web links of an HTML body to their redirects and
+// appends the open pixel. Any failure sends the email untracked.
+func (s *EmailService) addTracking(ctx context.Context, m TemplateMessage, body string) string {
+	var links []string
+	for _, match := range trackableHref.FindAllStringSubmatch(body, -1) {
+		links = append(links, html.UnescapeString(match[1]))
+	}
+
+	tracking, err := s.tracker.Track(ctx, m.Template, m.UserID, links)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Email tracking unavailable, sending untracked", "template", m.Template, "error", err)
+		return body
+	}
+	if tracking == nil {
+		return body
+	}
+
+	body = trackableHref.ReplaceAllStringFunc(body, func(href string) string {
+		url := html.UnescapeString(trackableHref.FindStringSubmatch(href)[1])
+		if tracked, ok := tracking.Links[url]; ok {
+			return `href="` + html.EscapeString(tracked) + `"`
+		}
+		return href
+	})
+	pixel := `<img src="` + html.EscapeString(tracking.PixelURL) + `" width="1" height="1" alt="" style="display:none">`
+	if i := strings.LastIndex(body, "</body>"); i >= 0 {
+		return body[:i] + pixel + body[i:]
+	}
+	return body + pixel
+}
@@ -0,0 +1,241 @@
+// Package errreport sends panics and server errors to an error tracker: Sentry
+// (via its envelope API, given a DSN) and/or a generic JSON webhook. Events carry
+// the release and environment, so a crash can be tied to the deploy that caused it.
+//
+// Reporting is off until Init is called; Capture is then a no-op, so call sites
+// need no checks. Events are sent in the background and dropped when the queue
+// is full rather than slowing requests down.
+package errreport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go-recruitment-backend/pkg/logger"
+)
+
+// Levels
+const (
+	LevelError = "error"
+	LevelFatal = "fatal" // panics
+)
+
+const queueSize = 256
+
+// Config configures where events are sent; empty destinations are skipped
+type Config struct {
+	DSN         string // Sentry DSN, e.g. https://<key>@o123.ingest.sentry.io/456
+	WebhookURL  string // receives every event as a JSON POST
+	Environment string
+	Release     string
+	Commit      string
+}
+
+// Event is one error occurrence
+type Event struct {
+	ID        string            `json:"event_id"`
+	Timestamp time.Time         `json:"timestamp"`
+	Level     string            `json:"level"`
+	Message   string            `json:"message"`
+	ErrorType string            `json:"error_type,omitempty"` // Go type of the error or panic value
+	Stack     []Frame           `json:"stack,omitempty"`      // innermost call first
+	Request   *Request          `json:"request,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+
+	Release     string `json:"release,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	ServerName  string `json:"server_name,omitempty"`
+}
+
+// Request is the HTTP request an event happened in. The query string, body and
+// headers other than the user agent are left out, as they may hold credentials.
+type Request struct {
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Route     string `json:"route,omitempty"`
+	Status    int    `json:"status,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	TraceID   string `json:"trace_id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// Frame is one call of a stack trace
+type Frame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	InApp    bool   `json:"in_app"` // code of this module rather than a dependency or the runtime
+}
+
+// modulePrefix marks frames of this module as in-app
+const modulePrefix = "go-recruitment-backend/"
+
+// Stack returns the calling goroutine's stack, skipping skip frames above the
+// caller of Stack. Called while recovering, it starts at the panicking call:
+// the runtime's panic frames are left out.
+func Stack(skip int) []Frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []Frame
+	for {
+		f, more := frames.Next()
+		if f.Function != "" && !(len(stack) == 0 && strings.HasPrefix(f.Function, "runtime.")) {
+			stack = append(stack, Frame{
+				Function: f.Function,
+				File:     f.File,
+				Line:     f.Line,
+				InApp:    strings.HasPrefix(f.Function, modulePrefix),
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// sender delivers an event to one destination
+type sender interface {
+	name() string
+	send(e *Event) error
+}
+
+type reporter struct {
+	cfg     Config
+	host    string
+	senders []sender
+	queue   chan *Event
+	done    chan struct{}
+	exited  chan struct{}
+}
+
+var (
+	mu      sync.RWMutex
+	current *reporter
+)
+
+// Init starts reporting to the configured destinations; the returned func sends
+// queued events and stops reporting. An invalid DSN is returned as an error.
+func Init(cfg Config) (func(context.Context), error) {
+	var senders []sender
+	if cfg.DSN != "" {
+		s, err := newSentrySender(cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+		senders = append(senders, s)
+	}
+	if cfg.WebhookURL != "" {
+		senders = append(senders, newWebhookSender(cfg.WebhookURL))
+	}
+
+	host, _ := os.Hostname()
+	r := &reporter{
+		cfg:     cfg,
+		host:    host,
+		senders: senders,
+		queue:   make(chan *Event, queueSize),
+		done:    make(chan struct{}),
+		exited:  make(chan struct{}),
+	}
+	mu.Lock()
+	current = r
+	mu.Unlock()
+	go r.run()
+
+	return func(ctx context.Context) {
+		mu.Lock()
+		current = nil
+		mu.Unlock()
+		close(r.done)
+		select {
+		case <-r.exited:
+		case <-ctx.Done():
+		}
+	}, nil
+}
+
+// Enabled reports whether Init has been called
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current != nil
+}
+
+// Capture queues an event, filling in its ID, time, level and the release
+func Capture(ctx context.Context, e Event) {
+	mu.RLock()
+	r := current
+	mu.RUnlock()
+	if r == nil {
+		return
+	}
+
+	if e.ID == "" {
+		e.ID = newEventID()
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	if e.Level == "" {
+		e.Level = LevelError
+	}
+	e.Release, e.Environment, e.ServerName = r.cfg.Release, r.cfg.Environment, r.host
+	if r.cfg.Commit != "" {
+		tags := map[string]string{"commit": r.cfg.Commit}
+		for k, v := range e.Tags {
+			tags[k] = v
+		}
+		e.Tags = tags
+	}
+
+	select {
+	case r.queue <- &e:
+	default:
+		logger.Sampled(ctx, "errreport.queue_full").Warn("Error reporting: queue full, event dropped", "event_id", e.ID)
+	}
+}
+
+func (r *reporter) run() {
+	defer close(r.exited)
+	for {
+		select {
+		case e := <-r.queue:
+			r.send(e)
+		case <-r.done:
+			for {
+				select {
+				case e := <-r.queue:
+					r.send(e)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *reporter) send(e *Event) {
+	for _, s := range r.senders {
+		if err := s.send(e); err != nil {
+			logger.Sampled(context.Background(), "errreport.send_failed."+s.name()).
+				Warn("Error reporting: delivery failed", "destination", s.name(), "event_id", e.ID, "error", err)
+		}
+	}
+}
+
+// newEventID returns 32 hex characters, the event ID format Sentry expects
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,204 @@
+package errreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-recruitment-backend/pkg/buildinfo"
+)
+
+// sentrySender posts events to Sentry's envelope endpoint
+// (https://develop.sentry.dev/sdk/envelopes/)
+type sentrySender struct {
+	dsn    string
+	url    string
+	auth   string
+	client *http.Client
+}
+
+// newSentrySender parses a DSN of the form scheme://key@host[:port][/path]/project
+func newSentrySender(dsn string) (*sentrySender, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User == nil {
+		return nil, fmt.Errorf("invalid Sentry DSN")
+	}
+	key := u.User.Username()
+	path := strings.Trim(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	prefix, project := "", path
+	if i >= 0 {
+		prefix, project = "/"+path[:i], path[i+1:]
+	}
+	if key == "" || project == "" {
+		return nil, fmt.Errorf("invalid Sentry DSN: key and project ID are required")
+	}
+
+	return &sentrySender{
+		dsn:  dsn,
+		url:  fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, project),
+		auth: fmt.Sprintf("Sentry sentry_version=7, sentry_client=go-recruitment-backend/%s, sentry_key=%s", buildinfo.Get().Version, key),
+		// Own transport: the default one may be traced or fault-injected
+		client: &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}},
+	}, nil
+}
+
+func (s *sentrySender) name() string { return "sentry" }
+
+func (s *sentrySender) send(e *Event) error {
+	event, err := json.Marshal(sentryEventFrom(e))
+	if err != nil {
+		return err
+	}
+	header, _ := json.Marshal(map[string]string{"event_id": e.ID, "dsn": s.dsn, "sent_at": time.Now().UTC().Format(time.RFC3339)})
+	itemHeader, _ := json.Marshal(map[string]interface{}{"type": "event", "length": len(event)})
+
+	var body bytes.Buffer
+	for _, line := range [][]byte{header, itemHeader, event} {
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry responded %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sentry event payload (https://develop.sentry.dev/sdk/event-payloads/)
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	Logger      string            `json:"logger"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Exception   *sentryExceptions `json:"exception,omitempty"`
+	Request     *sentryRequest    `json:"request,omitempty"`
+	User        *sentryUser       `json:"user,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+type sentryRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+func sentryEventFrom(e *Event) sentryEvent {
+	errType := e.ErrorType
+	if errType == "" {
+		errType = "error"
+	}
+	out := sentryEvent{
+		EventID:     e.ID,
+		Timestamp:   e.Timestamp.Format(time.RFC3339Nano),
+		Level:       e.Level,
+		Platform:    "go",
+		Logger:      "errreport",
+		Release:     e.Release,
+		Environment: e.Environment,
+		ServerName:  e.ServerName,
+		Tags:        map[string]string{},
+	}
+	for k, v := range e.Tags {
+		out.Tags[k] = v
+	}
+
+	exception := sentryException{Type: errType, Value: e.Message}
+	if len(e.Stack) > 0 {
+		// Sentry lists frames outermost first
+		frames := make([]sentryFrame, len(e.Stack))
+		for i, f := range e.Stack {
+			frames[len(e.Stack)-1-i] = sentryFrame{
+				Function: f.Function,
+				Filename: shortFile(f.File),
+				AbsPath:  f.File,
+				Lineno:   f.Line,
+				InApp:    f.InApp,
+			}
+		}
+		exception.Stacktrace = &sentryStacktrace{Frames: frames}
+	}
+	out.Exception = &sentryExceptions{Values: []sentryException{exception}}
+
+	if r := e.Request; r != nil {
+		out.Transaction = strings.TrimSpace(r.Method + " " + r.Route)
+		out.Request = &sentryRequest{Method: r.Method, URL: r.Path}
+		if r.UserAgent != "" {
+			out.Request.Headers = map[string]string{"User-Agent": r.UserAgent}
+		}
+		if r.UserID != "" {
+			out.User = &sentryUser{ID: r.UserID}
+		}
+		if r.Route != "" {
+			out.Tags["route"] = r.Route
+		}
+		if r.Status != 0 {
+			out.Tags["status"] = fmt.Sprint(r.Status)
+		}
+		if r.RequestID != "" {
+			out.Tags["request_id"] = r.RequestID
+		}
+		if r.TraceID != "" {
+			out.Tags["trace_id"] = r.TraceID
+		}
+	}
+	return out
+}
+
+// shortFile keeps the last two path elements, e.g. v1/upload_handler.go
+func shortFile(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,49 @@
+package errreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// webhookSender posts each Event as JSON, for trackers and chat integrations
+// without a Sentry-compatible endpoint
+type webhookSender struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookSender(url string) *webhookSender {
+	return &webhookSender{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}},
+	}
+}
+
+func (s *webhookSender) name() string { return "webhook" }
+
+func (s *webhookSender) send(e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported locales
+const (
+	LocaleID = "id" // Bahasa Indonesia
+	LocaleEN = "en" // English
+	LocaleJA = "ja" // Japanese
+)
+
+// ContextKey is the gin context key holding the negotiated locale
+const ContextKey = "Locale"
+
+// SupportedLocales lists locales with a message catalog, in preference order for ties
+var SupportedLocales = []string{LocaleID, LocaleEN, LocaleJA}
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalogs maps locale → source message → translated message.
+// Source messages are the literal strings used in handlers and usecases
+// (English or Indonesian); a message is returned unchanged if it has no entry.
+var catalogs = map[string]map[string]string{}
+
+func init() {
+	for _, locale := range SupportedLocales {
+		data, err := localeFS.ReadFile("locales/" + locale + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing catalog for %s: %v", locale, err))
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog for %s: %v", locale, err))
+		}
+		catalogs[locale] = catalog
+	}
+}
+
+// IsSupported reports whether a catalog exists for locale
+func IsSupported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// T translates message into locale. Messages built as "<prefix>: <detail>"
+// are translated by prefix when the full message has no entry.
+// An empty or unsupported locale returns message unchanged.
+func T(locale, message string) string {
+	catalog, ok := catalogs[locale]
+	if !ok || message == "" {
+		return message
+	}
+
+	if translated, ok := catalog[message]; ok {
+		return translated
+	}
+
+	if idx := strings.Index(message, ": "); idx > 0 {
+		if translated, ok := catalog[message[:idx+2]]; ok {
+			return translated + message[idx+2:]
+		}
+	}
+	return message
+}
+
+// Negotiate picks the best supported locale from an Accept-Language header.
+// Returns "" when nothing matches so callers can keep source messages.
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		locale string
+		q      float64
+		order  int
+	}
+
+	var candidates []candidate
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		// "id-ID" → "id", "ja_JP" → "ja"
+		base := strings.ToLower(strings.TrimSpace(tag))
+		if idx := strings.IndexAny(base, "-_"); idx > 0 {
+			base = base[:idx]
+		}
+		if IsSupported(base) {
+			candidates = append(candidates, candidate{locale: base, q: q, order: i})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].q != candidates[b].q {
+			return candidates[a].q > candidates[b].q
+		}
+		return candidates[a].order < candidates[b].order
+	})
+	return candidates[0].locale
+}
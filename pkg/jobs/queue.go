@@ -0,0 +1,436 @@
+// Package jobs is a Postgres-backed background job queue. Jobs are rows in the
+// jobs table; workers claim them with FOR UPDATE SKIP LOCKED, so any number of
+// API instances can share one queue. Failed jobs are retried with exponential
+// backoff and end up dead (listed for admins) after their last attempt.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-recruitment-backend/pkg/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Job status
+const (
+	StatusQueued  = "queued"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusDead    = "dead" // out of attempts, or no handler for its type
+)
+
+var (
+	ErrNotFound = errors.New("job not found")
+	// ErrNotDead is returned when retrying a job that has not failed for good
+	ErrNotDead = errors.New("job is not dead")
+)
+
+// Job is one unit of background work
+type Job struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"` // next attempt, for queued jobs
+	LastError   *string         `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+// FinalAttempt reports whether a failure now makes the job dead
+func (j *Job) FinalAttempt() bool {
+	return j.Attempts >= j.MaxAttempts
+}
+
+// Handler runs one job; a returned error (or panic) schedules a retry
+type Handler func(ctx context.Context, job *Job) error
+
+// Config tunes the worker pool
+type Config struct {
+	Workers      int           // concurrent jobs per instance
+	PollInterval time.Duration // how often idle workers look for due jobs
+	MaxAttempts  int           // default attempts per job
+	BaseBackoff  time.Duration // delay before the first retry, doubled per attempt
+	MaxBackoff   time.Duration
+	JobTimeout   time.Duration // a running job is cancelled after this
+	// Jobs left running longer than JobTimeout+LeaseSlack (e.g. a crashed instance)
+	// are claimed again
+	LeaseSlack time.Duration
+	// Finished jobs are deleted after this
+	DoneRetention time.Duration
+}
+
+// DefaultConfig returns the defaults used for zero Config fields
+func DefaultConfig() Config {
+	return Config{
+		Workers:       4,
+		PollInterval:  2 * time.Second,
+		MaxAttempts:   5,
+		BaseBackoff:   30 * time.Second,
+		MaxBackoff:    1 * time.Hour,
+		JobTimeout:    15 * time.Minute,
+		LeaseSlack:    5 * time.Minute,
+		DoneRetention: 7 * 24 * time.Hour,
+	}
+}
+
+// Queue enqueues jobs and runs the registered handlers
+type Queue struct {
+	db       *pgxpool.Pool
+	cfg      Config
+	handlers map[string]Handler
+	mu       sync.RWMutex
+	wake     chan struct{}
+}
+
+// NewQueue creates a queue on the jobs table
+func NewQueue(db *pgxpool.Pool, cfg Config) *Queue {
+	def := DefaultConfig()
+	if cfg.Workers <= 0 {
+		cfg.Workers = def.Workers
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = def.PollInterval
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = def.MaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = def.BaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = def.MaxBackoff
+	}
+	if cfg.JobTimeout <= 0 {
+		cfg.JobTimeout = def.JobTimeout
+	}
+	if cfg.LeaseSlack <= 0 {
+		cfg.LeaseSlack = def.LeaseSlack
+	}
+	if cfg.DoneRetention <= 0 {
+		cfg.DoneRetention = def.DoneRetention
+	}
+	return &Queue{
+		db:       db,
+		cfg:      cfg,
+		handlers: map[string]Handler{},
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register sets the handler for a job type; register every type before Run
+func (q *Queue) Register(jobType string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = h
+}
+
+// Enqueue stores a job to run as soon as a worker is free. The payload is
+// stored as JSON and handed to the handler unchanged.
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload any) (int64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("encode %s job payload: %w", jobType, err)
+	}
+
+	var id int64
+	err = q.db.QueryRow(ctx, `
+		INSERT INTO jobs (type, payload, max_attempts)
+		VALUES ($1, $2, $3)
+		RETURNING id`, jobType, data, q.cfg.MaxAttempts,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Workers of this instance pick it up without waiting for the next poll
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return id, nil
+}
+
+// Run starts the workers and blocks until ctx is cancelled and running jobs finish
+func (q *Queue) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < q.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx)
+		}()
+	}
+
+	purge := time.NewTicker(time.Hour)
+	defer purge.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-purge.C:
+			if n, err := q.purgeDone(ctx); err != nil {
+				logger.Log.Error("Job queue: purge failed", "error", err)
+			} else if n > 0 {
+				logger.Log.Info("Job queue: purged finished jobs", "count", n)
+			}
+		}
+	}
+}
+
+// work runs due jobs back to back, then waits for the next poll or enqueue
+func (q *Queue) work(ctx context.Context) {
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			job, err := q.claim(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Log.Error("Job queue: claim failed", "error", err)
+				}
+				break
+			}
+			if job == nil {
+				break
+			}
+			q.execute(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// claim takes the oldest due job, or one whose worker stopped without finishing it
+func (q *Queue) claim(ctx context.Context) (*Job, error) {
+	job, err := scanJob(q.db.QueryRow(ctx, `
+		UPDATE jobs SET status = 'running', attempts = attempts + 1, locked_at = NOW(), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE (status = 'queued' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_at < NOW() - make_interval(secs => $1))
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns, (q.cfg.JobTimeout + q.cfg.LeaseSlack).Seconds()))
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return job, err
+}
+
+func (q *Queue) execute(ctx context.Context, job *Job) {
+	l := logger.Log.With("job_id", job.ID, "job_type", job.Type, "attempt", job.Attempts)
+
+	q.mu.RLock()
+	h, ok := q.handlers[job.Type]
+	q.mu.RUnlock()
+
+	var err error
+	if !ok {
+		// Nothing here can run it; retrying would not help
+		job.Attempts = job.MaxAttempts
+		err = fmt.Errorf("no handler for job type %q", job.Type)
+	} else {
+		err = q.runHandler(ctx, h, job)
+	}
+
+	// Record the outcome even when shutdown cancelled the job
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err == nil {
+		if _, serr := q.db.Exec(saveCtx, `
+			UPDATE jobs SET status = 'done', last_error = NULL, locked_at = NULL, finished_at = NOW(), updated_at = NOW()
+			WHERE id = $1`, job.ID); serr != nil {
+			l.Error("Job queue: failed to record success", "error", serr)
+		}
+		return
+	}
+
+	msg := err.Error()
+	if job.FinalAttempt() {
+		l.Error("Job queue: job failed for good", "error", err)
+		_, err = q.db.Exec(saveCtx, `
+			UPDATE jobs SET status = 'dead', attempts = $2, last_error = $3, locked_at = NULL, finished_at = NOW(), updated_at = NOW()
+			WHERE id = $1`, job.ID, job.Attempts, msg)
+	} else {
+		delay := Backoff(job.Attempts, q.cfg.BaseBackoff, q.cfg.MaxBackoff)
+		l.Warn("Job queue: job failed, retrying", "error", err, "retry_in", delay)
+		_, err = q.db.Exec(saveCtx, `
+			UPDATE jobs SET status = 'queued', last_error = $2, locked_at = NULL, run_at = NOW() + make_interval(secs => $3), updated_at = NOW()
+			WHERE id = $1`, job.ID, msg, delay.Seconds())
+	}
+	if err != nil {
+		l.Error("Job queue: failed to record failure", "error", err)
+	}
+}
+
+// runHandler calls h with the job timeout, turning a panic into an error
+func (q *Queue) runHandler(ctx context.Context, h Handler, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	jobCtx, cancel := context.WithTimeout(ctx, q.cfg.JobTimeout)
+	defer cancel()
+	return h(logger.With(jobCtx, "job_id", job.ID, "job_type", job.Type), job)
+}
+
+// Backoff is the delay before retrying after the given attempt: base doubled
+// per earlier attempt, capped at max
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+func (q *Queue) purgeDone(ctx context.Context) (int64, error) {
+	tag, err := q.db.Exec(ctx, `
+		DELETE FROM jobs WHERE status = 'done' AND finished_at < NOW() - make_interval(secs => $1)`, q.cfg.DoneRetention.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ListFilter selects jobs for the admin list
+type ListFilter struct {
+	Status   string // empty = every status
+	Type     string
+	Page     int
+	PageSize int
+}
+
+// List returns jobs newest first with the total count
+func (q *Queue) List(ctx context.Context, filter ListFilter) ([]Job, int64, error) {
+	where := " WHERE 1=1"
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Status != "" {
+		where += fmt.Sprintf(" AND status = $%d", argIndex)
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.Type != "" {
+		where += fmt.Sprintf(" AND type = $%d", argIndex)
+		args = append(args, filter.Type)
+		argIndex++
+	}
+
+	var total int64
+	if err := q.db.QueryRow(ctx, `SELECT COUNT(*) FROM jobs`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs` + where +
+		fmt.Sprintf(" ORDER BY updated_at DESC, id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	list := []Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		list = append(list, *job)
+	}
+	return list, total, rows.Err()
+}
+
+// TypeCount is the number of jobs of one type in one status
+type TypeCount struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+// Counts returns the number of jobs per type and status
+func (q *Queue) Counts(ctx context.Context) ([]TypeCount, error) {
+	rows, err := q.db.Query(ctx, `SELECT type, status, COUNT(*) FROM jobs GROUP BY type, status ORDER BY type, status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []TypeCount{}
+	for rows.Next() {
+		var c TypeCount
+		if err := rows.Scan(&c.Type, &c.Status, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// Retry queues a dead job again with a fresh set of attempts
+func (q *Queue) Retry(ctx context.Context, id int64) (*Job, error) {
+	job, err := scanJob(q.db.QueryRow(ctx, `
+		UPDATE jobs SET status = 'queued', attempts = 0, run_at = NOW(), finished_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'dead'
+		RETURNING `+jobColumns, id))
+	if errors.Is(err, ErrNotFound) {
+		var exists bool
+		if err := q.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM jobs WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrNotDead
+		}
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+const jobColumns = `id, type, payload, status, attempts, max_attempts, run_at, last_error, created_at, updated_at, finished_at`
+
+func scanJob(row pgx.Row) (*Job, error) {
+	var j Job
+	err := row.Scan(&j.ID, &j.Type, &j.Payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.RunAt, &j.LastError, &j.CreatedAt, &j.UpdatedAt, &j.FinishedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
@@ -0,0 +1,28 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 10*time.Minute
+
+	assert.Equal(t, 30*time.Second, Backoff(1, base, max))
+	assert.Equal(t, 60*time.Second, Backoff(2, base, max))
+	assert.Equal(t, 4*time.Minute, Backoff(4, base, max))
+	// Capped, including attempt counts that would overflow when doubled
+	assert.Equal(t, max, Backoff(6, base, max))
+	assert.Equal(t, max, Backoff(1000, base, max))
+	// Attempts start at 1; anything lower gets the base delay
+	assert.Equal(t, base, Backoff(0, base, max))
+}
+
+func TestFinalAttempt(t *testing.T) {
+	job := &Job{Attempts: 4, MaxAttempts: 5}
+	assert.False(t, job.FinalAttempt())
+	job.Attempts = 5
+	assert.True(t, job.FinalAttempt())
+}
@@ -1,16 +1,87 @@
 package logger
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 )
 
-var Log *slog.Logger
+// Log is the process-wide logger. Prefer FromContext inside request handling so
+// records carry the request and user IDs.
+var Log = slog.Default()
 
-func Init() {
+// Options configures Init
+type Options struct {
+	Format string // "json" (default) or "text"
+	Level  string // "debug", "info" (default), "warn" or "error"
+	// Sampling for noisy paths logged through Sampled: per key and level, the first
+	// SampleInitial records of every second are written, then one in SampleThereafter.
+	// SampleInitial 0 disables sampling.
+	SampleInitial    int
+	SampleThereafter int
+}
+
+var samples *sampler
+
+func Init(opts Options) {
+	Log = slog.New(newHandler(os.Stdout, opts))
+	samples = newSampler(opts.SampleInitial, opts.SampleThereafter)
+	slog.SetDefault(Log)
+}
+
+func newHandler(w io.Writer, opts Options) slog.Handler {
+	handlerOpts := &slog.HandlerOptions{Level: ParseLevel(opts.Level)}
+	if strings.EqualFold(opts.Format, "text") {
+		return slog.NewTextHandler(w, handlerOpts)
+	}
 	// JSON handler for production-ready logging
-	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-	})
-	Log = slog.New(handler)
+	return slog.NewJSONHandler(w, handlerOpts)
+}
+
+// ParseLevel maps a level name to a slog level; unknown names fall back to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying l
+func WithContext(ctx context.Context, l *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx by the request middleware, or Log
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+			return l
+		}
+	}
+	return Log
+}
+
+// With returns a copy of ctx whose logger adds the given attributes
+func With(ctx context.Context, args ...any) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(args...))
+}
+
+// Sampled returns the context logger with sampling applied under key. Use it for
+// paths that can log in bursts (failed logins, probing); errors are never dropped.
+func Sampled(ctx context.Context, key string) *slog.Logger {
+	l := FromContext(ctx)
+	if samples == nil {
+		return l
+	}
+	return slog.New(&sampledHandler{Handler: l.Handler(), key: key, sampler: samples})
 }
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// sampler counts records per key and level in one-second windows
+type sampler struct {
+	initial    int
+	thereafter int
+
+	mu     sync.Mutex
+	counts map[sampleKey]*sampleCount
+}
+
+type sampleKey struct {
+	key   string
+	level slog.Level
+}
+
+type sampleCount struct {
+	second int64
+	n      int
+}
+
+func newSampler(initial, thereafter int) *sampler {
+	if initial <= 0 {
+		return nil
+	}
+	return &sampler{initial: initial, thereafter: thereafter, counts: make(map[sampleKey]*sampleCount)}
+}
+
+func (s *sampler) allow(key string, r slog.Record) bool {
+	if r.Level >= slog.LevelError {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := sampleKey{key: key, level: r.Level}
+	c, ok := s.counts[k]
+	if !ok {
+		c = &sampleCount{}
+		s.counts[k] = c
+	}
+	if sec := r.Time.Unix(); c.second != sec {
+		c.second, c.n = sec, 0
+	}
+	c.n++
+	if c.n <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (c.n-s.initial)%s.thereafter == 0
+}
+
+// sampledHandler drops records the sampler does not allow
+type sampledHandler struct {
+	slog.Handler
+	key     string
+	sampler *sampler
+}
+
+func (h *sampledHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.sampler.allow(h.key, r) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *sampledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampledHandler{Handler: h.Handler.WithAttrs(attrs), key: h.key, sampler: h.sampler}
+}
+
+func (h *sampledHandler) WithGroup(name string) slog.Handler {
+	return &sampledHandler{Handler: h.Handler.WithGroup(name), key: h.key, sampler: h.sampler}
+}
@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Default is the registry served at /metrics
+var Default = NewRegistry()
+
+// Application metrics
+var (
+	HTTPRequests = Default.NewCounter("http_requests_total",
+		"HTTP requests by method, route and status code.", "method", "route", "status")
+	HTTPRequestDuration = Default.NewHistogram("http_request_duration_seconds",
+		"HTTP request latency by method and route.", DefaultBuckets, "method", "route")
+
+	DBQueryDuration = Default.NewHistogram("db_query_duration_seconds",
+		"Database query latency by statement type.", DefaultBuckets, "operation")
+	DBQueryErrors = Default.NewCounter("db_query_errors_total",
+		"Failed database queries by statement type.", "operation")
+
+	EmailsSent = Default.NewCounter("email_sent_total",
+		"Emails handed to the SMTP server.")
+	EmailSendFailures = Default.NewCounter("email_send_failures_total",
+		"Emails that could not be sent.")
+
+	SupabaseRequests = Default.NewCounter("supabase_requests_total",
+		"Requests to Supabase by service (auth, storage, ...) and status code; code is \"error\" when no response arrived.", "service", "code")
+	SupabaseErrors = Default.NewCounter("supabase_errors_total",
+		"Supabase requests that failed in transport or returned a 5xx.", "service")
+)
+
+// RegisterDBPool exposes the pool's connection statistics
+func RegisterDBPool(pool *pgxpool.Pool) {
+	Default.NewGaugeFunc("db_pool_acquired_connections", "Connections currently in use.", func() float64 {
+		return float64(pool.Stat().AcquiredConns())
+	})
+	Default.NewGaugeFunc("db_pool_idle_connections", "Idle connections.", func() float64 {
+		return float64(pool.Stat().IdleConns())
+	})
+	Default.NewGaugeFunc("db_pool_total_connections", "Open connections.", func() float64 {
+		return float64(pool.Stat().TotalConns())
+	})
+	Default.NewGaugeFunc("db_pool_max_connections", "Maximum pool size.", func() float64 {
+		return float64(pool.Stat().MaxConns())
+	})
+	Default.NewCounterFunc("db_pool_acquires_total", "Successful connection acquires.", func() float64 {
+		return float64(pool.Stat().AcquireCount())
+	})
+	Default.NewCounterFunc("db_pool_empty_acquires_total", "Acquires that had to wait for a connection.", func() float64 {
+		return float64(pool.Stat().EmptyAcquireCount())
+	})
+	Default.NewCounterFunc("db_pool_canceled_acquires_total", "Acquires cancelled by their context.", func() float64 {
+		return float64(pool.Stat().CanceledAcquireCount())
+	})
+	Default.NewCounterFunc("db_pool_acquire_duration_seconds_total", "Total time spent acquiring connections.", func() float64 {
+		return pool.Stat().AcquireDuration().Seconds()
+	})
+}
+
+// QueryTracer times every query on the pool, so it instruments all repositories
+// at once. next, if not nil, is traced as well (fault injection).
+func QueryTracer(next pgx.QueryTracer) pgx.QueryTracer {
+	return queryTracer{next: next}
+}
+
+type queryTracer struct {
+	next pgx.QueryTracer
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	at        time.Time
+	operation string
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx = context.WithValue(ctx, queryStartKey{}, queryStart{at: time.Now(), operation: sqlOperation(data.SQL)})
+	if t.next != nil {
+		ctx = t.next.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if start, ok := ctx.Value(queryStartKey{}).(queryStart); ok {
+		DBQueryDuration.Observe(time.Since(start.at).Seconds(), start.operation)
+		if data.Err != nil {
+			DBQueryErrors.Inc(start.operation)
+		}
+	}
+	if t.next != nil {
+		t.next.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+// sqlOperation is the statement's leading keyword (select, insert, with, ...)
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "other"
+	}
+	switch op := strings.ToLower(fields[0]); op {
+	case "select", "insert", "update", "delete", "with", "begin", "commit", "rollback":
+		return op
+	default:
+		return "other"
+	}
+}
+
+// SupabaseTransport counts requests to the Supabase project at host; other
+// hosts pass through untouched
+func SupabaseTransport(next http.RoundTripper, host string) http.RoundTripper {
+	if host == "" {
+		return next
+	}
+	return supabaseTransport{next: next, host: host}
+}
+
+type supabaseTransport struct {
+	next http.RoundTripper
+	host string
+}
+
+func (t supabaseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != t.host {
+		return t.next.RoundTrip(req)
+	}
+
+	// /auth/v1/token -> auth, /storage/v1/object -> storage
+	service, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+	switch service {
+	case "auth", "storage", "rest", "functions", "realtime":
+	default:
+		service = "other"
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		SupabaseRequests.Inc(service, "error")
+		SupabaseErrors.Inc(service)
+		return resp, err
+	}
+	SupabaseRequests.Inc(service, strconv.Itoa(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		SupabaseErrors.Inc(service)
+	}
+	return resp, nil
+}
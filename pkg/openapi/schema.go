@@ -0,0 +1,297 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is an OpenAPI 3.0 schema object (the subset this API needs)
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// File marks a multipart file part in a form DTO
+type File []byte
+
+var (
+	fileType       = reflect.TypeOf(File(nil))
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemaNameSanitizer strips what generic instantiations add to type names
+// ("PaginatedResult[go-recruitment-backend/internal/domain.AdminUser]")
+var schemaNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// schemas turns Go types into named component schemas. Struct types become
+// components referenced by $ref, so a DTO used by many operations is emitted once.
+type schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// of returns the schema for t, registering named structs as components
+func (s *schemas) of(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	schema := s.inline(t)
+	if nullable {
+		if schema.Ref != "" {
+			// $ref siblings are ignored in 3.0, so nullability needs a wrapper
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+	}
+	return schema
+}
+
+func (s *schemas) inline(t reflect.Type) *Schema {
+	switch t {
+	case fileType:
+		return &Schema{Type: "string", Format: "binary"}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return &Schema{} // custom JSON encoding; the Go type says nothing about its shape
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + s.component(t)}
+	default:
+		return &Schema{} // interface{} and friends: any JSON value
+	}
+}
+
+// component registers t under a unique name and returns that name
+func (s *schemas) component(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+
+	name := schemaNameSanitizer.ReplaceAllString(t.Name(), "")
+	if i := strings.IndexByte(t.Name(), '['); i >= 0 {
+		// PaginatedResult[...domain.AdminUser] -> PaginatedResultAdminUser
+		args := t.Name()[i+1 : len(t.Name())-1]
+		name = t.Name()[:i]
+		for _, arg := range strings.Split(args, ",") {
+			if dot := strings.LastIndexByte(arg, '.'); dot >= 0 {
+				arg = arg[dot+1:]
+			}
+			name += schemaNameSanitizer.ReplaceAllString(arg, "")
+		}
+	}
+	if _, taken := s.components[name]; taken {
+		// Same type name in two packages (v1.X and domain.X)
+		pkg := t.PkgPath()[strings.LastIndexByte(t.PkgPath(), '/')+1:]
+		base := strings.ToUpper(pkg[:1]) + pkg[1:] + name
+		name = base
+		for i := 2; s.components[name] != nil; i++ {
+			name = base + strconv.Itoa(i)
+		}
+	}
+
+	s.names[t] = name
+	s.components[name] = &Schema{} // placeholder so recursive types terminate
+	s.components[name] = s.object(t)
+	return name
+}
+
+// object builds the schema of a struct from its json tags, following
+// encoding/json rules for embedded structs, and binding/validate tags for
+// required fields and simple constraints
+func (s *schemas) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s.addFields(schema, t)
+	return schema
+}
+
+func (s *schemas) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.addFields(schema, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := s.of(f.Type)
+		if strings.Contains(opts, "string") && prop.Ref == "" {
+			prop = &Schema{Type: "string", Nullable: prop.Nullable}
+		}
+		rules := f.Tag.Get("binding")
+		if rules == "" {
+			rules = f.Tag.Get("validate")
+		}
+		if applyRules(prop, rules) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+}
+
+// applyRules maps validator rules onto prop and reports whether the field is required.
+// Only top-level rules are applied; "dive" rules describe slice elements.
+func applyRules(prop *Schema, rules string) bool {
+	if rules == "" {
+		return false
+	}
+	if i := strings.Index(rules, ",dive"); i >= 0 {
+		rules = rules[:i]
+	}
+
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		key, param, _ := strings.Cut(rule, "=")
+		if key == "required" {
+			required = true
+			continue
+		}
+		if prop.Ref != "" || len(prop.AllOf) > 0 {
+			continue // constraints on a referenced struct belong to its own fields
+		}
+		switch key {
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				prop.Enum = append(prop.Enum, enumValue(prop.Type, v))
+			}
+		case "email":
+			prop.Format = "email"
+		case "url", "http_url":
+			prop.Format = "uri"
+		case "uuid", "uuid4":
+			prop.Format = "uuid"
+		case "min", "gte", "max", "lte", "gt", "lt", "len":
+			applyBound(prop, key, param)
+		}
+	}
+	return required
+}
+
+func applyBound(prop *Schema, key, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return // field comparisons (gtefield=...) have no schema equivalent
+	}
+	lower := key == "min" || key == "gte" || key == "gt" || key == "len"
+	upper := key == "max" || key == "lte" || key == "lt" || key == "len"
+	count := int(n)
+
+	switch prop.Type {
+	case "string":
+		if lower {
+			prop.MinLength = &count
+		}
+		if upper {
+			prop.MaxLength = &count
+		}
+	case "array":
+		if lower {
+			prop.MinItems = &count
+		}
+		if upper {
+			prop.MaxItems = &count
+		}
+	case "integer", "number":
+		if lower {
+			prop.Minimum = &n
+			prop.ExclusiveMinimum = key == "gt"
+		}
+		if upper {
+			prop.Maximum = &n
+			prop.ExclusiveMaximum = key == "lt"
+		}
+	}
+}
+
+func enumValue(typ, v string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
@@ -0,0 +1,332 @@
+// Package openapi builds an OpenAPI 3.0 document from the routes registered on
+// the gin engine and the Go DTO types they bind and return. Schemas come from
+// reflection over json and binding tags, so the spec cannot drift from the
+// structs the handlers actually use, and every operation gets a stable
+// operationId that client SDK generators can rely on.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3.0 document
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem maps lower-case HTTP methods to operations
+type PathItem map[string]*Operation
+
+// SecurityRequirement maps a security scheme name to its scopes
+type SecurityRequirement map[string][]string
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	// Security overrides the document default; an empty list marks a public operation
+	Security *[]SecurityRequirement `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Route is a registered route; gin.RouteInfo converts directly
+type Route struct {
+	Method  string
+	Path    string
+	Handler string // fully qualified handler name, used to derive the operationId
+}
+
+// Op documents one route. Zero values fall back to what the route itself says:
+// the operationId comes from the handler name and the response data is untyped.
+type Op struct {
+	ID      string
+	Summary string
+	Public  bool        // no bearer token required
+	Body    interface{} // zero value of the request body type (JSON unless Form)
+	Form    bool        // Body is multipart/form-data; its fields are form parts
+	// OptionalBody marks Body as optional (e.g. the token can come from a cookie instead)
+	OptionalBody bool
+	Query        interface{} // zero value of a struct whose `form` tags are query parameters
+	Data         interface{} // zero value of the envelope's data payload type
+	Status       int         // success status, default 200
+	Content      string      // non-JSON success media type (file downloads, PDFs)
+}
+
+// Config describes the document being built
+type Config struct {
+	Title       string
+	Description string
+	Version     string
+	BasePath    string      // server URL prefix stripped from route paths, e.g. "/v1"
+	Envelope    interface{} // zero value of the response envelope (response.Response)
+	DataField   string      // envelope field that carries the payload
+	ErrorCodes  []int       // documented failure statuses, all returning the envelope
+	// Skip excludes routes (documentation UIs, non-public surfaces)
+	Skip func(Route) bool
+}
+
+// Build generates the document for routes, documenting each with ops[method+" "+path] when present
+func Build(cfg Config, routes []Route, ops map[string]Op) (*Document, error) {
+	s := newSchemas()
+	envelope := s.of(reflect.TypeOf(cfg.Envelope))
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: cfg.Title, Description: cfg.Description, Version: cfg.Version},
+		Servers: []Server{{URL: cfg.BasePath}},
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: s.components,
+			SecuritySchemes: map[string]SecurityScheme{
+				"BearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+		Security: []SecurityRequirement{{"BearerAuth": {}}},
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	used := map[string]bool{}
+	seenIDs := map[string]string{}
+	seenTags := map[string]bool{}
+	for _, route := range routes {
+		if route.Method == http.MethodHead || (cfg.Skip != nil && cfg.Skip(route)) {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		op, documented := ops[key]
+		if documented {
+			used[key] = true
+		}
+
+		path, params := convertPath(strings.TrimPrefix(route.Path, cfg.BasePath))
+		operation := &Operation{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Parameters:  params,
+			Responses:   map[string]*Response{},
+		}
+		if operation.OperationID == "" {
+			operation.OperationID = operationID(route.Handler)
+		}
+		if other, dup := seenIDs[operation.OperationID]; dup {
+			return nil, fmt.Errorf("openapi: operationId %q used by %s and %s", operation.OperationID, other, key)
+		}
+		seenIDs[operation.OperationID] = key
+
+		if tag := firstSegment(path); tag != "" {
+			operation.Tags = []string{tag}
+			seenTags[tag] = true
+		}
+		if op.Public {
+			operation.Security = &[]SecurityRequirement{}
+		}
+		if op.Query != nil {
+			operation.Parameters = append(operation.Parameters, queryParams(s, reflect.TypeOf(op.Query))...)
+		}
+		if op.Body != nil {
+			media := "application/json"
+			if op.Form {
+				media = "multipart/form-data"
+			}
+			operation.RequestBody = &RequestBody{
+				Required: !op.OptionalBody,
+				Content:  map[string]MediaType{media: {Schema: s.of(reflect.TypeOf(op.Body))}},
+			}
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		success := &Response{Description: http.StatusText(status)}
+		switch {
+		case op.Content != "":
+			success.Content = map[string]MediaType{op.Content: {Schema: &Schema{Type: "string", Format: "binary"}}}
+		case op.Data != nil:
+			data := &Schema{Type: "object", Properties: map[string]*Schema{cfg.DataField: s.of(reflect.TypeOf(op.Data))}}
+			success.Content = jsonContent(&Schema{AllOf: []*Schema{envelope, data}})
+		default:
+			success.Content = jsonContent(envelope)
+		}
+		operation.Responses[fmt.Sprint(status)] = success
+		for _, code := range cfg.ErrorCodes {
+			if code == http.StatusUnauthorized && op.Public {
+				continue
+			}
+			operation.Responses[fmt.Sprint(code)] = &Response{Description: http.StatusText(code), Content: jsonContent(envelope)}
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	for key := range ops {
+		if !used[key] {
+			return nil, fmt.Errorf("openapi: %s is documented but not routed", key)
+		}
+	}
+
+	for tag := range seenTags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	return doc, nil
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// convertPath turns gin's /jobs/:id and /files/*path into /jobs/{id} and /files/{path}
+func convertPath(path string) (string, []Parameter) {
+	var params []Parameter
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if seg == "" || (seg[0] != ':' && seg[0] != '*') {
+			continue
+		}
+		name := seg[1:]
+		segments[i] = "{" + name + "}"
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func firstSegment(path string) string {
+	seg, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	seg, _, _ = strings.Cut(seg, ".")
+	if strings.HasPrefix(seg, "{") {
+		return ""
+	}
+	return seg
+}
+
+// operationID derives "jobCreate" from "go-recruitment-backend/internal/delivery/http/v1.(*JobHandler).Create-fm"
+// and "atsSearchCandidates" from "(*ATSHandler).SearchCandidates-fm"
+func operationID(handler string) string {
+	name := handler[strings.LastIndexByte(handler, '/')+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	if _, rest, ok := strings.Cut(name, "."); ok {
+		name = rest
+	}
+	name = strings.NewReplacer("(*", "", "Handler).", ".", ")", "").Replace(name)
+
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper && b.Len() > 0 {
+			r = unicode.ToUpper(r)
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return lowerInitialism(b.String())
+}
+
+// lowerInitialism lower-cases the leading word, including a whole initialism ("CVParse" → "cvParse")
+func lowerInitialism(s string) string {
+	runes := []rune(s)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// queryParams turns the `form`-tagged fields of a struct into query parameters
+func queryParams(s *schemas, t reflect.Type) []Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema := s.of(f.Type)
+		rules := f.Tag.Get("binding")
+		params = append(params, Parameter{Name: name, In: "query", Required: applyRules(schema, rules), Schema: schema})
+	}
+	return params
+}
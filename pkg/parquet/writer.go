@@ -0,0 +1,392 @@
+// Package parquet writes flat, single-row-group Apache Parquet files using only
+// the standard library. It supports the handful of column types needed for
+// analyst exports (int64, millisecond timestamps, UTF-8 and JSON strings) with
+// PLAIN encoding, RLE definition levels, and GZIP page compression.
+//
+// Files are readable by pyarrow, pandas, DuckDB, and Spark.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// ColumnType is the logical type of a column
+type ColumnType int
+
+const (
+	Int64           ColumnType = iota // INT64
+	TimestampMillis                   // INT64 annotated TIMESTAMP_MILLIS (UTC)
+	String                            // BYTE_ARRAY annotated UTF8
+	JSON                              // BYTE_ARRAY annotated JSON
+)
+
+// Column describes one leaf column of a flat schema
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Optional bool // nil values are allowed and stored as nulls
+}
+
+// Parquet physical types, encodings and codecs (parquet.thrift)
+const (
+	physicalInt64     = 2
+	physicalByteArray = 6
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+	convertedJSON            = 19
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecGzip = 2
+
+	pageTypeData = 0
+)
+
+var magic = []byte("PAR1")
+
+// Encode writes rows as a Parquet file. Each row must have one value per column:
+// int64 for Int64, time.Time for TimestampMillis, string or []byte for String/JSON,
+// or nil for optional columns.
+func Encode(columns []Column, rows [][]interface{}, createdBy string) ([]byte, error) {
+	var out bytes.Buffer
+	out.Write(magic)
+
+	chunks := make([]columnChunk, len(columns))
+	for i, col := range columns {
+		offset := int64(out.Len())
+		chunk, err := writeColumn(&out, col, i, rows)
+		if err != nil {
+			return nil, fmt.Errorf("parquet: column %q: %w", col.Name, err)
+		}
+		chunk.offset = offset
+		chunks[i] = chunk
+	}
+
+	footer := encodeFileMetaData(columns, chunks, int64(len(rows)), createdBy)
+	out.Write(footer)
+
+	var footerLen [4]byte
+	binary.LittleEndian.PutUint32(footerLen[:], uint32(len(footer)))
+	out.Write(footerLen[:])
+	out.Write(magic)
+
+	return out.Bytes(), nil
+}
+
+type columnChunk struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+// writeColumn writes a single data page holding every value of the column
+func writeColumn(out *bytes.Buffer, col Column, index int, rows [][]interface{}) (columnChunk, error) {
+	var raw bytes.Buffer
+
+	// 1. Definition levels (optional columns only; max level 1)
+	values := make([]interface{}, 0, len(rows))
+	defined := make([]bool, len(rows))
+	for r, row := range rows {
+		if index >= len(row) {
+			return columnChunk{}, fmt.Errorf("row %d has %d values", r, len(row))
+		}
+		v := row[index]
+		if v == nil {
+			if !col.Optional {
+				return columnChunk{}, fmt.Errorf("row %d: nil value in required column", r)
+			}
+			continue
+		}
+		defined[r] = true
+		values = append(values, v)
+	}
+	if col.Optional {
+		levels := encodeDefinitionLevels(defined)
+		var n [4]byte
+		binary.LittleEndian.PutUint32(n[:], uint32(len(levels)))
+		raw.Write(n[:])
+		raw.Write(levels)
+	}
+
+	// 2. PLAIN values
+	for r, v := range values {
+		if err := writePlainValue(&raw, col.Type, v); err != nil {
+			return columnChunk{}, fmt.Errorf("value %d: %w", r, err)
+		}
+	}
+
+	// 3. Compress page
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(raw.Bytes()); err != nil {
+		return columnChunk{}, err
+	}
+	if err := zw.Close(); err != nil {
+		return columnChunk{}, err
+	}
+
+	header := encodePageHeader(raw.Len(), compressed.Len(), len(rows))
+	out.Write(header)
+	out.Write(compressed.Bytes())
+
+	return columnChunk{
+		uncompressedSize: int64(len(header) + raw.Len()),
+		compressedSize:   int64(len(header) + compressed.Len()),
+	}, nil
+}
+
+func writePlainValue(buf *bytes.Buffer, t ColumnType, v interface{}) error {
+	switch t {
+	case Int64:
+		n, ok := v.(int64)
+		if !ok {
+			return fmt.Errorf("expected int64, got %T", v)
+		}
+		return binary.Write(buf, binary.LittleEndian, n)
+	case TimestampMillis:
+		ts, ok := v.(time.Time)
+		if !ok {
+			return fmt.Errorf("expected time.Time, got %T", v)
+		}
+		return binary.Write(buf, binary.LittleEndian, ts.UnixMilli())
+	case String, JSON:
+		var b []byte
+		switch s := v.(type) {
+		case string:
+			b = []byte(s)
+		case []byte:
+			b = s
+		default:
+			return fmt.Errorf("expected string, got %T", v)
+		}
+		if err := binary.Write(buf, binary.LittleEndian, uint32(len(b))); err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+	return fmt.Errorf("unsupported column type %d", t)
+}
+
+// encodeDefinitionLevels encodes 0/1 levels with the RLE/bit-packed hybrid
+// encoding using RLE runs only (bit width 1, one byte per run value)
+func encodeDefinitionLevels(defined []bool) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		writeUvarint(&buf, uint64(j-i)<<1)
+		if defined[i] {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		i = j
+	}
+	return buf.Bytes()
+}
+
+func schemaTypes(t ColumnType) (physical, converted int32) {
+	switch t {
+	case Int64:
+		return physicalInt64, -1
+	case TimestampMillis:
+		return physicalInt64, convertedTimestampMillis
+	case JSON:
+		return physicalByteArray, convertedJSON
+	default:
+		return physicalByteArray, convertedUTF8
+	}
+}
+
+// ============================================================================
+// Thrift compact protocol structures
+// ============================================================================
+
+func encodePageHeader(uncompressed, compressed, numValues int) []byte {
+	w := &compactWriter{}
+	w.i32Field(1, pageTypeData)
+	w.i32Field(2, int32(uncompressed))
+	w.i32Field(3, int32(compressed))
+	w.structField(5, func() {
+		w.i32Field(1, int32(numValues))
+		w.i32Field(2, encodingPlain)
+		w.i32Field(3, encodingRLE)
+		w.i32Field(4, encodingRLE)
+	})
+	w.stop()
+	return w.buf.Bytes()
+}
+
+func encodeFileMetaData(columns []Column, chunks []columnChunk, numRows int64, createdBy string) []byte {
+	w := &compactWriter{}
+	w.i32Field(1, 1) // version
+
+	// 2: schema (root + leaves)
+	w.listField(2, compactStruct, len(columns)+1, func(i int) {
+		w.structElem(func() {
+			if i == 0 {
+				w.binaryField(4, "schema")
+				w.i32Field(5, int32(len(columns)))
+				return
+			}
+			col := columns[i-1]
+			physical, converted := schemaTypes(col.Type)
+			repetition := int32(repetitionRequired)
+			if col.Optional {
+				repetition = repetitionOptional
+			}
+			w.i32Field(1, physical)
+			w.i32Field(3, repetition)
+			w.binaryField(4, col.Name)
+			if converted >= 0 {
+				w.i32Field(6, converted)
+			}
+		})
+	})
+
+	w.i64Field(3, numRows)
+
+	// 4: row_groups (exactly one)
+	var totalSize int64
+	for _, c := range chunks {
+		totalSize += c.uncompressedSize
+	}
+	w.listField(4, compactStruct, 1, func(int) {
+		w.structElem(func() {
+			w.listField(1, compactStruct, len(columns), func(i int) {
+				col, chunk := columns[i], chunks[i]
+				physical, _ := schemaTypes(col.Type)
+				w.structElem(func() {
+					w.i64Field(2, chunk.offset)
+					w.structField(3, func() {
+						w.i32Field(1, physical)
+						w.listField(2, compactI32, 2, func(j int) {
+							if j == 0 {
+								w.varint(encodingPlain)
+							} else {
+								w.varint(encodingRLE)
+							}
+						})
+						w.listField(3, compactBinary, 1, func(int) { w.binary(col.Name) })
+						w.i32Field(4, codecGzip)
+						w.i64Field(5, numRows)
+						w.i64Field(6, chunk.uncompressedSize)
+						w.i64Field(7, chunk.compressedSize)
+						w.i64Field(9, chunk.offset)
+					})
+				})
+			})
+			w.i64Field(2, totalSize)
+			w.i64Field(3, numRows)
+		})
+	})
+
+	if createdBy != "" {
+		w.binaryField(6, createdBy)
+	}
+	w.stop()
+	return w.buf.Bytes()
+}
+
+// Compact protocol type ids
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// compactWriter is a minimal Thrift compact protocol encoder with nested
+// struct support (field ids are tracked per struct level)
+type compactWriter struct {
+	buf     bytes.Buffer
+	lastIDs []int16
+	lastID  int16
+}
+
+func (w *compactWriter) fieldHeader(id int16, typ byte) {
+	delta := id - w.lastID
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	w.lastID = id
+}
+
+func (w *compactWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, compactI32)
+	w.varint(int64(v))
+}
+
+func (w *compactWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, compactI64)
+	w.varint(v)
+}
+
+func (w *compactWriter) binaryField(id int16, s string) {
+	w.fieldHeader(id, compactBinary)
+	w.binary(s)
+}
+
+func (w *compactWriter) structField(id int16, body func()) {
+	w.fieldHeader(id, compactStruct)
+	w.structElem(body)
+}
+
+// structElem writes a struct body (used directly for list elements)
+func (w *compactWriter) structElem(body func()) {
+	w.lastIDs = append(w.lastIDs, w.lastID)
+	w.lastID = 0
+	body()
+	w.stop()
+	w.lastID = w.lastIDs[len(w.lastIDs)-1]
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *compactWriter) listField(id int16, elemType byte, size int, elem func(i int)) {
+	w.fieldHeader(id, compactList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xF0 | elemType)
+		writeUvarint(&w.buf, uint64(size))
+	}
+	for i := 0; i < size; i++ {
+		elem(i)
+	}
+}
+
+func (w *compactWriter) stop() {
+	w.buf.WriteByte(0)
+}
+
+// varint writes a zigzag-encoded signed integer
+func (w *compactWriter) varint(v int64) {
+	writeUvarint(&w.buf, uint64((v<<1)^(v>>63)))
+}
+
+func (w *compactWriter) binary(s string) {
+	writeUvarint(&w.buf, uint64(len(s)))
+	w.buf.WriteString(s)
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
@@ -0,0 +1,360 @@
+// Package pdf writes simple A4 PDF documents using only the standard library.
+// It supports headings, labeled fields and wrapped paragraphs in the built-in
+// Helvetica fonts with automatic page breaks, and photos (embedded as JPEG).
+//
+// Text is encoded as WinAnsi (Windows-1252); characters outside Latin-1 are
+// replaced with '?'.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // Photo accepts PNG too
+	"io"
+	"strings"
+	"time"
+)
+
+// Page geometry in points (A4)
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 50.0
+	textWidth  = pageWidth - 2*margin
+)
+
+// Font sizes
+const (
+	sizeHeading    = 18.0
+	sizeSubheading = 12.0
+	sizeBody       = 10.0
+	sizeSmall      = 8.0
+)
+
+const (
+	fontRegular = "F1" // Helvetica
+	fontBold    = "F2" // Helvetica-Bold
+)
+
+// Document is a PDF being built top to bottom
+type Document struct {
+	title  string
+	pages  []*bytes.Buffer
+	y      float64 // baseline of the next line, from the bottom of the page
+	footer string
+	images []embeddedImage
+
+	// A photo on the right narrows text lines until y passes its bottom
+	besideBottom float64
+	besideWidth  float64
+}
+
+// embeddedImage is a JPEG image XObject
+type embeddedImage struct {
+	data          []byte
+	width, height int
+	colorSpace    string
+}
+
+// New starts a document; title is stored in the document info
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.newPage()
+	return d
+}
+
+// SetFooter sets a line printed at the bottom of every page
+func (d *Document) SetFooter(text string) {
+	d.footer = text
+}
+
+// Heading writes a large bold line
+func (d *Document) Heading(text string) {
+	d.writeWrapped(fontBold, sizeHeading, text, 0, nil)
+	d.Space(4)
+}
+
+// Subheading writes a bold section title
+func (d *Document) Subheading(text string) {
+	d.Space(8)
+	d.writeWrapped(fontBold, sizeSubheading, text, 0, nil)
+	d.Space(2)
+}
+
+// Paragraph writes wrapped body text; newlines start new lines
+func (d *Document) Paragraph(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		d.writeWrapped(fontRegular, sizeBody, line, 0, nil)
+	}
+}
+
+// Bullet writes an indented, wrapped list item
+func (d *Document) Bullet(text string) {
+	d.writeWrapped(fontRegular, sizeBody, text, 16, &lead{font: fontRegular, text: "-", x: margin + 6})
+}
+
+// Field writes "Label: value"; nothing is written when value is empty
+func (d *Document) Field(label, value string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+	prefix := label + ": "
+	d.writeWrapped(fontRegular, sizeBody, value, textWidthOf(prefix, sizeBody, true), &lead{font: fontBold, text: prefix, x: margin})
+}
+
+// Note writes a small line of text
+func (d *Document) Note(text string) {
+	d.writeWrapped(fontRegular, sizeSmall, text, 0, nil)
+}
+
+// Space adds vertical space
+func (d *Document) Space(points float64) {
+	d.y -= points
+}
+
+// Photo draws an image in the top-right corner of the space below the last
+// line, width points wide with its aspect ratio kept. Lines written beside it
+// wrap short of it. JPEGs are embedded as they are; other images (PNG, CMYK
+// JPEG) are re-encoded as JPEG.
+func (d *Document) Photo(data []byte, width float64) error {
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.ColorModel == color.CMYKModel {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("pdf: unsupported photo: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return fmt.Errorf("pdf: re-encode photo: %w", err)
+		}
+		return d.Photo(buf.Bytes(), width)
+	}
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return fmt.Errorf("pdf: empty photo")
+	}
+
+	colorSpace := "DeviceRGB"
+	if cfg.ColorModel == color.GrayModel {
+		colorSpace = "DeviceGray"
+	}
+	d.images = append(d.images, embeddedImage{data: data, width: cfg.Width, height: cfg.Height, colorSpace: colorSpace})
+
+	height := width * float64(cfg.Height) / float64(cfg.Width)
+	d.ensureSpace(height)
+	x := pageWidth - margin - width
+	fmt.Fprintf(d.pages[len(d.pages)-1], "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", width, height, x, d.y-height, len(d.images))
+	d.besideBottom = d.y - height
+	d.besideWidth = width + 12
+	return nil
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	d.WriteTo(&out) // bytes.Buffer writes do not fail
+	return out.Bytes()
+}
+
+// WriteTo renders the document to w, keeping only one object in memory at a time
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	out := &countingWriter{w: w}
+	var offsets []int64
+	obj := func(body string) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	io.WriteString(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; each page then takes a page and a content object,
+	// and images follow the pages
+	const firstPage = 6
+	firstImage := firstPage + 2*len(d.pages)
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	var xobjects strings.Builder
+	for i := range d.images {
+		fmt.Fprintf(&xobjects, " /Im%d %d 0 R", i+1, firstImage+i)
+	}
+	resources := "/Font << /F1 3 0 R /F2 4 0 R >>"
+	if xobjects.Len() > 0 {
+		resources += " /XObject <<" + xobjects.String() + " >>"
+	}
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (jexr-api) /CreationDate (D:%s) >>",
+		escape(d.title), time.Now().UTC().Format("20060102150405Z")))
+
+	for i, page := range d.pages {
+		content := page.Bytes()
+		if d.footer != "" {
+			var f bytes.Buffer
+			writeText(&f, fontRegular, sizeSmall, margin, margin/2, fmt.Sprintf("%s - %d/%d", d.footer, i+1, len(d.pages)))
+			content = append(append([]byte{}, content...), f.Bytes()...)
+		}
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << %s >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, resources, firstPage+2*i+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+	for _, img := range d.images {
+		obj(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>\nstream\n%s\nendstream",
+			img.width, img.height, img.colorSpace, len(img.data), img.data))
+	}
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.n, out.err
+}
+
+// countingWriter tracks the byte offset for the xref table and keeps the first error
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+	d.besideBottom, d.besideWidth = 0, 0
+}
+
+// ensureSpace starts a new page when a line of the given height does not fit
+func (d *Document) ensureSpace(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+}
+
+// lead is text drawn on the first line before wrapped text (a label or bullet)
+type lead struct {
+	font string
+	text string
+	x    float64
+}
+
+// writeWrapped writes text indent points from the margin, wrapping every line to
+// the same indent; l, if set, is drawn at the baseline of the first line
+func (d *Document) writeWrapped(font string, size float64, text string, indent float64, l *lead) {
+	lineHeight := size * 1.4
+	bold := font == fontBold
+	width := textWidth - indent
+	if d.y > d.besideBottom {
+		width -= d.besideWidth
+	}
+	for i, line := range wrap(text, width, size, bold) {
+		d.ensureSpace(lineHeight)
+		d.y -= size
+		page := d.pages[len(d.pages)-1]
+		if i == 0 && l != nil {
+			writeText(page, l.font, size, l.x, d.y, l.text)
+		}
+		writeText(page, font, size, margin+indent, d.y, line)
+		d.y -= lineHeight - size
+	}
+}
+
+func writeText(buf *bytes.Buffer, font string, size, x, y float64, s string) {
+	fmt.Fprintf(buf, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(s))
+}
+
+// wrap splits text into lines no wider than width; an empty text is one empty line
+func wrap(text string, width, size float64, bold bool) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	line := ""
+	for _, word := range words {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && textWidthOf(candidate, size, bold) > width {
+			lines = append(lines, line)
+			candidate = word
+		}
+		// A single word wider than the line is broken by characters
+		for textWidthOf(candidate, size, bold) > width && len([]rune(candidate)) > 1 {
+			runes := []rune(candidate)
+			n := len(runes) - 1
+			for n > 1 && textWidthOf(string(runes[:n]), size, bold) > width {
+				n--
+			}
+			lines = append(lines, string(runes[:n]))
+			candidate = string(runes[n:])
+		}
+		line = candidate
+	}
+	return append(lines, line)
+}
+
+// textWidthOf measures text in points using Helvetica metrics; bold is approximated
+func textWidthOf(s string, size float64, bold bool) float64 {
+	units := 0
+	for _, r := range s {
+		if r >= 32 && r < 127 {
+			units += helveticaWidths[r-32]
+		} else {
+			units += 556
+		}
+	}
+	w := float64(units) * size / 1000
+	if bold {
+		w *= 1.08
+	}
+	return w
+}
+
+// escape encodes s as WinAnsi and escapes PDF string delimiters
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r == '\t':
+			b.WriteByte(' ')
+		case r >= 32 && r < 127, r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// helveticaWidths are the Helvetica glyph widths for ASCII 32-126 (1/1000 em)
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
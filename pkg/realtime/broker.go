@@ -0,0 +1,92 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Event is one message pushed to clients, sent as a JSON text message
+type Event struct {
+	Type       string    `json:"type"`
+	Data       any       `json:"data,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Broker delivers events to a user's connections. Delivery is best-effort: a
+// user with no open connection misses the event.
+type Broker interface {
+	Publish(ctx context.Context, userID string, event Event) error
+}
+
+// Publish delivers to connections on this instance only, which is enough for a
+// single-instance deployment
+func (h *Hub) Publish(_ context.Context, userID string, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	h.Deliver(userID, payload)
+	return nil
+}
+
+// redisChannel carries events between instances
+const redisChannel = "realtime:events"
+
+// RedisBroker fans events out through Redis pub/sub, so a user connected to
+// another instance still receives them. Every instance must call Run.
+type RedisBroker struct {
+	client *redis.Client
+	hub    *Hub
+}
+
+// NewRedisBroker creates a broker that publishes through client and delivers to hub
+func NewRedisBroker(client *redis.Client, hub *Hub) *RedisBroker {
+	return &RedisBroker{client: client, hub: hub}
+}
+
+type envelope struct {
+	UserID string          `json:"user_id"`
+	Event  json.RawMessage `json:"event"`
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, userID string, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	msg, err := json.Marshal(envelope{UserID: userID, Event: payload})
+	if err != nil {
+		return err
+	}
+	return b.client.Publish(ctx, redisChannel, msg).Err()
+}
+
+// Run subscribes to the channel and delivers events to this instance's hub until
+// ctx is cancelled. It returns an error only when the subscription fails.
+func (b *RedisBroker) Run(ctx context.Context) error {
+	sub := b.client.Subscribe(ctx, redisChannel)
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			var env envelope
+			if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil || env.UserID == "" {
+				continue
+			}
+			b.hub.Deliver(env.UserID, env.Event)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package realtime
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	writeWait  = 10 * time.Second  // time allowed to write one frame
+	pongWait   = 60 * time.Second  // a client that sends nothing (not even a pong) for this long is dropped
+	pingPeriod = pongWait * 9 / 10 // must be shorter than pongWait
+	sendBuffer = 16                // queued messages per connection before it counts as too slow
+	maxPerUser = 5                 // connections per user (tabs and devices)
+)
+
+// Hub holds the WebSocket connections of this instance, grouped by user
+type Hub struct {
+	mu      sync.RWMutex
+	clients map[string]map[*client]struct{}
+	closed  bool
+}
+
+type client struct {
+	conn *conn
+	send chan []byte
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{clients: make(map[string]map[*client]struct{})}
+}
+
+// Serve upgrades the request and streams messages delivered for userID until the
+// client disconnects or the hub is closed. It returns ErrBadHandshake, with
+// nothing written yet, when r is not a WebSocket handshake.
+func (h *Hub) Serve(w http.ResponseWriter, r *http.Request, userID string) error {
+	c, err := upgrade(w, r)
+	if err != nil {
+		return err
+	}
+	cl := &client{conn: c, send: make(chan []byte, sendBuffer), done: make(chan struct{})}
+
+	if err := h.register(userID, cl); err != nil {
+		c.writeClose(closePolicyViolation, err.Error())
+		c.close()
+		return err
+	}
+	defer h.unregister(userID, cl)
+
+	go cl.readLoop()
+	cl.writeLoop()
+	return nil
+}
+
+// Deliver queues payload as a text message on every connection of userID and
+// returns how many connections it was queued on. Connections that have fallen
+// behind are closed; clients are expected to reconnect and refetch state.
+func (h *Hub) Deliver(userID string, payload []byte) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	queued := 0
+	for cl := range h.clients[userID] {
+		select {
+		case cl.send <- payload:
+			queued++
+		case <-cl.done:
+		default:
+			cl.stop()
+		}
+	}
+	return queued
+}
+
+// Connections returns the number of open connections
+func (h *Hub) Connections() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	n := 0
+	for _, set := range h.clients {
+		n += len(set)
+	}
+	return n
+}
+
+// Close tells every client the server is going away and refuses new connections.
+// Hijacked connections are not closed by http.Server.Shutdown, so call this on shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	h.closed = true
+	var all []*client
+	for _, set := range h.clients {
+		for cl := range set {
+			all = append(all, cl)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, cl := range all {
+		cl.conn.writeClose(closeGoingAway, "server shutting down")
+		cl.stop()
+	}
+}
+
+var (
+	errHubClosed      = errors.New("server shutting down")
+	errTooManyClients = errors.New("too many connections")
+)
+
+func (h *Hub) register(userID string, cl *client) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return errHubClosed
+	}
+	set := h.clients[userID]
+	if len(set) >= maxPerUser {
+		return errTooManyClients
+	}
+	if set == nil {
+		set = make(map[*client]struct{})
+		h.clients[userID] = set
+	}
+	set[cl] = struct{}{}
+	return nil
+}
+
+func (h *Hub) unregister(userID string, cl *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if set := h.clients[userID]; set != nil {
+		delete(set, cl)
+		if len(set) == 0 {
+			delete(h.clients, userID)
+		}
+	}
+}
+
+// stop closes the connection once; both loops exit after it
+func (cl *client) stop() {
+	cl.stopOnce.Do(func() {
+		close(cl.done)
+		cl.conn.close()
+	})
+}
+
+// writeLoop sends queued messages and keep-alive pings
+func (cl *client) writeLoop() {
+	defer cl.stop()
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-cl.send:
+			if err := cl.conn.writeFrame(opText, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := cl.conn.writeFrame(opPing, nil); err != nil {
+				return
+			}
+		case <-cl.done:
+			return
+		}
+	}
+}
+
+// readLoop answers pings and close frames; any frame counts as a sign of life
+func (cl *client) readLoop() {
+	defer cl.stop()
+	for {
+		cl.conn.netConn.SetReadDeadline(time.Now().Add(pongWait))
+		opcode, payload, err := cl.conn.readFrame()
+		if err != nil {
+			var fe *frameError
+			if errors.As(err, &fe) {
+				cl.conn.writeClose(fe.code, fe.reason)
+			}
+			return
+		}
+
+		switch opcode {
+		case opPing:
+			if err := cl.conn.writeFrame(opPong, payload); err != nil {
+				return
+			}
+		case opClose:
+			// Echo the client's status code, as the closing handshake requires
+			code := closeNormal
+			if len(payload) >= 2 {
+				code = int(payload[0])<<8 | int(payload[1])
+			}
+			cl.conn.writeClose(code, "")
+			return
+		}
+	}
+}
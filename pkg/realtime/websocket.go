@@ -0,0 +1,225 @@
+// Package realtime pushes server events to connected clients over WebSocket
+// (RFC 6455) using only the standard library.
+//
+// Only what a server-to-client event stream needs is implemented: the opening
+// handshake, unfragmented text messages to the client, and ping/pong/close
+// handling for frames sent by the client. Data messages from the client are read
+// and discarded.
+package realtime
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBadHandshake is returned before anything is written to the response, so the
+// caller can still reply with a normal HTTP error
+var ErrBadHandshake = errors.New("realtime: not a websocket handshake")
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Frame opcodes
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+)
+
+// Close status codes
+const (
+	closeNormal          = 1000
+	closeGoingAway       = 1001
+	closeProtocolError   = 1002
+	closePolicyViolation = 1008
+	closeMessageTooBig   = 1009
+)
+
+const (
+	maxControlPayload = 125
+	maxClientMessage  = 4096 // clients have nothing to send beyond control frames
+)
+
+// conn is a server-side WebSocket connection. Writes are serialized; reads must
+// come from a single goroutine.
+type conn struct {
+	netConn net.Conn
+	br      *bufio.Reader
+
+	writeMu sync.Mutex
+}
+
+// upgrade validates the handshake, hijacks the connection and completes the
+// opening handshake
+func upgrade(w http.ResponseWriter, r *http.Request) (*conn, error) {
+	if r.Method != http.MethodGet ||
+		!headerHasToken(r.Header, "Connection", "upgrade") ||
+		!headerHasToken(r.Header, "Upgrade", "websocket") {
+		return nil, ErrBadHandshake
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		return nil, ErrBadHandshake
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, ErrBadHandshake
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("realtime: response writer does not support hijacking")
+	}
+	netConn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("realtime: hijack: %w", err)
+	}
+	if rw.Reader.Buffered() > 0 {
+		netConn.Close()
+		return nil, errors.New("realtime: client sent data before the handshake completed")
+	}
+
+	// The HTTP server's read/write timeouts are still set on the hijacked connection
+	c := &conn{netConn: netConn, br: rw.Reader}
+	c.netConn.SetReadDeadline(time.Time{})
+	c.netConn.SetWriteDeadline(time.Now().Add(writeWait))
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+	if _, err := c.netConn.Write([]byte(response)); err != nil {
+		c.netConn.Close()
+		return nil, fmt.Errorf("realtime: write handshake: %w", err)
+	}
+	return c, nil
+}
+
+func acceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// headerHasToken reports whether a comma-separated header contains token (case-insensitive)
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeFrame writes one unfragmented, unmasked frame
+func (c *conn) writeFrame(opcode byte, payload []byte) error {
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | opcode // FIN
+	switch n := len(payload); {
+	case n <= 125:
+		header[1] = byte(n)
+	case n <= 0xFFFF:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.netConn.SetWriteDeadline(time.Now().Add(writeWait))
+	if _, err := c.netConn.Write(header); err != nil {
+		return err
+	}
+	_, err := c.netConn.Write(payload)
+	return err
+}
+
+// writeClose sends a close frame with a status code and short reason
+func (c *conn) writeClose(code int, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	if len(reason) > maxControlPayload-2 {
+		reason = reason[:maxControlPayload-2]
+	}
+	return c.writeFrame(opClose, append(payload, reason...))
+}
+
+// frameError is a protocol violation by the client, answered with a close frame
+type frameError struct {
+	code   int
+	reason string
+}
+
+func (e *frameError) Error() string {
+	return fmt.Sprintf("realtime: %s (close %d)", e.reason, e.code)
+}
+
+// readFrame reads one frame from the client and unmasks its payload
+func (c *conn) readFrame() (opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.br, head[:]); err != nil {
+		return 0, nil, err
+	}
+	fin := head[0]&0x80 != 0
+	opcode = head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+
+	if head[0]&0x70 != 0 {
+		return 0, nil, &frameError{closeProtocolError, "reserved bits set"}
+	}
+	if !masked {
+		return 0, nil, &frameError{closeProtocolError, "client frames must be masked"}
+	}
+	isControl := opcode&0x8 != 0
+	if isControl && (!fin || length > maxControlPayload) {
+		return 0, nil, &frameError{closeProtocolError, "invalid control frame"}
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxClientMessage {
+		return 0, nil, &frameError{closeMessageTooBig, "message too big"}
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
+
+func (c *conn) close() error {
+	return c.netConn.Close()
+}
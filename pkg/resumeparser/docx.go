@@ -0,0 +1,78 @@
+package resumeparser
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// maxDocumentXMLSize bounds the uncompressed word/document.xml (zip-bomb guard)
+const maxDocumentXMLSize = 16 * 1024 * 1024
+
+// extractDOCXText reads the paragraphs of word/document.xml, one line each;
+// paragraphs in table cells are lines too
+func extractDOCXText(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", ErrUnsupportedFormat
+	}
+
+	var doc *zip.File
+	for _, f := range zr.File {
+		if f.Name == "word/document.xml" {
+			doc = f
+			break
+		}
+	}
+	if doc == nil {
+		return "", ErrUnsupportedFormat
+	}
+
+	rc, err := doc.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	var out strings.Builder
+	dec := xml.NewDecoder(io.LimitReader(rc, maxDocumentXMLSize))
+	inText := false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				out.WriteByte(' ')
+			case "br", "cr":
+				out.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				out.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				out.Write(t)
+			}
+		}
+		if out.Len() > maxTextSize {
+			break
+		}
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,681 @@
+// Package resumeparser extracts a structured draft (name, contact details,
+// education, work experience and skills) from a CV using only the standard
+// library. PDF text is read from the page content streams and DOCX text from
+// word/document.xml; the structure is then recovered heuristically from
+// section headings and date ranges.
+//
+// Results are a best-effort draft meant to be confirmed by the candidate, not
+// an authoritative record. Scanned (image-only) PDFs yield no text.
+package resumeparser
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxTextSize bounds the extracted text; real CVs are far smaller
+const maxTextSize = 512 * 1024
+
+// maxSkills caps the skills list so a pasted keyword dump stays usable
+const maxSkills = 50
+
+var (
+	ErrUnsupportedFormat = errors.New("resumeparser: only PDF and DOCX files are supported")
+	ErrNoText            = errors.New("resumeparser: no text found in document")
+)
+
+// Resume is the structured draft extracted from a CV
+type Resume struct {
+	Name       string
+	Email      string
+	Phone      string
+	Education  []Education
+	Experience []Experience
+	Skills     []string
+}
+
+// Education is one school or degree entry
+type Education struct {
+	Institution string
+	Degree      string
+	Major       string
+	StartYear   int // 0 when not found
+	EndYear     int // 0 when not found or ongoing
+}
+
+// Experience is one job entry. Dates have month precision (the 1st of the month).
+type Experience struct {
+	Company     string
+	Title       string
+	Start       *time.Time
+	End         *time.Time // nil when Current or not found
+	Current     bool
+	Description string
+}
+
+// Parse extracts the text of a PDF or DOCX file and parses it. The format is
+// detected from the content, falling back to the file extension.
+func Parse(filename string, data []byte) (*Resume, error) {
+	text, err := ExtractText(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseText(text), nil
+}
+
+// ExtractText returns the plain text of a PDF or DOCX file, one line per
+// paragraph or text line
+func ExtractText(filename string, data []byte) (string, error) {
+	var (
+		text string
+		err  error
+	)
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF")) || ext == ".pdf":
+		text, err = extractPDFText(data)
+	case bytes.HasPrefix(data, []byte("PK")) && ext != ".doc":
+		text, err = extractDOCXText(data)
+	default:
+		return "", ErrUnsupportedFormat
+	}
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
+
+// Section kinds recognised from headings
+const (
+	sectionHeader = iota // before the first heading: name and contact details
+	sectionEducation
+	sectionExperience
+	sectionSkills
+	sectionOther
+)
+
+// sectionHeadings maps normalized headings (English, Indonesian, Japanese) to sections
+var sectionHeadings = map[string]int{
+	"education":                 sectionEducation,
+	"educational background":    sectionEducation,
+	"academic background":       sectionEducation,
+	"pendidikan":                sectionEducation,
+	"riwayat pendidikan":        sectionEducation,
+	"学歴":                        sectionEducation,
+	"experience":                sectionExperience,
+	"work experience":           sectionExperience,
+	"professional experience":   sectionExperience,
+	"employment history":        sectionExperience,
+	"work history":              sectionExperience,
+	"career history":            sectionExperience,
+	"pengalaman":                sectionExperience,
+	"pengalaman kerja":          sectionExperience,
+	"riwayat pekerjaan":         sectionExperience,
+	"職歴":                        sectionExperience,
+	"職務経歴":                      sectionExperience,
+	"skills":                    sectionSkills,
+	"skill":                     sectionSkills,
+	"technical skills":          sectionSkills,
+	"key skills":                sectionSkills,
+	"keahlian":                  sectionSkills,
+	"keterampilan":              sectionSkills,
+	"スキル":                       sectionSkills,
+	"summary":                   sectionOther,
+	"profile":                   sectionOther,
+	"about me":                  sectionOther,
+	"objective":                 sectionOther,
+	"certifications":            sectionOther,
+	"certificates":              sectionOther,
+	"sertifikat":                sectionOther,
+	"languages":                 sectionOther,
+	"bahasa":                    sectionOther,
+	"references":                sectionOther,
+	"referensi":                 sectionOther,
+	"projects":                  sectionOther,
+	"awards":                    sectionOther,
+	"organizational experience": sectionOther,
+	"pengalaman organisasi":     sectionOther,
+	"personal information":      sectionOther,
+	"data pribadi":              sectionOther,
+	"資格":                        sectionOther,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`(?:\+|\b0)[0-9][0-9 ()\-.]{7,16}[0-9]`)
+	urlPattern   = regexp.MustCompile(`(?i)(https?://|www\.|linkedin\.com|github\.com)`)
+)
+
+// ParseText recovers the resume structure from plain text
+func ParseText(text string) *Resume {
+	if len(text) > maxTextSize {
+		text = text[:maxTextSize]
+	}
+
+	r := &Resume{}
+	if m := emailPattern.FindString(text); m != "" {
+		r.Email = m
+	}
+	if m := phonePattern.FindString(text); m != "" {
+		r.Phone = normalizePhone(m)
+	}
+
+	sections := map[int][]string{}
+	current := sectionHeader
+	for _, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(strings.ReplaceAll(raw, "\t", " "))
+		if line == "" {
+			continue
+		}
+		if kind, ok := headingSection(line); ok {
+			current = kind
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+
+	r.Name = findName(sections[sectionHeader])
+	r.Experience = parseExperience(sections[sectionExperience])
+	r.Education = parseEducation(sections[sectionEducation])
+	r.Skills = parseSkills(sections[sectionSkills])
+	return r
+}
+
+// headingSection reports whether line is a section heading like "WORK EXPERIENCE:"
+func headingSection(line string) (int, bool) {
+	if len([]rune(line)) > 40 {
+		return 0, false
+	}
+	key := strings.ToLower(strings.Trim(line, " :：-–—•*#|"))
+	key = strings.Join(strings.Fields(key), " ")
+	kind, ok := sectionHeadings[key]
+	return kind, ok
+}
+
+// findName picks the first header line that looks like a person's name
+func findName(lines []string) string {
+	for i, line := range lines {
+		if i >= 5 {
+			break
+		}
+		if emailPattern.MatchString(line) || phonePattern.MatchString(line) || urlPattern.MatchString(line) {
+			continue
+		}
+		lower := strings.ToLower(line)
+		if lower == "curriculum vitae" || lower == "resume" || lower == "cv" || lower == "daftar riwayat hidup" || line == "履歴書" {
+			continue
+		}
+		words := strings.Fields(line)
+		if len(words) < 1 || len(words) > 5 || len([]rune(line)) > 60 {
+			continue
+		}
+		if isNameLike(line) {
+			return titleCaseIfUpper(line)
+		}
+	}
+	return ""
+}
+
+func isNameLike(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && r != ' ' && r != '.' && r != '\'' && r != '-' && r != ',' {
+			return false
+		}
+	}
+	return true
+}
+
+// titleCaseIfUpper turns "BUDI SANTOSO" into "Budi Santoso"; mixed case is kept as written
+func titleCaseIfUpper(s string) string {
+	if strings.ToUpper(s) != s {
+		return s
+	}
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
+
+func normalizePhone(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if r == '+' && i == 0 || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// SplitName splits a full name into first and last name at the last space.
+// Single names (common in Indonesia) have no last name.
+func SplitName(full string) (first, last string) {
+	full = strings.TrimSpace(full)
+	i := strings.LastIndex(full, " ")
+	if i < 0 {
+		return full, ""
+	}
+	return strings.TrimSpace(full[:i]), strings.TrimSpace(full[i+1:])
+}
+
+// ---- Dates ----
+
+var monthNames = map[string]time.Month{
+	"jan": time.January, "january": time.January, "januari": time.January,
+	"feb": time.February, "february": time.February, "februari": time.February,
+	"mar": time.March, "march": time.March, "maret": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May, "mei": time.May,
+	"jun": time.June, "june": time.June, "juni": time.June,
+	"jul": time.July, "july": time.July, "juli": time.July,
+	"aug": time.August, "august": time.August, "agu": time.August, "agustus": time.August, "agt": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October, "okt": time.October, "oktober": time.October,
+	"nov": time.November, "november": time.November, "nopember": time.November,
+	"dec": time.December, "december": time.December, "des": time.December, "desember": time.December,
+}
+
+// datePart matches "Jan 2020", "January 2020", "01/2020", "2020/01", "2020.01", "2020年1月" or "2020"
+const datePart = `(?:[A-Za-z]{3,9}\.?\s+\d{4}|\d{1,2}[/.\-]\d{4}|\d{4}[/.\-]\d{1,2}|\d{4}年\s*\d{1,2}月|\d{4})`
+
+// ongoing words that end a range of a current position or study
+const ongoingPart = `(?:present|now|current|currently|sekarang|saat ini|kini|現在|至今)`
+
+var (
+	dateRangePattern = regexp.MustCompile(`(?i)(` + datePart + `)\s*(?:-|–|—|~|〜|to|until|s/d|sampai|hingga)\s*(` + datePart + `|` + ongoingPart + `)`)
+	yearPattern      = regexp.MustCompile(`\b(19[5-9]\d|20\d{2})\b`)
+	ongoingPattern   = regexp.MustCompile(`(?i)^` + ongoingPart + `$`)
+	monthYearPattern = regexp.MustCompile(`^([A-Za-z]{3,9})\.?\s+(\d{4})$`)
+	numMonthYear     = regexp.MustCompile(`^(\d{1,2})[/.\-](\d{4})$`)
+	numYearMonth     = regexp.MustCompile(`^(\d{4})[/.\-](\d{1,2})$`)
+	kanjiYearMonth   = regexp.MustCompile(`^(\d{4})年\s*(\d{1,2})月$`)
+)
+
+// parseDate converts one side of a date range to the first of its month; a
+// bare year becomes January
+func parseDate(s string) *time.Time {
+	s = strings.TrimSpace(s)
+	var year, month int
+	if m := monthYearPattern.FindStringSubmatch(s); m != nil {
+		mon, ok := monthNames[strings.ToLower(m[1])]
+		if !ok {
+			return nil
+		}
+		year, _ = strconv.Atoi(m[2])
+		month = int(mon)
+	} else if m := numMonthYear.FindStringSubmatch(s); m != nil {
+		month, _ = strconv.Atoi(m[1])
+		year, _ = strconv.Atoi(m[2])
+	} else if m := numYearMonth.FindStringSubmatch(s); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+	} else if m := kanjiYearMonth.FindStringSubmatch(s); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		month, _ = strconv.Atoi(m[2])
+	} else if len(s) == 4 {
+		year, _ = strconv.Atoi(s)
+		month = 1
+	}
+	if year < 1950 || year > 2100 || month < 1 || month > 12 {
+		return nil
+	}
+	t := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+// dateRange is a period found on a line, with the rest of the line
+type dateRange struct {
+	start, end *time.Time
+	current    bool
+	rest       string
+}
+
+func findDateRange(line string) (*dateRange, bool) {
+	loc := dateRangePattern.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return nil, false
+	}
+	startText := line[loc[2]:loc[3]]
+	endText := line[loc[4]:loc[5]]
+	dr := &dateRange{start: parseDate(startText)}
+	if ongoingPattern.MatchString(strings.TrimSpace(endText)) {
+		dr.current = true
+	} else {
+		dr.end = parseDate(endText)
+	}
+	if dr.start == nil {
+		return nil, false
+	}
+	dr.rest = cleanPiece(line[:loc[0]] + " " + line[loc[1]:])
+	return dr, true
+}
+
+// ---- Entries ----
+
+// entry is a block of lines belonging to one job or school
+type entry struct {
+	header []string
+	period *dateRange
+	body   []string
+}
+
+// splitEntries groups section lines into entries around date ranges. The
+// header of an entry is the rest of its date line, or failing that the up to
+// two non-bullet lines just above it.
+func splitEntries(lines []string) []entry {
+	var entries []entry
+	var pending []string
+	for _, line := range lines {
+		dr, ok := findDateRange(line)
+		if !ok {
+			pending = append(pending, line)
+			continue
+		}
+
+		var header []string
+		if dr.rest != "" {
+			header = append(header, dr.rest)
+		}
+		limit := 2 - len(header)
+		take := 0
+		for take < limit && take < len(pending) && isHeaderLine(pending[len(pending)-1-take]) {
+			take++
+		}
+		header = append(append([]string{}, pending[len(pending)-take:]...), header...)
+		if len(entries) > 0 {
+			entries[len(entries)-1].body = append(entries[len(entries)-1].body, pending[:len(pending)-take]...)
+		}
+		entries = append(entries, entry{header: header, period: dr})
+		pending = nil
+	}
+	if len(entries) > 0 {
+		entries[len(entries)-1].body = append(entries[len(entries)-1].body, pending...)
+	}
+	return entries
+}
+
+// isHeaderLine reports whether a line can name a job or school: short and not a bullet point
+func isHeaderLine(line string) bool {
+	return !isBullet(line) && len([]rune(line)) <= 80
+}
+
+func isBullet(line string) bool {
+	return strings.HasPrefix(line, "-") || strings.HasPrefix(line, "•") || strings.HasPrefix(line, "*") ||
+		strings.HasPrefix(line, "●") || strings.HasPrefix(line, "・") || strings.HasPrefix(line, "▪")
+}
+
+var (
+	headerSeparator = regexp.MustCompile(`\s+(?:-|–|—|\||@|at|di)\s+|\s*[|,，、]\s*`)
+	legalSuffix     = regexp.MustCompile(`(?i),\s*(ltd|inc|tbk|llc|co)\b`)
+)
+
+// splitHeader breaks "Title at Company" or "Company | Title" into pieces
+func splitHeader(lines []string) []string {
+	var pieces []string
+	for _, l := range lines {
+		// "Tanaka Kogyo Co., Ltd" is one name
+		l = legalSuffix.ReplaceAllString(l, " $1")
+		for _, p := range headerSeparator.Split(l, -1) {
+			if p = cleanPiece(p); p != "" {
+				pieces = append(pieces, p)
+			}
+		}
+	}
+	return pieces
+}
+
+func cleanPiece(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.Trim(s, " -–—|,:()[]•*")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var companyMarkers = []string{
+	"pt ", "pt.", "cv ", "cv.", "tbk", "inc", "ltd", "llc", "corp", "co.,", "company", "group",
+	"k.k.", "kabushiki", "株式会社", "有限会社", "gmbh", "limited", "foundation", "yayasan", "koperasi",
+}
+
+func looksLikeCompany(s string) bool {
+	lower := strings.ToLower(s) + " "
+	for _, m := range companyMarkers {
+		if strings.Contains(lower, m) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseExperience(lines []string) []Experience {
+	var out []Experience
+	for _, e := range splitEntries(lines) {
+		pieces := splitHeader(e.header)
+		exp := Experience{Start: e.period.start, End: e.period.end, Current: e.period.current}
+		switch len(pieces) {
+		case 0:
+		case 1:
+			if looksLikeCompany(pieces[0]) {
+				exp.Company = pieces[0]
+			} else {
+				exp.Title = pieces[0]
+			}
+		default:
+			// Title first unless the first piece is clearly the employer
+			exp.Title, exp.Company = pieces[0], pieces[1]
+			if looksLikeCompany(pieces[0]) && !looksLikeCompany(pieces[1]) {
+				exp.Title, exp.Company = pieces[1], pieces[0]
+			}
+		}
+		var body []string
+		for _, b := range e.body {
+			body = append(body, strings.TrimSpace(strings.TrimLeft(b, "-•*●・▪ ")))
+		}
+		exp.Description = strings.Join(body, "\n")
+		if exp.Title == "" && exp.Company == "" {
+			continue
+		}
+		out = append(out, exp)
+	}
+	return out
+}
+
+var institutionMarkers = []string{
+	"university", "universitas", "universiti", "institut", "institute", "politeknik", "polytechnic",
+	"college", "school", "academy", "akademi", "sekolah", "sma", "smk", "stm", "madrasah",
+	"大学", "高校", "高等学校", "専門学校",
+}
+
+// degreeMarkers are checked in order; the first match names the degree
+var degreeMarkers = []struct{ marker, degree string }{
+	{"ph.d", "S3"}, {"phd", "S3"}, {"doctor", "S3"}, {"s3", "S3"}, {"博士", "S3"},
+	{"master", "S2"}, {"magister", "S2"}, {"m.sc", "S2"}, {"mba", "S2"}, {"s2", "S2"}, {"修士", "S2"},
+	{"bachelor", "S1"}, {"sarjana", "S1"}, {"b.sc", "S1"}, {"s1", "S1"}, {"学士", "S1"},
+	{"d4", "D4"}, {"diploma iv", "D4"},
+	{"d3", "D3"}, {"diploma iii", "D3"}, {"diploma", "D3"},
+	{"d2", "D2"}, {"d1", "D1"},
+	{"smk", "SMK"}, {"stm", "SMK"}, {"vocational high school", "SMK"},
+	{"sma", "SMA"}, {"senior high school", "SMA"}, {"high school", "SMA"}, {"madrasah aliyah", "SMA"}, {"高校", "SMA"},
+	{"smp", "SMP"}, {"junior high school", "SMP"},
+}
+
+// degreeRank orders degrees for picking the highest education
+var degreeRank = map[string]int{"SMP": 1, "SMA": 2, "SMK": 2, "D1": 3, "D2": 4, "D3": 5, "D4": 6, "S1": 6, "S2": 7, "S3": 8}
+
+func containsWord(lower, marker string) bool {
+	if len(marker) > 3 || !isASCII(marker) {
+		return strings.Contains(lower, marker)
+	}
+	// Short markers (s1, sma, d3) must stand alone
+	for _, w := range strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if w == marker {
+			return true
+		}
+	}
+	return false
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
+
+func degreeOf(s string) string {
+	lower := strings.ToLower(s)
+	for _, d := range degreeMarkers {
+		if containsWord(lower, d.marker) {
+			return d.degree
+		}
+	}
+	return ""
+}
+
+func isInstitution(s string) bool {
+	lower := strings.ToLower(s)
+	for _, m := range institutionMarkers {
+		if containsWord(lower, m) {
+			return true
+		}
+	}
+	return false
+}
+
+var majorPattern = regexp.MustCompile(`(?i)\b(?:in|of|jurusan|program studi|prodi|major)\s*:?\s+(.+)$`)
+
+func parseEducation(lines []string) []Education {
+	var out []Education
+	entries := splitEntries(lines)
+	if len(entries) == 0 {
+		// Education lines often carry a single graduation year instead of a range
+		for _, line := range lines {
+			if years := yearPattern.FindAllString(line, -1); len(years) > 0 && isInstitution(line) {
+				end, _ := strconv.Atoi(years[len(years)-1])
+				rest := cleanPiece(yearPattern.ReplaceAllString(line, ""))
+				entries = append(entries, entry{header: []string{rest}, period: &dateRange{end: yearStart(end)}})
+			}
+		}
+	}
+
+	for _, e := range entries {
+		ed := Education{}
+		if e.period.start != nil {
+			ed.StartYear = e.period.start.Year()
+		}
+		if e.period.end != nil {
+			ed.EndYear = e.period.end.Year()
+		}
+		for _, p := range splitHeader(append(append([]string{}, e.header...), e.body...)) {
+			switch {
+			case ed.Institution == "" && isInstitution(p):
+				ed.Institution = p
+				if ed.Degree == "" {
+					ed.Degree = degreeOf(p) // "SMA Negeri 1 Jakarta"
+				}
+			case ed.Degree == "" && degreeOf(p) != "":
+				ed.Degree = degreeOf(p)
+				if ed.Major == "" {
+					ed.Major = majorOf(p)
+				}
+			case ed.Major == "" && len([]rune(p)) <= 80 && !yearPattern.MatchString(p):
+				ed.Major = p
+			}
+		}
+		if ed.Institution == "" && ed.Degree == "" {
+			continue
+		}
+		out = append(out, ed)
+	}
+	return out
+}
+
+// majorOf finds the field of study in a degree line: "Bachelor of Engineering in
+// Informatics", "S1 Teknik Informatika"
+func majorOf(p string) string {
+	if m := majorPattern.FindStringSubmatch(p); m != nil {
+		return cleanPiece(m[1])
+	}
+	words := strings.Fields(p)
+	if len(words) > 1 && len(words[0]) <= 3 && degreeOf(words[0]) != "" {
+		return strings.Join(words[1:], " ")
+	}
+	return ""
+}
+
+func yearStart(year int) *time.Time {
+	t := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+// HighestDegree returns the highest recognised degree in the list, if any
+func HighestDegree(education []Education) (Education, bool) {
+	best, found := Education{}, false
+	for _, e := range education {
+		if degreeRank[e.Degree] > degreeRank[best.Degree] {
+			best, found = e, true
+		}
+	}
+	return best, found
+}
+
+// splitSkills splits a skills line on list separators outside parentheses, so
+// "Welding (SMAW, GTAW)" stays one skill
+func splitSkills(line string) []string {
+	var out []string
+	var cur strings.Builder
+	depth := 0
+	for _, r := range line {
+		switch {
+		case r == '(' || r == '（':
+			depth++
+		case (r == ')' || r == '）') && depth > 0:
+			depth--
+		case depth == 0 && strings.ContainsRune(",;|•●・/、，", r):
+			out = append(out, cur.String())
+			cur.Reset()
+			continue
+		}
+		cur.WriteRune(r)
+	}
+	return append(out, cur.String())
+}
+
+func parseSkills(lines []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, line := range lines {
+		line = strings.TrimLeft(line, "-•*●・▪ ")
+		// "Programming: Go, SQL" lists skills after the label
+		if i := strings.IndexAny(line, ":："); i >= 0 && i < 30 {
+			line = line[i+1:]
+		}
+		for _, s := range splitSkills(line) {
+			s = strings.Join(strings.Fields(strings.Trim(s, " -–—:•*.")), " ")
+			if s == "" || len([]rune(s)) > 50 || len(strings.Fields(s)) > 5 {
+				continue
+			}
+			key := strings.ToLower(s)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, s)
+			if len(out) == maxSkills {
+				return out
+			}
+		}
+	}
+	return out
+}
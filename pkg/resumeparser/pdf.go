@@ -0,0 +1,622 @@
+package resumeparser
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxStreamSize bounds one decompressed stream (zip-bomb guard)
+const maxStreamSize = 8 * 1024 * 1024
+
+// ErrEncrypted is returned for password-protected PDFs
+var ErrEncrypted = errors.New("resumeparser: encrypted PDFs are not supported")
+
+var (
+	objPattern       = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	refPattern       = regexp.MustCompile(`/([A-Za-z0-9_.+\-]+)\s+(\d+)\s+\d+\s+R`)
+	fontDictPattern  = regexp.MustCompile(`/Font\s*<<([^>]*)>>`)
+	fontRefPattern   = regexp.MustCompile(`/Font\s+(\d+)\s+\d+\s+R`)
+	toUnicodePattern = regexp.MustCompile(`/ToUnicode\s+(\d+)\s+\d+\s+R`)
+	firstPattern     = regexp.MustCompile(`/First\s+(\d+)`)
+)
+
+// skipStreamMarkers identify streams that never hold page text
+var skipStreamMarkers = []string{
+	"/Image", "/XRef", "/ObjStm", "/Metadata", "/Length1", "/Length2", "/Type1C",
+	"/CIDFontType0C", "/OpenType", "/FunctionType", "/ShadingType", "/EmbeddedFile", "/DCTDecode", "/JPXDecode",
+}
+
+// pdfDocument holds the objects of a PDF by number
+type pdfDocument struct {
+	objects map[int][]byte
+}
+
+// extractPDFText reads the text shown by the content streams, in object order.
+// Fonts with a ToUnicode CMap are decoded through it; other strings are read
+// as Latin-1.
+func extractPDFText(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF")) {
+		return "", ErrUnsupportedFormat
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return "", ErrEncrypted
+	}
+
+	doc := parsePDFObjects(data)
+	fonts := doc.fontCMaps()
+
+	var out strings.Builder
+	for _, num := range doc.sortedObjectNumbers() {
+		body := doc.objects[num]
+		dict, stream, ok := splitStream(body)
+		if !ok || containsAny(dict, skipStreamMarkers) {
+			continue
+		}
+		content, err := decodeStream(dict, stream)
+		if err != nil || bytes.Contains(content, []byte("begincmap")) {
+			continue
+		}
+		extractContentText(content, fonts, &out)
+		if out.Len() > maxTextSize {
+			break
+		}
+	}
+	return out.String(), nil
+}
+
+func parsePDFObjects(data []byte) *pdfDocument {
+	doc := &pdfDocument{objects: map[int][]byte{}}
+	locs := objPattern.FindAllSubmatchIndex(data, -1)
+	for i, loc := range locs {
+		num, _ := strconv.Atoi(string(data[loc[2]:loc[3]]))
+		end := len(data)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		body := data[loc[1]:end]
+		if j := bytes.Index(body, []byte("endobj")); j >= 0 {
+			body = body[:j]
+		}
+		doc.objects[num] = body
+	}
+
+	// Objects compressed into object streams (PDF 1.5+), typically font dictionaries
+	for _, body := range doc.objects {
+		dict, stream, ok := splitStream(body)
+		if !ok || !bytes.Contains(dict, []byte("/ObjStm")) {
+			continue
+		}
+		content, err := decodeStream(dict, stream)
+		if err != nil {
+			continue
+		}
+		doc.addObjectStream(dict, content)
+	}
+	return doc
+}
+
+// addObjectStream unpacks "num offset num offset ..." followed by the objects
+func (d *pdfDocument) addObjectStream(dict, content []byte) {
+	m := firstPattern.FindSubmatch(dict)
+	if m == nil {
+		return
+	}
+	first, _ := strconv.Atoi(string(m[1]))
+	if first <= 0 || first > len(content) {
+		return
+	}
+	fields := strings.Fields(string(content[:first]))
+	for i := 0; i+1 < len(fields); i += 2 {
+		num, err1 := strconv.Atoi(fields[i])
+		off, err2 := strconv.Atoi(fields[i+1])
+		if err1 != nil || err2 != nil || first+off > len(content) {
+			continue
+		}
+		end := len(content)
+		if i+3 < len(fields) {
+			if next, err := strconv.Atoi(fields[i+3]); err == nil && first+next <= len(content) && next >= off {
+				end = first + next
+			}
+		}
+		if _, exists := d.objects[num]; !exists {
+			d.objects[num] = content[first+off : end]
+		}
+	}
+}
+
+func (d *pdfDocument) sortedObjectNumbers() []int {
+	nums := make([]int, 0, len(d.objects))
+	for n := range d.objects {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	return nums
+}
+
+// fontCMaps maps font resource names (/F1) to their ToUnicode CMap. Resource
+// names are merged across pages; generators rarely reuse a name for another font.
+func (d *pdfDocument) fontCMaps() map[string]*cmap {
+	fonts := map[string]*cmap{}
+	addRefs := func(dict []byte) {
+		for _, m := range refPattern.FindAllSubmatch(dict, -1) {
+			fontNum, _ := strconv.Atoi(string(m[2]))
+			if cm := d.toUnicode(fontNum); cm != nil {
+				fonts[string(m[1])] = cm
+			}
+		}
+	}
+	for _, body := range d.objects {
+		if _, _, isStream := splitStream(body); isStream {
+			continue
+		}
+		for _, m := range fontDictPattern.FindAllSubmatch(body, -1) {
+			addRefs(m[1])
+		}
+		for _, m := range fontRefPattern.FindAllSubmatch(body, -1) {
+			num, _ := strconv.Atoi(string(m[1]))
+			if inner := fontDictPattern.FindSubmatch(append([]byte("/Font "), d.objects[num]...)); inner != nil {
+				addRefs(inner[1])
+			}
+		}
+	}
+	return fonts
+}
+
+func (d *pdfDocument) toUnicode(fontNum int) *cmap {
+	m := toUnicodePattern.FindSubmatch(d.objects[fontNum])
+	if m == nil {
+		return nil
+	}
+	num, _ := strconv.Atoi(string(m[1]))
+	dict, stream, ok := splitStream(d.objects[num])
+	if !ok {
+		return nil
+	}
+	content, err := decodeStream(dict, stream)
+	if err != nil {
+		return nil
+	}
+	return parseCMap(content)
+}
+
+// splitStream separates an object's dictionary from its stream bytes
+func splitStream(body []byte) (dict, stream []byte, ok bool) {
+	i := bytes.Index(body, []byte("stream"))
+	if i < 0 {
+		return nil, nil, false
+	}
+	dict = body[:i]
+	stream = body[i+len("stream"):]
+	stream = bytes.TrimPrefix(stream, []byte("\r"))
+	stream = bytes.TrimPrefix(stream, []byte("\n"))
+	if j := bytes.LastIndex(stream, []byte("endstream")); j >= 0 {
+		stream = stream[:j]
+	}
+	return dict, stream, true
+}
+
+func decodeStream(dict, stream []byte) ([]byte, error) {
+	if !bytes.Contains(dict, []byte("/Filter")) {
+		return stream, nil
+	}
+	if !bytes.Contains(dict, []byte("/FlateDecode")) || bytes.Count(dict, []byte("Decode")) > 1 {
+		return nil, errors.New("unsupported stream filter")
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(io.LimitReader(zr, maxStreamSize))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+func containsAny(b []byte, markers []string) bool {
+	for _, m := range markers {
+		if bytes.Contains(b, []byte(m)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ---- ToUnicode CMaps ----
+
+// cmap maps character codes of one font to text
+type cmap struct {
+	width int // code length in bytes
+	codes map[uint32]string
+}
+
+var (
+	bfcharPattern  = regexp.MustCompile(`(?s)beginbfchar(.*?)endbfchar`)
+	bfrangePattern = regexp.MustCompile(`(?s)beginbfrange(.*?)endbfrange`)
+	hexPattern     = regexp.MustCompile(`<([0-9A-Fa-f\s]*)>|\[([^\]]*)\]`)
+)
+
+func parseCMap(content []byte) *cmap {
+	cm := &cmap{width: 1, codes: map[uint32]string{}}
+	setWidth := func(code string) {
+		if len(code) >= 4 {
+			cm.width = 2
+		}
+	}
+
+	for _, block := range bfcharPattern.FindAllSubmatch(content, -1) {
+		tokens := hexPattern.FindAllSubmatch(block[1], -1)
+		for i := 0; i+1 < len(tokens); i += 2 {
+			src := string(tokens[i][1])
+			setWidth(src)
+			cm.codes[hexToUint(src)] = utf16HexToString(string(tokens[i+1][1]))
+		}
+	}
+
+	for _, block := range bfrangePattern.FindAllSubmatch(content, -1) {
+		tokens := hexPattern.FindAllSubmatch(block[1], -1)
+		for i := 0; i+2 < len(tokens); i += 3 {
+			lo, hi := hexToUint(string(tokens[i][1])), hexToUint(string(tokens[i+1][1]))
+			setWidth(string(tokens[i][1]))
+			if hi < lo || hi-lo > 0xFFFF {
+				continue
+			}
+			if tokens[i+2][2] != nil {
+				// [<dst1> <dst2> ...] lists each destination
+				dsts := hexPattern.FindAllSubmatch(tokens[i+2][2], -1)
+				for j, dst := range dsts {
+					if lo+uint32(j) > hi {
+						break
+					}
+					cm.codes[lo+uint32(j)] = utf16HexToString(string(dst[1]))
+				}
+				continue
+			}
+			base := []rune(utf16HexToString(string(tokens[i+2][1])))
+			if len(base) == 0 {
+				continue
+			}
+			last := len(base) - 1
+			for code := lo; code <= hi; code++ {
+				r := append([]rune{}, base...)
+				r[last] += rune(code - lo)
+				cm.codes[code] = string(r)
+			}
+		}
+	}
+
+	if len(cm.codes) == 0 {
+		return nil
+	}
+	return cm
+}
+
+func (cm *cmap) decode(b []byte) string {
+	var sb strings.Builder
+	for i := 0; i+cm.width <= len(b); i += cm.width {
+		var code uint32
+		for j := 0; j < cm.width; j++ {
+			code = code<<8 | uint32(b[i+j])
+		}
+		sb.WriteString(cm.codes[code])
+	}
+	return sb.String()
+}
+
+func hexToUint(s string) uint32 {
+	s = strings.Join(strings.Fields(s), "")
+	v, _ := strconv.ParseUint(s, 16, 32)
+	return uint32(v)
+}
+
+func utf16HexToString(s string) string {
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil || len(b)%2 != 0 {
+		return ""
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+	}
+	return string(utf16.Decode(u))
+}
+
+// ---- Content streams ----
+
+// pdfOperand is a string, number or array operand before an operator
+type pdfOperand struct {
+	str    []byte
+	isStr  bool
+	num    float64
+	isNum  bool
+	array  []pdfOperand
+	isName bool
+	name   string
+}
+
+// extractContentText runs the text operators of a content stream, writing one
+// line per text line
+func extractContentText(content []byte, fonts map[string]*cmap, out *strings.Builder) {
+	var (
+		operands []pdfOperand
+		font     *cmap
+		lineOpen bool
+	)
+	newline := func() {
+		if lineOpen {
+			out.WriteByte('\n')
+			lineOpen = false
+		}
+	}
+	show := func(s []byte) {
+		var text string
+		if font != nil {
+			text = font.decode(s)
+		} else {
+			text = latin1(s)
+		}
+		if text != "" {
+			out.WriteString(text)
+			lineOpen = true
+		}
+	}
+
+	p := &contentScanner{data: content}
+	for {
+		tok, op, ok := p.next()
+		if !ok {
+			break
+		}
+		if op == "" {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch op {
+		case "Tf":
+			if len(operands) >= 2 && operands[len(operands)-2].isName {
+				font = fonts[operands[len(operands)-2].name]
+			}
+		case "Tj":
+			if n := len(operands); n > 0 && operands[n-1].isStr {
+				show(operands[n-1].str)
+			}
+		case "'", "\"":
+			newline()
+			if n := len(operands); n > 0 && operands[n-1].isStr {
+				show(operands[n-1].str)
+			}
+		case "TJ":
+			if n := len(operands); n > 0 {
+				for _, el := range operands[n-1].array {
+					switch {
+					case el.isStr:
+						show(el.str)
+					case el.isNum && el.num < -200 && lineOpen:
+						// A wide negative kern is a word gap
+						out.WriteByte(' ')
+					}
+				}
+			}
+		case "Td", "TD":
+			if n := len(operands); n >= 2 {
+				if operands[n-1].num != 0 {
+					newline()
+				} else if operands[n-2].num > 0 && lineOpen {
+					out.WriteByte(' ')
+				}
+			}
+		case "T*", "ET":
+			newline()
+		case "Tm":
+			newline()
+		}
+		operands = operands[:0]
+	}
+	newline()
+}
+
+// latin1 reads bytes as Latin-1, dropping control characters
+func latin1(b []byte) string {
+	if len(b) >= 2 && b[0] == 0xFE && b[1] == 0xFF {
+		return utf16HexToString(hex.EncodeToString(b[2:]))
+	}
+	var sb strings.Builder
+	for _, c := range b {
+		if c >= 0x20 && c != 0x7F && (c < 0x80 || c >= 0xA0) {
+			sb.WriteRune(rune(c))
+		}
+	}
+	return sb.String()
+}
+
+// contentScanner tokenizes a content stream
+type contentScanner struct {
+	data []byte
+	pos  int
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// next returns either an operand or an operator name
+func (p *contentScanner) next() (pdfOperand, string, bool) {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case isPDFWhitespace(c):
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		case c == '(':
+			return pdfOperand{str: p.literalString(), isStr: true}, "", true
+		case c == '<' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '<':
+			p.skipDict()
+		case c == '<':
+			return pdfOperand{str: p.hexString(), isStr: true}, "", true
+		case c == '[':
+			p.pos++
+			var arr []pdfOperand
+			for {
+				tok, op, ok := p.next()
+				if !ok || op == "]" {
+					break
+				}
+				if op == "" {
+					arr = append(arr, tok)
+				}
+			}
+			return pdfOperand{array: arr}, "", true
+		case c == ']':
+			p.pos++
+			return pdfOperand{}, "]", true
+		case c == '/':
+			start := p.pos + 1
+			p.pos++
+			for p.pos < len(p.data) && !isPDFWhitespace(p.data[p.pos]) && !isPDFDelimiter(p.data[p.pos]) {
+				p.pos++
+			}
+			return pdfOperand{isName: true, name: string(p.data[start:p.pos])}, "", true
+		default:
+			start := p.pos
+			for p.pos < len(p.data) && !isPDFWhitespace(p.data[p.pos]) && !isPDFDelimiter(p.data[p.pos]) {
+				p.pos++
+			}
+			if p.pos == start {
+				p.pos++ // stray delimiter
+				continue
+			}
+			word := string(p.data[start:p.pos])
+			if n, err := strconv.ParseFloat(word, 64); err == nil {
+				return pdfOperand{num: n, isNum: true}, "", true
+			}
+			if word == "BI" {
+				p.skipInlineImage()
+				continue
+			}
+			return pdfOperand{}, word, true
+		}
+	}
+	return pdfOperand{}, "", false
+}
+
+func (p *contentScanner) literalString() []byte {
+	var out []byte
+	depth := 0
+	p.pos++ // (
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '\\':
+			if p.pos >= len(p.data) {
+				return out
+			}
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for k := 0; k < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; k++ {
+						v = v*8 + int(p.data[p.pos]-'0')
+						p.pos++
+					}
+					out = append(out, byte(v))
+				} else {
+					out = append(out, e)
+				}
+			}
+		case '(':
+			depth++
+			out = append(out, c)
+		case ')':
+			if depth == 0 {
+				return out
+			}
+			depth--
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func (p *contentScanner) hexString() []byte {
+	p.pos++ // <
+	start := p.pos
+	for p.pos < len(p.data) && p.data[p.pos] != '>' {
+		p.pos++
+	}
+	digits := strings.Join(strings.Fields(string(p.data[start:p.pos])), "")
+	p.pos++ // >
+	if len(digits)%2 == 1 {
+		digits += "0"
+	}
+	b, _ := hex.DecodeString(digits)
+	return b
+}
+
+func (p *contentScanner) skipDict() {
+	depth := 0
+	for p.pos+1 < len(p.data) {
+		switch {
+		case p.data[p.pos] == '<' && p.data[p.pos+1] == '<':
+			depth++
+			p.pos += 2
+		case p.data[p.pos] == '>' && p.data[p.pos+1] == '>':
+			depth--
+			p.pos += 2
+			if depth == 0 {
+				return
+			}
+		default:
+			p.pos++
+		}
+	}
+	p.pos = len(p.data)
+}
+
+// skipInlineImage jumps past binary inline image data (BI ... ID <data> EI)
+func (p *contentScanner) skipInlineImage() {
+	i := bytes.Index(p.data[p.pos:], []byte("EI"))
+	for i >= 0 {
+		end := p.pos + i + 2
+		if end >= len(p.data) || isPDFWhitespace(p.data[end]) {
+			p.pos = end
+			return
+		}
+		j := bytes.Index(p.data[end:], []byte("EI"))
+		if j < 0 {
+			break
+		}
+		i = end - p.pos + j
+	}
+	p.pos = len(p.data)
+}
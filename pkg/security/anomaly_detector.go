@@ -0,0 +1,292 @@
+package security
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Anomaly detection rules
+const (
+	RuleFailedLoginBurst   = "failed_login_burst"   // many failed logins from one IP
+	RuleBreakglassOffHours = "breakglass_off_hours" // break-glass activated outside business hours
+	RuleExportSpike        = "export_spike"         // many export requests/downloads by one user
+)
+
+// AnomalyRules lists every rule the detector evaluates
+var AnomalyRules = []string{RuleFailedLoginBurst, RuleBreakglassOffHours, RuleExportSpike}
+
+// AnomalyDetectorConfig holds the rule thresholds
+type AnomalyDetectorConfig struct {
+	FailedLoginThreshold int           // Failed logins from one IP that raise an alert (default: 10)
+	FailedLoginWindow    time.Duration // Window for counting them (default: 10min)
+	ExportSpikeThreshold int           // Exports requested or downloaded by one user (default: 5)
+	ExportSpikeWindow    time.Duration // Window for counting them (default: 1h)
+	BusinessHoursStart   int           // First business hour, local time (default: 8)
+	BusinessHoursEnd     int           // Business hours end at this hour (default: 18)
+	BusinessLocation     *time.Location
+}
+
+// DefaultAnomalyDetectorConfig returns sensible defaults (weekdays 08:00-18:00 WIB)
+func DefaultAnomalyDetectorConfig() AnomalyDetectorConfig {
+	return AnomalyDetectorConfig{
+		FailedLoginThreshold: 10,
+		FailedLoginWindow:    10 * time.Minute,
+		ExportSpikeThreshold: 5,
+		ExportSpikeWindow:    time.Hour,
+		BusinessHoursStart:   8,
+		BusinessHoursEnd:     18,
+		BusinessLocation:     jakartaLocation(),
+	}
+}
+
+func jakartaLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// DetectionEvent is the part of a security event the rules look at
+type DetectionEvent struct {
+	ID           int64
+	Type         EventType
+	SubjectValue string
+	IP           string
+	Timestamp    time.Time
+}
+
+// Anomaly is one rule match. Matches with the same Rule and DedupKey describe
+// the same incident and should update one alert instead of raising another.
+type Anomaly struct {
+	Rule       string
+	Severity   Severity
+	DedupKey   string
+	Title      string
+	EventCount int
+	FirstSeen  time.Time
+	LastSeen   time.Time
+	Details    map[string]interface{}
+}
+
+// AnomalyDetector evaluates the detection rules over recent security events
+type AnomalyDetector struct {
+	config AnomalyDetectorConfig
+}
+
+// NewAnomalyDetector creates a detector; zero config fields take the defaults
+func NewAnomalyDetector(config AnomalyDetectorConfig) *AnomalyDetector {
+	defaults := DefaultAnomalyDetectorConfig()
+	if config.FailedLoginThreshold <= 0 {
+		config.FailedLoginThreshold = defaults.FailedLoginThreshold
+	}
+	if config.FailedLoginWindow <= 0 {
+		config.FailedLoginWindow = defaults.FailedLoginWindow
+	}
+	if config.ExportSpikeThreshold <= 0 {
+		config.ExportSpikeThreshold = defaults.ExportSpikeThreshold
+	}
+	if config.ExportSpikeWindow <= 0 {
+		config.ExportSpikeWindow = defaults.ExportSpikeWindow
+	}
+	if config.BusinessHoursStart < 0 || config.BusinessHoursEnd > 24 || config.BusinessHoursStart >= config.BusinessHoursEnd {
+		config.BusinessHoursStart = defaults.BusinessHoursStart
+		config.BusinessHoursEnd = defaults.BusinessHoursEnd
+	}
+	if config.BusinessLocation == nil {
+		config.BusinessLocation = defaults.BusinessLocation
+	}
+	return &AnomalyDetector{config: config}
+}
+
+// EventTypes returns the event types the rules read
+func (d *AnomalyDetector) EventTypes() []EventType {
+	return []EventType{
+		EventLoginFailed, EventSecDashboardLoginFailed,
+		EventBreakglassActivated,
+		EventDataExport, EventDataExportDownloaded,
+	}
+}
+
+// Lookback is how far back events must be loaded for Detect. Break-glass
+// activations are only seen while they are inside it, so the detector must
+// run more often than this.
+func (d *AnomalyDetector) Lookback() time.Duration {
+	if d.config.ExportSpikeWindow > d.config.FailedLoginWindow {
+		return d.config.ExportSpikeWindow
+	}
+	return d.config.FailedLoginWindow
+}
+
+// Detect evaluates every rule over events (any order) as of now
+func (d *AnomalyDetector) Detect(events []DetectionEvent, now time.Time) []Anomaly {
+	sorted := make([]DetectionEvent, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	var anomalies []Anomaly
+	anomalies = append(anomalies, d.failedLoginBursts(sorted, now)...)
+	anomalies = append(anomalies, d.offHoursBreakglass(sorted)...)
+	anomalies = append(anomalies, d.exportSpikes(sorted, now)...)
+	return anomalies
+}
+
+// failedLoginBursts flags IPs with at least the threshold of failed logins in the window
+func (d *AnomalyDetector) failedLoginBursts(events []DetectionEvent, now time.Time) []Anomaly {
+	since := now.Add(-d.config.FailedLoginWindow)
+	groups := groupEvents(events, since, func(e DetectionEvent) string {
+		if e.Type != EventLoginFailed && e.Type != EventSecDashboardLoginFailed {
+			return ""
+		}
+		return e.IP
+	})
+
+	var anomalies []Anomaly
+	for _, g := range groups {
+		if len(g.events) < d.config.FailedLoginThreshold {
+			continue
+		}
+		minutes := int(d.config.FailedLoginWindow.Minutes())
+		anomalies = append(anomalies, Anomaly{
+			Rule:       RuleFailedLoginBurst,
+			Severity:   SeverityHIGH,
+			DedupKey:   g.key,
+			Title:      fmt.Sprintf("%d failed logins from %s within %d minutes", len(g.events), g.key, minutes),
+			EventCount: len(g.events),
+			FirstSeen:  g.events[0].Timestamp,
+			LastSeen:   g.events[len(g.events)-1].Timestamp,
+			Details: map[string]interface{}{
+				"ip":             g.key,
+				"threshold":      d.config.FailedLoginThreshold,
+				"window_minutes": minutes,
+				"subjects":       distinctSubjects(g.events),
+			},
+		})
+	}
+	return anomalies
+}
+
+// offHoursBreakglass flags each break-glass activation outside weekday business hours
+func (d *AnomalyDetector) offHoursBreakglass(events []DetectionEvent) []Anomaly {
+	var anomalies []Anomaly
+	for _, e := range events {
+		if e.Type != EventBreakglassActivated || d.withinBusinessHours(e.Timestamp) {
+			continue
+		}
+		local := e.Timestamp.In(d.config.BusinessLocation)
+		anomalies = append(anomalies, Anomaly{
+			Rule:       RuleBreakglassOffHours,
+			Severity:   SeverityCRITICAL,
+			DedupKey:   strconv.FormatInt(e.ID, 10),
+			Title:      "Break-glass activated outside business hours at " + local.Format("Mon 2006-01-02 15:04 MST"),
+			EventCount: 1,
+			FirstSeen:  e.Timestamp,
+			LastSeen:   e.Timestamp,
+			Details: map[string]interface{}{
+				"event_id":       e.ID,
+				"subject":        e.SubjectValue,
+				"local_time":     local.Format(time.RFC3339),
+				"business_hours": fmt.Sprintf("Mon-Fri %02d:00-%02d:00 %s", d.config.BusinessHoursStart, d.config.BusinessHoursEnd, d.config.BusinessLocation),
+			},
+		})
+	}
+	return anomalies
+}
+
+// exportSpikes flags users with at least the threshold of export requests and downloads in the window
+func (d *AnomalyDetector) exportSpikes(events []DetectionEvent, now time.Time) []Anomaly {
+	since := now.Add(-d.config.ExportSpikeWindow)
+	groups := groupEvents(events, since, func(e DetectionEvent) string {
+		if e.Type != EventDataExport && e.Type != EventDataExportDownloaded {
+			return ""
+		}
+		return e.SubjectValue
+	})
+
+	var anomalies []Anomaly
+	for _, g := range groups {
+		if len(g.events) < d.config.ExportSpikeThreshold {
+			continue
+		}
+		requests, downloads := 0, 0
+		for _, e := range g.events {
+			if e.Type == EventDataExport {
+				requests++
+			} else {
+				downloads++
+			}
+		}
+		minutes := int(d.config.ExportSpikeWindow.Minutes())
+		anomalies = append(anomalies, Anomaly{
+			Rule:       RuleExportSpike,
+			Severity:   SeverityHIGH,
+			DedupKey:   g.key,
+			Title:      fmt.Sprintf("%d export requests and downloads by one user within %d minutes", len(g.events), minutes),
+			EventCount: len(g.events),
+			FirstSeen:  g.events[0].Timestamp,
+			LastSeen:   g.events[len(g.events)-1].Timestamp,
+			Details: map[string]interface{}{
+				"subject":        g.key,
+				"requests":       requests,
+				"downloads":      downloads,
+				"threshold":      d.config.ExportSpikeThreshold,
+				"window_minutes": minutes,
+			},
+		})
+	}
+	return anomalies
+}
+
+// withinBusinessHours reports whether t falls on a weekday between the configured hours
+func (d *AnomalyDetector) withinBusinessHours(t time.Time) bool {
+	local := t.In(d.config.BusinessLocation)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	return local.Hour() >= d.config.BusinessHoursStart && local.Hour() < d.config.BusinessHoursEnd
+}
+
+type eventGroup struct {
+	key    string
+	events []DetectionEvent
+}
+
+// groupEvents groups time-ordered events from since on by key, skipping empty keys.
+// Groups come back in order of their first event.
+func groupEvents(events []DetectionEvent, since time.Time, key func(DetectionEvent) string) []*eventGroup {
+	var groups []*eventGroup
+	byKey := map[string]*eventGroup{}
+	for _, e := range events {
+		if e.Timestamp.Before(since) {
+			continue
+		}
+		k := key(e)
+		if k == "" {
+			continue
+		}
+		g, ok := byKey[k]
+		if !ok {
+			g = &eventGroup{key: k}
+			byKey[k] = g
+			groups = append(groups, g)
+		}
+		g.events = append(g.events, e)
+	}
+	return groups
+}
+
+// distinctSubjects lists the (masked) subjects in events, first seen first
+func distinctSubjects(events []DetectionEvent) []string {
+	subjects := []string{}
+	seen := map[string]bool{}
+	for _, e := range events {
+		if e.SubjectValue == "" || seen[e.SubjectValue] {
+			continue
+		}
+		seen[e.SubjectValue] = true
+		subjects = append(subjects, e.SubjectValue)
+	}
+	return subjects
+}
@@ -0,0 +1,69 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnomalyDetectorFailedLoginBurst(t *testing.T) {
+	d := NewAnomalyDetector(AnomalyDetectorConfig{FailedLoginThreshold: 3})
+	now := time.Date(2026, 3, 4, 3, 0, 0, 0, time.UTC) // Wednesday 10:00 WIB
+
+	events := []DetectionEvent{
+		{ID: 1, Type: EventLoginFailed, IP: "203.0.113.7", SubjectValue: "a***@x.com", Timestamp: now.Add(-11 * time.Minute)}, // outside the window
+		{ID: 2, Type: EventLoginFailed, IP: "203.0.113.7", SubjectValue: "a***@x.com", Timestamp: now.Add(-9 * time.Minute)},
+		{ID: 3, Type: EventSecDashboardLoginFailed, IP: "203.0.113.7", SubjectValue: "b***@x.com", Timestamp: now.Add(-5 * time.Minute)},
+		{ID: 4, Type: EventLoginFailed, IP: "203.0.113.7", SubjectValue: "a***@x.com", Timestamp: now.Add(-time.Minute)},
+		{ID: 5, Type: EventLoginFailed, IP: "198.51.100.2", Timestamp: now.Add(-time.Minute)},
+		{ID: 6, Type: EventLoginFailed, Timestamp: now.Add(-time.Minute)}, // no IP
+	}
+
+	anomalies := d.Detect(events, now)
+	require.Len(t, anomalies, 1)
+	a := anomalies[0]
+	assert.Equal(t, RuleFailedLoginBurst, a.Rule)
+	assert.Equal(t, "203.0.113.7", a.DedupKey)
+	assert.Equal(t, 3, a.EventCount)
+	assert.Equal(t, now.Add(-9*time.Minute), a.FirstSeen)
+	assert.Equal(t, now.Add(-time.Minute), a.LastSeen)
+	assert.Equal(t, []string{"a***@x.com", "b***@x.com"}, a.Details["subjects"])
+}
+
+func TestAnomalyDetectorBreakglassOffHours(t *testing.T) {
+	d := NewAnomalyDetector(DefaultAnomalyDetectorConfig())
+	now := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+
+	events := []DetectionEvent{
+		{ID: 10, Type: EventBreakglassActivated, Timestamp: time.Date(2026, 3, 6, 3, 0, 0, 0, time.UTC)},   // Fri 10:00 WIB
+		{ID: 11, Type: EventBreakglassActivated, Timestamp: time.Date(2026, 3, 6, 11, 30, 0, 0, time.UTC)}, // Fri 18:30 WIB
+		{ID: 12, Type: EventBreakglassActivated, Timestamp: time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC)},   // Sat 10:00 WIB
+	}
+
+	anomalies := d.Detect(events, now)
+	require.Len(t, anomalies, 2)
+	assert.Equal(t, "11", anomalies[0].DedupKey)
+	assert.Equal(t, "12", anomalies[1].DedupKey)
+	assert.Equal(t, SeverityCRITICAL, anomalies[0].Severity)
+}
+
+func TestAnomalyDetectorExportSpike(t *testing.T) {
+	d := NewAnomalyDetector(AnomalyDetectorConfig{ExportSpikeThreshold: 3})
+	now := time.Date(2026, 3, 4, 3, 0, 0, 0, time.UTC)
+
+	events := []DetectionEvent{
+		{Type: EventDataExport, SubjectValue: "u1", Timestamp: now.Add(-50 * time.Minute)},
+		{Type: EventDataExportDownloaded, SubjectValue: "u1", Timestamp: now.Add(-20 * time.Minute)},
+		{Type: EventDataExportDownloaded, SubjectValue: "u1", Timestamp: now.Add(-10 * time.Minute)},
+		{Type: EventDataExport, SubjectValue: "u2", Timestamp: now.Add(-10 * time.Minute)},
+	}
+
+	anomalies := d.Detect(events, now)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, RuleExportSpike, anomalies[0].Rule)
+	assert.Equal(t, "u1", anomalies[0].DedupKey)
+	assert.Equal(t, 1, anomalies[0].Details["requests"])
+	assert.Equal(t, 2, anomalies[0].Details["downloads"])
+}
@@ -15,36 +15,54 @@ const (
 // Extended event types for security dashboard
 const (
 	// Administrative events
-	EventPasswordReset      EventType = "password_reset"
-	EventPasswordChange     EventType = "password_change"
-	EventRoleModified       EventType = "role_modified"
-	EventUserCreated        EventType = "user_created"
-	EventUserDeleted        EventType = "user_deleted"
-	EventUserDisabled       EventType = "user_disabled"
-	EventConfigChanged      EventType = "config_changed"
-	EventDataExport         EventType = "data_export"
-	EventDataExportApproved EventType = "data_export_approved"
-	EventDataExportRejected EventType = "data_export_rejected"
+	EventPasswordReset        EventType = "password_reset"
+	EventPasswordChange       EventType = "password_change"
+	EventRoleModified         EventType = "role_modified"
+	EventUserCreated          EventType = "user_created"
+	EventUserDeleted          EventType = "user_deleted"
+	EventUserDisabled         EventType = "user_disabled"
+	EventConfigChanged        EventType = "config_changed"
+	EventDataExport           EventType = "data_export"
+	EventDataExportApproved   EventType = "data_export_approved"
+	EventDataExportRejected   EventType = "data_export_rejected"
+	EventDataExportDownloaded EventType = "data_export_downloaded"
 
 	// Error and anomaly events
 	EventServerError     EventType = "server_error"
 	EventSuspiciousInput EventType = "suspicious_input"
 	EventCSRFViolation   EventType = "csrf_violation"
 
+	// Anomaly detection raised a new alert
+	EventSecurityAlertRaised EventType = "security_alert_raised"
+
 	// Break-glass events
 	EventBreakglassActivated EventType = "breakglass_activated"
 	EventBreakglassExpired   EventType = "breakglass_expired"
 	EventBreakglassRevoked   EventType = "breakglass_revoked"
 
+	// Break-glass dual approval transitions
+	EventBreakglassRequested EventType = "breakglass_requested"
+	EventBreakglassApproved  EventType = "breakglass_approved"
+	EventBreakglassDenied    EventType = "breakglass_denied"
+
 	// Log integrity events
 	EventHashAnchorCreated EventType = "hash_anchor_created"
 	EventHashChainBreak    EventType = "hash_chain_break"
 
+	// Anchoring dead-man switch: no anchor heartbeat within the window
+	EventAnchorHeartbeatMissed EventType = "anchor_heartbeat_missed"
+
 	// Security dashboard auth events
 	EventSecDashboardLogin       EventType = "sec_dashboard_login"
 	EventSecDashboardLoginFailed EventType = "sec_dashboard_login_failed"
 	EventSecDashboardLogout      EventType = "sec_dashboard_logout"
 	EventIPDenied                EventType = "ip_denied"
+
+	// Session events
+	EventRefreshTokenReuse EventType = "refresh_token_reuse"
+
+	// Account events reported by Supabase auth webhooks
+	EventEmailConfirmed EventType = "email_confirmed"
 )
 
 // EventSeverityMap defines the hard-coded severity for each event type
@@ -58,12 +76,14 @@ var EventSeverityMap = map[EventType]Severity{
 	EventSecDashboardLogout: SeverityINFO,
 	EventHashAnchorCreated:  SeverityINFO,
 	EventBreakglassExpired:  SeverityINFO,
+	EventEmailConfirmed:     SeverityINFO,
 
 	// MEDIUM - Notable but not urgent
-	EventPasswordReset:  SeverityMEDIUM,
-	EventPasswordChange: SeverityMEDIUM,
-	EventDataExport:     SeverityMEDIUM,
-	EventServerError:    SeverityMEDIUM,
+	EventPasswordReset:        SeverityMEDIUM,
+	EventPasswordChange:       SeverityMEDIUM,
+	EventDataExport:           SeverityMEDIUM,
+	EventDataExportDownloaded: SeverityMEDIUM,
+	EventServerError:          SeverityMEDIUM,
 
 	// WARN - Potential issues, monitor
 	EventLoginFailed:             SeverityWARN,
@@ -86,9 +106,16 @@ var EventSeverityMap = map[EventType]Severity{
 	EventDataExportRejected: SeverityHIGH,
 	EventIPDenied:           SeverityHIGH,
 	EventBreakglassRevoked:  SeverityHIGH,
+	EventRefreshTokenReuse:  SeverityHIGH,
+
+	EventAnchorHeartbeatMissed: SeverityHIGH,
+	EventSecurityAlertRaised:   SeverityHIGH,
 
 	// CRITICAL - Immediate attention required
 	EventBreakglassActivated: SeverityCRITICAL,
+	EventBreakglassRequested: SeverityCRITICAL,
+	EventBreakglassApproved:  SeverityCRITICAL,
+	EventBreakglassDenied:    SeverityCRITICAL,
 	EventHashChainBreak:      SeverityCRITICAL,
 }
 
@@ -0,0 +1,77 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3ExportStore keeps generated security exports in a private bucket and
+// hands out short-lived presigned GET URLs for download
+type S3ExportStore struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	bucket  string
+}
+
+// NewS3ExportStore creates an export store on an existing S3 client
+func NewS3ExportStore(client *s3.Client, bucket string) *S3ExportStore {
+	return &S3ExportStore{
+		client:  client,
+		presign: s3.NewPresignClient(client),
+		bucket:  bucket,
+	}
+}
+
+// NewS3ExportStoreFromEnv creates an export store using the shared S3_* credentials
+// and SECURITY_EXPORT_BUCKET. Returns nil when the bucket is not configured.
+func NewS3ExportStoreFromEnv(ctx context.Context) (*S3ExportStore, error) {
+	return NewS3ExportStoreForBucket(ctx, os.Getenv("SECURITY_EXPORT_BUCKET"))
+}
+
+// NewS3ExportStoreForBucket creates an export store on bucket using the shared
+// S3_* credentials. Returns nil when bucket is empty.
+func NewS3ExportStoreForBucket(ctx context.Context, bucket string) (*S3ExportStore, error) {
+	if bucket == "" {
+		return nil, nil
+	}
+
+	cfg := NewS3ClientConfigFromEnv()
+	cfg.Bucket = bucket
+	client, err := NewS3Client(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewS3ExportStore(client, bucket), nil
+}
+
+// Put uploads an export file
+func (s *S3ExportStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload export: %w", err)
+	}
+	return nil
+}
+
+// PresignGet returns a signed download URL valid for ttl
+func (s *S3ExportStore) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign export URL: %w", err)
+	}
+	return req.URL, nil
+}
@@ -0,0 +1,59 @@
+package security
+
+import (
+	"image"
+	"image/color"
+	"math/bits"
+)
+
+// dHash grid: 9x8 cells give 8 horizontal gradients per row, 64 bits in total
+const (
+	imageHashWidth  = 9
+	imageHashHeight = 8
+)
+
+// PerceptualHash returns the difference hash (dHash) of img. The image is reduced
+// to a 9x8 grid of average brightness and each bit records whether a cell is
+// brighter than its right neighbour, so re-encoding, resizing and small edits of
+// the same picture give hashes only a few bits apart.
+func PerceptualHash(img image.Image) uint64 {
+	b := img.Bounds()
+	if b.Empty() {
+		return 0
+	}
+
+	var sums [imageHashHeight][imageHashWidth]float64
+	var counts [imageHashHeight][imageHashWidth]int
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := (y - b.Min.Y) * imageHashHeight / b.Dy()
+		for x := b.Min.X; x < b.Max.X; x++ {
+			col := (x - b.Min.X) * imageHashWidth / b.Dx()
+			gray := color.Gray16Model.Convert(img.At(x, y)).(color.Gray16)
+			sums[row][col] += float64(gray.Y)
+			counts[row][col]++
+		}
+	}
+
+	var hash uint64
+	for row := 0; row < imageHashHeight; row++ {
+		for col := 0; col < imageHashWidth-1; col++ {
+			hash <<= 1
+			if average(sums[row][col], counts[row][col]) > average(sums[row][col+1], counts[row][col+1]) {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// HashDistance is the number of differing bits between two perceptual hashes
+func HashDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func average(sum float64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
@@ -0,0 +1,58 @@
+package security
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// certificateImage draws a simple document: a light page with dark text bars
+func certificateImage(w, h int, bars []image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{245, 240, 225, 255}), image.Point{}, draw.Src)
+	for _, bar := range bars {
+		r := image.Rect(bar.Min.X*w/100, bar.Min.Y*h/100, bar.Max.X*w/100, bar.Max.Y*h/100)
+		draw.Draw(img, r, image.NewUniform(color.RGBA{30, 30, 60, 255}), image.Point{}, draw.Src)
+	}
+	return img
+}
+
+func TestPerceptualHash(t *testing.T) {
+	jlpt := []image.Rectangle{
+		image.Rect(10, 8, 90, 18), image.Rect(20, 30, 60, 36), image.Rect(20, 45, 80, 50),
+		image.Rect(5, 60, 35, 90), image.Rect(65, 70, 95, 85),
+	}
+	other := []image.Rectangle{
+		image.Rect(60, 5, 95, 40), image.Rect(5, 50, 50, 55), image.Rect(40, 75, 70, 95),
+	}
+
+	original := certificateImage(1200, 900, jlpt)
+	hash := PerceptualHash(original)
+
+	// A resized copy of the same certificate (nearest neighbour)
+	resized := image.NewRGBA(image.Rect(0, 0, 640, 480))
+	for y := 0; y < 480; y++ {
+		for x := 0; x < 640; x++ {
+			resized.Set(x, y, original.At(x*1200/640, y*900/480))
+		}
+	}
+	assert.LessOrEqual(t, HashDistance(hash, PerceptualHash(resized)), 4)
+
+	// A copy with a different name written on it
+	edited := certificateImage(1200, 900, append(jlpt[:len(jlpt):len(jlpt)], image.Rect(22, 37, 40, 40)))
+	assert.LessOrEqual(t, HashDistance(hash, PerceptualHash(edited)), 6)
+
+	// A different document
+	assert.Greater(t, HashDistance(hash, PerceptualHash(certificateImage(1200, 900, other))), 10)
+
+	assert.Equal(t, uint64(0), PerceptualHash(image.NewRGBA(image.Rectangle{})))
+}
+
+func TestHashDistance(t *testing.T) {
+	assert.Equal(t, 0, HashDistance(0xF0F0, 0xF0F0))
+	assert.Equal(t, 64, HashDistance(0, ^uint64(0)))
+	assert.Equal(t, 2, HashDistance(0b1010, 0b0110))
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -34,8 +35,10 @@ type IntegrityReport struct {
 	ChainBreaks       int64     `json:"chainBreaks"`
 	MissingAnchors    int64     `json:"missingAnchors"`
 	AnchorMismatches  int64     `json:"anchorMismatches"`
-	Status            string    `json:"status"` // "intact", "degraded", "compromised"
+	Status            string    `json:"status"`                // "intact", "degraded", "compromised"
+	StatusLabel       string    `json:"statusLabel,omitempty"` // Status in the operator's language, set by the dashboard
 	FirstBreakEventID *int64    `json:"firstBreakEventId,omitempty"`
+	FailedDates       []string  `json:"failedDates,omitempty"` // YYYY-MM-DD with a chain break or anchor mismatch
 	Details           []string  `json:"details,omitempty"`
 }
 
@@ -239,6 +242,12 @@ func (s *LogIntegrityService) AnchorToS3(ctx context.Context, date time.Time, ro
 		return fmt.Errorf("failed to record anchor in database: %w", err)
 	}
 
+	// Heartbeat for the dead-man switch: its absence means anchoring has stopped
+	_, err = s.db.Exec(ctx, `INSERT INTO anchor_heartbeats (anchor_date, event_count) VALUES ($1, $2)`, date, eventCount)
+	if err != nil {
+		return fmt.Errorf("failed to record anchor heartbeat: %w", err)
+	}
+
 	// Log anchor creation
 	s.logger.Log(ctx, SecurityEvent{
 		Event: EventHashAnchorCreated,
@@ -261,7 +270,7 @@ func (s *LogIntegrityService) VerifyIntegrity(ctx context.Context, startDate, en
 	}
 
 	// Verify hash chain
-	chainBreaks, firstBreak, err := s.verifyHashChain(ctx, startDate, endDate)
+	chainBreaks, firstBreak, breakDates, err := s.verifyHashChain(ctx, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
@@ -271,13 +280,22 @@ func (s *LogIntegrityService) VerifyIntegrity(ctx context.Context, startDate, en
 	}
 
 	// Verify against external anchors
-	anchorMismatches, missingAnchors, err := s.verifyAnchors(ctx, startDate, endDate)
+	anchorMismatches, missingAnchors, mismatchDates, err := s.verifyAnchors(ctx, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 	report.AnchorMismatches = anchorMismatches
 	report.MissingAnchors = missingAnchors
 
+	failed := map[string]bool{}
+	for _, d := range append(breakDates, mismatchDates...) {
+		if !failed[d] {
+			failed[d] = true
+			report.FailedDates = append(report.FailedDates, d)
+		}
+	}
+	sort.Strings(report.FailedDates)
+
 	// Determine overall status
 	if chainBreaks > 0 || anchorMismatches > 0 {
 		report.Status = "compromised"
@@ -299,8 +317,8 @@ func (s *LogIntegrityService) VerifyIntegrity(ctx context.Context, startDate, en
 	return report, nil
 }
 
-// verifyHashChain verifies the internal hash chain
-func (s *LogIntegrityService) verifyHashChain(ctx context.Context, startDate, endDate time.Time) (int64, int64, error) {
+// verifyHashChain verifies the internal hash chain and returns the days containing breaks
+func (s *LogIntegrityService) verifyHashChain(ctx context.Context, startDate, endDate time.Time) (int64, int64, []string, error) {
 	query := `
 		SELECT id, event_type, created_at, subject_value, ip_address, details, previous_hash, row_hash
 		FROM security_events
@@ -309,13 +327,24 @@ func (s *LogIntegrityService) verifyHashChain(ctx context.Context, startDate, en
 	`
 	rows, err := s.db.Query(ctx, query, startDate, endDate)
 	if err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 	defer rows.Close()
 
 	var chainBreaks int64
 	var firstBreak int64
 	var previousHash string
+	var breakDates []string
+	markBreak := func(id int64, at time.Time) {
+		chainBreaks++
+		if firstBreak == 0 {
+			firstBreak = id
+		}
+		day := at.UTC().Format("2006-01-02")
+		if len(breakDates) == 0 || breakDates[len(breakDates)-1] != day {
+			breakDates = append(breakDates, day)
+		}
+	}
 
 	for rows.Next() {
 		var id int64
@@ -325,7 +354,7 @@ func (s *LogIntegrityService) verifyHashChain(ctx context.Context, startDate, en
 		var details []byte
 
 		if err := rows.Scan(&id, &eventType, &createdAt, &subjectValue, &ipAddress, &details, &prevHash, &rowHash); err != nil {
-			return 0, 0, err
+			return 0, 0, nil, err
 		}
 
 		// Skip events without hash chain (pre-migration)
@@ -335,10 +364,7 @@ func (s *LogIntegrityService) verifyHashChain(ctx context.Context, startDate, en
 
 		// Verify previous_hash matches last row's row_hash
 		if previousHash != "" && *prevHash != previousHash {
-			chainBreaks++
-			if firstBreak == 0 {
-				firstBreak = id
-			}
+			markBreak(id, createdAt)
 		}
 
 		// Verify row_hash is correct
@@ -357,21 +383,19 @@ func (s *LogIntegrityService) verifyHashChain(ctx context.Context, startDate, en
 
 		expectedHash := ComputeEventHash(id, eventType, createdAt, subjectStr, ipStr, string(details), prevHashStr)
 		if *rowHash != expectedHash {
-			chainBreaks++
-			if firstBreak == 0 {
-				firstBreak = id
-			}
+			markBreak(id, createdAt)
 		}
 
 		previousHash = *rowHash
 	}
 
-	return chainBreaks, firstBreak, nil
+	return chainBreaks, firstBreak, breakDates, nil
 }
 
-// verifyAnchors verifies computed hashes against S3 anchors
-func (s *LogIntegrityService) verifyAnchors(ctx context.Context, startDate, endDate time.Time) (int64, int64, error) {
+// verifyAnchors verifies computed hashes against S3 anchors and returns the mismatched days
+func (s *LogIntegrityService) verifyAnchors(ctx context.Context, startDate, endDate time.Time) (int64, int64, []string, error) {
 	var anchorMismatches, missingAnchors int64
+	var mismatchDates []string
 
 	// Iterate through each day
 	for d := startDate; d.Before(endDate) || d.Equal(endDate); d = d.AddDate(0, 0, 1) {
@@ -387,15 +411,16 @@ func (s *LogIntegrityService) verifyAnchors(ctx context.Context, startDate, endD
 		// Recompute hash for the day
 		computedHash, count, _, _, err := s.ComputeDailyRootHash(ctx, d)
 		if err != nil {
-			return 0, 0, err
+			return 0, 0, nil, err
 		}
 
 		if count > 0 && computedHash != storedHash {
 			anchorMismatches++
+			mismatchDates = append(mismatchDates, d.Format("2006-01-02"))
 		}
 	}
 
-	return anchorMismatches, missingAnchors, nil
+	return anchorMismatches, missingAnchors, mismatchDates, nil
 }
 
 // Helper functions
@@ -18,15 +18,18 @@ func NewSecurityEventRepository(db *pgxpool.Pool) *SecurityEventRepository {
 	return &SecurityEventRepository{db: db}
 }
 
-// PersistEvent inserts a security event into the database
+// PersistEvent inserts a security event into the database, with the client
+// parsed from the user agent and the severity derived from both
 func (r *SecurityEventRepository) PersistEvent(ctx context.Context, event SecurityEvent) error {
 	query := `
 		INSERT INTO security_events (
 			event_type, service, environment, level,
 			subject_type, subject_value, ip_address, user_agent,
-			request_id, details, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			request_id, details, created_at,
+			severity, ua_browser, ua_os, ua_device_class, ua_scanner
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
+	client := ParseUserAgent(event.UserAgent)
 
 	// Convert details to JSON
 	var detailsJSON []byte
@@ -54,6 +57,11 @@ func (r *SecurityEventRepository) PersistEvent(ctx context.Context, event Securi
 		event.RequestID,
 		string(detailsJSON),
 		event.Timestamp,
+		string(GetSeverityForClient(event.Event, client)),
+		client.Browser,
+		client.OS,
+		client.DeviceClass,
+		client.Scanner,
 	)
 
 	if err != nil {
@@ -3,6 +3,7 @@ package security
 import (
 	"context"
 	"fmt"
+	"go-recruitment-backend/pkg/tracing"
 	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -93,16 +94,21 @@ func NewS3Client(ctx context.Context, cfg S3ClientConfig) (*s3.Client, error) {
 	// Create S3 client with provider-specific options
 	var s3Client *s3.Client
 
+	// Requests made inside a trace get a client span
+	traced := func(o *s3.Options) {
+		o.HTTPClient = tracing.WrapClient(o.HTTPClient)
+	}
+
 	switch cfg.Provider {
 	case S3ProviderWasabi:
 		// Wasabi requires custom endpoint and path-style addressing
-		s3Client = s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		s3Client = s3.NewFromConfig(awsCfg, traced, func(o *s3.Options) {
 			o.BaseEndpoint = aws.String("https://" + cfg.WasabiEndpoint)
 			o.UsePathStyle = true // Wasabi requires path-style
 		})
 	default:
 		// AWS S3 - use default configuration
-		s3Client = s3.NewFromConfig(awsCfg)
+		s3Client = s3.NewFromConfig(awsCfg, traced)
 	}
 
 	return s3Client, nil
@@ -2,6 +2,7 @@ package security
 
 import (
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/subtle"
@@ -10,10 +11,12 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"time"
 
+	"go-recruitment-backend/pkg/i18n"
+
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/pquerna/otp/totp"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -40,6 +43,7 @@ type SecurityUser struct {
 	LastLoginIP         string       `json:"lastLoginIP,omitempty"`
 	FailedLoginAttempts int          `json:"-"`
 	LockedUntil         *time.Time   `json:"-"`
+	PreferredLocale     *string      `json:"preferredLocale,omitempty"` // en or id; nil follows Accept-Language
 	CreatedAt           time.Time    `json:"createdAt"`
 	UpdatedAt           time.Time    `json:"updatedAt"`
 }
@@ -66,8 +70,30 @@ type BreakGlassSession struct {
 	ExpiresAt      time.Time  `json:"expiresAt"`
 	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
 	RevokedReason  string     `json:"revokedReason,omitempty"`
+	// Dual approval. While PENDING, ActivatedAt is the request time and
+	// ExpiresAt the approval deadline.
+	Status          string     `json:"status"`
+	DurationMinutes int        `json:"durationMinutes"`
+	Username        string     `json:"username,omitempty"` // requester, set on pending lists
+	DecidedBy       string     `json:"decidedBy,omitempty"`
+	DecidedAt       *time.Time `json:"decidedAt,omitempty"`
+	DenialReason    string     `json:"denialReason,omitempty"`
 }
 
+// Break-glass session statuses
+const (
+	BreakGlassPending  = "PENDING"
+	BreakGlassApproved = "APPROVED"
+	BreakGlassDenied   = "DENIED"
+)
+
+// Break-glass approval errors
+var (
+	ErrBreakGlassNotPending      = errors.New("break-glass request is not pending")
+	ErrBreakGlassSelfApproval    = errors.New("break-glass requests need a second security admin")
+	ErrBreakGlassApprovalExpired = errors.New("break-glass request was not approved in time")
+)
+
 // AllowedIPRange represents an IP range allowed to access the security dashboard
 type AllowedIPRange struct {
 	ID          int       `json:"id"`
@@ -79,11 +105,13 @@ type AllowedIPRange struct {
 
 // SecurityAuthService handles authentication for the security dashboard
 type SecurityAuthService struct {
-	db           *pgxpool.Pool
+	repo         SecurityAuthRepository
 	logger       *SecurityLogger
+	now          func() time.Time
 	sessionTTL   time.Duration
 	maxAttempts  int
 	lockDuration time.Duration
+	dualApproval bool
 }
 
 // SecurityAuthConfig holds configuration for the security auth service
@@ -91,6 +119,8 @@ type SecurityAuthConfig struct {
 	SessionTTL   time.Duration // Default: 30 minutes
 	MaxAttempts  int           // Default: 5
 	LockDuration time.Duration // Default: 15 minutes
+	// BreakGlassDualApproval makes break-glass wait for a second SECURITY_ADMIN
+	BreakGlassDualApproval bool
 }
 
 // DefaultSecurityAuthConfig returns sensible defaults
@@ -102,14 +132,32 @@ func DefaultSecurityAuthConfig() SecurityAuthConfig {
 	}
 }
 
-// NewSecurityAuthService creates a new security auth service
+// DashboardLocales are the languages the security dashboard is translated into;
+// English is the source language of its messages
+var DashboardLocales = []string{i18n.LocaleEN, i18n.LocaleID}
+
+// Break-glass limits
+const (
+	minBreakGlassJustification = 50
+	maxBreakGlassMinutes       = 60
+	breakGlassApprovalWindow   = 10 * time.Minute
+)
+
+// NewSecurityAuthService creates a new security auth service backed by Postgres
 func NewSecurityAuthService(db *pgxpool.Pool, config SecurityAuthConfig) *SecurityAuthService {
+	return NewSecurityAuthServiceWithRepository(NewPostgresSecurityAuthRepository(db), config)
+}
+
+// NewSecurityAuthServiceWithRepository creates a security auth service on any storage
+func NewSecurityAuthServiceWithRepository(repo SecurityAuthRepository, config SecurityAuthConfig) *SecurityAuthService {
 	return &SecurityAuthService{
-		db:           db,
+		repo:         repo,
 		logger:       DefaultLogger(),
+		now:          time.Now,
 		sessionTTL:   config.SessionTTL,
 		maxAttempts:  config.MaxAttempts,
 		lockDuration: config.LockDuration,
+		dualApproval: config.BreakGlassDualApproval,
 	}
 }
 
@@ -121,22 +169,12 @@ func (s *SecurityAuthService) ValidateIP(ctx context.Context, ipStr string) (boo
 		return false, fmt.Errorf("invalid IP address: %s", ipStr)
 	}
 
-	query := `
-		SELECT cidr FROM allowed_ip_ranges 
-		WHERE is_active = true
-	`
-	rows, err := s.db.Query(ctx, query)
+	cidrs, err := s.repo.ListActiveIPRanges(ctx)
 	if err != nil {
-		return false, fmt.Errorf("failed to query allowed IPs: %w", err)
+		return false, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var cidrStr string
-		if err := rows.Scan(&cidrStr); err != nil {
-			continue
-		}
 
+	for _, cidrStr := range cidrs {
 		_, network, err := net.ParseCIDR(cidrStr)
 		if err != nil {
 			continue
@@ -171,16 +209,24 @@ func (s *SecurityAuthService) Authenticate(ctx context.Context, username, passwo
 	}
 
 	// Load user
-	user, err := s.getUserByUsername(ctx, username)
+	user, err := s.repo.GetActiveUserByUsername(ctx, username)
 	if err != nil {
 		s.logFailedLogin(ctx, username, ip, userAgent, "user_not_found")
 		return nil, errors.New("invalid credentials")
 	}
 
-	// Check if locked
-	if user.LockedUntil != nil && user.LockedUntil.After(time.Now()) {
-		s.logFailedLogin(ctx, username, ip, userAgent, "account_locked")
-		return nil, fmt.Errorf("account locked until %s", user.LockedUntil.Format(time.RFC3339))
+	// Check if locked; an expired lock starts a fresh attempt window
+	now := s.now()
+	if user.LockedUntil != nil {
+		if user.LockedUntil.After(now) {
+			s.logFailedLogin(ctx, username, ip, userAgent, "account_locked")
+			return nil, fmt.Errorf("account locked until: %s", user.LockedUntil.Format(time.RFC3339))
+		}
+		if err := s.repo.ClearFailedAttempts(ctx, user.ID); err != nil {
+			return nil, fmt.Errorf("failed to reset lockout: %w", err)
+		}
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = nil
 	}
 
 	// Validate password
@@ -198,8 +244,7 @@ func (s *SecurityAuthService) ValidateTOTP(ctx context.Context, user *SecurityUs
 		return false, errors.New("TOTP not enabled for this user")
 	}
 
-	valid := totp.Validate(code, user.TOTPSecret)
-	if !valid {
+	if !ValidateTOTPCode(code, user.TOTPSecret, s.now()) {
 		s.logFailedLogin(ctx, user.Username, "", "", "invalid_totp")
 		return false, nil
 	}
@@ -215,31 +260,22 @@ func (s *SecurityAuthService) CreateSession(ctx context.Context, userID, ip, use
 		return nil, "", fmt.Errorf("failed to generate session token: %w", err)
 	}
 	token := hex.EncodeToString(tokenBytes)
-	tokenHash := hashToken(token)
-
-	expiresAt := time.Now().Add(s.sessionTTL)
 
-	query := `
-		INSERT INTO security_sessions (security_user_id, token_hash, ip_address, user_agent, expires_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at
-	`
+	now := s.now()
 	session := &SecuritySession{
 		SecurityUserID: userID,
-		TokenHash:      tokenHash,
+		TokenHash:      hashToken(token),
 		IPAddress:      ip,
 		UserAgent:      userAgent,
-		ExpiresAt:      expiresAt,
+		ExpiresAt:      now.Add(s.sessionTTL),
 	}
 
-	err := s.db.QueryRow(ctx, query, userID, tokenHash, ip, userAgent, expiresAt).
-		Scan(&session.ID, &session.CreatedAt)
-	if err != nil {
+	if err := s.repo.CreateSession(ctx, session); err != nil {
 		return nil, "", fmt.Errorf("failed to create session: %w", err)
 	}
 
-	// Update last login
-	s.updateLastLogin(ctx, userID, ip)
+	// Update last login (also clears failed attempts and any lock)
+	s.repo.RecordLogin(ctx, userID, ip, now)
 
 	// Log successful login
 	s.logger.Log(ctx, SecurityEvent{
@@ -255,29 +291,13 @@ func (s *SecurityAuthService) CreateSession(ctx context.Context, userID, ip, use
 
 // ValidateSession validates a session token and returns the session if valid
 func (s *SecurityAuthService) ValidateSession(ctx context.Context, token, ip string) (*SecuritySession, *SecurityUser, error) {
-	tokenHash := hashToken(token)
-
-	query := `
-		SELECT ss.id, ss.security_user_id, ss.ip_address, ss.user_agent, ss.created_at, ss.expires_at,
-		       su.id, su.username, su.email, su.role, su.totp_enabled, su.is_active
-		FROM security_sessions ss
-		JOIN security_users su ON ss.security_user_id = su.id
-		WHERE ss.token_hash = $1 
-		  AND ss.expires_at > NOW()
-		  AND ss.revoked_at IS NULL
-		  AND su.is_active = true
-	`
-
-	var session SecuritySession
-	var user SecurityUser
-	err := s.db.QueryRow(ctx, query, tokenHash).Scan(
-		&session.ID, &session.SecurityUserID, &session.IPAddress, &session.UserAgent,
-		&session.CreatedAt, &session.ExpiresAt,
-		&user.ID, &user.Username, &user.Email, &user.Role, &user.TOTPEnabled, &user.IsActive,
-	)
+	session, user, err := s.repo.GetSessionByTokenHash(ctx, hashToken(token))
 	if err != nil {
 		return nil, nil, errors.New("invalid or expired session")
 	}
+	if session.RevokedAt != nil || !session.ExpiresAt.After(s.now()) || !user.IsActive {
+		return nil, nil, errors.New("invalid or expired session")
+	}
 
 	// Validate IP matches session IP (strict session binding)
 	if session.IPAddress != ip {
@@ -295,50 +315,77 @@ func (s *SecurityAuthService) ValidateSession(ctx context.Context, token, ip str
 		return nil, nil, errors.New("session IP mismatch")
 	}
 
-	return &session, &user, nil
+	return session, user, nil
+}
+
+// SessionCSRFToken derives the CSRF token of a session: an HMAC keyed by the
+// session token. It needs no storage, changes with every session, and cannot be
+// computed without the session token, which browsers only hold in an HttpOnly cookie.
+func SessionCSRFToken(sessionToken string) string {
+	mac := hmac.New(sha256.New, []byte(sessionToken))
+	mac.Write([]byte("security-dashboard-csrf"))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateCSRFToken reports whether csrfToken was issued for the session
+func ValidateCSRFToken(sessionToken, csrfToken string) bool {
+	if sessionToken == "" || csrfToken == "" {
+		return false
+	}
+	return hmac.Equal([]byte(SessionCSRFToken(sessionToken)), []byte(csrfToken))
 }
 
 // RevokeSession revokes an active session
 func (s *SecurityAuthService) RevokeSession(ctx context.Context, sessionID, reason string) error {
-	query := `
-		UPDATE security_sessions 
-		SET revoked_at = NOW(), revoked_reason = $2
-		WHERE id = $1 AND revoked_at IS NULL
-	`
-	_, err := s.db.Exec(ctx, query, sessionID, reason)
-	return err
+	return s.repo.RevokeSession(ctx, sessionID, reason, s.now())
 }
 
-// ActivateBreakGlass creates a time-limited DEVELOPER_ROOT elevation
+// ActivateBreakGlass creates a time-limited DEVELOPER_ROOT elevation. With dual
+// approval it creates a PENDING request that a second SECURITY_ADMIN must
+// approve within the approval window.
 func (s *SecurityAuthService) ActivateBreakGlass(ctx context.Context, userID, justification string, durationMinutes int) (*BreakGlassSession, error) {
-	if len(justification) < 50 {
+	if len(justification) < minBreakGlassJustification {
 		return nil, errors.New("justification must be at least 50 characters")
 	}
 
-	if durationMinutes > 60 {
+	if durationMinutes <= 0 {
+		return nil, errors.New("break-glass duration must be positive")
+	}
+	if durationMinutes > maxBreakGlassMinutes {
 		return nil, errors.New("break-glass duration cannot exceed 60 minutes")
 	}
 
-	expiresAt := time.Now().Add(time.Duration(durationMinutes) * time.Minute)
-
-	query := `
-		INSERT INTO break_glass_sessions (security_user_id, justification, expires_at)
-		VALUES ($1, $2, $3)
-		RETURNING id, activated_at
-	`
-
 	session := &BreakGlassSession{
-		SecurityUserID: userID,
-		Justification:  justification,
-		ExpiresAt:      expiresAt,
+		SecurityUserID:  userID,
+		Justification:   justification,
+		ExpiresAt:       s.now().Add(time.Duration(durationMinutes) * time.Minute),
+		Status:          BreakGlassApproved,
+		DurationMinutes: durationMinutes,
+	}
+	if s.dualApproval {
+		session.Status = BreakGlassPending
+		session.ExpiresAt = s.now().Add(breakGlassApprovalWindow)
 	}
 
-	err := s.db.QueryRow(ctx, query, userID, justification, expiresAt).
-		Scan(&session.ID, &session.ActivatedAt)
-	if err != nil {
+	if err := s.repo.CreateBreakGlassSession(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to activate break-glass: %w", err)
 	}
 
+	if session.Status == BreakGlassPending {
+		s.logger.Log(ctx, SecurityEvent{
+			Event:        EventBreakglassRequested,
+			SubjectType:  "break_glass_session",
+			SubjectValue: session.ID,
+			Details: map[string]interface{}{
+				"requester_id":      HashValue(userID),
+				"duration_minutes":  durationMinutes,
+				"approval_deadline": session.ExpiresAt,
+				"justification":     justification[:min(100, len(justification))],
+			},
+		})
+		return session, nil
+	}
+
 	// Log CRITICAL event
 	s.logger.Log(ctx, SecurityEvent{
 		Event:        EventBreakglassActivated,
@@ -355,41 +402,131 @@ func (s *SecurityAuthService) ActivateBreakGlass(ctx context.Context, userID, ju
 
 // CheckBreakGlassActive checks if user has an active break-glass session
 func (s *SecurityAuthService) CheckBreakGlassActive(ctx context.Context, userID string) (*BreakGlassSession, bool, error) {
-	query := `
-		SELECT id, justification, activated_at, expires_at
-		FROM break_glass_sessions
-		WHERE security_user_id = $1 
-		  AND expires_at > NOW()
-		  AND revoked_at IS NULL
-		ORDER BY activated_at DESC
-		LIMIT 1
-	`
-
-	var session BreakGlassSession
-	err := s.db.QueryRow(ctx, query, userID).Scan(
-		&session.ID, &session.Justification, &session.ActivatedAt, &session.ExpiresAt,
-	)
-	if err != nil {
+	session, err := s.repo.GetLatestBreakGlassSession(ctx, userID)
+	if err != nil || session.Status != BreakGlassApproved || !session.ExpiresAt.After(s.now()) {
 		return nil, false, nil // No active session
 	}
 
-	session.SecurityUserID = userID
-	return &session, true, nil
+	return session, true, nil
+}
+
+// CheckBreakGlassPending checks if user has a request awaiting a second admin
+func (s *SecurityAuthService) CheckBreakGlassPending(ctx context.Context, userID string) (*BreakGlassSession, bool, error) {
+	session, err := s.repo.GetLatestBreakGlassSession(ctx, userID)
+	if err != nil || session.Status != BreakGlassPending || !session.ExpiresAt.After(s.now()) {
+		return nil, false, nil // Nothing awaiting approval
+	}
+
+	return session, true, nil
+}
+
+// ListPendingBreakGlass returns the requests still inside their approval window
+func (s *SecurityAuthService) ListPendingBreakGlass(ctx context.Context) ([]BreakGlassSession, error) {
+	return s.repo.ListPendingBreakGlassSessions(ctx, s.now())
+}
+
+// ApproveBreakGlass activates a pending request for its requested duration,
+// counted from the approval
+func (s *SecurityAuthService) ApproveBreakGlass(ctx context.Context, sessionID, approverID string) (*BreakGlassSession, error) {
+	session, err := s.pendingBreakGlass(ctx, sessionID, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	expiresAt := now.Add(time.Duration(session.DurationMinutes) * time.Minute)
+	approved, err := s.repo.ApproveBreakGlassSession(ctx, sessionID, approverID, now, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve break-glass: %w", err)
+	}
+	if !approved {
+		return nil, ErrBreakGlassNotPending // decided or revoked meanwhile
+	}
+	session.Status = BreakGlassApproved
+	session.ActivatedAt = now
+	session.ExpiresAt = expiresAt
+	session.DecidedBy = approverID
+	session.DecidedAt = &now
+
+	s.logger.Log(ctx, SecurityEvent{
+		Event:        EventBreakglassApproved,
+		SubjectType:  "break_glass_session",
+		SubjectValue: sessionID,
+		Details: map[string]interface{}{
+			"approver_id":  HashValue(approverID),
+			"requester_id": HashValue(session.SecurityUserID),
+		},
+	})
+	// The session is active now; logged like a single-admin activation so the
+	// off-hours rule and alerting see it
+	s.logger.Log(ctx, SecurityEvent{
+		Event:        EventBreakglassActivated,
+		SubjectType:  "user_id",
+		SubjectValue: HashValue(session.SecurityUserID),
+		Details: map[string]interface{}{
+			"duration_minutes": session.DurationMinutes,
+			"approver_id":      HashValue(approverID),
+			"justification":    session.Justification[:min(100, len(session.Justification))],
+		},
+	})
+
+	return session, nil
+}
+
+// DenyBreakGlass closes a pending request without activating it
+func (s *SecurityAuthService) DenyBreakGlass(ctx context.Context, sessionID, approverID, reason string) error {
+	session, err := s.pendingBreakGlass(ctx, sessionID, approverID)
+	if err != nil {
+		return err
+	}
+
+	denied, err := s.repo.DenyBreakGlassSession(ctx, sessionID, approverID, reason, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to deny break-glass: %w", err)
+	}
+	if !denied {
+		return ErrBreakGlassNotPending
+	}
+
+	s.logger.Log(ctx, SecurityEvent{
+		Event:        EventBreakglassDenied,
+		SubjectType:  "break_glass_session",
+		SubjectValue: sessionID,
+		Details: map[string]interface{}{
+			"approver_id":  HashValue(approverID),
+			"requester_id": HashValue(session.SecurityUserID),
+			"reason":       reason,
+		},
+	})
+
+	return nil
+}
+
+// pendingBreakGlass loads a request the approver may still decide
+func (s *SecurityAuthService) pendingBreakGlass(ctx context.Context, sessionID, approverID string) (*BreakGlassSession, error) {
+	session, err := s.repo.GetBreakGlassSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case session.Status != BreakGlassPending || session.RevokedAt != nil:
+		return nil, ErrBreakGlassNotPending
+	case session.SecurityUserID == approverID:
+		return nil, ErrBreakGlassSelfApproval
+	case !session.ExpiresAt.After(s.now()):
+		return nil, ErrBreakGlassApprovalExpired
+	}
+	return session, nil
 }
 
 // RevokeBreakGlass revokes an active break-glass session
 func (s *SecurityAuthService) RevokeBreakGlass(ctx context.Context, sessionID, reason string) error {
-	query := `
-		UPDATE break_glass_sessions 
-		SET revoked_at = NOW(), revoked_reason = $2
-		WHERE id = $1 AND revoked_at IS NULL
-	`
-	result, err := s.db.Exec(ctx, query, sessionID, reason)
+	revoked, err := s.repo.RevokeBreakGlassSession(ctx, sessionID, reason, s.now())
 	if err != nil {
 		return err
 	}
 
-	if result.RowsAffected() > 0 {
+	if revoked {
 		s.logger.Log(ctx, SecurityEvent{
 			Event:        EventBreakglassRevoked,
 			SubjectType:  "break_glass_session",
@@ -403,119 +540,65 @@ func (s *SecurityAuthService) RevokeBreakGlass(ctx context.Context, sessionID, r
 
 // GenerateTOTPSecret generates a new TOTP secret for a user
 func (s *SecurityAuthService) GenerateTOTPSecret(username string) (string, string, error) {
-	key, err := totp.Generate(totp.GenerateOpts{
-		Issuer:      "J-Expert Security",
-		AccountName: username,
-	})
-	if err != nil {
-		return "", "", fmt.Errorf("failed to generate TOTP key: %w", err)
-	}
-
-	return key.Secret(), key.URL(), nil
+	return GenerateTOTPKey("J-Expert Security", username)
 }
 
 // EnableTOTP enables TOTP for a user after validating the initial code
 func (s *SecurityAuthService) EnableTOTP(ctx context.Context, userID, secret, code string) error {
 	// Validate code first
-	if !totp.Validate(code, secret) {
+	if !ValidateTOTPCode(code, secret, s.now()) {
 		return errors.New("invalid TOTP code")
 	}
 
-	query := `
-		UPDATE security_users 
-		SET totp_secret = $2, totp_enabled = true, updated_at = NOW()
-		WHERE id = $1
-	`
-	_, err := s.db.Exec(ctx, query, userID, secret)
-	return err
+	return s.repo.SetTOTPSecret(ctx, userID, secret, true)
+}
+
+// SetPreferredLocale sets the operator's dashboard language; "" clears it so
+// the browser's Accept-Language applies again
+func (s *SecurityAuthService) SetPreferredLocale(ctx context.Context, userID, locale string) error {
+	if locale == "" {
+		return s.repo.SetPreferredLocale(ctx, userID, nil)
+	}
+	if !slices.Contains(DashboardLocales, locale) {
+		return fmt.Errorf("unsupported dashboard language: %s", locale)
+	}
+	return s.repo.SetPreferredLocale(ctx, userID, &locale)
 }
 
 // StoreTempTOTPSecret stores a temporary TOTP secret during setup
 // This is stored in totp_secret column but with totp_enabled = false
 func (s *SecurityAuthService) StoreTempTOTPSecret(ctx context.Context, userID, secret string) error {
-	query := `
-		UPDATE security_users 
-		SET totp_secret = $2, totp_enabled = false, updated_at = NOW()
-		WHERE id = $1
-	`
-	_, err := s.db.Exec(ctx, query, userID, secret)
-	return err
+	return s.repo.SetTOTPSecret(ctx, userID, secret, false)
 }
 
 // GetTempTOTPSecret retrieves the temporary TOTP secret for confirmation
 func (s *SecurityAuthService) GetTempTOTPSecret(ctx context.Context, userID string) (string, error) {
-	query := `
-		SELECT totp_secret FROM security_users 
-		WHERE id = $1 AND totp_enabled = false AND totp_secret IS NOT NULL
-	`
-	var secret string
-	err := s.db.QueryRow(ctx, query, userID).Scan(&secret)
-	if err != nil {
-		return "", err
-	}
-	return secret, nil
+	return s.repo.GetPendingTOTPSecret(ctx, userID)
 }
 
 // Helper functions
 
-func (s *SecurityAuthService) getUserByUsername(ctx context.Context, username string) (*SecurityUser, error) {
-	query := `
-		SELECT id, username, email, password_hash, role, totp_secret, totp_enabled,
-		       is_active, last_login_at, last_login_ip, failed_login_attempts, locked_until,
-		       created_at, updated_at
-		FROM security_users
-		WHERE username = $1 AND is_active = true
-	`
-
-	user := &SecurityUser{}
-	var totpSecret *string
-	var lastLoginIP *string
-	err := s.db.QueryRow(ctx, query, username).Scan(
-		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Role,
-		&totpSecret, &user.TOTPEnabled, &user.IsActive,
-		&user.LastLoginAt, &lastLoginIP, &user.FailedLoginAttempts, &user.LockedUntil,
-		&user.CreatedAt, &user.UpdatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-	if totpSecret != nil {
-		user.TOTPSecret = *totpSecret
-	}
-	if lastLoginIP != nil {
-		user.LastLoginIP = *lastLoginIP
-	}
-
-	return user, nil
-}
-
+// incrementFailedAttempts records a bad password and locks the account once
+// maxAttempts is reached. Failures while locked never reach here, so a lock
+// is not extended by further guessing.
 func (s *SecurityAuthService) incrementFailedAttempts(ctx context.Context, userID, ip, userAgent string) {
-	query := `
-		UPDATE security_users 
-		SET failed_login_attempts = failed_login_attempts + 1,
-		    locked_until = CASE 
-		        WHEN failed_login_attempts + 1 >= $2 THEN NOW() + $3::interval 
-		        ELSE locked_until 
-		    END,
-		    updated_at = NOW()
-		WHERE id = $1
-		RETURNING failed_login_attempts
-	`
-	var attempts int
-	s.db.QueryRow(ctx, query, userID, s.maxAttempts, fmt.Sprintf("%d minutes", int(s.lockDuration.Minutes()))).Scan(&attempts)
+	attempts, err := s.repo.IncrementFailedAttempts(ctx, userID)
 
 	s.logFailedLogin(ctx, userID, ip, userAgent, "invalid_password")
 
-	if attempts >= s.maxAttempts {
-		s.logger.Log(ctx, SecurityEvent{
-			Event:        EventLoginBlocked,
-			SubjectType:  "user_id",
-			SubjectValue: HashValue(userID),
-			IP:           ip,
-			UserAgent:    userAgent,
-			Details:      map[string]interface{}{"reason": "max_attempts_exceeded"},
-		})
+	if err != nil || attempts < s.maxAttempts {
+		return
 	}
+
+	s.repo.LockUser(ctx, userID, s.now().Add(s.lockDuration))
+	s.logger.Log(ctx, SecurityEvent{
+		Event:        EventLoginBlocked,
+		SubjectType:  "user_id",
+		SubjectValue: HashValue(userID),
+		IP:           ip,
+		UserAgent:    userAgent,
+		Details:      map[string]interface{}{"reason": "max_attempts_exceeded"},
+	})
 }
 
 func (s *SecurityAuthService) logFailedLogin(ctx context.Context, identifier, ip, userAgent, reason string) {
@@ -529,15 +612,6 @@ func (s *SecurityAuthService) logFailedLogin(ctx context.Context, identifier, ip
 	})
 }
 
-func (s *SecurityAuthService) updateLastLogin(ctx context.Context, userID, ip string) {
-	query := `
-		UPDATE security_users 
-		SET last_login_at = NOW(), last_login_ip = $2, failed_login_attempts = 0, locked_until = NULL
-		WHERE id = $1
-	`
-	s.db.Exec(ctx, query, userID, ip)
-}
-
 func hashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(hash[:])
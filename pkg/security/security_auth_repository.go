@@ -0,0 +1,371 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrSecurityRecordNotFound is returned by SecurityAuthRepository lookups with no match
+var ErrSecurityRecordNotFound = errors.New("security record not found")
+
+// SecurityAuthRepository is the storage behind SecurityAuthService.
+// It only persists and loads state; lockout, expiry, session binding and
+// break-glass rules are decided by the service so they can be unit-tested.
+type SecurityAuthRepository interface {
+	// IP allowlist
+	ListActiveIPRanges(ctx context.Context) ([]string, error)
+
+	// Users and lockout state
+	GetActiveUserByUsername(ctx context.Context, username string) (*SecurityUser, error)
+	IncrementFailedAttempts(ctx context.Context, userID string) (int, error)
+	LockUser(ctx context.Context, userID string, until time.Time) error
+	ClearFailedAttempts(ctx context.Context, userID string) error
+	RecordLogin(ctx context.Context, userID, ip string, at time.Time) error
+
+	// Sessions (lookups return revoked/expired sessions too)
+	CreateSession(ctx context.Context, session *SecuritySession) error
+	GetSessionByTokenHash(ctx context.Context, tokenHash string) (*SecuritySession, *SecurityUser, error)
+	RevokeSession(ctx context.Context, sessionID, reason string, at time.Time) error
+
+	// Break-glass
+	CreateBreakGlassSession(ctx context.Context, session *BreakGlassSession) error
+	GetLatestBreakGlassSession(ctx context.Context, userID string) (*BreakGlassSession, error)
+	RevokeBreakGlassSession(ctx context.Context, sessionID, reason string, at time.Time) (bool, error)
+
+	// Break-glass dual approval; decisions report whether the request was still pending
+	GetBreakGlassSession(ctx context.Context, sessionID string) (*BreakGlassSession, error)
+	ListPendingBreakGlassSessions(ctx context.Context, now time.Time) ([]BreakGlassSession, error)
+	ApproveBreakGlassSession(ctx context.Context, sessionID, approverID string, activatedAt, expiresAt time.Time) (bool, error)
+	DenyBreakGlassSession(ctx context.Context, sessionID, approverID, reason string, at time.Time) (bool, error)
+
+	// TOTP enrollment
+	SetTOTPSecret(ctx context.Context, userID, secret string, enabled bool) error
+	GetPendingTOTPSecret(ctx context.Context, userID string) (string, error)
+
+	// Dashboard language; nil clears the preference
+	SetPreferredLocale(ctx context.Context, userID string, locale *string) error
+}
+
+// PostgresSecurityAuthRepository implements SecurityAuthRepository on pgxpool
+type PostgresSecurityAuthRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresSecurityAuthRepository creates the production security auth repository
+func NewPostgresSecurityAuthRepository(db *pgxpool.Pool) *PostgresSecurityAuthRepository {
+	return &PostgresSecurityAuthRepository{db: db}
+}
+
+// ListActiveIPRanges returns the CIDRs of all active allowlist entries
+func (r *PostgresSecurityAuthRepository) ListActiveIPRanges(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT cidr FROM allowed_ip_ranges WHERE is_active = true`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query allowed IPs: %w", err)
+	}
+	defer rows.Close()
+
+	var cidrs []string
+	for rows.Next() {
+		var cidr string
+		if err := rows.Scan(&cidr); err != nil {
+			continue
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, rows.Err()
+}
+
+// GetActiveUserByUsername loads an active operator with credentials and lockout state
+func (r *PostgresSecurityAuthRepository) GetActiveUserByUsername(ctx context.Context, username string) (*SecurityUser, error) {
+	query := `
+		SELECT id, username, email, password_hash, role, totp_secret, totp_enabled,
+		       is_active, last_login_at, last_login_ip, failed_login_attempts, locked_until,
+		       preferred_locale, created_at, updated_at
+		FROM security_users
+		WHERE username = $1 AND is_active = true
+	`
+
+	user := &SecurityUser{}
+	var totpSecret *string
+	var lastLoginIP *string
+	err := r.db.QueryRow(ctx, query, username).Scan(
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Role,
+		&totpSecret, &user.TOTPEnabled, &user.IsActive,
+		&user.LastLoginAt, &lastLoginIP, &user.FailedLoginAttempts, &user.LockedUntil,
+		&user.PreferredLocale, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, notFoundOr(err)
+	}
+	if totpSecret != nil {
+		user.TOTPSecret = *totpSecret
+	}
+	if lastLoginIP != nil {
+		user.LastLoginIP = *lastLoginIP
+	}
+
+	return user, nil
+}
+
+// IncrementFailedAttempts atomically bumps the failure counter and returns the new value
+func (r *PostgresSecurityAuthRepository) IncrementFailedAttempts(ctx context.Context, userID string) (int, error) {
+	query := `
+		UPDATE security_users
+		SET failed_login_attempts = failed_login_attempts + 1, updated_at = NOW()
+		WHERE id = $1
+		RETURNING failed_login_attempts
+	`
+	var attempts int
+	err := r.db.QueryRow(ctx, query, userID).Scan(&attempts)
+	return attempts, notFoundOr(err)
+}
+
+// LockUser blocks logins until the given time
+func (r *PostgresSecurityAuthRepository) LockUser(ctx context.Context, userID string, until time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE security_users SET locked_until = $2, updated_at = NOW() WHERE id = $1`, userID, until)
+	return err
+}
+
+// ClearFailedAttempts resets the failure counter and any lock
+func (r *PostgresSecurityAuthRepository) ClearFailedAttempts(ctx context.Context, userID string) error {
+	query := `
+		UPDATE security_users
+		SET failed_login_attempts = 0, locked_until = NULL, updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, userID)
+	return err
+}
+
+// RecordLogin stores the last successful login and clears lockout state
+func (r *PostgresSecurityAuthRepository) RecordLogin(ctx context.Context, userID, ip string, at time.Time) error {
+	query := `
+		UPDATE security_users
+		SET last_login_at = $3, last_login_ip = $2, failed_login_attempts = 0, locked_until = NULL
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, userID, ip, at)
+	return err
+}
+
+// CreateSession inserts a session and fills its ID and CreatedAt
+func (r *PostgresSecurityAuthRepository) CreateSession(ctx context.Context, session *SecuritySession) error {
+	query := `
+		INSERT INTO security_sessions (security_user_id, token_hash, ip_address, user_agent, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query,
+		session.SecurityUserID, session.TokenHash, session.IPAddress, session.UserAgent, session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt)
+}
+
+// GetSessionByTokenHash returns a session and its operator regardless of expiry or revocation
+func (r *PostgresSecurityAuthRepository) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*SecuritySession, *SecurityUser, error) {
+	query := `
+		SELECT ss.id, ss.security_user_id, ss.ip_address, ss.user_agent, ss.created_at, ss.expires_at, ss.revoked_at,
+		       su.id, su.username, su.email, su.role, su.totp_enabled, su.is_active, su.preferred_locale
+		FROM security_sessions ss
+		JOIN security_users su ON ss.security_user_id = su.id
+		WHERE ss.token_hash = $1
+	`
+
+	var session SecuritySession
+	var user SecurityUser
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&session.ID, &session.SecurityUserID, &session.IPAddress, &session.UserAgent,
+		&session.CreatedAt, &session.ExpiresAt, &session.RevokedAt,
+		&user.ID, &user.Username, &user.Email, &user.Role, &user.TOTPEnabled, &user.IsActive, &user.PreferredLocale,
+	)
+	if err != nil {
+		return nil, nil, notFoundOr(err)
+	}
+	return &session, &user, nil
+}
+
+// RevokeSession marks an active session revoked
+func (r *PostgresSecurityAuthRepository) RevokeSession(ctx context.Context, sessionID, reason string, at time.Time) error {
+	query := `
+		UPDATE security_sessions
+		SET revoked_at = $3, revoked_reason = $2
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(ctx, query, sessionID, reason, at)
+	return err
+}
+
+// CreateBreakGlassSession inserts a break-glass elevation or pending request and fills its ID and ActivatedAt
+func (r *PostgresSecurityAuthRepository) CreateBreakGlassSession(ctx context.Context, session *BreakGlassSession) error {
+	query := `
+		INSERT INTO break_glass_sessions (security_user_id, justification, expires_at, status, duration_minutes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, activated_at
+	`
+	return r.db.QueryRow(ctx, query, session.SecurityUserID, session.Justification, session.ExpiresAt,
+		session.Status, session.DurationMinutes).
+		Scan(&session.ID, &session.ActivatedAt)
+}
+
+// GetLatestBreakGlassSession returns the operator's most recent unrevoked elevation
+// or request, whatever its status and whether or not it expired
+func (r *PostgresSecurityAuthRepository) GetLatestBreakGlassSession(ctx context.Context, userID string) (*BreakGlassSession, error) {
+	query := `
+		SELECT id, justification, activated_at, expires_at, status, COALESCE(duration_minutes, 0)
+		FROM break_glass_sessions
+		WHERE security_user_id = $1 AND revoked_at IS NULL
+		ORDER BY activated_at DESC
+		LIMIT 1
+	`
+
+	session := &BreakGlassSession{SecurityUserID: userID}
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&session.ID, &session.Justification, &session.ActivatedAt, &session.ExpiresAt,
+		&session.Status, &session.DurationMinutes,
+	)
+	if err != nil {
+		return nil, notFoundOr(err)
+	}
+	return session, nil
+}
+
+// GetBreakGlassSession returns an elevation or request by ID, revoked or not
+func (r *PostgresSecurityAuthRepository) GetBreakGlassSession(ctx context.Context, sessionID string) (*BreakGlassSession, error) {
+	query := `
+		SELECT b.id, b.security_user_id, u.username, b.justification, b.activated_at, b.expires_at,
+		       b.revoked_at, COALESCE(b.revoked_reason, ''), b.status, COALESCE(b.duration_minutes, 0),
+		       COALESCE(b.decided_by::text, ''), b.decided_at, COALESCE(b.denial_reason, '')
+		FROM break_glass_sessions b
+		JOIN security_users u ON u.id = b.security_user_id
+		WHERE b.id = $1
+	`
+
+	var session BreakGlassSession
+	err := r.db.QueryRow(ctx, query, sessionID).Scan(
+		&session.ID, &session.SecurityUserID, &session.Username, &session.Justification,
+		&session.ActivatedAt, &session.ExpiresAt, &session.RevokedAt, &session.RevokedReason,
+		&session.Status, &session.DurationMinutes, &session.DecidedBy, &session.DecidedAt, &session.DenialReason,
+	)
+	if err != nil {
+		return nil, notFoundOr(err)
+	}
+	return &session, nil
+}
+
+// ListPendingBreakGlassSessions returns unrevoked requests whose approval deadline is after now, oldest first
+func (r *PostgresSecurityAuthRepository) ListPendingBreakGlassSessions(ctx context.Context, now time.Time) ([]BreakGlassSession, error) {
+	query := `
+		SELECT b.id, b.security_user_id, u.username, b.justification, b.activated_at, b.expires_at,
+		       b.status, COALESCE(b.duration_minutes, 0)
+		FROM break_glass_sessions b
+		JOIN security_users u ON u.id = b.security_user_id
+		WHERE b.status = 'PENDING' AND b.revoked_at IS NULL AND b.expires_at > $1
+		ORDER BY b.activated_at
+	`
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []BreakGlassSession{}
+	for rows.Next() {
+		var session BreakGlassSession
+		if err := rows.Scan(
+			&session.ID, &session.SecurityUserID, &session.Username, &session.Justification,
+			&session.ActivatedAt, &session.ExpiresAt, &session.Status, &session.DurationMinutes,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// ApproveBreakGlassSession activates a request still inside its approval window
+// from activatedAt until expiresAt
+func (r *PostgresSecurityAuthRepository) ApproveBreakGlassSession(ctx context.Context, sessionID, approverID string, activatedAt, expiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE break_glass_sessions
+		SET status = 'APPROVED', decided_by = $2, decided_at = $3, activated_at = $3, expires_at = $4
+		WHERE id = $1 AND status = 'PENDING' AND revoked_at IS NULL AND expires_at > $3
+	`
+	result, err := r.db.Exec(ctx, query, sessionID, approverID, activatedAt, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// DenyBreakGlassSession closes a pending request without activating it
+func (r *PostgresSecurityAuthRepository) DenyBreakGlassSession(ctx context.Context, sessionID, approverID, reason string, at time.Time) (bool, error) {
+	query := `
+		UPDATE break_glass_sessions
+		SET status = 'DENIED', decided_by = $2, decided_at = $4, denial_reason = $3
+		WHERE id = $1 AND status = 'PENDING' AND revoked_at IS NULL
+	`
+	result, err := r.db.Exec(ctx, query, sessionID, approverID, reason, at)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// RevokeBreakGlassSession revokes an elevation; the bool reports whether one was active
+func (r *PostgresSecurityAuthRepository) RevokeBreakGlassSession(ctx context.Context, sessionID, reason string, at time.Time) (bool, error) {
+	query := `
+		UPDATE break_glass_sessions
+		SET revoked_at = $3, revoked_reason = $2
+		WHERE id = $1 AND revoked_at IS NULL
+	`
+	result, err := r.db.Exec(ctx, query, sessionID, reason, at)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// SetTOTPSecret stores a TOTP secret; enabled=false keeps it pending confirmation
+func (r *PostgresSecurityAuthRepository) SetTOTPSecret(ctx context.Context, userID, secret string, enabled bool) error {
+	query := `
+		UPDATE security_users
+		SET totp_secret = $2, totp_enabled = $3, updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, userID, secret, enabled)
+	return err
+}
+
+// GetPendingTOTPSecret returns a secret stored during setup but not yet confirmed
+func (r *PostgresSecurityAuthRepository) GetPendingTOTPSecret(ctx context.Context, userID string) (string, error) {
+	query := `
+		SELECT totp_secret FROM security_users
+		WHERE id = $1 AND totp_enabled = false AND totp_secret IS NOT NULL
+	`
+	var secret string
+	err := r.db.QueryRow(ctx, query, userID).Scan(&secret)
+	return secret, notFoundOr(err)
+}
+
+// SetPreferredLocale stores the operator's dashboard language
+func (r *PostgresSecurityAuthRepository) SetPreferredLocale(ctx context.Context, userID string, locale *string) error {
+	tag, err := r.db.Exec(ctx, `UPDATE security_users SET preferred_locale = $2, updated_at = NOW() WHERE id = $1`, userID, locale)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSecurityRecordNotFound
+	}
+	return nil
+}
+
+func notFoundOr(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrSecurityRecordNotFound
+	}
+	return err
+}
@@ -0,0 +1,815 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeAuthRepo is an in-memory SecurityAuthRepository
+type fakeAuthRepo struct {
+	mu         sync.Mutex
+	ipRanges   []string
+	users      map[string]*SecurityUser // by ID
+	sessions   map[string]*SecuritySession
+	breakGlass []*BreakGlassSession
+	nextID     int
+}
+
+func newFakeAuthRepo() *fakeAuthRepo {
+	return &fakeAuthRepo{
+		ipRanges: []string{"10.0.0.0/8"},
+		users:    map[string]*SecurityUser{},
+		sessions: map[string]*SecuritySession{},
+	}
+}
+
+func (r *fakeAuthRepo) id(prefix string) string {
+	r.nextID++
+	return fmt.Sprintf("%s-%d", prefix, r.nextID)
+}
+
+func (r *fakeAuthRepo) ListActiveIPRanges(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ipRanges...), nil
+}
+
+func (r *fakeAuthRepo) GetActiveUserByUsername(ctx context.Context, username string) (*SecurityUser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Username == username && u.IsActive {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, ErrSecurityRecordNotFound
+}
+
+func (r *fakeAuthRepo) IncrementFailedAttempts(ctx context.Context, userID string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[userID]
+	if !ok {
+		return 0, ErrSecurityRecordNotFound
+	}
+	u.FailedLoginAttempts++
+	return u.FailedLoginAttempts, nil
+}
+
+func (r *fakeAuthRepo) LockUser(ctx context.Context, userID string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[userID].LockedUntil = &until
+	return nil
+}
+
+func (r *fakeAuthRepo) ClearFailedAttempts(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[userID].FailedLoginAttempts = 0
+	r.users[userID].LockedUntil = nil
+	return nil
+}
+
+func (r *fakeAuthRepo) RecordLogin(ctx context.Context, userID, ip string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u := r.users[userID]
+	u.LastLoginAt = &at
+	u.LastLoginIP = ip
+	u.FailedLoginAttempts = 0
+	u.LockedUntil = nil
+	return nil
+}
+
+func (r *fakeAuthRepo) CreateSession(ctx context.Context, session *SecuritySession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session.ID = r.id("session")
+	session.CreatedAt = time.Now()
+	copied := *session
+	r.sessions[session.TokenHash] = &copied
+	return nil
+}
+
+func (r *fakeAuthRepo) GetSessionByTokenHash(ctx context.Context, tokenHash string) (*SecuritySession, *SecurityUser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[tokenHash]
+	if !ok {
+		return nil, nil, ErrSecurityRecordNotFound
+	}
+	session, user := *s, *r.users[s.SecurityUserID]
+	return &session, &user, nil
+}
+
+func (r *fakeAuthRepo) RevokeSession(ctx context.Context, sessionID, reason string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sessions {
+		if s.ID == sessionID && s.RevokedAt == nil {
+			s.RevokedAt = &at
+			s.RevokedReason = reason
+		}
+	}
+	return nil
+}
+
+func (r *fakeAuthRepo) CreateBreakGlassSession(ctx context.Context, session *BreakGlassSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session.ID = r.id("breakglass")
+	session.ActivatedAt = time.Now()
+	copied := *session
+	r.breakGlass = append(r.breakGlass, &copied)
+	return nil
+}
+
+func (r *fakeAuthRepo) GetLatestBreakGlassSession(ctx context.Context, userID string) (*BreakGlassSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.breakGlass) - 1; i >= 0; i-- {
+		if b := r.breakGlass[i]; b.SecurityUserID == userID && b.RevokedAt == nil {
+			copied := *b
+			return &copied, nil
+		}
+	}
+	return nil, ErrSecurityRecordNotFound
+}
+
+func (r *fakeAuthRepo) RevokeBreakGlassSession(ctx context.Context, sessionID, reason string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range r.breakGlass {
+		if b.ID == sessionID && b.RevokedAt == nil {
+			b.RevokedAt = &at
+			b.RevokedReason = reason
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeAuthRepo) GetBreakGlassSession(ctx context.Context, sessionID string) (*BreakGlassSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range r.breakGlass {
+		if b.ID == sessionID {
+			copied := *b
+			return &copied, nil
+		}
+	}
+	return nil, ErrSecurityRecordNotFound
+}
+
+func (r *fakeAuthRepo) ListPendingBreakGlassSessions(ctx context.Context, now time.Time) ([]BreakGlassSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var pending []BreakGlassSession
+	for _, b := range r.breakGlass {
+		if b.Status == BreakGlassPending && b.RevokedAt == nil && b.ExpiresAt.After(now) {
+			pending = append(pending, *b)
+		}
+	}
+	return pending, nil
+}
+
+func (r *fakeAuthRepo) ApproveBreakGlassSession(ctx context.Context, sessionID, approverID string, activatedAt, expiresAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range r.breakGlass {
+		if b.ID == sessionID && b.Status == BreakGlassPending && b.RevokedAt == nil && b.ExpiresAt.After(activatedAt) {
+			b.Status = BreakGlassApproved
+			b.DecidedBy = approverID
+			b.DecidedAt = &activatedAt
+			b.ActivatedAt = activatedAt
+			b.ExpiresAt = expiresAt
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeAuthRepo) DenyBreakGlassSession(ctx context.Context, sessionID, approverID, reason string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range r.breakGlass {
+		if b.ID == sessionID && b.Status == BreakGlassPending && b.RevokedAt == nil {
+			b.Status = BreakGlassDenied
+			b.DecidedBy = approverID
+			b.DecidedAt = &at
+			b.DenialReason = reason
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeAuthRepo) SetTOTPSecret(ctx context.Context, userID, secret string, enabled bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[userID].TOTPSecret = secret
+	r.users[userID].TOTPEnabled = enabled
+	return nil
+}
+
+func (r *fakeAuthRepo) GetPendingTOTPSecret(ctx context.Context, userID string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u := r.users[userID]
+	if u.TOTPEnabled || u.TOTPSecret == "" {
+		return "", ErrSecurityRecordNotFound
+	}
+	return u.TOTPSecret, nil
+}
+
+func (r *fakeAuthRepo) SetPreferredLocale(ctx context.Context, userID string, locale *string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[userID]
+	if !ok {
+		return ErrSecurityRecordNotFound
+	}
+	u.PreferredLocale = locale
+	return nil
+}
+
+const (
+	testIP       = "10.1.2.3"
+	testUA       = "go-test"
+	testPassword = "correct horse battery staple"
+)
+
+var testJustification = strings.Repeat("investigating incident INC-1 ", 3)
+
+type authFixture struct {
+	svc   *SecurityAuthService
+	repo  *fakeAuthRepo
+	clock time.Time
+	user  *SecurityUser
+}
+
+func (f *authFixture) advance(d time.Duration) { f.clock = f.clock.Add(d) }
+
+func (f *authFixture) stored() *SecurityUser {
+	f.repo.mu.Lock()
+	defer f.repo.mu.Unlock()
+	copied := *f.repo.users[f.user.ID]
+	return &copied
+}
+
+func newAuthFixture(t *testing.T) *authFixture {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	repo := newFakeAuthRepo()
+	user := &SecurityUser{
+		ID:           "user-1",
+		Username:     "analyst",
+		Email:        "analyst@example.com",
+		PasswordHash: string(hash),
+		Role:         RoleSecurityAnalyst,
+		IsActive:     true,
+	}
+	repo.users[user.ID] = user
+
+	f := &authFixture{
+		repo:  repo,
+		clock: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+		user:  user,
+	}
+	f.svc = NewSecurityAuthServiceWithRepository(repo, DefaultSecurityAuthConfig())
+	f.svc.logger = &SecurityLogger{zapLogger: zap.NewNop()}
+	f.svc.now = func() time.Time { return f.clock }
+	return f
+}
+
+func (f *authFixture) login(password string) (*SecurityUser, error) {
+	return f.svc.Authenticate(context.Background(), f.user.Username, password, testIP, testUA)
+}
+
+// ============================================================================
+// Lockout
+// ============================================================================
+
+func TestAuthenticate_Success(t *testing.T) {
+	f := newAuthFixture(t)
+
+	user, err := f.login(testPassword)
+
+	require.NoError(t, err)
+	assert.Equal(t, f.user.ID, user.ID)
+	assert.Equal(t, 0, f.stored().FailedLoginAttempts)
+}
+
+func TestAuthenticate_BelowMaxAttemptsDoesNotLock(t *testing.T) {
+	f := newAuthFixture(t)
+
+	for i := 0; i < f.svc.maxAttempts-1; i++ {
+		_, err := f.login("wrong")
+		assert.EqualError(t, err, "invalid credentials")
+	}
+
+	stored := f.stored()
+	assert.Equal(t, f.svc.maxAttempts-1, stored.FailedLoginAttempts)
+	assert.Nil(t, stored.LockedUntil)
+
+	_, err := f.login(testPassword)
+	assert.NoError(t, err)
+}
+
+func TestAuthenticate_LocksAtExactlyMaxAttempts(t *testing.T) {
+	f := newAuthFixture(t)
+
+	for i := 0; i < f.svc.maxAttempts; i++ {
+		_, err := f.login("wrong")
+		assert.EqualError(t, err, "invalid credentials")
+	}
+
+	stored := f.stored()
+	require.NotNil(t, stored.LockedUntil)
+	assert.Equal(t, f.clock.Add(f.svc.lockDuration), *stored.LockedUntil)
+}
+
+func TestAuthenticate_LockedRejectsCorrectPassword(t *testing.T) {
+	f := newAuthFixture(t)
+	for i := 0; i < f.svc.maxAttempts; i++ {
+		f.login("wrong")
+	}
+
+	_, err := f.login(testPassword)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "account locked until")
+}
+
+func TestAuthenticate_FailuresWhileLockedDoNotExtendLock(t *testing.T) {
+	f := newAuthFixture(t)
+	for i := 0; i < f.svc.maxAttempts; i++ {
+		f.login("wrong")
+	}
+	lockedUntil := *f.stored().LockedUntil
+
+	f.advance(5 * time.Minute)
+	for i := 0; i < 10; i++ {
+		f.login("wrong")
+	}
+
+	stored := f.stored()
+	assert.Equal(t, lockedUntil, *stored.LockedUntil)
+	assert.Equal(t, f.svc.maxAttempts, stored.FailedLoginAttempts)
+}
+
+func TestAuthenticate_LockExpiresAtBoundary(t *testing.T) {
+	f := newAuthFixture(t)
+	for i := 0; i < f.svc.maxAttempts; i++ {
+		f.login("wrong")
+	}
+
+	// One nanosecond before expiry the account is still locked
+	f.advance(f.svc.lockDuration - time.Nanosecond)
+	_, err := f.login(testPassword)
+	require.Error(t, err)
+
+	// At the expiry instant the lock no longer applies
+	f.advance(time.Nanosecond)
+	_, err = f.login(testPassword)
+	assert.NoError(t, err)
+}
+
+func TestAuthenticate_ExpiredLockResetsAttemptWindow(t *testing.T) {
+	f := newAuthFixture(t)
+	for i := 0; i < f.svc.maxAttempts; i++ {
+		f.login("wrong")
+	}
+	f.advance(f.svc.lockDuration + time.Minute)
+
+	// A single failure after expiry must not immediately re-lock
+	_, err := f.login("wrong")
+	assert.EqualError(t, err, "invalid credentials")
+
+	stored := f.stored()
+	assert.Equal(t, 1, stored.FailedLoginAttempts)
+	assert.Nil(t, stored.LockedUntil)
+}
+
+func TestAuthenticate_SuccessfulLoginResetsAttempts(t *testing.T) {
+	f := newAuthFixture(t)
+	for i := 0; i < f.svc.maxAttempts-1; i++ {
+		f.login("wrong")
+	}
+
+	user, err := f.login(testPassword)
+	require.NoError(t, err)
+	_, _, err = f.svc.CreateSession(context.Background(), user.ID, testIP, testUA)
+	require.NoError(t, err)
+
+	stored := f.stored()
+	assert.Equal(t, 0, stored.FailedLoginAttempts)
+	assert.Equal(t, testIP, stored.LastLoginIP)
+
+	// The counter starts over, so max-1 failures again do not lock
+	for i := 0; i < f.svc.maxAttempts-1; i++ {
+		f.login("wrong")
+	}
+	assert.Nil(t, f.stored().LockedUntil)
+}
+
+func TestAuthenticate_UnknownUser(t *testing.T) {
+	f := newAuthFixture(t)
+
+	_, err := f.svc.Authenticate(context.Background(), "nobody", testPassword, testIP, testUA)
+
+	assert.EqualError(t, err, "invalid credentials")
+}
+
+func TestAuthenticate_InactiveUser(t *testing.T) {
+	f := newAuthFixture(t)
+	f.repo.users[f.user.ID].IsActive = false
+
+	_, err := f.login(testPassword)
+
+	assert.EqualError(t, err, "invalid credentials")
+}
+
+func TestAuthenticate_IPNotAllowlisted(t *testing.T) {
+	f := newAuthFixture(t)
+
+	_, err := f.svc.Authenticate(context.Background(), f.user.Username, testPassword, "192.168.1.1", testUA)
+
+	assert.EqualError(t, err, "access denied: IP not in allowlist")
+	assert.Equal(t, 0, f.stored().FailedLoginAttempts)
+}
+
+func TestValidateIP(t *testing.T) {
+	f := newAuthFixture(t)
+	f.repo.ipRanges = []string{"not-a-cidr", "10.0.0.0/8", "2001:db8::/32"}
+	ctx := context.Background()
+
+	allowed, err := f.svc.ValidateIP(ctx, "10.255.0.1")
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = f.svc.ValidateIP(ctx, "2001:db8::1")
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = f.svc.ValidateIP(ctx, "11.0.0.1")
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	_, err = f.svc.ValidateIP(ctx, "not-an-ip")
+	assert.Error(t, err)
+}
+
+// ============================================================================
+// TOTP enrollment
+// ============================================================================
+
+func TestTOTPEnrollment(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+
+	secret, url, err := f.svc.GenerateTOTPSecret(f.user.Username)
+	require.NoError(t, err)
+	assert.Contains(t, url, "otpauth://totp/")
+
+	// Setup stores the secret without enabling it
+	require.NoError(t, f.svc.StoreTempTOTPSecret(ctx, f.user.ID, secret))
+	pending, err := f.svc.GetTempTOTPSecret(ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, secret, pending)
+	assert.False(t, f.stored().TOTPEnabled)
+
+	// A wrong code leaves TOTP disabled
+	assert.EqualError(t, f.svc.EnableTOTP(ctx, f.user.ID, pending, "000000"), "invalid TOTP code")
+	assert.False(t, f.stored().TOTPEnabled)
+
+	// The current code enables it and clears the pending state
+	code, err := totp.GenerateCode(secret, f.clock)
+	require.NoError(t, err)
+	require.NoError(t, f.svc.EnableTOTP(ctx, f.user.ID, pending, code))
+	assert.True(t, f.stored().TOTPEnabled)
+
+	_, err = f.svc.GetTempTOTPSecret(ctx, f.user.ID)
+	assert.ErrorIs(t, err, ErrSecurityRecordNotFound)
+}
+
+func TestSetPreferredLocale(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+
+	require.NoError(t, f.svc.SetPreferredLocale(ctx, f.user.ID, "id"))
+	require.NotNil(t, f.stored().PreferredLocale)
+	assert.Equal(t, "id", *f.stored().PreferredLocale)
+
+	// Only dashboard languages are accepted; the stored preference is kept
+	assert.EqualError(t, f.svc.SetPreferredLocale(ctx, f.user.ID, "ja"), "unsupported dashboard language: ja")
+	assert.Equal(t, "id", *f.stored().PreferredLocale)
+
+	// Empty clears it
+	require.NoError(t, f.svc.SetPreferredLocale(ctx, f.user.ID, ""))
+	assert.Nil(t, f.stored().PreferredLocale)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+	secret, _, err := f.svc.GenerateTOTPSecret(f.user.Username)
+	require.NoError(t, err)
+
+	// Not enabled yet
+	_, err = f.svc.ValidateTOTP(ctx, f.user, "123456")
+	assert.EqualError(t, err, "TOTP not enabled for this user")
+
+	user := f.stored()
+	user.TOTPSecret = secret
+	user.TOTPEnabled = true
+
+	code, err := totp.GenerateCode(secret, f.clock)
+	require.NoError(t, err)
+
+	valid, err := f.svc.ValidateTOTP(ctx, user, code)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// One step of skew is tolerated, two are not
+	f.advance(30 * time.Second)
+	valid, _ = f.svc.ValidateTOTP(ctx, user, code)
+	assert.True(t, valid)
+
+	f.advance(60 * time.Second)
+	valid, err = f.svc.ValidateTOTP(ctx, user, code)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
+
+// ============================================================================
+// Session binding
+// ============================================================================
+
+func TestValidateSession(t *testing.T) {
+	ctx := context.Background()
+
+	newSession := func(t *testing.T) (*authFixture, *SecuritySession, string) {
+		f := newAuthFixture(t)
+		session, token, err := f.svc.CreateSession(ctx, f.user.ID, testIP, testUA)
+		require.NoError(t, err)
+		assert.Equal(t, f.clock.Add(f.svc.sessionTTL), session.ExpiresAt)
+		return f, session, token
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		f, session, token := newSession(t)
+
+		got, user, err := f.svc.ValidateSession(ctx, token, testIP)
+
+		require.NoError(t, err)
+		assert.Equal(t, session.ID, got.ID)
+		assert.Equal(t, f.user.ID, user.ID)
+	})
+
+	t.Run("ip mismatch", func(t *testing.T) {
+		f, _, token := newSession(t)
+
+		_, _, err := f.svc.ValidateSession(ctx, token, "10.9.9.9")
+
+		assert.EqualError(t, err, "session IP mismatch")
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		f, _, _ := newSession(t)
+
+		_, _, err := f.svc.ValidateSession(ctx, "forged", testIP)
+
+		assert.EqualError(t, err, "invalid or expired session")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		f, _, token := newSession(t)
+		f.advance(f.svc.sessionTTL)
+
+		_, _, err := f.svc.ValidateSession(ctx, token, testIP)
+
+		assert.EqualError(t, err, "invalid or expired session")
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		f, session, token := newSession(t)
+		require.NoError(t, f.svc.RevokeSession(ctx, session.ID, "user_logout"))
+
+		_, _, err := f.svc.ValidateSession(ctx, token, testIP)
+
+		assert.EqualError(t, err, "invalid or expired session")
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		f, _, token := newSession(t)
+		f.repo.users[f.user.ID].IsActive = false
+
+		_, _, err := f.svc.ValidateSession(ctx, token, testIP)
+
+		assert.EqualError(t, err, "invalid or expired session")
+	})
+}
+
+func TestSessionCSRFToken(t *testing.T) {
+	f := newAuthFixture(t)
+	_, token, err := f.svc.CreateSession(context.Background(), f.user.ID, testIP, testUA)
+	require.NoError(t, err)
+	_, otherToken, err := f.svc.CreateSession(context.Background(), f.user.ID, testIP, testUA)
+	require.NoError(t, err)
+
+	csrf := SessionCSRFToken(token)
+
+	assert.Equal(t, csrf, SessionCSRFToken(token), "stable for the session")
+	assert.True(t, ValidateCSRFToken(token, csrf))
+	assert.False(t, ValidateCSRFToken(otherToken, csrf), "bound to its session")
+	assert.False(t, ValidateCSRFToken(token, ""))
+	assert.False(t, ValidateCSRFToken("", SessionCSRFToken("")))
+	assert.False(t, ValidateCSRFToken(token, token), "the session token itself is not a CSRF token")
+}
+
+// ============================================================================
+// Break-glass
+// ============================================================================
+
+func TestActivateBreakGlass_Validation(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.ActivateBreakGlass(ctx, f.user.ID, "too short", 30)
+	assert.EqualError(t, err, "justification must be at least 50 characters")
+
+	_, err = f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 61)
+	assert.EqualError(t, err, "break-glass duration cannot exceed 60 minutes")
+
+	_, err = f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 0)
+	assert.EqualError(t, err, "break-glass duration must be positive")
+
+	_, err = f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, -5)
+	assert.Error(t, err)
+
+	assert.Empty(t, f.repo.breakGlass)
+}
+
+func TestBreakGlass_Lifecycle(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+
+	_, active, err := f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	session, err := f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 60)
+	require.NoError(t, err)
+	assert.Equal(t, f.clock.Add(60*time.Minute), session.ExpiresAt)
+
+	got, active, err := f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	require.NoError(t, err)
+	assert.True(t, active)
+	assert.Equal(t, session.ID, got.ID)
+
+	// Revoke is idempotent
+	require.NoError(t, f.svc.RevokeBreakGlass(ctx, session.ID, "done"))
+	require.NoError(t, f.svc.RevokeBreakGlass(ctx, session.ID, "done"))
+
+	_, active, _ = f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	assert.False(t, active)
+}
+
+func TestBreakGlass_Expires(t *testing.T) {
+	f := newAuthFixture(t)
+	ctx := context.Background()
+
+	_, err := f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 15)
+	require.NoError(t, err)
+
+	f.advance(15*time.Minute - time.Second)
+	_, active, _ := f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	assert.True(t, active)
+
+	f.advance(time.Second)
+	_, active, _ = f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	assert.False(t, active)
+}
+
+func newDualApprovalFixture(t *testing.T) *authFixture {
+	t.Helper()
+	f := newAuthFixture(t)
+	f.svc.dualApproval = true
+	return f
+}
+
+const testApproverID = "user-2"
+
+func TestBreakGlass_DualApprovalLifecycle(t *testing.T) {
+	f := newDualApprovalFixture(t)
+	ctx := context.Background()
+
+	request, err := f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 30)
+	require.NoError(t, err)
+	assert.Equal(t, BreakGlassPending, request.Status)
+	assert.Equal(t, f.clock.Add(breakGlassApprovalWindow), request.ExpiresAt)
+
+	// Pending is not active
+	_, active, _ := f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	assert.False(t, active)
+	_, pending, _ := f.svc.CheckBreakGlassPending(ctx, f.user.ID)
+	assert.True(t, pending)
+
+	requests, err := f.svc.ListPendingBreakGlass(ctx)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, request.ID, requests[0].ID)
+
+	f.advance(5 * time.Minute)
+	session, err := f.svc.ApproveBreakGlass(ctx, request.ID, testApproverID)
+	require.NoError(t, err)
+	assert.Equal(t, BreakGlassApproved, session.Status)
+	assert.Equal(t, testApproverID, session.DecidedBy)
+	// The requested duration counts from the approval
+	assert.Equal(t, f.clock.Add(30*time.Minute), session.ExpiresAt)
+
+	got, active, _ := f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	assert.True(t, active)
+	assert.Equal(t, request.ID, got.ID)
+
+	// Decided once
+	_, err = f.svc.ApproveBreakGlass(ctx, request.ID, testApproverID)
+	assert.ErrorIs(t, err, ErrBreakGlassNotPending)
+	assert.ErrorIs(t, f.svc.DenyBreakGlass(ctx, request.ID, testApproverID, "changed my mind"), ErrBreakGlassNotPending)
+}
+
+func TestBreakGlass_DualApprovalRejectsSelfApproval(t *testing.T) {
+	f := newDualApprovalFixture(t)
+	ctx := context.Background()
+
+	request, err := f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 15)
+	require.NoError(t, err)
+
+	_, err = f.svc.ApproveBreakGlass(ctx, request.ID, f.user.ID)
+	assert.ErrorIs(t, err, ErrBreakGlassSelfApproval)
+	assert.ErrorIs(t, f.svc.DenyBreakGlass(ctx, request.ID, f.user.ID, "not needed"), ErrBreakGlassSelfApproval)
+
+	_, active, _ := f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	assert.False(t, active)
+}
+
+func TestBreakGlass_DualApprovalWindow(t *testing.T) {
+	f := newDualApprovalFixture(t)
+	ctx := context.Background()
+
+	request, err := f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 15)
+	require.NoError(t, err)
+
+	f.advance(breakGlassApprovalWindow)
+	_, err = f.svc.ApproveBreakGlass(ctx, request.ID, testApproverID)
+	assert.ErrorIs(t, err, ErrBreakGlassApprovalExpired)
+
+	_, pending, _ := f.svc.CheckBreakGlassPending(ctx, f.user.ID)
+	assert.False(t, pending)
+	requests, err := f.svc.ListPendingBreakGlass(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+}
+
+func TestBreakGlass_DualApprovalDeny(t *testing.T) {
+	f := newDualApprovalFixture(t)
+	ctx := context.Background()
+
+	request, err := f.svc.ActivateBreakGlass(ctx, f.user.ID, testJustification, 60)
+	require.NoError(t, err)
+
+	require.NoError(t, f.svc.DenyBreakGlass(ctx, request.ID, testApproverID, "no open incident"))
+
+	stored, err := f.repo.GetBreakGlassSession(ctx, request.ID)
+	require.NoError(t, err)
+	assert.Equal(t, BreakGlassDenied, stored.Status)
+	assert.Equal(t, "no open incident", stored.DenialReason)
+
+	_, active, _ := f.svc.CheckBreakGlassActive(ctx, f.user.ID)
+	assert.False(t, active)
+	_, err = f.svc.ApproveBreakGlass(ctx, request.ID, testApproverID)
+	assert.ErrorIs(t, err, ErrBreakGlassNotPending)
+}
+
+func TestBreakGlass_UnknownRequest(t *testing.T) {
+	f := newDualApprovalFixture(t)
+
+	_, err := f.svc.ApproveBreakGlass(context.Background(), "breakglass-404", testApproverID)
+	assert.ErrorIs(t, err, ErrSecurityRecordNotFound)
+}
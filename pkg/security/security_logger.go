@@ -48,6 +48,8 @@ type SecurityLogger struct {
 	environment string
 	// Optional: DB persistence function
 	persistFunc func(ctx context.Context, event SecurityEvent) error
+	// Optional: forwarding to an external SIEM
+	siemSink *SIEMSink
 }
 
 var (
@@ -100,6 +102,11 @@ func (sl *SecurityLogger) SetPersistFunc(f func(ctx context.Context, event Secur
 	sl.persistFunc = f
 }
 
+// SetSIEMSink forwards every logged event (of the sink's severities) to an external SIEM
+func (sl *SecurityLogger) SetSIEMSink(sink *SIEMSink) {
+	sl.siemSink = sink
+}
+
 // Log logs a security event
 func (sl *SecurityLogger) Log(ctx context.Context, event SecurityEvent) {
 	// Fill in defaults
@@ -163,6 +170,11 @@ func (sl *SecurityLogger) Log(ctx context.Context, event SecurityEvent) {
 			}
 		}(event)
 	}
+
+	// Forward to the SIEM if configured (buffered, never blocks)
+	if sl.siemSink != nil {
+		sl.siemSink.Enqueue(event)
+	}
 }
 
 // LogLoginFailed logs a failed login attempt
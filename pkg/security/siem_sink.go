@@ -0,0 +1,457 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SIEM sink formats
+const (
+	SIEMFormatCEF       = "cef"   // ArcSight Common Event Format
+	SIEMFormatJSONLines = "jsonl" // one JSON object per event
+)
+
+// SIEM sink transports
+const (
+	SIEMTransportUDP  = "udp"  // RFC 5424 syslog, one datagram per event
+	SIEMTransportTCP  = "tcp"  // RFC 5424 syslog with octet-counting framing (RFC 6587)
+	SIEMTransportHTTP = "http" // newline-delimited batch POST, e.g. Splunk HEC /services/collector/raw
+)
+
+// SIEMSinkConfig holds configuration for forwarding security events to a SIEM
+type SIEMSinkConfig struct {
+	Transport     string        // udp, tcp or http
+	Address       string        // host:port for syslog, URL for http
+	Format        string        // cef or jsonl (default: jsonl)
+	AuthHeader    string        // http only: Authorization header value, e.g. "Splunk <token>"
+	Severities    []Severity    // Severities to forward (default: all)
+	BufferSize    int           // Events held while the SIEM is unreachable; oldest dropped when full (default: 10000)
+	BatchSize     int           // Events sent per attempt (default: 100)
+	FlushInterval time.Duration // How often buffered events are sent (default: 2s)
+	MaxRetryDelay time.Duration // Backoff cap while the SIEM is unreachable (default: 1min)
+}
+
+// SIEMSink buffers security events in memory and forwards them to an external
+// SIEM from a background goroutine, retrying with backoff while it is down.
+// Delivery is at-least-once for events still buffered; the database remains
+// the system of record.
+type SIEMSink struct {
+	config    SIEMSinkConfig
+	transport siemTransport
+	hostname  string
+	zapLogger *zap.Logger
+
+	mu      sync.Mutex
+	buffer  []queuedEvent
+	nextSeq uint64
+	dropped int64
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+type queuedEvent struct {
+	seq   uint64
+	event SecurityEvent
+}
+
+// siemTransport delivers one batch of formatted events
+type siemTransport interface {
+	send(ctx context.Context, messages [][]byte) error
+	close()
+}
+
+// NewSIEMSink validates the configuration and creates a sink; call Start to begin forwarding
+func NewSIEMSink(config SIEMSinkConfig) (*SIEMSink, error) {
+	if config.Format == "" {
+		config.Format = SIEMFormatJSONLines
+	}
+	if config.Format != SIEMFormatCEF && config.Format != SIEMFormatJSONLines {
+		return nil, fmt.Errorf("unsupported SIEM format %q", config.Format)
+	}
+	if config.Address == "" {
+		return nil, errors.New("SIEM address is required")
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 10000
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 2 * time.Second
+	}
+	if config.MaxRetryDelay <= 0 {
+		config.MaxRetryDelay = time.Minute
+	}
+	for _, s := range config.Severities {
+		if !slices.Contains(severityOrder, s) {
+			return nil, fmt.Errorf("unknown severity %q", s)
+		}
+	}
+
+	var transport siemTransport
+	switch config.Transport {
+	case SIEMTransportUDP, SIEMTransportTCP:
+		transport = &syslogTransport{network: config.Transport, address: config.Address}
+	case SIEMTransportHTTP:
+		transport = &httpTransport{url: config.Address, authHeader: config.AuthHeader, client: &http.Client{Timeout: 10 * time.Second}}
+	default:
+		return nil, fmt.Errorf("unsupported SIEM transport %q", config.Transport)
+	}
+
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "-"
+	}
+	return &SIEMSink{
+		config:    config,
+		transport: transport,
+		hostname:  hostname,
+		zapLogger: DefaultLogger().zapLogger,
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// ParseSeverities parses a comma-separated severity list such as "HIGH,CRITICAL"
+func ParseSeverities(list string) ([]Severity, error) {
+	var severities []Severity
+	for _, part := range strings.Split(list, ",") {
+		part = strings.ToUpper(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		if !slices.Contains(severityOrder, Severity(part)) {
+			return nil, fmt.Errorf("unknown severity %q", part)
+		}
+		severities = append(severities, Severity(part))
+	}
+	return severities, nil
+}
+
+// Start begins forwarding buffered events in the background; call it once before Close
+func (s *SIEMSink) Start() {
+	go s.run()
+}
+
+// Close stops forwarding after a last attempt to send what is buffered, bounded by ctx
+func (s *SIEMSink) Close(ctx context.Context) {
+	close(s.stop)
+	<-s.done
+	s.flush(ctx)
+	s.transport.close()
+	if n := s.pending(); n > 0 {
+		s.zapLogger.Warn("SIEM sink closed with undelivered events", zap.Int("events", n))
+	}
+}
+
+// Enqueue buffers an event if its severity is forwarded; it never blocks the caller
+func (s *SIEMSink) Enqueue(event SecurityEvent) {
+	if !s.forwards(eventSeverity(event)) {
+		return
+	}
+	s.mu.Lock()
+	if len(s.buffer) >= s.config.BufferSize {
+		s.buffer = s.buffer[1:]
+		s.dropped++
+	}
+	s.nextSeq++
+	s.buffer = append(s.buffer, queuedEvent{seq: s.nextSeq, event: event})
+	full := len(s.buffer) >= s.config.BatchSize
+	s.mu.Unlock()
+
+	if full {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *SIEMSink) forwards(severity Severity) bool {
+	return len(s.config.Severities) == 0 || slices.Contains(s.config.Severities, severity)
+}
+
+func (s *SIEMSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	firstRetry := time.Second
+	if firstRetry > s.config.MaxRetryDelay {
+		firstRetry = s.config.MaxRetryDelay
+	}
+	retryDelay := firstRetry
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := s.flush(ctx)
+		cancel()
+		if err == nil {
+			retryDelay = firstRetry
+			continue
+		}
+
+		s.zapLogger.Warn("SIEM sink unavailable, retrying",
+			zap.Error(err), zap.Int("buffered", s.pending()), zap.Duration("retry_in", retryDelay))
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(retryDelay):
+		}
+		if retryDelay *= 2; retryDelay > s.config.MaxRetryDelay {
+			retryDelay = s.config.MaxRetryDelay
+		}
+	}
+}
+
+// flush sends buffered events batch by batch, keeping a batch buffered until it is delivered
+func (s *SIEMSink) flush(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		if dropped := s.dropped; dropped > 0 {
+			s.dropped = 0
+			s.zapLogger.Error("SIEM sink buffer full, oldest events dropped", zap.Int64("events", dropped))
+		}
+		batch := slices.Clone(s.buffer[:min(len(s.buffer), s.config.BatchSize)])
+		s.mu.Unlock()
+		if len(batch) == 0 {
+			return nil
+		}
+
+		messages := make([][]byte, 0, len(batch))
+		for _, q := range batch {
+			msg, err := s.format(q.event)
+			if err != nil {
+				s.zapLogger.Error("SIEM sink: failed to format event", zap.String("event", string(q.event.Event)), zap.Error(err))
+				continue
+			}
+			messages = append(messages, msg)
+		}
+		if err := s.transport.send(ctx, messages); err != nil {
+			return err
+		}
+
+		// The buffer may have dropped some of the batch meanwhile; remove what is left of it
+		last := batch[len(batch)-1].seq
+		s.mu.Lock()
+		for len(s.buffer) > 0 && s.buffer[0].seq <= last {
+			s.buffer = s.buffer[1:]
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *SIEMSink) pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buffer)
+}
+
+// format renders an event in the configured format, framed for syslog transports
+func (s *SIEMSink) format(event SecurityEvent) ([]byte, error) {
+	var msg []byte
+	if s.config.Format == SIEMFormatCEF {
+		msg = []byte(formatCEF(event))
+	} else {
+		var err error
+		msg, err = formatJSONLine(event)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.config.Transport == SIEMTransportHTTP {
+		return msg, nil
+	}
+	return s.syslogFrame(event, msg), nil
+}
+
+// syslogFrame wraps msg in an RFC 5424 header (facility authpriv)
+func (s *SIEMSink) syslogFrame(event SecurityEvent, msg []byte) []byte {
+	pri := 10*8 + syslogSeverity(eventSeverity(event))
+	header := fmt.Sprintf("<%d>1 %s %s %s - %s - ",
+		pri, event.Timestamp.UTC().Format(time.RFC3339Nano), s.hostname, orNil(event.Service), orNil(string(event.Event)))
+	return append([]byte(header), msg...)
+}
+
+// eventSeverity matches the severity stored in security_events
+func eventSeverity(event SecurityEvent) Severity {
+	return GetSeverityForClient(event.Event, ParseUserAgent(event.UserAgent))
+}
+
+func syslogSeverity(severity Severity) int {
+	switch severity {
+	case SeverityCRITICAL:
+		return 2 // crit
+	case SeverityHIGH:
+		return 3 // err
+	case SeverityWARN:
+		return 4 // warning
+	case SeverityMEDIUM:
+		return 5 // notice
+	default:
+		return 6 // info
+	}
+}
+
+func cefSeverity(severity Severity) int {
+	switch severity {
+	case SeverityCRITICAL:
+		return 10
+	case SeverityHIGH:
+		return 8
+	case SeverityWARN:
+		return 5
+	case SeverityMEDIUM:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// formatJSONLine renders the event with its severity as a single JSON object
+func formatJSONLine(event SecurityEvent) ([]byte, error) {
+	return json.Marshal(struct {
+		SecurityEvent
+		Severity Severity `json:"severity"`
+	}{event, eventSeverity(event)})
+}
+
+// formatCEF renders the event as CEF:0|vendor|product|version|signature|name|severity|extension
+func formatCEF(event SecurityEvent) string {
+	ext := []string{"rt=" + strconv.FormatInt(event.Timestamp.UnixMilli(), 10)}
+	add := func(key, value string) {
+		if value != "" {
+			ext = append(ext, key+"="+cefExtensionEscape(value))
+		}
+	}
+	add("src", event.IP)
+	add("requestClientApplication", event.UserAgent)
+	add("suser", event.SubjectValue)
+	if event.SubjectType != "" {
+		add("cs1Label", "subjectType")
+		add("cs1", event.SubjectType)
+	}
+	if event.RequestID != "" {
+		add("cs2Label", "requestId")
+		add("cs2", event.RequestID)
+	}
+	if len(event.Details) > 0 {
+		details, _ := json.Marshal(event.Details)
+		add("cs3Label", "details")
+		add("cs3", string(details))
+	}
+	add("deviceExternalId", event.Environment)
+
+	return fmt.Sprintf("CEF:0|J-Expert|%s|1.0|%s|%s|%d|%s",
+		cefHeaderEscape(event.Service), cefHeaderEscape(string(event.Event)),
+		cefHeaderEscape(strings.ReplaceAll(string(event.Event), "_", " ")),
+		cefSeverity(eventSeverity(event)), strings.Join(ext, " "))
+}
+
+func cefHeaderEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(s)
+}
+
+func cefExtensionEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(s)
+}
+
+func orNil(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// syslogTransport writes syslog messages over a connection it re-dials after errors
+type syslogTransport struct {
+	network string
+	address string
+	conn    net.Conn
+}
+
+func (t *syslogTransport) send(ctx context.Context, messages [][]byte) error {
+	if t.conn == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, t.network, t.address)
+		if err != nil {
+			return err
+		}
+		t.conn = conn
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		t.conn.SetWriteDeadline(deadline)
+	}
+
+	for _, msg := range messages {
+		frame := msg
+		if t.network == SIEMTransportTCP {
+			frame = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+		}
+		if _, err := t.conn.Write(frame); err != nil {
+			t.close()
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *syslogTransport) close() {
+	if t.conn != nil {
+		t.conn.Close()
+		t.conn = nil
+	}
+}
+
+// httpTransport posts a batch as newline-delimited messages
+type httpTransport struct {
+	url        string
+	authHeader string
+	client     *http.Client
+}
+
+func (t *httpTransport) send(ctx context.Context, messages [][]byte) error {
+	body := bytes.Join(messages, []byte("\n"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if t.authHeader != "" {
+		req.Header.Set("Authorization", t.authHeader)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("SIEM endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (t *httpTransport) close() {}
@@ -0,0 +1,79 @@
+package security
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatCEF(t *testing.T) {
+	event := SecurityEvent{
+		Timestamp:    time.UnixMilli(1767225600000),
+		Service:      "j-expert-backend",
+		Environment:  "production",
+		Event:        EventLoginFailed,
+		SubjectType:  "email",
+		SubjectValue: "a***@x.com",
+		IP:           "203.0.113.7",
+		Details:      map[string]interface{}{"reason": "a=b"},
+	}
+
+	assert.Equal(t,
+		`CEF:0|J-Expert|j-expert-backend|1.0|login_failed|login failed|5|rt=1767225600000 src=203.0.113.7 suser=a***@x.com `+
+			`cs1Label=subjectType cs1=email cs3Label=details cs3={"reason":"a\=b"} deviceExternalId=production`,
+		formatCEF(event))
+}
+
+func TestSIEMSinkRetriesUntilDelivered(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	failures := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "Splunk token", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, strings.Split(string(body), "\n")...)
+	}))
+	defer srv.Close()
+
+	sink, err := NewSIEMSink(SIEMSinkConfig{
+		Transport:     SIEMTransportHTTP,
+		Address:       srv.URL,
+		AuthHeader:    "Splunk token",
+		Severities:    []Severity{SeverityWARN, SeverityCRITICAL},
+		FlushInterval: 10 * time.Millisecond,
+		MaxRetryDelay: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	sink.Start()
+
+	sink.Enqueue(SecurityEvent{Event: EventLoginFailed, Timestamp: time.Now()})
+	sink.Enqueue(SecurityEvent{Event: EventLoginSuccess, Timestamp: time.Now()}) // INFO is not forwarded
+	sink.Enqueue(SecurityEvent{Event: EventBreakglassActivated, Timestamp: time.Now()})
+
+	// The first send fails; the retry delivers both events
+	require.Eventually(t, func() bool { return sink.pending() == 0 }, 5*time.Second, 10*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sink.Close(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 2)
+	assert.Contains(t, received[0], `"event":"login_failed"`)
+	assert.Contains(t, received[1], `"severity":"CRITICAL"`)
+}
@@ -0,0 +1,37 @@
+package security
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// totpValidateOpts matches totp.Validate (30s period, ±1 step skew)
+var totpValidateOpts = totp.ValidateOpts{
+	Period:    30,
+	Skew:      1,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// GenerateTOTPKey creates a TOTP secret (Base32) and the otpauth:// URL an
+// authenticator app enrolls from
+func GenerateTOTPKey(issuer, accountName string) (string, string, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: accountName,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate TOTP key: %w", err)
+	}
+
+	return key.Secret(), key.URL(), nil
+}
+
+// ValidateTOTPCode reports whether code is valid for secret at the given time
+func ValidateTOTPCode(code, secret string, at time.Time) bool {
+	valid, _ := totp.ValidateCustom(code, secret, at.UTC(), totpValidateOpts)
+	return valid
+}
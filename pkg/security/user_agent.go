@@ -0,0 +1,176 @@
+package security
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Device classes derived from the user agent
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot" // crawlers, HTTP libraries and scanners
+	DeviceUnknown = "unknown"
+)
+
+// DeviceClasses lists the valid device classes for filtering
+var DeviceClasses = []string{DeviceDesktop, DeviceMobile, DeviceTablet, DeviceBot, DeviceUnknown}
+
+// UserAgentInfo is a user agent parsed into structured fields
+type UserAgentInfo struct {
+	Browser     string `json:"browser,omitempty"` // "Chrome 120", or the bot/tool name ("curl 8")
+	OS          string `json:"os,omitempty"`      // "Android 14", "Windows", "iOS 17.1"
+	DeviceClass string `json:"deviceClass"`
+	Scanner     bool   `json:"scanner,omitempty"` // Known vulnerability scanner or attack tool
+}
+
+// scannerTokens identify vulnerability scanners and attack tools (matched lower-case)
+var scannerTokens = []string{
+	"sqlmap", "nikto", "nmap", "masscan", "zgrab", "nuclei", "wpscan", "dirbuster",
+	"gobuster", "ffuf", "wfuzz", "acunetix", "nessus", "openvas", "netsparker", "hydra",
+}
+
+// botTokens identify crawlers and non-browser HTTP clients (matched lower-case)
+var botTokens = []string{
+	"bot", "crawler", "spider", "slurp", "curl/", "wget/", "python-requests", "python-urllib",
+	"go-http-client", "okhttp", "java/", "libwww-perl", "httpclient", "headlesschrome", "phantomjs",
+}
+
+// browserPatterns are checked in order: Edge, Opera and Samsung Internet also
+// claim to be Chrome, and Chrome claims to be Safari
+var browserPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"Edge", regexp.MustCompile(`Edg(?:e|A|iOS)?/(\d+)`)},
+	{"Opera", regexp.MustCompile(`OPR/(\d+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/(\d+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/(\d+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/(\d+)`)},
+	{"Safari", regexp.MustCompile(`Version/(\d+).*Safari/`)},
+}
+
+var (
+	toolPattern    = regexp.MustCompile(`^([A-Za-z][\w.\-]*)/(\d+)`)
+	botNamePattern = regexp.MustCompile(`(?i)([\w\-]*(?:bot|crawler|spider|slurp)[\w\-]*)`)
+	androidPattern = regexp.MustCompile(`Android (\d+(?:\.\d+)?)`)
+	iosPattern     = regexp.MustCompile(`OS (\d+)[_.](\d+)`)
+	macPattern     = regexp.MustCompile(`Mac OS X (\d+)[_.](\d+)`)
+	windowsPattern = regexp.MustCompile(`Windows NT (\d+\.\d+)`)
+)
+
+// windowsVersions maps NT versions to marketing names (Windows 11 still reports NT 10.0)
+var windowsVersions = map[string]string{"10.0": "Windows 10/11", "6.3": "Windows 8.1", "6.2": "Windows 8", "6.1": "Windows 7"}
+
+// ParseUserAgent classifies a user agent string. It recognizes the common
+// browsers, operating systems, crawlers and scanners; anything else is unknown.
+func ParseUserAgent(ua string) UserAgentInfo {
+	ua = strings.TrimSpace(ua)
+	if ua == "" {
+		return UserAgentInfo{DeviceClass: DeviceUnknown}
+	}
+	lower := strings.ToLower(ua)
+
+	for _, token := range scannerTokens {
+		if strings.Contains(lower, token) {
+			return UserAgentInfo{Browser: token, DeviceClass: DeviceBot, Scanner: true}
+		}
+	}
+
+	info := UserAgentInfo{OS: parseOS(ua), DeviceClass: DeviceUnknown}
+	for _, token := range botTokens {
+		if strings.Contains(lower, token) {
+			info.DeviceClass = DeviceBot
+			info.Browser = botName(ua)
+			return info
+		}
+	}
+
+	for _, b := range browserPatterns {
+		if m := b.pattern.FindStringSubmatch(ua); m != nil {
+			info.Browser = b.name + " " + m[1]
+			break
+		}
+	}
+
+	switch {
+	case strings.Contains(lower, "ipad") || strings.Contains(lower, "tablet") ||
+		(strings.Contains(lower, "android") && !strings.Contains(lower, "mobile")):
+		info.DeviceClass = DeviceTablet
+	case strings.Contains(lower, "mobi") || strings.Contains(lower, "iphone") || strings.Contains(lower, "ipod"):
+		info.DeviceClass = DeviceMobile
+	case info.OS != "" && info.Browser != "":
+		info.DeviceClass = DeviceDesktop
+	}
+	return info
+}
+
+// botName returns the crawler name ("Googlebot") or the tool and major version ("curl 8")
+func botName(ua string) string {
+	if m := botNamePattern.FindStringSubmatch(ua); m != nil {
+		return m[1]
+	}
+	if m := toolPattern.FindStringSubmatch(ua); m != nil {
+		return m[1] + " " + m[2]
+	}
+	return ""
+}
+
+func parseOS(ua string) string {
+	switch {
+	case strings.Contains(ua, "iPhone") || strings.Contains(ua, "iPad") || strings.Contains(ua, "iPod"):
+		if m := iosPattern.FindStringSubmatch(ua); m != nil {
+			return "iOS " + m[1] + "." + m[2]
+		}
+		return "iOS"
+	case strings.Contains(ua, "Android"):
+		if m := androidPattern.FindStringSubmatch(ua); m != nil {
+			return "Android " + m[1]
+		}
+		return "Android"
+	case strings.Contains(ua, "Windows"):
+		if m := windowsPattern.FindStringSubmatch(ua); m != nil {
+			if name, ok := windowsVersions[m[1]]; ok {
+				return name
+			}
+		}
+		return "Windows"
+	case strings.Contains(ua, "CrOS"):
+		return "ChromeOS"
+	case strings.Contains(ua, "Macintosh"):
+		if m := macPattern.FindStringSubmatch(ua); m != nil {
+			return "macOS " + m[1] + "." + m[2]
+		}
+		return "macOS"
+	case strings.Contains(ua, "Linux"):
+		return "Linux"
+	}
+	return ""
+}
+
+// severityOrder ranks severities for elevation
+var severityOrder = []Severity{SeverityINFO, SeverityMEDIUM, SeverityWARN, SeverityHIGH, SeverityCRITICAL}
+
+// GetSeverityForClient returns the event severity, elevated for automated
+// clients: scanners are at least HIGH, other bots one level above the event's own.
+func GetSeverityForClient(eventType EventType, client UserAgentInfo) Severity {
+	severity := GetSeverity(eventType)
+	if client.DeviceClass != DeviceBot {
+		return severity
+	}
+
+	rank := 0
+	for i, s := range severityOrder {
+		if s == severity {
+			rank = i
+		}
+	}
+	switch {
+	case rank >= 3:
+		return severity
+	case client.Scanner:
+		return SeverityHIGH
+	}
+	return severityOrder[rank+1] // a bot alone never makes an event CRITICAL
+}
@@ -0,0 +1,58 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	cases := []struct {
+		ua   string
+		want UserAgentInfo
+	}{
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			UserAgentInfo{Browser: "Chrome 120", OS: "Windows 10/11", DeviceClass: DeviceDesktop},
+		},
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.2210.61",
+			UserAgentInfo{Browser: "Edge 120", OS: "Windows 10/11", DeviceClass: DeviceDesktop},
+		},
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			UserAgentInfo{Browser: "Safari 17", OS: "iOS 17.1", DeviceClass: DeviceMobile},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 14; SM-S911B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/23.0 Chrome/115.0.0.0 Mobile Safari/537.36",
+			UserAgentInfo{Browser: "Samsung Internet 23", OS: "Android 14", DeviceClass: DeviceMobile},
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 13; SM-X200) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36",
+			UserAgentInfo{Browser: "Chrome 119", OS: "Android 13", DeviceClass: DeviceTablet},
+		},
+		{
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			UserAgentInfo{Browser: "Googlebot", DeviceClass: DeviceBot},
+		},
+		{"curl/8.4.0", UserAgentInfo{Browser: "curl 8", DeviceClass: DeviceBot}},
+		{"sqlmap/1.7.2#stable (https://sqlmap.org)", UserAgentInfo{Browser: "sqlmap", DeviceClass: DeviceBot, Scanner: true}},
+		{"", UserAgentInfo{DeviceClass: DeviceUnknown}},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, ParseUserAgent(tc.ua), tc.ua)
+	}
+}
+
+func TestGetSeverityForClient(t *testing.T) {
+	browser := ParseUserAgent("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Safari/605.1.15")
+	bot := ParseUserAgent("python-requests/2.31.0")
+	scanner := ParseUserAgent("Mozilla/5.00 (Nikto/2.5.0) (Evasions:None) (Test:map_codes)")
+
+	assert.Equal(t, SeverityWARN, GetSeverityForClient(EventLoginFailed, browser))
+	assert.Equal(t, SeverityHIGH, GetSeverityForClient(EventLoginFailed, bot))
+	assert.Equal(t, SeverityMEDIUM, GetSeverityForClient(EventLoginSuccess, bot))
+	assert.Equal(t, SeverityHIGH, GetSeverityForClient(EventLoginSuccess, scanner))
+	assert.Equal(t, SeverityCRITICAL, GetSeverityForClient(EventHashChainBreak, scanner))
+}
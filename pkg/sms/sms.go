@@ -0,0 +1,78 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"go-recruitment-backend/config"
+	"go-recruitment-backend/pkg/logger"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// SMSService sends text messages via the Twilio-compatible REST API
+type SMSService struct {
+	apiURL     string
+	accountSID string
+	authToken  string
+	fromNumber string
+	httpClient *http.Client
+}
+
+// NewSMSService creates a new SMS service from configuration
+func NewSMSService(cfg *config.Config) *SMSService {
+	return &SMSService{
+		apiURL:     strings.TrimRight(cfg.SMSAPIURL, "/"),
+		accountSID: cfg.SMSAccountSID,
+		authToken:  cfg.SMSAuthToken,
+		fromNumber: cfg.SMSFromNumber,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// IsConfigured returns true if provider credentials are set
+func (s *SMSService) IsConfigured() bool {
+	return s.accountSID != "" && s.authToken != "" && s.fromNumber != ""
+}
+
+// Send delivers a message to an E.164 phone number.
+// When the provider is not configured, the message is only logged outside release mode
+// so OTP flows remain testable locally; in release mode an error is returned.
+func (s *SMSService) Send(ctx context.Context, to, message string) error {
+	if !s.IsConfigured() {
+		if os.Getenv("GIN_MODE") != "release" {
+			logger.FromContext(ctx).Info("SMS provider not configured; message logged instead of sent (dev mode)", "to", to, "message", message)
+			return nil
+		}
+		return fmt.Errorf("SMS provider not configured")
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.apiURL, s.accountSID)
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", s.fromNumber)
+	form.Set("Body", message)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create SMS request: %w", err)
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("SMS provider returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
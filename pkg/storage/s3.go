@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Store keeps every bucket as a key prefix ("CV/<path>") of one S3 bucket.
+// Objects are private unless a bucket policy makes a prefix readable; private
+// prefixes are read through presigned URLs.
+type S3Store struct {
+	client    *s3.Client
+	presign   *s3.PresignClient
+	bucket    string
+	publicURL string
+}
+
+// NewS3Store creates a store on an existing S3 client. publicURL is where the
+// S3 bucket is served (a CDN or the bucket endpoint), without a trailing slash.
+func NewS3Store(client *s3.Client, bucket, publicURL string) *S3Store {
+	return &S3Store{
+		client:    client,
+		presign:   s3.NewPresignClient(client),
+		bucket:    bucket,
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+func (s *S3Store) Upload(ctx context.Context, bucket, path, contentType string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(bucket + "/" + path),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	return nil
+}
+
+// Delete looks the object up first: S3 deletes are idempotent and report
+// neither whether the object existed nor its size
+func (s *S3Store) Delete(ctx context.Context, bucket, path string) (int64, bool, error) {
+	key := aws.String(bucket + "/" + path)
+	head, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(s.bucket), Key: key})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to look up object: %w", err)
+	}
+	if _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(s.bucket), Key: key}); err != nil {
+		return 0, false, fmt.Errorf("failed to delete object: %w", err)
+	}
+	return aws.ToInt64(head.ContentLength), true, nil
+}
+
+func (s *S3Store) SignedURL(ctx context.Context, bucket, path string, ttl time.Duration) (string, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(bucket + "/" + path),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign object URL: %w", err)
+	}
+	return req.URL, nil
+}
+
+func (s *S3Store) List(ctx context.Context, bucket string) ([]Object, error) {
+	prefix := bucket + "/"
+	objects := []Object{}
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(s.bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"), // top level only
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+		for _, o := range page.Contents {
+			obj := Object{
+				Bucket:    bucket,
+				Path:      strings.TrimPrefix(aws.ToString(o.Key), prefix),
+				SizeBytes: aws.ToInt64(o.Size),
+			}
+			if o.LastModified != nil {
+				obj.CreatedAt = *o.LastModified
+			}
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+func (s *S3Store) URL(bucket, path string) string {
+	return fmt.Sprintf("%s/%s/%s", s.publicURL, bucket, path)
+}
@@ -0,0 +1,58 @@
+// Package storage keeps uploaded files in named buckets behind one interface,
+// with Supabase Storage and S3 implementations. Every object has a stable URL
+// ending in "<bucket>/<path>", which is what the database stores. In public
+// buckets that URL serves the file; private buckets are read through
+// time-limited signed URLs.
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured is returned by every call of a store without credentials
+var ErrNotConfigured = errors.New("storage is not configured")
+
+// Object is one object in a bucket listing
+type Object struct {
+	Bucket    string
+	Path      string
+	SizeBytes int64
+	CreatedAt time.Time
+}
+
+// Store reads and writes objects in buckets
+type Store interface {
+	// Upload stores data at bucket/path, replacing an existing object
+	Upload(ctx context.Context, bucket, path, contentType string, data []byte) error
+	// Delete removes the object and returns its size; found is false when it was already gone
+	Delete(ctx context.Context, bucket, path string) (sizeBytes int64, found bool, err error)
+	// SignedURL returns a download URL for the object that stops working after ttl
+	SignedURL(ctx context.Context, bucket, path string, ttl time.Duration) (string, error)
+	// List returns every object at the top level of the bucket; a bucket that
+	// does not exist has no objects
+	List(ctx context.Context, bucket string) ([]Object, error)
+	// URL is the stable address of the object, stored in place of the file
+	URL(bucket, path string) string
+}
+
+// ParseURL returns the bucket and object path of a URL made by Store.URL: its
+// last two path segments. Callers check the bucket against the buckets they know.
+func ParseURL(raw string) (bucket, path string, ok bool) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", "", false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 {
+		return "", "", false
+	}
+	bucket, path = segments[len(segments)-2], segments[len(segments)-1]
+	if bucket == "" || path == "" || path == "." || path == ".." {
+		return "", "", false
+	}
+	return bucket, path, true
+}
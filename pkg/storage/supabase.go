@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// supabaseListPageSize is how many objects one storage list request returns
+const supabaseListPageSize = 1000
+
+// SupabaseStore talks to the Supabase Storage REST API with the service key
+type SupabaseStore struct {
+	baseURL    string
+	serviceKey string
+	client     *http.Client
+}
+
+// NewSupabaseStore creates a store on the project's storage. Without a URL or
+// key every call fails with ErrNotConfigured.
+func NewSupabaseStore(supabaseURL, serviceKey string) *SupabaseStore {
+	return &SupabaseStore{
+		baseURL:    strings.TrimRight(supabaseURL, "/"),
+		serviceKey: serviceKey,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// supabaseObject is an entry of a list or delete response. Folders have no id.
+type supabaseObject struct {
+	Name      string     `json:"name"`
+	ID        *string    `json:"id"`
+	CreatedAt *time.Time `json:"created_at"`
+	Metadata  *struct {
+		Size int64 `json:"size"`
+	} `json:"metadata"`
+}
+
+func (s *SupabaseStore) Upload(ctx context.Context, bucket, path, contentType string, data []byte) error {
+	req, err := s.newRequest(ctx, http.MethodPost, "/storage/v1/object/"+objectPath(bucket, path), contentType, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-upsert", "true") // overwrite if exists
+	_, err = s.do(req, nil)
+	return err
+}
+
+// Delete uses the bulk delete endpoint, which answers with the objects it
+// removed, so a missing object is told apart from a failure and the size is known
+func (s *SupabaseStore) Delete(ctx context.Context, bucket, path string) (int64, bool, error) {
+	body, _ := json.Marshal(map[string][]string{"prefixes": {path}})
+	var deleted []supabaseObject
+	if _, err := s.call(ctx, http.MethodDelete, "/storage/v1/object/"+url.PathEscape(bucket), body, &deleted); err != nil {
+		return 0, false, err
+	}
+	if len(deleted) == 0 {
+		return 0, false, nil
+	}
+	var size int64
+	for _, o := range deleted {
+		if o.Metadata != nil {
+			size += o.Metadata.Size
+		}
+	}
+	return size, true, nil
+}
+
+// SignedURL works for public and private buckets alike
+func (s *SupabaseStore) SignedURL(ctx context.Context, bucket, path string, ttl time.Duration) (string, error) {
+	body, _ := json.Marshal(map[string]int{"expiresIn": int(ttl.Seconds())})
+	var signed struct {
+		SignedURL string `json:"signedURL"`
+	}
+	if _, err := s.call(ctx, http.MethodPost, "/storage/v1/object/sign/"+objectPath(bucket, path), body, &signed); err != nil {
+		return "", err
+	}
+	if signed.SignedURL == "" {
+		return "", fmt.Errorf("storage returned no signed URL for %s/%s", bucket, path)
+	}
+	// The signed path is relative to the storage API
+	return s.baseURL + "/storage/v1" + signed.SignedURL, nil
+}
+
+func (s *SupabaseStore) List(ctx context.Context, bucket string) ([]Object, error) {
+	objects := []Object{}
+	for offset := 0; ; offset += supabaseListPageSize {
+		body, _ := json.Marshal(map[string]any{
+			"prefix": "",
+			"limit":  supabaseListPageSize,
+			"offset": offset,
+			"sortBy": map[string]string{"column": "name", "order": "asc"},
+		})
+		var page []supabaseObject
+		status, err := s.call(ctx, http.MethodPost, "/storage/v1/object/list/"+url.PathEscape(bucket), body, &page)
+		if err != nil {
+			if status == http.StatusNotFound || (status == http.StatusBadRequest && strings.Contains(err.Error(), "Bucket not found")) {
+				return objects, nil
+			}
+			return nil, err
+		}
+
+		for _, o := range page {
+			if o.ID == nil {
+				continue // folder
+			}
+			obj := Object{Bucket: bucket, Path: o.Name}
+			if o.Metadata != nil {
+				obj.SizeBytes = o.Metadata.Size
+			}
+			if o.CreatedAt != nil {
+				obj.CreatedAt = *o.CreatedAt
+			}
+			objects = append(objects, obj)
+		}
+		if len(page) < supabaseListPageSize {
+			return objects, nil
+		}
+	}
+}
+
+// URL is the public object URL; in a private bucket it only identifies the object
+func (s *SupabaseStore) URL(bucket, path string) string {
+	return fmt.Sprintf("%s/storage/v1/object/public/%s/%s", s.baseURL, bucket, path)
+}
+
+// call sends a JSON request and decodes the response into out
+func (s *SupabaseStore) call(ctx context.Context, method, path string, body []byte, out any) (int, error) {
+	req, err := s.newRequest(ctx, method, path, "application/json", body)
+	if err != nil {
+		return 0, err
+	}
+	return s.do(req, out)
+}
+
+// newRequest builds a request to the storage API authenticated with the service key
+func (s *SupabaseStore) newRequest(ctx context.Context, method, path, contentType string, body []byte) (*http.Request, error) {
+	if s.baseURL == "" || s.serviceKey == "" {
+		return nil, ErrNotConfigured
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.serviceKey)
+	req.Header.Set("apikey", s.serviceKey)
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
+
+// do sends the request and decodes a 2xx JSON response into out (when not nil).
+// The status code is returned with errors so callers can tell missing buckets apart.
+func (s *SupabaseStore) do(req *http.Request, out any) (int, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("storage returned %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	if out == nil {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("invalid storage response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// objectPath escapes bucket and path for an object endpoint
+func objectPath(bucket, path string) string {
+	return url.PathEscape(bucket) + "/" + url.PathEscape(path)
+}
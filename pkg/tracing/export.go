@@ -0,0 +1,233 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-recruitment-backend/pkg/logger"
+)
+
+const (
+	queueSize     = 4096
+	maxBatch      = 512
+	flushInterval = 5 * time.Second
+)
+
+// exporter batches ended spans and posts them to the collector. Spans are
+// dropped when the queue is full rather than slowing requests down.
+type exporter struct {
+	cfg    Config
+	url    string
+	client *http.Client
+	queue  chan *Span
+	done   chan struct{}
+	exited chan struct{}
+}
+
+func newExporter(cfg Config) *exporter {
+	if cfg.SamplePercent < 0 {
+		cfg.SamplePercent = 0
+	}
+	if cfg.SamplePercent > 100 {
+		cfg.SamplePercent = 100
+	}
+	return &exporter{
+		cfg: cfg,
+		url: strings.TrimSuffix(cfg.Endpoint, "/") + "/v1/traces",
+		// Own transport: the default one is traced, and exports must not trace themselves
+		client: &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}},
+		queue:  make(chan *Span, queueSize),
+		done:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+}
+
+func (e *exporter) enqueue(s *Span) {
+	select {
+	case e.queue <- s:
+	default:
+		logger.Sampled(context.Background(), "tracing.queue_full").Warn("Tracing: export queue full, span dropped")
+	}
+}
+
+func (e *exporter) run() {
+	defer close(e.exited)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, maxBatch)
+	flush := func() {
+		if len(batch) > 0 {
+			e.post(batch)
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case s := <-e.queue:
+			batch = append(batch, s)
+			if len(batch) == maxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.done:
+			for {
+				select {
+				case s := <-e.queue:
+					batch = append(batch, s)
+					if len(batch) == maxBatch {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *exporter) shutdown(ctx context.Context) {
+	close(e.done)
+	select {
+	case <-e.exited:
+	case <-ctx.Done():
+	}
+}
+
+func (e *exporter) post(spans []*Span) {
+	body, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		logger.Log.Error("Tracing: failed to encode spans", "error", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		logger.Log.Error("Tracing: invalid OTLP endpoint", "error", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		logger.Sampled(context.Background(), "tracing.export_failed").Warn("Tracing: export failed", "spans", len(spans), "error", err)
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		logger.Sampled(context.Background(), "tracing.export_failed").Warn("Tracing: collector rejected spans", "spans", len(spans), "status", resp.StatusCode)
+	}
+}
+
+// OTLP/JSON payload (opentelemetry-proto ExportTraceServiceRequest)
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus    `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"` // 2 = error
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+func (e *exporter) encode(spans []*Span) otlpRequest {
+	resource := []otlpKeyValue{keyValue("service.name", e.cfg.ServiceName)}
+	if e.cfg.Version != "" {
+		resource = append(resource, keyValue("service.version", e.cfg.Version))
+	}
+
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.traceID[:]),
+			SpanID:            hex.EncodeToString(s.spanID[:]),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		}
+		if s.parentID != [8]byte{} {
+			span.ParentSpanID = hex.EncodeToString(s.parentID[:])
+		}
+		for _, a := range s.attrs {
+			span.Attributes = append(span.Attributes, keyValue(a.key, a.value))
+		}
+		if s.errMsg != "" {
+			span.Status = &otlpStatus{Code: 2, Message: s.errMsg}
+		}
+		s.mu.Unlock()
+		out = append(out, span)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: resource},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "go-recruitment-backend"}, Spans: out}},
+	}}}
+}
+
+func keyValue(key string, value any) otlpKeyValue {
+	var v map[string]any
+	switch x := value.(type) {
+	case string:
+		v = map[string]any{"stringValue": x}
+	case bool:
+		v = map[string]any{"boolValue": x}
+	case int:
+		v = map[string]any{"intValue": strconv.Itoa(x)}
+	case int64:
+		v = map[string]any{"intValue": strconv.FormatInt(x, 10)}
+	case float64:
+		v = map[string]any{"doubleValue": x}
+	default:
+		v = map[string]any{"stringValue": fmt.Sprint(x)}
+	}
+	return otlpKeyValue{Key: key, Value: v}
+}
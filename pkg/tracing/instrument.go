@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// maxStatementLength caps db.statement; queries are parameterized, so no values are recorded
+const maxStatementLength = 2000
+
+// QueryTracer records a client span per query on the pool, so it instruments
+// all repositories at once. next, if not nil, is traced as well.
+func QueryTracer(next pgx.QueryTracer) pgx.QueryTracer {
+	return queryTracer{next: next}
+}
+
+type queryTracer struct {
+	next pgx.QueryTracer
+}
+
+type querySpanKey struct{}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if SpanFromContext(ctx) != nil {
+		// Only queries inside a traced request or job; a root span per background query is noise
+		operation := "query"
+		if fields := strings.Fields(data.SQL); len(fields) > 0 {
+			operation = strings.ToLower(fields[0])
+		}
+		statement := data.SQL
+		if len(statement) > maxStatementLength {
+			statement = statement[:maxStatementLength]
+		}
+		var span *Span
+		ctx, span = StartKind(ctx, "postgres "+operation, KindClient)
+		span.Set("db.system", "postgresql")
+		span.Set("db.operation", operation)
+		span.Set("db.statement", statement)
+		ctx = context.WithValue(ctx, querySpanKey{}, span)
+	}
+	if t.next != nil {
+		ctx = t.next.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if span, ok := ctx.Value(querySpanKey{}).(*Span); ok {
+		span.SetError(data.Err)
+		span.End()
+	}
+	if t.next != nil {
+		t.next.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+// Transport records a client span per outbound request and propagates the
+// trace context and request ID to the callee
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := startHTTPSpan(req)
+	if span == nil {
+		return t.next.RoundTrip(req)
+	}
+	defer span.End()
+
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(ctx)
+	Inject(ctx, req.Header)
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok && req.Header.Get("X-Request-ID") == "" {
+		req.Header.Set("X-Request-ID", id)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	finishHTTPSpan(span, resp, err)
+	return resp, err
+}
+
+// HTTPDoer is the client interface of SDKs that take their own http.Client
+// (the AWS SDK's HTTPClient)
+type HTTPDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// WrapClient records a client span per request sent through an SDK client.
+// Headers are left alone, since the request is already signed.
+func WrapClient(next HTTPDoer) HTTPDoer {
+	if next == nil {
+		next = http.DefaultClient
+	}
+	return doer{next: next}
+}
+
+type doer struct {
+	next HTTPDoer
+}
+
+func (d doer) Do(req *http.Request) (*http.Response, error) {
+	_, span := startHTTPSpan(req)
+	if span == nil {
+		return d.next.Do(req)
+	}
+	defer span.End()
+
+	resp, err := d.next.Do(req)
+	finishHTTPSpan(span, resp, err)
+	return resp, err
+}
+
+// startHTTPSpan starts a span only inside a trace; the path is left out since
+// storage paths carry file names
+func startHTTPSpan(req *http.Request) (context.Context, *Span) {
+	ctx := req.Context()
+	if SpanFromContext(ctx) == nil {
+		return ctx, nil
+	}
+	ctx, span := StartKind(ctx, "HTTP "+req.Method+" "+req.URL.Host, KindClient)
+	span.Set("http.request.method", req.Method)
+	span.Set("server.address", req.URL.Host)
+	return ctx, span
+}
+
+func finishHTTPSpan(span *Span, resp *http.Response, err error) {
+	if err != nil {
+		span.SetError(err)
+		return
+	}
+	span.Set("http.response.status_code", resp.StatusCode)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetError(errStatus(resp.Status))
+	}
+}
+
+type errStatus string
+
+func (e errStatus) Error() string { return string(e) }
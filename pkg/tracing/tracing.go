@@ -0,0 +1,250 @@
+// Package tracing records OpenTelemetry-compatible spans and exports them to an
+// OTLP/HTTP collector (JSON encoding). It propagates W3C trace context, so
+// traces continue across services that speak traceparent.
+//
+// Tracing is off until Init is called; Start then returns a nil *Span, and all
+// Span methods are no-ops on nil, so call sites need no checks.
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Span kinds (OTLP enum values)
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+)
+
+// Config configures the exporter
+type Config struct {
+	Endpoint      string            // OTLP/HTTP base URL, e.g. http://otel-collector:4318
+	Headers       map[string]string // sent with every export, e.g. a vendor API key
+	ServiceName   string
+	Version       string
+	SamplePercent int // share of new traces recorded (0-100); incoming sampled traces are always recorded
+}
+
+var (
+	mu      sync.RWMutex
+	current *exporter
+)
+
+// Init starts exporting spans; the returned func flushes pending spans and
+// stops the exporter
+func Init(cfg Config) func(context.Context) {
+	e := newExporter(cfg)
+	mu.Lock()
+	current = e
+	mu.Unlock()
+	go e.run()
+
+	return func(ctx context.Context) {
+		mu.Lock()
+		current = nil
+		mu.Unlock()
+		e.shutdown(ctx)
+	}
+}
+
+func active() *exporter {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Span is one timed operation of a trace
+type Span struct {
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+	sampled  bool
+	remote   bool // extracted from an incoming traceparent; never exported
+
+	name  string
+	kind  int
+	start time.Time
+
+	mu     sync.Mutex
+	end    time.Time
+	attrs  []attribute
+	errMsg string
+	ended  bool
+	exp    *exporter
+}
+
+type attribute struct {
+	key   string
+	value any
+}
+
+type spanKey struct{}
+type requestIDKey struct{}
+
+// Start begins an internal span as a child of the span in ctx
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	return StartKind(ctx, name, KindInternal)
+}
+
+// StartKind begins a span of the given kind as a child of the span in ctx, or a
+// new trace when there is none
+func StartKind(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	e := active()
+	if e == nil {
+		return ctx, nil
+	}
+
+	s := &Span{name: name, kind: kind, start: time.Now(), exp: e}
+	if parent := SpanFromContext(ctx); parent != nil {
+		s.traceID = parent.traceID
+		s.parentID = parent.spanID
+		s.sampled = parent.sampled
+	} else {
+		s.traceID = newTraceID()
+		s.sampled = rand.IntN(100) < e.cfg.SamplePercent
+	}
+	s.spanID = newSpanID()
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		s.attrs = append(s.attrs, attribute{"request.id", id})
+	}
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// SpanFromContext returns the current span, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// WithRequestID tags every span started from ctx with the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Set adds an attribute; strings, bools, ints and floats are supported
+func (s *Span) Set(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.attrs = append(s.attrs, attribute{key, value})
+	s.mu.Unlock()
+}
+
+// SetError marks the span as failed; nil is ignored
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.errMsg = err.Error()
+	s.mu.Unlock()
+}
+
+// End finishes the span and queues it for export when sampled
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	if s.sampled && !s.remote {
+		s.exp.enqueue(s)
+	}
+}
+
+// TraceID is the hex trace ID, or "" for a nil span
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.traceID[:])
+}
+
+// Extract continues the trace of an incoming W3C traceparent header, if valid
+func Extract(ctx context.Context, header http.Header) context.Context {
+	e := active()
+	if e == nil {
+		return ctx
+	}
+	// version-traceid-spanid-flags, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	parts := strings.Split(strings.TrimSpace(header.Get("traceparent")), "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return ctx
+	}
+	s := &Span{remote: true, exp: e}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return ctx
+	}
+	if _, err := hex.Decode(s.traceID[:], []byte(parts[1])); err != nil || s.traceID == [16]byte{} {
+		return ctx
+	}
+	if _, err := hex.Decode(s.spanID[:], []byte(parts[2])); err != nil || s.spanID == [8]byte{} {
+		return ctx
+	}
+	s.sampled = flags[0]&1 == 1
+	return context.WithValue(ctx, spanKey{}, s)
+}
+
+// Inject writes the current span as a W3C traceparent header
+func Inject(ctx context.Context, header http.Header) {
+	s := SpanFromContext(ctx)
+	if s == nil {
+		return
+	}
+	flags := "00"
+	if s.sampled {
+		flags = "01"
+	}
+	header.Set("traceparent", fmt.Sprintf("00-%x-%x-%s", s.traceID, s.spanID, flags))
+}
+
+func newTraceID() (id [16]byte) {
+	for id == [16]byte{} {
+		for i := 0; i < 16; i += 8 {
+			v := rand.Uint64()
+			for j := 0; j < 8; j++ {
+				id[i+j] = byte(v >> (8 * j))
+			}
+		}
+	}
+	return id
+}
+
+func newSpanID() (id [8]byte) {
+	for id == [8]byte{} {
+		v := rand.Uint64()
+		for j := 0; j < 8; j++ {
+			id[j] = byte(v >> (8 * j))
+		}
+	}
+	return id
+}
+
+// ParseHeaders reads OTEL_EXPORTER_OTLP_HEADERS style "key=value,key2=value2"
+func ParseHeaders(list string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(list, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if k = strings.TrimSpace(k); ok && k != "" {
+			headers[k] = strings.TrimSpace(v)
+		}
+	}
+	return headers
+}
@@ -105,6 +105,13 @@ var ValidationRules = map[string]map[string]interface{}{
 
 // FormatValidationErrors converts validator.ValidationErrors to user-friendly messages
 func FormatValidationErrors(err error) []string {
+	return FormatValidationErrorsWithLabels(err, nil)
+}
+
+// FormatValidationErrorsWithLabels is FormatValidationErrors with labels that take
+// precedence over FieldLabels, for structs whose users know the fields by other
+// names (e.g. the columns of an import file)
+func FormatValidationErrorsWithLabels(err error, labels map[string]string) []string {
 	var messages []string
 
 	validationErrors, ok := err.(validator.ValidationErrors)
@@ -114,7 +121,7 @@ func FormatValidationErrors(err error) []string {
 	}
 
 	for _, e := range validationErrors {
-		msg := formatSingleError(e)
+		msg := formatSingleError(e, labels)
 		messages = append(messages, msg)
 	}
 
@@ -122,9 +129,9 @@ func FormatValidationErrors(err error) []string {
 }
 
 // formatSingleError formats a single validation error to a user-friendly message
-func formatSingleError(e validator.FieldError) string {
+func formatSingleError(e validator.FieldError, labels map[string]string) string {
 	fieldName := e.Field()
-	label := getFieldLabel(fieldName)
+	label := getFieldLabel(fieldName, labels)
 	tag := e.Tag()
 	param := e.Param()
 
@@ -180,15 +187,19 @@ func formatSingleError(e validator.FieldError) string {
 		return fmt.Sprintf("%s: Tidak boleh melebihi tahun ini", label)
 
 	case "eqfield":
-		paramLabel := getFieldLabel(param)
+		paramLabel := getFieldLabel(param, labels)
 		return fmt.Sprintf("%s: Harus sama dengan %s", label, paramLabel)
 
 	case "gtfield":
-		paramLabel := getFieldLabel(param)
+		paramLabel := getFieldLabel(param, labels)
 		return fmt.Sprintf("%s: Harus lebih besar dari %s", label, paramLabel)
 
+	case "gtefield":
+		paramLabel := getFieldLabel(param, labels)
+		return fmt.Sprintf("%s: Tidak boleh lebih kecil dari %s", label, paramLabel)
+
 	case "ltfield":
-		paramLabel := getFieldLabel(param)
+		paramLabel := getFieldLabel(param, labels)
 		return fmt.Sprintf("%s: Harus lebih kecil dari %s", label, paramLabel)
 
 	default:
@@ -198,7 +209,10 @@ func formatSingleError(e validator.FieldError) string {
 }
 
 // getFieldLabel returns the user-friendly label for a field
-func getFieldLabel(fieldName string) string {
+func getFieldLabel(fieldName string, labels map[string]string) string {
+	if label, ok := labels[fieldName]; ok {
+		return label
+	}
 	if label, ok := FieldLabels[fieldName]; ok {
 		return label
 	}